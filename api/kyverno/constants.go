@@ -7,13 +7,24 @@ const (
 	LabelCacheEnabled     = "cache.kyverno.io/enabled"
 	LabelCertManagedBy    = "cert.kyverno.io/managed-by"
 	LabelCleanupTtl       = "cleanup.kyverno.io/ttl"
+	LabelPolicyProfile    = "policies.kyverno.io/profile"
+	LabelPolicySet        = "policyset.kyverno.io/name"
 	LabelWebhookManagedBy = "webhook.kyverno.io/managed-by"
 	// Well known annotations
 	AnnotationAutogenControllers = "pod-policies.kyverno.io/autogen-controllers"
+	AnnotationCleanupExpiresAt   = "cleanup.kyverno.io/expires-at"
+	AnnotationCleanupReplicas    = "cleanup.kyverno.io/replicas"
 	AnnotationImageVerify        = "kyverno.io/verify-images"
+	AnnotationImageVerifyStamp   = "kyverno.io/verified-images"
+	AnnotationPolicyCanary       = "policies.kyverno.io/canary"
 	AnnotationPolicyCategory     = "policies.kyverno.io/category"
+	AnnotationPolicyProfileHash  = "policies.kyverno.io/profile-hash"
+	AnnotationPolicyReport       = "policies.kyverno.io/report"
+	AnnotationPolicyReportSample = "policies.kyverno.io/report-pass-sampling"
+	AnnotationPolicyScanPriority = "policies.kyverno.io/scan-priority"
 	AnnotationPolicyScored       = "policies.kyverno.io/scored"
 	AnnotationPolicySeverity     = "policies.kyverno.io/severity"
+	AnnotationPolicySetVersion   = "policyset.kyverno.io/version"
 	// Well known values
 	ValueKyvernoApp        = "kyverno"
 	ValueTtlDateTimeLayout = "2006-01-02T150405Z"
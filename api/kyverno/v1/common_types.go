@@ -38,6 +38,18 @@ const (
 	ApplyOne ApplyRulesType = "One"
 )
 
+// ReinvocationPolicyType specifies if the mutating webhook of a policy is called again when other
+// admission plugins modify the object after the initial webhook call.
+// +kubebuilder:validation:Enum=Never;IfNeeded
+type ReinvocationPolicyType string
+
+const (
+	// NeverReinvocationPolicy means that the webhook is called once per admission request.
+	NeverReinvocationPolicy ReinvocationPolicyType = "Never"
+	// IfNeededReinvocationPolicy means that the webhook is called again if the object is modified after the initial call.
+	IfNeededReinvocationPolicy ReinvocationPolicyType = "IfNeeded"
+)
+
 // ForeachOrder specifies the iteration order in foreach statements.
 // +kubebuilder:validation:Enum=Ascending;Descending
 type ForeachOrder string
@@ -66,6 +78,24 @@ type AnyAllConditions struct {
 	// Here, all of the conditions need to pass
 	// +optional
 	AllConditions []Condition `json:"all,omitempty" yaml:"all,omitempty"`
+
+	// Templates references condition templates declared in the policy `conditionTemplates`.
+	// The `any` and `all` conditions of the referenced templates are merged into this block.
+	// A block holds a single `any` group, a template with `any` conditions can't be merged into
+	// a block that already has `any` conditions.
+	// +optional
+	Templates []string `json:"templates,omitempty" yaml:"templates,omitempty"`
+}
+
+// ConditionTemplate is a named group of conditions declared once in a policy and shared
+// by the preconditions and deny conditions of its rules.
+type ConditionTemplate struct {
+	// Name is the name used to reference the template.
+	Name string `json:"name" yaml:"name"`
+
+	// Conditions are the conditions merged into the blocks referencing the template.
+	// A template can reference other templates.
+	Conditions AnyAllConditions `json:"conditions" yaml:"conditions"`
 }
 
 // ContextEntry adds variables and data sources to a rule Context. Either a
@@ -87,6 +117,43 @@ type ContextEntry struct {
 
 	// Variable defines an arbitrary JMESPath context variable that can be defined inline.
 	Variable *Variable `json:"variable,omitempty" yaml:"variable,omitempty"`
+
+	// RelatedResources lists resources of a kind, tracked with informers, to validate relationships
+	// between the resource being processed and other resources in the cluster.
+	RelatedResources *RelatedResources `json:"relatedResources,omitempty" yaml:"relatedResources,omitempty"`
+}
+
+// RelatedResources lists resources of a kind, tracked with informers.
+// The data stored in the context is the list of related resources.
+type RelatedResources struct {
+	// APIVersion is the API version of the related resources (e.g. policy/v1).
+	APIVersion string `json:"apiVersion" yaml:"apiVersion"`
+
+	// Kind is the kind of the related resources (e.g. PodDisruptionBudget).
+	Kind string `json:"kind" yaml:"kind"`
+
+	// Namespace is the namespace of the related resources, resources from all namespaces are
+	// listed when empty. Supports variables (e.g. "{{ request.namespace }}").
+	// +optional
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+
+	// SelectorPath is the dot separated path of a label selector in the related resources
+	// (e.g. spec.selector). When set, only the related resources with a selector at this path
+	// selecting the labels defined in Labels are listed.
+	// +optional
+	SelectorPath string `json:"selectorPath,omitempty" yaml:"selectorPath,omitempty"`
+
+	// Labels are the labels that must be selected by the label selector of the related resources
+	// found at SelectorPath. Supports variables (e.g. "{{ request.object.spec.template.metadata.labels }}").
+	// +optional
+	Labels *apiextv1.JSON `json:"labels,omitempty" yaml:"labels,omitempty"`
+
+	// JMESPath is an optional JSON Match Expression that can be used to
+	// transform the list of related resources. For example a JMESPath of
+	// "[].spec.replicas | sum(@)" applied to the Deployments of a namespace
+	// returns the total number of replicas in the namespace.
+	// +optional
+	JMESPath string `json:"jmesPath,omitempty" yaml:"jmesPath,omitempty"`
 }
 
 // Variable defines an arbitrary JMESPath context variable that can be defined inline.
@@ -133,6 +200,32 @@ type ConfigMapReference struct {
 	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
 }
 
+// Prefetch declares data that can be loaded in bulk before a rule is evaluated against
+// a set of resources. Prefetch declarations are static, they don't support variables.
+type Prefetch struct {
+	// ConfigMaps lists the ConfigMaps to be loaded once per scan.
+	// +optional
+	ConfigMaps []ConfigMapReference `json:"configMaps,omitempty" yaml:"configMaps,omitempty"`
+
+	// Resources lists the resources to be listed once per scan.
+	// +optional
+	Resources []PrefetchResource `json:"resources,omitempty" yaml:"resources,omitempty"`
+}
+
+// PrefetchResource identifies a resource to be listed in bulk.
+type PrefetchResource struct {
+	// APIVersion is the group and version of the resource (e.g. "v1" or "apps/v1").
+	APIVersion string `json:"apiVersion" yaml:"apiVersion"`
+
+	// Resource is the plural resource name (e.g. "services" or "deployments").
+	Resource string `json:"resource" yaml:"resource"`
+
+	// Namespace restricts the listing to a single namespace.
+	// When empty, resources are listed across all namespaces.
+	// +optional
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+}
+
 type APICall struct {
 	// URLPath is the URL path to be used in the HTTP GET or POST request to the
 	// Kubernetes API server (e.g. "/api/v1/namespaces" or  "/apis/apps/v1/deployments").
@@ -396,6 +489,10 @@ type Validation struct {
 	// CEL allows validation checks using the Common Expression Language (https://kubernetes.io/docs/reference/using-api/cel/).
 	// +optional
 	CEL *CEL `json:"cel,omitempty" yaml:"cel,omitempty"`
+
+	// WASM allows validation checks implemented by a WebAssembly module.
+	// +optional
+	WASM *WASM `json:"wasm,omitempty" yaml:"wasm,omitempty"`
 }
 
 // PodSecurity applies exemptions for Kubernetes Pod Security admission
@@ -467,6 +564,17 @@ func (c *CEL) GetParamRef() v1alpha1.ParamRef {
 	return *c.ParamRef
 }
 
+// WASM references a WebAssembly module implementing a validation check.
+// The module is executed in a sandbox, without access to the file system, the network or the environment,
+// with the resource and the rule context as input.
+type WASM struct {
+	// Module is the OCI reference of the WebAssembly module, for example oci://ghcr.io/org/checks:v1.
+	Module string `json:"module" yaml:"module"`
+
+	// Entrypoint is the name of the function exported by the module performing the validation.
+	Entrypoint string `json:"entrypoint" yaml:"entrypoint"`
+}
+
 // DeserializeAnyPattern deserialize apiextensions.JSON to []interface{}
 func (in *Validation) DeserializeAnyPattern() ([]interface{}, error) {
 	anyPattern := in.GetAnyPattern()
@@ -521,6 +629,11 @@ type Deny struct {
 	// but will be deprecated in the next major release.
 	// See: https://kyverno.io/docs/writing-policies/validate/#deny-rules
 	RawAnyAllConditions *apiextv1.JSON `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+
+	// Violations evaluates conditions for each element of a list, every element matching the conditions
+	// is reported as a violation with its pointer and reason instead of a single aggregated message.
+	// +optional
+	Violations *DenyViolations `json:"violations,omitempty" yaml:"violations,omitempty"`
 }
 
 func (d *Deny) GetAnyAllConditions() apiextensions.JSON {
@@ -531,6 +644,28 @@ func (d *Deny) SetAnyAllConditions(in apiextensions.JSON) {
 	d.RawAnyAllConditions = ToJSON(in)
 }
 
+// DenyViolations lists the elements of a list violating a deny rule.
+type DenyViolations struct {
+	// List specifies a JMESPath expression that results in the elements to check.
+	// Violations carry the JSON pointer of the element when the list is a path of
+	// the resource, like `request.object.spec.containers`.
+	List string `json:"list" yaml:"list"`
+
+	// Conditions are evaluated for each element, available as `element` and `elementIndex`.
+	// An element matching the conditions is a violation.
+	// Multiple conditions can be declared under an `any` or `all` statement.
+	// +kubebuilder:validation:XPreserveUnknownFields
+	RawAnyAllConditions *apiextv1.JSON `json:"conditions" yaml:"conditions"`
+
+	// Reason explains a violation, it can reference the element with variables.
+	// +optional
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+func (d *DenyViolations) GetAnyAllConditions() apiextensions.JSON {
+	return FromJSON(d.RawAnyAllConditions)
+}
+
 // ForEachValidation applies validate rules to a list of sub-elements by creating a context for each entry in the list and looping over it to apply the specified logic.
 type ForEachValidation struct {
 	// List specifies a JMESPath expression that results in one or more elements
@@ -615,6 +750,38 @@ type Generation struct {
 	// CloneList specifies the list of source resource used to populate each generated resource.
 	// +optional
 	CloneList CloneList `json:"cloneList,omitempty" yaml:"cloneList,omitempty"`
+
+	// Cluster specifies a remote cluster in which the resource is generated.
+	// Only Data can be used with a remote cluster, and downstream resources in the remote cluster are
+	// not watched, they are synchronized when the trigger changes and deleted with the trigger.
+	// The background controller must be granted permissions to get, list and watch the kubeconfig Secret,
+	// and the policy author must be allowed to get it. Remote clusters can't be targeted by policies with a ServiceAccount.
+	// +optional
+	Cluster *ClusterReference `json:"cluster,omitempty" yaml:"cluster,omitempty"`
+}
+
+// ClusterReference identifies a remote cluster by a Secret containing its kubeconfig,
+// like the `<cluster>-kubeconfig` Secrets managed by Cluster API.
+type ClusterReference struct {
+	// KubeconfigSecret references the Secret containing the kubeconfig of the remote cluster.
+	KubeconfigSecret SecretReference `json:"kubeconfigSecret" yaml:"kubeconfigSecret"`
+
+	// Key is the key of the kubeconfig in the Secret data. Defaults to "value".
+	// +optional
+	Key string `json:"key,omitempty" yaml:"key,omitempty"`
+}
+
+// GetKey returns the key of the kubeconfig in the Secret data.
+func (c *ClusterReference) GetKey() string {
+	if c.Key == "" {
+		return "value"
+	}
+	return c.Key
+}
+
+// String returns the namespaced name of the kubeconfig Secret.
+func (c *ClusterReference) String() string {
+	return c.KubeconfigSecret.Namespace + "/" + c.KubeconfigSecret.Name
 }
 
 type CloneList struct {
@@ -632,7 +799,7 @@ type CloneList struct {
 
 func (g *Generation) Validate(path *field.Path, namespaced bool, policyNamespace string, clusterResources sets.Set[string]) (errs field.ErrorList) {
 	if namespaced {
-		if err := g.validateNamespacedTargetsScope(clusterResources, policyNamespace); err != nil {
+		if err := g.validateNamespacedTargetsScope(clusterResources); err != nil {
 			errs = append(errs, field.Forbidden(path.Child("generate").Child("namespace"), fmt.Sprintf("target resource scope mismatched: %v ", err)))
 		}
 	}
@@ -649,6 +816,10 @@ func (g *Generation) Validate(path *field.Path, namespaced bool, policyNamespace
 		}
 	}
 
+	if g.Cluster != nil {
+		errs = append(errs, g.validateCluster(path.Child("generate").Child("cluster"), namespaced)...)
+	}
+
 	generateType, _ := g.GetTypeAndSync()
 	if generateType == Data {
 		return errs
@@ -690,9 +861,6 @@ func (g *Generation) ValidateCloneList(path *field.Path, namespaced bool, policy
 			if clusterResources.Has(kind) {
 				errs = append(errs, field.Forbidden(path.Child("cloneList").Child("kinds"), fmt.Sprintf("the source in cloneList must be a namespaced resource: %v", kind)))
 			}
-			if g.CloneList.Namespace != policyNamespace {
-				errs = append(errs, field.Forbidden(path.Child("cloneList").Child("namespace"), fmt.Sprintf("a namespaced policy cannot clone resources from other namespace, expected: %v, received: %v", policyNamespace, g.CloneList.Namespace)))
-			}
 		}
 	}
 
@@ -717,6 +885,22 @@ func (g *Generation) ValidateCloneList(path *field.Path, namespaced bool, policy
 	return errs
 }
 
+func (g *Generation) validateCluster(path *field.Path, namespaced bool) (errs field.ErrorList) {
+	if namespaced {
+		errs = append(errs, field.Forbidden(path, "remote clusters can only be targeted by cluster-wide policies"))
+	}
+	if g.RawData == nil {
+		errs = append(errs, field.Forbidden(path, "only data can be generated in a remote cluster"))
+	}
+	if g.Cluster.KubeconfigSecret.Name == "" {
+		errs = append(errs, field.Required(path.Child("kubeconfigSecret").Child("name"), "the kubeconfig secret name is required"))
+	}
+	if g.Cluster.KubeconfigSecret.Namespace == "" {
+		errs = append(errs, field.Required(path.Child("kubeconfigSecret").Child("namespace"), "the kubeconfig secret namespace is required"))
+	}
+	return errs
+}
+
 func (g *Generation) GetData() apiextensions.JSON {
 	return FromJSON(g.RawData)
 }
@@ -725,21 +909,14 @@ func (g *Generation) SetData(in apiextensions.JSON) {
 	g.RawData = ToJSON(in)
 }
 
-func (g *Generation) validateNamespacedTargetsScope(clusterResources sets.Set[string], policyNamespace string) error {
+// validateNamespacedTargetsScope checks the targets of a namespaced policy are namespaced resources.
+// Accessing resources outside of the policy namespace depends on the permissions of the policy author and is
+// checked at admission.
+func (g *Generation) validateNamespacedTargetsScope(clusterResources sets.Set[string]) error {
 	target := g.ResourceSpec
 	if clusterResources.Has(target.GetAPIVersion() + "/" + target.GetKind()) {
 		return fmt.Errorf("the target must be a namespaced resource: %v/%v", target.GetAPIVersion(), target.GetKind())
 	}
-
-	if g.GetNamespace() != policyNamespace {
-		return fmt.Errorf("a namespaced policy cannot generate resources in other namespaces, expected: %v, received: %v", policyNamespace, g.GetNamespace())
-	}
-
-	if g.Clone.Name != "" {
-		if g.Clone.Namespace != policyNamespace {
-			return fmt.Errorf("a namespaced policy cannot clone resources from other namespaces, expected: %v, received: %v", policyNamespace, g.Clone.Namespace)
-		}
-	}
 	return nil
 }
 
@@ -247,6 +247,31 @@ func Test_ImageVerification(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "attestation cluster metadata",
+			subject: ImageVerification{
+				ImageReferences: []string{"bla"},
+				Attestations: []Attestation{{
+					Type:            "https://example.com/deployment/v1",
+					ClusterMetadata: &AttestationClusterMetadata{Labels: map[string]string{"environment": "deployment.environment"}},
+				}},
+			},
+		},
+		{
+			name: "empty attestation cluster metadata",
+			subject: ImageVerification{
+				ImageReferences: []string{"bla"},
+				Attestations: []Attestation{{
+					Type:            "https://example.com/deployment/v1",
+					ClusterMetadata: &AttestationClusterMetadata{},
+				}},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				return field.ErrorList{
+					field.Required(path.Child("attestations").Index(0).Child("clusterMetadata"), "a cluster name or label field is required"),
+				}
+			},
+		},
 	}
 
 	isAuditFailureAction := false
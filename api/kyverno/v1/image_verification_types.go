@@ -112,6 +112,11 @@ type ImageVerification struct {
 	// +kubebuilder:default=true
 	// +kubebuilder:validation:Optional
 	UseCache bool `json:"useCache" yaml:"useCache"`
+
+	// StampVerification adds a kyverno.io/verified-images annotation, HMAC-signed by Kyverno, recording
+	// the digest, the attestors and the time of verification of the images verified by this rule.
+	// +kubebuilder:validation:Optional
+	StampVerification bool `json:"stampVerification,omitempty" yaml:"stampVerification,omitempty"`
 }
 
 type AttestorSet struct {
@@ -300,6 +305,25 @@ type Attestation struct {
 	// the attestation check is satisfied as long there are predicates that match the predicate type.
 	// +kubebuilder:validation:Optional
 	Conditions []AnyAllConditions `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+
+	// ClusterMetadata checks fields of the predicate against the metadata of the cluster declared in the
+	// Kyverno ConfigMap, images attested for other clusters or environments are rejected.
+	// +kubebuilder:validation:Optional
+	ClusterMetadata *AttestationClusterMetadata `json:"clusterMetadata,omitempty" yaml:"clusterMetadata,omitempty"`
+}
+
+// AttestationClusterMetadata declares the predicate fields checked against the metadata of the cluster.
+// Fields are JMESPath expressions evaluated against the predicate, they must return a string or a list
+// of strings matching the cluster value. Wildcards are supported in the predicate values.
+type AttestationClusterMetadata struct {
+	// ClusterName is the predicate field holding the names of the clusters the image is attested for.
+	// +kubebuilder:validation:Optional
+	ClusterName string `json:"clusterName,omitempty" yaml:"clusterName,omitempty"`
+
+	// Labels maps the keys of cluster labels to the predicate fields holding the label values the image
+	// is attested for, for example `deployment.environments` for the `environment` label.
+	// +kubebuilder:validation:Optional
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
 }
 
 type ImageRegistryCredentials struct {
@@ -367,8 +391,8 @@ func (iv *ImageVerification) Validate(isAuditFailureAction bool, path *field.Pat
 }
 
 func (a *Attestation) Validate(path *field.Path) (errs field.ErrorList) {
-	if len(a.Attestors) == 0 {
-		return
+	if a.ClusterMetadata != nil {
+		errs = append(errs, a.ClusterMetadata.Validate(path.Child("clusterMetadata"))...)
 	}
 
 	attestorsPath := path.Child("attestors")
@@ -379,6 +403,21 @@ func (a *Attestation) Validate(path *field.Path) (errs field.ErrorList) {
 	return errs
 }
 
+// Validate implements programmatic validation
+func (m *AttestationClusterMetadata) Validate(path *field.Path) (errs field.ErrorList) {
+	if m.ClusterName == "" && len(m.Labels) == 0 {
+		errs = append(errs, field.Required(path, "a cluster name or label field is required"))
+	}
+	for key, value := range m.Labels {
+		if key == "" {
+			errs = append(errs, field.Invalid(path.Child("labels"), key, "label keys must not be empty"))
+		} else if value == "" {
+			errs = append(errs, field.Required(path.Child("labels").Key(key), "a predicate field is required"))
+		}
+	}
+	return errs
+}
+
 func (as *AttestorSet) Validate(path *field.Path) (errs field.ErrorList) {
 	return validateAttestorSet(as, path)
 }
@@ -0,0 +1,58 @@
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// PolicyOrdering declares the order in which a policy is applied relative to other policies and the policies
+// it is mutually exclusive with. Cluster policies are referenced by name and policies by namespace/name.
+type PolicyOrdering struct {
+	// Before is the list of policies this policy is applied before.
+	// +optional
+	Before []string `json:"before,omitempty" yaml:"before,omitempty"`
+
+	// After is the list of policies this policy is applied after.
+	// +optional
+	After []string `json:"after,omitempty" yaml:"after,omitempty"`
+
+	// ExclusionGroups is the list of mutual exclusion groups the policy belongs to. When mutating or enforcing
+	// validations on an admission request, a policy is skipped if a policy sharing one of its groups was
+	// already applied to the request.
+	// +optional
+	ExclusionGroups []string `json:"exclusionGroups,omitempty" yaml:"exclusionGroups,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (o *PolicyOrdering) Validate(path *field.Path) (errs field.ErrorList) {
+	before := sets.New[string]()
+	for i, name := range o.Before {
+		if name == "" {
+			errs = append(errs, field.Required(path.Child("before").Index(i), "policy name must not be empty"))
+		} else if before.Has(name) {
+			errs = append(errs, field.Duplicate(path.Child("before").Index(i), name))
+		}
+		before.Insert(name)
+	}
+	after := sets.New[string]()
+	for i, name := range o.After {
+		if name == "" {
+			errs = append(errs, field.Required(path.Child("after").Index(i), "policy name must not be empty"))
+		} else if after.Has(name) {
+			errs = append(errs, field.Duplicate(path.Child("after").Index(i), name))
+		} else if before.Has(name) {
+			errs = append(errs, field.Invalid(path.Child("after").Index(i), name, "policy can't be applied both before and after this policy"))
+		}
+		after.Insert(name)
+	}
+	groups := sets.New[string]()
+	for i, group := range o.ExclusionGroups {
+		if group == "" {
+			errs = append(errs, field.Required(path.Child("exclusionGroups").Index(i), "group name must not be empty"))
+		} else if groups.Has(group) {
+			errs = append(errs, field.Duplicate(path.Child("exclusionGroups").Index(i), group))
+		}
+		groups.Insert(group)
+	}
+	return errs
+}
@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"encoding/json"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// PolicyParameters references the resource holding the parameters of a policy. The parameter resource is
+// available to the rules of the policy in the `params` variable, so that values like allowed registries or
+// replica limits can be changed without editing the policy.
+type PolicyParameters struct {
+	// ParamKind is the API version and kind of the parameter resource, a ConfigMap or a custom resource.
+	ParamKind ParamKind `json:"paramKind" yaml:"paramKind"`
+
+	// ParamRef references the parameter resource.
+	ParamRef ParamRef `json:"paramRef" yaml:"paramRef"`
+
+	// Schema is an OpenAPI v3 schema, in the format used by CustomResourceDefinitions, the parameter resource
+	// is validated against before the rules are applied. Rules fail with an error when the parameter resource
+	// does not match the schema.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Type=object
+	Schema *apiextv1.JSON `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// ParamKind is the API version and kind of a parameter resource.
+type ParamKind struct {
+	// APIVersion is the API group version of the parameter resource, `v1` for ConfigMaps.
+	APIVersion string `json:"apiVersion" yaml:"apiVersion"`
+
+	// Kind is the kind of the parameter resource.
+	Kind string `json:"kind" yaml:"kind"`
+}
+
+// IsConfigMap returns true if the parameter resource is a ConfigMap
+func (k ParamKind) IsConfigMap() bool {
+	return k.APIVersion == "v1" && k.Kind == "ConfigMap"
+}
+
+// ParamRef references a parameter resource.
+type ParamRef struct {
+	// Name is the name of the parameter resource.
+	Name string `json:"name" yaml:"name"`
+
+	// Namespace is the namespace of the parameter resource, it must be empty for cluster scoped resources.
+	// Policies can only reference the resources of their namespace and default to it.
+	// +optional
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+}
+
+// GetNamespace returns the namespace of the parameter resource of a policy in policyNamespace
+func (p *PolicyParameters) GetNamespace(policyNamespace string) string {
+	if p.ParamRef.Namespace == "" {
+		return policyNamespace
+	}
+	return p.ParamRef.Namespace
+}
+
+// Validate implements programmatic validation
+func (p *PolicyParameters) Validate(path *field.Path, namespaced bool, policyNamespace string) (errs field.ErrorList) {
+	if p.ParamKind.APIVersion == "" {
+		errs = append(errs, field.Required(path.Child("paramKind").Child("apiVersion"), "apiVersion is required"))
+	}
+	if p.ParamKind.Kind == "" {
+		errs = append(errs, field.Required(path.Child("paramKind").Child("kind"), "kind is required"))
+	}
+	if p.ParamRef.Name == "" {
+		errs = append(errs, field.Required(path.Child("paramRef").Child("name"), "name is required"))
+	}
+	if namespaced {
+		if p.ParamRef.Namespace != "" && p.ParamRef.Namespace != policyNamespace {
+			errs = append(errs, field.Forbidden(path.Child("paramRef").Child("namespace"), "a policy can only reference parameters in its namespace"))
+		}
+	} else if p.ParamKind.IsConfigMap() && p.ParamRef.Namespace == "" {
+		errs = append(errs, field.Required(path.Child("paramRef").Child("namespace"), "namespace is required for ConfigMap parameters"))
+	}
+	if p.Schema != nil {
+		var schema map[string]interface{}
+		if err := json.Unmarshal(p.Schema.Raw, &schema); err != nil {
+			errs = append(errs, field.Invalid(path.Child("schema"), string(p.Schema.Raw), "the schema must be an object"))
+		}
+	}
+	return errs
+}
@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"strings"
+
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -8,6 +10,17 @@ import (
 const (
 	// PolicyConditionReady means that the policy is ready
 	PolicyConditionReady = "Ready"
+	// PolicyConditionRulesReady means that all the rules of the policy are ready
+	PolicyConditionRulesReady = "RulesReady"
+)
+
+const (
+	// RuleConditionWebhookConfigured means that the webhooks needed by the rule are configured
+	RuleConditionWebhookConfigured = "WebhookConfigured"
+	// RuleConditionContextResolvable means that the context entries of the rule can be resolved
+	RuleConditionContextResolvable = "ContextResolvable"
+	// RuleConditionValidatingAdmissionPolicyGenerated means that a validating admission policy is generated for the rule
+	RuleConditionValidatingAdmissionPolicyGenerated = "ValidatingAdmissionPolicyGenerated"
 )
 
 const (
@@ -15,6 +28,27 @@ const (
 	PolicyReasonSucceeded = "Succeeded"
 	// PolicyReasonSucceeded is the reason set when the policy is not ready
 	PolicyReasonFailed = "Failed"
+	// PolicyReasonRulesReady is the reason set when all the rules of the policy are ready
+	PolicyReasonRulesReady = "RulesReady"
+	// PolicyReasonRulesNotReady is the reason set when at least one rule of the policy is not ready
+	PolicyReasonRulesNotReady = "RulesNotReady"
+)
+
+const (
+	// RuleReasonWebhookConfigured is the reason set when the webhooks needed by the rule are configured
+	RuleReasonWebhookConfigured = "WebhookConfigured"
+	// RuleReasonWebhookPending is the reason set when the webhooks needed by the rule are not configured yet
+	RuleReasonWebhookPending = "WebhookPending"
+	// RuleReasonWebhookNotRequired is the reason set when the rule doesn't need webhooks
+	RuleReasonWebhookNotRequired = "WebhookNotRequired"
+	// RuleReasonContextResolved is the reason set when all the context entries of the rule can be resolved
+	RuleReasonContextResolved = "ContextResolved"
+	// RuleReasonContextUnresolved is the reason set when a context entry of the rule references a missing resource
+	RuleReasonContextUnresolved = "ContextUnresolved"
+	// RuleReasonValidatingAdmissionPolicyGenerated is the reason set when a validating admission policy is generated
+	RuleReasonValidatingAdmissionPolicyGenerated = "Generated"
+	// RuleReasonValidatingAdmissionPolicyNotGenerated is the reason set when no validating admission policy is generated
+	RuleReasonValidatingAdmissionPolicyNotGenerated = "NotGenerated"
 )
 
 // Deprecated. Policy metrics are now available via the "/metrics" endpoint.
@@ -31,6 +65,23 @@ type PolicyStatus struct {
 	// ValidatingAdmissionPolicy contains status information
 	// +optional
 	ValidatingAdmissionPolicy ValidatingAdmissionPolicyStatus `json:"validatingadmissionpolicy" yaml:"validatingadmissionpolicy"`
+	// Rules contains the readiness of every rule of the policy
+	// +optional
+	Rules []RuleStatus `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+// RuleStatus contains the readiness of a rule
+type RuleStatus struct {
+	// Name is the name of the rule
+	Name string `json:"name" yaml:"name"`
+	// Ready indicates whether the webhooks needed by the rule are configured and its context entries can be resolved
+	Ready bool `json:"ready" yaml:"ready"`
+	// AutogenRules contains the names of the rules auto generated from the rule for pod controllers
+	// +optional
+	AutogenRules []string `json:"autogenRules,omitempty" yaml:"autogenRules,omitempty"`
+	// Conditions contains the conditions of the rule
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" yaml:"conditions,omitempty"`
 }
 
 // RuleCountStatus contains four variables which describes counts for
@@ -62,6 +113,65 @@ func (status *PolicyStatus) SetReady(ready bool, message string) {
 	meta.SetStatusCondition(&status.Conditions, condition)
 }
 
+// GetRuleStatus returns the status of a rule, nil if the status of the rule is not known
+func (status *PolicyStatus) GetRuleStatus(rule string) *RuleStatus {
+	for i := range status.Rules {
+		if status.Rules[i].Name == rule {
+			return &status.Rules[i]
+		}
+	}
+	return nil
+}
+
+// SetRuleCondition sets a condition of a rule and updates the readiness of the rule and of all the rules
+func (status *PolicyStatus) SetRuleCondition(rule string, condition metav1.Condition) {
+	ruleStatus := status.GetRuleStatus(rule)
+	if ruleStatus == nil {
+		status.Rules = append(status.Rules, RuleStatus{Name: rule})
+		ruleStatus = &status.Rules[len(status.Rules)-1]
+	}
+	meta.SetStatusCondition(&ruleStatus.Conditions, condition)
+	ruleStatus.Ready = !meta.IsStatusConditionFalse(ruleStatus.Conditions, RuleConditionWebhookConfigured) &&
+		!meta.IsStatusConditionFalse(ruleStatus.Conditions, RuleConditionContextResolvable)
+	status.setRulesReady()
+}
+
+// RetainRules drops the status of the rules that are not in the given list
+func (status *PolicyStatus) RetainRules(rules ...string) {
+	var retained []RuleStatus
+	for _, ruleStatus := range status.Rules {
+		for _, rule := range rules {
+			if ruleStatus.Name == rule {
+				retained = append(retained, ruleStatus)
+				break
+			}
+		}
+	}
+	status.Rules = retained
+	status.setRulesReady()
+}
+
+func (status *PolicyStatus) setRulesReady() {
+	var notReady []string
+	for _, ruleStatus := range status.Rules {
+		if !ruleStatus.Ready {
+			notReady = append(notReady, ruleStatus.Name)
+		}
+	}
+	condition := metav1.Condition{
+		Type:    PolicyConditionRulesReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  PolicyReasonRulesReady,
+		Message: "All rules are ready",
+	}
+	if len(notReady) != 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = PolicyReasonRulesNotReady
+		condition.Message = "Rules not ready: " + strings.Join(notReady, ", ")
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
 // IsReady indicates if the policy is ready to serve the admission request
 func (status *PolicyStatus) IsReady() bool {
 	condition := meta.FindStatusCondition(status.Conditions, PolicyConditionReady)
@@ -72,6 +182,10 @@ func (status *PolicyStatus) IsReady() bool {
 type AutogenStatus struct {
 	// Rules is a list of Rule instances. It contains auto generated rules added for pod controllers
 	Rules []Rule `json:"rules,omitempty" yaml:"rules,omitempty"`
+
+	// Hash is a content hash of the auto generated rules, it only changes when the generated rules change
+	// +optional
+	Hash string `json:"hash,omitempty" yaml:"hash,omitempty"`
 }
 
 // ValidatingAdmissionPolicy contains status information
@@ -0,0 +1,41 @@
+package v1
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_PolicyStatus_SetRuleCondition(t *testing.T) {
+	var status PolicyStatus
+	status.SetRuleCondition("rule-1", metav1.Condition{Type: RuleConditionWebhookConfigured, Status: metav1.ConditionTrue, Reason: RuleReasonWebhookConfigured})
+	status.SetRuleCondition("rule-2", metav1.Condition{Type: RuleConditionWebhookConfigured, Status: metav1.ConditionFalse, Reason: RuleReasonWebhookPending})
+	assert.Equal(t, len(status.Rules), 2)
+	assert.Equal(t, status.GetRuleStatus("rule-1").Ready, true)
+	assert.Equal(t, status.GetRuleStatus("rule-2").Ready, false)
+	assert.Assert(t, status.GetRuleStatus("rule-3") == nil)
+	condition := meta.FindStatusCondition(status.Conditions, PolicyConditionRulesReady)
+	assert.Equal(t, condition.Status, metav1.ConditionFalse)
+	assert.Equal(t, condition.Reason, PolicyReasonRulesNotReady)
+	assert.Equal(t, condition.Message, "Rules not ready: rule-2")
+	// a condition not affecting readiness keeps the rule ready
+	status.SetRuleCondition("rule-1", metav1.Condition{Type: RuleConditionValidatingAdmissionPolicyGenerated, Status: metav1.ConditionFalse, Reason: RuleReasonValidatingAdmissionPolicyNotGenerated})
+	assert.Equal(t, status.GetRuleStatus("rule-1").Ready, true)
+	assert.Equal(t, len(status.GetRuleStatus("rule-1").Conditions), 2)
+	status.SetRuleCondition("rule-2", metav1.Condition{Type: RuleConditionWebhookConfigured, Status: metav1.ConditionTrue, Reason: RuleReasonWebhookConfigured})
+	assert.Equal(t, status.GetRuleStatus("rule-2").Ready, true)
+	assert.Equal(t, meta.IsStatusConditionTrue(status.Conditions, PolicyConditionRulesReady), true)
+}
+
+func Test_PolicyStatus_RetainRules(t *testing.T) {
+	var status PolicyStatus
+	status.SetRuleCondition("rule-1", metav1.Condition{Type: RuleConditionContextResolvable, Status: metav1.ConditionTrue, Reason: RuleReasonContextResolved})
+	status.SetRuleCondition("rule-2", metav1.Condition{Type: RuleConditionContextResolvable, Status: metav1.ConditionFalse, Reason: RuleReasonContextUnresolved})
+	assert.Equal(t, meta.IsStatusConditionFalse(status.Conditions, PolicyConditionRulesReady), true)
+	status.RetainRules("rule-1")
+	assert.Equal(t, len(status.Rules), 1)
+	assert.Equal(t, status.Rules[0].Name, "rule-1")
+	assert.Equal(t, meta.IsStatusConditionTrue(status.Conditions, PolicyConditionRulesReady), true)
+}
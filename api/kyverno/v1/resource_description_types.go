@@ -5,6 +5,7 @@ import (
 
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -52,6 +53,20 @@ type ResourceDescription struct {
 	// +optional
 	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty" yaml:"namespaceSelector,omitempty"`
 
+	// FieldSelector is a comma separated list of field requirements evaluated against the resource,
+	// using the Kubernetes field selector syntax (e.g. "spec.nodeName=node-1,status.phase!=Running").
+	// Fields are dot separated paths in the resource, values support the wildcard characters
+	// "*" (matches zero or many characters) and "?" (at least one character).
+	// A missing field matches the empty value.
+	// +optional
+	FieldSelector string `json:"fieldSelector,omitempty" yaml:"fieldSelector,omitempty"`
+
+	// Owner matches resources by their owners, resolved through the chain of controller owner references
+	// (e.g. the pods owned by a CronJob through a Job). The resource matches when an owner in the chain
+	// matches all the owner criteria.
+	// +optional
+	Owner *OwnerDescription `json:"owner,omitempty" yaml:"owner,omitempty"`
+
 	// Operations can contain values ["CREATE, "UPDATE", "CONNECT", "DELETE"], which are used to match a specific action.
 	// +optional
 	Operations []AdmissionOperation `json:"operations,omitempty" yaml:"operations,omitempty"`
@@ -64,7 +79,9 @@ func (r ResourceDescription) IsEmpty() bool {
 		len(r.Namespaces) == 0 &&
 		len(r.Annotations) == 0 &&
 		r.Selector == nil &&
-		r.NamespaceSelector == nil
+		r.NamespaceSelector == nil &&
+		r.FieldSelector == "" &&
+		r.Owner == nil
 }
 
 func (r ResourceDescription) GetOperations() []string {
@@ -90,6 +107,14 @@ func (r *ResourceDescription) Validate(path *field.Path, namespaced bool, cluste
 			}
 		}
 	}
+	if r.FieldSelector != "" {
+		if _, err := fields.ParseSelector(r.FieldSelector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("fieldSelector"), r.FieldSelector, err.Error()))
+		}
+	}
+	if r.Owner != nil {
+		errs = append(errs, r.Owner.Validate(path.Child("owner"))...)
+	}
 	if namespaced {
 		if len(r.Namespaces) > 0 {
 			errs = append(errs, field.Forbidden(path.Child("namespaces"), "Filtering namespaces not allowed in namespaced policies"))
@@ -103,3 +128,33 @@ func (r *ResourceDescription) Validate(path *field.Path, namespaced bool, cluste
 	}
 	return errs
 }
+
+// OwnerDescription contains criteria used to match the owners of resources.
+type OwnerDescription struct {
+	// Kinds is a list of owner kinds.
+	// +optional
+	Kinds []string `json:"kinds,omitempty" yaml:"kinds,omitempty"`
+
+	// Names are the names of the owner. Each name supports wildcard characters
+	// "*" (matches zero or many characters) and "?" (at least one character).
+	// +optional
+	Names []string `json:"names,omitempty" yaml:"names,omitempty"`
+
+	// Selector is a label selector for the owner labels. Label keys and values in `matchLabels`
+	// support the wildcard characters `*` (matches zero or many characters) and `?` (matches one character).
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty" yaml:"selector,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (o *OwnerDescription) Validate(path *field.Path) (errs field.ErrorList) {
+	if len(o.Kinds) == 0 && len(o.Names) == 0 && o.Selector == nil {
+		errs = append(errs, field.Required(path, "at least one of kinds, names or selector must be specified"))
+	}
+	if o.Selector != nil && !kubeutils.LabelSelectorContainsWildcard(o.Selector) {
+		if _, err := metav1.LabelSelectorAsSelector(o.Selector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("selector"), o.Selector, err.Error()))
+		}
+	}
+	return errs
+}
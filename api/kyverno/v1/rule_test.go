@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -1137,3 +1139,120 @@ func Test_Validate_ClusterPolicy_Generate_Variables(t *testing.T) {
 		assert.Equal(t, len(errs) != 0, testcase.shouldFail, testcase.name)
 	}
 }
+
+func Test_ValidateAutogen(t *testing.T) {
+	disabled := false
+	testCases := []struct {
+		name    string
+		autogen *RuleAutogen
+		errors  int
+	}{{
+		name: "not set",
+	}, {
+		name:    "disabled",
+		autogen: &RuleAutogen{Enabled: &disabled},
+	}, {
+		name:    "controllers",
+		autogen: &RuleAutogen{Controllers: []string{"Deployment", "CronJob"}},
+	}, {
+		name:    "disabled with controllers",
+		autogen: &RuleAutogen{Enabled: &disabled, Controllers: []string{"Deployment"}},
+		errors:  1,
+	}, {
+		name:    "invalid controllers",
+		autogen: &RuleAutogen{Controllers: []string{"Pod", "all", ""}},
+		errors:  3,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := Rule{Name: "test", Autogen: tc.autogen}
+			errs := rule.ValidateAutogen(field.NewPath("autogen"))
+			assert.Equal(t, len(errs), tc.errors)
+		})
+	}
+}
+
+func Test_ValidateTimeout(t *testing.T) {
+	ignore := Ignore
+	testCases := []struct {
+		name                 string
+		timeout              *metav1.Duration
+		timeoutFailurePolicy *FailurePolicyType
+		errors               int
+	}{{
+		name: "not set",
+	}, {
+		name:    "timeout",
+		timeout: &metav1.Duration{Duration: time.Second},
+	}, {
+		name:                 "timeout with failure policy",
+		timeout:              &metav1.Duration{Duration: time.Second},
+		timeoutFailurePolicy: &ignore,
+	}, {
+		name:    "zero timeout",
+		timeout: &metav1.Duration{},
+		errors:  1,
+	}, {
+		name:    "negative timeout",
+		timeout: &metav1.Duration{Duration: -time.Second},
+		errors:  1,
+	}, {
+		name:                 "failure policy without timeout",
+		timeoutFailurePolicy: &ignore,
+		errors:               1,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := Rule{Name: "test", Timeout: tc.timeout, TimeoutFailurePolicy: tc.timeoutFailurePolicy}
+			errs := rule.ValidateTimeout(field.NewPath("rule"))
+			assert.Equal(t, len(errs), tc.errors)
+		})
+	}
+}
+
+func Test_Generation_Validate_Cluster(t *testing.T) {
+	cluster := &ClusterReference{KubeconfigSecret: SecretReference{Name: "management-kubeconfig", Namespace: "clusters"}}
+	testCases := []struct {
+		name       string
+		generation Generation
+		namespaced bool
+		errors     []string
+	}{{
+		name: "data",
+		generation: Generation{
+			ResourceSpec: ResourceSpec{APIVersion: "v1", Kind: "ResourceQuota", Name: "default", Namespace: "tenants"},
+			RawData:      ToJSON(map[string]interface{}{"spec": map[string]interface{}{}}),
+			Cluster:      cluster,
+		},
+	}, {
+		name: "namespaced policy",
+		generation: Generation{
+			ResourceSpec: ResourceSpec{APIVersion: "v1", Kind: "ResourceQuota", Name: "default", Namespace: "tenants"},
+			RawData:      ToJSON(map[string]interface{}{"spec": map[string]interface{}{}}),
+			Cluster:      cluster,
+		},
+		namespaced: true,
+		errors:     []string{"dummy.generate.cluster: Forbidden: remote clusters can only be targeted by cluster-wide policies"},
+	}, {
+		name: "clone",
+		generation: Generation{
+			ResourceSpec: ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Name: "config", Namespace: "tenants"},
+			Clone:        CloneFrom{Namespace: "default", Name: "config"},
+			Cluster:      &ClusterReference{},
+		},
+		errors: []string{
+			"dummy.generate.cluster: Forbidden: only data can be generated in a remote cluster",
+			"dummy.generate.cluster.kubeconfigSecret.name: Required value: the kubeconfig secret name is required",
+			"dummy.generate.cluster.kubeconfigSecret.namespace: Required value: the kubeconfig secret namespace is required",
+		},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := tc.generation.Validate(field.NewPath("dummy"), tc.namespaced, "", nil)
+			assert.Equal(t, len(errs), len(tc.errors))
+			for i := range errs {
+				assert.Equal(t, errs[i].Error(), tc.errors[i])
+			}
+		})
+	}
+}
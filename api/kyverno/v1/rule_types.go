@@ -10,6 +10,7 @@ import (
 	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -53,6 +54,12 @@ type Rule struct {
 	// +optional
 	Context []ContextEntry `json:"context,omitempty" yaml:"context,omitempty"`
 
+	// Prefetch declares data that the background scanner loads in bulk before evaluating the rule
+	// against individual resources. API calls and ConfigMap lookups targeting prefetched data are
+	// served from the prefetched copy instead of reaching the API server for every resource.
+	// +optional
+	Prefetch *Prefetch `json:"prefetch,omitempty" yaml:"prefetch,omitempty"`
+
 	// MatchResources defines when this policy rule should be applied. The match
 	// criteria can include resource information (e.g. kind, name, namespace, labels)
 	// and admission review request information like the user name or role.
@@ -91,6 +98,11 @@ type Rule struct {
 	// +optional
 	Validation Validation `json:"validate,omitempty" yaml:"validate,omitempty"`
 
+	// Remediation is a message explaining how to fix a violation of the rule.
+	// It is added to policy report results and can contain variables.
+	// +optional
+	Remediation string `json:"remediation,omitempty" yaml:"remediation,omitempty"`
+
 	// Generation is used to create new resources.
 	// +optional
 	Generation Generation `json:"generate,omitempty" yaml:"generate,omitempty"`
@@ -105,6 +117,48 @@ type Rule struct {
 	// +kubebuilder:default=true
 	// +kubebuilder:validation:Optional
 	SkipBackgroundRequests bool `json:"skipBackgroundRequests,omitempty" yaml:"skipBackgroundRequests,omitempty"`
+
+	// Timeout limits the time spent evaluating the rule, including the context entries, API calls
+	// and image registry requests it performs. When the timeout expires the evaluation is cancelled
+	// and the rule is reported according to TimeoutFailurePolicy.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	// TimeoutFailurePolicy defines how a rule that timed out is reported. Fail reports the rule as
+	// errored and Ignore reports it as a warning, which never blocks the admission request.
+	// Defaults to the failure policy of the policy.
+	// +optional
+	TimeoutFailurePolicy *FailurePolicyType `json:"timeoutFailurePolicy,omitempty" yaml:"timeoutFailurePolicy,omitempty"`
+
+	// Autogen controls the rules auto generated from this rule for pod controllers.
+	// +optional
+	Autogen *RuleAutogen `json:"autogen,omitempty" yaml:"autogen,omitempty"`
+}
+
+// RuleAutogen controls the rules auto generated from a rule for pod controllers.
+type RuleAutogen struct {
+	// Enabled turns off the generation of rules for pod controllers from this rule when set to false.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+
+	// Controllers restricts the pod controller kinds rules are generated for.
+	// When empty, rules are generated for all the controllers enabled on the policy.
+	// +optional
+	Controllers []string `json:"controllers,omitempty" yaml:"controllers,omitempty"`
+}
+
+// GetTimeoutFailurePolicy returns the failure policy applied when the rule times out,
+// falling back to the given policy failure policy when not set
+func (r *Rule) GetTimeoutFailurePolicy(policyFailurePolicy FailurePolicyType) FailurePolicyType {
+	if r.TimeoutFailurePolicy == nil {
+		return policyFailurePolicy
+	}
+	return *r.TimeoutFailurePolicy
+}
+
+// IsAutogenEnabled returns false when the generation of rules for pod controllers is turned off for this rule
+func (r *Rule) IsAutogenEnabled() bool {
+	return r.Autogen == nil || r.Autogen.Enabled == nil || *r.Autogen.Enabled
 }
 
 // HasMutate checks for mutate rule
@@ -147,6 +201,11 @@ func (r *Rule) HasValidateCEL() bool {
 	return r.Validation.CEL != nil && !datautils.DeepEqual(r.Validation.CEL, &CEL{})
 }
 
+// HasValidateWASM checks for validate.wasm rule
+func (r *Rule) HasValidateWASM() bool {
+	return r.Validation.WASM != nil && !datautils.DeepEqual(r.Validation.WASM, &WASM{})
+}
+
 // HasValidate checks for validate rule
 func (r *Rule) HasValidate() bool {
 	return !datautils.DeepEqual(r.Validation, Validation{})
@@ -419,6 +478,33 @@ func (r *Rule) ValidateGenerate(path *field.Path, namespaced bool, policyNamespa
 	return r.Generation.Validate(path, namespaced, policyNamespace, clusterResources)
 }
 
+// ValidateAutogen checks the autogen controls of the rule
+func (r *Rule) ValidateAutogen(path *field.Path) (errs field.ErrorList) {
+	if r.Autogen == nil {
+		return nil
+	}
+	if !r.IsAutogenEnabled() && len(r.Autogen.Controllers) != 0 {
+		errs = append(errs, field.Forbidden(path.Child("controllers"), "controllers can't be set when autogen is disabled"))
+	}
+	for i, controller := range r.Autogen.Controllers {
+		if controller == "" || controller == "Pod" || controller == "all" || controller == "none" {
+			errs = append(errs, field.Invalid(path.Child("controllers").Index(i), controller, "must be a pod controller kind"))
+		}
+	}
+	return errs
+}
+
+// ValidateTimeout checks the timeout of the rule
+func (r *Rule) ValidateTimeout(path *field.Path) (errs field.ErrorList) {
+	if r.Timeout != nil && r.Timeout.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("timeout"), r.Timeout.Duration.String(), "timeout must be greater than zero"))
+	}
+	if r.Timeout == nil && r.TimeoutFailurePolicy != nil {
+		errs = append(errs, field.Forbidden(path.Child("timeoutFailurePolicy"), "timeoutFailurePolicy requires a timeout"))
+	}
+	return errs
+}
+
 // Validate implements programmatic validation
 func (r *Rule) Validate(path *field.Path, namespaced bool, policyNamespace string, clusterResources sets.Set[string]) (errs field.ErrorList) {
 	errs = append(errs, r.ValidateRuleType(path)...)
@@ -428,5 +514,7 @@ func (r *Rule) Validate(path *field.Path, namespaced bool, policyNamespace strin
 	errs = append(errs, r.ValidateMutationRuleTargetNamespace(path, namespaced, policyNamespace)...)
 	errs = append(errs, r.ValidatePSaControlNames(path)...)
 	errs = append(errs, r.ValidateGenerate(path, namespaced, policyNamespace, clusterResources)...)
+	errs = append(errs, r.ValidateAutogen(path.Child("autogen"))...)
+	errs = append(errs, r.ValidateTimeout(path)...)
 	return errs
 }
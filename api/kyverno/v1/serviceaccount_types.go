@@ -0,0 +1,45 @@
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ServiceAccountReference references the ServiceAccount impersonated by the background controller when it
+// applies the generate and mutate existing rules of a policy.
+type ServiceAccountReference struct {
+	// Name is the name of the ServiceAccount.
+	Name string `json:"name" yaml:"name"`
+
+	// Namespace is the namespace of the ServiceAccount, it is required for cluster-wide policies.
+	// Policies can only reference the ServiceAccounts of their namespace and default to it.
+	// +optional
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+}
+
+// GetNamespace returns the namespace of the ServiceAccount of a policy in policyNamespace
+func (s *ServiceAccountReference) GetNamespace(policyNamespace string) string {
+	if s.Namespace == "" {
+		return policyNamespace
+	}
+	return s.Namespace
+}
+
+// Username returns the name of the user authenticated with the ServiceAccount of a policy in policyNamespace
+func (s *ServiceAccountReference) Username(policyNamespace string) string {
+	return "system:serviceaccount:" + s.GetNamespace(policyNamespace) + ":" + s.Name
+}
+
+// Validate implements programmatic validation
+func (s *ServiceAccountReference) Validate(path *field.Path, namespaced bool, policyNamespace string) (errs field.ErrorList) {
+	if s.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), "name is required"))
+	}
+	if namespaced {
+		if s.Namespace != "" && s.Namespace != policyNamespace {
+			errs = append(errs, field.Forbidden(path.Child("namespace"), "a policy can only reference service accounts in its namespace"))
+		}
+	} else if s.Namespace == "" {
+		errs = append(errs, field.Required(path.Child("namespace"), "namespace is required"))
+	}
+	return errs
+}
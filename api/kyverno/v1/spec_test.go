@@ -2,9 +2,11 @@ package v1
 
 import (
 	"testing"
+	"time"
 
 	"gotest.tools/assert"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -49,3 +51,186 @@ func Test_Validate_UniqueRuleName(t *testing.T) {
 	assert.Equal(t, errs[0].Type, field.ErrorTypeInvalid)
 	assert.Equal(t, errs[0].Detail, "Duplicate rule name: 'deny-privileged-disallowpriviligedescalation'")
 }
+
+func Test_Validate_UniqueConditionTemplateName(t *testing.T) {
+	subject := Spec{
+		ConditionTemplates: []ConditionTemplate{{
+			Name: "is-production",
+		}, {
+			Name: "is-production",
+		}, {}},
+	}
+	path := field.NewPath("dummy")
+	errs := subject.ValidateConditionTemplates(path.Child("conditionTemplates"))
+	assert.Equal(t, len(errs), 2)
+	assert.Equal(t, errs[0].Field, "dummy.conditionTemplates[1].name")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeInvalid)
+	assert.Equal(t, errs[0].Detail, "Duplicate condition template name: 'is-production'")
+	assert.Equal(t, errs[1].Field, "dummy.conditionTemplates[2].name")
+	assert.Equal(t, errs[1].Type, field.ErrorTypeRequired)
+}
+
+func Test_Validate_Schedule(t *testing.T) {
+	subject := Spec{
+		Schedule: &PolicySchedule{
+			TimeZone: "Nowhere/Unknown",
+			Windows: []ScheduleWindow{{
+				Start:    "0 8 * * 1-5",
+				Duration: metav1.Duration{Duration: 10 * time.Hour},
+			}, {
+				Start: "invalid",
+			}},
+		},
+	}
+	path := field.NewPath("dummy")
+	errs := subject.Validate(path, false, "", nil)
+	assert.Equal(t, len(errs), 3)
+	assert.Equal(t, errs[0].Field, "dummy.schedule.windows[1].start")
+	assert.Equal(t, errs[1].Field, "dummy.schedule.windows[1].duration")
+	assert.Equal(t, errs[2].Field, "dummy.schedule.timeZone")
+	errs = subject.Validate(path, true, "default", nil)
+	assert.Equal(t, errs[0].Field, "dummy.schedule")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeForbidden)
+}
+
+func Test_PolicySchedule_IsActive(t *testing.T) {
+	// working hours, from monday to friday
+	schedule := &PolicySchedule{
+		TimeZone: "Europe/Paris",
+		Windows: []ScheduleWindow{{
+			Start:    "0 8 * * 1-5",
+			Duration: metav1.Duration{Duration: 10 * time.Hour},
+		}},
+	}
+	paris, err := time.LoadLocation("Europe/Paris")
+	assert.NilError(t, err)
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{{
+		name: "window start",
+		now:  time.Date(2024, 1, 8, 8, 0, 0, 0, paris),
+		want: true,
+	}, {
+		name: "within window",
+		now:  time.Date(2024, 1, 10, 17, 59, 0, 0, paris),
+		want: true,
+	}, {
+		name: "window end",
+		now:  time.Date(2024, 1, 10, 18, 0, 0, 0, paris),
+		want: false,
+	}, {
+		name: "before window",
+		now:  time.Date(2024, 1, 10, 6, 59, 0, 0, time.UTC),
+		want: false,
+	}, {
+		name: "weekend",
+		now:  time.Date(2024, 1, 13, 12, 0, 0, 0, paris),
+		want: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, schedule.IsActive(tt.now), tt.want)
+		})
+	}
+	var none *PolicySchedule
+	assert.Equal(t, none.IsActive(time.Now()), true)
+}
+
+func Test_Validate_Ordering(t *testing.T) {
+	subject := Spec{
+		Ordering: &PolicyOrdering{
+			Before:          []string{"add-defaults", ""},
+			After:           []string{"add-defaults", "team-a/add-labels", "team-a/add-labels"},
+			ExclusionGroups: []string{"sidecars", "sidecars"},
+		},
+	}
+	path := field.NewPath("dummy")
+	errs := subject.Validate(path, false, "", nil)
+	assert.Equal(t, len(errs), 4)
+	assert.Equal(t, errs[0].Field, "dummy.ordering.before[1]")
+	assert.Equal(t, errs[1].Field, "dummy.ordering.after[0]")
+	assert.Equal(t, errs[2].Field, "dummy.ordering.after[2]")
+	assert.Equal(t, errs[2].Type, field.ErrorTypeDuplicate)
+	assert.Equal(t, errs[3].Field, "dummy.ordering.exclusionGroups[1]")
+}
+
+func Test_Validate_Parameters(t *testing.T) {
+	subject := Spec{
+		Parameters: &PolicyParameters{
+			ParamKind: ParamKind{APIVersion: "v1", Kind: "ConfigMap"},
+			ParamRef:  ParamRef{Name: "limits"},
+			Schema:    &apiextv1.JSON{Raw: []byte(`"string"`)},
+		},
+	}
+	path := field.NewPath("dummy")
+	errs := subject.Validate(path, false, "", nil)
+	assert.Equal(t, len(errs), 2)
+	assert.Equal(t, errs[0].Field, "dummy.parameters.paramRef.namespace")
+	assert.Equal(t, errs[1].Field, "dummy.parameters.schema")
+
+	subject.Parameters.ParamRef.Namespace = "other"
+	subject.Parameters.Schema = nil
+	errs = subject.Validate(path, true, "team", nil)
+	assert.Equal(t, len(errs), 1)
+	assert.Equal(t, errs[0].Field, "dummy.parameters.paramRef.namespace")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeForbidden)
+
+	subject.Parameters.ParamRef.Namespace = ""
+	errs = subject.Validate(path, true, "team", nil)
+	assert.Equal(t, len(errs), 0)
+	assert.Equal(t, subject.Parameters.GetNamespace("team"), "team")
+}
+
+func Test_Validate_ServiceAccountReference(t *testing.T) {
+	subject := Spec{ServiceAccount: &ServiceAccountReference{}}
+	path := field.NewPath("dummy")
+	errs := subject.Validate(path, false, "", nil)
+	assert.Equal(t, len(errs), 2)
+	assert.Equal(t, errs[0].Field, "dummy.serviceAccount.name")
+	assert.Equal(t, errs[1].Field, "dummy.serviceAccount.namespace")
+
+	subject.ServiceAccount = &ServiceAccountReference{Name: "generator", Namespace: "other"}
+	errs = subject.Validate(path, true, "team", nil)
+	assert.Equal(t, len(errs), 1)
+	assert.Equal(t, errs[0].Field, "dummy.serviceAccount.namespace")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeForbidden)
+
+	subject.ServiceAccount.Namespace = ""
+	errs = subject.Validate(path, true, "team", nil)
+	assert.Equal(t, len(errs), 0)
+	assert.Equal(t, subject.ServiceAccount.Username("team"), "system:serviceaccount:team:generator")
+}
+
+func Test_Validate_ServiceAccountReference_RemoteCluster(t *testing.T) {
+	subject := Spec{
+		ServiceAccount: &ServiceAccountReference{Name: "generator", Namespace: "tenants"},
+		Rules: []Rule{{
+			Name: "remote",
+			Generation: Generation{
+				RawData: &apiextv1.JSON{Raw: []byte(`{}`)},
+				Cluster: &ClusterReference{KubeconfigSecret: SecretReference{Namespace: "clusters", Name: "workload-kubeconfig"}},
+			},
+		}},
+	}
+	path := field.NewPath("dummy")
+	var forbidden []*field.Error
+	for _, err := range subject.Validate(path, false, "", nil) {
+		if err.Field == "dummy.rules[0].generate.cluster" && err.Type == field.ErrorTypeForbidden {
+			forbidden = append(forbidden, err)
+		}
+	}
+	assert.Equal(t, len(forbidden), 1)
+	assert.Equal(t, forbidden[0].Detail, "remote clusters cannot be targeted by policies with a service account")
+}
+
+func Test_GetReinvocationPolicy(t *testing.T) {
+	subject := Spec{Rules: []Rule{{Name: "verify", VerifyImages: []ImageVerification{{ImageReferences: []string{"*"}}}}}}
+	assert.Equal(t, subject.GetReinvocationPolicy(), IfNeededReinvocationPolicy)
+	subject.Rules = append(subject.Rules, Rule{Name: "mutate", Mutation: Mutation{PatchesJSON6902: "[]"}})
+	assert.Equal(t, subject.GetReinvocationPolicy(), IfNeededReinvocationPolicy)
+	never := NeverReinvocationPolicy
+	subject.ReinvocationPolicy = &never
+	assert.Equal(t, subject.GetReinvocationPolicy(), NeverReinvocationPolicy)
+}
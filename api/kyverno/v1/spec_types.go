@@ -3,8 +3,10 @@ package v1
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/kyverno/kyverno/pkg/toggle"
+	"github.com/robfig/cron"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -46,12 +48,120 @@ type ValidationFailureActionOverride struct {
 	NamespaceSelector *metav1.LabelSelector   `json:"namespaceSelector,omitempty" yaml:"namespaceSelector,omitempty"`
 }
 
+// PolicySchedule defines the time windows during which a policy is enforced.
+type PolicySchedule struct {
+	// Windows is the list of time windows during which the policy is enforced.
+	Windows []ScheduleWindow `json:"windows" yaml:"windows"`
+
+	// TimeZone is the IANA time zone name (e.g. "Europe/Paris") the windows are evaluated in.
+	// Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty" yaml:"timeZone,omitempty"`
+}
+
+// ScheduleWindow is a time window opening on a cron schedule for a given duration.
+type ScheduleWindow struct {
+	// Start is the cron expression, in the standard five fields format, at which the window opens.
+	Start string `json:"start" yaml:"start"`
+
+	// Duration is the time the window stays open after it opened (e.g. "48h").
+	Duration metav1.Duration `json:"duration" yaml:"duration"`
+}
+
+// IsActive returns true if now falls within one of the schedule windows, a nil schedule is always active.
+// Invalid windows never match. The schedule is parsed on every call, callers evaluating it repeatedly
+// should keep the CompiledSchedule returned by Compile.
+func (s *PolicySchedule) IsActive(now time.Time) bool {
+	if s == nil {
+		return true
+	}
+	schedule, _ := s.Compile()
+	return schedule.IsActive(now)
+}
+
+// Compile parses the windows and the time zone of the schedule, invalid windows are dropped.
+// When the time zone is invalid, the returned schedule is never active.
+func (s *PolicySchedule) Compile() (*CompiledSchedule, error) {
+	location, err := s.location()
+	if err != nil {
+		return &CompiledSchedule{}, err
+	}
+	compiled := &CompiledSchedule{location: location}
+	for _, window := range s.Windows {
+		schedule, err := cron.ParseStandard(window.Start)
+		if err != nil || window.Duration.Duration <= 0 {
+			continue
+		}
+		compiled.windows = append(compiled.windows, compiledWindow{schedule: schedule, duration: window.Duration.Duration})
+	}
+	return compiled, nil
+}
+
+func (s *PolicySchedule) location() (*time.Location, error) {
+	if s.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(s.TimeZone)
+}
+
+// CompiledSchedule is a PolicySchedule with its windows and time zone parsed.
+// +k8s:deepcopy-gen=false
+type CompiledSchedule struct {
+	location *time.Location
+	windows  []compiledWindow
+}
+
+type compiledWindow struct {
+	schedule cron.Schedule
+	duration time.Duration
+}
+
+// IsActive returns true if now falls within one of the schedule windows.
+func (s *CompiledSchedule) IsActive(now time.Time) bool {
+	if len(s.windows) == 0 {
+		return false
+	}
+	now = now.In(s.location)
+	for _, window := range s.windows {
+		// the window is open if it opened in the last window duration
+		if !window.schedule.Next(now.Add(-window.duration)).After(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate implements programmatic validation
+func (s *PolicySchedule) Validate(path *field.Path) (errs field.ErrorList) {
+	if len(s.Windows) == 0 {
+		errs = append(errs, field.Required(path.Child("windows"), "at least one window must be specified"))
+	}
+	for i, window := range s.Windows {
+		windowPath := path.Child("windows").Index(i)
+		if _, err := cron.ParseStandard(window.Start); err != nil {
+			errs = append(errs, field.Invalid(windowPath.Child("start"), window.Start, err.Error()))
+		}
+		if window.Duration.Duration <= 0 {
+			errs = append(errs, field.Invalid(windowPath.Child("duration"), window.Duration.Duration.String(), "the duration must be positive"))
+		}
+	}
+	if _, err := s.location(); err != nil {
+		errs = append(errs, field.Invalid(path.Child("timeZone"), s.TimeZone, err.Error()))
+	}
+	return errs
+}
+
 // Spec contains a list of Rule instances and other policy controls.
 type Spec struct {
 	// Rules is a list of Rule instances. A Policy contains multiple rules and
 	// each rule can validate, mutate, or generate resources.
 	Rules []Rule `json:"rules,omitempty" yaml:"rules,omitempty"`
 
+	// ConditionTemplates declares named groups of conditions that rules can reference from their
+	// preconditions and deny conditions with `templates`, instead of repeating the same conditions.
+	// +optional
+	ConditionTemplates []ConditionTemplate `json:"conditionTemplates,omitempty" yaml:"conditionTemplates,omitempty"`
+
 	// ApplyRules controls how rules in a policy are applied. Rule are processed in
 	// the order of declaration. When set to `One` processing stops after a rule has
 	// been applied i.e. the rule matches and results in a pass, fail, or error. When
@@ -66,6 +176,14 @@ type Spec struct {
 	// +optional
 	FailurePolicy *FailurePolicyType `json:"failurePolicy,omitempty" yaml:"failurePolicy,omitempty"`
 
+	// ReinvocationPolicy defines if the mutating webhook applying the policy is called again when other
+	// admission plugins modify the object. Patches already applied are skipped when the policy is reinvoked,
+	// and non idempotent JSON patch operations are rejected when IfNeeded is set explicitly.
+	// This field should not be accessed directly, instead `GetReinvocationPolicy()` should be used.
+	// Allowed values are Never or IfNeeded. Defaults to IfNeeded.
+	// +optional
+	ReinvocationPolicy *ReinvocationPolicyType `json:"reinvocationPolicy,omitempty" yaml:"reinvocationPolicy,omitempty"`
+
 	// ValidationFailureAction defines if a validation policy rule violation should block
 	// the admission review request (enforce), or allow (audit) the admission review request
 	// and report an error in a policy report. Optional.
@@ -80,6 +198,33 @@ type Spec struct {
 	// +optional
 	ValidationFailureActionOverrides []ValidationFailureActionOverride `json:"validationFailureActionOverrides,omitempty" yaml:"validationFailureActionOverrides,omitempty"`
 
+	// Schedule restricts the enforcement of the policy to time windows, outside of the windows validation
+	// failures are reported as if ValidationFailureAction was Audit. Schedule is a Cluster Policy attribute.
+	// +optional
+	Schedule *PolicySchedule `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+
+	// EmitWarning enables admission response warnings for the validation failures of the policy
+	// when it runs in Audit mode, it lets users see failures at apply time without being blocked.
+	// Optional. Default value is "false".
+	// +optional
+	EmitWarning *bool `json:"emitWarning,omitempty" yaml:"emitWarning,omitempty"`
+
+	// Ordering declares the order in which the policy is applied relative to other policies and the
+	// policies it is mutually exclusive with. Policies without ordering constraints are applied in name order.
+	// +optional
+	Ordering *PolicyOrdering `json:"ordering,omitempty" yaml:"ordering,omitempty"`
+
+	// Parameters references the resource holding the parameters of the policy, available to its rules
+	// in the `params` variable.
+	// +optional
+	Parameters *PolicyParameters `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+
+	// ServiceAccount is impersonated by the background controller when it applies the generate and mutate
+	// existing rules of the policy, the permissions to manage the targets are granted to the ServiceAccount
+	// instead of the background controller. The background controller must be allowed to impersonate it.
+	// +optional
+	ServiceAccount *ServiceAccountReference `json:"serviceAccount,omitempty" yaml:"serviceAccount,omitempty"`
+
 	// Admission controls if rules are applied during admission.
 	// Optional. Default value is "true".
 	// +optional
@@ -214,6 +359,11 @@ func (s *Spec) BackgroundProcessingEnabled() bool {
 	return *s.Background
 }
 
+// EmitWarningEnabled checks if emitWarning is set to true
+func (s *Spec) EmitWarningEnabled() bool {
+	return s.EmitWarning != nil && *s.EmitWarning
+}
+
 // IsMutateExisting checks if the mutate policy applies to existing resources
 func (s *Spec) IsMutateExisting() bool {
 	for _, rule := range s.Rules {
@@ -238,8 +388,9 @@ func (s *Spec) IsGenerateExisting() bool {
 }
 
 // GetFailurePolicy returns the failure policy to be applied
+// In shadow mode the failure policy is always Ignore, an unavailable Kyverno must not block requests.
 func (s *Spec) GetFailurePolicy(ctx context.Context) FailurePolicyType {
-	if toggle.FromContext(ctx).ForceFailurePolicyIgnore() {
+	if toggle.FromContext(ctx).ForceFailurePolicyIgnore() || toggle.FromContext(ctx).ShadowMode() {
 		return Ignore
 	} else if s.FailurePolicy == nil {
 		return Fail
@@ -247,6 +398,14 @@ func (s *Spec) GetFailurePolicy(ctx context.Context) FailurePolicyType {
 	return *s.FailurePolicy
 }
 
+// GetReinvocationPolicy returns the reinvocation policy of the mutating webhook applying the policy
+func (s *Spec) GetReinvocationPolicy() ReinvocationPolicyType {
+	if s.ReinvocationPolicy == nil {
+		return IfNeededReinvocationPolicy
+	}
+	return *s.ReinvocationPolicy
+}
+
 // GetFailurePolicy returns the failure policy to be applied
 func (s *Spec) GetApplyRules() ApplyRulesType {
 	if s.ApplyRules == nil {
@@ -278,6 +437,21 @@ func (s *Spec) ValidateRules(path *field.Path, namespaced bool, policyNamespace
 	return errs
 }
 
+// ValidateConditionTemplates checks if the condition template names are unique across a policy
+func (s *Spec) ValidateConditionTemplates(path *field.Path) (errs field.ErrorList) {
+	names := sets.New[string]()
+	for i, template := range s.ConditionTemplates {
+		templatePath := path.Index(i)
+		if template.Name == "" {
+			errs = append(errs, field.Required(templatePath.Child("name"), "condition template name is required"))
+		} else if names.Has(template.Name) {
+			errs = append(errs, field.Invalid(templatePath.Child("name"), template.Name, fmt.Sprintf(`Duplicate condition template name: '%s'`, template.Name)))
+		}
+		names.Insert(template.Name)
+	}
+	return errs
+}
+
 func (s *Spec) validateDeprecatedFields(path *field.Path) (errs field.ErrorList) {
 	if s.GenerateExistingOnPolicyUpdate != nil && s.GenerateExisting {
 		errs = append(errs, field.Forbidden(path.Child("generateExistingOnPolicyUpdate"), "remove the deprecated field and use generateExisting instead"))
@@ -310,9 +484,31 @@ func (s *Spec) Validate(path *field.Path, namespaced bool, policyNamespace strin
 	if s.WebhookTimeoutSeconds != nil && (*s.WebhookTimeoutSeconds < 1 || *s.WebhookTimeoutSeconds > 30) {
 		errs = append(errs, field.Invalid(path.Child("webhookTimeoutSeconds"), s.WebhookTimeoutSeconds, "the timeout value must be between 1 and 30 seconds"))
 	}
+	errs = append(errs, s.ValidateConditionTemplates(path.Child("conditionTemplates"))...)
 	errs = append(errs, s.ValidateRules(path.Child("rules"), namespaced, policyNamespace, clusterResources)...)
 	if namespaced && len(s.ValidationFailureActionOverrides) > 0 {
 		errs = append(errs, field.Forbidden(path.Child("validationFailureActionOverrides"), "Use of validationFailureActionOverrides is supported only with ClusterPolicy"))
 	}
+	if s.Schedule != nil {
+		if namespaced {
+			errs = append(errs, field.Forbidden(path.Child("schedule"), "Use of schedule is supported only with ClusterPolicy"))
+		}
+		errs = append(errs, s.Schedule.Validate(path.Child("schedule"))...)
+	}
+	if s.Ordering != nil {
+		errs = append(errs, s.Ordering.Validate(path.Child("ordering"))...)
+	}
+	if s.Parameters != nil {
+		errs = append(errs, s.Parameters.Validate(path.Child("parameters"), namespaced, policyNamespace)...)
+	}
+	if s.ServiceAccount != nil {
+		errs = append(errs, s.ServiceAccount.Validate(path.Child("serviceAccount"), namespaced, policyNamespace)...)
+		// remote clusters are managed with the credentials of their kubeconfig, the service account can't be impersonated there
+		for i, rule := range s.Rules {
+			if rule.Generation.Cluster != nil {
+				errs = append(errs, field.Forbidden(path.Child("rules").Index(i).Child("generate").Child("cluster"), "remote clusters cannot be targeted by policies with a service account"))
+			}
+		}
+	}
 	return errs
 }
@@ -75,6 +75,11 @@ func (in *AnyAllConditions) DeepCopyInto(out *AnyAllConditions) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -105,6 +110,11 @@ func (in *Attestation) DeepCopyInto(out *Attestation) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ClusterMetadata != nil {
+		in, out := &in.ClusterMetadata, &out.ClusterMetadata
+		*out = new(AttestationClusterMetadata)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -118,6 +128,29 @@ func (in *Attestation) DeepCopy() *Attestation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AttestationClusterMetadata) DeepCopyInto(out *AttestationClusterMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttestationClusterMetadata.
+func (in *AttestationClusterMetadata) DeepCopy() *AttestationClusterMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(AttestationClusterMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Attestor) DeepCopyInto(out *Attestor) {
 	*out = *in
@@ -400,6 +433,23 @@ func (in *ClusterPolicyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterReference) DeepCopyInto(out *ClusterReference) {
+	*out = *in
+	out.KubeconfigSecret = in.KubeconfigSecret
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReference.
+func (in *ClusterReference) DeepCopy() *ClusterReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
@@ -426,6 +476,23 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionTemplate) DeepCopyInto(out *ConditionTemplate) {
+	*out = *in
+	in.Conditions.DeepCopyInto(&out.Conditions)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionTemplate.
+func (in *ConditionTemplate) DeepCopy() *ConditionTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigMapReference) DeepCopyInto(out *ConfigMapReference) {
 	*out = *in
@@ -465,6 +532,11 @@ func (in *ContextEntry) DeepCopyInto(out *ContextEntry) {
 		*out = new(Variable)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RelatedResources != nil {
+		in, out := &in.RelatedResources, &out.RelatedResources
+		*out = new(RelatedResources)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -486,6 +558,11 @@ func (in *Deny) DeepCopyInto(out *Deny) {
 		*out = new(apiextensionsv1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Violations != nil {
+		in, out := &in.Violations, &out.Violations
+		*out = new(DenyViolations)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -499,6 +576,27 @@ func (in *Deny) DeepCopy() *Deny {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DenyViolations) DeepCopyInto(out *DenyViolations) {
+	*out = *in
+	if in.RawAnyAllConditions != nil {
+		in, out := &in.RawAnyAllConditions, &out.RawAnyAllConditions
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DenyViolations.
+func (in *DenyViolations) DeepCopy() *DenyViolations {
+	if in == nil {
+		return nil
+	}
+	out := new(DenyViolations)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DryRunOption) DeepCopyInto(out *DryRunOption) {
 	*out = *in
@@ -622,6 +720,11 @@ func (in *Generation) DeepCopyInto(out *Generation) {
 	}
 	out.Clone = in.Clone
 	in.CloneList.DeepCopyInto(&out.CloneList)
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(ClusterReference)
+		**out = **in
+	}
 	return
 }
 
@@ -961,6 +1064,69 @@ func (in *ObjectFieldBinding) DeepCopy() *ObjectFieldBinding {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OwnerDescription) DeepCopyInto(out *OwnerDescription) {
+	*out = *in
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OwnerDescription.
+func (in *OwnerDescription) DeepCopy() *OwnerDescription {
+	if in == nil {
+		return nil
+	}
+	out := new(OwnerDescription)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParamKind) DeepCopyInto(out *ParamKind) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParamKind.
+func (in *ParamKind) DeepCopy() *ParamKind {
+	if in == nil {
+		return nil
+	}
+	out := new(ParamKind)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParamRef) DeepCopyInto(out *ParamRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParamRef.
+func (in *ParamRef) DeepCopy() *ParamRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ParamRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSecurity) DeepCopyInto(out *PodSecurity) {
 	*out = *in
@@ -1066,6 +1232,81 @@ func (in *PolicyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyOrdering) DeepCopyInto(out *PolicyOrdering) {
+	*out = *in
+	if in.Before != nil {
+		in, out := &in.Before, &out.Before
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.After != nil {
+		in, out := &in.After, &out.After
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExclusionGroups != nil {
+		in, out := &in.ExclusionGroups, &out.ExclusionGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyOrdering.
+func (in *PolicyOrdering) DeepCopy() *PolicyOrdering {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyOrdering)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyParameters) DeepCopyInto(out *PolicyParameters) {
+	*out = *in
+	out.ParamKind = in.ParamKind
+	out.ParamRef = in.ParamRef
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyParameters.
+func (in *PolicyParameters) DeepCopy() *PolicyParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicySchedule) DeepCopyInto(out *PolicySchedule) {
+	*out = *in
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]ScheduleWindow, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicySchedule.
+func (in *PolicySchedule) DeepCopy() *PolicySchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicySchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicyStatus) DeepCopyInto(out *PolicyStatus) {
 	*out = *in
@@ -1079,6 +1320,13 @@ func (in *PolicyStatus) DeepCopyInto(out *PolicyStatus) {
 	in.Autogen.DeepCopyInto(&out.Autogen)
 	out.RuleCount = in.RuleCount
 	out.ValidatingAdmissionPolicy = in.ValidatingAdmissionPolicy
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]RuleStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1092,6 +1340,48 @@ func (in *PolicyStatus) DeepCopy() *PolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Prefetch) DeepCopyInto(out *Prefetch) {
+	*out = *in
+	if in.ConfigMaps != nil {
+		in, out := &in.ConfigMaps, &out.ConfigMaps
+		*out = make([]ConfigMapReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]PrefetchResource, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Prefetch.
+func (in *Prefetch) DeepCopy() *Prefetch {
+	if in == nil {
+		return nil
+	}
+	out := new(Prefetch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrefetchResource) DeepCopyInto(out *PrefetchResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrefetchResource.
+func (in *PrefetchResource) DeepCopy() *PrefetchResource {
+	if in == nil {
+		return nil
+	}
+	out := new(PrefetchResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rekor) DeepCopyInto(out *Rekor) {
 	*out = *in
@@ -1108,6 +1398,27 @@ func (in *Rekor) DeepCopy() *Rekor {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RelatedResources) DeepCopyInto(out *RelatedResources) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelatedResources.
+func (in *RelatedResources) DeepCopy() *RelatedResources {
+	if in == nil {
+		return nil
+	}
+	out := new(RelatedResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequestData) DeepCopyInto(out *RequestData) {
 	*out = *in
@@ -1164,6 +1475,11 @@ func (in *ResourceDescription) DeepCopyInto(out *ResourceDescription) {
 		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Owner != nil {
+		in, out := &in.Owner, &out.Owner
+		*out = new(OwnerDescription)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Operations != nil {
 		in, out := &in.Operations, &out.Operations
 		*out = make([]AdmissionOperation, len(*in))
@@ -1248,6 +1564,11 @@ func (in *Rule) DeepCopyInto(out *Rule) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Prefetch != nil {
+		in, out := &in.Prefetch, &out.Prefetch
+		*out = new(Prefetch)
+		(*in).DeepCopyInto(*out)
+	}
 	in.MatchResources.DeepCopyInto(&out.MatchResources)
 	in.ExcludeResources.DeepCopyInto(&out.ExcludeResources)
 	if in.ImageExtractors != nil {
@@ -1285,6 +1606,21 @@ func (in *Rule) DeepCopyInto(out *Rule) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TimeoutFailurePolicy != nil {
+		in, out := &in.TimeoutFailurePolicy, &out.TimeoutFailurePolicy
+		*out = new(FailurePolicyType)
+		**out = **in
+	}
+	if in.Autogen != nil {
+		in, out := &in.Autogen, &out.Autogen
+		*out = new(RuleAutogen)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1298,6 +1634,32 @@ func (in *Rule) DeepCopy() *Rule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleAutogen) DeepCopyInto(out *RuleAutogen) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Controllers != nil {
+		in, out := &in.Controllers, &out.Controllers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleAutogen.
+func (in *RuleAutogen) DeepCopy() *RuleAutogen {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleAutogen)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RuleCountStatus) DeepCopyInto(out *RuleCountStatus) {
 	*out = *in
@@ -1314,6 +1676,51 @@ func (in *RuleCountStatus) DeepCopy() *RuleCountStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleStatus) DeepCopyInto(out *RuleStatus) {
+	*out = *in
+	if in.AutogenRules != nil {
+		in, out := &in.AutogenRules, &out.AutogenRules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleStatus.
+func (in *RuleStatus) DeepCopy() *RuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleWindow) DeepCopyInto(out *ScheduleWindow) {
+	*out = *in
+	out.Duration = in.Duration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleWindow.
+func (in *ScheduleWindow) DeepCopy() *ScheduleWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretReference) DeepCopyInto(out *SecretReference) {
 	*out = *in
@@ -1330,6 +1737,22 @@ func (in *SecretReference) DeepCopy() *SecretReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountReference) DeepCopyInto(out *ServiceAccountReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountReference.
+func (in *ServiceAccountReference) DeepCopy() *ServiceAccountReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceCall) DeepCopyInto(out *ServiceCall) {
 	*out = *in
@@ -1356,6 +1779,13 @@ func (in *Spec) DeepCopyInto(out *Spec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ConditionTemplates != nil {
+		in, out := &in.ConditionTemplates, &out.ConditionTemplates
+		*out = make([]ConditionTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ApplyRules != nil {
 		in, out := &in.ApplyRules, &out.ApplyRules
 		*out = new(ApplyRulesType)
@@ -1366,6 +1796,11 @@ func (in *Spec) DeepCopyInto(out *Spec) {
 		*out = new(FailurePolicyType)
 		**out = **in
 	}
+	if in.ReinvocationPolicy != nil {
+		in, out := &in.ReinvocationPolicy, &out.ReinvocationPolicy
+		*out = new(ReinvocationPolicyType)
+		**out = **in
+	}
 	if in.ValidationFailureActionOverrides != nil {
 		in, out := &in.ValidationFailureActionOverrides, &out.ValidationFailureActionOverrides
 		*out = make([]ValidationFailureActionOverride, len(*in))
@@ -1373,6 +1808,31 @@ func (in *Spec) DeepCopyInto(out *Spec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(PolicySchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EmitWarning != nil {
+		in, out := &in.EmitWarning, &out.EmitWarning
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Ordering != nil {
+		in, out := &in.Ordering, &out.Ordering
+		*out = new(PolicyOrdering)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = new(PolicyParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(ServiceAccountReference)
+		**out = **in
+	}
 	if in.Admission != nil {
 		in, out := &in.Admission, &out.Admission
 		*out = new(bool)
@@ -1558,6 +2018,11 @@ func (in *Validation) DeepCopyInto(out *Validation) {
 		*out = new(CEL)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WASM != nil {
+		in, out := &in.WASM, &out.WASM
+		*out = new(WASM)
+		**out = **in
+	}
 	return
 }
 
@@ -1622,3 +2087,19 @@ func (in *Variable) DeepCopy() *Variable {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WASM) DeepCopyInto(out *WASM) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WASM.
+func (in *WASM) DeepCopy() *WASM {
+	if in == nil {
+		return nil
+	}
+	out := new(WASM)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -39,9 +39,29 @@ type UpdateRequestStatus struct {
 	// Will be used during clean up resources.
 	GeneratedResources []kyvernov1.ResourceSpec `json:"generatedResources,omitempty" yaml:"generatedResources,omitempty"`
 
+	// ClusterTargets reports the state of the resources generated in remote clusters.
+	// +optional
+	ClusterTargets []ClusterTargetStatus `json:"clusterTargets,omitempty" yaml:"clusterTargets,omitempty"`
+
 	RetryCount int `json:"retryCount,omitempty" yaml:"retryCount,omitempty"`
 }
 
+// ClusterTargetStatus reports the state of a resource generated in a remote cluster.
+type ClusterTargetStatus struct {
+	// Cluster references the kubeconfig of the remote cluster.
+	Cluster kyvernov1.ClusterReference `json:"cluster" yaml:"cluster"`
+
+	// Resource is the resource generated in the remote cluster.
+	Resource kyvernov1.ResourceSpec `json:"resource" yaml:"resource"`
+
+	// State represents the state of the generated resource.
+	State UpdateRequestState `json:"state" yaml:"state"`
+
+	// Message reports why the resource could not be generated.
+	// +optional
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:object:root=true
@@ -97,6 +117,11 @@ type UpdateRequestSpec struct {
 	// ResourceSpec is the information to identify the trigger resource.
 	Resource kyvernov1.ResourceSpec `json:"resource" yaml:"resource"`
 
+	// ServiceAccount is the service account of the policy, the downstream resources
+	// of a deleted policy are deleted on its behalf.
+	// +optional
+	ServiceAccount *kyvernov1.ServiceAccountReference `json:"serviceAccount,omitempty" yaml:"serviceAccount,omitempty"`
+
 	// Context ...
 	Context UpdateRequestSpecContext `json:"context" yaml:"context"`
 }
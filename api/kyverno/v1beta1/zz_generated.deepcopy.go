@@ -48,6 +48,24 @@ func (in *AdmissionRequestInfoObject) DeepCopy() *AdmissionRequestInfoObject {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTargetStatus) DeepCopyInto(out *ClusterTargetStatus) {
+	*out = *in
+	out.Cluster = in.Cluster
+	out.Resource = in.Resource
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTargetStatus.
+func (in *ClusterTargetStatus) DeepCopy() *ClusterTargetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTargetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequestInfo) DeepCopyInto(out *RequestInfo) {
 	*out = *in
@@ -140,6 +158,11 @@ func (in *UpdateRequestList) DeepCopyObject() runtime.Object {
 func (in *UpdateRequestSpec) DeepCopyInto(out *UpdateRequestSpec) {
 	*out = *in
 	out.Resource = in.Resource
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(kyvernov1.ServiceAccountReference)
+		**out = **in
+	}
 	in.Context.DeepCopyInto(&out.Context)
 	return
 }
@@ -180,6 +203,11 @@ func (in *UpdateRequestStatus) DeepCopyInto(out *UpdateRequestStatus) {
 		*out = make([]kyvernov1.ResourceSpec, len(*in))
 		copy(*out, *in)
 	}
+	if in.ClusterTargets != nil {
+		in, out := &in.ClusterTargets, &out.ClusterTargets
+		*out = make([]ClusterTargetStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -0,0 +1,145 @@
+package v2
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_PolicyException_GetExpirationTime(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := metav1.NewTime(created.Add(48 * time.Hour))
+	tests := []struct {
+		name    string
+		spec    PolicyExceptionSpec
+		want    *time.Time
+		wantErr bool
+	}{{
+		name: "no expiry",
+	}, {
+		name: "expiresAt",
+		spec: PolicyExceptionSpec{ExpiresAt: &expiresAt},
+		want: &expiresAt.Time,
+	}, {
+		name: "ttl",
+		spec: PolicyExceptionSpec{TTL: "1d"},
+		want: ptr(created.Add(24 * time.Hour)),
+	}, {
+		name: "earliest of expiresAt and ttl",
+		spec: PolicyExceptionSpec{ExpiresAt: &expiresAt, TTL: "72h"},
+		want: &expiresAt.Time,
+	}, {
+		name:    "invalid ttl",
+		spec:    PolicyExceptionSpec{TTL: "tomorrow"},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polex := PolicyException{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+				Spec:       tt.spec,
+			}
+			got, err := polex.GetExpirationTime()
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				assert.Assert(t, !polex.IsExpired(created.Add(time.Hour*24*365)))
+				return
+			}
+			assert.NilError(t, err)
+			if tt.want == nil {
+				assert.Assert(t, got == nil)
+				assert.Assert(t, !polex.IsExpired(created.Add(time.Hour*24*365)))
+				return
+			}
+			assert.Assert(t, got != nil)
+			assert.Assert(t, got.Equal(*tt.want))
+			assert.Assert(t, !polex.IsExpired(tt.want.Add(-time.Second)))
+			assert.Assert(t, polex.IsExpired(*tt.want))
+		})
+	}
+}
+
+func Test_PolicyExceptionSpec_ValidateExpiry(t *testing.T) {
+	expiresAt := metav1.Now()
+	tests := []struct {
+		name string
+		spec PolicyExceptionSpec
+		want []string
+	}{{
+		name: "valid ttl",
+		spec: PolicyExceptionSpec{TTL: "2w", DeleteOnExpiry: true},
+	}, {
+		name: "valid expiresAt",
+		spec: PolicyExceptionSpec{ExpiresAt: &expiresAt, DeleteOnExpiry: true},
+	}, {
+		name: "invalid ttl",
+		spec: PolicyExceptionSpec{TTL: "tomorrow"},
+		want: []string{"spec.ttl"},
+	}, {
+		name: "negative ttl",
+		spec: PolicyExceptionSpec{TTL: "-1h"},
+		want: []string{"spec.ttl"},
+	}, {
+		name: "deleteOnExpiry without expiry",
+		spec: PolicyExceptionSpec{DeleteOnExpiry: true},
+		want: []string{"spec.deleteOnExpiry"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polex := PolicyException{Spec: tt.spec}
+			var got []string
+			for _, err := range polex.Validate() {
+				if err.Field == "spec.ttl" || err.Field == "spec.deleteOnExpiry" {
+					got = append(got, err.Field)
+				}
+			}
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+}
+
+func Test_PolicyExceptionStatus_SetActive(t *testing.T) {
+	var status PolicyExceptionStatus
+	status.SetActive(true, "exception expires soon")
+	assert.Assert(t, !status.IsExpired())
+	status.SetActive(false, "exception expired")
+	assert.Assert(t, status.IsExpired())
+	assert.Equal(t, len(status.Conditions), 1)
+	assert.Equal(t, status.Conditions[0].Reason, PolicyExceptionReasonExpired)
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
+
+func Test_PolicyException_IsApproved(t *testing.T) {
+	polex := PolicyException{ObjectMeta: metav1.ObjectMeta{Generation: 1}}
+	assert.Assert(t, !polex.IsApproved())
+	assert.Assert(t, !polex.HasApprovalCondition())
+	polex.Status.SetPending(1)
+	assert.Assert(t, !polex.IsApproved())
+	assert.Assert(t, polex.HasApprovalCondition())
+	polex.Status.Conditions[0].Status = metav1.ConditionTrue
+	assert.Assert(t, polex.IsApproved())
+	// changing the spec requires a new approval
+	polex.Generation = 2
+	assert.Assert(t, !polex.IsApproved())
+	assert.Assert(t, !polex.HasApprovalCondition())
+}
+
+func Test_PolicyExceptionStatus_RecordUsage(t *testing.T) {
+	var status PolicyExceptionStatus
+	assert.Assert(t, status.GetLastUsed() == nil)
+	first := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	second := metav1.NewTime(first.Add(time.Hour))
+	status.RecordUsage("require-labels", "check-team", 2, second)
+	status.RecordUsage("require-labels", "check-team", 3, first)
+	status.RecordUsage("default/disallow-latest", "check-tag", 1, first)
+	assert.Equal(t, len(status.Usage), 2)
+	assert.Equal(t, status.Usage[0].Count, int64(5))
+	// the last use time is never moved backward
+	assert.Assert(t, status.Usage[0].LastUsed.Equal(&second))
+	assert.Assert(t, status.GetLastUsed().Equal(&second))
+}
@@ -16,10 +16,15 @@ limitations under the License.
 package v2
 
 import (
+	"time"
+
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 	"github.com/kyverno/kyverno/ext/wildcard"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
 )
 
 // +genclient
@@ -27,6 +32,7 @@ import (
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:resource:shortName=polex,categories=kyverno
 // +kubebuilder:storageversion
+// +kubebuilder:subresource:status
 
 // PolicyException declares resources to be excluded from specified policies.
 type PolicyException struct {
@@ -35,6 +41,10 @@ type PolicyException struct {
 
 	// Spec declares policy exception behaviors.
 	Spec PolicyExceptionSpec `json:"spec" yaml:"spec"`
+
+	// Status contains policy exception runtime data.
+	// +optional
+	Status PolicyExceptionStatus `json:"status,omitempty" yaml:"status,omitempty"`
 }
 
 // Validate implements programmatic validation
@@ -48,6 +58,48 @@ func (p *PolicyException) Contains(policy string, rule string) bool {
 	return p.Spec.Contains(policy, rule)
 }
 
+// GetExpirationTime returns the time after which the exception no longer applies, nil if it doesn't expire
+func (p *PolicyException) GetExpirationTime() (*time.Time, error) {
+	var expiration *time.Time
+	if p.Spec.ExpiresAt != nil {
+		expiresAt := p.Spec.ExpiresAt.Time
+		expiration = &expiresAt
+	}
+	if p.Spec.TTL != "" {
+		ttl, err := strfmt.ParseDuration(p.Spec.TTL)
+		if err != nil {
+			return nil, err
+		}
+		expiresAt := p.GetCreationTimestamp().Add(ttl)
+		if expiration == nil || expiresAt.Before(*expiration) {
+			expiration = &expiresAt
+		}
+	}
+	return expiration, nil
+}
+
+// IsExpired returns true if the exception expired at the given time, exceptions with an invalid ttl don't expire
+func (p *PolicyException) IsExpired(now time.Time) bool {
+	expiration, err := p.GetExpirationTime()
+	if err != nil || expiration == nil {
+		return false
+	}
+	return !now.Before(*expiration)
+}
+
+// IsApproved returns true if the current generation of the exception was approved,
+// changing the spec of an approved exception requires a new approval
+func (p *PolicyException) IsApproved() bool {
+	condition := meta.FindStatusCondition(p.Status.Conditions, PolicyExceptionConditionApproved)
+	return condition != nil && condition.Status == metav1.ConditionTrue && condition.ObservedGeneration == p.GetGeneration()
+}
+
+// HasApprovalCondition returns true if the approval condition was set for the current generation of the exception
+func (p *PolicyException) HasApprovalCondition() bool {
+	condition := meta.FindStatusCondition(p.Status.Conditions, PolicyExceptionConditionApproved)
+	return condition != nil && condition.ObservedGeneration == p.GetGeneration()
+}
+
 // PolicyExceptionSpec stores policy exception spec
 type PolicyExceptionSpec struct {
 	// Background controls if exceptions are applied to existing policies during a background scan.
@@ -63,8 +115,34 @@ type PolicyExceptionSpec struct {
 	// +optional
 	Conditions *kyvernov2beta1.AnyAllConditions `json:"conditions,omitempty"`
 
+	// Images restricts the exception to resources whose images all match one of the image reference
+	// patterns, for example `ghcr.io/example/controller:*`. Wildcards are supported.
+	// Resources without images don't match when images are set.
+	// +optional
+	Images []string `json:"images,omitempty" yaml:"images,omitempty"`
+
+	// NamespaceSelector restricts the exception to resources in namespaces matching the label selector,
+	// so that a class of namespaces can be exempted with a single exception. Namespaces are matched
+	// against their own labels and other cluster scoped resources don't match when it is set.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty" yaml:"namespaceSelector,omitempty"`
+
 	// Exceptions is a list policy/rules to be excluded
 	Exceptions []Exception `json:"exceptions" yaml:"exceptions"`
+
+	// ExpiresAt is the time after which the exception no longer applies.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty" yaml:"expiresAt,omitempty"`
+
+	// TTL is the time to live of the exception, computed from its creation.
+	// Days (d) and weeks (w) are supported in addition to the Go duration units, for example 30d.
+	// When both expiresAt and ttl are set the earliest expiration applies.
+	// +optional
+	TTL string `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+
+	// DeleteOnExpiry deletes the exception once it expired, expired exceptions are only marked inactive otherwise.
+	// +optional
+	DeleteOnExpiry bool `json:"deleteOnExpiry,omitempty" yaml:"deleteOnExpiry,omitempty"`
 }
 
 func (p *PolicyExceptionSpec) BackgroundProcessingEnabled() bool {
@@ -82,10 +160,31 @@ func (p *PolicyExceptionSpec) Validate(path *field.Path) (errs field.ErrorList)
 		}
 	}
 	errs = append(errs, p.Match.Validate(path.Child("match"), false, nil)...)
+	errs = append(errs, p.Conditions.ValidateNoTemplates(path.Child("conditions"))...)
+	for i, image := range p.Images {
+		if image == "" {
+			errs = append(errs, field.Invalid(path.Child("images").Index(i), image, "image reference pattern must not be empty"))
+		}
+	}
+	if p.NamespaceSelector != nil && !kubeutils.LabelSelectorContainsWildcard(p.NamespaceSelector) {
+		if _, err := metav1.LabelSelectorAsSelector(p.NamespaceSelector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("namespaceSelector"), p.NamespaceSelector, err.Error()))
+		}
+	}
 	exceptionsPath := path.Child("exceptions")
 	for i, e := range p.Exceptions {
 		errs = append(errs, e.Validate(exceptionsPath.Index(i))...)
 	}
+	if p.TTL != "" {
+		if ttl, err := strfmt.ParseDuration(p.TTL); err != nil {
+			errs = append(errs, field.Invalid(path.Child("ttl"), p.TTL, err.Error()))
+		} else if ttl <= 0 {
+			errs = append(errs, field.Invalid(path.Child("ttl"), p.TTL, "ttl must be positive"))
+		}
+	}
+	if p.DeleteOnExpiry && p.ExpiresAt == nil && p.TTL == "" {
+		errs = append(errs, field.Invalid(path.Child("deleteOnExpiry"), p.DeleteOnExpiry, "deleteOnExpiry requires expiresAt or ttl"))
+	}
 	return errs
 }
 
@@ -99,6 +198,112 @@ func (p *PolicyExceptionSpec) Contains(policy string, rule string) bool {
 	return false
 }
 
+const (
+	// PolicyExceptionConditionActive reports whether the exception still applies
+	PolicyExceptionConditionActive = "Active"
+	// PolicyExceptionReasonValid is used when the exception has not expired
+	PolicyExceptionReasonValid = "Valid"
+	// PolicyExceptionReasonExpired is used when the exception expired
+	PolicyExceptionReasonExpired = "Expired"
+	// PolicyExceptionConditionApproved reports whether the exception was approved, it is only used
+	// when the approval workflow is enabled in the Kyverno configuration
+	PolicyExceptionConditionApproved = "Approved"
+	// PolicyExceptionReasonPending is used when the exception is waiting for approval
+	PolicyExceptionReasonPending = "Pending"
+)
+
+// PolicyExceptionStatus stores the status of the exception.
+type PolicyExceptionStatus struct {
+	// Conditions reports whether the exception is active, expired exceptions no longer apply.
+	// When the approval workflow is enabled, the Approved condition reports whether the current
+	// generation of the exception was approved, pending or rejected exceptions don't apply.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+
+	// Usage records how often the exception skipped each policy rule.
+	// +optional
+	Usage []PolicyExceptionUsage `json:"usage,omitempty" yaml:"usage,omitempty"`
+}
+
+// PolicyExceptionUsage records how often an exception skipped a policy rule.
+type PolicyExceptionUsage struct {
+	// PolicyName identifies the policy, it uses the format <namespace>/<name> unless it
+	// references a ClusterPolicy.
+	PolicyName string `json:"policyName" yaml:"policyName"`
+
+	// RuleName identifies the rule.
+	RuleName string `json:"ruleName" yaml:"ruleName"`
+
+	// Count is the number of times the exception skipped the rule.
+	Count int64 `json:"count" yaml:"count"`
+
+	// LastUsed is the last time the exception skipped the rule.
+	LastUsed metav1.Time `json:"lastUsed" yaml:"lastUsed"`
+}
+
+func (status *PolicyExceptionStatus) SetActive(active bool, message string) {
+	condition := metav1.Condition{
+		Type:    PolicyExceptionConditionActive,
+		Message: message,
+	}
+	if active {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = PolicyExceptionReasonValid
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = PolicyExceptionReasonExpired
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// SetPending marks the given generation of the exception as waiting for approval
+func (status *PolicyExceptionStatus) SetPending(generation int64) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               PolicyExceptionConditionApproved,
+		Status:             metav1.ConditionFalse,
+		Reason:             PolicyExceptionReasonPending,
+		Message:            "exception is waiting for approval",
+		ObservedGeneration: generation,
+	})
+}
+
+// RecordUsage adds count uses of the exception for the policy rule, the last use time is only moved forward
+func (status *PolicyExceptionStatus) RecordUsage(policy, rule string, count int64, lastUsed metav1.Time) {
+	for i := range status.Usage {
+		usage := &status.Usage[i]
+		if usage.PolicyName == policy && usage.RuleName == rule {
+			usage.Count += count
+			if usage.LastUsed.Before(&lastUsed) {
+				usage.LastUsed = lastUsed
+			}
+			return
+		}
+	}
+	status.Usage = append(status.Usage, PolicyExceptionUsage{
+		PolicyName: policy,
+		RuleName:   rule,
+		Count:      count,
+		LastUsed:   lastUsed,
+	})
+}
+
+// GetLastUsed returns the last time the exception skipped a rule, nil if it was never used
+func (status *PolicyExceptionStatus) GetLastUsed() *metav1.Time {
+	var lastUsed *metav1.Time
+	for i := range status.Usage {
+		if lastUsed == nil || lastUsed.Before(&status.Usage[i].LastUsed) {
+			lastUsed = &status.Usage[i].LastUsed
+		}
+	}
+	return lastUsed
+}
+
+// IsExpired indicates if the exception was marked expired
+func (status *PolicyExceptionStatus) IsExpired() bool {
+	condition := meta.FindStatusCondition(status.Conditions, PolicyExceptionConditionActive)
+	return condition != nil && condition.Status == metav1.ConditionFalse && condition.Reason == PolicyExceptionReasonExpired
+}
+
 // Exception stores infos about a policy and rules
 type Exception struct {
 	// PolicyName identifies the policy to which the exception is applied.
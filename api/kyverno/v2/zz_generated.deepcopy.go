@@ -23,6 +23,7 @@ package v2
 
 import (
 	v2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -53,6 +54,7 @@ func (in *PolicyException) DeepCopyInto(out *PolicyException) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -121,6 +123,16 @@ func (in *PolicyExceptionSpec) DeepCopyInto(out *PolicyExceptionSpec) {
 		*out = new(v2beta1.AnyAllConditions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Exceptions != nil {
 		in, out := &in.Exceptions, &out.Exceptions
 		*out = make([]Exception, len(*in))
@@ -128,6 +140,10 @@ func (in *PolicyExceptionSpec) DeepCopyInto(out *PolicyExceptionSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -140,3 +156,50 @@ func (in *PolicyExceptionSpec) DeepCopy() *PolicyExceptionSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyExceptionStatus) DeepCopyInto(out *PolicyExceptionStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = make([]PolicyExceptionUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyExceptionStatus.
+func (in *PolicyExceptionStatus) DeepCopy() *PolicyExceptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyExceptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyExceptionUsage) DeepCopyInto(out *PolicyExceptionUsage) {
+	*out = *in
+	in.LastUsed.DeepCopyInto(&out.LastUsed)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyExceptionUsage.
+func (in *PolicyExceptionUsage) DeepCopy() *PolicyExceptionUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyExceptionUsage)
+	in.DeepCopyInto(out)
+	return out
+}
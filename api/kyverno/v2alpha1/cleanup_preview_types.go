@@ -0,0 +1,100 @@
+/*
+Copyright 2020 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=cleanprev,categories=kyverno
+// +kubebuilder:printcolumn:name="Kind",type=string,JSONPath=".spec.policyRef.kind"
+// +kubebuilder:printcolumn:name="Policy",type=string,JSONPath=".spec.policyRef.name"
+// +kubebuilder:printcolumn:name="Next Execution",type="date",JSONPath=".spec.nextExecutionTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// CleanupPreview lists the resources a cleanup policy running in dry run mode would delete on its next execution.
+// It is maintained by the cleanup controller and owned by the policy.
+type CleanupPreview struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec contains the resources that would be deleted.
+	Spec CleanupPreviewSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// CleanupPreviewList is a list of CleanupPreview instances.
+type CleanupPreviewList struct {
+	metav1.TypeMeta `json:",inline" yaml:",inline"`
+	metav1.ListMeta `json:"metadata" yaml:"metadata"`
+	Items           []CleanupPreview `json:"items" yaml:"items"`
+}
+
+// CleanupPreviewSpec stores the resources matched by a cleanup policy running in dry run mode.
+type CleanupPreviewSpec struct {
+	// PolicyRef references the cleanup policy.
+	PolicyRef CleanupPolicyReference `json:"policyRef"`
+
+	// EvaluationTime is the time the resources were matched.
+	EvaluationTime metav1.Time `json:"evaluationTime"`
+
+	// NextExecutionTime is the next scheduled execution of the policy.
+	// +optional
+	NextExecutionTime *metav1.Time `json:"nextExecutionTime,omitempty"`
+
+	// Resources contains the resources that would be deleted.
+	// +optional
+	Resources []CleanupResource `json:"resources,omitempty"`
+}
+
+// CleanupPolicyReference references a cleanup policy.
+type CleanupPolicyReference struct {
+	// Kind is the kind of the policy, either CleanupPolicy or ClusterCleanupPolicy.
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace of the policy, empty for cluster policies.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the name of the policy.
+	Name string `json:"name"`
+}
+
+// CleanupResource identifies a resource selected by a cleanup policy.
+type CleanupResource struct {
+	// APIVersion is the api version of the resource.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind is the kind of the resource.
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace of the resource, empty for cluster wide resources.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the name of the resource.
+	Name string `json:"name"`
+
+	// UID is the uid of the resource.
+	// +optional
+	UID types.UID `json:"uid,omitempty"`
+}
@@ -0,0 +1,37 @@
+package v2alpha1
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func deletion(name string, timestamp time.Time) CleanupDeletion {
+	return CleanupDeletion{
+		CleanupResource: CleanupResource{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Namespace:  "default",
+			Name:       name,
+		},
+		Timestamp: metav1.NewTime(timestamp),
+	}
+}
+
+func Test_CleanupReportSpec_AddDeletions(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	var spec CleanupReportSpec
+	spec.AddDeletions(3)
+	assert.Assert(t, spec.LastDeletionTime == nil)
+	assert.Equal(t, len(spec.Deletions), 0)
+	spec.AddDeletions(3, deletion("a", now), deletion("b", now.Add(time.Minute)))
+	assert.Equal(t, len(spec.Deletions), 2)
+	assert.Equal(t, spec.LastDeletionTime.Time, now.Add(time.Minute))
+	spec.AddDeletions(3, deletion("c", now.Add(2*time.Minute)), deletion("d", now.Add(3*time.Minute)))
+	assert.Equal(t, len(spec.Deletions), 3)
+	assert.Equal(t, spec.Deletions[0].Name, "b")
+	assert.Equal(t, spec.Deletions[2].Name, "d")
+	assert.Equal(t, spec.LastDeletionTime.Time, now.Add(3*time.Minute))
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=cleanrep,categories=kyverno
+// +kubebuilder:printcolumn:name="Kind",type=string,JSONPath=".spec.policyRef.kind"
+// +kubebuilder:printcolumn:name="Policy",type=string,JSONPath=".spec.policyRef.name"
+// +kubebuilder:printcolumn:name="Last Deletion",type="date",JSONPath=".spec.lastDeletionTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// CleanupReport records the resources deleted by a cleanup policy.
+// It is maintained by the cleanup controller and owned by the policy.
+type CleanupReport struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec contains the deletions performed by the policy.
+	Spec CleanupReportSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// CleanupReportList is a list of CleanupReport instances.
+type CleanupReportList struct {
+	metav1.TypeMeta `json:",inline" yaml:",inline"`
+	metav1.ListMeta `json:"metadata" yaml:"metadata"`
+	Items           []CleanupReport `json:"items" yaml:"items"`
+}
+
+// CleanupReportSpec stores the deletions performed by a cleanup policy.
+type CleanupReportSpec struct {
+	// PolicyRef references the cleanup policy.
+	PolicyRef CleanupPolicyReference `json:"policyRef"`
+
+	// LastDeletionTime is the time of the most recent deletion.
+	// +optional
+	LastDeletionTime *metav1.Time `json:"lastDeletionTime,omitempty"`
+
+	// Deletions contains the deleted resources, oldest first.
+	// Only the most recent deletions are kept.
+	// +optional
+	Deletions []CleanupDeletion `json:"deletions,omitempty"`
+}
+
+// CleanupDeletion records a resource deleted, or otherwise processed, by a cleanup policy.
+type CleanupDeletion struct {
+	CleanupResource `json:",inline"`
+
+	// Action is the action performed on the resource, empty when the resource was deleted.
+	// +optional
+	Action string `json:"action,omitempty"`
+
+	// Timestamp is the time the action was performed.
+	Timestamp metav1.Time `json:"timestamp"`
+}
+
+// AddDeletions appends the deletions to the report, keeping at most max entries.
+func (s *CleanupReportSpec) AddDeletions(max int, deletions ...CleanupDeletion) {
+	if len(deletions) == 0 {
+		return
+	}
+	s.Deletions = append(s.Deletions, deletions...)
+	if max > 0 && len(s.Deletions) > max {
+		s.Deletions = s.Deletions[len(s.Deletions)-max:]
+	}
+	last := deletions[len(deletions)-1].Timestamp
+	s.LastDeletionTime = &last
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2020 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	"encoding/json"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope="Cluster",shortName=cbaseline,categories=kyverno
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type == "Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClusterBaseline declares a set of guardrail objects that must exist in the cluster.
+// The objects are continuously reconciled, drifts from the declared content are corrected and reported.
+type ClusterBaseline struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares the baseline objects.
+	Spec ClusterBaselineSpec `json:"spec"`
+
+	// Status contains the reconciliation state of the baseline.
+	// +optional
+	Status ClusterBaselineStatus `json:"status,omitempty"`
+}
+
+// GetReconcileInterval returns the interval between two reconciliations of the baseline
+func (b *ClusterBaseline) GetReconcileInterval() time.Duration {
+	if b.Spec.Interval != nil && b.Spec.Interval.Duration > 0 {
+		return b.Spec.Interval.Duration
+	}
+	return DefaultBaselineInterval
+}
+
+// Validate implements programmatic validation
+func (b *ClusterBaseline) Validate() (errs field.ErrorList) {
+	errs = append(errs, kyvernov1.ValidatePolicyName(field.NewPath("metadata").Child("name"), b.Name)...)
+	errs = append(errs, b.Spec.Validate(field.NewPath("spec"))...)
+	return errs
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterBaselineList is a list of ClusterBaseline instances.
+type ClusterBaselineList struct {
+	metav1.TypeMeta `json:",inline" yaml:",inline"`
+	metav1.ListMeta `json:"metadata" yaml:"metadata"`
+	Items           []ClusterBaseline `json:"items" yaml:"items"`
+}
+
+// DefaultBaselineInterval is the interval between two reconciliations of a baseline when not specified
+const DefaultBaselineInterval = 10 * time.Minute
+
+// ClusterBaselineSpec stores the objects declared by a baseline.
+type ClusterBaselineSpec struct {
+	// Resources declares the objects that must exist and match their declared content.
+	Resources []BaselineResource `json:"resources"`
+
+	// Interval is the period between two reconciliations of the baseline, defaults to 10m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (s *ClusterBaselineSpec) Validate(path *field.Path) (errs field.ErrorList) {
+	if len(s.Resources) == 0 {
+		errs = append(errs, field.Required(path.Child("resources"), "at least one resource is required"))
+	}
+	for i := range s.Resources {
+		errs = append(errs, s.Resources[i].Validate(path.Child("resources").Index(i))...)
+	}
+	return errs
+}
+
+// BaselineResource declares an object of a baseline.
+type BaselineResource struct {
+	// APIVersion of the object.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the object.
+	Kind string `json:"kind"`
+
+	// Name of the object.
+	Name string `json:"name"`
+
+	// Namespace of the object, only used for namespaced objects when NamespaceSelector is not set.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// NamespaceSelector turns the object into a template reconciled in every namespace matching the selector.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Data is the declared content of the object.
+	// Fields not declared are left untouched when reconciling an existing object.
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	RawData *apiextv1.JSON `json:"data,omitempty"`
+}
+
+// GetData returns the declared content of the object
+func (r *BaselineResource) GetData() (map[string]interface{}, error) {
+	data := map[string]interface{}{}
+	if r.RawData == nil || len(r.RawData.Raw) == 0 {
+		return data, nil
+	}
+	if err := json.Unmarshal(r.RawData.Raw, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Validate implements programmatic validation
+func (r *BaselineResource) Validate(path *field.Path) (errs field.ErrorList) {
+	if r.APIVersion == "" {
+		errs = append(errs, field.Required(path.Child("apiVersion"), "an apiVersion is required"))
+	}
+	if r.Kind == "" {
+		errs = append(errs, field.Required(path.Child("kind"), "a kind is required"))
+	}
+	if r.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), "a name is required"))
+	}
+	if r.Namespace != "" && r.NamespaceSelector != nil {
+		errs = append(errs, field.Forbidden(path.Child("namespace"), "namespace and namespaceSelector are mutually exclusive"))
+	}
+	if r.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(r.NamespaceSelector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("namespaceSelector"), r.NamespaceSelector, err.Error()))
+		}
+	}
+	if _, err := r.GetData(); err != nil {
+		errs = append(errs, field.Invalid(path.Child("data"), string(r.RawData.Raw), "data must be an object"))
+	}
+	return errs
+}
+
+// BaselineResourceState is the reconciliation state of a baseline object.
+// +kubebuilder:validation:Enum=Created;InSync;Drifted;Failed
+type BaselineResourceState string
+
+const (
+	// BaselineResourceCreated means the object was missing and has been created
+	BaselineResourceCreated BaselineResourceState = "Created"
+	// BaselineResourceInSync means the object matches its declared content
+	BaselineResourceInSync BaselineResourceState = "InSync"
+	// BaselineResourceDrifted means the object drifted from its declared content and has been corrected
+	BaselineResourceDrifted BaselineResourceState = "Drifted"
+	// BaselineResourceFailed means the object could not be reconciled
+	BaselineResourceFailed BaselineResourceState = "Failed"
+)
+
+// ClusterBaselineStatus stores the reconciliation state of a baseline.
+type ClusterBaselineStatus struct {
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// LastReconcileTime is the time of the last reconciliation.
+	// +optional
+	LastReconcileTime metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	// Resources reports the state of the reconciled objects.
+	// +optional
+	Resources []BaselineResourceStatus `json:"resources,omitempty"`
+}
+
+// SetReady sets the ready condition of the baseline
+func (status *ClusterBaselineStatus) SetReady(ready bool, message string) {
+	condition := metav1.Condition{
+		Type:    kyvernov1.PolicyConditionReady,
+		Message: message,
+	}
+	if ready {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = kyvernov1.PolicyReasonSucceeded
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = kyvernov1.PolicyReasonFailed
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// IsReady indicates if the last reconciliation of the baseline succeeded
+func (status *ClusterBaselineStatus) IsReady() bool {
+	condition := meta.FindStatusCondition(status.Conditions, kyvernov1.PolicyConditionReady)
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
+
+// BaselineResourceStatus stores the reconciliation state of a baseline object.
+type BaselineResourceStatus struct {
+	// APIVersion of the object.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the object.
+	Kind string `json:"kind"`
+
+	// Namespace of the object.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the object.
+	Name string `json:"name"`
+
+	// State is the reconciliation state of the object.
+	State BaselineResourceState `json:"state"`
+
+	// Drift lists the paths of the fields that did not match the declared content.
+	// +optional
+	Drift []string `json:"drift,omitempty"`
+
+	// Message contains details about the reconciliation.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2020 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// DefaultComplianceSummaryInterval is the default period between two samples of the policy reports
+	DefaultComplianceSummaryInterval = 5 * time.Minute
+)
+
+// DefaultComplianceSummaryWindows are the default windows the trends are computed over
+var DefaultComplianceSummaryWindows = []time.Duration{time.Hour, 24 * time.Hour}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope="Cluster",shortName=ccsummary,categories=kyverno
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Pass",type=integer,JSONPath=".status.summary.pass"
+// +kubebuilder:printcolumn:name="Fail",type=integer,JSONPath=".status.summary.fail"
+// +kubebuilder:printcolumn:name="Warn",type=integer,JSONPath=".status.summary.warn"
+// +kubebuilder:printcolumn:name="Updated",type="date",JSONPath=".status.lastUpdateTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClusterComplianceSummary is maintained by the reports controller and tracks the number of results per policy
+// across all the policy reports in the cluster, along with their evolution over configurable time windows.
+type ClusterComplianceSummary struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares the policies and time windows tracked by the summary.
+	// +optional
+	Spec ClusterComplianceSummarySpec `json:"spec,omitempty"`
+
+	// Status contains the counts and trends computed by the controller.
+	// +optional
+	Status ClusterComplianceSummaryStatus `json:"status,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (s *ClusterComplianceSummary) Validate() (errs field.ErrorList) {
+	errs = append(errs, kyvernov1.ValidatePolicyName(field.NewPath("metadata").Child("name"), s.Name)...)
+	errs = append(errs, s.Spec.Validate(field.NewPath("spec"))...)
+	return errs
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterComplianceSummaryList is a list of ClusterComplianceSummary instances.
+type ClusterComplianceSummaryList struct {
+	metav1.TypeMeta `json:",inline" yaml:",inline"`
+	metav1.ListMeta `json:"metadata" yaml:"metadata"`
+	Items           []ClusterComplianceSummary `json:"items" yaml:"items"`
+}
+
+// ClusterComplianceSummarySpec stores the policies and time windows tracked by a summary.
+type ClusterComplianceSummarySpec struct {
+	// Policies restricts the summary to the policies with the given names.
+	// Namespaced policies are referenced with their namespace, using the namespace/name format.
+	// All the policies found in reports are tracked when empty.
+	// +optional
+	Policies []string `json:"policies,omitempty"`
+
+	// Interval is the period between two samples of the policy reports, defaults to 5 minutes.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Windows are the time windows the trends are computed over, defaults to 1 hour and 24 hours.
+	// +optional
+	Windows []metav1.Duration `json:"windows,omitempty"`
+}
+
+// GetInterval returns the period between two samples of the policy reports
+func (s *ClusterComplianceSummarySpec) GetInterval() time.Duration {
+	if s.Interval == nil || s.Interval.Duration <= 0 {
+		return DefaultComplianceSummaryInterval
+	}
+	return s.Interval.Duration
+}
+
+// GetWindows returns the time windows the trends are computed over
+func (s *ClusterComplianceSummarySpec) GetWindows() []time.Duration {
+	if len(s.Windows) == 0 {
+		return DefaultComplianceSummaryWindows
+	}
+	windows := make([]time.Duration, 0, len(s.Windows))
+	for _, window := range s.Windows {
+		windows = append(windows, window.Duration)
+	}
+	return windows
+}
+
+// Validate implements programmatic validation
+func (s *ClusterComplianceSummarySpec) Validate(path *field.Path) (errs field.ErrorList) {
+	if s.Interval != nil && s.Interval.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("interval"), s.Interval.Duration.String(), "interval must be positive"))
+	}
+	interval := s.GetInterval()
+	for i, window := range s.Windows {
+		if window.Duration < interval {
+			errs = append(errs, field.Invalid(path.Child("windows").Index(i), window.Duration.String(), "window must not be shorter than the interval"))
+		}
+	}
+	return errs
+}
+
+// PolicyComplianceCount stores the number of results of a policy per result type.
+type PolicyComplianceCount struct {
+	// Policy is the name of the policy, namespaced policies use the namespace/name format.
+	Policy string `json:"policy"`
+
+	// Pass provides the count of policies whose requirements were met.
+	// +optional
+	Pass int `json:"pass"`
+
+	// Fail provides the count of policies whose requirements were not met.
+	// +optional
+	Fail int `json:"fail"`
+
+	// Warn provides the count of non-scored policies whose requirements were not met.
+	// +optional
+	Warn int `json:"warn"`
+
+	// Error provides the count of policies that could not be evaluated.
+	// +optional
+	Error int `json:"error"`
+
+	// Skip indicates the count of policies that were not selected for evaluation.
+	// +optional
+	Skip int `json:"skip"`
+}
+
+// ComplianceTrend stores the change in the number of results of a policy over a time window.
+type ComplianceTrend struct {
+	// Window is the time window the deltas are computed over.
+	Window metav1.Duration `json:"window"`
+
+	// Since is the time of the sample the deltas are computed from.
+	// It is more recent than the start of the window when not enough samples were recorded yet.
+	Since metav1.Time `json:"since"`
+
+	// Pass is the change in the number of pass results.
+	// +optional
+	Pass int `json:"pass"`
+
+	// Fail is the change in the number of fail results.
+	// +optional
+	Fail int `json:"fail"`
+
+	// Warn is the change in the number of warn results.
+	// +optional
+	Warn int `json:"warn"`
+}
+
+// PolicyComplianceSummary stores the current counts and the trends of a policy.
+type PolicyComplianceSummary struct {
+	PolicyComplianceCount `json:",inline"`
+
+	// Trends contains the changes in the counts over the configured time windows.
+	// +optional
+	Trends []ComplianceTrend `json:"trends,omitempty"`
+}
+
+// ComplianceSample is a point in time record of the counts, used to compute the trends.
+type ComplianceSample struct {
+	// Time is the time the sample was recorded.
+	Time metav1.Time `json:"time"`
+
+	// Policies contains the counts per policy.
+	// +optional
+	Policies []PolicyComplianceCount `json:"policies,omitempty"`
+}
+
+// ClusterComplianceSummaryStatus stores the counts and trends computed by the controller.
+type ClusterComplianceSummaryStatus struct {
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// LastUpdateTime is the time the counts were last computed.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+
+	// Summary provides the total counts across the tracked policies.
+	// +optional
+	Summary policyreportv1alpha2.PolicyReportSummary `json:"summary,omitempty"`
+
+	// Policies contains the counts and trends per policy.
+	// +optional
+	Policies []PolicyComplianceSummary `json:"policies,omitempty"`
+
+	// Samples contains the history needed to compute the trends over the longest window.
+	// +optional
+	Samples []ComplianceSample `json:"samples,omitempty"`
+}
+
+// SetReady sets the ready condition of the summary
+func (status *ClusterComplianceSummaryStatus) SetReady(ready bool, message string) {
+	condition := metav1.Condition{
+		Type:    kyvernov1.PolicyConditionReady,
+		Message: message,
+	}
+	if ready {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = kyvernov1.PolicyReasonSucceeded
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = kyvernov1.PolicyReasonFailed
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2020 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope="Cluster",shortName=cscan,categories=kyverno
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Pass",type=integer,JSONPath=".status.summary.pass"
+// +kubebuilder:printcolumn:name="Fail",type=integer,JSONPath=".status.summary.fail"
+// +kubebuilder:printcolumn:name="Completed",type="date",JSONPath=".status.completionTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ComplianceScan triggers a one time evaluation of the selected policies against the selected resources.
+// The results are recorded in the status once and never updated afterwards, providing point in time audit evidence.
+type ComplianceScan struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares the policies and resources to scan.
+	Spec ComplianceScanSpec `json:"spec"`
+
+	// Status contains the results of the scan.
+	// +optional
+	Status ComplianceScanStatus `json:"status,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (s *ComplianceScan) Validate() (errs field.ErrorList) {
+	errs = append(errs, kyvernov1.ValidatePolicyName(field.NewPath("metadata").Child("name"), s.Name)...)
+	errs = append(errs, s.Spec.Validate(field.NewPath("spec"))...)
+	return errs
+}
+
+// +kubebuilder:object:root=true
+
+// ComplianceScanList is a list of ComplianceScan instances.
+type ComplianceScanList struct {
+	metav1.TypeMeta `json:",inline" yaml:",inline"`
+	metav1.ListMeta `json:"metadata" yaml:"metadata"`
+	Items           []ComplianceScan `json:"items" yaml:"items"`
+}
+
+// ComplianceScanSpec stores the policies and resources selected by a scan.
+type ComplianceScanSpec struct {
+	// Policies restricts the scan to the policies with the given names.
+	// Namespaced policies are referenced with their namespace, using the namespace/name format.
+	// All the policies supporting background processing are evaluated when empty.
+	// +optional
+	Policies []string `json:"policies,omitempty"`
+
+	// PolicySelector restricts the scan to the policies matching the label selector.
+	// +optional
+	PolicySelector *metav1.LabelSelector `json:"policySelector,omitempty"`
+
+	// Resources selects the resources evaluated by the scan.
+	// +optional
+	Resources ComplianceScanResources `json:"resources,omitempty"`
+
+	// Export writes the results of the scan as JSON in an immutable config map in the Kyverno namespace.
+	// +optional
+	Export bool `json:"export,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (s *ComplianceScanSpec) Validate(path *field.Path) (errs field.ErrorList) {
+	if s.PolicySelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(s.PolicySelector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("policySelector"), s.PolicySelector, err.Error()))
+		}
+	}
+	errs = append(errs, s.Resources.Validate(path.Child("resources"))...)
+	return errs
+}
+
+// ComplianceScanResources selects the resources evaluated by a scan.
+type ComplianceScanResources struct {
+	// Kinds restricts the scan to the given kinds, defaults to the kinds matched by the selected policies.
+	// +optional
+	Kinds []string `json:"kinds,omitempty"`
+
+	// Namespaces restricts the scan to the resources in the given namespaces.
+	// Cluster wide resources are not evaluated when namespaces are set.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Selector restricts the scan to the resources matching the label selector.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (r *ComplianceScanResources) Validate(path *field.Path) (errs field.ErrorList) {
+	if r.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(r.Selector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("selector"), r.Selector, err.Error()))
+		}
+	}
+	return errs
+}
+
+// ComplianceScanState is the state of a scan.
+// +kubebuilder:validation:Enum=Running;Completed;Failed
+type ComplianceScanState string
+
+const (
+	// ComplianceScanRunning means the scan is in progress
+	ComplianceScanRunning ComplianceScanState = "Running"
+	// ComplianceScanCompleted means the scan completed and its results are recorded
+	ComplianceScanCompleted ComplianceScanState = "Completed"
+	// ComplianceScanFailed means the scan could not be completed
+	ComplianceScanFailed ComplianceScanState = "Failed"
+)
+
+// ComplianceScanStatus stores the results of a scan.
+type ComplianceScanStatus struct {
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// State is the state of the scan.
+	// +optional
+	State ComplianceScanState `json:"state,omitempty"`
+
+	// Message contains details about the state of the scan.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// StartTime is the time the scan started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the scan completed, the status is not updated afterwards.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Summary provides a summary of the results.
+	// +optional
+	Summary policyreportv1alpha2.PolicyReportSummary `json:"summary,omitempty"`
+
+	// Results contains the results of the evaluation of the policies against the resources.
+	// +optional
+	Results []policyreportv1alpha2.PolicyReportResult `json:"results,omitempty"`
+
+	// ExportRef references the config map the results were exported to.
+	// +optional
+	ExportRef *corev1.ObjectReference `json:"exportRef,omitempty"`
+}
+
+// IsDone indicates if the scan completed or failed, in which case it must not run again
+func (status *ComplianceScanStatus) IsDone() bool {
+	return status.CompletionTime != nil
+}
+
+// SetReady sets the ready condition of the scan
+func (status *ComplianceScanStatus) SetReady(ready bool, message string) {
+	condition := metav1.Condition{
+		Type:    kyvernov1.PolicyConditionReady,
+		Message: message,
+	}
+	if ready {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = kyvernov1.PolicyReasonSucceeded
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = kyvernov1.PolicyReasonFailed
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2020 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope="Cluster",shortName=exclpol,categories=kyverno
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Filtered",type=integer,JSONPath=".status.filteredRequests"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ExclusionPolicy declares resources Kyverno controllers must ignore.
+// It replaces the string encoded resourceFilters of the Kyverno ConfigMap.
+type ExclusionPolicy struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares the excluded resources.
+	Spec ExclusionPolicySpec `json:"spec"`
+
+	// Status contains the number of requests filtered by the policy.
+	// +optional
+	Status ExclusionPolicyStatus `json:"status,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (p *ExclusionPolicy) Validate() (errs field.ErrorList) {
+	errs = append(errs, kyvernov1.ValidatePolicyName(field.NewPath("metadata").Child("name"), p.Name)...)
+	errs = append(errs, p.Spec.Validate(field.NewPath("spec"))...)
+	return errs
+}
+
+// +kubebuilder:object:root=true
+
+// ExclusionPolicyList is a list of ExclusionPolicy instances.
+type ExclusionPolicyList struct {
+	metav1.TypeMeta `json:",inline" yaml:",inline"`
+	metav1.ListMeta `json:"metadata" yaml:"metadata"`
+	Items           []ExclusionPolicy `json:"items" yaml:"items"`
+}
+
+// ExclusionController is a Kyverno controller an exclusion policy can be scoped to.
+// +kubebuilder:validation:Enum=Admission;Background;Reports
+type ExclusionController string
+
+const (
+	// ExclusionAdmission excludes the resources from the admission webhooks
+	ExclusionAdmission ExclusionController = "Admission"
+	// ExclusionBackground excludes the resources from generate and mutate existing rules
+	ExclusionBackground ExclusionController = "Background"
+	// ExclusionReports excludes the resources from the background scan reports
+	ExclusionReports ExclusionController = "Reports"
+)
+
+// ExclusionPolicySpec stores the resources excluded by a policy.
+type ExclusionPolicySpec struct {
+	// Controllers are the controllers the exclusions apply to, all controllers when empty.
+	// +optional
+	Controllers []ExclusionController `json:"controllers,omitempty"`
+
+	// Exclusions declares the excluded resources, a resource is excluded when it matches any of the exclusions.
+	Exclusions []ResourceExclusion `json:"exclusions"`
+}
+
+// AppliesTo returns true if the exclusions apply to the controller
+func (s *ExclusionPolicySpec) AppliesTo(controller ExclusionController) bool {
+	if len(s.Controllers) == 0 {
+		return true
+	}
+	for _, c := range s.Controllers {
+		if c == controller {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate implements programmatic validation
+func (s *ExclusionPolicySpec) Validate(path *field.Path) (errs field.ErrorList) {
+	if len(s.Exclusions) == 0 {
+		errs = append(errs, field.Required(path.Child("exclusions"), "at least one exclusion is required"))
+	}
+	for i := range s.Exclusions {
+		errs = append(errs, s.Exclusions[i].Validate(path.Child("exclusions").Index(i))...)
+	}
+	return errs
+}
+
+// ResourceExclusion selects excluded resources, a resource is excluded when it matches all the specified fields.
+type ResourceExclusion struct {
+	// Kinds is a list of resource kinds, in the kind, version/kind, group/version/kind or kind/subresource format.
+	// Wildcards (* and ?) are supported, all kinds when empty.
+	// +optional
+	Kinds []string `json:"kinds,omitempty"`
+
+	// Namespaces is a list of namespaces, wildcards (* and ?) are supported, all namespaces when empty.
+	// The namespace of a Namespace resource is its name.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Names is a list of resource names, wildcards (* and ?) are supported, all names when empty.
+	// +optional
+	Names []string `json:"names,omitempty"`
+
+	// Selector is a label selector on the labels of the resources.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (e *ResourceExclusion) Validate(path *field.Path) (errs field.ErrorList) {
+	if len(e.Kinds) == 0 && len(e.Namespaces) == 0 && len(e.Names) == 0 && e.Selector == nil {
+		errs = append(errs, field.Required(path, "at least one of kinds, namespaces, names or selector is required"))
+	}
+	for i, kind := range e.Kinds {
+		if kind == "" || strings.Count(kind, "/") > 3 {
+			errs = append(errs, field.Invalid(path.Child("kinds").Index(i), kind, "kind must be in the kind, version/kind, group/version/kind or kind/subresource format"))
+		}
+	}
+	if e.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(e.Selector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("selector"), e.Selector, err.Error()))
+		}
+	}
+	return errs
+}
+
+// ExclusionPolicyStatus stores the number of requests filtered by a policy.
+type ExclusionPolicyStatus struct {
+	// FilteredRequests is the number of admission requests filtered by the policy since its creation.
+	// +optional
+	FilteredRequests int64 `json:"filteredRequests,omitempty"`
+
+	// LastFilteredTime is the time an admission request was last filtered by the policy.
+	// +optional
+	LastFilteredTime *metav1.Time `json:"lastFilteredTime,omitempty"`
+}
+
+// RecordFiltered adds filtered requests to the status
+func (status *ExclusionPolicyStatus) RecordFiltered(count int64, lastFiltered metav1.Time) {
+	status.FilteredRequests += count
+	if status.LastFilteredTime == nil || status.LastFilteredTime.Before(&lastFiltered) {
+		status.LastFilteredTime = &lastFiltered
+	}
+}
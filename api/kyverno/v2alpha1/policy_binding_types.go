@@ -0,0 +1,136 @@
+/*
+Copyright 2020 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/ext/wildcard"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope="Cluster",shortName=polbind,categories=kyverno
+// +kubebuilder:printcolumn:name="Policy",type=string,JSONPath=".spec.policyName"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// PolicyBinding binds a ClusterPolicy to namespaces and overrides the variables of its rules
+// for the resources of these namespaces, so that the same policy can enforce different thresholds per team.
+type PolicyBinding struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares the bound policy, namespaces and variables.
+	Spec PolicyBindingSpec `json:"spec"`
+}
+
+// Validate implements programmatic validation
+func (b *PolicyBinding) Validate() (errs field.ErrorList) {
+	errs = append(errs, kyvernov1.ValidatePolicyName(field.NewPath("metadata").Child("name"), b.Name)...)
+	errs = append(errs, b.Spec.Validate(field.NewPath("spec"))...)
+	return errs
+}
+
+// +kubebuilder:object:root=true
+
+// PolicyBindingList is a list of PolicyBinding instances.
+type PolicyBindingList struct {
+	metav1.TypeMeta `json:",inline" yaml:",inline"`
+	metav1.ListMeta `json:"metadata" yaml:"metadata"`
+	Items           []PolicyBinding `json:"items" yaml:"items"`
+}
+
+// PolicyBindingSpec stores the namespaces a policy is bound to and the variables overridden for them.
+type PolicyBindingSpec struct {
+	// PolicyName is the name of the bound ClusterPolicy.
+	PolicyName string `json:"policyName"`
+
+	// Namespaces is a list of namespaces the policy is bound to, wildcards (* and ?) are supported.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// NamespaceSelector is a label selector on the labels of the namespaces the policy is bound to.
+	// A namespace is bound when it matches the namespaces or the selector.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Variables override the variable context entries of the same name declared by the rules of the policy,
+	// variables not declared by a rule are added to its context.
+	Variables []PolicyBindingVariable `json:"variables"`
+}
+
+// Validate implements programmatic validation
+func (s *PolicyBindingSpec) Validate(path *field.Path) (errs field.ErrorList) {
+	if s.PolicyName == "" {
+		errs = append(errs, field.Required(path.Child("policyName"), "policy name is required"))
+	}
+	if len(s.Namespaces) == 0 && s.NamespaceSelector == nil {
+		errs = append(errs, field.Required(path, "at least one of namespaces or namespaceSelector is required"))
+	}
+	if s.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(s.NamespaceSelector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("namespaceSelector"), s.NamespaceSelector, err.Error()))
+		}
+	}
+	if len(s.Variables) == 0 {
+		errs = append(errs, field.Required(path.Child("variables"), "at least one variable is required"))
+	}
+	names := sets.New[string]()
+	for i, variable := range s.Variables {
+		if variable.Name == "" {
+			errs = append(errs, field.Required(path.Child("variables").Index(i).Child("name"), "variable name is required"))
+		} else if names.Has(variable.Name) {
+			errs = append(errs, field.Duplicate(path.Child("variables").Index(i).Child("name"), variable.Name))
+		}
+		names.Insert(variable.Name)
+	}
+	return errs
+}
+
+// Binds returns true if the policy is bound to a namespace with the given labels
+func (s *PolicyBindingSpec) Binds(namespace string, namespaceLabels map[string]string) bool {
+	if namespace == "" {
+		return false
+	}
+	if wildcard.CheckPatterns(s.Namespaces, namespace) {
+		return true
+	}
+	if s.NamespaceSelector == nil {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(s.NamespaceSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(namespaceLabels))
+}
+
+// PolicyBindingVariable is a variable overridden by a policy binding.
+type PolicyBindingVariable struct {
+	// Name is the name of the variable context entry.
+	Name string `json:"name"`
+
+	// Value is any arbitrary JSON object representable in YAML or JSON form.
+	Value apiextv1.JSON `json:"value"`
+}
@@ -0,0 +1,52 @@
+package v2alpha1
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func Test_PolicyProfileSpec_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   PolicyProfileSpec
+		errors int
+	}{{
+		name:   "no policies",
+		errors: 1,
+	}, {
+		name: "valid",
+		spec: PolicyProfileSpec{Policies: []PolicyProfilePolicy{
+			{Namespace: "templates", Name: "require-labels"},
+			{Namespace: "templates", Name: "disallow-latest", ValidationFailureAction: "Enforce"},
+		}},
+	}, {
+		name:   "missing reference",
+		spec:   PolicyProfileSpec{Policies: []PolicyProfilePolicy{{}}},
+		errors: 2,
+	}, {
+		name: "duplicate names",
+		spec: PolicyProfileSpec{Policies: []PolicyProfilePolicy{
+			{Namespace: "templates", Name: "require-labels"},
+			{Namespace: "other", Name: "require-labels"},
+		}},
+		errors: 1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.spec.Validate(field.NewPath("spec"))
+			assert.Equal(t, len(errs), tt.errors, errs.ToAggregate())
+		})
+	}
+}
+
+func Test_PolicyProfileStatus_SetReady(t *testing.T) {
+	var status PolicyProfileStatus
+	assert.Assert(t, !status.IsReady())
+	status.SetReady(true, "applied")
+	assert.Assert(t, status.IsReady())
+	status.SetReady(false, "template not found")
+	assert.Assert(t, !status.IsReady())
+	assert.Equal(t, len(status.Conditions), 1)
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2020 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope="Cluster",shortName=polprof,categories=kyverno
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type == "Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// PolicyProfile declares a tier of policies applied to the namespaces labelled with
+// `policies.kyverno.io/profile=<profile name>`. The template policies of the profile are copied in every
+// labelled namespace and removed from the namespaces that leave the profile.
+type PolicyProfile struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares the policies of the profile.
+	Spec PolicyProfileSpec `json:"spec"`
+
+	// Status contains the namespaces the policies of the profile are applied to.
+	// +optional
+	Status PolicyProfileStatus `json:"status,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (p *PolicyProfile) Validate() (errs field.ErrorList) {
+	errs = append(errs, kyvernov1.ValidatePolicyName(field.NewPath("metadata").Child("name"), p.Name)...)
+	errs = append(errs, p.Spec.Validate(field.NewPath("spec"))...)
+	return errs
+}
+
+// +kubebuilder:object:root=true
+
+// PolicyProfileList is a list of PolicyProfile instances.
+type PolicyProfileList struct {
+	metav1.TypeMeta `json:",inline" yaml:",inline"`
+	metav1.ListMeta `json:"metadata" yaml:"metadata"`
+	Items           []PolicyProfile `json:"items" yaml:"items"`
+}
+
+// PolicyProfileSpec stores the policies of a profile.
+type PolicyProfileSpec struct {
+	// Policies references the template policies applied to the namespaces of the profile.
+	Policies []PolicyProfilePolicy `json:"policies"`
+}
+
+// Validate implements programmatic validation
+func (s *PolicyProfileSpec) Validate(path *field.Path) (errs field.ErrorList) {
+	if len(s.Policies) == 0 {
+		errs = append(errs, field.Required(path.Child("policies"), "at least one policy is required"))
+	}
+	names := sets.New[string]()
+	for i, policy := range s.Policies {
+		policyPath := path.Child("policies").Index(i)
+		if policy.Namespace == "" {
+			errs = append(errs, field.Required(policyPath.Child("namespace"), "the namespace of the template policy is required"))
+		}
+		if policy.Name == "" {
+			errs = append(errs, field.Required(policyPath.Child("name"), "the name of the template policy is required"))
+		} else if names.Has(policy.Name) {
+			// the copies of the templates are named after them
+			errs = append(errs, field.Duplicate(policyPath.Child("name"), policy.Name))
+		}
+		names.Insert(policy.Name)
+	}
+	return errs
+}
+
+// PolicyProfilePolicy references a template policy of a profile.
+// Templates are namespaced policies, they should be kept in a namespace without workloads as they also apply to it.
+type PolicyProfilePolicy struct {
+	// Namespace of the template policy.
+	Namespace string `json:"namespace"`
+
+	// Name of the template policy, the copies of the template are named after it.
+	Name string `json:"name"`
+
+	// ValidationFailureAction overrides the validation failure action of the template policy.
+	// +optional
+	// +kubebuilder:validation:Enum=Audit;Enforce
+	ValidationFailureAction kyvernov1.ValidationFailureAction `json:"validationFailureAction,omitempty"`
+}
+
+// PolicyProfileStatus stores the namespaces the policies of a profile are applied to.
+type PolicyProfileStatus struct {
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Namespaces lists the namespaces of the profile and the policies applied to them.
+	// +optional
+	Namespaces []PolicyProfileNamespace `json:"namespaces,omitempty"`
+}
+
+// SetReady sets the ready condition of the policy profile
+func (status *PolicyProfileStatus) SetReady(ready bool, message string) {
+	condition := metav1.Condition{
+		Type:    kyvernov1.PolicyConditionReady,
+		Message: message,
+	}
+	if ready {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = kyvernov1.PolicyReasonSucceeded
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = kyvernov1.PolicyReasonFailed
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// IsReady indicates if the policies of the profile are applied to all its namespaces
+func (status *PolicyProfileStatus) IsReady() bool {
+	condition := meta.FindStatusCondition(status.Conditions, kyvernov1.PolicyConditionReady)
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
+
+// PolicyProfileNamespace stores the policies of a profile applied to a namespace.
+type PolicyProfileNamespace struct {
+	// Name of the namespace.
+	Name string `json:"name"`
+
+	// Policies lists the names of the policies applied to the namespace.
+	// +optional
+	Policies []string `json:"policies,omitempty"`
+
+	// Message contains details about the policies that could not be applied to the namespace.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,60 @@
+package v2alpha1
+
+import (
+	"fmt"
+	"testing"
+
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_PolicySetSource_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		source PolicySetSource
+		errors int
+	}{{
+		name:   "no source",
+		errors: 1,
+	}, {
+		name:   "oci with key",
+		source: PolicySetSource{OCI: &OCIPolicySetSource{Image: "ghcr.io/org/policies:v1", Key: "-----BEGIN PUBLIC KEY-----"}},
+	}, {
+		name:   "oci without verification",
+		source: PolicySetSource{OCI: &OCIPolicySetSource{Image: "ghcr.io/org/policies:v1"}},
+		errors: 1,
+	}, {
+		name:   "git without keys",
+		source: PolicySetSource{Git: &GitPolicySetSource{URL: "https://github.com/org/policies", Revision: "v1"}},
+		errors: 1,
+	}, {
+		name: "both sources",
+		source: PolicySetSource{
+			OCI: &OCIPolicySetSource{Image: "ghcr.io/org/policies:v1", Subject: "https://github.com/org/*"},
+			Git: &GitPolicySetSource{URL: "https://github.com/org/policies", Revision: "v1", PublicKeys: "keys"},
+		},
+		errors: 1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.source.Validate(nil)
+			assert.Equal(t, len(errs), tt.errors, errs.ToAggregate())
+		})
+	}
+}
+
+func Test_PolicySetStatus_RecordVersion(t *testing.T) {
+	var status PolicySetStatus
+	status.RecordVersion(PolicySetVersionStatus{Version: "sha256:1", State: PolicySetVersionApplied, Time: metav1.Unix(1, 0)})
+	status.RecordVersion(PolicySetVersionStatus{Version: "sha256:1", State: PolicySetVersionApplied, Time: metav1.Unix(2, 0)})
+	assert.Equal(t, len(status.History), 1)
+	assert.Equal(t, status.History[0].Time, metav1.Unix(2, 0))
+	status.RecordVersion(PolicySetVersionStatus{Version: "sha256:2", State: PolicySetVersionFailed})
+	assert.Equal(t, len(status.History), 2)
+	assert.Equal(t, status.History[0].Version, "sha256:2")
+	for i := 0; i < MaxPolicySetHistory; i++ {
+		status.RecordVersion(PolicySetVersionStatus{Version: fmt.Sprintf("sha256:%d", i+3), State: PolicySetVersionApplied})
+	}
+	assert.Equal(t, len(status.History), MaxPolicySetHistory)
+	assert.Equal(t, status.History[0].Version, fmt.Sprintf("sha256:%d", MaxPolicySetHistory+2))
+}
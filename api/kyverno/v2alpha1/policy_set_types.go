@@ -0,0 +1,298 @@
+/*
+Copyright 2020 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope="Cluster",shortName=pset,categories=kyverno
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=".status.version"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type == "Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// PolicySet declares a bundle of policies pulled from an OCI image or a Git repository.
+// The policies of a bundle version are verified and validated before any of them is applied, policies removed
+// from the bundle are deleted, so that pointing the source to another version rolls policies out or back as a whole.
+type PolicySet struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares the bundle source.
+	Spec PolicySetSpec `json:"spec"`
+
+	// Status contains the synchronization state of the bundle.
+	// +optional
+	Status PolicySetStatus `json:"status,omitempty"`
+}
+
+// GetSyncInterval returns the interval between two synchronizations of the bundle
+func (s *PolicySet) GetSyncInterval() time.Duration {
+	if s.Spec.Interval != nil && s.Spec.Interval.Duration > 0 {
+		return s.Spec.Interval.Duration
+	}
+	return DefaultPolicySetInterval
+}
+
+// Validate implements programmatic validation
+func (s *PolicySet) Validate() (errs field.ErrorList) {
+	errs = append(errs, kyvernov1.ValidatePolicyName(field.NewPath("metadata").Child("name"), s.Name)...)
+	errs = append(errs, s.Spec.Validate(field.NewPath("spec"))...)
+	return errs
+}
+
+// +kubebuilder:object:root=true
+
+// PolicySetList is a list of PolicySet instances.
+type PolicySetList struct {
+	metav1.TypeMeta `json:",inline" yaml:",inline"`
+	metav1.ListMeta `json:"metadata" yaml:"metadata"`
+	Items           []PolicySet `json:"items" yaml:"items"`
+}
+
+// DefaultPolicySetInterval is the interval between two synchronizations of a bundle when not specified
+const DefaultPolicySetInterval = 10 * time.Minute
+
+// MaxPolicySetHistory is the number of bundle versions kept in the status history
+const MaxPolicySetHistory = 10
+
+// PolicySetSpec stores the bundle source of a policy set.
+type PolicySetSpec struct {
+	// Source is the location of the bundle.
+	Source PolicySetSource `json:"source"`
+
+	// Interval is the period between two synchronizations of the bundle, defaults to 10m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Suspend stops the synchronization of the bundle, the policies already applied are left untouched.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (s *PolicySetSpec) Validate(path *field.Path) (errs field.ErrorList) {
+	return s.Source.Validate(path.Child("source"))
+}
+
+// PolicySetSource is the location of a bundle, exactly one of OCI or Git must be set.
+type PolicySetSource struct {
+	// OCI pulls the bundle from an image built with `kyverno oci push`.
+	// +optional
+	OCI *OCIPolicySetSource `json:"oci,omitempty"`
+
+	// Git pulls the bundle from a Git repository.
+	// +optional
+	Git *GitPolicySetSource `json:"git,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (s *PolicySetSource) Validate(path *field.Path) (errs field.ErrorList) {
+	if (s.OCI == nil) == (s.Git == nil) {
+		errs = append(errs, field.Invalid(path, s, "exactly one of oci or git must be set"))
+	}
+	if s.OCI != nil {
+		errs = append(errs, s.OCI.Validate(path.Child("oci"))...)
+	}
+	if s.Git != nil {
+		errs = append(errs, s.Git.Validate(path.Child("git"))...)
+	}
+	return errs
+}
+
+// OCIPolicySetSource pulls a bundle from an OCI image whose cosign signature is verified.
+type OCIPolicySetSource struct {
+	// Image is the reference of the bundle image, a tag or a digest selects the bundle version.
+	Image string `json:"image"`
+
+	// Key is the PEM encoded public key verifying the image signature.
+	// Keyless verification is used when the key is not set.
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// Subject is the expected certificate identity for keyless verification, wildcards are supported.
+	// +optional
+	Subject string `json:"subject,omitempty"`
+
+	// Issuer is the expected certificate OIDC issuer for keyless verification, wildcards are supported.
+	// +optional
+	Issuer string `json:"issuer,omitempty"`
+
+	// RekorURL is the transparency log url, it defaults to https://rekor.sigstore.dev.
+	// +optional
+	RekorURL string `json:"rekorURL,omitempty"`
+
+	// IgnoreTlog skips the transparency log verification.
+	// +optional
+	IgnoreTlog bool `json:"ignoreTlog,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (s *OCIPolicySetSource) Validate(path *field.Path) (errs field.ErrorList) {
+	if s.Image == "" {
+		errs = append(errs, field.Required(path.Child("image"), "an image is required"))
+	}
+	if s.Key == "" && s.Subject == "" {
+		errs = append(errs, field.Required(path.Child("subject"), "a subject is required for keyless verification when no key is set"))
+	}
+	return errs
+}
+
+// GitPolicySetSource pulls a bundle from a Git repository whose commit signature is verified.
+type GitPolicySetSource struct {
+	// URL of the repository.
+	URL string `json:"url"`
+
+	// Revision is the branch or tag selecting the bundle version.
+	Revision string `json:"revision"`
+
+	// Path is the directory containing the policies in the repository, defaults to the repository root.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// PublicKeys is the armored PGP key ring verifying the signature of the revision commit.
+	PublicKeys string `json:"publicKeys"`
+}
+
+// Validate implements programmatic validation
+func (s *GitPolicySetSource) Validate(path *field.Path) (errs field.ErrorList) {
+	if s.URL == "" {
+		errs = append(errs, field.Required(path.Child("url"), "a url is required"))
+	}
+	if s.Revision == "" {
+		errs = append(errs, field.Required(path.Child("revision"), "a revision is required"))
+	}
+	if s.PublicKeys == "" {
+		errs = append(errs, field.Required(path.Child("publicKeys"), "public keys are required to verify the commit signature"))
+	}
+	return errs
+}
+
+// PolicySetVersionState is the synchronization state of a bundle version.
+// +kubebuilder:validation:Enum=Applied;Failed
+type PolicySetVersionState string
+
+const (
+	// PolicySetVersionApplied means the policies of the version have been applied
+	PolicySetVersionApplied PolicySetVersionState = "Applied"
+	// PolicySetVersionFailed means the version could not be pulled, verified or applied
+	PolicySetVersionFailed PolicySetVersionState = "Failed"
+)
+
+// PolicySetStatus stores the synchronization state of a policy set.
+type PolicySetStatus struct {
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Version is the bundle version currently applied, the image digest or the commit hash.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Policies lists the policies applied from the current version.
+	// +optional
+	Policies []PolicySetPolicy `json:"policies,omitempty"`
+
+	// LastSyncTime is the time of the last synchronization.
+	// +optional
+	LastSyncTime metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// History reports the synchronized bundle versions, most recent first.
+	// +optional
+	History []PolicySetVersionStatus `json:"history,omitempty"`
+}
+
+// SetReady sets the ready condition of the policy set
+func (status *PolicySetStatus) SetReady(ready bool, message string) {
+	condition := metav1.Condition{
+		Type:    kyvernov1.PolicyConditionReady,
+		Message: message,
+	}
+	if ready {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = kyvernov1.PolicyReasonSucceeded
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = kyvernov1.PolicyReasonFailed
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// IsReady indicates if the last synchronization of the policy set succeeded
+func (status *PolicySetStatus) IsReady() bool {
+	condition := meta.FindStatusCondition(status.Conditions, kyvernov1.PolicyConditionReady)
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
+
+// RecordVersion records the outcome of a version synchronization in the history.
+// The entry of the most recent version is updated in place when the version and its state did not change.
+func (status *PolicySetStatus) RecordVersion(entry PolicySetVersionStatus) {
+	if len(status.History) != 0 && status.History[0].Version == entry.Version && status.History[0].State == entry.State {
+		status.History[0] = entry
+		return
+	}
+	status.History = append([]PolicySetVersionStatus{entry}, status.History...)
+	if len(status.History) > MaxPolicySetHistory {
+		status.History = status.History[:MaxPolicySetHistory]
+	}
+}
+
+// PolicySetPolicy references a policy applied from a bundle.
+type PolicySetPolicy struct {
+	// Kind of the policy, ClusterPolicy or Policy.
+	Kind string `json:"kind"`
+
+	// Namespace of the policy.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the policy.
+	Name string `json:"name"`
+}
+
+// PolicySetVersionStatus stores the synchronization outcome of a bundle version.
+type PolicySetVersionStatus struct {
+	// Version is the image digest or the commit hash, empty when the bundle could not be pulled.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Source is the image reference or the repository revision the version was pulled from.
+	Source string `json:"source"`
+
+	// State is the synchronization state of the version.
+	State PolicySetVersionState `json:"state"`
+
+	// Policies is the number of policies contained in the version.
+	// +optional
+	Policies int `json:"policies,omitempty"`
+
+	// Message contains details about the synchronization.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Time is the time of the synchronization.
+	Time metav1.Time `json:"time"`
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2020 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// DefaultPolicySimulationTTL is the time a simulation is kept after completing when no ttl is set.
+const DefaultPolicySimulationTTL = time.Hour
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope="Cluster",shortName=polsim,categories=kyverno
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Pass",type=integer,JSONPath=".status.summary.pass"
+// +kubebuilder:printcolumn:name="Fail",type=integer,JSONPath=".status.summary.fail"
+// +kubebuilder:printcolumn:name="Expires",type="date",JSONPath=".status.expirationTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// PolicySimulation evaluates a candidate policy in audit mode against the existing resources of the cluster.
+// The candidate is never applied to admission requests and its results are only recorded in the status of the
+// simulation, policy reports are not updated. Simulations are deleted once their ttl expires.
+type PolicySimulation struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares the candidate policy and the resources to evaluate.
+	Spec PolicySimulationSpec `json:"spec"`
+
+	// Status contains the results of the simulation.
+	// +optional
+	Status PolicySimulationStatus `json:"status,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (s *PolicySimulation) Validate() (errs field.ErrorList) {
+	errs = append(errs, kyvernov1.ValidatePolicyName(field.NewPath("metadata").Child("name"), s.Name)...)
+	errs = append(errs, s.Spec.Validate(field.NewPath("spec"))...)
+	return errs
+}
+
+// GetTTL returns the time the simulation is kept after completing
+func (s *PolicySimulation) GetTTL() time.Duration {
+	if s.Spec.TTL != nil && s.Spec.TTL.Duration > 0 {
+		return s.Spec.TTL.Duration
+	}
+	return DefaultPolicySimulationTTL
+}
+
+// +kubebuilder:object:root=true
+
+// PolicySimulationList is a list of PolicySimulation instances.
+type PolicySimulationList struct {
+	metav1.TypeMeta `json:",inline" yaml:",inline"`
+	metav1.ListMeta `json:"metadata" yaml:"metadata"`
+	Items           []PolicySimulation `json:"items" yaml:"items"`
+}
+
+// PolicySimulationSpec stores the candidate policy of a simulation.
+type PolicySimulationSpec struct {
+	// Namespace simulates a namespaced policy, evaluated against the resources of the namespace only.
+	// A cluster policy is simulated when empty.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Policy is the spec of the candidate policy.
+	// The validation failure action of the candidate is always Audit.
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Policy kyvernov1.Spec `json:"policy"`
+
+	// Resources selects the resources evaluated by the simulation.
+	// +optional
+	Resources ComplianceScanResources `json:"resources,omitempty"`
+
+	// TTL is the time the simulation is kept after completing before being deleted, defaults to one hour.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (s *PolicySimulationSpec) Validate(path *field.Path) (errs field.ErrorList) {
+	errs = append(errs, s.Policy.Validate(path.Child("policy"), s.Namespace != "", s.Namespace, nil)...)
+	errs = append(errs, s.Resources.Validate(path.Child("resources"))...)
+	if s.Namespace != "" && len(s.Resources.Namespaces) != 0 {
+		errs = append(errs, field.Forbidden(path.Child("resources").Child("namespaces"), "namespaces can't be set when simulating a namespaced policy"))
+	}
+	if s.TTL != nil && s.TTL.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("ttl"), s.TTL.Duration.String(), "ttl must be greater than zero"))
+	}
+	return errs
+}
+
+// PolicySimulationState is the state of a simulation.
+// +kubebuilder:validation:Enum=Running;Completed;Failed
+type PolicySimulationState string
+
+const (
+	// PolicySimulationRunning means the simulation is in progress
+	PolicySimulationRunning PolicySimulationState = "Running"
+	// PolicySimulationCompleted means the simulation completed and its results are recorded
+	PolicySimulationCompleted PolicySimulationState = "Completed"
+	// PolicySimulationFailed means the simulation could not be completed
+	PolicySimulationFailed PolicySimulationState = "Failed"
+)
+
+// PolicySimulationStatus stores the results of a simulation.
+type PolicySimulationStatus struct {
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// State is the state of the simulation.
+	// +optional
+	State PolicySimulationState `json:"state,omitempty"`
+
+	// Message contains details about the state of the simulation.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// StartTime is the time the simulation started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the simulation completed, the status is not updated afterwards.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// ExpirationTime is the time the simulation is deleted.
+	// +optional
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+
+	// Summary provides a summary of the results.
+	// +optional
+	Summary policyreportv1alpha2.PolicyReportSummary `json:"summary,omitempty"`
+
+	// Results contains the results the candidate policy would produce.
+	// The number of results is limited, the summary accounts for all of them.
+	// +optional
+	Results []policyreportv1alpha2.PolicyReportResult `json:"results,omitempty"`
+}
+
+// IsDone indicates if the simulation completed or failed, in which case it must not run again
+func (status *PolicySimulationStatus) IsDone() bool {
+	return status.CompletionTime != nil
+}
+
+// SetReady sets the ready condition of the simulation
+func (status *PolicySimulationStatus) SetReady(ready bool, message string) {
+	condition := metav1.Condition{
+		Type:    kyvernov1.PolicyConditionReady,
+		Message: message,
+	}
+	if ready {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = kyvernov1.PolicyReasonSucceeded
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = kyvernov1.PolicyReasonFailed
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2020 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope="Cluster",shortName=vpol,categories=kyverno
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ValidatingPolicy validates JSON payloads that are not Kubernetes resources, like Terraform plans,
+// Dockerfiles converted to JSON or cloud API responses.
+// Payloads are validated outside of admission, with the CLI or the payload scanning endpoint.
+type ValidatingPolicy struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares the validation rules.
+	Spec ValidatingPolicySpec `json:"spec"`
+}
+
+// Validate implements programmatic validation
+func (p *ValidatingPolicy) Validate() (errs field.ErrorList) {
+	errs = append(errs, kyvernov1.ValidatePolicyName(field.NewPath("metadata").Child("name"), p.Name)...)
+	errs = append(errs, p.Spec.Validate(field.NewPath("spec"))...)
+	return errs
+}
+
+// +kubebuilder:object:root=true
+
+// ValidatingPolicyList is a list of ValidatingPolicy instances.
+type ValidatingPolicyList struct {
+	metav1.TypeMeta `json:",inline" yaml:",inline"`
+	metav1.ListMeta `json:"metadata" yaml:"metadata"`
+	Items           []ValidatingPolicy `json:"items" yaml:"items"`
+}
+
+// ValidatingPolicySpec stores the validation rules of a payload policy.
+type ValidatingPolicySpec struct {
+	// Rules is a list of rules applied to payloads.
+	Rules []ValidatingRule `json:"rules"`
+}
+
+// Validate implements programmatic validation
+func (s *ValidatingPolicySpec) Validate(path *field.Path) (errs field.ErrorList) {
+	if len(s.Rules) == 0 {
+		errs = append(errs, field.Required(path.Child("rules"), "at least one rule is required"))
+	}
+	names := sets.New[string]()
+	for i, rule := range s.Rules {
+		rulePath := path.Child("rules").Index(i)
+		if names.Has(rule.Name) {
+			errs = append(errs, field.Duplicate(rulePath.Child("name"), rule.Name))
+		}
+		names.Insert(rule.Name)
+		errs = append(errs, rule.Validate(rulePath)...)
+	}
+	return errs
+}
+
+// ValidatingRule validates the payloads it matches.
+type ValidatingRule struct {
+	// Name is a label to identify the rule, it must be unique within the policy.
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// Match selects the payloads the rule applies to, all payloads are selected when not set.
+	// +optional
+	Match *PayloadMatch `json:"match,omitempty"`
+
+	// Exclude skips the payloads the rule would apply to.
+	// +optional
+	Exclude *PayloadMatch `json:"exclude,omitempty"`
+
+	// Preconditions are conditions on the payload, referenced with the `payload` variable,
+	// that must be met for the rule to be applied.
+	// +optional
+	Preconditions *kyvernov1.AnyAllConditions `json:"preconditions,omitempty"`
+
+	// Validation declares the checks applied to the payload.
+	Validation PayloadValidation `json:"validate"`
+}
+
+// Validate implements programmatic validation
+func (r *ValidatingRule) Validate(path *field.Path) (errs field.ErrorList) {
+	if r.Name == "" {
+		errs = append(errs, field.Required(path.Child("name"), "a rule name is required"))
+	}
+	if r.Match != nil {
+		errs = append(errs, r.Match.Validate(path.Child("match"))...)
+	}
+	if r.Exclude != nil {
+		errs = append(errs, r.Exclude.Validate(path.Child("exclude"))...)
+	}
+	errs = append(errs, validateConditions(path.Child("preconditions"), r.Preconditions)...)
+	errs = append(errs, r.Validation.Validate(path.Child("validate"))...)
+	return errs
+}
+
+// PayloadMatch selects payloads with patterns, using the same syntax as validation patterns.
+// Exactly one of Any or All must be set.
+type PayloadMatch struct {
+	// Any selects the payloads matching at least one of the patterns.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Any []apiextv1.JSON `json:"any,omitempty"`
+
+	// All selects the payloads matching all of the patterns.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	All []apiextv1.JSON `json:"all,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (m *PayloadMatch) Validate(path *field.Path) (errs field.ErrorList) {
+	if (len(m.Any) == 0) == (len(m.All) == 0) {
+		errs = append(errs, field.Invalid(path, m, "exactly one of any or all must be set"))
+	}
+	return errs
+}
+
+// PayloadValidation declares the checks applied to a payload, exactly one of Pattern, AnyPattern or Deny must be set.
+type PayloadValidation struct {
+	// Message specifies a custom message to be displayed on failure.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Pattern specifies an overlay-style pattern the payload must match.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Pattern *apiextv1.JSON `json:"pattern,omitempty"`
+
+	// AnyPattern specifies a list of patterns, the payload must match at least one of them.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	AnyPattern []apiextv1.JSON `json:"anyPattern,omitempty"`
+
+	// Deny defines conditions on the payload, referenced with the `payload` variable, that fail the validation when met.
+	// +optional
+	Deny *kyvernov1.AnyAllConditions `json:"deny,omitempty"`
+}
+
+// Validate implements programmatic validation
+func (v *PayloadValidation) Validate(path *field.Path) (errs field.ErrorList) {
+	count := 0
+	if v.Pattern != nil {
+		count++
+	}
+	if len(v.AnyPattern) != 0 {
+		count++
+	}
+	if v.Deny != nil {
+		count++
+	}
+	if count != 1 {
+		errs = append(errs, field.Invalid(path, v, "exactly one of pattern, anyPattern or deny must be set"))
+	}
+	errs = append(errs, validateConditions(path.Child("deny"), v.Deny)...)
+	return errs
+}
+
+func validateConditions(path *field.Path, conditions *kyvernov1.AnyAllConditions) (errs field.ErrorList) {
+	if conditions != nil && len(conditions.Templates) != 0 {
+		errs = append(errs, field.Forbidden(path.Child("templates"), "condition templates are not supported by payload policies"))
+	}
+	return errs
+}
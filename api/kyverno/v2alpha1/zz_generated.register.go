@@ -60,10 +60,32 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&CleanupPolicy{},
 		&CleanupPolicyList{},
+		&CleanupPreview{},
+		&CleanupPreviewList{},
+		&CleanupReport{},
+		&CleanupReportList{},
+		&ClusterBaseline{},
+		&ClusterBaselineList{},
 		&ClusterCleanupPolicy{},
 		&ClusterCleanupPolicyList{},
+		&ClusterComplianceSummary{},
+		&ClusterComplianceSummaryList{},
+		&ComplianceScan{},
+		&ComplianceScanList{},
+		&ExclusionPolicy{},
+		&ExclusionPolicyList{},
+		&PolicyBinding{},
+		&PolicyBindingList{},
 		&PolicyException{},
 		&PolicyExceptionList{},
+		&PolicyProfile{},
+		&PolicyProfileList{},
+		&PolicySet{},
+		&PolicySetList{},
+		&PolicySimulation{},
+		&PolicySimulationList{},
+		&ValidatingPolicy{},
+		&ValidatingPolicyList{},
 	)
 	// AddToGroupVersion allows the serialization of client types like ListOptions.
 	v1.AddToGroupVersion(scheme, SchemeGroupVersion)
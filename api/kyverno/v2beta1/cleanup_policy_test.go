@@ -710,3 +710,91 @@ func Test_doesMatchExcludeConflict_cleanupPolicy(t *testing.T) {
 		})
 	}
 }
+
+func Test_CleanupPolicy_OlderThan(t *testing.T) {
+	subject := CleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-policy",
+		},
+		Spec: CleanupPolicySpec{
+			Schedule: "* * * * *",
+			OlderThan: &CleanupAge{
+				Duration: "seven days",
+			},
+		},
+	}
+	errs := subject.Validate(nil)
+	assert.Assert(t, len(errs) == 1)
+	assert.Equal(t, errs[0].Field, "spec.olderThan.duration")
+	subject.Spec.OlderThan.Duration = "7d"
+	assert.Assert(t, len(subject.Validate(nil)) == 0)
+}
+
+func Test_CleanupPolicy_PreDelete(t *testing.T) {
+	subject := CleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-policy",
+		},
+		Spec: CleanupPolicySpec{
+			Schedule: "* * * * *",
+			PreDelete: &CleanupPreDelete{
+				Event: true,
+				Webhook: &CleanupWebhook{
+					URL: "audit.example.com/cleanup",
+				},
+			},
+		},
+	}
+	errs := subject.Validate(nil)
+	assert.Assert(t, len(errs) == 1)
+	assert.Equal(t, errs[0].Field, "spec.preDelete.webhook.url")
+	subject.Spec.PreDelete.Webhook.URL = "https://audit.example.com/cleanup"
+	assert.Assert(t, len(subject.Validate(nil)) == 0)
+}
+
+func Test_CleanupPolicy_RateLimit(t *testing.T) {
+	subject := CleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-policy",
+		},
+		Spec: CleanupPolicySpec{
+			Schedule: "* * * * *",
+			RateLimit: &CleanupRateLimit{
+				Burst:     10,
+				BatchSize: 100,
+			},
+		},
+	}
+	errs := subject.Validate(nil)
+	assert.Assert(t, len(errs) == 1)
+	assert.Equal(t, errs[0].Field, "spec.rateLimit.burst")
+	subject.Spec.RateLimit.DeletionsPerSecond = 5
+	assert.Assert(t, len(subject.Validate(nil)) == 0)
+	assert.Equal(t, subject.Spec.RateLimit.GetBurst(), 10)
+	subject.Spec.RateLimit.Burst = 0
+	assert.Equal(t, subject.Spec.RateLimit.GetBurst(), 5)
+}
+
+func Test_CleanupPolicy_Action(t *testing.T) {
+	subject := CleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-policy",
+		},
+		Spec: CleanupPolicySpec{
+			Schedule: "0 20 * * *",
+			Action:   CleanupActionAnnotate,
+		},
+	}
+	assert.Equal(t, (&CleanupPolicySpec{}).GetAction(), CleanupActionDelete)
+	errs := subject.Validate(nil)
+	assert.Assert(t, len(errs) == 1)
+	assert.Equal(t, errs[0].Field, "spec.annotations")
+	subject.Spec.Annotations = map[string]string{"example.com/parked": "true"}
+	assert.Assert(t, len(subject.Validate(nil)) == 0)
+	subject.Spec.Action = CleanupActionScaleToZero
+	subject.Spec.PreDelete = &CleanupPreDelete{Event: true}
+	errs = subject.Validate(nil)
+	assert.Assert(t, len(errs) == 2)
+	assert.Equal(t, errs[0].Field, "spec.annotations")
+	assert.Equal(t, errs[1].Field, "spec.preDelete")
+}
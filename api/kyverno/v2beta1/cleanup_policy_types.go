@@ -17,6 +17,7 @@ limitations under the License.
 package v2beta1
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/aptible/supercronic/cronexpr"
@@ -26,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
 )
 
 // +genclient
@@ -224,6 +226,207 @@ type CleanupPolicySpec struct {
 	// Conditions defines the conditions used to select the resources which will be cleaned up.
 	// +optional
 	Conditions *AnyAllConditions `json:"conditions,omitempty"`
+
+	// OlderThan restricts the cleanup to the resources older than the given age.
+	// +optional
+	OlderThan *CleanupAge `json:"olderThan,omitempty"`
+
+	// DryRun prevents the policy from deleting resources.
+	// The resources that would be deleted on the next execution are listed in a CleanupPreview instead.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Action is the action performed on the selected resources, defaults to delete.
+	// scaleToZero sets the replicas of scalable resources like Deployments and StatefulSets to zero and remembers them,
+	// restore brings back the replicas of the resources previously scaled to zero, typically from a companion policy,
+	// annotate adds the annotations to the resources.
+	// +optional
+	Action CleanupAction `json:"action,omitempty"`
+
+	// Annotations are added to the selected resources by the annotate action.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// PreDelete declares actions performed before each resource is deleted, for audit purposes.
+	// +optional
+	PreDelete *CleanupPreDelete `json:"preDelete,omitempty"`
+
+	// RateLimit limits the rate at which the policy deletes resources.
+	// The global limits configured on the cleanup controller apply as well.
+	// +optional
+	RateLimit *CleanupRateLimit `json:"rateLimit,omitempty"`
+}
+
+// CleanupAction is the action performed on the resources selected by a cleanup policy.
+// +kubebuilder:validation:Enum=delete;scaleToZero;restore;annotate
+type CleanupAction string
+
+const (
+	// CleanupActionDelete deletes the resources
+	CleanupActionDelete CleanupAction = "delete"
+	// CleanupActionScaleToZero sets the replicas to zero, the previous replicas are stored in an annotation
+	CleanupActionScaleToZero CleanupAction = "scaleToZero"
+	// CleanupActionRestore sets the replicas stored by scaleToZero back
+	CleanupActionRestore CleanupAction = "restore"
+	// CleanupActionAnnotate adds annotations to the resources
+	CleanupActionAnnotate CleanupAction = "annotate"
+)
+
+// GetAction returns the action performed on the selected resources
+func (p *CleanupPolicySpec) GetAction() CleanupAction {
+	if p.Action == "" {
+		return CleanupActionDelete
+	}
+	return p.Action
+}
+
+// ValidateAction checks the fields of the spec are consistent with the action
+func (p *CleanupPolicySpec) ValidateAction(path *field.Path) (errs field.ErrorList) {
+	action := p.GetAction()
+	if action == CleanupActionAnnotate && len(p.Annotations) == 0 {
+		errs = append(errs, field.Required(path.Child("annotations"), "annotations are required by the annotate action"))
+	}
+	if action != CleanupActionAnnotate && len(p.Annotations) != 0 {
+		errs = append(errs, field.Forbidden(path.Child("annotations"), "annotations are only supported by the annotate action"))
+	}
+	if action != CleanupActionDelete && p.PreDelete != nil {
+		errs = append(errs, field.Forbidden(path.Child("preDelete"), "pre-delete actions are only supported by the delete action"))
+	}
+	return errs
+}
+
+// CleanupPreDelete declares the actions performed before deleting a resource.
+type CleanupPreDelete struct {
+	// Event emits an event regarding the resource before deleting it.
+	// +optional
+	Event bool `json:"event,omitempty"`
+
+	// Annotations are added to the resource before deleting it.
+	// The cleanup controller needs the permission to patch the resources.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Webhook is called with the resource before deleting it.
+	// The resource is not deleted when the call fails or returns a non 2xx status code.
+	// +optional
+	Webhook *CleanupWebhook `json:"webhook,omitempty"`
+}
+
+// CleanupWebhook declares an HTTP endpoint called before deleting a resource.
+// TLS, proxies and timeouts are configured with the outbound transport settings of the Kyverno ConfigMap.
+type CleanupWebhook struct {
+	// URL is the http or https URL receiving the POST requests.
+	URL string `json:"url"`
+}
+
+// Validate implements programmatic validation
+func (h *CleanupPreDelete) Validate(path *field.Path) (errs field.ErrorList) {
+	if h == nil || h.Webhook == nil {
+		return errs
+	}
+	if u, err := url.Parse(h.Webhook.URL); err != nil {
+		errs = append(errs, field.Invalid(path.Child("webhook", "url"), h.Webhook.URL, err.Error()))
+	} else if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, field.Invalid(path.Child("webhook", "url"), h.Webhook.URL, "an absolute http or https URL is required"))
+	}
+	return errs
+}
+
+// CleanupRateLimit limits the deletions performed by a cleanup policy.
+type CleanupRateLimit struct {
+	// DeletionsPerSecond is the maximum sustained number of deletions per second, unlimited when not set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	DeletionsPerSecond int `json:"deletionsPerSecond,omitempty"`
+
+	// Burst is the maximum number of deletions performed at once, defaults to DeletionsPerSecond.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Burst int `json:"burst,omitempty"`
+
+	// BatchSize is the maximum number of resources deleted in a single run, unlimited when not set.
+	// The remaining resources are deleted in the following runs, shortly after the batch completes.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	BatchSize int `json:"batchSize,omitempty"`
+}
+
+// GetBurst returns the maximum number of deletions performed at once
+func (l *CleanupRateLimit) GetBurst() int {
+	if l.Burst == 0 {
+		return l.DeletionsPerSecond
+	}
+	return l.Burst
+}
+
+// Validate implements programmatic validation
+func (l *CleanupRateLimit) Validate(path *field.Path) (errs field.ErrorList) {
+	if l == nil {
+		return errs
+	}
+	if l.DeletionsPerSecond < 0 {
+		errs = append(errs, field.Invalid(path.Child("deletionsPerSecond"), l.DeletionsPerSecond, "must not be negative"))
+	}
+	if l.Burst < 0 {
+		errs = append(errs, field.Invalid(path.Child("burst"), l.Burst, "must not be negative"))
+	} else if l.Burst != 0 && l.DeletionsPerSecond == 0 {
+		errs = append(errs, field.Invalid(path.Child("burst"), l.Burst, "requires deletionsPerSecond to be set"))
+	}
+	if l.BatchSize < 0 {
+		errs = append(errs, field.Invalid(path.Child("batchSize"), l.BatchSize, "must not be negative"))
+	}
+	return errs
+}
+
+// CleanupAgeReference is the point in time the age of a resource is computed from.
+// +kubebuilder:validation:Enum=Creation;Completion;LastTransition
+type CleanupAgeReference string
+
+const (
+	// CleanupAgeCreation computes the age from the resource creation timestamp
+	CleanupAgeCreation CleanupAgeReference = "Creation"
+	// CleanupAgeCompletion computes the age from the resource status.completionTime
+	CleanupAgeCompletion CleanupAgeReference = "Completion"
+	// CleanupAgeLastTransition computes the age from the most recent status.conditions[].lastTransitionTime
+	CleanupAgeLastTransition CleanupAgeReference = "LastTransition"
+)
+
+// CleanupAge selects resources based on their age.
+type CleanupAge struct {
+	// Duration is the minimum age of the resources.
+	// Days (d) and weeks (w) are supported in addition to the Go duration units, for example 7d or 1w12h.
+	Duration string `json:"duration"`
+
+	// Since is the point in time the age is computed from, defaults to Creation.
+	// Resources without the corresponding timestamp, like running Jobs for Completion, are not selected.
+	// +optional
+	Since CleanupAgeReference `json:"since,omitempty"`
+}
+
+// GetDuration parses the minimum age of the resources
+func (a *CleanupAge) GetDuration() (time.Duration, error) {
+	return strfmt.ParseDuration(a.Duration)
+}
+
+// GetSince returns the point in time the age is computed from
+func (a *CleanupAge) GetSince() CleanupAgeReference {
+	if a.Since == "" {
+		return CleanupAgeCreation
+	}
+	return a.Since
+}
+
+// Validate implements programmatic validation
+func (a *CleanupAge) Validate(path *field.Path) (errs field.ErrorList) {
+	if a == nil {
+		return errs
+	}
+	if d, err := a.GetDuration(); err != nil {
+		errs = append(errs, field.Invalid(path.Child("duration"), a.Duration, err.Error()))
+	} else if d <= 0 {
+		errs = append(errs, field.Invalid(path.Child("duration"), a.Duration, "duration must be positive"))
+	}
+	return errs
 }
 
 // CleanupPolicyStatus stores the status of the policy.
@@ -237,6 +440,11 @@ func (p *CleanupPolicySpec) Validate(path *field.Path, clusterResources sets.Set
 	// Write context validation code here by following other validations.
 	errs = append(errs, ValidateContext(path.Child("context"), p.Context)...)
 	errs = append(errs, ValidateSchedule(path.Child("schedule"), p.Schedule)...)
+	errs = append(errs, p.Conditions.ValidateNoTemplates(path.Child("conditions"))...)
+	errs = append(errs, p.OlderThan.Validate(path.Child("olderThan"))...)
+	errs = append(errs, p.ValidateAction(path)...)
+	errs = append(errs, p.PreDelete.Validate(path.Child("preDelete"))...)
+	errs = append(errs, p.RateLimit.Validate(path.Child("rateLimit"))...)
 	if userInfoErrs := p.MatchResources.ValidateNoUserInfo(path.Child("match")); len(userInfoErrs) != 0 {
 		errs = append(errs, userInfoErrs...)
 	} else {
@@ -4,6 +4,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // Validation defines checks to be performed on matching resources.
@@ -41,6 +42,10 @@ type Validation struct {
 	// CEL allows validation checks using the Common Expression Language (https://kubernetes.io/docs/reference/using-api/cel/).
 	// +optional
 	CEL *kyvernov1.CEL `json:"cel,omitempty" yaml:"cel,omitempty"`
+
+	// WASM allows validation checks implemented by a WebAssembly module.
+	// +optional
+	WASM *kyvernov1.WASM `json:"wasm,omitempty" yaml:"wasm,omitempty"`
 }
 
 // ConditionOperator is the operation performed on condition key and value.
@@ -135,6 +140,33 @@ type AnyAllConditions struct {
 	// Here, all of the conditions need to pass.
 	// +optional
 	AllConditions []Condition `json:"all,omitempty" yaml:"all,omitempty"`
+
+	// Templates references condition templates declared in the policy `conditionTemplates`.
+	// The `any` and `all` conditions of the referenced templates are merged into this block.
+	// A block holds a single `any` group, a template with `any` conditions can't be merged into
+	// a block that already has `any` conditions. Templates are only supported in policy rules.
+	// +optional
+	Templates []string `json:"templates,omitempty" yaml:"templates,omitempty"`
+}
+
+// ValidateNoTemplates checks that the conditions don't reference condition templates,
+// they are only supported in policy rules.
+func (c *AnyAllConditions) ValidateNoTemplates(path *field.Path) (errs field.ErrorList) {
+	if c != nil && len(c.Templates) != 0 {
+		errs = append(errs, field.Forbidden(path.Child("templates"), "condition templates are only supported in policy rules"))
+	}
+	return errs
+}
+
+// ConditionTemplate is a named group of conditions declared once in a policy and shared
+// by the preconditions and deny conditions of its rules.
+type ConditionTemplate struct {
+	// Name is the name used to reference the template.
+	Name string `json:"name" yaml:"name"`
+
+	// Conditions are the conditions merged into the blocks referencing the template.
+	// A template can reference other templates.
+	Conditions AnyAllConditions `json:"conditions" yaml:"conditions"`
 }
 
 // ResourceFilters is a slice of ResourceFilter
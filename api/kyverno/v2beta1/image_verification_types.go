@@ -59,6 +59,11 @@ type ImageVerification struct {
 	// +kubebuilder:default=true
 	// +kubebuilder:validation:Optional
 	UseCache bool `json:"useCache" yaml:"useCache"`
+
+	// StampVerification adds a kyverno.io/verified-images annotation, HMAC-signed by Kyverno, recording
+	// the digest, the attestors and the time of verification of the images verified by this rule.
+	// +kubebuilder:validation:Optional
+	StampVerification bool `json:"stampVerification,omitempty" yaml:"stampVerification,omitempty"`
 }
 
 // Validate implements programmatic validation
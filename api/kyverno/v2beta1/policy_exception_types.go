@@ -16,9 +16,13 @@ limitations under the License.
 package v2beta1
 
 import (
+	"time"
+
 	"github.com/kyverno/kyverno/ext/wildcard"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
 )
 
 // +genclient
@@ -47,6 +51,35 @@ func (p *PolicyException) Contains(policy string, rule string) bool {
 	return p.Spec.Contains(policy, rule)
 }
 
+// GetExpirationTime returns the time after which the exception no longer applies, nil if it doesn't expire
+func (p *PolicyException) GetExpirationTime() (*time.Time, error) {
+	var expiration *time.Time
+	if p.Spec.ExpiresAt != nil {
+		expiresAt := p.Spec.ExpiresAt.Time
+		expiration = &expiresAt
+	}
+	if p.Spec.TTL != "" {
+		ttl, err := strfmt.ParseDuration(p.Spec.TTL)
+		if err != nil {
+			return nil, err
+		}
+		expiresAt := p.GetCreationTimestamp().Add(ttl)
+		if expiration == nil || expiresAt.Before(*expiration) {
+			expiration = &expiresAt
+		}
+	}
+	return expiration, nil
+}
+
+// IsExpired returns true if the exception expired at the given time, exceptions with an invalid ttl don't expire
+func (p *PolicyException) IsExpired(now time.Time) bool {
+	expiration, err := p.GetExpirationTime()
+	if err != nil || expiration == nil {
+		return false
+	}
+	return !now.Before(*expiration)
+}
+
 // PolicyExceptionSpec stores policy exception spec
 type PolicyExceptionSpec struct {
 	// Background controls if exceptions are applied to existing policies during a background scan.
@@ -62,8 +95,34 @@ type PolicyExceptionSpec struct {
 	// +optional
 	Conditions *AnyAllConditions `json:"conditions,omitempty"`
 
+	// Images restricts the exception to resources whose images all match one of the image reference
+	// patterns, for example `ghcr.io/example/controller:*`. Wildcards are supported.
+	// Resources without images don't match when images are set.
+	// +optional
+	Images []string `json:"images,omitempty" yaml:"images,omitempty"`
+
+	// NamespaceSelector restricts the exception to resources in namespaces matching the label selector,
+	// so that a class of namespaces can be exempted with a single exception. Namespaces are matched
+	// against their own labels and other cluster scoped resources don't match when it is set.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty" yaml:"namespaceSelector,omitempty"`
+
 	// Exceptions is a list policy/rules to be excluded
 	Exceptions []Exception `json:"exceptions" yaml:"exceptions"`
+
+	// ExpiresAt is the time after which the exception no longer applies.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty" yaml:"expiresAt,omitempty"`
+
+	// TTL is the time to live of the exception, computed from its creation.
+	// Days (d) and weeks (w) are supported in addition to the Go duration units, for example 30d.
+	// When both expiresAt and ttl are set the earliest expiration applies.
+	// +optional
+	TTL string `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+
+	// DeleteOnExpiry deletes the exception once it expired, expired exceptions are only marked inactive otherwise.
+	// +optional
+	DeleteOnExpiry bool `json:"deleteOnExpiry,omitempty" yaml:"deleteOnExpiry,omitempty"`
 }
 
 func (p *PolicyExceptionSpec) BackgroundProcessingEnabled() bool {
@@ -81,10 +140,31 @@ func (p *PolicyExceptionSpec) Validate(path *field.Path) (errs field.ErrorList)
 		}
 	}
 	errs = append(errs, p.Match.Validate(path.Child("match"), false, nil)...)
+	errs = append(errs, p.Conditions.ValidateNoTemplates(path.Child("conditions"))...)
+	for i, image := range p.Images {
+		if image == "" {
+			errs = append(errs, field.Invalid(path.Child("images").Index(i), image, "image reference pattern must not be empty"))
+		}
+	}
+	if p.NamespaceSelector != nil && !kubeutils.LabelSelectorContainsWildcard(p.NamespaceSelector) {
+		if _, err := metav1.LabelSelectorAsSelector(p.NamespaceSelector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("namespaceSelector"), p.NamespaceSelector, err.Error()))
+		}
+	}
 	exceptionsPath := path.Child("exceptions")
 	for i, e := range p.Exceptions {
 		errs = append(errs, e.Validate(exceptionsPath.Index(i))...)
 	}
+	if p.TTL != "" {
+		if ttl, err := strfmt.ParseDuration(p.TTL); err != nil {
+			errs = append(errs, field.Invalid(path.Child("ttl"), p.TTL, err.Error()))
+		} else if ttl <= 0 {
+			errs = append(errs, field.Invalid(path.Child("ttl"), p.TTL, "ttl must be positive"))
+		}
+	}
+	if p.DeleteOnExpiry && p.ExpiresAt == nil && p.TTL == "" {
+		errs = append(errs, field.Invalid(path.Child("deleteOnExpiry"), p.DeleteOnExpiry, "deleteOnExpiry requires expiresAt or ttl"))
+	}
 	return errs
 }
 
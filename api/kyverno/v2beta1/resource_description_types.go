@@ -6,6 +6,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -47,6 +48,20 @@ type ResourceDescription struct {
 	// +optional
 	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty" yaml:"namespaceSelector,omitempty"`
 
+	// FieldSelector is a comma separated list of field requirements evaluated against the resource,
+	// using the Kubernetes field selector syntax (e.g. "spec.nodeName=node-1,status.phase!=Running").
+	// Fields are dot separated paths in the resource, values support the wildcard characters
+	// "*" (matches zero or many characters) and "?" (at least one character).
+	// A missing field matches the empty value.
+	// +optional
+	FieldSelector string `json:"fieldSelector,omitempty" yaml:"fieldSelector,omitempty"`
+
+	// Owner matches resources by their owners, resolved through the chain of controller owner references
+	// (e.g. the pods owned by a CronJob through a Job). The resource matches when an owner in the chain
+	// matches all the owner criteria.
+	// +optional
+	Owner *kyvernov1.OwnerDescription `json:"owner,omitempty" yaml:"owner,omitempty"`
+
 	// Operations can contain values ["CREATE, "UPDATE", "CONNECT", "DELETE"], which are used to match a specific action.
 	// +optional
 	Operations []kyvernov1.AdmissionOperation `json:"operations,omitempty" yaml:"operations,omitempty"`
@@ -75,6 +90,14 @@ func (r *ResourceDescription) Validate(path *field.Path, namespaced bool, cluste
 			}
 		}
 	}
+	if r.FieldSelector != "" {
+		if _, err := fields.ParseSelector(r.FieldSelector); err != nil {
+			errs = append(errs, field.Invalid(path.Child("fieldSelector"), r.FieldSelector, err.Error()))
+		}
+	}
+	if r.Owner != nil {
+		errs = append(errs, r.Owner.Validate(path.Child("owner"))...)
+	}
 	if namespaced {
 		if len(r.Namespaces) > 0 {
 			errs = append(errs, field.Forbidden(path.Child("namespaces"), "Filtering namespaces not allowed in namespaced policies"))
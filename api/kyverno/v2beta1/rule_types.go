@@ -6,6 +6,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -22,6 +23,12 @@ type Rule struct {
 	// +optional
 	Context []kyvernov1.ContextEntry `json:"context,omitempty" yaml:"context,omitempty"`
 
+	// Prefetch declares data that the background scanner loads in bulk before evaluating the rule
+	// against individual resources. API calls and ConfigMap lookups targeting prefetched data are
+	// served from the prefetched copy instead of reaching the API server for every resource.
+	// +optional
+	Prefetch *kyvernov1.Prefetch `json:"prefetch,omitempty" yaml:"prefetch,omitempty"`
+
 	// MatchResources defines when this policy rule should be applied. The match
 	// criteria can include resource information (e.g. kind, name, namespace, labels)
 	// and admission review request information like the user name or role.
@@ -58,6 +65,11 @@ type Rule struct {
 	// +optional
 	Validation Validation `json:"validate,omitempty" yaml:"validate,omitempty"`
 
+	// Remediation is a message explaining how to fix a violation of the rule.
+	// It is added to policy report results and can contain variables.
+	// +optional
+	Remediation string `json:"remediation,omitempty" yaml:"remediation,omitempty"`
+
 	// Generation is used to create new resources.
 	// +optional
 	Generation kyvernov1.Generation `json:"generate,omitempty" yaml:"generate,omitempty"`
@@ -72,6 +84,22 @@ type Rule struct {
 	// +kubebuilder:default=true
 	// +kubebuilder:validation:Optional
 	SkipBackgroundRequests bool `json:"skipBackgroundRequests,omitempty" yaml:"skipBackgroundRequests,omitempty"`
+
+	// Timeout limits the time spent evaluating the rule, including the context entries, API calls
+	// and image registry requests it performs. When the timeout expires the evaluation is cancelled
+	// and the rule is reported according to TimeoutFailurePolicy.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	// TimeoutFailurePolicy defines how a rule that timed out is reported. Fail reports the rule as
+	// errored and Ignore reports it as a warning, which never blocks the admission request.
+	// Defaults to the failure policy of the policy.
+	// +optional
+	TimeoutFailurePolicy *kyvernov1.FailurePolicyType `json:"timeoutFailurePolicy,omitempty" yaml:"timeoutFailurePolicy,omitempty"`
+
+	// Autogen controls the rules auto generated from this rule for pod controllers.
+	// +optional
+	Autogen *kyvernov1.RuleAutogen `json:"autogen,omitempty" yaml:"autogen,omitempty"`
 }
 
 // HasMutate checks for mutate rule
@@ -114,6 +142,11 @@ func (r *Rule) HasValidateCEL() bool {
 	return r.Validation.CEL != nil && !datautils.DeepEqual(r.Validation.CEL, &kyvernov1.CEL{})
 }
 
+// HasValidateWASM checks for validate.wasm rule
+func (r *Rule) HasValidateWASM() bool {
+	return r.Validation.WASM != nil && !datautils.DeepEqual(r.Validation.WASM, &kyvernov1.WASM{})
+}
+
 // HasValidate checks for validate rule
 func (r *Rule) HasValidate() bool {
 	return !datautils.DeepEqual(r.Validation, Validation{})
@@ -190,6 +223,17 @@ func (r *Rule) ValidateGenerate(path *field.Path, namespaced bool, policyNamespa
 	return r.Generation.Validate(path, namespaced, policyNamespace, clusterResources)
 }
 
+// ValidateTimeout checks the timeout of the rule
+func (r *Rule) ValidateTimeout(path *field.Path) (errs field.ErrorList) {
+	if r.Timeout != nil && r.Timeout.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("timeout"), r.Timeout.Duration.String(), "timeout must be greater than zero"))
+	}
+	if r.Timeout == nil && r.TimeoutFailurePolicy != nil {
+		errs = append(errs, field.Forbidden(path.Child("timeoutFailurePolicy"), "timeoutFailurePolicy requires a timeout"))
+	}
+	return errs
+}
+
 // Validate implements programmatic validation
 func (r *Rule) Validate(path *field.Path, namespaced bool, policyNamespace string, clusterResources sets.Set[string]) (errs field.ErrorList) {
 	errs = append(errs, r.ValidateRuleType(path)...)
@@ -197,5 +241,6 @@ func (r *Rule) Validate(path *field.Path, namespaced bool, policyNamespace strin
 	errs = append(errs, r.MatchResources.Validate(path.Child("match"), namespaced, clusterResources)...)
 	errs = append(errs, r.ExcludeResources.Validate(path.Child("exclude"), namespaced, clusterResources)...)
 	errs = append(errs, r.ValidateGenerate(path, namespaced, policyNamespace, clusterResources)...)
+	errs = append(errs, r.ValidateTimeout(path)...)
 	return errs
 }
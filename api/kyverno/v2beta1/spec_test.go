@@ -53,3 +53,31 @@ func Test_Validate_UniqueRuleName(t *testing.T) {
 	assert.Equal(t, errs[0].Type, field.ErrorTypeInvalid)
 	assert.Equal(t, errs[0].Detail, "Duplicate rule name: 'deny-privileged-disallowpriviligedescalation'")
 }
+
+func Test_Validate_UniqueConditionTemplateName(t *testing.T) {
+	subject := Spec{
+		ConditionTemplates: []ConditionTemplate{{
+			Name: "is-production",
+		}, {
+			Name: "is-production",
+		}, {}},
+	}
+	path := field.NewPath("dummy")
+	errs := subject.ValidateConditionTemplates(path.Child("conditionTemplates"))
+	assert.Equal(t, len(errs), 2)
+	assert.Equal(t, errs[0].Field, "dummy.conditionTemplates[1].name")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeInvalid)
+	assert.Equal(t, errs[0].Detail, "Duplicate condition template name: 'is-production'")
+	assert.Equal(t, errs[1].Field, "dummy.conditionTemplates[2].name")
+	assert.Equal(t, errs[1].Type, field.ErrorTypeRequired)
+}
+
+func Test_Validate_NoConditionTemplates(t *testing.T) {
+	var conditions *AnyAllConditions
+	assert.Equal(t, len(conditions.ValidateNoTemplates(field.NewPath("conditions"))), 0)
+	conditions = &AnyAllConditions{Templates: []string{"is-production"}}
+	errs := conditions.ValidateNoTemplates(field.NewPath("conditions"))
+	assert.Equal(t, len(errs), 1)
+	assert.Equal(t, errs[0].Field, "conditions.templates")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeForbidden)
+}
@@ -14,6 +14,11 @@ type Spec struct {
 	// each rule can validate, mutate, or generate resources.
 	Rules []Rule `json:"rules,omitempty" yaml:"rules,omitempty"`
 
+	// ConditionTemplates declares named groups of conditions that rules can reference from their
+	// preconditions and deny conditions with `templates`, instead of repeating the same conditions.
+	// +optional
+	ConditionTemplates []ConditionTemplate `json:"conditionTemplates,omitempty" yaml:"conditionTemplates,omitempty"`
+
 	// ApplyRules controls how rules in a policy are applied. Rule are processed in
 	// the order of declaration. When set to `One` processing stops after a rule has
 	// been applied i.e. the rule matches and results in a pass, fail, or error. When
@@ -27,6 +32,13 @@ type Spec struct {
 	// +optional
 	FailurePolicy *kyvernov1.FailurePolicyType `json:"failurePolicy,omitempty" yaml:"failurePolicy,omitempty"`
 
+	// ReinvocationPolicy defines if the mutating webhook applying the policy is called again when other
+	// admission plugins modify the object. Patches already applied are skipped when the policy is reinvoked,
+	// and non idempotent JSON patch operations are rejected when IfNeeded is set explicitly.
+	// Allowed values are Never or IfNeeded. Defaults to IfNeeded.
+	// +optional
+	ReinvocationPolicy *kyvernov1.ReinvocationPolicyType `json:"reinvocationPolicy,omitempty" yaml:"reinvocationPolicy,omitempty"`
+
 	// ValidationFailureAction defines if a validation policy rule violation should block
 	// the admission review request (enforce), or allow (audit) the admission review request
 	// and report an error in a policy report. Optional.
@@ -41,6 +53,33 @@ type Spec struct {
 	// +optional
 	ValidationFailureActionOverrides []kyvernov1.ValidationFailureActionOverride `json:"validationFailureActionOverrides,omitempty" yaml:"validationFailureActionOverrides,omitempty"`
 
+	// Schedule restricts the enforcement of the policy to time windows, outside of the windows validation
+	// failures are reported as if ValidationFailureAction was Audit. Schedule is a Cluster Policy attribute.
+	// +optional
+	Schedule *kyvernov1.PolicySchedule `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+
+	// EmitWarning enables admission response warnings for the validation failures of the policy
+	// when it runs in Audit mode, it lets users see failures at apply time without being blocked.
+	// Optional. Default value is "false".
+	// +optional
+	EmitWarning *bool `json:"emitWarning,omitempty" yaml:"emitWarning,omitempty"`
+
+	// Ordering declares the order in which the policy is applied relative to other policies and the
+	// policies it is mutually exclusive with. Policies without ordering constraints are applied in name order.
+	// +optional
+	Ordering *kyvernov1.PolicyOrdering `json:"ordering,omitempty" yaml:"ordering,omitempty"`
+
+	// Parameters references the resource holding the parameters of the policy, available to its rules
+	// in the `params` variable.
+	// +optional
+	Parameters *kyvernov1.PolicyParameters `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+
+	// ServiceAccount is impersonated by the background controller when it applies the generate and mutate
+	// existing rules of the policy, the permissions to manage the targets are granted to the ServiceAccount
+	// instead of the background controller. The background controller must be allowed to impersonate it.
+	// +optional
+	ServiceAccount *kyvernov1.ServiceAccountReference `json:"serviceAccount,omitempty" yaml:"serviceAccount,omitempty"`
+
 	// Admission controls if rules are applied during admission.
 	// Optional. Default value is "true".
 	// +optional
@@ -182,6 +221,11 @@ func (s *Spec) BackgroundProcessingEnabled() bool {
 	return *s.Background
 }
 
+// EmitWarningEnabled checks if emitWarning is set to true
+func (s *Spec) EmitWarningEnabled() bool {
+	return s.EmitWarning != nil && *s.EmitWarning
+}
+
 // IsMutateExisting checks if the mutate policy applies to existing resources
 func (s *Spec) IsMutateExisting() bool {
 	for _, rule := range s.Rules {
@@ -213,6 +257,14 @@ func (s *Spec) GetFailurePolicy() kyvernov1.FailurePolicyType {
 	return *s.FailurePolicy
 }
 
+// GetReinvocationPolicy returns the reinvocation policy of the mutating webhook applying the policy
+func (s *Spec) GetReinvocationPolicy() kyvernov1.ReinvocationPolicyType {
+	if s.ReinvocationPolicy == nil {
+		return kyvernov1.IfNeededReinvocationPolicy
+	}
+	return *s.ReinvocationPolicy
+}
+
 // GetFailurePolicy returns the failure policy to be applied
 func (s *Spec) GetApplyRules() kyvernov1.ApplyRulesType {
 	if s.ApplyRules == nil {
@@ -243,6 +295,21 @@ func (s *Spec) ValidateRules(path *field.Path, namespaced bool, policyNamespace
 	return errs
 }
 
+// ValidateConditionTemplates checks if the condition template names are unique across a policy
+func (s *Spec) ValidateConditionTemplates(path *field.Path) (errs field.ErrorList) {
+	names := sets.New[string]()
+	for i, template := range s.ConditionTemplates {
+		templatePath := path.Index(i)
+		if template.Name == "" {
+			errs = append(errs, field.Required(templatePath.Child("name"), "condition template name is required"))
+		} else if names.Has(template.Name) {
+			errs = append(errs, field.Invalid(templatePath.Child("name"), template.Name, fmt.Sprintf(`Duplicate condition template name: '%s'`, template.Name)))
+		}
+		names.Insert(template.Name)
+	}
+	return errs
+}
+
 func (s *Spec) ValidateDeprecatedFields(path *field.Path) (errs field.ErrorList) {
 	if s.GenerateExistingOnPolicyUpdate != nil && s.GenerateExisting {
 		errs = append(errs, field.Forbidden(path.Child("generateExistingOnPolicyUpdate"), "remove the deprecated field and use generateExisting instead"))
@@ -258,9 +325,25 @@ func (s *Spec) Validate(path *field.Path, namespaced bool, policyNamespace strin
 	if s.WebhookTimeoutSeconds != nil && (*s.WebhookTimeoutSeconds < 1 || *s.WebhookTimeoutSeconds > 30) {
 		errs = append(errs, field.Invalid(path.Child("webhookTimeoutSeconds"), s.WebhookTimeoutSeconds, "the timeout value must be between 1 and 30 seconds"))
 	}
+	errs = append(errs, s.ValidateConditionTemplates(path.Child("conditionTemplates"))...)
 	errs = append(errs, s.ValidateRules(path.Child("rules"), namespaced, policyNamespace, clusterResources)...)
 	if namespaced && len(s.ValidationFailureActionOverrides) > 0 {
 		errs = append(errs, field.Forbidden(path.Child("validationFailureActionOverrides"), "Use of validationFailureActionOverrides is supported only with ClusterPolicy"))
 	}
+	if s.Schedule != nil {
+		if namespaced {
+			errs = append(errs, field.Forbidden(path.Child("schedule"), "Use of schedule is supported only with ClusterPolicy"))
+		}
+		errs = append(errs, s.Schedule.Validate(path.Child("schedule"))...)
+	}
+	if s.Ordering != nil {
+		errs = append(errs, s.Ordering.Validate(path.Child("ordering"))...)
+	}
+	if s.Parameters != nil {
+		errs = append(errs, s.Parameters.Validate(path.Child("parameters"), namespaced, policyNamespace)...)
+	}
+	if s.ServiceAccount != nil {
+		errs = append(errs, s.ServiceAccount.Validate(path.Child("serviceAccount"), namespaced, policyNamespace)...)
+	}
 	return errs
 }
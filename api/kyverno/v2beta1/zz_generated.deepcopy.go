@@ -46,6 +46,11 @@ func (in *AnyAllConditions) DeepCopyInto(out *AnyAllConditions) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -59,6 +64,22 @@ func (in *AnyAllConditions) DeepCopy() *AnyAllConditions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupAge) DeepCopyInto(out *CleanupAge) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupAge.
+func (in *CleanupAge) DeepCopy() *CleanupAge {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupAge)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CleanupPolicy) DeepCopyInto(out *CleanupPolicy) {
 	*out = *in
@@ -141,6 +162,28 @@ func (in *CleanupPolicySpec) DeepCopyInto(out *CleanupPolicySpec) {
 		*out = new(AnyAllConditions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OlderThan != nil {
+		in, out := &in.OlderThan, &out.OlderThan
+		*out = new(CleanupAge)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PreDelete != nil {
+		in, out := &in.PreDelete, &out.PreDelete
+		*out = new(CleanupPreDelete)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(CleanupRateLimit)
+		**out = **in
+	}
 	return
 }
 
@@ -178,6 +221,66 @@ func (in *CleanupPolicyStatus) DeepCopy() *CleanupPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupPreDelete) DeepCopyInto(out *CleanupPreDelete) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(CleanupWebhook)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupPreDelete.
+func (in *CleanupPreDelete) DeepCopy() *CleanupPreDelete {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupPreDelete)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupRateLimit) DeepCopyInto(out *CleanupRateLimit) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupRateLimit.
+func (in *CleanupRateLimit) DeepCopy() *CleanupRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CleanupWebhook) DeepCopyInto(out *CleanupWebhook) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CleanupWebhook.
+func (in *CleanupWebhook) DeepCopy() *CleanupWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(CleanupWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterCleanupPolicy) DeepCopyInto(out *ClusterCleanupPolicy) {
 	*out = *in
@@ -326,6 +429,23 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionTemplate) DeepCopyInto(out *ConditionTemplate) {
+	*out = *in
+	in.Conditions.DeepCopyInto(&out.Conditions)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionTemplate.
+func (in *ConditionTemplate) DeepCopy() *ConditionTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Deny) DeepCopyInto(out *Deny) {
 	*out = *in
@@ -540,6 +660,16 @@ func (in *PolicyExceptionSpec) DeepCopyInto(out *PolicyExceptionSpec) {
 		*out = new(AnyAllConditions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Exceptions != nil {
 		in, out := &in.Exceptions, &out.Exceptions
 		*out = make([]Exception, len(*in))
@@ -547,6 +677,10 @@ func (in *PolicyExceptionSpec) DeepCopyInto(out *PolicyExceptionSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -628,6 +762,11 @@ func (in *ResourceDescription) DeepCopyInto(out *ResourceDescription) {
 		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Owner != nil {
+		in, out := &in.Owner, &out.Owner
+		*out = new(v1.OwnerDescription)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Operations != nil {
 		in, out := &in.Operations, &out.Operations
 		*out = make([]v1.AdmissionOperation, len(*in))
@@ -696,6 +835,11 @@ func (in *Rule) DeepCopyInto(out *Rule) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Prefetch != nil {
+		in, out := &in.Prefetch, &out.Prefetch
+		*out = new(v1.Prefetch)
+		(*in).DeepCopyInto(*out)
+	}
 	in.MatchResources.DeepCopyInto(&out.MatchResources)
 	in.ExcludeResources.DeepCopyInto(&out.ExcludeResources)
 	if in.ImageExtractors != nil {
@@ -733,6 +877,21 @@ func (in *Rule) DeepCopyInto(out *Rule) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TimeoutFailurePolicy != nil {
+		in, out := &in.TimeoutFailurePolicy, &out.TimeoutFailurePolicy
+		*out = new(v1.FailurePolicyType)
+		**out = **in
+	}
+	if in.Autogen != nil {
+		in, out := &in.Autogen, &out.Autogen
+		*out = new(v1.RuleAutogen)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -756,6 +915,13 @@ func (in *Spec) DeepCopyInto(out *Spec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ConditionTemplates != nil {
+		in, out := &in.ConditionTemplates, &out.ConditionTemplates
+		*out = make([]ConditionTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ApplyRules != nil {
 		in, out := &in.ApplyRules, &out.ApplyRules
 		*out = new(v1.ApplyRulesType)
@@ -766,6 +932,11 @@ func (in *Spec) DeepCopyInto(out *Spec) {
 		*out = new(v1.FailurePolicyType)
 		**out = **in
 	}
+	if in.ReinvocationPolicy != nil {
+		in, out := &in.ReinvocationPolicy, &out.ReinvocationPolicy
+		*out = new(v1.ReinvocationPolicyType)
+		**out = **in
+	}
 	if in.ValidationFailureActionOverrides != nil {
 		in, out := &in.ValidationFailureActionOverrides, &out.ValidationFailureActionOverrides
 		*out = make([]v1.ValidationFailureActionOverride, len(*in))
@@ -773,6 +944,31 @@ func (in *Spec) DeepCopyInto(out *Spec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(v1.PolicySchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EmitWarning != nil {
+		in, out := &in.EmitWarning, &out.EmitWarning
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Ordering != nil {
+		in, out := &in.Ordering, &out.Ordering
+		*out = new(v1.PolicyOrdering)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = new(v1.PolicyParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(v1.ServiceAccountReference)
+		**out = **in
+	}
 	if in.Admission != nil {
 		in, out := &in.Admission, &out.Admission
 		*out = new(bool)
@@ -851,6 +1047,11 @@ func (in *Validation) DeepCopyInto(out *Validation) {
 		*out = new(v1.CEL)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WASM != nil {
+		in, out := &in.WASM, &out.WASM
+		*out = new(v1.WASM)
+		**out = **in
+	}
 	return
 }
 
@@ -29,23 +29,96 @@
   {{- $flags = append $flags (print "--backgroundScan=" .enabled) -}}
   {{- $flags = append $flags (print "--backgroundScanWorkers=" .backgroundScanWorkers) -}}
   {{- $flags = append $flags (print "--backgroundScanInterval=" .backgroundScanInterval) -}}
+  {{- with .backgroundScanIncremental -}}
+    {{- $flags = append $flags (print "--backgroundScanIncremental=" .) -}}
+  {{- end -}}
+  {{- with .backgroundScanRolloutHistory -}}
+    {{- $flags = append $flags (print "--backgroundScanRolloutHistory=" .) -}}
+  {{- end -}}
+  {{- with .backgroundScanNamespaceConcurrency -}}
+    {{- $flags = append $flags (print "--backgroundScanNamespaceConcurrency=" .) -}}
+  {{- end -}}
+  {{- with .backgroundScanPriorityIntervals -}}
+    {{- $intervals := list -}}
+    {{- range $class, $interval := . -}}
+      {{- $intervals = append $intervals (print $class "=" $interval) -}}
+    {{- end -}}
+    {{- $flags = append $flags (print "--backgroundScanPriorityIntervals=" (join "," $intervals)) -}}
+  {{- end -}}
   {{- $flags = append $flags (print "--skipResourceFilters=" .skipResourceFilters) -}}
 {{- end -}}
 {{- with .configMapCaching -}}
   {{- $flags = append $flags (print "--enableConfigMapCaching=" .enabled) -}}
 {{- end -}}
+{{- with .relatedResources -}}
+  {{- with .kinds -}}
+    {{- $kinds := list -}}
+    {{- range . -}}
+      {{- $kinds = append $kinds (trimPrefix "/" (print .apiGroup "/" .version "/" .kind)) -}}
+    {{- end -}}
+    {{- $flags = append $flags (print "--relatedResources=" (join "," $kinds)) -}}
+  {{- end -}}
+{{- end -}}
+{{- with .ownerResources -}}
+  {{- with .kinds -}}
+    {{- $kinds := list -}}
+    {{- range . -}}
+      {{- $kinds = append $kinds (trimPrefix "/" (print .apiGroup "/" .version "/" .kind)) -}}
+    {{- end -}}
+    {{- $flags = append $flags (print "--ownerResources=" (join "," $kinds)) -}}
+  {{- end -}}
+{{- end -}}
+{{- with .contextCallLimits -}}
+  {{- $flags = append $flags (print "--maxConcurrentContextCalls=" (int .maxConcurrentCalls)) -}}
+  {{- $flags = append $flags (print "--maxConcurrentContextCallsPerRule=" (int .maxConcurrentCallsPerRule)) -}}
+  {{- with .circuitBreaker -}}
+    {{- $flags = append $flags (print "--contextCallCircuitBreaker=" .) -}}
+  {{- end -}}
+{{- end -}}
 {{- with .deferredLoading -}}
   {{- $flags = append $flags (print "--enableDeferredLoading=" .enabled) -}}
 {{- end -}}
 {{- with .dumpPayload -}}
   {{- $flags = append $flags (print "--dumpPayload=" .enabled) -}}
 {{- end -}}
+{{- with .decisionLog -}}
+  {{- with .sink -}}
+    {{- $flags = append $flags (print "--decisionLog=" .) -}}
+  {{- end -}}
+  {{- $flags = append $flags (print "--decisionLogSampling=" (int .sampling)) -}}
+{{- end -}}
+{{- with .admissionRecording -}}
+  {{- with .target -}}
+    {{- $flags = append $flags (print "--admissionRecording=" .) -}}
+  {{- end -}}
+  {{- $flags = append $flags (print "--admissionRecordingSampling=" (int .sampling)) -}}
+{{- end -}}
 {{- with .forceFailurePolicyIgnore -}}
   {{- $flags = append $flags (print "--forceFailurePolicyIgnore=" .enabled) -}}
 {{- end -}}
 {{- with .generateValidatingAdmissionPolicy -}}
   {{- $flags = append $flags (print "--generateValidatingAdmissionPolicy=" .enabled) -}}
 {{- end -}}
+{{- with .shadowMode -}}
+  {{- $flags = append $flags (print "--shadowMode=" .enabled) -}}
+{{- end -}}
+{{- with .reportUpdateDiff -}}
+  {{- $flags = append $flags (print "--reportUpdateDiff=" .enabled) -}}
+{{- end -}}
+{{- with .admissionDeduplication -}}
+  {{- $flags = append $flags (print "--admissionDeduplication=" .enabled) -}}
+{{- end -}}
+{{- with .namespacedPolicyDelegation -}}
+  {{- $flags = append $flags (print "--namespacedPolicyDelegation=" .enabled) -}}
+{{- end -}}
+{{- with .imageVerificationStamp -}}
+  {{- with .secretName -}}
+    {{- $flags = append $flags (print "--imageVerificationStampSecret=" .) -}}
+  {{- end -}}
+{{- end -}}
+{{- with .admissionLatencyBudget -}}
+  {{- $flags = append $flags (print "--admissionLatencyBudget=" .budget) -}}
+{{- end -}}
 {{- with .logging -}}
   {{- $flags = append $flags (print "--loggingFormat=" .format) -}}
   {{- $flags = append $flags (print "--v=" (join "," .verbosity)) -}}
@@ -66,11 +139,49 @@
 {{- end -}}
 {{- with .reports -}}
   {{- $flags = append $flags (print "--reportsChunkSize=" .chunkSize) -}}
+  {{- $flags = append $flags (print "--aggregateReportsByOwner=" .aggregateByOwner) -}}
+  {{- $flags = append $flags (print "--keepPodReportDetails=" .keepPodDetails) -}}
+  {{- $flags = append $flags (print "--aggregateReportsByNamespace=" .aggregateByNamespace) -}}
+  {{- $flags = append $flags (print "--reportsSizeBudget=" (int .sizeBudget)) -}}
+  {{- with .admissionReports -}}
+    {{- $flags = append $flags (print "--admissionReportsTTL=" .ttl) -}}
+    {{- $flags = append $flags (print "--admissionReportsMaxPerNamespace=" (int .maxPerNamespace)) -}}
+    {{- $flags = append $flags (print "--admissionReportsBacklogThreshold=" (int .backlogThreshold)) -}}
+  {{- end -}}
+  {{- with .export -}}
+    {{- with .webhookURL -}}
+      {{- $flags = append $flags (print "--exportWebhookURL=" .) -}}
+    {{- end -}}
+    {{- with .s3 -}}
+      {{- if .bucket -}}
+        {{- $flags = append $flags (print "--exportS3Bucket=" .bucket) -}}
+        {{- $flags = append $flags (print "--exportS3Prefix=" .prefix) -}}
+        {{- $flags = append $flags (print "--exportS3Region=" .region) -}}
+        {{- with .endpoint -}}
+          {{- $flags = append $flags (print "--exportS3Endpoint=" .) -}}
+        {{- end -}}
+      {{- end -}}
+    {{- end -}}
+    {{- with .kafka -}}
+      {{- if .url -}}
+        {{- $flags = append $flags (print "--exportKafkaURL=" .url) -}}
+        {{- $flags = append $flags (print "--exportKafkaTopic=" .topic) -}}
+      {{- end -}}
+    {{- end -}}
+    {{- $flags = append $flags (print "--exportBatchSize=" (int .batchSize)) -}}
+    {{- $flags = append $flags (print "--exportBatchInterval=" .batchInterval) -}}
+    {{- $flags = append $flags (print "--exportMaxRetries=" (int .maxRetries)) -}}
+  {{- end -}}
 {{- end -}}
 {{- with .registryClient -}}
   {{- $flags = append $flags (print "--allowInsecureRegistry=" .allowInsecure) -}}
   {{- $flags = append $flags (print "--registryCredentialHelpers=" (join "," .credentialHelpers)) -}}
 {{- end -}}
+{{- with .tls -}}
+  {{- with .profile -}}
+    {{- $flags = append $flags (print "--tlsProfile=" .) -}}
+  {{- end -}}
+{{- end -}}
 {{- with .ttlController -}}
   {{- $flags = append $flags (print "--ttlReconciliationInterval=" .reconciliationInterval) -}}
 {{- end -}}
@@ -88,4 +199,42 @@
 {{- with $flags -}}
   {{- toYaml . -}}
 {{- end -}}
-{{- end -}}
\ No newline at end of file
+{{- end -}}
+
+{{- define "kyverno.metering.resourceAttributes" -}}
+{{- $attributes := list -}}
+{{- range $key, $value := . -}}
+  {{- $attributes = append $attributes (print $key "=" $value) -}}
+{{- end -}}
+{{- join "," $attributes -}}
+{{- end -}}
+
+{{- define "kyverno.features.relatedResources.rules" -}}
+{{- with .relatedResources -}}
+{{- range .kinds }}
+- apiGroups:
+    - {{ .apiGroup | quote }}
+  resources:
+    - {{ required "features.relatedResources.kinds[].resource is required" .resource }}
+  verbs:
+    - get
+    - list
+    - watch
+{{- end }}
+{{- end -}}
+{{- end -}}
+
+{{- define "kyverno.features.ownerResources.rules" -}}
+{{- with .ownerResources -}}
+{{- range .kinds }}
+- apiGroups:
+    - {{ .apiGroup | quote }}
+  resources:
+    - {{ required "features.ownerResources.kinds[].resource is required" .resource }}
+  verbs:
+    - get
+    - list
+    - watch
+{{- end }}
+{{- end -}}
+{{- end -}}
@@ -32,9 +32,6 @@
 
 {{- define "kyverno.config.resourceFilters" -}}
 {{- $resourceFilters := .Values.config.resourceFilters -}}
-{{- if .Values.config.excludeKyvernoNamespace -}}
-  {{- $resourceFilters = prepend .Values.config.resourceFilters (printf "[*/*,%s,*]" (include "kyverno.namespace" .)) -}}
-{{- end -}}
 {{- range $resourceExclude := .Values.config.resourceFiltersExclude -}}
   {{- $resourceFilters = without $resourceFilters $resourceExclude -}}
 {{- end -}}
@@ -50,18 +47,6 @@
 {{- end -}}
 {{- end -}}
 
-{{- define "kyverno.config.webhooks" -}}
-{{- $excludeDefault := dict "key" "kubernetes.io/metadata.name" "operator" "NotIn" "values" (list (include "kyverno.namespace" .)) }}
-{{- $newWebhook := list }}
-{{- range $webhook := .Values.config.webhooks }}
-  {{- $namespaceSelector := default dict $webhook.namespaceSelector }}
-  {{- $matchExpressions := default list $namespaceSelector.matchExpressions }}
-  {{- $newNamespaceSelector := dict "matchLabels" $namespaceSelector.matchLabels "matchExpressions" (append $matchExpressions $excludeDefault) }}
-  {{- $newWebhook = append $newWebhook (merge (omit $webhook "namespaceSelector") (dict "namespaceSelector" $newNamespaceSelector)) }}
-{{- end }}
-{{- $newWebhook | toJson }}
-{{- end -}}
-
 {{- define "kyverno.config.imagePullSecret" -}}
 {{- printf "{\"auths\":{\"%s\":{\"auth\":\"%s\"}}}" .registry (printf "%s:%s" .username .password | b64enc) | b64enc }}
 {{- end -}}
@@ -4,26 +4,34 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"os"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/cmd/internal"
 	"github.com/kyverno/kyverno/pkg/background"
+	"github.com/kyverno/kyverno/pkg/background/journal"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
+	baselinecontroller "github.com/kyverno/kyverno/pkg/controllers/baseline"
+	exceptioncontroller "github.com/kyverno/kyverno/pkg/controllers/exception"
 	policymetricscontroller "github.com/kyverno/kyverno/pkg/controllers/metrics/policy"
+	policyprofilecontroller "github.com/kyverno/kyverno/pkg/controllers/policyprofile"
+	policysetcontroller "github.com/kyverno/kyverno/pkg/controllers/policyset"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
-	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/policy"
+	"github.com/kyverno/kyverno/pkg/registryclient"
 	kubeinformers "k8s.io/client-go/informers"
 	kyamlopenapi "sigs.k8s.io/kustomize/kyaml/openapi"
 )
@@ -39,46 +47,103 @@ func createrLeaderControllers(
 	kyvernoInformer kyvernoinformer.SharedInformerFactory,
 	kyvernoClient versioned.Interface,
 	dynamicClient dclient.Interface,
+	clusters dclient.Clusters,
+	impersonator dclient.Impersonator,
 	configuration config.Configuration,
 	metricsConfig metrics.MetricsConfigManager,
 	eventGenerator event.Interface,
 	jp jmespath.Interface,
 	backgroundScanInterval time.Duration,
+	decisionJournal journal.Journal,
+	enableBaselines bool,
+	enablePolicySets bool,
+	enablePolicyProfiles bool,
+	rclient registryclient.Client,
+	exceptionExpiryWarning time.Duration,
+	shard background.Shard,
 ) ([]internal.Controller, error) {
-	policyCtrl, err := policy.NewPolicyController(
+	backgroundController := background.NewController(
 		kyvernoClient,
 		dynamicClient,
+		clusters,
+		impersonator,
 		eng,
 		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
 		kyvernoInformer.Kyverno().V1().Policies(),
 		kyvernoInformer.Kyverno().V1beta1().UpdateRequests(),
-		configuration,
-		eventGenerator,
 		kubeInformer.Core().V1().Namespaces(),
-		logging.WithName("PolicyController"),
-		backgroundScanInterval,
-		metricsConfig,
+		eventGenerator,
+		configuration,
 		jp,
+		decisionJournal,
+		shard,
 	)
-	if err != nil {
-		return nil, err
+	controllers := []internal.Controller{
+		internal.NewController("background-controller", backgroundController, genWorkers),
 	}
-	backgroundController := background.NewController(
+	// the controllers creating update requests for existing resources and reconciling baselines
+	// run in the first shard only
+	if shard.Index != 0 {
+		return controllers, nil
+	}
+	policyCtrl, err := policy.NewPolicyController(
 		kyvernoClient,
 		dynamicClient,
 		eng,
 		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
 		kyvernoInformer.Kyverno().V1().Policies(),
 		kyvernoInformer.Kyverno().V1beta1().UpdateRequests(),
-		kubeInformer.Core().V1().Namespaces(),
-		eventGenerator,
 		configuration,
+		eventGenerator,
+		kubeInformer.Core().V1().Namespaces(),
+		logging.WithName("PolicyController"),
+		backgroundScanInterval,
+		metricsConfig,
 		jp,
 	)
-	return []internal.Controller{
-		internal.NewController("policy-controller", policyCtrl, 2),
-		internal.NewController("background-controller", backgroundController, genWorkers),
-	}, err
+	if err != nil {
+		return nil, err
+	}
+	controllers = append(controllers, internal.NewController("policy-controller", policyCtrl, 2))
+	if enableBaselines {
+		baselineController := baselinecontroller.NewController(
+			dynamicClient,
+			kyvernoClient,
+			kyvernoInformer.Kyverno().V2alpha1().ClusterBaselines(),
+			kubeInformer.Core().V1().Namespaces().Lister(),
+		)
+		controllers = append(controllers, internal.NewController(baselinecontroller.ControllerName, baselineController, baselinecontroller.Workers))
+	}
+	if enablePolicySets {
+		policySetController := policysetcontroller.NewController(
+			kyvernoClient,
+			rclient,
+			kyvernoInformer.Kyverno().V2alpha1().PolicySets(),
+			kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+			kyvernoInformer.Kyverno().V1().Policies(),
+		)
+		controllers = append(controllers, internal.NewController(policysetcontroller.ControllerName, policySetController, policysetcontroller.Workers))
+	}
+	if enablePolicyProfiles {
+		policyProfileController := policyprofilecontroller.NewController(
+			kyvernoClient,
+			kyvernoInformer.Kyverno().V2alpha1().PolicyProfiles(),
+			kyvernoInformer.Kyverno().V1().Policies(),
+			kubeInformer.Core().V1().Namespaces(),
+		)
+		controllers = append(controllers, internal.NewController(policyprofilecontroller.ControllerName, policyProfileController, policyprofilecontroller.Workers))
+	}
+	if internal.PolicyExceptionEnabled() {
+		exceptionController := exceptioncontroller.NewController(
+			kyvernoClient,
+			kyvernoInformer.Kyverno().V2().PolicyExceptions(),
+			eventGenerator,
+			configuration,
+			exceptionExpiryWarning,
+		)
+		controllers = append(controllers, internal.NewController(exceptioncontroller.ControllerName, exceptionController, exceptioncontroller.Workers))
+	}
+	return controllers, err
 }
 
 func main() {
@@ -87,12 +152,34 @@ func main() {
 		maxQueuedEvents          int
 		omitEvents               string
 		maxAPICallResponseLength int64
+		enableDecisionJournal    bool
+		replayDecisionJournal    bool
+		decisionJournalShardSize int
+		decisionJournalMaxShards int
+		decisionJournalFlush     time.Duration
+		enableBaselines          bool
+		enablePolicySets         bool
+		enablePolicyProfiles     bool
+		shards                   int
+		shardTakeoverDelay       time.Duration
+		exceptionExpiryWarning   time.Duration
 	)
 	flagset := flag.NewFlagSet("updaterequest-controller", flag.ExitOnError)
 	flagset.IntVar(&genWorkers, "genWorkers", 10, "Workers for the background controller.")
 	flagset.IntVar(&maxQueuedEvents, "maxQueuedEvents", 1000, "Maximum events to be queued.")
 	flagset.StringVar(&omitEvents, "omit-events", "", "Set this flag to a comma sperated list of PolicyViolation, PolicyApplied, PolicyError, PolicySkipped to disable events, e.g. --omit-events=PolicyApplied,PolicyViolation")
 	flagset.Int64Var(&maxAPICallResponseLength, "maxAPICallResponseLength", 2*1000*1000, "Maximum allowed response size from API Calls. A value of 0 bypasses checks (not recommended).")
+	flagset.BoolVar(&enableDecisionJournal, "enableDecisionJournal", false, "Record generate and mutate existing decisions in a journal stored in ConfigMaps.")
+	flagset.BoolVar(&replayDecisionJournal, "replayDecisionJournal", false, "Replay the decision journal when becoming leader to reconstruct lost update requests and generate labels.")
+	flagset.IntVar(&decisionJournalShardSize, "decisionJournalShardSize", journal.DefaultShardSize, "Maximum size in bytes of a decision journal shard.")
+	flagset.IntVar(&decisionJournalMaxShards, "decisionJournalMaxShards", journal.DefaultMaxShards, "Maximum number of decision journal shards, the oldest decisions are dropped beyond it.")
+	flagset.DurationVar(&decisionJournalFlush, "decisionJournalFlushInterval", 10*time.Second, "Interval at which recorded decisions are written to the decision journal.")
+	flagset.BoolVar(&enableBaselines, "enableClusterBaselines", true, "Enable the controller reconciling the objects declared by ClusterBaselines.")
+	flagset.BoolVar(&enablePolicySets, "enablePolicySets", true, "Enable the controller synchronizing the policy bundles declared by PolicySets.")
+	flagset.BoolVar(&enablePolicyProfiles, "enablePolicyProfiles", true, "Enable the controller applying the policies of PolicyProfiles to the namespaces labelled with their name.")
+	flagset.IntVar(&shards, "shards", 1, "Number of shards update requests are spread across by the namespace of their trigger, each shard is processed by the replica holding its lease.")
+	flagset.DurationVar(&shardTakeoverDelay, "shardTakeoverDelay", 30*time.Second, "Delay before a replica competes for the shards other than the one derived from its pod name.")
+	flagset.DurationVar(&exceptionExpiryWarning, "exceptionExpiryWarning", 24*time.Hour, "Delay before the expiration of a PolicyException at which an event warning about the expiration is emitted.")
 
 	// config
 	appConfig := internal.NewConfiguration(
@@ -102,6 +189,9 @@ func main() {
 		internal.WithKubeconfig(),
 		internal.WithPolicyExceptions(),
 		internal.WithConfigMapCaching(),
+		internal.WithRelatedResources(),
+		internal.WithOwnerResources(),
+		internal.WithContextCallLimits(),
 		internal.WithDeferredLoading(),
 		internal.WithRegistryClient(),
 		internal.WithLeaderElection(),
@@ -130,6 +220,8 @@ func main() {
 	// THIS IS AN UGLY FIX
 	// ELSE KYAML IS NOT THREAD SAFE
 	kyamlopenapi.Schema()
+	// exclusion policies
+	internal.StartExclusionPolicies(signalCtx, setup.Logger, setup.KyvernoClient, setup.Configuration, kyvernov2alpha1.ExclusionBackground, resyncPeriod)
 	// informer factories
 	kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(setup.KyvernoClient, resyncPeriod)
 	emitEventsValues := strings.Split(omitEvents, ",")
@@ -142,30 +234,50 @@ func main() {
 		kyvernoInformer.Kyverno().V1().Policies(),
 		maxQueuedEvents,
 		emitEventsValues,
+		setup.Configuration,
 		logging.WithName("EventGenerator"),
 	)
 	// this controller only subscribe to events, nothing is returned...
 	var wg sync.WaitGroup
 	policymetricscontroller.NewController(
 		setup.MetricsManager,
+		setup.Configuration,
 		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
 		kyvernoInformer.Kyverno().V1().Policies(),
 		&wg,
 	)
+	// related resources informer
+	relatedResourceInformer := internal.NewRelatedResourceInformer(signalCtx, setup.Logger, setup.KyvernoDynamicClient, resyncPeriod)
+	// context call limiter
+	contextCallLimiter := internal.NewContextCallLimiter(setup.Logger)
+	// owner resolver
+	ownerResolver := internal.NewOwnerResolver(signalCtx, setup.Logger, setup.KyvernoDynamicClient, resyncPeriod)
+	// policy exceptions
+	exceptionsSelector := internal.NewExceptionSelector(signalCtx, setup.Logger, setup.KyvernoClient, 15*time.Minute)
 	engine := internal.NewEngine(
 		signalCtx,
 		setup.Logger,
 		setup.Configuration,
 		setup.MetricsConfiguration,
 		setup.Jp,
+		nil,
 		setup.KyvernoDynamicClient,
 		setup.RegistryClient,
 		setup.ImageVerifyCacheClient,
 		setup.KubeClient,
 		setup.KyvernoClient,
 		setup.RegistrySecretLister,
-		apicall.NewAPICallConfiguration(maxAPICallResponseLength),
+		apicall.NewAPICallConfiguration(maxAPICallResponseLength, setup.OutboundTransport),
+		nil,
+		relatedResourceInformer,
+		contextCallLimiter,
+		exceptionsSelector,
+		ownerResolver,
 	)
+	var decisionJournal journal.Journal
+	if enableDecisionJournal {
+		decisionJournal = journal.NewConfigMapJournal(setup.KubeClient, config.KyvernoNamespace(), "kyverno-decision-journal", decisionJournalShardSize, decisionJournalMaxShards)
+	}
 	// start informers and wait for cache sync
 	if !internal.StartInformersAndWaitForCacheSync(signalCtx, setup.Logger, kyvernoInformer) {
 		setup.Logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
@@ -173,57 +285,125 @@ func main() {
 	}
 	// start event generator
 	go eventGenerator.Run(signalCtx, 3, &wg)
-	// setup leader election
-	le, err := leaderelection.New(
-		setup.Logger.WithName("leader-election"),
-		"kyverno-background-controller",
-		config.KyvernoNamespace(),
-		setup.LeaderElectionClient,
-		config.KyvernoPodName(),
-		internal.LeaderElectionRetryPeriod(),
-		func(ctx context.Context) {
-			logger := setup.Logger.WithName("leader")
-			// create leader factories
-			kubeInformer := kubeinformers.NewSharedInformerFactory(setup.KubeClient, resyncPeriod)
-			kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(setup.KyvernoClient, resyncPeriod)
-			// create leader controllers
-			leaderControllers, err := createrLeaderControllers(
-				engine,
-				genWorkers,
-				kubeInformer,
-				kyvernoInformer,
-				setup.KyvernoClient,
-				setup.KyvernoDynamicClient,
-				setup.Configuration,
-				setup.MetricsManager,
-				eventGenerator,
-				setup.Jp,
-				bgscanInterval,
-			)
-			if err != nil {
-				logger.Error(err, "failed to create leader controllers")
-				os.Exit(1)
+	// start decision journal writer
+	if decisionJournal != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			journal.Run(signalCtx, setup.Logger.WithName("journal"), decisionJournal, decisionJournalFlush)
+		}()
+	}
+	if shards < 1 {
+		shards = 1
+	}
+	// setup leader election, one per shard
+	preferredShard := background.PreferredShard(config.KyvernoPodName(), shards)
+	var elections sync.WaitGroup
+	for i := 0; i < shards; i++ {
+		shard := background.Shard{Index: i, Count: shards}
+		le, err := internal.NewLeaderElection(
+			setup.Logger.WithName("leader-election"),
+			leaseName(shard),
+			config.KyvernoNamespace(),
+			setup.LeaderElectionClient,
+			config.KyvernoPodName(),
+			func(ctx context.Context) {
+				logger := setup.Logger.WithName("leader").WithValues("shard", shard.Index)
+				// create leader factories
+				kubeInformer := kubeinformers.NewSharedInformerFactory(setup.KubeClient, resyncPeriod)
+				kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(setup.KyvernoClient, resyncPeriod)
+				// create leader controllers
+				leaderControllers, err := createrLeaderControllers(
+					engine,
+					genWorkers,
+					kubeInformer,
+					kyvernoInformer,
+					setup.KyvernoClient,
+					setup.KyvernoDynamicClient,
+					dclient.NewClusters(ctx, setup.KubeClient, resyncPeriod),
+					internal.CreateImpersonator(logger, ctx, resyncPeriod),
+					setup.Configuration,
+					setup.MetricsManager,
+					eventGenerator,
+					setup.Jp,
+					bgscanInterval,
+					decisionJournal,
+					enableBaselines,
+					enablePolicySets,
+					enablePolicyProfiles,
+					setup.RegistryClient,
+					exceptionExpiryWarning,
+					shard,
+				)
+				if err != nil {
+					logger.Error(err, "failed to create leader controllers")
+					os.Exit(1)
+				}
+				// start informers and wait for cache sync, informers and controllers stop when the shard lease is lost
+				if !internal.StartInformersAndWaitForCacheSync(ctx, logger, kyvernoInformer, kubeInformer) {
+					logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+					os.Exit(1)
+				}
+				// replay the decision journal before the background controller processes update requests,
+				// on the replica running the background controller of the first shard
+				if decisionJournal != nil && replayDecisionJournal && shard.Index == 0 {
+					leaderControllers[0] = internal.WithStartup(leaderControllers[0], func(ctx context.Context, logger logr.Logger) {
+						if entries, err := decisionJournal.Load(ctx); err != nil {
+							logger.Error(err, "failed to load decision journal")
+						} else if err := journal.Replay(ctx, logger.WithName("journal"), setup.KyvernoDynamicClient, setup.KyvernoClient, entries...); err != nil {
+							logger.Error(err, "failed to replay decision journal")
+						}
+					})
+				}
+				// start leader controllers
+				leaderControllers = internal.LeaderElectedControllers(
+					setup.Logger.WithName("leader-election"),
+					leaseName(shard),
+					config.KyvernoNamespace(),
+					setup.LeaderElectionClient,
+					config.KyvernoPodName(),
+					leaderControllers...,
+				)
+				var wg sync.WaitGroup
+				for _, controller := range leaderControllers {
+					controller.Run(ctx, logger.WithName("controllers"), &wg)
+				}
+				// wait all controllers shut down
+				wg.Wait()
+			},
+		)
+		if err != nil {
+			setup.Logger.Error(err, "failed to initialize leader election")
+			os.Exit(1)
+		}
+		// start leader election, replicas compete for the other shards after a delay
+		// so that shards spread across replicas while any replica can take over an orphaned shard
+		elections.Add(1)
+		go func() {
+			defer elections.Done()
+			if shard.Index != preferredShard {
+				select {
+				case <-signalCtx.Done():
+					return
+				case <-time.After(shardTakeoverDelay):
+				}
 			}
-			// start informers and wait for cache sync
-			if !internal.StartInformersAndWaitForCacheSync(signalCtx, logger, kyvernoInformer, kubeInformer) {
-				logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+			le.Run(signalCtx)
+			// the lease of the shard was lost, the replica exits to compete again for the shards
+			if signalCtx.Err() == nil {
+				setup.Logger.Info("shard lease lost, exiting", "shard", shard.Index)
 				os.Exit(1)
 			}
-			// start leader controllers
-			var wg sync.WaitGroup
-			for _, controller := range leaderControllers {
-				controller.Run(signalCtx, logger.WithName("controllers"), &wg)
-			}
-			// wait all controllers shut down
-			wg.Wait()
-		},
-		nil,
-	)
-	if err != nil {
-		setup.Logger.Error(err, "failed to initialize leader election")
-		os.Exit(1)
+		}()
 	}
-	// start leader election
-	le.Run(signalCtx)
+	elections.Wait()
 	wg.Wait()
 }
+
+// leaseName keeps the historical lease name for the first shard
+func leaseName(shard background.Shard) string {
+	if shard.Index == 0 {
+		return "kyverno-background-controller"
+	}
+	return fmt.Sprintf("kyverno-background-controller-shard-%d", shard.Index)
+}
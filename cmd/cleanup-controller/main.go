@@ -22,9 +22,7 @@ import (
 	ttlcontroller "github.com/kyverno/kyverno/pkg/controllers/ttl"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/informers"
-	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
-	"github.com/kyverno/kyverno/pkg/tls"
 	"github.com/kyverno/kyverno/pkg/webhooks"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -68,6 +66,9 @@ func main() {
 		maxQueuedEvents   int
 		interval          time.Duration
 		renewBefore       time.Duration
+		deletionQPS       float64
+		deletionBurst     int
+		deletionBatchSize int
 	)
 	flagset := flag.NewFlagSet("cleanup-controller", flag.ExitOnError)
 	flagset.BoolVar(&dumpPayload, "dumpPayload", false, "Set this flag to activate/deactivate debug mode.")
@@ -79,6 +80,9 @@ func main() {
 	flagset.StringVar(&caSecretName, "caSecretName", "", "Name of the secret containing CA.")
 	flagset.StringVar(&tlsSecretName, "tlsSecretName", "", "Name of the secret containing TLS pair.")
 	flagset.DurationVar(&renewBefore, "renewBefore", 15*24*time.Hour, "The certificate renewal time before expiration")
+	flagset.Float64Var(&deletionQPS, "deletionQPS", 0, "Maximum sustained number of deletions per second performed by cleanup policies, unlimited if zero.")
+	flagset.IntVar(&deletionBurst, "deletionBurst", 0, "Maximum number of deletions performed at once by cleanup policies, defaults to deletionQPS if zero.")
+	flagset.IntVar(&deletionBatchSize, "deletionBatchSize", 0, "Maximum number of resources deleted by a cleanup policy in a single run, unlimited if zero.")
 	// config
 	appConfig := internal.NewConfiguration(
 		internal.WithProfiling(),
@@ -86,6 +90,7 @@ func main() {
 		internal.WithTracing(),
 		internal.WithKubeconfig(),
 		internal.WithLeaderElection(),
+		internal.WithCertificates(),
 		internal.WithKyvernoClient(),
 		internal.WithKyvernoDynamicClient(),
 		internal.WithConfigMapCaching(),
@@ -106,6 +111,8 @@ func main() {
 		setup.Logger.Error(errors.New("exiting... tlsSecretName is a required flag"), "exiting... tlsSecretName is a required flag")
 		os.Exit(1)
 	}
+	// cert-manager stores the CA in the TLS secret
+	caSecretName = internal.CASecretName(caSecretName, tlsSecretName)
 	// certificates informers
 	caSecret := informers.NewSecretInformer(setup.KubeClient, config.KyvernoNamespace(), caSecretName, resyncPeriod)
 	tlsSecret := informers.NewSecretInformer(setup.KubeClient, config.KyvernoNamespace(), tlsSecretName, resyncPeriod)
@@ -137,6 +144,7 @@ func main() {
 		kyvernoInformer.Kyverno().V2beta1().ClusterCleanupPolicies(),
 		kyvernoInformer.Kyverno().V2beta1().CleanupPolicies(),
 		maxQueuedEvents,
+		setup.Configuration,
 		logging.WithName("EventGenerator"),
 	)
 	// start informers and wait for cache sync
@@ -147,13 +155,12 @@ func main() {
 	var wg sync.WaitGroup
 	go eventGenerator.Run(ctx, 3, &wg)
 	// setup leader election
-	le, err := leaderelection.New(
+	le, err := internal.NewLeaderElection(
 		setup.Logger.WithName("leader-election"),
 		"kyverno-cleanup-controller",
 		config.KyvernoNamespace(),
 		setup.LeaderElectionClient,
 		config.KyvernoPodName(),
-		internal.LeaderElectionRetryPeriod(),
 		func(ctx context.Context) {
 			logger := setup.Logger.WithName("leader")
 			// informer factories
@@ -163,16 +170,12 @@ func main() {
 			cmResolver := internal.NewConfigMapResolver(ctx, setup.Logger, setup.KubeClient, resyncPeriod)
 
 			// controllers
-			renewer := tls.NewCertRenewer(
-				setup.KubeClient.CoreV1().Secrets(config.KyvernoNamespace()),
-				tls.CertRenewalInterval,
-				tls.CAValidityDuration,
-				tls.TLSValidityDuration,
+			renewer := internal.NewCertRenewer(
+				setup.Logger,
+				setup.KubeClient,
+				setup.DynamicClient,
 				renewBefore,
 				serverIP,
-				config.KyvernoServiceName(),
-				config.DnsNames(config.KyvernoServiceName(), config.KyvernoNamespace()),
-				config.KyvernoNamespace(),
 				caSecretName,
 				tlsSecretName,
 			)
@@ -271,11 +274,19 @@ func main() {
 					setup.KyvernoClient,
 					kyvernoInformer.Kyverno().V2beta1().ClusterCleanupPolicies(),
 					kyvernoInformer.Kyverno().V2beta1().CleanupPolicies(),
+					kyvernoInformer.Kyverno().V2alpha1().CleanupPreviews(),
+					kyvernoInformer.Kyverno().V2alpha1().CleanupReports(),
 					nsLister,
 					setup.Configuration,
 					cmResolver,
 					setup.Jp,
 					eventGenerator,
+					setup.OutboundTransport,
+					cleanup.DeletionLimits{
+						QPS:       float32(deletionQPS),
+						Burst:     deletionBurst,
+						BatchSize: deletionBatchSize,
+					},
 				),
 				cleanup.Workers,
 			)
@@ -295,15 +306,24 @@ func main() {
 				os.Exit(1)
 			}
 			// start leader controllers
+			leaderControllers := internal.LeaderElectedControllers(
+				setup.Logger.WithName("leader-election"),
+				"kyverno-cleanup-controller",
+				config.KyvernoNamespace(),
+				setup.LeaderElectionClient,
+				config.KyvernoPodName(),
+				certController,
+				policyValidatingWebhookController,
+				ttlWebhookController,
+				cleanupController,
+				ttlManagerController,
+			)
 			var wg sync.WaitGroup
-			certController.Run(ctx, logger, &wg)
-			policyValidatingWebhookController.Run(ctx, logger, &wg)
-			ttlWebhookController.Run(ctx, logger, &wg)
-			cleanupController.Run(ctx, logger, &wg)
-			ttlManagerController.Run(ctx, logger, &wg)
+			for _, controller := range leaderControllers {
+				controller.Run(ctx, logger, &wg)
+			}
 			wg.Wait()
 		},
-		nil,
 	)
 	if err != nil {
 		setup.Logger.Error(err, "failed to initialize leader election")
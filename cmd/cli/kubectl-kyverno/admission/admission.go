@@ -0,0 +1,149 @@
+package admission
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+var operations = []kyvernov1.AdmissionOperation{kyvernov1.Create, kyvernov1.Update, kyvernov1.Delete, kyvernov1.Connect}
+
+// Request holds the attributes of a simulated admission request.
+type Request struct {
+	Operation kyvernov1.AdmissionOperation
+	UserInfo  kyvernov1beta1.RequestInfo
+	// Resource is the object of the request, or the old object for DELETE requests.
+	Resource *unstructured.Unstructured
+	// OldResource is the old object of UPDATE requests.
+	OldResource *unstructured.Unstructured
+}
+
+// ParseOperation parses an admission operation, the comparison is case insensitive.
+func ParseOperation(value string) (kyvernov1.AdmissionOperation, error) {
+	var names []string
+	for _, operation := range operations {
+		if strings.EqualFold(value, string(operation)) {
+			return operation, nil
+		}
+		names = append(names, string(operation))
+	}
+	return "", fmt.Errorf("invalid operation %q, must be one of %s", value, strings.Join(names, ", "))
+}
+
+// Load reads an AdmissionReview, or a bare AdmissionRequest, from a yaml or json file.
+func Load(path string) (*Request, error) {
+	content, err := resource.GetFileBytes(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read admission request (%w)", err)
+	}
+	return Parse(content)
+}
+
+// Parse decodes an AdmissionReview, or a bare AdmissionRequest, from yaml or json content.
+func Parse(content []byte) (*Request, error) {
+	data, err := yaml.YAMLToJSON(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode admission request (%w)", err)
+	}
+	var review admissionv1.AdmissionReview
+	if err := json.Unmarshal(data, &review); err != nil {
+		return nil, fmt.Errorf("failed to decode admission request (%w)", err)
+	}
+	request := review.Request
+	if request == nil {
+		var bare admissionv1.AdmissionRequest
+		if err := json.Unmarshal(data, &bare); err != nil {
+			return nil, fmt.Errorf("failed to decode admission request (%w)", err)
+		}
+		request = &bare
+	}
+	if request.Operation == "" {
+		return nil, errors.New("admission request operation is missing")
+	}
+	operation, err := ParseOperation(string(request.Operation))
+	if err != nil {
+		return nil, err
+	}
+	if request.Kind.Kind == "" {
+		// the kind is optional in hand written requests, it defaults to the kind of the objects
+		raw := request.Object.Raw
+		if raw == nil {
+			raw = request.OldObject.Raw
+		}
+		if raw != nil {
+			obj, err := kubeutils.BytesToUnstructured(raw)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode admission request object (%w)", err)
+			}
+			gvk := obj.GroupVersionKind()
+			request.Kind = metav1.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind}
+		}
+	}
+	object, oldObject, err := admissionutils.ExtractResources(nil, *request)
+	if err != nil {
+		return nil, err
+	}
+	result := Request{
+		Operation: operation,
+		UserInfo: kyvernov1beta1.RequestInfo{
+			AdmissionUserInfo: *request.UserInfo.DeepCopy(),
+		},
+	}
+	if operation == kyvernov1.Delete {
+		if oldObject.Object == nil {
+			return nil, errors.New("admission request for DELETE operation must have an old object")
+		}
+		result.Resource = &oldObject
+		return &result, nil
+	}
+	if object.Object == nil {
+		return nil, fmt.Errorf("admission request for %s operation must have an object", operation)
+	}
+	result.Resource = &object
+	if oldObject.Object != nil {
+		result.OldResource = &oldObject
+	}
+	return &result, nil
+}
+
+// FindOldResource returns the resource in olds with the same api version, kind, namespace and name as resource.
+func FindOldResource(olds []*unstructured.Unstructured, resource unstructured.Unstructured) *unstructured.Unstructured {
+	for _, old := range olds {
+		if old.GetAPIVersion() == resource.GetAPIVersion() &&
+			old.GetKind() == resource.GetKind() &&
+			old.GetNamespace() == resource.GetNamespace() &&
+			old.GetName() == resource.GetName() {
+			return old
+		}
+	}
+	return nil
+}
+
+// LoadResources loads the resources found in the given files, typically the old version of the
+// resources used to simulate UPDATE requests.
+func LoadResources(paths ...string) ([]*unstructured.Unstructured, error) {
+	var resources []*unstructured.Unstructured
+	for _, path := range paths {
+		content, err := resource.GetFileBytes(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read old resources (%w)", err)
+		}
+		loaded, err := resource.GetUnstructuredResources(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode old resources (%w)", err)
+		}
+		resources = append(resources, loaded...)
+	}
+	return resources, nil
+}
@@ -0,0 +1,117 @@
+package admission
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestParseOperation(t *testing.T) {
+	operation, err := ParseOperation("update")
+	assert.NoError(t, err)
+	assert.Equal(t, kyvernov1.Update, operation)
+	_, err = ParseOperation("PATCH")
+	assert.EqualError(t, err, `invalid operation "PATCH", must be one of CREATE, UPDATE, DELETE, CONNECT`)
+}
+
+func TestLoad(t *testing.T) {
+	request, err := Load("../_testdata/admission/admission-review.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, kyvernov1.Delete, request.Operation)
+	assert.Equal(t, "system:serviceaccount:default:ci", request.UserInfo.AdmissionUserInfo.Username)
+	assert.Equal(t, []string{"system:serviceaccounts", "system:authenticated"}, request.UserInfo.AdmissionUserInfo.Groups)
+	assert.Equal(t, "Pod", request.Resource.GetKind())
+	assert.Equal(t, "nginx", request.Resource.GetName())
+	assert.Nil(t, request.OldResource)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    *Request
+		wantErr string
+	}{{
+		name: "bare request",
+		content: `
+operation: UPDATE
+userInfo:
+  username: alice
+object:
+  apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: cm
+    namespace: test
+oldObject:
+  apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: cm
+    namespace: test
+`,
+		want: &Request{
+			Operation: kyvernov1.Update,
+		},
+	}, {
+		name: "missing operation",
+		content: `
+apiVersion: admission.k8s.io/v1
+kind: AdmissionReview
+request:
+  object:
+    apiVersion: v1
+    kind: ConfigMap
+`,
+		wantErr: "admission request operation is missing",
+	}, {
+		name: "missing object",
+		content: `
+operation: CREATE
+`,
+		wantErr: "admission request for CREATE operation must have an object",
+	}, {
+		name: "missing old object",
+		content: `
+operation: DELETE
+object:
+  apiVersion: v1
+  kind: ConfigMap
+`,
+		wantErr: "admission request for DELETE operation must have an old object",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.content))
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.Operation, got.Operation)
+			assert.Equal(t, "alice", got.UserInfo.AdmissionUserInfo.Username)
+			assert.Equal(t, "ConfigMap", got.Resource.GetKind())
+			assert.Equal(t, "test", got.Resource.GetNamespace())
+			assert.NotNil(t, got.OldResource)
+		})
+	}
+}
+
+func TestFindOldResource(t *testing.T) {
+	newResource := func(kind, namespace, name string) *unstructured.Unstructured {
+		var resource unstructured.Unstructured
+		resource.SetAPIVersion("v1")
+		resource.SetKind(kind)
+		resource.SetNamespace(namespace)
+		resource.SetName(name)
+		return &resource
+	}
+	olds := []*unstructured.Unstructured{
+		newResource("Pod", "default", "nginx"),
+		newResource("ConfigMap", "default", "nginx"),
+	}
+	assert.Equal(t, olds[1], FindOldResource(olds, *newResource("ConfigMap", "default", "nginx")))
+	assert.Nil(t, FindOldResource(olds, *newResource("ConfigMap", "test", "nginx")))
+}
@@ -13,10 +13,15 @@ import (
 	"github.com/go-git/go-billy/v5/memfs"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/admission"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/deprecations"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/exception"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/log"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/oci"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/color"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/format"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/report"
@@ -37,6 +42,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
 	"sigs.k8s.io/yaml"
 )
 
@@ -55,6 +61,12 @@ type ApplyCommandConfig struct {
 	Variables      []string
 	ValuesFile     string
 	UserInfoPath   string
+	Username       string
+	Groups         []string
+	Operation      string
+	OldResources   []string
+	Exceptions     []string
+	AdmissionPath  string
 	Cluster        bool
 	PolicyReport   bool
 	Stdin          bool
@@ -63,12 +75,15 @@ type ApplyCommandConfig struct {
 	ResourcePaths  []string
 	PolicyPaths    []string
 	GitBranch      string
+	OciVerify      oci.VerifyOptions
 	warnExitCode   int
 	warnNoPassed   bool
 }
 
 func Command() *cobra.Command {
 	var removeColor, detailedResults, table bool
+	var outputFormat string
+	var policyPaths []string
 	applyCommandConfig := &ApplyCommandConfig{}
 	cmd := &cobra.Command{
 		Use:          "apply",
@@ -79,7 +94,25 @@ func Command() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			out := cmd.OutOrStdout()
 			color.Init(removeColor)
-			applyCommandConfig.PolicyPaths = args
+			applyCommandConfig.PolicyPaths = append(args, policyPaths...)
+			resultsFormat, err := format.Parse(outputFormat)
+			if err != nil {
+				return err
+			}
+			if resultsFormat != format.Text {
+				if applyCommandConfig.PolicyReport || table {
+					return fmt.Errorf("--output-format can't be used together with --policy-report or --table")
+				}
+				// only the report is written, the regular output would make it unparsable
+				rc, _, _, responses, err := applyCommandConfig.applyCommandHelper(io.Discard)
+				if err != nil {
+					return err
+				}
+				if err := printFormat(out, resultsFormat, applyCommandConfig.ResourcePaths, applyCommandConfig.AuditWarn, responses...); err != nil {
+					return err
+				}
+				return exit(rc, applyCommandConfig.warnExitCode, applyCommandConfig.warnNoPassed)
+			}
 			rc, _, skipInvalidPolicies, responses, err := applyCommandConfig.applyCommandHelper(out)
 			if err != nil {
 				return err
@@ -96,10 +129,22 @@ func Command() *cobra.Command {
 		},
 	}
 	cmd.Flags().StringSliceVarP(&applyCommandConfig.ResourcePaths, "resource", "r", []string{}, "Path to resource files")
+	cmd.Flags().StringSliceVar(&policyPaths, "policy", nil, "Path to policy files, in addition to the command arguments (oci://<image> loads the policies from an OCI image)")
+	cmd.Flags().StringVar(&applyCommandConfig.OciVerify.Key, "oci-key", "", "Public key used to verify the signature of policy OCI images (path, KMS uri or k8s:// secret reference)")
+	cmd.Flags().StringVar(&applyCommandConfig.OciVerify.Subject, "oci-subject", "", "Expected certificate identity for keyless verification of policy OCI images")
+	cmd.Flags().StringVar(&applyCommandConfig.OciVerify.Issuer, "oci-issuer", "", "Expected certificate OIDC issuer for keyless verification of policy OCI images")
+	cmd.Flags().StringVar(&applyCommandConfig.OciVerify.RekorURL, "oci-rekor-url", oci.DefaultRekorURL, "Transparency log used to verify the signature of policy OCI images")
+	cmd.Flags().BoolVar(&applyCommandConfig.OciVerify.IgnoreTlog, "oci-ignore-tlog", false, "Skip the transparency log verification of policy OCI images")
 	cmd.Flags().BoolVarP(&applyCommandConfig.Cluster, "cluster", "c", false, "Checks if policies should be applied to cluster in the current context")
 	cmd.Flags().StringVarP(&applyCommandConfig.MutateLogPath, "output", "o", "", "Prints the mutated resources in provided file/directory")
 	// currently `set` flag supports variable for single policy applied on single resource
 	cmd.Flags().StringVarP(&applyCommandConfig.UserInfoPath, "userinfo", "u", "", "Admission Info including Roles, Cluster Roles and Subjects")
+	cmd.Flags().StringVar(&applyCommandConfig.Username, "username", "", "Username of the simulated admission request")
+	cmd.Flags().StringSliceVar(&applyCommandConfig.Groups, "groups", nil, "Groups of the simulated admission request")
+	cmd.Flags().StringVar(&applyCommandConfig.Operation, "operation", "", "Operation of the simulated admission request (CREATE, UPDATE, DELETE or CONNECT)")
+	cmd.Flags().StringSliceVar(&applyCommandConfig.OldResources, "old-resource", nil, "Path to the old version of the resources, implies the UPDATE operation (every resource must have an old version)")
+	cmd.Flags().StringSliceVar(&applyCommandConfig.Exceptions, "exceptions", nil, "Path to policy exception files, matching policy exceptions are honored when applying policies")
+	cmd.Flags().StringVar(&applyCommandConfig.AdmissionPath, "admission-request", "", "Path to an AdmissionReview (or AdmissionRequest) providing the resource, old resource, operation and user info")
 	cmd.Flags().StringSliceVarP(&applyCommandConfig.Variables, "set", "s", nil, "Variables that are required")
 	cmd.Flags().StringVarP(&applyCommandConfig.ValuesFile, "values-file", "f", "", "File containing values for policy variables")
 	cmd.Flags().BoolVarP(&applyCommandConfig.PolicyReport, "policy-report", "p", false, "Generates policy report when passed (default policyviolation)")
@@ -115,6 +160,7 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVar(&removeColor, "remove-color", false, "Remove any color from output")
 	cmd.Flags().BoolVar(&detailedResults, "detailed-results", false, "If set to true, display detailed results")
 	cmd.Flags().BoolVarP(&table, "table", "t", false, "Show results in table format")
+	cmd.Flags().StringVar(&outputFormat, "output-format", string(format.Text), "Output format for results (text, junit, sarif or github)")
 	return cmd
 }
 
@@ -140,10 +186,30 @@ func (c *ApplyCommandConfig) applyCommandHelper(out io.Writer) (*processor.Resul
 		deprecations.CheckUserInfo(out, c.UserInfoPath, info)
 		userInfo = &info.RequestInfo
 	}
+	if c.Username != "" || len(c.Groups) != 0 {
+		if userInfo == nil {
+			userInfo = &v1beta1.RequestInfo{}
+		}
+		if c.Username != "" {
+			userInfo.AdmissionUserInfo.Username = c.Username
+		}
+		userInfo.AdmissionUserInfo.Groups = append(userInfo.AdmissionUserInfo.Groups, c.Groups...)
+	}
+	operation, oldResources, request, err := c.loadAdmissionAttributes()
+	if err != nil {
+		return nil, nil, skipInvalidPolicies, nil, err
+	}
+	if request != nil {
+		userInfo = &request.UserInfo
+	}
 	variables, err := variables.New(out, nil, "", c.ValuesFile, nil, c.Variables...)
 	if err != nil {
 		return nil, nil, skipInvalidPolicies, nil, fmt.Errorf("failed to decode yaml (%w)", err)
 	}
+	exceptions, err := exception.LoadFiles(c.Exceptions...)
+	if err != nil {
+		return nil, nil, skipInvalidPolicies, nil, err
+	}
 	var store store.Store
 	rc, resources1, skipInvalidPolicies, responses1, err, dClient := c.initStoreAndClusterClient(&store, skipInvalidPolicies)
 	if err != nil {
@@ -153,14 +219,19 @@ func (c *ApplyCommandConfig) applyCommandHelper(out io.Writer) (*processor.Resul
 	if err != nil {
 		return rc, resources1, skipInvalidPolicies, responses1, err
 	}
-	resources, err := c.loadResources(out, policies, validatingAdmissionPolicies, dClient)
-	if err != nil {
-		return rc, resources1, skipInvalidPolicies, responses1, err
+	var resources []*unstructured.Unstructured
+	if request != nil {
+		resources = append(resources, request.Resource)
+	} else {
+		resources, err = c.loadResources(out, policies, validatingAdmissionPolicies, dClient)
+		if err != nil {
+			return rc, resources1, skipInvalidPolicies, responses1, err
+		}
 	}
 	if !c.Stdin {
 		var policyRulesCount int
 		for _, policy := range policies {
-			policyRulesCount += len(autogen.ComputeRules(policy))
+			policyRulesCount += len(autogen.ComputeRules(policy, nil))
 		}
 		policyRulesCount += len(validatingAdmissionPolicies)
 		fmt.Fprintf(out, "\nApplying %d policy rule(s) to %d resource(s)...\n", policyRulesCount, len(resources))
@@ -178,10 +249,13 @@ func (c *ApplyCommandConfig) applyCommandHelper(out io.Writer) (*processor.Resul
 		&store,
 		variables,
 		policies,
+		exceptions,
 		resources,
 		&skipInvalidPolicies,
 		dClient,
 		userInfo,
+		operation,
+		oldResources,
 		mutateLogPathIsDir,
 		rclient,
 	)
@@ -236,10 +310,13 @@ func (c *ApplyCommandConfig) applyPolicytoResource(
 	store *store.Store,
 	vars *variables.Variables,
 	policies []kyvernov1.PolicyInterface,
+	exceptions []*kyvernov2.PolicyException,
 	resources []*unstructured.Unstructured,
 	skipInvalidPolicies *SkippedInvalidPolicies,
 	dClient dclient.Interface,
 	userInfo *v1beta1.RequestInfo,
+	operation kyvernov1.AdmissionOperation,
+	oldResources []*unstructured.Unstructured,
 	mutateLogPathIsDir bool,
 	rclient registryclient.Client,
 ) (*processor.ResultCounts, []*unstructured.Unstructured, []engineapi.EngineResponse, error) {
@@ -250,7 +327,7 @@ func (c *ApplyCommandConfig) applyPolicytoResource(
 	var validPolicies []kyvernov1.PolicyInterface
 	for _, pol := range policies {
 		// TODO we should return this info to the caller
-		_, err := policyvalidation.Validate(pol, nil, nil, true, config.KyvernoUserName(config.KyvernoServiceAccountName()))
+		_, err := policyvalidation.Validate(context.TODO(), pol, nil, nil, true, config.KyvernoUserName(config.KyvernoServiceAccountName()))
 		if err != nil {
 			log.Log.Error(err, "policy validation error")
 			if strings.HasPrefix(err.Error(), "variable 'element.name'") {
@@ -266,14 +343,23 @@ func (c *ApplyCommandConfig) applyPolicytoResource(
 	var rc processor.ResultCounts
 	var responses []engineapi.EngineResponse
 	for _, resource := range resources {
+		oldResource := admission.FindOldResource(oldResources, *resource)
+		// an UPDATE request without old object would silently be evaluated against the new object
+		if oldResource == nil && len(c.OldResources) != 0 {
+			key, _ := cache.MetaNamespaceKeyFunc(resource)
+			return &rc, resources, responses, fmt.Errorf("no old version of %s %s found in --old-resource files", resource.GetKind(), key)
+		}
 		processor := processor.PolicyProcessor{
 			Store:                store,
 			Policies:             validPolicies,
+			Exceptions:           exceptions,
 			Resource:             *resource,
 			MutateLogPath:        c.MutateLogPath,
 			MutateLogPathIsDir:   mutateLogPathIsDir,
 			Variables:            vars,
 			UserInfo:             userInfo,
+			Operation:            operation,
+			OldResource:          oldResource,
 			PolicyReport:         c.PolicyReport,
 			NamespaceSelectorMap: vars.NamespaceSelectors(),
 			Stdin:                c.Stdin,
@@ -294,6 +380,43 @@ func (c *ApplyCommandConfig) applyPolicytoResource(
 	return &rc, resources, responses, nil
 }
 
+// loadAdmissionAttributes returns the operation and old resources of the simulated admission requests.
+// When an admission request file is given, the request is returned too.
+func (c *ApplyCommandConfig) loadAdmissionAttributes() (kyvernov1.AdmissionOperation, []*unstructured.Unstructured, *admission.Request, error) {
+	if c.AdmissionPath != "" {
+		request, err := admission.Load(c.AdmissionPath)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		var oldResources []*unstructured.Unstructured
+		if request.OldResource != nil {
+			oldResources = append(oldResources, request.OldResource)
+		}
+		return request.Operation, oldResources, request, nil
+	}
+	var operation kyvernov1.AdmissionOperation
+	if c.Operation != "" {
+		parsed, err := admission.ParseOperation(c.Operation)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		operation = parsed
+	}
+	if len(c.OldResources) == 0 {
+		return operation, nil, nil, nil
+	}
+	if operation == "" {
+		operation = kyvernov1.Update
+	} else if operation != kyvernov1.Update {
+		return "", nil, nil, fmt.Errorf("--old-resource can only be used with the UPDATE operation")
+	}
+	oldResources, err := admission.LoadResources(c.OldResources...)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return operation, oldResources, nil, nil
+}
+
 func (c *ApplyCommandConfig) loadResources(out io.Writer, policies []kyvernov1.PolicyInterface, validatingAdmissionPolicies []v1alpha1.ValidatingAdmissionPolicy, dClient dclient.Interface) ([]*unstructured.Unstructured, error) {
 	resources, err := common.GetResourceAccordingToResourcePath(out, nil, c.ResourcePaths, c.Cluster, policies, validatingAdmissionPolicies, dClient, c.Namespace, c.PolicyReport, "")
 	if err != nil {
@@ -310,7 +433,13 @@ func (c *ApplyCommandConfig) loadPolicies(skipInvalidPolicies SkippedInvalidPoli
 	for _, path := range c.PolicyPaths {
 		isGit := source.IsGit(path)
 
-		if isGit {
+		if source.IsOci(path) {
+			policiesFromImage, err := oci.Fetch(context.Background(), source.OciReference(path), oci.Keychain(), c.OciVerify)
+			if err != nil {
+				return nil, nil, skipInvalidPolicies, nil, fmt.Errorf("failed to load policies from %s (%w)", path, err), nil, nil
+			}
+			policies = append(policies, policiesFromImage...)
+		} else if isGit {
 			gitSourceURL, err := url.Parse(path)
 			if err != nil {
 				return nil, nil, skipInvalidPolicies, nil, fmt.Errorf("failed to load policies (%w)", err), nil, nil
@@ -409,7 +538,11 @@ func (c *ApplyCommandConfig) checkArguments() (*processor.ResultCounts, []*unstr
 	if (len(c.PolicyPaths) > 0 && c.PolicyPaths[0] == "-") && len(c.ResourcePaths) > 0 && c.ResourcePaths[0] == "-" {
 		return nil, nil, skipInvalidPolicies, nil, fmt.Errorf("a stdin pipe can be used for either policies or resources, not both")
 	}
-	if len(c.ResourcePaths) == 0 && !c.Cluster {
+	if c.AdmissionPath != "" {
+		if len(c.ResourcePaths) > 0 || c.Cluster || c.Operation != "" || len(c.OldResources) > 0 || c.UserInfoPath != "" || c.Username != "" || len(c.Groups) > 0 {
+			return nil, nil, skipInvalidPolicies, nil, fmt.Errorf("--admission-request can't be used together with --resource, --cluster, --operation, --old-resource, --userinfo, --username or --groups")
+		}
+	} else if len(c.ResourcePaths) == 0 && !c.Cluster {
 		return nil, nil, skipInvalidPolicies, nil, fmt.Errorf("resource file(s) or cluster required")
 	}
 	return nil, nil, skipInvalidPolicies, nil, nil
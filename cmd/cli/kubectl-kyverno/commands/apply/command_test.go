@@ -310,6 +310,75 @@ func Test_Apply(t *testing.T) {
 				},
 			}},
 		},
+		{
+			config: ApplyCommandConfig{
+				PolicyPaths:   []string{"../../_testdata/admission/policy.yaml"},
+				ResourcePaths: []string{"../../_testdata/admission/resource.yaml"},
+				OldResources:  []string{"../../_testdata/admission/old-resource.yaml"},
+				PolicyReport:  true,
+			},
+			expectedPolicyReports: []policyreportv1alpha2.PolicyReport{{
+				Summary: policyreportv1alpha2.PolicyReportSummary{
+					Pass:  0,
+					Fail:  1,
+					Skip:  0,
+					Error: 0,
+					Warn:  0,
+				},
+			}},
+		},
+		{
+			config: ApplyCommandConfig{
+				PolicyPaths:   []string{"../../_testdata/admission/policy.yaml"},
+				ResourcePaths: []string{"../../_testdata/admission/resource.yaml"},
+				Operation:     "UPDATE",
+				PolicyReport:  true,
+			},
+			expectedPolicyReports: []policyreportv1alpha2.PolicyReport{{
+				Summary: policyreportv1alpha2.PolicyReportSummary{
+					Pass:  1,
+					Fail:  0,
+					Skip:  0,
+					Error: 0,
+					Warn:  0,
+				},
+			}},
+		},
+		{
+			config: ApplyCommandConfig{
+				PolicyPaths:   []string{"../../_testdata/admission/policy.yaml"},
+				ResourcePaths: []string{"../../_testdata/admission/resource.yaml"},
+				Operation:     "DELETE",
+				Username:      "admin",
+				Groups:        []string{"cluster-admins"},
+				PolicyReport:  true,
+			},
+			expectedPolicyReports: []policyreportv1alpha2.PolicyReport{{
+				Summary: policyreportv1alpha2.PolicyReportSummary{
+					Pass:  1,
+					Fail:  0,
+					Skip:  0,
+					Error: 0,
+					Warn:  0,
+				},
+			}},
+		},
+		{
+			config: ApplyCommandConfig{
+				PolicyPaths:   []string{"../../_testdata/admission/policy.yaml"},
+				AdmissionPath: "../../_testdata/admission/admission-review.yaml",
+				PolicyReport:  true,
+			},
+			expectedPolicyReports: []policyreportv1alpha2.PolicyReport{{
+				Summary: policyreportv1alpha2.PolicyReportSummary{
+					Pass:  0,
+					Fail:  1,
+					Skip:  0,
+					Error: 0,
+					Warn:  0,
+				},
+			}},
+		},
 	}
 
 	compareSummary := func(expected policyreportv1alpha2.PolicyReportSummary, actual policyreportv1alpha2.PolicyReportSummary, desc string) {
@@ -375,6 +444,89 @@ func TestCommand(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCommandWithOutputFormat(t *testing.T) {
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"../../_testdata/apply/test-1/policy.yaml",
+		"--resource",
+		"../../_testdata/apply/test-1/resources.yaml",
+		"--output-format",
+		"junit",
+	})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), "<?xml"))
+	assert.Contains(t, string(out), `<testsuite name="test-policy"`)
+	assert.Contains(t, string(out), `file="../../_testdata/apply/test-1/resources.yaml" line="21"`)
+}
+
+func TestCommandWithInvalidOutputFormat(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{
+		"../../_testdata/apply/test-1/policy.yaml",
+		"--resource",
+		"../../_testdata/apply/test-1/resources.yaml",
+		"--output-format",
+		"xml",
+	})
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandWithAdmissionRequestAndResource(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{"../../_testdata/admission/policy.yaml", "--admission-request", "../../_testdata/admission/admission-review.yaml", "--resource", "../../_testdata/admission/resource.yaml"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandWithOldResourceAndInvalidOperation(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{"../../_testdata/admission/policy.yaml", "--resource", "../../_testdata/admission/resource.yaml", "--old-resource", "../../_testdata/admission/old-resource.yaml", "--operation", "DELETE"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandWithUnmatchedOldResource(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{"../../_testdata/admission/policy.yaml", "--resource", "../../_testdata/admission/resource.yaml", "--old-resource", "../../_testdata/admission/policy.yaml"})
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, "no old version of Pod default/nginx found in --old-resource files")
+}
+
+func TestCommandWithExceptions(t *testing.T) {
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"../../_testdata/exceptions/policy.yaml", "--resource", "../../_testdata/exceptions/resources.yaml"})
+	assert.Error(t, cmd.Execute())
+	assert.Contains(t, b.String(), "pass: 0, fail: 2, warn: 0, error: 0, skip: 4")
+	cmd = Command()
+	b = bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"../../_testdata/exceptions/policy.yaml", "--resource", "../../_testdata/exceptions/resources.yaml", "--exceptions", "../../_testdata/exceptions/exception.yaml"})
+	assert.Error(t, cmd.Execute())
+	assert.Contains(t, b.String(), "pass: 0, fail: 1, warn: 0, error: 0, skip: 5")
+}
+
+func TestCommandWithInvalidExceptions(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{"../../_testdata/exceptions/policy.yaml", "--resource", "../../_testdata/exceptions/resources.yaml", "--exceptions", "../../_testdata/exceptions/policy.yaml"})
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, "failed to load policy exceptions from ../../_testdata/exceptions/policy.yaml")
+}
+
+func TestCommandWithInvalidOciPolicy(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{"--policy", "oci://Invalid Reference", "--resource", "../../_testdata/admission/resource.yaml"})
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, "failed to load policies from oci://Invalid Reference")
+}
+
 func TestCommandWithInvalidArg(t *testing.T) {
 	cmd := Command()
 	assert.NotNil(t, cmd)
@@ -31,4 +31,24 @@ var examples = [][]string{
 		"# Apply multiple policy with variable on multiple resource",
 		"kyverno apply /path/to/policy1.yaml /path/to/policy2.yaml --resource /path/to/resource1.yaml --resource /path/to/resource2.yaml -f /path/to/value.yaml",
 	},
+	{
+		"# Apply on a folder of resources and write results in SARIF format for code scanning",
+		"kyverno apply /path/to/policy.yaml --resource=/path/to/resources/ --output-format sarif > results.sarif",
+	},
+	{
+		"# Simulate an UPDATE request by a given user",
+		"kyverno apply /path/to/policy.yaml --resource /path/to/new.yaml --old-resource /path/to/old.yaml --username alice --groups dev,qa",
+	},
+	{
+		"# Simulate a DELETE request",
+		"kyverno apply /path/to/policy.yaml --resource /path/to/resource.yaml --operation DELETE",
+	},
+	{
+		"# Replay a captured AdmissionReview",
+		"kyverno apply /path/to/policy.yaml --admission-request /path/to/admission-review.yaml",
+	},
+	{
+		"# Apply policies from an OCI image after verifying its cosign signature",
+		"kyverno apply --policy oci://ghcr.io/org/policies:v1 --oci-key cosign.pub --resource /path/to/resources/",
+	},
 }
@@ -0,0 +1,32 @@
+package apply
+
+import (
+	"io"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/format"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/report"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+)
+
+func printFormat(out io.Writer, f format.Format, resourcePaths []string, auditWarn bool, engineResponses ...engineapi.EngineResponse) error {
+	locator := format.NewLocator(nil, resourcePaths...)
+	var results []format.Result
+	for _, engineResponse := range engineResponses {
+		resource := engineResponse.Resource
+		for _, ruleResponse := range engineResponse.PolicyResponse.Rules {
+			result := report.ComputePolicyReportResult(auditWarn, engineResponse, ruleResponse)
+			results = append(results, format.Result{
+				Policy:    result.Policy,
+				Rule:      result.Rule,
+				Kind:      resource.GetKind(),
+				Namespace: resource.GetNamespace(),
+				Name:      resource.GetName(),
+				Status:    result.Result,
+				Severity:  result.Severity,
+				Message:   result.Message,
+				Location:  locator.Locate(resource.GetKind(), resource.GetNamespace(), resource.GetName()),
+			})
+		}
+	}
+	return format.Write(out, f, results...)
+}
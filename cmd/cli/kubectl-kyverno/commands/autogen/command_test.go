@@ -0,0 +1,135 @@
+package autogen
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPolicy = `apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+spec:
+  rules:
+  - name: require-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: label team is required
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+  - name: require-app
+    autogen:
+      enabled: false
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: label app is required
+      pattern:
+        metadata:
+          labels:
+            app: "?*"
+`
+
+func writePolicy(t *testing.T) string {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "policy.yaml"), []byte(testPolicy), 0o600))
+	return dir
+}
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandWithPolicies(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{writePolicy(t)})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "  rule require-team:\n    autogen-require-team (DaemonSet, Deployment, Job, StatefulSet, ReplicaSet, ReplicationController)\n    autogen-cronjob-require-team (CronJob)\n")
+	assert.Contains(t, string(out), "  rule require-app: autogen disabled\n")
+}
+
+func TestCommandWithPoliciesYaml(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{writePolicy(t), "--output-format", "yaml"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "name: autogen-cronjob-require-team")
+	assert.NotContains(t, string(out), "name: autogen-require-app")
+}
+
+func TestCommandWithInvalidArg(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: requires at least 1 arg(s), only received 0`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandWithInvalidFlag(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--xxx"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown flag: --xxx`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
+
+func TestCommandInvalidOutputFormat(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	cmd.SetArgs([]string{"foo", "--output-format", "xml"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
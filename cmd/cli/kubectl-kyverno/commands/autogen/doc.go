@@ -0,0 +1,24 @@
+package autogen
+
+// TODO
+var websiteUrl = ``
+
+var description = []string{
+	`Show the rules auto generated for pod controllers.`,
+	``,
+	`The autogen command loads policy files and shows, for every rule, the pod controllers selected`,
+	`and the rules generated for them, or why no rule was generated.`,
+	``,
+	`In yaml format, policies are printed with the effective rule set evaluated by Kyverno.`,
+}
+
+var examples = [][]string{
+	{
+		`# Show the rules auto generated from Kyverno policy files`,
+		`KYVERNO_EXPERIMENTAL=true kyverno autogen .`,
+	},
+	{
+		`# Print Kyverno policies with their effective rule set`,
+		`KYVERNO_EXPERIMENTAL=true kyverno autogen . --output-format yaml`,
+	},
+}
@@ -0,0 +1,89 @@
+package autogen
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	clipath "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/path"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+type options struct {
+	outputFormat string
+}
+
+func (o options) validate(dirs ...string) error {
+	if err := clipath.ValidateDirs(dirs...); err != nil {
+		return err
+	}
+	if o.outputFormat != "text" && o.outputFormat != "yaml" {
+		return fmt.Errorf("invalid output format: %s", o.outputFormat)
+	}
+	return nil
+}
+
+func (o options) execute(out io.Writer, errOut io.Writer, dirs ...string) error {
+	files, err := clipath.FindYamls(dirs...)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		policies, _, err := policy.Load(nil, "", file)
+		if err != nil {
+			fmt.Fprintf(errOut, "Skipping file %s: %s\n", file, err)
+			continue
+		}
+		for _, policy := range policies {
+			if o.outputFormat == "yaml" {
+				if err := printYaml(out, policy); err != nil {
+					return err
+				}
+			} else {
+				printText(out, file, policy)
+			}
+		}
+	}
+	return nil
+}
+
+func printText(out io.Writer, file string, policy kyvernov1.PolicyInterface) {
+	fmt.Fprintf(out, "%s (%s):\n", policy.GetName(), file)
+	for _, generated := range autogen.ExplainRules(policy, nil) {
+		switch {
+		case generated.Disabled:
+			fmt.Fprintf(out, "  rule %s: autogen disabled\n", generated.Rule)
+		case len(generated.Rules) == 0:
+			fmt.Fprintf(out, "  rule %s: no rule generated\n", generated.Rule)
+		default:
+			fmt.Fprintf(out, "  rule %s:\n", generated.Rule)
+			for _, rule := range generated.Rules {
+				fmt.Fprintf(out, "    %s (%s)\n", rule.Name, strings.Join(rule.MatchResources.GetKinds(), ", "))
+			}
+		}
+	}
+}
+
+func printYaml(out io.Writer, policy kyvernov1.PolicyInterface) error {
+	policy = policy.CreateDeepCopy()
+	policy.GetSpec().SetRules(autogen.ComputeRules(policy, nil))
+	untyped, err := kubeutils.ObjToUnstructured(policy)
+	if err != nil {
+		return err
+	}
+	// prune some fields
+	unstructured.RemoveNestedField(untyped.UnstructuredContent(), "status")
+	unstructured.RemoveNestedField(untyped.UnstructuredContent(), "metadata", "creationTimestamp")
+	data, err := yaml.Marshal(untyped.UnstructuredContent())
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, "---")
+	fmt.Fprint(out, string(data))
+	return nil
+}
@@ -0,0 +1,29 @@
+package bench
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "bench",
+		Short:        command.FormatDescription(true, websiteUrl, true, description...),
+		Long:         command.FormatDescription(false, websiteUrl, true, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return options.execute(cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringSliceVar(&options.policyPaths, "policy", nil, "Path to policy files or folders")
+	cmd.Flags().StringSliceVar(&options.resourcePaths, "resource", nil, "Path to resource files or folders")
+	cmd.Flags().IntVarP(&options.iterations, "iterations", "n", 10, "Number of times the policies are applied to every resource")
+	cmd.Flags().StringVarP(&options.valuesFile, "values-file", "f", "", "File containing values for policy variables")
+	return cmd
+}
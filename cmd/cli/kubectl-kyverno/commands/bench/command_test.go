@@ -0,0 +1,55 @@
+package bench
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	err := cmd.Execute()
+	assert.EqualError(t, err, "a policy is required")
+}
+
+func TestCommandWithInvalidIterations(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{
+		"--policy", "../../_testdata/policies/cpol-pod-requirements.yaml",
+		"--resource", "../../_testdata/resources/all-unique.yaml",
+		"--iterations", "0",
+	})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "iterations must be at least 1")
+}
+
+func TestCommandWithPolicy(t *testing.T) {
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"--policy", "../../_testdata/policies/cpol-pod-requirements.yaml",
+		"--resource", "../../_testdata/resources/all-unique.yaml",
+		"--iterations", "3",
+	})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out := b.String()
+	assert.Contains(t, out, "Iterations: 3\nPolicies: 1\nResources: 6\n")
+	assert.Contains(t, out, "Rule latencies:\n  POLICY")
+	assert.Regexp(t, `pod-requirements\s+pods-require-account\s+12\s`, out)
+	assert.Regexp(t, `pod-requirements\s+pods-require-limits\s+12\s`, out)
+	assert.Regexp(t, `Policy allocations:\n\s+POLICY\s+RUNS\s+ALLOCS/RUN\s+BYTES/RUN\n\s+pod-requirements\s+18\s`, out)
+}
+
+func TestPercentile(t *testing.T) {
+	samples := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	assert.Equal(t, time.Duration(0), percentile(nil, 50))
+	assert.Equal(t, time.Duration(5), percentile(samples, 50))
+	assert.Equal(t, time.Duration(9), percentile(samples, 90))
+	assert.Equal(t, time.Duration(10), percentile(samples, 99))
+	assert.Equal(t, time.Duration(1), percentile(samples, 0))
+}
@@ -0,0 +1,26 @@
+package bench
+
+// TODO
+var websiteUrl = ``
+
+var description = []string{
+	`Benchmark policies against a corpus of resources.`,
+	``,
+	`The bench command applies the policies to every resource the given number of times and reports,`,
+	`for every rule, the latency percentiles observed across runs, and for every policy, the memory`,
+	`allocated per run. It helps detecting expensive JMESPath expressions and context calls before`,
+	`deploying policies to production webhooks.`,
+	``,
+	`Variables can be provided with a values file, the same way as with the apply command.`,
+}
+
+var examples = [][]string{
+	{
+		`# Benchmark a policy against resources`,
+		`KYVERNO_EXPERIMENTAL=true kyverno bench --policy policy.yaml --resource resources.yaml`,
+	},
+	{
+		`# Benchmark a policy set with 100 iterations`,
+		`KYVERNO_EXPERIMENTAL=true kyverno bench --policy policies/ --resource resources/ --iterations 100`,
+	},
+}
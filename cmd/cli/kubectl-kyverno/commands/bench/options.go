@@ -0,0 +1,113 @@
+package bench
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/variables"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+)
+
+type options struct {
+	policyPaths   []string
+	resourcePaths []string
+	iterations    int
+	valuesFile    string
+}
+
+func (o options) validate() error {
+	if len(o.policyPaths) == 0 {
+		return errors.New("a policy is required")
+	}
+	if len(o.resourcePaths) == 0 {
+		return errors.New("a resource is required")
+	}
+	if o.iterations < 1 {
+		return errors.New("iterations must be at least 1")
+	}
+	return nil
+}
+
+func (o options) execute(out io.Writer) error {
+	policies, _, err := policy.Load(nil, "", o.policyPaths...)
+	if err != nil {
+		return fmt.Errorf("failed to load policies (%w)", err)
+	}
+	if len(policies) == 0 {
+		return errors.New("no policies found")
+	}
+	resources, err := common.GetResourceAccordingToResourcePath(out, nil, o.resourcePaths, false, policies, nil, nil, "", false, "")
+	if err != nil {
+		return fmt.Errorf("failed to load resources (%w)", err)
+	}
+	if len(resources) == 0 {
+		return errors.New("no resources found")
+	}
+	vars, err := variables.New(out, nil, "", o.valuesFile, nil)
+	if err != nil {
+		return fmt.Errorf("failed to decode yaml (%w)", err)
+	}
+	var s store.Store
+	s.SetLocal(true)
+	vars.SetInStore(&s)
+	results := newResults()
+	for i := 0; i < o.iterations; i++ {
+		for _, policy := range policies {
+			for _, resource := range resources {
+				if err := o.run(&s, vars, policy, *resource, results); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	fmt.Fprintf(out, "Iterations: %d\n", o.iterations)
+	fmt.Fprintf(out, "Policies: %d\n", len(policies))
+	fmt.Fprintf(out, "Resources: %d\n", len(resources))
+	fmt.Fprintln(out)
+	results.print(out)
+	return nil
+}
+
+// run applies a single policy to a single resource, recording the latency of every rule and the memory allocated by the run
+func (o options) run(
+	s *store.Store,
+	vars *variables.Variables,
+	policy kyvernov1.PolicyInterface,
+	resource unstructured.Unstructured,
+	results *results,
+) error {
+	var rc processor.ResultCounts
+	processor := processor.PolicyProcessor{
+		Store:                s,
+		Policies:             []kyvernov1.PolicyInterface{policy},
+		Resource:             resource,
+		Variables:            vars,
+		NamespaceSelectorMap: vars.NamespaceSelectors(),
+		Rc:                   &rc,
+		Subresources:         vars.Subresources(),
+		Out:                  io.Discard,
+	}
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	responses, err := processor.ApplyPoliciesOnResource()
+	runtime.ReadMemStats(&after)
+	if err != nil {
+		key, _ := cache.MetaNamespaceKeyFunc(&resource)
+		return fmt.Errorf("failed to apply policy %s on resource %s (%w)", policy.GetName(), key, err)
+	}
+	results.addAllocations(policy.GetName(), after.Mallocs-before.Mallocs, after.TotalAlloc-before.TotalAlloc)
+	for _, response := range responses {
+		for _, rule := range response.PolicyResponse.Rules {
+			results.addLatency(response.Policy().GetName(), rule.Name(), rule.Stats().ProcessingTime())
+		}
+	}
+	return nil
+}
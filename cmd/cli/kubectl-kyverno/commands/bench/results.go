@@ -0,0 +1,105 @@
+package bench
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+type ruleKey struct {
+	policy string
+	rule   string
+}
+
+type allocations struct {
+	runs   uint64
+	allocs uint64
+	bytes  uint64
+}
+
+type results struct {
+	latencies   map[ruleKey][]time.Duration
+	allocations map[string]*allocations
+}
+
+func newResults() *results {
+	return &results{
+		latencies:   map[ruleKey][]time.Duration{},
+		allocations: map[string]*allocations{},
+	}
+}
+
+func (r *results) addLatency(policy, rule string, duration time.Duration) {
+	key := ruleKey{policy: policy, rule: rule}
+	r.latencies[key] = append(r.latencies[key], duration)
+}
+
+func (r *results) addAllocations(policy string, allocs, bytes uint64) {
+	stats := r.allocations[policy]
+	if stats == nil {
+		stats = &allocations{}
+		r.allocations[policy] = stats
+	}
+	stats.runs++
+	stats.allocs += allocs
+	stats.bytes += bytes
+}
+
+func (r *results) print(out io.Writer) {
+	keys := make([]ruleKey, 0, len(r.latencies))
+	for key := range r.latencies {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].policy != keys[j].policy {
+			return keys[i].policy < keys[j].policy
+		}
+		return keys[i].rule < keys[j].rule
+	})
+	fmt.Fprintln(out, "Rule latencies:")
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  POLICY\tRULE\tSAMPLES\tP50\tP90\tP99\tMAX")
+	for _, key := range keys {
+		samples := r.latencies[key]
+		sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+		fmt.Fprintf(w, "  %s\t%s\t%d\t%s\t%s\t%s\t%s\n",
+			key.policy,
+			key.rule,
+			len(samples),
+			percentile(samples, 50),
+			percentile(samples, 90),
+			percentile(samples, 99),
+			samples[len(samples)-1],
+		)
+	}
+	w.Flush()
+	policies := make([]string, 0, len(r.allocations))
+	for policy := range r.allocations {
+		policies = append(policies, policy)
+	}
+	sort.Strings(policies)
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Policy allocations:")
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  POLICY\tRUNS\tALLOCS/RUN\tBYTES/RUN")
+	for _, policy := range policies {
+		stats := r.allocations[policy]
+		fmt.Fprintf(w, "  %s\t%d\t%d\t%d\n", policy, stats.runs, stats.allocs/stats.runs, stats.bytes/stats.runs)
+	}
+	w.Flush()
+}
+
+// percentile returns the nearest-rank percentile of sorted samples
+func percentile(samples []time.Duration, p float64) time.Duration {
+	if len(samples) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(samples))))
+	if rank < 1 {
+		rank = 1
+	}
+	return samples[rank-1]
+}
@@ -3,11 +3,19 @@ package commands
 import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/apply"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/autogen"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/bench"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/convert"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/create"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/diff"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/docs"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/explain"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/fix"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/jp"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/lint"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/oci"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/replay"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/scan"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/test"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/version"
 	"github.com/spf13/cobra"
@@ -33,8 +41,16 @@ func RootCommand(experimental bool) *cobra.Command {
 	)
 	if experimental {
 		cmd.AddCommand(
+			autogen.Command(),
+			bench.Command(),
+			convert.Command(),
+			diff.Command(),
+			explain.Command(),
 			fix.Command(),
+			lint.Command(),
 			oci.Command(),
+			replay.Command(),
+			scan.Command(),
 		)
 	}
 	return cmd
@@ -0,0 +1,18 @@
+package convert
+
+// TODO
+var websiteUrl = ``
+
+var description = []string{
+	`Convert Kyverno resources to and from other formats.`,
+	``,
+	`The convert command provides a command-line interface to translate Kyverno resources.`,
+	`It can be used to convert Kyverno policies to ValidatingAdmissionPolicies and back.`,
+}
+
+var examples = [][]string{
+	{
+		`# Convert Kyverno policies to ValidatingAdmissionPolicies`,
+		`KYVERNO_EXPERIMENTAL=true kyverno convert vap ./policies`,
+	},
+}
@@ -0,0 +1,112 @@
+package vap
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandWithVap(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"../../../../../../test/cli/test/validating-admission-policies/disallow-host-path/disallow-host-path.yaml"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "kind: ClusterPolicy")
+	assert.Contains(t, string(out), "- apps/v1/Deployment")
+}
+
+func TestCommandWithInvalidArg(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: requires at least 1 arg(s), only received 0`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandWithInvalidFlag(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--xxx"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: unknown flag: --xxx`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
+
+func TestCommandWithVapBinding(t *testing.T) {
+	dir := t.TempDir()
+	vap, err := os.ReadFile("../../../../../../test/cli/test/validating-admission-policies/disallow-host-path/disallow-host-path.yaml")
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "vap.yaml"), vap, 0o600))
+	binding := `apiVersion: admissionregistration.k8s.io/v1alpha1
+kind: ValidatingAdmissionPolicyBinding
+metadata:
+  name: disallow-host-path-binding
+spec:
+  policyName: disallow-host-path
+  validationActions: [Deny]
+`
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "binding.yaml"), []byte(binding), 0o600))
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	e := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetErr(e)
+	cmd.SetArgs([]string{dir})
+	assert.NoError(t, cmd.Execute())
+	assert.Empty(t, e.String())
+	assert.Contains(t, b.String(), "name: disallow-host-path\n")
+	assert.Contains(t, b.String(), "validationFailureAction: Enforce")
+}
+
+func TestCommandWithInvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.yaml"), []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: foo\n"), 0o600))
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	e := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetErr(e)
+	cmd.SetArgs([]string{dir})
+	assert.NoError(t, cmd.Execute())
+	assert.Contains(t, e.String(), "Skipping file "+filepath.Join(dir, "invalid.yaml"))
+}
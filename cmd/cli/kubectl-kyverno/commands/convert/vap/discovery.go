@@ -0,0 +1,141 @@
+package vap
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	openapiv2 "github.com/google/gnostic-models/openapiv2"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// discoveryClient resolves built-in kinds and resources from the client-go scheme,
+// it allows converting policies without access to a cluster.
+type discoveryClient struct {
+	scheme *runtime.Scheme
+}
+
+func newDiscoveryClient() *discoveryClient {
+	return &discoveryClient{scheme: scheme.Scheme}
+}
+
+func (c *discoveryClient) kinds() []schema.GroupVersionKind {
+	var gvks []schema.GroupVersionKind
+	for gvk := range c.scheme.AllKnownTypes() {
+		if gvk.Version == runtime.APIVersionInternal || strings.HasSuffix(gvk.Kind, "List") {
+			continue
+		}
+		gvks = append(gvks, gvk)
+	}
+	return gvks
+}
+
+func (c *discoveryClient) FindResources(group, version, kind, subresource string) (map[dclient.TopLevelApiDescription]metav1.APIResource, error) {
+	if kind == "*" {
+		return nil, errors.New("wildcard kinds aren't applicable")
+	}
+	var candidates []schema.GroupVersionKind
+	for _, gvk := range c.kinds() {
+		if gvk.Kind != kind || (group != "*" && gvk.Group != group) || (version != "*" && gvk.Version != version) {
+			continue
+		}
+		candidates = append(candidates, gvk)
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("kind %s not found", kind)
+	}
+	candidates = preferStable(candidates)
+	for _, candidate := range candidates[1:] {
+		if candidate.Group != candidates[0].Group {
+			return nil, fmt.Errorf("no unique match for kind %s", kind)
+		}
+	}
+	// prefer the version with the highest priority in the scheme
+	selected := candidates[0]
+	for _, gv := range c.scheme.PrioritizedVersionsForGroup(selected.Group) {
+		found := false
+		for _, candidate := range candidates {
+			if candidate.GroupVersion() == gv {
+				selected, found = candidate, true
+				break
+			}
+		}
+		if found {
+			break
+		}
+	}
+	gvr, _ := meta.UnsafeGuessKindToResource(selected)
+	name := gvr.Resource
+	if subresource != "" {
+		name = name + "/" + subresource
+	}
+	return map[dclient.TopLevelApiDescription]metav1.APIResource{
+		{
+			GroupVersion: selected.GroupVersion(),
+			Kind:         selected.Kind,
+			Resource:     gvr.Resource,
+			SubResource:  subresource,
+		}: {
+			Name:    name,
+			Group:   selected.Group,
+			Version: selected.Version,
+			Kind:    selected.Kind,
+		},
+	}, nil
+}
+
+// preferStable drops the candidates of groups without a stable version when a candidate has one,
+// the scheme still registers kinds of legacy groups (e.g. extensions/v1beta1 Deployment).
+func preferStable(candidates []schema.GroupVersionKind) []schema.GroupVersionKind {
+	stableGroups := sets.New[string]()
+	for _, candidate := range candidates {
+		if !strings.Contains(candidate.Version, "alpha") && !strings.Contains(candidate.Version, "beta") {
+			stableGroups.Insert(candidate.Group)
+		}
+	}
+	if stableGroups.Len() == 0 {
+		return candidates
+	}
+	var stable []schema.GroupVersionKind
+	for _, candidate := range candidates {
+		if stableGroups.Has(candidate.Group) {
+			stable = append(stable, candidate)
+		}
+	}
+	return stable
+}
+
+func (c *discoveryClient) GetGVRFromGVK(gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
+	if !c.scheme.Recognizes(gvk) {
+		return schema.GroupVersionResource{}, fmt.Errorf("kind %s not found", gvk)
+	}
+	gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+	return gvr, nil
+}
+
+func (c *discoveryClient) GetGVKFromGVR(gvr schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	for _, gvk := range c.kinds() {
+		if gvk.GroupVersion() != gvr.GroupVersion() {
+			continue
+		}
+		if guessed, _ := meta.UnsafeGuessKindToResource(gvk); guessed == gvr {
+			return gvk, nil
+		}
+	}
+	return schema.GroupVersionKind{}, fmt.Errorf("resource %s not found", gvr)
+}
+
+func (c *discoveryClient) OpenAPISchema() (*openapiv2.Document, error) {
+	return nil, errors.New("openapi schema isn't available")
+}
+
+func (c *discoveryClient) CachedDiscoveryInterface() discovery.CachedDiscoveryInterface {
+	return nil
+}
@@ -0,0 +1,24 @@
+package vap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestDiscoveryClient(t *testing.T) {
+	client := newDiscoveryClient()
+	resources, err := client.FindResources("*", "*", "Deployment", "")
+	assert.NoError(t, err)
+	assert.Len(t, resources, 1)
+	for api, resource := range resources {
+		assert.Equal(t, schema.GroupVersion{Group: "apps", Version: "v1"}, api.GroupVersion)
+		assert.Equal(t, "deployments", resource.Name)
+	}
+	_, err = client.FindResources("*", "*", "Unknown", "")
+	assert.Error(t, err)
+	gvk, err := client.GetGVKFromGVR(schema.GroupVersionResource{Version: "v1", Resource: "pods"})
+	assert.NoError(t, err)
+	assert.Equal(t, schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, gvk)
+}
@@ -0,0 +1,25 @@
+package vap
+
+// TODO
+var websiteUrl = ``
+
+var description = []string{
+	`Convert Kyverno policies to ValidatingAdmissionPolicies and back.`,
+	``,
+	`Kyverno policies with a single CEL rule are converted to a ValidatingAdmissionPolicy and its binding.`,
+	`ValidatingAdmissionPolicies are converted to Kyverno ClusterPolicies, using the ValidatingAdmissionPolicyBindings found`,
+	`in the same directories: a binding with the Deny action produces an Enforce policy, otherwise the policy is in Audit mode.`,
+	``,
+	`Constructs that cannot be converted are reported and the corresponding policy is skipped.`,
+}
+
+var examples = [][]string{
+	{
+		`# Convert Kyverno policies to ValidatingAdmissionPolicies`,
+		`KYVERNO_EXPERIMENTAL=true kyverno convert vap ./policies`,
+	},
+	{
+		`# Convert ValidatingAdmissionPolicies to Kyverno policies`,
+		`KYVERNO_EXPERIMENTAL=true kyverno convert vap ./vaps > kyverno-policies.yaml`,
+	},
+}
@@ -0,0 +1,216 @@
+package vap
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	clipath "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/path"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	extyaml "github.com/kyverno/kyverno/ext/yaml"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	admissionpolicy "github.com/kyverno/kyverno/pkg/validatingadmissionpolicy"
+	"k8s.io/api/admissionregistration/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+var bindingV1Alpha1 = v1alpha1.SchemeGroupVersion.WithKind("ValidatingAdmissionPolicyBinding")
+
+type options struct {
+	binding bool
+}
+
+func (o options) validate(dirs ...string) error {
+	return clipath.ValidateDirs(dirs...)
+}
+
+func (o options) execute(out io.Writer, errOut io.Writer, dirs ...string) error {
+	discoveryClient := newDiscoveryClient()
+	var policies []loadedPolicy
+	var vaps []loadedVap
+	bindings := map[string][]v1alpha1.ValidatingAdmissionPolicyBinding{}
+	files, err := clipath.FindYamls(dirs...)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		p, v, b, err := load(file)
+		if err != nil {
+			fmt.Fprintf(errOut, "Skipping file %s: %s\n", file, err)
+			continue
+		}
+		for _, policy := range p {
+			policies = append(policies, loadedPolicy{policy: policy, file: file})
+		}
+		for _, vap := range v {
+			vaps = append(vaps, loadedVap{vap: vap, file: file})
+		}
+		for _, binding := range b {
+			bindings[binding.Spec.PolicyName] = append(bindings[binding.Spec.PolicyName], binding)
+		}
+	}
+	var objects []interface{}
+	for _, loaded := range policies {
+		converted, err := o.convertPolicy(discoveryClient, loaded.policy)
+		if err != nil {
+			fmt.Fprintf(errOut, "Skipping %s %s (%s): %s\n", loaded.policy.GetKind(), loaded.policy.GetName(), loaded.file, err)
+			continue
+		}
+		objects = append(objects, converted...)
+	}
+	for _, loaded := range vaps {
+		converted, err := convertVap(discoveryClient, loaded.vap, bindings[loaded.vap.GetName()])
+		if err != nil {
+			fmt.Fprintf(errOut, "Skipping ValidatingAdmissionPolicy %s (%s): %s\n", loaded.vap.GetName(), loaded.file, err)
+			continue
+		}
+		objects = append(objects, converted...)
+	}
+	for _, object := range objects {
+		untyped, err := kubeutils.ObjToUnstructured(object)
+		if err != nil {
+			return err
+		}
+		// prune some fields
+		unstructured.RemoveNestedField(untyped.UnstructuredContent(), "status")
+		unstructured.RemoveNestedField(untyped.UnstructuredContent(), "metadata", "creationTimestamp")
+		data, err := yaml.Marshal(untyped.UnstructuredContent())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, "---")
+		fmt.Fprint(out, string(data))
+	}
+	return nil
+}
+
+type loadedPolicy struct {
+	policy kyvernov1.PolicyInterface
+	file   string
+}
+
+type loadedVap struct {
+	vap  v1alpha1.ValidatingAdmissionPolicy
+	file string
+}
+
+// load loads the policies, validating admission policies and validating admission policy bindings of a file.
+func load(file string) ([]kyvernov1.PolicyInterface, []v1alpha1.ValidatingAdmissionPolicy, []v1alpha1.ValidatingAdmissionPolicyBinding, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	documents, err := extyaml.SplitDocuments(content)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var policies []kyvernov1.PolicyInterface
+	var vaps []v1alpha1.ValidatingAdmissionPolicy
+	var bindings []v1alpha1.ValidatingAdmissionPolicyBinding
+	for _, document := range documents {
+		var typeMeta metav1.TypeMeta
+		if err := yaml.Unmarshal(document, &typeMeta); err != nil {
+			return nil, nil, nil, err
+		}
+		if typeMeta.GroupVersionKind() == bindingV1Alpha1 {
+			var binding v1alpha1.ValidatingAdmissionPolicyBinding
+			if err := yaml.UnmarshalStrict(document, &binding); err != nil {
+				return nil, nil, nil, err
+			}
+			bindings = append(bindings, binding)
+			continue
+		}
+		p, v, err := policy.KubectlValidateLoader(document)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		policies = append(policies, p...)
+		vaps = append(vaps, v...)
+	}
+	return policies, vaps, bindings, nil
+}
+
+// convertVap converts a validating admission policy to a cluster policy for each of its bindings,
+// the converted policies are named after the bindings when there is more than one.
+func convertVap(discoveryClient *discoveryClient, vap v1alpha1.ValidatingAdmissionPolicy, bindings []v1alpha1.ValidatingAdmissionPolicyBinding) ([]interface{}, error) {
+	if len(bindings) == 0 {
+		converted, err := admissionpolicy.ConvertToKyvernoPolicy(discoveryClient, vap, nil)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{converted}, nil
+	}
+	var objects []interface{}
+	for i := range bindings {
+		converted, err := admissionpolicy.ConvertToKyvernoPolicy(discoveryClient, vap, &bindings[i])
+		if err != nil {
+			return nil, fmt.Errorf("binding %s: %w", bindings[i].GetName(), err)
+		}
+		if len(bindings) > 1 {
+			converted.SetName(bindings[i].GetName())
+		}
+		objects = append(objects, converted)
+	}
+	return objects, nil
+}
+
+func (o options) convertPolicy(discoveryClient *discoveryClient, policy kyvernov1.PolicyInterface) ([]interface{}, error) {
+	if policy.IsNamespaced() {
+		return nil, errors.New("namespaced policies aren't applicable")
+	}
+	spec := policy.GetSpec()
+	if len(spec.Rules) == 0 {
+		return nil, errors.New("policy has no rules")
+	}
+	if ok, msg := admissionpolicy.CanGenerateVAP(spec); !ok {
+		return nil, errors.New(msg)
+	}
+	vap := &v1alpha1.ValidatingAdmissionPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       "ValidatingAdmissionPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: policy.GetName(),
+		},
+	}
+	if err := admissionpolicy.BuildValidatingAdmissionPolicy(discoveryClient, vap, policy); err != nil {
+		return nil, err
+	}
+	cleanMetadata(vap)
+	objects := []interface{}{vap}
+	if o.binding {
+		binding := &v1alpha1.ValidatingAdmissionPolicyBinding{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1alpha1.SchemeGroupVersion.String(),
+				Kind:       "ValidatingAdmissionPolicyBinding",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: policy.GetName() + "-binding",
+			},
+		}
+		if err := admissionpolicy.BuildValidatingAdmissionPolicyBinding(binding, policy); err != nil {
+			return nil, err
+		}
+		cleanMetadata(binding)
+		objects = append(objects, binding)
+	}
+	return objects, nil
+}
+
+// cleanMetadata removes the owner references and labels set for resources generated in-cluster,
+// converted resources are not managed by Kyverno.
+func cleanMetadata(obj metav1.Object) {
+	obj.SetOwnerReferences(nil)
+	labels := obj.GetLabels()
+	delete(labels, kyverno.LabelAppManagedBy)
+	if len(labels) == 0 {
+		labels = nil
+	}
+	obj.SetLabels(labels)
+}
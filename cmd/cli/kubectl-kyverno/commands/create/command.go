@@ -4,6 +4,7 @@ import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/create/exception"
 	metricsconfig "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/create/metrics-config"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/create/policy"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/create/test"
 	userinfo "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/create/user-info"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/create/values"
@@ -25,6 +26,7 @@ func Command() *cobra.Command {
 	cmd.AddCommand(
 		exception.Command(),
 		metricsconfig.Command(),
+		policy.Command(),
 		test.Command(),
 		userinfo.Command(),
 		values.Command(),
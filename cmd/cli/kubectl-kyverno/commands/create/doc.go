@@ -12,6 +12,10 @@ var examples = [][]string{
 		"# Create metrics config file",
 		"kyverno create metrics-config -i ns-included-1 -i ns-included-2 -e ns-excluded",
 	},
+	{
+		"# Create policy file",
+		"kyverno create policy require-labels --type validate --kind Pod",
+	},
 	{
 		"# Create test file",
 		"kyverno create test -p policy.yaml -r resource.yaml -f values.yaml --pass policy-name,rule-name,resource-name,resource-namespace,resource-kind",
@@ -1,7 +1,7 @@
 package exception
 
 import (
-	"log"
+	"errors"
 	"os"
 	"strings"
 	"text/template"
@@ -22,7 +22,7 @@ type options struct {
 }
 
 func Command() *cobra.Command {
-	var path string
+	var path, reportPath string
 	var rules, any, all []string
 	var options options
 	cmd := &cobra.Command{
@@ -44,6 +44,16 @@ func Command() *cobra.Command {
 					options.Exceptions = append(options.Exceptions, *result)
 				}
 			}
+			if reportPath != "" {
+				exceptions, resources, err := fromReport(reportPath, options.Exceptions)
+				if err != nil {
+					return err
+				}
+				options.Exceptions = exceptions
+				options.Match.Any = append(options.Match.Any, resources...)
+			} else if len(rules) == 0 {
+				return errors.New("either --policy-rules or --from-report is required")
+			}
 			for _, result := range any {
 				result := parseResourceFilter(result)
 				if result != nil {
@@ -71,12 +81,10 @@ func Command() *cobra.Command {
 	cmd.Flags().StringVarP(&path, "output", "o", "", "Output path (uses standard console output if not set)")
 	cmd.Flags().StringVar(&options.Namespace, "namespace", "", "Policy exception namespace")
 	cmd.Flags().BoolVarP(&options.Background, "background", "b", true, "Set to false when policy shouldn't be considered in background scans")
-	cmd.Flags().StringArrayVar(&rules, "policy-rules", nil, "Policy name, followed by rule names (`--policy-rules=policy,rule-1,rule-2,...`)")
+	cmd.Flags().StringArrayVar(&rules, "policy-rules", nil, "Policy name, followed by rule names (`--policy-rules=policy,rule-1,rule-2,...`), restricts the violations taken from the report when used with --from-report")
+	cmd.Flags().StringVar(&reportPath, "from-report", "", "Path to a policy report, the exception covers the violating resources and rules found in the report")
 	cmd.Flags().StringArrayVar(&any, "any", nil, "List of resource filters")
 	cmd.Flags().StringArrayVar(&all, "all", nil, "List of resource filters")
-	if err := cmd.MarkFlagRequired("policy-rules"); err != nil {
-		log.Println("WARNING", err)
-	}
 	return cmd
 }
 
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/exception"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -49,10 +50,11 @@ spec:
   background: true
   match:
     any:
-    - kinds:
+    - resources:
+        kinds:
         - Pod
         - Deployment
-      names:
+        names:
         - test-*
   exceptions:
     - policyName: policy
@@ -81,14 +83,15 @@ spec:
   background: true
   match:
     all:
-    - kinds:
+    - resources:
+        kinds:
         - Pod
         - Deployment
-      names:
+        names:
         - test-*
-      namespaces:
+        namespaces:
         - test
-      operations:
+        operations:
         - UPDATE
   exceptions:
     - policyName: policy
@@ -98,6 +101,69 @@ spec:
 	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
 }
 
+func TestCommandFromReport(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{"test", "--namespace", "delta", "--from-report", "../../../_testdata/exceptions/report.yaml"})
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	expected := `
+apiVersion: kyverno.io/v2
+kind: PolicyException
+metadata:
+  name: test
+  namespace: delta
+spec:
+  background: true
+  match:
+    any:
+    - resources:
+        kinds:
+        - Pod
+        names:
+        - important-tool
+        namespaces:
+        - delta
+    - resources:
+        kinds:
+        - Pod
+        names:
+        - other-tool
+        namespaces:
+        - delta
+  exceptions:
+    - policyName: disallow-host-namespaces
+      ruleNames:
+        - host-namespaces
+    - policyName: require-labels
+      ruleNames:
+        - check-team`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(b.String()))
+	exceptions, err := exception.Load(b.Bytes())
+	assert.NoError(t, err)
+	assert.Len(t, exceptions, 1)
+}
+
+func TestCommandFromReportWithPolicyRules(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{"test", "--from-report", "../../../_testdata/exceptions/report.yaml", "--policy-rules", "require-labels,check-team"})
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	assert.Contains(t, b.String(), "        names:\n        - important-tool\n")
+	assert.NotContains(t, b.String(), "other-tool")
+	assert.NotContains(t, b.String(), "disallow-host-namespaces")
+}
+
+func TestCommandFromReportWithoutViolations(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{"test", "--from-report", "../../../_testdata/exceptions/report.yaml", "--policy-rules", "disallow-latest-tag,validate-image-tag"})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "no violations found in ../../../_testdata/exceptions/report.yaml")
+}
+
 func TestCommandWithInvalidArg(t *testing.T) {
 	cmd := Command()
 	assert.NotNil(t, cmd)
@@ -5,6 +5,9 @@ var websiteUrl = ``
 
 var description = []string{
 	`Create a Kyverno policy exception file.`,
+	``,
+	`With --from-report, the exception is generated from the violations found in a policy report,`,
+	`it covers the failing rules and is scoped to the violating resources.`,
 }
 
 var examples = [][]string{
@@ -12,4 +15,8 @@ var examples = [][]string{
 		"# Create a policy exception file",
 		`kyverno create exception my-exception --namespace my-ns --policy-rules "policy,rule-1,rule-2" --any "kind=Pod,kind=Deployment,name=test-*"`,
 	},
+	{
+		"# Create a policy exception for the violations of a rule found in a policy report",
+		`kyverno create exception my-exception --namespace my-ns --from-report report.yaml --policy-rules "policy,rule-1"`,
+	},
 }
@@ -0,0 +1,99 @@
+package exception
+
+import (
+	"fmt"
+	"os"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	yamlutils "github.com/kyverno/kyverno/ext/yaml"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// fromReport computes the exceptions and the resource filters covering the violations found in a policy report.
+// When filters are given, only the violations of the corresponding policies and rules are considered.
+func fromReport(path string, filters []v2beta1.Exception) ([]v2beta1.Exception, []kyvernov1.ResourceFilter, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	documents, err := yamlutils.SplitDocuments(content)
+	if err != nil {
+		return nil, nil, err
+	}
+	var exceptions []v2beta1.Exception
+	var resources []kyvernov1.ResourceFilter
+	seen := map[corev1.ObjectReference]bool{}
+	for _, document := range documents {
+		// cluster and namespaced reports share the same layout
+		var report policyreportv1alpha2.PolicyReport
+		if err := yaml.Unmarshal(document, &report); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode policy report %s (%w)", path, err)
+		}
+		if report.Kind != "PolicyReport" && report.Kind != "ClusterPolicyReport" {
+			return nil, nil, fmt.Errorf("policy report type not supported %s", report.Kind)
+		}
+		for _, result := range report.Results {
+			if result.Result != policyreportv1alpha2.StatusFail || !matchesFilters(filters, result.Policy, result.Rule) {
+				continue
+			}
+			exceptions = addException(exceptions, result.Policy, result.Rule)
+			refs := result.Resources
+			if len(refs) == 0 && report.Scope != nil {
+				refs = []corev1.ObjectReference{*report.Scope}
+			}
+			for _, ref := range refs {
+				key := corev1.ObjectReference{Kind: ref.Kind, Namespace: ref.Namespace, Name: ref.Name}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				var filter kyvernov1.ResourceFilter
+				filter.Kinds = []string{ref.Kind}
+				filter.Names = []string{ref.Name}
+				if ref.Namespace != "" {
+					filter.Namespaces = []string{ref.Namespace}
+				}
+				resources = append(resources, filter)
+			}
+		}
+	}
+	if len(exceptions) == 0 {
+		return nil, nil, fmt.Errorf("no violations found in %s", path)
+	}
+	return exceptions, resources, nil
+}
+
+func matchesFilters(filters []v2beta1.Exception, policy, rule string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, filter := range filters {
+		if filter.PolicyName != policy {
+			continue
+		}
+		for _, name := range filter.RuleNames {
+			if name == rule {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func addException(exceptions []v2beta1.Exception, policy, rule string) []v2beta1.Exception {
+	for i := range exceptions {
+		if exceptions[i].PolicyName == policy {
+			for _, name := range exceptions[i].RuleNames {
+				if name == rule {
+					return exceptions
+				}
+			}
+			exceptions[i].RuleNames = append(exceptions[i].RuleNames, rule)
+			return exceptions
+		}
+	}
+	return append(exceptions, v2beta1.Exception{PolicyName: policy, RuleNames: []string{rule}})
+}
@@ -0,0 +1,250 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/create/templates"
+	"github.com/spf13/cobra"
+)
+
+var ruleTypes = []string{"validate", "mutate", "generate", "verifyImages"}
+
+// well known kinds, used to compute the api version and scope of sample resources
+var knownKinds = map[string]kind{
+	"Pod":                   {APIVersion: "v1", Namespaced: true},
+	"Service":               {APIVersion: "v1", Namespaced: true},
+	"ConfigMap":             {APIVersion: "v1", Namespaced: true},
+	"Secret":                {APIVersion: "v1", Namespaced: true},
+	"ServiceAccount":        {APIVersion: "v1", Namespaced: true},
+	"Namespace":             {APIVersion: "v1"},
+	"Node":                  {APIVersion: "v1"},
+	"Deployment":            {APIVersion: "apps/v1", Namespaced: true},
+	"StatefulSet":           {APIVersion: "apps/v1", Namespaced: true},
+	"DaemonSet":             {APIVersion: "apps/v1", Namespaced: true},
+	"ReplicaSet":            {APIVersion: "apps/v1", Namespaced: true},
+	"Job":                   {APIVersion: "batch/v1", Namespaced: true},
+	"CronJob":               {APIVersion: "batch/v1", Namespaced: true},
+	"Ingress":               {APIVersion: "networking.k8s.io/v1", Namespaced: true},
+	"NetworkPolicy":         {APIVersion: "networking.k8s.io/v1", Namespaced: true},
+	"Role":                  {APIVersion: "rbac.authorization.k8s.io/v1", Namespaced: true},
+	"RoleBinding":           {APIVersion: "rbac.authorization.k8s.io/v1", Namespaced: true},
+	"ClusterRole":           {APIVersion: "rbac.authorization.k8s.io/v1"},
+	"ClusterRoleBinding":    {APIVersion: "rbac.authorization.k8s.io/v1"},
+	"PersistentVolume":      {APIVersion: "v1"},
+	"PersistentVolumeClaim": {APIVersion: "v1", Namespaced: true},
+}
+
+type kind struct {
+	Match      string
+	APIVersion string
+	Kind       string
+	Namespaced bool
+}
+
+type options struct {
+	Name              string
+	Namespace         string
+	Type              string
+	Rule              string
+	Subject           string
+	GenerateNamespace string
+	Kinds             []kind
+}
+
+type resource struct {
+	APIVersion string
+	Kind       string
+	Name       string
+	Namespace  string
+}
+
+type result struct {
+	Policy    string
+	Rule      string
+	Resource  string
+	Namespace string
+	Kind      string
+	Result    string
+}
+
+type test struct {
+	Name      string
+	Policies  []string
+	Resources []string
+	Values    string
+	Results   []result
+}
+
+func Command() *cobra.Command {
+	var path, testDir string
+	var kinds []string
+	var options options
+	cmd := &cobra.Command{
+		Use:          "policy [name]",
+		Short:        command.FormatDescription(true, websiteUrl, false, description...),
+		Long:         command.FormatDescription(false, websiteUrl, false, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if path != "" && testDir != "" {
+				return errors.New("--output and --test-dir cannot be used together")
+			}
+			if !isValidRuleType(options.Type) {
+				return fmt.Errorf("invalid rule type %q, must be one of %s", options.Type, strings.Join(ruleTypes, ", "))
+			}
+			if len(kinds) == 0 {
+				return errors.New("at least one kind is required")
+			}
+			options.Name = args[0]
+			options.Kinds = nil
+			for _, k := range kinds {
+				kind, err := parseKind(k)
+				if err != nil {
+					return err
+				}
+				options.Kinds = append(options.Kinds, kind)
+			}
+			options.Subject = options.Kinds[0].Kind
+			options.GenerateNamespace = "{{request.object.metadata.namespace}}"
+			if len(options.Kinds) == 1 && options.Kinds[0].Kind == "Namespace" {
+				options.GenerateNamespace = "{{request.object.metadata.name}}"
+			}
+			if testDir != "" {
+				return writeTest(testDir, options)
+			}
+			output := cmd.OutOrStdout()
+			if path != "" {
+				file, err := os.Create(path)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				output = file
+			}
+			return writePolicy(output, options)
+		},
+	}
+	cmd.Flags().StringVarP(&path, "output", "o", "", "Output path (uses standard console output if not set)")
+	cmd.Flags().StringVar(&options.Namespace, "namespace", "", "Policy namespace (creates a Policy instead of a ClusterPolicy)")
+	cmd.Flags().StringVarP(&options.Type, "type", "t", "validate", "Rule type (validate, mutate, generate or verifyImages)")
+	cmd.Flags().StringVarP(&options.Rule, "rule", "r", "rule-name", "Rule name")
+	cmd.Flags().StringSliceVarP(&kinds, "kind", "k", []string{"Pod"}, "List of kinds matched by the rule (Kind for well known kinds, version/Kind or group/version/Kind)")
+	cmd.Flags().StringVarP(&testDir, "test-dir", "d", "", "Directory where the policy, a sample resource and a test file are created")
+	return cmd
+}
+
+func isValidRuleType(ruleType string) bool {
+	for _, t := range ruleTypes {
+		if t == ruleType {
+			return true
+		}
+	}
+	return false
+}
+
+// parseKind parses a kind with an optional version or group/version, the version is required
+// for kinds that are not well known. Kinds that are not well known are assumed to be namespaced.
+func parseKind(in string) (kind, error) {
+	parts := strings.Split(in, "/")
+	result := kind{
+		Match:      in,
+		Kind:       parts[len(parts)-1],
+		Namespaced: true,
+	}
+	known, ok := knownKinds[result.Kind]
+	if ok {
+		result.APIVersion = known.APIVersion
+		result.Namespaced = known.Namespaced
+	}
+	if len(parts) > 1 {
+		result.APIVersion = strings.Join(parts[:len(parts)-1], "/")
+	} else if !ok {
+		return kind{}, fmt.Errorf("unknown kind %q, use version/Kind or group/version/Kind", in)
+	}
+	return result, nil
+}
+
+func writePolicy(out io.Writer, options options) error {
+	tmpl, err := template.New("policy").Parse(templates.PolicyTemplate)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(out, options)
+}
+
+func writeTest(dir string, options options) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	resourceTmpl, err := template.New("resource").Parse(templates.ResourceTemplate)
+	if err != nil {
+		return err
+	}
+	testTmpl, err := template.New("test").Parse(templates.TestTemplate)
+	if err != nil {
+		return err
+	}
+	test := test{
+		Name:      options.Name,
+		Policies:  []string{"policy.yaml"},
+		Resources: []string{"resource.yaml"},
+	}
+	var resources strings.Builder
+	for i, k := range options.Kinds {
+		resource := resource{
+			APIVersion: k.APIVersion,
+			Kind:       k.Kind,
+			Name:       strings.ToLower(k.Kind) + "-sample",
+		}
+		if k.Namespaced {
+			resource.Namespace = "default"
+			if options.Namespace != "" {
+				resource.Namespace = options.Namespace
+			}
+		}
+		if i > 0 {
+			resources.WriteString("---\n")
+		}
+		if err := resourceTmpl.Execute(&resources, resource); err != nil {
+			return err
+		}
+		// the sample image doesn't match the image references of the verifyImages stub
+		if options.Type != "verifyImages" {
+			test.Results = append(test.Results, result{
+				Policy:    options.Name,
+				Rule:      options.Rule,
+				Resource:  resource.Name,
+				Namespace: resource.Namespace,
+				Kind:      resource.Kind,
+				Result:    "pass",
+			})
+		}
+	}
+	var policy strings.Builder
+	if err := writePolicy(&policy, options); err != nil {
+		return err
+	}
+	var kyvernoTest strings.Builder
+	if err := testTmpl.Execute(&kyvernoTest, test); err != nil {
+		return err
+	}
+	files := map[string]string{
+		"policy.yaml":       policy.String(),
+		"resource.yaml":     resources.String(),
+		"kyverno-test.yaml": kyvernoTest.String(),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{"test"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+}
+
+func TestCommandWithMultipleArgs(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{"test", "test2"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandWithInvalidType(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{"test", "--type", "foo"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandWithOutputAndTestDir(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{"test", "--output", "policy.yaml", "--test-dir", "test"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestCommandValidate(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{"require-labels", "--kind", "Pod,apps/v1/Deployment"})
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+  annotations:
+    policies.kyverno.io/title: require-labels
+    policies.kyverno.io/category: Other
+    policies.kyverno.io/severity: medium
+    policies.kyverno.io/subject: Pod
+    policies.kyverno.io/description: >-
+      Describe what the policy does and why.
+spec:
+  validationFailureAction: Audit
+  background: true
+  rules:
+    - name: rule-name
+      match:
+        any:
+          - resources:
+              kinds:
+                - Pod
+                - apps/v1/Deployment
+      validate:
+        message: "The label ` + "`app.kubernetes.io/name`" + ` is required."
+        pattern:
+          metadata:
+            labels:
+              app.kubernetes.io/name: "?*"`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandGenerate(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{"add-configmap", "--namespace", "my-ns", "--type", "generate", "--kind", "Namespace", "--rule", "add"})
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "kind: Policy\n")
+	assert.Contains(t, string(out), "  namespace: my-ns\n")
+	assert.Contains(t, string(out), "    - name: add\n")
+	assert.Contains(t, string(out), `namespace: "{{request.object.metadata.name}}"`)
+	assert.NotContains(t, string(out), "validationFailureAction")
+}
+
+func TestCommandWithTestDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "require-labels")
+	cmd := Command()
+	cmd.SetArgs([]string{"require-labels", "--kind", "Pod,Namespace", "--test-dir", dir})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	policy, err := os.ReadFile(filepath.Join(dir, "policy.yaml"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(policy), "name: require-labels")
+	resource, err := os.ReadFile(filepath.Join(dir, "resource.yaml"))
+	assert.NoError(t, err)
+	expected := `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod-sample
+  namespace: default
+  labels:
+    app.kubernetes.io/name: pod-sample
+spec:
+  containers:
+    - name: app
+      image: nginx:1.25
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: namespace-sample
+  labels:
+    app.kubernetes.io/name: namespace-sample`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(resource)))
+	test, err := os.ReadFile(filepath.Join(dir, "kyverno-test.yaml"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(test), "  - policy.yaml\n")
+	assert.Contains(t, string(test), "  - resource.yaml\n")
+	assert.Contains(t, string(test), "    resource: pod-sample\n    namespace: default\n    kind: Pod\n    result: pass")
+	assert.Contains(t, string(test), "    resource: namespace-sample\n    namespace: \n    kind: Namespace\n    result: pass")
+}
+
+func TestCommandWithInvalidArg(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: accepts 1 arg(s), received 0`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandHelp(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
+}
+
+func TestCommandVerifyImages(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{"verify-images", "--type", "verifyImages"})
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	var policy kyvernov1.ClusterPolicy
+	assert.NoError(t, yaml.UnmarshalStrict(b.Bytes(), &policy))
+	_, err = policyvalidation.Validate(context.TODO(), &policy, nil, nil, true, "")
+	assert.NoError(t, err)
+	// the stub must not contain placeholder keys
+	entry := policy.Spec.Rules[0].VerifyImages[0].Attestors[0].Entries[0]
+	assert.Nil(t, entry.Keys)
+	assert.NotNil(t, entry.Keyless)
+}
+
+func TestCommandWithUnknownKind(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{"test", "--kind", "Widget"})
+	err := cmd.Execute()
+	assert.EqualError(t, err, `unknown kind "Widget", use version/Kind or group/version/Kind`)
+}
+
+func TestParseKind(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    kind
+		wantErr bool
+	}{{
+		in:   "Namespace",
+		want: kind{Match: "Namespace", APIVersion: "v1", Kind: "Namespace"},
+	}, {
+		in:   "apps/v1/Deployment",
+		want: kind{Match: "apps/v1/Deployment", APIVersion: "apps/v1", Kind: "Deployment", Namespaced: true},
+	}, {
+		in:   "example.com/v1/Widget",
+		want: kind{Match: "example.com/v1/Widget", APIVersion: "example.com/v1", Kind: "Widget", Namespaced: true},
+	}, {
+		in:      "Widget",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseKind(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
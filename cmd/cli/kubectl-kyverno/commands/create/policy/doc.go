@@ -0,0 +1,27 @@
+package policy
+
+// TODO
+var websiteUrl = ``
+
+var description = []string{
+	`Create a Kyverno policy skeleton.`,
+	``,
+	`The generated policy contains a single rule stub of the requested type matching the given kinds.`,
+	``,
+	`When a test directory is given, a sample resource and a kyverno-test.yaml file wired to the policy and the resource are created alongside the policy.`,
+}
+
+var examples = [][]string{
+	{
+		"# Create a validate policy matching pods",
+		"kyverno create policy require-labels",
+	},
+	{
+		"# Create a namespaced mutate policy matching deployments",
+		"kyverno create policy add-labels --namespace my-ns --type mutate --kind apps/v1/Deployment",
+	},
+	{
+		"# Create a generate policy, a sample resource and a test in the add-configmap directory",
+		"kyverno create policy add-configmap --type generate --kind Namespace --test-dir add-configmap",
+	},
+}
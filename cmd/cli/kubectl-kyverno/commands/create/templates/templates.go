@@ -18,3 +18,9 @@ var ExceptionTemplate string
 
 //go:embed metrics-config.yaml
 var MetricsConfigTemplate string
+
+//go:embed policy.yaml
+var PolicyTemplate string
+
+//go:embed resource.yaml
+var ResourceTemplate string
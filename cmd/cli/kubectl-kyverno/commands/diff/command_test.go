@@ -0,0 +1,57 @@
+package diff
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	err := cmd.Execute()
+	assert.EqualError(t, err, "old policies are required")
+}
+
+func TestCommandWithoutResources(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{
+		"--old", "../../_testdata/diff/old",
+		"--new", "../../_testdata/diff/new",
+	})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "a resource is required")
+}
+
+func TestCommandWithPolicies(t *testing.T) {
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"--old", "../../_testdata/diff/old",
+		"--new", "../../_testdata/diff/new",
+		"--resources", "../../_testdata/diff/resources.yaml",
+	})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	expected := `Resources: 4
+Newly blocked: 1
+Newly allowed: 1
+Different mutation: 1
+Unchanged: 1
+
+Newly blocked:
+  Pod default/latest-tag
+    disallow-latest/check-tag
+
+Newly allowed:
+  Pod default/no-team
+    require-team/check-team
+
+Different mutation:
+  Pod default/web
+    add /metadata/labels/tier: "frontend"
+`
+	assert.Equal(t, expected, b.String())
+}
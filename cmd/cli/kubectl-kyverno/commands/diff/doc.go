@@ -0,0 +1,25 @@
+package diff
+
+// TODO
+var websiteUrl = ``
+
+var description = []string{
+	`Compare the admission outcome of two policy versions on a set of resources.`,
+	``,
+	`The diff command applies the old and the new policies to every resource and reports the resources`,
+	`that are newly blocked, newly allowed, or mutated differently by the new policies, with the rules`,
+	`responsible for the change. It helps rolling out policy changes safely.`,
+	``,
+	`Variables can be provided with a values file, the same way as with the apply command.`,
+}
+
+var examples = [][]string{
+	{
+		`# Compare two versions of a policy set`,
+		`KYVERNO_EXPERIMENTAL=true kyverno diff --old policies-v1/ --new policies-v2/ --resources resources/`,
+	},
+	{
+		`# Compare two versions of a policy using variables from a values file`,
+		`KYVERNO_EXPERIMENTAL=true kyverno diff --old policy-v1.yaml --new policy-v2.yaml --resources resources.yaml --values-file values.yaml`,
+	},
+}
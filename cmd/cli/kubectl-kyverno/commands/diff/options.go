@@ -0,0 +1,138 @@
+package diff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/variables"
+	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+)
+
+type options struct {
+	oldPaths      []string
+	newPaths      []string
+	resourcePaths []string
+	valuesFile    string
+}
+
+func (o options) validate() error {
+	if len(o.oldPaths) == 0 {
+		return errors.New("old policies are required")
+	}
+	if len(o.newPaths) == 0 {
+		return errors.New("new policies are required")
+	}
+	if len(o.resourcePaths) == 0 {
+		return errors.New("a resource is required")
+	}
+	return nil
+}
+
+func (o options) execute(out io.Writer) error {
+	oldPolicies, _, err := policy.Load(nil, "", o.oldPaths...)
+	if err != nil {
+		return fmt.Errorf("failed to load old policies (%w)", err)
+	}
+	newPolicies, _, err := policy.Load(nil, "", o.newPaths...)
+	if err != nil {
+		return fmt.Errorf("failed to load new policies (%w)", err)
+	}
+	var policies []kyvernov1.PolicyInterface
+	policies = append(policies, oldPolicies...)
+	policies = append(policies, newPolicies...)
+	resources, err := common.GetResourceAccordingToResourcePath(out, nil, o.resourcePaths, false, policies, nil, nil, "", false, "")
+	if err != nil {
+		return fmt.Errorf("failed to load resources (%w)", err)
+	}
+	if len(resources) == 0 {
+		return errors.New("no resources found")
+	}
+	vars, err := variables.New(out, nil, "", o.valuesFile, nil)
+	if err != nil {
+		return fmt.Errorf("failed to decode yaml (%w)", err)
+	}
+	var s store.Store
+	s.SetLocal(true)
+	vars.SetInStore(&s)
+	var results results
+	for _, resource := range resources {
+		before, err := evaluate(&s, vars, oldPolicies, *resource)
+		if err != nil {
+			return err
+		}
+		after, err := evaluate(&s, vars, newPolicies, *resource)
+		if err != nil {
+			return err
+		}
+		if err := results.add(*resource, before, after); err != nil {
+			return err
+		}
+	}
+	results.print(out)
+	return nil
+}
+
+// outcome is the admission outcome of a set of policies on a resource
+type outcome struct {
+	// blockedBy contains the policy/rule pairs blocking the resource
+	blockedBy []string
+	// patched is the resource mutated by the policies
+	patched unstructured.Unstructured
+}
+
+func (o outcome) blocked() bool {
+	return len(o.blockedBy) != 0
+}
+
+// evaluate applies the policies to the resource the way the admission controller would
+func evaluate(
+	s *store.Store,
+	vars *variables.Variables,
+	policies []kyvernov1.PolicyInterface,
+	resource unstructured.Unstructured,
+) (outcome, error) {
+	result := outcome{patched: resource}
+	if len(policies) == 0 {
+		return result, nil
+	}
+	var rc processor.ResultCounts
+	processor := processor.PolicyProcessor{
+		Store:                s,
+		Policies:             policies,
+		Resource:             resource,
+		Variables:            vars,
+		NamespaceSelectorMap: vars.NamespaceSelectors(),
+		Rc:                   &rc,
+		Subresources:         vars.Subresources(),
+		Out:                  io.Discard,
+	}
+	responses, err := processor.ApplyPoliciesOnResource()
+	if err != nil {
+		key, _ := cache.MetaNamespaceKeyFunc(&resource)
+		return result, fmt.Errorf("failed to apply policies on resource %s (%w)", key, err)
+	}
+	for _, response := range responses {
+		if response.PatchedResource.Object != nil {
+			result.patched = response.PatchedResource
+		}
+		policy, ok := response.Policy().GetPolicy().(kyvernov1.PolicyInterface)
+		if !ok {
+			continue
+		}
+		if engineutils.BlockRequest(response, policy.GetSpec().GetFailurePolicy(context.TODO())) {
+			for _, rule := range response.GetFailedRules() {
+				result.blockedBy = append(result.blockedBy, policy.GetName()+"/"+rule)
+			}
+		}
+	}
+	return result, nil
+}
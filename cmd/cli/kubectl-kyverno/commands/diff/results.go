@@ -0,0 +1,105 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+)
+
+type change struct {
+	resource string
+	details  []string
+}
+
+type results struct {
+	total        int
+	newlyBlocked []change
+	newlyAllowed []change
+	mutated      []change
+	unchanged    int
+}
+
+func (r *results) add(resource unstructured.Unstructured, before, after outcome) error {
+	r.total++
+	key, _ := cache.MetaNamespaceKeyFunc(&resource)
+	name := resource.GetKind() + " " + key
+	switch {
+	case !before.blocked() && after.blocked():
+		r.newlyBlocked = append(r.newlyBlocked, change{resource: name, details: after.blockedBy})
+	case before.blocked() && !after.blocked():
+		r.newlyAllowed = append(r.newlyAllowed, change{resource: name, details: before.blockedBy})
+	case !before.blocked() && !after.blocked():
+		details, err := mutationDiff(before.patched, after.patched)
+		if err != nil {
+			return fmt.Errorf("failed to compare mutations of resource %s (%w)", key, err)
+		}
+		if len(details) != 0 {
+			r.mutated = append(r.mutated, change{resource: name, details: details})
+		} else {
+			r.unchanged++
+		}
+	default:
+		r.unchanged++
+	}
+	return nil
+}
+
+// mutationDiff returns the operations turning the resource mutated by the old policies into the one mutated by the new policies
+func mutationDiff(before, after unstructured.Unstructured) ([]string, error) {
+	beforeBytes, err := json.Marshal(before.Object)
+	if err != nil {
+		return nil, err
+	}
+	afterBytes, err := json.Marshal(after.Object)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := jsonpatch.CreatePatch(beforeBytes, afterBytes)
+	if err != nil {
+		return nil, err
+	}
+	details := make([]string, 0, len(patch))
+	for _, operation := range patch {
+		if operation.Operation == "remove" {
+			details = append(details, fmt.Sprintf("%s %s", operation.Operation, operation.Path))
+		} else {
+			value, err := json.Marshal(operation.Value)
+			if err != nil {
+				return nil, err
+			}
+			details = append(details, fmt.Sprintf("%s %s: %s", operation.Operation, operation.Path, value))
+		}
+	}
+	sort.Strings(details)
+	return details, nil
+}
+
+func (r *results) print(out io.Writer) {
+	fmt.Fprintf(out, "Resources: %d\n", r.total)
+	fmt.Fprintf(out, "Newly blocked: %d\n", len(r.newlyBlocked))
+	fmt.Fprintf(out, "Newly allowed: %d\n", len(r.newlyAllowed))
+	fmt.Fprintf(out, "Different mutation: %d\n", len(r.mutated))
+	fmt.Fprintf(out, "Unchanged: %d\n", r.unchanged)
+	printChanges(out, "Newly blocked", r.newlyBlocked)
+	printChanges(out, "Newly allowed", r.newlyAllowed)
+	printChanges(out, "Different mutation", r.mutated)
+}
+
+func printChanges(out io.Writer, title string, changes []change) {
+	if len(changes) == 0 {
+		return
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "%s:\n", title)
+	for _, change := range changes {
+		fmt.Fprintf(out, "  %s\n", change.resource)
+		for _, detail := range change.details {
+			fmt.Fprintf(out, "    %s\n", detail)
+		}
+	}
+}
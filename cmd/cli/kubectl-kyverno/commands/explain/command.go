@@ -0,0 +1,29 @@
+package explain
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "explain",
+		Short:        command.FormatDescription(true, websiteUrl, true, description...),
+		Long:         command.FormatDescription(false, websiteUrl, true, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return options.execute(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringVar(&options.policyPath, "policy", "", "Path to the policy file")
+	cmd.Flags().StringVar(&options.resourcePath, "resource", "", "Path to the resource file")
+	cmd.Flags().StringVar(&options.rule, "rule", "", "Name of the rule to explain")
+	cmd.Flags().StringVarP(&options.valuesFile, "values-file", "f", "", "File containing values for policy variables")
+	return cmd
+}
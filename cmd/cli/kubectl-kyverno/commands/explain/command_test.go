@@ -0,0 +1,67 @@
+package explain
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	err := cmd.Execute()
+	assert.EqualError(t, err, "a policy is required")
+}
+
+func TestCommandWithPattern(t *testing.T) {
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"--policy", "../../_testdata/explain/policy.yaml",
+		"--resource", "../../_testdata/explain/resources.yaml",
+		"--rule", "check-limits",
+	})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out := b.String()
+	assert.Contains(t, out, "Rule: check-limits\n")
+	assert.Contains(t, out, "  request.operation: \"CREATE\"\n")
+	assert.Contains(t, out, "Preconditions: true\n  all[0]: true\n")
+	assert.Contains(t, out, "    resolved: \"CREATE\" NotEquals \"DELETE\"\n")
+	assert.Contains(t, out, "Result: fail\n")
+	assert.Contains(t, out, "Failing paths:\n  /spec/containers/0/resources/limits/memory/\n")
+}
+
+func TestCommandWithDeny(t *testing.T) {
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"--policy", "../../_testdata/explain/policy.yaml",
+		"--resource", "../../_testdata/explain/resources.yaml",
+		"--rule", "check-team",
+		"--values-file", "../../_testdata/explain/values.yaml",
+	})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	out := b.String()
+	assert.Contains(t, out, "  allowedTeams: [\"payments\"]\n")
+	assert.Contains(t, out, "  team: \"payments\"\n")
+	assert.Contains(t, out, "Deny conditions: false\n  any[0]: false\n")
+	assert.Contains(t, out, "  any[1]: false\n")
+	assert.Contains(t, out, "Result: pass\n")
+	assert.NotContains(t, out, "Failing paths:")
+}
+
+func TestCommandWithUnknownRule(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{
+		"--policy", "../../_testdata/explain/policy.yaml",
+		"--resource", "../../_testdata/explain/resources.yaml",
+		"--rule", "unknown",
+	})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "rule unknown not found in ../../_testdata/explain/policy.yaml")
+}
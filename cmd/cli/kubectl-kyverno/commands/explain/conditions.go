@@ -0,0 +1,56 @@
+package explain
+
+import (
+	"fmt"
+	"io"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/log"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+// printConditions prints the outcome of a condition block followed by the outcome of every branch.
+// Unlike the engine, every branch is evaluated so that all the failing ones are reported.
+func printConditions(out io.Writer, jsonContext enginecontext.Interface, title string, conditions apiextensions.JSON) error {
+	typed, err := engineutils.TransformConditions(conditions)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s (%w)", title, err)
+	}
+	result, _, err := variables.EvaluateConditions(log.Log, jsonContext, typed)
+	if err != nil {
+		fmt.Fprintf(out, "%s: error (%s)\n", title, err)
+		return nil
+	}
+	fmt.Fprintf(out, "%s: %t\n", title, result)
+	switch typed := typed.(type) {
+	case kyvernov1.AnyAllConditions:
+		printBranches(out, jsonContext, "any", typed.AnyConditions)
+		printBranches(out, jsonContext, "all", typed.AllConditions)
+	case []kyvernov1.Condition:
+		printBranches(out, jsonContext, "all", typed)
+	}
+	return nil
+}
+
+func printBranches(out io.Writer, jsonContext enginecontext.Interface, block string, conditions []kyvernov1.Condition) {
+	for i, condition := range conditions {
+		result, _, err := variables.Evaluate(log.Log, jsonContext, condition)
+		if err != nil {
+			fmt.Fprintf(out, "  %s[%d]: error (%s)\n", block, i, err)
+		} else {
+			fmt.Fprintf(out, "  %s[%d]: %t\n", block, i, result)
+		}
+		fmt.Fprintf(out, "    %s %s %s\n", toJSON(condition.GetKey()), condition.Operator, toJSON(condition.GetValue()))
+		key, keyErr := variables.SubstituteAllInPreconditions(log.Log, jsonContext, condition.GetKey())
+		value, valueErr := variables.SubstituteAllInPreconditions(log.Log, jsonContext, condition.GetValue())
+		if keyErr == nil && valueErr == nil {
+			fmt.Fprintf(out, "    resolved: %s %s %s\n", toJSON(key), condition.Operator, toJSON(value))
+		}
+		if condition.Message != "" {
+			fmt.Fprintf(out, "    message: %s\n", condition.Message)
+		}
+	}
+}
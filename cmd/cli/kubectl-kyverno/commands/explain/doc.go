@@ -0,0 +1,25 @@
+package explain
+
+// TODO
+var websiteUrl = ``
+
+var description = []string{
+	`Explain the result of a policy rule on a resource.`,
+	``,
+	`The explain command evaluates a single rule against the given resources and prints,`,
+	`for every resource, the resolved context variables, the evaluated precondition and deny`,
+	`condition trees with the outcome of each branch, the rule result and the failing field paths.`,
+	``,
+	`Variables can be provided with a values file, the same way as with the apply command.`,
+}
+
+var examples = [][]string{
+	{
+		`# Explain why a resource fails a rule`,
+		`KYVERNO_EXPERIMENTAL=true kyverno explain --policy policy.yaml --resource resource.yaml --rule check-limits`,
+	},
+	{
+		`# Explain a rule using variables from a values file`,
+		`KYVERNO_EXPERIMENTAL=true kyverno explain --policy policy.yaml --resource resource.yaml --rule check-limits --values-file values.yaml`,
+	},
+}
@@ -0,0 +1,262 @@
+package explain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/log"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/variables"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+)
+
+var failedAtPath = regexp.MustCompile(`failed at path (\S+)`)
+
+type options struct {
+	policyPath   string
+	resourcePath string
+	rule         string
+	valuesFile   string
+}
+
+func (o options) validate() error {
+	if o.policyPath == "" {
+		return errors.New("a policy is required")
+	}
+	if o.resourcePath == "" {
+		return errors.New("a resource is required")
+	}
+	if o.rule == "" {
+		return errors.New("a rule is required")
+	}
+	return nil
+}
+
+func (o options) execute(ctx context.Context, out io.Writer) error {
+	policies, _, err := policy.Load(nil, "", o.policyPath)
+	if err != nil {
+		return fmt.Errorf("failed to load policies (%w)", err)
+	}
+	explained, err := o.selectRule(policies...)
+	if err != nil {
+		return err
+	}
+	resources, err := common.GetResourceAccordingToResourcePath(out, nil, []string{o.resourcePath}, false, policies, nil, nil, "", false, "")
+	if err != nil {
+		return fmt.Errorf("failed to load resources (%w)", err)
+	}
+	if len(resources) == 0 {
+		return errors.New("no resources found")
+	}
+	vars, err := variables.New(out, nil, "", o.valuesFile, nil)
+	if err != nil {
+		return fmt.Errorf("failed to decode yaml (%w)", err)
+	}
+	var store store.Store
+	store.SetLocal(true)
+	vars.SetInStore(&store)
+	for i, resource := range resources {
+		if i != 0 {
+			fmt.Fprintln(out)
+		}
+		if err := o.explain(ctx, out, &store, vars, explained, *resource); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// selectRule returns a copy of the policy containing the rule, restricted to the rule and the rules generated from it.
+func (o options) selectRule(policies ...kyvernov1.PolicyInterface) (kyvernov1.PolicyInterface, error) {
+	names := []string{o.rule, "autogen-" + o.rule, "autogen-cronjob-" + o.rule}
+	for _, policy := range policies {
+		var rules []kyvernov1.Rule
+		for _, rule := range autogen.ComputeRules(policy, nil) {
+			for _, name := range names {
+				if rule.Name == name {
+					rules = append(rules, rule)
+				}
+			}
+		}
+		if len(rules) == 0 {
+			continue
+		}
+		policy = policy.CreateDeepCopy()
+		// rules were already computed, the engine must not generate them again
+		annotations := policy.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[kyverno.AnnotationAutogenControllers] = "none"
+		policy.SetAnnotations(annotations)
+		policy.GetSpec().Rules = rules
+		return policy, nil
+	}
+	return nil, fmt.Errorf("rule %s not found in %s", o.rule, o.policyPath)
+}
+
+func (o options) explain(
+	ctx context.Context,
+	out io.Writer,
+	s *store.Store,
+	vars *variables.Variables,
+	policy kyvernov1.PolicyInterface,
+	resource unstructured.Unstructured,
+) error {
+	key, _ := cache.MetaNamespaceKeyFunc(&resource)
+	fmt.Fprintf(out, "Policy: %s\n", policy.GetName())
+	fmt.Fprintf(out, "Resource: %s %s\n", resource.GetKind(), key)
+	var rc processor.ResultCounts
+	processor := processor.PolicyProcessor{
+		Store:                s,
+		Policies:             []kyvernov1.PolicyInterface{policy},
+		Resource:             resource,
+		Variables:            vars,
+		NamespaceSelectorMap: vars.NamespaceSelectors(),
+		Rc:                   &rc,
+		Subresources:         vars.Subresources(),
+		Out:                  io.Discard,
+	}
+	responses, err := processor.ApplyPoliciesOnResource()
+	if err != nil {
+		return fmt.Errorf("failed to apply policy on resource %s (%w)", key, err)
+	}
+	var ruleResponse *engineapi.RuleResponse
+	for _, response := range responses {
+		for i := range response.PolicyResponse.Rules {
+			ruleResponse = &response.PolicyResponse.Rules[i]
+		}
+	}
+	if ruleResponse == nil {
+		fmt.Fprintf(out, "Rule: %s\n", o.rule)
+		fmt.Fprintln(out, "Result: the rule does not match the resource")
+		return nil
+	}
+	var rule kyvernov1.Rule
+	for _, r := range policy.GetSpec().Rules {
+		if r.Name == ruleResponse.Name() {
+			rule = r
+		}
+	}
+	fmt.Fprintf(out, "Rule: %s\n", rule.Name)
+	jsonContext, values, err := o.loadContext(ctx, s, vars, policy, rule, resource)
+	if err != nil {
+		return err
+	}
+	printContext(out, jsonContext, values, rule.Context)
+	if rule.GetAnyAllConditions() != nil {
+		if err := printConditions(out, jsonContext, "Preconditions", rule.GetAnyAllConditions()); err != nil {
+			return err
+		}
+	}
+	if rule.HasValidate() && rule.Validation.Deny != nil {
+		if err := printConditions(out, jsonContext, "Deny conditions", rule.Validation.Deny.GetAnyAllConditions()); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(out, "Result: %s\n", ruleResponse.Status())
+	if ruleResponse.Message() != "" {
+		fmt.Fprintf(out, "  %s\n", ruleResponse.Message())
+	}
+	if matches := failedAtPath.FindAllStringSubmatch(ruleResponse.Message(), -1); len(matches) != 0 {
+		fmt.Fprintln(out, "Failing paths:")
+		for _, match := range matches {
+			fmt.Fprintf(out, "  %s\n", strings.TrimSuffix(match[1], "."))
+		}
+	}
+	return nil
+}
+
+// loadContext builds the JSON context the rule is evaluated with: the resource, the variables and the rule context entries.
+func (o options) loadContext(
+	ctx context.Context,
+	s *store.Store,
+	vars *variables.Variables,
+	policy kyvernov1.PolicyInterface,
+	rule kyvernov1.Rule,
+	resource unstructured.Unstructured,
+) (enginecontext.Interface, map[string]interface{}, error) {
+	cfg := config.NewDefaultConfiguration(false)
+	jp := jmespath.New(cfg)
+	eng := engine.NewEngine(
+		cfg,
+		config.NewDefaultMetricsConfiguration(),
+		jp,
+		nil,
+		nil,
+		factories.DefaultRegistryClientFactory(nil, nil),
+		imageverifycache.DisabledImageVerifyCache(),
+		store.ContextLoaderFactory(s, nil),
+		nil,
+		nil,
+		nil,
+		"",
+	)
+	policyContext, err := engine.NewPolicyContext(jp, resource, kyvernov1.Create, nil, cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create policy context (%w)", err)
+	}
+	kinds := common.GetKindsFromPolicy(io.Discard, policy, vars.Subresources(), nil)
+	values, err := vars.ComputeVariables(s, policy.GetName(), resource.GetName(), resource.GetKind(), kinds)
+	if err != nil {
+		return nil, nil, err
+	}
+	jsonContext := policyContext.JSONContext()
+	for key, value := range values {
+		if err := jsonContext.AddVariable(key, value); err != nil {
+			return nil, nil, fmt.Errorf("failed to add variable to context %s (%w)", key, err)
+		}
+	}
+	if err := eng.ContextLoader(policy, rule)(ctx, rule.Context, jsonContext); err != nil {
+		log.Log.V(3).Info("failed to load context", "error", err)
+	}
+	return jsonContext, values, nil
+}
+
+func printContext(out io.Writer, jsonContext enginecontext.Interface, values map[string]interface{}, entries []kyvernov1.ContextEntry) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fmt.Fprintln(out, "Context:")
+	for _, key := range keys {
+		fmt.Fprintf(out, "  %s: %s\n", key, toJSON(values[key]))
+	}
+	for _, entry := range entries {
+		value, err := jsonContext.Query(entry.Name)
+		if err != nil || value == nil {
+			fmt.Fprintf(out, "  %s: <unresolved>\n", entry.Name)
+			continue
+		}
+		fmt.Fprintf(out, "  %s: %s\n", entry.Name, toJSON(value))
+	}
+}
+
+func toJSON(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -1,18 +1,15 @@
 package policy
 
 import (
-	"errors"
 	"fmt"
 	"io"
-	"io/fs"
 	"os"
-	"path/filepath"
 	"reflect"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/fix"
+	clipath "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/path"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
-	gitutils "github.com/kyverno/kyverno/pkg/utils/git"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/yaml"
@@ -23,38 +20,16 @@ type options struct {
 }
 
 func (o options) validate(dirs ...string) error {
-	if len(dirs) == 0 {
-		return errors.New("at least one directory is required")
-	}
-	return nil
+	return clipath.ValidateDirs(dirs...)
 }
 
-func find(path string) ([]string, error) {
-	var files []string
-	err := filepath.Walk(path, func(file string, info fs.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		if gitutils.IsYaml(info) {
-			files = append(files, file)
-		}
-		return nil
-	})
+func (o options) execute(out io.Writer, dirs ...string) error {
+	files, err := clipath.FindYamls(dirs...)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return files, nil
-}
-
-func (o options) execute(out io.Writer, dirs ...string) error {
-	for _, dir := range dirs {
-		files, err := find(dir)
-		if err != nil {
-			return err
-		}
-		for _, file := range files {
-			o.processFile(out, file)
-		}
+	for _, file := range files {
+		o.processFile(out, file)
 	}
 	fmt.Fprintln(out, "Done.")
 	return nil
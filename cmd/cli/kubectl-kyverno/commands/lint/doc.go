@@ -0,0 +1,29 @@
+package lint
+
+// TODO
+var websiteUrl = ``
+
+var description = []string{
+	`Lint and score Kyverno policy files.`,
+	``,
+	`The lint command statically checks policies for unresolvable variables, deprecated fields,`,
+	`request variables used in background mode, overly broad match blocks and autogen conflicts.`,
+	``,
+	`Each policy gets a score out of 100, results can be printed as text or in SARIF format.`,
+	`Files that cannot be loaded as Kyverno policies are reported and make the command fail.`,
+}
+
+var examples = [][]string{
+	{
+		`# Lint Kyverno policy files`,
+		`KYVERNO_EXPERIMENTAL=true kyverno lint .`,
+	},
+	{
+		`# Lint Kyverno policy files and produce a SARIF report`,
+		`KYVERNO_EXPERIMENTAL=true kyverno lint . --output-format sarif > kyverno.sarif`,
+	},
+	{
+		`# Fail if a policy scores below 80`,
+		`KYVERNO_EXPERIMENTAL=true kyverno lint . --min-score 80`,
+	},
+}
@@ -0,0 +1,91 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/lint"
+	clipath "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/path"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+)
+
+type options struct {
+	outputFormat string
+	minScore     int
+}
+
+func (o options) validate(dirs ...string) error {
+	if err := clipath.ValidateDirs(dirs...); err != nil {
+		return err
+	}
+	if o.outputFormat != "text" && o.outputFormat != "sarif" {
+		return fmt.Errorf("invalid output format: %s", o.outputFormat)
+	}
+	if o.minScore < 0 || o.minScore > 100 {
+		return fmt.Errorf("min score must be between 0 and 100: %d", o.minScore)
+	}
+	return nil
+}
+
+func (o options) execute(out io.Writer, dirs ...string) error {
+	var results []lint.Result
+	files, err := clipath.FindYamls(dirs...)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		policies, _, err := policy.LoadWithLoader(policy.KubectlValidateLoader, nil, "", file)
+		if err != nil {
+			results = append(results, lint.Invalid(filepath.ToSlash(file), err))
+			continue
+		}
+		for _, policy := range policies {
+			result := lint.Lint(policy)
+			result.Path = filepath.ToSlash(file)
+			results = append(results, result)
+		}
+	}
+	if o.outputFormat == "sarif" {
+		if err := lint.WriteSARIF(out, results...); err != nil {
+			return err
+		}
+	} else {
+		printText(out, results...)
+	}
+	var invalid, failed int
+	for _, result := range results {
+		if result.Policy == nil {
+			invalid++
+		} else if result.Score < o.minScore {
+			failed++
+		}
+	}
+	if invalid != 0 {
+		return fmt.Errorf("%d files could not be loaded", invalid)
+	}
+	if failed != 0 {
+		return fmt.Errorf("%d policies scored below %d", failed, o.minScore)
+	}
+	return nil
+}
+
+func printText(out io.Writer, results ...lint.Result) {
+	var policies int
+	for _, result := range results {
+		if result.Policy == nil {
+			fmt.Fprintf(out, "%s: invalid\n", result.Path)
+		} else {
+			policies++
+			fmt.Fprintf(out, "%s (%s): score %d/100\n", result.Name(), result.Path, result.Score)
+		}
+		for _, finding := range result.Findings {
+			if finding.Rule != "" {
+				fmt.Fprintf(out, "  %s [%s] rule %s: %s\n", finding.Check.Severity, finding.Check.ID, finding.Rule, finding.Message)
+			} else {
+				fmt.Fprintf(out, "  %s [%s] %s\n", finding.Check.Severity, finding.Check.ID, finding.Message)
+			}
+		}
+	}
+	fmt.Fprintf(out, "Linted %d policies.\n", policies)
+}
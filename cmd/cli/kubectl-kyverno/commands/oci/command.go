@@ -1,23 +1,15 @@
 package oci
 
 import (
-	"github.com/google/go-containerregistry/pkg/authn"
-	"github.com/google/go-containerregistry/pkg/authn/github"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/oci/pull"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/commands/oci/push"
-	"github.com/kyverno/kyverno/pkg/registryclient"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/oci"
 	"github.com/spf13/cobra"
 )
 
 func Command() *cobra.Command {
-	keychain := authn.NewMultiKeychain(
-		authn.DefaultKeychain,
-		github.Keychain,
-		registryclient.AWSKeychain,
-		registryclient.GCPKeychain,
-		registryclient.AzureKeychain,
-	)
+	keychain := oci.Keychain()
 	cmd := &cobra.Command{
 		Use:          "oci",
 		Short:        command.FormatDescription(true, websiteUrl, true, description...),
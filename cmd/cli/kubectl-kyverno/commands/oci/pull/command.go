@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/oci"
 	"github.com/spf13/cobra"
 )
 
@@ -26,6 +27,11 @@ func Command(keychain authn.Keychain) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&options.imageRef, "image", "i", "", "image reference to push to or pull from")
+	cmd.Flags().StringVar(&options.verify.Key, "key", "", "public key used to verify the image signature (path, KMS uri or k8s:// secret reference)")
+	cmd.Flags().StringVar(&options.verify.Subject, "subject", "", "expected certificate identity for keyless verification of the image signature")
+	cmd.Flags().StringVar(&options.verify.Issuer, "issuer", "", "expected certificate OIDC issuer for keyless verification of the image signature")
+	cmd.Flags().StringVar(&options.verify.RekorURL, "rekor-url", oci.DefaultRekorURL, "transparency log used to verify the image signature")
+	cmd.Flags().BoolVar(&options.verify.IgnoreTlog, "ignore-tlog", false, "skip the transparency log verification of the image signature")
 	if err := cmd.MarkFlagRequired("image"); err != nil {
 		log.Println("WARNING", err)
 	}
@@ -11,4 +11,8 @@ var examples = [][]string{
 		`# Pull policy from an OCI image and save it to the specific directory`,
 		`kyverno oci pull . -i <imgref>`,
 	},
+	{
+		`# Pull policy from an OCI image after verifying its cosign signature`,
+		`kyverno oci pull . -i <imgref> --key cosign.pub`,
+	},
 }
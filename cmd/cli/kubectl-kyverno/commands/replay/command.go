@@ -0,0 +1,28 @@
+package replay
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "replay",
+		Short:        command.FormatDescription(true, websiteUrl, true, description...),
+		Long:         command.FormatDescription(false, websiteUrl, true, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return options.execute(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringSliceVar(&options.recordingPaths, "recordings", nil, "Path to the recording files or folders")
+	cmd.Flags().StringSliceVar(&options.policyPaths, "policy", nil, "Path to the new policy files or folders, they replace the recorded policies with the same kind, namespace and name")
+	cmd.Flags().BoolVar(&options.failOnChange, "fail-on-change", false, "Fail when the outcome of a recorded admission request changed")
+	return cmd
+}
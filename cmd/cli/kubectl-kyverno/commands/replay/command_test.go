@@ -0,0 +1,68 @@
+package replay
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	err := cmd.Execute()
+	assert.EqualError(t, err, "a recording is required")
+}
+
+func TestCommandWithRecordedPolicies(t *testing.T) {
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"--recordings", "../../_testdata/replay/recordings.jsonl",
+		"--fail-on-change",
+	})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	expected := `Recordings: 4
+Newly blocked: 0
+Newly allowed: 0
+Different mutation: 0
+Unchanged: 4
+`
+	assert.Equal(t, expected, b.String())
+}
+
+func TestCommandWithPolicies(t *testing.T) {
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"--recordings", "../../_testdata/replay",
+		"--policy", "../../_testdata/replay/policies.yaml",
+		"--fail-on-change",
+	})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "the outcome of 3 recorded admission requests changed")
+	expected := `Recordings: 4
+Newly blocked: 1
+Newly allowed: 1
+Different mutation: 1
+Unchanged: 1
+
+Newly blocked:
+  CREATE Pod default/latest-tag (validate)
+    disallow-latest/check-tag
+
+Newly allowed:
+  CREATE Pod default/no-team (validate)
+    policy Pod/default/no-team for resource violation:
+    require-team:
+      check-team: 'validation error: The label ` + "`team`" + ` is required. rule check-team failed at path /metadata/labels/team/'
+
+Different mutation:
+  CREATE Pod default/web (mutate)
+    add /metadata/labels/tier: "frontend"
+`
+	assert.Equal(t, expected, b.String())
+}
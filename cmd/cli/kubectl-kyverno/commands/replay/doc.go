@@ -0,0 +1,27 @@
+package replay
+
+// TODO
+var websiteUrl = ``
+
+var description = []string{
+	`Replay recorded admission requests against new versions of the policies.`,
+	``,
+	`The admission controller records sampled admission requests and the policies in effect when the`,
+	`--admissionRecording flag is set. The replay command evaluates the recorded requests again, the way the`,
+	`webhook that recorded them did, with the recorded policies replaced by the given ones, and reports the`,
+	`requests that are newly blocked, newly allowed, or mutated differently than they were in the cluster.`,
+	``,
+	`Policies loading data from the cluster are evaluated without it, the same way as with the apply command.`,
+}
+
+var examples = [][]string{
+	{
+		`# Replay the recordings of a day against a new version of the policies`,
+		`aws s3 cp --recursive s3://recordings/kyverno/2024/01/02 recordings/`,
+		`KYVERNO_EXPERIMENTAL=true kyverno replay --recordings recordings/ --policy policies/`,
+	},
+	{
+		`# Fail when the outcome of a recorded admission request changed`,
+		`KYVERNO_EXPERIMENTAL=true kyverno replay --recordings recordings.jsonl --policy policy.yaml --fail-on-change`,
+	},
+}
@@ -0,0 +1,222 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"github.com/kyverno/kyverno/pkg/recorder"
+	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type options struct {
+	recordingPaths []string
+	policyPaths    []string
+	failOnChange   bool
+}
+
+func (o options) validate() error {
+	if len(o.recordingPaths) == 0 {
+		return errors.New("a recording is required")
+	}
+	return nil
+}
+
+func (o options) execute(ctx context.Context, out io.Writer) error {
+	recordings, err := loadRecordings(o.recordingPaths...)
+	if err != nil {
+		return err
+	}
+	if len(recordings) == 0 {
+		return errors.New("no recordings found")
+	}
+	var policies []kyvernov1.PolicyInterface
+	if len(o.policyPaths) != 0 {
+		if policies, _, err = policy.Load(nil, "", o.policyPaths...); err != nil {
+			return fmt.Errorf("failed to load policies (%w)", err)
+		}
+	}
+	var s store.Store
+	s.SetLocal(true)
+	cfg := config.NewDefaultConfiguration(false)
+	jp := jmespath.New(cfg)
+	eng := engine.NewEngine(
+		cfg,
+		config.NewDefaultMetricsConfiguration(),
+		jp,
+		nil,
+		nil,
+		factories.DefaultRegistryClientFactory(nil, nil),
+		imageverifycache.DisabledImageVerifyCache(),
+		store.ContextLoaderFactory(&s, nil),
+		nil,
+		nil,
+		nil,
+		"",
+	)
+	var results results
+	for _, recording := range recordings {
+		after, err := replay(ctx, eng, jp, cfg, recording, withPolicies(recording.PolicyInterfaces(), policies))
+		if err != nil {
+			return fmt.Errorf("failed to replay admission request %s (%w)", recording.Request.UID, err)
+		}
+		if err := results.add(recording, after); err != nil {
+			return err
+		}
+	}
+	results.print(out)
+	if changed := results.changed(); o.failOnChange && changed != 0 {
+		return fmt.Errorf("the outcome of %d recorded admission requests changed", changed)
+	}
+	return nil
+}
+
+// loadRecordings reads the JSON lines recording files, folders are walked recursively.
+func loadRecordings(paths ...string) ([]recorder.Recording, error) {
+	var recordings []recorder.Recording
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() || (file != path && !strings.HasSuffix(file, ".jsonl")) {
+				return nil
+			}
+			data, err := os.ReadFile(file) //nolint:gosec
+			if err != nil {
+				return err
+			}
+			decoded, err := recorder.Decode(data)
+			if err != nil {
+				return fmt.Errorf("failed to load recordings from %s (%w)", file, err)
+			}
+			recordings = append(recordings, decoded...)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return recordings, nil
+}
+
+// withPolicies replaces the recorded policies with the new policies of the same kind, namespace and name,
+// the new policies that were not recorded are added.
+func withPolicies(recorded, policies []kyvernov1.PolicyInterface) []kyvernov1.PolicyInterface {
+	key := func(policy kyvernov1.PolicyInterface) string {
+		if policy.IsNamespaced() {
+			return policy.GetNamespace() + "/" + policy.GetName()
+		}
+		return policy.GetName()
+	}
+	replaced := map[string]struct{}{}
+	for _, policy := range policies {
+		replaced[key(policy)] = struct{}{}
+	}
+	result := append([]kyvernov1.PolicyInterface(nil), policies...)
+	for _, policy := range recorded {
+		if _, ok := replaced[key(policy)]; !ok {
+			result = append(result, policy)
+		}
+	}
+	return result
+}
+
+// outcome is the admission outcome of the policies on a recorded admission request
+type outcome struct {
+	// blockedBy contains the policy/rule pairs blocking the admission request
+	blockedBy []string
+	// patched is the resource mutated by the policies
+	patched unstructured.Unstructured
+}
+
+func (o outcome) blocked() bool {
+	return len(o.blockedBy) != 0
+}
+
+// replay evaluates the admission request again with the rules of the webhook that recorded it:
+// mutate and verifyImages rules for the mutate webhook, validate rules for the validate webhook.
+func replay(
+	ctx context.Context,
+	eng engineapi.Engine,
+	jp jmespath.Interface,
+	cfg config.Configuration,
+	recording recorder.Recording,
+	policies []kyvernov1.PolicyInterface,
+) (outcome, error) {
+	policyContext, err := engine.NewPolicyContextFromAdmissionRequest(
+		jp,
+		recording.Request,
+		kyvernov1beta1.RequestInfo{
+			AdmissionUserInfo: recording.Request.UserInfo,
+			Roles:             recording.Roles,
+			ClusterRoles:      recording.ClusterRoles,
+		},
+		schema.GroupVersionKind(recording.Request.Kind),
+		cfg,
+	)
+	if err != nil {
+		return outcome{}, err
+	}
+	policyContext = policyContext.WithNamespaceLabels(recording.NamespaceLabels)
+	policies, _ = policyutils.Sort(policies...)
+	var result outcome
+	apply := func(policy kyvernov1.PolicyInterface, response engineapi.EngineResponse) {
+		if response.PatchedResource.Object != nil {
+			policyContext = policyContext.WithNewResource(response.PatchedResource)
+		}
+		if engineutils.BlockRequest(response, policy.GetSpec().GetFailurePolicy(ctx)) {
+			for _, rule := range response.GetFailedRules() {
+				result.blockedBy = append(result.blockedBy, policy.GetName()+"/"+rule)
+			}
+		}
+	}
+	for _, policy := range policies {
+		if policy.IsNamespaced() && policy.GetNamespace() != recording.Request.Namespace {
+			continue
+		}
+		switch recording.Webhook {
+		case "mutate":
+			if policy.GetSpec().HasMutate() {
+				apply(policy, eng.Mutate(ctx, policyContext.WithPolicy(policy)))
+			}
+		case "validate":
+			if policy.GetSpec().HasValidate() {
+				apply(policy, eng.Validate(ctx, policyContext.WithPolicy(policy)))
+			}
+		default:
+			return outcome{}, fmt.Errorf("unsupported webhook %q", recording.Webhook)
+		}
+	}
+	if recording.Webhook == "mutate" {
+		for _, policy := range policies {
+			if policy.IsNamespaced() && policy.GetNamespace() != recording.Request.Namespace {
+				continue
+			}
+			if policy.GetSpec().HasVerifyImages() {
+				response, _ := eng.VerifyAndPatchImages(ctx, policyContext.WithPolicy(policy))
+				apply(policy, response)
+			}
+		}
+	}
+	result.patched = policyContext.NewResource()
+	return result, nil
+}
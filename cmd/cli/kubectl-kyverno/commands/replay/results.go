@@ -0,0 +1,127 @@
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	"github.com/kyverno/kyverno/pkg/recorder"
+	"gomodules.xyz/jsonpatch/v2"
+)
+
+type change struct {
+	request string
+	details []string
+}
+
+type results struct {
+	total        int
+	newlyBlocked []change
+	newlyAllowed []change
+	mutated      []change
+	unchanged    int
+}
+
+func (r *results) add(recording recorder.Recording, after outcome) error {
+	r.total++
+	request := recording.Request
+	name := fmt.Sprintf("%s %s %s (%s)", request.Operation, request.Kind.Kind, request.Name, recording.Webhook)
+	if request.Namespace != "" {
+		name = fmt.Sprintf("%s %s %s/%s (%s)", request.Operation, request.Kind.Kind, request.Namespace, request.Name, recording.Webhook)
+	}
+	switch {
+	case recording.Allowed && after.blocked():
+		r.newlyBlocked = append(r.newlyBlocked, change{request: name, details: after.blockedBy})
+	case !recording.Allowed && !after.blocked():
+		var details []string
+		for _, line := range strings.Split(recording.Message, "\n") {
+			if strings.TrimSpace(line) != "" {
+				details = append(details, line)
+			}
+		}
+		r.newlyAllowed = append(r.newlyAllowed, change{request: name, details: details})
+	case recording.Allowed && recording.Webhook == "mutate":
+		before, err := recordedMutation(recording)
+		if err != nil {
+			return fmt.Errorf("failed to apply the recorded patches of admission request %s (%w)", request.UID, err)
+		}
+		afterBytes, err := json.Marshal(after.patched.Object)
+		if err != nil {
+			return err
+		}
+		details, err := mutationDiff(before, afterBytes)
+		if err != nil {
+			return fmt.Errorf("failed to compare mutations of admission request %s (%w)", request.UID, err)
+		}
+		if len(details) != 0 {
+			r.mutated = append(r.mutated, change{request: name, details: details})
+		} else {
+			r.unchanged++
+		}
+	default:
+		r.unchanged++
+	}
+	return nil
+}
+
+func (r *results) changed() int {
+	return len(r.newlyBlocked) + len(r.newlyAllowed) + len(r.mutated)
+}
+
+// recordedMutation returns the resource mutated by the recorded patches
+func recordedMutation(recording recorder.Recording) ([]byte, error) {
+	if len(recording.Patches) == 0 {
+		return recording.Request.Object.Raw, nil
+	}
+	return engineutils.ApplyPatchNew(recording.Request.Object.Raw, recording.Patches)
+}
+
+// mutationDiff returns the operations turning the resource mutated in the cluster into the one mutated by the new policies
+func mutationDiff(before, after []byte) ([]string, error) {
+	patch, err := jsonpatch.CreatePatch(before, after)
+	if err != nil {
+		return nil, err
+	}
+	details := make([]string, 0, len(patch))
+	for _, operation := range patch {
+		if operation.Operation == "remove" {
+			details = append(details, fmt.Sprintf("%s %s", operation.Operation, operation.Path))
+		} else {
+			value, err := json.Marshal(operation.Value)
+			if err != nil {
+				return nil, err
+			}
+			details = append(details, fmt.Sprintf("%s %s: %s", operation.Operation, operation.Path, value))
+		}
+	}
+	sort.Strings(details)
+	return details, nil
+}
+
+func (r *results) print(out io.Writer) {
+	fmt.Fprintf(out, "Recordings: %d\n", r.total)
+	fmt.Fprintf(out, "Newly blocked: %d\n", len(r.newlyBlocked))
+	fmt.Fprintf(out, "Newly allowed: %d\n", len(r.newlyAllowed))
+	fmt.Fprintf(out, "Different mutation: %d\n", len(r.mutated))
+	fmt.Fprintf(out, "Unchanged: %d\n", r.unchanged)
+	printChanges(out, "Newly blocked", r.newlyBlocked)
+	printChanges(out, "Newly allowed", r.newlyAllowed)
+	printChanges(out, "Different mutation", r.mutated)
+}
+
+func printChanges(out io.Writer, title string, changes []change) {
+	if len(changes) == 0 {
+		return
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "%s:\n", title)
+	for _, change := range changes {
+		fmt.Fprintf(out, "  %s\n", change.request)
+		for _, detail := range change.details {
+			fmt.Fprintf(out, "    %s\n", detail)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package scan
+
+import (
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
+	"github.com/spf13/cobra"
+)
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "scan",
+		Short:        command.FormatDescription(true, websiteUrl, true, description...),
+		Long:         command.FormatDescription(false, websiteUrl, true, description...),
+		Example:      command.FormatExamples(examples...),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+			return options.execute(cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringSliceVar(&options.policyPaths, "policy", nil, "Path to the ValidatingPolicy files or folders")
+	cmd.Flags().StringSliceVar(&options.payloadPaths, "payload", nil, "Path to the JSON or YAML payload files")
+	cmd.Flags().StringVarP(&options.output, "output", "o", "text", "Output format (text or json)")
+	return cmd
+}
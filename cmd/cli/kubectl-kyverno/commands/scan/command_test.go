@@ -0,0 +1,73 @@
+package scan
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommand(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	err := cmd.Execute()
+	assert.EqualError(t, err, "a policy is required")
+}
+
+func TestCommandWithoutPayload(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{
+		"--policy", "../../_testdata/scan/policy.yaml",
+	})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "a payload is required")
+}
+
+func TestCommandWithInvalidOutput(t *testing.T) {
+	cmd := Command()
+	cmd.SetArgs([]string{
+		"--policy", "../../_testdata/scan/policy.yaml",
+		"--payload", "../../_testdata/scan/pass.json",
+		"--output", "xml",
+	})
+	err := cmd.Execute()
+	assert.EqualError(t, err, `invalid output format "xml", must be one of text, json`)
+}
+
+func TestCommandWithPassingPayload(t *testing.T) {
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"--policy", "../../_testdata/scan/policy.yaml",
+		"--payload", "../../_testdata/scan/pass.json",
+	})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	expected := `../../_testdata/scan/pass.json
+  terraform-s3/require-encryption: pass
+  terraform-s3/deny-public-acl: pass
+
+pass: 2, fail: 0, error: 0, skip: 0
+`
+	assert.Equal(t, expected, b.String())
+}
+
+func TestCommandWithFailingPayload(t *testing.T) {
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"--policy", "../../_testdata/scan/policy.yaml",
+		"--payload", "../../_testdata/scan/fail.yaml",
+	})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "2 rules failed")
+	expected := `../../_testdata/scan/fail.yaml
+  terraform-s3/require-encryption: fail (S3 buckets must be encrypted: failed at path /resource_changes/0/change/after/server_side_encryption_configuration/)
+  terraform-s3/deny-public-acl: fail (bucket website is public)
+
+pass: 0, fail: 2, error: 0, skip: 0
+`
+	assert.Equal(t, expected, b.String())
+}
@@ -0,0 +1,27 @@
+package scan
+
+// TODO
+var websiteUrl = ``
+
+var description = []string{
+	`Validate JSON payloads that are not Kubernetes resources against ValidatingPolicies.`,
+	``,
+	`The scan command applies the rules of kyverno.io/v2alpha1 ValidatingPolicies to arbitrary JSON or YAML`,
+	`payloads, like Terraform plans, Dockerfiles converted to JSON or cloud API responses.`,
+	`Rules use the same patterns and conditions as policies for Kubernetes resources, the payload is available`,
+	`to conditions and messages with the 'payload' variable.`,
+	``,
+	`The command fails when a rule fails or errors.`,
+}
+
+var examples = [][]string{
+	{
+		`# Validate a Terraform plan`,
+		`terraform show -json tfplan > plan.json`,
+		`KYVERNO_EXPERIMENTAL=true kyverno scan --policy policies/ --payload plan.json`,
+	},
+	{
+		`# Validate several payloads and print the results as JSON`,
+		`KYVERNO_EXPERIMENTAL=true kyverno scan --policy policy.yaml --payload a.json --payload b.yaml --output json`,
+	},
+}
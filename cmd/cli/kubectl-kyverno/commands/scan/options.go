@@ -0,0 +1,171 @@
+package scan
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/log"
+	clipath "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/path"
+	extyaml "github.com/kyverno/kyverno/ext/yaml"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/payload"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+type options struct {
+	policyPaths  []string
+	payloadPaths []string
+	output       string
+}
+
+// result is the outcome of the policies applied to a payload file
+type result struct {
+	Payload  string                   `json:"payload"`
+	Policies []payload.PolicyResponse `json:"policies"`
+}
+
+func (o options) validate() error {
+	if len(o.policyPaths) == 0 {
+		return errors.New("a policy is required")
+	}
+	if len(o.payloadPaths) == 0 {
+		return errors.New("a payload is required")
+	}
+	if o.output != "text" && o.output != "json" {
+		return fmt.Errorf("invalid output format %q, must be one of text, json", o.output)
+	}
+	return nil
+}
+
+func (o options) execute(out io.Writer) error {
+	policies, err := loadPolicies(o.policyPaths...)
+	if err != nil {
+		return err
+	}
+	if len(policies) == 0 {
+		return errors.New("no ValidatingPolicy found")
+	}
+	jp := jmespath.New(config.NewDefaultConfiguration(false))
+	results := make([]result, 0, len(o.payloadPaths))
+	for _, path := range o.payloadPaths {
+		data, err := loadPayload(path)
+		if err != nil {
+			return err
+		}
+		responses, err := payload.Validate(log.Log, jp, data, policies...)
+		if err != nil {
+			return fmt.Errorf("failed to validate payload %s (%w)", path, err)
+		}
+		results = append(results, result{Payload: path, Policies: responses})
+	}
+	if o.output == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(results); err != nil {
+			return err
+		}
+	} else {
+		printText(out, results)
+	}
+	var failed int
+	for _, result := range results {
+		for _, policy := range result.Policies {
+			for _, rule := range policy.Rules {
+				if rule.Status == engineapi.RuleStatusFail || rule.Status == engineapi.RuleStatusError {
+					failed++
+				}
+			}
+		}
+	}
+	if failed != 0 {
+		return fmt.Errorf("%d rules failed", failed)
+	}
+	return nil
+}
+
+func printText(out io.Writer, results []result) {
+	var pass, fail, errored, skip int
+	for _, result := range results {
+		fmt.Fprintf(out, "%s\n", result.Payload)
+		for _, policy := range result.Policies {
+			for _, rule := range policy.Rules {
+				switch rule.Status {
+				case engineapi.RuleStatusPass:
+					pass++
+				case engineapi.RuleStatusFail:
+					fail++
+				case engineapi.RuleStatusError:
+					errored++
+				case engineapi.RuleStatusSkip:
+					skip++
+				}
+				fmt.Fprintf(out, "  %s/%s: %s", policy.Policy, rule.Name, rule.Status)
+				if rule.Status != engineapi.RuleStatusPass && rule.Message != "" {
+					fmt.Fprintf(out, " (%s)", rule.Message)
+				}
+				fmt.Fprintln(out)
+			}
+		}
+	}
+	fmt.Fprintf(out, "\npass: %d, fail: %d, error: %d, skip: %d\n", pass, fail, errored, skip)
+}
+
+// loadPolicies reads the ValidatingPolicies of the yaml files, other documents are ignored
+func loadPolicies(paths ...string) ([]*kyvernov2alpha1.ValidatingPolicy, error) {
+	files, err := clipath.FindYamls(paths...)
+	if err != nil {
+		return nil, err
+	}
+	var policies []*kyvernov2alpha1.ValidatingPolicy
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s (%w)", file, err)
+		}
+		documents, err := extyaml.SplitDocuments(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to split %s (%w)", file, err)
+		}
+		for _, document := range documents {
+			if extyaml.IsEmptyDocument(document) {
+				continue
+			}
+			var object unstructured.Unstructured
+			if err := yaml.Unmarshal(document, &object.Object); err != nil {
+				return nil, fmt.Errorf("failed to decode %s (%w)", file, err)
+			}
+			if object.GroupVersionKind() != kyvernov2alpha1.SchemeGroupVersion.WithKind("ValidatingPolicy") {
+				continue
+			}
+			var policy kyvernov2alpha1.ValidatingPolicy
+			if err := yaml.UnmarshalStrict(document, &policy); err != nil {
+				return nil, fmt.Errorf("failed to decode %s (%w)", file, err)
+			}
+			if errs := policy.Validate(); len(errs) != 0 {
+				return nil, fmt.Errorf("policy %s in %s is invalid (%w)", policy.GetName(), file, errs.ToAggregate())
+			}
+			policies = append(policies, &policy)
+		}
+	}
+	return policies, nil
+}
+
+// loadPayload reads a JSON or YAML payload
+func loadPayload(path string) (interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payload %s (%w)", path, err)
+	}
+	var payload interface{}
+	if err := yaml.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode payload %s (%w)", path, err)
+	}
+	return payload, nil
+}
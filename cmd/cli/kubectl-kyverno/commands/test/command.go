@@ -6,11 +6,16 @@ import (
 	"path/filepath"
 
 	"github.com/go-git/go-billy/v5"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/deprecations"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/exception"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/color"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/format"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/path"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/report"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/filter"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
@@ -20,8 +25,10 @@ import (
 
 func Command() *cobra.Command {
 	var testCase string
-	var fileName, gitBranch string
-	var registryAccess, failOnly, removeColor, detailedResults bool
+	var fileName, gitBranch, outputFormat string
+	var registryAccess, failOnly, removeColor, detailedResults, showCoverage bool
+	var minCoverage float64
+	var exceptionPaths []string
 	cmd := &cobra.Command{
 		Use:          "test [local folder or git repository]...",
 		Short:        command.FormatDescription(true, websiteUrl, false, description...),
@@ -31,7 +38,18 @@ func Command() *cobra.Command {
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, dirPath []string) (err error) {
 			color.Init(removeColor)
-			return testCommandExecute(cmd.OutOrStdout(), dirPath, fileName, gitBranch, testCase, registryAccess, failOnly, detailedResults)
+			resultsFormat, err := format.Parse(outputFormat)
+			if err != nil {
+				return err
+			}
+			if minCoverage < 0 || minCoverage > 100 {
+				return fmt.Errorf("invalid minimum coverage %v, must be between 0 and 100", minCoverage)
+			}
+			exceptions, err := exception.LoadFiles(exceptionPaths...)
+			if err != nil {
+				return err
+			}
+			return testCommandExecute(cmd.OutOrStdout(), dirPath, fileName, gitBranch, testCase, registryAccess, failOnly, detailedResults, resultsFormat, showCoverage || minCoverage > 0, minCoverage, exceptions)
 		},
 	}
 	cmd.Flags().StringVarP(&fileName, "file-name", "f", "kyverno-test.yaml", "Test filename")
@@ -41,6 +59,10 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVar(&failOnly, "fail-only", false, "If set to true, display all the failing test only as output for the test command")
 	cmd.Flags().BoolVar(&removeColor, "remove-color", false, "Remove any color from output")
 	cmd.Flags().BoolVar(&detailedResults, "detailed-results", false, "If set to true, display detailed results")
+	cmd.Flags().StringVar(&outputFormat, "output-format", string(format.Text), "Output format for results (text, junit, sarif or github)")
+	cmd.Flags().BoolVar(&showCoverage, "coverage", false, "If set to true, display the policy rule branches exercised by the tests")
+	cmd.Flags().StringSliceVar(&exceptionPaths, "exceptions", nil, "Path to policy exception files, matching policy exceptions are honored by every test")
+	cmd.Flags().Float64Var(&minCoverage, "min-coverage", 0, "Minimum percentage of policy rule branches the tests must exercise, the command fails below it (implies --coverage)")
 	return cmd
 }
 
@@ -59,7 +81,27 @@ func testCommandExecute(
 	registryAccess bool,
 	failOnly bool,
 	detailedResults bool,
+	resultsFormat format.Format,
+	showCoverage bool,
+	minCoverage float64,
+	exceptions []*kyvernov2.PolicyException,
 ) (err error) {
+	var results []format.Result
+	// only the report is written when a machine readable format is requested,
+	// filter and test loading errors are reported as errored results and fail the command
+	var loadErrors int
+	if resultsFormat != format.Text {
+		reportOut := out
+		out = io.Discard
+		defer func() {
+			if writeErr := format.Write(reportOut, resultsFormat, results...); writeErr != nil && err == nil {
+				err = writeErr
+			}
+			if loadErrors != 0 && err == nil {
+				err = fmt.Errorf("%d errors while loading tests", loadErrors)
+			}
+		}()
+	}
 	// check input dir
 	if len(dirPath) == 0 {
 		return fmt.Errorf("a directory is required")
@@ -71,13 +113,16 @@ func testCommandExecute(
 		fmt.Fprintln(out, "Filter errors:")
 		for _, e := range errors {
 			fmt.Fprintln(out, "  Error:", e)
+			results = append(results, errorResult("filter", "Filter", testCase, "", e))
 		}
+		loadErrors += len(errors)
 	}
 	// load tests
 	tests, err := loadTests(dirPath, fileName, gitBranch)
 	if err != nil {
 		fmt.Fprintln(out)
 		fmt.Fprintln(out, "Error loading tests:", err)
+		results = append(results, errorResult("load", "Test", fileName, "", err))
 		return err
 	}
 	if len(tests) == 0 {
@@ -90,7 +135,9 @@ func testCommandExecute(
 		for _, e := range errs {
 			fmt.Fprintln(out, "  Path:", e.Path)
 			fmt.Fprintln(out, "    Error:", e.Err)
+			results = append(results, errorResult("load", "Test", e.Path, e.Path, e.Err))
 		}
+		loadErrors += len(errs)
 	}
 	if len(tests) == 0 {
 		if len(errors) == 0 {
@@ -101,6 +148,7 @@ func testCommandExecute(
 		}
 	}
 	rc := &resultCounts{}
+	cov := newCoverage()
 	var table table.Table
 	for _, test := range tests {
 		if test.Err == nil {
@@ -116,15 +164,19 @@ func testCommandExecute(
 				continue
 			}
 			resourcePath := filepath.Dir(test.Path)
-			responses, err := runTest(out, test, registryAccess, false)
+			responses, policies, err := runTest(out, test, exceptions, registryAccess, false)
 			if err != nil {
 				return fmt.Errorf("failed to run test (%w)", err)
 			}
+			cov.addPolicies(policies...)
+			cov.addResponses(filteredResults, responses...)
 			fmt.Fprintln(out, "  Checking results ...")
-			t, err := printTestResult(out, filteredResults, responses, rc, failOnly, detailedResults, test.Fs, resourcePath)
+			locator := format.NewLocator(test.Fs, path.GetFullPaths(test.Test.Resources, test.Dir(), test.Fs != nil)...)
+			t, r, err := printTestResult(out, filteredResults, responses, rc, failOnly, detailedResults, test.Fs, resourcePath, locator)
 			if err != nil {
 				return fmt.Errorf("failed to print test result (%w)", err)
 			}
+			results = append(results, r...)
 			table.AddFailed(t.RawRows...)
 		}
 	}
@@ -134,15 +186,34 @@ func testCommandExecute(
 		fmt.Fprintf(out, "\nTest Summary: %d out of %d tests failed\n", rc.Fail, rc.Pass+rc.Skip+rc.Fail)
 	}
 	fmt.Fprintln(out)
+	if showCoverage {
+		cov.print(out)
+	}
 	if rc.Fail > 0 {
 		if !failOnly {
 			printFailedTestResult(out, table, detailedResults)
 		}
 		return fmt.Errorf("%d tests failed", rc.Fail)
 	}
+	if percent := cov.percent(); percent < minCoverage {
+		return fmt.Errorf("coverage %.1f%% is below the minimum of %.1f%%", percent, minCoverage)
+	}
 	return nil
 }
 
+// errorResult returns an errored result for an error happening before tests run.
+func errorResult(rule, kind, name, path string, err error) format.Result {
+	return format.Result{
+		Policy:   "kyverno-test",
+		Rule:     rule,
+		Kind:     kind,
+		Name:     name,
+		Status:   policyreportv1alpha2.StatusError,
+		Message:  err.Error(),
+		Location: format.Location{Path: path},
+	}
+}
+
 func checkResult(test v1alpha1.TestResult, fs billy.Filesystem, resoucePath string, response engineapi.EngineResponse, rule engineapi.RuleResponse) (bool, string, string) {
 	expected := test.Result
 	// fallback to the deprecated field
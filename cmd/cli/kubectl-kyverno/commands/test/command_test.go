@@ -3,6 +3,8 @@ package test
 import (
 	"bytes"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -48,3 +50,67 @@ func TestCommandHelp(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
 }
+
+func TestCommandWithInvalidOutputFormat(t *testing.T) {
+	cmd := Command()
+	assert.NotNil(t, cmd)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{".", "--output-format", "xml"})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	out, err := io.ReadAll(b)
+	assert.NoError(t, err)
+	expected := `Error: invalid output format "xml", must be one of text, junit, sarif, github`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(string(out)))
+}
+
+func TestCommandWithInvalidTestAndMachineFormat(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "kyverno-test.yaml"), []byte("apiVersion: cli.kyverno.io/v1alpha1\nkind: Test\nmetadata: [\n"), 0o600))
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{dir, "--output-format", "junit"})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "1 errors while loading tests")
+	assert.Contains(t, b.String(), "kyverno-test/load Test/")
+	assert.Contains(t, b.String(), "<error")
+}
+
+func TestCommandWithCoverage(t *testing.T) {
+	cmd := Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"../../_testdata/coverage", "--coverage"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+	assert.Contains(t, b.String(), "  pod-requirements require-app-label: 0/2 (missing pass, fail)\n")
+	assert.Contains(t, b.String(), "  pod-requirements require-run-as-non-root: 1/3 (missing anyPattern[1], fail)\n")
+	assert.Contains(t, b.String(), "Coverage Summary: 1 of 5 rule branches exercised (20.0%)")
+}
+
+func TestCommandWithExceptions(t *testing.T) {
+	cmd := Command()
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{"../../_testdata/exceptions"})
+	assert.EqualError(t, cmd.Execute(), "1 tests failed")
+	cmd = Command()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"../../_testdata/exceptions", "--exceptions", "../../_testdata/exceptions/exception.yaml"})
+	assert.NoError(t, cmd.Execute())
+	assert.Contains(t, b.String(), "Test Summary: 2 tests passed and 0 tests failed")
+}
+
+func TestCommandWithMinCoverage(t *testing.T) {
+	cmd := Command()
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{"../../_testdata/coverage", "--min-coverage", "50"})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "coverage 20.0% is below the minimum of 50.0%")
+	cmd = Command()
+	cmd.SetOut(io.Discard)
+	cmd.SetArgs([]string{"../../_testdata/coverage", "--min-coverage", "20"})
+	assert.NoError(t, cmd.Execute())
+}
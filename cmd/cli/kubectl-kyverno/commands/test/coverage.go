@@ -0,0 +1,167 @@
+package test
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"k8s.io/client-go/tools/cache"
+)
+
+var anyPatternPassed = regexp.MustCompile(`anyPattern\[(\d+)\] passed`)
+
+// coverage tracks the branches of policy rules exercised by the tests.
+// A validation rule has a pass and a fail branch, or one branch per anyPattern alternative and a fail branch,
+// mutate and generate rules have a single branch. Rules using foreach have an additional branch exercised
+// when the rule processes at least one element.
+// Only rules asserted by a test result count as exercised.
+type coverage struct {
+	rules map[string]*ruleCoverage
+}
+
+type ruleCoverage struct {
+	policy    string
+	rule      string
+	branches  []string
+	exercised map[string]bool
+}
+
+func newCoverage() *coverage {
+	return &coverage{rules: map[string]*ruleCoverage{}}
+}
+
+// addPolicies registers the branches of the rules of the given policies.
+func (c *coverage) addPolicies(policies ...kyvernov1.PolicyInterface) {
+	for _, policy := range policies {
+		name := cache.MetaObjectToName(policy).String()
+		for _, rule := range policy.GetSpec().Rules {
+			key := name + "/" + rule.Name
+			if _, ok := c.rules[key]; ok {
+				continue
+			}
+			c.rules[key] = &ruleCoverage{
+				policy:    name,
+				rule:      rule.Name,
+				branches:  ruleBranches(rule),
+				exercised: map[string]bool{},
+			}
+		}
+	}
+}
+
+func ruleBranches(rule kyvernov1.Rule) []string {
+	var branches []string
+	switch {
+	case rule.HasValidate():
+		if anyPattern, err := rule.Validation.DeserializeAnyPattern(); err == nil && len(anyPattern) != 0 {
+			for i := range anyPattern {
+				branches = append(branches, fmt.Sprintf("anyPattern[%d]", i))
+			}
+		} else {
+			branches = append(branches, "pass")
+		}
+		branches = append(branches, "fail")
+		if len(rule.Validation.ForEachValidation) != 0 {
+			branches = append(branches, "foreach")
+		}
+	case rule.HasVerifyImages():
+		branches = append(branches, "pass", "fail")
+	case rule.HasMutate():
+		branches = append(branches, "pass")
+		if len(rule.Mutation.ForEachMutation) != 0 {
+			branches = append(branches, "foreach")
+		}
+	default:
+		branches = append(branches, "pass")
+	}
+	return branches
+}
+
+// addResponses marks the branches exercised by the engine responses of rules asserted by the test results.
+func (c *coverage) addResponses(results []v1alpha1.TestResult, responses ...engineapi.EngineResponse) {
+	for _, response := range responses {
+		policy := cache.MetaObjectToName(response.Policy().MetaObject()).String()
+		for _, ruleResponse := range response.PolicyResponse.Rules {
+			rule := strings.TrimPrefix(strings.TrimPrefix(ruleResponse.Name(), "autogen-cronjob-"), "autogen-")
+			coverage, ok := c.rules[policy+"/"+rule]
+			if !ok || !isAsserted(results, policy, rule) {
+				continue
+			}
+			switch ruleResponse.Status() {
+			case engineapi.RuleStatusPass:
+				if match := anyPatternPassed.FindStringSubmatch(ruleResponse.Message()); match != nil {
+					coverage.exercised[fmt.Sprintf("anyPattern[%s]", match[1])] = true
+				} else {
+					coverage.exercised["pass"] = true
+				}
+				coverage.exercised["foreach"] = true
+			case engineapi.RuleStatusFail:
+				coverage.exercised["fail"] = true
+				coverage.exercised["foreach"] = true
+			}
+		}
+	}
+}
+
+func isAsserted(results []v1alpha1.TestResult, policy string, rule string) bool {
+	for _, result := range results {
+		if result.Policy == policy && result.Rule == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// percent returns the percentage of exercised branches, 100 when there are no branches.
+func (c *coverage) percent() float64 {
+	total, exercised := c.count()
+	if total == 0 {
+		return 100
+	}
+	return float64(exercised) * 100 / float64(total)
+}
+
+func (c *coverage) count() (int, int) {
+	var total, exercised int
+	for _, rule := range c.rules {
+		for _, branch := range rule.branches {
+			total++
+			if rule.exercised[branch] {
+				exercised++
+			}
+		}
+	}
+	return total, exercised
+}
+
+// print writes the coverage of every rule followed by the coverage summary.
+func (c *coverage) print(out io.Writer) {
+	keys := make([]string, 0, len(c.rules))
+	for key := range c.rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fmt.Fprintln(out, "Coverage:")
+	for _, key := range keys {
+		rule := c.rules[key]
+		var missing []string
+		for _, branch := range rule.branches {
+			if !rule.exercised[branch] {
+				missing = append(missing, branch)
+			}
+		}
+		line := fmt.Sprintf("  %s %s: %d/%d", rule.policy, rule.rule, len(rule.branches)-len(missing), len(rule.branches))
+		if len(missing) != 0 {
+			line += " (missing " + strings.Join(missing, ", ") + ")"
+		}
+		fmt.Fprintln(out, line)
+	}
+	total, exercised := c.count()
+	fmt.Fprintf(out, "\nCoverage Summary: %d of %d rule branches exercised (%.1f%%)\n", exercised, total, c.percent())
+	fmt.Fprintln(out)
+}
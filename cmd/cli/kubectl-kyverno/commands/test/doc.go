@@ -10,6 +10,10 @@ var description = []string{
 	``,
 	`Users provide the path to the folder containing a kyverno-test.yaml file where the location could be`,
 	`on a local filesystem or a remote git repository.`,
+	``,
+	`A coverage report lists the branches of policy rules asserted by the tests: pass and fail for validation rules`,
+	`(one branch per anyPattern alternative instead of pass), foreach rules processing at least one element,`,
+	`and applied mutate and generate rules.`,
 }
 
 var examples = [][]string{
@@ -25,4 +29,12 @@ var examples = [][]string{
 		`# Test some specific test cases out of many test cases in a local folder`,
 		`kyverno test . --test-case-selector "policy=disallow-latest-tag, rule=require-image-tag, resource=test-require-image-tag-pass"`,
 	},
+	{
+		`# Test a local folder containing test cases and write a JUnit report`,
+		`kyverno test . --output-format junit > report.xml`,
+	},
+	{
+		`# Test a local folder containing test cases and fail when less than 80% of the rule branches are exercised`,
+		`kyverno test . --min-coverage 80`,
+	},
 }
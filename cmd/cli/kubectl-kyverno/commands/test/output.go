@@ -3,12 +3,15 @@ package test
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/go-git/go-billy/v5"
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/color"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/format"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/table"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy/annotations"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 )
 
@@ -21,9 +24,11 @@ func printTestResult(
 	detailedResults bool,
 	fs billy.Filesystem,
 	resoucePath string,
-) (table.Table, error) {
+	locator *format.Locator,
+) (table.Table, []format.Result, error) {
 	printer := table.NewTablePrinter(out)
 	var resultsTable table.Table
+	var results []format.Result
 	var countDeprecatedResource int
 	testCount := 1
 	for _, test := range tests {
@@ -41,6 +46,18 @@ func printTestResult(
 		}
 		for _, resource := range resources {
 			var rows []table.Row
+			namespace, name := test.Namespace, resource
+			if parts := strings.SplitN(resource, "/", 2); len(parts) == 2 {
+				namespace, name = parts[0], parts[1]
+			}
+			result := format.Result{
+				Policy:    test.Policy,
+				Rule:      test.Rule,
+				Kind:      test.Kind,
+				Namespace: namespace,
+				Name:      name,
+				Location:  locator.Locate(test.Kind, namespace, name),
+			}
 			// lookup matching engine responses (with the resource name this time)
 			for _, response := range lookupEngineResponses(test, resource, responses...) {
 				// lookup matching rule responses
@@ -60,19 +77,27 @@ func printTestResult(
 						},
 						Message: message,
 					}
+					result := result
+					result.Severity = annotations.Severity(response.Policy().GetAnnotations())
+					result.Message = message
 					if success {
 						row.Result = color.ResultPass()
 						if test.Result == policyreportv1alpha2.StatusSkip {
 							rc.Skip++
+							result.Status = policyreportv1alpha2.StatusSkip
 						} else {
 							rc.Pass++
+							result.Status = policyreportv1alpha2.StatusPass
 						}
 					} else {
 						row.Result = color.ResultFail()
 						rc.Fail++
+						result.Status = policyreportv1alpha2.StatusFail
+						result.Message = reason + ": " + message
 					}
 					testCount++
 					rows = append(rows, row)
+					results = append(results, result)
 				}
 			}
 			// if not found
@@ -92,6 +117,9 @@ func printTestResult(
 				testCount++
 				resultsTable.Add(row)
 				rc.Fail++
+				result.Status = policyreportv1alpha2.StatusFail
+				result.Message = "Not found"
+				results = append(results, result)
 			} else {
 				resultsTable.Add(rows...)
 			}
@@ -100,7 +128,7 @@ func printTestResult(
 	fmt.Fprintln(out)
 	printer.Print(resultsTable.Rows(detailedResults))
 	fmt.Fprintln(out)
-	return resultsTable, nil
+	return resultsTable, results, nil
 }
 
 func printFailedTestResult(out io.Writer, resultsTable table.Table, detailedResults bool) {
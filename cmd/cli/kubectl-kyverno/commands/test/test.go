@@ -1,11 +1,13 @@
 package test
 
 import (
+	"context"
 	"fmt"
 	"io"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/deprecations"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/log"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/path"
@@ -28,10 +30,11 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
-func runTest(out io.Writer, testCase test.TestCase, registryAccess bool, auditWarn bool) ([]engineapi.EngineResponse, error) {
+// runTest runs a test case and returns the engine responses and the valid policies that were applied.
+func runTest(out io.Writer, testCase test.TestCase, exceptions []*kyvernov2.PolicyException, registryAccess bool, auditWarn bool) ([]engineapi.EngineResponse, []kyvernov1.PolicyInterface, error) {
 	// don't process test case with errors
 	if testCase.Err != nil {
-		return nil, testCase.Err
+		return nil, nil, testCase.Err
 	}
 	fmt.Fprintln(out, "Loading test", testCase.Test.Name, "(", testCase.Path, ")", "...")
 	isGit := testCase.Fs != nil
@@ -42,7 +45,7 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool, auditWa
 	vars, err := variables.New(out, testCase.Fs, testDir, testCase.Test.Variables, testCase.Test.Values)
 	if err != nil {
 		err = fmt.Errorf("failed to decode yaml (%w)", err)
-		return nil, err
+		return nil, nil, err
 	}
 	// user info
 	var userInfo *v1beta1.RequestInfo
@@ -50,7 +53,7 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool, auditWa
 		fmt.Fprintln(out, "  Loading user infos", "...")
 		info, err := userinfo.Load(testCase.Fs, testCase.Test.UserInfo, testDir)
 		if err != nil {
-			return nil, fmt.Errorf("Error: failed to load request info (%s)", err)
+			return nil, nil, fmt.Errorf("Error: failed to load request info (%s)", err)
 		}
 		deprecations.CheckUserInfo(out, testCase.Test.UserInfo, info)
 		userInfo = &info.RequestInfo
@@ -60,14 +63,14 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool, auditWa
 	policyFullPath := path.GetFullPaths(testCase.Test.Policies, testDir, isGit)
 	policies, validatingAdmissionPolicies, err := policy.Load(testCase.Fs, testDir, policyFullPath...)
 	if err != nil {
-		return nil, fmt.Errorf("Error: failed to load policies (%s)", err)
+		return nil, nil, fmt.Errorf("Error: failed to load policies (%s)", err)
 	}
 	// resources
 	fmt.Fprintln(out, "  Loading resources", "...")
 	resourceFullPath := path.GetFullPaths(testCase.Test.Resources, testDir, isGit)
 	resources, err := common.GetResourceAccordingToResourcePath(out, testCase.Fs, resourceFullPath, false, policies, validatingAdmissionPolicies, dClient, "", false, testDir)
 	if err != nil {
-		return nil, fmt.Errorf("Error: failed to load resources (%s)", err)
+		return nil, nil, fmt.Errorf("Error: failed to load resources (%s)", err)
 	}
 	uniques, duplicates := resource.RemoveDuplicates(resources)
 	if len(duplicates) > 0 {
@@ -86,7 +89,7 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool, auditWa
 	// TODO document the code below
 	ruleToCloneSourceResource := map[string]string{}
 	for _, policy := range policies {
-		for _, rule := range autogen.ComputeRules(policy) {
+		for _, rule := range autogen.ComputeRules(policy, nil) {
 			for _, res := range testCase.Test.Results {
 				if res.IsValidatingAdmissionPolicy {
 					continue
@@ -129,7 +132,7 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool, auditWa
 	var validPolicies []kyvernov1.PolicyInterface
 	for _, pol := range policies {
 		// TODO we should return this info to the caller
-		_, err := policyvalidation.Validate(pol, nil, nil, true, config.KyvernoUserName(config.KyvernoServiceAccountName()))
+		_, err := policyvalidation.Validate(context.TODO(), pol, nil, nil, true, config.KyvernoUserName(config.KyvernoServiceAccountName()))
 		if err != nil {
 			log.Log.Error(err, "skipping invalid policy", "name", pol.GetName())
 			continue
@@ -143,6 +146,7 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool, auditWa
 		processor := processor.PolicyProcessor{
 			Store:                     &store,
 			Policies:                  validPolicies,
+			Exceptions:                exceptions,
 			Resource:                  *resource,
 			MutateLogPath:             "",
 			Variables:                 vars,
@@ -158,7 +162,7 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool, auditWa
 		}
 		ers, err := processor.ApplyPoliciesOnResource()
 		if err != nil {
-			return nil, fmt.Errorf("failed to apply policies on resource %v (%w)", resource.GetName(), err)
+			return nil, nil, fmt.Errorf("failed to apply policies on resource %v (%w)", resource.GetName(), err)
 		}
 		engineResponses = append(engineResponses, ers...)
 	}
@@ -171,9 +175,9 @@ func runTest(out io.Writer, testCase test.TestCase, registryAccess bool, auditWa
 		}
 		ers, err := processor.ApplyPolicyOnResource()
 		if err != nil {
-			return nil, fmt.Errorf("failed to apply policies on resource %s (%w)", resource.GetName(), err)
+			return nil, nil, fmt.Errorf("failed to apply policies on resource %s (%w)", resource.GetName(), err)
 		}
 		engineResponses = append(engineResponses, ers...)
 	}
-	return engineResponses, nil
+	return engineResponses, validPolicies, nil
 }
@@ -2,6 +2,7 @@ package exception
 
 import (
 	"fmt"
+	"os"
 
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
@@ -43,3 +44,20 @@ func Load(content []byte) ([]*kyvernov2.PolicyException, error) {
 	}
 	return exceptions, nil
 }
+
+// LoadFiles loads the policy exceptions contained in the given files.
+func LoadFiles(paths ...string) ([]*kyvernov2.PolicyException, error) {
+	var exceptions []*kyvernov2.PolicyException
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		loaded, err := Load(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load policy exceptions from %s (%w)", path, err)
+		}
+		exceptions = append(exceptions, loaded...)
+	}
+	return exceptions, nil
+}
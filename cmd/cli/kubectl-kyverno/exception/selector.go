@@ -0,0 +1,24 @@
+package exception
+
+import (
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type selector []*kyvernov2.PolicyException
+
+// Selector returns a policy exception selector listing the given policy exceptions.
+func Selector(exceptions ...*kyvernov2.PolicyException) engineapi.PolicyExceptionSelector {
+	return selector(exceptions)
+}
+
+func (s selector) List(sel labels.Selector) ([]*kyvernov2.PolicyException, error) {
+	var exceptions []*kyvernov2.PolicyException
+	for _, exception := range s {
+		if sel.Matches(labels.Set(exception.GetLabels())) {
+			exceptions = append(exceptions, exception)
+		}
+	}
+	return exceptions, nil
+}
@@ -0,0 +1,22 @@
+package exception
+
+import (
+	"testing"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestSelector(t *testing.T) {
+	labelled := &kyvernov2.PolicyException{ObjectMeta: metav1.ObjectMeta{Name: "labelled", Labels: map[string]string{"team": "payments"}}}
+	other := &kyvernov2.PolicyException{ObjectMeta: metav1.ObjectMeta{Name: "other"}}
+	selector := Selector(labelled, other)
+	exceptions, err := selector.List(labels.Everything())
+	assert.NoError(t, err)
+	assert.Equal(t, []*kyvernov2.PolicyException{labelled, other}, exceptions)
+	exceptions, err = selector.List(labels.SelectorFromSet(labels.Set{"team": "payments"}))
+	assert.NoError(t, err)
+	assert.Equal(t, []*kyvernov2.PolicyException{labelled}, exceptions)
+}
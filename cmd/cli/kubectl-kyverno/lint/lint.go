@@ -0,0 +1,256 @@
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/fix"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// penalties applied to the score of a policy for each finding
+var penalties = map[Severity]int{
+	SeverityError:   25,
+	SeverityWarning: 10,
+	SeverityInfo:    2,
+}
+
+type Check struct {
+	ID          string
+	Description string
+	Severity    Severity
+}
+
+var (
+	CheckInvalidPolicy = Check{
+		ID:          "invalid-policy",
+		Description: "File cannot be loaded as Kyverno policies.",
+		Severity:    SeverityError,
+	}
+	CheckUnresolvableVariables = Check{
+		ID:          "unresolvable-variables",
+		Description: "Policy uses variables that cannot be resolved.",
+		Severity:    SeverityError,
+	}
+	CheckBackgroundRequestVariables = Check{
+		ID:          "background-request-variables",
+		Description: "Policy uses request variables that are not available in background scans without setting background to false.",
+		Severity:    SeverityError,
+	}
+	CheckDeprecatedFields = Check{
+		ID:          "deprecated-fields",
+		Description: "Policy uses deprecated fields or operators.",
+		Severity:    SeverityWarning,
+	}
+	CheckBroadMatch = Check{
+		ID:          "broad-match",
+		Description: "Rule matches all kinds without any name, namespace or selector restriction.",
+		Severity:    SeverityWarning,
+	}
+	CheckAutogenConflict = Check{
+		ID:          "autogen-conflict",
+		Description: "Policy rules conflict with the rules generated for pod controllers.",
+		Severity:    SeverityWarning,
+	}
+)
+
+// Checks lists all checks performed by the linter.
+var Checks = []Check{
+	CheckInvalidPolicy,
+	CheckUnresolvableVariables,
+	CheckBackgroundRequestVariables,
+	CheckDeprecatedFields,
+	CheckBroadMatch,
+	CheckAutogenConflict,
+}
+
+type Finding struct {
+	Check   Check
+	Rule    string
+	Message string
+}
+
+// Result holds the findings of a policy, Policy is nil when the file could not be loaded.
+type Result struct {
+	Path     string
+	Policy   kyvernov1.PolicyInterface
+	Findings []Finding
+	Score    int
+}
+
+// Name returns the name of the policy, prefixed with its namespace for a namespaced policy.
+func (r Result) Name() string {
+	if r.Policy == nil {
+		return ""
+	}
+	if ns := r.Policy.GetNamespace(); ns != "" {
+		return ns + "/" + r.Policy.GetName()
+	}
+	return r.Policy.GetName()
+}
+
+// Invalid returns the result of a file that could not be loaded.
+func Invalid(path string, err error) Result {
+	return Result{
+		Path:     path,
+		Findings: []Finding{{Check: CheckInvalidPolicy, Message: err.Error()}},
+		Score:    0,
+	}
+}
+
+func Lint(policy kyvernov1.PolicyInterface) Result {
+	var findings []Finding
+	findings = append(findings, lintVariables(policy)...)
+	findings = append(findings, lintDeprecations(policy)...)
+	findings = append(findings, lintMatch(policy)...)
+	findings = append(findings, lintAutogen(policy)...)
+	return Result{
+		Policy:   policy,
+		Findings: findings,
+		Score:    Score(findings),
+	}
+}
+
+// Score computes a score between 0 and 100 from the findings of a policy.
+func Score(findings []Finding) int {
+	score := 100
+	for _, finding := range findings {
+		score -= penalties[finding.Check.Severity]
+	}
+	if score < 0 {
+		return 0
+	}
+	return score
+}
+
+func lintVariables(policy kyvernov1.PolicyInterface) []Finding {
+	if err := policyvalidation.ValidateVariables(policy, false); err != nil {
+		return []Finding{{
+			Check:   CheckUnresolvableVariables,
+			Message: err.Error(),
+		}}
+	}
+	if policy.BackgroundProcessingEnabled() {
+		if err := policyvalidation.ValidateVariables(policy, true); err != nil {
+			return []Finding{{
+				Check:   CheckBackgroundRequestVariables,
+				Message: fmt.Sprintf("set spec.background to false: %s", err),
+			}}
+		}
+	}
+	return nil
+}
+
+func lintDeprecations(policy kyvernov1.PolicyInterface) []Finding {
+	messages, _ := fix.FixPolicy(policy.CreateDeepCopy())
+	var findings []Finding
+	for _, message := range messages {
+		findings = append(findings, Finding{
+			Check:   CheckDeprecatedFields,
+			Message: message,
+		})
+	}
+	for _, rule := range policy.GetSpec().Rules {
+		for _, imageVerify := range rule.VerifyImages {
+			for _, attestation := range imageVerify.Attestations {
+				if attestation.PredicateType != "" {
+					findings = append(findings, Finding{
+						Check:   CheckDeprecatedFields,
+						Rule:    rule.Name,
+						Message: fmt.Sprintf("predicateType has been deprecated use 'type: %s' instead of 'predicateType: %s'", attestation.PredicateType, attestation.PredicateType),
+					})
+				}
+			}
+		}
+	}
+	return findings
+}
+
+func isBroad(description kyvernov1.ResourceDescription) bool {
+	if !slices.Contains(description.Kinds, "*") {
+		return false
+	}
+	return len(description.Names) == 0 &&
+		description.Name == "" &&
+		len(description.Namespaces) == 0 &&
+		description.Selector == nil &&
+		description.NamespaceSelector == nil
+}
+
+func lintMatch(policy kyvernov1.PolicyInterface) []Finding {
+	var findings []Finding
+	for _, rule := range policy.GetSpec().Rules {
+		filters := []kyvernov1.ResourceDescription{rule.MatchResources.ResourceDescription}
+		for _, filter := range rule.MatchResources.Any {
+			filters = append(filters, filter.ResourceDescription)
+		}
+		for _, filter := range rule.MatchResources.All {
+			filters = append(filters, filter.ResourceDescription)
+		}
+		for _, filter := range filters {
+			if isBroad(filter) {
+				findings = append(findings, Finding{
+					Check:   CheckBroadMatch,
+					Rule:    rule.Name,
+					Message: "rule matches all kinds, restrict it with names, namespaces or selectors",
+				})
+				break
+			}
+		}
+	}
+	return findings
+}
+
+func lintAutogen(policy kyvernov1.PolicyInterface) []Finding {
+	var findings []Finding
+	spec := policy.GetSpec()
+	rules := autogen.ComputeRules(policy, nil)
+	computed := map[string]int{}
+	matches := map[string]kyvernov1.MatchResources{}
+	for _, rule := range rules {
+		computed[rule.Name]++
+		matches[rule.Name] = rule.MatchResources
+	}
+	for _, rule := range spec.Rules {
+		// a rule using the autogen prefix is dropped, it may be replaced by a generated rule with the same name
+		if match, ok := matches[rule.Name]; !ok || (strings.HasPrefix(rule.Name, "autogen-") && !datautils.DeepEqual(match, rule.MatchResources)) {
+			findings = append(findings, Finding{
+				Check:   CheckAutogenConflict,
+				Rule:    rule.Name,
+				Message: "rule name uses the autogen prefix and the rule is replaced by generated rules",
+			})
+		}
+	}
+	for _, rule := range rules {
+		if computed[rule.Name] > 1 {
+			computed[rule.Name] = 0
+			findings = append(findings, Finding{
+				Check:   CheckAutogenConflict,
+				Rule:    rule.Name,
+				Message: "multiple rules share the same name once autogen rules are generated",
+			})
+		}
+	}
+	if controllers := policy.GetAnnotations()[kyverno.AnnotationAutogenControllers]; controllers != "" && controllers != "none" {
+		if supported := autogen.GetSupportedControllers(spec, nil); len(supported) == 0 {
+			findings = append(findings, Finding{
+				Check:   CheckAutogenConflict,
+				Message: fmt.Sprintf("annotation %s requests autogen for %s but rules do not support autogen", kyverno.AnnotationAutogenControllers, controllers),
+			})
+		}
+	}
+	return findings
+}
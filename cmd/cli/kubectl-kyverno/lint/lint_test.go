@@ -0,0 +1,127 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/format"
+	"github.com/stretchr/testify/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func ids(findings []Finding) []string {
+	var out []string
+	for _, finding := range findings {
+		out = append(out, finding.Check.ID)
+	}
+	return out
+}
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		findings []Finding
+		want     int
+	}{{
+		name: "none",
+		want: 100,
+	}, {
+		name:     "error and warning",
+		findings: []Finding{{Check: CheckUnresolvableVariables}, {Check: CheckBroadMatch}},
+		want:     65,
+	}, {
+		name: "floor",
+		findings: []Finding{
+			{Check: CheckUnresolvableVariables},
+			{Check: CheckUnresolvableVariables},
+			{Check: CheckUnresolvableVariables},
+			{Check: CheckUnresolvableVariables},
+			{Check: CheckUnresolvableVariables},
+		},
+		want: 0,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Score(tt.findings))
+		})
+	}
+}
+
+func TestLintMatch(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{{
+				Name: "broad",
+				MatchResources: kyvernov1.MatchResources{
+					Any: kyvernov1.ResourceFilters{{
+						ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"*"}},
+					}},
+				},
+			}, {
+				Name: "scoped",
+				MatchResources: kyvernov1.MatchResources{
+					Any: kyvernov1.ResourceFilters{{
+						ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"*"}, Namespaces: []string{"default"}},
+					}},
+				},
+			}},
+		},
+	}
+	findings := lintMatch(policy)
+	assert.Equal(t, []string{CheckBroadMatch.ID}, ids(findings))
+	assert.Equal(t, "broad", findings[0].Rule)
+}
+
+func TestLintAutogen(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{{
+				Name: "check",
+				MatchResources: kyvernov1.MatchResources{
+					Any: kyvernov1.ResourceFilters{{
+						ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}},
+					}},
+				},
+				// autogen only generates rules for rules having a validate or mutate statement
+				Validation: kyvernov1.Validation{Message: "label required", RawPattern: &apiextv1.JSON{Raw: []byte(`{"metadata":{"labels":{"app":"?*"}}}`)}},
+			}, {
+				Name: "autogen-check",
+				MatchResources: kyvernov1.MatchResources{
+					Any: kyvernov1.ResourceFilters{{
+						ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}},
+					}},
+				},
+				Validation: kyvernov1.Validation{Message: "label required", RawPattern: &apiextv1.JSON{Raw: []byte(`{"metadata":{"labels":{"app":"?*"}}}`)}},
+			}},
+		},
+	}
+	findings := lintAutogen(policy)
+	assert.Contains(t, ids(findings), CheckAutogenConflict.ID)
+}
+
+func TestWriteSARIF(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	var b bytes.Buffer
+	err := WriteSARIF(&b, Result{
+		Path:     "policy.yaml",
+		Policy:   policy,
+		Findings: []Finding{{Check: CheckBroadMatch, Rule: "rule", Message: "too broad"}},
+	})
+	assert.NoError(t, err)
+	var log format.SARIFLog
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	assert.Len(t, log.Runs, 1)
+	assert.Len(t, log.Runs[0].Tool.Driver.Rules, len(Checks))
+	assert.Len(t, log.Runs[0].Results, 1)
+	result := log.Runs[0].Results[0]
+	assert.Equal(t, CheckBroadMatch.ID, result.RuleID)
+	assert.Equal(t, "warning", result.Level)
+	assert.Equal(t, "policy.yaml", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, "test/rule", result.Locations[0].LogicalLocations[0].FullyQualifiedName)
+}
@@ -0,0 +1,60 @@
+package lint
+
+import (
+	"io"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/format"
+)
+
+func sarifLevel(severity Severity) string {
+	switch severity {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// WriteSARIF writes lint results to the given writer in SARIF format.
+func WriteSARIF(out io.Writer, results ...Result) error {
+	driver := format.NewSARIFDriver()
+	for _, check := range Checks {
+		driver.Rules = append(driver.Rules, format.SARIFRule{
+			ID:                   check.ID,
+			ShortDescription:     format.SARIFMessage{Text: check.Description},
+			DefaultConfiguration: format.SARIFConfiguration{Level: sarifLevel(check.Severity)},
+		})
+	}
+	run := format.SARIFRun{
+		Tool:    format.SARIFTool{Driver: driver},
+		Results: []format.SARIFResult{},
+	}
+	for _, result := range results {
+		for _, finding := range result.Findings {
+			var location format.SARIFLocation
+			if name := result.Name(); name != "" {
+				if finding.Rule != "" {
+					name = name + "/" + finding.Rule
+				}
+				location.LogicalLocations = []format.SARIFLogicalLocation{{
+					FullyQualifiedName: name,
+					Kind:               "policy",
+				}}
+			}
+			if result.Path != "" {
+				location.PhysicalLocation = &format.SARIFPhysicalLocation{
+					ArtifactLocation: format.SARIFArtifactLocation{URI: result.Path},
+				}
+			}
+			run.Results = append(run.Results, format.SARIFResult{
+				RuleID:    finding.Check.ID,
+				Level:     sarifLevel(finding.Check.Severity),
+				Message:   format.SARIFMessage{Text: finding.Message},
+				Locations: []format.SARIFLocation{location},
+			})
+		}
+	}
+	return format.WriteSARIFLog(out, run)
+}
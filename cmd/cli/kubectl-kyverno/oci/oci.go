@@ -0,0 +1,161 @@
+package oci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/authn/github"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/cosign"
+	"github.com/kyverno/kyverno/pkg/images"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+)
+
+const (
+	PolicyConfigMediaType = "application/vnd.cncf.kyverno.config.v1+json"
+	PolicyLayerMediaType  = "application/vnd.cncf.kyverno.policy.layer.v1+yaml"
+	// DefaultRekorURL is the transparency log used to verify signatures.
+	DefaultRekorURL = "https://rekor.sigstore.dev"
+)
+
+// Keychain returns the keychain used to authenticate against OCI registries.
+func Keychain() authn.Keychain {
+	return authn.NewMultiKeychain(
+		authn.DefaultKeychain,
+		github.Keychain,
+		registryclient.AWSKeychain,
+		registryclient.GCPKeychain,
+		registryclient.AzureKeychain,
+	)
+}
+
+// VerifyOptions configures the cosign verification of a policy image signature.
+type VerifyOptions struct {
+	// Key is the public key used to verify the signature, either a path, a KMS uri or a k8s:// secret reference.
+	// Keyless verification is used when the key is not set.
+	Key string
+	// Subject is the expected certificate identity for keyless verification, wildcards are supported.
+	Subject string
+	// Issuer is the expected certificate OIDC issuer for keyless verification, wildcards are supported.
+	Issuer string
+	// RekorURL is the transparency log url, it defaults to DefaultRekorURL.
+	RekorURL string
+	// IgnoreTlog skips the transparency log verification.
+	IgnoreTlog bool
+}
+
+// Enabled returns true if verification is configured.
+func (o VerifyOptions) Enabled() bool {
+	return o.Key != "" || o.Subject != "" || o.Issuer != ""
+}
+
+// Fetch downloads the policies contained in an OCI image.
+// When verification is enabled, the image signature is verified first and the policies are read
+// from the verified digest.
+func Fetch(ctx context.Context, imageRef string, keychain authn.Keychain, verify VerifyOptions) ([]kyvernov1.PolicyInterface, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return nil, fmt.Errorf("parsing image reference: %v", err)
+	}
+	if verify.Enabled() {
+		digest, err := Verify(ctx, ref, keychain, verify)
+		if err != nil {
+			return nil, err
+		}
+		ref = ref.Context().Digest(digest)
+	}
+	rmt, err := remote.Get(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain))
+	if err != nil {
+		return nil, fmt.Errorf("getting image: %v", err)
+	}
+	img, err := rmt.Image()
+	if err != nil {
+		return nil, fmt.Errorf("getting image: %v", err)
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("getting image layers: %v", err)
+	}
+	var policies []kyvernov1.PolicyInterface
+	for _, layer := range layers {
+		mediaType, err := layer.MediaType()
+		if err != nil {
+			return nil, fmt.Errorf("getting layer media type: %v", err)
+		}
+		if mediaType != PolicyLayerMediaType {
+			continue
+		}
+		layerBytes, err := readLayer(layer)
+		if err != nil {
+			return nil, err
+		}
+		layerPolicies, _, err := yamlutils.GetPolicy(layerBytes)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshaling layer blob: %v", err)
+		}
+		policies = append(policies, layerPolicies...)
+	}
+	return policies, nil
+}
+
+// Verify verifies the cosign signature of an image and returns the verified digest.
+func Verify(ctx context.Context, ref name.Reference, keychain authn.Keychain, verify VerifyOptions) (string, error) {
+	if verify.Key == "" && verify.Subject == "" {
+		return "", errors.New("keyless verification requires a certificate subject")
+	}
+	rekorURL := verify.RekorURL
+	if rekorURL == "" {
+		rekorURL = DefaultRekorURL
+	}
+	response, err := cosign.NewVerifier().VerifySignature(ctx, images.Options{
+		ImageRef:   ref.String(),
+		Client:     client{keychain: keychain},
+		Key:        verify.Key,
+		Subject:    verify.Subject,
+		Issuer:     verify.Issuer,
+		RekorURL:   rekorURL,
+		IgnoreTlog: verify.IgnoreTlog,
+		// certificate transparency only applies to keyless signatures
+		IgnoreSCT: verify.Key != "",
+	})
+	if err != nil {
+		return "", fmt.Errorf("verifying image signature: %v", err)
+	}
+	return response.Digest, nil
+}
+
+func readLayer(layer v1.Layer) ([]byte, error) {
+	blob, err := layer.Compressed()
+	if err != nil {
+		return nil, fmt.Errorf("getting layer blob: %v", err)
+	}
+	defer blob.Close()
+	data, err := io.ReadAll(blob)
+	if err != nil {
+		return nil, fmt.Errorf("reading layer blob: %v", err)
+	}
+	return data, nil
+}
+
+// client adapts a keychain to the registry client expected by the cosign verifier.
+type client struct {
+	keychain authn.Keychain
+}
+
+func (c client) Keychain() authn.Keychain {
+	return c.keychain
+}
+
+func (c client) Options(ctx context.Context) ([]remote.Option, error) {
+	return []remote.Option{
+		remote.WithAuthFromKeychain(c.keychain),
+		remote.WithContext(ctx),
+	}, nil
+}
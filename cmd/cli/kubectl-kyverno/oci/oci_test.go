@@ -0,0 +1,65 @@
+package oci
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func pushPolicies(t *testing.T, imageRef string, paths ...string) {
+	img := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	img = mutate.ConfigMediaType(img, PolicyConfigMediaType)
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		img, err = mutate.Append(img, mutate.Addendum{Layer: static.NewLayer(content, PolicyLayerMediaType)})
+		assert.NoError(t, err)
+	}
+	ref, err := name.ParseReference(imageRef)
+	assert.NoError(t, err)
+	assert.NoError(t, remote.Write(ref, img))
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	imageRef := strings.TrimPrefix(server.URL, "http://") + "/kyverno/policies:v1"
+	pushPolicies(t, imageRef, "../_testdata/policies/cpol-pod-requirements.yaml", "../_testdata/policies/pol-pod-requirements.yaml")
+	policies, err := Fetch(context.TODO(), imageRef, authn.DefaultKeychain, VerifyOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, policies, 2)
+	assert.False(t, policies[0].IsNamespaced())
+	assert.Equal(t, "pod-requirements", policies[0].GetName())
+	assert.True(t, policies[1].IsNamespaced())
+}
+
+func TestFetchNotFound(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	imageRef := strings.TrimPrefix(server.URL, "http://") + "/kyverno/policies:v1"
+	_, err := Fetch(context.TODO(), imageRef, authn.DefaultKeychain, VerifyOptions{})
+	assert.ErrorContains(t, err, "getting image")
+}
+
+func TestFetchKeylessWithoutSubject(t *testing.T) {
+	_, err := Fetch(context.TODO(), "ghcr.io/kyverno/policies:v1", authn.DefaultKeychain, VerifyOptions{Issuer: "https://token.actions.githubusercontent.com"})
+	assert.EqualError(t, err, "keyless verification requires a certificate subject")
+}
+
+func TestVerifyOptionsEnabled(t *testing.T) {
+	assert.False(t, VerifyOptions{RekorURL: DefaultRekorURL, IgnoreTlog: true}.Enabled())
+	assert.True(t, VerifyOptions{Key: "cosign.pub"}.Enabled())
+	assert.True(t, VerifyOptions{Subject: "https://github.com/kyverno/policies/*"}.Enabled())
+}
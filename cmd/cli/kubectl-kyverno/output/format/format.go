@@ -0,0 +1,89 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+)
+
+// Format is a machine readable output format for policy results.
+type Format string
+
+const (
+	// Text is the default human readable output, it is handled by the commands themselves.
+	Text Format = "text"
+	// JUnit produces a JUnit XML test report.
+	JUnit Format = "junit"
+	// SARIF produces a SARIF log suitable for code scanning tools.
+	SARIF Format = "sarif"
+	// GitHub produces GitHub Actions workflow annotations.
+	GitHub Format = "github"
+)
+
+// Formats lists the supported formats.
+var Formats = []Format{Text, JUnit, SARIF, GitHub}
+
+// Parse converts a string to a Format.
+func Parse(value string) (Format, error) {
+	for _, format := range Formats {
+		if string(format) == strings.ToLower(value) {
+			return format, nil
+		}
+	}
+	var names []string
+	for _, format := range Formats {
+		names = append(names, string(format))
+	}
+	return "", fmt.Errorf("invalid output format %q, must be one of %s", value, strings.Join(names, ", "))
+}
+
+// Location points to a line in a file.
+type Location struct {
+	Path string
+	Line int
+}
+
+// Result is the result of a policy rule applied to a resource.
+type Result struct {
+	Policy    string
+	Rule      string
+	Kind      string
+	Namespace string
+	Name      string
+	Status    policyreportv1alpha2.PolicyResult
+	Severity  policyreportv1alpha2.PolicySeverity
+	Message   string
+	Location  Location
+}
+
+// RuleID returns the identifier of the policy rule that produced the result.
+func (r Result) RuleID() string {
+	if r.Rule == "" {
+		return r.Policy
+	}
+	return r.Policy + "/" + r.Rule
+}
+
+// Resource returns the resource the result applies to.
+func (r Result) Resource() string {
+	if r.Namespace == "" {
+		return r.Kind + "/" + r.Name
+	}
+	return r.Kind + "/" + r.Namespace + "/" + r.Name
+}
+
+// Write writes results to the given writer in the given format.
+func Write(out io.Writer, format Format, results ...Result) error {
+	switch format {
+	case JUnit:
+		return writeJUnit(out, results...)
+	case SARIF:
+		return writeSARIF(out, results...)
+	case GitHub:
+		return writeGitHub(out, results...)
+	default:
+		return fmt.Errorf("output format %q is not supported", format)
+	}
+}
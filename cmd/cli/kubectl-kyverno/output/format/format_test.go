@@ -0,0 +1,101 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/stretchr/testify/assert"
+)
+
+var results = []Result{{
+	Policy:   "require-labels",
+	Rule:     "check-team",
+	Kind:     "Pod",
+	Name:     "good",
+	Status:   policyreportv1alpha2.StatusPass,
+	Location: Location{Path: "resources.yaml", Line: 1},
+}, {
+	Policy:    "require-labels",
+	Rule:      "check-team",
+	Kind:      "Pod",
+	Namespace: "default",
+	Name:      "bad",
+	Status:    policyreportv1alpha2.StatusFail,
+	Severity:  policyreportv1alpha2.SeverityMedium,
+	Message:   "label 'team' is required",
+	Location:  Location{Path: "resources.yaml", Line: 8},
+}, {
+	Policy:  "disallow-latest",
+	Rule:    "check-tag",
+	Kind:    "Pod",
+	Name:    "bad",
+	Status:  policyreportv1alpha2.StatusWarn,
+	Message: "using latest tag,\nplease pin",
+}, {
+	Policy: "disallow-latest",
+	Rule:   "check-tag",
+	Kind:   "Pod",
+	Name:   "skipped",
+	Status: policyreportv1alpha2.StatusSkip,
+}}
+
+func TestParse(t *testing.T) {
+	format, err := Parse("SARIF")
+	assert.NoError(t, err)
+	assert.Equal(t, SARIF, format)
+	_, err = Parse("xml")
+	assert.EqualError(t, err, `invalid output format "xml", must be one of text, junit, sarif, github`)
+}
+
+func TestWriteText(t *testing.T) {
+	assert.Error(t, Write(&bytes.Buffer{}, Text, results...))
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var out bytes.Buffer
+	assert.NoError(t, Write(&out, JUnit, results...))
+	var report junitTestSuites
+	assert.NoError(t, xml.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, 4, report.Tests)
+	assert.Equal(t, 1, report.Failures)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Len(t, report.Suites, 2)
+	assert.Equal(t, "require-labels", report.Suites[0].Name)
+	failed := report.Suites[0].Cases[1]
+	assert.Equal(t, "require-labels/check-team Pod/default/bad", failed.Name)
+	assert.Equal(t, "resources.yaml", failed.File)
+	assert.Equal(t, 8, failed.Line)
+	assert.NotNil(t, failed.Failure)
+	assert.Equal(t, "label 'team' is required", failed.Failure.Message)
+	assert.Equal(t, "using latest tag,\nplease pin", report.Suites[1].Cases[0].SystemOut)
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var out bytes.Buffer
+	assert.NoError(t, Write(&out, SARIF, results...))
+	var log SARIFLog
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &log))
+	assert.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Len(t, run.Tool.Driver.Rules, 2)
+	assert.Equal(t, "require-labels/check-team", run.Tool.Driver.Rules[0].ID)
+	assert.Equal(t, "warning", run.Tool.Driver.Rules[0].DefaultConfiguration.Level)
+	assert.Len(t, run.Results, 2)
+	assert.Equal(t, "require-labels/check-team", run.Results[0].RuleID)
+	assert.Equal(t, "warning", run.Results[0].Level)
+	location := run.Results[0].Locations[0].PhysicalLocation
+	assert.Equal(t, "resources.yaml", location.ArtifactLocation.URI)
+	assert.Equal(t, 8, location.Region.StartLine)
+	assert.Nil(t, run.Results[1].Locations[0].PhysicalLocation)
+}
+
+func TestWriteGitHub(t *testing.T) {
+	var out bytes.Buffer
+	assert.NoError(t, Write(&out, GitHub, results...))
+	expected := "::error file=resources.yaml,line=8,title=require-labels/check-team::Pod/default/bad: label 'team' is required\n" +
+		"::warning title=disallow-latest/check-tag::Pod/bad: using latest tag,%0Aplease pin\n"
+	assert.Equal(t, expected, out.String())
+}
@@ -0,0 +1,43 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+)
+
+var (
+	githubDataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	githubPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+// writeGitHub writes GitHub Actions workflow commands annotating the input manifests.
+// Failures and errors are reported as errors, warnings as warnings, other results are omitted.
+func writeGitHub(out io.Writer, results ...Result) error {
+	for _, result := range results {
+		var command string
+		switch result.Status {
+		case policyreportv1alpha2.StatusFail, policyreportv1alpha2.StatusError:
+			command = "error"
+		case policyreportv1alpha2.StatusWarn:
+			command = "warning"
+		default:
+			continue
+		}
+		var properties []string
+		if result.Location.Path != "" {
+			properties = append(properties, "file="+githubPropertyEscaper.Replace(result.Location.Path))
+			if result.Location.Line > 0 {
+				properties = append(properties, fmt.Sprintf("line=%d", result.Location.Line))
+			}
+		}
+		properties = append(properties, "title="+githubPropertyEscaper.Replace(result.RuleID()))
+		message := result.Resource() + ": " + result.Message
+		if _, err := fmt.Fprintf(out, "::%s %s::%s\n", command, strings.Join(properties, ","), githubDataEscaper.Replace(message)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
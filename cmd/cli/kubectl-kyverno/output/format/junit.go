@@ -0,0 +1,99 @@
+package format
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnit writes one test suite per policy and one test case per rule and resource.
+// Failed rules are reported as failures, rule errors as errors and warnings as passing
+// test cases with the message in the test case output.
+func writeJUnit(out io.Writer, results ...Result) error {
+	report := junitTestSuites{Name: "kyverno"}
+	index := map[string]int{}
+	for _, result := range results {
+		i, ok := index[result.Policy]
+		if !ok {
+			i = len(report.Suites)
+			index[result.Policy] = i
+			report.Suites = append(report.Suites, junitTestSuite{Name: result.Policy})
+		}
+		suite := &report.Suites[i]
+		testCase := junitTestCase{
+			Name:      fmt.Sprintf("%s %s", result.RuleID(), result.Resource()),
+			ClassName: result.Policy,
+			File:      result.Location.Path,
+			Line:      result.Location.Line,
+		}
+		switch result.Status {
+		case policyreportv1alpha2.StatusFail:
+			testCase.Failure = &junitMessage{Message: result.Message, Type: string(result.Severity), Text: result.Message}
+			suite.Failures++
+		case policyreportv1alpha2.StatusError:
+			testCase.Error = &junitMessage{Message: result.Message, Text: result.Message}
+			suite.Errors++
+		case policyreportv1alpha2.StatusSkip:
+			testCase.Skipped = &junitMessage{Message: result.Message}
+			suite.Skipped++
+		case policyreportv1alpha2.StatusWarn:
+			testCase.SystemOut = result.Message
+		}
+		suite.Tests++
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	for _, suite := range report.Suites {
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Skipped += suite.Skipped
+	}
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(out)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(out)
+	return err
+}
@@ -0,0 +1,149 @@
+package format
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-billy/v5"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+type locatedResource struct {
+	namespace string
+	location  Location
+}
+
+// Locator maps resources to the file and line where they are declared.
+type Locator struct {
+	resources map[string][]locatedResource
+}
+
+// NewLocator indexes the resources declared in the given files or directories.
+// When fs is nil, paths are read from the local file system.
+// Paths that can't be read or parsed are ignored, as are stdin and URLs.
+func NewLocator(fs billy.Filesystem, paths ...string) *Locator {
+	locator := &Locator{resources: map[string][]locatedResource{}}
+	for _, path := range paths {
+		if path == "-" || strings.Contains(path, "://") {
+			continue
+		}
+		if fs == nil {
+			if info, err := os.Stat(path); err == nil && info.IsDir() {
+				locator.addDir(path)
+				continue
+			}
+		}
+		if content, err := readFile(fs, path); err == nil {
+			locator.Add(path, content)
+		}
+	}
+	return locator
+}
+
+func (l *Locator) addDir(dir string) {
+	_ = filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return nil
+		}
+		if ext := filepath.Ext(path); ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+		if content, err := os.ReadFile(path); err == nil { // #nosec G304
+			l.Add(path, content)
+		}
+		return nil
+	})
+}
+
+// Add indexes the resources declared in the given YAML content.
+func (l *Locator) Add(path string, content []byte) {
+	for _, document := range splitDocuments(content) {
+		var object unstructured.Unstructured
+		if err := yaml.Unmarshal(document.content, &object.Object); err != nil || object.Object == nil {
+			continue
+		}
+		location := Location{Path: path, Line: document.line}
+		if object.IsList() {
+			_ = object.EachListItem(func(item runtime.Object) error {
+				if u, ok := item.(*unstructured.Unstructured); ok {
+					l.add(u, location)
+				}
+				return nil
+			})
+			continue
+		}
+		l.add(&object, location)
+	}
+}
+
+func (l *Locator) add(object *unstructured.Unstructured, location Location) {
+	key := object.GetKind() + "/" + object.GetName()
+	l.resources[key] = append(l.resources[key], locatedResource{namespace: object.GetNamespace(), location: location})
+}
+
+// Locate returns the location of a resource.
+// Resources declared without a namespace match any namespace.
+func (l *Locator) Locate(kind, namespace, name string) Location {
+	if l == nil {
+		return Location{}
+	}
+	candidates := l.resources[kind+"/"+name]
+	for _, candidate := range candidates {
+		if candidate.namespace == namespace {
+			return candidate.location
+		}
+	}
+	for _, candidate := range candidates {
+		if candidate.namespace == "" {
+			return candidate.location
+		}
+	}
+	return Location{}
+}
+
+type document struct {
+	line    int
+	content []byte
+}
+
+// splitDocuments splits YAML content into documents, recording the first significant line of each document.
+func splitDocuments(content []byte) []document {
+	var documents []document
+	var current []string
+	start := 0
+	flush := func() {
+		if start != 0 {
+			documents = append(documents, document{line: start, content: []byte(strings.Join(current, "\n"))})
+		}
+		current = nil
+		start = 0
+	}
+	for i, line := range strings.Split(string(content), "\n") {
+		if strings.HasPrefix(line, "---") {
+			flush()
+			continue
+		}
+		current = append(current, line)
+		if trimmed := strings.TrimSpace(line); start == 0 && trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			start = i + 1
+		}
+	}
+	flush()
+	return documents
+}
+
+func readFile(fs billy.Filesystem, path string) ([]byte, error) {
+	if fs == nil {
+		return os.ReadFile(filepath.Clean(path))
+	}
+	file, err := fs.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
@@ -0,0 +1,60 @@
+package format
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/stretchr/testify/assert"
+)
+
+const manifests = `# test resources
+apiVersion: v1
+kind: Pod
+metadata:
+  name: good
+---
+
+apiVersion: v1
+kind: Pod
+metadata:
+  name: bad
+  namespace: prod
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: cm
+`
+
+func TestLocator(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "resources.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(manifests), 0o600))
+	locator := NewLocator(nil, dir, "-", "https://example.com/resources.yaml")
+	assert.Equal(t, Location{Path: path, Line: 2}, locator.Locate("Pod", "default", "good"))
+	assert.Equal(t, Location{Path: path, Line: 8}, locator.Locate("Pod", "prod", "bad"))
+	assert.Equal(t, Location{}, locator.Locate("Pod", "default", "bad"))
+	assert.Equal(t, Location{Path: path, Line: 14}, locator.Locate("ConfigMap", "default", "cm"))
+	assert.Equal(t, Location{}, locator.Locate("Pod", "", "unknown"))
+}
+
+func TestLocatorWithFs(t *testing.T) {
+	fs := memfs.New()
+	file, err := fs.Create("resources.yaml")
+	assert.NoError(t, err)
+	_, err = file.Write([]byte(manifests))
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+	locator := NewLocator(fs, "resources.yaml", "missing.yaml")
+	assert.Equal(t, Location{Path: "resources.yaml", Line: 8}, locator.Locate("Pod", "prod", "bad"))
+}
+
+func TestLocatorNil(t *testing.T) {
+	var locator *Locator
+	assert.Equal(t, Location{}, locator.Locate("Pod", "", "good"))
+}
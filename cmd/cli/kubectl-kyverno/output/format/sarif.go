@@ -0,0 +1,181 @@
+package format
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/version"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+// SARIFLog is the root of a SARIF 2.1.0 log, only the properties used by the CLI are modeled.
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version,omitempty"`
+	Rules          []SARIFRule `json:"rules"`
+}
+
+type SARIFRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     SARIFMessage       `json:"shortDescription"`
+	DefaultConfiguration SARIFConfiguration `json:"defaultConfiguration"`
+	Properties           *SARIFProperties   `json:"properties,omitempty"`
+}
+
+type SARIFConfiguration struct {
+	Level string `json:"level"`
+}
+
+type SARIFProperties struct {
+	Severity string `json:"severity"`
+}
+
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+type SARIFResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   SARIFMessage    `json:"message"`
+	Locations []SARIFLocation `json:"locations,omitempty"`
+}
+
+type SARIFLocation struct {
+	PhysicalLocation *SARIFPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []SARIFLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
+}
+
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type SARIFRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type SARIFLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+// sarifLevel maps a policy severity to a SARIF level, policies without a severity are errors.
+func sarifLevel(severity policyreportv1alpha2.PolicySeverity) string {
+	switch severity {
+	case policyreportv1alpha2.SeverityMedium:
+		return "warning"
+	case policyreportv1alpha2.SeverityLow, policyreportv1alpha2.SeverityInfo:
+		return "note"
+	default:
+		return "error"
+	}
+}
+
+// writeSARIF writes failed, warned and errored results, passing and skipped results are omitted.
+func writeSARIF(out io.Writer, results ...Result) error {
+	driver := NewSARIFDriver()
+	run := SARIFRun{
+		Results: []SARIFResult{},
+	}
+	rules := map[string]struct{}{}
+	for _, result := range results {
+		var level string
+		switch result.Status {
+		case policyreportv1alpha2.StatusFail:
+			level = sarifLevel(result.Severity)
+		case policyreportv1alpha2.StatusWarn:
+			level = "warning"
+		case policyreportv1alpha2.StatusError:
+			level = "error"
+		default:
+			continue
+		}
+		id := result.RuleID()
+		if _, ok := rules[id]; !ok {
+			rules[id] = struct{}{}
+			rule := SARIFRule{
+				ID:                   id,
+				ShortDescription:     SARIFMessage{Text: id},
+				DefaultConfiguration: SARIFConfiguration{Level: sarifLevel(result.Severity)},
+			}
+			if result.Severity != "" {
+				rule.Properties = &SARIFProperties{Severity: string(result.Severity)}
+			}
+			driver.Rules = append(driver.Rules, rule)
+		}
+		location := SARIFLocation{
+			LogicalLocations: []SARIFLogicalLocation{{
+				FullyQualifiedName: result.Resource(),
+				Kind:               "resource",
+			}},
+		}
+		if result.Location.Path != "" {
+			location.PhysicalLocation = &SARIFPhysicalLocation{
+				ArtifactLocation: SARIFArtifactLocation{URI: filepath.ToSlash(result.Location.Path)},
+			}
+			if result.Location.Line > 0 {
+				location.PhysicalLocation.Region = &SARIFRegion{StartLine: result.Location.Line}
+			}
+		}
+		message := result.Message
+		if message == "" {
+			message = string(result.Status)
+		}
+		run.Results = append(run.Results, SARIFResult{
+			RuleID:    id,
+			Level:     level,
+			Message:   SARIFMessage{Text: result.Resource() + ": " + message},
+			Locations: []SARIFLocation{location},
+		})
+	}
+	run.Tool = SARIFTool{Driver: driver}
+	return WriteSARIFLog(out, run)
+}
+
+// NewSARIFDriver returns the description of the kyverno tool, without rules.
+func NewSARIFDriver() SARIFDriver {
+	return SARIFDriver{
+		Name:           "kyverno",
+		InformationURI: "https://kyverno.io",
+		Version:        version.Version(),
+		Rules:          []SARIFRule{},
+	}
+}
+
+// WriteSARIFLog writes a SARIF log made of the given runs.
+func WriteSARIFLog(out io.Writer, runs ...SARIFRun) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(SARIFLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    runs,
+	})
+}
@@ -0,0 +1,37 @@
+package path
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+
+	gitutils "github.com/kyverno/kyverno/pkg/utils/git"
+)
+
+// ValidateDirs returns an error if no directory is given.
+func ValidateDirs(dirs ...string) error {
+	if len(dirs) == 0 {
+		return errors.New("at least one directory is required")
+	}
+	return nil
+}
+
+// FindYamls walks the given directories and returns the yaml files found, in walk order.
+func FindYamls(dirs ...string) ([]string, error) {
+	var files []string
+	for _, dir := range dirs {
+		err := filepath.Walk(dir, func(file string, info fs.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if gitutils.IsYaml(info) {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
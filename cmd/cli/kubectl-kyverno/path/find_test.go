@@ -0,0 +1,40 @@
+package path
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestValidateDirs(t *testing.T) {
+	if err := ValidateDirs(); err == nil {
+		t.Error("ValidateDirs() expected an error")
+	}
+	if err := ValidateDirs("."); err != nil {
+		t.Errorf("ValidateDirs() unexpected error = %v", err)
+	}
+}
+
+func TestFindYamls(t *testing.T) {
+	dir := t.TempDir()
+	for _, file := range []string{"a.yaml", "b.txt", "sub/c.yml"} {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, file)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, file), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := FindYamls(dir, filepath.Join(dir, "sub"))
+	if err != nil {
+		t.Fatalf("FindYamls() unexpected error = %v", err)
+	}
+	want := []string{filepath.Join(dir, "a.yaml"), filepath.Join(dir, "sub/c.yml"), filepath.Join(dir, "sub/c.yml")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindYamls() = %v, want %v", got, want)
+	}
+	if _, err := FindYamls(filepath.Join(dir, "missing")); err == nil {
+		t.Error("FindYamls() expected an error")
+	}
+}
@@ -48,7 +48,7 @@ func handleGeneratePolicy(out io.Writer, store *store.Store, generateResponse *e
 	listKinds := map[schema.GroupVersionResource]string{}
 
 	// Collect items in a potential cloneList to provide list kinds to the fake dynamic client.
-	for _, rule := range autogen.ComputeRules(policyContext.Policy()) {
+	for _, rule := range autogen.ComputeRules(policyContext.Policy(), nil) {
 		if !rule.HasGenerate() || len(rule.Generation.CloneList.Kinds) == 0 {
 			continue
 		}
@@ -96,7 +96,7 @@ func handleGeneratePolicy(out io.Writer, store *store.Store, generateResponse *e
 	var newRuleResponse []engineapi.RuleResponse
 
 	for _, rule := range generateResponse.PolicyResponse.Rules {
-		genResource, err := c.ApplyGeneratePolicy(log.Log.V(2), &policyContext, gr, []string{rule.Name()})
+		genResource, _, err := c.ApplyGeneratePolicy(log.Log.V(2), &policyContext, gr, []string{rule.Name()})
 		if err != nil {
 			return nil, err
 		}
@@ -130,11 +130,14 @@ func initializeMockController(out io.Writer, s *store.Store, gvrToListKind map[s
 		cfg,
 		config.NewDefaultMetricsConfiguration(),
 		jmespath.New(cfg),
+		nil,
 		adapters.Client(client),
 		nil,
 		imageverifycache.DisabledImageVerifyCache(),
 		store.ContextLoaderFactory(s, nil),
 		nil,
+		nil,
+		nil,
 		"",
 	))
 	return c, nil
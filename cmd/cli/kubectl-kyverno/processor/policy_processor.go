@@ -8,10 +8,11 @@ import (
 	"path/filepath"
 	"strings"
 
-	json_patch "github.com/evanphx/json-patch/v5"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/exception"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/log"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
@@ -23,7 +24,6 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/factories"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
-	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	"github.com/kyverno/kyverno/pkg/engine/policycontext"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/registryclient"
@@ -37,11 +37,14 @@ import (
 type PolicyProcessor struct {
 	Store                     *store.Store
 	Policies                  []kyvernov1.PolicyInterface
+	Exceptions                []*kyvernov2.PolicyException
 	Resource                  unstructured.Unstructured
 	MutateLogPath             string
 	MutateLogPathIsDir        bool
 	Variables                 *variables.Variables
 	UserInfo                  *kyvernov1beta1.RequestInfo
+	Operation                 kyvernov1.AdmissionOperation
+	OldResource               *unstructured.Unstructured
 	PolicyReport              bool
 	NamespaceSelectorMap      map[string]map[string]string
 	Stdin                     bool
@@ -64,15 +67,22 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 	if p.Client != nil {
 		client = adapters.Client(p.Client)
 	}
+	var exceptionSelector engineapi.PolicyExceptionSelector
+	if len(p.Exceptions) != 0 {
+		exceptionSelector = exception.Selector(p.Exceptions...)
+	}
 
 	eng := engine.NewEngine(
 		cfg,
 		config.NewDefaultMetricsConfiguration(),
 		jmespath.New(cfg),
+		nil,
 		client,
 		factories.DefaultRegistryClientFactory(adapters.RegistryClient(p.RegistryClient), nil),
 		imageverifycache.DisabledImageVerifyCache(),
 		store.ContextLoaderFactory(p.Store, nil),
+		exceptionSelector,
+		nil,
 		nil,
 		"",
 	)
@@ -136,17 +146,15 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 			patches = append(annotationPatches, patches...)
 		}
 		if len(patches) != 0 {
-			patch := jsonutils.JoinPatches(patch.ConvertPatches(patches...)...)
-			decoded, err := json_patch.DecodePatch(patch)
-			if err != nil {
-				return responses, err
+			builder := jsonutils.NewPatchBuilder()
+			for _, patch := range patches {
+				builder.Operation(patch.Operation, patch.Path, patch.Value)
 			}
-			options := &json_patch.ApplyOptions{SupportNegativeIndices: true, AllowMissingPathOnRemove: true, EnsurePathExistsOnAdd: true}
 			resourceBytes, err := verifyImageResponse.PatchedResource.MarshalJSON()
 			if err != nil {
 				return responses, err
 			}
-			patchedResourceBytes, err := decoded.ApplyWithOptions(resourceBytes, options)
+			patchedResourceBytes, err := builder.Apply(resourceBytes)
 			if err != nil {
 				return responses, err
 			}
@@ -224,6 +232,9 @@ func (p *PolicyProcessor) makePolicyContext(
 	case "UPDATE":
 		operation = kyvernov1.Update
 	}
+	if p.Operation != "" {
+		operation = p.Operation
+	}
 	policyContext, err := engine.NewPolicyContext(
 		jp,
 		resource,
@@ -237,6 +248,9 @@ func (p *PolicyProcessor) makePolicyContext(
 	}
 	if operation == kyvernov1.Update {
 		resource := resource.DeepCopy()
+		if p.OldResource != nil {
+			resource = p.OldResource.DeepCopy()
+		}
 		policyContext = policyContext.WithOldResource(*resource)
 		if err := policyContext.JSONContext().AddOldResource(resource.Object); err != nil {
 			return nil, fmt.Errorf("failed to update old resource in json context (%w)", err)
@@ -253,6 +267,10 @@ func (p *PolicyProcessor) makePolicyContext(
 		WithNamespaceLabels(namespaceLabels).
 		WithResourceKind(gvk, subresource)
 	for key, value := range resourceValues {
+		// the simulated operation takes precedence over the one from values
+		if p.Operation != "" && key == "request.operation" {
+			continue
+		}
 		err = policyContext.JSONContext().AddVariable(key, value)
 		if err != nil {
 			log.Log.Error(err, "failed to add variable to context", "key", key, "value", value)
@@ -36,7 +36,7 @@ func (rc *ResultCounts) addEngineResponse(auditWarn bool, response engineapi.Eng
 		}
 		policy := genericPolicy.GetPolicy().(kyvernov1.PolicyInterface)
 		scored := annotations.Scored(policy.GetAnnotations())
-		for _, rule := range autogen.ComputeRules(policy) {
+		for _, rule := range autogen.ComputeRules(policy, nil) {
 			if rule.HasValidate() || rule.HasVerifyImageChecks() || rule.HasVerifyImages() {
 				ruleFoundInEngineResponse := false
 				for _, valResponseRule := range response.PolicyResponse.Rules {
@@ -78,7 +78,7 @@ func (rc *ResultCounts) addGenerateResponse(auditWarn bool, resPath string, resp
 		return
 	}
 	policy := genericPolicy.GetPolicy().(kyvernov1.PolicyInterface)
-	for _, policyRule := range autogen.ComputeRules(policy) {
+	for _, policyRule := range autogen.ComputeRules(policy, nil) {
 		ruleFoundInEngineResponse := false
 		for _, ruleResponse := range response.PolicyResponse.Rules {
 			if policyRule.Name == ruleResponse.Name() {
@@ -108,7 +108,7 @@ func (rc *ResultCounts) addMutateResponse(resourcePath string, response engineap
 	}
 	policy := genericPolicy.GetPolicy().(kyvernov1.PolicyInterface)
 	var policyHasMutate bool
-	for _, rule := range autogen.ComputeRules(policy) {
+	for _, rule := range autogen.ComputeRules(policy, nil) {
 		if rule.HasMutate() {
 			policyHasMutate = true
 		}
@@ -117,7 +117,7 @@ func (rc *ResultCounts) addMutateResponse(resourcePath string, response engineap
 		return false
 	}
 	printMutatedRes := false
-	for _, policyRule := range autogen.ComputeRules(policy) {
+	for _, policyRule := range autogen.ComputeRules(policy, nil) {
 		ruleFoundInEngineResponse := false
 		for _, mutateResponseRule := range response.PolicyResponse.Rules {
 			if policyRule.Name == mutateResponseRule.Name() {
@@ -0,0 +1,16 @@
+package source
+
+import (
+	"strings"
+)
+
+const OciScheme = "oci://"
+
+func IsOci(in string) bool {
+	return strings.HasPrefix(in, OciScheme)
+}
+
+// OciReference returns the image reference of an oci:// source.
+func OciReference(in string) string {
+	return strings.TrimPrefix(in, OciScheme)
+}
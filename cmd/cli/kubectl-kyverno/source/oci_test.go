@@ -0,0 +1,44 @@
+package source
+
+import "testing"
+
+func TestIsOci(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want bool
+	}{{
+		name: "empty",
+		in:   "",
+		want: false,
+	}, {
+		name: "oci",
+		in:   "oci://ghcr.io/kyverno/policies:v1",
+		want: true,
+	}, {
+		name: "https",
+		in:   "https://github.com/kyverno/policies",
+		want: false,
+	}, {
+		name: "image reference",
+		in:   "ghcr.io/kyverno/policies:v1",
+		want: false,
+	}, {
+		name: "local path",
+		in:   "/oci/kyverno/policies",
+		want: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsOci(tt.in); got != tt.want {
+				t.Errorf("IsOci() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOciReference(t *testing.T) {
+	if got := OciReference("oci://ghcr.io/kyverno/policies:v1"); got != "ghcr.io/kyverno/policies:v1" {
+		t.Errorf("OciReference() = %v, want %v", got, "ghcr.io/kyverno/policies:v1")
+	}
+}
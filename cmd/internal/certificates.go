@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/tls"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// CertRenewer renews and validates the webhook serving certificates
+type CertRenewer interface {
+	tls.CertRenewer
+	tls.CertValidator
+}
+
+// CASecretName returns the name of the secret holding the CA bundle of the webhooks,
+// cert-manager stores the CA in the TLS secret so that the bundle is patched when the certificate is rotated.
+func CASecretName(caSecretName, tlsSecretName string) string {
+	if certificateMode == tls.CertificateModeCertManager {
+		return tlsSecretName
+	}
+	return caSecretName
+}
+
+// NewCertRenewer returns the cert renewer of the configured certificate mode.
+func NewCertRenewer(
+	logger logr.Logger,
+	kubeClient kubernetes.Interface,
+	dynamicClient dynamic.Interface,
+	renewBefore time.Duration,
+	server string,
+	caSecretName string,
+	tlsSecretName string,
+) CertRenewer {
+	logger = logger.WithName("certificates").WithValues("mode", certificateMode)
+	logger.Info("setup certificates...")
+	namespace := config.KyvernoNamespace()
+	commonName := config.KyvernoServiceName()
+	dnsNames := config.DnsNames(commonName, namespace)
+	switch certificateMode {
+	case tls.CertificateModeSelfSigned:
+		return tls.NewCertRenewer(
+			kubeClient.CoreV1().Secrets(namespace),
+			tls.CertRenewalInterval,
+			tls.CAValidityDuration,
+			tls.TLSValidityDuration,
+			renewBefore,
+			server,
+			commonName,
+			dnsNames,
+			namespace,
+			caSecretName,
+			tlsSecretName,
+		)
+	case tls.CertificateModeCertManager:
+		if certManagerIssuerName == "" {
+			checkError(logger, errors.New("certManagerIssuerName is required"), "failed to setup certificates")
+		}
+		if dynamicClient == nil {
+			checkError(logger, errors.New("dynamic client is required"), "failed to setup certificates")
+		}
+		logger.Info("webhook serving certificate is issued by cert-manager", "issuer", certManagerIssuerName, "kind", certManagerIssuerKind)
+		return tls.NewCertManagerRenewer(
+			dynamicClient.Resource(tls.CertificatesResource).Namespace(namespace),
+			kubeClient.CoreV1().Secrets(namespace),
+			tls.TLSValidityDuration,
+			renewBefore,
+			commonName,
+			dnsNames,
+			namespace,
+			tlsSecretName,
+			tls.IssuerRef{
+				Name:  certManagerIssuerName,
+				Kind:  certManagerIssuerKind,
+				Group: certManagerIssuerGroup,
+			},
+		)
+	case tls.CertificateModeCSR:
+		if certificateSignerName == "" {
+			checkError(logger, errors.New("certificateSignerName is required"), "failed to setup certificates")
+		}
+		logger.Info("webhook serving certificate is issued by an external signer", "signer", certificateSignerName)
+		return tls.NewCSRRenewer(
+			kubeClient.CoreV1().Secrets(namespace),
+			kubeClient.CertificatesV1().CertificateSigningRequests(),
+			tls.TLSValidityDuration,
+			renewBefore,
+			commonName,
+			dnsNames,
+			namespace,
+			caSecretName,
+			tlsSecretName,
+			certificateSignerName,
+		)
+	default:
+		checkError(logger, fmt.Errorf("unsupported certificate mode %s", certificateMode), "failed to setup certificates")
+		return nil
+	}
+}
@@ -0,0 +1,80 @@
+package internal
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/tls"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func withCertificatesFlags(t *testing.T, mode, issuerName, signerName string) {
+	t.Helper()
+	previousMode, previousIssuerName, previousSignerName := certificateMode, certManagerIssuerName, certificateSignerName
+	certificateMode, certManagerIssuerName, certificateSignerName = mode, issuerName, signerName
+	t.Cleanup(func() {
+		certificateMode, certManagerIssuerName, certificateSignerName = previousMode, previousIssuerName, previousSignerName
+	})
+}
+
+func TestCASecretName(t *testing.T) {
+	tests := []struct {
+		mode string
+		want string
+	}{{
+		mode: tls.CertificateModeSelfSigned,
+		want: "kyverno-tls-ca",
+	}, {
+		mode: tls.CertificateModeCertManager,
+		want: "kyverno-tls-pair",
+	}, {
+		mode: tls.CertificateModeCSR,
+		want: "kyverno-tls-ca",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			withCertificatesFlags(t, tt.mode, "", "")
+			assert.Equal(t, tt.want, CASecretName("kyverno-tls-ca", "kyverno-tls-pair"))
+		})
+	}
+}
+
+func TestNewCertRenewer(t *testing.T) {
+	tests := []struct {
+		mode       string
+		issuerName string
+		signerName string
+		want       string
+	}{{
+		mode: tls.CertificateModeSelfSigned,
+		want: "*tls.certRenewer",
+	}, {
+		mode:       tls.CertificateModeCertManager,
+		issuerName: "kyverno",
+		want:       "*tls.certManagerRenewer",
+	}, {
+		mode:       tls.CertificateModeCSR,
+		signerName: "example.com/kyverno",
+		want:       "*tls.csrRenewer",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			withCertificatesFlags(t, tt.mode, tt.issuerName, tt.signerName)
+			renewer := NewCertRenewer(
+				logr.Discard(),
+				fake.NewSimpleClientset(),
+				dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()),
+				15*24*time.Hour,
+				"",
+				"kyverno-tls-ca",
+				"kyverno-tls-pair",
+			)
+			assert.Equal(t, tt.want, fmt.Sprintf("%T", renewer))
+		})
+	}
+}
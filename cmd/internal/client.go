@@ -14,6 +14,7 @@ import (
 	kube "github.com/kyverno/kyverno/pkg/clients/kube"
 	kyverno "github.com/kyverno/kyverno/pkg/clients/kyverno"
 	meta "github.com/kyverno/kyverno/pkg/clients/metadata"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
@@ -36,6 +37,18 @@ func createClientConfig(logger logr.Logger) *rest.Config {
 	return clientConfig
 }
 
+func createClientMiddlewares(logger logr.Logger) (*middleware.RateLimits, *middleware.Retries, *middleware.CircuitBreakers, *middleware.AuditLogging) {
+	limits, err := middleware.ParseRateLimits(clientKindRateLimits)
+	checkError(logger, err, "failed to parse client rate limits")
+	retries, err := middleware.ParseRetries(clientKindRetries)
+	checkError(logger, err, "failed to parse client retries")
+	breakers, err := middleware.ParseCircuitBreakers(logger.WithName("circuit-breaker"), clientKindCircuitBreakers)
+	checkError(logger, err, "failed to parse client circuit breakers")
+	audit, err := middleware.ParseAuditLogging(clientKindAuditLogging)
+	checkError(logger, err, "failed to parse client audit logging")
+	return limits, retries, breakers, audit
+}
+
 func createKubernetesClient(logger logr.Logger, opts ...kube.NewOption) kubernetes.Interface {
 	logger = logger.WithName("kube-client")
 	logger.Info("create kube client...", "kubeconfig", kubeconfig, "qps", clientRateLimitQPS, "burst", clientRateLimitBurst)
@@ -91,3 +104,9 @@ func CreateAggregatorClient(logger logr.Logger, opts ...agg.NewOption) aggregato
 	checkError(logger, err, "failed to create aggregator client")
 	return client
 }
+
+func CreateImpersonator(logger logr.Logger, ctx context.Context, resync time.Duration) dclient.Impersonator {
+	logger = logger.WithName("impersonator")
+	logger.Info("create impersonator...", "kubeconfig", kubeconfig, "qps", clientRateLimitQPS, "burst", clientRateLimitBurst)
+	return dclient.NewImpersonator(ctx, createClientConfig(logger), resync)
+}
@@ -11,11 +11,15 @@ type Configuration interface {
 	UsesKubeconfig() bool
 	UsesPolicyExceptions() bool
 	UsesConfigMapCaching() bool
+	UsesRelatedResources() bool
+	UsesOwnerResources() bool
+	UsesContextCallLimits() bool
 	UsesDeferredLoading() bool
 	UsesCosign() bool
 	UsesRegistryClient() bool
 	UsesImageVerifyCache() bool
 	UsesLeaderElection() bool
+	UsesCertificates() bool
 	UsesKyvernoClient() bool
 	UsesDynamicClient() bool
 	UsesApiServerClient() bool
@@ -70,6 +74,24 @@ func WithConfigMapCaching() ConfigurationOption {
 	}
 }
 
+func WithRelatedResources() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesRelatedResources = true
+	}
+}
+
+func WithOwnerResources() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesOwnerResources = true
+	}
+}
+
+func WithContextCallLimits() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesContextCallLimits = true
+	}
+}
+
 func WithDeferredLoading() ConfigurationOption {
 	return func(c *configuration) {
 		c.usesDeferredLoading = true
@@ -100,6 +122,12 @@ func WithLeaderElection() ConfigurationOption {
 	}
 }
 
+func WithCertificates() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesCertificates = true
+	}
+}
+
 func WithKyvernoClient() ConfigurationOption {
 	return func(c *configuration) {
 		c.usesKyvernoClient = true
@@ -145,11 +173,15 @@ type configuration struct {
 	usesKubeconfig           bool
 	usesPolicyExceptions     bool
 	usesConfigMapCaching     bool
+	usesRelatedResources     bool
+	usesOwnerResources       bool
+	usesContextCallLimits    bool
 	usesDeferredLoading      bool
 	usesCosign               bool
 	usesRegistryClient       bool
 	usesImageVerifyCache     bool
 	usesLeaderElection       bool
+	usesCertificates         bool
 	usesKyvernoClient        bool
 	usesDynamicClient        bool
 	usesApiServerClient      bool
@@ -182,6 +214,18 @@ func (c *configuration) UsesConfigMapCaching() bool {
 	return c.usesConfigMapCaching
 }
 
+func (c *configuration) UsesRelatedResources() bool {
+	return c.usesRelatedResources
+}
+
+func (c *configuration) UsesOwnerResources() bool {
+	return c.usesOwnerResources
+}
+
+func (c *configuration) UsesContextCallLimits() bool {
+	return c.usesContextCallLimits
+}
+
 func (c *configuration) UsesDeferredLoading() bool {
 	return c.usesDeferredLoading
 }
@@ -202,6 +246,10 @@ func (c *configuration) UsesLeaderElection() bool {
 	return c.usesLeaderElection
 }
 
+func (c *configuration) UsesCertificates() bool {
+	return c.usesCertificates
+}
+
 func (c *configuration) UsesKyvernoClient() bool {
 	return c.usesKyvernoClient
 }
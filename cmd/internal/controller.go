@@ -9,6 +9,7 @@ import (
 )
 
 type Controller interface {
+	Name() string
 	Run(context.Context, logr.Logger, *sync.WaitGroup)
 }
 
@@ -26,6 +27,10 @@ func NewController(name string, c controllers.Controller, w int) Controller {
 	}
 }
 
+func (c controller) Name() string {
+	return c.name
+}
+
 func (c controller) Run(ctx context.Context, logger logr.Logger, wg *sync.WaitGroup) {
 	wg.Add(1)
 	go func(logger logr.Logger) {
@@ -35,3 +40,22 @@ func (c controller) Run(ctx context.Context, logger logr.Logger, wg *sync.WaitGr
 		c.controller.Run(ctx, c.workers)
 	}(logger.WithValues("name", c.name))
 }
+
+type controllerWithStartup struct {
+	Controller
+	startup func(context.Context, logr.Logger)
+}
+
+// WithStartup returns a controller running startup before starting the given controller. Startup work runs
+// on the replica running the controller, under the lease of the controller when leader election is split per controller.
+func WithStartup(c Controller, startup func(context.Context, logr.Logger)) Controller {
+	return controllerWithStartup{
+		Controller: c,
+		startup:    startup,
+	}
+}
+
+func (c controllerWithStartup) Run(ctx context.Context, logger logr.Logger, wg *sync.WaitGroup) {
+	c.startup(ctx, logger.WithValues("name", c.Name()))
+	c.Controller.Run(ctx, logger, wg)
+}
@@ -3,6 +3,9 @@ package internal
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -10,15 +13,22 @@ import (
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers/exceptionusage"
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/adapters"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	"github.com/kyverno/kyverno/pkg/engine/compiled"
+	"github.com/kyverno/kyverno/pkg/engine/concurrency"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
 	"github.com/kyverno/kyverno/pkg/engine/factories"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/prefetch"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/registryclient"
+	matchutils "github.com/kyverno/kyverno/pkg/utils/match"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 )
@@ -29,6 +39,7 @@ func NewEngine(
 	configuration config.Configuration,
 	metricsConfiguration config.MetricsConfiguration,
 	jp jmespath.Interface,
+	compiledCache compiled.Cache,
 	client dclient.Interface,
 	rclient registryclient.Client,
 	ivCache imageverifycache.Client,
@@ -36,20 +47,44 @@ func NewEngine(
 	kyvernoClient versioned.Interface,
 	secretLister corev1listers.SecretNamespaceLister,
 	apiCallConfig apicall.APICallConfiguration,
+	prefetchCache prefetch.Cache,
+	relatedResourceInformer resolvers.RelatedResourceInformer,
+	contextCallLimiter *concurrency.Limiter,
+	exceptionsSelector engineapi.PolicyExceptionSelector,
+	ownerResolver matchutils.OwnerResolver,
 ) engineapi.Engine {
 	configMapResolver := NewConfigMapResolver(ctx, logger, kubeClient, 15*time.Minute)
-	exceptionsSelector := NewExceptionSelector(ctx, logger, kyvernoClient, 15*time.Minute)
+	exceptionUsage := NewExceptionUsageRecorder(ctx, logger, kyvernoClient)
+	policyBindingResolver := NewPolicyBindingResolver(ctx, logger, kyvernoClient, kubeClient, 15*time.Minute)
+	contextLoaderFactory := func(cmResolver engineapi.ConfigmapResolver) engineapi.ContextLoaderFactory {
+		return factories.DefaultContextLoaderFactory(
+			cmResolver,
+			factories.WithAPICallConfig(apiCallConfig),
+			factories.WithRelatedResourceLister(relatedResourceInformer),
+			factories.WithLimiter(contextCallLimiter),
+			factories.WithPolicyBindingResolver(policyBindingResolver),
+		)
+	}
+	var contextLoader engineapi.ContextLoaderFactory
+	if prefetchCache != nil {
+		contextLoader = prefetchCache.ContextLoaderFactory(configMapResolver, contextLoaderFactory)
+	} else {
+		contextLoader = contextLoaderFactory(configMapResolver)
+	}
 	logger = logger.WithName("engine")
 	logger.Info("setup engine...")
 	return engine.NewEngine(
 		configuration,
 		metricsConfiguration,
 		jp,
+		compiledCache,
 		adapters.Client(client),
 		factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), secretLister),
 		ivCache,
-		factories.DefaultContextLoaderFactory(configMapResolver, factories.WithAPICallConfig(apiCallConfig)),
+		contextLoader,
 		exceptionsSelector,
+		exceptionUsage,
+		ownerResolver,
 		imageSignatureRepository,
 	)
 }
@@ -79,6 +114,164 @@ func NewExceptionSelector(
 	return exceptionsLister
 }
 
+// NewPolicyBindingResolver creates the resolver of the policy bindings overriding the variables of cluster policies
+// per namespace, it returns after the informers have been synced.
+func NewPolicyBindingResolver(
+	ctx context.Context,
+	logger logr.Logger,
+	kyvernoClient versioned.Interface,
+	kubeClient kubernetes.Interface,
+	resyncPeriod time.Duration,
+) engineapi.PolicyBindingResolver {
+	logger = logger.WithName("policy-binding-resolver")
+	logger.Info("setup policy binding resolver...")
+	kyvernoFactory := kyvernoinformer.NewSharedInformerFactory(kyvernoClient, resyncPeriod)
+	kubeFactory := kubeinformers.NewSharedInformerFactory(kubeClient, resyncPeriod)
+	resolver, err := resolvers.NewInformerBasedPolicyBindingResolver(
+		kyvernoFactory.Kyverno().V2alpha1().PolicyBindings().Lister(),
+		kubeFactory.Core().V1().Namespaces().Lister(),
+	)
+	checkError(logger, err, "failed to create policy binding resolver")
+	// start informers and wait for cache sync
+	if !StartInformersAndWaitForCacheSync(ctx, logger, kyvernoFactory, kubeFactory) {
+		checkError(logger, errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+	}
+	return resolver
+}
+
+// NewExceptionUsageRecorder creates the recorder writing the usage of policy exceptions to their status and starts it,
+// nil is returned when policy exceptions are disabled.
+func NewExceptionUsageRecorder(
+	ctx context.Context,
+	logger logr.Logger,
+	kyvernoClient versioned.Interface,
+) engineapi.PolicyExceptionUsageRecorder {
+	if !enablePolicyException {
+		return nil
+	}
+	logger = logger.WithName("exception-usage")
+	logger.Info("setup exception usage recorder...")
+	recorder := exceptionusage.NewController(kyvernoClient)
+	go recorder.Run(ctx, exceptionusage.Workers)
+	return recorder
+}
+
+// NewOwnerResolver creates the resolver walking the owner references chain of resources matched by owner, it creates
+// informers for the kinds configured with the ownerResources flag, starts them and waits for cache sync.
+// Resolving owners of kinds that are not configured fails.
+func NewOwnerResolver(
+	ctx context.Context,
+	logger logr.Logger,
+	client dclient.Interface,
+	resyncPeriod time.Duration,
+) matchutils.OwnerResolver {
+	logger = logger.WithName("owner-resolver").WithValues("ownerResources", ownerResources)
+	logger.Info("setup owner resolver...")
+	kinds, err := parseKinds(ownerResources)
+	checkError(logger, err, "failed to parse owner resources")
+	if len(kinds) == 0 {
+		return nil
+	}
+	resolver, err := resolvers.NewInformerBasedOwnerResolver(
+		client.GetDynamicInterface(),
+		func(gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
+			return client.Discovery().GetGVRFromGVK(gvk)
+		},
+		kinds,
+		resyncPeriod,
+	)
+	checkError(logger, err, "failed to create owner resolver")
+	// start informers and wait for cache sync
+	resolver.Start(ctx.Done())
+	if !CheckCacheSync(logger, resolver.WaitForCacheSync(ctx.Done())) {
+		checkError(logger, errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+	}
+	return resolver
+}
+
+// NewRelatedResourceInformer creates informers for the kinds configured with the relatedResources flag,
+// starts them and waits for cache sync. Listing kinds that are not configured fails.
+func NewRelatedResourceInformer(
+	ctx context.Context,
+	logger logr.Logger,
+	client dclient.Interface,
+	resyncPeriod time.Duration,
+) resolvers.RelatedResourceInformer {
+	logger = logger.WithName("related-resource-informer").WithValues("relatedResources", relatedResources)
+	logger.Info("setup related resource informer...")
+	kinds, err := parseKinds(relatedResources)
+	checkError(logger, err, "failed to parse related resources")
+	informer, err := resolvers.NewInformerBasedRelatedResourceLister(
+		client.GetDynamicInterface(),
+		func(gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
+			return client.Discovery().GetGVRFromGVK(gvk)
+		},
+		kinds,
+		resyncPeriod,
+	)
+	checkError(logger, err, "failed to create related resource informer")
+	// start informers and wait for cache sync
+	informer.Start(ctx.Done())
+	if !CheckCacheSync(logger, informer.WaitForCacheSync(ctx.Done())) {
+		checkError(logger, errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+	}
+	return informer
+}
+
+// NewContextCallLimiter creates the limiter bounding the concurrency of the external calls made to load context entries,
+// with the limits and circuit breaker configured by flags.
+func NewContextCallLimiter(logger logr.Logger) *concurrency.Limiter {
+	logger = logger.WithName("context-call-limiter").WithValues(
+		"maxConcurrentContextCalls", maxConcurrentContextCalls,
+		"maxConcurrentContextCallsPerRule", maxConcurrentContextCallsPerRule,
+		"contextCallCircuitBreaker", contextCallCircuitBreaker,
+	)
+	logger.Info("setup context call limiter...")
+	breaker, err := parseContextCallCircuitBreaker(contextCallCircuitBreaker)
+	checkError(logger, err, "failed to parse context call circuit breaker")
+	return concurrency.NewLimiter(logger, maxConcurrentContextCalls, maxConcurrentContextCallsPerRule, breaker)
+}
+
+func parseContextCallCircuitBreaker(in string) (concurrency.BreakerConfig, error) {
+	in = strings.TrimSpace(in)
+	if in == "" {
+		return concurrency.BreakerConfig{}, nil
+	}
+	failuresValue, cooldownValue, hasCooldown := strings.Cut(in, "/")
+	failures, err := strconv.Atoi(failuresValue)
+	if err != nil || failures <= 0 {
+		return concurrency.BreakerConfig{}, fmt.Errorf("invalid circuit breaker %s, failures must be a positive integer", in)
+	}
+	cooldown := 30 * time.Second
+	if hasCooldown {
+		if cooldown, err = time.ParseDuration(cooldownValue); err != nil || cooldown <= 0 {
+			return concurrency.BreakerConfig{}, fmt.Errorf("invalid circuit breaker %s, cooldown must be a positive duration", in)
+		}
+	}
+	return concurrency.BreakerConfig{Failures: failures, Cooldown: cooldown}, nil
+}
+
+// parseKinds parses a comma separated list of group/version/Kind or version/Kind entries
+func parseKinds(in string) ([]schema.GroupVersionKind, error) {
+	var kinds []schema.GroupVersionKind
+	for _, entry := range strings.Split(in, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		index := strings.LastIndex(entry, "/")
+		if index <= 0 || index == len(entry)-1 {
+			return nil, fmt.Errorf("invalid kind %s, expected group/version/Kind or version/Kind", entry)
+		}
+		gv, err := schema.ParseGroupVersion(entry[:index])
+		if err != nil {
+			return nil, fmt.Errorf("invalid kind %s: %w", entry, err)
+		}
+		kinds = append(kinds, gv.WithKind(entry[index+1:]))
+	}
+	return kinds, nil
+}
+
 func NewConfigMapResolver(
 	ctx context.Context,
 	logger logr.Logger,
@@ -0,0 +1,36 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers/exclusionpolicy"
+)
+
+// StartExclusionPolicies starts the controller compiling the exclusion policies scoped to a Kyverno controller
+// and sets them in the configuration, it returns after the policies have been loaded.
+func StartExclusionPolicies(
+	ctx context.Context,
+	logger logr.Logger,
+	kyvernoClient versioned.Interface,
+	configuration config.Configuration,
+	scope kyvernov2alpha1.ExclusionController,
+	resyncPeriod time.Duration,
+) {
+	logger = logger.WithName("exclusion-policies").WithValues("scope", scope)
+	logger.Info("setup exclusion policies...")
+	factory := kyvernoinformer.NewSharedInformerFactory(kyvernoClient, resyncPeriod)
+	controller := exclusionpolicy.NewController(kyvernoClient, factory.Kyverno().V2alpha1().ExclusionPolicies(), scope)
+	// start informers and wait for cache sync
+	if !StartInformersAndWaitForCacheSync(ctx, logger, factory) {
+		checkError(logger, errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+	}
+	configuration.SetExclusionPolicies(controller)
+	go controller.Run(ctx, exclusionpolicy.Workers)
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/tls"
 	"github.com/kyverno/kyverno/pkg/toggle"
+	tlsutils "github.com/kyverno/kyverno/pkg/utils/tls"
 	"github.com/sigstore/sigstore/pkg/tuf"
 )
 
@@ -23,20 +25,36 @@ var (
 	tracingAddress string
 	tracingPort    string
 	tracingCreds   string
+	tracingRatio   float64
+	tracingTail    bool
 	// metrics
-	otel                 string
-	otelCollector        string
-	metricsPort          string
-	transportCreds       string
-	disableMetricsExport bool
+	otel                   string
+	otelCollector          string
+	otelTemporality        string
+	otelResourceAttributes string
+	metricsPort            string
+	metricsTLSCertFile     string
+	metricsTLSKeyFile      string
+	transportCreds         string
+	disableMetricsExport   bool
 	// kubeconfig
-	kubeconfig           string
-	clientRateLimitQPS   float64
-	clientRateLimitBurst int
+	kubeconfig                string
+	clientRateLimitQPS        float64
+	clientRateLimitBurst      int
+	clientKindRateLimits      string
+	clientKindRetries         string
+	clientKindCircuitBreakers string
+	clientKindAuditLogging    string
 	// engine
 	enablePolicyException  bool
 	exceptionNamespace     string
 	enableConfigMapCaching bool
+	relatedResources       string
+	ownerResources         string
+	// context calls
+	maxConcurrentContextCalls        int
+	maxConcurrentContextCallsPerRule int
+	contextCallCircuitBreaker        string
 	// cosign
 	imageSignatureRepository string
 	enableTUF                bool
@@ -47,13 +65,22 @@ var (
 	allowInsecureRegistry     bool
 	registryCredentialHelpers string
 	// leader election
-	leaderElectionRetryPeriod time.Duration
+	leaderElectionRetryPeriod   time.Duration
+	leaderElectionPerController bool
+	// certificates
+	certificateMode        string
+	certManagerIssuerName  string
+	certManagerIssuerKind  string
+	certManagerIssuerGroup string
+	certificateSignerName  string
 	// cleanupServerPort is the kyverno cleanup server port
 	cleanupServerPort string
 	// image verify cache
 	imageVerifyCacheEnabled     bool
 	imageVerifyCacheTTLDuration time.Duration
 	imageVerifyCacheMaxSize     int64
+	// tls
+	tlsProfile string
 )
 
 func initLoggingFlags() {
@@ -62,6 +89,10 @@ func initLoggingFlags() {
 	checkErr(flag.Set("v", "2"), "failed to init flags")
 }
 
+func initTLSFlags() {
+	flag.StringVar(&tlsProfile, "tlsProfile", string(tlsutils.CurrentProfile()), "Configure the TLS profile of servers and clients, 'strict' enforces TLS 1.2 only with FIPS approved cipher suites and curves only ('strict' is the only profile allowed in fips builds).")
+}
+
 func initProfilingFlags() {
 	flag.BoolVar(&profilingEnabled, "profile", false, "Set this flag to 'true', to enable profiling.")
 	flag.StringVar(&profilingPort, "profilePort", "6060", "Profiling server port, defaults to '6060'.")
@@ -73,13 +104,19 @@ func initTracingFlags() {
 	flag.StringVar(&tracingPort, "tracingPort", "4317", "Tracing receiver port, defaults to '4317'.")
 	flag.StringVar(&tracingAddress, "tracingAddress", "", "Tracing receiver address, defaults to ''.")
 	flag.StringVar(&tracingCreds, "tracingCreds", "", "Set this flag to the CA secret containing the certificate which is used by our Opentelemetry Tracing Client. If empty string is set, means an insecure connection will be used")
+	flag.Float64Var(&tracingRatio, "tracingSamplingRatio", 1, "Ratio of traces retained, between 0 and 1. With tail sampling, only applies to traces without errors or denied requests.")
+	flag.BoolVar(&tracingTail, "tracingTailSampling", false, "Set this flag to 'true' to always retain traces of denied requests and errors, other traces are retained according to the sampling ratio.")
 }
 
 func initMetricsFlags() {
-	flag.StringVar(&otel, "otelConfig", "prometheus", "Set this flag to 'grpc', to enable exporting metrics to an Opentelemetry Collector. The default collector is set to \"prometheus\"")
-	flag.StringVar(&otelCollector, "otelCollector", "opentelemetrycollector.kyverno.svc.cluster.local", "Set this flag to the OpenTelemetry Collector Service Address. Kyverno will try to connect to this on the metrics port.")
+	flag.StringVar(&otel, "otelConfig", "prometheus", "Set this flag to 'grpc', to enable exporting metrics to an Opentelemetry Collector, or to 'prometheus,grpc' to do both. The default collector is set to \"prometheus\"")
+	flag.StringVar(&otelCollector, "otelCollector", "opentelemetrycollector.kyverno.svc.cluster.local", "Set this flag to the OpenTelemetry Collector Service Address. Kyverno will try to connect to this on the metrics port, unless the address has a port.")
+	flag.StringVar(&otelTemporality, "otelTemporality", "cumulative", "Temporality of the metrics exported to the Opentelemetry Collector, can be 'cumulative' or 'delta'.")
+	flag.StringVar(&otelResourceAttributes, "otelResourceAttributes", "", "Comma separated list of key=value resource attributes added to the exported metrics.")
 	flag.StringVar(&transportCreds, "transportCreds", "", "Set this flag to the CA secret containing the certificate which is used by our Opentelemetry Metrics Client. If empty string is set, means an insecure connection will be used")
 	flag.StringVar(&metricsPort, "metricsPort", "8000", "Expose prometheus metrics at the given port, default to 8000.")
+	flag.StringVar(&metricsTLSCertFile, "metricsTLSCertFile", "", "Path to the certificate used to serve prometheus metrics over TLS, required with the strict TLS profile.")
+	flag.StringVar(&metricsTLSKeyFile, "metricsTLSKeyFile", "", "Path to the private key used to serve prometheus metrics over TLS, required with the strict TLS profile.")
 	flag.BoolVar(&disableMetricsExport, "disableMetrics", false, "Set this flag to 'true' to disable metrics.")
 }
 
@@ -87,6 +124,10 @@ func initKubeconfigFlags(qps float64, burst int) {
 	flag.StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
 	flag.Float64Var(&clientRateLimitQPS, "clientRateLimitQPS", qps, "Configure the maximum QPS to the Kubernetes API server from Kyverno. Uses the client default if zero.")
 	flag.IntVar(&clientRateLimitBurst, "clientRateLimitBurst", burst, "Configure the maximum burst for throttle. Uses the client default if zero.")
+	flag.StringVar(&clientKindRateLimits, "clientKindRateLimits", "", "Comma separated list of kind=qps[/burst] budgets throttling the calls made for a resource kind on top of the client rate limit, * applies to all other kinds. Dynamic and metadata clients match resources (policyreports) instead of kinds.")
+	flag.StringVar(&clientKindRetries, "clientKindRetries", "", "Comma separated list of kind=attempts[/delay] policies retrying the calls made for a resource kind when the API server is overloaded, * applies to all other kinds. Dynamic and metadata clients match resources (policyreports) instead of kinds.")
+	flag.StringVar(&clientKindCircuitBreakers, "clientKindCircuitBreakers", "", "Comma separated list of kind=failures[/cooldown] circuit breakers shedding the calls made for a resource kind after consecutive API server overload errors, * applies to all other kinds. Only use it for kinds not critical to admission (PolicyReport, Event). Dynamic and metadata clients match resources (policyreports) instead of kinds.")
+	flag.StringVar(&clientKindAuditLogging, "clientKindAuditLogging", "", "Comma separated list of kind=verbosity levels logging the calls made for a resource kind with their duration and request/response sizes, * applies to all other kinds. Dynamic and metadata clients match resources (policyreports) instead of kinds.")
 }
 
 func initPolicyExceptionsFlags() {
@@ -98,6 +139,20 @@ func initConfigMapCachingFlags() {
 	flag.BoolVar(&enableConfigMapCaching, "enableConfigMapCaching", true, "Enable config maps caching.")
 }
 
+func initRelatedResourcesFlags() {
+	flag.StringVar(&relatedResources, "relatedResources", "", "Comma separated list of kinds (group/version/Kind, v1/Kind for the core group) tracked by informers for relatedResources context entries.")
+}
+
+func initOwnerResourcesFlags() {
+	flag.StringVar(&ownerResources, "ownerResources", "", "Comma separated list of kinds (group/version/Kind, v1/Kind for the core group) tracked by informers to walk the owner references chain of resources matched by owner.")
+}
+
+func initContextCallLimitsFlags() {
+	flag.IntVar(&maxConcurrentContextCalls, "maxConcurrentContextCalls", 256, "Maximum number of concurrent external calls (apiCall services, API server paths and imageRegistry lookups) made to load context entries. A value of 0 disables the limit.")
+	flag.IntVar(&maxConcurrentContextCallsPerRule, "maxConcurrentContextCallsPerRule", 32, "Maximum number of concurrent external calls made to load the context entries of a single rule. A value of 0 disables the limit.")
+	flag.StringVar(&contextCallCircuitBreaker, "contextCallCircuitBreaker", "", "Circuit breaker shedding the external calls made to a destination (service or registry host) after consecutive failures, formatted as failures[/cooldown] (cooldown defaults to 30s). Breakers are disabled when empty.")
+}
+
 func initDeferredLoadingFlags() {
 	flag.Func(toggle.EnableDeferredLoadingFlagName, toggle.EnableDeferredLoadingDescription, toggle.EnableDeferredLoading.Parse)
 }
@@ -123,6 +178,15 @@ func initImageVerifyCacheFlags() {
 
 func initLeaderElectionFlags() {
 	flag.DurationVar(&leaderElectionRetryPeriod, "leaderElectionRetryPeriod", leaderelection.DefaultRetryPeriod, "Configure leader election retry period.")
+	flag.BoolVar(&leaderElectionPerController, "leaderElectionPerController", false, "Set this flag to 'true' to elect a leader for each controller instead of a single leader running all controllers, so that controllers can run on different replicas.")
+}
+
+func initCertificatesFlags() {
+	flag.StringVar(&certificateMode, "certificateMode", tls.CertificateModeSelfSigned, "Configure how the webhook serving certificate is managed, 'self-signed' generates and rotates a self signed CA, 'cert-manager' requests the certificate from a cert-manager issuer, 'csr' requests the certificate from an external signer with a CertificateSigningRequest.")
+	flag.StringVar(&certManagerIssuerName, "certManagerIssuerName", "", "Name of the cert-manager issuer signing the webhook serving certificate, required when certificateMode is 'cert-manager'.")
+	flag.StringVar(&certManagerIssuerKind, "certManagerIssuerKind", "Issuer", "Kind of the cert-manager issuer signing the webhook serving certificate (Issuer or ClusterIssuer).")
+	flag.StringVar(&certManagerIssuerGroup, "certManagerIssuerGroup", "cert-manager.io", "API group of the cert-manager issuer signing the webhook serving certificate.")
+	flag.StringVar(&certificateSignerName, "certificateSignerName", "", "Name of the signer issuing the webhook serving certificate, required when certificateMode is 'csr'. The CA of the signer must be provided in the CA secret.")
 }
 
 func initCleanupFlags() {
@@ -164,6 +228,8 @@ func initFlags(config Configuration, opts ...Option) {
 	}
 	// logging
 	initLoggingFlags()
+	// tls
+	initTLSFlags()
 	// profiling
 	if config.UsesProfiling() {
 		initProfilingFlags()
@@ -188,6 +254,18 @@ func initFlags(config Configuration, opts ...Option) {
 	if config.UsesConfigMapCaching() {
 		initConfigMapCachingFlags()
 	}
+	// related resources
+	if config.UsesRelatedResources() {
+		initRelatedResourcesFlags()
+	}
+	// owner resources
+	if config.UsesOwnerResources() {
+		initOwnerResourcesFlags()
+	}
+	// context call limits
+	if config.UsesContextCallLimits() {
+		initContextCallLimitsFlags()
+	}
 	// deferred loading
 	if config.UsesDeferredLoading() {
 		initDeferredLoadingFlags()
@@ -208,6 +286,10 @@ func initFlags(config Configuration, opts ...Option) {
 	if config.UsesLeaderElection() {
 		initLeaderElectionFlags()
 	}
+	// certificates
+	if config.UsesCertificates() {
+		initCertificatesFlags()
+	}
 
 	initCleanupFlags()
 
@@ -0,0 +1,153 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/leaderelection"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NewLeaderElection creates the leader election of a component, startWork runs on the replica holding the lease.
+// When leader election is split per controller, startWork runs on every replica and the controllers it starts must
+// be wrapped with LeaderElectedControllers so that each one is elected separately. The component lease is still
+// elected in this mode, IsLeader and GetLeader report its holder.
+func NewLeaderElection(
+	logger logr.Logger,
+	name string,
+	namespace string,
+	kubeClient kubernetes.Interface,
+	id string,
+	startWork func(context.Context),
+) (leaderelection.Interface, error) {
+	if !leaderElectionPerController {
+		return leaderelection.New(logger, name, namespace, kubeClient, id, leaderElectionRetryPeriod, startWork, nil)
+	}
+	le, err := leaderelection.New(logger, name, namespace, kubeClient, id, leaderElectionRetryPeriod, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &replicaElection{
+		Interface: le,
+		startWork: startWork,
+	}, nil
+}
+
+// LeaderElectedControllers returns the controllers of a component started by its leader election work.
+// When leader election is split per controller, each controller is wrapped in a leader election using
+// a lease named after the component lease and the controller, otherwise controllers are returned unchanged.
+// A controller losing its lease is stopped, the other controllers of the replica keep running. Controllers
+// can't be restarted, the replica exits to compete again for the leases once it doesn't hold any.
+func LeaderElectedControllers(
+	logger logr.Logger,
+	name string,
+	namespace string,
+	kubeClient kubernetes.Interface,
+	id string,
+	controllers ...Controller,
+) []Controller {
+	if !leaderElectionPerController {
+		return controllers
+	}
+	held := &heldLeases{exit: func() { os.Exit(1) }}
+	elected := make([]Controller, 0, len(controllers))
+	for _, controller := range controllers {
+		elected = append(elected, leaderElectedController{
+			logger:     logger,
+			lease:      fmt.Sprintf("%s-%s", name, controller.Name()),
+			namespace:  namespace,
+			kubeClient: kubeClient,
+			id:         id,
+			controller: controller,
+			held:       held,
+		})
+	}
+	return elected
+}
+
+// replicaElection runs the work of a component on every replica while electing the holder of the component lease
+type replicaElection struct {
+	leaderelection.Interface
+	startWork func(context.Context)
+}
+
+func (e *replicaElection) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		e.Interface.Run(ctx)
+	}()
+	e.startWork(ctx)
+	wg.Wait()
+}
+
+// heldLeases counts the controller leases held by a replica
+type heldLeases struct {
+	count atomic.Int32
+	exit  func()
+}
+
+func (h *heldLeases) acquired() {
+	h.count.Add(1)
+}
+
+func (h *heldLeases) lost(logger logr.Logger) {
+	if h.count.Add(-1) == 0 {
+		logger.Info("leadership lost and no lease held, exiting")
+		h.exit()
+	} else {
+		logger.Info("leadership lost, controller stopped")
+	}
+}
+
+// leaderElectedController runs a controller on the replica holding its lease
+type leaderElectedController struct {
+	logger     logr.Logger
+	lease      string
+	namespace  string
+	kubeClient kubernetes.Interface
+	id         string
+	controller Controller
+	held       *heldLeases
+}
+
+func (c leaderElectedController) Name() string {
+	return c.controller.Name()
+}
+
+func (c leaderElectedController) Run(ctx context.Context, logger logr.Logger, wg *sync.WaitGroup) {
+	electionLogger := c.logger.WithValues("lease", c.lease)
+	le, err := leaderelection.New(
+		electionLogger,
+		c.lease,
+		c.namespace,
+		c.kubeClient,
+		c.id,
+		leaderElectionRetryPeriod,
+		func(leaderCtx context.Context) {
+			c.held.acquired()
+			// the leader context is cancelled when the lease is lost, only this controller stops
+			var controllerWg sync.WaitGroup
+			c.controller.Run(leaderCtx, logger, &controllerWg)
+			controllerWg.Wait()
+			if ctx.Err() == nil {
+				c.held.lost(electionLogger)
+			}
+		},
+		nil,
+	)
+	if err != nil {
+		electionLogger.Error(err, "failed to initialize leader election")
+		os.Exit(1)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		le.Run(ctx)
+	}()
+}
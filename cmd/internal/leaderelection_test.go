@@ -0,0 +1,153 @@
+package internal
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	eventually = 10 * time.Second
+	tick       = 20 * time.Millisecond
+)
+
+func withLeaderElectionFlags(t *testing.T, perController bool) {
+	t.Helper()
+	previousPerController, previousRetryPeriod := leaderElectionPerController, leaderElectionRetryPeriod
+	leaderElectionPerController, leaderElectionRetryPeriod = perController, 100*time.Millisecond
+	t.Cleanup(func() {
+		leaderElectionPerController, leaderElectionRetryPeriod = previousPerController, previousRetryPeriod
+	})
+}
+
+// stealLease gives a lease to another replica
+func stealLease(t *testing.T, client kubernetes.Interface, name string) {
+	t.Helper()
+	lease, err := client.CoordinationV1().Leases("kyverno").Get(context.TODO(), name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	lease.Spec.HolderIdentity = ptr.To("other")
+	lease.Spec.LeaseDurationSeconds = ptr.To[int32](3600)
+	lease.Spec.RenewTime = &metav1.MicroTime{Time: time.Now()}
+	_, err = client.CoordinationV1().Leases("kyverno").Update(context.TODO(), lease, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+}
+
+type fakeController struct {
+	name    string
+	running atomic.Bool
+	started atomic.Int32
+}
+
+func (c *fakeController) Name() string {
+	return c.name
+}
+
+func (c *fakeController) Run(ctx context.Context, _ logr.Logger, wg *sync.WaitGroup) {
+	c.started.Add(1)
+	c.running.Store(true)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		c.running.Store(false)
+	}()
+}
+
+func TestNewLeaderElection(t *testing.T) {
+	tests := []struct {
+		name          string
+		perController bool
+		// startWork runs on every replica when leader election is split per controller
+		wantStarted int32
+	}{{
+		name:          "single leader",
+		perController: false,
+		wantStarted:   1,
+	}, {
+		name:          "per controller",
+		perController: true,
+		wantStarted:   2,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withLeaderElectionFlags(t, tt.perController)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			client := fake.NewSimpleClientset()
+			var started atomic.Int32
+			var replicas []interface{ IsLeader() bool }
+			var wg sync.WaitGroup
+			for _, id := range []string{"replica-1", "replica-2"} {
+				le, err := NewLeaderElection(logr.Discard(), "kyverno", "kyverno", client, id, func(ctx context.Context) {
+					started.Add(1)
+					<-ctx.Done()
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, "kyverno", le.Name())
+				assert.Equal(t, id, le.ID())
+				replicas = append(replicas, le)
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					le.Run(ctx)
+				}()
+			}
+			assert.Eventually(t, func() bool { return started.Load() == tt.wantStarted }, eventually, tick)
+			// the component lease is held by exactly one replica in both modes
+			assert.Eventually(t, func() bool { return replicas[0].IsLeader() != replicas[1].IsLeader() }, eventually, tick)
+			cancel()
+			wg.Wait()
+		})
+	}
+}
+
+func TestLeaderElectedControllers(t *testing.T) {
+	t.Run("single leader", func(t *testing.T) {
+		withLeaderElectionFlags(t, false)
+		controllers := []Controller{&fakeController{name: "a"}, &fakeController{name: "b"}}
+		assert.Equal(t, controllers, LeaderElectedControllers(logr.Discard(), "kyverno", "kyverno", fake.NewSimpleClientset(), "replica-1", controllers...))
+	})
+	t.Run("per controller", func(t *testing.T) {
+		withLeaderElectionFlags(t, true)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		client := fake.NewSimpleClientset()
+		a, b := &fakeController{name: "a"}, &fakeController{name: "b"}
+		elected := LeaderElectedControllers(logr.Discard(), "kyverno", "kyverno", client, "replica-1", a, b)
+		assert.Len(t, elected, 2)
+		assert.Equal(t, "a", elected[0].Name())
+		assert.Equal(t, "b", elected[1].Name())
+		var exited atomic.Bool
+		elected[0].(leaderElectedController).held.exit = func() { exited.Store(true) }
+		var wg sync.WaitGroup
+		for _, controller := range elected {
+			controller.Run(ctx, logr.Discard(), &wg)
+		}
+		assert.Eventually(t, func() bool { return a.running.Load() && b.running.Load() }, eventually, tick)
+		for _, name := range []string{"kyverno-a", "kyverno-b"} {
+			lease, err := client.CoordinationV1().Leases("kyverno").Get(ctx, name, metav1.GetOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, "replica-1", *lease.Spec.HolderIdentity)
+		}
+		// losing a lease only stops its controller
+		stealLease(t, client, "kyverno-a")
+		assert.Eventually(t, func() bool { return !a.running.Load() }, eventually, tick)
+		assert.True(t, b.running.Load())
+		assert.False(t, exited.Load())
+		// the replica exits once it doesn't hold any lease
+		stealLease(t, client, "kyverno-b")
+		assert.Eventually(t, func() bool { return !b.running.Load() && exited.Load() }, eventually, tick)
+		assert.Equal(t, int32(1), a.started.Load())
+		cancel()
+		wg.Wait()
+	})
+}
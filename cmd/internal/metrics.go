@@ -2,6 +2,7 @@ package internal
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
@@ -9,13 +10,14 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
+	tlsutils "github.com/kyverno/kyverno/pkg/utils/tls"
 	otlp "go.opentelemetry.io/otel"
 	"k8s.io/client-go/kubernetes"
 )
 
 func SetupMetrics(ctx context.Context, logger logr.Logger, metricsConfiguration config.MetricsConfiguration, kubeClient kubernetes.Interface) (metrics.MetricsConfigManager, context.CancelFunc) {
 	logger = logger.WithName("metrics")
-	logger.Info("setup metrics...", "otel", otel, "port", metricsPort, "collector", otelCollector, "creds", transportCreds)
+	logger.Info("setup metrics...", "otel", otel, "port", metricsPort, "collector", otelCollector, "creds", transportCreds, "temporality", otelTemporality)
 	metricsAddr := ":" + metricsPort
 	metricsConfig, metricsServerMux, metricsPusher, err := metrics.InitMetrics(
 		ctx,
@@ -23,6 +25,8 @@ func SetupMetrics(ctx context.Context, logger logr.Logger, metricsConfiguration
 		otel,
 		metricsAddr,
 		otelCollector,
+		otelTemporality,
+		otelResourceAttributes,
 		metricsConfiguration,
 		transportCreds,
 		kubeClient,
@@ -32,14 +36,18 @@ func SetupMetrics(ctx context.Context, logger logr.Logger, metricsConfiguration
 	// Pass logger to opentelemetry so JSON format is used (when configured)
 	otlp.SetLogger(logger)
 	var cancel context.CancelFunc
-	if otel == "grpc" {
+	if metricsPusher != nil {
 		cancel = func() {
 			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 			defer cancel()
 			metrics.ShutDownController(ctx, metricsPusher)
 		}
 	}
-	if otel == "prometheus" {
+	if metricsServerMux != nil {
+		serveTLS := metricsTLSCertFile != "" || metricsTLSKeyFile != ""
+		if !serveTLS && tlsutils.CurrentProfile() == tlsutils.ProfileStrict {
+			checkError(logger, errors.New("metricsTLSCertFile and metricsTLSKeyFile are required with the strict tls profile"), "failed to enable metrics")
+		}
 		go func() {
 			server := &http.Server{
 				Addr:              metricsAddr,
@@ -50,7 +58,14 @@ func SetupMetrics(ctx context.Context, logger logr.Logger, metricsConfiguration
 				IdleTimeout:       5 * time.Minute,
 				ErrorLog:          logging.StdLogger(logging.WithName("prometheus-server"), ""),
 			}
-			if err := server.ListenAndServe(); err != nil {
+			var err error
+			if serveTLS {
+				server.TLSConfig = tlsutils.ServerConfig()
+				err = server.ListenAndServeTLS(metricsTLSCertFile, metricsTLSKeyFile)
+			} else {
+				err = server.ListenAndServe()
+			}
+			if err != nil {
 				logger.Error(err, "failed to enable metrics", "address", metricsAddr)
 			}
 		}()
@@ -13,7 +13,7 @@ import (
 	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
-func setupRegistryClient(ctx context.Context, logger logr.Logger, client kubernetes.Interface) (registryclient.Client, corev1listers.SecretNamespaceLister) {
+func setupRegistryClient(ctx context.Context, logger logr.Logger, client kubernetes.Interface, configuration config.Configuration) (registryclient.Client, corev1listers.SecretNamespaceLister) {
 	logger = logger.WithName("registry-client").WithValues("secrets", imagePullSecrets, "insecure", allowInsecureRegistry)
 	logger.Info("setup registry client...")
 	factory := kubeinformers.NewSharedInformerFactoryWithOptions(client, resyncPeriod, kubeinformers.WithNamespace(config.KyvernoNamespace()))
@@ -23,6 +23,7 @@ func setupRegistryClient(ctx context.Context, logger logr.Logger, client kuberne
 		checkError(logger, errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
 	}
 	registryOptions := []registryclient.Option{
+		registryclient.WithOutboundTransport(logger.WithName("transport"), configuration),
 		registryclient.WithTracing(),
 	}
 	secrets := strings.Split(imagePullSecrets, ",")
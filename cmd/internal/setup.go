@@ -2,6 +2,7 @@ package internal
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -16,6 +17,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/registryclient"
+	"github.com/kyverno/kyverno/pkg/transport"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
@@ -36,6 +38,7 @@ type SetupResult struct {
 	MetricsConfiguration   config.MetricsConfiguration
 	MetricsManager         metrics.MetricsConfigManager
 	Jp                     jmespath.Interface
+	OutboundTransport      http.RoundTripper
 	KubeClient             kubeclient.UpstreamInterface
 	LeaderElectionClient   kubeclient.UpstreamInterface
 	RegistryClient         registryclient.Client
@@ -54,19 +57,23 @@ func Setup(config Configuration, name string, skipResourceFilters bool) (context
 	printFlagSettings(logger)
 	showWarnings(config, logger)
 	check(logger)
+	setupTLSProfile(logger, config)
 	sdownMaxProcs := setupMaxProcs(logger)
 	setupProfiling(logger)
 	ctx, sdownSignals := setupSignals(logger)
 	client := kubeclient.From(createKubernetesClient(logger), kubeclient.WithTracing())
 	metricsConfiguration := startMetricsConfigController(ctx, logger, client)
 	metricsManager, sdownMetrics := SetupMetrics(ctx, logger, metricsConfiguration, client)
-	client = client.WithMetrics(metricsManager, metrics.KubeClient)
+	reportTLSPosture(logger.WithName("tls"))
+	limits, retries, breakers, audit := createClientMiddlewares(logger)
+	auditLogger := logger.WithName("client-audit")
+	client = client.WithAuditLogging(auditLogger.WithValues("client", metrics.KubeClient), audit).WithMetrics(metricsManager, metrics.KubeClient).WithRateLimiting(limits).WithRetry(retries).WithCircuitBreaker(breakers)
 	configuration := startConfigController(ctx, logger, client, skipResourceFilters)
 	sdownTracing := SetupTracing(logger, name, client)
 	var registryClient registryclient.Client
 	var registrySecretLister corev1listers.SecretNamespaceLister
 	if config.UsesRegistryClient() {
-		registryClient, registrySecretLister = setupRegistryClient(ctx, logger, client)
+		registryClient, registrySecretLister = setupRegistryClient(ctx, logger, client, configuration)
 	}
 	var imageVerifyCache imageverifycache.Client
 	if config.UsesImageVerifyCache() {
@@ -81,15 +88,15 @@ func Setup(config Configuration, name string, skipResourceFilters bool) (context
 	}
 	var kyvernoClient kyvernoclient.UpstreamInterface
 	if config.UsesKyvernoClient() {
-		kyvernoClient = createKyvernoClient(logger, kyvernoclient.WithMetrics(metricsManager, metrics.KyvernoClient), kyvernoclient.WithTracing())
+		kyvernoClient = createKyvernoClient(logger, kyvernoclient.WithAuditLogging(auditLogger.WithValues("client", metrics.KyvernoClient), audit), kyvernoclient.WithMetrics(metricsManager, metrics.KyvernoClient), kyvernoclient.WithRateLimiting(limits), kyvernoclient.WithRetry(retries), kyvernoclient.WithCircuitBreaker(breakers), kyvernoclient.WithTracing())
 	}
 	var dynamicClient dynamicclient.UpstreamInterface
 	if config.UsesDynamicClient() {
-		dynamicClient = createDynamicClient(logger, dynamicclient.WithMetrics(metricsManager, metrics.DynamicClient), dynamicclient.WithTracing())
+		dynamicClient = createDynamicClient(logger, dynamicclient.WithAuditLogging(auditLogger.WithValues("client", metrics.DynamicClient), audit), dynamicclient.WithMetrics(metricsManager, metrics.DynamicClient), dynamicclient.WithRateLimiting(limits), dynamicclient.WithRetry(retries), dynamicclient.WithCircuitBreaker(breakers), dynamicclient.WithTracing())
 	}
 	var apiServerClient apiserverclient.UpstreamInterface
 	if config.UsesApiServerClient() {
-		apiServerClient = createApiServerClient(logger, apiserverclient.WithMetrics(metricsManager, metrics.ApiServerClient), apiserverclient.WithTracing())
+		apiServerClient = createApiServerClient(logger, apiserverclient.WithAuditLogging(auditLogger.WithValues("client", metrics.ApiServerClient), audit), apiserverclient.WithMetrics(metricsManager, metrics.ApiServerClient), apiserverclient.WithRateLimiting(limits), apiserverclient.WithRetry(retries), apiserverclient.WithCircuitBreaker(breakers), apiserverclient.WithTracing())
 	}
 	var dClient dclient.Interface
 	if config.UsesKyvernoDynamicClient() {
@@ -97,7 +104,7 @@ func Setup(config Configuration, name string, skipResourceFilters bool) (context
 	}
 	var metadataClient metadataclient.UpstreamInterface
 	if config.UsesMetadataClient() {
-		metadataClient = createMetadataClient(logger, metadataclient.WithMetrics(metricsManager, metrics.MetadataClient), metadataclient.WithTracing())
+		metadataClient = createMetadataClient(logger, metadataclient.WithAuditLogging(auditLogger.WithValues("client", metrics.MetadataClient), audit), metadataclient.WithMetrics(metricsManager, metrics.MetadataClient), metadataclient.WithRateLimiting(limits), metadataclient.WithRetry(retries), metadataclient.WithCircuitBreaker(breakers), metadataclient.WithTracing())
 	}
 	return ctx,
 		SetupResult{
@@ -106,6 +113,7 @@ func Setup(config Configuration, name string, skipResourceFilters bool) (context
 			MetricsConfiguration:   metricsConfiguration,
 			MetricsManager:         metricsManager,
 			Jp:                     jmespath.New(configuration),
+			OutboundTransport:      transport.New(logger.WithName("transport"), configuration, nil),
 			KubeClient:             client,
 			LeaderElectionClient:   leaderElectionClient,
 			RegistryClient:         registryClient,
@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	tlsutils "github.com/kyverno/kyverno/pkg/utils/tls"
+	otlp "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func setupTLSProfile(logger logr.Logger, config Configuration) {
+	logger = logger.WithName("tls")
+	logger.Info("setup tls profile...", "profile", tlsProfile)
+	checkError(logger, tlsutils.SetProfile(tlsutils.Profile(tlsProfile)), "failed to set tls profile")
+	if tlsutils.CurrentProfile() == tlsutils.ProfileStrict && config.UsesRegistryClient() && allowInsecureRegistry {
+		checkError(logger, errors.New("allowInsecureRegistry can't be enabled with the strict tls profile"), "invalid tls configuration")
+	}
+	checkError(logger, tlsutils.Validate(tlsutils.ServerConfig()), "invalid server tls configuration")
+	checkError(logger, tlsutils.Validate(tlsutils.ClientConfig()), "invalid client tls configuration")
+	posture := tlsutils.CurrentPosture()
+	logger.Info("cryptographic posture", "profile", posture.Profile, "fipsBuild", posture.FIPSBuild, "minVersion", posture.MinVersion, "maxVersion", posture.MaxVersion, "cipherSuites", posture.CipherSuites)
+}
+
+func reportTLSPosture(logger logr.Logger) {
+	meter := otlp.GetMeterProvider().Meter(metrics.MeterName)
+	postureInfo, err := meter.Int64ObservableGauge(
+		"kyverno_tls_posture_info",
+		metric.WithDescription("can be used to track the TLS profile enforced by the servers and clients of the controller, the value is always 1."),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_tls_posture_info")
+		return
+	}
+	if _, err := meter.RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+		posture := tlsutils.CurrentPosture()
+		observer.ObserveInt64(postureInfo, 1, metric.WithAttributes(
+			attribute.String("profile", string(posture.Profile)),
+			attribute.Bool("fips_build", posture.FIPSBuild),
+			attribute.String("min_version", posture.MinVersion),
+			attribute.String("max_version", posture.MaxVersion),
+			attribute.String("cipher_suites", strings.Join(posture.CipherSuites, ",")),
+		))
+		return nil
+	}, postureInfo); err != nil {
+		logger.Error(err, "Failed to register callback")
+	}
+}
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"context"
+	"errors"
 	"net"
 
 	"github.com/go-logr/logr"
@@ -10,14 +11,18 @@ import (
 )
 
 func SetupTracing(logger logr.Logger, name string, kubeClient kubernetes.Interface) context.CancelFunc {
-	logger = logger.WithName("tracing").WithValues("enabled", tracingEnabled, "name", name, "address", tracingAddress, "port", tracingPort, "creds", tracingCreds)
+	logger = logger.WithName("tracing").WithValues("enabled", tracingEnabled, "name", name, "address", tracingAddress, "port", tracingPort, "creds", tracingCreds, "ratio", tracingRatio, "tail", tracingTail)
 	if tracingEnabled {
 		logger.Info("setup tracing...")
+		if tracingRatio < 0 || tracingRatio > 1 {
+			checkError(logger, errors.New("tracing sampling ratio must be between 0 and 1"), "failed to setup tracing")
+		}
 		shutdown, err := tracing.NewTraceConfig(
 			logger,
 			name,
 			net.JoinHostPort(tracingAddress, tracingPort),
 			tracingCreds,
+			tracing.Sampling{Ratio: tracingRatio, Tail: tracingTail},
 			kubeClient,
 		)
 		checkError(logger, err, "failed to setup tracing")
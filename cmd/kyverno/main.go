@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/cmd/internal"
 	"github.com/kyverno/kyverno/pkg/auth/checker"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
@@ -18,29 +19,41 @@ import (
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers/certmanager"
+	"github.com/kyverno/kyverno/pkg/controllers/exceptionapproval"
 	genericloggingcontroller "github.com/kyverno/kyverno/pkg/controllers/generic/logging"
 	genericwebhookcontroller "github.com/kyverno/kyverno/pkg/controllers/generic/webhook"
 	policymetricscontroller "github.com/kyverno/kyverno/pkg/controllers/metrics/policy"
 	policycachecontroller "github.com/kyverno/kyverno/pkg/controllers/policycache"
+	"github.com/kyverno/kyverno/pkg/controllers/selfprotection"
 	vapcontroller "github.com/kyverno/kyverno/pkg/controllers/validatingadmissionpolicy-generate"
 	webhookcontroller "github.com/kyverno/kyverno/pkg/controllers/webhook"
+	"github.com/kyverno/kyverno/pkg/decisionlog"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	"github.com/kyverno/kyverno/pkg/engine/compiled"
+	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/imageverifystamp"
 	"github.com/kyverno/kyverno/pkg/informers"
-	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/policycache"
+	"github.com/kyverno/kyverno/pkg/recorder"
 	"github.com/kyverno/kyverno/pkg/tls"
 	"github.com/kyverno/kyverno/pkg/toggle"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	runtimeutils "github.com/kyverno/kyverno/pkg/utils/runtime"
 	"github.com/kyverno/kyverno/pkg/validation/exception"
 	"github.com/kyverno/kyverno/pkg/webhooks"
+	webhookseffective "github.com/kyverno/kyverno/pkg/webhooks/effective"
+	webhooksevaluate "github.com/kyverno/kyverno/pkg/webhooks/evaluate"
 	webhooksexception "github.com/kyverno/kyverno/pkg/webhooks/exception"
+	webhookspayload "github.com/kyverno/kyverno/pkg/webhooks/payload"
 	webhookspolicy "github.com/kyverno/kyverno/pkg/webhooks/policy"
 	webhooksresource "github.com/kyverno/kyverno/pkg/webhooks/resource"
+	webhookscanary "github.com/kyverno/kyverno/pkg/webhooks/resource/canary"
 	webhookgenerate "github.com/kyverno/kyverno/pkg/webhooks/updaterequest"
+	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiserver "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
@@ -67,6 +80,10 @@ func showWarnings(ctx context.Context, logger logr.Logger) {
 	if toggle.FromContext(ctx).ForceFailurePolicyIgnore() {
 		logger.Info("'ForceFailurePolicyIgnore' is enabled, all policies with policy failures will be set to Ignore")
 	}
+	// log if `shadowMode` flag has been set or not
+	if toggle.FromContext(ctx).ShadowMode() {
+		logger.Info("'ShadowMode' is enabled, admission requests are evaluated but never denied or mutated")
+	}
 }
 
 func sanityChecks(apiserverClient apiserver.Interface) error {
@@ -82,10 +99,13 @@ func createNonLeaderControllers(
 	dynamicClient dclient.Interface,
 	configuration config.Configuration,
 	policyCache policycache.Cache,
+	compiledCache compiled.Cache,
 ) ([]internal.Controller, func(context.Context) error) {
 	policyCacheController := policycachecontroller.NewController(
 		dynamicClient,
 		policyCache,
+		compiledCache,
+		configuration,
 		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
 		kyvernoInformer.Kyverno().V1().Policies(),
 	)
@@ -100,6 +120,17 @@ func createNonLeaderControllers(
 		}
 }
 
+// controllerServiceAccounts returns the usernames of the Kyverno controllers, the ones not configured are omitted
+func controllerServiceAccounts(usernames ...string) []string {
+	serviceAccounts := []string{config.KyvernoUserName(config.KyvernoServiceAccountName())}
+	for _, username := range usernames {
+		if username != "" {
+			serviceAccounts = append(serviceAccounts, username)
+		}
+	}
+	return serviceAccounts
+}
+
 func createrLeaderControllers(
 	generateVAPs bool,
 	admissionReports bool,
@@ -120,9 +151,14 @@ func createrLeaderControllers(
 	webhookServerPort int32,
 	configuration config.Configuration,
 	eventGenerator event.Interface,
+	controllerServiceAccounts []string,
 ) ([]internal.Controller, func(context.Context) error, error) {
 	var leaderControllers []internal.Controller
 
+	configMapResolver, err := resolvers.NewClientBasedResolver(kubeClient)
+	if err != nil {
+		return nil, nil, err
+	}
 	certManager := certmanager.NewController(
 		caInformer,
 		tlsInformer,
@@ -144,6 +180,7 @@ func createrLeaderControllers(
 		caInformer,
 		kubeKyvernoInformer.Coordination().V1().Leases(),
 		kubeInformer.Rbac().V1().ClusterRoles(),
+		configMapResolver,
 		serverIP,
 		int32(webhookTimeout),
 		servicePort,
@@ -184,6 +221,21 @@ func createrLeaderControllers(
 	leaderControllers = append(leaderControllers, internal.NewController(certmanager.ControllerName, certManager, certmanager.Workers))
 	leaderControllers = append(leaderControllers, internal.NewController(webhookcontroller.ControllerName, webhookController, webhookcontroller.Workers))
 	leaderControllers = append(leaderControllers, internal.NewController(exceptionWebhookControllerName, exceptionWebhookController, 1))
+	selfProtectionController := selfprotection.NewController(
+		kyvernoClient,
+		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+		configuration,
+		config.KyvernoNamespace(),
+	)
+	leaderControllers = append(leaderControllers, internal.NewController(selfprotection.ControllerName, selfProtectionController, selfprotection.Workers))
+	exceptionApprovalController := exceptionapproval.NewController(
+		kyvernoClient,
+		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+		configuration,
+		controllerServiceAccounts,
+		internal.ExceptionNamespace(),
+	)
+	leaderControllers = append(leaderControllers, internal.NewController(exceptionapproval.ControllerName, exceptionApprovalController, exceptionapproval.Workers))
 
 	if generateVAPs {
 		checker := checker.NewSelfChecker(kubeClient.AuthorizationV1().SelfSubjectAccessReviews())
@@ -218,8 +270,21 @@ func main() {
 		servicePort                  int
 		webhookServerPort            int
 		backgroundServiceAccountName string
+		reportsServiceAccountName    string
 		maxAPICallResponseLength     int64
 		renewBefore                  time.Duration
+		policyConflictAction         string
+		policyDryRunAction           string
+		policyDryRunSampleSize       int
+		effectivePolicies            bool
+		policyEvaluation             bool
+		payloadScanning              bool
+		imageVerificationStampSecret string
+		decisionLog                  string
+		decisionLogSampling          int
+		admissionRecording           string
+		admissionRecordingSampling   int
+		admissionLatencyBudget       time.Duration
 	)
 	flagset := flag.NewFlagSet("kyverno", flag.ExitOnError)
 	flagset.BoolVar(&dumpPayload, "dumpPayload", false, "Set this flag to activate/deactivate debug mode.")
@@ -232,14 +297,31 @@ func main() {
 	flagset.Func(toggle.ProtectManagedResourcesFlagName, toggle.ProtectManagedResourcesDescription, toggle.ProtectManagedResources.Parse)
 	flagset.Func(toggle.ForceFailurePolicyIgnoreFlagName, toggle.ForceFailurePolicyIgnoreDescription, toggle.ForceFailurePolicyIgnore.Parse)
 	flagset.Func(toggle.GenerateValidatingAdmissionPolicyFlagName, toggle.GenerateValidatingAdmissionPolicyDescription, toggle.GenerateValidatingAdmissionPolicy.Parse)
+	flagset.Func(toggle.ShadowModeFlagName, toggle.ShadowModeDescription, toggle.ShadowMode.Parse)
+	flagset.Func(toggle.ReportUpdateDiffFlagName, toggle.ReportUpdateDiffDescription, toggle.ReportUpdateDiff.Parse)
+	flagset.Func(toggle.AdmissionDeduplicationFlagName, toggle.AdmissionDeduplicationDescription, toggle.AdmissionDeduplication.Parse)
+	flagset.Func(toggle.NamespacedPolicyDelegationFlagName, toggle.NamespacedPolicyDelegationDescription, toggle.NamespacedPolicyDelegation.Parse)
 	flagset.BoolVar(&admissionReports, "admissionReports", true, "Enable or disable admission reports.")
+	flagset.DurationVar(&admissionLatencyBudget, "admissionLatencyBudget", 0, "Latency budget of the Audit policies of an admission request, measured from the admission request, once the budget is exceeded the remaining Audit policies are skipped and recorded as such (0 disables the budget).")
 	flagset.IntVar(&servicePort, "servicePort", 443, "Port used by the Kyverno Service resource and for webhook configurations.")
 	flagset.IntVar(&webhookServerPort, "webhookServerPort", 9443, "Port used by the webhook server.")
 	flagset.StringVar(&backgroundServiceAccountName, "backgroundServiceAccountName", "", "Background service account name.")
+	flagset.StringVar(&reportsServiceAccountName, "reportsServiceAccountName", "", "Reports service account name.")
 	flagset.StringVar(&caSecretName, "caSecretName", "", "Name of the secret containing CA.")
 	flagset.StringVar(&tlsSecretName, "tlsSecretName", "", "Name of the secret containing TLS pair.")
 	flagset.Int64Var(&maxAPICallResponseLength, "maxAPICallResponseLength", 10*1000*1000, "Configure the value of maximum allowed GET response size from API Calls")
 	flagset.DurationVar(&renewBefore, "renewBefore", 15*24*time.Hour, "The certificate renewal time before expiration")
+	flagset.BoolVar(&effectivePolicies, "effectivePolicies", false, "Enable or disable the endpoint listing the policies in effect in a namespace.")
+	flagset.BoolVar(&policyEvaluation, "policyEvaluation", false, "Enable or disable the endpoint evaluating a resource against the policies of the cluster.")
+	flagset.BoolVar(&payloadScanning, "payloadScanning", false, "Enable or disable the endpoint validating JSON payloads against ValidatingPolicies.")
+	flagset.StringVar(&imageVerificationStampSecret, "imageVerificationStampSecret", "", "Name of the secret in the Kyverno namespace holding the HMAC key signing the verified images stamps requested by image verification rules, stamps are not added when empty.")
+	flagset.StringVar(&decisionLog, "decisionLog", "", "Sink admission decisions are written to as JSON lines (stdout, file://<path>, unix://<path> or tcp://<host>:<port>), disabled when empty.")
+	flagset.IntVar(&decisionLogSampling, "decisionLogSampling", 1, "Write one in N allowed admission decisions to the decision log, denied decisions are always written.")
+	flagset.StringVar(&admissionRecording, "admissionRecording", "", "Object storage sampled admission requests and the policies in effect are recorded to for replays (file://<directory> or s3://<bucket>/<prefix>?region=<region>&endpoint=<endpoint>), disabled when empty.")
+	flagset.IntVar(&admissionRecordingSampling, "admissionRecordingSampling", 100, "Record one in N admission requests.")
+	flagset.StringVar(&policyConflictAction, "policyConflictAction", string(webhookspolicy.ConflictActionWarn), "Action taken when a policy duplicates or conflicts with an existing policy at admission time (ignore, warn or reject).")
+	flagset.StringVar(&policyDryRunAction, "policyDryRunAction", string(webhookspolicy.DryRunActionIgnore), "Action taken when a policy fails to evaluate against a sample of existing matching resources at admission time (ignore, warn or reject).")
+	flagset.IntVar(&policyDryRunSampleSize, "policyDryRunSampleSize", 20, "Maximum number of existing resources a policy is evaluated against at admission time.")
 	// config
 	appConfig := internal.NewConfiguration(
 		internal.WithProfiling(),
@@ -248,11 +330,15 @@ func main() {
 		internal.WithKubeconfig(),
 		internal.WithPolicyExceptions(),
 		internal.WithConfigMapCaching(),
+		internal.WithRelatedResources(),
+		internal.WithOwnerResources(),
+		internal.WithContextCallLimits(),
 		internal.WithDeferredLoading(),
 		internal.WithCosign(),
 		internal.WithRegistryClient(),
 		internal.WithImageVerifyCache(),
 		internal.WithLeaderElection(),
+		internal.WithCertificates(),
 		internal.WithKyvernoClient(),
 		internal.WithDynamicClient(),
 		internal.WithKyvernoDynamicClient(),
@@ -272,6 +358,26 @@ func main() {
 		setup.Logger.Error(errors.New("exiting... tlsSecretName is a required flag"), "exiting... tlsSecretName is a required flag")
 		os.Exit(1)
 	}
+	// cert-manager stores the CA in the TLS secret
+	caSecretName = internal.CASecretName(caSecretName, tlsSecretName)
+	switch webhookspolicy.ConflictAction(policyConflictAction) {
+	case webhookspolicy.ConflictActionIgnore, webhookspolicy.ConflictActionWarn, webhookspolicy.ConflictActionReject:
+	default:
+		setup.Logger.Error(errors.New("exiting... policyConflictAction must be one of ignore, warn or reject"), "exiting... policyConflictAction must be one of ignore, warn or reject")
+		os.Exit(1)
+	}
+	switch webhookspolicy.DryRunAction(policyDryRunAction) {
+	case webhookspolicy.DryRunActionIgnore, webhookspolicy.DryRunActionWarn, webhookspolicy.DryRunActionReject:
+	default:
+		setup.Logger.Error(errors.New("exiting... policyDryRunAction must be one of ignore, warn or reject"), "exiting... policyDryRunAction must be one of ignore, warn or reject")
+		os.Exit(1)
+	}
+	if internal.PolicyExceptionEnabled() {
+		if err := exceptionapproval.ValidateConfiguration(setup.Configuration, internal.ExceptionNamespace()); err != nil {
+			setup.Logger.Error(err, "exiting... exception approval can't be enabled")
+			os.Exit(1)
+		}
+	}
 	// check if validating admission policies are registered in the API server
 	generateValidatingAdmissionPolicy := toggle.FromContext(context.TODO()).GenerateValidatingAdmissionPolicy()
 	if generateValidatingAdmissionPolicy {
@@ -287,6 +393,15 @@ func main() {
 		setup.Logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
 		os.Exit(1)
 	}
+	var stampSigner imageverifystamp.Signer
+	if imageVerificationStampSecret != "" {
+		stampSecret := informers.NewSecretInformer(setup.KubeClient, config.KyvernoNamespace(), imageVerificationStampSecret, resyncPeriod)
+		if !informers.StartInformersAndWaitForCacheSync(signalCtx, setup.Logger, stampSecret) {
+			setup.Logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+			os.Exit(1)
+		}
+		stampSigner = imageverifystamp.NewSigner(stampSecret.Lister().Secrets(config.KyvernoNamespace()), imageVerificationStampSecret)
+	}
 	// show version
 	showWarnings(signalCtx, setup.Logger)
 	// THIS IS AN UGLY FIX
@@ -297,25 +412,26 @@ func main() {
 		setup.Logger.Error(err, "sanity checks failed")
 		os.Exit(1)
 	}
+	// exclusion policies
+	internal.StartExclusionPolicies(signalCtx, setup.Logger, setup.KyvernoClient, setup.Configuration, kyvernov2alpha1.ExclusionAdmission, resyncPeriod)
 	// informer factories
 	kubeInformer := kubeinformers.NewSharedInformerFactory(setup.KubeClient, resyncPeriod)
 	kubeKyvernoInformer := kubeinformers.NewSharedInformerFactoryWithOptions(setup.KubeClient, resyncPeriod, kubeinformers.WithNamespace(config.KyvernoNamespace()))
 	kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(setup.KyvernoClient, resyncPeriod)
 	var wg sync.WaitGroup
-	certRenewer := tls.NewCertRenewer(
-		setup.KubeClient.CoreV1().Secrets(config.KyvernoNamespace()),
-		tls.CertRenewalInterval,
-		tls.CAValidityDuration,
-		tls.TLSValidityDuration,
+	certRenewer := internal.NewCertRenewer(
+		setup.Logger,
+		setup.KubeClient,
+		setup.DynamicClient,
 		renewBefore,
 		serverIP,
-		config.KyvernoServiceName(),
-		config.DnsNames(config.KyvernoServiceName(), config.KyvernoNamespace()),
-		config.KyvernoNamespace(),
 		caSecretName,
 		tlsSecretName,
 	)
 	policyCache := policycache.NewCache()
+	// policy expressions are compiled when policies are synced and reused across admission requests
+	compiledCache := compiled.NewCache(setup.Logger.WithName("compiled-cache"))
+	jp := jmespath.NewWithPrecompiled(setup.Configuration, compiledCache)
 	omitEventsValues := strings.Split(omitEvents, ",")
 	if omitEvents == "" {
 		omitEventsValues = []string{}
@@ -326,11 +442,20 @@ func main() {
 		kyvernoInformer.Kyverno().V1().Policies(),
 		maxQueuedEvents,
 		omitEventsValues,
+		setup.Configuration,
 		logging.WithName("EventGenerator"),
 	)
+	canaryRecorder := webhookscanary.NewRecorder(
+		setup.KyvernoClient,
+		logging.WithName("CanaryRecorder"),
+		admissionReports,
+		webhookscanary.DefaultRetention,
+		webhookscanary.DefaultFlushInterval,
+	)
 	// this controller only subscribe to events, nothing is returned...
 	policymetricscontroller.NewController(
 		setup.MetricsManager,
+		setup.Configuration,
 		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
 		kyvernoInformer.Kyverno().V1().Policies(),
 		&wg,
@@ -355,19 +480,33 @@ func main() {
 		certRenewer,
 	)
 	// engine
+	// related resources informer
+	relatedResourceInformer := internal.NewRelatedResourceInformer(signalCtx, setup.Logger, setup.KyvernoDynamicClient, resyncPeriod)
+	// context call limiter
+	contextCallLimiter := internal.NewContextCallLimiter(setup.Logger)
+	// owner resolver
+	ownerResolver := internal.NewOwnerResolver(signalCtx, setup.Logger, setup.KyvernoDynamicClient, resyncPeriod)
+	// policy exceptions
+	exceptionsSelector := internal.NewExceptionSelector(signalCtx, setup.Logger, setup.KyvernoClient, 15*time.Minute)
 	engine := internal.NewEngine(
 		signalCtx,
 		setup.Logger,
 		setup.Configuration,
 		setup.MetricsConfiguration,
-		setup.Jp,
+		jp,
+		compiledCache,
 		setup.KyvernoDynamicClient,
 		setup.RegistryClient,
 		setup.ImageVerifyCacheClient,
 		setup.KubeClient,
 		setup.KyvernoClient,
 		setup.RegistrySecretLister,
-		apicall.NewAPICallConfiguration(maxAPICallResponseLength),
+		apicall.NewAPICallConfiguration(maxAPICallResponseLength, setup.OutboundTransport),
+		nil,
+		relatedResourceInformer,
+		contextCallLimiter,
+		exceptionsSelector,
+		ownerResolver,
 	)
 	// create non leader controllers
 	nonLeaderControllers, nonLeaderBootstrap := createNonLeaderControllers(
@@ -379,6 +518,7 @@ func main() {
 		setup.KyvernoDynamicClient,
 		setup.Configuration,
 		policyCache,
+		compiledCache,
 	)
 	// start informers and wait for cache sync
 	if !internal.StartInformersAndWaitForCacheSync(signalCtx, setup.Logger, kyvernoInformer, kubeInformer, kubeKyvernoInformer) {
@@ -394,14 +534,15 @@ func main() {
 	}
 	// start event generator
 	go eventGenerator.Run(signalCtx, 3, &wg)
+	// start canary recorder
+	go canaryRecorder.Run(signalCtx, &wg)
 	// setup leader election
-	le, err := leaderelection.New(
+	le, err := internal.NewLeaderElection(
 		setup.Logger.WithName("leader-election"),
 		"kyverno",
 		config.KyvernoNamespace(),
 		setup.LeaderElectionClient,
 		config.KyvernoPodName(),
-		internal.LeaderElectionRetryPeriod(),
 		func(ctx context.Context) {
 			logger := setup.Logger.WithName("leader")
 			// create leader factories
@@ -428,6 +569,7 @@ func main() {
 				int32(webhookServerPort),
 				setup.Configuration,
 				eventGenerator,
+				controllerServiceAccounts(backgroundServiceAccountName, reportsServiceAccountName),
 			)
 			if err != nil {
 				logger.Error(err, "failed to create leader controllers")
@@ -445,6 +587,14 @@ func main() {
 				}
 			}
 			// start leader controllers
+			leaderControllers = internal.LeaderElectedControllers(
+				setup.Logger.WithName("leader-election"),
+				"kyverno",
+				config.KyvernoNamespace(),
+				setup.LeaderElectionClient,
+				config.KyvernoPodName(),
+				leaderControllers...,
+			)
 			var wg sync.WaitGroup
 			for _, controller := range leaderControllers {
 				controller.Run(signalCtx, logger.WithName("controllers"), &wg)
@@ -452,7 +602,6 @@ func main() {
 			// wait all controllers shut down
 			wg.Wait()
 		},
-		nil,
 	)
 	if err != nil {
 		setup.Logger.Error(err, "failed to initialize leader election")
@@ -479,6 +628,16 @@ func main() {
 	policyHandlers := webhookspolicy.NewHandlers(
 		setup.KyvernoDynamicClient,
 		backgroundServiceAccountName,
+		kyvernoInformer.Kyverno().V1().ClusterPolicies().Lister(),
+		kyvernoInformer.Kyverno().V1().Policies().Lister(),
+		webhookspolicy.ConflictAction(policyConflictAction),
+		webhookspolicy.DryRunOptions{
+			Action:        webhookspolicy.DryRunAction(policyDryRunAction),
+			SampleSize:    policyDryRunSampleSize,
+			Engine:        engine,
+			Configuration: setup.Configuration,
+			Jp:            jp,
+		},
 	)
 	resourceHandlers := webhooksresource.NewHandlers(
 		engine,
@@ -493,24 +652,104 @@ func main() {
 		kyvernoInformer.Kyverno().V1().Policies(),
 		urgen,
 		eventGenerator,
+		canaryRecorder,
 		admissionReports,
+		admissionLatencyBudget,
 		backgroundServiceAccountName,
-		setup.Jp,
+		time.Duration(webhookTimeout)*time.Second,
+		jp,
+		stampSigner,
+		ownerResolver,
 	)
 	exceptionHandlers := webhooksexception.NewHandlers(exception.ValidationOptions{
 		Enabled:   internal.PolicyExceptionEnabled(),
 		Namespace: internal.ExceptionNamespace(),
 	})
+	var effectivePolicyHandlers webhooks.EffectivePolicyHandlers
+	if effectivePolicies {
+		var exceptionSelector engineapi.PolicyExceptionSelector
+		if internal.PolicyExceptionEnabled() {
+			exceptionLister := kyvernoInformer.Kyverno().V2().PolicyExceptions().Lister()
+			if namespace := internal.ExceptionNamespace(); namespace != "" {
+				exceptionSelector = exceptionLister.PolicyExceptions(namespace)
+			} else {
+				exceptionSelector = exceptionLister
+			}
+		}
+		effectivePolicyHandlers = webhookseffective.NewHandlers(
+			setup.Configuration,
+			kubeInformer.Core().V1().Namespaces().Lister(),
+			kyvernoInformer.Kyverno().V1().ClusterPolicies().Lister(),
+			kyvernoInformer.Kyverno().V1().Policies().Lister(),
+			exceptionSelector,
+			setup.KubeClient.AuthenticationV1().TokenReviews(),
+			setup.KubeClient.AuthorizationV1().SubjectAccessReviews(),
+		)
+	}
+	var policyEvaluationHandlers webhooks.PolicyEvaluationHandlers
+	if policyEvaluation {
+		policyEvaluationHandlers = webhooksevaluate.NewHandlers(
+			engine,
+			webhookutils.NewPolicyContextBuilder(setup.Configuration, jp),
+			setup.KyvernoDynamicClient.Discovery(),
+			kubeInformer.Core().V1().Namespaces().Lister(),
+			kyvernoInformer.Kyverno().V1().ClusterPolicies().Lister(),
+			kyvernoInformer.Kyverno().V1().Policies().Lister(),
+			kubeInformer.Rbac().V1().RoleBindings().Lister(),
+			kubeInformer.Rbac().V1().ClusterRoleBindings().Lister(),
+			setup.KubeClient.AuthenticationV1().TokenReviews(),
+			setup.KubeClient.AuthorizationV1().SubjectAccessReviews(),
+		)
+	}
+	var payloadScanHandlers webhooks.PayloadScanHandlers
+	if payloadScanning {
+		payloadScanHandlers = webhookspayload.NewHandlers(
+			kyvernoInformer.Kyverno().V2alpha1().ValidatingPolicies().Lister(),
+			jp,
+			setup.KubeClient.AuthenticationV1().TokenReviews(),
+			setup.KubeClient.AuthorizationV1().SubjectAccessReviews(),
+		)
+	}
+	var decisionLogger *decisionlog.Logger
+	if decisionLog != "" {
+		sink, err := decisionlog.NewSink(decisionLog)
+		if err != nil {
+			setup.Logger.Error(err, "failed to create decision log sink")
+			os.Exit(1)
+		}
+		defer sink.Close()
+		decisionLogger = decisionlog.NewLogger(logging.WithName("DecisionLog"), sink, decisionLogSampling)
+	}
+	var admissionRecorder *recorder.Recorder
+	if admissionRecording != "" {
+		store, err := recorder.NewStore(signalCtx, admissionRecording)
+		if err != nil {
+			setup.Logger.Error(err, "failed to create admission recording store")
+			os.Exit(1)
+		}
+		admissionRecorder = recorder.NewRecorder(
+			logging.WithName("AdmissionRecorder"),
+			store,
+			admissionRecordingSampling,
+			kubeInformer.Core().V1().Namespaces().Lister(),
+		)
+		go admissionRecorder.Run(signalCtx, &wg)
+	}
 	server := webhooks.NewServer(
 		signalCtx,
 		policyHandlers,
 		resourceHandlers,
 		exceptionHandlers,
+		effectivePolicyHandlers,
+		policyEvaluationHandlers,
+		payloadScanHandlers,
 		setup.Configuration,
 		setup.MetricsManager,
 		webhooks.DebugModeOptions{
 			DumpPayload: dumpPayload,
 		},
+		decisionLogger,
+		admissionRecorder,
 		func() ([]byte, []byte, error) {
 			secret, err := tlsSecret.Lister().Secrets(config.KyvernoNamespace()).Get(tlsSecretName)
 			if err != nil {
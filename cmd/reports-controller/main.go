@@ -4,25 +4,34 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"net/http"
 	"os"
 	"strings"
 	"sync"
 	"time"
 
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/cmd/internal"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
 	admissionreportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/admission"
+	namespaceaggregatereportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/aggregate/namespace"
 	aggregatereportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/aggregate/resource"
 	backgroundscancontroller "github.com/kyverno/kyverno/pkg/controllers/report/background"
+	compliancescancontroller "github.com/kyverno/kyverno/pkg/controllers/report/compliance"
+	reportexportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/export"
 	resourcereportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/resource"
+	simulationcontroller "github.com/kyverno/kyverno/pkg/controllers/report/simulation"
+	compliancesummarycontroller "github.com/kyverno/kyverno/pkg/controllers/report/summary"
+	"github.com/kyverno/kyverno/pkg/engine/adapters"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/prefetch"
 	"github.com/kyverno/kyverno/pkg/event"
-	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	kubeinformers "k8s.io/client-go/informers"
@@ -37,13 +46,22 @@ const (
 
 func createReportControllers(
 	eng engineapi.Engine,
+	prefetchCache prefetch.Cache,
+	relatedResourceInformer resolvers.RelatedResourceInformer,
+	exceptionsSelector engineapi.PolicyExceptionSelector,
 	backgroundScan bool,
 	admissionReports bool,
 	aggregateReports bool,
+	aggregateReportsByOwner bool,
+	keepPodReportDetails bool,
+	aggregateReportsByNamespace bool,
 	policyReports bool,
 	validatingAdmissionPolicyReports bool,
 	reportsChunkSize int,
+	reportsSizeBudget int,
+	admissionReportsPruneOptions admissionreportcontroller.PruneOptions,
 	backgroundScanWorkers int,
+	backgroundScanOptions backgroundscancontroller.ScanOptions,
 	client dclient.Interface,
 	kyvernoClient versioned.Interface,
 	metadataFactory metadatainformers.SharedInformerFactory,
@@ -69,6 +87,8 @@ func createReportControllers(
 			kyvernoV1.Policies(),
 			kyvernoV1.ClusterPolicies(),
 			vapInformer,
+			configuration,
+			backgroundScan && backgroundScanOptions.RolloutHistory,
 		)
 		warmups = append(warmups, func(ctx context.Context) error {
 			return resourceReportController.Warmup(ctx)
@@ -78,7 +98,22 @@ func createReportControllers(
 			resourceReportController,
 			resourcereportcontroller.Workers,
 		))
-		if aggregateReports {
+		if aggregateReports && aggregateReportsByNamespace {
+			ctrls = append(ctrls, internal.NewController(
+				namespaceaggregatereportcontroller.ControllerName,
+				namespaceaggregatereportcontroller.NewController(
+					kyvernoClient,
+					metadataFactory,
+					kyvernoV1.Policies(),
+					kyvernoV1.ClusterPolicies(),
+					resourceReportController,
+					configuration,
+					reportsChunkSize,
+					reportsSizeBudget,
+				),
+				namespaceaggregatereportcontroller.Workers,
+			))
+		} else if aggregateReports {
 			ctrls = append(ctrls, internal.NewController(
 				aggregatereportcontroller.ControllerName,
 				aggregatereportcontroller.NewController(
@@ -88,7 +123,10 @@ func createReportControllers(
 					kyvernoV1.ClusterPolicies(),
 					vapInformer,
 					resourceReportController,
+					configuration,
 					reportsChunkSize,
+					aggregateReportsByOwner,
+					keepPodReportDetails,
 				),
 				aggregatereportcontroller.Workers,
 			))
@@ -100,6 +138,7 @@ func createReportControllers(
 					kyvernoClient,
 					client,
 					metadataFactory,
+					admissionReportsPruneOptions,
 				),
 				admissionreportcontroller.Workers,
 			))
@@ -109,6 +148,9 @@ func createReportControllers(
 				client,
 				kyvernoClient,
 				eng,
+				prefetchCache,
+				relatedResourceInformer,
+				exceptionsSelector,
 				metadataFactory,
 				kyvernoV1.Policies(),
 				kyvernoV1.ClusterPolicies(),
@@ -116,6 +158,7 @@ func createReportControllers(
 				kubeInformer.Core().V1().Namespaces(),
 				resourceReportController,
 				backgroundScanInterval,
+				backgroundScanOptions,
 				configuration,
 				jp,
 				eventGenerator,
@@ -126,6 +169,44 @@ func createReportControllers(
 				backgroundScanController,
 				backgroundScanWorkers),
 			)
+			ctrls = append(ctrls, internal.NewController(
+				compliancescancontroller.ControllerName,
+				compliancescancontroller.NewController(
+					client,
+					kyvernoClient,
+					eng,
+					kyvernoInformer.Kyverno().V2alpha1().ComplianceScans(),
+					kyvernoV1.Policies(),
+					kyvernoV1.ClusterPolicies(),
+					kubeInformer.Core().V1().Namespaces(),
+					configuration,
+					jp,
+				),
+				compliancescancontroller.Workers,
+			))
+			ctrls = append(ctrls, internal.NewController(
+				simulationcontroller.ControllerName,
+				simulationcontroller.NewController(
+					client,
+					kyvernoClient,
+					eng,
+					kyvernoInformer.Kyverno().V2alpha1().PolicySimulations(),
+					kubeInformer.Core().V1().Namespaces(),
+					configuration,
+					jp,
+				),
+				simulationcontroller.Workers,
+			))
+		}
+		if policyReports {
+			ctrls = append(ctrls, internal.NewController(
+				compliancesummarycontroller.ControllerName,
+				compliancesummarycontroller.NewController(
+					kyvernoClient,
+					kyvernoInformer.Kyverno().V2alpha1().ClusterComplianceSummaries(),
+				),
+				compliancesummarycontroller.Workers,
+			))
 		}
 	}
 	return ctrls, func(ctx context.Context) error {
@@ -140,13 +221,22 @@ func createReportControllers(
 
 func createrLeaderControllers(
 	eng engineapi.Engine,
+	prefetchCache prefetch.Cache,
+	relatedResourceInformer resolvers.RelatedResourceInformer,
+	exceptionsSelector engineapi.PolicyExceptionSelector,
 	backgroundScan bool,
 	admissionReports bool,
 	aggregateReports bool,
+	aggregateReportsByOwner bool,
+	keepPodReportDetails bool,
+	aggregateReportsByNamespace bool,
 	policyReports bool,
 	validatingAdmissionPolicyReports bool,
 	reportsChunkSize int,
+	reportsSizeBudget int,
+	admissionReportsPruneOptions admissionreportcontroller.PruneOptions,
 	backgroundScanWorkers int,
+	backgroundScanOptions backgroundscancontroller.ScanOptions,
 	kubeInformer kubeinformers.SharedInformerFactory,
 	kyvernoInformer kyvernoinformer.SharedInformerFactory,
 	metadataInformer metadatainformers.SharedInformerFactory,
@@ -159,13 +249,22 @@ func createrLeaderControllers(
 ) ([]internal.Controller, func(context.Context) error, error) {
 	reportControllers, warmup := createReportControllers(
 		eng,
+		prefetchCache,
+		relatedResourceInformer,
+		exceptionsSelector,
 		backgroundScan,
 		admissionReports,
 		aggregateReports,
+		aggregateReportsByOwner,
+		keepPodReportDetails,
+		aggregateReportsByNamespace,
 		policyReports,
 		validatingAdmissionPolicyReports,
 		reportsChunkSize,
+		reportsSizeBudget,
+		admissionReportsPruneOptions,
 		backgroundScanWorkers,
+		backgroundScanOptions,
 		dynamicClient,
 		kyvernoClient,
 		metadataInformer,
@@ -179,34 +278,110 @@ func createrLeaderControllers(
 	return reportControllers, warmup, nil
 }
 
+func createExportSinks(
+	ctx context.Context,
+	transport http.RoundTripper,
+	webhookURL string,
+	s3Bucket string,
+	s3Prefix string,
+	s3Region string,
+	s3Endpoint string,
+	kafkaURL string,
+	kafkaTopic string,
+) ([]reportexportcontroller.Sink, error) {
+	client := &http.Client{Transport: transport, Timeout: 30 * time.Second}
+	var sinks []reportexportcontroller.Sink
+	if webhookURL != "" {
+		sinks = append(sinks, reportexportcontroller.NewWebhookSink(client, webhookURL))
+	}
+	if s3Bucket != "" {
+		sink, err := reportexportcontroller.NewS3Sink(ctx, client, s3Bucket, s3Prefix, s3Region, s3Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if kafkaURL != "" {
+		if kafkaTopic == "" {
+			return nil, errors.New("a kafka topic is required to export reports to kafka")
+		}
+		sinks = append(sinks, reportexportcontroller.NewKafkaSink(client, kafkaURL, kafkaTopic))
+	}
+	return sinks, nil
+}
+
 func main() {
 	var (
-		backgroundScan                   bool
-		admissionReports                 bool
-		aggregateReports                 bool
-		policyReports                    bool
-		validatingAdmissionPolicyReports bool
-		reportsChunkSize                 int
-		backgroundScanWorkers            int
-		backgroundScanInterval           time.Duration
-		maxQueuedEvents                  int
-		omitEvents                       string
-		skipResourceFilters              bool
-		maxAPICallResponseLength         int64
+		backgroundScan                     bool
+		admissionReports                   bool
+		aggregateReports                   bool
+		aggregateReportsByOwner            bool
+		keepPodReportDetails               bool
+		aggregateReportsByNamespace        bool
+		policyReports                      bool
+		validatingAdmissionPolicyReports   bool
+		reportsChunkSize                   int
+		reportsSizeBudget                  int
+		admissionReportsTTL                time.Duration
+		admissionReportsMaxPerNamespace    int
+		admissionReportsBacklogThreshold   int
+		backgroundScanWorkers              int
+		backgroundScanInterval             time.Duration
+		backgroundScanNamespaceConcurrency int
+		backgroundScanPriorityIntervals    string
+		backgroundScanIncremental          bool
+		backgroundScanRolloutHistory       bool
+		maxQueuedEvents                    int
+		omitEvents                         string
+		skipResourceFilters                bool
+		maxAPICallResponseLength           int64
+		backgroundScanPrefetchTTL          time.Duration
+		exportWebhookURL                   string
+		exportS3Bucket                     string
+		exportS3Prefix                     string
+		exportS3Region                     string
+		exportS3Endpoint                   string
+		exportKafkaURL                     string
+		exportKafkaTopic                   string
+		exportBatchSize                    int
+		exportBatchInterval                time.Duration
+		exportMaxRetries                   int
 	)
 	flagset := flag.NewFlagSet("reports-controller", flag.ExitOnError)
 	flagset.BoolVar(&backgroundScan, "backgroundScan", true, "Enable or disable background scan.")
 	flagset.BoolVar(&admissionReports, "admissionReports", true, "Enable or disable admission reports.")
 	flagset.BoolVar(&aggregateReports, "aggregateReports", true, "Enable or disable aggregated policy reports.")
+	flagset.BoolVar(&aggregateReportsByOwner, "aggregateReportsByOwner", false, "Roll pod results up to the policy report of their controller owner (Deployment, StatefulSet, ...) instead of reporting every pod.")
+	flagset.BoolVar(&keepPodReportDetails, "keepPodReportDetails", false, "Keep one result per pod in the reports aggregated by owner.")
+	flagset.BoolVar(&aggregateReportsByNamespace, "aggregateReportsByNamespace", false, "Aggregate policy reports per namespace and policy instead of per resource.")
 	flagset.BoolVar(&policyReports, "policyReports", true, "Enable or disable policy reports.")
 	flagset.BoolVar(&validatingAdmissionPolicyReports, "validatingAdmissionPolicyReports", false, "Enable or disable validating admission policy reports.")
 	flagset.IntVar(&reportsChunkSize, "reportsChunkSize", 1000, "Max number of results in generated reports, reports will be split accordingly if there are more results to be stored.")
+	flagset.IntVar(&reportsSizeBudget, "reportsSizeBudget", 1024*1024, "Max size in bytes of the results stored in a namespace report, reports are sharded accordingly if their results are bigger.")
+	flagset.DurationVar(&admissionReportsTTL, "admissionReportsTTL", admissionreportcontroller.DefaultTTL, "Time an admission report is kept waiting for aggregation before it is deleted.")
+	flagset.IntVar(&admissionReportsMaxPerNamespace, "admissionReportsMaxPerNamespace", 0, "Max number of admission reports waiting for aggregation in a namespace, the oldest ones are deleted above this limit (0 means unlimited).")
+	flagset.IntVar(&admissionReportsBacklogThreshold, "admissionReportsBacklogThreshold", 0, "Number of admission reports waiting in the aggregation queue above which reports older than their TTL are deleted without being aggregated (0 disables aggressive pruning).")
 	flagset.IntVar(&backgroundScanWorkers, "backgroundScanWorkers", backgroundscancontroller.Workers, "Configure the number of background scan workers.")
 	flagset.DurationVar(&backgroundScanInterval, "backgroundScanInterval", time.Hour, "Configure background scan interval.")
+	flagset.IntVar(&backgroundScanNamespaceConcurrency, "backgroundScanNamespaceConcurrency", 0, "Max number of resources of a namespace scanned concurrently by the background scan (0 means unlimited).")
+	flagset.StringVar(&backgroundScanPriorityIntervals, "backgroundScanPriorityIntervals", "", "Comma separated list of <priority class>=<interval> scan intervals of the policies annotated with policies.kyverno.io/scan-priority, e.g. critical=5m,high=15m. Other policies are scanned at the background scan interval.")
+	flagset.BoolVar(&backgroundScanIncremental, "backgroundScanIncremental", false, "Only scan again the resources whose spec, labels, namespace labels or policies changed since their last scan, policies loading external data are still scanned again at the background scan interval.")
+	flagset.BoolVar(&backgroundScanRolloutHistory, "backgroundScanRolloutHistory", false, "Also scan the revisions retained in the rollout history of pod controllers, ReplicaSets of Deployments and ControllerRevisions of DaemonSets and StatefulSets are evaluated as their owner with the pod template of the revision.")
+	flagset.DurationVar(&backgroundScanPrefetchTTL, "backgroundScanPrefetchTTL", 5*time.Minute, "Configure how often data declared in rule prefetch entries is reloaded by the background scanner.")
 	flagset.IntVar(&maxQueuedEvents, "maxQueuedEvents", 1000, "Maximum events to be queued.")
 	flagset.StringVar(&omitEvents, "omit-events", "", "Set this flag to a comma separated list of PolicyViolation, PolicyApplied, PolicyError, PolicySkipped to disable events, e.g. --omit-events=PolicyApplied,PolicyViolation")
 	flagset.BoolVar(&skipResourceFilters, "skipResourceFilters", true, "If true, resource filters wont be considered.")
 	flagset.Int64Var(&maxAPICallResponseLength, "maxAPICallResponseLength", 2*1000*1000, "Maximum allowed response size from API Calls. A value of 0 bypasses checks (not recommended).")
+	flagset.StringVar(&exportWebhookURL, "exportWebhookURL", "", "URL of an HTTP endpoint policy report results are posted to.")
+	flagset.StringVar(&exportS3Bucket, "exportS3Bucket", "", "Name of an S3 bucket policy report results are written to.")
+	flagset.StringVar(&exportS3Prefix, "exportS3Prefix", "kyverno", "Prefix of the S3 objects policy report results are written to.")
+	flagset.StringVar(&exportS3Region, "exportS3Region", "us-east-1", "Region of the S3 bucket policy report results are written to.")
+	flagset.StringVar(&exportS3Endpoint, "exportS3Endpoint", "", "Endpoint of an S3 compatible store, objects are addressed with path style requests when set.")
+	flagset.StringVar(&exportKafkaURL, "exportKafkaURL", "", "URL of a Kafka REST proxy policy report results are produced through.")
+	flagset.StringVar(&exportKafkaTopic, "exportKafkaTopic", "kyverno-policy-results", "Kafka topic policy report results are produced to.")
+	flagset.IntVar(&exportBatchSize, "exportBatchSize", 100, "Max number of policy report results sent to the export sinks at once.")
+	flagset.DurationVar(&exportBatchInterval, "exportBatchInterval", 10*time.Second, "Interval at which pending policy report results are sent to the export sinks.")
+	flagset.IntVar(&exportMaxRetries, "exportMaxRetries", 3, "Number of times sending policy report results to an export sink is retried before they are dropped.")
 	// config
 	appConfig := internal.NewConfiguration(
 		internal.WithProfiling(),
@@ -215,6 +390,9 @@ func main() {
 		internal.WithKubeconfig(),
 		internal.WithPolicyExceptions(),
 		internal.WithConfigMapCaching(),
+		internal.WithRelatedResources(),
+		internal.WithOwnerResources(),
+		internal.WithContextCallLimits(),
 		internal.WithDeferredLoading(),
 		internal.WithCosign(),
 		internal.WithRegistryClient(),
@@ -239,6 +417,11 @@ func main() {
 	// ELSE KYAML IS NOT THREAD SAFE
 	kyamlopenapi.Schema()
 	setup.Logger.Info("background scan interval", "duration", backgroundScanInterval.String())
+	priorityIntervals, err := backgroundscancontroller.ParsePriorityIntervals(backgroundScanPriorityIntervals)
+	if err != nil {
+		setup.Logger.Error(err, "invalid background scan priority intervals")
+		os.Exit(1)
+	}
 	// check if validating admission policies are registered in the API server
 	if validatingAdmissionPolicyReports {
 		groupVersion := schema.GroupVersion{Group: "admissionregistration.k8s.io", Version: "v1alpha1"}
@@ -247,6 +430,24 @@ func main() {
 			os.Exit(1)
 		}
 	}
+	// report export sinks
+	exportSinks, err := createExportSinks(
+		ctx,
+		setup.OutboundTransport,
+		exportWebhookURL,
+		exportS3Bucket,
+		exportS3Prefix,
+		exportS3Region,
+		exportS3Endpoint,
+		exportKafkaURL,
+		exportKafkaTopic,
+	)
+	if err != nil {
+		setup.Logger.Error(err, "failed to create report export sinks")
+		os.Exit(1)
+	}
+	// exclusion policies
+	internal.StartExclusionPolicies(ctx, setup.Logger, setup.KyvernoClient, setup.Configuration, kyvernov2alpha1.ExclusionReports, resyncPeriod)
 	// informer factories
 	kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(setup.KyvernoClient, resyncPeriod)
 	omitEventsValues := strings.Split(omitEvents, ",")
@@ -259,22 +460,39 @@ func main() {
 		kyvernoInformer.Kyverno().V1().Policies(),
 		maxQueuedEvents,
 		omitEventsValues,
+		setup.Configuration,
 		logging.WithName("EventGenerator"),
 	)
+	// prefetch cache used by the background scanner
+	prefetchCache := prefetch.NewCache(adapters.Client(setup.KyvernoDynamicClient), backgroundScanPrefetchTTL)
 	// engine
+	// related resources informer
+	relatedResourceInformer := internal.NewRelatedResourceInformer(ctx, setup.Logger, setup.KyvernoDynamicClient, resyncPeriod)
+	// context call limiter
+	contextCallLimiter := internal.NewContextCallLimiter(setup.Logger)
+	// owner resolver
+	ownerResolver := internal.NewOwnerResolver(ctx, setup.Logger, setup.KyvernoDynamicClient, resyncPeriod)
+	// policy exceptions
+	exceptionsSelector := internal.NewExceptionSelector(ctx, setup.Logger, setup.KyvernoClient, 15*time.Minute)
 	engine := internal.NewEngine(
 		ctx,
 		setup.Logger,
 		setup.Configuration,
 		setup.MetricsConfiguration,
 		setup.Jp,
+		nil,
 		setup.KyvernoDynamicClient,
 		setup.RegistryClient,
 		setup.ImageVerifyCacheClient,
 		setup.KubeClient,
 		setup.KyvernoClient,
 		setup.RegistrySecretLister,
-		apicall.NewAPICallConfiguration(maxAPICallResponseLength),
+		apicall.NewAPICallConfiguration(maxAPICallResponseLength, setup.OutboundTransport),
+		prefetchCache,
+		relatedResourceInformer,
+		contextCallLimiter,
+		exceptionsSelector,
+		ownerResolver,
 	)
 	// start informers and wait for cache sync
 	if !internal.StartInformersAndWaitForCacheSync(ctx, setup.Logger, kyvernoInformer) {
@@ -285,13 +503,12 @@ func main() {
 	var wg sync.WaitGroup
 	go eventGenerator.Run(ctx, 3, &wg)
 	// setup leader election
-	le, err := leaderelection.New(
+	le, err := internal.NewLeaderElection(
 		setup.Logger.WithName("leader-election"),
 		"kyverno-reports-controller",
 		config.KyvernoNamespace(),
 		setup.LeaderElectionClient,
 		config.KyvernoPodName(),
-		internal.LeaderElectionRetryPeriod(),
 		func(ctx context.Context) {
 			logger := setup.Logger.WithName("leader")
 			// create leader factories
@@ -302,13 +519,31 @@ func main() {
 			// create leader controllers
 			leaderControllers, warmup, err := createrLeaderControllers(
 				engine,
+				prefetchCache,
+				relatedResourceInformer,
+				exceptionsSelector,
 				backgroundScan,
 				admissionReports,
 				aggregateReports,
+				aggregateReportsByOwner,
+				keepPodReportDetails,
+				aggregateReportsByNamespace,
 				policyReports,
 				validatingAdmissionPolicyReports,
 				reportsChunkSize,
+				reportsSizeBudget,
+				admissionreportcontroller.PruneOptions{
+					TTL:              admissionReportsTTL,
+					MaxPerNamespace:  admissionReportsMaxPerNamespace,
+					BacklogThreshold: admissionReportsBacklogThreshold,
+				},
 				backgroundScanWorkers,
+				backgroundscancontroller.ScanOptions{
+					NamespaceConcurrency: backgroundScanNamespaceConcurrency,
+					PriorityIntervals:    priorityIntervals,
+					Incremental:          backgroundScanIncremental,
+					RolloutHistory:       backgroundScanRolloutHistory,
+				},
 				kubeInformer,
 				kyvernoInformer,
 				metadataInformer,
@@ -323,6 +558,20 @@ func main() {
 				logger.Error(err, "failed to create leader controllers")
 				os.Exit(1)
 			}
+			if len(exportSinks) != 0 {
+				leaderControllers = append(leaderControllers, internal.NewController(
+					reportexportcontroller.ControllerName,
+					reportexportcontroller.NewController(
+						setup.KyvernoClient,
+						metadataInformer,
+						exportSinks,
+						exportBatchSize,
+						exportBatchInterval,
+						exportMaxRetries,
+					),
+					reportexportcontroller.Workers,
+				))
+			}
 			// start informers and wait for cache sync
 			if !internal.StartInformersAndWaitForCacheSync(ctx, logger, kyvernoInformer, kubeInformer, kubeKyvernoInformer) {
 				logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
@@ -338,6 +587,14 @@ func main() {
 				os.Exit(1)
 			}
 			// start leader controllers
+			leaderControllers = internal.LeaderElectedControllers(
+				setup.Logger.WithName("leader-election"),
+				"kyverno-reports-controller",
+				config.KyvernoNamespace(),
+				setup.LeaderElectionClient,
+				config.KyvernoPodName(),
+				leaderControllers...,
+			)
 			var wg sync.WaitGroup
 			for _, controller := range leaderControllers {
 				controller.Run(ctx, logger.WithName("controllers"), &wg)
@@ -345,7 +602,6 @@ func main() {
 			// wait all controllers shut down
 			wg.Wait()
 		},
-		nil,
 	)
 	if err != nil {
 		setup.Logger.Error(err, "failed to initialize leader election")
@@ -23,22 +23,29 @@ const (
 package resource
 
 import (
+	"errors"
 	"fmt"
 	"time"
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/multierr"
+	"k8s.io/client-go/util/flowcontrol"
 	{{- range $package := Packages .Target.Type }}
 	{{ Pkg $package }} {{ Quote $package }}
 	{{- end }}
 )
 
 func WithLogging(inner {{ GoType .Target.Type }}, logger logr.Logger) {{ GoType .Target.Type }} {
-	return &withLogging{inner, logger}
+	return &withLogging{inner, logger, false}
+}
+
+func WithAuditLogging(inner {{ GoType .Target.Type }}, logger logr.Logger) {{ GoType .Target.Type }} {
+	return &withLogging{inner, logger, true}
 }
 
 func WithMetrics(inner {{ GoType .Target.Type }}, recorder metrics.Recorder) {{ GoType .Target.Type }} {
@@ -49,9 +56,31 @@ func WithTracing(inner {{ GoType .Target.Type }}, client, kind string) {{ GoType
 	return &withTracing{inner, client, kind}
 }
 
+func WithRateLimiting(inner {{ GoType .Target.Type }}, limiter flowcontrol.RateLimiter) {{ GoType .Target.Type }} {
+	if limiter == nil {
+		return inner
+	}
+	return &withRateLimiting{inner, limiter}
+}
+
+func WithRetry(inner {{ GoType .Target.Type }}, retrier *middleware.Retrier) {{ GoType .Target.Type }} {
+	if retrier == nil {
+		return inner
+	}
+	return &withRetry{inner, retrier}
+}
+
+func WithCircuitBreaker(inner {{ GoType .Target.Type }}, breaker *middleware.CircuitBreaker) {{ GoType .Target.Type }} {
+	if breaker == nil {
+		return inner
+	}
+	return &withCircuitBreaker{inner, breaker}
+}
+
 type withLogging struct {
 	inner  {{ GoType .Target.Type }}
 	logger logr.Logger
+	sizes  bool
 }
 
 {{- range $operation := .Target.Operations }}
@@ -79,6 +108,24 @@ func (c *withLogging) {{ $operation.Method.Name }}(
 		{{- end -}}
 		{{- end -}}
 	)
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(
+				{{- range $i, $arg := Args $operation.Method -}}
+				{{- if not (and $operation.HasContext (eq $i 0)) -}}
+				arg{{ $i }},
+				{{- end -}}
+				{{- end -}}
+			),
+			"responseSize", middleware.Size(
+				{{- range $i, $ret := Returns $operation.Method -}}
+				{{- if not $ret.IsError -}}
+				ret{{ $i }},
+				{{- end -}}
+				{{- end -}}
+			),
+		)
+	}
 	{{- if $operation.HasError }}
 	if err := multierr.Combine(
 		{{- range $i, $ret := Returns $operation.Method -}}
@@ -205,12 +252,181 @@ func (c *withTracing) {{ $operation.Method.Name }}(
 	{{- end }}
 }
 {{- end }}
+
+type withRateLimiting struct {
+	inner   {{ GoType .Target.Type }}
+	limiter flowcontrol.RateLimiter
+}
+
+{{- range $operation := .Target.Operations }}
+func (c *withRateLimiting) {{ $operation.Method.Name }}(
+	{{- range $i, $arg := Args $operation.Method -}}
+	{{- if $arg.IsVariadic -}}
+	arg{{ $i }} ...{{ GoType $arg.Type.Elem }},
+	{{- else -}}
+	arg{{ $i }} {{ GoType $arg.Type }},
+	{{- end -}}
+	{{- end -}}
+) (
+	{{- range $return := Returns $operation.Method -}}
+	{{ GoType $return }},
+	{{- end -}}
+) {
+	{{- if and $operation.HasContext $operation.HasError }}
+	if err := c.limiter.Wait(arg0); err != nil {
+		{{- range $i, $ret := Returns $operation.Method }}
+		var ret{{ $i }} {{ GoType $ret }}
+		{{- end }}
+		{{- range $i, $ret := Returns $operation.Method }}
+		{{- if $ret.IsError }}
+		ret{{ $i }} = err
+		{{- end }}
+		{{- end }}
+		return	{{ range $i, $ret := Returns $operation.Method -}}
+		ret{{ $i }}{{ if not $ret.IsLast -}},{{- end }}
+		{{- end }}
+	}
+	{{- end }}
+	return c.inner.{{ $operation.Method.Name }}(
+		{{- range $i, $arg := Args $operation.Method -}}
+		{{- if $arg.IsVariadic -}}
+		arg{{ $i }}...,
+		{{- else -}}
+		arg{{ $i }},
+		{{- end -}}
+		{{- end -}}
+	)
+}
+{{- end }}
+
+type withRetry struct {
+	inner   {{ GoType .Target.Type }}
+	retrier *middleware.Retrier
+}
+
+{{- range $operation := .Target.Operations }}
+func (c *withRetry) {{ $operation.Method.Name }}(
+	{{- range $i, $arg := Args $operation.Method -}}
+	{{- if $arg.IsVariadic -}}
+	arg{{ $i }} ...{{ GoType $arg.Type.Elem }},
+	{{- else -}}
+	arg{{ $i }} {{ GoType $arg.Type }},
+	{{- end -}}
+	{{- end -}}
+) (
+	{{- range $return := Returns $operation.Method -}}
+	{{ GoType $return }},
+	{{- end -}}
+) {
+	{{- if and $operation.HasContext $operation.HasError }}
+	{{- range $i, $ret := Returns $operation.Method }}
+	var ret{{ $i }} {{ GoType $ret }}
+	{{- end }}
+	c.retrier.Retry(arg0, func() error {
+		{{ range $i, $ret := Returns $operation.Method }}ret{{ $i }}{{ if not $ret.IsLast -}},{{- end }} {{ end }} = c.inner.{{ $operation.Method.Name }}(
+			{{- range $i, $arg := Args $operation.Method -}}
+			{{- if $arg.IsVariadic -}}
+			arg{{ $i }}...,
+			{{- else -}}
+			arg{{ $i }},
+			{{- end -}}
+			{{- end -}}
+		)
+		return multierr.Combine(
+			{{- range $i, $ret := Returns $operation.Method -}}
+			{{- if $ret.IsError -}}
+			ret{{ $i }},
+			{{- end -}}
+			{{- end -}}
+		)
+	})
+	return	{{ range $i, $ret := Returns $operation.Method -}}
+	ret{{ $i }}{{ if not $ret.IsLast -}},{{- end }}
+	{{- end }}
+	{{- else }}
+	return c.inner.{{ $operation.Method.Name }}(
+		{{- range $i, $arg := Args $operation.Method -}}
+		{{- if $arg.IsVariadic -}}
+		arg{{ $i }}...,
+		{{- else -}}
+		arg{{ $i }},
+		{{- end -}}
+		{{- end -}}
+	)
+	{{- end }}
+}
+{{- end }}
+
+type withCircuitBreaker struct {
+	inner   {{ GoType .Target.Type }}
+	breaker *middleware.CircuitBreaker
+}
+
+{{- range $operation := .Target.Operations }}
+func (c *withCircuitBreaker) {{ $operation.Method.Name }}(
+	{{- range $i, $arg := Args $operation.Method -}}
+	{{- if $arg.IsVariadic -}}
+	arg{{ $i }} ...{{ GoType $arg.Type.Elem }},
+	{{- else -}}
+	arg{{ $i }} {{ GoType $arg.Type }},
+	{{- end -}}
+	{{- end -}}
+) (
+	{{- range $return := Returns $operation.Method -}}
+	{{ GoType $return }},
+	{{- end -}}
+) {
+	{{- if and $operation.HasContext $operation.HasError }}
+	{{- range $i, $ret := Returns $operation.Method }}
+	var ret{{ $i }} {{ GoType $ret }}
+	{{- end }}
+	if err := c.breaker.Call(arg0, func() error {
+		{{ range $i, $ret := Returns $operation.Method }}ret{{ $i }}{{ if not $ret.IsLast -}},{{- end }} {{ end }} = c.inner.{{ $operation.Method.Name }}(
+			{{- range $i, $arg := Args $operation.Method -}}
+			{{- if $arg.IsVariadic -}}
+			arg{{ $i }}...,
+			{{- else -}}
+			arg{{ $i }},
+			{{- end -}}
+			{{- end -}}
+		)
+		return multierr.Combine(
+			{{- range $i, $ret := Returns $operation.Method -}}
+			{{- if $ret.IsError -}}
+			ret{{ $i }},
+			{{- end -}}
+			{{- end -}}
+		)
+	}); errors.Is(err, middleware.ErrCircuitOpen) {
+		{{- range $i, $ret := Returns $operation.Method }}
+		{{- if $ret.IsError }}
+		ret{{ $i }} = err
+		{{- end }}
+		{{- end }}
+	}
+	return	{{ range $i, $ret := Returns $operation.Method -}}
+	ret{{ $i }}{{ if not $ret.IsLast -}},{{- end }}
+	{{- end }}
+	{{- else }}
+	return c.inner.{{ $operation.Method.Name }}(
+		{{- range $i, $arg := Args $operation.Method -}}
+		{{- if $arg.IsVariadic -}}
+		arg{{ $i }}...,
+		{{- else -}}
+		arg{{ $i }},
+		{{- end -}}
+		{{- end -}}
+	)
+	{{- end }}
+}
+{{- end }}
 `
 	clientTpl = `
 package client
 
 import (
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"k8s.io/client-go/rest"
 	{{- range $package := Packages .Target.Type }}
@@ -233,6 +449,22 @@ func WithLogging(inner {{ GoType .Target.Type }}, logger logr.Logger) {{ GoType
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner {{ GoType .Target.Type }}, logger logr.Logger, audit *middleware.AuditLogging) {{ GoType .Target.Type }} {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner {{ GoType .Target.Type }}, limits *middleware.RateLimits) {{ GoType .Target.Type }} {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner {{ GoType .Target.Type }}, retries *middleware.Retries) {{ GoType .Target.Type }} {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner {{ GoType .Target.Type }}, breakers *middleware.CircuitBreakers) {{ GoType .Target.Type }} {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      {{ GoType .Target }}
 	metrics    metrics.MetricsConfigManager
@@ -284,12 +516,76 @@ func (c *withLogging) {{ $method.Name }}({{- if $method.IsNamespaced -}}namespac
 	)
 }
 {{- end }}
+
+type withAuditLogging struct {
+	inner  {{ GoType .Target }}
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+{{- range $method, $resource := .Target.Resources }}
+func (c *withAuditLogging) {{ $method.Name }}({{- if $method.IsNamespaced -}}namespace string{{- end -}}) {{ GoType $resource.Type }} {
+	inner := c.inner.{{ $method.Name }}({{- if $method.IsNamespaced -}}namespace{{- end -}})
+	level, ok := c.audit.For({{ Quote $resource.Kind }})
+	if !ok {
+		return inner
+	}
+	return 	{{ ToLower $method.Name }}.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", {{ Quote $method.Name }})
+		{{- if $method.IsNamespaced -}}.WithValues("namespace", namespace){{- end -}}
+	)
+}
+{{- end }}
+
+type withRateLimiting struct {
+	inner  {{ GoType .Target }}
+	limits *middleware.RateLimits
+}
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+{{- range $method, $resource := .Target.Resources }}
+func (c *withRateLimiting) {{ $method.Name }}({{- if $method.IsNamespaced -}}namespace string{{- end -}}) {{ GoType $resource.Type }} {
+	return 	{{ ToLower $method.Name }}.WithRateLimiting(c.inner.{{ $method.Name }}(
+		{{- if $method.IsNamespaced -}}namespace{{- end -}}), c.limits.For({{ Quote $resource.Kind }}))
+}
+{{- end }}
+
+type withRetry struct {
+	inner   {{ GoType .Target }}
+	retries *middleware.Retries
+}
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+{{- range $method, $resource := .Target.Resources }}
+func (c *withRetry) {{ $method.Name }}({{- if $method.IsNamespaced -}}namespace string{{- end -}}) {{ GoType $resource.Type }} {
+	return 	{{ ToLower $method.Name }}.WithRetry(c.inner.{{ $method.Name }}(
+		{{- if $method.IsNamespaced -}}namespace{{- end -}}), c.retries.For({{ Quote $resource.Kind }}))
+}
+{{- end }}
+
+type withCircuitBreaker struct {
+	inner    {{ GoType .Target }}
+	breakers *middleware.CircuitBreakers
+}
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+{{- range $method, $resource := .Target.Resources }}
+func (c *withCircuitBreaker) {{ $method.Name }}({{- if $method.IsNamespaced -}}namespace string{{- end -}}) {{ GoType $resource.Type }} {
+	return 	{{ ToLower $method.Name }}.WithCircuitBreaker(c.inner.{{ $method.Name }}(
+		{{- if $method.IsNamespaced -}}namespace{{- end -}}), c.breakers.For({{ Quote $resource.Kind }}))
+}
+{{- end }}
 `
 	clientsetTpl = `
 package clientset
 
 import (
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	{{- range $package := Packages .Target.Type }}
 	{{ Pkg $package }} {{ Quote $package }}
@@ -355,12 +651,66 @@ func WrapWithLogging(inner {{ GoType .Target }}, logger logr.Logger) {{ GoType .
 		{{- end }}
 	}
 }
+func WrapWithAuditLogging(inner {{ GoType .Target }}, logger logr.Logger, audit *middleware.AuditLogging) {{ GoType .Target }} {
+	return &clientset{
+		{{- range $resourceMethod, $resource := .Target.Resources }}
+		{{ ToLower $resourceMethod.Name }}: auditLogging{{ $resourceMethod.Name }}(inner.{{ $resourceMethod.Name }}(), logger.WithValues("group", {{ Quote $resourceMethod.Name }}), audit),
+		{{- end }}
+		{{- range $clientMethod, $client := .Target.Clients }}
+		{{ ToLower $clientMethod.Name }}: {{ ToLower $clientMethod.Name }}.WithAuditLogging(inner.{{ $clientMethod.Name }}(), logger.WithValues("group", {{ Quote $clientMethod.Name }}), audit),
+		{{- end }}
+	}
+}
+{{- range $resourceMethod, $resource := .Target.Resources }}
+
+func auditLogging{{ $resourceMethod.Name }}(inner {{ GoType $resource.Type }}, logger logr.Logger, audit *middleware.AuditLogging) {{ GoType $resource.Type }} {
+	level, ok := audit.For({{ Quote $resource.Kind }})
+	if !ok {
+		return inner
+	}
+	return {{ ToLower $resourceMethod.Name }}.WithAuditLogging(inner, logger.V(level))
+}
+{{- end }}
+
+func WrapWithRateLimiting(inner {{ GoType .Target }}, limits *middleware.RateLimits) {{ GoType .Target }} {
+	return &clientset{
+		{{- range $resourceMethod, $resource := .Target.Resources }}
+		{{ ToLower $resourceMethod.Name }}: {{ ToLower $resourceMethod.Name }}.WithRateLimiting(inner.{{ $resourceMethod.Name }}(), limits.For({{ Quote $resource.Kind }})),
+		{{- end }}
+		{{- range $clientMethod, $client := .Target.Clients }}
+		{{ ToLower $clientMethod.Name }}: {{ ToLower $clientMethod.Name }}.WithRateLimiting(inner.{{ $clientMethod.Name }}(), limits),
+		{{- end }}
+	}
+}
+
+func WrapWithRetry(inner {{ GoType .Target }}, retries *middleware.Retries) {{ GoType .Target }} {
+	return &clientset{
+		{{- range $resourceMethod, $resource := .Target.Resources }}
+		{{ ToLower $resourceMethod.Name }}: {{ ToLower $resourceMethod.Name }}.WithRetry(inner.{{ $resourceMethod.Name }}(), retries.For({{ Quote $resource.Kind }})),
+		{{- end }}
+		{{- range $clientMethod, $client := .Target.Clients }}
+		{{ ToLower $clientMethod.Name }}: {{ ToLower $clientMethod.Name }}.WithRetry(inner.{{ $clientMethod.Name }}(), retries),
+		{{- end }}
+	}
+}
+
+func WrapWithCircuitBreaker(inner {{ GoType .Target }}, breakers *middleware.CircuitBreakers) {{ GoType .Target }} {
+	return &clientset{
+		{{- range $resourceMethod, $resource := .Target.Resources }}
+		{{ ToLower $resourceMethod.Name }}: {{ ToLower $resourceMethod.Name }}.WithCircuitBreaker(inner.{{ $resourceMethod.Name }}(), breakers.For({{ Quote $resource.Kind }})),
+		{{- end }}
+		{{- range $clientMethod, $client := .Target.Clients }}
+		{{ ToLower $clientMethod.Name }}: {{ ToLower $clientMethod.Name }}.WithCircuitBreaker(inner.{{ $clientMethod.Name }}(), breakers),
+		{{- end }}
+	}
+}
 `
 	interfaceTpl = `
 package clientset
 
 import (
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	{{- range $package := Packages .Target.Type }}
 	{{ Pkg $package }} {{ Quote $package }}
@@ -377,6 +727,10 @@ type Interface interface {
 	WithMetrics(metrics.MetricsConfigManager, metrics.ClientType) Interface
 	WithTracing() Interface
 	WithLogging(logr.Logger) Interface
+	WithAuditLogging(logr.Logger, *middleware.AuditLogging) Interface
+	WithRateLimiting(*middleware.RateLimits) Interface
+	WithRetry(*middleware.Retries) Interface
+	WithCircuitBreaker(*middleware.CircuitBreakers) Interface
 }
 
 func From(inner {{ GoType .Target }}, opts ...NewOption) Interface {
@@ -407,6 +761,30 @@ func WithLogging(logger logr.Logger) NewOption {
 	}
 }
 
+func WithAuditLogging(logger logr.Logger, audit *middleware.AuditLogging) NewOption {
+	return func(i Interface) Interface {
+		return i.WithAuditLogging(logger, audit)
+	}
+}
+
+func WithRateLimiting(limits *middleware.RateLimits) NewOption {
+	return func(i Interface) Interface {
+		return i.WithRateLimiting(limits)
+	}
+}
+
+func WithRetry(retries *middleware.Retries) NewOption {
+	return func(i Interface) Interface {
+		return i.WithRetry(retries)
+	}
+}
+
+func WithCircuitBreaker(breakers *middleware.CircuitBreakers) NewOption {
+	return func(i Interface) Interface {
+		return i.WithCircuitBreaker(breakers)
+	}
+}
+
 func NewForConfig(c *rest.Config, opts ...NewOption) (Interface, error) {
 	inner, err := {{ Pkg .Target.Type.PkgPath }}.NewForConfig(c)
 	if err != nil {
@@ -446,6 +824,22 @@ func (i *wrapper) WithTracing() Interface {
 func (i *wrapper) WithLogging(logger logr.Logger) Interface {
 	return from(WrapWithLogging(i, logger))
 }
+
+func (i *wrapper) WithAuditLogging(logger logr.Logger, audit *middleware.AuditLogging) Interface {
+	return from(WrapWithAuditLogging(i, logger, audit))
+}
+
+func (i *wrapper) WithRateLimiting(limits *middleware.RateLimits) Interface {
+	return from(WrapWithRateLimiting(i, limits))
+}
+
+func (i *wrapper) WithRetry(retries *middleware.Retries) Interface {
+	return from(WrapWithRetry(i, retries))
+}
+
+func (i *wrapper) WithCircuitBreaker(breakers *middleware.CircuitBreakers) Interface {
+	return from(WrapWithCircuitBreaker(i, breakers))
+}
 `
 )
 
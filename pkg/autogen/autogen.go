@@ -1,12 +1,15 @@
 package autogen
 
 import (
+	"crypto/sha256"
 	"encoding/json"
+	"fmt"
 	"slices"
 	"strings"
 
 	"github.com/kyverno/kyverno/api/kyverno"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -30,7 +33,7 @@ func isKindOtherthanPod(kinds []string) bool {
 
 func checkAutogenSupport(needed *bool, subjects ...kyvernov1.ResourceDescription) bool {
 	for _, subject := range subjects {
-		if subject.Name != "" || len(subject.Names) > 0 || subject.Selector != nil || subject.Annotations != nil || isKindOtherthanPod(subject.Kinds) {
+		if subject.Name != "" || len(subject.Names) > 0 || subject.Selector != nil || subject.FieldSelector != "" || subject.Owner != nil || subject.Annotations != nil || isKindOtherthanPod(subject.Kinds) {
 			return false
 		}
 		if needed != nil {
@@ -64,10 +67,16 @@ func stripCronJob(controllers string) string {
 //   - Pod and PodControllers are not defined
 //   - mutate.Patches/mutate.PatchesJSON6902/validate.deny/generate rule is defined
 //
+// rules with autogen turned off are not taken into account
+//
 // - otherwise it returns all pod controllers
-func CanAutoGen(spec *kyvernov1.Spec) (applyAutoGen bool, controllers string) {
+func CanAutoGen(spec *kyvernov1.Spec, custom []config.AutogenController) (applyAutoGen bool, controllers string) {
 	needed := false
 	for _, rule := range spec.Rules {
+		// rules with autogen turned off don't prevent generating the other rules
+		if !rule.IsAutogenEnabled() {
+			continue
+		}
 		if rule.Mutation.PatchesJSON6902 != "" || rule.HasGenerate() {
 			return false, "none"
 		}
@@ -109,12 +118,15 @@ func CanAutoGen(spec *kyvernov1.Spec) (applyAutoGen bool, controllers string) {
 	if !needed {
 		return false, ""
 	}
+	if kinds := customControllerKinds(custom); kinds != "" {
+		return true, PodControllers + "," + kinds
+	}
 	return true, PodControllers
 }
 
 // GetSupportedControllers returns the supported autogen controllers for a given spec.
-func GetSupportedControllers(spec *kyvernov1.Spec) []string {
-	apply, controllers := CanAutoGen(spec)
+func GetSupportedControllers(spec *kyvernov1.Spec, custom []config.AutogenController) []string {
+	apply, controllers := CanAutoGen(spec, custom)
 	if !apply || controllers == "none" {
 		return nil
 	}
@@ -139,9 +151,9 @@ func GetRequestedControllers(meta *metav1.ObjectMeta) []string {
 
 // GetControllers computes the autogen controllers that should be applied to a policy.
 // It returns the requested, supported and effective controllers (intersection of requested and supported ones).
-func GetControllers(meta *metav1.ObjectMeta, spec *kyvernov1.Spec) ([]string, []string, []string) {
+func GetControllers(meta *metav1.ObjectMeta, spec *kyvernov1.Spec, custom []config.AutogenController) ([]string, []string, []string) {
 	// compute supported and requested controllers
-	supported, requested := GetSupportedControllers(spec), GetRequestedControllers(meta)
+	supported, requested := GetSupportedControllers(spec, custom), GetRequestedControllers(meta)
 	// no specific request, we can return supported controllers without further filtering
 	if requested == nil {
 		return requested, supported, supported
@@ -166,30 +178,66 @@ func GetControllers(meta *metav1.ObjectMeta, spec *kyvernov1.Spec) ([]string, []
 //             make sure all fields are applicable to pod controllers
 
 // generateRules generates rule for podControllers based on scenario A and C
-func generateRules(spec *kyvernov1.Spec, controllers string) []kyvernov1.Rule {
+func generateRules(spec *kyvernov1.Spec, controllers string, custom []config.AutogenController) []kyvernov1.Rule {
 	var rules []kyvernov1.Rule
 	for i := range spec.Rules {
-		// handle all other controllers other than CronJob
-		if genRule := createRule(generateRuleForControllers(&spec.Rules[i], stripCronJob(controllers))); genRule != nil {
-			if convRule, err := convertRule(*genRule, "Pod"); err == nil {
-				rules = append(rules, *convRule)
-			} else {
-				logger.Error(err, "failed to create rule")
-			}
+		rules = append(rules, generateRulesFor(&spec.Rules[i], ruleControllers(&spec.Rules[i], controllers), custom)...)
+	}
+	return rules
+}
+
+// generateRulesFor generates the rules of a single rule for the given podControllers
+func generateRulesFor(rule *kyvernov1.Rule, controllers string, custom []config.AutogenController) []kyvernov1.Rule {
+	var rules []kyvernov1.Rule
+	builtinControllers := stripCustomControllers(controllers, custom)
+	// handle all other controllers other than CronJob
+	if genRule := createRule(generateRuleForControllers(rule, stripCronJob(builtinControllers))); genRule != nil {
+		if convRule, err := convertRule(*genRule, "Pod", custom); err == nil {
+			rules = append(rules, *convRule)
+		} else {
+			logger.Error(err, "failed to create rule")
 		}
-		// handle CronJob, it appends an additional rule
-		if genRule := createRule(generateCronJobRule(&spec.Rules[i], controllers)); genRule != nil {
-			if convRule, err := convertRule(*genRule, "Cronjob"); err == nil {
+	}
+	// handle CronJob, it appends an additional rule
+	if genRule := createRule(generateCronJobRule(rule, builtinControllers)); genRule != nil {
+		if convRule, err := convertRule(*genRule, "Cronjob", custom); err == nil {
+			rules = append(rules, *convRule)
+		} else {
+			logger.Error(err, "failed to create Cronjob rule")
+		}
+	}
+	// handle the custom controllers registered in the configuration, each appends an additional rule
+	for _, controller := range custom {
+		if genRule := createRule(generateCustomControllerRule(rule, controller, controllers)); genRule != nil {
+			if convRule, err := convertRule(*genRule, controller.Kind, custom); err == nil {
 				rules = append(rules, *convRule)
 			} else {
-				logger.Error(err, "failed to create Cronjob rule")
+				logger.Error(err, "failed to create rule", "kind", controller.Kind)
 			}
 		}
 	}
 	return rules
 }
 
-func convertRule(rule kyvernoRule, kind string) (*kyvernov1.Rule, error) {
+// ruleControllers restricts the podControllers of a policy to the ones selected by the autogen controls of a rule,
+// it returns an empty string when autogen is turned off for the rule
+func ruleControllers(rule *kyvernov1.Rule, controllers string) string {
+	if !rule.IsAutogenEnabled() {
+		return ""
+	}
+	if rule.Autogen == nil || len(rule.Autogen.Controllers) == 0 {
+		return controllers
+	}
+	var selected []string
+	for _, controller := range rule.Autogen.Controllers {
+		if controllers == "all" || slices.Contains(strings.Split(controllers, ","), controller) {
+			selected = append(selected, controller)
+		}
+	}
+	return strings.Join(selected, ",")
+}
+
+func convertRule(rule kyvernoRule, kind string, custom []config.AutogenController) (*kyvernov1.Rule, error) {
 	if bytes, err := json.Marshal(rule); err != nil {
 		return nil, err
 	} else {
@@ -199,7 +247,7 @@ func convertRule(rule kyvernoRule, kind string) (*kyvernov1.Rule, error) {
 				return nil, err
 			}
 		} else {
-			bytes = updateGenRuleByte(bytes, kind)
+			bytes = updateGenRuleByte(bytes, kind, custom)
 			if err := json.Unmarshal(bytes, &rule); err != nil {
 				return nil, err
 			}
@@ -208,7 +256,7 @@ func convertRule(rule kyvernoRule, kind string) (*kyvernov1.Rule, error) {
 		// CEL variables are object, oldObject, request, params and authorizer.
 		// Therefore CEL expressions can be either written as object.spec or request.object.spec
 		if rule.Validation != nil && rule.Validation.CEL != nil {
-			bytes = updateCELFields(bytes, kind)
+			bytes = updateCELFields(bytes, kind, custom)
 			if err := json.Unmarshal(bytes, &rule); err != nil {
 				return nil, err
 			}
@@ -216,8 +264,11 @@ func convertRule(rule kyvernoRule, kind string) (*kyvernov1.Rule, error) {
 	}
 
 	out := kyvernov1.Rule{
-		Name:         rule.Name,
-		VerifyImages: rule.VerifyImages,
+		Name:                 rule.Name,
+		VerifyImages:         rule.VerifyImages,
+		Remediation:          rule.Remediation,
+		Timeout:              rule.Timeout,
+		TimeoutFailurePolicy: rule.TimeoutFailurePolicy,
 	}
 	if rule.MatchResources != nil {
 		out.MatchResources = *rule.MatchResources
@@ -228,6 +279,7 @@ func convertRule(rule kyvernoRule, kind string) (*kyvernov1.Rule, error) {
 	if rule.Context != nil {
 		out.Context = *rule.Context
 	}
+	out.Prefetch = rule.Prefetch
 	if rule.AnyAllConditions != nil {
 		out.SetAnyAllConditions(*rule.AnyAllConditions)
 	}
@@ -240,29 +292,29 @@ func convertRule(rule kyvernoRule, kind string) (*kyvernov1.Rule, error) {
 	return &out, nil
 }
 
-func ComputeRules(p kyvernov1.PolicyInterface) []kyvernov1.Rule {
-	return computeRules(p)
-}
-
-func computeRules(p kyvernov1.PolicyInterface) []kyvernov1.Rule {
-	spec := p.GetSpec()
-	applyAutoGen, desiredControllers := CanAutoGen(spec)
-	if !applyAutoGen {
-		desiredControllers = "none"
+// ComputeHash returns a content hash of the given rules.
+// Rules are serialized with sorted keys so the hash is stable as long as the rules don't change.
+func ComputeHash(rules []kyvernov1.Rule) string {
+	if len(rules) == 0 {
+		return ""
 	}
-	ann := p.GetAnnotations()
-	actualControllers, ok := ann[kyverno.AnnotationAutogenControllers]
-	if !ok || !applyAutoGen {
-		actualControllers = desiredControllers
-	} else {
-		if !applyAutoGen {
-			actualControllers = desiredControllers
-		}
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return ""
 	}
-	if actualControllers == "none" {
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
+func ComputeRules(p kyvernov1.PolicyInterface, custom []config.AutogenController) []kyvernov1.Rule {
+	return computeRules(p, custom)
+}
+
+func computeRules(p kyvernov1.PolicyInterface, custom []config.AutogenController) []kyvernov1.Rule {
+	spec, controllers := computeControllers(p, custom)
+	if controllers == "none" {
 		return spec.Rules
 	}
-	genRules := generateRules(spec.DeepCopy(), actualControllers)
+	genRules := generateRules(spec.DeepCopy(), controllers, custom)
 	if len(genRules) == 0 {
 		return spec.Rules
 	}
@@ -275,3 +327,68 @@ func computeRules(p kyvernov1.PolicyInterface) []kyvernov1.Rule {
 	out = append(out, genRules...)
 	return out
 }
+
+// computeControllers returns the spec autogen applies to, with condition templates expanded,
+// and the podControllers rules are generated for
+func computeControllers(p kyvernov1.PolicyInterface, custom []config.AutogenController) (*kyvernov1.Spec, string) {
+	spec := p.GetSpec()
+	if len(spec.ConditionTemplates) != 0 {
+		// condition templates are expanded first so that autogen rules get the translated conditions
+		// when expansion fails the template references are kept and the engine fails the rules using them
+		rules, err := ExpandConditionTemplates(spec)
+		if err != nil {
+			logger.Error(err, "failed to expand condition templates", "policy", p.GetName())
+		} else {
+			expanded := *spec
+			expanded.Rules = rules
+			spec = &expanded
+		}
+	}
+	applyAutoGen, desiredControllers := CanAutoGen(spec, custom)
+	if !applyAutoGen {
+		desiredControllers = "none"
+	}
+	ann := p.GetAnnotations()
+	actualControllers, ok := ann[kyverno.AnnotationAutogenControllers]
+	if !ok || !applyAutoGen {
+		actualControllers = desiredControllers
+	}
+	return spec, actualControllers
+}
+
+// GeneratedRules describes the rules auto generated for pod controllers from a rule of a policy
+type GeneratedRules struct {
+	// Rule is the name of the policy rule
+	Rule string
+	// Disabled is true when autogen is turned off by the rule autogen controls
+	Disabled bool
+	// Controllers contains the pod controllers selected for the rule
+	Controllers []string
+	// Rules contains the rules generated from the rule
+	Rules []kyvernov1.Rule
+}
+
+// ExplainRules returns the rules auto generated for pod controllers from every rule of a policy,
+// in the order of the policy rules, they are the rules added by ComputeRules.
+func ExplainRules(p kyvernov1.PolicyInterface, custom []config.AutogenController) []GeneratedRules {
+	spec, controllers := computeControllers(p, custom)
+	var out []GeneratedRules
+	for i := range spec.Rules {
+		rule := spec.Rules[i].DeepCopy()
+		if isAutogenRuleName(rule.Name) {
+			continue
+		}
+		generated := GeneratedRules{
+			Rule:     rule.Name,
+			Disabled: !rule.IsAutogenEnabled(),
+		}
+		if controllers != "none" && !generated.Disabled {
+			if selected := ruleControllers(rule, controllers); selected != "" {
+				generated.Controllers = strings.Split(selected, ",")
+				generated.Rules = generateRulesFor(rule, selected, custom)
+			}
+		}
+		out = append(out, generated)
+	}
+	return out
+}
@@ -148,7 +148,7 @@ func Test_CanAutoGen(t *testing.T) {
 			err := json.Unmarshal(test.policy, &policy)
 			assert.NilError(t, err)
 
-			applyAutoGen, controllers := CanAutoGen(&policy.Spec)
+			applyAutoGen, controllers := CanAutoGen(&policy.Spec, nil)
 			if !applyAutoGen {
 				controllers = "none"
 			}
@@ -251,7 +251,7 @@ func Test_GetSupportedControllers(t *testing.T) {
 			err := json.Unmarshal(test.policy, &policy)
 			assert.NilError(t, err)
 
-			controllers := GetSupportedControllers(&policy.Spec)
+			controllers := GetSupportedControllers(&policy.Spec, nil)
 
 			var expectedControllers []string
 			if test.expectedControllers != "none" {
@@ -343,7 +343,7 @@ func TestUpdateGenRuleByte(t *testing.T) {
 		},
 	}
 	for _, tt := range tests {
-		got := updateGenRuleByte(tt.pbyte, tt.kind)
+		got := updateGenRuleByte(tt.pbyte, tt.kind, nil)
 		if !reflect.DeepEqual(got, tt.want) {
 			t.Errorf("updateGenRuleByte() = %v, want %v", string(got), string(tt.want))
 		}
@@ -384,7 +384,7 @@ func TestUpdateCELFields(t *testing.T) {
 		},
 	}
 	for _, tt := range tests {
-		got := updateCELFields(tt.pbyte, tt.kind)
+		got := updateCELFields(tt.pbyte, tt.kind, nil)
 		if !reflect.DeepEqual(got, tt.want) {
 			t.Errorf("updateCELFields() = %v, want %v", string(got), string(tt.want))
 		}
@@ -572,19 +572,73 @@ kA==
 			policies, _, err := yamlutils.GetPolicy([]byte(test.policy))
 			assert.NilError(t, err)
 			assert.Equal(t, 1, len(policies))
-			rules := computeRules(policies[0])
+			rules := computeRules(policies[0], nil)
 			assert.DeepEqual(t, test.expectedRules, rules)
 		})
 	}
 }
 
+func Test_ComputeRules_Deterministic(t *testing.T) {
+	policy := func(controllers string) string {
+		return `{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"require-labels","annotations":{"pod-policies.kyverno.io/autogen-controllers":"` + controllers + `"}},"spec":{"rules":[{"name":"require-team","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"validate":{"message":"label team is required","pattern":{"metadata":{"labels":{"team":"?*","app":"?*"}}}}}]}}`
+	}
+	var hashes []string
+	for _, controllers := range []string{"Deployment,StatefulSet,CronJob", "CronJob,StatefulSet,Deployment", "StatefulSet,Deployment,CronJob"} {
+		policies, _, err := yamlutils.GetPolicy([]byte(policy(controllers)))
+		assert.NilError(t, err)
+		assert.Equal(t, 1, len(policies))
+		rules := computeRules(policies[0], nil)
+		assert.Equal(t, 3, len(rules))
+		assert.DeepEqual(t, []string{"Deployment", "StatefulSet"}, rules[1].MatchResources.Any[0].Kinds)
+		hashes = append(hashes, ComputeHash(rules[1:]))
+	}
+	assert.Assert(t, hashes[0] != "")
+	assert.Equal(t, hashes[0], hashes[1])
+	assert.Equal(t, hashes[0], hashes[2])
+}
+
+func Test_ComputeRules_RuleAutogen(t *testing.T) {
+	policy := `{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"require-labels"},"spec":{"rules":[` +
+		`{"name":"require-team","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"validate":{"message":"label team is required","pattern":{"metadata":{"labels":{"team":"?*"}}}}},` +
+		`{"name":"require-app","autogen":{"enabled":false},"match":{"any":[{"resources":{"kinds":["Pod"],"names":["nginx"]}}]},"validate":{"message":"label app is required","pattern":{"metadata":{"labels":{"app":"?*"}}}}},` +
+		`{"name":"require-owner","autogen":{"controllers":["Deployment","CronJob"]},"match":{"any":[{"resources":{"kinds":["Pod"]}}]},"validate":{"message":"label owner is required","pattern":{"metadata":{"labels":{"owner":"?*"}}}}}` +
+		`]}}`
+	policies, _, err := yamlutils.GetPolicy([]byte(policy))
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(policies))
+	var names []string
+	for _, rule := range computeRules(policies[0], nil) {
+		names = append(names, rule.Name)
+	}
+	assert.DeepEqual(t, []string{
+		"require-team",
+		"require-app",
+		"require-owner",
+		"autogen-require-team",
+		"autogen-cronjob-require-team",
+		"autogen-require-owner",
+		"autogen-cronjob-require-owner",
+	}, names)
+	explained := ExplainRules(policies[0], nil)
+	assert.Equal(t, 3, len(explained))
+	assert.Equal(t, "require-team", explained[0].Rule)
+	assert.Equal(t, 2, len(explained[0].Rules))
+	assert.Equal(t, "require-app", explained[1].Rule)
+	assert.Assert(t, explained[1].Disabled)
+	assert.Equal(t, 0, len(explained[1].Rules))
+	assert.Equal(t, "require-owner", explained[2].Rule)
+	assert.DeepEqual(t, []string{"Deployment", "CronJob"}, explained[2].Controllers)
+	assert.DeepEqual(t, []string{"Deployment"}, explained[2].Rules[0].MatchResources.Any[0].Kinds)
+	assert.DeepEqual(t, []string{"CronJob"}, explained[2].Rules[1].MatchResources.Any[0].Kinds)
+}
+
 func Test_PodSecurityWithNoExceptions(t *testing.T) {
 	policy := []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"pod-security"},"spec":{"validationFailureAction":"enforce","rules":[{"name":"restricted","match":{"all":[{"resources":{"kinds":["Pod"]}}]},"validate":{"podSecurity":{"level":"restricted","version":"v1.24"}}}]}}`)
 	policies, _, err := yamlutils.GetPolicy([]byte(policy))
 	assert.NilError(t, err)
 	assert.Equal(t, 1, len(policies))
 
-	rules := computeRules(policies[0])
+	rules := computeRules(policies[0], nil)
 	assert.Equal(t, 3, len(rules))
 }
 
@@ -632,6 +686,6 @@ func Test_ValidateWithCELExpressions(t *testing.T) {
 	assert.NilError(t, err)
 	assert.Equal(t, 1, len(policies))
 
-	rules := computeRules(policies[0])
+	rules := computeRules(policies[0], nil)
 	assert.Equal(t, 3, len(rules))
 }
@@ -0,0 +1,165 @@
+package autogen
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// ExpandConditionTemplates returns a copy of the spec rules where condition template references are
+// replaced with the conditions of the referenced templates.
+// References are resolved in rule preconditions, deny conditions, foreach preconditions and deny
+// conditions, and attestation conditions.
+func ExpandConditionTemplates(spec *kyvernov1.Spec) ([]kyvernov1.Rule, error) {
+	e := expander{
+		templates: map[string]kyvernov1.AnyAllConditions{},
+		resolved:  map[string]kyvernov1.AnyAllConditions{},
+	}
+	for _, template := range spec.ConditionTemplates {
+		e.templates[template.Name] = template.Conditions
+	}
+	rules := make([]kyvernov1.Rule, 0, len(spec.Rules))
+	for i := range spec.Rules {
+		rule := spec.Rules[i].DeepCopy()
+		if err := e.expandRule(rule); err != nil {
+			return nil, fmt.Errorf("path: spec.rules[%d]%s", i, err)
+		}
+		rules = append(rules, *rule)
+	}
+	return rules, nil
+}
+
+type expander struct {
+	templates map[string]kyvernov1.AnyAllConditions
+	resolved  map[string]kyvernov1.AnyAllConditions
+}
+
+func (e *expander) expandRule(rule *kyvernov1.Rule) error {
+	if err := e.expandRaw(&rule.RawAnyAllConditions); err != nil {
+		return fmt.Errorf(".preconditions: %w", err)
+	}
+	if rule.Validation.Deny != nil {
+		if err := e.expandRaw(&rule.Validation.Deny.RawAnyAllConditions); err != nil {
+			return fmt.Errorf(".validate.deny.conditions: %w", err)
+		}
+	}
+	for i := range rule.Validation.ForEachValidation {
+		foreach := &rule.Validation.ForEachValidation[i]
+		if foreach.AnyAllConditions != nil {
+			if err := e.expand(foreach.AnyAllConditions); err != nil {
+				return fmt.Errorf(".validate.foreach[%d].preconditions: %w", i, err)
+			}
+		}
+		if foreach.Deny != nil {
+			if err := e.expandRaw(&foreach.Deny.RawAnyAllConditions); err != nil {
+				return fmt.Errorf(".validate.foreach[%d].deny.conditions: %w", i, err)
+			}
+		}
+	}
+	for i := range rule.Mutation.ForEachMutation {
+		foreach := &rule.Mutation.ForEachMutation[i]
+		if foreach.AnyAllConditions != nil {
+			if err := e.expand(foreach.AnyAllConditions); err != nil {
+				return fmt.Errorf(".mutate.foreach[%d].preconditions: %w", i, err)
+			}
+		}
+	}
+	for i := range rule.VerifyImages {
+		for j := range rule.VerifyImages[i].Attestations {
+			attestation := &rule.VerifyImages[i].Attestations[j]
+			for k := range attestation.Conditions {
+				if err := e.expand(&attestation.Conditions[k]); err != nil {
+					return fmt.Errorf(".verifyImages[%d].attestations[%d].conditions[%d]: %w", i, j, k, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// expandRaw expands conditions stored as raw json, the deprecated list form doesn't support templates.
+func (e *expander) expandRaw(raw **apiextv1.JSON) error {
+	if *raw == nil {
+		return nil
+	}
+	var conditions kyvernov1.AnyAllConditions
+	if err := json.Unmarshal((*raw).Raw, &conditions); err != nil || len(conditions.Templates) == 0 {
+		return nil
+	}
+	if err := e.expand(&conditions); err != nil {
+		return err
+	}
+	data, err := json.Marshal(conditions)
+	if err != nil {
+		return err
+	}
+	*raw = &apiextv1.JSON{Raw: data}
+	return nil
+}
+
+func (e *expander) expand(conditions *kyvernov1.AnyAllConditions) error {
+	references := conditions.Templates
+	conditions.Templates = nil
+	for _, name := range references {
+		template, err := e.resolve(name, nil)
+		if err != nil {
+			return err
+		}
+		if err := merge(conditions, template, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *expander) resolve(name string, stack []string) (kyvernov1.AnyAllConditions, error) {
+	if resolved, ok := e.resolved[name]; ok {
+		return resolved, nil
+	}
+	for _, visited := range stack {
+		if visited == name {
+			return kyvernov1.AnyAllConditions{}, fmt.Errorf("cyclic condition template reference %s", strings.Join(append(stack, name), " -> "))
+		}
+	}
+	template, ok := e.templates[name]
+	if !ok {
+		return kyvernov1.AnyAllConditions{}, fmt.Errorf("unknown condition template %q", name)
+	}
+	resolved := kyvernov1.AnyAllConditions{
+		AnyConditions: appendConditions(nil, template.AnyConditions),
+		AllConditions: appendConditions(nil, template.AllConditions),
+	}
+	for _, reference := range template.Templates {
+		nested, err := e.resolve(reference, append(stack, name))
+		if err != nil {
+			return kyvernov1.AnyAllConditions{}, err
+		}
+		if err := merge(&resolved, nested, reference); err != nil {
+			return kyvernov1.AnyAllConditions{}, fmt.Errorf("condition template %q: %w", name, err)
+		}
+	}
+	e.resolved[name] = resolved
+	return resolved, nil
+}
+
+// merge adds the conditions of a template to conditions.
+// A block holds a single any group, merging two any groups would turn A and (B or C) into A or B or C,
+// that's why a template with any conditions can't be merged into a block that already has any conditions.
+func merge(conditions *kyvernov1.AnyAllConditions, template kyvernov1.AnyAllConditions, name string) error {
+	if len(template.AnyConditions) != 0 && len(conditions.AnyConditions) != 0 {
+		return fmt.Errorf("condition template %q has any conditions and can't be merged into conditions that already have any conditions", name)
+	}
+	conditions.AnyConditions = appendConditions(conditions.AnyConditions, template.AnyConditions)
+	conditions.AllConditions = appendConditions(conditions.AllConditions, template.AllConditions)
+	return nil
+}
+
+func appendConditions(out []kyvernov1.Condition, conditions []kyvernov1.Condition) []kyvernov1.Condition {
+	for i := range conditions {
+		out = append(out, *conditions[i].DeepCopy())
+	}
+	return out
+}
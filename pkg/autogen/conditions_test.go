@@ -0,0 +1,195 @@
+package autogen
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+)
+
+const conditionTemplatesPolicy = `{
+  "apiVersion": "kyverno.io/v1",
+  "kind": "ClusterPolicy",
+  "metadata": {
+    "name": "production-workloads"
+  },
+  "spec": {
+    "conditionTemplates": [
+      {
+        "name": "is-production",
+        "conditions": {
+          "all": [{ "key": "{{ request.object.metadata.labels.env }}", "operator": "Equals", "value": "prod" }]
+        }
+      },
+      {
+        "name": "is-owned-production",
+        "conditions": {
+          "templates": ["is-production"],
+          "any": [{ "key": "{{ request.object.metadata.labels.team }}", "operator": "Equals", "value": "payments" }]
+        }
+      }
+    ],
+    "rules": [
+      {
+        "name": "require-run-as-non-root",
+        "match": { "any": [{ "resources": { "kinds": ["Pod"] } }] },
+        "preconditions": {
+          "templates": ["is-owned-production"],
+          "all": [{ "key": "{{ request.operation }}", "operator": "Equals", "value": "CREATE" }]
+        },
+        "validate": {
+          "pattern": { "spec": { "securityContext": { "runAsNonRoot": true } } }
+        }
+      }
+    ]
+  }
+}`
+
+func Test_ExpandConditionTemplates(t *testing.T) {
+	var policy kyvernov1.ClusterPolicy
+	assert.NilError(t, json.Unmarshal([]byte(conditionTemplatesPolicy), &policy))
+	rules, err := ExpandConditionTemplates(policy.GetSpec())
+	assert.NilError(t, err)
+	assert.Equal(t, len(rules), 1)
+	var preconditions kyvernov1.AnyAllConditions
+	assert.NilError(t, json.Unmarshal(rules[0].RawAnyAllConditions.Raw, &preconditions))
+	assert.Equal(t, len(preconditions.Templates), 0)
+	assert.Equal(t, len(preconditions.AllConditions), 2)
+	assert.Equal(t, string(preconditions.AllConditions[0].RawKey.Raw), `"{{ request.operation }}"`)
+	assert.Equal(t, string(preconditions.AllConditions[1].RawKey.Raw), `"{{ request.object.metadata.labels.env }}"`)
+	assert.Equal(t, len(preconditions.AnyConditions), 1)
+	assert.Equal(t, string(preconditions.AnyConditions[0].RawKey.Raw), `"{{ request.object.metadata.labels.team }}"`)
+	// the policy is left untouched
+	assert.Assert(t, strings.Contains(string(policy.Spec.Rules[0].RawAnyAllConditions.Raw), "templates"))
+}
+
+func Test_ExpandConditionTemplatesErrors(t *testing.T) {
+	condition := func(key string) kyvernov1.Condition {
+		var condition kyvernov1.Condition
+		condition.SetKey(key)
+		condition.Operator = kyvernov1.ConditionOperators["Equals"]
+		condition.SetValue("true")
+		return condition
+	}
+	testCases := []struct {
+		name          string
+		templates     []kyvernov1.ConditionTemplate
+		preconditions map[string]interface{}
+		expected      string
+	}{{
+		name:     "unknown",
+		expected: `path: spec.rules[0].preconditions: unknown condition template "is-production"`,
+	}, {
+		name: "cycle",
+		templates: []kyvernov1.ConditionTemplate{{
+			Name:       "is-production",
+			Conditions: kyvernov1.AnyAllConditions{Templates: []string{"is-owned"}},
+		}, {
+			Name:       "is-owned",
+			Conditions: kyvernov1.AnyAllConditions{Templates: []string{"is-production"}},
+		}},
+		expected: `path: spec.rules[0].preconditions: cyclic condition template reference is-production -> is-owned -> is-production`,
+	}, {
+		name: "any merged into any",
+		templates: []kyvernov1.ConditionTemplate{{
+			Name:       "is-production",
+			Conditions: kyvernov1.AnyAllConditions{AnyConditions: []kyvernov1.Condition{condition("b"), condition("c")}},
+		}},
+		preconditions: map[string]interface{}{
+			"templates": []string{"is-production"},
+			"any":       []interface{}{map[string]interface{}{"key": "a", "operator": "Equals", "value": "true"}},
+		},
+		expected: `path: spec.rules[0].preconditions: condition template "is-production" has any conditions and can't be merged into conditions that already have any conditions`,
+	}, {
+		name: "two templates with any",
+		templates: []kyvernov1.ConditionTemplate{{
+			Name:       "is-production",
+			Conditions: kyvernov1.AnyAllConditions{AnyConditions: []kyvernov1.Condition{condition("a")}},
+		}, {
+			Name:       "is-owned",
+			Conditions: kyvernov1.AnyAllConditions{AnyConditions: []kyvernov1.Condition{condition("b"), condition("c")}},
+		}},
+		preconditions: map[string]interface{}{"templates": []string{"is-production", "is-owned"}},
+		expected:      `path: spec.rules[0].preconditions: condition template "is-owned" has any conditions and can't be merged into conditions that already have any conditions`,
+	}, {
+		name: "nested templates with any",
+		templates: []kyvernov1.ConditionTemplate{{
+			Name:       "is-production",
+			Conditions: kyvernov1.AnyAllConditions{AnyConditions: []kyvernov1.Condition{condition("a")}, Templates: []string{"is-owned"}},
+		}, {
+			Name:       "is-owned",
+			Conditions: kyvernov1.AnyAllConditions{AnyConditions: []kyvernov1.Condition{condition("b"), condition("c")}},
+		}},
+		expected: `path: spec.rules[0].preconditions: condition template "is-production": condition template "is-owned" has any conditions and can't be merged into conditions that already have any conditions`,
+	}}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			spec := kyvernov1.Spec{
+				ConditionTemplates: test.templates,
+				Rules:              []kyvernov1.Rule{{Name: "rule"}},
+			}
+			preconditions := test.preconditions
+			if preconditions == nil {
+				preconditions = map[string]interface{}{"templates": []string{"is-production"}}
+			}
+			spec.Rules[0].SetAnyAllConditions(preconditions)
+			_, err := ExpandConditionTemplates(&spec)
+			assert.Error(t, err, test.expected)
+		})
+	}
+}
+
+func Test_ExpandConditionTemplatesKeepsAnyGroups(t *testing.T) {
+	// any: [a] with a template holding all conditions only must keep a single any group
+	spec := kyvernov1.Spec{
+		ConditionTemplates: []kyvernov1.ConditionTemplate{{
+			Name: "is-production",
+			Conditions: kyvernov1.AnyAllConditions{AllConditions: []kyvernov1.Condition{{
+				RawKey:   kyvernov1.ToJSON("b"),
+				Operator: kyvernov1.ConditionOperators["Equals"],
+				RawValue: kyvernov1.ToJSON("true"),
+			}}},
+		}},
+		Rules: []kyvernov1.Rule{{Name: "rule"}},
+	}
+	spec.Rules[0].SetAnyAllConditions(map[string]interface{}{
+		"templates": []string{"is-production"},
+		"any":       []interface{}{map[string]interface{}{"key": "a", "operator": "Equals", "value": "true"}},
+	})
+	rules, err := ExpandConditionTemplates(&spec)
+	assert.NilError(t, err)
+	var preconditions kyvernov1.AnyAllConditions
+	assert.NilError(t, json.Unmarshal(rules[0].RawAnyAllConditions.Raw, &preconditions))
+	assert.Equal(t, len(preconditions.AnyConditions), 1)
+	assert.Equal(t, string(preconditions.AnyConditions[0].RawKey.Raw), `"a"`)
+	assert.Equal(t, len(preconditions.AllConditions), 1)
+	assert.Equal(t, string(preconditions.AllConditions[0].RawKey.Raw), `"b"`)
+}
+
+func Test_ComputeRulesWithConditionTemplates(t *testing.T) {
+	var policy kyvernov1.ClusterPolicy
+	assert.NilError(t, json.Unmarshal([]byte(conditionTemplatesPolicy), &policy))
+	rules := ComputeRules(&policy, nil)
+	assert.Equal(t, len(rules), 3)
+	for _, rule := range rules {
+		preconditions := string(rule.RawAnyAllConditions.Raw)
+		assert.Assert(t, !strings.Contains(preconditions, "templates"), preconditions)
+		if rule.Name == "autogen-require-run-as-non-root" {
+			assert.Assert(t, strings.Contains(preconditions, "request.object.spec.template.metadata.labels.env"), preconditions)
+		}
+	}
+}
+
+func Test_ComputeRulesWithInvalidConditionTemplates(t *testing.T) {
+	var policy kyvernov1.ClusterPolicy
+	assert.NilError(t, json.Unmarshal([]byte(conditionTemplatesPolicy), &policy))
+	policy.Spec.ConditionTemplates = policy.Spec.ConditionTemplates[1:]
+	// expansion fails, references are kept so that the engine can't evaluate the rule without them
+	for _, rule := range ComputeRules(&policy, nil) {
+		var preconditions kyvernov1.AnyAllConditions
+		assert.NilError(t, json.Unmarshal(rule.RawAnyAllConditions.Raw, &preconditions))
+		assert.DeepEqual(t, preconditions.Templates, []string{"is-owned-production"})
+	}
+}
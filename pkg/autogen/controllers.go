@@ -0,0 +1,61 @@
+package autogen
+
+import (
+	"slices"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+)
+
+// customControllerKinds returns the kinds of the custom pod controllers in csv string
+func customControllerKinds(controllers []config.AutogenController) string {
+	var kinds []string
+	for _, controller := range controllers {
+		kinds = append(kinds, controller.Kind)
+	}
+	return strings.Join(kinds, ",")
+}
+
+// stripCustomControllers removes the custom pod controllers from controllers
+func stripCustomControllers(controllers string, custom []config.AutogenController) string {
+	if len(custom) == 0 || controllers == "all" {
+		return controllers
+	}
+	var newControllers []string
+	for _, c := range strings.Split(controllers, ",") {
+		if !slices.ContainsFunc(custom, func(controller config.AutogenController) bool { return controller.Kind == c }) {
+			newControllers = append(newControllers, c)
+		}
+	}
+	return strings.Join(newControllers, ",")
+}
+
+// generateCustomControllerRule generates the rule of a custom pod controller, the pod template of the
+// controller is found at the path declared in the configuration. Pod security rules are not generated
+// as pod security checks only support the built-in controllers.
+func generateCustomControllerRule(rule *kyvernov1.Rule, controller config.AutogenController, controllers string) *kyvernov1.Rule {
+	if isAutogenRuleName(rule.Name) || rule.Validation.PodSecurity != nil {
+		return nil
+	}
+	if controllers != "all" && !slices.Contains(strings.Split(controllers, ","), controller.Kind) {
+		return nil
+	}
+	match, exclude := rule.MatchResources, rule.ExcludeResources
+	matchKinds, excludeKinds := match.GetKinds(), exclude.GetKinds()
+	if !kubeutils.ContainsKind(matchKinds, "Pod") || (len(excludeKinds) != 0 && !kubeutils.ContainsKind(excludeKinds, "Pod")) {
+		return nil
+	}
+	path := strings.Split(controller.PodTemplatePath, ".")
+	return generateRule(
+		getAutogenRuleName("autogen-"+strings.ToLower(controller.Kind), rule.Name),
+		rule,
+		path,
+		strings.Join(path, "/"),
+		[]string{controller.MatchKind()},
+		func(r kyvernov1.ResourceFilters, kinds []string) kyvernov1.ResourceFilters {
+			return getAnyAllAutogenRule(r, "Pod", kinds)
+		},
+	)
+}
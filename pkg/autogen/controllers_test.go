@@ -0,0 +1,67 @@
+package autogen
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"gotest.tools/assert"
+)
+
+func Test_ComputeRules_CustomControllers(t *testing.T) {
+	custom := []config.AutogenController{
+		{Kind: "Rollout", APIVersion: "argoproj.io/v1alpha1", PodTemplatePath: "spec.template"},
+		{Kind: "Service", APIVersion: "serving.knative.dev/v1", PodTemplatePath: "spec.template"},
+	}
+	policy := func(annotations string) string {
+		return `{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"require-labels"` + annotations + `},"spec":{"rules":[{"name":"require-team","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"validate":{"message":"label {{ request.object.metadata.labels.team }} is required","pattern":{"metadata":{"labels":{"team":"?*"}}}}}]}}`
+	}
+	policies, _, err := yamlutils.GetPolicy([]byte(policy("")))
+	assert.NilError(t, err)
+	rules := computeRules(policies[0], custom)
+	var names []string
+	for _, rule := range rules {
+		names = append(names, rule.Name)
+	}
+	assert.DeepEqual(t, names, []string{"require-team", "autogen-require-team", "autogen-cronjob-require-team", "autogen-rollout-require-team", "autogen-service-require-team"})
+	rollout := rules[3]
+	assert.DeepEqual(t, rollout.MatchResources.Any[0].Kinds, []string{"argoproj.io/v1alpha1/Rollout"})
+	assert.DeepEqual(t, rollout.Validation.GetPattern(), map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{"team": "?*"},
+				},
+			},
+		},
+	})
+	assert.Equal(t, rollout.Validation.Message, "label {{ request.object.spec.template.metadata.labels.team }} is required")
+	// only the requested controllers get rules
+	policies, _, err = yamlutils.GetPolicy([]byte(policy(`,"annotations":{"pod-policies.kyverno.io/autogen-controllers":"Rollout"}`)))
+	assert.NilError(t, err)
+	rules = computeRules(policies[0], custom)
+	assert.Equal(t, len(rules), 2)
+	assert.Equal(t, rules[1].Name, "autogen-rollout-require-team")
+}
+
+func Test_CanAutoGen_CustomControllers(t *testing.T) {
+	spec := &kyvernov1.Spec{
+		Rules: []kyvernov1.Rule{{
+			Name:           "rule",
+			MatchResources: kyvernov1.MatchResources{ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}}},
+		}},
+	}
+	_, controllers := CanAutoGen(spec, nil)
+	assert.Equal(t, controllers, PodControllers)
+	_, controllers = CanAutoGen(spec, []config.AutogenController{{Kind: "Rollout", PodTemplatePath: "spec.template"}})
+	assert.Equal(t, controllers, PodControllers+",Rollout")
+}
+
+func Test_stripCustomControllers(t *testing.T) {
+	custom := []config.AutogenController{{Kind: "Rollout"}}
+	assert.Equal(t, stripCustomControllers("Deployment,Rollout,CronJob", custom), "Deployment,CronJob")
+	assert.Equal(t, stripCustomControllers("Rollout", custom), "")
+	assert.Equal(t, stripCustomControllers("all", custom), "all")
+	assert.Equal(t, stripCustomControllers("Deployment", nil), "Deployment")
+}
@@ -4,11 +4,14 @@ import (
 	"strings"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // the kyvernoRule holds the temporary kyverno rule struct
@@ -21,14 +24,18 @@ import (
 // https://github.com/kyverno/kyverno/issues/568
 
 type kyvernoRule struct {
-	Name             string                        `json:"name"`
-	MatchResources   *kyvernov1.MatchResources     `json:"match"`
-	ExcludeResources *kyvernov1.MatchResources     `json:"exclude,omitempty"`
-	Context          *[]kyvernov1.ContextEntry     `json:"context,omitempty"`
-	AnyAllConditions *apiextensions.JSON           `json:"preconditions,omitempty"`
-	Mutation         *kyvernov1.Mutation           `json:"mutate,omitempty"`
-	Validation       *kyvernov1.Validation         `json:"validate,omitempty"`
-	VerifyImages     []kyvernov1.ImageVerification `json:"verifyImages,omitempty" yaml:"verifyImages,omitempty"`
+	Name                 string                        `json:"name"`
+	MatchResources       *kyvernov1.MatchResources     `json:"match"`
+	ExcludeResources     *kyvernov1.MatchResources     `json:"exclude,omitempty"`
+	Context              *[]kyvernov1.ContextEntry     `json:"context,omitempty"`
+	Prefetch             *kyvernov1.Prefetch           `json:"prefetch,omitempty"`
+	AnyAllConditions     *apiextensions.JSON           `json:"preconditions,omitempty"`
+	Mutation             *kyvernov1.Mutation           `json:"mutate,omitempty"`
+	Validation           *kyvernov1.Validation         `json:"validate,omitempty"`
+	Remediation          string                        `json:"remediation,omitempty"`
+	VerifyImages         []kyvernov1.ImageVerification `json:"verifyImages,omitempty" yaml:"verifyImages,omitempty"`
+	Timeout              *metav1.Duration              `json:"timeout,omitempty"`
+	TimeoutFailurePolicy *kyvernov1.FailurePolicyType  `json:"timeoutFailurePolicy,omitempty"`
 }
 
 func createRule(rule *kyvernov1.Rule) *kyvernoRule {
@@ -36,8 +43,11 @@ func createRule(rule *kyvernov1.Rule) *kyvernoRule {
 		return nil
 	}
 	jsonFriendlyStruct := kyvernoRule{
-		Name:         rule.Name,
-		VerifyImages: rule.VerifyImages,
+		Name:                 rule.Name,
+		VerifyImages:         rule.VerifyImages,
+		Remediation:          rule.Remediation,
+		Timeout:              rule.Timeout,
+		TimeoutFailurePolicy: rule.TimeoutFailurePolicy,
 	}
 	if !datautils.DeepEqual(rule.MatchResources, kyvernov1.MatchResources{}) {
 		jsonFriendlyStruct.MatchResources = rule.MatchResources.DeepCopy()
@@ -51,7 +61,11 @@ func createRule(rule *kyvernov1.Rule) *kyvernoRule {
 	if !datautils.DeepEqual(rule.Validation, kyvernov1.Validation{}) {
 		jsonFriendlyStruct.Validation = rule.Validation.DeepCopy()
 	}
-	kyvernoAnyAllConditions, _ := apiutils.ApiextensionsJsonToKyvernoConditions(rule.GetAnyAllConditions())
+	kyvernoAnyAllConditions, err := apiutils.ApiextensionsJsonToKyvernoConditions(rule.GetAnyAllConditions())
+	if err != nil && rule.RawAnyAllConditions != nil {
+		// keep preconditions that can't be parsed, the engine fails the generated rule instead of skipping them
+		jsonFriendlyStruct.AnyAllConditions = rule.DeepCopy().RawAnyAllConditions
+	}
 	switch typedAnyAllConditions := kyvernoAnyAllConditions.(type) {
 	case kyvernov1.AnyAllConditions:
 		if !datautils.DeepEqual(typedAnyAllConditions, kyvernov1.AnyAllConditions{}) {
@@ -65,12 +79,15 @@ func createRule(rule *kyvernov1.Rule) *kyvernoRule {
 	if len(rule.Context) > 0 {
 		jsonFriendlyStruct.Context = &rule.DeepCopy().Context
 	}
+	if rule.Prefetch != nil {
+		jsonFriendlyStruct.Prefetch = rule.Prefetch.DeepCopy()
+	}
 	return &jsonFriendlyStruct
 }
 
 type generateResourceFilters func(kyvernov1.ResourceFilters, []string) kyvernov1.ResourceFilters
 
-func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds []string, grf generateResourceFilters) *kyvernov1.Rule {
+func generateRule(name string, rule *kyvernov1.Rule, tplPath []string, shift string, kinds []string, grf generateResourceFilters) *kyvernov1.Rule {
 	if rule == nil {
 		return nil
 	}
@@ -96,11 +113,7 @@ func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds
 	if target := rule.Mutation.GetPatchStrategicMerge(); target != nil {
 		newMutation := kyvernov1.Mutation{}
 		newMutation.SetPatchStrategicMerge(
-			map[string]interface{}{
-				"spec": map[string]interface{}{
-					tplKey: target,
-				},
-			},
+			nest(tplPath, target),
 		)
 		rule.Mutation = newMutation
 		return rule
@@ -114,11 +127,7 @@ func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds
 				AnyAllConditions: foreach.AnyAllConditions,
 			}
 			temp.SetPatchStrategicMerge(
-				map[string]interface{}{
-					"spec": map[string]interface{}{
-						tplKey: foreach.GetPatchStrategicMerge(),
-					},
-				},
+				nest(tplPath, foreach.GetPatchStrategicMerge()),
 			)
 			newForEachMutation = append(newForEachMutation, temp)
 		}
@@ -132,11 +141,7 @@ func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds
 			Message: variables.FindAndShiftReferences(logger, rule.Validation.Message, shift, "pattern"),
 		}
 		newValidate.SetPattern(
-			map[string]interface{}{
-				"spec": map[string]interface{}{
-					tplKey: target,
-				},
-			},
+			nest(tplPath, target),
 		)
 		rule.Validation = newValidate
 		return rule
@@ -170,11 +175,7 @@ func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds
 		}
 		var patterns []interface{}
 		for _, pattern := range anyPatterns {
-			newPattern := map[string]interface{}{
-				"spec": map[string]interface{}{
-					tplKey: pattern,
-				},
-			}
+			newPattern := nest(tplPath, pattern)
 			patterns = append(patterns, newPattern)
 		}
 		rule.Validation = kyvernov1.Validation{
@@ -208,6 +209,15 @@ func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds
 	return nil
 }
 
+// nest returns the value nested at the given path
+func nest(path []string, value interface{}) map[string]interface{} {
+	out := map[string]interface{}{path[len(path)-1]: value}
+	for i := len(path) - 2; i >= 0; i-- {
+		out = map[string]interface{}{path[i]: out}
+	}
+	return out
+}
+
 func getAutogenRuleName(prefix string, name string) string {
 	name = prefix + "-" + name
 	if len(name) > 63 {
@@ -247,16 +257,11 @@ func generateRuleForControllers(rule *kyvernov1.Rule, controllers string) *kyver
 	if controllers == "all" {
 		skipAutoGeneration = true
 	} else if controllers != "none" && controllers != "all" {
-		controllersList := map[string]int{
-			"DaemonSet":             1,
-			"Deployment":            1,
-			"Job":                   1,
-			"StatefulSet":           1,
-			"ReplicaSet":            1,
-			"ReplicationController": 1,
-		}
-		for _, value := range strings.Split(controllers, ",") {
-			if _, ok := controllersList[value]; ok {
+		// kinds are generated in a canonical order, whatever the order of the requested controllers,
+		// so that the generated rules don't change when the annotation is reordered
+		requested := sets.New(strings.Split(controllers, ",")...)
+		for _, value := range strings.Split(stripCronJob(PodControllers), ",") {
+			if requested.Has(value) {
 				controllersValidated = append(controllersValidated, value)
 			}
 		}
@@ -274,7 +279,7 @@ func generateRuleForControllers(rule *kyvernov1.Rule, controllers string) *kyver
 	return generateRule(
 		getAutogenRuleName("autogen", rule.Name),
 		rule,
-		"template",
+		[]string{"spec", "template"},
 		"spec/template",
 		strings.Split(controllers, ","),
 		func(r kyvernov1.ResourceFilters, kinds []string) kyvernov1.ResourceFilters {
@@ -292,7 +297,7 @@ func generateCronJobRule(rule *kyvernov1.Rule, controllers string) *kyvernov1.Ru
 	return generateRule(
 		getAutogenRuleName("autogen-cronjob", rule.Name),
 		generateRuleForControllers(rule, controllers),
-		"jobTemplate",
+		[]string{"spec", "jobTemplate"},
 		"spec/jobTemplate/spec/template",
 		[]string{PodControllerCronJob},
 		func(r kyvernov1.ResourceFilters, kinds []string) kyvernov1.ResourceFilters {
@@ -305,18 +310,12 @@ func generateCronJobRule(rule *kyvernov1.Rule, controllers string) *kyvernov1.Ru
 	)
 }
 
-func updateGenRuleByte(pbyte []byte, kind string) (obj []byte) {
-	if kind == "Pod" {
-		obj = []byte(strings.ReplaceAll(string(pbyte), "request.object.spec", "request.object.spec.template.spec"))
-		obj = []byte(strings.ReplaceAll(string(obj), "request.oldObject.spec", "request.oldObject.spec.template.spec"))
-		obj = []byte(strings.ReplaceAll(string(obj), "request.object.metadata", "request.object.spec.template.metadata"))
-		obj = []byte(strings.ReplaceAll(string(obj), "request.oldObject.metadata", "request.oldObject.spec.template.metadata"))
-	}
-	if kind == "Cronjob" {
-		obj = []byte(strings.ReplaceAll(string(pbyte), "request.object.spec", "request.object.spec.jobTemplate.spec.template.spec"))
-		obj = []byte(strings.ReplaceAll(string(obj), "request.oldObject.spec", "request.oldObject.spec.jobTemplate.spec.template.spec"))
-		obj = []byte(strings.ReplaceAll(string(obj), "request.object.metadata", "request.object.spec.jobTemplate.spec.template.metadata"))
-		obj = []byte(strings.ReplaceAll(string(obj), "request.oldObject.metadata", "request.oldObject.spec.jobTemplate.spec.template.metadata"))
+func updateGenRuleByte(pbyte []byte, kind string, custom []config.AutogenController) (obj []byte) {
+	if path := podTemplatePath(kind, custom); path != "" {
+		obj = []byte(strings.ReplaceAll(string(pbyte), "request.object.spec", "request.object."+path+".spec"))
+		obj = []byte(strings.ReplaceAll(string(obj), "request.oldObject.spec", "request.oldObject."+path+".spec"))
+		obj = []byte(strings.ReplaceAll(string(obj), "request.object.metadata", "request.object."+path+".metadata"))
+		obj = []byte(strings.ReplaceAll(string(obj), "request.oldObject.metadata", "request.oldObject."+path+".metadata"))
 	}
 	return obj
 }
@@ -332,18 +331,28 @@ func updateRestrictedFields(pbyte []byte, kind string) (obj []byte) {
 	return obj
 }
 
-func updateCELFields(pbyte []byte, kind string) (obj []byte) {
-	if kind == "Pod" {
-		obj = []byte(strings.ReplaceAll(string(pbyte), "object.spec", "object.spec.template.spec"))
-		obj = []byte(strings.ReplaceAll(string(obj), "oldObject.spec", "oldObject.spec.template.spec"))
-		obj = []byte(strings.ReplaceAll(string(obj), "object.metadata", "object.spec.template.metadata"))
-		obj = []byte(strings.ReplaceAll(string(obj), "oldObject.metadata", "oldObject.spec.template.metadata"))
-	}
-	if kind == "Cronjob" {
-		obj = []byte(strings.ReplaceAll(string(pbyte), "object.spec", "object.spec.jobTemplate.spec.template.spec"))
-		obj = []byte(strings.ReplaceAll(string(obj), "oldObject.spec", "oldObject.spec.jobTemplate.spec.template.spec"))
-		obj = []byte(strings.ReplaceAll(string(obj), "object.metadata", "object.spec.jobTemplate.spec.template.metadata"))
-		obj = []byte(strings.ReplaceAll(string(obj), "oldObject.metadata", "oldObject.spec.jobTemplate.spec.template.metadata"))
+func updateCELFields(pbyte []byte, kind string, custom []config.AutogenController) (obj []byte) {
+	if path := podTemplatePath(kind, custom); path != "" {
+		obj = []byte(strings.ReplaceAll(string(pbyte), "object.spec", "object."+path+".spec"))
+		obj = []byte(strings.ReplaceAll(string(obj), "oldObject.spec", "oldObject."+path+".spec"))
+		obj = []byte(strings.ReplaceAll(string(obj), "object.metadata", "object."+path+".metadata"))
+		obj = []byte(strings.ReplaceAll(string(obj), "oldObject.metadata", "oldObject."+path+".metadata"))
 	}
 	return obj
 }
+
+// podTemplatePath returns the path to the pod template of the kind autogen rules are converted for
+func podTemplatePath(kind string, custom []config.AutogenController) string {
+	switch kind {
+	case "Pod":
+		return "spec.template"
+	case "Cronjob":
+		return "spec.jobTemplate.spec.template"
+	}
+	for _, controller := range custom {
+		if controller.Kind == kind {
+			return controller.PodTemplatePath
+		}
+	}
+	return ""
+}
@@ -42,6 +42,33 @@ func UpdateStatus(client versioned.Interface, urLister kyvernov1beta1listers.Upd
 	return ur, nil
 }
 
+// UpdateClusterTargets records the state of the resources generated in remote clusters in the ur status,
+// the state of a target previously recorded is replaced
+func UpdateClusterTargets(client versioned.Interface, name string, targets []kyvernov1beta1.ClusterTargetStatus) error {
+	ur, err := client.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch update request")
+	}
+	latest := ur.DeepCopy()
+	for _, target := range targets {
+		found := false
+		for i, existing := range latest.Status.ClusterTargets {
+			if existing.Cluster == target.Cluster && existing.Resource.String() == target.Resource.String() {
+				latest.Status.ClusterTargets[i] = target
+				found = true
+				break
+			}
+		}
+		if !found {
+			latest.Status.ClusterTargets = append(latest.Status.ClusterTargets, target)
+		}
+	}
+	if _, err := client.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(context.TODO(), latest, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrapf(err, "failed to update ur cluster targets")
+	}
+	return nil
+}
+
 func PolicyKey(namespace, name string) string {
 	if namespace != "" {
 		return namespace + "/" + name
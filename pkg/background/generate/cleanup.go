@@ -8,10 +8,13 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"go.uber.org/multierr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
 )
 
 func (c *GenerateController) deleteDownstream(policy kyvernov1.PolicyInterface, ur *kyvernov1beta1.UpdateRequest) (err error) {
@@ -20,16 +23,30 @@ func (c *GenerateController) deleteDownstream(policy kyvernov1.PolicyInterface,
 	}
 
 	// handle data policy/rule deletion
-	if ur.Status.GeneratedResources != nil {
+	if ur.Status.GeneratedResources != nil || ur.Status.ClusterTargets != nil {
 		c.log.V(4).Info("policy/rule no longer exists, deleting the downstream resource based on synchronize", "ur", ur.Name, "policy", ur.Spec.Policy, "rule", ur.Spec.Rule)
+		if policy == nil {
+			policy = deletedPolicy(ur)
+		}
+		client, clientErr := c.targetClient(context.TODO(), policy, kyvernov1.Generation{})
+		if clientErr != nil {
+			_, err = c.statusControl.Failed(ur.GetName(), fmt.Sprintf("failed to get the target cluster client: %v", clientErr), nil)
+			return
+		}
 		var errs []error
 		failedDownstreams := []kyvernov1.ResourceSpec{}
 		for _, e := range ur.Status.GeneratedResources {
-			if err := c.client.DeleteResource(context.TODO(), e.GetAPIVersion(), e.GetKind(), e.GetNamespace(), e.GetName(), false); err != nil && !apierrors.IsNotFound(err) {
+			if err := client.DeleteResource(context.TODO(), e.GetAPIVersion(), e.GetKind(), e.GetNamespace(), e.GetName(), false); err != nil && !apierrors.IsNotFound(err) {
 				failedDownstreams = append(failedDownstreams, e)
 				errs = append(errs, err)
 			}
 		}
+		// the cluster targets are kept in the status, they are processed again when the ur is retried
+		for _, target := range ur.Status.ClusterTargets {
+			if err := c.deleteClusterTarget(policy, ur, target); err != nil {
+				errs = append(errs, err)
+			}
+		}
 
 		if len(errs) != 0 {
 			c.log.Error(multierr.Combine(errs...), "failed to clean up downstream resources on policy deletion")
@@ -49,6 +66,55 @@ func (c *GenerateController) deleteDownstream(policy kyvernov1.PolicyInterface,
 	return c.handleNonPolicyChanges(policy, ur)
 }
 
+// deletedPolicy returns a policy with the name and the service account of a deleted policy recorded in the ur
+func deletedPolicy(ur *kyvernov1beta1.UpdateRequest) kyvernov1.PolicyInterface {
+	namespace, name, _ := cache.SplitMetaNamespaceKey(ur.Spec.GetPolicyKey())
+	spec := kyvernov1.Spec{ServiceAccount: ur.Spec.ServiceAccount}
+	if namespace == "" {
+		return &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec}
+	}
+	return &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}, Spec: spec}
+}
+
+// deleteClusterTarget deletes the downstream resources of a deleted policy/rule in a remote cluster,
+// they are looked up by the policy and rule labels when the target doesn't name a resource.
+func (c *GenerateController) deleteClusterTarget(policy kyvernov1.PolicyInterface, ur *kyvernov1beta1.UpdateRequest, target kyvernov1beta1.ClusterTargetStatus) error {
+	client, err := c.targetClient(context.TODO(), policy, kyvernov1.Generation{Cluster: &target.Cluster})
+	if err != nil {
+		return fmt.Errorf("failed to get the client of cluster %s: %v", target.Cluster.String(), err)
+	}
+	downstreams := []kyvernov1.ResourceSpec{target.Resource}
+	if target.Resource.GetName() == "" {
+		policyNamespace, policyName, err := cache.SplitMetaNamespaceKey(ur.Spec.GetPolicyKey())
+		if err != nil {
+			return err
+		}
+		labels := map[string]string{
+			common.GeneratePolicyLabel:          policyName,
+			common.GeneratePolicyNamespaceLabel: policyNamespace,
+			common.GenerateRuleLabel:            ur.Spec.GetRuleName(),
+			kyverno.LabelAppManagedBy:           kyverno.ValueKyvernoApp,
+		}
+		list, err := common.FindDownstream(client, target.Resource.GetAPIVersion(), target.Resource.GetKind(), labels)
+		if err != nil {
+			return fmt.Errorf("failed to fetch downstream resources in cluster %s: %v", target.Cluster.String(), err)
+		}
+		downstreams = downstreams[:0]
+		for _, downstream := range list.Items {
+			downstreams = append(downstreams, common.ResourceSpecFromUnstructured(downstream))
+		}
+	}
+	var errs []error
+	for _, downstream := range downstreams {
+		if err := client.DeleteResource(context.TODO(), downstream.GetAPIVersion(), downstream.GetKind(), downstream.GetNamespace(), downstream.GetName(), false); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete %s in cluster %s: %v", downstream.String(), target.Cluster.String(), err))
+		} else {
+			c.log.V(4).Info("downstream resource deleted", "cluster", target.Cluster.String(), "resource", downstream.String())
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
 func (c *GenerateController) handleNonPolicyChanges(policy kyvernov1.PolicyInterface, ur *kyvernov1beta1.UpdateRequest) error {
 	if !ur.Spec.DeleteDownstream {
 		return nil
@@ -65,7 +131,11 @@ func (c *GenerateController) handleNonPolicyChanges(policy kyvernov1.PolicyInter
 			kyverno.LabelAppManagedBy:           kyverno.ValueKyvernoApp,
 		}
 
-		downstreams, err := c.getDownstreams(rule, labels, ur)
+		client, err := c.targetClient(context.TODO(), policy, rule.Generation)
+		if err != nil {
+			return fmt.Errorf("failed to get the target cluster client: %v", err)
+		}
+		downstreams, err := c.getDownstreams(client, rule, labels, ur)
 		if err != nil {
 			return fmt.Errorf("failed to fetch downstream resources: %v", err)
 		}
@@ -73,7 +143,7 @@ func (c *GenerateController) handleNonPolicyChanges(policy kyvernov1.PolicyInter
 		failedDownstreams := []kyvernov1.ResourceSpec{}
 		for _, downstream := range downstreams.Items {
 			spec := common.ResourceSpecFromUnstructured(downstream)
-			if err := c.client.DeleteResource(context.TODO(), downstream.GetAPIVersion(), downstream.GetKind(), downstream.GetNamespace(), downstream.GetName(), false); err != nil && !apierrors.IsNotFound(err) {
+			if err := client.DeleteResource(context.TODO(), downstream.GetAPIVersion(), downstream.GetKind(), downstream.GetNamespace(), downstream.GetName(), false); err != nil && !apierrors.IsNotFound(err) {
 				failedDownstreams = append(failedDownstreams, spec)
 				errs = append(errs, err)
 			} else {
@@ -95,7 +165,7 @@ func (c *GenerateController) handleNonPolicyChanges(policy kyvernov1.PolicyInter
 	return nil
 }
 
-func (c *GenerateController) getDownstreams(rule kyvernov1.Rule, selector map[string]string, ur *kyvernov1beta1.UpdateRequest) (*unstructured.UnstructuredList, error) {
+func (c *GenerateController) getDownstreams(client dclient.Interface, rule kyvernov1.Rule, selector map[string]string, ur *kyvernov1beta1.UpdateRequest) (*unstructured.UnstructuredList, error) {
 	gv, err := ur.Spec.GetResource().GetGroupVersion()
 	if err != nil {
 		return nil, err
@@ -109,7 +179,7 @@ func (c *GenerateController) getDownstreams(rule kyvernov1.Rule, selector map[st
 	if rule.Generation.GetKind() != "" {
 		// Fetch downstream resources using trigger uid label
 		c.log.V(4).Info("fetching downstream resource by the UID", "APIVersion", rule.Generation.GetAPIVersion(), "kind", rule.Generation.GetKind(), "selector", selector)
-		downstreamList, err := common.FindDownstream(c.client, rule.Generation.GetAPIVersion(), rule.Generation.GetKind(), selector)
+		downstreamList, err := common.FindDownstream(client, rule.Generation.GetAPIVersion(), rule.Generation.GetKind(), selector)
 		if err != nil {
 			return nil, err
 		}
@@ -119,7 +189,7 @@ func (c *GenerateController) getDownstreams(rule kyvernov1.Rule, selector map[st
 			delete(selector, common.GenerateTriggerUIDLabel)
 			selector[common.GenerateTriggerNameLabel] = ur.Spec.GetResource().GetName()
 			c.log.V(4).Info("fetching downstream resource by the name", "APIVersion", rule.Generation.GetAPIVersion(), "kind", rule.Generation.GetKind(), "selector", selector)
-			dsList, err := common.FindDownstream(c.client, rule.Generation.GetAPIVersion(), rule.Generation.GetKind(), selector)
+			dsList, err := common.FindDownstream(client, rule.Generation.GetAPIVersion(), rule.Generation.GetKind(), selector)
 			if err != nil {
 				return nil, err
 			}
@@ -133,7 +203,7 @@ func (c *GenerateController) getDownstreams(rule kyvernov1.Rule, selector map[st
 	for _, kind := range rule.Generation.CloneList.Kinds {
 		apiVersion, kind := kubeutils.GetKindFromGVK(kind)
 		c.log.V(4).Info("fetching downstream cloneList resources by the UID", "APIVersion", apiVersion, "kind", kind, "selector", selector)
-		dsList, err = common.FindDownstream(c.client, apiVersion, kind, selector)
+		dsList, err = common.FindDownstream(client, apiVersion, kind, selector)
 		if err != nil {
 			return nil, err
 		}
@@ -142,7 +212,7 @@ func (c *GenerateController) getDownstreams(rule kyvernov1.Rule, selector map[st
 			delete(selector, common.GenerateTriggerUIDLabel)
 			selector[common.GenerateTriggerNameLabel] = ur.Spec.GetResource().GetName()
 			c.log.V(4).Info("fetching downstream resource by the name", "APIVersion", rule.Generation.GetAPIVersion(), "kind", rule.Generation.GetKind(), "selector", selector)
-			dsList, err = common.FindDownstream(c.client, rule.Generation.GetAPIVersion(), rule.Generation.GetKind(), selector)
+			dsList, err = common.FindDownstream(client, rule.Generation.GetAPIVersion(), rule.Generation.GetKind(), selector)
 			if err != nil {
 				return nil, err
 			}
@@ -0,0 +1,136 @@
+package generate
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"gotest.tools/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type fakeStatusControl struct {
+	state   kyvernov1beta1.UpdateRequestState
+	message string
+}
+
+func (sc *fakeStatusControl) Failed(_, message string, _ []kyvernov1.ResourceSpec) (*kyvernov1beta1.UpdateRequest, error) {
+	sc.state, sc.message = kyvernov1beta1.Failed, message
+	return nil, nil
+}
+
+func (sc *fakeStatusControl) Success(string, []kyvernov1.ResourceSpec) (*kyvernov1beta1.UpdateRequest, error) {
+	sc.state, sc.message = kyvernov1beta1.Completed, ""
+	return nil, nil
+}
+
+func (sc *fakeStatusControl) Skip(string, []kyvernov1.ResourceSpec) (*kyvernov1beta1.UpdateRequest, error) {
+	sc.state, sc.message = kyvernov1beta1.Skip, ""
+	return nil, nil
+}
+
+func newConfigMap(namespace, name, rule string) *unstructured.Unstructured {
+	configMap := &unstructured.Unstructured{}
+	configMap.SetAPIVersion("v1")
+	configMap.SetKind("ConfigMap")
+	configMap.SetNamespace(namespace)
+	configMap.SetName(name)
+	configMap.SetLabels(map[string]string{
+		common.GeneratePolicyLabel:          "generate-config",
+		common.GeneratePolicyNamespaceLabel: "",
+		common.GenerateRuleLabel:            rule,
+		kyverno.LabelAppManagedBy:           kyverno.ValueKyvernoApp,
+	})
+	return configMap
+}
+
+func newFakeClient(t *testing.T, objects ...runtime.Object) dclient.Interface {
+	t.Helper()
+	gvrToListKind := map[schema.GroupVersionResource]string{{Version: "v1", Resource: "configmaps"}: "ConfigMapList"}
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), gvrToListKind, objects...)
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+	return client
+}
+
+func Test_deleteDownstream_clusterTargets(t *testing.T) {
+	cluster := kyvernov1.ClusterReference{KubeconfigSecret: kyvernov1.SecretReference{Namespace: "clusters", Name: "management-kubeconfig"}}
+	local := newFakeClient(t, newConfigMap("tenants", "local", "local"))
+	remote := newFakeClient(t, newConfigMap("tenants", "remote", "remote"), newConfigMap("tenants", "other", "other"))
+	ur := &kyvernov1beta1.UpdateRequest{
+		Spec: kyvernov1beta1.UpdateRequestSpec{Policy: "generate-config", Rule: "remote", DeleteDownstream: true},
+		Status: kyvernov1beta1.UpdateRequestStatus{
+			GeneratedResources: []kyvernov1.ResourceSpec{{APIVersion: "v1", Kind: "ConfigMap", Namespace: "tenants", Name: "local"}},
+			ClusterTargets: []kyvernov1beta1.ClusterTargetStatus{{
+				Cluster:  cluster,
+				Resource: kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap"},
+				State:    kyvernov1beta1.Pending,
+			}},
+		},
+	}
+
+	statusControl := &fakeStatusControl{}
+	c := NewGenerateControllerWithOnlyClient(local, nil)
+	c.statusControl = statusControl
+	c.log = logr.Discard()
+	// the remote cluster can't be reached, the ur is retried
+	assert.NilError(t, c.deleteDownstream(nil, ur))
+	assert.Equal(t, statusControl.state, kyvernov1beta1.Failed)
+	assert.Assert(t, strings.Contains(statusControl.message, "failed to get the client of cluster clusters/management-kubeconfig"), statusControl.message)
+	_, err := remote.GetResource(context.TODO(), "v1", "ConfigMap", "tenants", "remote")
+	assert.NilError(t, err)
+
+	c.clusters = fakeClusters{"clusters/management-kubeconfig/value": remote}
+	assert.NilError(t, c.deleteDownstream(nil, ur))
+	assert.Equal(t, statusControl.state, kyvernov1beta1.Completed)
+	_, err = local.GetResource(context.TODO(), "v1", "ConfigMap", "tenants", "local")
+	assert.Assert(t, apierrors.IsNotFound(err))
+	// only the downstream resources of the deleted rule are deleted in the remote cluster
+	_, err = remote.GetResource(context.TODO(), "v1", "ConfigMap", "tenants", "remote")
+	assert.Assert(t, apierrors.IsNotFound(err))
+	_, err = remote.GetResource(context.TODO(), "v1", "ConfigMap", "tenants", "other")
+	assert.NilError(t, err)
+}
+
+func Test_deleteDownstream_serviceAccount(t *testing.T) {
+	local := newFakeClient(t, newConfigMap("tenants", "local", "local"))
+	impersonated := newFakeClient(t, newConfigMap("tenants", "local", "local"))
+	ur := &kyvernov1beta1.UpdateRequest{
+		Spec: kyvernov1beta1.UpdateRequestSpec{
+			Policy:           "generate-config",
+			Rule:             "local",
+			DeleteDownstream: true,
+			ServiceAccount:   &kyvernov1.ServiceAccountReference{Namespace: "tenants", Name: "generator"},
+		},
+		Status: kyvernov1beta1.UpdateRequestStatus{
+			GeneratedResources: []kyvernov1.ResourceSpec{{APIVersion: "v1", Kind: "ConfigMap", Namespace: "tenants", Name: "local"}},
+		},
+	}
+
+	statusControl := &fakeStatusControl{}
+	c := NewGenerateControllerWithOnlyClient(local, nil)
+	c.statusControl = statusControl
+	c.log = logr.Discard()
+	// the policy is deleted, the service account recorded in the ur can't be impersonated
+	assert.NilError(t, c.deleteDownstream(nil, ur))
+	assert.Equal(t, statusControl.state, kyvernov1beta1.Failed)
+	assert.Equal(t, statusControl.message, "failed to get the target cluster client: impersonation of service account system:serviceaccount:tenants:generator is not supported")
+
+	c.impersonator = fakeImpersonator{"system:serviceaccount:tenants:generator": impersonated}
+	assert.NilError(t, c.deleteDownstream(nil, ur))
+	assert.Equal(t, statusControl.state, kyvernov1beta1.Completed)
+	_, err := impersonated.GetResource(context.TODO(), "v1", "ConfigMap", "tenants", "local")
+	assert.Assert(t, apierrors.IsNotFound(err))
+	// the downstream resources are not deleted with the controller identity
+	_, err = local.GetResource(context.TODO(), "v1", "ConfigMap", "tenants", "local")
+	assert.NilError(t, err)
+}
@@ -0,0 +1,48 @@
+package generate
+
+import (
+	"context"
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+)
+
+// targetClient returns the client managing the targets of a generate rule,
+// the targets are either in the local cluster or in the remote cluster configured in the rule.
+// Local targets are managed on behalf of the policy service account when one is configured.
+func (c *GenerateController) targetClient(ctx context.Context, policy kyvernov1.PolicyInterface, generation kyvernov1.Generation) (dclient.Interface, error) {
+	cluster := generation.Cluster
+	if cluster == nil {
+		serviceAccount := policy.GetSpec().ServiceAccount
+		if serviceAccount == nil {
+			return c.client, nil
+		}
+		if c.impersonator == nil {
+			return nil, fmt.Errorf("impersonation of service account %s is not supported", serviceAccount.Username(policy.GetNamespace()))
+		}
+		return c.impersonator.Impersonate(serviceAccount.Username(policy.GetNamespace()))
+	}
+	// the policy service account can't be impersonated in the remote cluster
+	if serviceAccount := policy.GetSpec().ServiceAccount; serviceAccount != nil {
+		return nil, fmt.Errorf("remote cluster %s cannot be targeted on behalf of service account %s", cluster, serviceAccount.Username(policy.GetNamespace()))
+	}
+	if c.clusters == nil {
+		return nil, fmt.Errorf("remote cluster %s is not supported", cluster)
+	}
+	return c.clusters.Get(ctx, cluster.KubeconfigSecret.Namespace, cluster.KubeconfigSecret.Name, cluster.GetKey())
+}
+
+func newClusterTargetStatus(generation kyvernov1.Generation, err error) kyvernov1beta1.ClusterTargetStatus {
+	status := kyvernov1beta1.ClusterTargetStatus{
+		Cluster:  *generation.Cluster,
+		Resource: generation.ResourceSpec,
+		State:    kyvernov1beta1.Completed,
+	}
+	if err != nil {
+		status.State = kyvernov1beta1.Failed
+		status.Message = err.Error()
+	}
+	return status
+}
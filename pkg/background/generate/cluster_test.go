@@ -0,0 +1,87 @@
+package generate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+type fakeImpersonator map[string]dclient.Interface
+
+func (i fakeImpersonator) Impersonate(username string) (dclient.Interface, error) {
+	if client, ok := i[username]; ok {
+		return client, nil
+	}
+	return nil, errors.New("not found")
+}
+
+type fakeClusters map[string]dclient.Interface
+
+func (c fakeClusters) Get(_ context.Context, namespace, name, key string) (dclient.Interface, error) {
+	if client, ok := c[namespace+"/"+name+"/"+key]; ok {
+		return client, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (c fakeClusters) Prune(sets.Set[string]) {}
+
+func Test_targetClient(t *testing.T) {
+	local, err := dclient.NewFakeClient(runtime.NewScheme(), nil)
+	assert.NilError(t, err)
+	remote, err := dclient.NewFakeClient(runtime.NewScheme(), nil)
+	assert.NilError(t, err)
+	cluster := &kyvernov1.ClusterReference{KubeconfigSecret: kyvernov1.SecretReference{Namespace: "clusters", Name: "management-kubeconfig"}}
+	policy := &kyvernov1.ClusterPolicy{}
+
+	c := NewGenerateControllerWithOnlyClient(local, nil)
+	client, err := c.targetClient(context.TODO(), policy, kyvernov1.Generation{})
+	assert.NilError(t, err)
+	assert.Equal(t, client, local)
+	_, err = c.targetClient(context.TODO(), policy, kyvernov1.Generation{Cluster: cluster})
+	assert.Error(t, err, "remote cluster clusters/management-kubeconfig is not supported")
+
+	c.clusters = fakeClusters{"clusters/management-kubeconfig/value": remote}
+	client, err = c.targetClient(context.TODO(), policy, kyvernov1.Generation{Cluster: cluster})
+	assert.NilError(t, err)
+	assert.Equal(t, client, remote)
+
+	impersonated, err := dclient.NewFakeClient(runtime.NewScheme(), nil)
+	assert.NilError(t, err)
+	policy.Spec.ServiceAccount = &kyvernov1.ServiceAccountReference{Namespace: "tenants", Name: "generator"}
+	_, err = c.targetClient(context.TODO(), policy, kyvernov1.Generation{})
+	assert.Error(t, err, "impersonation of service account system:serviceaccount:tenants:generator is not supported")
+
+	c.impersonator = fakeImpersonator{"system:serviceaccount:tenants:generator": impersonated}
+	client, err = c.targetClient(context.TODO(), policy, kyvernov1.Generation{})
+	assert.NilError(t, err)
+	assert.Equal(t, client, impersonated)
+	// the service account can't be impersonated in the remote cluster, its kubeconfig isn't used instead
+	_, err = c.targetClient(context.TODO(), policy, kyvernov1.Generation{Cluster: cluster})
+	assert.Error(t, err, "remote cluster clusters/management-kubeconfig cannot be targeted on behalf of service account system:serviceaccount:tenants:generator")
+}
+
+func Test_newClusterTargetStatus(t *testing.T) {
+	generation := kyvernov1.Generation{
+		ResourceSpec: kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ResourceQuota", Namespace: "tenants", Name: "default"},
+		Cluster:      &kyvernov1.ClusterReference{KubeconfigSecret: kyvernov1.SecretReference{Namespace: "clusters", Name: "management-kubeconfig"}},
+	}
+	assert.DeepEqual(t, newClusterTargetStatus(generation, nil), kyvernov1beta1.ClusterTargetStatus{
+		Cluster:  *generation.Cluster,
+		Resource: generation.ResourceSpec,
+		State:    kyvernov1beta1.Completed,
+	})
+	assert.DeepEqual(t, newClusterTargetStatus(generation, errors.New("connection refused")), kyvernov1beta1.ClusterTargetStatus{
+		Cluster:  *generation.Cluster,
+		Resource: generation.ResourceSpec,
+		State:    kyvernov1beta1.Failed,
+		Message:  "connection refused",
+	})
+}
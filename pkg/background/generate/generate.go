@@ -43,6 +43,8 @@ import (
 type GenerateController struct {
 	// clients
 	client        dclient.Interface
+	clusters      dclient.Clusters
+	impersonator  dclient.Impersonator
 	kyvernoClient versioned.Interface
 	statusControl common.StatusControlInterface
 	engine        engineapi.Engine
@@ -63,6 +65,8 @@ type GenerateController struct {
 // NewGenerateController returns an instance of the Generate-Request Controller
 func NewGenerateController(
 	client dclient.Interface,
+	clusters dclient.Clusters,
+	impersonator dclient.Impersonator,
 	kyvernoClient versioned.Interface,
 	statusControl common.StatusControlInterface,
 	engine engineapi.Engine,
@@ -77,6 +81,8 @@ func NewGenerateController(
 ) *GenerateController {
 	c := GenerateController{
 		client:        client,
+		clusters:      clusters,
+		impersonator:  impersonator,
 		kyvernoClient: kyvernoClient,
 		statusControl: statusControl,
 		engine:        engine,
@@ -92,12 +98,21 @@ func NewGenerateController(
 	return &c
 }
 
-func (c *GenerateController) ProcessUR(ur *kyvernov1beta1.UpdateRequest) error {
+func (c *GenerateController) ProcessUR(ctx context.Context, ur *kyvernov1beta1.UpdateRequest) error {
 	logger := c.log.WithValues("name", ur.GetName(), "policy", ur.Spec.GetPolicyKey(), "resource", ur.Spec.GetResource().String())
 	var err error
 	var genResources []kyvernov1.ResourceSpec
 	logger.Info("start processing UR", "ur", ur.Name, "resourceVersion", ur.GetResourceVersion())
 
+	// the downstream resources of a deleted policy/rule in remote clusters are not tied to a trigger
+	if ur.Spec.DeleteDownstream && ur.Status.ClusterTargets != nil {
+		policy, err := c.getPolicySpec(*ur)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		return c.deleteDownstream(policy, ur)
+	}
+
 	trigger, err := c.getTrigger(ur.Spec)
 	if err != nil {
 		logger.V(3).Info("the trigger resource does not exist or is pending creation, re-queueing", "details", err.Error())
@@ -112,12 +127,12 @@ func (c *GenerateController) ProcessUR(ur *kyvernov1beta1.UpdateRequest) error {
 	}
 
 	namespaceLabels := engineutils.GetNamespaceSelectorsFromNamespaceLister(trigger.GetKind(), trigger.GetNamespace(), c.nsLister, logger)
-	genResources, err = c.applyGenerate(*trigger, *ur, namespaceLabels)
+	genResources, err = c.applyGenerate(ctx, *trigger, *ur, namespaceLabels)
 	if err != nil {
 		if strings.Contains(err.Error(), doesNotApply) {
 			ur.Status.State = kyvernov1beta1.Completed
 			logger.V(4).Info(fmt.Sprintf("%s, updating UR status to Completed", err.Error()))
-			_, err := c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(context.TODO(), ur, metav1.UpdateOptions{})
+			_, err := c.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(ctx, ur, metav1.UpdateOptions{})
 			return err
 		}
 
@@ -199,7 +214,7 @@ func (c *GenerateController) getTriggerForCreateOperation(spec kyvernov1beta1.Up
 	return trigger, err
 }
 
-func (c *GenerateController) applyGenerate(resource unstructured.Unstructured, ur kyvernov1beta1.UpdateRequest, namespaceLabels map[string]string) ([]kyvernov1.ResourceSpec, error) {
+func (c *GenerateController) applyGenerate(ctx context.Context, resource unstructured.Unstructured, ur kyvernov1beta1.UpdateRequest, namespaceLabels map[string]string) ([]kyvernov1.ResourceSpec, error) {
 	logger := c.log.WithValues("name", ur.GetName(), "policy", ur.Spec.GetPolicyKey(), "resource", ur.Spec.GetResource().String())
 	logger.V(3).Info("applying generate policy rule")
 
@@ -230,7 +245,7 @@ func (c *GenerateController) applyGenerate(resource unstructured.Unstructured, u
 	}
 
 	// check if the policy still applies to the resource
-	engineResponse := c.engine.Generate(context.Background(), policyContext)
+	engineResponse := c.engine.Generate(ctx, policyContext)
 	if len(engineResponse.PolicyResponse.Rules) == 0 {
 		logger.V(4).Info(doesNotApply)
 		return nil, errors.New(doesNotApply)
@@ -285,7 +300,12 @@ func (c *GenerateController) applyGenerate(resource unstructured.Unstructured, u
 	}
 
 	// Apply the generate rule on resource
-	genResources, err := c.ApplyGeneratePolicy(logger, policyContext, ur, applicableRules)
+	genResources, clusterTargets, err := c.ApplyGeneratePolicy(logger, policyContext, ur, applicableRules)
+	if len(clusterTargets) != 0 {
+		if err := common.UpdateClusterTargets(c.kyvernoClient, ur.GetName(), clusterTargets); err != nil {
+			logger.Error(err, "failed to update the status of the remote cluster targets")
+		}
+	}
 
 	// generate events.
 	if err == nil {
@@ -335,7 +355,7 @@ func updateStatus(statusControl common.StatusControlInterface, ur kyvernov1beta1
 	return nil
 }
 
-func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext *engine.PolicyContext, ur kyvernov1beta1.UpdateRequest, applicableRules []string) (genResources []kyvernov1.ResourceSpec, err error) {
+func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext *engine.PolicyContext, ur kyvernov1beta1.UpdateRequest, applicableRules []string) (genResources []kyvernov1.ResourceSpec, clusterTargets []kyvernov1beta1.ClusterTargetStatus, err error) {
 	// Get the response as the actions to be performed on the resource
 	// - - substitute values
 	policy := policyContext.Policy()
@@ -346,7 +366,7 @@ func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext
 	applyRules := policy.GetSpec().GetApplyRules()
 	applyCount := 0
 
-	for _, rule := range autogen.ComputeRules(policy) {
+	for _, rule := range autogen.ComputeRules(policy, c.configuration.GetAutogenControllers()) {
 		var err error
 		if !rule.HasGenerate() {
 			continue
@@ -365,25 +385,34 @@ func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext
 		// add configmap json data to context
 		if err := c.engine.ContextLoader(policyContext.Policy(), rule)(context.TODO(), rule.Context, policyContext.JSONContext()); err != nil {
 			log.Error(err, "cannot add configmaps to context")
-			return nil, err
+			return nil, clusterTargets, err
 		}
 
 		if rule, err = variables.SubstituteAllInRule(log, policyContext.JSONContext(), rule); err != nil {
 			log.Error(err, "variable substitution failed for rule %s", rule.Name)
-			return nil, err
+			return nil, clusterTargets, err
 		}
 
-		genResource, err = applyRule(log, c.client, rule, resource, jsonContext, policy, ur)
+		client, err := c.targetClient(context.TODO(), policy, rule.Generation)
+		if err == nil {
+			genResource, err = applyRule(log, client, rule, resource, jsonContext, policy, ur)
+		}
+		if rule.Generation.Cluster != nil {
+			clusterTargets = append(clusterTargets, newClusterTargetStatus(rule.Generation, err))
+		}
 		if err != nil {
 			log.Error(err, "failed to apply generate rule", "policy", policy.GetName(), "rule", rule.Name, "resource", resource.GetName())
-			return nil, err
+			return nil, clusterTargets, err
 		}
 		ruleNameToProcessingTime[rule.Name] = time.Since(startTime)
-		genResources = append(genResources, genResource...)
+		// resources generated in remote clusters are reported in the cluster targets
+		if rule.Generation.Cluster == nil {
+			genResources = append(genResources, genResource...)
+		}
 		applyCount++
 	}
 
-	return genResources, nil
+	return genResources, clusterTargets, nil
 }
 
 func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, trigger unstructured.Unstructured, ctx enginecontext.EvalInterface, policy kyvernov1.PolicyInterface, ur kyvernov1beta1.UpdateRequest) ([]kyvernov1.ResourceSpec, error) {
@@ -408,7 +437,7 @@ func applyRule(log logr.Logger, client dclient.Interface, rule kyvernov1.Rule, t
 		targetMeta := response.GetTarget()
 		if response.GetError() != nil {
 			logger.Error(response.GetError(), "failed to generate resource", "mode", response.GetAction())
-			return newGenResources, err
+			return newGenResources, response.GetError()
 		}
 
 		if response.GetAction() == Skip {
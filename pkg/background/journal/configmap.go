@@ -0,0 +1,281 @@
+package journal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const (
+	// LabelJournal is set on the ConfigMaps holding journal shards, its value is the journal name.
+	LabelJournal = "kyverno.io/decision-journal"
+	// DefaultShardSize is the default maximum size of a shard, well below the ConfigMap size limit.
+	DefaultShardSize = 512 * 1024
+	// DefaultMaxShards is the default maximum number of shards, the oldest entries are dropped beyond it.
+	DefaultMaxShards = 8
+	dataKey          = "entries"
+)
+
+type configMapJournal struct {
+	client    corev1client.ConfigMapInterface
+	name      string
+	shardSize int
+	maxShards int
+	// lock protects the pending entries
+	lock    sync.Mutex
+	pending []Entry
+	// flushLock protects the last known state of the shards
+	flushLock sync.Mutex
+	loaded    bool
+	state     []corev1.ConfigMap
+}
+
+// NewConfigMapJournal creates a Journal storing entries in ConfigMap shards named <name>-<index>.
+// Recorded entries are buffered in memory and appended to the last shard when the journal is flushed.
+// When the last shard is full the journal is compacted and rewritten, keeping the most recent entries
+// that fit in maxShards shards.
+// The last known state of the shards is kept in memory, it is reloaded when a write conflicts.
+func NewConfigMapJournal(client kubernetes.Interface, namespace string, name string, shardSize int, maxShards int) Journal {
+	if shardSize <= 0 {
+		shardSize = DefaultShardSize
+	}
+	if maxShards <= 0 {
+		maxShards = DefaultMaxShards
+	}
+	return &configMapJournal{
+		client:    client.CoreV1().ConfigMaps(namespace),
+		name:      name,
+		shardSize: shardSize,
+		maxShards: maxShards,
+	}
+}
+
+func (j *configMapJournal) Record(_ context.Context, entries ...Entry) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	j.pending = append(j.pending, entries...)
+	return nil
+}
+
+func (j *configMapJournal) Flush(ctx context.Context) error {
+	j.flushLock.Lock()
+	defer j.flushLock.Unlock()
+	j.lock.Lock()
+	entries := j.pending
+	j.pending = nil
+	j.lock.Unlock()
+	if len(entries) == 0 {
+		return nil
+	}
+	err := j.flush(ctx, entries)
+	if apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err) {
+		// another instance wrote the journal, reload it and try again
+		j.loaded = false
+		err = j.flush(ctx, entries)
+	}
+	if err != nil {
+		// keep the entries for the next flush
+		j.loaded = false
+		j.lock.Lock()
+		j.pending = append(entries, j.pending...)
+		j.lock.Unlock()
+	}
+	return err
+}
+
+func (j *configMapJournal) Load(ctx context.Context) ([]Entry, error) {
+	j.flushLock.Lock()
+	defer j.flushLock.Unlock()
+	if err := j.load(ctx); err != nil {
+		return nil, err
+	}
+	entries, err := decodeShards(j.state...)
+	if err != nil {
+		return nil, err
+	}
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	return append(entries, j.pending...), nil
+}
+
+// load reads the shards from the cluster.
+func (j *configMapJournal) load(ctx context.Context) error {
+	shards, err := j.shards(ctx)
+	if err != nil {
+		return err
+	}
+	j.state = shards
+	j.loaded = true
+	return nil
+}
+
+// flush appends entries to the last shard, or rewrites the journal when it is full.
+func (j *configMapJournal) flush(ctx context.Context, entries []Entry) error {
+	if !j.loaded {
+		if err := j.load(ctx); err != nil {
+			return err
+		}
+	}
+	if len(j.state) == 0 {
+		return j.write(ctx, j.rotate(Compact(entries...)))
+	}
+	data, err := encode(entries...)
+	if err != nil {
+		return err
+	}
+	last := j.state[len(j.state)-1].DeepCopy()
+	if len(last.Data[dataKey])+len(data) <= j.shardSize {
+		if last.Data == nil {
+			last.Data = map[string]string{}
+		}
+		last.Data[dataKey] += string(data)
+		updated, err := j.client.Update(ctx, last, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+		j.state[len(j.state)-1] = *updated
+		return nil
+	}
+	recorded, err := decodeShards(j.state...)
+	if err != nil {
+		return err
+	}
+	return j.write(ctx, j.rotate(Compact(append(recorded, entries...)...)))
+}
+
+// rotate drops the oldest entries that don't fit in the maximum number of shards.
+func (j *configMapJournal) rotate(entries []Entry) []Entry {
+	size := 0
+	for i := len(entries) - 1; i >= 0; i-- {
+		data, err := encode(entries[i])
+		if err != nil {
+			continue
+		}
+		size += len(data)
+		if size > j.shardSize*j.maxShards {
+			return entries[i+1:]
+		}
+	}
+	return entries
+}
+
+// shards returns the journal shards ordered by index.
+func (j *configMapJournal) shards(ctx context.Context) ([]corev1.ConfigMap, error) {
+	list, err := j.client.List(ctx, metav1.ListOptions{LabelSelector: LabelJournal + "=" + j.name})
+	if err != nil {
+		return nil, err
+	}
+	shards := list.Items
+	sort.Slice(shards, func(a, b int) bool {
+		return shardIndex(shards[a].Name) < shardIndex(shards[b].Name)
+	})
+	return shards, nil
+}
+
+// write rewrites the journal with the given entries, reusing existing shards and deleting the ones left over.
+func (j *configMapJournal) write(ctx context.Context, entries []Entry) error {
+	var chunks []string
+	var current bytes.Buffer
+	for _, entry := range entries {
+		data, err := encode(entry)
+		if err != nil {
+			return err
+		}
+		if current.Len() > 0 && current.Len()+len(data) > j.shardSize {
+			chunks = append(chunks, current.String())
+			current.Reset()
+		}
+		current.Write(data)
+	}
+	if current.Len() > 0 || len(chunks) == 0 {
+		chunks = append(chunks, current.String())
+	}
+	shards := j.state
+	// the state is reloaded if anything goes wrong
+	j.loaded = false
+	var state []corev1.ConfigMap
+	for i, chunk := range chunks {
+		if i < len(shards) {
+			shard := shards[i].DeepCopy()
+			shard.Data = map[string]string{dataKey: chunk}
+			updated, err := j.client.Update(ctx, shard, metav1.UpdateOptions{})
+			if err != nil {
+				return err
+			}
+			state = append(state, *updated)
+			continue
+		}
+		shard := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("%s-%d", j.name, i),
+				Labels: map[string]string{
+					kyverno.LabelAppManagedBy: kyverno.ValueKyvernoApp,
+					LabelJournal:              j.name,
+				},
+			},
+			Data: map[string]string{dataKey: chunk},
+		}
+		created, err := j.client.Create(ctx, shard, metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+		state = append(state, *created)
+	}
+	for i := len(chunks); i < len(shards); i++ {
+		if err := j.client.Delete(ctx, shards[i].Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	j.state = state
+	j.loaded = true
+	return nil
+}
+
+func shardIndex(name string) int {
+	index, err := strconv.Atoi(name[strings.LastIndex(name, "-")+1:])
+	if err != nil {
+		return -1
+	}
+	return index
+}
+
+// encode encodes entries as JSON lines.
+func encode(entries ...Entry) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return nil, err
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+func decodeShards(shards ...corev1.ConfigMap) ([]Entry, error) {
+	var entries []Entry
+	for _, shard := range shards {
+		for _, line := range strings.Split(shard.Data[dataKey], "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			var entry Entry
+			if err := json.Unmarshal([]byte(line), &entry); err != nil {
+				return nil, fmt.Errorf("failed to decode journal shard %s: %w", shard.Name, err)
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,82 @@
+package journal
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Entry records a decision taken by the background controller for a trigger resource.
+type Entry struct {
+	// Time is the unix time at which the decision was recorded.
+	Time int64 `json:"t"`
+	// Type is the type of the update request that produced the decision.
+	Type kyvernov1beta1.RequestType `json:"type"`
+	// Policy is the key of the policy.
+	Policy string `json:"policy"`
+	// Rule is the name of the rule.
+	Rule string `json:"rule"`
+	// Synchronize is set when the targets are kept in sync with the trigger.
+	Synchronize bool `json:"sync,omitempty"`
+	// Deleted is set when the relationship between the trigger and the targets was removed.
+	Deleted bool `json:"deleted,omitempty"`
+	// Trigger is the resource that triggered the rule.
+	Trigger kyvernov1.ResourceSpec `json:"trigger"`
+	// Targets are the resources produced for the trigger.
+	Targets []kyvernov1.ResourceSpec `json:"targets,omitempty"`
+}
+
+// key identifies the trigger/rule relationship an entry applies to.
+func (e Entry) key() string {
+	return strings.Join([]string{
+		string(e.Type),
+		e.Policy,
+		e.Rule,
+		e.Trigger.APIVersion,
+		e.Trigger.Kind,
+		e.Trigger.Namespace,
+		e.Trigger.Name,
+	}, "|")
+}
+
+// Journal is an append-only log of background decisions.
+type Journal interface {
+	// Record appends entries to the journal, entries may be buffered until the journal is flushed.
+	Record(context.Context, ...Entry) error
+	// Flush persists the buffered entries.
+	Flush(context.Context) error
+	// Load returns all the entries in the journal, in the order they were recorded.
+	Load(context.Context) ([]Entry, error)
+}
+
+// Run flushes the journal periodically until the context is cancelled, the remaining entries are flushed on exit.
+func Run(ctx context.Context, logger logr.Logger, journal Journal, period time.Duration) {
+	flush := func(ctx context.Context) {
+		if err := journal.Flush(ctx); err != nil {
+			logger.Error(err, "failed to flush decision journal")
+		}
+	}
+	wait.UntilWithContext(ctx, flush, period)
+	flush(context.Background())
+}
+
+// Compact keeps the latest entry of every trigger/rule relationship and drops deleted relationships.
+// The order of the remaining entries is preserved.
+func Compact(entries ...Entry) []Entry {
+	latest := map[string]int{}
+	for i, entry := range entries {
+		latest[entry.key()] = i
+	}
+	var compacted []Entry
+	for i, entry := range entries {
+		if latest[entry.key()] == i && !entry.Deleted {
+			compacted = append(compacted, entry)
+		}
+	}
+	return compacted
+}
@@ -0,0 +1,181 @@
+package journal
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	kubetesting "k8s.io/client-go/testing"
+)
+
+func newEntry(trigger string, rule string, targets ...string) Entry {
+	entry := Entry{
+		Type:        kyvernov1beta1.Generate,
+		Policy:      "add-networkpolicy",
+		Rule:        rule,
+		Synchronize: true,
+		Trigger:     kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "Namespace", Name: trigger},
+	}
+	for _, target := range targets {
+		entry.Targets = append(entry.Targets, kyvernov1.ResourceSpec{
+			APIVersion: "networking.k8s.io/v1",
+			Kind:       "NetworkPolicy",
+			Namespace:  trigger,
+			Name:       target,
+		})
+	}
+	return entry
+}
+
+func TestCompact(t *testing.T) {
+	deleted := newEntry("ns-2", "default-deny")
+	deleted.Deleted = true
+	entries := []Entry{
+		newEntry("ns-1", "default-deny", "old"),
+		newEntry("ns-2", "default-deny", "default-deny"),
+		newEntry("ns-1", "allow-dns", "allow-dns"),
+		newEntry("ns-1", "default-deny", "default-deny"),
+		deleted,
+	}
+	compacted := Compact(entries...)
+	assert.Equal(t, 2, len(compacted))
+	assert.Equal(t, "allow-dns", compacted[0].Rule)
+	assert.Equal(t, "default-deny", compacted[1].Rule)
+	assert.Equal(t, "default-deny", compacted[1].Targets[0].Name)
+}
+
+func TestConfigMapJournal(t *testing.T) {
+	ctx := context.TODO()
+	client := kubefake.NewSimpleClientset()
+	journal := NewConfigMapJournal(client, "kyverno", "journal", 0, 0)
+	entries, err := journal.Load(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(entries))
+	assert.NilError(t, journal.Record(ctx, newEntry("ns-1", "default-deny", "default-deny")))
+	assert.NilError(t, journal.Record(ctx, newEntry("ns-2", "default-deny", "default-deny"), newEntry("ns-3", "default-deny")))
+	// recorded entries are buffered until the journal is flushed
+	assert.Equal(t, 1, len(client.Actions()))
+	entries, err = journal.Load(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, 3, len(entries))
+	assert.NilError(t, journal.Flush(ctx))
+	entries, err = journal.Load(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, 3, len(entries))
+	assert.Equal(t, "ns-1", entries[0].Trigger.Name)
+	assert.Equal(t, "ns-3", entries[2].Trigger.Name)
+	shards, err := client.CoreV1().ConfigMaps("kyverno").List(ctx, metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(shards.Items))
+	assert.Equal(t, "journal-0", shards.Items[0].Name)
+}
+
+func TestConfigMapJournalCompaction(t *testing.T) {
+	ctx := context.TODO()
+	client := kubefake.NewSimpleClientset()
+	data, err := encode(newEntry("ns-1", "default-deny", "default-deny"))
+	assert.NilError(t, err)
+	// room for two entries per shard
+	journal := NewConfigMapJournal(client, "kyverno", "journal", 2*len(data), 0)
+	for _, trigger := range []string{"ns-1", "ns-2", "ns-3", "ns-1", "ns-2", "ns-1"} {
+		assert.NilError(t, journal.Record(ctx, newEntry(trigger, "default-deny", "default-deny")))
+		assert.NilError(t, journal.Flush(ctx))
+	}
+	entries, err := journal.Load(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, 3, len(Compact(entries...)))
+	shards, err := client.CoreV1().ConfigMaps("kyverno").List(ctx, metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, len(shards.Items) <= 2)
+	// deleted relationships are dropped by the compaction
+	deleted := newEntry("ns-3", "default-deny")
+	deleted.Deleted = true
+	for _, entry := range []Entry{deleted, newEntry("ns-2", "default-deny", "default-deny"), newEntry("ns-1", "default-deny", "default-deny")} {
+		assert.NilError(t, journal.Record(ctx, entry))
+		assert.NilError(t, journal.Flush(ctx))
+	}
+	entries, err = journal.Load(ctx)
+	assert.NilError(t, err)
+	compacted := Compact(entries...)
+	assert.Equal(t, 2, len(compacted))
+	assert.Equal(t, "ns-2", compacted[0].Trigger.Name)
+	assert.Equal(t, "ns-1", compacted[1].Trigger.Name)
+}
+
+func TestConfigMapJournalRotation(t *testing.T) {
+	ctx := context.TODO()
+	client := kubefake.NewSimpleClientset()
+	data, err := encode(newEntry("ns-1", "default-deny", "default-deny"))
+	assert.NilError(t, err)
+	// room for four entries in two shards
+	journal := NewConfigMapJournal(client, "kyverno", "journal", 2*len(data), 2)
+	for _, trigger := range []string{"ns-1", "ns-2", "ns-3", "ns-4", "ns-5", "ns-6"} {
+		assert.NilError(t, journal.Record(ctx, newEntry(trigger, "default-deny", "default-deny")))
+		assert.NilError(t, journal.Flush(ctx))
+	}
+	shards, err := client.CoreV1().ConfigMaps("kyverno").List(ctx, metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, len(shards.Items) <= 2)
+	entries, err := journal.Load(ctx)
+	assert.NilError(t, err)
+	// the oldest entries were dropped
+	assert.Assert(t, len(entries) <= 4)
+	assert.Equal(t, "ns-6", entries[len(entries)-1].Trigger.Name)
+	assert.Assert(t, entries[0].Trigger.Name != "ns-1")
+}
+
+// newVersionedClient returns a fake client rejecting ConfigMap updates with a stale resource version.
+func newVersionedClient() *kubefake.Clientset {
+	client := kubefake.NewSimpleClientset()
+	gvr := corev1.SchemeGroupVersion.WithResource("configmaps")
+	client.PrependReactor("create", "configmaps", func(action kubetesting.Action) (bool, runtime.Object, error) {
+		configMap := action.(kubetesting.CreateAction).GetObject().(*corev1.ConfigMap).DeepCopy()
+		configMap.ResourceVersion = "1"
+		return true, configMap, client.Tracker().Create(gvr, configMap, action.GetNamespace())
+	})
+	client.PrependReactor("update", "configmaps", func(action kubetesting.Action) (bool, runtime.Object, error) {
+		configMap := action.(kubetesting.UpdateAction).GetObject().(*corev1.ConfigMap).DeepCopy()
+		stored, err := client.Tracker().Get(gvr, action.GetNamespace(), configMap.Name)
+		if err != nil {
+			return true, nil, err
+		}
+		version := stored.(*corev1.ConfigMap).ResourceVersion
+		if configMap.ResourceVersion != version {
+			return true, nil, apierrors.NewConflict(gvr.GroupResource(), configMap.Name, errors.New("stale resource version"))
+		}
+		next, _ := strconv.Atoi(version)
+		configMap.ResourceVersion = strconv.Itoa(next + 1)
+		return true, configMap, client.Tracker().Update(gvr, configMap, action.GetNamespace())
+	})
+	return client
+}
+
+func TestConfigMapJournalConflict(t *testing.T) {
+	ctx := context.TODO()
+	client := newVersionedClient()
+	first := NewConfigMapJournal(client, "kyverno", "journal", 0, 0)
+	second := NewConfigMapJournal(client, "kyverno", "journal", 0, 0)
+	assert.NilError(t, first.Record(ctx, newEntry("ns-1", "default-deny", "default-deny")))
+	assert.NilError(t, first.Flush(ctx))
+	// the second journal doesn't know about the shard written by the first one
+	assert.NilError(t, second.Record(ctx, newEntry("ns-2", "default-deny", "default-deny")))
+	assert.NilError(t, second.Flush(ctx))
+	// the first journal state is stale and reloaded on conflict
+	assert.NilError(t, first.Record(ctx, newEntry("ns-3", "default-deny", "default-deny")))
+	assert.NilError(t, first.Flush(ctx))
+	entries, err := second.Load(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, 3, len(entries))
+	assert.Equal(t, "ns-1", entries[0].Trigger.Name)
+	assert.Equal(t, "ns-2", entries[1].Trigger.Name)
+	assert.Equal(t, "ns-3", entries[2].Trigger.Name)
+}
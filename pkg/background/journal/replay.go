@@ -0,0 +1,174 @@
+package journal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"go.uber.org/multierr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Replay reconstructs the trigger to target relationships recorded in the journal, typically after
+// update requests and generate labels were lost.
+// Relationships whose policy, rule or trigger no longer exist are skipped. For the remaining ones,
+// labels linking generated targets to their trigger are restored and an update request is created
+// (unless one already exists) so that the background controller resumes synchronization.
+func Replay(ctx context.Context, logger logr.Logger, client dclient.Interface, kyvernoClient versioned.Interface, entries ...Entry) error {
+	var errs []error
+	for _, entry := range Compact(entries...) {
+		logger := logger.WithValues("type", entry.Type, "policy", entry.Policy, "rule", entry.Rule, "trigger", entry.Trigger.String())
+		if err := replay(ctx, logger, client, kyvernoClient, entry); err != nil {
+			logger.Error(err, "failed to replay journal entry")
+			errs = append(errs, err)
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
+func replay(ctx context.Context, logger logr.Logger, client dclient.Interface, kyvernoClient versioned.Interface, entry Entry) error {
+	policy, err := getPolicy(ctx, kyvernoClient, entry.Policy)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(3).Info("policy not found, skipping")
+			return nil
+		}
+		return err
+	}
+	var rule *kyvernov1.Rule
+	// journaled rules are generate rules, autogen never generates rules for pod controllers from them
+	for _, r := range autogen.ComputeRules(policy, nil) {
+		if r.Name == entry.Rule {
+			r := r
+			rule = &r
+			break
+		}
+	}
+	if rule == nil {
+		logger.V(3).Info("rule not found, skipping")
+		return nil
+	}
+	trigger, err := client.GetResource(ctx, entry.Trigger.APIVersion, entry.Trigger.Kind, entry.Trigger.Namespace, entry.Trigger.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(3).Info("trigger not found, skipping")
+			return nil
+		}
+		return err
+	}
+	if entry.Trigger.UID != "" && entry.Trigger.UID != trigger.GetUID() {
+		logger.V(3).Info("trigger was recreated, skipping")
+		return nil
+	}
+	switch entry.Type {
+	case kyvernov1beta1.Generate:
+		if !rule.HasGenerate() {
+			return nil
+		}
+		for _, target := range entry.Targets {
+			if err := restoreLabels(ctx, client, target, *trigger, policy, rule.Name); err != nil {
+				return err
+			}
+		}
+		if !entry.Synchronize {
+			return nil
+		}
+	case kyvernov1beta1.Mutate:
+		if !rule.IsMutateExisting() {
+			return nil
+		}
+	default:
+		return fmt.Errorf("unsupported request type %s", entry.Type)
+	}
+	return createUpdateRequest(ctx, logger, kyvernoClient, entry, *trigger)
+}
+
+func getPolicy(ctx context.Context, kyvernoClient versioned.Interface, key string) (kyvernov1.PolicyInterface, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" {
+		return kyvernoClient.KyvernoV1().ClusterPolicies().Get(ctx, name, metav1.GetOptions{})
+	}
+	return kyvernoClient.KyvernoV1().Policies(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// restoreLabels sets the generate labels on a target that lost them.
+func restoreLabels(ctx context.Context, client dclient.Interface, target kyvernov1.ResourceSpec, trigger unstructured.Unstructured, policy kyvernov1.PolicyInterface, rule string) error {
+	obj, err := client.GetResource(ctx, target.APIVersion, target.Kind, target.Namespace, target.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if obj.GetLabels()[common.GeneratePolicyLabel] != "" {
+		return nil
+	}
+	common.ManageLabels(obj, trigger, policy, rule)
+	_, err = client.UpdateResource(ctx, obj.GetAPIVersion(), obj.GetKind(), obj.GetNamespace(), obj, false)
+	return err
+}
+
+func createUpdateRequest(ctx context.Context, logger logr.Logger, kyvernoClient versioned.Interface, entry Entry, trigger unstructured.Unstructured) error {
+	resource := kyvernov1.ResourceSpec{
+		APIVersion: trigger.GetAPIVersion(),
+		Kind:       trigger.GetKind(),
+		Namespace:  trigger.GetNamespace(),
+		Name:       trigger.GetName(),
+		UID:        trigger.GetUID(),
+	}
+	var selector labels.Set
+	if entry.Type == kyvernov1beta1.Mutate {
+		selector = common.MutateLabelsSet(entry.Policy, resource)
+	} else {
+		selector = common.GenerateLabelsSet(entry.Policy, resource)
+	}
+	urs, err := kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return err
+	}
+	for _, ur := range urs.Items {
+		if ur.Spec.Rule == entry.Rule {
+			logger.V(3).Info("update request already exists, skipping", "ur", ur.GetName())
+			return nil
+		}
+	}
+	ur := &kyvernov1beta1.UpdateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "ur-",
+			Namespace:    config.KyvernoNamespace(),
+			Labels:       selector,
+		},
+		Spec: kyvernov1beta1.UpdateRequestSpec{
+			Type:        entry.Type,
+			Policy:      entry.Policy,
+			Rule:        entry.Rule,
+			Synchronize: entry.Synchronize,
+			Resource:    resource,
+		},
+	}
+	created, err := kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Create(ctx, ur, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	updated := created.DeepCopy()
+	updated.Status.State = kyvernov1beta1.Pending
+	if _, err := kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(ctx, updated, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	logger.V(2).Info("created update request from journal", "ur", created.GetName())
+	return nil
+}
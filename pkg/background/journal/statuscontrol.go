@@ -0,0 +1,51 @@
+package journal
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+)
+
+type statusControl struct {
+	common.StatusControlInterface
+	journal Journal
+	logger  logr.Logger
+}
+
+// StatusControl wraps a status control so that successfully processed update requests are recorded in the journal.
+// Failing to record an entry is logged and doesn't fail the update request.
+func StatusControl(inner common.StatusControlInterface, journal Journal, logger logr.Logger) common.StatusControlInterface {
+	return &statusControl{
+		StatusControlInterface: inner,
+		journal:                journal,
+		logger:                 logger,
+	}
+}
+
+func (sc *statusControl) Success(name string, genResources []kyvernov1.ResourceSpec) (*kyvernov1beta1.UpdateRequest, error) {
+	ur, err := sc.StatusControlInterface.Success(name, genResources)
+	if err == nil && ur != nil {
+		if err := sc.journal.Record(context.TODO(), NewEntry(ur, genResources)); err != nil {
+			sc.logger.Error(err, "failed to record decision in the journal", "ur", name)
+		}
+	}
+	return ur, err
+}
+
+// NewEntry creates a journal entry for an update request and the resources it produced.
+func NewEntry(ur *kyvernov1beta1.UpdateRequest, targets []kyvernov1.ResourceSpec) Entry {
+	return Entry{
+		Time:        time.Now().Unix(),
+		Type:        ur.Spec.GetRequestType(),
+		Policy:      ur.Spec.GetPolicyKey(),
+		Rule:        ur.Spec.GetRuleName(),
+		Synchronize: ur.Spec.GetSynchronize(),
+		Deleted:     ur.Spec.DeleteDownstream,
+		Trigger:     ur.Spec.GetResource(),
+		Targets:     targets,
+	}
+}
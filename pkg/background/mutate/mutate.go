@@ -30,6 +30,7 @@ var ErrEmptyPatch error = fmt.Errorf("empty resource to patch")
 type mutateExistingController struct {
 	// clients
 	client        dclient.Interface
+	impersonator  dclient.Impersonator
 	kyvernoClient versioned.Interface
 	statusControl common.StatusControlInterface
 	engine        engineapi.Engine
@@ -49,6 +50,7 @@ type mutateExistingController struct {
 // NewMutateExistingController returns an instance of the MutateExistingController
 func NewMutateExistingController(
 	client dclient.Interface,
+	impersonator dclient.Impersonator,
 	kyvernoClient versioned.Interface,
 	statusControl common.StatusControlInterface,
 	engine engineapi.Engine,
@@ -62,6 +64,7 @@ func NewMutateExistingController(
 ) *mutateExistingController {
 	c := mutateExistingController{
 		client:        client,
+		impersonator:  impersonator,
 		kyvernoClient: kyvernoClient,
 		statusControl: statusControl,
 		engine:        engine,
@@ -76,7 +79,7 @@ func NewMutateExistingController(
 	return &c
 }
 
-func (c *mutateExistingController) ProcessUR(ur *kyvernov1beta1.UpdateRequest) error {
+func (c *mutateExistingController) ProcessUR(ctx context.Context, ur *kyvernov1beta1.UpdateRequest) error {
 	logger := c.log.WithValues("name", ur.GetName(), "policy", ur.Spec.GetPolicyKey(), "resource", ur.Spec.GetResource().String())
 	var errs []error
 
@@ -86,6 +89,12 @@ func (c *mutateExistingController) ProcessUR(ur *kyvernov1beta1.UpdateRequest) e
 		return err
 	}
 
+	targetClient, err := c.targetClient(policy)
+	if err != nil {
+		logger.Error(err, "failed to get the target client")
+		return updateURStatus(c.statusControl, *ur, err)
+	}
+
 	for _, rule := range policy.GetSpec().Rules {
 		if !rule.IsMutateExisting() || ur.Spec.Rule != rule.Name {
 			continue
@@ -156,7 +165,7 @@ func (c *mutateExistingController) ProcessUR(ur *kyvernov1beta1.UpdateRequest) e
 			policyContext = policyContext.WithResourceKind(gvk, admissionRequest.SubResource)
 		}
 
-		er := c.engine.Mutate(context.TODO(), policyContext)
+		er := c.engine.Mutate(ctx, policyContext)
 		for _, r := range er.PolicyResponse.Rules {
 			patched, parentGVR, patchedSubresource := r.PatchedTarget()
 			switch r.Status() {
@@ -182,7 +191,7 @@ func (c *mutateExistingController) ProcessUR(ur *kyvernov1beta1.UpdateRequest) e
 				patchedNew.SetResourceVersion(patched.GetResourceVersion())
 				var updateErr error
 				if patchedSubresource == "status" {
-					_, updateErr = c.client.UpdateStatusResource(context.TODO(), patchedNew.GetAPIVersion(), patchedNew.GetKind(), patchedNew.GetNamespace(), patchedNew.Object, false)
+					_, updateErr = targetClient.UpdateStatusResource(ctx, patchedNew.GetAPIVersion(), patchedNew.GetKind(), patchedNew.GetNamespace(), patchedNew.Object, false)
 				} else if patchedSubresource != "" {
 					parentResourceGVR := parentGVR
 					parentResourceGV := schema.GroupVersion{Group: parentResourceGVR.Group, Version: parentResourceGVR.Version}
@@ -192,9 +201,9 @@ func (c *mutateExistingController) ProcessUR(ur *kyvernov1beta1.UpdateRequest) e
 						errs = append(errs, err)
 						continue
 					}
-					_, updateErr = c.client.UpdateResource(context.TODO(), parentResourceGV.String(), parentResourceGVK.Kind, patchedNew.GetNamespace(), patchedNew.Object, false, patchedSubresource)
+					_, updateErr = targetClient.UpdateResource(ctx, parentResourceGV.String(), parentResourceGVK.Kind, patchedNew.GetNamespace(), patchedNew.Object, false, patchedSubresource)
 				} else {
-					_, updateErr = c.client.UpdateResource(context.TODO(), patchedNew.GetAPIVersion(), patchedNew.GetKind(), patchedNew.GetNamespace(), patchedNew.Object, false)
+					_, updateErr = targetClient.UpdateResource(ctx, patchedNew.GetAPIVersion(), patchedNew.GetKind(), patchedNew.GetNamespace(), patchedNew.Object, false)
 				}
 				if updateErr != nil {
 					errs = append(errs, updateErr)
@@ -212,6 +221,19 @@ func (c *mutateExistingController) ProcessUR(ur *kyvernov1beta1.UpdateRequest) e
 	return updateURStatus(c.statusControl, *ur, err)
 }
 
+// targetClient returns the client updating the targets of the policy,
+// targets are updated on behalf of the policy service account when one is configured
+func (c *mutateExistingController) targetClient(policy kyvernov1.PolicyInterface) (dclient.Interface, error) {
+	serviceAccount := policy.GetSpec().ServiceAccount
+	if serviceAccount == nil {
+		return c.client, nil
+	}
+	if c.impersonator == nil {
+		return nil, fmt.Errorf("impersonation of service account %s is not supported", serviceAccount.Username(policy.GetNamespace()))
+	}
+	return c.impersonator.Impersonate(serviceAccount.Username(policy.GetNamespace()))
+}
+
 func (c *mutateExistingController) getPolicy(ur *kyvernov1beta1.UpdateRequest) (policy kyvernov1.PolicyInterface, err error) {
 	pNamespace, pName, err := cache.SplitMetaNamespaceKey(ur.Spec.Policy)
 	if err != nil {
@@ -0,0 +1,48 @@
+package background
+
+import (
+	"hash/fnv"
+
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+)
+
+// Shard identifies the part of the update requests processed by a controller,
+// update requests are spread across shards by the namespace of their trigger.
+type Shard struct {
+	Index int
+	Count int
+}
+
+// Owns returns true if the update request belongs to the shard.
+func (s Shard) Owns(ur *kyvernov1beta1.UpdateRequest) bool {
+	if s.Count <= 1 {
+		return true
+	}
+	return ShardOf(ur, s.Count) == s.Index
+}
+
+// ShardOf returns the shard of an update request, the requests triggered by the same namespace or by the
+// namespace itself always belong to the same shard so that their generated resources are handled by a single replica.
+func ShardOf(ur *kyvernov1beta1.UpdateRequest, count int) int {
+	if count <= 1 {
+		return 0
+	}
+	trigger := ur.Spec.GetResource()
+	namespace := trigger.GetNamespace()
+	if trigger.GetKind() == "Namespace" {
+		namespace = trigger.GetName()
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(namespace))
+	return int(h.Sum32() % uint32(count))
+}
+
+// PreferredShard returns the shard a replica competes for first.
+func PreferredShard(id string, count int) int {
+	if count <= 1 {
+		return 0
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(id))
+	return int(h.Sum32() % uint32(count))
+}
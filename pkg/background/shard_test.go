@@ -0,0 +1,51 @@
+package background
+
+import (
+	"fmt"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+)
+
+func newUpdateRequest(kind, namespace, name string) *kyvernov1beta1.UpdateRequest {
+	return &kyvernov1beta1.UpdateRequest{
+		Spec: kyvernov1beta1.UpdateRequestSpec{
+			Resource: kyvernov1.ResourceSpec{Kind: kind, Namespace: namespace, Name: name},
+		},
+	}
+}
+
+func TestShardOf(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		namespace := fmt.Sprintf("team-%d", i)
+		trigger := newUpdateRequest("Namespace", "", namespace)
+		resource := newUpdateRequest("ConfigMap", namespace, "settings")
+		if ShardOf(trigger, 4) != ShardOf(resource, 4) {
+			t.Errorf("namespace %s and its resources must belong to the same shard", namespace)
+		}
+	}
+}
+
+func TestShardOwns(t *testing.T) {
+	owned := map[int]int{}
+	for i := 0; i < 100; i++ {
+		ur := newUpdateRequest("ConfigMap", fmt.Sprintf("ns-%d", i), "settings")
+		if !(Shard{Index: 0, Count: 1}).Owns(ur) {
+			t.Fatal("all update requests belong to a single shard")
+		}
+		count := 0
+		for index := 0; index < 3; index++ {
+			if (Shard{Index: index, Count: 3}).Owns(ur) {
+				owned[index]++
+				count++
+			}
+		}
+		if count != 1 {
+			t.Fatalf("update request must belong to exactly one shard, got %d", count)
+		}
+	}
+	if len(owned) != 3 {
+		t.Errorf("expected update requests spread across 3 shards, got %v", owned)
+	}
+}
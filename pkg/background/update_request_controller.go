@@ -9,6 +9,7 @@ import (
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	common "github.com/kyverno/kyverno/pkg/background/common"
 	"github.com/kyverno/kyverno/pkg/background/generate"
+	"github.com/kyverno/kyverno/pkg/background/journal"
 	"github.com/kyverno/kyverno/pkg/background/mutate"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
@@ -20,9 +21,13 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	corev1informers "k8s.io/client-go/informers/core/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
@@ -43,6 +48,8 @@ type Controller interface {
 type controller struct {
 	// clients
 	client        dclient.Interface
+	clusters      dclient.Clusters
+	impersonator  dclient.Impersonator
 	kyvernoClient versioned.Interface
 	engine        engineapi.Engine
 
@@ -60,12 +67,16 @@ type controller struct {
 	eventGen      event.Interface
 	configuration config.Configuration
 	jp            jmespath.Interface
+	journal       journal.Journal
+	shard         Shard
 }
 
 // NewController returns an instance of the Generate-Request Controller
 func NewController(
 	kyvernoClient versioned.Interface,
 	client dclient.Interface,
+	clusters dclient.Clusters,
+	impersonator dclient.Impersonator,
 	engine engineapi.Engine,
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
 	polInformer kyvernov1informers.PolicyInformer,
@@ -74,10 +85,14 @@ func NewController(
 	eventGen event.Interface,
 	configuration config.Configuration,
 	jp jmespath.Interface,
+	journal journal.Journal,
+	shard Shard,
 ) Controller {
 	urLister := urInformer.Lister().UpdateRequests(config.KyvernoNamespace())
 	c := controller{
 		client:        client,
+		clusters:      clusters,
+		impersonator:  impersonator,
 		kyvernoClient: kyvernoClient,
 		engine:        engine,
 		cpolLister:    cpolInformer.Lister(),
@@ -88,11 +103,19 @@ func NewController(
 		eventGen:      eventGen,
 		configuration: configuration,
 		jp:            jp,
+		journal:       journal,
+		shard:         shard,
 	}
 	_, _ = urInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.addUR,
 		UpdateFunc: c.updateUR,
 	})
+	if clusters != nil {
+		_, _ = cpolInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(_, _ interface{}) { c.pruneClusters() },
+			DeleteFunc: func(_ interface{}) { c.pruneClusters() },
+		})
+	}
 
 	c.informersSynced = []cache.InformerSynced{cpolInformer.Informer().HasSynced, polInformer.Informer().HasSynced, urInformer.Informer().HasSynced, namespaceInformer.Informer().HasSynced}
 
@@ -120,18 +143,18 @@ func (c *controller) Run(ctx context.Context, workers int) {
 // worker runs a worker thread that just dequeues items, processes them, and marks them done.
 // It enforces that the syncHandler is never invoked concurrently with the same key.
 func (c *controller) worker(ctx context.Context) {
-	for c.processNextWorkItem() {
+	for c.processNextWorkItem(ctx) {
 	}
 }
 
-func (c *controller) processNextWorkItem() bool {
+func (c *controller) processNextWorkItem(ctx context.Context) bool {
 	key, quit := c.queue.Get()
 	if quit {
 		return false
 	}
 
 	defer c.queue.Done(key)
-	err := c.syncUpdateRequest(key.(string))
+	err := c.syncUpdateRequest(ctx, key.(string))
 	c.handleErr(err, key)
 	return true
 }
@@ -158,7 +181,7 @@ func (c *controller) handleErr(err error, key interface{}) {
 	c.queue.Forget(key)
 }
 
-func (c *controller) syncUpdateRequest(key string) error {
+func (c *controller) syncUpdateRequest(ctx context.Context, key string) error {
 	startTime := time.Now()
 	logger.V(4).Info("started sync", "key", key, "startTime", startTime)
 	_, urName, err := cache.SplitMetaNamespaceKey(key)
@@ -172,6 +195,29 @@ func (c *controller) syncUpdateRequest(key string) error {
 
 	// Deep-copy otherwise we are mutating our cache.
 	ur = ur.DeepCopy()
+	// update requests created at admission are linked to the admission request trace
+	return tracing.Span1(
+		ctx,
+		"",
+		fmt.Sprintf("UPDATEREQUEST %s %s", ur.Spec.GetRequestType(), ur.Spec.GetPolicyKey()),
+		func(ctx context.Context, span trace.Span) error {
+			err := c.reconcileUpdateRequest(ctx, key, ur)
+			tracing.SetSpanStatus(span, err)
+			if err == nil {
+				logger.V(4).Info("synced update request", "key", key, "processingTime", time.Since(startTime).String())
+			}
+			return err
+		},
+		tracing.WithObjectLinks(ur),
+		trace.WithAttributes(
+			tracing.UpdateRequestNameKey.String(ur.GetName()),
+			tracing.UpdateRequestTypeKey.String(string(ur.Spec.GetRequestType())),
+			tracing.UpdateRequestResourceKey.String(tracing.StringValue(ur.Spec.GetResource().String())),
+		),
+	)
+}
+
+func (c *controller) reconcileUpdateRequest(ctx context.Context, key string, ur *kyvernov1beta1.UpdateRequest) error {
 	if _, err := c.getPolicy(ur.Spec.Policy); err != nil && apierrors.IsNotFound(err) {
 		if ur.Spec.GetRequestType() == kyvernov1beta1.Mutate {
 			return c.handleMutatePolicyAbsence(ur)
@@ -179,7 +225,7 @@ func (c *controller) syncUpdateRequest(key string) error {
 	}
 
 	if ur.Status.State == kyvernov1beta1.Pending {
-		if err := c.processUR(ur); err != nil {
+		if err := c.processUR(ctx, ur); err != nil {
 			return fmt.Errorf("failed to process UR %s: %v", key, err)
 		}
 	}
@@ -188,8 +234,7 @@ func (c *controller) syncUpdateRequest(key string) error {
 	if err != nil {
 		return err
 	}
-
-	logger.V(4).Info("synced update request", "key", key, "processingTime", time.Since(startTime).String(), "ur status", urStatus)
+	logger.V(4).Info("reconciled update request status", "key", key, "ur status", urStatus)
 	return nil
 }
 
@@ -205,26 +250,53 @@ func (c *controller) enqueueUpdateRequest(obj interface{}) {
 
 func (c *controller) addUR(obj interface{}) {
 	ur := obj.(*kyvernov1beta1.UpdateRequest)
+	if !c.shard.Owns(ur) {
+		return
+	}
 	c.enqueueUpdateRequest(ur)
 }
 
 func (c *controller) updateUR(_, cur interface{}) {
 	curUr := cur.(*kyvernov1beta1.UpdateRequest)
+	if !c.shard.Owns(curUr) {
+		return
+	}
 	if curUr.Status.State == kyvernov1beta1.Skip || curUr.Status.State == kyvernov1beta1.Completed {
 		return
 	}
 	c.enqueueUpdateRequest(curUr)
 }
 
-func (c *controller) processUR(ur *kyvernov1beta1.UpdateRequest) error {
+// pruneClusters releases the remote clusters no longer referenced by generate rules
+func (c *controller) pruneClusters() {
+	policies, err := c.cpolLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list cluster policies")
+		return
+	}
+	secrets := sets.New[string]()
+	for _, policy := range policies {
+		for _, rule := range policy.GetSpec().Rules {
+			if cluster := rule.Generation.Cluster; cluster != nil {
+				secrets.Insert(cluster.String())
+			}
+		}
+	}
+	c.clusters.Prune(secrets)
+}
+
+func (c *controller) processUR(ctx context.Context, ur *kyvernov1beta1.UpdateRequest) error {
 	statusControl := common.NewStatusControl(c.kyvernoClient, c.urLister)
+	if c.journal != nil {
+		statusControl = journal.StatusControl(statusControl, c.journal, logger)
+	}
 	switch ur.Spec.GetRequestType() {
 	case kyvernov1beta1.Mutate:
-		ctrl := mutate.NewMutateExistingController(c.client, c.kyvernoClient, statusControl, c.engine, c.cpolLister, c.polLister, c.nsLister, c.configuration, c.eventGen, logger, c.jp)
-		return ctrl.ProcessUR(ur)
+		ctrl := mutate.NewMutateExistingController(c.client, c.impersonator, c.kyvernoClient, statusControl, c.engine, c.cpolLister, c.polLister, c.nsLister, c.configuration, c.eventGen, logger, c.jp)
+		return ctrl.ProcessUR(ctx, ur)
 	case kyvernov1beta1.Generate:
-		ctrl := generate.NewGenerateController(c.client, c.kyvernoClient, statusControl, c.engine, c.cpolLister, c.polLister, c.urLister, c.nsLister, c.configuration, c.eventGen, logger, c.jp)
-		return ctrl.ProcessUR(ur)
+		ctrl := generate.NewGenerateController(c.client, c.clusters, c.impersonator, c.kyvernoClient, statusControl, c.engine, c.cpolLister, c.polLister, c.urLister, c.nsLister, c.configuration, c.eventGen, logger, c.jp)
+		return ctrl.ProcessUR(ctx, ur)
 	}
 	return nil
 }
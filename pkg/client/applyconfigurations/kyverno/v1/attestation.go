@@ -21,10 +21,11 @@ package v1
 // AttestationApplyConfiguration represents an declarative configuration of the Attestation type for use
 // with apply.
 type AttestationApplyConfiguration struct {
-	PredicateType *string                              `json:"predicateType,omitempty"`
-	Type          *string                              `json:"type,omitempty"`
-	Attestors     []AttestorSetApplyConfiguration      `json:"attestors,omitempty"`
-	Conditions    []AnyAllConditionsApplyConfiguration `json:"conditions,omitempty"`
+	PredicateType   *string                                       `json:"predicateType,omitempty"`
+	Type            *string                                       `json:"type,omitempty"`
+	Attestors       []AttestorSetApplyConfiguration               `json:"attestors,omitempty"`
+	Conditions      []AnyAllConditionsApplyConfiguration          `json:"conditions,omitempty"`
+	ClusterMetadata *AttestationClusterMetadataApplyConfiguration `json:"clusterMetadata,omitempty"`
 }
 
 // AttestationApplyConfiguration constructs an declarative configuration of the Attestation type for use with
@@ -74,3 +75,11 @@ func (b *AttestationApplyConfiguration) WithConditions(values ...*AnyAllConditio
 	}
 	return b
 }
+
+// WithClusterMetadata sets the ClusterMetadata field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClusterMetadata field is set to the value of the last call.
+func (b *AttestationApplyConfiguration) WithClusterMetadata(value *AttestationClusterMetadataApplyConfiguration) *AttestationApplyConfiguration {
+	b.ClusterMetadata = value
+	return b
+}
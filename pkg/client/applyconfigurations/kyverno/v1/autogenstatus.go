@@ -22,6 +22,7 @@ package v1
 // with apply.
 type AutogenStatusApplyConfiguration struct {
 	Rules []RuleApplyConfiguration `json:"rules,omitempty"`
+	Hash  *string                  `json:"hash,omitempty"`
 }
 
 // AutogenStatusApplyConfiguration constructs an declarative configuration of the AutogenStatus type for use with
@@ -42,3 +43,11 @@ func (b *AutogenStatusApplyConfiguration) WithRules(values ...*RuleApplyConfigur
 	}
 	return b
 }
+
+// WithHash sets the Hash field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Hash field is set to the value of the last call.
+func (b *AutogenStatusApplyConfiguration) WithHash(value string) *AutogenStatusApplyConfiguration {
+	b.Hash = &value
+	return b
+}
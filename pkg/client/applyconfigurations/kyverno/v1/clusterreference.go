@@ -0,0 +1,48 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// ClusterReferenceApplyConfiguration represents an declarative configuration of the ClusterReference type for use
+// with apply.
+type ClusterReferenceApplyConfiguration struct {
+	KubeconfigSecret *SecretReferenceApplyConfiguration `json:"kubeconfigSecret,omitempty"`
+	Key              *string                            `json:"key,omitempty"`
+}
+
+// ClusterReferenceApplyConfiguration constructs an declarative configuration of the ClusterReference type for use with
+// apply.
+func ClusterReference() *ClusterReferenceApplyConfiguration {
+	return &ClusterReferenceApplyConfiguration{}
+}
+
+// WithKubeconfigSecret sets the KubeconfigSecret field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the KubeconfigSecret field is set to the value of the last call.
+func (b *ClusterReferenceApplyConfiguration) WithKubeconfigSecret(value *SecretReferenceApplyConfiguration) *ClusterReferenceApplyConfiguration {
+	b.KubeconfigSecret = value
+	return b
+}
+
+// WithKey sets the Key field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Key field is set to the value of the last call.
+func (b *ClusterReferenceApplyConfiguration) WithKey(value string) *ClusterReferenceApplyConfiguration {
+	b.Key = &value
+	return b
+}
@@ -21,11 +21,12 @@ package v1
 // ContextEntryApplyConfiguration represents an declarative configuration of the ContextEntry type for use
 // with apply.
 type ContextEntryApplyConfiguration struct {
-	Name          *string                               `json:"name,omitempty"`
-	ConfigMap     *ConfigMapReferenceApplyConfiguration `json:"configMap,omitempty"`
-	APICall       *APICallApplyConfiguration            `json:"apiCall,omitempty"`
-	ImageRegistry *ImageRegistryApplyConfiguration      `json:"imageRegistry,omitempty"`
-	Variable      *VariableApplyConfiguration           `json:"variable,omitempty"`
+	Name             *string                               `json:"name,omitempty"`
+	ConfigMap        *ConfigMapReferenceApplyConfiguration `json:"configMap,omitempty"`
+	APICall          *APICallApplyConfiguration            `json:"apiCall,omitempty"`
+	ImageRegistry    *ImageRegistryApplyConfiguration      `json:"imageRegistry,omitempty"`
+	Variable         *VariableApplyConfiguration           `json:"variable,omitempty"`
+	RelatedResources *RelatedResourcesApplyConfiguration   `json:"relatedResources,omitempty"`
 }
 
 // ContextEntryApplyConfiguration constructs an declarative configuration of the ContextEntry type for use with
@@ -73,3 +74,11 @@ func (b *ContextEntryApplyConfiguration) WithVariable(value *VariableApplyConfig
 	b.Variable = value
 	return b
 }
+
+// WithRelatedResources sets the RelatedResources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RelatedResources field is set to the value of the last call.
+func (b *ContextEntryApplyConfiguration) WithRelatedResources(value *RelatedResourcesApplyConfiguration) *ContextEntryApplyConfiguration {
+	b.RelatedResources = value
+	return b
+}
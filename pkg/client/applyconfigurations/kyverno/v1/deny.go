@@ -25,7 +25,8 @@ import (
 // DenyApplyConfiguration represents an declarative configuration of the Deny type for use
 // with apply.
 type DenyApplyConfiguration struct {
-	RawAnyAllConditions *v1.JSON `json:"conditions,omitempty"`
+	RawAnyAllConditions *v1.JSON                          `json:"conditions,omitempty"`
+	Violations          *DenyViolationsApplyConfiguration `json:"violations,omitempty"`
 }
 
 // DenyApplyConfiguration constructs an declarative configuration of the Deny type for use with
@@ -41,3 +42,11 @@ func (b *DenyApplyConfiguration) WithRawAnyAllConditions(value v1.JSON) *DenyApp
 	b.RawAnyAllConditions = &value
 	return b
 }
+
+// WithViolations sets the Violations field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Violations field is set to the value of the last call.
+func (b *DenyApplyConfiguration) WithViolations(value *DenyViolationsApplyConfiguration) *DenyApplyConfiguration {
+	b.Violations = value
+	return b
+}
@@ -0,0 +1,61 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// DenyViolationsApplyConfiguration represents an declarative configuration of the DenyViolations type for use
+// with apply.
+type DenyViolationsApplyConfiguration struct {
+	List                *string  `json:"list,omitempty"`
+	RawAnyAllConditions *v1.JSON `json:"conditions,omitempty"`
+	Reason              *string  `json:"reason,omitempty"`
+}
+
+// DenyViolationsApplyConfiguration constructs an declarative configuration of the DenyViolations type for use with
+// apply.
+func DenyViolations() *DenyViolationsApplyConfiguration {
+	return &DenyViolationsApplyConfiguration{}
+}
+
+// WithList sets the List field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the List field is set to the value of the last call.
+func (b *DenyViolationsApplyConfiguration) WithList(value string) *DenyViolationsApplyConfiguration {
+	b.List = &value
+	return b
+}
+
+// WithRawAnyAllConditions sets the RawAnyAllConditions field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RawAnyAllConditions field is set to the value of the last call.
+func (b *DenyViolationsApplyConfiguration) WithRawAnyAllConditions(value v1.JSON) *DenyViolationsApplyConfiguration {
+	b.RawAnyAllConditions = &value
+	return b
+}
+
+// WithReason sets the Reason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reason field is set to the value of the last call.
+func (b *DenyViolationsApplyConfiguration) WithReason(value string) *DenyViolationsApplyConfiguration {
+	b.Reason = &value
+	return b
+}
@@ -27,10 +27,11 @@ import (
 // with apply.
 type GenerationApplyConfiguration struct {
 	*ResourceSpecApplyConfiguration `json:"ResourceSpec,omitempty"`
-	Synchronize                     *bool                        `json:"synchronize,omitempty"`
-	RawData                         *apiextensionsv1.JSON        `json:"data,omitempty"`
-	Clone                           *CloneFromApplyConfiguration `json:"clone,omitempty"`
-	CloneList                       *CloneListApplyConfiguration `json:"cloneList,omitempty"`
+	Synchronize                     *bool                               `json:"synchronize,omitempty"`
+	RawData                         *apiextensionsv1.JSON               `json:"data,omitempty"`
+	Clone                           *CloneFromApplyConfiguration        `json:"clone,omitempty"`
+	CloneList                       *CloneListApplyConfiguration        `json:"cloneList,omitempty"`
+	Cluster                         *ClusterReferenceApplyConfiguration `json:"cluster,omitempty"`
 }
 
 // GenerationApplyConfiguration constructs an declarative configuration of the Generation type for use with
@@ -121,3 +122,11 @@ func (b *GenerationApplyConfiguration) WithCloneList(value *CloneListApplyConfig
 	b.CloneList = value
 	return b
 }
+
+// WithCluster sets the Cluster field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cluster field is set to the value of the last call.
+func (b *GenerationApplyConfiguration) WithCluster(value *ClusterReferenceApplyConfiguration) *GenerationApplyConfiguration {
+	b.Cluster = value
+	return b
+}
@@ -169,6 +169,24 @@ func (b *MatchResourcesApplyConfiguration) WithNamespaceSelector(value metav1.La
 	return b
 }
 
+// WithFieldSelector sets the FieldSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FieldSelector field is set to the value of the last call.
+func (b *MatchResourcesApplyConfiguration) WithFieldSelector(value string) *MatchResourcesApplyConfiguration {
+	b.ensureResourceDescriptionApplyConfigurationExists()
+	b.FieldSelector = &value
+	return b
+}
+
+// WithOwner sets the Owner field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Owner field is set to the value of the last call.
+func (b *MatchResourcesApplyConfiguration) WithOwner(value *OwnerDescriptionApplyConfiguration) *MatchResourcesApplyConfiguration {
+	b.ensureResourceDescriptionApplyConfigurationExists()
+	b.Owner = value
+	return b
+}
+
 // WithOperations adds the given value to the Operations field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Operations field.
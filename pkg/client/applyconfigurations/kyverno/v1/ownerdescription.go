@@ -0,0 +1,65 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OwnerDescriptionApplyConfiguration represents an declarative configuration of the OwnerDescription type for use
+// with apply.
+type OwnerDescriptionApplyConfiguration struct {
+	Kinds    []string          `json:"kinds,omitempty"`
+	Names    []string          `json:"names,omitempty"`
+	Selector *v1.LabelSelector `json:"selector,omitempty"`
+}
+
+// OwnerDescriptionApplyConfiguration constructs an declarative configuration of the OwnerDescription type for use with
+// apply.
+func OwnerDescription() *OwnerDescriptionApplyConfiguration {
+	return &OwnerDescriptionApplyConfiguration{}
+}
+
+// WithKinds adds the given value to the Kinds field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Kinds field.
+func (b *OwnerDescriptionApplyConfiguration) WithKinds(values ...string) *OwnerDescriptionApplyConfiguration {
+	for i := range values {
+		b.Kinds = append(b.Kinds, values[i])
+	}
+	return b
+}
+
+// WithNames adds the given value to the Names field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Names field.
+func (b *OwnerDescriptionApplyConfiguration) WithNames(values ...string) *OwnerDescriptionApplyConfiguration {
+	for i := range values {
+		b.Names = append(b.Names, values[i])
+	}
+	return b
+}
+
+// WithSelector sets the Selector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Selector field is set to the value of the last call.
+func (b *OwnerDescriptionApplyConfiguration) WithSelector(value v1.LabelSelector) *OwnerDescriptionApplyConfiguration {
+	b.Selector = &value
+	return b
+}
@@ -0,0 +1,63 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// PolicyOrderingApplyConfiguration represents an declarative configuration of the PolicyOrdering type for use
+// with apply.
+type PolicyOrderingApplyConfiguration struct {
+	Before          []string `json:"before,omitempty"`
+	After           []string `json:"after,omitempty"`
+	ExclusionGroups []string `json:"exclusionGroups,omitempty"`
+}
+
+// PolicyOrderingApplyConfiguration constructs an declarative configuration of the PolicyOrdering type for use with
+// apply.
+func PolicyOrdering() *PolicyOrderingApplyConfiguration {
+	return &PolicyOrderingApplyConfiguration{}
+}
+
+// WithBefore adds the given value to the Before field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Before field.
+func (b *PolicyOrderingApplyConfiguration) WithBefore(values ...string) *PolicyOrderingApplyConfiguration {
+	for i := range values {
+		b.Before = append(b.Before, values[i])
+	}
+	return b
+}
+
+// WithAfter adds the given value to the After field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the After field.
+func (b *PolicyOrderingApplyConfiguration) WithAfter(values ...string) *PolicyOrderingApplyConfiguration {
+	for i := range values {
+		b.After = append(b.After, values[i])
+	}
+	return b
+}
+
+// WithExclusionGroups adds the given value to the ExclusionGroups field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ExclusionGroups field.
+func (b *PolicyOrderingApplyConfiguration) WithExclusionGroups(values ...string) *PolicyOrderingApplyConfiguration {
+	for i := range values {
+		b.ExclusionGroups = append(b.ExclusionGroups, values[i])
+	}
+	return b
+}
@@ -0,0 +1,61 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// PolicyParametersApplyConfiguration represents an declarative configuration of the PolicyParameters type for use
+// with apply.
+type PolicyParametersApplyConfiguration struct {
+	ParamKind *ParamKindApplyConfiguration `json:"paramKind,omitempty"`
+	ParamRef  *ParamRefApplyConfiguration  `json:"paramRef,omitempty"`
+	Schema    *apiextensionsv1.JSON        `json:"schema,omitempty"`
+}
+
+// PolicyParametersApplyConfiguration constructs an declarative configuration of the PolicyParameters type for use with
+// apply.
+func PolicyParameters() *PolicyParametersApplyConfiguration {
+	return &PolicyParametersApplyConfiguration{}
+}
+
+// WithParamKind sets the ParamKind field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ParamKind field is set to the value of the last call.
+func (b *PolicyParametersApplyConfiguration) WithParamKind(value *ParamKindApplyConfiguration) *PolicyParametersApplyConfiguration {
+	b.ParamKind = value
+	return b
+}
+
+// WithParamRef sets the ParamRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ParamRef field is set to the value of the last call.
+func (b *PolicyParametersApplyConfiguration) WithParamRef(value *ParamRefApplyConfiguration) *PolicyParametersApplyConfiguration {
+	b.ParamRef = value
+	return b
+}
+
+// WithSchema sets the Schema field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Schema field is set to the value of the last call.
+func (b *PolicyParametersApplyConfiguration) WithSchema(value apiextensionsv1.JSON) *PolicyParametersApplyConfiguration {
+	b.Schema = &value
+	return b
+}
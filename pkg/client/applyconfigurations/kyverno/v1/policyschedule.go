@@ -0,0 +1,53 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// PolicyScheduleApplyConfiguration represents an declarative configuration of the PolicySchedule type for use
+// with apply.
+type PolicyScheduleApplyConfiguration struct {
+	Windows  []ScheduleWindowApplyConfiguration `json:"windows,omitempty"`
+	TimeZone *string                            `json:"timeZone,omitempty"`
+}
+
+// PolicyScheduleApplyConfiguration constructs an declarative configuration of the PolicySchedule type for use with
+// apply.
+func PolicySchedule() *PolicyScheduleApplyConfiguration {
+	return &PolicyScheduleApplyConfiguration{}
+}
+
+// WithWindows adds the given value to the Windows field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Windows field.
+func (b *PolicyScheduleApplyConfiguration) WithWindows(values ...*ScheduleWindowApplyConfiguration) *PolicyScheduleApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithWindows")
+		}
+		b.Windows = append(b.Windows, *values[i])
+	}
+	return b
+}
+
+// WithTimeZone sets the TimeZone field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TimeZone field is set to the value of the last call.
+func (b *PolicyScheduleApplyConfiguration) WithTimeZone(value string) *PolicyScheduleApplyConfiguration {
+	b.TimeZone = &value
+	return b
+}
@@ -30,6 +30,7 @@ type PolicyStatusApplyConfiguration struct {
 	Autogen                   *AutogenStatusApplyConfiguration                   `json:"autogen,omitempty"`
 	RuleCount                 *RuleCountStatusApplyConfiguration                 `json:"rulecount,omitempty"`
 	ValidatingAdmissionPolicy *ValidatingAdmissionPolicyStatusApplyConfiguration `json:"validatingadmissionpolicy,omitempty"`
+	Rules                     []RuleStatusApplyConfiguration                     `json:"rules,omitempty"`
 }
 
 // PolicyStatusApplyConfiguration constructs an declarative configuration of the PolicyStatus type for use with
@@ -79,3 +80,16 @@ func (b *PolicyStatusApplyConfiguration) WithValidatingAdmissionPolicy(value *Va
 	b.ValidatingAdmissionPolicy = value
 	return b
 }
+
+// WithRules adds the given value to the Rules field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Rules field.
+func (b *PolicyStatusApplyConfiguration) WithRules(values ...*RuleStatusApplyConfiguration) *PolicyStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithRules")
+		}
+		b.Rules = append(b.Rules, *values[i])
+	}
+	return b
+}
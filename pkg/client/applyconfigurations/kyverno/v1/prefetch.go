@@ -0,0 +1,58 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// PrefetchApplyConfiguration represents an declarative configuration of the Prefetch type for use
+// with apply.
+type PrefetchApplyConfiguration struct {
+	ConfigMaps []ConfigMapReferenceApplyConfiguration `json:"configMaps,omitempty"`
+	Resources  []PrefetchResourceApplyConfiguration   `json:"resources,omitempty"`
+}
+
+// PrefetchApplyConfiguration constructs an declarative configuration of the Prefetch type for use with
+// apply.
+func Prefetch() *PrefetchApplyConfiguration {
+	return &PrefetchApplyConfiguration{}
+}
+
+// WithConfigMaps adds the given value to the ConfigMaps field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ConfigMaps field.
+func (b *PrefetchApplyConfiguration) WithConfigMaps(values ...*ConfigMapReferenceApplyConfiguration) *PrefetchApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConfigMaps")
+		}
+		b.ConfigMaps = append(b.ConfigMaps, *values[i])
+	}
+	return b
+}
+
+// WithResources adds the given value to the Resources field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Resources field.
+func (b *PrefetchApplyConfiguration) WithResources(values ...*PrefetchResourceApplyConfiguration) *PrefetchApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithResources")
+		}
+		b.Resources = append(b.Resources, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,57 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// PrefetchResourceApplyConfiguration represents an declarative configuration of the PrefetchResource type for use
+// with apply.
+type PrefetchResourceApplyConfiguration struct {
+	APIVersion *string `json:"apiVersion,omitempty"`
+	Resource   *string `json:"resource,omitempty"`
+	Namespace  *string `json:"namespace,omitempty"`
+}
+
+// PrefetchResourceApplyConfiguration constructs an declarative configuration of the PrefetchResource type for use with
+// apply.
+func PrefetchResource() *PrefetchResourceApplyConfiguration {
+	return &PrefetchResourceApplyConfiguration{}
+}
+
+// WithAPIVersion sets the APIVersion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the APIVersion field is set to the value of the last call.
+func (b *PrefetchResourceApplyConfiguration) WithAPIVersion(value string) *PrefetchResourceApplyConfiguration {
+	b.APIVersion = &value
+	return b
+}
+
+// WithResource sets the Resource field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Resource field is set to the value of the last call.
+func (b *PrefetchResourceApplyConfiguration) WithResource(value string) *PrefetchResourceApplyConfiguration {
+	b.Resource = &value
+	return b
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *PrefetchResourceApplyConfiguration) WithNamespace(value string) *PrefetchResourceApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
@@ -0,0 +1,88 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// RelatedResourcesApplyConfiguration represents an declarative configuration of the RelatedResources type for use
+// with apply.
+type RelatedResourcesApplyConfiguration struct {
+	APIVersion   *string  `json:"apiVersion,omitempty"`
+	Kind         *string  `json:"kind,omitempty"`
+	Namespace    *string  `json:"namespace,omitempty"`
+	SelectorPath *string  `json:"selectorPath,omitempty"`
+	Labels       *v1.JSON `json:"labels,omitempty"`
+	JMESPath     *string  `json:"jmesPath,omitempty"`
+}
+
+// RelatedResourcesApplyConfiguration constructs an declarative configuration of the RelatedResources type for use with
+// apply.
+func RelatedResources() *RelatedResourcesApplyConfiguration {
+	return &RelatedResourcesApplyConfiguration{}
+}
+
+// WithAPIVersion sets the APIVersion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the APIVersion field is set to the value of the last call.
+func (b *RelatedResourcesApplyConfiguration) WithAPIVersion(value string) *RelatedResourcesApplyConfiguration {
+	b.APIVersion = &value
+	return b
+}
+
+// WithKind sets the Kind field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Kind field is set to the value of the last call.
+func (b *RelatedResourcesApplyConfiguration) WithKind(value string) *RelatedResourcesApplyConfiguration {
+	b.Kind = &value
+	return b
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *RelatedResourcesApplyConfiguration) WithNamespace(value string) *RelatedResourcesApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithSelectorPath sets the SelectorPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SelectorPath field is set to the value of the last call.
+func (b *RelatedResourcesApplyConfiguration) WithSelectorPath(value string) *RelatedResourcesApplyConfiguration {
+	b.SelectorPath = &value
+	return b
+}
+
+// WithLabels sets the Labels field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Labels field is set to the value of the last call.
+func (b *RelatedResourcesApplyConfiguration) WithLabels(value v1.JSON) *RelatedResourcesApplyConfiguration {
+	b.Labels = &value
+	return b
+}
+
+// WithJMESPath sets the JMESPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the JMESPath field is set to the value of the last call.
+func (b *RelatedResourcesApplyConfiguration) WithJMESPath(value string) *RelatedResourcesApplyConfiguration {
+	b.JMESPath = &value
+	return b
+}
@@ -26,14 +26,16 @@ import (
 // ResourceDescriptionApplyConfiguration represents an declarative configuration of the ResourceDescription type for use
 // with apply.
 type ResourceDescriptionApplyConfiguration struct {
-	Kinds             []string                       `json:"kinds,omitempty"`
-	Name              *string                        `json:"name,omitempty"`
-	Names             []string                       `json:"names,omitempty"`
-	Namespaces        []string                       `json:"namespaces,omitempty"`
-	Annotations       map[string]string              `json:"annotations,omitempty"`
-	Selector          *v1.LabelSelector              `json:"selector,omitempty"`
-	NamespaceSelector *v1.LabelSelector              `json:"namespaceSelector,omitempty"`
-	Operations        []kyvernov1.AdmissionOperation `json:"operations,omitempty"`
+	Kinds             []string                            `json:"kinds,omitempty"`
+	Name              *string                             `json:"name,omitempty"`
+	Names             []string                            `json:"names,omitempty"`
+	Namespaces        []string                            `json:"namespaces,omitempty"`
+	Annotations       map[string]string                   `json:"annotations,omitempty"`
+	Selector          *v1.LabelSelector                   `json:"selector,omitempty"`
+	NamespaceSelector *v1.LabelSelector                   `json:"namespaceSelector,omitempty"`
+	FieldSelector     *string                             `json:"fieldSelector,omitempty"`
+	Owner             *OwnerDescriptionApplyConfiguration `json:"owner,omitempty"`
+	Operations        []kyvernov1.AdmissionOperation      `json:"operations,omitempty"`
 }
 
 // ResourceDescriptionApplyConfiguration constructs an declarative configuration of the ResourceDescription type for use with
@@ -110,6 +112,22 @@ func (b *ResourceDescriptionApplyConfiguration) WithNamespaceSelector(value v1.L
 	return b
 }
 
+// WithFieldSelector sets the FieldSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FieldSelector field is set to the value of the last call.
+func (b *ResourceDescriptionApplyConfiguration) WithFieldSelector(value string) *ResourceDescriptionApplyConfiguration {
+	b.FieldSelector = &value
+	return b
+}
+
+// WithOwner sets the Owner field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Owner field is set to the value of the last call.
+func (b *ResourceDescriptionApplyConfiguration) WithOwner(value *OwnerDescriptionApplyConfiguration) *ResourceDescriptionApplyConfiguration {
+	b.Owner = value
+	return b
+}
+
 // WithOperations adds the given value to the Operations field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Operations field.
@@ -22,6 +22,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	v1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // RuleApplyConfiguration represents an declarative configuration of the Rule type for use
@@ -29,6 +30,7 @@ import (
 type RuleApplyConfiguration struct {
 	Name                   *string                               `json:"name,omitempty"`
 	Context                []ContextEntryApplyConfiguration      `json:"context,omitempty"`
+	Prefetch               *PrefetchApplyConfiguration           `json:"prefetch,omitempty"`
 	MatchResources         *MatchResourcesApplyConfiguration     `json:"match,omitempty"`
 	ExcludeResources       *MatchResourcesApplyConfiguration     `json:"exclude,omitempty"`
 	ImageExtractors        *kyvernov1.ImageExtractorConfigs      `json:"imageExtractors,omitempty"`
@@ -36,9 +38,13 @@ type RuleApplyConfiguration struct {
 	CELPreconditions       []v1alpha1.MatchCondition             `json:"celPreconditions,omitempty"`
 	Mutation               *MutationApplyConfiguration           `json:"mutate,omitempty"`
 	Validation             *ValidationApplyConfiguration         `json:"validate,omitempty"`
+	Remediation            *string                               `json:"remediation,omitempty"`
 	Generation             *GenerationApplyConfiguration         `json:"generate,omitempty"`
 	VerifyImages           []ImageVerificationApplyConfiguration `json:"verifyImages,omitempty"`
 	SkipBackgroundRequests *bool                                 `json:"skipBackgroundRequests,omitempty"`
+	Timeout                *v1.Duration                          `json:"timeout,omitempty"`
+	TimeoutFailurePolicy   *kyvernov1.FailurePolicyType          `json:"timeoutFailurePolicy,omitempty"`
+	Autogen                *RuleAutogenApplyConfiguration        `json:"autogen,omitempty"`
 }
 
 // RuleApplyConfiguration constructs an declarative configuration of the Rule type for use with
@@ -68,6 +74,14 @@ func (b *RuleApplyConfiguration) WithContext(values ...*ContextEntryApplyConfigu
 	return b
 }
 
+// WithPrefetch sets the Prefetch field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Prefetch field is set to the value of the last call.
+func (b *RuleApplyConfiguration) WithPrefetch(value *PrefetchApplyConfiguration) *RuleApplyConfiguration {
+	b.Prefetch = value
+	return b
+}
+
 // WithMatchResources sets the MatchResources field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the MatchResources field is set to the value of the last call.
@@ -126,6 +140,14 @@ func (b *RuleApplyConfiguration) WithValidation(value *ValidationApplyConfigurat
 	return b
 }
 
+// WithRemediation sets the Remediation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Remediation field is set to the value of the last call.
+func (b *RuleApplyConfiguration) WithRemediation(value string) *RuleApplyConfiguration {
+	b.Remediation = &value
+	return b
+}
+
 // WithGeneration sets the Generation field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Generation field is set to the value of the last call.
@@ -154,3 +176,27 @@ func (b *RuleApplyConfiguration) WithSkipBackgroundRequests(value bool) *RuleApp
 	b.SkipBackgroundRequests = &value
 	return b
 }
+
+// WithTimeout sets the Timeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Timeout field is set to the value of the last call.
+func (b *RuleApplyConfiguration) WithTimeout(value v1.Duration) *RuleApplyConfiguration {
+	b.Timeout = &value
+	return b
+}
+
+// WithTimeoutFailurePolicy sets the TimeoutFailurePolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TimeoutFailurePolicy field is set to the value of the last call.
+func (b *RuleApplyConfiguration) WithTimeoutFailurePolicy(value kyvernov1.FailurePolicyType) *RuleApplyConfiguration {
+	b.TimeoutFailurePolicy = &value
+	return b
+}
+
+// WithAutogen sets the Autogen field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Autogen field is set to the value of the last call.
+func (b *RuleApplyConfiguration) WithAutogen(value *RuleAutogenApplyConfiguration) *RuleApplyConfiguration {
+	b.Autogen = value
+	return b
+}
@@ -0,0 +1,50 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// RuleAutogenApplyConfiguration represents an declarative configuration of the RuleAutogen type for use
+// with apply.
+type RuleAutogenApplyConfiguration struct {
+	Enabled     *bool    `json:"enabled,omitempty"`
+	Controllers []string `json:"controllers,omitempty"`
+}
+
+// RuleAutogenApplyConfiguration constructs an declarative configuration of the RuleAutogen type for use with
+// apply.
+func RuleAutogen() *RuleAutogenApplyConfiguration {
+	return &RuleAutogenApplyConfiguration{}
+}
+
+// WithEnabled sets the Enabled field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Enabled field is set to the value of the last call.
+func (b *RuleAutogenApplyConfiguration) WithEnabled(value bool) *RuleAutogenApplyConfiguration {
+	b.Enabled = &value
+	return b
+}
+
+// WithControllers adds the given value to the Controllers field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Controllers field.
+func (b *RuleAutogenApplyConfiguration) WithControllers(values ...string) *RuleAutogenApplyConfiguration {
+	for i := range values {
+		b.Controllers = append(b.Controllers, values[i])
+	}
+	return b
+}
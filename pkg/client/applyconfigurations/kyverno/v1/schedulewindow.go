@@ -0,0 +1,52 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScheduleWindowApplyConfiguration represents an declarative configuration of the ScheduleWindow type for use
+// with apply.
+type ScheduleWindowApplyConfiguration struct {
+	Start    *string      `json:"start,omitempty"`
+	Duration *v1.Duration `json:"duration,omitempty"`
+}
+
+// ScheduleWindowApplyConfiguration constructs an declarative configuration of the ScheduleWindow type for use with
+// apply.
+func ScheduleWindow() *ScheduleWindowApplyConfiguration {
+	return &ScheduleWindowApplyConfiguration{}
+}
+
+// WithStart sets the Start field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Start field is set to the value of the last call.
+func (b *ScheduleWindowApplyConfiguration) WithStart(value string) *ScheduleWindowApplyConfiguration {
+	b.Start = &value
+	return b
+}
+
+// WithDuration sets the Duration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Duration field is set to the value of the last call.
+func (b *ScheduleWindowApplyConfiguration) WithDuration(value v1.Duration) *ScheduleWindowApplyConfiguration {
+	b.Duration = &value
+	return b
+}
@@ -33,6 +33,7 @@ type ValidationApplyConfiguration struct {
 	Deny              *DenyApplyConfiguration               `json:"deny,omitempty"`
 	PodSecurity       *PodSecurityApplyConfiguration        `json:"podSecurity,omitempty"`
 	CEL               *CELApplyConfiguration                `json:"cel,omitempty"`
+	WASM              *WASMApplyConfiguration               `json:"wasm,omitempty"`
 }
 
 // ValidationApplyConfiguration constructs an declarative configuration of the Validation type for use with
@@ -109,3 +110,11 @@ func (b *ValidationApplyConfiguration) WithCEL(value *CELApplyConfiguration) *Va
 	b.CEL = value
 	return b
 }
+
+// WithWASM sets the WASM field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WASM field is set to the value of the last call.
+func (b *ValidationApplyConfiguration) WithWASM(value *WASMApplyConfiguration) *ValidationApplyConfiguration {
+	b.WASM = value
+	return b
+}
@@ -0,0 +1,71 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	v1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v1"
+)
+
+// ClusterTargetStatusApplyConfiguration represents an declarative configuration of the ClusterTargetStatus type for use
+// with apply.
+type ClusterTargetStatusApplyConfiguration struct {
+	Cluster  *v1.ClusterReferenceApplyConfiguration `json:"cluster,omitempty"`
+	Resource *v1.ResourceSpecApplyConfiguration     `json:"resource,omitempty"`
+	State    *v1beta1.UpdateRequestState            `json:"state,omitempty"`
+	Message  *string                                `json:"message,omitempty"`
+}
+
+// ClusterTargetStatusApplyConfiguration constructs an declarative configuration of the ClusterTargetStatus type for use with
+// apply.
+func ClusterTargetStatus() *ClusterTargetStatusApplyConfiguration {
+	return &ClusterTargetStatusApplyConfiguration{}
+}
+
+// WithCluster sets the Cluster field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cluster field is set to the value of the last call.
+func (b *ClusterTargetStatusApplyConfiguration) WithCluster(value *v1.ClusterReferenceApplyConfiguration) *ClusterTargetStatusApplyConfiguration {
+	b.Cluster = value
+	return b
+}
+
+// WithResource sets the Resource field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Resource field is set to the value of the last call.
+func (b *ClusterTargetStatusApplyConfiguration) WithResource(value *v1.ResourceSpecApplyConfiguration) *ClusterTargetStatusApplyConfiguration {
+	b.Resource = value
+	return b
+}
+
+// WithState sets the State field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the State field is set to the value of the last call.
+func (b *ClusterTargetStatusApplyConfiguration) WithState(value v1beta1.UpdateRequestState) *ClusterTargetStatusApplyConfiguration {
+	b.State = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *ClusterTargetStatusApplyConfiguration) WithMessage(value string) *ClusterTargetStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
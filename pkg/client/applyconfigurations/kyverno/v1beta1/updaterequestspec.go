@@ -26,13 +26,14 @@ import (
 // UpdateRequestSpecApplyConfiguration represents an declarative configuration of the UpdateRequestSpec type for use
 // with apply.
 type UpdateRequestSpecApplyConfiguration struct {
-	Type             *v1beta1.RequestType                        `json:"requestType,omitempty"`
-	Policy           *string                                     `json:"policy,omitempty"`
-	Rule             *string                                     `json:"rule,omitempty"`
-	DeleteDownstream *bool                                       `json:"deleteDownstream,omitempty"`
-	Synchronize      *bool                                       `json:"synchronize,omitempty"`
-	Resource         *v1.ResourceSpecApplyConfiguration          `json:"resource,omitempty"`
-	Context          *UpdateRequestSpecContextApplyConfiguration `json:"context,omitempty"`
+	Type             *v1beta1.RequestType                          `json:"requestType,omitempty"`
+	Policy           *string                                       `json:"policy,omitempty"`
+	Rule             *string                                       `json:"rule,omitempty"`
+	DeleteDownstream *bool                                         `json:"deleteDownstream,omitempty"`
+	Synchronize      *bool                                         `json:"synchronize,omitempty"`
+	Resource         *v1.ResourceSpecApplyConfiguration            `json:"resource,omitempty"`
+	ServiceAccount   *v1.ServiceAccountReferenceApplyConfiguration `json:"serviceAccount,omitempty"`
+	Context          *UpdateRequestSpecContextApplyConfiguration   `json:"context,omitempty"`
 }
 
 // UpdateRequestSpecApplyConfiguration constructs an declarative configuration of the UpdateRequestSpec type for use with
@@ -89,6 +90,14 @@ func (b *UpdateRequestSpecApplyConfiguration) WithResource(value *v1.ResourceSpe
 	return b
 }
 
+// WithServiceAccount sets the ServiceAccount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceAccount field is set to the value of the last call.
+func (b *UpdateRequestSpecApplyConfiguration) WithServiceAccount(value *v1.ServiceAccountReferenceApplyConfiguration) *UpdateRequestSpecApplyConfiguration {
+	b.ServiceAccount = value
+	return b
+}
+
 // WithContext sets the Context field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Context field is set to the value of the last call.
@@ -26,11 +26,12 @@ import (
 // UpdateRequestStatusApplyConfiguration represents an declarative configuration of the UpdateRequestStatus type for use
 // with apply.
 type UpdateRequestStatusApplyConfiguration struct {
-	Handler            *string                             `json:"handler,omitempty"`
-	State              *v1beta1.UpdateRequestState         `json:"state,omitempty"`
-	Message            *string                             `json:"message,omitempty"`
-	GeneratedResources []v1.ResourceSpecApplyConfiguration `json:"generatedResources,omitempty"`
-	RetryCount         *int                                `json:"retryCount,omitempty"`
+	Handler            *string                                 `json:"handler,omitempty"`
+	State              *v1beta1.UpdateRequestState             `json:"state,omitempty"`
+	Message            *string                                 `json:"message,omitempty"`
+	GeneratedResources []v1.ResourceSpecApplyConfiguration     `json:"generatedResources,omitempty"`
+	ClusterTargets     []ClusterTargetStatusApplyConfiguration `json:"clusterTargets,omitempty"`
+	RetryCount         *int                                    `json:"retryCount,omitempty"`
 }
 
 // UpdateRequestStatusApplyConfiguration constructs an declarative configuration of the UpdateRequestStatus type for use with
@@ -76,6 +77,19 @@ func (b *UpdateRequestStatusApplyConfiguration) WithGeneratedResources(values ..
 	return b
 }
 
+// WithClusterTargets adds the given value to the ClusterTargets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ClusterTargets field.
+func (b *UpdateRequestStatusApplyConfiguration) WithClusterTargets(values ...*ClusterTargetStatusApplyConfiguration) *UpdateRequestStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithClusterTargets")
+		}
+		b.ClusterTargets = append(b.ClusterTargets, *values[i])
+	}
+	return b
+}
+
 // WithRetryCount sets the RetryCount field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the RetryCount field is set to the value of the last call.
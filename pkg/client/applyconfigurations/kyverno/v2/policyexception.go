@@ -29,7 +29,8 @@ import (
 type PolicyExceptionApplyConfiguration struct {
 	v1.TypeMetaApplyConfiguration    `json:",omitempty,inline"`
 	*v1.ObjectMetaApplyConfiguration `json:"metadata,omitempty"`
-	Spec                             *PolicyExceptionSpecApplyConfiguration `json:"spec,omitempty"`
+	Spec                             *PolicyExceptionSpecApplyConfiguration   `json:"spec,omitempty"`
+	Status                           *PolicyExceptionStatusApplyConfiguration `json:"status,omitempty"`
 }
 
 // PolicyException constructs an declarative configuration of the PolicyException type for use with
@@ -208,3 +209,11 @@ func (b *PolicyExceptionApplyConfiguration) WithSpec(value *PolicyExceptionSpecA
 	b.Spec = value
 	return b
 }
+
+// WithStatus sets the Status field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Status field is set to the value of the last call.
+func (b *PolicyExceptionApplyConfiguration) WithStatus(value *PolicyExceptionStatusApplyConfiguration) *PolicyExceptionApplyConfiguration {
+	b.Status = value
+	return b
+}
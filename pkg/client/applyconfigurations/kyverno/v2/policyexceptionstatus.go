@@ -0,0 +1,59 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PolicyExceptionStatusApplyConfiguration represents an declarative configuration of the PolicyExceptionStatus type for use
+// with apply.
+type PolicyExceptionStatusApplyConfiguration struct {
+	Conditions []v1.Condition                           `json:"conditions,omitempty"`
+	Usage      []PolicyExceptionUsageApplyConfiguration `json:"usage,omitempty"`
+}
+
+// PolicyExceptionStatusApplyConfiguration constructs an declarative configuration of the PolicyExceptionStatus type for use with
+// apply.
+func PolicyExceptionStatus() *PolicyExceptionStatusApplyConfiguration {
+	return &PolicyExceptionStatusApplyConfiguration{}
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *PolicyExceptionStatusApplyConfiguration) WithConditions(values ...v1.Condition) *PolicyExceptionStatusApplyConfiguration {
+	for i := range values {
+		b.Conditions = append(b.Conditions, values[i])
+	}
+	return b
+}
+
+// WithUsage adds the given value to the Usage field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Usage field.
+func (b *PolicyExceptionStatusApplyConfiguration) WithUsage(values ...*PolicyExceptionUsageApplyConfiguration) *PolicyExceptionStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithUsage")
+		}
+		b.Usage = append(b.Usage, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,70 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PolicyExceptionUsageApplyConfiguration represents an declarative configuration of the PolicyExceptionUsage type for use
+// with apply.
+type PolicyExceptionUsageApplyConfiguration struct {
+	PolicyName *string  `json:"policyName,omitempty"`
+	RuleName   *string  `json:"ruleName,omitempty"`
+	Count      *int64   `json:"count,omitempty"`
+	LastUsed   *v1.Time `json:"lastUsed,omitempty"`
+}
+
+// PolicyExceptionUsageApplyConfiguration constructs an declarative configuration of the PolicyExceptionUsage type for use with
+// apply.
+func PolicyExceptionUsage() *PolicyExceptionUsageApplyConfiguration {
+	return &PolicyExceptionUsageApplyConfiguration{}
+}
+
+// WithPolicyName sets the PolicyName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PolicyName field is set to the value of the last call.
+func (b *PolicyExceptionUsageApplyConfiguration) WithPolicyName(value string) *PolicyExceptionUsageApplyConfiguration {
+	b.PolicyName = &value
+	return b
+}
+
+// WithRuleName sets the RuleName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RuleName field is set to the value of the last call.
+func (b *PolicyExceptionUsageApplyConfiguration) WithRuleName(value string) *PolicyExceptionUsageApplyConfiguration {
+	b.RuleName = &value
+	return b
+}
+
+// WithCount sets the Count field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Count field is set to the value of the last call.
+func (b *PolicyExceptionUsageApplyConfiguration) WithCount(value int64) *PolicyExceptionUsageApplyConfiguration {
+	b.Count = &value
+	return b
+}
+
+// WithLastUsed sets the LastUsed field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastUsed field is set to the value of the last call.
+func (b *PolicyExceptionUsageApplyConfiguration) WithLastUsed(value v1.Time) *PolicyExceptionUsageApplyConfiguration {
+	b.LastUsed = &value
+	return b
+}
@@ -19,6 +19,7 @@ limitations under the License.
 package v2alpha1
 
 import (
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 	v1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v1"
 	v2beta1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v2beta1"
 )
@@ -31,6 +32,12 @@ type CleanupPolicySpecApplyConfiguration struct {
 	ExcludeResources *v2beta1.MatchResourcesApplyConfiguration   `json:"exclude,omitempty"`
 	Schedule         *string                                     `json:"schedule,omitempty"`
 	Conditions       *v2beta1.AnyAllConditionsApplyConfiguration `json:"conditions,omitempty"`
+	OlderThan        *v2beta1.CleanupAgeApplyConfiguration       `json:"olderThan,omitempty"`
+	DryRun           *bool                                       `json:"dryRun,omitempty"`
+	Action           *kyvernov2beta1.CleanupAction               `json:"action,omitempty"`
+	Annotations      map[string]string                           `json:"annotations,omitempty"`
+	PreDelete        *v2beta1.CleanupPreDeleteApplyConfiguration `json:"preDelete,omitempty"`
+	RateLimit        *v2beta1.CleanupRateLimitApplyConfiguration `json:"rateLimit,omitempty"`
 }
 
 // CleanupPolicySpecApplyConfiguration constructs an declarative configuration of the CleanupPolicySpec type for use with
@@ -83,3 +90,57 @@ func (b *CleanupPolicySpecApplyConfiguration) WithConditions(value *v2beta1.AnyA
 	b.Conditions = value
 	return b
 }
+
+// WithOlderThan sets the OlderThan field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OlderThan field is set to the value of the last call.
+func (b *CleanupPolicySpecApplyConfiguration) WithOlderThan(value *v2beta1.CleanupAgeApplyConfiguration) *CleanupPolicySpecApplyConfiguration {
+	b.OlderThan = value
+	return b
+}
+
+// WithDryRun sets the DryRun field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DryRun field is set to the value of the last call.
+func (b *CleanupPolicySpecApplyConfiguration) WithDryRun(value bool) *CleanupPolicySpecApplyConfiguration {
+	b.DryRun = &value
+	return b
+}
+
+// WithAction sets the Action field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Action field is set to the value of the last call.
+func (b *CleanupPolicySpecApplyConfiguration) WithAction(value kyvernov2beta1.CleanupAction) *CleanupPolicySpecApplyConfiguration {
+	b.Action = &value
+	return b
+}
+
+// WithAnnotations puts the entries into the Annotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Annotations field,
+// overwriting an existing map entries in Annotations field with the same key.
+func (b *CleanupPolicySpecApplyConfiguration) WithAnnotations(entries map[string]string) *CleanupPolicySpecApplyConfiguration {
+	if b.Annotations == nil && len(entries) > 0 {
+		b.Annotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Annotations[k] = v
+	}
+	return b
+}
+
+// WithPreDelete sets the PreDelete field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PreDelete field is set to the value of the last call.
+func (b *CleanupPolicySpecApplyConfiguration) WithPreDelete(value *v2beta1.CleanupPreDeleteApplyConfiguration) *CleanupPolicySpecApplyConfiguration {
+	b.PreDelete = value
+	return b
+}
+
+// WithRateLimit sets the RateLimit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RateLimit field is set to the value of the last call.
+func (b *CleanupPolicySpecApplyConfiguration) WithRateLimit(value *v2beta1.CleanupRateLimitApplyConfiguration) *CleanupPolicySpecApplyConfiguration {
+	b.RateLimit = value
+	return b
+}
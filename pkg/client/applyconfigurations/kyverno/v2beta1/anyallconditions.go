@@ -23,6 +23,7 @@ package v2beta1
 type AnyAllConditionsApplyConfiguration struct {
 	AnyConditions []ConditionApplyConfiguration `json:"any,omitempty"`
 	AllConditions []ConditionApplyConfiguration `json:"all,omitempty"`
+	Templates     []string                      `json:"templates,omitempty"`
 }
 
 // AnyAllConditionsApplyConfiguration constructs an declarative configuration of the AnyAllConditions type for use with
@@ -56,3 +57,13 @@ func (b *AnyAllConditionsApplyConfiguration) WithAllConditions(values ...*Condit
 	}
 	return b
 }
+
+// WithTemplates adds the given value to the Templates field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Templates field.
+func (b *AnyAllConditionsApplyConfiguration) WithTemplates(values ...string) *AnyAllConditionsApplyConfiguration {
+	for i := range values {
+		b.Templates = append(b.Templates, values[i])
+	}
+	return b
+}
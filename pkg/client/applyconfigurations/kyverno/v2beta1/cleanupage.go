@@ -0,0 +1,52 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2beta1
+
+import (
+	v2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+)
+
+// CleanupAgeApplyConfiguration represents an declarative configuration of the CleanupAge type for use
+// with apply.
+type CleanupAgeApplyConfiguration struct {
+	Duration *string                      `json:"duration,omitempty"`
+	Since    *v2beta1.CleanupAgeReference `json:"since,omitempty"`
+}
+
+// CleanupAgeApplyConfiguration constructs an declarative configuration of the CleanupAge type for use with
+// apply.
+func CleanupAge() *CleanupAgeApplyConfiguration {
+	return &CleanupAgeApplyConfiguration{}
+}
+
+// WithDuration sets the Duration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Duration field is set to the value of the last call.
+func (b *CleanupAgeApplyConfiguration) WithDuration(value string) *CleanupAgeApplyConfiguration {
+	b.Duration = &value
+	return b
+}
+
+// WithSince sets the Since field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Since field is set to the value of the last call.
+func (b *CleanupAgeApplyConfiguration) WithSince(value v2beta1.CleanupAgeReference) *CleanupAgeApplyConfiguration {
+	b.Since = &value
+	return b
+}
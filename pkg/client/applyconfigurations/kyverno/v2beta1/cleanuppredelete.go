@@ -0,0 +1,63 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2beta1
+
+// CleanupPreDeleteApplyConfiguration represents an declarative configuration of the CleanupPreDelete type for use
+// with apply.
+type CleanupPreDeleteApplyConfiguration struct {
+	Event       *bool                             `json:"event,omitempty"`
+	Annotations map[string]string                 `json:"annotations,omitempty"`
+	Webhook     *CleanupWebhookApplyConfiguration `json:"webhook,omitempty"`
+}
+
+// CleanupPreDeleteApplyConfiguration constructs an declarative configuration of the CleanupPreDelete type for use with
+// apply.
+func CleanupPreDelete() *CleanupPreDeleteApplyConfiguration {
+	return &CleanupPreDeleteApplyConfiguration{}
+}
+
+// WithEvent sets the Event field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Event field is set to the value of the last call.
+func (b *CleanupPreDeleteApplyConfiguration) WithEvent(value bool) *CleanupPreDeleteApplyConfiguration {
+	b.Event = &value
+	return b
+}
+
+// WithAnnotations puts the entries into the Annotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Annotations field,
+// overwriting an existing map entries in Annotations field with the same key.
+func (b *CleanupPreDeleteApplyConfiguration) WithAnnotations(entries map[string]string) *CleanupPreDeleteApplyConfiguration {
+	if b.Annotations == nil && len(entries) > 0 {
+		b.Annotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Annotations[k] = v
+	}
+	return b
+}
+
+// WithWebhook sets the Webhook field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Webhook field is set to the value of the last call.
+func (b *CleanupPreDeleteApplyConfiguration) WithWebhook(value *CleanupWebhookApplyConfiguration) *CleanupPreDeleteApplyConfiguration {
+	b.Webhook = value
+	return b
+}
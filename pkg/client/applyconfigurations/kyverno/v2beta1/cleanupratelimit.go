@@ -0,0 +1,57 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v2beta1
+
+// CleanupRateLimitApplyConfiguration represents an declarative configuration of the CleanupRateLimit type for use
+// with apply.
+type CleanupRateLimitApplyConfiguration struct {
+	DeletionsPerSecond *int `json:"deletionsPerSecond,omitempty"`
+	Burst              *int `json:"burst,omitempty"`
+	BatchSize          *int `json:"batchSize,omitempty"`
+}
+
+// CleanupRateLimitApplyConfiguration constructs an declarative configuration of the CleanupRateLimit type for use with
+// apply.
+func CleanupRateLimit() *CleanupRateLimitApplyConfiguration {
+	return &CleanupRateLimitApplyConfiguration{}
+}
+
+// WithDeletionsPerSecond sets the DeletionsPerSecond field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeletionsPerSecond field is set to the value of the last call.
+func (b *CleanupRateLimitApplyConfiguration) WithDeletionsPerSecond(value int) *CleanupRateLimitApplyConfiguration {
+	b.DeletionsPerSecond = &value
+	return b
+}
+
+// WithBurst sets the Burst field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Burst field is set to the value of the last call.
+func (b *CleanupRateLimitApplyConfiguration) WithBurst(value int) *CleanupRateLimitApplyConfiguration {
+	b.Burst = &value
+	return b
+}
+
+// WithBatchSize sets the BatchSize field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BatchSize field is set to the value of the last call.
+func (b *CleanupRateLimitApplyConfiguration) WithBatchSize(value int) *CleanupRateLimitApplyConfiguration {
+	b.BatchSize = &value
+	return b
+}
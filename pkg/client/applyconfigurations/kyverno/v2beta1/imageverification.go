@@ -36,6 +36,7 @@ type ImageVerificationApplyConfiguration struct {
 	Required                 *bool                                                 `json:"required,omitempty"`
 	ImageRegistryCredentials *kyvernov1.ImageRegistryCredentialsApplyConfiguration `json:"imageRegistryCredentials,omitempty"`
 	UseCache                 *bool                                                 `json:"useCache,omitempty"`
+	StampVerification        *bool                                                 `json:"stampVerification,omitempty"`
 }
 
 // ImageVerificationApplyConfiguration constructs an declarative configuration of the ImageVerification type for use with
@@ -135,3 +136,11 @@ func (b *ImageVerificationApplyConfiguration) WithUseCache(value bool) *ImageVer
 	b.UseCache = &value
 	return b
 }
+
+// WithStampVerification sets the StampVerification field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StampVerification field is set to the value of the last call.
+func (b *ImageVerificationApplyConfiguration) WithStampVerification(value bool) *ImageVerificationApplyConfiguration {
+	b.StampVerification = &value
+	return b
+}
@@ -18,13 +18,22 @@ limitations under the License.
 
 package v2beta1
 
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // PolicyExceptionSpecApplyConfiguration represents an declarative configuration of the PolicyExceptionSpec type for use
 // with apply.
 type PolicyExceptionSpecApplyConfiguration struct {
-	Background *bool                               `json:"background,omitempty"`
-	Match      *MatchResourcesApplyConfiguration   `json:"match,omitempty"`
-	Conditions *AnyAllConditionsApplyConfiguration `json:"conditions,omitempty"`
-	Exceptions []ExceptionApplyConfiguration       `json:"exceptions,omitempty"`
+	Background        *bool                               `json:"background,omitempty"`
+	Match             *MatchResourcesApplyConfiguration   `json:"match,omitempty"`
+	Conditions        *AnyAllConditionsApplyConfiguration `json:"conditions,omitempty"`
+	Images            []string                            `json:"images,omitempty"`
+	NamespaceSelector *v1.LabelSelector                   `json:"namespaceSelector,omitempty"`
+	Exceptions        []ExceptionApplyConfiguration       `json:"exceptions,omitempty"`
+	ExpiresAt         *v1.Time                            `json:"expiresAt,omitempty"`
+	TTL               *string                             `json:"ttl,omitempty"`
+	DeleteOnExpiry    *bool                               `json:"deleteOnExpiry,omitempty"`
 }
 
 // PolicyExceptionSpecApplyConfiguration constructs an declarative configuration of the PolicyExceptionSpec type for use with
@@ -57,6 +66,24 @@ func (b *PolicyExceptionSpecApplyConfiguration) WithConditions(value *AnyAllCond
 	return b
 }
 
+// WithImages adds the given value to the Images field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Images field.
+func (b *PolicyExceptionSpecApplyConfiguration) WithImages(values ...string) *PolicyExceptionSpecApplyConfiguration {
+	for i := range values {
+		b.Images = append(b.Images, values[i])
+	}
+	return b
+}
+
+// WithNamespaceSelector sets the NamespaceSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamespaceSelector field is set to the value of the last call.
+func (b *PolicyExceptionSpecApplyConfiguration) WithNamespaceSelector(value v1.LabelSelector) *PolicyExceptionSpecApplyConfiguration {
+	b.NamespaceSelector = &value
+	return b
+}
+
 // WithExceptions adds the given value to the Exceptions field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Exceptions field.
@@ -69,3 +96,27 @@ func (b *PolicyExceptionSpecApplyConfiguration) WithExceptions(values ...*Except
 	}
 	return b
 }
+
+// WithExpiresAt sets the ExpiresAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ExpiresAt field is set to the value of the last call.
+func (b *PolicyExceptionSpecApplyConfiguration) WithExpiresAt(value v1.Time) *PolicyExceptionSpecApplyConfiguration {
+	b.ExpiresAt = &value
+	return b
+}
+
+// WithTTL sets the TTL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TTL field is set to the value of the last call.
+func (b *PolicyExceptionSpecApplyConfiguration) WithTTL(value string) *PolicyExceptionSpecApplyConfiguration {
+	b.TTL = &value
+	return b
+}
+
+// WithDeleteOnExpiry sets the DeleteOnExpiry field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeleteOnExpiry field is set to the value of the last call.
+func (b *PolicyExceptionSpecApplyConfiguration) WithDeleteOnExpiry(value bool) *PolicyExceptionSpecApplyConfiguration {
+	b.DeleteOnExpiry = &value
+	return b
+}
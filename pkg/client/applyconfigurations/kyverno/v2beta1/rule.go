@@ -22,6 +22,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	v1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v1"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // RuleApplyConfiguration represents an declarative configuration of the Rule type for use
@@ -29,6 +30,7 @@ import (
 type RuleApplyConfiguration struct {
 	Name                   *string                                  `json:"name,omitempty"`
 	Context                []v1.ContextEntryApplyConfiguration      `json:"context,omitempty"`
+	Prefetch               *v1.PrefetchApplyConfiguration           `json:"prefetch,omitempty"`
 	MatchResources         *MatchResourcesApplyConfiguration        `json:"match,omitempty"`
 	ExcludeResources       *MatchResourcesApplyConfiguration        `json:"exclude,omitempty"`
 	ImageExtractors        *kyvernov1.ImageExtractorConfigs         `json:"imageExtractors,omitempty"`
@@ -36,9 +38,13 @@ type RuleApplyConfiguration struct {
 	CELPreconditions       []admissionregistrationv1.MatchCondition `json:"celPreconditions,omitempty"`
 	Mutation               *v1.MutationApplyConfiguration           `json:"mutate,omitempty"`
 	Validation             *ValidationApplyConfiguration            `json:"validate,omitempty"`
+	Remediation            *string                                  `json:"remediation,omitempty"`
 	Generation             *v1.GenerationApplyConfiguration         `json:"generate,omitempty"`
 	VerifyImages           []ImageVerificationApplyConfiguration    `json:"verifyImages,omitempty"`
 	SkipBackgroundRequests *bool                                    `json:"skipBackgroundRequests,omitempty"`
+	Timeout                *metav1.Duration                         `json:"timeout,omitempty"`
+	TimeoutFailurePolicy   *kyvernov1.FailurePolicyType             `json:"timeoutFailurePolicy,omitempty"`
+	Autogen                *v1.RuleAutogenApplyConfiguration        `json:"autogen,omitempty"`
 }
 
 // RuleApplyConfiguration constructs an declarative configuration of the Rule type for use with
@@ -68,6 +74,14 @@ func (b *RuleApplyConfiguration) WithContext(values ...*v1.ContextEntryApplyConf
 	return b
 }
 
+// WithPrefetch sets the Prefetch field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Prefetch field is set to the value of the last call.
+func (b *RuleApplyConfiguration) WithPrefetch(value *v1.PrefetchApplyConfiguration) *RuleApplyConfiguration {
+	b.Prefetch = value
+	return b
+}
+
 // WithMatchResources sets the MatchResources field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the MatchResources field is set to the value of the last call.
@@ -126,6 +140,14 @@ func (b *RuleApplyConfiguration) WithValidation(value *ValidationApplyConfigurat
 	return b
 }
 
+// WithRemediation sets the Remediation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Remediation field is set to the value of the last call.
+func (b *RuleApplyConfiguration) WithRemediation(value string) *RuleApplyConfiguration {
+	b.Remediation = &value
+	return b
+}
+
 // WithGeneration sets the Generation field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Generation field is set to the value of the last call.
@@ -154,3 +176,27 @@ func (b *RuleApplyConfiguration) WithSkipBackgroundRequests(value bool) *RuleApp
 	b.SkipBackgroundRequests = &value
 	return b
 }
+
+// WithTimeout sets the Timeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Timeout field is set to the value of the last call.
+func (b *RuleApplyConfiguration) WithTimeout(value metav1.Duration) *RuleApplyConfiguration {
+	b.Timeout = &value
+	return b
+}
+
+// WithTimeoutFailurePolicy sets the TimeoutFailurePolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TimeoutFailurePolicy field is set to the value of the last call.
+func (b *RuleApplyConfiguration) WithTimeoutFailurePolicy(value kyvernov1.FailurePolicyType) *RuleApplyConfiguration {
+	b.TimeoutFailurePolicy = &value
+	return b
+}
+
+// WithAutogen sets the Autogen field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Autogen field is set to the value of the last call.
+func (b *RuleApplyConfiguration) WithAutogen(value *v1.RuleAutogenApplyConfiguration) *RuleApplyConfiguration {
+	b.Autogen = value
+	return b
+}
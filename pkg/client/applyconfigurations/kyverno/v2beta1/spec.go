@@ -27,10 +27,17 @@ import (
 // with apply.
 type SpecApplyConfiguration struct {
 	Rules                            []RuleApplyConfiguration                                      `json:"rules,omitempty"`
+	ConditionTemplates               []ConditionTemplateApplyConfiguration                         `json:"conditionTemplates,omitempty"`
 	ApplyRules                       *v1.ApplyRulesType                                            `json:"applyRules,omitempty"`
 	FailurePolicy                    *v1.FailurePolicyType                                         `json:"failurePolicy,omitempty"`
+	ReinvocationPolicy               *v1.ReinvocationPolicyType                                    `json:"reinvocationPolicy,omitempty"`
 	ValidationFailureAction          *v1.ValidationFailureAction                                   `json:"validationFailureAction,omitempty"`
 	ValidationFailureActionOverrides []kyvernov1.ValidationFailureActionOverrideApplyConfiguration `json:"validationFailureActionOverrides,omitempty"`
+	Schedule                         *kyvernov1.PolicyScheduleApplyConfiguration                   `json:"schedule,omitempty"`
+	EmitWarning                      *bool                                                         `json:"emitWarning,omitempty"`
+	Ordering                         *kyvernov1.PolicyOrderingApplyConfiguration                   `json:"ordering,omitempty"`
+	Parameters                       *kyvernov1.PolicyParametersApplyConfiguration                 `json:"parameters,omitempty"`
+	ServiceAccount                   *kyvernov1.ServiceAccountReferenceApplyConfiguration          `json:"serviceAccount,omitempty"`
 	Admission                        *bool                                                         `json:"admission,omitempty"`
 	Background                       *bool                                                         `json:"background,omitempty"`
 	SchemaValidation                 *bool                                                         `json:"schemaValidation,omitempty"`
@@ -60,6 +67,19 @@ func (b *SpecApplyConfiguration) WithRules(values ...*RuleApplyConfiguration) *S
 	return b
 }
 
+// WithConditionTemplates adds the given value to the ConditionTemplates field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ConditionTemplates field.
+func (b *SpecApplyConfiguration) WithConditionTemplates(values ...*ConditionTemplateApplyConfiguration) *SpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditionTemplates")
+		}
+		b.ConditionTemplates = append(b.ConditionTemplates, *values[i])
+	}
+	return b
+}
+
 // WithApplyRules sets the ApplyRules field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ApplyRules field is set to the value of the last call.
@@ -76,6 +96,14 @@ func (b *SpecApplyConfiguration) WithFailurePolicy(value v1.FailurePolicyType) *
 	return b
 }
 
+// WithReinvocationPolicy sets the ReinvocationPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReinvocationPolicy field is set to the value of the last call.
+func (b *SpecApplyConfiguration) WithReinvocationPolicy(value v1.ReinvocationPolicyType) *SpecApplyConfiguration {
+	b.ReinvocationPolicy = &value
+	return b
+}
+
 // WithValidationFailureAction sets the ValidationFailureAction field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ValidationFailureAction field is set to the value of the last call.
@@ -97,6 +125,46 @@ func (b *SpecApplyConfiguration) WithValidationFailureActionOverrides(values ...
 	return b
 }
 
+// WithSchedule sets the Schedule field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Schedule field is set to the value of the last call.
+func (b *SpecApplyConfiguration) WithSchedule(value *kyvernov1.PolicyScheduleApplyConfiguration) *SpecApplyConfiguration {
+	b.Schedule = value
+	return b
+}
+
+// WithEmitWarning sets the EmitWarning field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EmitWarning field is set to the value of the last call.
+func (b *SpecApplyConfiguration) WithEmitWarning(value bool) *SpecApplyConfiguration {
+	b.EmitWarning = &value
+	return b
+}
+
+// WithOrdering sets the Ordering field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Ordering field is set to the value of the last call.
+func (b *SpecApplyConfiguration) WithOrdering(value *kyvernov1.PolicyOrderingApplyConfiguration) *SpecApplyConfiguration {
+	b.Ordering = value
+	return b
+}
+
+// WithParameters sets the Parameters field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Parameters field is set to the value of the last call.
+func (b *SpecApplyConfiguration) WithParameters(value *kyvernov1.PolicyParametersApplyConfiguration) *SpecApplyConfiguration {
+	b.Parameters = value
+	return b
+}
+
+// WithServiceAccount sets the ServiceAccount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceAccount field is set to the value of the last call.
+func (b *SpecApplyConfiguration) WithServiceAccount(value *kyvernov1.ServiceAccountReferenceApplyConfiguration) *SpecApplyConfiguration {
+	b.ServiceAccount = value
+	return b
+}
+
 // WithAdmission sets the Admission field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Admission field is set to the value of the last call.
@@ -34,6 +34,7 @@ type ValidationApplyConfiguration struct {
 	Deny              *DenyApplyConfiguration                  `json:"deny,omitempty"`
 	PodSecurity       *v1.PodSecurityApplyConfiguration        `json:"podSecurity,omitempty"`
 	CEL               *v1.CELApplyConfiguration                `json:"cel,omitempty"`
+	WASM              *v1.WASMApplyConfiguration               `json:"wasm,omitempty"`
 }
 
 // ValidationApplyConfiguration constructs an declarative configuration of the Validation type for use with
@@ -110,3 +111,11 @@ func (b *ValidationApplyConfiguration) WithCEL(value *v1.CELApplyConfiguration)
 	b.CEL = value
 	return b
 }
+
+// WithWASM sets the WASM field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WASM field is set to the value of the last call.
+func (b *ValidationApplyConfiguration) WithWASM(value *v1.WASMApplyConfiguration) *ValidationApplyConfiguration {
+	b.WASM = value
+	return b
+}
@@ -47,6 +47,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.APICallApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Attestation"):
 		return &kyvernov1.AttestationApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("AttestationClusterMetadata"):
+		return &kyvernov1.AttestationClusterMetadataApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Attestor"):
 		return &kyvernov1.AttestorApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("AttestorSet"):
@@ -63,8 +65,12 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.CloneListApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ClusterPolicy"):
 		return &kyvernov1.ClusterPolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ClusterReference"):
+		return &kyvernov1.ClusterReferenceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Condition"):
 		return &kyvernov1.ConditionApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ConditionTemplate"):
+		return &kyvernov1.ConditionTemplateApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ConfigMapReference"):
 		return &kyvernov1.ConfigMapReferenceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ContextEntry"):
@@ -73,6 +79,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.CTLogApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Deny"):
 		return &kyvernov1.DenyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("DenyViolations"):
+		return &kyvernov1.DenyViolationsApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("DryRunOption"):
 		return &kyvernov1.DryRunOptionApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ForEachMutation"):
@@ -99,16 +107,30 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.MutationApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ObjectFieldBinding"):
 		return &kyvernov1.ObjectFieldBindingApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OwnerDescription"):
+		return &kyvernov1.OwnerDescriptionApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ParamKind"):
+		return &kyvernov1.ParamKindApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ParamRef"):
+		return &kyvernov1.ParamRefApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("PodSecurity"):
 		return &kyvernov1.PodSecurityApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("PodSecurityStandard"):
 		return &kyvernov1.PodSecurityStandardApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Policy"):
 		return &kyvernov1.PolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PolicyOrdering"):
+		return &kyvernov1.PolicyOrderingApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PolicyParameters"):
+		return &kyvernov1.PolicyParametersApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PolicySchedule"):
+		return &kyvernov1.PolicyScheduleApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("PolicyStatus"):
 		return &kyvernov1.PolicyStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Rekor"):
 		return &kyvernov1.RekorApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RelatedResources"):
+		return &kyvernov1.RelatedResourcesApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RequestData"):
 		return &kyvernov1.RequestDataApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ResourceDescription"):
@@ -119,10 +141,18 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.ResourceSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Rule"):
 		return &kyvernov1.RuleApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RuleAutogen"):
+		return &kyvernov1.RuleAutogenApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RuleCountStatus"):
 		return &kyvernov1.RuleCountStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RuleStatus"):
+		return &kyvernov1.RuleStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ScheduleWindow"):
+		return &kyvernov1.ScheduleWindowApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("SecretReference"):
 		return &kyvernov1.SecretReferenceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ServiceAccountReference"):
+		return &kyvernov1.ServiceAccountReferenceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ServiceCall"):
 		return &kyvernov1.ServiceCallApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Spec"):
@@ -141,6 +171,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.ValidationFailureActionOverrideApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Variable"):
 		return &kyvernov1.VariableApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("WASM"):
+		return &kyvernov1.WASMApplyConfiguration{}
 
 		// Group=kyverno.io, Version=v1alpha2
 	case v1alpha2.SchemeGroupVersion.WithKind("AdmissionReport"):
@@ -159,6 +191,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		// Group=kyverno.io, Version=v1beta1
 	case v1beta1.SchemeGroupVersion.WithKind("AdmissionRequestInfoObject"):
 		return &kyvernov1beta1.AdmissionRequestInfoObjectApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ClusterTargetStatus"):
+		return &kyvernov1beta1.ClusterTargetStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("RequestInfo"):
 		return &kyvernov1beta1.RequestInfoApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("UpdateRequest"):
@@ -177,6 +211,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov2.PolicyExceptionApplyConfiguration{}
 	case v2.SchemeGroupVersion.WithKind("PolicyExceptionSpec"):
 		return &kyvernov2.PolicyExceptionSpecApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("PolicyExceptionStatus"):
+		return &kyvernov2.PolicyExceptionStatusApplyConfiguration{}
+	case v2.SchemeGroupVersion.WithKind("PolicyExceptionUsage"):
+		return &kyvernov2.PolicyExceptionUsageApplyConfiguration{}
 
 		// Group=kyverno.io, Version=v2alpha1
 	case v2alpha1.SchemeGroupVersion.WithKind("CleanupPolicy"):
@@ -189,18 +227,28 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		// Group=kyverno.io, Version=v2beta1
 	case v2beta1.SchemeGroupVersion.WithKind("AnyAllConditions"):
 		return &kyvernov2beta1.AnyAllConditionsApplyConfiguration{}
+	case v2beta1.SchemeGroupVersion.WithKind("CleanupAge"):
+		return &kyvernov2beta1.CleanupAgeApplyConfiguration{}
 	case v2beta1.SchemeGroupVersion.WithKind("CleanupPolicy"):
 		return &kyvernov2beta1.CleanupPolicyApplyConfiguration{}
 	case v2beta1.SchemeGroupVersion.WithKind("CleanupPolicySpec"):
 		return &kyvernov2beta1.CleanupPolicySpecApplyConfiguration{}
 	case v2beta1.SchemeGroupVersion.WithKind("CleanupPolicyStatus"):
 		return &kyvernov2beta1.CleanupPolicyStatusApplyConfiguration{}
+	case v2beta1.SchemeGroupVersion.WithKind("CleanupPreDelete"):
+		return &kyvernov2beta1.CleanupPreDeleteApplyConfiguration{}
+	case v2beta1.SchemeGroupVersion.WithKind("CleanupRateLimit"):
+		return &kyvernov2beta1.CleanupRateLimitApplyConfiguration{}
+	case v2beta1.SchemeGroupVersion.WithKind("CleanupWebhook"):
+		return &kyvernov2beta1.CleanupWebhookApplyConfiguration{}
 	case v2beta1.SchemeGroupVersion.WithKind("ClusterCleanupPolicy"):
 		return &kyvernov2beta1.ClusterCleanupPolicyApplyConfiguration{}
 	case v2beta1.SchemeGroupVersion.WithKind("ClusterPolicy"):
 		return &kyvernov2beta1.ClusterPolicyApplyConfiguration{}
 	case v2beta1.SchemeGroupVersion.WithKind("Condition"):
 		return &kyvernov2beta1.ConditionApplyConfiguration{}
+	case v2beta1.SchemeGroupVersion.WithKind("ConditionTemplate"):
+		return &kyvernov2beta1.ConditionTemplateApplyConfiguration{}
 	case v2beta1.SchemeGroupVersion.WithKind("Deny"):
 		return &kyvernov2beta1.DenyApplyConfiguration{}
 	case v2beta1.SchemeGroupVersion.WithKind("Exception"):
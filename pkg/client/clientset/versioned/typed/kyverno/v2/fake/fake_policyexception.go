@@ -101,6 +101,18 @@ func (c *FakePolicyExceptions) Update(ctx context.Context, policyException *v2.P
 	return obj.(*v2.PolicyException), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakePolicyExceptions) UpdateStatus(ctx context.Context, policyException *v2.PolicyException, opts v1.UpdateOptions) (*v2.PolicyException, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(policyexceptionsResource, "status", c.ns, policyException), &v2.PolicyException{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2.PolicyException), err
+}
+
 // Delete takes name of the policyException and deletes it. Returns an error if one occurs.
 func (c *FakePolicyExceptions) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
@@ -40,6 +40,7 @@ type PolicyExceptionsGetter interface {
 type PolicyExceptionInterface interface {
 	Create(ctx context.Context, policyException *v2.PolicyException, opts v1.CreateOptions) (*v2.PolicyException, error)
 	Update(ctx context.Context, policyException *v2.PolicyException, opts v1.UpdateOptions) (*v2.PolicyException, error)
+	UpdateStatus(ctx context.Context, policyException *v2.PolicyException, opts v1.UpdateOptions) (*v2.PolicyException, error)
 	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
 	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
 	Get(ctx context.Context, name string, opts v1.GetOptions) (*v2.PolicyException, error)
@@ -135,6 +136,22 @@ func (c *policyExceptions) Update(ctx context.Context, policyException *v2.Polic
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *policyExceptions) UpdateStatus(ctx context.Context, policyException *v2.PolicyException, opts v1.UpdateOptions) (result *v2.PolicyException, err error) {
+	result = &v2.PolicyException{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("policyexceptions").
+		Name(policyException.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(policyException).
+		Do(ctx).
+		Into(result)
+	return
+}
+
 // Delete takes name of the policyException and deletes it. Returns an error if one occurs.
 func (c *policyExceptions) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	return c.client.Delete().
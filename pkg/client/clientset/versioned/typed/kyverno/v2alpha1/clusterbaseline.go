@@ -0,0 +1,184 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v2alpha1
+
+import (
+	"context"
+	"time"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ClusterBaselinesGetter has a method to return a ClusterBaselineInterface.
+// A group's client should implement this interface.
+type ClusterBaselinesGetter interface {
+	ClusterBaselines() ClusterBaselineInterface
+}
+
+// ClusterBaselineInterface has methods to work with ClusterBaseline resources.
+type ClusterBaselineInterface interface {
+	Create(ctx context.Context, clusterBaseline *v2alpha1.ClusterBaseline, opts v1.CreateOptions) (*v2alpha1.ClusterBaseline, error)
+	Update(ctx context.Context, clusterBaseline *v2alpha1.ClusterBaseline, opts v1.UpdateOptions) (*v2alpha1.ClusterBaseline, error)
+	UpdateStatus(ctx context.Context, clusterBaseline *v2alpha1.ClusterBaseline, opts v1.UpdateOptions) (*v2alpha1.ClusterBaseline, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v2alpha1.ClusterBaseline, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v2alpha1.ClusterBaselineList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.ClusterBaseline, err error)
+	ClusterBaselineExpansion
+}
+
+// clusterBaselines implements ClusterBaselineInterface
+type clusterBaselines struct {
+	client rest.Interface
+}
+
+// newClusterBaselines returns a ClusterBaselines
+func newClusterBaselines(c *KyvernoV2alpha1Client) *clusterBaselines {
+	return &clusterBaselines{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the clusterBaseline, and returns the corresponding clusterBaseline object, and an error if there is any.
+func (c *clusterBaselines) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.ClusterBaseline, err error) {
+	result = &v2alpha1.ClusterBaseline{}
+	err = c.client.Get().
+		Resource("clusterbaselines").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterBaselines that match those selectors.
+func (c *clusterBaselines) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.ClusterBaselineList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v2alpha1.ClusterBaselineList{}
+	err = c.client.Get().
+		Resource("clusterbaselines").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterBaselines.
+func (c *clusterBaselines) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("clusterbaselines").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a clusterBaseline and creates it.  Returns the server's representation of the clusterBaseline, and an error, if there is any.
+func (c *clusterBaselines) Create(ctx context.Context, clusterBaseline *v2alpha1.ClusterBaseline, opts v1.CreateOptions) (result *v2alpha1.ClusterBaseline, err error) {
+	result = &v2alpha1.ClusterBaseline{}
+	err = c.client.Post().
+		Resource("clusterbaselines").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterBaseline).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterBaseline and updates it. Returns the server's representation of the clusterBaseline, and an error, if there is any.
+func (c *clusterBaselines) Update(ctx context.Context, clusterBaseline *v2alpha1.ClusterBaseline, opts v1.UpdateOptions) (result *v2alpha1.ClusterBaseline, err error) {
+	result = &v2alpha1.ClusterBaseline{}
+	err = c.client.Put().
+		Resource("clusterbaselines").
+		Name(clusterBaseline.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterBaseline).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *clusterBaselines) UpdateStatus(ctx context.Context, clusterBaseline *v2alpha1.ClusterBaseline, opts v1.UpdateOptions) (result *v2alpha1.ClusterBaseline, err error) {
+	result = &v2alpha1.ClusterBaseline{}
+	err = c.client.Put().
+		Resource("clusterbaselines").
+		Name(clusterBaseline.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterBaseline).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterBaseline and deletes it. Returns an error if one occurs.
+func (c *clusterBaselines) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("clusterbaselines").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterBaselines) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("clusterbaselines").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterBaseline.
+func (c *clusterBaselines) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.ClusterBaseline, err error) {
+	result = &v2alpha1.ClusterBaseline{}
+	err = c.client.Patch(pt).
+		Resource("clusterbaselines").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,184 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v2alpha1
+
+import (
+	"context"
+	"time"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ClusterComplianceSummariesGetter has a method to return a ClusterComplianceSummaryInterface.
+// A group's client should implement this interface.
+type ClusterComplianceSummariesGetter interface {
+	ClusterComplianceSummaries() ClusterComplianceSummaryInterface
+}
+
+// ClusterComplianceSummaryInterface has methods to work with ClusterComplianceSummary resources.
+type ClusterComplianceSummaryInterface interface {
+	Create(ctx context.Context, clusterComplianceSummary *v2alpha1.ClusterComplianceSummary, opts v1.CreateOptions) (*v2alpha1.ClusterComplianceSummary, error)
+	Update(ctx context.Context, clusterComplianceSummary *v2alpha1.ClusterComplianceSummary, opts v1.UpdateOptions) (*v2alpha1.ClusterComplianceSummary, error)
+	UpdateStatus(ctx context.Context, clusterComplianceSummary *v2alpha1.ClusterComplianceSummary, opts v1.UpdateOptions) (*v2alpha1.ClusterComplianceSummary, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v2alpha1.ClusterComplianceSummary, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v2alpha1.ClusterComplianceSummaryList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.ClusterComplianceSummary, err error)
+	ClusterComplianceSummaryExpansion
+}
+
+// clusterComplianceSummaries implements ClusterComplianceSummaryInterface
+type clusterComplianceSummaries struct {
+	client rest.Interface
+}
+
+// newClusterComplianceSummaries returns a ClusterComplianceSummaries
+func newClusterComplianceSummaries(c *KyvernoV2alpha1Client) *clusterComplianceSummaries {
+	return &clusterComplianceSummaries{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the clusterComplianceSummary, and returns the corresponding clusterComplianceSummary object, and an error if there is any.
+func (c *clusterComplianceSummaries) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.ClusterComplianceSummary, err error) {
+	result = &v2alpha1.ClusterComplianceSummary{}
+	err = c.client.Get().
+		Resource("clustercompliancesummaries").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterComplianceSummaries that match those selectors.
+func (c *clusterComplianceSummaries) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.ClusterComplianceSummaryList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v2alpha1.ClusterComplianceSummaryList{}
+	err = c.client.Get().
+		Resource("clustercompliancesummaries").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterComplianceSummaries.
+func (c *clusterComplianceSummaries) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("clustercompliancesummaries").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a clusterComplianceSummary and creates it.  Returns the server's representation of the clusterComplianceSummary, and an error, if there is any.
+func (c *clusterComplianceSummaries) Create(ctx context.Context, clusterComplianceSummary *v2alpha1.ClusterComplianceSummary, opts v1.CreateOptions) (result *v2alpha1.ClusterComplianceSummary, err error) {
+	result = &v2alpha1.ClusterComplianceSummary{}
+	err = c.client.Post().
+		Resource("clustercompliancesummaries").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterComplianceSummary).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterComplianceSummary and updates it. Returns the server's representation of the clusterComplianceSummary, and an error, if there is any.
+func (c *clusterComplianceSummaries) Update(ctx context.Context, clusterComplianceSummary *v2alpha1.ClusterComplianceSummary, opts v1.UpdateOptions) (result *v2alpha1.ClusterComplianceSummary, err error) {
+	result = &v2alpha1.ClusterComplianceSummary{}
+	err = c.client.Put().
+		Resource("clustercompliancesummaries").
+		Name(clusterComplianceSummary.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterComplianceSummary).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *clusterComplianceSummaries) UpdateStatus(ctx context.Context, clusterComplianceSummary *v2alpha1.ClusterComplianceSummary, opts v1.UpdateOptions) (result *v2alpha1.ClusterComplianceSummary, err error) {
+	result = &v2alpha1.ClusterComplianceSummary{}
+	err = c.client.Put().
+		Resource("clustercompliancesummaries").
+		Name(clusterComplianceSummary.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterComplianceSummary).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterComplianceSummary and deletes it. Returns an error if one occurs.
+func (c *clusterComplianceSummaries) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("clustercompliancesummaries").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterComplianceSummaries) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("clustercompliancesummaries").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterComplianceSummary.
+func (c *clusterComplianceSummaries) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.ClusterComplianceSummary, err error) {
+	result = &v2alpha1.ClusterComplianceSummary{}
+	err = c.client.Patch(pt).
+		Resource("clustercompliancesummaries").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,184 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v2alpha1
+
+import (
+	"context"
+	"time"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ComplianceScansGetter has a method to return a ComplianceScanInterface.
+// A group's client should implement this interface.
+type ComplianceScansGetter interface {
+	ComplianceScans() ComplianceScanInterface
+}
+
+// ComplianceScanInterface has methods to work with ComplianceScan resources.
+type ComplianceScanInterface interface {
+	Create(ctx context.Context, complianceScan *v2alpha1.ComplianceScan, opts v1.CreateOptions) (*v2alpha1.ComplianceScan, error)
+	Update(ctx context.Context, complianceScan *v2alpha1.ComplianceScan, opts v1.UpdateOptions) (*v2alpha1.ComplianceScan, error)
+	UpdateStatus(ctx context.Context, complianceScan *v2alpha1.ComplianceScan, opts v1.UpdateOptions) (*v2alpha1.ComplianceScan, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v2alpha1.ComplianceScan, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v2alpha1.ComplianceScanList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.ComplianceScan, err error)
+	ComplianceScanExpansion
+}
+
+// complianceScans implements ComplianceScanInterface
+type complianceScans struct {
+	client rest.Interface
+}
+
+// newComplianceScans returns a ComplianceScans
+func newComplianceScans(c *KyvernoV2alpha1Client) *complianceScans {
+	return &complianceScans{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the complianceScan, and returns the corresponding complianceScan object, and an error if there is any.
+func (c *complianceScans) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.ComplianceScan, err error) {
+	result = &v2alpha1.ComplianceScan{}
+	err = c.client.Get().
+		Resource("compliancescans").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ComplianceScans that match those selectors.
+func (c *complianceScans) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.ComplianceScanList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v2alpha1.ComplianceScanList{}
+	err = c.client.Get().
+		Resource("compliancescans").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested complianceScans.
+func (c *complianceScans) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("compliancescans").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a complianceScan and creates it.  Returns the server's representation of the complianceScan, and an error, if there is any.
+func (c *complianceScans) Create(ctx context.Context, complianceScan *v2alpha1.ComplianceScan, opts v1.CreateOptions) (result *v2alpha1.ComplianceScan, err error) {
+	result = &v2alpha1.ComplianceScan{}
+	err = c.client.Post().
+		Resource("compliancescans").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(complianceScan).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a complianceScan and updates it. Returns the server's representation of the complianceScan, and an error, if there is any.
+func (c *complianceScans) Update(ctx context.Context, complianceScan *v2alpha1.ComplianceScan, opts v1.UpdateOptions) (result *v2alpha1.ComplianceScan, err error) {
+	result = &v2alpha1.ComplianceScan{}
+	err = c.client.Put().
+		Resource("compliancescans").
+		Name(complianceScan.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(complianceScan).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *complianceScans) UpdateStatus(ctx context.Context, complianceScan *v2alpha1.ComplianceScan, opts v1.UpdateOptions) (result *v2alpha1.ComplianceScan, err error) {
+	result = &v2alpha1.ComplianceScan{}
+	err = c.client.Put().
+		Resource("compliancescans").
+		Name(complianceScan.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(complianceScan).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the complianceScan and deletes it. Returns an error if one occurs.
+func (c *complianceScans) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("compliancescans").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *complianceScans) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("compliancescans").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched complianceScan.
+func (c *complianceScans) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.ComplianceScan, err error) {
+	result = &v2alpha1.ComplianceScan{}
+	err = c.client.Patch(pt).
+		Resource("compliancescans").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,184 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v2alpha1
+
+import (
+	"context"
+	"time"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ExclusionPoliciesGetter has a method to return a ExclusionPolicyInterface.
+// A group's client should implement this interface.
+type ExclusionPoliciesGetter interface {
+	ExclusionPolicies() ExclusionPolicyInterface
+}
+
+// ExclusionPolicyInterface has methods to work with ExclusionPolicy resources.
+type ExclusionPolicyInterface interface {
+	Create(ctx context.Context, exclusionPolicy *v2alpha1.ExclusionPolicy, opts v1.CreateOptions) (*v2alpha1.ExclusionPolicy, error)
+	Update(ctx context.Context, exclusionPolicy *v2alpha1.ExclusionPolicy, opts v1.UpdateOptions) (*v2alpha1.ExclusionPolicy, error)
+	UpdateStatus(ctx context.Context, exclusionPolicy *v2alpha1.ExclusionPolicy, opts v1.UpdateOptions) (*v2alpha1.ExclusionPolicy, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v2alpha1.ExclusionPolicy, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v2alpha1.ExclusionPolicyList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.ExclusionPolicy, err error)
+	ExclusionPolicyExpansion
+}
+
+// exclusionPolicies implements ExclusionPolicyInterface
+type exclusionPolicies struct {
+	client rest.Interface
+}
+
+// newExclusionPolicies returns a ExclusionPolicies
+func newExclusionPolicies(c *KyvernoV2alpha1Client) *exclusionPolicies {
+	return &exclusionPolicies{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the exclusionPolicy, and returns the corresponding exclusionPolicy object, and an error if there is any.
+func (c *exclusionPolicies) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.ExclusionPolicy, err error) {
+	result = &v2alpha1.ExclusionPolicy{}
+	err = c.client.Get().
+		Resource("exclusionpolicies").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ExclusionPolicies that match those selectors.
+func (c *exclusionPolicies) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.ExclusionPolicyList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v2alpha1.ExclusionPolicyList{}
+	err = c.client.Get().
+		Resource("exclusionpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested exclusionPolicies.
+func (c *exclusionPolicies) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("exclusionpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a exclusionPolicy and creates it.  Returns the server's representation of the exclusionPolicy, and an error, if there is any.
+func (c *exclusionPolicies) Create(ctx context.Context, exclusionPolicy *v2alpha1.ExclusionPolicy, opts v1.CreateOptions) (result *v2alpha1.ExclusionPolicy, err error) {
+	result = &v2alpha1.ExclusionPolicy{}
+	err = c.client.Post().
+		Resource("exclusionpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(exclusionPolicy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a exclusionPolicy and updates it. Returns the server's representation of the exclusionPolicy, and an error, if there is any.
+func (c *exclusionPolicies) Update(ctx context.Context, exclusionPolicy *v2alpha1.ExclusionPolicy, opts v1.UpdateOptions) (result *v2alpha1.ExclusionPolicy, err error) {
+	result = &v2alpha1.ExclusionPolicy{}
+	err = c.client.Put().
+		Resource("exclusionpolicies").
+		Name(exclusionPolicy.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(exclusionPolicy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *exclusionPolicies) UpdateStatus(ctx context.Context, exclusionPolicy *v2alpha1.ExclusionPolicy, opts v1.UpdateOptions) (result *v2alpha1.ExclusionPolicy, err error) {
+	result = &v2alpha1.ExclusionPolicy{}
+	err = c.client.Put().
+		Resource("exclusionpolicies").
+		Name(exclusionPolicy.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(exclusionPolicy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the exclusionPolicy and deletes it. Returns an error if one occurs.
+func (c *exclusionPolicies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("exclusionpolicies").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *exclusionPolicies) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("exclusionpolicies").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched exclusionPolicy.
+func (c *exclusionPolicies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.ExclusionPolicy, err error) {
+	result = &v2alpha1.ExclusionPolicy{}
+	err = c.client.Patch(pt).
+		Resource("exclusionpolicies").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
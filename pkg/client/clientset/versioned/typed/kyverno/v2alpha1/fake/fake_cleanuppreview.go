@@ -0,0 +1,129 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeCleanupPreviews implements CleanupPreviewInterface
+type FakeCleanupPreviews struct {
+	Fake *FakeKyvernoV2alpha1
+	ns   string
+}
+
+var cleanuppreviewsResource = v2alpha1.SchemeGroupVersion.WithResource("cleanuppreviews")
+
+var cleanuppreviewsKind = v2alpha1.SchemeGroupVersion.WithKind("CleanupPreview")
+
+// Get takes name of the cleanupPreview, and returns the corresponding cleanupPreview object, and an error if there is any.
+func (c *FakeCleanupPreviews) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.CleanupPreview, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(cleanuppreviewsResource, c.ns, name), &v2alpha1.CleanupPreview{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.CleanupPreview), err
+}
+
+// List takes label and field selectors, and returns the list of CleanupPreviews that match those selectors.
+func (c *FakeCleanupPreviews) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.CleanupPreviewList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(cleanuppreviewsResource, cleanuppreviewsKind, c.ns, opts), &v2alpha1.CleanupPreviewList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v2alpha1.CleanupPreviewList{ListMeta: obj.(*v2alpha1.CleanupPreviewList).ListMeta}
+	for _, item := range obj.(*v2alpha1.CleanupPreviewList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested cleanupPreviews.
+func (c *FakeCleanupPreviews) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(cleanuppreviewsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a cleanupPreview and creates it.  Returns the server's representation of the cleanupPreview, and an error, if there is any.
+func (c *FakeCleanupPreviews) Create(ctx context.Context, cleanupPreview *v2alpha1.CleanupPreview, opts v1.CreateOptions) (result *v2alpha1.CleanupPreview, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(cleanuppreviewsResource, c.ns, cleanupPreview), &v2alpha1.CleanupPreview{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.CleanupPreview), err
+}
+
+// Update takes the representation of a cleanupPreview and updates it. Returns the server's representation of the cleanupPreview, and an error, if there is any.
+func (c *FakeCleanupPreviews) Update(ctx context.Context, cleanupPreview *v2alpha1.CleanupPreview, opts v1.UpdateOptions) (result *v2alpha1.CleanupPreview, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(cleanuppreviewsResource, c.ns, cleanupPreview), &v2alpha1.CleanupPreview{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.CleanupPreview), err
+}
+
+// Delete takes name of the cleanupPreview and deletes it. Returns an error if one occurs.
+func (c *FakeCleanupPreviews) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(cleanuppreviewsResource, c.ns, name, opts), &v2alpha1.CleanupPreview{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeCleanupPreviews) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(cleanuppreviewsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v2alpha1.CleanupPreviewList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched cleanupPreview.
+func (c *FakeCleanupPreviews) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.CleanupPreview, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(cleanuppreviewsResource, c.ns, name, pt, data, subresources...), &v2alpha1.CleanupPreview{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.CleanupPreview), err
+}
@@ -0,0 +1,129 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeCleanupReports implements CleanupReportInterface
+type FakeCleanupReports struct {
+	Fake *FakeKyvernoV2alpha1
+	ns   string
+}
+
+var cleanupreportsResource = v2alpha1.SchemeGroupVersion.WithResource("cleanupreports")
+
+var cleanupreportsKind = v2alpha1.SchemeGroupVersion.WithKind("CleanupReport")
+
+// Get takes name of the cleanupReport, and returns the corresponding cleanupReport object, and an error if there is any.
+func (c *FakeCleanupReports) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.CleanupReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(cleanupreportsResource, c.ns, name), &v2alpha1.CleanupReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.CleanupReport), err
+}
+
+// List takes label and field selectors, and returns the list of CleanupReports that match those selectors.
+func (c *FakeCleanupReports) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.CleanupReportList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(cleanupreportsResource, cleanupreportsKind, c.ns, opts), &v2alpha1.CleanupReportList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v2alpha1.CleanupReportList{ListMeta: obj.(*v2alpha1.CleanupReportList).ListMeta}
+	for _, item := range obj.(*v2alpha1.CleanupReportList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested cleanupReports.
+func (c *FakeCleanupReports) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(cleanupreportsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a cleanupReport and creates it.  Returns the server's representation of the cleanupReport, and an error, if there is any.
+func (c *FakeCleanupReports) Create(ctx context.Context, cleanupReport *v2alpha1.CleanupReport, opts v1.CreateOptions) (result *v2alpha1.CleanupReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(cleanupreportsResource, c.ns, cleanupReport), &v2alpha1.CleanupReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.CleanupReport), err
+}
+
+// Update takes the representation of a cleanupReport and updates it. Returns the server's representation of the cleanupReport, and an error, if there is any.
+func (c *FakeCleanupReports) Update(ctx context.Context, cleanupReport *v2alpha1.CleanupReport, opts v1.UpdateOptions) (result *v2alpha1.CleanupReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(cleanupreportsResource, c.ns, cleanupReport), &v2alpha1.CleanupReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.CleanupReport), err
+}
+
+// Delete takes name of the cleanupReport and deletes it. Returns an error if one occurs.
+func (c *FakeCleanupReports) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(cleanupreportsResource, c.ns, name, opts), &v2alpha1.CleanupReport{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeCleanupReports) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(cleanupreportsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v2alpha1.CleanupReportList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched cleanupReport.
+func (c *FakeCleanupReports) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.CleanupReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(cleanupreportsResource, c.ns, name, pt, data, subresources...), &v2alpha1.CleanupReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.CleanupReport), err
+}
@@ -0,0 +1,132 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterBaselines implements ClusterBaselineInterface
+type FakeClusterBaselines struct {
+	Fake *FakeKyvernoV2alpha1
+}
+
+var clusterbaselinesResource = v2alpha1.SchemeGroupVersion.WithResource("clusterbaselines")
+
+var clusterbaselinesKind = v2alpha1.SchemeGroupVersion.WithKind("ClusterBaseline")
+
+// Get takes name of the clusterBaseline, and returns the corresponding clusterBaseline object, and an error if there is any.
+func (c *FakeClusterBaselines) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.ClusterBaseline, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(clusterbaselinesResource, name), &v2alpha1.ClusterBaseline{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ClusterBaseline), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterBaselines that match those selectors.
+func (c *FakeClusterBaselines) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.ClusterBaselineList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(clusterbaselinesResource, clusterbaselinesKind, opts), &v2alpha1.ClusterBaselineList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v2alpha1.ClusterBaselineList{ListMeta: obj.(*v2alpha1.ClusterBaselineList).ListMeta}
+	for _, item := range obj.(*v2alpha1.ClusterBaselineList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterBaselines.
+func (c *FakeClusterBaselines) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(clusterbaselinesResource, opts))
+}
+
+// Create takes the representation of a clusterBaseline and creates it.  Returns the server's representation of the clusterBaseline, and an error, if there is any.
+func (c *FakeClusterBaselines) Create(ctx context.Context, clusterBaseline *v2alpha1.ClusterBaseline, opts v1.CreateOptions) (result *v2alpha1.ClusterBaseline, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(clusterbaselinesResource, clusterBaseline), &v2alpha1.ClusterBaseline{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ClusterBaseline), err
+}
+
+// Update takes the representation of a clusterBaseline and updates it. Returns the server's representation of the clusterBaseline, and an error, if there is any.
+func (c *FakeClusterBaselines) Update(ctx context.Context, clusterBaseline *v2alpha1.ClusterBaseline, opts v1.UpdateOptions) (result *v2alpha1.ClusterBaseline, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(clusterbaselinesResource, clusterBaseline), &v2alpha1.ClusterBaseline{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ClusterBaseline), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeClusterBaselines) UpdateStatus(ctx context.Context, clusterBaseline *v2alpha1.ClusterBaseline, opts v1.UpdateOptions) (*v2alpha1.ClusterBaseline, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(clusterbaselinesResource, "status", clusterBaseline), &v2alpha1.ClusterBaseline{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ClusterBaseline), err
+}
+
+// Delete takes name of the clusterBaseline and deletes it. Returns an error if one occurs.
+func (c *FakeClusterBaselines) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(clusterbaselinesResource, name, opts), &v2alpha1.ClusterBaseline{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterBaselines) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(clusterbaselinesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v2alpha1.ClusterBaselineList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterBaseline.
+func (c *FakeClusterBaselines) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.ClusterBaseline, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterbaselinesResource, name, pt, data, subresources...), &v2alpha1.ClusterBaseline{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ClusterBaseline), err
+}
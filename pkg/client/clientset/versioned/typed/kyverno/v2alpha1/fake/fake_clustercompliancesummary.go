@@ -0,0 +1,132 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterComplianceSummaries implements ClusterComplianceSummaryInterface
+type FakeClusterComplianceSummaries struct {
+	Fake *FakeKyvernoV2alpha1
+}
+
+var clustercompliancesummariesResource = v2alpha1.SchemeGroupVersion.WithResource("clustercompliancesummaries")
+
+var clustercompliancesummariesKind = v2alpha1.SchemeGroupVersion.WithKind("ClusterComplianceSummary")
+
+// Get takes name of the clusterComplianceSummary, and returns the corresponding clusterComplianceSummary object, and an error if there is any.
+func (c *FakeClusterComplianceSummaries) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.ClusterComplianceSummary, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(clustercompliancesummariesResource, name), &v2alpha1.ClusterComplianceSummary{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ClusterComplianceSummary), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterComplianceSummaries that match those selectors.
+func (c *FakeClusterComplianceSummaries) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.ClusterComplianceSummaryList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(clustercompliancesummariesResource, clustercompliancesummariesKind, opts), &v2alpha1.ClusterComplianceSummaryList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v2alpha1.ClusterComplianceSummaryList{ListMeta: obj.(*v2alpha1.ClusterComplianceSummaryList).ListMeta}
+	for _, item := range obj.(*v2alpha1.ClusterComplianceSummaryList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterComplianceSummaries.
+func (c *FakeClusterComplianceSummaries) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(clustercompliancesummariesResource, opts))
+}
+
+// Create takes the representation of a clusterComplianceSummary and creates it.  Returns the server's representation of the clusterComplianceSummary, and an error, if there is any.
+func (c *FakeClusterComplianceSummaries) Create(ctx context.Context, clusterComplianceSummary *v2alpha1.ClusterComplianceSummary, opts v1.CreateOptions) (result *v2alpha1.ClusterComplianceSummary, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(clustercompliancesummariesResource, clusterComplianceSummary), &v2alpha1.ClusterComplianceSummary{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ClusterComplianceSummary), err
+}
+
+// Update takes the representation of a clusterComplianceSummary and updates it. Returns the server's representation of the clusterComplianceSummary, and an error, if there is any.
+func (c *FakeClusterComplianceSummaries) Update(ctx context.Context, clusterComplianceSummary *v2alpha1.ClusterComplianceSummary, opts v1.UpdateOptions) (result *v2alpha1.ClusterComplianceSummary, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(clustercompliancesummariesResource, clusterComplianceSummary), &v2alpha1.ClusterComplianceSummary{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ClusterComplianceSummary), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeClusterComplianceSummaries) UpdateStatus(ctx context.Context, clusterComplianceSummary *v2alpha1.ClusterComplianceSummary, opts v1.UpdateOptions) (*v2alpha1.ClusterComplianceSummary, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(clustercompliancesummariesResource, "status", clusterComplianceSummary), &v2alpha1.ClusterComplianceSummary{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ClusterComplianceSummary), err
+}
+
+// Delete takes name of the clusterComplianceSummary and deletes it. Returns an error if one occurs.
+func (c *FakeClusterComplianceSummaries) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(clustercompliancesummariesResource, name, opts), &v2alpha1.ClusterComplianceSummary{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterComplianceSummaries) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(clustercompliancesummariesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v2alpha1.ClusterComplianceSummaryList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterComplianceSummary.
+func (c *FakeClusterComplianceSummaries) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.ClusterComplianceSummary, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clustercompliancesummariesResource, name, pt, data, subresources...), &v2alpha1.ClusterComplianceSummary{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ClusterComplianceSummary), err
+}
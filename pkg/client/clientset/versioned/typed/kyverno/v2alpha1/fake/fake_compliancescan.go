@@ -0,0 +1,132 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeComplianceScans implements ComplianceScanInterface
+type FakeComplianceScans struct {
+	Fake *FakeKyvernoV2alpha1
+}
+
+var compliancescansResource = v2alpha1.SchemeGroupVersion.WithResource("compliancescans")
+
+var compliancescansKind = v2alpha1.SchemeGroupVersion.WithKind("ComplianceScan")
+
+// Get takes name of the complianceScan, and returns the corresponding complianceScan object, and an error if there is any.
+func (c *FakeComplianceScans) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.ComplianceScan, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(compliancescansResource, name), &v2alpha1.ComplianceScan{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ComplianceScan), err
+}
+
+// List takes label and field selectors, and returns the list of ComplianceScans that match those selectors.
+func (c *FakeComplianceScans) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.ComplianceScanList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(compliancescansResource, compliancescansKind, opts), &v2alpha1.ComplianceScanList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v2alpha1.ComplianceScanList{ListMeta: obj.(*v2alpha1.ComplianceScanList).ListMeta}
+	for _, item := range obj.(*v2alpha1.ComplianceScanList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested complianceScans.
+func (c *FakeComplianceScans) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(compliancescansResource, opts))
+}
+
+// Create takes the representation of a complianceScan and creates it.  Returns the server's representation of the complianceScan, and an error, if there is any.
+func (c *FakeComplianceScans) Create(ctx context.Context, complianceScan *v2alpha1.ComplianceScan, opts v1.CreateOptions) (result *v2alpha1.ComplianceScan, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(compliancescansResource, complianceScan), &v2alpha1.ComplianceScan{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ComplianceScan), err
+}
+
+// Update takes the representation of a complianceScan and updates it. Returns the server's representation of the complianceScan, and an error, if there is any.
+func (c *FakeComplianceScans) Update(ctx context.Context, complianceScan *v2alpha1.ComplianceScan, opts v1.UpdateOptions) (result *v2alpha1.ComplianceScan, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(compliancescansResource, complianceScan), &v2alpha1.ComplianceScan{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ComplianceScan), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeComplianceScans) UpdateStatus(ctx context.Context, complianceScan *v2alpha1.ComplianceScan, opts v1.UpdateOptions) (*v2alpha1.ComplianceScan, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(compliancescansResource, "status", complianceScan), &v2alpha1.ComplianceScan{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ComplianceScan), err
+}
+
+// Delete takes name of the complianceScan and deletes it. Returns an error if one occurs.
+func (c *FakeComplianceScans) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(compliancescansResource, name, opts), &v2alpha1.ComplianceScan{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeComplianceScans) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(compliancescansResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v2alpha1.ComplianceScanList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched complianceScan.
+func (c *FakeComplianceScans) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.ComplianceScan, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(compliancescansResource, name, pt, data, subresources...), &v2alpha1.ComplianceScan{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ComplianceScan), err
+}
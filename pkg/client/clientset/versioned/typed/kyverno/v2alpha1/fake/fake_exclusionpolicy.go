@@ -0,0 +1,132 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeExclusionPolicies implements ExclusionPolicyInterface
+type FakeExclusionPolicies struct {
+	Fake *FakeKyvernoV2alpha1
+}
+
+var exclusionpoliciesResource = v2alpha1.SchemeGroupVersion.WithResource("exclusionpolicies")
+
+var exclusionpoliciesKind = v2alpha1.SchemeGroupVersion.WithKind("ExclusionPolicy")
+
+// Get takes name of the exclusionPolicy, and returns the corresponding exclusionPolicy object, and an error if there is any.
+func (c *FakeExclusionPolicies) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.ExclusionPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(exclusionpoliciesResource, name), &v2alpha1.ExclusionPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ExclusionPolicy), err
+}
+
+// List takes label and field selectors, and returns the list of ExclusionPolicies that match those selectors.
+func (c *FakeExclusionPolicies) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.ExclusionPolicyList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(exclusionpoliciesResource, exclusionpoliciesKind, opts), &v2alpha1.ExclusionPolicyList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v2alpha1.ExclusionPolicyList{ListMeta: obj.(*v2alpha1.ExclusionPolicyList).ListMeta}
+	for _, item := range obj.(*v2alpha1.ExclusionPolicyList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested exclusionPolicies.
+func (c *FakeExclusionPolicies) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(exclusionpoliciesResource, opts))
+}
+
+// Create takes the representation of a exclusionPolicy and creates it.  Returns the server's representation of the exclusionPolicy, and an error, if there is any.
+func (c *FakeExclusionPolicies) Create(ctx context.Context, exclusionPolicy *v2alpha1.ExclusionPolicy, opts v1.CreateOptions) (result *v2alpha1.ExclusionPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(exclusionpoliciesResource, exclusionPolicy), &v2alpha1.ExclusionPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ExclusionPolicy), err
+}
+
+// Update takes the representation of a exclusionPolicy and updates it. Returns the server's representation of the exclusionPolicy, and an error, if there is any.
+func (c *FakeExclusionPolicies) Update(ctx context.Context, exclusionPolicy *v2alpha1.ExclusionPolicy, opts v1.UpdateOptions) (result *v2alpha1.ExclusionPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(exclusionpoliciesResource, exclusionPolicy), &v2alpha1.ExclusionPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ExclusionPolicy), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeExclusionPolicies) UpdateStatus(ctx context.Context, exclusionPolicy *v2alpha1.ExclusionPolicy, opts v1.UpdateOptions) (*v2alpha1.ExclusionPolicy, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(exclusionpoliciesResource, "status", exclusionPolicy), &v2alpha1.ExclusionPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ExclusionPolicy), err
+}
+
+// Delete takes name of the exclusionPolicy and deletes it. Returns an error if one occurs.
+func (c *FakeExclusionPolicies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(exclusionpoliciesResource, name, opts), &v2alpha1.ExclusionPolicy{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeExclusionPolicies) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(exclusionpoliciesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v2alpha1.ExclusionPolicyList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched exclusionPolicy.
+func (c *FakeExclusionPolicies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.ExclusionPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(exclusionpoliciesResource, name, pt, data, subresources...), &v2alpha1.ExclusionPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ExclusionPolicy), err
+}
@@ -32,14 +32,58 @@ func (c *FakeKyvernoV2alpha1) CleanupPolicies(namespace string) v2alpha1.Cleanup
 	return &FakeCleanupPolicies{c, namespace}
 }
 
+func (c *FakeKyvernoV2alpha1) CleanupPreviews(namespace string) v2alpha1.CleanupPreviewInterface {
+	return &FakeCleanupPreviews{c, namespace}
+}
+
+func (c *FakeKyvernoV2alpha1) CleanupReports(namespace string) v2alpha1.CleanupReportInterface {
+	return &FakeCleanupReports{c, namespace}
+}
+
+func (c *FakeKyvernoV2alpha1) ClusterBaselines() v2alpha1.ClusterBaselineInterface {
+	return &FakeClusterBaselines{c}
+}
+
 func (c *FakeKyvernoV2alpha1) ClusterCleanupPolicies() v2alpha1.ClusterCleanupPolicyInterface {
 	return &FakeClusterCleanupPolicies{c}
 }
 
+func (c *FakeKyvernoV2alpha1) ClusterComplianceSummaries() v2alpha1.ClusterComplianceSummaryInterface {
+	return &FakeClusterComplianceSummaries{c}
+}
+
+func (c *FakeKyvernoV2alpha1) ComplianceScans() v2alpha1.ComplianceScanInterface {
+	return &FakeComplianceScans{c}
+}
+
+func (c *FakeKyvernoV2alpha1) ExclusionPolicies() v2alpha1.ExclusionPolicyInterface {
+	return &FakeExclusionPolicies{c}
+}
+
+func (c *FakeKyvernoV2alpha1) PolicyBindings() v2alpha1.PolicyBindingInterface {
+	return &FakePolicyBindings{c}
+}
+
 func (c *FakeKyvernoV2alpha1) PolicyExceptions(namespace string) v2alpha1.PolicyExceptionInterface {
 	return &FakePolicyExceptions{c, namespace}
 }
 
+func (c *FakeKyvernoV2alpha1) PolicyProfiles() v2alpha1.PolicyProfileInterface {
+	return &FakePolicyProfiles{c}
+}
+
+func (c *FakeKyvernoV2alpha1) PolicySets() v2alpha1.PolicySetInterface {
+	return &FakePolicySets{c}
+}
+
+func (c *FakeKyvernoV2alpha1) PolicySimulations() v2alpha1.PolicySimulationInterface {
+	return &FakePolicySimulations{c}
+}
+
+func (c *FakeKyvernoV2alpha1) ValidatingPolicies() v2alpha1.ValidatingPolicyInterface {
+	return &FakeValidatingPolicies{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeKyvernoV2alpha1) RESTClient() rest.Interface {
@@ -0,0 +1,121 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakePolicyBindings implements PolicyBindingInterface
+type FakePolicyBindings struct {
+	Fake *FakeKyvernoV2alpha1
+}
+
+var policybindingsResource = v2alpha1.SchemeGroupVersion.WithResource("policybindings")
+
+var policybindingsKind = v2alpha1.SchemeGroupVersion.WithKind("PolicyBinding")
+
+// Get takes name of the policyBinding, and returns the corresponding policyBinding object, and an error if there is any.
+func (c *FakePolicyBindings) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.PolicyBinding, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(policybindingsResource, name), &v2alpha1.PolicyBinding{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.PolicyBinding), err
+}
+
+// List takes label and field selectors, and returns the list of PolicyBindings that match those selectors.
+func (c *FakePolicyBindings) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.PolicyBindingList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(policybindingsResource, policybindingsKind, opts), &v2alpha1.PolicyBindingList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v2alpha1.PolicyBindingList{ListMeta: obj.(*v2alpha1.PolicyBindingList).ListMeta}
+	for _, item := range obj.(*v2alpha1.PolicyBindingList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested policyBindings.
+func (c *FakePolicyBindings) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(policybindingsResource, opts))
+}
+
+// Create takes the representation of a policyBinding and creates it.  Returns the server's representation of the policyBinding, and an error, if there is any.
+func (c *FakePolicyBindings) Create(ctx context.Context, policyBinding *v2alpha1.PolicyBinding, opts v1.CreateOptions) (result *v2alpha1.PolicyBinding, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(policybindingsResource, policyBinding), &v2alpha1.PolicyBinding{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.PolicyBinding), err
+}
+
+// Update takes the representation of a policyBinding and updates it. Returns the server's representation of the policyBinding, and an error, if there is any.
+func (c *FakePolicyBindings) Update(ctx context.Context, policyBinding *v2alpha1.PolicyBinding, opts v1.UpdateOptions) (result *v2alpha1.PolicyBinding, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(policybindingsResource, policyBinding), &v2alpha1.PolicyBinding{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.PolicyBinding), err
+}
+
+// Delete takes name of the policyBinding and deletes it. Returns an error if one occurs.
+func (c *FakePolicyBindings) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(policybindingsResource, name, opts), &v2alpha1.PolicyBinding{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakePolicyBindings) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(policybindingsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v2alpha1.PolicyBindingList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched policyBinding.
+func (c *FakePolicyBindings) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.PolicyBinding, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(policybindingsResource, name, pt, data, subresources...), &v2alpha1.PolicyBinding{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.PolicyBinding), err
+}
@@ -0,0 +1,132 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakePolicyProfiles implements PolicyProfileInterface
+type FakePolicyProfiles struct {
+	Fake *FakeKyvernoV2alpha1
+}
+
+var policyprofilesResource = v2alpha1.SchemeGroupVersion.WithResource("policyprofiles")
+
+var policyprofilesKind = v2alpha1.SchemeGroupVersion.WithKind("PolicyProfile")
+
+// Get takes name of the policyProfile, and returns the corresponding policyProfile object, and an error if there is any.
+func (c *FakePolicyProfiles) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.PolicyProfile, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(policyprofilesResource, name), &v2alpha1.PolicyProfile{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.PolicyProfile), err
+}
+
+// List takes label and field selectors, and returns the list of PolicyProfiles that match those selectors.
+func (c *FakePolicyProfiles) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.PolicyProfileList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(policyprofilesResource, policyprofilesKind, opts), &v2alpha1.PolicyProfileList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v2alpha1.PolicyProfileList{ListMeta: obj.(*v2alpha1.PolicyProfileList).ListMeta}
+	for _, item := range obj.(*v2alpha1.PolicyProfileList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested policyProfiles.
+func (c *FakePolicyProfiles) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(policyprofilesResource, opts))
+}
+
+// Create takes the representation of a policyProfile and creates it.  Returns the server's representation of the policyProfile, and an error, if there is any.
+func (c *FakePolicyProfiles) Create(ctx context.Context, policyProfile *v2alpha1.PolicyProfile, opts v1.CreateOptions) (result *v2alpha1.PolicyProfile, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(policyprofilesResource, policyProfile), &v2alpha1.PolicyProfile{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.PolicyProfile), err
+}
+
+// Update takes the representation of a policyProfile and updates it. Returns the server's representation of the policyProfile, and an error, if there is any.
+func (c *FakePolicyProfiles) Update(ctx context.Context, policyProfile *v2alpha1.PolicyProfile, opts v1.UpdateOptions) (result *v2alpha1.PolicyProfile, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(policyprofilesResource, policyProfile), &v2alpha1.PolicyProfile{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.PolicyProfile), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakePolicyProfiles) UpdateStatus(ctx context.Context, policyProfile *v2alpha1.PolicyProfile, opts v1.UpdateOptions) (*v2alpha1.PolicyProfile, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(policyprofilesResource, "status", policyProfile), &v2alpha1.PolicyProfile{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.PolicyProfile), err
+}
+
+// Delete takes name of the policyProfile and deletes it. Returns an error if one occurs.
+func (c *FakePolicyProfiles) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(policyprofilesResource, name, opts), &v2alpha1.PolicyProfile{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakePolicyProfiles) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(policyprofilesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v2alpha1.PolicyProfileList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched policyProfile.
+func (c *FakePolicyProfiles) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.PolicyProfile, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(policyprofilesResource, name, pt, data, subresources...), &v2alpha1.PolicyProfile{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.PolicyProfile), err
+}
@@ -0,0 +1,132 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakePolicySets implements PolicySetInterface
+type FakePolicySets struct {
+	Fake *FakeKyvernoV2alpha1
+}
+
+var policysetsResource = v2alpha1.SchemeGroupVersion.WithResource("policysets")
+
+var policysetsKind = v2alpha1.SchemeGroupVersion.WithKind("PolicySet")
+
+// Get takes name of the policySet, and returns the corresponding policySet object, and an error if there is any.
+func (c *FakePolicySets) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.PolicySet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(policysetsResource, name), &v2alpha1.PolicySet{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.PolicySet), err
+}
+
+// List takes label and field selectors, and returns the list of PolicySets that match those selectors.
+func (c *FakePolicySets) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.PolicySetList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(policysetsResource, policysetsKind, opts), &v2alpha1.PolicySetList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v2alpha1.PolicySetList{ListMeta: obj.(*v2alpha1.PolicySetList).ListMeta}
+	for _, item := range obj.(*v2alpha1.PolicySetList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested policySets.
+func (c *FakePolicySets) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(policysetsResource, opts))
+}
+
+// Create takes the representation of a policySet and creates it.  Returns the server's representation of the policySet, and an error, if there is any.
+func (c *FakePolicySets) Create(ctx context.Context, policySet *v2alpha1.PolicySet, opts v1.CreateOptions) (result *v2alpha1.PolicySet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(policysetsResource, policySet), &v2alpha1.PolicySet{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.PolicySet), err
+}
+
+// Update takes the representation of a policySet and updates it. Returns the server's representation of the policySet, and an error, if there is any.
+func (c *FakePolicySets) Update(ctx context.Context, policySet *v2alpha1.PolicySet, opts v1.UpdateOptions) (result *v2alpha1.PolicySet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(policysetsResource, policySet), &v2alpha1.PolicySet{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.PolicySet), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakePolicySets) UpdateStatus(ctx context.Context, policySet *v2alpha1.PolicySet, opts v1.UpdateOptions) (*v2alpha1.PolicySet, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(policysetsResource, "status", policySet), &v2alpha1.PolicySet{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.PolicySet), err
+}
+
+// Delete takes name of the policySet and deletes it. Returns an error if one occurs.
+func (c *FakePolicySets) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(policysetsResource, name, opts), &v2alpha1.PolicySet{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakePolicySets) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(policysetsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v2alpha1.PolicySetList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched policySet.
+func (c *FakePolicySets) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.PolicySet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(policysetsResource, name, pt, data, subresources...), &v2alpha1.PolicySet{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.PolicySet), err
+}
@@ -0,0 +1,132 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakePolicySimulations implements PolicySimulationInterface
+type FakePolicySimulations struct {
+	Fake *FakeKyvernoV2alpha1
+}
+
+var policysimulationsResource = v2alpha1.SchemeGroupVersion.WithResource("policysimulations")
+
+var policysimulationsKind = v2alpha1.SchemeGroupVersion.WithKind("PolicySimulation")
+
+// Get takes name of the policySimulation, and returns the corresponding policySimulation object, and an error if there is any.
+func (c *FakePolicySimulations) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.PolicySimulation, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(policysimulationsResource, name), &v2alpha1.PolicySimulation{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.PolicySimulation), err
+}
+
+// List takes label and field selectors, and returns the list of PolicySimulations that match those selectors.
+func (c *FakePolicySimulations) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.PolicySimulationList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(policysimulationsResource, policysimulationsKind, opts), &v2alpha1.PolicySimulationList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v2alpha1.PolicySimulationList{ListMeta: obj.(*v2alpha1.PolicySimulationList).ListMeta}
+	for _, item := range obj.(*v2alpha1.PolicySimulationList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested policySimulations.
+func (c *FakePolicySimulations) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(policysimulationsResource, opts))
+}
+
+// Create takes the representation of a policySimulation and creates it.  Returns the server's representation of the policySimulation, and an error, if there is any.
+func (c *FakePolicySimulations) Create(ctx context.Context, policySimulation *v2alpha1.PolicySimulation, opts v1.CreateOptions) (result *v2alpha1.PolicySimulation, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(policysimulationsResource, policySimulation), &v2alpha1.PolicySimulation{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.PolicySimulation), err
+}
+
+// Update takes the representation of a policySimulation and updates it. Returns the server's representation of the policySimulation, and an error, if there is any.
+func (c *FakePolicySimulations) Update(ctx context.Context, policySimulation *v2alpha1.PolicySimulation, opts v1.UpdateOptions) (result *v2alpha1.PolicySimulation, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(policysimulationsResource, policySimulation), &v2alpha1.PolicySimulation{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.PolicySimulation), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakePolicySimulations) UpdateStatus(ctx context.Context, policySimulation *v2alpha1.PolicySimulation, opts v1.UpdateOptions) (*v2alpha1.PolicySimulation, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(policysimulationsResource, "status", policySimulation), &v2alpha1.PolicySimulation{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.PolicySimulation), err
+}
+
+// Delete takes name of the policySimulation and deletes it. Returns an error if one occurs.
+func (c *FakePolicySimulations) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(policysimulationsResource, name, opts), &v2alpha1.PolicySimulation{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakePolicySimulations) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(policysimulationsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v2alpha1.PolicySimulationList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched policySimulation.
+func (c *FakePolicySimulations) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.PolicySimulation, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(policysimulationsResource, name, pt, data, subresources...), &v2alpha1.PolicySimulation{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.PolicySimulation), err
+}
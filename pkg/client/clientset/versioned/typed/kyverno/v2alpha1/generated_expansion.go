@@ -20,6 +20,28 @@ package v2alpha1
 
 type CleanupPolicyExpansion interface{}
 
+type CleanupPreviewExpansion interface{}
+
+type CleanupReportExpansion interface{}
+
+type ClusterBaselineExpansion interface{}
+
 type ClusterCleanupPolicyExpansion interface{}
 
+type ClusterComplianceSummaryExpansion interface{}
+
+type ComplianceScanExpansion interface{}
+
+type ExclusionPolicyExpansion interface{}
+
+type PolicyBindingExpansion interface{}
+
 type PolicyExceptionExpansion interface{}
+
+type PolicyProfileExpansion interface{}
+
+type PolicySetExpansion interface{}
+
+type PolicySimulationExpansion interface{}
+
+type ValidatingPolicyExpansion interface{}
@@ -29,8 +29,19 @@ import (
 type KyvernoV2alpha1Interface interface {
 	RESTClient() rest.Interface
 	CleanupPoliciesGetter
+	CleanupPreviewsGetter
+	CleanupReportsGetter
+	ClusterBaselinesGetter
 	ClusterCleanupPoliciesGetter
+	ClusterComplianceSummariesGetter
+	ComplianceScansGetter
+	ExclusionPoliciesGetter
+	PolicyBindingsGetter
 	PolicyExceptionsGetter
+	PolicyProfilesGetter
+	PolicySetsGetter
+	PolicySimulationsGetter
+	ValidatingPoliciesGetter
 }
 
 // KyvernoV2alpha1Client is used to interact with features provided by the kyverno.io group.
@@ -42,14 +53,58 @@ func (c *KyvernoV2alpha1Client) CleanupPolicies(namespace string) CleanupPolicyI
 	return newCleanupPolicies(c, namespace)
 }
 
+func (c *KyvernoV2alpha1Client) CleanupPreviews(namespace string) CleanupPreviewInterface {
+	return newCleanupPreviews(c, namespace)
+}
+
+func (c *KyvernoV2alpha1Client) CleanupReports(namespace string) CleanupReportInterface {
+	return newCleanupReports(c, namespace)
+}
+
+func (c *KyvernoV2alpha1Client) ClusterBaselines() ClusterBaselineInterface {
+	return newClusterBaselines(c)
+}
+
 func (c *KyvernoV2alpha1Client) ClusterCleanupPolicies() ClusterCleanupPolicyInterface {
 	return newClusterCleanupPolicies(c)
 }
 
+func (c *KyvernoV2alpha1Client) ClusterComplianceSummaries() ClusterComplianceSummaryInterface {
+	return newClusterComplianceSummaries(c)
+}
+
+func (c *KyvernoV2alpha1Client) ComplianceScans() ComplianceScanInterface {
+	return newComplianceScans(c)
+}
+
+func (c *KyvernoV2alpha1Client) ExclusionPolicies() ExclusionPolicyInterface {
+	return newExclusionPolicies(c)
+}
+
+func (c *KyvernoV2alpha1Client) PolicyBindings() PolicyBindingInterface {
+	return newPolicyBindings(c)
+}
+
 func (c *KyvernoV2alpha1Client) PolicyExceptions(namespace string) PolicyExceptionInterface {
 	return newPolicyExceptions(c, namespace)
 }
 
+func (c *KyvernoV2alpha1Client) PolicyProfiles() PolicyProfileInterface {
+	return newPolicyProfiles(c)
+}
+
+func (c *KyvernoV2alpha1Client) PolicySets() PolicySetInterface {
+	return newPolicySets(c)
+}
+
+func (c *KyvernoV2alpha1Client) PolicySimulations() PolicySimulationInterface {
+	return newPolicySimulations(c)
+}
+
+func (c *KyvernoV2alpha1Client) ValidatingPolicies() ValidatingPolicyInterface {
+	return newValidatingPolicies(c)
+}
+
 // NewForConfig creates a new KyvernoV2alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
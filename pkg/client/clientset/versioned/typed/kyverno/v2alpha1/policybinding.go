@@ -0,0 +1,168 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v2alpha1
+
+import (
+	"context"
+	"time"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// PolicyBindingsGetter has a method to return a PolicyBindingInterface.
+// A group's client should implement this interface.
+type PolicyBindingsGetter interface {
+	PolicyBindings() PolicyBindingInterface
+}
+
+// PolicyBindingInterface has methods to work with PolicyBinding resources.
+type PolicyBindingInterface interface {
+	Create(ctx context.Context, policyBinding *v2alpha1.PolicyBinding, opts v1.CreateOptions) (*v2alpha1.PolicyBinding, error)
+	Update(ctx context.Context, policyBinding *v2alpha1.PolicyBinding, opts v1.UpdateOptions) (*v2alpha1.PolicyBinding, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v2alpha1.PolicyBinding, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v2alpha1.PolicyBindingList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.PolicyBinding, err error)
+	PolicyBindingExpansion
+}
+
+// policyBindings implements PolicyBindingInterface
+type policyBindings struct {
+	client rest.Interface
+}
+
+// newPolicyBindings returns a PolicyBindings
+func newPolicyBindings(c *KyvernoV2alpha1Client) *policyBindings {
+	return &policyBindings{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the policyBinding, and returns the corresponding policyBinding object, and an error if there is any.
+func (c *policyBindings) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.PolicyBinding, err error) {
+	result = &v2alpha1.PolicyBinding{}
+	err = c.client.Get().
+		Resource("policybindings").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of PolicyBindings that match those selectors.
+func (c *policyBindings) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.PolicyBindingList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v2alpha1.PolicyBindingList{}
+	err = c.client.Get().
+		Resource("policybindings").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested policyBindings.
+func (c *policyBindings) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("policybindings").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a policyBinding and creates it.  Returns the server's representation of the policyBinding, and an error, if there is any.
+func (c *policyBindings) Create(ctx context.Context, policyBinding *v2alpha1.PolicyBinding, opts v1.CreateOptions) (result *v2alpha1.PolicyBinding, err error) {
+	result = &v2alpha1.PolicyBinding{}
+	err = c.client.Post().
+		Resource("policybindings").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(policyBinding).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a policyBinding and updates it. Returns the server's representation of the policyBinding, and an error, if there is any.
+func (c *policyBindings) Update(ctx context.Context, policyBinding *v2alpha1.PolicyBinding, opts v1.UpdateOptions) (result *v2alpha1.PolicyBinding, err error) {
+	result = &v2alpha1.PolicyBinding{}
+	err = c.client.Put().
+		Resource("policybindings").
+		Name(policyBinding.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(policyBinding).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the policyBinding and deletes it. Returns an error if one occurs.
+func (c *policyBindings) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("policybindings").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *policyBindings) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("policybindings").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched policyBinding.
+func (c *policyBindings) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.PolicyBinding, err error) {
+	result = &v2alpha1.PolicyBinding{}
+	err = c.client.Patch(pt).
+		Resource("policybindings").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
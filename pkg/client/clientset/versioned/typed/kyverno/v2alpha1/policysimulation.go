@@ -0,0 +1,184 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v2alpha1
+
+import (
+	"context"
+	"time"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// PolicySimulationsGetter has a method to return a PolicySimulationInterface.
+// A group's client should implement this interface.
+type PolicySimulationsGetter interface {
+	PolicySimulations() PolicySimulationInterface
+}
+
+// PolicySimulationInterface has methods to work with PolicySimulation resources.
+type PolicySimulationInterface interface {
+	Create(ctx context.Context, policySimulation *v2alpha1.PolicySimulation, opts v1.CreateOptions) (*v2alpha1.PolicySimulation, error)
+	Update(ctx context.Context, policySimulation *v2alpha1.PolicySimulation, opts v1.UpdateOptions) (*v2alpha1.PolicySimulation, error)
+	UpdateStatus(ctx context.Context, policySimulation *v2alpha1.PolicySimulation, opts v1.UpdateOptions) (*v2alpha1.PolicySimulation, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v2alpha1.PolicySimulation, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v2alpha1.PolicySimulationList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.PolicySimulation, err error)
+	PolicySimulationExpansion
+}
+
+// policySimulations implements PolicySimulationInterface
+type policySimulations struct {
+	client rest.Interface
+}
+
+// newPolicySimulations returns a PolicySimulations
+func newPolicySimulations(c *KyvernoV2alpha1Client) *policySimulations {
+	return &policySimulations{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the policySimulation, and returns the corresponding policySimulation object, and an error if there is any.
+func (c *policySimulations) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.PolicySimulation, err error) {
+	result = &v2alpha1.PolicySimulation{}
+	err = c.client.Get().
+		Resource("policysimulations").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of PolicySimulations that match those selectors.
+func (c *policySimulations) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.PolicySimulationList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v2alpha1.PolicySimulationList{}
+	err = c.client.Get().
+		Resource("policysimulations").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested policySimulations.
+func (c *policySimulations) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("policysimulations").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a policySimulation and creates it.  Returns the server's representation of the policySimulation, and an error, if there is any.
+func (c *policySimulations) Create(ctx context.Context, policySimulation *v2alpha1.PolicySimulation, opts v1.CreateOptions) (result *v2alpha1.PolicySimulation, err error) {
+	result = &v2alpha1.PolicySimulation{}
+	err = c.client.Post().
+		Resource("policysimulations").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(policySimulation).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a policySimulation and updates it. Returns the server's representation of the policySimulation, and an error, if there is any.
+func (c *policySimulations) Update(ctx context.Context, policySimulation *v2alpha1.PolicySimulation, opts v1.UpdateOptions) (result *v2alpha1.PolicySimulation, err error) {
+	result = &v2alpha1.PolicySimulation{}
+	err = c.client.Put().
+		Resource("policysimulations").
+		Name(policySimulation.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(policySimulation).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *policySimulations) UpdateStatus(ctx context.Context, policySimulation *v2alpha1.PolicySimulation, opts v1.UpdateOptions) (result *v2alpha1.PolicySimulation, err error) {
+	result = &v2alpha1.PolicySimulation{}
+	err = c.client.Put().
+		Resource("policysimulations").
+		Name(policySimulation.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(policySimulation).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the policySimulation and deletes it. Returns an error if one occurs.
+func (c *policySimulations) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("policysimulations").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *policySimulations) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("policysimulations").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched policySimulation.
+func (c *policySimulations) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.PolicySimulation, err error) {
+	result = &v2alpha1.PolicySimulation{}
+	err = c.client.Patch(pt).
+		Resource("policysimulations").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -85,10 +85,32 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		// Group=kyverno.io, Version=v2alpha1
 	case v2alpha1.SchemeGroupVersion.WithResource("cleanuppolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().CleanupPolicies().Informer()}, nil
+	case v2alpha1.SchemeGroupVersion.WithResource("cleanuppreviews"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().CleanupPreviews().Informer()}, nil
+	case v2alpha1.SchemeGroupVersion.WithResource("cleanupreports"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().CleanupReports().Informer()}, nil
+	case v2alpha1.SchemeGroupVersion.WithResource("clusterbaselines"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().ClusterBaselines().Informer()}, nil
 	case v2alpha1.SchemeGroupVersion.WithResource("clustercleanuppolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().ClusterCleanupPolicies().Informer()}, nil
+	case v2alpha1.SchemeGroupVersion.WithResource("clustercompliancesummaries"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().ClusterComplianceSummaries().Informer()}, nil
+	case v2alpha1.SchemeGroupVersion.WithResource("compliancescans"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().ComplianceScans().Informer()}, nil
+	case v2alpha1.SchemeGroupVersion.WithResource("exclusionpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().ExclusionPolicies().Informer()}, nil
+	case v2alpha1.SchemeGroupVersion.WithResource("policybindings"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().PolicyBindings().Informer()}, nil
 	case v2alpha1.SchemeGroupVersion.WithResource("policyexceptions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().PolicyExceptions().Informer()}, nil
+	case v2alpha1.SchemeGroupVersion.WithResource("policyprofiles"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().PolicyProfiles().Informer()}, nil
+	case v2alpha1.SchemeGroupVersion.WithResource("policysets"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().PolicySets().Informer()}, nil
+	case v2alpha1.SchemeGroupVersion.WithResource("policysimulations"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().PolicySimulations().Informer()}, nil
+	case v2alpha1.SchemeGroupVersion.WithResource("validatingpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().ValidatingPolicies().Informer()}, nil
 
 		// Group=kyverno.io, Version=v2beta1
 	case v2beta1.SchemeGroupVersion.WithResource("cleanuppolicies"):
@@ -26,10 +26,32 @@ import (
 type Interface interface {
 	// CleanupPolicies returns a CleanupPolicyInformer.
 	CleanupPolicies() CleanupPolicyInformer
+	// CleanupPreviews returns a CleanupPreviewInformer.
+	CleanupPreviews() CleanupPreviewInformer
+	// CleanupReports returns a CleanupReportInformer.
+	CleanupReports() CleanupReportInformer
+	// ClusterBaselines returns a ClusterBaselineInformer.
+	ClusterBaselines() ClusterBaselineInformer
 	// ClusterCleanupPolicies returns a ClusterCleanupPolicyInformer.
 	ClusterCleanupPolicies() ClusterCleanupPolicyInformer
+	// ClusterComplianceSummaries returns a ClusterComplianceSummaryInformer.
+	ClusterComplianceSummaries() ClusterComplianceSummaryInformer
+	// ComplianceScans returns a ComplianceScanInformer.
+	ComplianceScans() ComplianceScanInformer
+	// ExclusionPolicies returns a ExclusionPolicyInformer.
+	ExclusionPolicies() ExclusionPolicyInformer
+	// PolicyBindings returns a PolicyBindingInformer.
+	PolicyBindings() PolicyBindingInformer
 	// PolicyExceptions returns a PolicyExceptionInformer.
 	PolicyExceptions() PolicyExceptionInformer
+	// PolicyProfiles returns a PolicyProfileInformer.
+	PolicyProfiles() PolicyProfileInformer
+	// PolicySets returns a PolicySetInformer.
+	PolicySets() PolicySetInformer
+	// PolicySimulations returns a PolicySimulationInformer.
+	PolicySimulations() PolicySimulationInformer
+	// ValidatingPolicies returns a ValidatingPolicyInformer.
+	ValidatingPolicies() ValidatingPolicyInformer
 }
 
 type version struct {
@@ -48,12 +70,66 @@ func (v *version) CleanupPolicies() CleanupPolicyInformer {
 	return &cleanupPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// CleanupPreviews returns a CleanupPreviewInformer.
+func (v *version) CleanupPreviews() CleanupPreviewInformer {
+	return &cleanupPreviewInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// CleanupReports returns a CleanupReportInformer.
+func (v *version) CleanupReports() CleanupReportInformer {
+	return &cleanupReportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ClusterBaselines returns a ClusterBaselineInformer.
+func (v *version) ClusterBaselines() ClusterBaselineInformer {
+	return &clusterBaselineInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterCleanupPolicies returns a ClusterCleanupPolicyInformer.
 func (v *version) ClusterCleanupPolicies() ClusterCleanupPolicyInformer {
 	return &clusterCleanupPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ClusterComplianceSummaries returns a ClusterComplianceSummaryInformer.
+func (v *version) ClusterComplianceSummaries() ClusterComplianceSummaryInformer {
+	return &clusterComplianceSummaryInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// ComplianceScans returns a ComplianceScanInformer.
+func (v *version) ComplianceScans() ComplianceScanInformer {
+	return &complianceScanInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+func (v *version) ExclusionPolicies() ExclusionPolicyInformer {
+	return &exclusionPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// PolicyBindings returns a PolicyBindingInformer.
+func (v *version) PolicyBindings() PolicyBindingInformer {
+	return &policyBindingInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // PolicyExceptions returns a PolicyExceptionInformer.
 func (v *version) PolicyExceptions() PolicyExceptionInformer {
 	return &policyExceptionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// PolicyProfiles returns a PolicyProfileInformer.
+func (v *version) PolicyProfiles() PolicyProfileInformer {
+	return &policyProfileInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// PolicySets returns a PolicySetInformer.
+func (v *version) PolicySets() PolicySetInformer {
+	return &policySetInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// PolicySimulations returns a PolicySimulationInformer.
+func (v *version) PolicySimulations() PolicySimulationInformer {
+	return &policySimulationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// ValidatingPolicies returns a ValidatingPolicyInformer.
+func (v *version) ValidatingPolicies() ValidatingPolicyInformer {
+	return &validatingPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v2alpha1
+
+import (
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// CleanupPreviewLister helps list CleanupPreviews.
+// All objects returned here must be treated as read-only.
+type CleanupPreviewLister interface {
+	// List lists all CleanupPreviews in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v2alpha1.CleanupPreview, err error)
+	// CleanupPreviews returns an object that can list and get CleanupPreviews.
+	CleanupPreviews(namespace string) CleanupPreviewNamespaceLister
+	CleanupPreviewListerExpansion
+}
+
+// cleanupPreviewLister implements the CleanupPreviewLister interface.
+type cleanupPreviewLister struct {
+	indexer cache.Indexer
+}
+
+// NewCleanupPreviewLister returns a new CleanupPreviewLister.
+func NewCleanupPreviewLister(indexer cache.Indexer) CleanupPreviewLister {
+	return &cleanupPreviewLister{indexer: indexer}
+}
+
+// List lists all CleanupPreviews in the indexer.
+func (s *cleanupPreviewLister) List(selector labels.Selector) (ret []*v2alpha1.CleanupPreview, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v2alpha1.CleanupPreview))
+	})
+	return ret, err
+}
+
+// CleanupPreviews returns an object that can list and get CleanupPreviews.
+func (s *cleanupPreviewLister) CleanupPreviews(namespace string) CleanupPreviewNamespaceLister {
+	return cleanupPreviewNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// CleanupPreviewNamespaceLister helps list and get CleanupPreviews.
+// All objects returned here must be treated as read-only.
+type CleanupPreviewNamespaceLister interface {
+	// List lists all CleanupPreviews in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v2alpha1.CleanupPreview, err error)
+	// Get retrieves the CleanupPreview from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v2alpha1.CleanupPreview, error)
+	CleanupPreviewNamespaceListerExpansion
+}
+
+// cleanupPreviewNamespaceLister implements the CleanupPreviewNamespaceLister
+// interface.
+type cleanupPreviewNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all CleanupPreviews in the indexer for a given namespace.
+func (s cleanupPreviewNamespaceLister) List(selector labels.Selector) (ret []*v2alpha1.CleanupPreview, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v2alpha1.CleanupPreview))
+	})
+	return ret, err
+}
+
+// Get retrieves the CleanupPreview from the indexer for a given namespace and name.
+func (s cleanupPreviewNamespaceLister) Get(name string) (*v2alpha1.CleanupPreview, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v2alpha1.Resource("cleanuppreview"), name)
+	}
+	return obj.(*v2alpha1.CleanupPreview), nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v2alpha1
+
+import (
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// CleanupReportLister helps list CleanupReports.
+// All objects returned here must be treated as read-only.
+type CleanupReportLister interface {
+	// List lists all CleanupReports in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v2alpha1.CleanupReport, err error)
+	// CleanupReports returns an object that can list and get CleanupReports.
+	CleanupReports(namespace string) CleanupReportNamespaceLister
+	CleanupReportListerExpansion
+}
+
+// cleanupReportLister implements the CleanupReportLister interface.
+type cleanupReportLister struct {
+	indexer cache.Indexer
+}
+
+// NewCleanupReportLister returns a new CleanupReportLister.
+func NewCleanupReportLister(indexer cache.Indexer) CleanupReportLister {
+	return &cleanupReportLister{indexer: indexer}
+}
+
+// List lists all CleanupReports in the indexer.
+func (s *cleanupReportLister) List(selector labels.Selector) (ret []*v2alpha1.CleanupReport, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v2alpha1.CleanupReport))
+	})
+	return ret, err
+}
+
+// CleanupReports returns an object that can list and get CleanupReports.
+func (s *cleanupReportLister) CleanupReports(namespace string) CleanupReportNamespaceLister {
+	return cleanupReportNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// CleanupReportNamespaceLister helps list and get CleanupReports.
+// All objects returned here must be treated as read-only.
+type CleanupReportNamespaceLister interface {
+	// List lists all CleanupReports in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v2alpha1.CleanupReport, err error)
+	// Get retrieves the CleanupReport from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v2alpha1.CleanupReport, error)
+	CleanupReportNamespaceListerExpansion
+}
+
+// cleanupReportNamespaceLister implements the CleanupReportNamespaceLister
+// interface.
+type cleanupReportNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all CleanupReports in the indexer for a given namespace.
+func (s cleanupReportNamespaceLister) List(selector labels.Selector) (ret []*v2alpha1.CleanupReport, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v2alpha1.CleanupReport))
+	})
+	return ret, err
+}
+
+// Get retrieves the CleanupReport from the indexer for a given namespace and name.
+func (s cleanupReportNamespaceLister) Get(name string) (*v2alpha1.CleanupReport, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v2alpha1.Resource("cleanupreport"), name)
+	}
+	return obj.(*v2alpha1.CleanupReport), nil
+}
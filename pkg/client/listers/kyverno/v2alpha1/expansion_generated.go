@@ -26,10 +26,46 @@ type CleanupPolicyListerExpansion interface{}
 // CleanupPolicyNamespaceLister.
 type CleanupPolicyNamespaceListerExpansion interface{}
 
+// CleanupPreviewListerExpansion allows custom methods to be added to
+// CleanupPreviewLister.
+type CleanupPreviewListerExpansion interface{}
+
+// CleanupPreviewNamespaceListerExpansion allows custom methods to be added to
+// CleanupPreviewNamespaceLister.
+type CleanupPreviewNamespaceListerExpansion interface{}
+
+// CleanupReportListerExpansion allows custom methods to be added to
+// CleanupReportLister.
+type CleanupReportListerExpansion interface{}
+
+// CleanupReportNamespaceListerExpansion allows custom methods to be added to
+// CleanupReportNamespaceLister.
+type CleanupReportNamespaceListerExpansion interface{}
+
+// ClusterBaselineListerExpansion allows custom methods to be added to
+// ClusterBaselineLister.
+type ClusterBaselineListerExpansion interface{}
+
 // ClusterCleanupPolicyListerExpansion allows custom methods to be added to
 // ClusterCleanupPolicyLister.
 type ClusterCleanupPolicyListerExpansion interface{}
 
+// ClusterComplianceSummaryListerExpansion allows custom methods to be added to
+// ClusterComplianceSummaryLister.
+type ClusterComplianceSummaryListerExpansion interface{}
+
+// ComplianceScanListerExpansion allows custom methods to be added to
+// ComplianceScanLister.
+type ComplianceScanListerExpansion interface{}
+
+// ExclusionPolicyListerExpansion allows custom methods to be added to
+// ExclusionPolicyLister.
+type ExclusionPolicyListerExpansion interface{}
+
+// PolicyBindingListerExpansion allows custom methods to be added to
+// PolicyBindingLister.
+type PolicyBindingListerExpansion interface{}
+
 // PolicyExceptionListerExpansion allows custom methods to be added to
 // PolicyExceptionLister.
 type PolicyExceptionListerExpansion interface{}
@@ -37,3 +73,19 @@ type PolicyExceptionListerExpansion interface{}
 // PolicyExceptionNamespaceListerExpansion allows custom methods to be added to
 // PolicyExceptionNamespaceLister.
 type PolicyExceptionNamespaceListerExpansion interface{}
+
+// PolicyProfileListerExpansion allows custom methods to be added to
+// PolicyProfileLister.
+type PolicyProfileListerExpansion interface{}
+
+// PolicySetListerExpansion allows custom methods to be added to
+// PolicySetLister.
+type PolicySetListerExpansion interface{}
+
+// PolicySimulationListerExpansion allows custom methods to be added to
+// PolicySimulationLister.
+type PolicySimulationListerExpansion interface{}
+
+// ValidatingPolicyListerExpansion allows custom methods to be added to
+// ValidatingPolicyLister.
+type ValidatingPolicyListerExpansion interface{}
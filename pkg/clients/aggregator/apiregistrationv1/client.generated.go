@@ -3,6 +3,7 @@ package client
 import (
 	"github.com/go-logr/logr"
 	apiservices "github.com/kyverno/kyverno/pkg/clients/aggregator/apiregistrationv1/apiservices"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"k8s.io/client-go/rest"
 	k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset_typed_apiregistration_v1 "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset/typed/apiregistration/v1"
@@ -20,6 +21,22 @@ func WithLogging(inner k8s_io_kube_aggregator_pkg_client_clientset_generated_cli
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset_typed_apiregistration_v1.ApiregistrationV1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset_typed_apiregistration_v1.ApiregistrationV1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset_typed_apiregistration_v1.ApiregistrationV1Interface, limits *middleware.RateLimits) k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset_typed_apiregistration_v1.ApiregistrationV1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset_typed_apiregistration_v1.ApiregistrationV1Interface, retries *middleware.Retries) k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset_typed_apiregistration_v1.ApiregistrationV1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset_typed_apiregistration_v1.ApiregistrationV1Interface, breakers *middleware.CircuitBreakers) k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset_typed_apiregistration_v1.ApiregistrationV1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset_typed_apiregistration_v1.ApiregistrationV1Interface
 	metrics    metrics.MetricsConfigManager
@@ -57,3 +74,57 @@ func (c *withLogging) RESTClient() rest.Interface {
 func (c *withLogging) APIServices() k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset_typed_apiregistration_v1.APIServiceInterface {
 	return apiservices.WithLogging(c.inner.APIServices(), c.logger.WithValues("resource", "APIServices"))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset_typed_apiregistration_v1.ApiregistrationV1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) APIServices() k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset_typed_apiregistration_v1.APIServiceInterface {
+	inner := c.inner.APIServices()
+	level, ok := c.audit.For("APIService")
+	if !ok {
+		return inner
+	}
+	return apiservices.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "APIServices"))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset_typed_apiregistration_v1.ApiregistrationV1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) APIServices() k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset_typed_apiregistration_v1.APIServiceInterface {
+	return apiservices.WithRateLimiting(c.inner.APIServices(), c.limits.For("APIService"))
+}
+
+type withRetry struct {
+	inner   k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset_typed_apiregistration_v1.ApiregistrationV1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) APIServices() k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset_typed_apiregistration_v1.APIServiceInterface {
+	return apiservices.WithRetry(c.inner.APIServices(), c.retries.For("APIService"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset_typed_apiregistration_v1.ApiregistrationV1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) APIServices() k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset_typed_apiregistration_v1.APIServiceInterface {
+	return apiservices.WithCircuitBreaker(c.inner.APIServices(), c.breakers.For("APIService"))
+}
@@ -5,6 +5,7 @@ import (
 	apiregistrationv1 "github.com/kyverno/kyverno/pkg/clients/aggregator/apiregistrationv1"
 	apiregistrationv1beta1 "github.com/kyverno/kyverno/pkg/clients/aggregator/apiregistrationv1beta1"
 	discovery "github.com/kyverno/kyverno/pkg/clients/aggregator/discovery"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_discovery "k8s.io/client-go/discovery"
 	k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset"
@@ -51,3 +52,42 @@ func WrapWithLogging(inner k8s_io_kube_aggregator_pkg_client_clientset_generated
 		apiregistrationv1beta1: apiregistrationv1beta1.WithLogging(inner.ApiregistrationV1beta1(), logger.WithValues("group", "ApiregistrationV1beta1")),
 	}
 }
+func WrapWithAuditLogging(inner k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset.Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset.Interface {
+	return &clientset{
+		discovery:              auditLoggingDiscovery(inner.Discovery(), logger.WithValues("group", "Discovery"), audit),
+		apiregistrationv1:      apiregistrationv1.WithAuditLogging(inner.ApiregistrationV1(), logger.WithValues("group", "ApiregistrationV1"), audit),
+		apiregistrationv1beta1: apiregistrationv1beta1.WithAuditLogging(inner.ApiregistrationV1beta1(), logger.WithValues("group", "ApiregistrationV1beta1"), audit),
+	}
+}
+
+func auditLoggingDiscovery(inner k8s_io_client_go_discovery.DiscoveryInterface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_discovery.DiscoveryInterface {
+	level, ok := audit.For("Discovery")
+	if !ok {
+		return inner
+	}
+	return discovery.WithAuditLogging(inner, logger.V(level))
+}
+
+func WrapWithRateLimiting(inner k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset.Interface, limits *middleware.RateLimits) k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset.Interface {
+	return &clientset{
+		discovery:              discovery.WithRateLimiting(inner.Discovery(), limits.For("Discovery")),
+		apiregistrationv1:      apiregistrationv1.WithRateLimiting(inner.ApiregistrationV1(), limits),
+		apiregistrationv1beta1: apiregistrationv1beta1.WithRateLimiting(inner.ApiregistrationV1beta1(), limits),
+	}
+}
+
+func WrapWithRetry(inner k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset.Interface, retries *middleware.Retries) k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset.Interface {
+	return &clientset{
+		discovery:              discovery.WithRetry(inner.Discovery(), retries.For("Discovery")),
+		apiregistrationv1:      apiregistrationv1.WithRetry(inner.ApiregistrationV1(), retries),
+		apiregistrationv1beta1: apiregistrationv1beta1.WithRetry(inner.ApiregistrationV1beta1(), retries),
+	}
+}
+
+func WrapWithCircuitBreaker(inner k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset.Interface, breakers *middleware.CircuitBreakers) k8s_io_kube_aggregator_pkg_client_clientset_generated_clientset.Interface {
+	return &clientset{
+		discovery:              discovery.WithCircuitBreaker(inner.Discovery(), breakers.For("Discovery")),
+		apiregistrationv1:      apiregistrationv1.WithCircuitBreaker(inner.ApiregistrationV1(), breakers),
+		apiregistrationv1beta1: apiregistrationv1beta1.WithCircuitBreaker(inner.ApiregistrationV1beta1(), breakers),
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-logr/logr"
 	github_com_google_gnostic_models_openapiv2 "github.com/google/gnostic-models/openapiv2"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"go.uber.org/multierr"
 	k8s_io_apimachinery_pkg_apis_meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -12,10 +13,15 @@ import (
 	k8s_io_client_go_discovery "k8s.io/client-go/discovery"
 	k8s_io_client_go_openapi "k8s.io/client-go/openapi"
 	k8s_io_client_go_rest "k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
 )
 
 func WithLogging(inner k8s_io_client_go_discovery.DiscoveryInterface, logger logr.Logger) k8s_io_client_go_discovery.DiscoveryInterface {
-	return &withLogging{inner, logger}
+	return &withLogging{inner, logger, false}
+}
+
+func WithAuditLogging(inner k8s_io_client_go_discovery.DiscoveryInterface, logger logr.Logger) k8s_io_client_go_discovery.DiscoveryInterface {
+	return &withLogging{inner, logger, true}
 }
 
 func WithMetrics(inner k8s_io_client_go_discovery.DiscoveryInterface, recorder metrics.Recorder) k8s_io_client_go_discovery.DiscoveryInterface {
@@ -26,15 +32,43 @@ func WithTracing(inner k8s_io_client_go_discovery.DiscoveryInterface, client, ki
 	return &withTracing{inner, client, kind}
 }
 
+func WithRateLimiting(inner k8s_io_client_go_discovery.DiscoveryInterface, limiter flowcontrol.RateLimiter) k8s_io_client_go_discovery.DiscoveryInterface {
+	if limiter == nil {
+		return inner
+	}
+	return &withRateLimiting{inner, limiter}
+}
+
+func WithRetry(inner k8s_io_client_go_discovery.DiscoveryInterface, retrier *middleware.Retrier) k8s_io_client_go_discovery.DiscoveryInterface {
+	if retrier == nil {
+		return inner
+	}
+	return &withRetry{inner, retrier}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_discovery.DiscoveryInterface, breaker *middleware.CircuitBreaker) k8s_io_client_go_discovery.DiscoveryInterface {
+	if breaker == nil {
+		return inner
+	}
+	return &withCircuitBreaker{inner, breaker}
+}
+
 type withLogging struct {
 	inner  k8s_io_client_go_discovery.DiscoveryInterface
 	logger logr.Logger
+	sizes  bool
 }
 
 func (c *withLogging) OpenAPISchema() (*github_com_google_gnostic_models_openapiv2.Document, error) {
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "OpenAPISchema")
 	ret0, ret1 := c.inner.OpenAPISchema()
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(),
+			"responseSize", middleware.Size(ret0),
+		)
+	}
 	if err := multierr.Combine(ret1); err != nil {
 		logger.Error(err, "OpenAPISchema failed", "duration", time.Since(start))
 	} else {
@@ -46,6 +80,12 @@ func (c *withLogging) OpenAPIV3() k8s_io_client_go_openapi.Client {
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "OpenAPIV3")
 	ret0 := c.inner.OpenAPIV3()
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(),
+			"responseSize", middleware.Size(ret0),
+		)
+	}
 	logger.Info("OpenAPIV3 done", "duration", time.Since(start))
 	return ret0
 }
@@ -53,6 +93,12 @@ func (c *withLogging) RESTClient() k8s_io_client_go_rest.Interface {
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "RESTClient")
 	ret0 := c.inner.RESTClient()
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(),
+			"responseSize", middleware.Size(ret0),
+		)
+	}
 	logger.Info("RESTClient done", "duration", time.Since(start))
 	return ret0
 }
@@ -60,6 +106,12 @@ func (c *withLogging) ServerGroups() (*k8s_io_apimachinery_pkg_apis_meta_v1.APIG
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "ServerGroups")
 	ret0, ret1 := c.inner.ServerGroups()
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(),
+			"responseSize", middleware.Size(ret0),
+		)
+	}
 	if err := multierr.Combine(ret1); err != nil {
 		logger.Error(err, "ServerGroups failed", "duration", time.Since(start))
 	} else {
@@ -71,6 +123,12 @@ func (c *withLogging) ServerGroupsAndResources() ([]*k8s_io_apimachinery_pkg_api
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "ServerGroupsAndResources")
 	ret0, ret1, ret2 := c.inner.ServerGroupsAndResources()
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(),
+			"responseSize", middleware.Size(ret0, ret1),
+		)
+	}
 	if err := multierr.Combine(ret2); err != nil {
 		logger.Error(err, "ServerGroupsAndResources failed", "duration", time.Since(start))
 	} else {
@@ -82,6 +140,12 @@ func (c *withLogging) ServerPreferredNamespacedResources() ([]*k8s_io_apimachine
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "ServerPreferredNamespacedResources")
 	ret0, ret1 := c.inner.ServerPreferredNamespacedResources()
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(),
+			"responseSize", middleware.Size(ret0),
+		)
+	}
 	if err := multierr.Combine(ret1); err != nil {
 		logger.Error(err, "ServerPreferredNamespacedResources failed", "duration", time.Since(start))
 	} else {
@@ -93,6 +157,12 @@ func (c *withLogging) ServerPreferredResources() ([]*k8s_io_apimachinery_pkg_api
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "ServerPreferredResources")
 	ret0, ret1 := c.inner.ServerPreferredResources()
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(),
+			"responseSize", middleware.Size(ret0),
+		)
+	}
 	if err := multierr.Combine(ret1); err != nil {
 		logger.Error(err, "ServerPreferredResources failed", "duration", time.Since(start))
 	} else {
@@ -104,6 +174,12 @@ func (c *withLogging) ServerResourcesForGroupVersion(arg0 string) (*k8s_io_apima
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "ServerResourcesForGroupVersion")
 	ret0, ret1 := c.inner.ServerResourcesForGroupVersion(arg0)
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(arg0),
+			"responseSize", middleware.Size(ret0),
+		)
+	}
 	if err := multierr.Combine(ret1); err != nil {
 		logger.Error(err, "ServerResourcesForGroupVersion failed", "duration", time.Since(start))
 	} else {
@@ -115,6 +191,12 @@ func (c *withLogging) ServerVersion() (*k8s_io_apimachinery_pkg_version.Info, er
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "ServerVersion")
 	ret0, ret1 := c.inner.ServerVersion()
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(),
+			"responseSize", middleware.Size(ret0),
+		)
+	}
 	if err := multierr.Combine(ret1); err != nil {
 		logger.Error(err, "ServerVersion failed", "duration", time.Since(start))
 	} else {
@@ -126,6 +208,12 @@ func (c *withLogging) WithLegacy() k8s_io_client_go_discovery.DiscoveryInterface
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "WithLegacy")
 	ret0 := c.inner.WithLegacy()
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(),
+			"responseSize", middleware.Size(ret0),
+		)
+	}
 	logger.Info("WithLegacy done", "duration", time.Since(start))
 	return ret0
 }
@@ -212,3 +300,111 @@ func (c *withTracing) ServerVersion() (*k8s_io_apimachinery_pkg_version.Info, er
 func (c *withTracing) WithLegacy() k8s_io_client_go_discovery.DiscoveryInterface {
 	return c.inner.WithLegacy()
 }
+
+type withRateLimiting struct {
+	inner   k8s_io_client_go_discovery.DiscoveryInterface
+	limiter flowcontrol.RateLimiter
+}
+
+func (c *withRateLimiting) OpenAPISchema() (*github_com_google_gnostic_models_openapiv2.Document, error) {
+	return c.inner.OpenAPISchema()
+}
+func (c *withRateLimiting) OpenAPIV3() k8s_io_client_go_openapi.Client {
+	return c.inner.OpenAPIV3()
+}
+func (c *withRateLimiting) RESTClient() k8s_io_client_go_rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) ServerGroups() (*k8s_io_apimachinery_pkg_apis_meta_v1.APIGroupList, error) {
+	return c.inner.ServerGroups()
+}
+func (c *withRateLimiting) ServerGroupsAndResources() ([]*k8s_io_apimachinery_pkg_apis_meta_v1.APIGroup, []*k8s_io_apimachinery_pkg_apis_meta_v1.APIResourceList, error) {
+	return c.inner.ServerGroupsAndResources()
+}
+func (c *withRateLimiting) ServerPreferredNamespacedResources() ([]*k8s_io_apimachinery_pkg_apis_meta_v1.APIResourceList, error) {
+	return c.inner.ServerPreferredNamespacedResources()
+}
+func (c *withRateLimiting) ServerPreferredResources() ([]*k8s_io_apimachinery_pkg_apis_meta_v1.APIResourceList, error) {
+	return c.inner.ServerPreferredResources()
+}
+func (c *withRateLimiting) ServerResourcesForGroupVersion(arg0 string) (*k8s_io_apimachinery_pkg_apis_meta_v1.APIResourceList, error) {
+	return c.inner.ServerResourcesForGroupVersion(arg0)
+}
+func (c *withRateLimiting) ServerVersion() (*k8s_io_apimachinery_pkg_version.Info, error) {
+	return c.inner.ServerVersion()
+}
+func (c *withRateLimiting) WithLegacy() k8s_io_client_go_discovery.DiscoveryInterface {
+	return c.inner.WithLegacy()
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_discovery.DiscoveryInterface
+	retrier *middleware.Retrier
+}
+
+func (c *withRetry) OpenAPISchema() (*github_com_google_gnostic_models_openapiv2.Document, error) {
+	return c.inner.OpenAPISchema()
+}
+func (c *withRetry) OpenAPIV3() k8s_io_client_go_openapi.Client {
+	return c.inner.OpenAPIV3()
+}
+func (c *withRetry) RESTClient() k8s_io_client_go_rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) ServerGroups() (*k8s_io_apimachinery_pkg_apis_meta_v1.APIGroupList, error) {
+	return c.inner.ServerGroups()
+}
+func (c *withRetry) ServerGroupsAndResources() ([]*k8s_io_apimachinery_pkg_apis_meta_v1.APIGroup, []*k8s_io_apimachinery_pkg_apis_meta_v1.APIResourceList, error) {
+	return c.inner.ServerGroupsAndResources()
+}
+func (c *withRetry) ServerPreferredNamespacedResources() ([]*k8s_io_apimachinery_pkg_apis_meta_v1.APIResourceList, error) {
+	return c.inner.ServerPreferredNamespacedResources()
+}
+func (c *withRetry) ServerPreferredResources() ([]*k8s_io_apimachinery_pkg_apis_meta_v1.APIResourceList, error) {
+	return c.inner.ServerPreferredResources()
+}
+func (c *withRetry) ServerResourcesForGroupVersion(arg0 string) (*k8s_io_apimachinery_pkg_apis_meta_v1.APIResourceList, error) {
+	return c.inner.ServerResourcesForGroupVersion(arg0)
+}
+func (c *withRetry) ServerVersion() (*k8s_io_apimachinery_pkg_version.Info, error) {
+	return c.inner.ServerVersion()
+}
+func (c *withRetry) WithLegacy() k8s_io_client_go_discovery.DiscoveryInterface {
+	return c.inner.WithLegacy()
+}
+
+type withCircuitBreaker struct {
+	inner   k8s_io_client_go_discovery.DiscoveryInterface
+	breaker *middleware.CircuitBreaker
+}
+
+func (c *withCircuitBreaker) OpenAPISchema() (*github_com_google_gnostic_models_openapiv2.Document, error) {
+	return c.inner.OpenAPISchema()
+}
+func (c *withCircuitBreaker) OpenAPIV3() k8s_io_client_go_openapi.Client {
+	return c.inner.OpenAPIV3()
+}
+func (c *withCircuitBreaker) RESTClient() k8s_io_client_go_rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) ServerGroups() (*k8s_io_apimachinery_pkg_apis_meta_v1.APIGroupList, error) {
+	return c.inner.ServerGroups()
+}
+func (c *withCircuitBreaker) ServerGroupsAndResources() ([]*k8s_io_apimachinery_pkg_apis_meta_v1.APIGroup, []*k8s_io_apimachinery_pkg_apis_meta_v1.APIResourceList, error) {
+	return c.inner.ServerGroupsAndResources()
+}
+func (c *withCircuitBreaker) ServerPreferredNamespacedResources() ([]*k8s_io_apimachinery_pkg_apis_meta_v1.APIResourceList, error) {
+	return c.inner.ServerPreferredNamespacedResources()
+}
+func (c *withCircuitBreaker) ServerPreferredResources() ([]*k8s_io_apimachinery_pkg_apis_meta_v1.APIResourceList, error) {
+	return c.inner.ServerPreferredResources()
+}
+func (c *withCircuitBreaker) ServerResourcesForGroupVersion(arg0 string) (*k8s_io_apimachinery_pkg_apis_meta_v1.APIResourceList, error) {
+	return c.inner.ServerResourcesForGroupVersion(arg0)
+}
+func (c *withCircuitBreaker) ServerVersion() (*k8s_io_apimachinery_pkg_version.Info, error) {
+	return c.inner.ServerVersion()
+}
+func (c *withCircuitBreaker) WithLegacy() k8s_io_client_go_discovery.DiscoveryInterface {
+	return c.inner.WithLegacy()
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"github.com/go-logr/logr"
 	customresourcedefinitions "github.com/kyverno/kyverno/pkg/clients/apiserver/apiextensionsv1/customresourcedefinitions"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1 "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1"
 	"k8s.io/client-go/rest"
@@ -20,6 +21,22 @@ func WithLogging(inner k8s_io_apiextensions_apiserver_pkg_client_clientset_clien
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.ApiextensionsV1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.ApiextensionsV1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.ApiextensionsV1Interface, limits *middleware.RateLimits) k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.ApiextensionsV1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.ApiextensionsV1Interface, retries *middleware.Retries) k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.ApiextensionsV1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.ApiextensionsV1Interface, breakers *middleware.CircuitBreakers) k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.ApiextensionsV1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.ApiextensionsV1Interface
 	metrics    metrics.MetricsConfigManager
@@ -57,3 +74,57 @@ func (c *withLogging) RESTClient() rest.Interface {
 func (c *withLogging) CustomResourceDefinitions() k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.CustomResourceDefinitionInterface {
 	return customresourcedefinitions.WithLogging(c.inner.CustomResourceDefinitions(), c.logger.WithValues("resource", "CustomResourceDefinitions"))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.ApiextensionsV1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) CustomResourceDefinitions() k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.CustomResourceDefinitionInterface {
+	inner := c.inner.CustomResourceDefinitions()
+	level, ok := c.audit.For("CustomResourceDefinition")
+	if !ok {
+		return inner
+	}
+	return customresourcedefinitions.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "CustomResourceDefinitions"))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.ApiextensionsV1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) CustomResourceDefinitions() k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.CustomResourceDefinitionInterface {
+	return customresourcedefinitions.WithRateLimiting(c.inner.CustomResourceDefinitions(), c.limits.For("CustomResourceDefinition"))
+}
+
+type withRetry struct {
+	inner   k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.ApiextensionsV1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) CustomResourceDefinitions() k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.CustomResourceDefinitionInterface {
+	return customresourcedefinitions.WithRetry(c.inner.CustomResourceDefinitions(), c.retries.For("CustomResourceDefinition"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.ApiextensionsV1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) CustomResourceDefinitions() k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.CustomResourceDefinitionInterface {
+	return customresourcedefinitions.WithCircuitBreaker(c.inner.CustomResourceDefinitions(), c.breakers.For("CustomResourceDefinition"))
+}
@@ -2,10 +2,12 @@ package resource
 
 import (
 	context "context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	"go.opentelemetry.io/otel/trace"
@@ -16,10 +18,15 @@ import (
 	k8s_io_apimachinery_pkg_apis_meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8s_io_apimachinery_pkg_types "k8s.io/apimachinery/pkg/types"
 	k8s_io_apimachinery_pkg_watch "k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/util/flowcontrol"
 )
 
 func WithLogging(inner k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.CustomResourceDefinitionInterface, logger logr.Logger) k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.CustomResourceDefinitionInterface {
-	return &withLogging{inner, logger}
+	return &withLogging{inner, logger, false}
+}
+
+func WithAuditLogging(inner k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.CustomResourceDefinitionInterface, logger logr.Logger) k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.CustomResourceDefinitionInterface {
+	return &withLogging{inner, logger, true}
 }
 
 func WithMetrics(inner k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.CustomResourceDefinitionInterface, recorder metrics.Recorder) k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.CustomResourceDefinitionInterface {
@@ -30,15 +37,43 @@ func WithTracing(inner k8s_io_apiextensions_apiserver_pkg_client_clientset_clien
 	return &withTracing{inner, client, kind}
 }
 
+func WithRateLimiting(inner k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.CustomResourceDefinitionInterface, limiter flowcontrol.RateLimiter) k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.CustomResourceDefinitionInterface {
+	if limiter == nil {
+		return inner
+	}
+	return &withRateLimiting{inner, limiter}
+}
+
+func WithRetry(inner k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.CustomResourceDefinitionInterface, retrier *middleware.Retrier) k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.CustomResourceDefinitionInterface {
+	if retrier == nil {
+		return inner
+	}
+	return &withRetry{inner, retrier}
+}
+
+func WithCircuitBreaker(inner k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.CustomResourceDefinitionInterface, breaker *middleware.CircuitBreaker) k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.CustomResourceDefinitionInterface {
+	if breaker == nil {
+		return inner
+	}
+	return &withCircuitBreaker{inner, breaker}
+}
+
 type withLogging struct {
 	inner  k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.CustomResourceDefinitionInterface
 	logger logr.Logger
+	sizes  bool
 }
 
 func (c *withLogging) Apply(arg0 context.Context, arg1 *k8s_io_apiextensions_apiserver_pkg_client_applyconfiguration_apiextensions_v1.CustomResourceDefinitionApplyConfiguration, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.ApplyOptions) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, error) {
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "Apply")
 	ret0, ret1 := c.inner.Apply(arg0, arg1, arg2)
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(arg1, arg2),
+			"responseSize", middleware.Size(ret0),
+		)
+	}
 	if err := multierr.Combine(ret1); err != nil {
 		logger.Error(err, "Apply failed", "duration", time.Since(start))
 	} else {
@@ -50,6 +85,12 @@ func (c *withLogging) ApplyStatus(arg0 context.Context, arg1 *k8s_io_apiextensio
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "ApplyStatus")
 	ret0, ret1 := c.inner.ApplyStatus(arg0, arg1, arg2)
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(arg1, arg2),
+			"responseSize", middleware.Size(ret0),
+		)
+	}
 	if err := multierr.Combine(ret1); err != nil {
 		logger.Error(err, "ApplyStatus failed", "duration", time.Since(start))
 	} else {
@@ -61,6 +102,12 @@ func (c *withLogging) Create(arg0 context.Context, arg1 *k8s_io_apiextensions_ap
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "Create")
 	ret0, ret1 := c.inner.Create(arg0, arg1, arg2)
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(arg1, arg2),
+			"responseSize", middleware.Size(ret0),
+		)
+	}
 	if err := multierr.Combine(ret1); err != nil {
 		logger.Error(err, "Create failed", "duration", time.Since(start))
 	} else {
@@ -72,6 +119,12 @@ func (c *withLogging) Delete(arg0 context.Context, arg1 string, arg2 k8s_io_apim
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "Delete")
 	ret0 := c.inner.Delete(arg0, arg1, arg2)
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(arg1, arg2),
+			"responseSize", middleware.Size(),
+		)
+	}
 	if err := multierr.Combine(ret0); err != nil {
 		logger.Error(err, "Delete failed", "duration", time.Since(start))
 	} else {
@@ -83,6 +136,12 @@ func (c *withLogging) DeleteCollection(arg0 context.Context, arg1 k8s_io_apimach
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "DeleteCollection")
 	ret0 := c.inner.DeleteCollection(arg0, arg1, arg2)
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(arg1, arg2),
+			"responseSize", middleware.Size(),
+		)
+	}
 	if err := multierr.Combine(ret0); err != nil {
 		logger.Error(err, "DeleteCollection failed", "duration", time.Since(start))
 	} else {
@@ -94,6 +153,12 @@ func (c *withLogging) Get(arg0 context.Context, arg1 string, arg2 k8s_io_apimach
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "Get")
 	ret0, ret1 := c.inner.Get(arg0, arg1, arg2)
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(arg1, arg2),
+			"responseSize", middleware.Size(ret0),
+		)
+	}
 	if err := multierr.Combine(ret1); err != nil {
 		logger.Error(err, "Get failed", "duration", time.Since(start))
 	} else {
@@ -105,6 +170,12 @@ func (c *withLogging) List(arg0 context.Context, arg1 k8s_io_apimachinery_pkg_ap
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "List")
 	ret0, ret1 := c.inner.List(arg0, arg1)
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(arg1),
+			"responseSize", middleware.Size(ret0),
+		)
+	}
 	if err := multierr.Combine(ret1); err != nil {
 		logger.Error(err, "List failed", "duration", time.Since(start))
 	} else {
@@ -116,6 +187,12 @@ func (c *withLogging) Patch(arg0 context.Context, arg1 string, arg2 k8s_io_apima
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "Patch")
 	ret0, ret1 := c.inner.Patch(arg0, arg1, arg2, arg3, arg4, arg5...)
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(arg1, arg2, arg3, arg4, arg5),
+			"responseSize", middleware.Size(ret0),
+		)
+	}
 	if err := multierr.Combine(ret1); err != nil {
 		logger.Error(err, "Patch failed", "duration", time.Since(start))
 	} else {
@@ -127,6 +204,12 @@ func (c *withLogging) Update(arg0 context.Context, arg1 *k8s_io_apiextensions_ap
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "Update")
 	ret0, ret1 := c.inner.Update(arg0, arg1, arg2)
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(arg1, arg2),
+			"responseSize", middleware.Size(ret0),
+		)
+	}
 	if err := multierr.Combine(ret1); err != nil {
 		logger.Error(err, "Update failed", "duration", time.Since(start))
 	} else {
@@ -138,6 +221,12 @@ func (c *withLogging) UpdateStatus(arg0 context.Context, arg1 *k8s_io_apiextensi
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "UpdateStatus")
 	ret0, ret1 := c.inner.UpdateStatus(arg0, arg1, arg2)
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(arg1, arg2),
+			"responseSize", middleware.Size(ret0),
+		)
+	}
 	if err := multierr.Combine(ret1); err != nil {
 		logger.Error(err, "UpdateStatus failed", "duration", time.Since(start))
 	} else {
@@ -149,6 +238,12 @@ func (c *withLogging) Watch(arg0 context.Context, arg1 k8s_io_apimachinery_pkg_a
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "Watch")
 	ret0, ret1 := c.inner.Watch(arg0, arg1)
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(arg1),
+			"responseSize", middleware.Size(ret0),
+		)
+	}
 	if err := multierr.Combine(ret1); err != nil {
 		logger.Error(err, "Watch failed", "duration", time.Since(start))
 	} else {
@@ -444,3 +539,334 @@ func (c *withTracing) Watch(arg0 context.Context, arg1 k8s_io_apimachinery_pkg_a
 	}
 	return ret0, ret1
 }
+
+type withRateLimiting struct {
+	inner   k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.CustomResourceDefinitionInterface
+	limiter flowcontrol.RateLimiter
+}
+
+func (c *withRateLimiting) Apply(arg0 context.Context, arg1 *k8s_io_apiextensions_apiserver_pkg_client_applyconfiguration_apiextensions_v1.CustomResourceDefinitionApplyConfiguration, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.ApplyOptions) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, error) {
+	if err := c.limiter.Wait(arg0); err != nil {
+		var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition
+		var ret1 error
+		ret1 = err
+		return ret0, ret1
+	}
+	return c.inner.Apply(arg0, arg1, arg2)
+}
+func (c *withRateLimiting) ApplyStatus(arg0 context.Context, arg1 *k8s_io_apiextensions_apiserver_pkg_client_applyconfiguration_apiextensions_v1.CustomResourceDefinitionApplyConfiguration, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.ApplyOptions) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, error) {
+	if err := c.limiter.Wait(arg0); err != nil {
+		var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition
+		var ret1 error
+		ret1 = err
+		return ret0, ret1
+	}
+	return c.inner.ApplyStatus(arg0, arg1, arg2)
+}
+func (c *withRateLimiting) Create(arg0 context.Context, arg1 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.CreateOptions) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, error) {
+	if err := c.limiter.Wait(arg0); err != nil {
+		var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition
+		var ret1 error
+		ret1 = err
+		return ret0, ret1
+	}
+	return c.inner.Create(arg0, arg1, arg2)
+}
+func (c *withRateLimiting) Delete(arg0 context.Context, arg1 string, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.DeleteOptions) error {
+	if err := c.limiter.Wait(arg0); err != nil {
+		var ret0 error
+		ret0 = err
+		return ret0
+	}
+	return c.inner.Delete(arg0, arg1, arg2)
+}
+func (c *withRateLimiting) DeleteCollection(arg0 context.Context, arg1 k8s_io_apimachinery_pkg_apis_meta_v1.DeleteOptions, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.ListOptions) error {
+	if err := c.limiter.Wait(arg0); err != nil {
+		var ret0 error
+		ret0 = err
+		return ret0
+	}
+	return c.inner.DeleteCollection(arg0, arg1, arg2)
+}
+func (c *withRateLimiting) Get(arg0 context.Context, arg1 string, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.GetOptions) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, error) {
+	if err := c.limiter.Wait(arg0); err != nil {
+		var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition
+		var ret1 error
+		ret1 = err
+		return ret0, ret1
+	}
+	return c.inner.Get(arg0, arg1, arg2)
+}
+func (c *withRateLimiting) List(arg0 context.Context, arg1 k8s_io_apimachinery_pkg_apis_meta_v1.ListOptions) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinitionList, error) {
+	if err := c.limiter.Wait(arg0); err != nil {
+		var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinitionList
+		var ret1 error
+		ret1 = err
+		return ret0, ret1
+	}
+	return c.inner.List(arg0, arg1)
+}
+func (c *withRateLimiting) Patch(arg0 context.Context, arg1 string, arg2 k8s_io_apimachinery_pkg_types.PatchType, arg3 []uint8, arg4 k8s_io_apimachinery_pkg_apis_meta_v1.PatchOptions, arg5 ...string) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, error) {
+	if err := c.limiter.Wait(arg0); err != nil {
+		var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition
+		var ret1 error
+		ret1 = err
+		return ret0, ret1
+	}
+	return c.inner.Patch(arg0, arg1, arg2, arg3, arg4, arg5...)
+}
+func (c *withRateLimiting) Update(arg0 context.Context, arg1 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.UpdateOptions) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, error) {
+	if err := c.limiter.Wait(arg0); err != nil {
+		var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition
+		var ret1 error
+		ret1 = err
+		return ret0, ret1
+	}
+	return c.inner.Update(arg0, arg1, arg2)
+}
+func (c *withRateLimiting) UpdateStatus(arg0 context.Context, arg1 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.UpdateOptions) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, error) {
+	if err := c.limiter.Wait(arg0); err != nil {
+		var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition
+		var ret1 error
+		ret1 = err
+		return ret0, ret1
+	}
+	return c.inner.UpdateStatus(arg0, arg1, arg2)
+}
+func (c *withRateLimiting) Watch(arg0 context.Context, arg1 k8s_io_apimachinery_pkg_apis_meta_v1.ListOptions) (k8s_io_apimachinery_pkg_watch.Interface, error) {
+	if err := c.limiter.Wait(arg0); err != nil {
+		var ret0 k8s_io_apimachinery_pkg_watch.Interface
+		var ret1 error
+		ret1 = err
+		return ret0, ret1
+	}
+	return c.inner.Watch(arg0, arg1)
+}
+
+type withRetry struct {
+	inner   k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.CustomResourceDefinitionInterface
+	retrier *middleware.Retrier
+}
+
+func (c *withRetry) Apply(arg0 context.Context, arg1 *k8s_io_apiextensions_apiserver_pkg_client_applyconfiguration_apiextensions_v1.CustomResourceDefinitionApplyConfiguration, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.ApplyOptions) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, error) {
+	var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition
+	var ret1 error
+	c.retrier.Retry(arg0, func() error {
+		ret0, ret1 = c.inner.Apply(arg0, arg1, arg2)
+		return multierr.Combine(ret1)
+	})
+	return ret0, ret1
+}
+func (c *withRetry) ApplyStatus(arg0 context.Context, arg1 *k8s_io_apiextensions_apiserver_pkg_client_applyconfiguration_apiextensions_v1.CustomResourceDefinitionApplyConfiguration, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.ApplyOptions) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, error) {
+	var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition
+	var ret1 error
+	c.retrier.Retry(arg0, func() error {
+		ret0, ret1 = c.inner.ApplyStatus(arg0, arg1, arg2)
+		return multierr.Combine(ret1)
+	})
+	return ret0, ret1
+}
+func (c *withRetry) Create(arg0 context.Context, arg1 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.CreateOptions) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, error) {
+	var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition
+	var ret1 error
+	c.retrier.Retry(arg0, func() error {
+		ret0, ret1 = c.inner.Create(arg0, arg1, arg2)
+		return multierr.Combine(ret1)
+	})
+	return ret0, ret1
+}
+func (c *withRetry) Delete(arg0 context.Context, arg1 string, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.DeleteOptions) error {
+	var ret0 error
+	c.retrier.Retry(arg0, func() error {
+		ret0 = c.inner.Delete(arg0, arg1, arg2)
+		return multierr.Combine(ret0)
+	})
+	return ret0
+}
+func (c *withRetry) DeleteCollection(arg0 context.Context, arg1 k8s_io_apimachinery_pkg_apis_meta_v1.DeleteOptions, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.ListOptions) error {
+	var ret0 error
+	c.retrier.Retry(arg0, func() error {
+		ret0 = c.inner.DeleteCollection(arg0, arg1, arg2)
+		return multierr.Combine(ret0)
+	})
+	return ret0
+}
+func (c *withRetry) Get(arg0 context.Context, arg1 string, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.GetOptions) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, error) {
+	var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition
+	var ret1 error
+	c.retrier.Retry(arg0, func() error {
+		ret0, ret1 = c.inner.Get(arg0, arg1, arg2)
+		return multierr.Combine(ret1)
+	})
+	return ret0, ret1
+}
+func (c *withRetry) List(arg0 context.Context, arg1 k8s_io_apimachinery_pkg_apis_meta_v1.ListOptions) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinitionList, error) {
+	var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinitionList
+	var ret1 error
+	c.retrier.Retry(arg0, func() error {
+		ret0, ret1 = c.inner.List(arg0, arg1)
+		return multierr.Combine(ret1)
+	})
+	return ret0, ret1
+}
+func (c *withRetry) Patch(arg0 context.Context, arg1 string, arg2 k8s_io_apimachinery_pkg_types.PatchType, arg3 []uint8, arg4 k8s_io_apimachinery_pkg_apis_meta_v1.PatchOptions, arg5 ...string) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, error) {
+	var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition
+	var ret1 error
+	c.retrier.Retry(arg0, func() error {
+		ret0, ret1 = c.inner.Patch(arg0, arg1, arg2, arg3, arg4, arg5...)
+		return multierr.Combine(ret1)
+	})
+	return ret0, ret1
+}
+func (c *withRetry) Update(arg0 context.Context, arg1 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.UpdateOptions) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, error) {
+	var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition
+	var ret1 error
+	c.retrier.Retry(arg0, func() error {
+		ret0, ret1 = c.inner.Update(arg0, arg1, arg2)
+		return multierr.Combine(ret1)
+	})
+	return ret0, ret1
+}
+func (c *withRetry) UpdateStatus(arg0 context.Context, arg1 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.UpdateOptions) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, error) {
+	var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition
+	var ret1 error
+	c.retrier.Retry(arg0, func() error {
+		ret0, ret1 = c.inner.UpdateStatus(arg0, arg1, arg2)
+		return multierr.Combine(ret1)
+	})
+	return ret0, ret1
+}
+func (c *withRetry) Watch(arg0 context.Context, arg1 k8s_io_apimachinery_pkg_apis_meta_v1.ListOptions) (k8s_io_apimachinery_pkg_watch.Interface, error) {
+	var ret0 k8s_io_apimachinery_pkg_watch.Interface
+	var ret1 error
+	c.retrier.Retry(arg0, func() error {
+		ret0, ret1 = c.inner.Watch(arg0, arg1)
+		return multierr.Combine(ret1)
+	})
+	return ret0, ret1
+}
+
+type withCircuitBreaker struct {
+	inner   k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1.CustomResourceDefinitionInterface
+	breaker *middleware.CircuitBreaker
+}
+
+func (c *withCircuitBreaker) Apply(arg0 context.Context, arg1 *k8s_io_apiextensions_apiserver_pkg_client_applyconfiguration_apiextensions_v1.CustomResourceDefinitionApplyConfiguration, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.ApplyOptions) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, error) {
+	var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition
+	var ret1 error
+	if err := c.breaker.Call(arg0, func() error {
+		ret0, ret1 = c.inner.Apply(arg0, arg1, arg2)
+		return multierr.Combine(ret1)
+	}); errors.Is(err, middleware.ErrCircuitOpen) {
+		ret1 = err
+	}
+	return ret0, ret1
+}
+func (c *withCircuitBreaker) ApplyStatus(arg0 context.Context, arg1 *k8s_io_apiextensions_apiserver_pkg_client_applyconfiguration_apiextensions_v1.CustomResourceDefinitionApplyConfiguration, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.ApplyOptions) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, error) {
+	var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition
+	var ret1 error
+	if err := c.breaker.Call(arg0, func() error {
+		ret0, ret1 = c.inner.ApplyStatus(arg0, arg1, arg2)
+		return multierr.Combine(ret1)
+	}); errors.Is(err, middleware.ErrCircuitOpen) {
+		ret1 = err
+	}
+	return ret0, ret1
+}
+func (c *withCircuitBreaker) Create(arg0 context.Context, arg1 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.CreateOptions) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, error) {
+	var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition
+	var ret1 error
+	if err := c.breaker.Call(arg0, func() error {
+		ret0, ret1 = c.inner.Create(arg0, arg1, arg2)
+		return multierr.Combine(ret1)
+	}); errors.Is(err, middleware.ErrCircuitOpen) {
+		ret1 = err
+	}
+	return ret0, ret1
+}
+func (c *withCircuitBreaker) Delete(arg0 context.Context, arg1 string, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.DeleteOptions) error {
+	var ret0 error
+	if err := c.breaker.Call(arg0, func() error {
+		ret0 = c.inner.Delete(arg0, arg1, arg2)
+		return multierr.Combine(ret0)
+	}); errors.Is(err, middleware.ErrCircuitOpen) {
+		ret0 = err
+	}
+	return ret0
+}
+func (c *withCircuitBreaker) DeleteCollection(arg0 context.Context, arg1 k8s_io_apimachinery_pkg_apis_meta_v1.DeleteOptions, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.ListOptions) error {
+	var ret0 error
+	if err := c.breaker.Call(arg0, func() error {
+		ret0 = c.inner.DeleteCollection(arg0, arg1, arg2)
+		return multierr.Combine(ret0)
+	}); errors.Is(err, middleware.ErrCircuitOpen) {
+		ret0 = err
+	}
+	return ret0
+}
+func (c *withCircuitBreaker) Get(arg0 context.Context, arg1 string, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.GetOptions) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, error) {
+	var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition
+	var ret1 error
+	if err := c.breaker.Call(arg0, func() error {
+		ret0, ret1 = c.inner.Get(arg0, arg1, arg2)
+		return multierr.Combine(ret1)
+	}); errors.Is(err, middleware.ErrCircuitOpen) {
+		ret1 = err
+	}
+	return ret0, ret1
+}
+func (c *withCircuitBreaker) List(arg0 context.Context, arg1 k8s_io_apimachinery_pkg_apis_meta_v1.ListOptions) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinitionList, error) {
+	var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinitionList
+	var ret1 error
+	if err := c.breaker.Call(arg0, func() error {
+		ret0, ret1 = c.inner.List(arg0, arg1)
+		return multierr.Combine(ret1)
+	}); errors.Is(err, middleware.ErrCircuitOpen) {
+		ret1 = err
+	}
+	return ret0, ret1
+}
+func (c *withCircuitBreaker) Patch(arg0 context.Context, arg1 string, arg2 k8s_io_apimachinery_pkg_types.PatchType, arg3 []uint8, arg4 k8s_io_apimachinery_pkg_apis_meta_v1.PatchOptions, arg5 ...string) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, error) {
+	var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition
+	var ret1 error
+	if err := c.breaker.Call(arg0, func() error {
+		ret0, ret1 = c.inner.Patch(arg0, arg1, arg2, arg3, arg4, arg5...)
+		return multierr.Combine(ret1)
+	}); errors.Is(err, middleware.ErrCircuitOpen) {
+		ret1 = err
+	}
+	return ret0, ret1
+}
+func (c *withCircuitBreaker) Update(arg0 context.Context, arg1 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.UpdateOptions) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, error) {
+	var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition
+	var ret1 error
+	if err := c.breaker.Call(arg0, func() error {
+		ret0, ret1 = c.inner.Update(arg0, arg1, arg2)
+		return multierr.Combine(ret1)
+	}); errors.Is(err, middleware.ErrCircuitOpen) {
+		ret1 = err
+	}
+	return ret0, ret1
+}
+func (c *withCircuitBreaker) UpdateStatus(arg0 context.Context, arg1 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.UpdateOptions) (*k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition, error) {
+	var ret0 *k8s_io_apiextensions_apiserver_pkg_apis_apiextensions_v1.CustomResourceDefinition
+	var ret1 error
+	if err := c.breaker.Call(arg0, func() error {
+		ret0, ret1 = c.inner.UpdateStatus(arg0, arg1, arg2)
+		return multierr.Combine(ret1)
+	}); errors.Is(err, middleware.ErrCircuitOpen) {
+		ret1 = err
+	}
+	return ret0, ret1
+}
+func (c *withCircuitBreaker) Watch(arg0 context.Context, arg1 k8s_io_apimachinery_pkg_apis_meta_v1.ListOptions) (k8s_io_apimachinery_pkg_watch.Interface, error) {
+	var ret0 k8s_io_apimachinery_pkg_watch.Interface
+	var ret1 error
+	if err := c.breaker.Call(arg0, func() error {
+		ret0, ret1 = c.inner.Watch(arg0, arg1)
+		return multierr.Combine(ret1)
+	}); errors.Is(err, middleware.ErrCircuitOpen) {
+		ret1 = err
+	}
+	return ret0, ret1
+}
@@ -5,6 +5,7 @@ import (
 	apiextensionsv1 "github.com/kyverno/kyverno/pkg/clients/apiserver/apiextensionsv1"
 	apiextensionsv1beta1 "github.com/kyverno/kyverno/pkg/clients/apiserver/apiextensionsv1beta1"
 	discovery "github.com/kyverno/kyverno/pkg/clients/apiserver/discovery"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset_typed_apiextensions_v1 "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1"
@@ -51,3 +52,42 @@ func WrapWithLogging(inner k8s_io_apiextensions_apiserver_pkg_client_clientset_c
 		apiextensionsv1beta1: apiextensionsv1beta1.WithLogging(inner.ApiextensionsV1beta1(), logger.WithValues("group", "ApiextensionsV1beta1")),
 	}
 }
+func WrapWithAuditLogging(inner k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset.Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset.Interface {
+	return &clientset{
+		discovery:            auditLoggingDiscovery(inner.Discovery(), logger.WithValues("group", "Discovery"), audit),
+		apiextensionsv1:      apiextensionsv1.WithAuditLogging(inner.ApiextensionsV1(), logger.WithValues("group", "ApiextensionsV1"), audit),
+		apiextensionsv1beta1: apiextensionsv1beta1.WithAuditLogging(inner.ApiextensionsV1beta1(), logger.WithValues("group", "ApiextensionsV1beta1"), audit),
+	}
+}
+
+func auditLoggingDiscovery(inner k8s_io_client_go_discovery.DiscoveryInterface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_discovery.DiscoveryInterface {
+	level, ok := audit.For("Discovery")
+	if !ok {
+		return inner
+	}
+	return discovery.WithAuditLogging(inner, logger.V(level))
+}
+
+func WrapWithRateLimiting(inner k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset.Interface, limits *middleware.RateLimits) k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset.Interface {
+	return &clientset{
+		discovery:            discovery.WithRateLimiting(inner.Discovery(), limits.For("Discovery")),
+		apiextensionsv1:      apiextensionsv1.WithRateLimiting(inner.ApiextensionsV1(), limits),
+		apiextensionsv1beta1: apiextensionsv1beta1.WithRateLimiting(inner.ApiextensionsV1beta1(), limits),
+	}
+}
+
+func WrapWithRetry(inner k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset.Interface, retries *middleware.Retries) k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset.Interface {
+	return &clientset{
+		discovery:            discovery.WithRetry(inner.Discovery(), retries.For("Discovery")),
+		apiextensionsv1:      apiextensionsv1.WithRetry(inner.ApiextensionsV1(), retries),
+		apiextensionsv1beta1: apiextensionsv1beta1.WithRetry(inner.ApiextensionsV1beta1(), retries),
+	}
+}
+
+func WrapWithCircuitBreaker(inner k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset.Interface, breakers *middleware.CircuitBreakers) k8s_io_apiextensions_apiserver_pkg_client_clientset_clientset.Interface {
+	return &clientset{
+		discovery:            discovery.WithCircuitBreaker(inner.Discovery(), breakers.For("Discovery")),
+		apiextensionsv1:      apiextensionsv1.WithCircuitBreaker(inner.ApiextensionsV1(), breakers),
+		apiextensionsv1beta1: apiextensionsv1beta1.WithCircuitBreaker(inner.ApiextensionsV1beta1(), breakers),
+	}
+}
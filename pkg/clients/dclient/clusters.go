@@ -0,0 +1,167 @@
+package dclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/informers"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// Clusters provides clients for remote clusters identified by a Secret containing their kubeconfig
+type Clusters interface {
+	// Get returns a client for the cluster configured by the kubeconfig stored at key in the given Secret
+	Get(ctx context.Context, namespace, name, key string) (Interface, error)
+	// Prune stops the clients of the clusters whose kubeconfig Secret, identified by its namespaced name,
+	// is not in the given set, and stops watching those Secrets
+	Prune(secrets sets.Set[string])
+}
+
+type remoteCluster struct {
+	resourceVersion string
+	client          Interface
+	cancel          context.CancelFunc
+}
+
+type kubeconfigSecret struct {
+	lister corev1listers.SecretNamespaceLister
+	synced cache.InformerSynced
+	cancel context.CancelFunc
+}
+
+type clusters struct {
+	ctx     context.Context
+	kube    kubernetes.Interface
+	resync  time.Duration
+	lock    sync.Mutex
+	secrets map[string]kubeconfigSecret
+	clients map[string]remoteCluster
+}
+
+// NewClusters creates a Clusters instance reading kubeconfig Secrets with the given kube client.
+// Each kubeconfig Secret is watched once referenced, clients are cached until the Secret changes
+// or is deleted and stop when ctx is done.
+func NewClusters(ctx context.Context, kube kubernetes.Interface, resync time.Duration) Clusters {
+	return &clusters{
+		ctx:     ctx,
+		kube:    kube,
+		resync:  resync,
+		secrets: map[string]kubeconfigSecret{},
+		clients: map[string]remoteCluster{},
+	}
+}
+
+func (c *clusters) Get(ctx context.Context, namespace, name, key string) (Interface, error) {
+	watched := c.watch(namespace, name)
+	if !cache.WaitForCacheSync(ctx.Done(), watched.synced) {
+		return nil, fmt.Errorf("failed to sync kubeconfig secret %s/%s", namespace, name)
+	}
+	secret, err := watched.lister.Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig secret %s/%s: %w", namespace, name, err)
+	}
+	id := namespace + "/" + name + "/" + key
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	cached, found := c.clients[id]
+	if found && cached.resourceVersion == secret.GetResourceVersion() {
+		return cached.client, nil
+	}
+	kubeconfig, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in kubeconfig secret %s/%s", key, namespace, name)
+	}
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig from secret %s/%s: %w", namespace, name, err)
+	}
+	kube, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	dyn, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	clientCtx, cancel := context.WithCancel(c.ctx)
+	client, err := NewClient(clientCtx, dyn, kube, c.resync)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if found {
+		cached.cancel()
+	}
+	c.clients[id] = remoteCluster{
+		resourceVersion: secret.GetResourceVersion(),
+		client:          client,
+		cancel:          cancel,
+	}
+	return client, nil
+}
+
+func (c *clusters) Prune(secrets sets.Set[string]) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for secret, watched := range c.secrets {
+		if !secrets.Has(secret) {
+			watched.cancel()
+			delete(c.secrets, secret)
+		}
+	}
+	for id, cached := range c.clients {
+		if !secrets.Has(id[:strings.LastIndex(id, "/")]) {
+			cached.cancel()
+			delete(c.clients, id)
+		}
+	}
+}
+
+// watch returns the watched kubeconfig Secret, starting an informer for the Secret on first use
+func (c *clusters) watch(namespace, name string) kubeconfigSecret {
+	secret := namespace + "/" + name
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if watched, ok := c.secrets[secret]; ok {
+		return watched
+	}
+	informer := informers.NewSecretInformer(c.kube, namespace, name, c.resync)
+	_, _ = informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj interface{}) {
+			if deleted, ok := kubeutils.GetObjectWithTombstone(obj).(*corev1.Secret); ok {
+				c.lock.Lock()
+				defer c.lock.Unlock()
+				c.evict(deleted.GetNamespace() + "/" + deleted.GetName())
+			}
+		},
+	})
+	ctx, cancel := context.WithCancel(c.ctx)
+	go informer.Informer().Run(ctx.Done())
+	watched := kubeconfigSecret{
+		lister: informer.Lister().Secrets(namespace),
+		synced: informer.Informer().HasSynced,
+		cancel: cancel,
+	}
+	c.secrets[secret] = watched
+	return watched
+}
+
+// evict stops the clients created from the given kubeconfig Secret, the lock must be held
+func (c *clusters) evict(secret string) {
+	for id, cached := range c.clients {
+		if strings.HasPrefix(id, secret+"/") {
+			cached.cancel()
+			delete(c.clients, id)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package dclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+var remoteKubeconfig = []byte(`
+apiVersion: v1
+kind: Config
+clusters:
+- name: remote
+  cluster:
+    server: https://remote.example.com:6443
+contexts:
+- name: remote
+  context:
+    cluster: remote
+    user: remote
+current-context: remote
+users:
+- name: remote
+  user:
+    token: token
+`)
+
+func Test_clustersGet(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "remote-kubeconfig", Namespace: "clusters", ResourceVersion: "1"},
+		Data:       map[string][]byte{"value": remoteKubeconfig},
+	}
+	kube := fake.NewSimpleClientset(secret)
+	clusters := NewClusters(ctx, kube, time.Hour)
+
+	client, err := clusters.Get(ctx, "clusters", "remote-kubeconfig", "value")
+	assert.NoError(t, err)
+	assert.NotNil(t, client)
+
+	cached, err := clusters.Get(ctx, "clusters", "remote-kubeconfig", "value")
+	assert.NoError(t, err)
+	assert.Same(t, client, cached)
+
+	secret.ResourceVersion = "2"
+	_, err = kube.CoreV1().Secrets("clusters").Update(ctx, secret, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		updated, err := clusters.Get(ctx, "clusters", "remote-kubeconfig", "value")
+		return err == nil && updated != client
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, err = clusters.Get(ctx, "clusters", "remote-kubeconfig", "kubeconfig")
+	assert.ErrorContains(t, err, "key kubeconfig not found in kubeconfig secret clusters/remote-kubeconfig")
+
+	_, err = clusters.Get(ctx, "clusters", "missing", "value")
+	assert.ErrorContains(t, err, "failed to get kubeconfig secret clusters/missing")
+}
+
+func Test_clustersEvict(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "remote-kubeconfig", Namespace: "clusters", ResourceVersion: "1"},
+		Data:       map[string][]byte{"value": remoteKubeconfig},
+	}
+	kube := fake.NewSimpleClientset(secret)
+	clusters := NewClusters(ctx, kube, time.Hour).(*clusters)
+
+	_, err := clusters.Get(ctx, "clusters", "remote-kubeconfig", "value")
+	assert.NoError(t, err)
+	clusters.Prune(sets.New("clusters/remote-kubeconfig"))
+	assert.Len(t, clusters.clients, 1)
+	clusters.Prune(sets.New[string]())
+	assert.Empty(t, clusters.clients)
+	assert.Empty(t, clusters.secrets)
+
+	_, err = clusters.Get(ctx, "clusters", "remote-kubeconfig", "value")
+	assert.NoError(t, err)
+	err = kube.CoreV1().Secrets("clusters").Delete(ctx, "remote-kubeconfig", metav1.DeleteOptions{})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		clusters.lock.Lock()
+		defer clusters.lock.Unlock()
+		return len(clusters.clients) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	_, err = clusters.Get(ctx, "clusters", "remote-kubeconfig", "value")
+	assert.ErrorContains(t, err, "failed to get kubeconfig secret clusters/remote-kubeconfig")
+}
+
+func Test_impersonatorImpersonate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	impersonator := NewImpersonator(ctx, &rest.Config{Host: "https://kubernetes.default.svc"}, time.Hour)
+
+	client, err := impersonator.Impersonate("system:serviceaccount:tenants:generator")
+	assert.NoError(t, err)
+	cached, err := impersonator.Impersonate("system:serviceaccount:tenants:generator")
+	assert.NoError(t, err)
+	assert.Same(t, client, cached)
+	other, err := impersonator.Impersonate("system:serviceaccount:tenants:mutator")
+	assert.NoError(t, err)
+	assert.NotSame(t, client, other)
+}
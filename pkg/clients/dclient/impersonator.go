@@ -0,0 +1,60 @@
+package dclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// Impersonator provides clients impersonating users
+type Impersonator interface {
+	// Impersonate returns a client impersonating the given user
+	Impersonate(username string) (Interface, error)
+}
+
+type impersonator struct {
+	ctx     context.Context
+	config  *rest.Config
+	resync  time.Duration
+	lock    sync.Mutex
+	clients map[string]Interface
+}
+
+// NewImpersonator creates an Impersonator deriving clients from the given rest config.
+// Clients are cached per user and stop when ctx is done.
+func NewImpersonator(ctx context.Context, config *rest.Config, resync time.Duration) Impersonator {
+	return &impersonator{
+		ctx:     ctx,
+		config:  config,
+		resync:  resync,
+		clients: map[string]Interface{},
+	}
+}
+
+func (i *impersonator) Impersonate(username string) (Interface, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if client, ok := i.clients[username]; ok {
+		return client, nil
+	}
+	config := rest.CopyConfig(i.config)
+	config.Impersonate = rest.ImpersonationConfig{UserName: username}
+	kube, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	dyn, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	client, err := NewClient(i.ctx, dyn, kube, i.resync)
+	if err != nil {
+		return nil, err
+	}
+	i.clients[username] = client
+	return client, nil
+}
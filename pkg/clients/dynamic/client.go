@@ -3,9 +3,11 @@ package clientset
 import (
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/pkg/clients/dynamic/resource"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/flowcontrol"
 )
 
 type namespaceableInterface interface {
@@ -24,6 +26,22 @@ func WrapWithLogging(inner dynamic.Interface, logger logr.Logger) dynamic.Interf
 	return &withLogging{inner, logger}
 }
 
+func WrapWithAuditLogging(inner dynamic.Interface, logger logr.Logger, audit *middleware.AuditLogging) dynamic.Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WrapWithRateLimiting(inner dynamic.Interface, limits *middleware.RateLimits) dynamic.Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WrapWithRetry(inner dynamic.Interface, retries *middleware.Retries) dynamic.Interface {
+	return &withRetry{inner, retries}
+}
+
+func WrapWithCircuitBreaker(inner dynamic.Interface, breakers *middleware.CircuitBreakers) dynamic.Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      dynamic.Interface
 	metrics    metrics.MetricsConfigManager
@@ -106,3 +124,121 @@ func (c *withLogging) Resource(gvr schema.GroupVersionResource) dynamic.Namespac
 		&withLoggingNamespaceable{inner, logger},
 	}
 }
+
+type withAuditLogging struct {
+	inner  dynamic.Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+type withAuditLoggingNamespaceable struct {
+	inner  namespaceableInterface
+	logger logr.Logger
+}
+
+func (c *withAuditLoggingNamespaceable) Namespace(namespace string) dynamic.ResourceInterface {
+	return resource.WithAuditLogging(c.inner.Namespace(namespace), c.logger.WithValues("namespace", namespace))
+}
+
+func (c *withAuditLogging) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	inner := c.inner.Resource(gvr)
+	level, ok := c.audit.For(gvr.Resource)
+	if !ok {
+		return inner
+	}
+	logger := c.logger.V(level).WithValues("group", gvr.Group, "version", gvr.Version, "resource", gvr.Resource)
+	return struct {
+		dynamic.ResourceInterface
+		namespaceableInterface
+	}{
+		resource.WithAuditLogging(inner, logger),
+		&withAuditLoggingNamespaceable{inner, logger},
+	}
+}
+
+type withRateLimiting struct {
+	inner  dynamic.Interface
+	limits *middleware.RateLimits
+}
+
+type withRateLimitingNamespaceable struct {
+	inner   namespaceableInterface
+	limiter flowcontrol.RateLimiter
+}
+
+func (c *withRateLimitingNamespaceable) Namespace(namespace string) dynamic.ResourceInterface {
+	return resource.WithRateLimiting(c.inner.Namespace(namespace), c.limiter)
+}
+
+func (c *withRateLimiting) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	limiter := c.limits.For(gvr.Resource)
+	inner := c.inner.Resource(gvr)
+	if limiter == nil {
+		return inner
+	}
+	return struct {
+		dynamic.ResourceInterface
+		namespaceableInterface
+	}{
+		resource.WithRateLimiting(inner, limiter),
+		&withRateLimitingNamespaceable{inner, limiter},
+	}
+}
+
+type withRetry struct {
+	inner   dynamic.Interface
+	retries *middleware.Retries
+}
+
+type withRetryNamespaceable struct {
+	inner   namespaceableInterface
+	retrier *middleware.Retrier
+}
+
+func (c *withRetryNamespaceable) Namespace(namespace string) dynamic.ResourceInterface {
+	return resource.WithRetry(c.inner.Namespace(namespace), c.retrier)
+}
+
+func (c *withRetry) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	retrier := c.retries.For(gvr.Resource)
+	inner := c.inner.Resource(gvr)
+	if retrier == nil {
+		return inner
+	}
+	return struct {
+		dynamic.ResourceInterface
+		namespaceableInterface
+	}{
+		resource.WithRetry(inner, retrier),
+		&withRetryNamespaceable{inner, retrier},
+	}
+}
+
+type withCircuitBreaker struct {
+	inner    dynamic.Interface
+	breakers *middleware.CircuitBreakers
+}
+
+type withCircuitBreakerNamespaceable struct {
+	inner   namespaceableInterface
+	breaker *middleware.CircuitBreaker
+}
+
+func (c *withCircuitBreakerNamespaceable) Namespace(namespace string) dynamic.ResourceInterface {
+	return resource.WithCircuitBreaker(c.inner.Namespace(namespace), c.breaker)
+}
+
+func (c *withCircuitBreaker) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	breaker := c.breakers.For(gvr.Resource)
+	inner := c.inner.Resource(gvr)
+	if breaker == nil {
+		return inner
+	}
+	return struct {
+		dynamic.ResourceInterface
+		namespaceableInterface
+	}{
+		resource.WithCircuitBreaker(inner, breaker),
+		&withCircuitBreakerNamespaceable{inner, breaker},
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_dynamic "k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
@@ -16,6 +17,10 @@ type Interface interface {
 	WithMetrics(metrics.MetricsConfigManager, metrics.ClientType) Interface
 	WithTracing() Interface
 	WithLogging(logr.Logger) Interface
+	WithAuditLogging(logr.Logger, *middleware.AuditLogging) Interface
+	WithRateLimiting(*middleware.RateLimits) Interface
+	WithRetry(*middleware.Retries) Interface
+	WithCircuitBreaker(*middleware.CircuitBreakers) Interface
 }
 
 func From(inner k8s_io_client_go_dynamic.Interface, opts ...NewOption) Interface {
@@ -46,6 +51,30 @@ func WithLogging(logger logr.Logger) NewOption {
 	}
 }
 
+func WithAuditLogging(logger logr.Logger, audit *middleware.AuditLogging) NewOption {
+	return func(i Interface) Interface {
+		return i.WithAuditLogging(logger, audit)
+	}
+}
+
+func WithRateLimiting(limits *middleware.RateLimits) NewOption {
+	return func(i Interface) Interface {
+		return i.WithRateLimiting(limits)
+	}
+}
+
+func WithRetry(retries *middleware.Retries) NewOption {
+	return func(i Interface) Interface {
+		return i.WithRetry(retries)
+	}
+}
+
+func WithCircuitBreaker(breakers *middleware.CircuitBreakers) NewOption {
+	return func(i Interface) Interface {
+		return i.WithCircuitBreaker(breakers)
+	}
+}
+
 func NewForConfig(c *rest.Config, opts ...NewOption) (Interface, error) {
 	inner, err := k8s_io_client_go_dynamic.NewForConfig(c)
 	if err != nil {
@@ -85,3 +114,19 @@ func (i *wrapper) WithTracing() Interface {
 func (i *wrapper) WithLogging(logger logr.Logger) Interface {
 	return from(WrapWithLogging(i, logger))
 }
+
+func (i *wrapper) WithAuditLogging(logger logr.Logger, audit *middleware.AuditLogging) Interface {
+	return from(WrapWithAuditLogging(i, logger, audit))
+}
+
+func (i *wrapper) WithRateLimiting(limits *middleware.RateLimits) Interface {
+	return from(WrapWithRateLimiting(i, limits))
+}
+
+func (i *wrapper) WithRetry(retries *middleware.Retries) Interface {
+	return from(WrapWithRetry(i, retries))
+}
+
+func (i *wrapper) WithCircuitBreaker(breakers *middleware.CircuitBreakers) Interface {
+	return from(WrapWithCircuitBreaker(i, breakers))
+}
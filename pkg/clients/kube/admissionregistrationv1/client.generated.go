@@ -4,6 +4,7 @@ import (
 	"github.com/go-logr/logr"
 	mutatingwebhookconfigurations "github.com/kyverno/kyverno/pkg/clients/kube/admissionregistrationv1/mutatingwebhookconfigurations"
 	validatingwebhookconfigurations "github.com/kyverno/kyverno/pkg/clients/kube/admissionregistrationv1/validatingwebhookconfigurations"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_admissionregistration_v1 "k8s.io/client-go/kubernetes/typed/admissionregistration/v1"
 	"k8s.io/client-go/rest"
@@ -21,6 +22,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_admissionregistration_v
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_admissionregistration_v1.AdmissionregistrationV1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_admissionregistration_v1.AdmissionregistrationV1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_admissionregistration_v1.AdmissionregistrationV1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_admissionregistration_v1.AdmissionregistrationV1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_admissionregistration_v1.AdmissionregistrationV1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_admissionregistration_v1.AdmissionregistrationV1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_admissionregistration_v1.AdmissionregistrationV1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_admissionregistration_v1.AdmissionregistrationV1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_admissionregistration_v1.AdmissionregistrationV1Interface
 	metrics    metrics.MetricsConfigManager
@@ -68,3 +85,74 @@ func (c *withLogging) MutatingWebhookConfigurations() k8s_io_client_go_kubernete
 func (c *withLogging) ValidatingWebhookConfigurations() k8s_io_client_go_kubernetes_typed_admissionregistration_v1.ValidatingWebhookConfigurationInterface {
 	return validatingwebhookconfigurations.WithLogging(c.inner.ValidatingWebhookConfigurations(), c.logger.WithValues("resource", "ValidatingWebhookConfigurations"))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_admissionregistration_v1.AdmissionregistrationV1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) MutatingWebhookConfigurations() k8s_io_client_go_kubernetes_typed_admissionregistration_v1.MutatingWebhookConfigurationInterface {
+	inner := c.inner.MutatingWebhookConfigurations()
+	level, ok := c.audit.For("MutatingWebhookConfiguration")
+	if !ok {
+		return inner
+	}
+	return mutatingwebhookconfigurations.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "MutatingWebhookConfigurations"))
+}
+func (c *withAuditLogging) ValidatingWebhookConfigurations() k8s_io_client_go_kubernetes_typed_admissionregistration_v1.ValidatingWebhookConfigurationInterface {
+	inner := c.inner.ValidatingWebhookConfigurations()
+	level, ok := c.audit.For("ValidatingWebhookConfiguration")
+	if !ok {
+		return inner
+	}
+	return validatingwebhookconfigurations.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ValidatingWebhookConfigurations"))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_admissionregistration_v1.AdmissionregistrationV1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) MutatingWebhookConfigurations() k8s_io_client_go_kubernetes_typed_admissionregistration_v1.MutatingWebhookConfigurationInterface {
+	return mutatingwebhookconfigurations.WithRateLimiting(c.inner.MutatingWebhookConfigurations(), c.limits.For("MutatingWebhookConfiguration"))
+}
+func (c *withRateLimiting) ValidatingWebhookConfigurations() k8s_io_client_go_kubernetes_typed_admissionregistration_v1.ValidatingWebhookConfigurationInterface {
+	return validatingwebhookconfigurations.WithRateLimiting(c.inner.ValidatingWebhookConfigurations(), c.limits.For("ValidatingWebhookConfiguration"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_admissionregistration_v1.AdmissionregistrationV1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) MutatingWebhookConfigurations() k8s_io_client_go_kubernetes_typed_admissionregistration_v1.MutatingWebhookConfigurationInterface {
+	return mutatingwebhookconfigurations.WithRetry(c.inner.MutatingWebhookConfigurations(), c.retries.For("MutatingWebhookConfiguration"))
+}
+func (c *withRetry) ValidatingWebhookConfigurations() k8s_io_client_go_kubernetes_typed_admissionregistration_v1.ValidatingWebhookConfigurationInterface {
+	return validatingwebhookconfigurations.WithRetry(c.inner.ValidatingWebhookConfigurations(), c.retries.For("ValidatingWebhookConfiguration"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_admissionregistration_v1.AdmissionregistrationV1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) MutatingWebhookConfigurations() k8s_io_client_go_kubernetes_typed_admissionregistration_v1.MutatingWebhookConfigurationInterface {
+	return mutatingwebhookconfigurations.WithCircuitBreaker(c.inner.MutatingWebhookConfigurations(), c.breakers.For("MutatingWebhookConfiguration"))
+}
+func (c *withCircuitBreaker) ValidatingWebhookConfigurations() k8s_io_client_go_kubernetes_typed_admissionregistration_v1.ValidatingWebhookConfigurationInterface {
+	return validatingwebhookconfigurations.WithCircuitBreaker(c.inner.ValidatingWebhookConfigurations(), c.breakers.For("ValidatingWebhookConfiguration"))
+}
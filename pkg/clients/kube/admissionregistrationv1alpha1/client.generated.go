@@ -4,6 +4,7 @@ import (
 	"github.com/go-logr/logr"
 	validatingadmissionpolicies "github.com/kyverno/kyverno/pkg/clients/kube/admissionregistrationv1alpha1/validatingadmissionpolicies"
 	validatingadmissionpolicybindings "github.com/kyverno/kyverno/pkg/clients/kube/admissionregistrationv1alpha1/validatingadmissionpolicybindings"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1 "k8s.io/client-go/kubernetes/typed/admissionregistration/v1alpha1"
 	"k8s.io/client-go/rest"
@@ -21,6 +22,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_admissionregistration_v
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1.AdmissionregistrationV1alpha1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1.AdmissionregistrationV1alpha1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1.AdmissionregistrationV1alpha1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1.AdmissionregistrationV1alpha1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1.AdmissionregistrationV1alpha1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1.AdmissionregistrationV1alpha1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1.AdmissionregistrationV1alpha1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1.AdmissionregistrationV1alpha1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1.AdmissionregistrationV1alpha1Interface
 	metrics    metrics.MetricsConfigManager
@@ -68,3 +85,74 @@ func (c *withLogging) ValidatingAdmissionPolicies() k8s_io_client_go_kubernetes_
 func (c *withLogging) ValidatingAdmissionPolicyBindings() k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1.ValidatingAdmissionPolicyBindingInterface {
 	return validatingadmissionpolicybindings.WithLogging(c.inner.ValidatingAdmissionPolicyBindings(), c.logger.WithValues("resource", "ValidatingAdmissionPolicyBindings"))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1.AdmissionregistrationV1alpha1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) ValidatingAdmissionPolicies() k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1.ValidatingAdmissionPolicyInterface {
+	inner := c.inner.ValidatingAdmissionPolicies()
+	level, ok := c.audit.For("ValidatingAdmissionPolicy")
+	if !ok {
+		return inner
+	}
+	return validatingadmissionpolicies.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ValidatingAdmissionPolicies"))
+}
+func (c *withAuditLogging) ValidatingAdmissionPolicyBindings() k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1.ValidatingAdmissionPolicyBindingInterface {
+	inner := c.inner.ValidatingAdmissionPolicyBindings()
+	level, ok := c.audit.For("ValidatingAdmissionPolicyBinding")
+	if !ok {
+		return inner
+	}
+	return validatingadmissionpolicybindings.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ValidatingAdmissionPolicyBindings"))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1.AdmissionregistrationV1alpha1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) ValidatingAdmissionPolicies() k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1.ValidatingAdmissionPolicyInterface {
+	return validatingadmissionpolicies.WithRateLimiting(c.inner.ValidatingAdmissionPolicies(), c.limits.For("ValidatingAdmissionPolicy"))
+}
+func (c *withRateLimiting) ValidatingAdmissionPolicyBindings() k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1.ValidatingAdmissionPolicyBindingInterface {
+	return validatingadmissionpolicybindings.WithRateLimiting(c.inner.ValidatingAdmissionPolicyBindings(), c.limits.For("ValidatingAdmissionPolicyBinding"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1.AdmissionregistrationV1alpha1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) ValidatingAdmissionPolicies() k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1.ValidatingAdmissionPolicyInterface {
+	return validatingadmissionpolicies.WithRetry(c.inner.ValidatingAdmissionPolicies(), c.retries.For("ValidatingAdmissionPolicy"))
+}
+func (c *withRetry) ValidatingAdmissionPolicyBindings() k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1.ValidatingAdmissionPolicyBindingInterface {
+	return validatingadmissionpolicybindings.WithRetry(c.inner.ValidatingAdmissionPolicyBindings(), c.retries.For("ValidatingAdmissionPolicyBinding"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1.AdmissionregistrationV1alpha1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) ValidatingAdmissionPolicies() k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1.ValidatingAdmissionPolicyInterface {
+	return validatingadmissionpolicies.WithCircuitBreaker(c.inner.ValidatingAdmissionPolicies(), c.breakers.For("ValidatingAdmissionPolicy"))
+}
+func (c *withCircuitBreaker) ValidatingAdmissionPolicyBindings() k8s_io_client_go_kubernetes_typed_admissionregistration_v1alpha1.ValidatingAdmissionPolicyBindingInterface {
+	return validatingadmissionpolicybindings.WithCircuitBreaker(c.inner.ValidatingAdmissionPolicyBindings(), c.breakers.For("ValidatingAdmissionPolicyBinding"))
+}
@@ -6,6 +6,7 @@ import (
 	validatingadmissionpolicies "github.com/kyverno/kyverno/pkg/clients/kube/admissionregistrationv1beta1/validatingadmissionpolicies"
 	validatingadmissionpolicybindings "github.com/kyverno/kyverno/pkg/clients/kube/admissionregistrationv1beta1/validatingadmissionpolicybindings"
 	validatingwebhookconfigurations "github.com/kyverno/kyverno/pkg/clients/kube/admissionregistrationv1beta1/validatingwebhookconfigurations"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1 "k8s.io/client-go/kubernetes/typed/admissionregistration/v1beta1"
 	"k8s.io/client-go/rest"
@@ -23,6 +24,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_admissionregistration_v
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.AdmissionregistrationV1beta1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.AdmissionregistrationV1beta1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.AdmissionregistrationV1beta1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.AdmissionregistrationV1beta1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.AdmissionregistrationV1beta1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.AdmissionregistrationV1beta1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.AdmissionregistrationV1beta1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.AdmissionregistrationV1beta1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.AdmissionregistrationV1beta1Interface
 	metrics    metrics.MetricsConfigManager
@@ -90,3 +107,108 @@ func (c *withLogging) ValidatingAdmissionPolicyBindings() k8s_io_client_go_kuber
 func (c *withLogging) ValidatingWebhookConfigurations() k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.ValidatingWebhookConfigurationInterface {
 	return validatingwebhookconfigurations.WithLogging(c.inner.ValidatingWebhookConfigurations(), c.logger.WithValues("resource", "ValidatingWebhookConfigurations"))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.AdmissionregistrationV1beta1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) MutatingWebhookConfigurations() k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.MutatingWebhookConfigurationInterface {
+	inner := c.inner.MutatingWebhookConfigurations()
+	level, ok := c.audit.For("MutatingWebhookConfiguration")
+	if !ok {
+		return inner
+	}
+	return mutatingwebhookconfigurations.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "MutatingWebhookConfigurations"))
+}
+func (c *withAuditLogging) ValidatingAdmissionPolicies() k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.ValidatingAdmissionPolicyInterface {
+	inner := c.inner.ValidatingAdmissionPolicies()
+	level, ok := c.audit.For("ValidatingAdmissionPolicy")
+	if !ok {
+		return inner
+	}
+	return validatingadmissionpolicies.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ValidatingAdmissionPolicies"))
+}
+func (c *withAuditLogging) ValidatingAdmissionPolicyBindings() k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.ValidatingAdmissionPolicyBindingInterface {
+	inner := c.inner.ValidatingAdmissionPolicyBindings()
+	level, ok := c.audit.For("ValidatingAdmissionPolicyBinding")
+	if !ok {
+		return inner
+	}
+	return validatingadmissionpolicybindings.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ValidatingAdmissionPolicyBindings"))
+}
+func (c *withAuditLogging) ValidatingWebhookConfigurations() k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.ValidatingWebhookConfigurationInterface {
+	inner := c.inner.ValidatingWebhookConfigurations()
+	level, ok := c.audit.For("ValidatingWebhookConfiguration")
+	if !ok {
+		return inner
+	}
+	return validatingwebhookconfigurations.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ValidatingWebhookConfigurations"))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.AdmissionregistrationV1beta1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) MutatingWebhookConfigurations() k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.MutatingWebhookConfigurationInterface {
+	return mutatingwebhookconfigurations.WithRateLimiting(c.inner.MutatingWebhookConfigurations(), c.limits.For("MutatingWebhookConfiguration"))
+}
+func (c *withRateLimiting) ValidatingAdmissionPolicies() k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.ValidatingAdmissionPolicyInterface {
+	return validatingadmissionpolicies.WithRateLimiting(c.inner.ValidatingAdmissionPolicies(), c.limits.For("ValidatingAdmissionPolicy"))
+}
+func (c *withRateLimiting) ValidatingAdmissionPolicyBindings() k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.ValidatingAdmissionPolicyBindingInterface {
+	return validatingadmissionpolicybindings.WithRateLimiting(c.inner.ValidatingAdmissionPolicyBindings(), c.limits.For("ValidatingAdmissionPolicyBinding"))
+}
+func (c *withRateLimiting) ValidatingWebhookConfigurations() k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.ValidatingWebhookConfigurationInterface {
+	return validatingwebhookconfigurations.WithRateLimiting(c.inner.ValidatingWebhookConfigurations(), c.limits.For("ValidatingWebhookConfiguration"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.AdmissionregistrationV1beta1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) MutatingWebhookConfigurations() k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.MutatingWebhookConfigurationInterface {
+	return mutatingwebhookconfigurations.WithRetry(c.inner.MutatingWebhookConfigurations(), c.retries.For("MutatingWebhookConfiguration"))
+}
+func (c *withRetry) ValidatingAdmissionPolicies() k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.ValidatingAdmissionPolicyInterface {
+	return validatingadmissionpolicies.WithRetry(c.inner.ValidatingAdmissionPolicies(), c.retries.For("ValidatingAdmissionPolicy"))
+}
+func (c *withRetry) ValidatingAdmissionPolicyBindings() k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.ValidatingAdmissionPolicyBindingInterface {
+	return validatingadmissionpolicybindings.WithRetry(c.inner.ValidatingAdmissionPolicyBindings(), c.retries.For("ValidatingAdmissionPolicyBinding"))
+}
+func (c *withRetry) ValidatingWebhookConfigurations() k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.ValidatingWebhookConfigurationInterface {
+	return validatingwebhookconfigurations.WithRetry(c.inner.ValidatingWebhookConfigurations(), c.retries.For("ValidatingWebhookConfiguration"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.AdmissionregistrationV1beta1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) MutatingWebhookConfigurations() k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.MutatingWebhookConfigurationInterface {
+	return mutatingwebhookconfigurations.WithCircuitBreaker(c.inner.MutatingWebhookConfigurations(), c.breakers.For("MutatingWebhookConfiguration"))
+}
+func (c *withCircuitBreaker) ValidatingAdmissionPolicies() k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.ValidatingAdmissionPolicyInterface {
+	return validatingadmissionpolicies.WithCircuitBreaker(c.inner.ValidatingAdmissionPolicies(), c.breakers.For("ValidatingAdmissionPolicy"))
+}
+func (c *withCircuitBreaker) ValidatingAdmissionPolicyBindings() k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.ValidatingAdmissionPolicyBindingInterface {
+	return validatingadmissionpolicybindings.WithCircuitBreaker(c.inner.ValidatingAdmissionPolicyBindings(), c.breakers.For("ValidatingAdmissionPolicyBinding"))
+}
+func (c *withCircuitBreaker) ValidatingWebhookConfigurations() k8s_io_client_go_kubernetes_typed_admissionregistration_v1beta1.ValidatingWebhookConfigurationInterface {
+	return validatingwebhookconfigurations.WithCircuitBreaker(c.inner.ValidatingWebhookConfigurations(), c.breakers.For("ValidatingWebhookConfiguration"))
+}
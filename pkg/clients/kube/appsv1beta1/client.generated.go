@@ -5,6 +5,7 @@ import (
 	controllerrevisions "github.com/kyverno/kyverno/pkg/clients/kube/appsv1beta1/controllerrevisions"
 	deployments "github.com/kyverno/kyverno/pkg/clients/kube/appsv1beta1/deployments"
 	statefulsets "github.com/kyverno/kyverno/pkg/clients/kube/appsv1beta1/statefulsets"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_apps_v1beta1 "k8s.io/client-go/kubernetes/typed/apps/v1beta1"
 	"k8s.io/client-go/rest"
@@ -22,6 +23,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_apps_v1beta1.AppsV1beta
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_apps_v1beta1.AppsV1beta1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_apps_v1beta1.AppsV1beta1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_apps_v1beta1.AppsV1beta1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_apps_v1beta1.AppsV1beta1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_apps_v1beta1.AppsV1beta1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_apps_v1beta1.AppsV1beta1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_apps_v1beta1.AppsV1beta1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_apps_v1beta1.AppsV1beta1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_apps_v1beta1.AppsV1beta1Interface
 	metrics    metrics.MetricsConfigManager
@@ -79,3 +96,91 @@ func (c *withLogging) Deployments(namespace string) k8s_io_client_go_kubernetes_
 func (c *withLogging) StatefulSets(namespace string) k8s_io_client_go_kubernetes_typed_apps_v1beta1.StatefulSetInterface {
 	return statefulsets.WithLogging(c.inner.StatefulSets(namespace), c.logger.WithValues("resource", "StatefulSets").WithValues("namespace", namespace))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_apps_v1beta1.AppsV1beta1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) ControllerRevisions(namespace string) k8s_io_client_go_kubernetes_typed_apps_v1beta1.ControllerRevisionInterface {
+	inner := c.inner.ControllerRevisions(namespace)
+	level, ok := c.audit.For("ControllerRevision")
+	if !ok {
+		return inner
+	}
+	return controllerrevisions.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ControllerRevisions").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) Deployments(namespace string) k8s_io_client_go_kubernetes_typed_apps_v1beta1.DeploymentInterface {
+	inner := c.inner.Deployments(namespace)
+	level, ok := c.audit.For("Deployment")
+	if !ok {
+		return inner
+	}
+	return deployments.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "Deployments").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) StatefulSets(namespace string) k8s_io_client_go_kubernetes_typed_apps_v1beta1.StatefulSetInterface {
+	inner := c.inner.StatefulSets(namespace)
+	level, ok := c.audit.For("StatefulSet")
+	if !ok {
+		return inner
+	}
+	return statefulsets.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "StatefulSets").WithValues("namespace", namespace))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_apps_v1beta1.AppsV1beta1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) ControllerRevisions(namespace string) k8s_io_client_go_kubernetes_typed_apps_v1beta1.ControllerRevisionInterface {
+	return controllerrevisions.WithRateLimiting(c.inner.ControllerRevisions(namespace), c.limits.For("ControllerRevision"))
+}
+func (c *withRateLimiting) Deployments(namespace string) k8s_io_client_go_kubernetes_typed_apps_v1beta1.DeploymentInterface {
+	return deployments.WithRateLimiting(c.inner.Deployments(namespace), c.limits.For("Deployment"))
+}
+func (c *withRateLimiting) StatefulSets(namespace string) k8s_io_client_go_kubernetes_typed_apps_v1beta1.StatefulSetInterface {
+	return statefulsets.WithRateLimiting(c.inner.StatefulSets(namespace), c.limits.For("StatefulSet"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_apps_v1beta1.AppsV1beta1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) ControllerRevisions(namespace string) k8s_io_client_go_kubernetes_typed_apps_v1beta1.ControllerRevisionInterface {
+	return controllerrevisions.WithRetry(c.inner.ControllerRevisions(namespace), c.retries.For("ControllerRevision"))
+}
+func (c *withRetry) Deployments(namespace string) k8s_io_client_go_kubernetes_typed_apps_v1beta1.DeploymentInterface {
+	return deployments.WithRetry(c.inner.Deployments(namespace), c.retries.For("Deployment"))
+}
+func (c *withRetry) StatefulSets(namespace string) k8s_io_client_go_kubernetes_typed_apps_v1beta1.StatefulSetInterface {
+	return statefulsets.WithRetry(c.inner.StatefulSets(namespace), c.retries.For("StatefulSet"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_apps_v1beta1.AppsV1beta1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) ControllerRevisions(namespace string) k8s_io_client_go_kubernetes_typed_apps_v1beta1.ControllerRevisionInterface {
+	return controllerrevisions.WithCircuitBreaker(c.inner.ControllerRevisions(namespace), c.breakers.For("ControllerRevision"))
+}
+func (c *withCircuitBreaker) Deployments(namespace string) k8s_io_client_go_kubernetes_typed_apps_v1beta1.DeploymentInterface {
+	return deployments.WithCircuitBreaker(c.inner.Deployments(namespace), c.breakers.For("Deployment"))
+}
+func (c *withCircuitBreaker) StatefulSets(namespace string) k8s_io_client_go_kubernetes_typed_apps_v1beta1.StatefulSetInterface {
+	return statefulsets.WithCircuitBreaker(c.inner.StatefulSets(namespace), c.breakers.For("StatefulSet"))
+}
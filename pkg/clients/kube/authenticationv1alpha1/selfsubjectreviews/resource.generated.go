@@ -2,10 +2,12 @@ package resource
 
 import (
 	context "context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	"go.opentelemetry.io/otel/trace"
@@ -13,10 +15,15 @@ import (
 	k8s_io_api_authentication_v1alpha1 "k8s.io/api/authentication/v1alpha1"
 	k8s_io_apimachinery_pkg_apis_meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8s_io_client_go_kubernetes_typed_authentication_v1alpha1 "k8s.io/client-go/kubernetes/typed/authentication/v1alpha1"
+	"k8s.io/client-go/util/flowcontrol"
 )
 
 func WithLogging(inner k8s_io_client_go_kubernetes_typed_authentication_v1alpha1.SelfSubjectReviewInterface, logger logr.Logger) k8s_io_client_go_kubernetes_typed_authentication_v1alpha1.SelfSubjectReviewInterface {
-	return &withLogging{inner, logger}
+	return &withLogging{inner, logger, false}
+}
+
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_authentication_v1alpha1.SelfSubjectReviewInterface, logger logr.Logger) k8s_io_client_go_kubernetes_typed_authentication_v1alpha1.SelfSubjectReviewInterface {
+	return &withLogging{inner, logger, true}
 }
 
 func WithMetrics(inner k8s_io_client_go_kubernetes_typed_authentication_v1alpha1.SelfSubjectReviewInterface, recorder metrics.Recorder) k8s_io_client_go_kubernetes_typed_authentication_v1alpha1.SelfSubjectReviewInterface {
@@ -27,15 +34,43 @@ func WithTracing(inner k8s_io_client_go_kubernetes_typed_authentication_v1alpha1
 	return &withTracing{inner, client, kind}
 }
 
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_authentication_v1alpha1.SelfSubjectReviewInterface, limiter flowcontrol.RateLimiter) k8s_io_client_go_kubernetes_typed_authentication_v1alpha1.SelfSubjectReviewInterface {
+	if limiter == nil {
+		return inner
+	}
+	return &withRateLimiting{inner, limiter}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_authentication_v1alpha1.SelfSubjectReviewInterface, retrier *middleware.Retrier) k8s_io_client_go_kubernetes_typed_authentication_v1alpha1.SelfSubjectReviewInterface {
+	if retrier == nil {
+		return inner
+	}
+	return &withRetry{inner, retrier}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_authentication_v1alpha1.SelfSubjectReviewInterface, breaker *middleware.CircuitBreaker) k8s_io_client_go_kubernetes_typed_authentication_v1alpha1.SelfSubjectReviewInterface {
+	if breaker == nil {
+		return inner
+	}
+	return &withCircuitBreaker{inner, breaker}
+}
+
 type withLogging struct {
 	inner  k8s_io_client_go_kubernetes_typed_authentication_v1alpha1.SelfSubjectReviewInterface
 	logger logr.Logger
+	sizes  bool
 }
 
 func (c *withLogging) Create(arg0 context.Context, arg1 *k8s_io_api_authentication_v1alpha1.SelfSubjectReview, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.CreateOptions) (*k8s_io_api_authentication_v1alpha1.SelfSubjectReview, error) {
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "Create")
 	ret0, ret1 := c.inner.Create(arg0, arg1, arg2)
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(arg1, arg2),
+			"responseSize", middleware.Size(ret0),
+		)
+	}
 	if err := multierr.Combine(ret1); err != nil {
 		logger.Error(err, "Create failed", "duration", time.Since(start))
 	} else {
@@ -81,3 +116,50 @@ func (c *withTracing) Create(arg0 context.Context, arg1 *k8s_io_api_authenticati
 	}
 	return ret0, ret1
 }
+
+type withRateLimiting struct {
+	inner   k8s_io_client_go_kubernetes_typed_authentication_v1alpha1.SelfSubjectReviewInterface
+	limiter flowcontrol.RateLimiter
+}
+
+func (c *withRateLimiting) Create(arg0 context.Context, arg1 *k8s_io_api_authentication_v1alpha1.SelfSubjectReview, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.CreateOptions) (*k8s_io_api_authentication_v1alpha1.SelfSubjectReview, error) {
+	if err := c.limiter.Wait(arg0); err != nil {
+		var ret0 *k8s_io_api_authentication_v1alpha1.SelfSubjectReview
+		var ret1 error
+		ret1 = err
+		return ret0, ret1
+	}
+	return c.inner.Create(arg0, arg1, arg2)
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_authentication_v1alpha1.SelfSubjectReviewInterface
+	retrier *middleware.Retrier
+}
+
+func (c *withRetry) Create(arg0 context.Context, arg1 *k8s_io_api_authentication_v1alpha1.SelfSubjectReview, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.CreateOptions) (*k8s_io_api_authentication_v1alpha1.SelfSubjectReview, error) {
+	var ret0 *k8s_io_api_authentication_v1alpha1.SelfSubjectReview
+	var ret1 error
+	c.retrier.Retry(arg0, func() error {
+		ret0, ret1 = c.inner.Create(arg0, arg1, arg2)
+		return multierr.Combine(ret1)
+	})
+	return ret0, ret1
+}
+
+type withCircuitBreaker struct {
+	inner   k8s_io_client_go_kubernetes_typed_authentication_v1alpha1.SelfSubjectReviewInterface
+	breaker *middleware.CircuitBreaker
+}
+
+func (c *withCircuitBreaker) Create(arg0 context.Context, arg1 *k8s_io_api_authentication_v1alpha1.SelfSubjectReview, arg2 k8s_io_apimachinery_pkg_apis_meta_v1.CreateOptions) (*k8s_io_api_authentication_v1alpha1.SelfSubjectReview, error) {
+	var ret0 *k8s_io_api_authentication_v1alpha1.SelfSubjectReview
+	var ret1 error
+	if err := c.breaker.Call(arg0, func() error {
+		ret0, ret1 = c.inner.Create(arg0, arg1, arg2)
+		return multierr.Combine(ret1)
+	}); errors.Is(err, middleware.ErrCircuitOpen) {
+		ret1 = err
+	}
+	return ret0, ret1
+}
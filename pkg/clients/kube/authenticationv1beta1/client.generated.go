@@ -4,6 +4,7 @@ import (
 	"github.com/go-logr/logr"
 	selfsubjectreviews "github.com/kyverno/kyverno/pkg/clients/kube/authenticationv1beta1/selfsubjectreviews"
 	tokenreviews "github.com/kyverno/kyverno/pkg/clients/kube/authenticationv1beta1/tokenreviews"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_authentication_v1beta1 "k8s.io/client-go/kubernetes/typed/authentication/v1beta1"
 	"k8s.io/client-go/rest"
@@ -21,6 +22,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_authentication_v1beta1.
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_authentication_v1beta1.AuthenticationV1beta1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_authentication_v1beta1.AuthenticationV1beta1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_authentication_v1beta1.AuthenticationV1beta1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_authentication_v1beta1.AuthenticationV1beta1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_authentication_v1beta1.AuthenticationV1beta1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_authentication_v1beta1.AuthenticationV1beta1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_authentication_v1beta1.AuthenticationV1beta1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_authentication_v1beta1.AuthenticationV1beta1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_authentication_v1beta1.AuthenticationV1beta1Interface
 	metrics    metrics.MetricsConfigManager
@@ -68,3 +85,74 @@ func (c *withLogging) SelfSubjectReviews() k8s_io_client_go_kubernetes_typed_aut
 func (c *withLogging) TokenReviews() k8s_io_client_go_kubernetes_typed_authentication_v1beta1.TokenReviewInterface {
 	return tokenreviews.WithLogging(c.inner.TokenReviews(), c.logger.WithValues("resource", "TokenReviews"))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_authentication_v1beta1.AuthenticationV1beta1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) SelfSubjectReviews() k8s_io_client_go_kubernetes_typed_authentication_v1beta1.SelfSubjectReviewInterface {
+	inner := c.inner.SelfSubjectReviews()
+	level, ok := c.audit.For("SelfSubjectReview")
+	if !ok {
+		return inner
+	}
+	return selfsubjectreviews.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "SelfSubjectReviews"))
+}
+func (c *withAuditLogging) TokenReviews() k8s_io_client_go_kubernetes_typed_authentication_v1beta1.TokenReviewInterface {
+	inner := c.inner.TokenReviews()
+	level, ok := c.audit.For("TokenReview")
+	if !ok {
+		return inner
+	}
+	return tokenreviews.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "TokenReviews"))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_authentication_v1beta1.AuthenticationV1beta1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) SelfSubjectReviews() k8s_io_client_go_kubernetes_typed_authentication_v1beta1.SelfSubjectReviewInterface {
+	return selfsubjectreviews.WithRateLimiting(c.inner.SelfSubjectReviews(), c.limits.For("SelfSubjectReview"))
+}
+func (c *withRateLimiting) TokenReviews() k8s_io_client_go_kubernetes_typed_authentication_v1beta1.TokenReviewInterface {
+	return tokenreviews.WithRateLimiting(c.inner.TokenReviews(), c.limits.For("TokenReview"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_authentication_v1beta1.AuthenticationV1beta1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) SelfSubjectReviews() k8s_io_client_go_kubernetes_typed_authentication_v1beta1.SelfSubjectReviewInterface {
+	return selfsubjectreviews.WithRetry(c.inner.SelfSubjectReviews(), c.retries.For("SelfSubjectReview"))
+}
+func (c *withRetry) TokenReviews() k8s_io_client_go_kubernetes_typed_authentication_v1beta1.TokenReviewInterface {
+	return tokenreviews.WithRetry(c.inner.TokenReviews(), c.retries.For("TokenReview"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_authentication_v1beta1.AuthenticationV1beta1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) SelfSubjectReviews() k8s_io_client_go_kubernetes_typed_authentication_v1beta1.SelfSubjectReviewInterface {
+	return selfsubjectreviews.WithCircuitBreaker(c.inner.SelfSubjectReviews(), c.breakers.For("SelfSubjectReview"))
+}
+func (c *withCircuitBreaker) TokenReviews() k8s_io_client_go_kubernetes_typed_authentication_v1beta1.TokenReviewInterface {
+	return tokenreviews.WithCircuitBreaker(c.inner.TokenReviews(), c.breakers.For("TokenReview"))
+}
@@ -6,6 +6,7 @@ import (
 	selfsubjectaccessreviews "github.com/kyverno/kyverno/pkg/clients/kube/authorizationv1beta1/selfsubjectaccessreviews"
 	selfsubjectrulesreviews "github.com/kyverno/kyverno/pkg/clients/kube/authorizationv1beta1/selfsubjectrulesreviews"
 	subjectaccessreviews "github.com/kyverno/kyverno/pkg/clients/kube/authorizationv1beta1/subjectaccessreviews"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_authorization_v1beta1 "k8s.io/client-go/kubernetes/typed/authorization/v1beta1"
 	"k8s.io/client-go/rest"
@@ -23,6 +24,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_authorization_v1beta1.A
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_authorization_v1beta1.AuthorizationV1beta1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_authorization_v1beta1.AuthorizationV1beta1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_authorization_v1beta1.AuthorizationV1beta1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_authorization_v1beta1.AuthorizationV1beta1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_authorization_v1beta1.AuthorizationV1beta1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_authorization_v1beta1.AuthorizationV1beta1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_authorization_v1beta1.AuthorizationV1beta1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_authorization_v1beta1.AuthorizationV1beta1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_authorization_v1beta1.AuthorizationV1beta1Interface
 	metrics    metrics.MetricsConfigManager
@@ -90,3 +107,108 @@ func (c *withLogging) SelfSubjectRulesReviews() k8s_io_client_go_kubernetes_type
 func (c *withLogging) SubjectAccessReviews() k8s_io_client_go_kubernetes_typed_authorization_v1beta1.SubjectAccessReviewInterface {
 	return subjectaccessreviews.WithLogging(c.inner.SubjectAccessReviews(), c.logger.WithValues("resource", "SubjectAccessReviews"))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_authorization_v1beta1.AuthorizationV1beta1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) LocalSubjectAccessReviews(namespace string) k8s_io_client_go_kubernetes_typed_authorization_v1beta1.LocalSubjectAccessReviewInterface {
+	inner := c.inner.LocalSubjectAccessReviews(namespace)
+	level, ok := c.audit.For("LocalSubjectAccessReview")
+	if !ok {
+		return inner
+	}
+	return localsubjectaccessreviews.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "LocalSubjectAccessReviews").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) SelfSubjectAccessReviews() k8s_io_client_go_kubernetes_typed_authorization_v1beta1.SelfSubjectAccessReviewInterface {
+	inner := c.inner.SelfSubjectAccessReviews()
+	level, ok := c.audit.For("SelfSubjectAccessReview")
+	if !ok {
+		return inner
+	}
+	return selfsubjectaccessreviews.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "SelfSubjectAccessReviews"))
+}
+func (c *withAuditLogging) SelfSubjectRulesReviews() k8s_io_client_go_kubernetes_typed_authorization_v1beta1.SelfSubjectRulesReviewInterface {
+	inner := c.inner.SelfSubjectRulesReviews()
+	level, ok := c.audit.For("SelfSubjectRulesReview")
+	if !ok {
+		return inner
+	}
+	return selfsubjectrulesreviews.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "SelfSubjectRulesReviews"))
+}
+func (c *withAuditLogging) SubjectAccessReviews() k8s_io_client_go_kubernetes_typed_authorization_v1beta1.SubjectAccessReviewInterface {
+	inner := c.inner.SubjectAccessReviews()
+	level, ok := c.audit.For("SubjectAccessReview")
+	if !ok {
+		return inner
+	}
+	return subjectaccessreviews.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "SubjectAccessReviews"))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_authorization_v1beta1.AuthorizationV1beta1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) LocalSubjectAccessReviews(namespace string) k8s_io_client_go_kubernetes_typed_authorization_v1beta1.LocalSubjectAccessReviewInterface {
+	return localsubjectaccessreviews.WithRateLimiting(c.inner.LocalSubjectAccessReviews(namespace), c.limits.For("LocalSubjectAccessReview"))
+}
+func (c *withRateLimiting) SelfSubjectAccessReviews() k8s_io_client_go_kubernetes_typed_authorization_v1beta1.SelfSubjectAccessReviewInterface {
+	return selfsubjectaccessreviews.WithRateLimiting(c.inner.SelfSubjectAccessReviews(), c.limits.For("SelfSubjectAccessReview"))
+}
+func (c *withRateLimiting) SelfSubjectRulesReviews() k8s_io_client_go_kubernetes_typed_authorization_v1beta1.SelfSubjectRulesReviewInterface {
+	return selfsubjectrulesreviews.WithRateLimiting(c.inner.SelfSubjectRulesReviews(), c.limits.For("SelfSubjectRulesReview"))
+}
+func (c *withRateLimiting) SubjectAccessReviews() k8s_io_client_go_kubernetes_typed_authorization_v1beta1.SubjectAccessReviewInterface {
+	return subjectaccessreviews.WithRateLimiting(c.inner.SubjectAccessReviews(), c.limits.For("SubjectAccessReview"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_authorization_v1beta1.AuthorizationV1beta1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) LocalSubjectAccessReviews(namespace string) k8s_io_client_go_kubernetes_typed_authorization_v1beta1.LocalSubjectAccessReviewInterface {
+	return localsubjectaccessreviews.WithRetry(c.inner.LocalSubjectAccessReviews(namespace), c.retries.For("LocalSubjectAccessReview"))
+}
+func (c *withRetry) SelfSubjectAccessReviews() k8s_io_client_go_kubernetes_typed_authorization_v1beta1.SelfSubjectAccessReviewInterface {
+	return selfsubjectaccessreviews.WithRetry(c.inner.SelfSubjectAccessReviews(), c.retries.For("SelfSubjectAccessReview"))
+}
+func (c *withRetry) SelfSubjectRulesReviews() k8s_io_client_go_kubernetes_typed_authorization_v1beta1.SelfSubjectRulesReviewInterface {
+	return selfsubjectrulesreviews.WithRetry(c.inner.SelfSubjectRulesReviews(), c.retries.For("SelfSubjectRulesReview"))
+}
+func (c *withRetry) SubjectAccessReviews() k8s_io_client_go_kubernetes_typed_authorization_v1beta1.SubjectAccessReviewInterface {
+	return subjectaccessreviews.WithRetry(c.inner.SubjectAccessReviews(), c.retries.For("SubjectAccessReview"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_authorization_v1beta1.AuthorizationV1beta1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) LocalSubjectAccessReviews(namespace string) k8s_io_client_go_kubernetes_typed_authorization_v1beta1.LocalSubjectAccessReviewInterface {
+	return localsubjectaccessreviews.WithCircuitBreaker(c.inner.LocalSubjectAccessReviews(namespace), c.breakers.For("LocalSubjectAccessReview"))
+}
+func (c *withCircuitBreaker) SelfSubjectAccessReviews() k8s_io_client_go_kubernetes_typed_authorization_v1beta1.SelfSubjectAccessReviewInterface {
+	return selfsubjectaccessreviews.WithCircuitBreaker(c.inner.SelfSubjectAccessReviews(), c.breakers.For("SelfSubjectAccessReview"))
+}
+func (c *withCircuitBreaker) SelfSubjectRulesReviews() k8s_io_client_go_kubernetes_typed_authorization_v1beta1.SelfSubjectRulesReviewInterface {
+	return selfsubjectrulesreviews.WithCircuitBreaker(c.inner.SelfSubjectRulesReviews(), c.breakers.For("SelfSubjectRulesReview"))
+}
+func (c *withCircuitBreaker) SubjectAccessReviews() k8s_io_client_go_kubernetes_typed_authorization_v1beta1.SubjectAccessReviewInterface {
+	return subjectaccessreviews.WithCircuitBreaker(c.inner.SubjectAccessReviews(), c.breakers.For("SubjectAccessReview"))
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"github.com/go-logr/logr"
 	horizontalpodautoscalers "github.com/kyverno/kyverno/pkg/clients/kube/autoscalingv2/horizontalpodautoscalers"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_autoscaling_v2 "k8s.io/client-go/kubernetes/typed/autoscaling/v2"
 	"k8s.io/client-go/rest"
@@ -20,6 +21,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_autoscaling_v2.Autoscal
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_autoscaling_v2.AutoscalingV2Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_autoscaling_v2.AutoscalingV2Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_autoscaling_v2.AutoscalingV2Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_autoscaling_v2.AutoscalingV2Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_autoscaling_v2.AutoscalingV2Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_autoscaling_v2.AutoscalingV2Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_autoscaling_v2.AutoscalingV2Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_autoscaling_v2.AutoscalingV2Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_autoscaling_v2.AutoscalingV2Interface
 	metrics    metrics.MetricsConfigManager
@@ -57,3 +74,57 @@ func (c *withLogging) RESTClient() rest.Interface {
 func (c *withLogging) HorizontalPodAutoscalers(namespace string) k8s_io_client_go_kubernetes_typed_autoscaling_v2.HorizontalPodAutoscalerInterface {
 	return horizontalpodautoscalers.WithLogging(c.inner.HorizontalPodAutoscalers(namespace), c.logger.WithValues("resource", "HorizontalPodAutoscalers").WithValues("namespace", namespace))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_autoscaling_v2.AutoscalingV2Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) HorizontalPodAutoscalers(namespace string) k8s_io_client_go_kubernetes_typed_autoscaling_v2.HorizontalPodAutoscalerInterface {
+	inner := c.inner.HorizontalPodAutoscalers(namespace)
+	level, ok := c.audit.For("HorizontalPodAutoscaler")
+	if !ok {
+		return inner
+	}
+	return horizontalpodautoscalers.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "HorizontalPodAutoscalers").WithValues("namespace", namespace))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_autoscaling_v2.AutoscalingV2Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) HorizontalPodAutoscalers(namespace string) k8s_io_client_go_kubernetes_typed_autoscaling_v2.HorizontalPodAutoscalerInterface {
+	return horizontalpodautoscalers.WithRateLimiting(c.inner.HorizontalPodAutoscalers(namespace), c.limits.For("HorizontalPodAutoscaler"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_autoscaling_v2.AutoscalingV2Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) HorizontalPodAutoscalers(namespace string) k8s_io_client_go_kubernetes_typed_autoscaling_v2.HorizontalPodAutoscalerInterface {
+	return horizontalpodautoscalers.WithRetry(c.inner.HorizontalPodAutoscalers(namespace), c.retries.For("HorizontalPodAutoscaler"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_autoscaling_v2.AutoscalingV2Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) HorizontalPodAutoscalers(namespace string) k8s_io_client_go_kubernetes_typed_autoscaling_v2.HorizontalPodAutoscalerInterface {
+	return horizontalpodautoscalers.WithCircuitBreaker(c.inner.HorizontalPodAutoscalers(namespace), c.breakers.For("HorizontalPodAutoscaler"))
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/go-logr/logr"
 	cronjobs "github.com/kyverno/kyverno/pkg/clients/kube/batchv1/cronjobs"
 	jobs "github.com/kyverno/kyverno/pkg/clients/kube/batchv1/jobs"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_batch_v1 "k8s.io/client-go/kubernetes/typed/batch/v1"
 	"k8s.io/client-go/rest"
@@ -21,6 +22,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_batch_v1.BatchV1Interfa
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_batch_v1.BatchV1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_batch_v1.BatchV1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_batch_v1.BatchV1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_batch_v1.BatchV1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_batch_v1.BatchV1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_batch_v1.BatchV1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_batch_v1.BatchV1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_batch_v1.BatchV1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_batch_v1.BatchV1Interface
 	metrics    metrics.MetricsConfigManager
@@ -68,3 +85,74 @@ func (c *withLogging) CronJobs(namespace string) k8s_io_client_go_kubernetes_typ
 func (c *withLogging) Jobs(namespace string) k8s_io_client_go_kubernetes_typed_batch_v1.JobInterface {
 	return jobs.WithLogging(c.inner.Jobs(namespace), c.logger.WithValues("resource", "Jobs").WithValues("namespace", namespace))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_batch_v1.BatchV1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) CronJobs(namespace string) k8s_io_client_go_kubernetes_typed_batch_v1.CronJobInterface {
+	inner := c.inner.CronJobs(namespace)
+	level, ok := c.audit.For("CronJob")
+	if !ok {
+		return inner
+	}
+	return cronjobs.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "CronJobs").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) Jobs(namespace string) k8s_io_client_go_kubernetes_typed_batch_v1.JobInterface {
+	inner := c.inner.Jobs(namespace)
+	level, ok := c.audit.For("Job")
+	if !ok {
+		return inner
+	}
+	return jobs.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "Jobs").WithValues("namespace", namespace))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_batch_v1.BatchV1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) CronJobs(namespace string) k8s_io_client_go_kubernetes_typed_batch_v1.CronJobInterface {
+	return cronjobs.WithRateLimiting(c.inner.CronJobs(namespace), c.limits.For("CronJob"))
+}
+func (c *withRateLimiting) Jobs(namespace string) k8s_io_client_go_kubernetes_typed_batch_v1.JobInterface {
+	return jobs.WithRateLimiting(c.inner.Jobs(namespace), c.limits.For("Job"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_batch_v1.BatchV1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) CronJobs(namespace string) k8s_io_client_go_kubernetes_typed_batch_v1.CronJobInterface {
+	return cronjobs.WithRetry(c.inner.CronJobs(namespace), c.retries.For("CronJob"))
+}
+func (c *withRetry) Jobs(namespace string) k8s_io_client_go_kubernetes_typed_batch_v1.JobInterface {
+	return jobs.WithRetry(c.inner.Jobs(namespace), c.retries.For("Job"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_batch_v1.BatchV1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) CronJobs(namespace string) k8s_io_client_go_kubernetes_typed_batch_v1.CronJobInterface {
+	return cronjobs.WithCircuitBreaker(c.inner.CronJobs(namespace), c.breakers.For("CronJob"))
+}
+func (c *withCircuitBreaker) Jobs(namespace string) k8s_io_client_go_kubernetes_typed_batch_v1.JobInterface {
+	return jobs.WithCircuitBreaker(c.inner.Jobs(namespace), c.breakers.For("Job"))
+}
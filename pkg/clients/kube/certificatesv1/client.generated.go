@@ -3,6 +3,7 @@ package client
 import (
 	"github.com/go-logr/logr"
 	certificatesigningrequests "github.com/kyverno/kyverno/pkg/clients/kube/certificatesv1/certificatesigningrequests"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_certificates_v1 "k8s.io/client-go/kubernetes/typed/certificates/v1"
 	"k8s.io/client-go/rest"
@@ -20,6 +21,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_certificates_v1.Certifi
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_certificates_v1.CertificatesV1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_certificates_v1.CertificatesV1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_certificates_v1.CertificatesV1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_certificates_v1.CertificatesV1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_certificates_v1.CertificatesV1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_certificates_v1.CertificatesV1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_certificates_v1.CertificatesV1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_certificates_v1.CertificatesV1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_certificates_v1.CertificatesV1Interface
 	metrics    metrics.MetricsConfigManager
@@ -57,3 +74,57 @@ func (c *withLogging) RESTClient() rest.Interface {
 func (c *withLogging) CertificateSigningRequests() k8s_io_client_go_kubernetes_typed_certificates_v1.CertificateSigningRequestInterface {
 	return certificatesigningrequests.WithLogging(c.inner.CertificateSigningRequests(), c.logger.WithValues("resource", "CertificateSigningRequests"))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_certificates_v1.CertificatesV1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) CertificateSigningRequests() k8s_io_client_go_kubernetes_typed_certificates_v1.CertificateSigningRequestInterface {
+	inner := c.inner.CertificateSigningRequests()
+	level, ok := c.audit.For("CertificateSigningRequest")
+	if !ok {
+		return inner
+	}
+	return certificatesigningrequests.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "CertificateSigningRequests"))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_certificates_v1.CertificatesV1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) CertificateSigningRequests() k8s_io_client_go_kubernetes_typed_certificates_v1.CertificateSigningRequestInterface {
+	return certificatesigningrequests.WithRateLimiting(c.inner.CertificateSigningRequests(), c.limits.For("CertificateSigningRequest"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_certificates_v1.CertificatesV1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) CertificateSigningRequests() k8s_io_client_go_kubernetes_typed_certificates_v1.CertificateSigningRequestInterface {
+	return certificatesigningrequests.WithRetry(c.inner.CertificateSigningRequests(), c.retries.For("CertificateSigningRequest"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_certificates_v1.CertificatesV1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) CertificateSigningRequests() k8s_io_client_go_kubernetes_typed_certificates_v1.CertificateSigningRequestInterface {
+	return certificatesigningrequests.WithCircuitBreaker(c.inner.CertificateSigningRequests(), c.breakers.For("CertificateSigningRequest"))
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"github.com/go-logr/logr"
 	clustertrustbundles "github.com/kyverno/kyverno/pkg/clients/kube/certificatesv1alpha1/clustertrustbundles"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_certificates_v1alpha1 "k8s.io/client-go/kubernetes/typed/certificates/v1alpha1"
 	"k8s.io/client-go/rest"
@@ -20,6 +21,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_certificates_v1alpha1.C
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_certificates_v1alpha1.CertificatesV1alpha1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_certificates_v1alpha1.CertificatesV1alpha1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_certificates_v1alpha1.CertificatesV1alpha1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_certificates_v1alpha1.CertificatesV1alpha1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_certificates_v1alpha1.CertificatesV1alpha1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_certificates_v1alpha1.CertificatesV1alpha1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_certificates_v1alpha1.CertificatesV1alpha1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_certificates_v1alpha1.CertificatesV1alpha1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_certificates_v1alpha1.CertificatesV1alpha1Interface
 	metrics    metrics.MetricsConfigManager
@@ -57,3 +74,57 @@ func (c *withLogging) RESTClient() rest.Interface {
 func (c *withLogging) ClusterTrustBundles() k8s_io_client_go_kubernetes_typed_certificates_v1alpha1.ClusterTrustBundleInterface {
 	return clustertrustbundles.WithLogging(c.inner.ClusterTrustBundles(), c.logger.WithValues("resource", "ClusterTrustBundles"))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_certificates_v1alpha1.CertificatesV1alpha1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) ClusterTrustBundles() k8s_io_client_go_kubernetes_typed_certificates_v1alpha1.ClusterTrustBundleInterface {
+	inner := c.inner.ClusterTrustBundles()
+	level, ok := c.audit.For("ClusterTrustBundle")
+	if !ok {
+		return inner
+	}
+	return clustertrustbundles.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ClusterTrustBundles"))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_certificates_v1alpha1.CertificatesV1alpha1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) ClusterTrustBundles() k8s_io_client_go_kubernetes_typed_certificates_v1alpha1.ClusterTrustBundleInterface {
+	return clustertrustbundles.WithRateLimiting(c.inner.ClusterTrustBundles(), c.limits.For("ClusterTrustBundle"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_certificates_v1alpha1.CertificatesV1alpha1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) ClusterTrustBundles() k8s_io_client_go_kubernetes_typed_certificates_v1alpha1.ClusterTrustBundleInterface {
+	return clustertrustbundles.WithRetry(c.inner.ClusterTrustBundles(), c.retries.For("ClusterTrustBundle"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_certificates_v1alpha1.CertificatesV1alpha1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) ClusterTrustBundles() k8s_io_client_go_kubernetes_typed_certificates_v1alpha1.ClusterTrustBundleInterface {
+	return clustertrustbundles.WithCircuitBreaker(c.inner.ClusterTrustBundles(), c.breakers.For("ClusterTrustBundle"))
+}
@@ -54,6 +54,7 @@ import (
 	storagev1 "github.com/kyverno/kyverno/pkg/clients/kube/storagev1"
 	storagev1alpha1 "github.com/kyverno/kyverno/pkg/clients/kube/storagev1alpha1"
 	storagev1beta1 "github.com/kyverno/kyverno/pkg/clients/kube/storagev1beta1"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_discovery "k8s.io/client-go/discovery"
 	k8s_io_client_go_kubernetes "k8s.io/client-go/kubernetes"
@@ -492,3 +493,238 @@ func WrapWithLogging(inner k8s_io_client_go_kubernetes.Interface, logger logr.Lo
 		storagev1beta1:                storagev1beta1.WithLogging(inner.StorageV1beta1(), logger.WithValues("group", "StorageV1beta1")),
 	}
 }
+func WrapWithAuditLogging(inner k8s_io_client_go_kubernetes.Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes.Interface {
+	return &clientset{
+		discovery:                     auditLoggingDiscovery(inner.Discovery(), logger.WithValues("group", "Discovery"), audit),
+		admissionregistrationv1:       admissionregistrationv1.WithAuditLogging(inner.AdmissionregistrationV1(), logger.WithValues("group", "AdmissionregistrationV1"), audit),
+		admissionregistrationv1alpha1: admissionregistrationv1alpha1.WithAuditLogging(inner.AdmissionregistrationV1alpha1(), logger.WithValues("group", "AdmissionregistrationV1alpha1"), audit),
+		admissionregistrationv1beta1:  admissionregistrationv1beta1.WithAuditLogging(inner.AdmissionregistrationV1beta1(), logger.WithValues("group", "AdmissionregistrationV1beta1"), audit),
+		appsv1:                        appsv1.WithAuditLogging(inner.AppsV1(), logger.WithValues("group", "AppsV1"), audit),
+		appsv1beta1:                   appsv1beta1.WithAuditLogging(inner.AppsV1beta1(), logger.WithValues("group", "AppsV1beta1"), audit),
+		appsv1beta2:                   appsv1beta2.WithAuditLogging(inner.AppsV1beta2(), logger.WithValues("group", "AppsV1beta2"), audit),
+		authenticationv1:              authenticationv1.WithAuditLogging(inner.AuthenticationV1(), logger.WithValues("group", "AuthenticationV1"), audit),
+		authenticationv1alpha1:        authenticationv1alpha1.WithAuditLogging(inner.AuthenticationV1alpha1(), logger.WithValues("group", "AuthenticationV1alpha1"), audit),
+		authenticationv1beta1:         authenticationv1beta1.WithAuditLogging(inner.AuthenticationV1beta1(), logger.WithValues("group", "AuthenticationV1beta1"), audit),
+		authorizationv1:               authorizationv1.WithAuditLogging(inner.AuthorizationV1(), logger.WithValues("group", "AuthorizationV1"), audit),
+		authorizationv1beta1:          authorizationv1beta1.WithAuditLogging(inner.AuthorizationV1beta1(), logger.WithValues("group", "AuthorizationV1beta1"), audit),
+		autoscalingv1:                 autoscalingv1.WithAuditLogging(inner.AutoscalingV1(), logger.WithValues("group", "AutoscalingV1"), audit),
+		autoscalingv2:                 autoscalingv2.WithAuditLogging(inner.AutoscalingV2(), logger.WithValues("group", "AutoscalingV2"), audit),
+		autoscalingv2beta1:            autoscalingv2beta1.WithAuditLogging(inner.AutoscalingV2beta1(), logger.WithValues("group", "AutoscalingV2beta1"), audit),
+		autoscalingv2beta2:            autoscalingv2beta2.WithAuditLogging(inner.AutoscalingV2beta2(), logger.WithValues("group", "AutoscalingV2beta2"), audit),
+		batchv1:                       batchv1.WithAuditLogging(inner.BatchV1(), logger.WithValues("group", "BatchV1"), audit),
+		batchv1beta1:                  batchv1beta1.WithAuditLogging(inner.BatchV1beta1(), logger.WithValues("group", "BatchV1beta1"), audit),
+		certificatesv1:                certificatesv1.WithAuditLogging(inner.CertificatesV1(), logger.WithValues("group", "CertificatesV1"), audit),
+		certificatesv1alpha1:          certificatesv1alpha1.WithAuditLogging(inner.CertificatesV1alpha1(), logger.WithValues("group", "CertificatesV1alpha1"), audit),
+		certificatesv1beta1:           certificatesv1beta1.WithAuditLogging(inner.CertificatesV1beta1(), logger.WithValues("group", "CertificatesV1beta1"), audit),
+		coordinationv1:                coordinationv1.WithAuditLogging(inner.CoordinationV1(), logger.WithValues("group", "CoordinationV1"), audit),
+		coordinationv1beta1:           coordinationv1beta1.WithAuditLogging(inner.CoordinationV1beta1(), logger.WithValues("group", "CoordinationV1beta1"), audit),
+		corev1:                        corev1.WithAuditLogging(inner.CoreV1(), logger.WithValues("group", "CoreV1"), audit),
+		discoveryv1:                   discoveryv1.WithAuditLogging(inner.DiscoveryV1(), logger.WithValues("group", "DiscoveryV1"), audit),
+		discoveryv1beta1:              discoveryv1beta1.WithAuditLogging(inner.DiscoveryV1beta1(), logger.WithValues("group", "DiscoveryV1beta1"), audit),
+		eventsv1:                      eventsv1.WithAuditLogging(inner.EventsV1(), logger.WithValues("group", "EventsV1"), audit),
+		eventsv1beta1:                 eventsv1beta1.WithAuditLogging(inner.EventsV1beta1(), logger.WithValues("group", "EventsV1beta1"), audit),
+		extensionsv1beta1:             extensionsv1beta1.WithAuditLogging(inner.ExtensionsV1beta1(), logger.WithValues("group", "ExtensionsV1beta1"), audit),
+		flowcontrolv1:                 flowcontrolv1.WithAuditLogging(inner.FlowcontrolV1(), logger.WithValues("group", "FlowcontrolV1"), audit),
+		flowcontrolv1beta1:            flowcontrolv1beta1.WithAuditLogging(inner.FlowcontrolV1beta1(), logger.WithValues("group", "FlowcontrolV1beta1"), audit),
+		flowcontrolv1beta2:            flowcontrolv1beta2.WithAuditLogging(inner.FlowcontrolV1beta2(), logger.WithValues("group", "FlowcontrolV1beta2"), audit),
+		flowcontrolv1beta3:            flowcontrolv1beta3.WithAuditLogging(inner.FlowcontrolV1beta3(), logger.WithValues("group", "FlowcontrolV1beta3"), audit),
+		internalv1alpha1:              internalv1alpha1.WithAuditLogging(inner.InternalV1alpha1(), logger.WithValues("group", "InternalV1alpha1"), audit),
+		networkingv1:                  networkingv1.WithAuditLogging(inner.NetworkingV1(), logger.WithValues("group", "NetworkingV1"), audit),
+		networkingv1alpha1:            networkingv1alpha1.WithAuditLogging(inner.NetworkingV1alpha1(), logger.WithValues("group", "NetworkingV1alpha1"), audit),
+		networkingv1beta1:             networkingv1beta1.WithAuditLogging(inner.NetworkingV1beta1(), logger.WithValues("group", "NetworkingV1beta1"), audit),
+		nodev1:                        nodev1.WithAuditLogging(inner.NodeV1(), logger.WithValues("group", "NodeV1"), audit),
+		nodev1alpha1:                  nodev1alpha1.WithAuditLogging(inner.NodeV1alpha1(), logger.WithValues("group", "NodeV1alpha1"), audit),
+		nodev1beta1:                   nodev1beta1.WithAuditLogging(inner.NodeV1beta1(), logger.WithValues("group", "NodeV1beta1"), audit),
+		policyv1:                      policyv1.WithAuditLogging(inner.PolicyV1(), logger.WithValues("group", "PolicyV1"), audit),
+		policyv1beta1:                 policyv1beta1.WithAuditLogging(inner.PolicyV1beta1(), logger.WithValues("group", "PolicyV1beta1"), audit),
+		rbacv1:                        rbacv1.WithAuditLogging(inner.RbacV1(), logger.WithValues("group", "RbacV1"), audit),
+		rbacv1alpha1:                  rbacv1alpha1.WithAuditLogging(inner.RbacV1alpha1(), logger.WithValues("group", "RbacV1alpha1"), audit),
+		rbacv1beta1:                   rbacv1beta1.WithAuditLogging(inner.RbacV1beta1(), logger.WithValues("group", "RbacV1beta1"), audit),
+		resourcev1alpha2:              resourcev1alpha2.WithAuditLogging(inner.ResourceV1alpha2(), logger.WithValues("group", "ResourceV1alpha2"), audit),
+		schedulingv1:                  schedulingv1.WithAuditLogging(inner.SchedulingV1(), logger.WithValues("group", "SchedulingV1"), audit),
+		schedulingv1alpha1:            schedulingv1alpha1.WithAuditLogging(inner.SchedulingV1alpha1(), logger.WithValues("group", "SchedulingV1alpha1"), audit),
+		schedulingv1beta1:             schedulingv1beta1.WithAuditLogging(inner.SchedulingV1beta1(), logger.WithValues("group", "SchedulingV1beta1"), audit),
+		storagev1:                     storagev1.WithAuditLogging(inner.StorageV1(), logger.WithValues("group", "StorageV1"), audit),
+		storagev1alpha1:               storagev1alpha1.WithAuditLogging(inner.StorageV1alpha1(), logger.WithValues("group", "StorageV1alpha1"), audit),
+		storagev1beta1:                storagev1beta1.WithAuditLogging(inner.StorageV1beta1(), logger.WithValues("group", "StorageV1beta1"), audit),
+	}
+}
+
+func auditLoggingDiscovery(inner k8s_io_client_go_discovery.DiscoveryInterface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_discovery.DiscoveryInterface {
+	level, ok := audit.For("Discovery")
+	if !ok {
+		return inner
+	}
+	return discovery.WithAuditLogging(inner, logger.V(level))
+}
+
+func WrapWithRateLimiting(inner k8s_io_client_go_kubernetes.Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes.Interface {
+	return &clientset{
+		discovery:                     discovery.WithRateLimiting(inner.Discovery(), limits.For("Discovery")),
+		admissionregistrationv1:       admissionregistrationv1.WithRateLimiting(inner.AdmissionregistrationV1(), limits),
+		admissionregistrationv1alpha1: admissionregistrationv1alpha1.WithRateLimiting(inner.AdmissionregistrationV1alpha1(), limits),
+		admissionregistrationv1beta1:  admissionregistrationv1beta1.WithRateLimiting(inner.AdmissionregistrationV1beta1(), limits),
+		appsv1:                        appsv1.WithRateLimiting(inner.AppsV1(), limits),
+		appsv1beta1:                   appsv1beta1.WithRateLimiting(inner.AppsV1beta1(), limits),
+		appsv1beta2:                   appsv1beta2.WithRateLimiting(inner.AppsV1beta2(), limits),
+		authenticationv1:              authenticationv1.WithRateLimiting(inner.AuthenticationV1(), limits),
+		authenticationv1alpha1:        authenticationv1alpha1.WithRateLimiting(inner.AuthenticationV1alpha1(), limits),
+		authenticationv1beta1:         authenticationv1beta1.WithRateLimiting(inner.AuthenticationV1beta1(), limits),
+		authorizationv1:               authorizationv1.WithRateLimiting(inner.AuthorizationV1(), limits),
+		authorizationv1beta1:          authorizationv1beta1.WithRateLimiting(inner.AuthorizationV1beta1(), limits),
+		autoscalingv1:                 autoscalingv1.WithRateLimiting(inner.AutoscalingV1(), limits),
+		autoscalingv2:                 autoscalingv2.WithRateLimiting(inner.AutoscalingV2(), limits),
+		autoscalingv2beta1:            autoscalingv2beta1.WithRateLimiting(inner.AutoscalingV2beta1(), limits),
+		autoscalingv2beta2:            autoscalingv2beta2.WithRateLimiting(inner.AutoscalingV2beta2(), limits),
+		batchv1:                       batchv1.WithRateLimiting(inner.BatchV1(), limits),
+		batchv1beta1:                  batchv1beta1.WithRateLimiting(inner.BatchV1beta1(), limits),
+		certificatesv1:                certificatesv1.WithRateLimiting(inner.CertificatesV1(), limits),
+		certificatesv1alpha1:          certificatesv1alpha1.WithRateLimiting(inner.CertificatesV1alpha1(), limits),
+		certificatesv1beta1:           certificatesv1beta1.WithRateLimiting(inner.CertificatesV1beta1(), limits),
+		coordinationv1:                coordinationv1.WithRateLimiting(inner.CoordinationV1(), limits),
+		coordinationv1beta1:           coordinationv1beta1.WithRateLimiting(inner.CoordinationV1beta1(), limits),
+		corev1:                        corev1.WithRateLimiting(inner.CoreV1(), limits),
+		discoveryv1:                   discoveryv1.WithRateLimiting(inner.DiscoveryV1(), limits),
+		discoveryv1beta1:              discoveryv1beta1.WithRateLimiting(inner.DiscoveryV1beta1(), limits),
+		eventsv1:                      eventsv1.WithRateLimiting(inner.EventsV1(), limits),
+		eventsv1beta1:                 eventsv1beta1.WithRateLimiting(inner.EventsV1beta1(), limits),
+		extensionsv1beta1:             extensionsv1beta1.WithRateLimiting(inner.ExtensionsV1beta1(), limits),
+		flowcontrolv1:                 flowcontrolv1.WithRateLimiting(inner.FlowcontrolV1(), limits),
+		flowcontrolv1beta1:            flowcontrolv1beta1.WithRateLimiting(inner.FlowcontrolV1beta1(), limits),
+		flowcontrolv1beta2:            flowcontrolv1beta2.WithRateLimiting(inner.FlowcontrolV1beta2(), limits),
+		flowcontrolv1beta3:            flowcontrolv1beta3.WithRateLimiting(inner.FlowcontrolV1beta3(), limits),
+		internalv1alpha1:              internalv1alpha1.WithRateLimiting(inner.InternalV1alpha1(), limits),
+		networkingv1:                  networkingv1.WithRateLimiting(inner.NetworkingV1(), limits),
+		networkingv1alpha1:            networkingv1alpha1.WithRateLimiting(inner.NetworkingV1alpha1(), limits),
+		networkingv1beta1:             networkingv1beta1.WithRateLimiting(inner.NetworkingV1beta1(), limits),
+		nodev1:                        nodev1.WithRateLimiting(inner.NodeV1(), limits),
+		nodev1alpha1:                  nodev1alpha1.WithRateLimiting(inner.NodeV1alpha1(), limits),
+		nodev1beta1:                   nodev1beta1.WithRateLimiting(inner.NodeV1beta1(), limits),
+		policyv1:                      policyv1.WithRateLimiting(inner.PolicyV1(), limits),
+		policyv1beta1:                 policyv1beta1.WithRateLimiting(inner.PolicyV1beta1(), limits),
+		rbacv1:                        rbacv1.WithRateLimiting(inner.RbacV1(), limits),
+		rbacv1alpha1:                  rbacv1alpha1.WithRateLimiting(inner.RbacV1alpha1(), limits),
+		rbacv1beta1:                   rbacv1beta1.WithRateLimiting(inner.RbacV1beta1(), limits),
+		resourcev1alpha2:              resourcev1alpha2.WithRateLimiting(inner.ResourceV1alpha2(), limits),
+		schedulingv1:                  schedulingv1.WithRateLimiting(inner.SchedulingV1(), limits),
+		schedulingv1alpha1:            schedulingv1alpha1.WithRateLimiting(inner.SchedulingV1alpha1(), limits),
+		schedulingv1beta1:             schedulingv1beta1.WithRateLimiting(inner.SchedulingV1beta1(), limits),
+		storagev1:                     storagev1.WithRateLimiting(inner.StorageV1(), limits),
+		storagev1alpha1:               storagev1alpha1.WithRateLimiting(inner.StorageV1alpha1(), limits),
+		storagev1beta1:                storagev1beta1.WithRateLimiting(inner.StorageV1beta1(), limits),
+	}
+}
+
+func WrapWithRetry(inner k8s_io_client_go_kubernetes.Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes.Interface {
+	return &clientset{
+		discovery:                     discovery.WithRetry(inner.Discovery(), retries.For("Discovery")),
+		admissionregistrationv1:       admissionregistrationv1.WithRetry(inner.AdmissionregistrationV1(), retries),
+		admissionregistrationv1alpha1: admissionregistrationv1alpha1.WithRetry(inner.AdmissionregistrationV1alpha1(), retries),
+		admissionregistrationv1beta1:  admissionregistrationv1beta1.WithRetry(inner.AdmissionregistrationV1beta1(), retries),
+		appsv1:                        appsv1.WithRetry(inner.AppsV1(), retries),
+		appsv1beta1:                   appsv1beta1.WithRetry(inner.AppsV1beta1(), retries),
+		appsv1beta2:                   appsv1beta2.WithRetry(inner.AppsV1beta2(), retries),
+		authenticationv1:              authenticationv1.WithRetry(inner.AuthenticationV1(), retries),
+		authenticationv1alpha1:        authenticationv1alpha1.WithRetry(inner.AuthenticationV1alpha1(), retries),
+		authenticationv1beta1:         authenticationv1beta1.WithRetry(inner.AuthenticationV1beta1(), retries),
+		authorizationv1:               authorizationv1.WithRetry(inner.AuthorizationV1(), retries),
+		authorizationv1beta1:          authorizationv1beta1.WithRetry(inner.AuthorizationV1beta1(), retries),
+		autoscalingv1:                 autoscalingv1.WithRetry(inner.AutoscalingV1(), retries),
+		autoscalingv2:                 autoscalingv2.WithRetry(inner.AutoscalingV2(), retries),
+		autoscalingv2beta1:            autoscalingv2beta1.WithRetry(inner.AutoscalingV2beta1(), retries),
+		autoscalingv2beta2:            autoscalingv2beta2.WithRetry(inner.AutoscalingV2beta2(), retries),
+		batchv1:                       batchv1.WithRetry(inner.BatchV1(), retries),
+		batchv1beta1:                  batchv1beta1.WithRetry(inner.BatchV1beta1(), retries),
+		certificatesv1:                certificatesv1.WithRetry(inner.CertificatesV1(), retries),
+		certificatesv1alpha1:          certificatesv1alpha1.WithRetry(inner.CertificatesV1alpha1(), retries),
+		certificatesv1beta1:           certificatesv1beta1.WithRetry(inner.CertificatesV1beta1(), retries),
+		coordinationv1:                coordinationv1.WithRetry(inner.CoordinationV1(), retries),
+		coordinationv1beta1:           coordinationv1beta1.WithRetry(inner.CoordinationV1beta1(), retries),
+		corev1:                        corev1.WithRetry(inner.CoreV1(), retries),
+		discoveryv1:                   discoveryv1.WithRetry(inner.DiscoveryV1(), retries),
+		discoveryv1beta1:              discoveryv1beta1.WithRetry(inner.DiscoveryV1beta1(), retries),
+		eventsv1:                      eventsv1.WithRetry(inner.EventsV1(), retries),
+		eventsv1beta1:                 eventsv1beta1.WithRetry(inner.EventsV1beta1(), retries),
+		extensionsv1beta1:             extensionsv1beta1.WithRetry(inner.ExtensionsV1beta1(), retries),
+		flowcontrolv1:                 flowcontrolv1.WithRetry(inner.FlowcontrolV1(), retries),
+		flowcontrolv1beta1:            flowcontrolv1beta1.WithRetry(inner.FlowcontrolV1beta1(), retries),
+		flowcontrolv1beta2:            flowcontrolv1beta2.WithRetry(inner.FlowcontrolV1beta2(), retries),
+		flowcontrolv1beta3:            flowcontrolv1beta3.WithRetry(inner.FlowcontrolV1beta3(), retries),
+		internalv1alpha1:              internalv1alpha1.WithRetry(inner.InternalV1alpha1(), retries),
+		networkingv1:                  networkingv1.WithRetry(inner.NetworkingV1(), retries),
+		networkingv1alpha1:            networkingv1alpha1.WithRetry(inner.NetworkingV1alpha1(), retries),
+		networkingv1beta1:             networkingv1beta1.WithRetry(inner.NetworkingV1beta1(), retries),
+		nodev1:                        nodev1.WithRetry(inner.NodeV1(), retries),
+		nodev1alpha1:                  nodev1alpha1.WithRetry(inner.NodeV1alpha1(), retries),
+		nodev1beta1:                   nodev1beta1.WithRetry(inner.NodeV1beta1(), retries),
+		policyv1:                      policyv1.WithRetry(inner.PolicyV1(), retries),
+		policyv1beta1:                 policyv1beta1.WithRetry(inner.PolicyV1beta1(), retries),
+		rbacv1:                        rbacv1.WithRetry(inner.RbacV1(), retries),
+		rbacv1alpha1:                  rbacv1alpha1.WithRetry(inner.RbacV1alpha1(), retries),
+		rbacv1beta1:                   rbacv1beta1.WithRetry(inner.RbacV1beta1(), retries),
+		resourcev1alpha2:              resourcev1alpha2.WithRetry(inner.ResourceV1alpha2(), retries),
+		schedulingv1:                  schedulingv1.WithRetry(inner.SchedulingV1(), retries),
+		schedulingv1alpha1:            schedulingv1alpha1.WithRetry(inner.SchedulingV1alpha1(), retries),
+		schedulingv1beta1:             schedulingv1beta1.WithRetry(inner.SchedulingV1beta1(), retries),
+		storagev1:                     storagev1.WithRetry(inner.StorageV1(), retries),
+		storagev1alpha1:               storagev1alpha1.WithRetry(inner.StorageV1alpha1(), retries),
+		storagev1beta1:                storagev1beta1.WithRetry(inner.StorageV1beta1(), retries),
+	}
+}
+
+func WrapWithCircuitBreaker(inner k8s_io_client_go_kubernetes.Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes.Interface {
+	return &clientset{
+		discovery:                     discovery.WithCircuitBreaker(inner.Discovery(), breakers.For("Discovery")),
+		admissionregistrationv1:       admissionregistrationv1.WithCircuitBreaker(inner.AdmissionregistrationV1(), breakers),
+		admissionregistrationv1alpha1: admissionregistrationv1alpha1.WithCircuitBreaker(inner.AdmissionregistrationV1alpha1(), breakers),
+		admissionregistrationv1beta1:  admissionregistrationv1beta1.WithCircuitBreaker(inner.AdmissionregistrationV1beta1(), breakers),
+		appsv1:                        appsv1.WithCircuitBreaker(inner.AppsV1(), breakers),
+		appsv1beta1:                   appsv1beta1.WithCircuitBreaker(inner.AppsV1beta1(), breakers),
+		appsv1beta2:                   appsv1beta2.WithCircuitBreaker(inner.AppsV1beta2(), breakers),
+		authenticationv1:              authenticationv1.WithCircuitBreaker(inner.AuthenticationV1(), breakers),
+		authenticationv1alpha1:        authenticationv1alpha1.WithCircuitBreaker(inner.AuthenticationV1alpha1(), breakers),
+		authenticationv1beta1:         authenticationv1beta1.WithCircuitBreaker(inner.AuthenticationV1beta1(), breakers),
+		authorizationv1:               authorizationv1.WithCircuitBreaker(inner.AuthorizationV1(), breakers),
+		authorizationv1beta1:          authorizationv1beta1.WithCircuitBreaker(inner.AuthorizationV1beta1(), breakers),
+		autoscalingv1:                 autoscalingv1.WithCircuitBreaker(inner.AutoscalingV1(), breakers),
+		autoscalingv2:                 autoscalingv2.WithCircuitBreaker(inner.AutoscalingV2(), breakers),
+		autoscalingv2beta1:            autoscalingv2beta1.WithCircuitBreaker(inner.AutoscalingV2beta1(), breakers),
+		autoscalingv2beta2:            autoscalingv2beta2.WithCircuitBreaker(inner.AutoscalingV2beta2(), breakers),
+		batchv1:                       batchv1.WithCircuitBreaker(inner.BatchV1(), breakers),
+		batchv1beta1:                  batchv1beta1.WithCircuitBreaker(inner.BatchV1beta1(), breakers),
+		certificatesv1:                certificatesv1.WithCircuitBreaker(inner.CertificatesV1(), breakers),
+		certificatesv1alpha1:          certificatesv1alpha1.WithCircuitBreaker(inner.CertificatesV1alpha1(), breakers),
+		certificatesv1beta1:           certificatesv1beta1.WithCircuitBreaker(inner.CertificatesV1beta1(), breakers),
+		coordinationv1:                coordinationv1.WithCircuitBreaker(inner.CoordinationV1(), breakers),
+		coordinationv1beta1:           coordinationv1beta1.WithCircuitBreaker(inner.CoordinationV1beta1(), breakers),
+		corev1:                        corev1.WithCircuitBreaker(inner.CoreV1(), breakers),
+		discoveryv1:                   discoveryv1.WithCircuitBreaker(inner.DiscoveryV1(), breakers),
+		discoveryv1beta1:              discoveryv1beta1.WithCircuitBreaker(inner.DiscoveryV1beta1(), breakers),
+		eventsv1:                      eventsv1.WithCircuitBreaker(inner.EventsV1(), breakers),
+		eventsv1beta1:                 eventsv1beta1.WithCircuitBreaker(inner.EventsV1beta1(), breakers),
+		extensionsv1beta1:             extensionsv1beta1.WithCircuitBreaker(inner.ExtensionsV1beta1(), breakers),
+		flowcontrolv1:                 flowcontrolv1.WithCircuitBreaker(inner.FlowcontrolV1(), breakers),
+		flowcontrolv1beta1:            flowcontrolv1beta1.WithCircuitBreaker(inner.FlowcontrolV1beta1(), breakers),
+		flowcontrolv1beta2:            flowcontrolv1beta2.WithCircuitBreaker(inner.FlowcontrolV1beta2(), breakers),
+		flowcontrolv1beta3:            flowcontrolv1beta3.WithCircuitBreaker(inner.FlowcontrolV1beta3(), breakers),
+		internalv1alpha1:              internalv1alpha1.WithCircuitBreaker(inner.InternalV1alpha1(), breakers),
+		networkingv1:                  networkingv1.WithCircuitBreaker(inner.NetworkingV1(), breakers),
+		networkingv1alpha1:            networkingv1alpha1.WithCircuitBreaker(inner.NetworkingV1alpha1(), breakers),
+		networkingv1beta1:             networkingv1beta1.WithCircuitBreaker(inner.NetworkingV1beta1(), breakers),
+		nodev1:                        nodev1.WithCircuitBreaker(inner.NodeV1(), breakers),
+		nodev1alpha1:                  nodev1alpha1.WithCircuitBreaker(inner.NodeV1alpha1(), breakers),
+		nodev1beta1:                   nodev1beta1.WithCircuitBreaker(inner.NodeV1beta1(), breakers),
+		policyv1:                      policyv1.WithCircuitBreaker(inner.PolicyV1(), breakers),
+		policyv1beta1:                 policyv1beta1.WithCircuitBreaker(inner.PolicyV1beta1(), breakers),
+		rbacv1:                        rbacv1.WithCircuitBreaker(inner.RbacV1(), breakers),
+		rbacv1alpha1:                  rbacv1alpha1.WithCircuitBreaker(inner.RbacV1alpha1(), breakers),
+		rbacv1beta1:                   rbacv1beta1.WithCircuitBreaker(inner.RbacV1beta1(), breakers),
+		resourcev1alpha2:              resourcev1alpha2.WithCircuitBreaker(inner.ResourceV1alpha2(), breakers),
+		schedulingv1:                  schedulingv1.WithCircuitBreaker(inner.SchedulingV1(), breakers),
+		schedulingv1alpha1:            schedulingv1alpha1.WithCircuitBreaker(inner.SchedulingV1alpha1(), breakers),
+		schedulingv1beta1:             schedulingv1beta1.WithCircuitBreaker(inner.SchedulingV1beta1(), breakers),
+		storagev1:                     storagev1.WithCircuitBreaker(inner.StorageV1(), breakers),
+		storagev1alpha1:               storagev1alpha1.WithCircuitBreaker(inner.StorageV1alpha1(), breakers),
+		storagev1beta1:                storagev1beta1.WithCircuitBreaker(inner.StorageV1beta1(), breakers),
+	}
+}
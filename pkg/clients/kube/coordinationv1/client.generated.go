@@ -3,6 +3,7 @@ package client
 import (
 	"github.com/go-logr/logr"
 	leases "github.com/kyverno/kyverno/pkg/clients/kube/coordinationv1/leases"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_coordination_v1 "k8s.io/client-go/kubernetes/typed/coordination/v1"
 	"k8s.io/client-go/rest"
@@ -20,6 +21,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_coordination_v1.Coordin
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_coordination_v1.CoordinationV1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_coordination_v1.CoordinationV1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_coordination_v1.CoordinationV1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_coordination_v1.CoordinationV1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_coordination_v1.CoordinationV1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_coordination_v1.CoordinationV1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_coordination_v1.CoordinationV1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_coordination_v1.CoordinationV1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_coordination_v1.CoordinationV1Interface
 	metrics    metrics.MetricsConfigManager
@@ -57,3 +74,57 @@ func (c *withLogging) RESTClient() rest.Interface {
 func (c *withLogging) Leases(namespace string) k8s_io_client_go_kubernetes_typed_coordination_v1.LeaseInterface {
 	return leases.WithLogging(c.inner.Leases(namespace), c.logger.WithValues("resource", "Leases").WithValues("namespace", namespace))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_coordination_v1.CoordinationV1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) Leases(namespace string) k8s_io_client_go_kubernetes_typed_coordination_v1.LeaseInterface {
+	inner := c.inner.Leases(namespace)
+	level, ok := c.audit.For("Lease")
+	if !ok {
+		return inner
+	}
+	return leases.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "Leases").WithValues("namespace", namespace))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_coordination_v1.CoordinationV1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) Leases(namespace string) k8s_io_client_go_kubernetes_typed_coordination_v1.LeaseInterface {
+	return leases.WithRateLimiting(c.inner.Leases(namespace), c.limits.For("Lease"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_coordination_v1.CoordinationV1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) Leases(namespace string) k8s_io_client_go_kubernetes_typed_coordination_v1.LeaseInterface {
+	return leases.WithRetry(c.inner.Leases(namespace), c.retries.For("Lease"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_coordination_v1.CoordinationV1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) Leases(namespace string) k8s_io_client_go_kubernetes_typed_coordination_v1.LeaseInterface {
+	return leases.WithCircuitBreaker(c.inner.Leases(namespace), c.breakers.For("Lease"))
+}
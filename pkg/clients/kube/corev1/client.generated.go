@@ -18,6 +18,7 @@ import (
 	secrets "github.com/kyverno/kyverno/pkg/clients/kube/corev1/secrets"
 	serviceaccounts "github.com/kyverno/kyverno/pkg/clients/kube/corev1/serviceaccounts"
 	services "github.com/kyverno/kyverno/pkg/clients/kube/corev1/services"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_core_v1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
@@ -35,6 +36,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_core_v1.CoreV1Interface
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_core_v1.CoreV1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_core_v1.CoreV1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_core_v1.CoreV1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_core_v1.CoreV1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_core_v1.CoreV1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_core_v1.CoreV1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_core_v1.CoreV1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_core_v1.CoreV1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_core_v1.CoreV1Interface
 	metrics    metrics.MetricsConfigManager
@@ -222,3 +239,312 @@ func (c *withLogging) ServiceAccounts(namespace string) k8s_io_client_go_kuberne
 func (c *withLogging) Services(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.ServiceInterface {
 	return services.WithLogging(c.inner.Services(namespace), c.logger.WithValues("resource", "Services").WithValues("namespace", namespace))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_core_v1.CoreV1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) ComponentStatuses() k8s_io_client_go_kubernetes_typed_core_v1.ComponentStatusInterface {
+	inner := c.inner.ComponentStatuses()
+	level, ok := c.audit.For("ComponentStatus")
+	if !ok {
+		return inner
+	}
+	return componentstatuses.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ComponentStatuses"))
+}
+func (c *withAuditLogging) ConfigMaps(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.ConfigMapInterface {
+	inner := c.inner.ConfigMaps(namespace)
+	level, ok := c.audit.For("ConfigMap")
+	if !ok {
+		return inner
+	}
+	return configmaps.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ConfigMaps").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) Endpoints(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.EndpointsInterface {
+	inner := c.inner.Endpoints(namespace)
+	level, ok := c.audit.For("Endpoints")
+	if !ok {
+		return inner
+	}
+	return endpoints.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "Endpoints").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) Events(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.EventInterface {
+	inner := c.inner.Events(namespace)
+	level, ok := c.audit.For("Event")
+	if !ok {
+		return inner
+	}
+	return events.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "Events").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) LimitRanges(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.LimitRangeInterface {
+	inner := c.inner.LimitRanges(namespace)
+	level, ok := c.audit.For("LimitRange")
+	if !ok {
+		return inner
+	}
+	return limitranges.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "LimitRanges").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) Namespaces() k8s_io_client_go_kubernetes_typed_core_v1.NamespaceInterface {
+	inner := c.inner.Namespaces()
+	level, ok := c.audit.For("Namespace")
+	if !ok {
+		return inner
+	}
+	return namespaces.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "Namespaces"))
+}
+func (c *withAuditLogging) Nodes() k8s_io_client_go_kubernetes_typed_core_v1.NodeInterface {
+	inner := c.inner.Nodes()
+	level, ok := c.audit.For("Node")
+	if !ok {
+		return inner
+	}
+	return nodes.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "Nodes"))
+}
+func (c *withAuditLogging) PersistentVolumeClaims(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.PersistentVolumeClaimInterface {
+	inner := c.inner.PersistentVolumeClaims(namespace)
+	level, ok := c.audit.For("PersistentVolumeClaim")
+	if !ok {
+		return inner
+	}
+	return persistentvolumeclaims.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "PersistentVolumeClaims").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) PersistentVolumes() k8s_io_client_go_kubernetes_typed_core_v1.PersistentVolumeInterface {
+	inner := c.inner.PersistentVolumes()
+	level, ok := c.audit.For("PersistentVolume")
+	if !ok {
+		return inner
+	}
+	return persistentvolumes.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "PersistentVolumes"))
+}
+func (c *withAuditLogging) PodTemplates(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.PodTemplateInterface {
+	inner := c.inner.PodTemplates(namespace)
+	level, ok := c.audit.For("PodTemplate")
+	if !ok {
+		return inner
+	}
+	return podtemplates.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "PodTemplates").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) Pods(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.PodInterface {
+	inner := c.inner.Pods(namespace)
+	level, ok := c.audit.For("Pod")
+	if !ok {
+		return inner
+	}
+	return pods.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "Pods").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) ReplicationControllers(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.ReplicationControllerInterface {
+	inner := c.inner.ReplicationControllers(namespace)
+	level, ok := c.audit.For("ReplicationController")
+	if !ok {
+		return inner
+	}
+	return replicationcontrollers.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ReplicationControllers").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) ResourceQuotas(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.ResourceQuotaInterface {
+	inner := c.inner.ResourceQuotas(namespace)
+	level, ok := c.audit.For("ResourceQuota")
+	if !ok {
+		return inner
+	}
+	return resourcequotas.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ResourceQuotas").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) Secrets(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.SecretInterface {
+	inner := c.inner.Secrets(namespace)
+	level, ok := c.audit.For("Secret")
+	if !ok {
+		return inner
+	}
+	return secrets.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "Secrets").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) ServiceAccounts(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.ServiceAccountInterface {
+	inner := c.inner.ServiceAccounts(namespace)
+	level, ok := c.audit.For("ServiceAccount")
+	if !ok {
+		return inner
+	}
+	return serviceaccounts.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ServiceAccounts").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) Services(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.ServiceInterface {
+	inner := c.inner.Services(namespace)
+	level, ok := c.audit.For("Service")
+	if !ok {
+		return inner
+	}
+	return services.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "Services").WithValues("namespace", namespace))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_core_v1.CoreV1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) ComponentStatuses() k8s_io_client_go_kubernetes_typed_core_v1.ComponentStatusInterface {
+	return componentstatuses.WithRateLimiting(c.inner.ComponentStatuses(), c.limits.For("ComponentStatus"))
+}
+func (c *withRateLimiting) ConfigMaps(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.ConfigMapInterface {
+	return configmaps.WithRateLimiting(c.inner.ConfigMaps(namespace), c.limits.For("ConfigMap"))
+}
+func (c *withRateLimiting) Endpoints(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.EndpointsInterface {
+	return endpoints.WithRateLimiting(c.inner.Endpoints(namespace), c.limits.For("Endpoints"))
+}
+func (c *withRateLimiting) Events(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.EventInterface {
+	return events.WithRateLimiting(c.inner.Events(namespace), c.limits.For("Event"))
+}
+func (c *withRateLimiting) LimitRanges(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.LimitRangeInterface {
+	return limitranges.WithRateLimiting(c.inner.LimitRanges(namespace), c.limits.For("LimitRange"))
+}
+func (c *withRateLimiting) Namespaces() k8s_io_client_go_kubernetes_typed_core_v1.NamespaceInterface {
+	return namespaces.WithRateLimiting(c.inner.Namespaces(), c.limits.For("Namespace"))
+}
+func (c *withRateLimiting) Nodes() k8s_io_client_go_kubernetes_typed_core_v1.NodeInterface {
+	return nodes.WithRateLimiting(c.inner.Nodes(), c.limits.For("Node"))
+}
+func (c *withRateLimiting) PersistentVolumeClaims(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.PersistentVolumeClaimInterface {
+	return persistentvolumeclaims.WithRateLimiting(c.inner.PersistentVolumeClaims(namespace), c.limits.For("PersistentVolumeClaim"))
+}
+func (c *withRateLimiting) PersistentVolumes() k8s_io_client_go_kubernetes_typed_core_v1.PersistentVolumeInterface {
+	return persistentvolumes.WithRateLimiting(c.inner.PersistentVolumes(), c.limits.For("PersistentVolume"))
+}
+func (c *withRateLimiting) PodTemplates(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.PodTemplateInterface {
+	return podtemplates.WithRateLimiting(c.inner.PodTemplates(namespace), c.limits.For("PodTemplate"))
+}
+func (c *withRateLimiting) Pods(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.PodInterface {
+	return pods.WithRateLimiting(c.inner.Pods(namespace), c.limits.For("Pod"))
+}
+func (c *withRateLimiting) ReplicationControllers(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.ReplicationControllerInterface {
+	return replicationcontrollers.WithRateLimiting(c.inner.ReplicationControllers(namespace), c.limits.For("ReplicationController"))
+}
+func (c *withRateLimiting) ResourceQuotas(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.ResourceQuotaInterface {
+	return resourcequotas.WithRateLimiting(c.inner.ResourceQuotas(namespace), c.limits.For("ResourceQuota"))
+}
+func (c *withRateLimiting) Secrets(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.SecretInterface {
+	return secrets.WithRateLimiting(c.inner.Secrets(namespace), c.limits.For("Secret"))
+}
+func (c *withRateLimiting) ServiceAccounts(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.ServiceAccountInterface {
+	return serviceaccounts.WithRateLimiting(c.inner.ServiceAccounts(namespace), c.limits.For("ServiceAccount"))
+}
+func (c *withRateLimiting) Services(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.ServiceInterface {
+	return services.WithRateLimiting(c.inner.Services(namespace), c.limits.For("Service"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_core_v1.CoreV1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) ComponentStatuses() k8s_io_client_go_kubernetes_typed_core_v1.ComponentStatusInterface {
+	return componentstatuses.WithRetry(c.inner.ComponentStatuses(), c.retries.For("ComponentStatus"))
+}
+func (c *withRetry) ConfigMaps(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.ConfigMapInterface {
+	return configmaps.WithRetry(c.inner.ConfigMaps(namespace), c.retries.For("ConfigMap"))
+}
+func (c *withRetry) Endpoints(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.EndpointsInterface {
+	return endpoints.WithRetry(c.inner.Endpoints(namespace), c.retries.For("Endpoints"))
+}
+func (c *withRetry) Events(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.EventInterface {
+	return events.WithRetry(c.inner.Events(namespace), c.retries.For("Event"))
+}
+func (c *withRetry) LimitRanges(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.LimitRangeInterface {
+	return limitranges.WithRetry(c.inner.LimitRanges(namespace), c.retries.For("LimitRange"))
+}
+func (c *withRetry) Namespaces() k8s_io_client_go_kubernetes_typed_core_v1.NamespaceInterface {
+	return namespaces.WithRetry(c.inner.Namespaces(), c.retries.For("Namespace"))
+}
+func (c *withRetry) Nodes() k8s_io_client_go_kubernetes_typed_core_v1.NodeInterface {
+	return nodes.WithRetry(c.inner.Nodes(), c.retries.For("Node"))
+}
+func (c *withRetry) PersistentVolumeClaims(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.PersistentVolumeClaimInterface {
+	return persistentvolumeclaims.WithRetry(c.inner.PersistentVolumeClaims(namespace), c.retries.For("PersistentVolumeClaim"))
+}
+func (c *withRetry) PersistentVolumes() k8s_io_client_go_kubernetes_typed_core_v1.PersistentVolumeInterface {
+	return persistentvolumes.WithRetry(c.inner.PersistentVolumes(), c.retries.For("PersistentVolume"))
+}
+func (c *withRetry) PodTemplates(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.PodTemplateInterface {
+	return podtemplates.WithRetry(c.inner.PodTemplates(namespace), c.retries.For("PodTemplate"))
+}
+func (c *withRetry) Pods(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.PodInterface {
+	return pods.WithRetry(c.inner.Pods(namespace), c.retries.For("Pod"))
+}
+func (c *withRetry) ReplicationControllers(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.ReplicationControllerInterface {
+	return replicationcontrollers.WithRetry(c.inner.ReplicationControllers(namespace), c.retries.For("ReplicationController"))
+}
+func (c *withRetry) ResourceQuotas(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.ResourceQuotaInterface {
+	return resourcequotas.WithRetry(c.inner.ResourceQuotas(namespace), c.retries.For("ResourceQuota"))
+}
+func (c *withRetry) Secrets(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.SecretInterface {
+	return secrets.WithRetry(c.inner.Secrets(namespace), c.retries.For("Secret"))
+}
+func (c *withRetry) ServiceAccounts(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.ServiceAccountInterface {
+	return serviceaccounts.WithRetry(c.inner.ServiceAccounts(namespace), c.retries.For("ServiceAccount"))
+}
+func (c *withRetry) Services(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.ServiceInterface {
+	return services.WithRetry(c.inner.Services(namespace), c.retries.For("Service"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_core_v1.CoreV1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) ComponentStatuses() k8s_io_client_go_kubernetes_typed_core_v1.ComponentStatusInterface {
+	return componentstatuses.WithCircuitBreaker(c.inner.ComponentStatuses(), c.breakers.For("ComponentStatus"))
+}
+func (c *withCircuitBreaker) ConfigMaps(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.ConfigMapInterface {
+	return configmaps.WithCircuitBreaker(c.inner.ConfigMaps(namespace), c.breakers.For("ConfigMap"))
+}
+func (c *withCircuitBreaker) Endpoints(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.EndpointsInterface {
+	return endpoints.WithCircuitBreaker(c.inner.Endpoints(namespace), c.breakers.For("Endpoints"))
+}
+func (c *withCircuitBreaker) Events(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.EventInterface {
+	return events.WithCircuitBreaker(c.inner.Events(namespace), c.breakers.For("Event"))
+}
+func (c *withCircuitBreaker) LimitRanges(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.LimitRangeInterface {
+	return limitranges.WithCircuitBreaker(c.inner.LimitRanges(namespace), c.breakers.For("LimitRange"))
+}
+func (c *withCircuitBreaker) Namespaces() k8s_io_client_go_kubernetes_typed_core_v1.NamespaceInterface {
+	return namespaces.WithCircuitBreaker(c.inner.Namespaces(), c.breakers.For("Namespace"))
+}
+func (c *withCircuitBreaker) Nodes() k8s_io_client_go_kubernetes_typed_core_v1.NodeInterface {
+	return nodes.WithCircuitBreaker(c.inner.Nodes(), c.breakers.For("Node"))
+}
+func (c *withCircuitBreaker) PersistentVolumeClaims(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.PersistentVolumeClaimInterface {
+	return persistentvolumeclaims.WithCircuitBreaker(c.inner.PersistentVolumeClaims(namespace), c.breakers.For("PersistentVolumeClaim"))
+}
+func (c *withCircuitBreaker) PersistentVolumes() k8s_io_client_go_kubernetes_typed_core_v1.PersistentVolumeInterface {
+	return persistentvolumes.WithCircuitBreaker(c.inner.PersistentVolumes(), c.breakers.For("PersistentVolume"))
+}
+func (c *withCircuitBreaker) PodTemplates(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.PodTemplateInterface {
+	return podtemplates.WithCircuitBreaker(c.inner.PodTemplates(namespace), c.breakers.For("PodTemplate"))
+}
+func (c *withCircuitBreaker) Pods(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.PodInterface {
+	return pods.WithCircuitBreaker(c.inner.Pods(namespace), c.breakers.For("Pod"))
+}
+func (c *withCircuitBreaker) ReplicationControllers(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.ReplicationControllerInterface {
+	return replicationcontrollers.WithCircuitBreaker(c.inner.ReplicationControllers(namespace), c.breakers.For("ReplicationController"))
+}
+func (c *withCircuitBreaker) ResourceQuotas(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.ResourceQuotaInterface {
+	return resourcequotas.WithCircuitBreaker(c.inner.ResourceQuotas(namespace), c.breakers.For("ResourceQuota"))
+}
+func (c *withCircuitBreaker) Secrets(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.SecretInterface {
+	return secrets.WithCircuitBreaker(c.inner.Secrets(namespace), c.breakers.For("Secret"))
+}
+func (c *withCircuitBreaker) ServiceAccounts(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.ServiceAccountInterface {
+	return serviceaccounts.WithCircuitBreaker(c.inner.ServiceAccounts(namespace), c.breakers.For("ServiceAccount"))
+}
+func (c *withCircuitBreaker) Services(namespace string) k8s_io_client_go_kubernetes_typed_core_v1.ServiceInterface {
+	return services.WithCircuitBreaker(c.inner.Services(namespace), c.breakers.For("Service"))
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"github.com/go-logr/logr"
 	endpointslices "github.com/kyverno/kyverno/pkg/clients/kube/discoveryv1/endpointslices"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_discovery_v1 "k8s.io/client-go/kubernetes/typed/discovery/v1"
 	"k8s.io/client-go/rest"
@@ -20,6 +21,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_discovery_v1.DiscoveryV
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_discovery_v1.DiscoveryV1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_discovery_v1.DiscoveryV1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_discovery_v1.DiscoveryV1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_discovery_v1.DiscoveryV1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_discovery_v1.DiscoveryV1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_discovery_v1.DiscoveryV1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_discovery_v1.DiscoveryV1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_discovery_v1.DiscoveryV1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_discovery_v1.DiscoveryV1Interface
 	metrics    metrics.MetricsConfigManager
@@ -57,3 +74,57 @@ func (c *withLogging) RESTClient() rest.Interface {
 func (c *withLogging) EndpointSlices(namespace string) k8s_io_client_go_kubernetes_typed_discovery_v1.EndpointSliceInterface {
 	return endpointslices.WithLogging(c.inner.EndpointSlices(namespace), c.logger.WithValues("resource", "EndpointSlices").WithValues("namespace", namespace))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_discovery_v1.DiscoveryV1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) EndpointSlices(namespace string) k8s_io_client_go_kubernetes_typed_discovery_v1.EndpointSliceInterface {
+	inner := c.inner.EndpointSlices(namespace)
+	level, ok := c.audit.For("EndpointSlice")
+	if !ok {
+		return inner
+	}
+	return endpointslices.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "EndpointSlices").WithValues("namespace", namespace))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_discovery_v1.DiscoveryV1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) EndpointSlices(namespace string) k8s_io_client_go_kubernetes_typed_discovery_v1.EndpointSliceInterface {
+	return endpointslices.WithRateLimiting(c.inner.EndpointSlices(namespace), c.limits.For("EndpointSlice"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_discovery_v1.DiscoveryV1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) EndpointSlices(namespace string) k8s_io_client_go_kubernetes_typed_discovery_v1.EndpointSliceInterface {
+	return endpointslices.WithRetry(c.inner.EndpointSlices(namespace), c.retries.For("EndpointSlice"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_discovery_v1.DiscoveryV1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) EndpointSlices(namespace string) k8s_io_client_go_kubernetes_typed_discovery_v1.EndpointSliceInterface {
+	return endpointslices.WithCircuitBreaker(c.inner.EndpointSlices(namespace), c.breakers.For("EndpointSlice"))
+}
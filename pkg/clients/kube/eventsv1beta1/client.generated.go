@@ -3,6 +3,7 @@ package client
 import (
 	"github.com/go-logr/logr"
 	events "github.com/kyverno/kyverno/pkg/clients/kube/eventsv1beta1/events"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_events_v1beta1 "k8s.io/client-go/kubernetes/typed/events/v1beta1"
 	"k8s.io/client-go/rest"
@@ -20,6 +21,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_events_v1beta1.EventsV1
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_events_v1beta1.EventsV1beta1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_events_v1beta1.EventsV1beta1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_events_v1beta1.EventsV1beta1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_events_v1beta1.EventsV1beta1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_events_v1beta1.EventsV1beta1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_events_v1beta1.EventsV1beta1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_events_v1beta1.EventsV1beta1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_events_v1beta1.EventsV1beta1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_events_v1beta1.EventsV1beta1Interface
 	metrics    metrics.MetricsConfigManager
@@ -57,3 +74,57 @@ func (c *withLogging) RESTClient() rest.Interface {
 func (c *withLogging) Events(namespace string) k8s_io_client_go_kubernetes_typed_events_v1beta1.EventInterface {
 	return events.WithLogging(c.inner.Events(namespace), c.logger.WithValues("resource", "Events").WithValues("namespace", namespace))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_events_v1beta1.EventsV1beta1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) Events(namespace string) k8s_io_client_go_kubernetes_typed_events_v1beta1.EventInterface {
+	inner := c.inner.Events(namespace)
+	level, ok := c.audit.For("Event")
+	if !ok {
+		return inner
+	}
+	return events.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "Events").WithValues("namespace", namespace))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_events_v1beta1.EventsV1beta1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) Events(namespace string) k8s_io_client_go_kubernetes_typed_events_v1beta1.EventInterface {
+	return events.WithRateLimiting(c.inner.Events(namespace), c.limits.For("Event"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_events_v1beta1.EventsV1beta1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) Events(namespace string) k8s_io_client_go_kubernetes_typed_events_v1beta1.EventInterface {
+	return events.WithRetry(c.inner.Events(namespace), c.retries.For("Event"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_events_v1beta1.EventsV1beta1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) Events(namespace string) k8s_io_client_go_kubernetes_typed_events_v1beta1.EventInterface {
+	return events.WithCircuitBreaker(c.inner.Events(namespace), c.breakers.For("Event"))
+}
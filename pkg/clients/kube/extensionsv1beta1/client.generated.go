@@ -7,6 +7,7 @@ import (
 	ingresses "github.com/kyverno/kyverno/pkg/clients/kube/extensionsv1beta1/ingresses"
 	networkpolicies "github.com/kyverno/kyverno/pkg/clients/kube/extensionsv1beta1/networkpolicies"
 	replicasets "github.com/kyverno/kyverno/pkg/clients/kube/extensionsv1beta1/replicasets"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_extensions_v1beta1 "k8s.io/client-go/kubernetes/typed/extensions/v1beta1"
 	"k8s.io/client-go/rest"
@@ -24,6 +25,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_extensions_v1beta1.Exte
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_extensions_v1beta1.ExtensionsV1beta1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.ExtensionsV1beta1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_extensions_v1beta1.ExtensionsV1beta1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.ExtensionsV1beta1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_extensions_v1beta1.ExtensionsV1beta1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.ExtensionsV1beta1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_extensions_v1beta1.ExtensionsV1beta1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.ExtensionsV1beta1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_extensions_v1beta1.ExtensionsV1beta1Interface
 	metrics    metrics.MetricsConfigManager
@@ -101,3 +118,125 @@ func (c *withLogging) NetworkPolicies(namespace string) k8s_io_client_go_kuberne
 func (c *withLogging) ReplicaSets(namespace string) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.ReplicaSetInterface {
 	return replicasets.WithLogging(c.inner.ReplicaSets(namespace), c.logger.WithValues("resource", "ReplicaSets").WithValues("namespace", namespace))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_extensions_v1beta1.ExtensionsV1beta1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) DaemonSets(namespace string) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.DaemonSetInterface {
+	inner := c.inner.DaemonSets(namespace)
+	level, ok := c.audit.For("DaemonSet")
+	if !ok {
+		return inner
+	}
+	return daemonsets.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "DaemonSets").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) Deployments(namespace string) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.DeploymentInterface {
+	inner := c.inner.Deployments(namespace)
+	level, ok := c.audit.For("Deployment")
+	if !ok {
+		return inner
+	}
+	return deployments.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "Deployments").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) Ingresses(namespace string) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.IngressInterface {
+	inner := c.inner.Ingresses(namespace)
+	level, ok := c.audit.For("Ingress")
+	if !ok {
+		return inner
+	}
+	return ingresses.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "Ingresses").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) NetworkPolicies(namespace string) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.NetworkPolicyInterface {
+	inner := c.inner.NetworkPolicies(namespace)
+	level, ok := c.audit.For("NetworkPolicy")
+	if !ok {
+		return inner
+	}
+	return networkpolicies.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "NetworkPolicies").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) ReplicaSets(namespace string) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.ReplicaSetInterface {
+	inner := c.inner.ReplicaSets(namespace)
+	level, ok := c.audit.For("ReplicaSet")
+	if !ok {
+		return inner
+	}
+	return replicasets.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ReplicaSets").WithValues("namespace", namespace))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_extensions_v1beta1.ExtensionsV1beta1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) DaemonSets(namespace string) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.DaemonSetInterface {
+	return daemonsets.WithRateLimiting(c.inner.DaemonSets(namespace), c.limits.For("DaemonSet"))
+}
+func (c *withRateLimiting) Deployments(namespace string) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.DeploymentInterface {
+	return deployments.WithRateLimiting(c.inner.Deployments(namespace), c.limits.For("Deployment"))
+}
+func (c *withRateLimiting) Ingresses(namespace string) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.IngressInterface {
+	return ingresses.WithRateLimiting(c.inner.Ingresses(namespace), c.limits.For("Ingress"))
+}
+func (c *withRateLimiting) NetworkPolicies(namespace string) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.NetworkPolicyInterface {
+	return networkpolicies.WithRateLimiting(c.inner.NetworkPolicies(namespace), c.limits.For("NetworkPolicy"))
+}
+func (c *withRateLimiting) ReplicaSets(namespace string) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.ReplicaSetInterface {
+	return replicasets.WithRateLimiting(c.inner.ReplicaSets(namespace), c.limits.For("ReplicaSet"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_extensions_v1beta1.ExtensionsV1beta1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) DaemonSets(namespace string) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.DaemonSetInterface {
+	return daemonsets.WithRetry(c.inner.DaemonSets(namespace), c.retries.For("DaemonSet"))
+}
+func (c *withRetry) Deployments(namespace string) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.DeploymentInterface {
+	return deployments.WithRetry(c.inner.Deployments(namespace), c.retries.For("Deployment"))
+}
+func (c *withRetry) Ingresses(namespace string) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.IngressInterface {
+	return ingresses.WithRetry(c.inner.Ingresses(namespace), c.retries.For("Ingress"))
+}
+func (c *withRetry) NetworkPolicies(namespace string) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.NetworkPolicyInterface {
+	return networkpolicies.WithRetry(c.inner.NetworkPolicies(namespace), c.retries.For("NetworkPolicy"))
+}
+func (c *withRetry) ReplicaSets(namespace string) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.ReplicaSetInterface {
+	return replicasets.WithRetry(c.inner.ReplicaSets(namespace), c.retries.For("ReplicaSet"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_extensions_v1beta1.ExtensionsV1beta1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) DaemonSets(namespace string) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.DaemonSetInterface {
+	return daemonsets.WithCircuitBreaker(c.inner.DaemonSets(namespace), c.breakers.For("DaemonSet"))
+}
+func (c *withCircuitBreaker) Deployments(namespace string) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.DeploymentInterface {
+	return deployments.WithCircuitBreaker(c.inner.Deployments(namespace), c.breakers.For("Deployment"))
+}
+func (c *withCircuitBreaker) Ingresses(namespace string) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.IngressInterface {
+	return ingresses.WithCircuitBreaker(c.inner.Ingresses(namespace), c.breakers.For("Ingress"))
+}
+func (c *withCircuitBreaker) NetworkPolicies(namespace string) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.NetworkPolicyInterface {
+	return networkpolicies.WithCircuitBreaker(c.inner.NetworkPolicies(namespace), c.breakers.For("NetworkPolicy"))
+}
+func (c *withCircuitBreaker) ReplicaSets(namespace string) k8s_io_client_go_kubernetes_typed_extensions_v1beta1.ReplicaSetInterface {
+	return replicasets.WithCircuitBreaker(c.inner.ReplicaSets(namespace), c.breakers.For("ReplicaSet"))
+}
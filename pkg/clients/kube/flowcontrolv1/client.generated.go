@@ -4,6 +4,7 @@ import (
 	"github.com/go-logr/logr"
 	flowschemas "github.com/kyverno/kyverno/pkg/clients/kube/flowcontrolv1/flowschemas"
 	prioritylevelconfigurations "github.com/kyverno/kyverno/pkg/clients/kube/flowcontrolv1/prioritylevelconfigurations"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_flowcontrol_v1 "k8s.io/client-go/kubernetes/typed/flowcontrol/v1"
 	"k8s.io/client-go/rest"
@@ -21,6 +22,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_flowcontrol_v1.Flowcont
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_flowcontrol_v1.FlowcontrolV1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_flowcontrol_v1.FlowcontrolV1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_flowcontrol_v1.FlowcontrolV1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_flowcontrol_v1.FlowcontrolV1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_flowcontrol_v1.FlowcontrolV1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_flowcontrol_v1.FlowcontrolV1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_flowcontrol_v1.FlowcontrolV1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_flowcontrol_v1.FlowcontrolV1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_flowcontrol_v1.FlowcontrolV1Interface
 	metrics    metrics.MetricsConfigManager
@@ -68,3 +85,74 @@ func (c *withLogging) FlowSchemas() k8s_io_client_go_kubernetes_typed_flowcontro
 func (c *withLogging) PriorityLevelConfigurations() k8s_io_client_go_kubernetes_typed_flowcontrol_v1.PriorityLevelConfigurationInterface {
 	return prioritylevelconfigurations.WithLogging(c.inner.PriorityLevelConfigurations(), c.logger.WithValues("resource", "PriorityLevelConfigurations"))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_flowcontrol_v1.FlowcontrolV1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) FlowSchemas() k8s_io_client_go_kubernetes_typed_flowcontrol_v1.FlowSchemaInterface {
+	inner := c.inner.FlowSchemas()
+	level, ok := c.audit.For("FlowSchema")
+	if !ok {
+		return inner
+	}
+	return flowschemas.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "FlowSchemas"))
+}
+func (c *withAuditLogging) PriorityLevelConfigurations() k8s_io_client_go_kubernetes_typed_flowcontrol_v1.PriorityLevelConfigurationInterface {
+	inner := c.inner.PriorityLevelConfigurations()
+	level, ok := c.audit.For("PriorityLevelConfiguration")
+	if !ok {
+		return inner
+	}
+	return prioritylevelconfigurations.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "PriorityLevelConfigurations"))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_flowcontrol_v1.FlowcontrolV1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) FlowSchemas() k8s_io_client_go_kubernetes_typed_flowcontrol_v1.FlowSchemaInterface {
+	return flowschemas.WithRateLimiting(c.inner.FlowSchemas(), c.limits.For("FlowSchema"))
+}
+func (c *withRateLimiting) PriorityLevelConfigurations() k8s_io_client_go_kubernetes_typed_flowcontrol_v1.PriorityLevelConfigurationInterface {
+	return prioritylevelconfigurations.WithRateLimiting(c.inner.PriorityLevelConfigurations(), c.limits.For("PriorityLevelConfiguration"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_flowcontrol_v1.FlowcontrolV1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) FlowSchemas() k8s_io_client_go_kubernetes_typed_flowcontrol_v1.FlowSchemaInterface {
+	return flowschemas.WithRetry(c.inner.FlowSchemas(), c.retries.For("FlowSchema"))
+}
+func (c *withRetry) PriorityLevelConfigurations() k8s_io_client_go_kubernetes_typed_flowcontrol_v1.PriorityLevelConfigurationInterface {
+	return prioritylevelconfigurations.WithRetry(c.inner.PriorityLevelConfigurations(), c.retries.For("PriorityLevelConfiguration"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_flowcontrol_v1.FlowcontrolV1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) FlowSchemas() k8s_io_client_go_kubernetes_typed_flowcontrol_v1.FlowSchemaInterface {
+	return flowschemas.WithCircuitBreaker(c.inner.FlowSchemas(), c.breakers.For("FlowSchema"))
+}
+func (c *withCircuitBreaker) PriorityLevelConfigurations() k8s_io_client_go_kubernetes_typed_flowcontrol_v1.PriorityLevelConfigurationInterface {
+	return prioritylevelconfigurations.WithCircuitBreaker(c.inner.PriorityLevelConfigurations(), c.breakers.For("PriorityLevelConfiguration"))
+}
@@ -5,6 +5,7 @@ import (
 	ingressclasses "github.com/kyverno/kyverno/pkg/clients/kube/networkingv1/ingressclasses"
 	ingresses "github.com/kyverno/kyverno/pkg/clients/kube/networkingv1/ingresses"
 	networkpolicies "github.com/kyverno/kyverno/pkg/clients/kube/networkingv1/networkpolicies"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_networking_v1 "k8s.io/client-go/kubernetes/typed/networking/v1"
 	"k8s.io/client-go/rest"
@@ -22,6 +23,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_networking_v1.Networkin
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_networking_v1.NetworkingV1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_networking_v1.NetworkingV1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_networking_v1.NetworkingV1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_networking_v1.NetworkingV1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_networking_v1.NetworkingV1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_networking_v1.NetworkingV1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_networking_v1.NetworkingV1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_networking_v1.NetworkingV1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_networking_v1.NetworkingV1Interface
 	metrics    metrics.MetricsConfigManager
@@ -79,3 +96,91 @@ func (c *withLogging) Ingresses(namespace string) k8s_io_client_go_kubernetes_ty
 func (c *withLogging) NetworkPolicies(namespace string) k8s_io_client_go_kubernetes_typed_networking_v1.NetworkPolicyInterface {
 	return networkpolicies.WithLogging(c.inner.NetworkPolicies(namespace), c.logger.WithValues("resource", "NetworkPolicies").WithValues("namespace", namespace))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_networking_v1.NetworkingV1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) IngressClasses() k8s_io_client_go_kubernetes_typed_networking_v1.IngressClassInterface {
+	inner := c.inner.IngressClasses()
+	level, ok := c.audit.For("IngressClass")
+	if !ok {
+		return inner
+	}
+	return ingressclasses.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "IngressClasses"))
+}
+func (c *withAuditLogging) Ingresses(namespace string) k8s_io_client_go_kubernetes_typed_networking_v1.IngressInterface {
+	inner := c.inner.Ingresses(namespace)
+	level, ok := c.audit.For("Ingress")
+	if !ok {
+		return inner
+	}
+	return ingresses.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "Ingresses").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) NetworkPolicies(namespace string) k8s_io_client_go_kubernetes_typed_networking_v1.NetworkPolicyInterface {
+	inner := c.inner.NetworkPolicies(namespace)
+	level, ok := c.audit.For("NetworkPolicy")
+	if !ok {
+		return inner
+	}
+	return networkpolicies.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "NetworkPolicies").WithValues("namespace", namespace))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_networking_v1.NetworkingV1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) IngressClasses() k8s_io_client_go_kubernetes_typed_networking_v1.IngressClassInterface {
+	return ingressclasses.WithRateLimiting(c.inner.IngressClasses(), c.limits.For("IngressClass"))
+}
+func (c *withRateLimiting) Ingresses(namespace string) k8s_io_client_go_kubernetes_typed_networking_v1.IngressInterface {
+	return ingresses.WithRateLimiting(c.inner.Ingresses(namespace), c.limits.For("Ingress"))
+}
+func (c *withRateLimiting) NetworkPolicies(namespace string) k8s_io_client_go_kubernetes_typed_networking_v1.NetworkPolicyInterface {
+	return networkpolicies.WithRateLimiting(c.inner.NetworkPolicies(namespace), c.limits.For("NetworkPolicy"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_networking_v1.NetworkingV1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) IngressClasses() k8s_io_client_go_kubernetes_typed_networking_v1.IngressClassInterface {
+	return ingressclasses.WithRetry(c.inner.IngressClasses(), c.retries.For("IngressClass"))
+}
+func (c *withRetry) Ingresses(namespace string) k8s_io_client_go_kubernetes_typed_networking_v1.IngressInterface {
+	return ingresses.WithRetry(c.inner.Ingresses(namespace), c.retries.For("Ingress"))
+}
+func (c *withRetry) NetworkPolicies(namespace string) k8s_io_client_go_kubernetes_typed_networking_v1.NetworkPolicyInterface {
+	return networkpolicies.WithRetry(c.inner.NetworkPolicies(namespace), c.retries.For("NetworkPolicy"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_networking_v1.NetworkingV1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) IngressClasses() k8s_io_client_go_kubernetes_typed_networking_v1.IngressClassInterface {
+	return ingressclasses.WithCircuitBreaker(c.inner.IngressClasses(), c.breakers.For("IngressClass"))
+}
+func (c *withCircuitBreaker) Ingresses(namespace string) k8s_io_client_go_kubernetes_typed_networking_v1.IngressInterface {
+	return ingresses.WithCircuitBreaker(c.inner.Ingresses(namespace), c.breakers.For("Ingress"))
+}
+func (c *withCircuitBreaker) NetworkPolicies(namespace string) k8s_io_client_go_kubernetes_typed_networking_v1.NetworkPolicyInterface {
+	return networkpolicies.WithCircuitBreaker(c.inner.NetworkPolicies(namespace), c.breakers.For("NetworkPolicy"))
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/go-logr/logr"
 	ipaddresses "github.com/kyverno/kyverno/pkg/clients/kube/networkingv1alpha1/ipaddresses"
 	servicecidrs "github.com/kyverno/kyverno/pkg/clients/kube/networkingv1alpha1/servicecidrs"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_networking_v1alpha1 "k8s.io/client-go/kubernetes/typed/networking/v1alpha1"
 	"k8s.io/client-go/rest"
@@ -21,6 +22,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_networking_v1alpha1.Net
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_networking_v1alpha1.NetworkingV1alpha1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_networking_v1alpha1.NetworkingV1alpha1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_networking_v1alpha1.NetworkingV1alpha1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_networking_v1alpha1.NetworkingV1alpha1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_networking_v1alpha1.NetworkingV1alpha1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_networking_v1alpha1.NetworkingV1alpha1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_networking_v1alpha1.NetworkingV1alpha1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_networking_v1alpha1.NetworkingV1alpha1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_networking_v1alpha1.NetworkingV1alpha1Interface
 	metrics    metrics.MetricsConfigManager
@@ -68,3 +85,74 @@ func (c *withLogging) IPAddresses() k8s_io_client_go_kubernetes_typed_networking
 func (c *withLogging) ServiceCIDRs() k8s_io_client_go_kubernetes_typed_networking_v1alpha1.ServiceCIDRInterface {
 	return servicecidrs.WithLogging(c.inner.ServiceCIDRs(), c.logger.WithValues("resource", "ServiceCIDRs"))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_networking_v1alpha1.NetworkingV1alpha1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) IPAddresses() k8s_io_client_go_kubernetes_typed_networking_v1alpha1.IPAddressInterface {
+	inner := c.inner.IPAddresses()
+	level, ok := c.audit.For("IPAddress")
+	if !ok {
+		return inner
+	}
+	return ipaddresses.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "IPAddresses"))
+}
+func (c *withAuditLogging) ServiceCIDRs() k8s_io_client_go_kubernetes_typed_networking_v1alpha1.ServiceCIDRInterface {
+	inner := c.inner.ServiceCIDRs()
+	level, ok := c.audit.For("ServiceCIDR")
+	if !ok {
+		return inner
+	}
+	return servicecidrs.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ServiceCIDRs"))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_networking_v1alpha1.NetworkingV1alpha1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) IPAddresses() k8s_io_client_go_kubernetes_typed_networking_v1alpha1.IPAddressInterface {
+	return ipaddresses.WithRateLimiting(c.inner.IPAddresses(), c.limits.For("IPAddress"))
+}
+func (c *withRateLimiting) ServiceCIDRs() k8s_io_client_go_kubernetes_typed_networking_v1alpha1.ServiceCIDRInterface {
+	return servicecidrs.WithRateLimiting(c.inner.ServiceCIDRs(), c.limits.For("ServiceCIDR"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_networking_v1alpha1.NetworkingV1alpha1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) IPAddresses() k8s_io_client_go_kubernetes_typed_networking_v1alpha1.IPAddressInterface {
+	return ipaddresses.WithRetry(c.inner.IPAddresses(), c.retries.For("IPAddress"))
+}
+func (c *withRetry) ServiceCIDRs() k8s_io_client_go_kubernetes_typed_networking_v1alpha1.ServiceCIDRInterface {
+	return servicecidrs.WithRetry(c.inner.ServiceCIDRs(), c.retries.For("ServiceCIDR"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_networking_v1alpha1.NetworkingV1alpha1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) IPAddresses() k8s_io_client_go_kubernetes_typed_networking_v1alpha1.IPAddressInterface {
+	return ipaddresses.WithCircuitBreaker(c.inner.IPAddresses(), c.breakers.For("IPAddress"))
+}
+func (c *withCircuitBreaker) ServiceCIDRs() k8s_io_client_go_kubernetes_typed_networking_v1alpha1.ServiceCIDRInterface {
+	return servicecidrs.WithCircuitBreaker(c.inner.ServiceCIDRs(), c.breakers.For("ServiceCIDR"))
+}
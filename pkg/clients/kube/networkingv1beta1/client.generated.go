@@ -4,6 +4,7 @@ import (
 	"github.com/go-logr/logr"
 	ingressclasses "github.com/kyverno/kyverno/pkg/clients/kube/networkingv1beta1/ingressclasses"
 	ingresses "github.com/kyverno/kyverno/pkg/clients/kube/networkingv1beta1/ingresses"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_networking_v1beta1 "k8s.io/client-go/kubernetes/typed/networking/v1beta1"
 	"k8s.io/client-go/rest"
@@ -21,6 +22,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_networking_v1beta1.Netw
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_networking_v1beta1.NetworkingV1beta1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_networking_v1beta1.NetworkingV1beta1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_networking_v1beta1.NetworkingV1beta1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_networking_v1beta1.NetworkingV1beta1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_networking_v1beta1.NetworkingV1beta1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_networking_v1beta1.NetworkingV1beta1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_networking_v1beta1.NetworkingV1beta1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_networking_v1beta1.NetworkingV1beta1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_networking_v1beta1.NetworkingV1beta1Interface
 	metrics    metrics.MetricsConfigManager
@@ -68,3 +85,74 @@ func (c *withLogging) IngressClasses() k8s_io_client_go_kubernetes_typed_network
 func (c *withLogging) Ingresses(namespace string) k8s_io_client_go_kubernetes_typed_networking_v1beta1.IngressInterface {
 	return ingresses.WithLogging(c.inner.Ingresses(namespace), c.logger.WithValues("resource", "Ingresses").WithValues("namespace", namespace))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_networking_v1beta1.NetworkingV1beta1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) IngressClasses() k8s_io_client_go_kubernetes_typed_networking_v1beta1.IngressClassInterface {
+	inner := c.inner.IngressClasses()
+	level, ok := c.audit.For("IngressClass")
+	if !ok {
+		return inner
+	}
+	return ingressclasses.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "IngressClasses"))
+}
+func (c *withAuditLogging) Ingresses(namespace string) k8s_io_client_go_kubernetes_typed_networking_v1beta1.IngressInterface {
+	inner := c.inner.Ingresses(namespace)
+	level, ok := c.audit.For("Ingress")
+	if !ok {
+		return inner
+	}
+	return ingresses.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "Ingresses").WithValues("namespace", namespace))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_networking_v1beta1.NetworkingV1beta1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) IngressClasses() k8s_io_client_go_kubernetes_typed_networking_v1beta1.IngressClassInterface {
+	return ingressclasses.WithRateLimiting(c.inner.IngressClasses(), c.limits.For("IngressClass"))
+}
+func (c *withRateLimiting) Ingresses(namespace string) k8s_io_client_go_kubernetes_typed_networking_v1beta1.IngressInterface {
+	return ingresses.WithRateLimiting(c.inner.Ingresses(namespace), c.limits.For("Ingress"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_networking_v1beta1.NetworkingV1beta1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) IngressClasses() k8s_io_client_go_kubernetes_typed_networking_v1beta1.IngressClassInterface {
+	return ingressclasses.WithRetry(c.inner.IngressClasses(), c.retries.For("IngressClass"))
+}
+func (c *withRetry) Ingresses(namespace string) k8s_io_client_go_kubernetes_typed_networking_v1beta1.IngressInterface {
+	return ingresses.WithRetry(c.inner.Ingresses(namespace), c.retries.For("Ingress"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_networking_v1beta1.NetworkingV1beta1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) IngressClasses() k8s_io_client_go_kubernetes_typed_networking_v1beta1.IngressClassInterface {
+	return ingressclasses.WithCircuitBreaker(c.inner.IngressClasses(), c.breakers.For("IngressClass"))
+}
+func (c *withCircuitBreaker) Ingresses(namespace string) k8s_io_client_go_kubernetes_typed_networking_v1beta1.IngressInterface {
+	return ingresses.WithCircuitBreaker(c.inner.Ingresses(namespace), c.breakers.For("Ingress"))
+}
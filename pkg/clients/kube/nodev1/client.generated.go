@@ -3,6 +3,7 @@ package client
 import (
 	"github.com/go-logr/logr"
 	runtimeclasses "github.com/kyverno/kyverno/pkg/clients/kube/nodev1/runtimeclasses"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_node_v1 "k8s.io/client-go/kubernetes/typed/node/v1"
 	"k8s.io/client-go/rest"
@@ -20,6 +21,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_node_v1.NodeV1Interface
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_node_v1.NodeV1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_node_v1.NodeV1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_node_v1.NodeV1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_node_v1.NodeV1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_node_v1.NodeV1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_node_v1.NodeV1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_node_v1.NodeV1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_node_v1.NodeV1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_node_v1.NodeV1Interface
 	metrics    metrics.MetricsConfigManager
@@ -57,3 +74,57 @@ func (c *withLogging) RESTClient() rest.Interface {
 func (c *withLogging) RuntimeClasses() k8s_io_client_go_kubernetes_typed_node_v1.RuntimeClassInterface {
 	return runtimeclasses.WithLogging(c.inner.RuntimeClasses(), c.logger.WithValues("resource", "RuntimeClasses"))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_node_v1.NodeV1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) RuntimeClasses() k8s_io_client_go_kubernetes_typed_node_v1.RuntimeClassInterface {
+	inner := c.inner.RuntimeClasses()
+	level, ok := c.audit.For("RuntimeClass")
+	if !ok {
+		return inner
+	}
+	return runtimeclasses.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "RuntimeClasses"))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_node_v1.NodeV1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) RuntimeClasses() k8s_io_client_go_kubernetes_typed_node_v1.RuntimeClassInterface {
+	return runtimeclasses.WithRateLimiting(c.inner.RuntimeClasses(), c.limits.For("RuntimeClass"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_node_v1.NodeV1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) RuntimeClasses() k8s_io_client_go_kubernetes_typed_node_v1.RuntimeClassInterface {
+	return runtimeclasses.WithRetry(c.inner.RuntimeClasses(), c.retries.For("RuntimeClass"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_node_v1.NodeV1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) RuntimeClasses() k8s_io_client_go_kubernetes_typed_node_v1.RuntimeClassInterface {
+	return runtimeclasses.WithCircuitBreaker(c.inner.RuntimeClasses(), c.breakers.For("RuntimeClass"))
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/go-logr/logr"
 	evictions "github.com/kyverno/kyverno/pkg/clients/kube/policyv1/evictions"
 	poddisruptionbudgets "github.com/kyverno/kyverno/pkg/clients/kube/policyv1/poddisruptionbudgets"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_policy_v1 "k8s.io/client-go/kubernetes/typed/policy/v1"
 	"k8s.io/client-go/rest"
@@ -21,6 +22,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_policy_v1.PolicyV1Inter
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_policy_v1.PolicyV1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_policy_v1.PolicyV1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_policy_v1.PolicyV1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_policy_v1.PolicyV1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_policy_v1.PolicyV1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_policy_v1.PolicyV1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_policy_v1.PolicyV1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_policy_v1.PolicyV1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_policy_v1.PolicyV1Interface
 	metrics    metrics.MetricsConfigManager
@@ -68,3 +85,74 @@ func (c *withLogging) Evictions(namespace string) k8s_io_client_go_kubernetes_ty
 func (c *withLogging) PodDisruptionBudgets(namespace string) k8s_io_client_go_kubernetes_typed_policy_v1.PodDisruptionBudgetInterface {
 	return poddisruptionbudgets.WithLogging(c.inner.PodDisruptionBudgets(namespace), c.logger.WithValues("resource", "PodDisruptionBudgets").WithValues("namespace", namespace))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_policy_v1.PolicyV1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) Evictions(namespace string) k8s_io_client_go_kubernetes_typed_policy_v1.EvictionInterface {
+	inner := c.inner.Evictions(namespace)
+	level, ok := c.audit.For("Eviction")
+	if !ok {
+		return inner
+	}
+	return evictions.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "Evictions").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) PodDisruptionBudgets(namespace string) k8s_io_client_go_kubernetes_typed_policy_v1.PodDisruptionBudgetInterface {
+	inner := c.inner.PodDisruptionBudgets(namespace)
+	level, ok := c.audit.For("PodDisruptionBudget")
+	if !ok {
+		return inner
+	}
+	return poddisruptionbudgets.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "PodDisruptionBudgets").WithValues("namespace", namespace))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_policy_v1.PolicyV1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) Evictions(namespace string) k8s_io_client_go_kubernetes_typed_policy_v1.EvictionInterface {
+	return evictions.WithRateLimiting(c.inner.Evictions(namespace), c.limits.For("Eviction"))
+}
+func (c *withRateLimiting) PodDisruptionBudgets(namespace string) k8s_io_client_go_kubernetes_typed_policy_v1.PodDisruptionBudgetInterface {
+	return poddisruptionbudgets.WithRateLimiting(c.inner.PodDisruptionBudgets(namespace), c.limits.For("PodDisruptionBudget"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_policy_v1.PolicyV1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) Evictions(namespace string) k8s_io_client_go_kubernetes_typed_policy_v1.EvictionInterface {
+	return evictions.WithRetry(c.inner.Evictions(namespace), c.retries.For("Eviction"))
+}
+func (c *withRetry) PodDisruptionBudgets(namespace string) k8s_io_client_go_kubernetes_typed_policy_v1.PodDisruptionBudgetInterface {
+	return poddisruptionbudgets.WithRetry(c.inner.PodDisruptionBudgets(namespace), c.retries.For("PodDisruptionBudget"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_policy_v1.PolicyV1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) Evictions(namespace string) k8s_io_client_go_kubernetes_typed_policy_v1.EvictionInterface {
+	return evictions.WithCircuitBreaker(c.inner.Evictions(namespace), c.breakers.For("Eviction"))
+}
+func (c *withCircuitBreaker) PodDisruptionBudgets(namespace string) k8s_io_client_go_kubernetes_typed_policy_v1.PodDisruptionBudgetInterface {
+	return poddisruptionbudgets.WithCircuitBreaker(c.inner.PodDisruptionBudgets(namespace), c.breakers.For("PodDisruptionBudget"))
+}
@@ -2,20 +2,27 @@ package resource
 
 import (
 	context "context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/multierr"
 	k8s_io_api_policy_v1beta1 "k8s.io/api/policy/v1beta1"
 	k8s_io_client_go_kubernetes_typed_policy_v1beta1 "k8s.io/client-go/kubernetes/typed/policy/v1beta1"
+	"k8s.io/client-go/util/flowcontrol"
 )
 
 func WithLogging(inner k8s_io_client_go_kubernetes_typed_policy_v1beta1.EvictionInterface, logger logr.Logger) k8s_io_client_go_kubernetes_typed_policy_v1beta1.EvictionInterface {
-	return &withLogging{inner, logger}
+	return &withLogging{inner, logger, false}
+}
+
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_policy_v1beta1.EvictionInterface, logger logr.Logger) k8s_io_client_go_kubernetes_typed_policy_v1beta1.EvictionInterface {
+	return &withLogging{inner, logger, true}
 }
 
 func WithMetrics(inner k8s_io_client_go_kubernetes_typed_policy_v1beta1.EvictionInterface, recorder metrics.Recorder) k8s_io_client_go_kubernetes_typed_policy_v1beta1.EvictionInterface {
@@ -26,15 +33,43 @@ func WithTracing(inner k8s_io_client_go_kubernetes_typed_policy_v1beta1.Eviction
 	return &withTracing{inner, client, kind}
 }
 
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_policy_v1beta1.EvictionInterface, limiter flowcontrol.RateLimiter) k8s_io_client_go_kubernetes_typed_policy_v1beta1.EvictionInterface {
+	if limiter == nil {
+		return inner
+	}
+	return &withRateLimiting{inner, limiter}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_policy_v1beta1.EvictionInterface, retrier *middleware.Retrier) k8s_io_client_go_kubernetes_typed_policy_v1beta1.EvictionInterface {
+	if retrier == nil {
+		return inner
+	}
+	return &withRetry{inner, retrier}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_policy_v1beta1.EvictionInterface, breaker *middleware.CircuitBreaker) k8s_io_client_go_kubernetes_typed_policy_v1beta1.EvictionInterface {
+	if breaker == nil {
+		return inner
+	}
+	return &withCircuitBreaker{inner, breaker}
+}
+
 type withLogging struct {
 	inner  k8s_io_client_go_kubernetes_typed_policy_v1beta1.EvictionInterface
 	logger logr.Logger
+	sizes  bool
 }
 
 func (c *withLogging) Evict(arg0 context.Context, arg1 *k8s_io_api_policy_v1beta1.Eviction) error {
 	start := time.Now()
 	logger := c.logger.WithValues("operation", "Evict")
 	ret0 := c.inner.Evict(arg0, arg1)
+	if c.sizes && logger.Enabled() {
+		logger = logger.WithValues(
+			"requestSize", middleware.Size(arg1),
+			"responseSize", middleware.Size(),
+		)
+	}
 	if err := multierr.Combine(ret0); err != nil {
 		logger.Error(err, "Evict failed", "duration", time.Since(start))
 	} else {
@@ -80,3 +115,47 @@ func (c *withTracing) Evict(arg0 context.Context, arg1 *k8s_io_api_policy_v1beta
 	}
 	return ret0
 }
+
+type withRateLimiting struct {
+	inner   k8s_io_client_go_kubernetes_typed_policy_v1beta1.EvictionInterface
+	limiter flowcontrol.RateLimiter
+}
+
+func (c *withRateLimiting) Evict(arg0 context.Context, arg1 *k8s_io_api_policy_v1beta1.Eviction) error {
+	if err := c.limiter.Wait(arg0); err != nil {
+		var ret0 error
+		ret0 = err
+		return ret0
+	}
+	return c.inner.Evict(arg0, arg1)
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_policy_v1beta1.EvictionInterface
+	retrier *middleware.Retrier
+}
+
+func (c *withRetry) Evict(arg0 context.Context, arg1 *k8s_io_api_policy_v1beta1.Eviction) error {
+	var ret0 error
+	c.retrier.Retry(arg0, func() error {
+		ret0 = c.inner.Evict(arg0, arg1)
+		return multierr.Combine(ret0)
+	})
+	return ret0
+}
+
+type withCircuitBreaker struct {
+	inner   k8s_io_client_go_kubernetes_typed_policy_v1beta1.EvictionInterface
+	breaker *middleware.CircuitBreaker
+}
+
+func (c *withCircuitBreaker) Evict(arg0 context.Context, arg1 *k8s_io_api_policy_v1beta1.Eviction) error {
+	var ret0 error
+	if err := c.breaker.Call(arg0, func() error {
+		ret0 = c.inner.Evict(arg0, arg1)
+		return multierr.Combine(ret0)
+	}); errors.Is(err, middleware.ErrCircuitOpen) {
+		ret0 = err
+	}
+	return ret0
+}
@@ -6,6 +6,7 @@ import (
 	clusterroles "github.com/kyverno/kyverno/pkg/clients/kube/rbacv1/clusterroles"
 	rolebindings "github.com/kyverno/kyverno/pkg/clients/kube/rbacv1/rolebindings"
 	roles "github.com/kyverno/kyverno/pkg/clients/kube/rbacv1/roles"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_rbac_v1 "k8s.io/client-go/kubernetes/typed/rbac/v1"
 	"k8s.io/client-go/rest"
@@ -23,6 +24,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_rbac_v1.RbacV1Interface
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_rbac_v1.RbacV1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_rbac_v1.RbacV1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_rbac_v1.RbacV1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_rbac_v1.RbacV1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_rbac_v1.RbacV1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_rbac_v1.RbacV1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_rbac_v1.RbacV1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_rbac_v1.RbacV1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_rbac_v1.RbacV1Interface
 	metrics    metrics.MetricsConfigManager
@@ -90,3 +107,108 @@ func (c *withLogging) RoleBindings(namespace string) k8s_io_client_go_kubernetes
 func (c *withLogging) Roles(namespace string) k8s_io_client_go_kubernetes_typed_rbac_v1.RoleInterface {
 	return roles.WithLogging(c.inner.Roles(namespace), c.logger.WithValues("resource", "Roles").WithValues("namespace", namespace))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_rbac_v1.RbacV1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) ClusterRoleBindings() k8s_io_client_go_kubernetes_typed_rbac_v1.ClusterRoleBindingInterface {
+	inner := c.inner.ClusterRoleBindings()
+	level, ok := c.audit.For("ClusterRoleBinding")
+	if !ok {
+		return inner
+	}
+	return clusterrolebindings.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ClusterRoleBindings"))
+}
+func (c *withAuditLogging) ClusterRoles() k8s_io_client_go_kubernetes_typed_rbac_v1.ClusterRoleInterface {
+	inner := c.inner.ClusterRoles()
+	level, ok := c.audit.For("ClusterRole")
+	if !ok {
+		return inner
+	}
+	return clusterroles.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ClusterRoles"))
+}
+func (c *withAuditLogging) RoleBindings(namespace string) k8s_io_client_go_kubernetes_typed_rbac_v1.RoleBindingInterface {
+	inner := c.inner.RoleBindings(namespace)
+	level, ok := c.audit.For("RoleBinding")
+	if !ok {
+		return inner
+	}
+	return rolebindings.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "RoleBindings").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) Roles(namespace string) k8s_io_client_go_kubernetes_typed_rbac_v1.RoleInterface {
+	inner := c.inner.Roles(namespace)
+	level, ok := c.audit.For("Role")
+	if !ok {
+		return inner
+	}
+	return roles.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "Roles").WithValues("namespace", namespace))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_rbac_v1.RbacV1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) ClusterRoleBindings() k8s_io_client_go_kubernetes_typed_rbac_v1.ClusterRoleBindingInterface {
+	return clusterrolebindings.WithRateLimiting(c.inner.ClusterRoleBindings(), c.limits.For("ClusterRoleBinding"))
+}
+func (c *withRateLimiting) ClusterRoles() k8s_io_client_go_kubernetes_typed_rbac_v1.ClusterRoleInterface {
+	return clusterroles.WithRateLimiting(c.inner.ClusterRoles(), c.limits.For("ClusterRole"))
+}
+func (c *withRateLimiting) RoleBindings(namespace string) k8s_io_client_go_kubernetes_typed_rbac_v1.RoleBindingInterface {
+	return rolebindings.WithRateLimiting(c.inner.RoleBindings(namespace), c.limits.For("RoleBinding"))
+}
+func (c *withRateLimiting) Roles(namespace string) k8s_io_client_go_kubernetes_typed_rbac_v1.RoleInterface {
+	return roles.WithRateLimiting(c.inner.Roles(namespace), c.limits.For("Role"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_rbac_v1.RbacV1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) ClusterRoleBindings() k8s_io_client_go_kubernetes_typed_rbac_v1.ClusterRoleBindingInterface {
+	return clusterrolebindings.WithRetry(c.inner.ClusterRoleBindings(), c.retries.For("ClusterRoleBinding"))
+}
+func (c *withRetry) ClusterRoles() k8s_io_client_go_kubernetes_typed_rbac_v1.ClusterRoleInterface {
+	return clusterroles.WithRetry(c.inner.ClusterRoles(), c.retries.For("ClusterRole"))
+}
+func (c *withRetry) RoleBindings(namespace string) k8s_io_client_go_kubernetes_typed_rbac_v1.RoleBindingInterface {
+	return rolebindings.WithRetry(c.inner.RoleBindings(namespace), c.retries.For("RoleBinding"))
+}
+func (c *withRetry) Roles(namespace string) k8s_io_client_go_kubernetes_typed_rbac_v1.RoleInterface {
+	return roles.WithRetry(c.inner.Roles(namespace), c.retries.For("Role"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_rbac_v1.RbacV1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) ClusterRoleBindings() k8s_io_client_go_kubernetes_typed_rbac_v1.ClusterRoleBindingInterface {
+	return clusterrolebindings.WithCircuitBreaker(c.inner.ClusterRoleBindings(), c.breakers.For("ClusterRoleBinding"))
+}
+func (c *withCircuitBreaker) ClusterRoles() k8s_io_client_go_kubernetes_typed_rbac_v1.ClusterRoleInterface {
+	return clusterroles.WithCircuitBreaker(c.inner.ClusterRoles(), c.breakers.For("ClusterRole"))
+}
+func (c *withCircuitBreaker) RoleBindings(namespace string) k8s_io_client_go_kubernetes_typed_rbac_v1.RoleBindingInterface {
+	return rolebindings.WithCircuitBreaker(c.inner.RoleBindings(namespace), c.breakers.For("RoleBinding"))
+}
+func (c *withCircuitBreaker) Roles(namespace string) k8s_io_client_go_kubernetes_typed_rbac_v1.RoleInterface {
+	return roles.WithCircuitBreaker(c.inner.Roles(namespace), c.breakers.For("Role"))
+}
@@ -6,6 +6,7 @@ import (
 	resourceclaims "github.com/kyverno/kyverno/pkg/clients/kube/resourcev1alpha2/resourceclaims"
 	resourceclaimtemplates "github.com/kyverno/kyverno/pkg/clients/kube/resourcev1alpha2/resourceclaimtemplates"
 	resourceclasses "github.com/kyverno/kyverno/pkg/clients/kube/resourcev1alpha2/resourceclasses"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_resource_v1alpha2 "k8s.io/client-go/kubernetes/typed/resource/v1alpha2"
 	"k8s.io/client-go/rest"
@@ -23,6 +24,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_resource_v1alpha2.Resou
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceV1alpha2Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceV1alpha2Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceV1alpha2Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceV1alpha2Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceV1alpha2Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceV1alpha2Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceV1alpha2Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceV1alpha2Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceV1alpha2Interface
 	metrics    metrics.MetricsConfigManager
@@ -90,3 +107,108 @@ func (c *withLogging) ResourceClaims(namespace string) k8s_io_client_go_kubernet
 func (c *withLogging) ResourceClasses() k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceClassInterface {
 	return resourceclasses.WithLogging(c.inner.ResourceClasses(), c.logger.WithValues("resource", "ResourceClasses"))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceV1alpha2Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) PodSchedulingContexts(namespace string) k8s_io_client_go_kubernetes_typed_resource_v1alpha2.PodSchedulingContextInterface {
+	inner := c.inner.PodSchedulingContexts(namespace)
+	level, ok := c.audit.For("PodSchedulingContext")
+	if !ok {
+		return inner
+	}
+	return podschedulingcontexts.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "PodSchedulingContexts").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) ResourceClaimTemplates(namespace string) k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceClaimTemplateInterface {
+	inner := c.inner.ResourceClaimTemplates(namespace)
+	level, ok := c.audit.For("ResourceClaimTemplate")
+	if !ok {
+		return inner
+	}
+	return resourceclaimtemplates.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ResourceClaimTemplates").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) ResourceClaims(namespace string) k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceClaimInterface {
+	inner := c.inner.ResourceClaims(namespace)
+	level, ok := c.audit.For("ResourceClaim")
+	if !ok {
+		return inner
+	}
+	return resourceclaims.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ResourceClaims").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) ResourceClasses() k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceClassInterface {
+	inner := c.inner.ResourceClasses()
+	level, ok := c.audit.For("ResourceClass")
+	if !ok {
+		return inner
+	}
+	return resourceclasses.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ResourceClasses"))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceV1alpha2Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) PodSchedulingContexts(namespace string) k8s_io_client_go_kubernetes_typed_resource_v1alpha2.PodSchedulingContextInterface {
+	return podschedulingcontexts.WithRateLimiting(c.inner.PodSchedulingContexts(namespace), c.limits.For("PodSchedulingContext"))
+}
+func (c *withRateLimiting) ResourceClaimTemplates(namespace string) k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceClaimTemplateInterface {
+	return resourceclaimtemplates.WithRateLimiting(c.inner.ResourceClaimTemplates(namespace), c.limits.For("ResourceClaimTemplate"))
+}
+func (c *withRateLimiting) ResourceClaims(namespace string) k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceClaimInterface {
+	return resourceclaims.WithRateLimiting(c.inner.ResourceClaims(namespace), c.limits.For("ResourceClaim"))
+}
+func (c *withRateLimiting) ResourceClasses() k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceClassInterface {
+	return resourceclasses.WithRateLimiting(c.inner.ResourceClasses(), c.limits.For("ResourceClass"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceV1alpha2Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) PodSchedulingContexts(namespace string) k8s_io_client_go_kubernetes_typed_resource_v1alpha2.PodSchedulingContextInterface {
+	return podschedulingcontexts.WithRetry(c.inner.PodSchedulingContexts(namespace), c.retries.For("PodSchedulingContext"))
+}
+func (c *withRetry) ResourceClaimTemplates(namespace string) k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceClaimTemplateInterface {
+	return resourceclaimtemplates.WithRetry(c.inner.ResourceClaimTemplates(namespace), c.retries.For("ResourceClaimTemplate"))
+}
+func (c *withRetry) ResourceClaims(namespace string) k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceClaimInterface {
+	return resourceclaims.WithRetry(c.inner.ResourceClaims(namespace), c.retries.For("ResourceClaim"))
+}
+func (c *withRetry) ResourceClasses() k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceClassInterface {
+	return resourceclasses.WithRetry(c.inner.ResourceClasses(), c.retries.For("ResourceClass"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceV1alpha2Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) PodSchedulingContexts(namespace string) k8s_io_client_go_kubernetes_typed_resource_v1alpha2.PodSchedulingContextInterface {
+	return podschedulingcontexts.WithCircuitBreaker(c.inner.PodSchedulingContexts(namespace), c.breakers.For("PodSchedulingContext"))
+}
+func (c *withCircuitBreaker) ResourceClaimTemplates(namespace string) k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceClaimTemplateInterface {
+	return resourceclaimtemplates.WithCircuitBreaker(c.inner.ResourceClaimTemplates(namespace), c.breakers.For("ResourceClaimTemplate"))
+}
+func (c *withCircuitBreaker) ResourceClaims(namespace string) k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceClaimInterface {
+	return resourceclaims.WithCircuitBreaker(c.inner.ResourceClaims(namespace), c.breakers.For("ResourceClaim"))
+}
+func (c *withCircuitBreaker) ResourceClasses() k8s_io_client_go_kubernetes_typed_resource_v1alpha2.ResourceClassInterface {
+	return resourceclasses.WithCircuitBreaker(c.inner.ResourceClasses(), c.breakers.For("ResourceClass"))
+}
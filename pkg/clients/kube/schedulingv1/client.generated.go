@@ -3,6 +3,7 @@ package client
 import (
 	"github.com/go-logr/logr"
 	priorityclasses "github.com/kyverno/kyverno/pkg/clients/kube/schedulingv1/priorityclasses"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_scheduling_v1 "k8s.io/client-go/kubernetes/typed/scheduling/v1"
 	"k8s.io/client-go/rest"
@@ -20,6 +21,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_scheduling_v1.Schedulin
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_scheduling_v1.SchedulingV1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_scheduling_v1.SchedulingV1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_scheduling_v1.SchedulingV1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_scheduling_v1.SchedulingV1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_scheduling_v1.SchedulingV1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_scheduling_v1.SchedulingV1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_scheduling_v1.SchedulingV1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_scheduling_v1.SchedulingV1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_scheduling_v1.SchedulingV1Interface
 	metrics    metrics.MetricsConfigManager
@@ -57,3 +74,57 @@ func (c *withLogging) RESTClient() rest.Interface {
 func (c *withLogging) PriorityClasses() k8s_io_client_go_kubernetes_typed_scheduling_v1.PriorityClassInterface {
 	return priorityclasses.WithLogging(c.inner.PriorityClasses(), c.logger.WithValues("resource", "PriorityClasses"))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_scheduling_v1.SchedulingV1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) PriorityClasses() k8s_io_client_go_kubernetes_typed_scheduling_v1.PriorityClassInterface {
+	inner := c.inner.PriorityClasses()
+	level, ok := c.audit.For("PriorityClass")
+	if !ok {
+		return inner
+	}
+	return priorityclasses.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "PriorityClasses"))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_scheduling_v1.SchedulingV1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) PriorityClasses() k8s_io_client_go_kubernetes_typed_scheduling_v1.PriorityClassInterface {
+	return priorityclasses.WithRateLimiting(c.inner.PriorityClasses(), c.limits.For("PriorityClass"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_scheduling_v1.SchedulingV1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) PriorityClasses() k8s_io_client_go_kubernetes_typed_scheduling_v1.PriorityClassInterface {
+	return priorityclasses.WithRetry(c.inner.PriorityClasses(), c.retries.For("PriorityClass"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_scheduling_v1.SchedulingV1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) PriorityClasses() k8s_io_client_go_kubernetes_typed_scheduling_v1.PriorityClassInterface {
+	return priorityclasses.WithCircuitBreaker(c.inner.PriorityClasses(), c.breakers.For("PriorityClass"))
+}
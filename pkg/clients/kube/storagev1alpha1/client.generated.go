@@ -5,6 +5,7 @@ import (
 	csistoragecapacities "github.com/kyverno/kyverno/pkg/clients/kube/storagev1alpha1/csistoragecapacities"
 	volumeattachments "github.com/kyverno/kyverno/pkg/clients/kube/storagev1alpha1/volumeattachments"
 	volumeattributesclasses "github.com/kyverno/kyverno/pkg/clients/kube/storagev1alpha1/volumeattributesclasses"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_storage_v1alpha1 "k8s.io/client-go/kubernetes/typed/storage/v1alpha1"
 	"k8s.io/client-go/rest"
@@ -22,6 +23,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_storage_v1alpha1.Storag
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_storage_v1alpha1.StorageV1alpha1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_storage_v1alpha1.StorageV1alpha1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_storage_v1alpha1.StorageV1alpha1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_storage_v1alpha1.StorageV1alpha1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_storage_v1alpha1.StorageV1alpha1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_storage_v1alpha1.StorageV1alpha1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_storage_v1alpha1.StorageV1alpha1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_storage_v1alpha1.StorageV1alpha1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_storage_v1alpha1.StorageV1alpha1Interface
 	metrics    metrics.MetricsConfigManager
@@ -79,3 +96,91 @@ func (c *withLogging) VolumeAttachments() k8s_io_client_go_kubernetes_typed_stor
 func (c *withLogging) VolumeAttributesClasses() k8s_io_client_go_kubernetes_typed_storage_v1alpha1.VolumeAttributesClassInterface {
 	return volumeattributesclasses.WithLogging(c.inner.VolumeAttributesClasses(), c.logger.WithValues("resource", "VolumeAttributesClasses"))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_storage_v1alpha1.StorageV1alpha1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) CSIStorageCapacities(namespace string) k8s_io_client_go_kubernetes_typed_storage_v1alpha1.CSIStorageCapacityInterface {
+	inner := c.inner.CSIStorageCapacities(namespace)
+	level, ok := c.audit.For("CSIStorageCapacity")
+	if !ok {
+		return inner
+	}
+	return csistoragecapacities.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "CSIStorageCapacities").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) VolumeAttachments() k8s_io_client_go_kubernetes_typed_storage_v1alpha1.VolumeAttachmentInterface {
+	inner := c.inner.VolumeAttachments()
+	level, ok := c.audit.For("VolumeAttachment")
+	if !ok {
+		return inner
+	}
+	return volumeattachments.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "VolumeAttachments"))
+}
+func (c *withAuditLogging) VolumeAttributesClasses() k8s_io_client_go_kubernetes_typed_storage_v1alpha1.VolumeAttributesClassInterface {
+	inner := c.inner.VolumeAttributesClasses()
+	level, ok := c.audit.For("VolumeAttributesClass")
+	if !ok {
+		return inner
+	}
+	return volumeattributesclasses.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "VolumeAttributesClasses"))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_storage_v1alpha1.StorageV1alpha1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) CSIStorageCapacities(namespace string) k8s_io_client_go_kubernetes_typed_storage_v1alpha1.CSIStorageCapacityInterface {
+	return csistoragecapacities.WithRateLimiting(c.inner.CSIStorageCapacities(namespace), c.limits.For("CSIStorageCapacity"))
+}
+func (c *withRateLimiting) VolumeAttachments() k8s_io_client_go_kubernetes_typed_storage_v1alpha1.VolumeAttachmentInterface {
+	return volumeattachments.WithRateLimiting(c.inner.VolumeAttachments(), c.limits.For("VolumeAttachment"))
+}
+func (c *withRateLimiting) VolumeAttributesClasses() k8s_io_client_go_kubernetes_typed_storage_v1alpha1.VolumeAttributesClassInterface {
+	return volumeattributesclasses.WithRateLimiting(c.inner.VolumeAttributesClasses(), c.limits.For("VolumeAttributesClass"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_storage_v1alpha1.StorageV1alpha1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) CSIStorageCapacities(namespace string) k8s_io_client_go_kubernetes_typed_storage_v1alpha1.CSIStorageCapacityInterface {
+	return csistoragecapacities.WithRetry(c.inner.CSIStorageCapacities(namespace), c.retries.For("CSIStorageCapacity"))
+}
+func (c *withRetry) VolumeAttachments() k8s_io_client_go_kubernetes_typed_storage_v1alpha1.VolumeAttachmentInterface {
+	return volumeattachments.WithRetry(c.inner.VolumeAttachments(), c.retries.For("VolumeAttachment"))
+}
+func (c *withRetry) VolumeAttributesClasses() k8s_io_client_go_kubernetes_typed_storage_v1alpha1.VolumeAttributesClassInterface {
+	return volumeattributesclasses.WithRetry(c.inner.VolumeAttributesClasses(), c.retries.For("VolumeAttributesClass"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_storage_v1alpha1.StorageV1alpha1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) CSIStorageCapacities(namespace string) k8s_io_client_go_kubernetes_typed_storage_v1alpha1.CSIStorageCapacityInterface {
+	return csistoragecapacities.WithCircuitBreaker(c.inner.CSIStorageCapacities(namespace), c.breakers.For("CSIStorageCapacity"))
+}
+func (c *withCircuitBreaker) VolumeAttachments() k8s_io_client_go_kubernetes_typed_storage_v1alpha1.VolumeAttachmentInterface {
+	return volumeattachments.WithCircuitBreaker(c.inner.VolumeAttachments(), c.breakers.For("VolumeAttachment"))
+}
+func (c *withCircuitBreaker) VolumeAttributesClasses() k8s_io_client_go_kubernetes_typed_storage_v1alpha1.VolumeAttributesClassInterface {
+	return volumeattributesclasses.WithCircuitBreaker(c.inner.VolumeAttributesClasses(), c.breakers.For("VolumeAttributesClass"))
+}
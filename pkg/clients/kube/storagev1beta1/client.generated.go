@@ -7,6 +7,7 @@ import (
 	csistoragecapacities "github.com/kyverno/kyverno/pkg/clients/kube/storagev1beta1/csistoragecapacities"
 	storageclasses "github.com/kyverno/kyverno/pkg/clients/kube/storagev1beta1/storageclasses"
 	volumeattachments "github.com/kyverno/kyverno/pkg/clients/kube/storagev1beta1/volumeattachments"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_kubernetes_typed_storage_v1beta1 "k8s.io/client-go/kubernetes/typed/storage/v1beta1"
 	"k8s.io/client-go/rest"
@@ -24,6 +25,22 @@ func WithLogging(inner k8s_io_client_go_kubernetes_typed_storage_v1beta1.Storage
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner k8s_io_client_go_kubernetes_typed_storage_v1beta1.StorageV1beta1Interface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_kubernetes_typed_storage_v1beta1.StorageV1beta1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner k8s_io_client_go_kubernetes_typed_storage_v1beta1.StorageV1beta1Interface, limits *middleware.RateLimits) k8s_io_client_go_kubernetes_typed_storage_v1beta1.StorageV1beta1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner k8s_io_client_go_kubernetes_typed_storage_v1beta1.StorageV1beta1Interface, retries *middleware.Retries) k8s_io_client_go_kubernetes_typed_storage_v1beta1.StorageV1beta1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner k8s_io_client_go_kubernetes_typed_storage_v1beta1.StorageV1beta1Interface, breakers *middleware.CircuitBreakers) k8s_io_client_go_kubernetes_typed_storage_v1beta1.StorageV1beta1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      k8s_io_client_go_kubernetes_typed_storage_v1beta1.StorageV1beta1Interface
 	metrics    metrics.MetricsConfigManager
@@ -101,3 +118,125 @@ func (c *withLogging) StorageClasses() k8s_io_client_go_kubernetes_typed_storage
 func (c *withLogging) VolumeAttachments() k8s_io_client_go_kubernetes_typed_storage_v1beta1.VolumeAttachmentInterface {
 	return volumeattachments.WithLogging(c.inner.VolumeAttachments(), c.logger.WithValues("resource", "VolumeAttachments"))
 }
+
+type withAuditLogging struct {
+	inner  k8s_io_client_go_kubernetes_typed_storage_v1beta1.StorageV1beta1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) CSIDrivers() k8s_io_client_go_kubernetes_typed_storage_v1beta1.CSIDriverInterface {
+	inner := c.inner.CSIDrivers()
+	level, ok := c.audit.For("CSIDriver")
+	if !ok {
+		return inner
+	}
+	return csidrivers.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "CSIDrivers"))
+}
+func (c *withAuditLogging) CSINodes() k8s_io_client_go_kubernetes_typed_storage_v1beta1.CSINodeInterface {
+	inner := c.inner.CSINodes()
+	level, ok := c.audit.For("CSINode")
+	if !ok {
+		return inner
+	}
+	return csinodes.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "CSINodes"))
+}
+func (c *withAuditLogging) CSIStorageCapacities(namespace string) k8s_io_client_go_kubernetes_typed_storage_v1beta1.CSIStorageCapacityInterface {
+	inner := c.inner.CSIStorageCapacities(namespace)
+	level, ok := c.audit.For("CSIStorageCapacity")
+	if !ok {
+		return inner
+	}
+	return csistoragecapacities.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "CSIStorageCapacities").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) StorageClasses() k8s_io_client_go_kubernetes_typed_storage_v1beta1.StorageClassInterface {
+	inner := c.inner.StorageClasses()
+	level, ok := c.audit.For("StorageClass")
+	if !ok {
+		return inner
+	}
+	return storageclasses.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "StorageClasses"))
+}
+func (c *withAuditLogging) VolumeAttachments() k8s_io_client_go_kubernetes_typed_storage_v1beta1.VolumeAttachmentInterface {
+	inner := c.inner.VolumeAttachments()
+	level, ok := c.audit.For("VolumeAttachment")
+	if !ok {
+		return inner
+	}
+	return volumeattachments.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "VolumeAttachments"))
+}
+
+type withRateLimiting struct {
+	inner  k8s_io_client_go_kubernetes_typed_storage_v1beta1.StorageV1beta1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) CSIDrivers() k8s_io_client_go_kubernetes_typed_storage_v1beta1.CSIDriverInterface {
+	return csidrivers.WithRateLimiting(c.inner.CSIDrivers(), c.limits.For("CSIDriver"))
+}
+func (c *withRateLimiting) CSINodes() k8s_io_client_go_kubernetes_typed_storage_v1beta1.CSINodeInterface {
+	return csinodes.WithRateLimiting(c.inner.CSINodes(), c.limits.For("CSINode"))
+}
+func (c *withRateLimiting) CSIStorageCapacities(namespace string) k8s_io_client_go_kubernetes_typed_storage_v1beta1.CSIStorageCapacityInterface {
+	return csistoragecapacities.WithRateLimiting(c.inner.CSIStorageCapacities(namespace), c.limits.For("CSIStorageCapacity"))
+}
+func (c *withRateLimiting) StorageClasses() k8s_io_client_go_kubernetes_typed_storage_v1beta1.StorageClassInterface {
+	return storageclasses.WithRateLimiting(c.inner.StorageClasses(), c.limits.For("StorageClass"))
+}
+func (c *withRateLimiting) VolumeAttachments() k8s_io_client_go_kubernetes_typed_storage_v1beta1.VolumeAttachmentInterface {
+	return volumeattachments.WithRateLimiting(c.inner.VolumeAttachments(), c.limits.For("VolumeAttachment"))
+}
+
+type withRetry struct {
+	inner   k8s_io_client_go_kubernetes_typed_storage_v1beta1.StorageV1beta1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) CSIDrivers() k8s_io_client_go_kubernetes_typed_storage_v1beta1.CSIDriverInterface {
+	return csidrivers.WithRetry(c.inner.CSIDrivers(), c.retries.For("CSIDriver"))
+}
+func (c *withRetry) CSINodes() k8s_io_client_go_kubernetes_typed_storage_v1beta1.CSINodeInterface {
+	return csinodes.WithRetry(c.inner.CSINodes(), c.retries.For("CSINode"))
+}
+func (c *withRetry) CSIStorageCapacities(namespace string) k8s_io_client_go_kubernetes_typed_storage_v1beta1.CSIStorageCapacityInterface {
+	return csistoragecapacities.WithRetry(c.inner.CSIStorageCapacities(namespace), c.retries.For("CSIStorageCapacity"))
+}
+func (c *withRetry) StorageClasses() k8s_io_client_go_kubernetes_typed_storage_v1beta1.StorageClassInterface {
+	return storageclasses.WithRetry(c.inner.StorageClasses(), c.retries.For("StorageClass"))
+}
+func (c *withRetry) VolumeAttachments() k8s_io_client_go_kubernetes_typed_storage_v1beta1.VolumeAttachmentInterface {
+	return volumeattachments.WithRetry(c.inner.VolumeAttachments(), c.retries.For("VolumeAttachment"))
+}
+
+type withCircuitBreaker struct {
+	inner    k8s_io_client_go_kubernetes_typed_storage_v1beta1.StorageV1beta1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) CSIDrivers() k8s_io_client_go_kubernetes_typed_storage_v1beta1.CSIDriverInterface {
+	return csidrivers.WithCircuitBreaker(c.inner.CSIDrivers(), c.breakers.For("CSIDriver"))
+}
+func (c *withCircuitBreaker) CSINodes() k8s_io_client_go_kubernetes_typed_storage_v1beta1.CSINodeInterface {
+	return csinodes.WithCircuitBreaker(c.inner.CSINodes(), c.breakers.For("CSINode"))
+}
+func (c *withCircuitBreaker) CSIStorageCapacities(namespace string) k8s_io_client_go_kubernetes_typed_storage_v1beta1.CSIStorageCapacityInterface {
+	return csistoragecapacities.WithCircuitBreaker(c.inner.CSIStorageCapacities(namespace), c.breakers.For("CSIStorageCapacity"))
+}
+func (c *withCircuitBreaker) StorageClasses() k8s_io_client_go_kubernetes_typed_storage_v1beta1.StorageClassInterface {
+	return storageclasses.WithCircuitBreaker(c.inner.StorageClasses(), c.breakers.For("StorageClass"))
+}
+func (c *withCircuitBreaker) VolumeAttachments() k8s_io_client_go_kubernetes_typed_storage_v1beta1.VolumeAttachmentInterface {
+	return volumeattachments.WithCircuitBreaker(c.inner.VolumeAttachments(), c.breakers.For("VolumeAttachment"))
+}
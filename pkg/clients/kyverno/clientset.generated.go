@@ -18,6 +18,7 @@ import (
 	kyvernov2alpha1 "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1"
 	kyvernov2beta1 "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2beta1"
 	wgpolicyk8sv1alpha2 "github.com/kyverno/kyverno/pkg/clients/kyverno/wgpolicyk8sv1alpha2"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	k8s_io_client_go_discovery "k8s.io/client-go/discovery"
 )
@@ -96,3 +97,62 @@ func WrapWithLogging(inner github_com_kyverno_kyverno_pkg_client_clientset_versi
 		wgpolicyk8sv1alpha2: wgpolicyk8sv1alpha2.WithLogging(inner.Wgpolicyk8sV1alpha2(), logger.WithValues("group", "Wgpolicyk8sV1alpha2")),
 	}
 }
+func WrapWithAuditLogging(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned.Interface, logger logr.Logger, audit *middleware.AuditLogging) github_com_kyverno_kyverno_pkg_client_clientset_versioned.Interface {
+	return &clientset{
+		discovery:           auditLoggingDiscovery(inner.Discovery(), logger.WithValues("group", "Discovery"), audit),
+		kyvernov1:           kyvernov1.WithAuditLogging(inner.KyvernoV1(), logger.WithValues("group", "KyvernoV1"), audit),
+		kyvernov1alpha2:     kyvernov1alpha2.WithAuditLogging(inner.KyvernoV1alpha2(), logger.WithValues("group", "KyvernoV1alpha2"), audit),
+		kyvernov1beta1:      kyvernov1beta1.WithAuditLogging(inner.KyvernoV1beta1(), logger.WithValues("group", "KyvernoV1beta1"), audit),
+		kyvernov2:           kyvernov2.WithAuditLogging(inner.KyvernoV2(), logger.WithValues("group", "KyvernoV2"), audit),
+		kyvernov2alpha1:     kyvernov2alpha1.WithAuditLogging(inner.KyvernoV2alpha1(), logger.WithValues("group", "KyvernoV2alpha1"), audit),
+		kyvernov2beta1:      kyvernov2beta1.WithAuditLogging(inner.KyvernoV2beta1(), logger.WithValues("group", "KyvernoV2beta1"), audit),
+		wgpolicyk8sv1alpha2: wgpolicyk8sv1alpha2.WithAuditLogging(inner.Wgpolicyk8sV1alpha2(), logger.WithValues("group", "Wgpolicyk8sV1alpha2"), audit),
+	}
+}
+
+func auditLoggingDiscovery(inner k8s_io_client_go_discovery.DiscoveryInterface, logger logr.Logger, audit *middleware.AuditLogging) k8s_io_client_go_discovery.DiscoveryInterface {
+	level, ok := audit.For("Discovery")
+	if !ok {
+		return inner
+	}
+	return discovery.WithAuditLogging(inner, logger.V(level))
+}
+
+func WrapWithRateLimiting(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned.Interface, limits *middleware.RateLimits) github_com_kyverno_kyverno_pkg_client_clientset_versioned.Interface {
+	return &clientset{
+		discovery:           discovery.WithRateLimiting(inner.Discovery(), limits.For("Discovery")),
+		kyvernov1:           kyvernov1.WithRateLimiting(inner.KyvernoV1(), limits),
+		kyvernov1alpha2:     kyvernov1alpha2.WithRateLimiting(inner.KyvernoV1alpha2(), limits),
+		kyvernov1beta1:      kyvernov1beta1.WithRateLimiting(inner.KyvernoV1beta1(), limits),
+		kyvernov2:           kyvernov2.WithRateLimiting(inner.KyvernoV2(), limits),
+		kyvernov2alpha1:     kyvernov2alpha1.WithRateLimiting(inner.KyvernoV2alpha1(), limits),
+		kyvernov2beta1:      kyvernov2beta1.WithRateLimiting(inner.KyvernoV2beta1(), limits),
+		wgpolicyk8sv1alpha2: wgpolicyk8sv1alpha2.WithRateLimiting(inner.Wgpolicyk8sV1alpha2(), limits),
+	}
+}
+
+func WrapWithRetry(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned.Interface, retries *middleware.Retries) github_com_kyverno_kyverno_pkg_client_clientset_versioned.Interface {
+	return &clientset{
+		discovery:           discovery.WithRetry(inner.Discovery(), retries.For("Discovery")),
+		kyvernov1:           kyvernov1.WithRetry(inner.KyvernoV1(), retries),
+		kyvernov1alpha2:     kyvernov1alpha2.WithRetry(inner.KyvernoV1alpha2(), retries),
+		kyvernov1beta1:      kyvernov1beta1.WithRetry(inner.KyvernoV1beta1(), retries),
+		kyvernov2:           kyvernov2.WithRetry(inner.KyvernoV2(), retries),
+		kyvernov2alpha1:     kyvernov2alpha1.WithRetry(inner.KyvernoV2alpha1(), retries),
+		kyvernov2beta1:      kyvernov2beta1.WithRetry(inner.KyvernoV2beta1(), retries),
+		wgpolicyk8sv1alpha2: wgpolicyk8sv1alpha2.WithRetry(inner.Wgpolicyk8sV1alpha2(), retries),
+	}
+}
+
+func WrapWithCircuitBreaker(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned.Interface, breakers *middleware.CircuitBreakers) github_com_kyverno_kyverno_pkg_client_clientset_versioned.Interface {
+	return &clientset{
+		discovery:           discovery.WithCircuitBreaker(inner.Discovery(), breakers.For("Discovery")),
+		kyvernov1:           kyvernov1.WithCircuitBreaker(inner.KyvernoV1(), breakers),
+		kyvernov1alpha2:     kyvernov1alpha2.WithCircuitBreaker(inner.KyvernoV1alpha2(), breakers),
+		kyvernov1beta1:      kyvernov1beta1.WithCircuitBreaker(inner.KyvernoV1beta1(), breakers),
+		kyvernov2:           kyvernov2.WithCircuitBreaker(inner.KyvernoV2(), breakers),
+		kyvernov2alpha1:     kyvernov2alpha1.WithCircuitBreaker(inner.KyvernoV2alpha1(), breakers),
+		kyvernov2beta1:      kyvernov2beta1.WithCircuitBreaker(inner.KyvernoV2beta1(), breakers),
+		wgpolicyk8sv1alpha2: wgpolicyk8sv1alpha2.WithCircuitBreaker(inner.Wgpolicyk8sV1alpha2(), breakers),
+	}
+}
@@ -5,6 +5,7 @@ import (
 	github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1 "github.com/kyverno/kyverno/pkg/client/clientset/versioned/typed/kyverno/v1"
 	clusterpolicies "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov1/clusterpolicies"
 	policies "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov1/policies"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"k8s.io/client-go/rest"
 )
@@ -21,6 +22,22 @@ func WithLogging(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1.KyvernoV1Interface, logger logr.Logger, audit *middleware.AuditLogging) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1.KyvernoV1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1.KyvernoV1Interface, limits *middleware.RateLimits) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1.KyvernoV1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1.KyvernoV1Interface, retries *middleware.Retries) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1.KyvernoV1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1.KyvernoV1Interface, breakers *middleware.CircuitBreakers) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1.KyvernoV1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1.KyvernoV1Interface
 	metrics    metrics.MetricsConfigManager
@@ -68,3 +85,74 @@ func (c *withLogging) ClusterPolicies() github_com_kyverno_kyverno_pkg_client_cl
 func (c *withLogging) Policies(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1.PolicyInterface {
 	return policies.WithLogging(c.inner.Policies(namespace), c.logger.WithValues("resource", "Policies").WithValues("namespace", namespace))
 }
+
+type withAuditLogging struct {
+	inner  github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1.KyvernoV1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) ClusterPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1.ClusterPolicyInterface {
+	inner := c.inner.ClusterPolicies()
+	level, ok := c.audit.For("ClusterPolicy")
+	if !ok {
+		return inner
+	}
+	return clusterpolicies.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ClusterPolicies"))
+}
+func (c *withAuditLogging) Policies(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1.PolicyInterface {
+	inner := c.inner.Policies(namespace)
+	level, ok := c.audit.For("Policy")
+	if !ok {
+		return inner
+	}
+	return policies.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "Policies").WithValues("namespace", namespace))
+}
+
+type withRateLimiting struct {
+	inner  github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1.KyvernoV1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) ClusterPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1.ClusterPolicyInterface {
+	return clusterpolicies.WithRateLimiting(c.inner.ClusterPolicies(), c.limits.For("ClusterPolicy"))
+}
+func (c *withRateLimiting) Policies(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1.PolicyInterface {
+	return policies.WithRateLimiting(c.inner.Policies(namespace), c.limits.For("Policy"))
+}
+
+type withRetry struct {
+	inner   github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1.KyvernoV1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) ClusterPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1.ClusterPolicyInterface {
+	return clusterpolicies.WithRetry(c.inner.ClusterPolicies(), c.retries.For("ClusterPolicy"))
+}
+func (c *withRetry) Policies(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1.PolicyInterface {
+	return policies.WithRetry(c.inner.Policies(namespace), c.retries.For("Policy"))
+}
+
+type withCircuitBreaker struct {
+	inner    github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1.KyvernoV1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) ClusterPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1.ClusterPolicyInterface {
+	return clusterpolicies.WithCircuitBreaker(c.inner.ClusterPolicies(), c.breakers.For("ClusterPolicy"))
+}
+func (c *withCircuitBreaker) Policies(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1.PolicyInterface {
+	return policies.WithCircuitBreaker(c.inner.Policies(namespace), c.breakers.For("Policy"))
+}
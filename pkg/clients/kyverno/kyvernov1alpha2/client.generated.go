@@ -7,6 +7,7 @@ import (
 	backgroundscanreports "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov1alpha2/backgroundscanreports"
 	clusteradmissionreports "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov1alpha2/clusteradmissionreports"
 	clusterbackgroundscanreports "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov1alpha2/clusterbackgroundscanreports"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"k8s.io/client-go/rest"
 )
@@ -23,6 +24,22 @@ func WithLogging(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.KyvernoV1alpha2Interface, logger logr.Logger, audit *middleware.AuditLogging) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.KyvernoV1alpha2Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.KyvernoV1alpha2Interface, limits *middleware.RateLimits) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.KyvernoV1alpha2Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.KyvernoV1alpha2Interface, retries *middleware.Retries) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.KyvernoV1alpha2Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.KyvernoV1alpha2Interface, breakers *middleware.CircuitBreakers) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.KyvernoV1alpha2Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.KyvernoV1alpha2Interface
 	metrics    metrics.MetricsConfigManager
@@ -90,3 +107,108 @@ func (c *withLogging) ClusterAdmissionReports() github_com_kyverno_kyverno_pkg_c
 func (c *withLogging) ClusterBackgroundScanReports() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.ClusterBackgroundScanReportInterface {
 	return clusterbackgroundscanreports.WithLogging(c.inner.ClusterBackgroundScanReports(), c.logger.WithValues("resource", "ClusterBackgroundScanReports"))
 }
+
+type withAuditLogging struct {
+	inner  github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.KyvernoV1alpha2Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) AdmissionReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.AdmissionReportInterface {
+	inner := c.inner.AdmissionReports(namespace)
+	level, ok := c.audit.For("AdmissionReport")
+	if !ok {
+		return inner
+	}
+	return admissionreports.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "AdmissionReports").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) BackgroundScanReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.BackgroundScanReportInterface {
+	inner := c.inner.BackgroundScanReports(namespace)
+	level, ok := c.audit.For("BackgroundScanReport")
+	if !ok {
+		return inner
+	}
+	return backgroundscanreports.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "BackgroundScanReports").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) ClusterAdmissionReports() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.ClusterAdmissionReportInterface {
+	inner := c.inner.ClusterAdmissionReports()
+	level, ok := c.audit.For("ClusterAdmissionReport")
+	if !ok {
+		return inner
+	}
+	return clusteradmissionreports.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ClusterAdmissionReports"))
+}
+func (c *withAuditLogging) ClusterBackgroundScanReports() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.ClusterBackgroundScanReportInterface {
+	inner := c.inner.ClusterBackgroundScanReports()
+	level, ok := c.audit.For("ClusterBackgroundScanReport")
+	if !ok {
+		return inner
+	}
+	return clusterbackgroundscanreports.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ClusterBackgroundScanReports"))
+}
+
+type withRateLimiting struct {
+	inner  github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.KyvernoV1alpha2Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) AdmissionReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.AdmissionReportInterface {
+	return admissionreports.WithRateLimiting(c.inner.AdmissionReports(namespace), c.limits.For("AdmissionReport"))
+}
+func (c *withRateLimiting) BackgroundScanReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.BackgroundScanReportInterface {
+	return backgroundscanreports.WithRateLimiting(c.inner.BackgroundScanReports(namespace), c.limits.For("BackgroundScanReport"))
+}
+func (c *withRateLimiting) ClusterAdmissionReports() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.ClusterAdmissionReportInterface {
+	return clusteradmissionreports.WithRateLimiting(c.inner.ClusterAdmissionReports(), c.limits.For("ClusterAdmissionReport"))
+}
+func (c *withRateLimiting) ClusterBackgroundScanReports() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.ClusterBackgroundScanReportInterface {
+	return clusterbackgroundscanreports.WithRateLimiting(c.inner.ClusterBackgroundScanReports(), c.limits.For("ClusterBackgroundScanReport"))
+}
+
+type withRetry struct {
+	inner   github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.KyvernoV1alpha2Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) AdmissionReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.AdmissionReportInterface {
+	return admissionreports.WithRetry(c.inner.AdmissionReports(namespace), c.retries.For("AdmissionReport"))
+}
+func (c *withRetry) BackgroundScanReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.BackgroundScanReportInterface {
+	return backgroundscanreports.WithRetry(c.inner.BackgroundScanReports(namespace), c.retries.For("BackgroundScanReport"))
+}
+func (c *withRetry) ClusterAdmissionReports() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.ClusterAdmissionReportInterface {
+	return clusteradmissionreports.WithRetry(c.inner.ClusterAdmissionReports(), c.retries.For("ClusterAdmissionReport"))
+}
+func (c *withRetry) ClusterBackgroundScanReports() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.ClusterBackgroundScanReportInterface {
+	return clusterbackgroundscanreports.WithRetry(c.inner.ClusterBackgroundScanReports(), c.retries.For("ClusterBackgroundScanReport"))
+}
+
+type withCircuitBreaker struct {
+	inner    github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.KyvernoV1alpha2Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) AdmissionReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.AdmissionReportInterface {
+	return admissionreports.WithCircuitBreaker(c.inner.AdmissionReports(namespace), c.breakers.For("AdmissionReport"))
+}
+func (c *withCircuitBreaker) BackgroundScanReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.BackgroundScanReportInterface {
+	return backgroundscanreports.WithCircuitBreaker(c.inner.BackgroundScanReports(namespace), c.breakers.For("BackgroundScanReport"))
+}
+func (c *withCircuitBreaker) ClusterAdmissionReports() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.ClusterAdmissionReportInterface {
+	return clusteradmissionreports.WithCircuitBreaker(c.inner.ClusterAdmissionReports(), c.breakers.For("ClusterAdmissionReport"))
+}
+func (c *withCircuitBreaker) ClusterBackgroundScanReports() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v1alpha2.ClusterBackgroundScanReportInterface {
+	return clusterbackgroundscanreports.WithCircuitBreaker(c.inner.ClusterBackgroundScanReports(), c.breakers.For("ClusterBackgroundScanReport"))
+}
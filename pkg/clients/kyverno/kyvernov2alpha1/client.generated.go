@@ -4,8 +4,20 @@ import (
 	"github.com/go-logr/logr"
 	github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1 "github.com/kyverno/kyverno/pkg/client/clientset/versioned/typed/kyverno/v2alpha1"
 	cleanuppolicies "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/cleanuppolicies"
+	cleanuppreviews "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/cleanuppreviews"
+	cleanupreports "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/cleanupreports"
+	clusterbaselines "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/clusterbaselines"
 	clustercleanuppolicies "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/clustercleanuppolicies"
+	clustercompliancesummaries "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/clustercompliancesummaries"
+	compliancescans "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/compliancescans"
+	exclusionpolicies "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/exclusionpolicies"
+	policybindings "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/policybindings"
 	policyexceptions "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/policyexceptions"
+	policyprofiles "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/policyprofiles"
+	policysets "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/policysets"
+	policysimulations "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/policysimulations"
+	validatingpolicies "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2alpha1/validatingpolicies"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"k8s.io/client-go/rest"
 )
@@ -22,6 +34,22 @@ func WithLogging(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface, logger logr.Logger, audit *middleware.AuditLogging) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface, limits *middleware.RateLimits) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface, retries *middleware.Retries) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface, breakers *middleware.CircuitBreakers) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface
 	metrics    metrics.MetricsConfigManager
@@ -35,14 +63,59 @@ func (c *withMetrics) CleanupPolicies(namespace string) github_com_kyverno_kyver
 	recorder := metrics.NamespacedClientQueryRecorder(c.metrics, namespace, "CleanupPolicy", c.clientType)
 	return cleanuppolicies.WithMetrics(c.inner.CleanupPolicies(namespace), recorder)
 }
+func (c *withMetrics) CleanupPreviews(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.CleanupPreviewInterface {
+	recorder := metrics.NamespacedClientQueryRecorder(c.metrics, namespace, "CleanupPreview", c.clientType)
+	return cleanuppreviews.WithMetrics(c.inner.CleanupPreviews(namespace), recorder)
+}
+func (c *withMetrics) CleanupReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.CleanupReportInterface {
+	recorder := metrics.NamespacedClientQueryRecorder(c.metrics, namespace, "CleanupReport", c.clientType)
+	return cleanupreports.WithMetrics(c.inner.CleanupReports(namespace), recorder)
+}
+func (c *withMetrics) ClusterBaselines() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterBaselineInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "ClusterBaseline", c.clientType)
+	return clusterbaselines.WithMetrics(c.inner.ClusterBaselines(), recorder)
+}
 func (c *withMetrics) ClusterCleanupPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterCleanupPolicyInterface {
 	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "ClusterCleanupPolicy", c.clientType)
 	return clustercleanuppolicies.WithMetrics(c.inner.ClusterCleanupPolicies(), recorder)
 }
+func (c *withMetrics) ClusterComplianceSummaries() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterComplianceSummaryInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "ClusterComplianceSummary", c.clientType)
+	return clustercompliancesummaries.WithMetrics(c.inner.ClusterComplianceSummaries(), recorder)
+}
+func (c *withMetrics) ComplianceScans() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ComplianceScanInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "ComplianceScan", c.clientType)
+	return compliancescans.WithMetrics(c.inner.ComplianceScans(), recorder)
+}
+func (c *withMetrics) ExclusionPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ExclusionPolicyInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "ExclusionPolicy", c.clientType)
+	return exclusionpolicies.WithMetrics(c.inner.ExclusionPolicies(), recorder)
+}
+func (c *withMetrics) PolicyBindings() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyBindingInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "PolicyBinding", c.clientType)
+	return policybindings.WithMetrics(c.inner.PolicyBindings(), recorder)
+}
 func (c *withMetrics) PolicyExceptions(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyExceptionInterface {
 	recorder := metrics.NamespacedClientQueryRecorder(c.metrics, namespace, "PolicyException", c.clientType)
 	return policyexceptions.WithMetrics(c.inner.PolicyExceptions(namespace), recorder)
 }
+func (c *withMetrics) PolicyProfiles() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyProfileInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "PolicyProfile", c.clientType)
+	return policyprofiles.WithMetrics(c.inner.PolicyProfiles(), recorder)
+}
+
+func (c *withMetrics) PolicySets() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySetInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "PolicySet", c.clientType)
+	return policysets.WithMetrics(c.inner.PolicySets(), recorder)
+}
+func (c *withMetrics) PolicySimulations() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySimulationInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "PolicySimulation", c.clientType)
+	return policysimulations.WithMetrics(c.inner.PolicySimulations(), recorder)
+}
+func (c *withMetrics) ValidatingPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ValidatingPolicyInterface {
+	recorder := metrics.ClusteredClientQueryRecorder(c.metrics, "ValidatingPolicy", c.clientType)
+	return validatingpolicies.WithMetrics(c.inner.ValidatingPolicies(), recorder)
+}
 
 type withTracing struct {
 	inner  github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface
@@ -55,12 +128,46 @@ func (c *withTracing) RESTClient() rest.Interface {
 func (c *withTracing) CleanupPolicies(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.CleanupPolicyInterface {
 	return cleanuppolicies.WithTracing(c.inner.CleanupPolicies(namespace), c.client, "CleanupPolicy")
 }
+func (c *withTracing) CleanupPreviews(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.CleanupPreviewInterface {
+	return cleanuppreviews.WithTracing(c.inner.CleanupPreviews(namespace), c.client, "CleanupPreview")
+}
+func (c *withTracing) CleanupReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.CleanupReportInterface {
+	return cleanupreports.WithTracing(c.inner.CleanupReports(namespace), c.client, "CleanupReport")
+}
+func (c *withTracing) ClusterBaselines() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterBaselineInterface {
+	return clusterbaselines.WithTracing(c.inner.ClusterBaselines(), c.client, "ClusterBaseline")
+}
 func (c *withTracing) ClusterCleanupPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterCleanupPolicyInterface {
 	return clustercleanuppolicies.WithTracing(c.inner.ClusterCleanupPolicies(), c.client, "ClusterCleanupPolicy")
 }
+func (c *withTracing) ClusterComplianceSummaries() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterComplianceSummaryInterface {
+	return clustercompliancesummaries.WithTracing(c.inner.ClusterComplianceSummaries(), c.client, "ClusterComplianceSummary")
+}
+func (c *withTracing) ComplianceScans() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ComplianceScanInterface {
+	return compliancescans.WithTracing(c.inner.ComplianceScans(), c.client, "ComplianceScan")
+}
+func (c *withTracing) ExclusionPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ExclusionPolicyInterface {
+	return exclusionpolicies.WithTracing(c.inner.ExclusionPolicies(), c.client, "ExclusionPolicy")
+}
+func (c *withTracing) PolicyBindings() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyBindingInterface {
+	return policybindings.WithTracing(c.inner.PolicyBindings(), c.client, "PolicyBinding")
+}
 func (c *withTracing) PolicyExceptions(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyExceptionInterface {
 	return policyexceptions.WithTracing(c.inner.PolicyExceptions(namespace), c.client, "PolicyException")
 }
+func (c *withTracing) PolicyProfiles() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyProfileInterface {
+	return policyprofiles.WithTracing(c.inner.PolicyProfiles(), c.client, "PolicyProfile")
+}
+
+func (c *withTracing) PolicySets() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySetInterface {
+	return policysets.WithTracing(c.inner.PolicySets(), c.client, "PolicySet")
+}
+func (c *withTracing) PolicySimulations() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySimulationInterface {
+	return policysimulations.WithTracing(c.inner.PolicySimulations(), c.client, "PolicySimulation")
+}
+func (c *withTracing) ValidatingPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ValidatingPolicyInterface {
+	return validatingpolicies.WithTracing(c.inner.ValidatingPolicies(), c.client, "ValidatingPolicy")
+}
 
 type withLogging struct {
 	inner  github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface
@@ -73,9 +180,322 @@ func (c *withLogging) RESTClient() rest.Interface {
 func (c *withLogging) CleanupPolicies(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.CleanupPolicyInterface {
 	return cleanuppolicies.WithLogging(c.inner.CleanupPolicies(namespace), c.logger.WithValues("resource", "CleanupPolicies").WithValues("namespace", namespace))
 }
+func (c *withLogging) CleanupPreviews(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.CleanupPreviewInterface {
+	return cleanuppreviews.WithLogging(c.inner.CleanupPreviews(namespace), c.logger.WithValues("resource", "CleanupPreviews").WithValues("namespace", namespace))
+}
+func (c *withLogging) CleanupReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.CleanupReportInterface {
+	return cleanupreports.WithLogging(c.inner.CleanupReports(namespace), c.logger.WithValues("resource", "CleanupReports").WithValues("namespace", namespace))
+}
+func (c *withLogging) ClusterBaselines() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterBaselineInterface {
+	return clusterbaselines.WithLogging(c.inner.ClusterBaselines(), c.logger.WithValues("resource", "ClusterBaselines"))
+}
 func (c *withLogging) ClusterCleanupPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterCleanupPolicyInterface {
 	return clustercleanuppolicies.WithLogging(c.inner.ClusterCleanupPolicies(), c.logger.WithValues("resource", "ClusterCleanupPolicies"))
 }
+func (c *withLogging) ClusterComplianceSummaries() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterComplianceSummaryInterface {
+	return clustercompliancesummaries.WithLogging(c.inner.ClusterComplianceSummaries(), c.logger.WithValues("resource", "ClusterComplianceSummaries"))
+}
+func (c *withLogging) ComplianceScans() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ComplianceScanInterface {
+	return compliancescans.WithLogging(c.inner.ComplianceScans(), c.logger.WithValues("resource", "ComplianceScans"))
+}
+func (c *withLogging) ExclusionPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ExclusionPolicyInterface {
+	return exclusionpolicies.WithLogging(c.inner.ExclusionPolicies(), c.logger.WithValues("resource", "ExclusionPolicies"))
+}
+func (c *withLogging) PolicyBindings() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyBindingInterface {
+	return policybindings.WithLogging(c.inner.PolicyBindings(), c.logger.WithValues("resource", "PolicyBindings"))
+}
 func (c *withLogging) PolicyExceptions(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyExceptionInterface {
 	return policyexceptions.WithLogging(c.inner.PolicyExceptions(namespace), c.logger.WithValues("resource", "PolicyExceptions").WithValues("namespace", namespace))
 }
+func (c *withLogging) PolicyProfiles() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyProfileInterface {
+	return policyprofiles.WithLogging(c.inner.PolicyProfiles(), c.logger.WithValues("resource", "PolicyProfiles"))
+}
+
+func (c *withLogging) PolicySets() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySetInterface {
+	return policysets.WithLogging(c.inner.PolicySets(), c.logger.WithValues("resource", "PolicySets"))
+}
+func (c *withLogging) PolicySimulations() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySimulationInterface {
+	return policysimulations.WithLogging(c.inner.PolicySimulations(), c.logger.WithValues("resource", "PolicySimulations"))
+}
+func (c *withLogging) ValidatingPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ValidatingPolicyInterface {
+	return validatingpolicies.WithLogging(c.inner.ValidatingPolicies(), c.logger.WithValues("resource", "ValidatingPolicies"))
+}
+
+type withAuditLogging struct {
+	inner  github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) CleanupPolicies(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.CleanupPolicyInterface {
+	inner := c.inner.CleanupPolicies(namespace)
+	level, ok := c.audit.For("CleanupPolicy")
+	if !ok {
+		return inner
+	}
+	return cleanuppolicies.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "CleanupPolicies").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) CleanupPreviews(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.CleanupPreviewInterface {
+	inner := c.inner.CleanupPreviews(namespace)
+	level, ok := c.audit.For("CleanupPreview")
+	if !ok {
+		return inner
+	}
+	return cleanuppreviews.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "CleanupPreviews").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) CleanupReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.CleanupReportInterface {
+	inner := c.inner.CleanupReports(namespace)
+	level, ok := c.audit.For("CleanupReport")
+	if !ok {
+		return inner
+	}
+	return cleanupreports.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "CleanupReports").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) ClusterBaselines() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterBaselineInterface {
+	inner := c.inner.ClusterBaselines()
+	level, ok := c.audit.For("ClusterBaseline")
+	if !ok {
+		return inner
+	}
+	return clusterbaselines.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ClusterBaselines"))
+}
+func (c *withAuditLogging) ClusterCleanupPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterCleanupPolicyInterface {
+	inner := c.inner.ClusterCleanupPolicies()
+	level, ok := c.audit.For("ClusterCleanupPolicy")
+	if !ok {
+		return inner
+	}
+	return clustercleanuppolicies.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ClusterCleanupPolicies"))
+}
+func (c *withAuditLogging) ClusterComplianceSummaries() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterComplianceSummaryInterface {
+	inner := c.inner.ClusterComplianceSummaries()
+	level, ok := c.audit.For("ClusterComplianceSummary")
+	if !ok {
+		return inner
+	}
+	return clustercompliancesummaries.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ClusterComplianceSummaries"))
+}
+func (c *withAuditLogging) ComplianceScans() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ComplianceScanInterface {
+	inner := c.inner.ComplianceScans()
+	level, ok := c.audit.For("ComplianceScan")
+	if !ok {
+		return inner
+	}
+	return compliancescans.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ComplianceScans"))
+}
+func (c *withAuditLogging) ExclusionPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ExclusionPolicyInterface {
+	inner := c.inner.ExclusionPolicies()
+	level, ok := c.audit.For("ExclusionPolicy")
+	if !ok {
+		return inner
+	}
+	return exclusionpolicies.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ExclusionPolicies"))
+}
+func (c *withAuditLogging) PolicyBindings() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyBindingInterface {
+	inner := c.inner.PolicyBindings()
+	level, ok := c.audit.For("PolicyBinding")
+	if !ok {
+		return inner
+	}
+	return policybindings.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "PolicyBindings"))
+}
+func (c *withAuditLogging) PolicyExceptions(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyExceptionInterface {
+	inner := c.inner.PolicyExceptions(namespace)
+	level, ok := c.audit.For("PolicyException")
+	if !ok {
+		return inner
+	}
+	return policyexceptions.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "PolicyExceptions").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) PolicyProfiles() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyProfileInterface {
+	inner := c.inner.PolicyProfiles()
+	level, ok := c.audit.For("PolicyProfile")
+	if !ok {
+		return inner
+	}
+	return policyprofiles.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "PolicyProfiles"))
+}
+
+func (c *withAuditLogging) PolicySets() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySetInterface {
+	inner := c.inner.PolicySets()
+	level, ok := c.audit.For("PolicySet")
+	if !ok {
+		return inner
+	}
+	return policysets.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "PolicySets"))
+}
+func (c *withAuditLogging) PolicySimulations() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySimulationInterface {
+	inner := c.inner.PolicySimulations()
+	level, ok := c.audit.For("PolicySimulation")
+	if !ok {
+		return inner
+	}
+	return policysimulations.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "PolicySimulations"))
+}
+func (c *withAuditLogging) ValidatingPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ValidatingPolicyInterface {
+	inner := c.inner.ValidatingPolicies()
+	level, ok := c.audit.For("ValidatingPolicy")
+	if !ok {
+		return inner
+	}
+	return validatingpolicies.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ValidatingPolicies"))
+}
+
+type withRateLimiting struct {
+	inner  github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) CleanupPolicies(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.CleanupPolicyInterface {
+	return cleanuppolicies.WithRateLimiting(c.inner.CleanupPolicies(namespace), c.limits.For("CleanupPolicy"))
+}
+func (c *withRateLimiting) CleanupPreviews(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.CleanupPreviewInterface {
+	return cleanuppreviews.WithRateLimiting(c.inner.CleanupPreviews(namespace), c.limits.For("CleanupPreview"))
+}
+func (c *withRateLimiting) CleanupReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.CleanupReportInterface {
+	return cleanupreports.WithRateLimiting(c.inner.CleanupReports(namespace), c.limits.For("CleanupReport"))
+}
+func (c *withRateLimiting) ClusterBaselines() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterBaselineInterface {
+	return clusterbaselines.WithRateLimiting(c.inner.ClusterBaselines(), c.limits.For("ClusterBaseline"))
+}
+func (c *withRateLimiting) ClusterCleanupPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterCleanupPolicyInterface {
+	return clustercleanuppolicies.WithRateLimiting(c.inner.ClusterCleanupPolicies(), c.limits.For("ClusterCleanupPolicy"))
+}
+func (c *withRateLimiting) ClusterComplianceSummaries() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterComplianceSummaryInterface {
+	return clustercompliancesummaries.WithRateLimiting(c.inner.ClusterComplianceSummaries(), c.limits.For("ClusterComplianceSummary"))
+}
+func (c *withRateLimiting) ComplianceScans() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ComplianceScanInterface {
+	return compliancescans.WithRateLimiting(c.inner.ComplianceScans(), c.limits.For("ComplianceScan"))
+}
+func (c *withRateLimiting) ExclusionPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ExclusionPolicyInterface {
+	return exclusionpolicies.WithRateLimiting(c.inner.ExclusionPolicies(), c.limits.For("ExclusionPolicy"))
+}
+func (c *withRateLimiting) PolicyBindings() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyBindingInterface {
+	return policybindings.WithRateLimiting(c.inner.PolicyBindings(), c.limits.For("PolicyBinding"))
+}
+func (c *withRateLimiting) PolicyExceptions(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyExceptionInterface {
+	return policyexceptions.WithRateLimiting(c.inner.PolicyExceptions(namespace), c.limits.For("PolicyException"))
+}
+func (c *withRateLimiting) PolicyProfiles() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyProfileInterface {
+	return policyprofiles.WithRateLimiting(c.inner.PolicyProfiles(), c.limits.For("PolicyProfile"))
+}
+
+func (c *withRateLimiting) PolicySets() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySetInterface {
+	return policysets.WithRateLimiting(c.inner.PolicySets(), c.limits.For("PolicySet"))
+}
+func (c *withRateLimiting) PolicySimulations() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySimulationInterface {
+	return policysimulations.WithRateLimiting(c.inner.PolicySimulations(), c.limits.For("PolicySimulation"))
+}
+func (c *withRateLimiting) ValidatingPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ValidatingPolicyInterface {
+	return validatingpolicies.WithRateLimiting(c.inner.ValidatingPolicies(), c.limits.For("ValidatingPolicy"))
+}
+
+type withRetry struct {
+	inner   github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) CleanupPolicies(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.CleanupPolicyInterface {
+	return cleanuppolicies.WithRetry(c.inner.CleanupPolicies(namespace), c.retries.For("CleanupPolicy"))
+}
+func (c *withRetry) CleanupPreviews(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.CleanupPreviewInterface {
+	return cleanuppreviews.WithRetry(c.inner.CleanupPreviews(namespace), c.retries.For("CleanupPreview"))
+}
+func (c *withRetry) CleanupReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.CleanupReportInterface {
+	return cleanupreports.WithRetry(c.inner.CleanupReports(namespace), c.retries.For("CleanupReport"))
+}
+func (c *withRetry) ClusterBaselines() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterBaselineInterface {
+	return clusterbaselines.WithRetry(c.inner.ClusterBaselines(), c.retries.For("ClusterBaseline"))
+}
+func (c *withRetry) ClusterCleanupPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterCleanupPolicyInterface {
+	return clustercleanuppolicies.WithRetry(c.inner.ClusterCleanupPolicies(), c.retries.For("ClusterCleanupPolicy"))
+}
+func (c *withRetry) ClusterComplianceSummaries() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterComplianceSummaryInterface {
+	return clustercompliancesummaries.WithRetry(c.inner.ClusterComplianceSummaries(), c.retries.For("ClusterComplianceSummary"))
+}
+func (c *withRetry) ComplianceScans() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ComplianceScanInterface {
+	return compliancescans.WithRetry(c.inner.ComplianceScans(), c.retries.For("ComplianceScan"))
+}
+func (c *withRetry) ExclusionPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ExclusionPolicyInterface {
+	return exclusionpolicies.WithRetry(c.inner.ExclusionPolicies(), c.retries.For("ExclusionPolicy"))
+}
+func (c *withRetry) PolicyBindings() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyBindingInterface {
+	return policybindings.WithRetry(c.inner.PolicyBindings(), c.retries.For("PolicyBinding"))
+}
+func (c *withRetry) PolicyExceptions(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyExceptionInterface {
+	return policyexceptions.WithRetry(c.inner.PolicyExceptions(namespace), c.retries.For("PolicyException"))
+}
+func (c *withRetry) PolicyProfiles() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyProfileInterface {
+	return policyprofiles.WithRetry(c.inner.PolicyProfiles(), c.retries.For("PolicyProfile"))
+}
+
+func (c *withRetry) PolicySets() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySetInterface {
+	return policysets.WithRetry(c.inner.PolicySets(), c.retries.For("PolicySet"))
+}
+func (c *withRetry) PolicySimulations() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySimulationInterface {
+	return policysimulations.WithRetry(c.inner.PolicySimulations(), c.retries.For("PolicySimulation"))
+}
+func (c *withRetry) ValidatingPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ValidatingPolicyInterface {
+	return validatingpolicies.WithRetry(c.inner.ValidatingPolicies(), c.retries.For("ValidatingPolicy"))
+}
+
+type withCircuitBreaker struct {
+	inner    github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.KyvernoV2alpha1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) CleanupPolicies(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.CleanupPolicyInterface {
+	return cleanuppolicies.WithCircuitBreaker(c.inner.CleanupPolicies(namespace), c.breakers.For("CleanupPolicy"))
+}
+func (c *withCircuitBreaker) CleanupPreviews(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.CleanupPreviewInterface {
+	return cleanuppreviews.WithCircuitBreaker(c.inner.CleanupPreviews(namespace), c.breakers.For("CleanupPreview"))
+}
+func (c *withCircuitBreaker) CleanupReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.CleanupReportInterface {
+	return cleanupreports.WithCircuitBreaker(c.inner.CleanupReports(namespace), c.breakers.For("CleanupReport"))
+}
+func (c *withCircuitBreaker) ClusterBaselines() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterBaselineInterface {
+	return clusterbaselines.WithCircuitBreaker(c.inner.ClusterBaselines(), c.breakers.For("ClusterBaseline"))
+}
+func (c *withCircuitBreaker) ClusterCleanupPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterCleanupPolicyInterface {
+	return clustercleanuppolicies.WithCircuitBreaker(c.inner.ClusterCleanupPolicies(), c.breakers.For("ClusterCleanupPolicy"))
+}
+func (c *withCircuitBreaker) ClusterComplianceSummaries() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ClusterComplianceSummaryInterface {
+	return clustercompliancesummaries.WithCircuitBreaker(c.inner.ClusterComplianceSummaries(), c.breakers.For("ClusterComplianceSummary"))
+}
+func (c *withCircuitBreaker) ComplianceScans() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ComplianceScanInterface {
+	return compliancescans.WithCircuitBreaker(c.inner.ComplianceScans(), c.breakers.For("ComplianceScan"))
+}
+func (c *withCircuitBreaker) ExclusionPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ExclusionPolicyInterface {
+	return exclusionpolicies.WithCircuitBreaker(c.inner.ExclusionPolicies(), c.breakers.For("ExclusionPolicy"))
+}
+func (c *withCircuitBreaker) PolicyBindings() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyBindingInterface {
+	return policybindings.WithCircuitBreaker(c.inner.PolicyBindings(), c.breakers.For("PolicyBinding"))
+}
+func (c *withCircuitBreaker) PolicyExceptions(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyExceptionInterface {
+	return policyexceptions.WithCircuitBreaker(c.inner.PolicyExceptions(namespace), c.breakers.For("PolicyException"))
+}
+func (c *withCircuitBreaker) PolicyProfiles() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicyProfileInterface {
+	return policyprofiles.WithCircuitBreaker(c.inner.PolicyProfiles(), c.breakers.For("PolicyProfile"))
+}
+
+func (c *withCircuitBreaker) PolicySets() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySetInterface {
+	return policysets.WithCircuitBreaker(c.inner.PolicySets(), c.breakers.For("PolicySet"))
+}
+func (c *withCircuitBreaker) PolicySimulations() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.PolicySimulationInterface {
+	return policysimulations.WithCircuitBreaker(c.inner.PolicySimulations(), c.breakers.For("PolicySimulation"))
+}
+func (c *withCircuitBreaker) ValidatingPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2alpha1.ValidatingPolicyInterface {
+	return validatingpolicies.WithCircuitBreaker(c.inner.ValidatingPolicies(), c.breakers.For("ValidatingPolicy"))
+}
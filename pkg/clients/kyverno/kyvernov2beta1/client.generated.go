@@ -8,6 +8,7 @@ import (
 	clusterpolicies "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2beta1/clusterpolicies"
 	policies "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2beta1/policies"
 	policyexceptions "github.com/kyverno/kyverno/pkg/clients/kyverno/kyvernov2beta1/policyexceptions"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"k8s.io/client-go/rest"
 )
@@ -24,6 +25,22 @@ func WithLogging(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned
 	return &withLogging{inner, logger}
 }
 
+func WithAuditLogging(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.KyvernoV2beta1Interface, logger logr.Logger, audit *middleware.AuditLogging) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.KyvernoV2beta1Interface {
+	return &withAuditLogging{inner, logger, audit}
+}
+
+func WithRateLimiting(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.KyvernoV2beta1Interface, limits *middleware.RateLimits) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.KyvernoV2beta1Interface {
+	return &withRateLimiting{inner, limits}
+}
+
+func WithRetry(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.KyvernoV2beta1Interface, retries *middleware.Retries) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.KyvernoV2beta1Interface {
+	return &withRetry{inner, retries}
+}
+
+func WithCircuitBreaker(inner github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.KyvernoV2beta1Interface, breakers *middleware.CircuitBreakers) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.KyvernoV2beta1Interface {
+	return &withCircuitBreaker{inner, breakers}
+}
+
 type withMetrics struct {
 	inner      github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.KyvernoV2beta1Interface
 	metrics    metrics.MetricsConfigManager
@@ -101,3 +118,125 @@ func (c *withLogging) Policies(namespace string) github_com_kyverno_kyverno_pkg_
 func (c *withLogging) PolicyExceptions(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.PolicyExceptionInterface {
 	return policyexceptions.WithLogging(c.inner.PolicyExceptions(namespace), c.logger.WithValues("resource", "PolicyExceptions").WithValues("namespace", namespace))
 }
+
+type withAuditLogging struct {
+	inner  github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.KyvernoV2beta1Interface
+	logger logr.Logger
+	audit  *middleware.AuditLogging
+}
+
+func (c *withAuditLogging) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withAuditLogging) CleanupPolicies(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.CleanupPolicyInterface {
+	inner := c.inner.CleanupPolicies(namespace)
+	level, ok := c.audit.For("CleanupPolicy")
+	if !ok {
+		return inner
+	}
+	return cleanuppolicies.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "CleanupPolicies").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) ClusterCleanupPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.ClusterCleanupPolicyInterface {
+	inner := c.inner.ClusterCleanupPolicies()
+	level, ok := c.audit.For("ClusterCleanupPolicy")
+	if !ok {
+		return inner
+	}
+	return clustercleanuppolicies.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ClusterCleanupPolicies"))
+}
+func (c *withAuditLogging) ClusterPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.ClusterPolicyInterface {
+	inner := c.inner.ClusterPolicies()
+	level, ok := c.audit.For("ClusterPolicy")
+	if !ok {
+		return inner
+	}
+	return clusterpolicies.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "ClusterPolicies"))
+}
+func (c *withAuditLogging) Policies(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.PolicyInterface {
+	inner := c.inner.Policies(namespace)
+	level, ok := c.audit.For("Policy")
+	if !ok {
+		return inner
+	}
+	return policies.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "Policies").WithValues("namespace", namespace))
+}
+func (c *withAuditLogging) PolicyExceptions(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.PolicyExceptionInterface {
+	inner := c.inner.PolicyExceptions(namespace)
+	level, ok := c.audit.For("PolicyException")
+	if !ok {
+		return inner
+	}
+	return policyexceptions.WithAuditLogging(inner, c.logger.V(level).WithValues("resource", "PolicyExceptions").WithValues("namespace", namespace))
+}
+
+type withRateLimiting struct {
+	inner  github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.KyvernoV2beta1Interface
+	limits *middleware.RateLimits
+}
+
+func (c *withRateLimiting) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRateLimiting) CleanupPolicies(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.CleanupPolicyInterface {
+	return cleanuppolicies.WithRateLimiting(c.inner.CleanupPolicies(namespace), c.limits.For("CleanupPolicy"))
+}
+func (c *withRateLimiting) ClusterCleanupPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.ClusterCleanupPolicyInterface {
+	return clustercleanuppolicies.WithRateLimiting(c.inner.ClusterCleanupPolicies(), c.limits.For("ClusterCleanupPolicy"))
+}
+func (c *withRateLimiting) ClusterPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.ClusterPolicyInterface {
+	return clusterpolicies.WithRateLimiting(c.inner.ClusterPolicies(), c.limits.For("ClusterPolicy"))
+}
+func (c *withRateLimiting) Policies(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.PolicyInterface {
+	return policies.WithRateLimiting(c.inner.Policies(namespace), c.limits.For("Policy"))
+}
+func (c *withRateLimiting) PolicyExceptions(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.PolicyExceptionInterface {
+	return policyexceptions.WithRateLimiting(c.inner.PolicyExceptions(namespace), c.limits.For("PolicyException"))
+}
+
+type withRetry struct {
+	inner   github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.KyvernoV2beta1Interface
+	retries *middleware.Retries
+}
+
+func (c *withRetry) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withRetry) CleanupPolicies(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.CleanupPolicyInterface {
+	return cleanuppolicies.WithRetry(c.inner.CleanupPolicies(namespace), c.retries.For("CleanupPolicy"))
+}
+func (c *withRetry) ClusterCleanupPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.ClusterCleanupPolicyInterface {
+	return clustercleanuppolicies.WithRetry(c.inner.ClusterCleanupPolicies(), c.retries.For("ClusterCleanupPolicy"))
+}
+func (c *withRetry) ClusterPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.ClusterPolicyInterface {
+	return clusterpolicies.WithRetry(c.inner.ClusterPolicies(), c.retries.For("ClusterPolicy"))
+}
+func (c *withRetry) Policies(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.PolicyInterface {
+	return policies.WithRetry(c.inner.Policies(namespace), c.retries.For("Policy"))
+}
+func (c *withRetry) PolicyExceptions(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.PolicyExceptionInterface {
+	return policyexceptions.WithRetry(c.inner.PolicyExceptions(namespace), c.retries.For("PolicyException"))
+}
+
+type withCircuitBreaker struct {
+	inner    github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.KyvernoV2beta1Interface
+	breakers *middleware.CircuitBreakers
+}
+
+func (c *withCircuitBreaker) RESTClient() rest.Interface {
+	return c.inner.RESTClient()
+}
+func (c *withCircuitBreaker) CleanupPolicies(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.CleanupPolicyInterface {
+	return cleanuppolicies.WithCircuitBreaker(c.inner.CleanupPolicies(namespace), c.breakers.For("CleanupPolicy"))
+}
+func (c *withCircuitBreaker) ClusterCleanupPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.ClusterCleanupPolicyInterface {
+	return clustercleanuppolicies.WithCircuitBreaker(c.inner.ClusterCleanupPolicies(), c.breakers.For("ClusterCleanupPolicy"))
+}
+func (c *withCircuitBreaker) ClusterPolicies() github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.ClusterPolicyInterface {
+	return clusterpolicies.WithCircuitBreaker(c.inner.ClusterPolicies(), c.breakers.For("ClusterPolicy"))
+}
+func (c *withCircuitBreaker) Policies(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.PolicyInterface {
+	return policies.WithCircuitBreaker(c.inner.Policies(namespace), c.breakers.For("Policy"))
+}
+func (c *withCircuitBreaker) PolicyExceptions(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_kyverno_v2beta1.PolicyExceptionInterface {
+	return policyexceptions.WithCircuitBreaker(c.inner.PolicyExceptions(namespace), c.breakers.For("PolicyException"))
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// AuditLogging holds the verbosity at which the calls made for every audited resource kind are logged
+type AuditLogging struct {
+	levels map[string]int
+}
+
+// For returns the verbosity at which the calls of a resource kind are logged, false when they are not audited
+func (a *AuditLogging) For(kind string) (int, bool) {
+	if a == nil {
+		return 0, false
+	}
+	if level, ok := a.levels[kind]; ok {
+		return level, true
+	}
+	level, ok := a.levels[Wildcard]
+	return level, ok
+}
+
+// ParseAuditLogging parses a comma separated list of kind=verbosity audit levels.
+// Typed clients are matched by kind (PolicyReport), dynamic and metadata clients by resource (policyreports).
+func ParseAuditLogging(in string) (*AuditLogging, error) {
+	levels := map[string]int{}
+	for _, entry := range strings.Split(in, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kind, levelValue, ok := strings.Cut(entry, "=")
+		if !ok || kind == "" {
+			return nil, fmt.Errorf("invalid audit logging %s, must be kind=verbosity", entry)
+		}
+		level, err := strconv.Atoi(levelValue)
+		if err != nil || level < 0 {
+			return nil, fmt.Errorf("invalid audit logging %s, verbosity must be a non negative integer", entry)
+		}
+		levels[kind] = level
+	}
+	if len(levels) == 0 {
+		return nil, nil
+	}
+	return &AuditLogging{levels: levels}, nil
+}
+
+// Size returns the total size in bytes of the objects sent or received by a client call.
+// Raw payloads (patches) count for their length and objects for the length of their json representation,
+// other values (names, options) are ignored.
+func Size(values ...any) int {
+	size := 0
+	for _, value := range values {
+		switch typed := value.(type) {
+		case []byte:
+			size += len(typed)
+		case runtime.Object:
+			if v := reflect.ValueOf(typed); v.Kind() == reflect.Pointer && v.IsNil() {
+				continue
+			}
+			if data, err := json.Marshal(typed); err == nil {
+				size += len(data)
+			}
+		}
+	}
+	return size
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseAuditLogging(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    map[string]int
+		wantErr bool
+	}{{
+		name: "empty",
+		in:   "",
+	}, {
+		name: "levels",
+		in:   "PolicyReport=2, *=4",
+		want: map[string]int{"PolicyReport": 2, Wildcard: 4},
+	}, {
+		name:    "missing verbosity",
+		in:      "PolicyReport",
+		wantErr: true,
+	}, {
+		name:    "negative verbosity",
+		in:      "PolicyReport=-1",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAuditLogging(tt.in)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			if tt.want == nil {
+				assert.Assert(t, got == nil)
+				return
+			}
+			assert.DeepEqual(t, got.levels, tt.want)
+		})
+	}
+}
+
+func TestAuditLogging_For(t *testing.T) {
+	var none *AuditLogging
+	_, ok := none.For("PolicyReport")
+	assert.Assert(t, !ok)
+	audit, err := ParseAuditLogging("PolicyReport=2")
+	assert.NilError(t, err)
+	level, ok := audit.For("PolicyReport")
+	assert.Assert(t, ok)
+	assert.Equal(t, level, 2)
+	_, ok = audit.For("ConfigMap")
+	assert.Assert(t, !ok)
+	audit, err = ParseAuditLogging("PolicyReport=2,*=6")
+	assert.NilError(t, err)
+	level, ok = audit.For("ConfigMap")
+	assert.Assert(t, ok)
+	assert.Equal(t, level, 6)
+}
+
+func TestSize(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	data, err := json.Marshal(pod)
+	assert.NilError(t, err)
+	var nilPod *corev1.Pod
+	assert.Equal(t, Size(), 0)
+	assert.Equal(t, Size("name", metav1.CreateOptions{}, []string{"status"}), 0)
+	assert.Equal(t, Size(nilPod), 0)
+	assert.Equal(t, Size(pod), len(data))
+	assert.Equal(t, Size([]byte(`{"a":1}`), pod), 7+len(data))
+}
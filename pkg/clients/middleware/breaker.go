@@ -0,0 +1,230 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernometrics "github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+)
+
+// ErrCircuitOpen is returned for the calls shed by an open circuit breaker
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerState is the state of a circuit breaker
+type BreakerState int64
+
+const (
+	// BreakerClosed lets all the calls through
+	BreakerClosed BreakerState = iota
+	// BreakerOpen sheds all the calls until the cooldown expires
+	BreakerOpen
+	// BreakerHalfOpen lets a single probe call through to decide whether the breaker closes or opens again
+	BreakerHalfOpen
+)
+
+// IsOverloaded returns true for the errors showing the API server is overloaded or unavailable
+func IsOverloaded(err error) bool {
+	if err == nil {
+		return false
+	}
+	if utilnet.IsConnectionRefused(err) {
+		return true
+	}
+	var status apierrors.APIStatus
+	if errors.As(err, &status) {
+		code := status.Status().Code
+		return code == 429 || code >= 500
+	}
+	return false
+}
+
+// CircuitBreaker sheds the calls of a client once the API server failed a number of consecutive calls
+// with overload errors, a probe call is let through after the cooldown to close the breaker again.
+type CircuitBreaker struct {
+	kind      string
+	threshold int
+	cooldown  time.Duration
+	metrics   *breakerMetrics
+	isFailure func(error) bool
+	now       func() time.Time
+
+	lock     sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+}
+
+// NewCircuitBreaker returns a breaker opening after threshold consecutive overload errors for cooldown
+func NewCircuitBreaker(kind string, threshold int, cooldown time.Duration) *CircuitBreaker {
+	return NewCircuitBreakerWithClassifier(kind, threshold, cooldown, IsOverloaded)
+}
+
+// NewCircuitBreakerWithClassifier returns a breaker opening after threshold consecutive errors for cooldown,
+// isFailure decides which errors count as failures of the callee.
+func NewCircuitBreakerWithClassifier(kind string, threshold int, cooldown time.Duration, isFailure func(error) bool) *CircuitBreaker {
+	return &CircuitBreaker{
+		kind:      kind,
+		threshold: threshold,
+		cooldown:  cooldown,
+		isFailure: isFailure,
+		now:       time.Now,
+	}
+}
+
+// State returns the current state of the breaker
+func (b *CircuitBreaker) State() BreakerState {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.state
+}
+
+// Call calls fn unless the breaker is open, in which case ErrCircuitOpen is returned
+func (b *CircuitBreaker) Call(ctx context.Context, fn func() error) error {
+	if !b.allow() {
+		b.metrics.recordRejected(ctx, b.kind)
+		return fmt.Errorf("%w for %s", ErrCircuitOpen, b.kind)
+	}
+	err := fn()
+	b.done(err)
+	return err
+}
+
+func (b *CircuitBreaker) allow() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = BreakerHalfOpen
+		return true
+	case BreakerHalfOpen:
+		// a probe is in flight
+		return false
+	default:
+		return true
+	}
+}
+
+func (b *CircuitBreaker) done(err error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if !b.isFailure(err) {
+		b.state = BreakerClosed
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.state = BreakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+// CircuitBreakers holds the circuit breaker of every resource kind whose calls can be shed
+type CircuitBreakers struct {
+	breakers map[string]*CircuitBreaker
+}
+
+// For returns the circuit breaker of a resource kind, nil when calls are never shed
+func (c *CircuitBreakers) For(kind string) *CircuitBreaker {
+	if c == nil {
+		return nil
+	}
+	if breaker, ok := c.breakers[kind]; ok {
+		return breaker
+	}
+	return c.breakers[Wildcard]
+}
+
+// ParseCircuitBreakers parses a comma separated list of kind=failures[/cooldown] breakers, the cooldown defaults to 30s.
+// Typed clients are matched by kind (PolicyReport), dynamic and metadata clients by resource (policyreports).
+// Only kinds whose calls are not critical to serve admission requests (reports, events) should be configured.
+func ParseCircuitBreakers(logger logr.Logger, in string) (*CircuitBreakers, error) {
+	breakers := map[string]*CircuitBreaker{}
+	for _, entry := range strings.Split(in, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kind, policy, ok := strings.Cut(entry, "=")
+		if !ok || kind == "" {
+			return nil, fmt.Errorf("invalid circuit breaker %s, must be kind=failures[/cooldown]", entry)
+		}
+		failuresValue, cooldownValue, hasCooldown := strings.Cut(policy, "/")
+		failures, err := strconv.Atoi(failuresValue)
+		if err != nil || failures <= 0 {
+			return nil, fmt.Errorf("invalid circuit breaker %s, failures must be a positive integer", entry)
+		}
+		cooldown := 30 * time.Second
+		if hasCooldown {
+			if cooldown, err = time.ParseDuration(cooldownValue); err != nil || cooldown <= 0 {
+				return nil, fmt.Errorf("invalid circuit breaker %s, cooldown must be a positive duration", entry)
+			}
+		}
+		breakers[kind] = NewCircuitBreaker(kind, failures, cooldown)
+	}
+	if len(breakers) == 0 {
+		return nil, nil
+	}
+	metrics := newBreakerMetrics(logger, breakers)
+	for _, breaker := range breakers {
+		breaker.metrics = metrics
+	}
+	return &CircuitBreakers{breakers: breakers}, nil
+}
+
+type breakerMetrics struct {
+	state    metric.Int64ObservableGauge
+	rejected metric.Int64Counter
+}
+
+func newBreakerMetrics(logger logr.Logger, breakers map[string]*CircuitBreaker) *breakerMetrics {
+	meter := otel.GetMeterProvider().Meter(kyvernometrics.MeterName)
+	state, err := meter.Int64ObservableGauge(
+		"kyverno_client_circuit_breaker_state",
+		metric.WithDescription("can be used to track the state of the client circuit breakers, 0 is closed, 1 is open and 2 is half open."),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_client_circuit_breaker_state")
+	} else {
+		_, err := meter.RegisterCallback(func(ctx context.Context, observer metric.Observer) error {
+			for kind, breaker := range breakers {
+				observer.ObserveInt64(state, int64(breaker.State()), metric.WithAttributes(attribute.String("resource_kind", kind)))
+			}
+			return nil
+		}, state)
+		if err != nil {
+			logger.Error(err, "Failed to register callback, kyverno_client_circuit_breaker_state")
+		}
+	}
+	rejected, err := meter.Int64Counter(
+		"kyverno_client_circuit_breaker_rejected_calls",
+		metric.WithDescription("can be used to track the number of client calls shed by an open circuit breaker."),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_client_circuit_breaker_rejected_calls")
+	}
+	return &breakerMetrics{
+		state:    state,
+		rejected: rejected,
+	}
+}
+
+func (m *breakerMetrics) recordRejected(ctx context.Context, kind string) {
+	if m != nil && m.rejected != nil {
+		m.rejected.Add(ctx, 1, metric.WithAttributes(attribute.String("resource_kind", kind)))
+	}
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"gotest.tools/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestIsOverloaded(t *testing.T) {
+	assert.Assert(t, !IsOverloaded(nil))
+	assert.Assert(t, IsOverloaded(apierrors.NewTooManyRequests("slow down", 1)))
+	assert.Assert(t, IsOverloaded(apierrors.NewServiceUnavailable("unavailable")))
+	assert.Assert(t, IsOverloaded(apierrors.NewInternalError(errors.New("boom"))))
+	assert.Assert(t, !IsOverloaded(apierrors.NewNotFound(schema.GroupResource{Resource: "policyreports"}, "test")))
+	assert.Assert(t, !IsOverloaded(errors.New("boom")))
+}
+
+func TestCircuitBreaker_Call(t *testing.T) {
+	overloaded := apierrors.NewTooManyRequests("slow down", 0)
+	now := time.Now()
+	breaker := NewCircuitBreaker("PolicyReport", 2, time.Minute)
+	breaker.now = func() time.Time { return now }
+	calls := 0
+	call := func(err error) error {
+		return breaker.Call(context.TODO(), func() error {
+			calls++
+			return err
+		})
+	}
+	// stays closed below the threshold and on errors not caused by overload
+	assert.Equal(t, call(overloaded), overloaded)
+	assert.Equal(t, call(nil), nil)
+	assert.Equal(t, call(overloaded), overloaded)
+	assert.Equal(t, breaker.State(), BreakerClosed)
+	// opens after consecutive overload errors
+	assert.Equal(t, call(overloaded), overloaded)
+	assert.Equal(t, breaker.State(), BreakerOpen)
+	assert.Equal(t, calls, 4)
+	// sheds calls until the cooldown expires
+	assert.Assert(t, errors.Is(call(nil), ErrCircuitOpen))
+	assert.Equal(t, calls, 4)
+	// a failed probe opens the breaker again
+	now = now.Add(time.Minute)
+	assert.Equal(t, call(overloaded), overloaded)
+	assert.Equal(t, breaker.State(), BreakerOpen)
+	assert.Assert(t, errors.Is(call(nil), ErrCircuitOpen))
+	// a successful probe closes the breaker
+	now = now.Add(time.Minute)
+	assert.Equal(t, call(nil), nil)
+	assert.Equal(t, breaker.State(), BreakerClosed)
+	assert.Equal(t, calls, 6)
+}
+
+func TestParseCircuitBreakers(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		kinds   []string
+		wantErr bool
+	}{{
+		name: "empty",
+		in:   "",
+	}, {
+		name:  "single",
+		in:    "PolicyReport=5",
+		kinds: []string{"PolicyReport"},
+	}, {
+		name:  "cooldown",
+		in:    "PolicyReport=5/10s, Event=3",
+		kinds: []string{"PolicyReport", "Event"},
+	}, {
+		name:    "missing failures",
+		in:      "PolicyReport",
+		wantErr: true,
+	}, {
+		name:    "invalid cooldown",
+		in:      "PolicyReport=5/-1s",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCircuitBreakers(logr.Discard(), tt.in)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			if len(tt.kinds) == 0 {
+				assert.Assert(t, got == nil)
+				return
+			}
+			assert.Equal(t, len(got.breakers), len(tt.kinds))
+			for _, kind := range tt.kinds {
+				assert.Assert(t, got.For(kind) != nil)
+			}
+			assert.Assert(t, got.For("ConfigMap") == nil)
+		})
+	}
+}
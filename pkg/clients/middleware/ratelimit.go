@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// Wildcard configures the rate limit or retry policy of the resources without a specific one
+const Wildcard = "*"
+
+// RateLimits holds the rate limiter of every resource kind with a QPS budget.
+// Limiters are shared by all the clients of a kind so that the budget applies across namespaces.
+type RateLimits struct {
+	limiters map[string]flowcontrol.RateLimiter
+}
+
+// For returns the rate limiter of a resource kind, nil when the kind has no QPS budget
+func (r *RateLimits) For(kind string) flowcontrol.RateLimiter {
+	if r == nil {
+		return nil
+	}
+	if limiter, ok := r.limiters[kind]; ok {
+		return limiter
+	}
+	return r.limiters[Wildcard]
+}
+
+// ParseRateLimits parses a comma separated list of kind=qps[/burst] budgets, the burst defaults to the qps.
+// Typed clients are matched by kind (PolicyReport), dynamic and metadata clients by resource (policyreports).
+func ParseRateLimits(in string) (*RateLimits, error) {
+	limiters := map[string]flowcontrol.RateLimiter{}
+	for _, entry := range strings.Split(in, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kind, budget, ok := strings.Cut(entry, "=")
+		if !ok || kind == "" {
+			return nil, fmt.Errorf("invalid rate limit %s, must be kind=qps[/burst]", entry)
+		}
+		qpsValue, burstValue, hasBurst := strings.Cut(budget, "/")
+		qps, err := strconv.ParseFloat(qpsValue, 32)
+		if err != nil || qps <= 0 {
+			return nil, fmt.Errorf("invalid rate limit %s, qps must be a positive number", entry)
+		}
+		burst := max(int(qps), 1)
+		if hasBurst {
+			if burst, err = strconv.Atoi(burstValue); err != nil || burst <= 0 {
+				return nil, fmt.Errorf("invalid rate limit %s, burst must be a positive integer", entry)
+			}
+		}
+		limiters[kind] = flowcontrol.NewTokenBucketRateLimiter(float32(qps), burst)
+	}
+	if len(limiters) == 0 {
+		return nil, nil
+	}
+	return &RateLimits{limiters: limiters}, nil
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestParseRateLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		kinds   []string
+		wantErr bool
+	}{{
+		name: "empty",
+		in:   "",
+	}, {
+		name:  "single",
+		in:    "PolicyReport=10",
+		kinds: []string{"PolicyReport"},
+	}, {
+		name:  "burst and wildcard",
+		in:    "PolicyReport=10/20, *=50",
+		kinds: []string{"PolicyReport", Wildcard},
+	}, {
+		name:    "missing qps",
+		in:      "PolicyReport",
+		wantErr: true,
+	}, {
+		name:    "invalid qps",
+		in:      "PolicyReport=abc",
+		wantErr: true,
+	}, {
+		name:    "negative qps",
+		in:      "PolicyReport=-1",
+		wantErr: true,
+	}, {
+		name:    "invalid burst",
+		in:      "PolicyReport=10/0",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRateLimits(tt.in)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			if len(tt.kinds) == 0 {
+				assert.Assert(t, got == nil)
+				return
+			}
+			assert.Equal(t, len(got.limiters), len(tt.kinds))
+			for _, kind := range tt.kinds {
+				assert.Assert(t, got.limiters[kind] != nil)
+			}
+		})
+	}
+}
+
+func TestRateLimits_For(t *testing.T) {
+	var none *RateLimits
+	assert.Assert(t, none.For("PolicyReport") == nil)
+	limits, err := ParseRateLimits("PolicyReport=10")
+	assert.NilError(t, err)
+	assert.Assert(t, limits.For("PolicyReport") != nil)
+	assert.Assert(t, limits.For("ConfigMap") == nil)
+	limits, err = ParseRateLimits("PolicyReport=10,*=5")
+	assert.NilError(t, err)
+	assert.Assert(t, limits.For("PolicyReport") != limits.For("ConfigMap"))
+	assert.Assert(t, limits.For("ConfigMap") == limits.limiters[Wildcard])
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+// Retrier retries the calls of a client failing because the API server could not process them
+type Retrier struct {
+	backoff wait.Backoff
+}
+
+// NewRetrier returns a retrier making at most attempts calls, waiting an exponential backoff starting at delay between them
+func NewRetrier(attempts int, delay time.Duration) *Retrier {
+	return &Retrier{
+		backoff: wait.Backoff{
+			Duration: delay,
+			Factor:   2,
+			Jitter:   0.1,
+			Steps:    attempts,
+		},
+	}
+}
+
+// IsRetriable returns true for the errors of requests the API server rejected before processing them,
+// retrying them is safe even for requests that are not idempotent
+func IsRetriable(err error) bool {
+	return apierrors.IsTooManyRequests(err) || apierrors.IsServiceUnavailable(err) || utilnet.IsConnectionRefused(err)
+}
+
+// Retry calls fn until it succeeds, fails with an error that is not retriable or the backoff is exhausted, and returns
+// the error of the last call. The delay suggested by the API server is honoured before the backoff delay.
+// fn is always called once, callers read its results, it is only retried while ctx is not done.
+func (r *Retrier) Retry(ctx context.Context, fn func() error) error {
+	calls := 0
+	return retry.OnError(r.backoff, IsRetriable, func() error {
+		if calls++; calls > 1 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		err := fn()
+		if seconds, ok := apierrors.SuggestsClientDelay(err); ok && IsRetriable(err) {
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Duration(seconds) * time.Second):
+			}
+		}
+		return err
+	})
+}
+
+// Retries holds the retry policy of every resource kind configured to retry failed calls
+type Retries struct {
+	retriers map[string]*Retrier
+}
+
+// For returns the retrier of a resource kind, nil when calls are not retried
+func (r *Retries) For(kind string) *Retrier {
+	if r == nil {
+		return nil
+	}
+	if retrier, ok := r.retriers[kind]; ok {
+		return retrier
+	}
+	return r.retriers[Wildcard]
+}
+
+// ParseRetries parses a comma separated list of kind=attempts[/delay] retry policies, the delay defaults to 100ms.
+// Typed clients are matched by kind (PolicyReport), dynamic and metadata clients by resource (policyreports).
+func ParseRetries(in string) (*Retries, error) {
+	retriers := map[string]*Retrier{}
+	for _, entry := range strings.Split(in, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kind, policy, ok := strings.Cut(entry, "=")
+		if !ok || kind == "" {
+			return nil, fmt.Errorf("invalid retry policy %s, must be kind=attempts[/delay]", entry)
+		}
+		attemptsValue, delayValue, hasDelay := strings.Cut(policy, "/")
+		attempts, err := strconv.Atoi(attemptsValue)
+		if err != nil || attempts <= 0 {
+			return nil, fmt.Errorf("invalid retry policy %s, attempts must be a positive integer", entry)
+		}
+		delay := 100 * time.Millisecond
+		if hasDelay {
+			if delay, err = time.ParseDuration(delayValue); err != nil || delay <= 0 {
+				return nil, fmt.Errorf("invalid retry policy %s, delay must be a positive duration", entry)
+			}
+		}
+		retriers[kind] = NewRetrier(attempts, delay)
+	}
+	if len(retriers) == 0 {
+		return nil, nil
+	}
+	return &Retries{retriers: retriers}, nil
+}
@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestParseRetries(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		kinds   []string
+		wantErr bool
+	}{{
+		name: "empty",
+		in:   "",
+	}, {
+		name:  "single",
+		in:    "PolicyReport=3",
+		kinds: []string{"PolicyReport"},
+	}, {
+		name:  "delay and wildcard",
+		in:    "PolicyReport=3/1s, *=2",
+		kinds: []string{"PolicyReport", Wildcard},
+	}, {
+		name:    "missing attempts",
+		in:      "PolicyReport=",
+		wantErr: true,
+	}, {
+		name:    "zero attempts",
+		in:      "PolicyReport=0",
+		wantErr: true,
+	}, {
+		name:    "invalid delay",
+		in:      "PolicyReport=3/abc",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRetries(tt.in)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			if len(tt.kinds) == 0 {
+				assert.Assert(t, got == nil)
+				return
+			}
+			assert.Equal(t, len(got.retriers), len(tt.kinds))
+			for _, kind := range tt.kinds {
+				assert.Assert(t, got.For(kind) != nil)
+			}
+		})
+	}
+}
+
+func TestRetrier_Retry(t *testing.T) {
+	tooManyRequests := apierrors.NewTooManyRequests("slow down", 0)
+	notFound := apierrors.NewNotFound(schema.GroupResource{Resource: "policyreports"}, "test")
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		errs      []error
+		wantCalls int
+		wantErr   error
+		wantRetry error
+	}{{
+		name:      "success",
+		wantCalls: 1,
+	}, {
+		name:      "retried until success",
+		errs:      []error{tooManyRequests, tooManyRequests},
+		wantCalls: 3,
+	}, {
+		name:      "not retriable",
+		errs:      []error{notFound},
+		wantCalls: 1,
+		wantErr:   notFound,
+	}, {
+		name:      "attempts exhausted",
+		errs:      []error{tooManyRequests, tooManyRequests, tooManyRequests, tooManyRequests},
+		wantCalls: 3,
+		wantErr:   tooManyRequests,
+	}, {
+		// the results of the call are read by the callers, it is made even if the context is done
+		name:      "context done",
+		ctx:       cancelled,
+		wantCalls: 1,
+	}, {
+		name:      "context done before retrying",
+		ctx:       cancelled,
+		errs:      []error{tooManyRequests},
+		wantCalls: 1,
+		wantErr:   tooManyRequests,
+		wantRetry: context.Canceled,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.TODO()
+			}
+			retrier := NewRetrier(3, time.Millisecond)
+			calls := 0
+			var err error
+			retryErr := retrier.Retry(ctx, func() error {
+				err = nil
+				if calls < len(tt.errs) {
+					err = tt.errs[calls]
+				}
+				calls++
+				return err
+			})
+			assert.Equal(t, calls, tt.wantCalls)
+			assert.Assert(t, errors.Is(err, tt.wantErr))
+			wantRetry := tt.wantRetry
+			if wantRetry == nil {
+				wantRetry = tt.wantErr
+			}
+			assert.Assert(t, errors.Is(retryErr, wantRetry))
+		})
+	}
+}
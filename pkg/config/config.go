@@ -3,7 +3,9 @@ package config
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 	"sync"
 
 	valid "github.com/asaskevich/govalidator"
@@ -11,6 +13,8 @@ import (
 	osutils "github.com/kyverno/kyverno/pkg/utils/os"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
@@ -68,10 +72,18 @@ const (
 	MutatingWebhookServicePath = "/mutate"
 	// VerifyMutatingWebhookServicePath is the path for verify webhook(used to veryfing if admission control is enabled and active)
 	VerifyMutatingWebhookServicePath = "/verifymutate"
+	// EffectivePoliciesServicePath is the path for listing the policies in effect in a namespace
+	EffectivePoliciesServicePath = "/effectivepolicies"
+	// PolicyEvaluationServicePath is the path for evaluating a resource against the policies of the cluster
+	PolicyEvaluationServicePath = "/evaluate"
+	// PayloadScanServicePath is the path for validating JSON payloads against ValidatingPolicies
+	PayloadScanServicePath = "/scan"
 	// LivenessServicePath is the path for check liveness health
 	LivenessServicePath = "/health/liveness"
 	// ReadinessServicePath is the path for check readness health
 	ReadinessServicePath = "/health/readiness"
+	// StatusServicePath is the path for reporting the status of the server
+	StatusServicePath = "/health/status"
 	// MetricsPath is the path for exposing metrics
 	MetricsPath = "/metrics"
 )
@@ -81,6 +93,7 @@ const (
 	resourceFilters               = "resourceFilters"
 	defaultRegistry               = "defaultRegistry"
 	enableDefaultRegistryMutation = "enableDefaultRegistryMutation"
+	preserveImageReferences       = "preserveImageReferences"
 	excludeGroups                 = "excludeGroups"
 	excludeUsernames              = "excludeUsernames"
 	excludeRoles                  = "excludeRoles"
@@ -90,6 +103,15 @@ const (
 	webhookAnnotations            = "webhookAnnotations"
 	webhookLabels                 = "webhookLabels"
 	matchConditions               = "matchConditions"
+	severityMapping               = "severityMapping"
+	categoryMapping               = "categoryMapping"
+	outboundTransport             = "outboundTransport"
+	excludeKyvernoNamespace       = "excludeKyvernoNamespace"
+	selfProtection                = "selfProtection"
+	exceptionApproval             = "exceptionApproval"
+	eventsConfig                  = "events"
+	autogenControllers            = "autogenControllers"
+	clusterMetadata               = "clusterMetadata"
 )
 
 var (
@@ -153,10 +175,19 @@ type Configuration interface {
 	GetDefaultRegistry() string
 	// GetEnableDefaultRegistryMutation returns true if image references should be mutated
 	GetEnableDefaultRegistryMutation() bool
+	// GetPreserveImageReferences returns true if image references should be shown as written in the resource
+	GetPreserveImageReferences() bool
 	// IsExcluded checks exlusions/inclusions to determine if the admission request should be excluded or not
 	IsExcluded(username string, groups []string, roles []string, clusterroles []string) bool
 	// ToFilter checks if the given resource is set to be filtered in the configuration
+	// Deprecated: resource filters are replaced by exclusion policies, see MatchExclusionPolicy
 	ToFilter(kind schema.GroupVersionKind, subresource, namespace, name string) bool
+	// MatchExclusionPolicy returns the name of the exclusion policy excluding the given resource, if any
+	MatchExclusionPolicy(kind schema.GroupVersionKind, subresource, namespace, name string, labels map[string]string) (string, bool)
+	// RecordExclusionPolicy records a request filtered by an exclusion policy
+	RecordExclusionPolicy(policy string)
+	// SetExclusionPolicies sets the exclusion policies of the controller
+	SetExclusionPolicies(ExclusionPolicies)
 	// GetGenerateSuccessEvents return if should generate success events
 	GetGenerateSuccessEvents() bool
 	// GetWebhooks returns the webhook configs
@@ -167,17 +198,44 @@ type Configuration interface {
 	GetWebhookLabels() map[string]string
 	// GetMatchConditions returns match conditions to set on webhook configs
 	GetMatchConditions() []admissionregistrationv1.MatchCondition
+	// GetSeverity maps the severity declared by a policy to the severity used in reports and metrics
+	GetSeverity(severity string) string
+	// GetCategory maps the categories declared by a policy to the categories used in reports and metrics
+	GetCategory(category string) string
+	// GetSelfProtection returns true if the policy protecting Kyverno resources should be generated
+	GetSelfProtection() bool
+	// GetExcludeKyvernoNamespace returns true if the Kyverno namespace is excluded from webhooks and policies
+	GetExcludeKyvernoNamespace() bool
+	// GetOutboundTransport returns the transport settings used for calls to external services
+	GetOutboundTransport() OutboundTransport
+	// GetExceptionApproval returns the approval workflow settings of policy exceptions
+	GetExceptionApproval() ExceptionApproval
+	// GetEvents returns the settings controlling how events are emitted
+	GetEvents() EventsConfig
+	// GetAutogenControllers returns the pod controllers autogen generates rules for in addition to the built-in ones
+	GetAutogenControllers() []AutogenController
+	// GetClusterMetadata returns the name and labels of the cluster
+	GetClusterMetadata() ClusterMetadata
 	// Load loads configuration from a configmap
 	Load(*corev1.ConfigMap)
 	// OnChanged adds a callback to be invoked when the configuration is reloaded
 	OnChanged(func())
 }
 
+// ExclusionPolicies matches resources against the exclusion policies of a controller
+type ExclusionPolicies interface {
+	// Match returns the name of the exclusion policy excluding the given resource, if any
+	Match(kind schema.GroupVersionKind, subresource, namespace, name string, labels map[string]string) (string, bool)
+	// Record records a request filtered by an exclusion policy
+	Record(policy string)
+}
+
 // configuration stores the configuration
 type configuration struct {
 	skipResourceFilters           bool
 	defaultRegistry               string
 	enableDefaultRegistryMutation bool
+	preserveImageReferences       bool
 	exclusions                    match
 	inclusions                    match
 	filters                       []filter
@@ -186,6 +244,16 @@ type configuration struct {
 	webhookAnnotations            map[string]string
 	webhookLabels                 map[string]string
 	matchConditions               []admissionregistrationv1.MatchCondition
+	severityMapping               map[string]string
+	categoryMapping               map[string]string
+	outboundTransport             OutboundTransport
+	excludeKyvernoNamespace       bool
+	selfProtection                bool
+	exceptionApproval             ExceptionApproval
+	events                        EventsConfig
+	autogenControllers            []AutogenController
+	clusterMetadata               ClusterMetadata
+	exclusionPolicies             ExclusionPolicies
 	mux                           sync.RWMutex
 	callbacks                     []func()
 }
@@ -274,6 +342,29 @@ func (cd *configuration) ToFilter(gvk schema.GroupVersionKind, subresource, name
 	return false
 }
 
+func (cd *configuration) MatchExclusionPolicy(gvk schema.GroupVersionKind, subresource, namespace, name string, labels map[string]string) (string, bool) {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	if cd.skipResourceFilters || cd.exclusionPolicies == nil {
+		return "", false
+	}
+	return cd.exclusionPolicies.Match(gvk, subresource, namespace, name, labels)
+}
+
+func (cd *configuration) RecordExclusionPolicy(policy string) {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	if cd.exclusionPolicies != nil {
+		cd.exclusionPolicies.Record(policy)
+	}
+}
+
+func (cd *configuration) SetExclusionPolicies(exclusionPolicies ExclusionPolicies) {
+	cd.mux.Lock()
+	defer cd.mux.Unlock()
+	cd.exclusionPolicies = exclusionPolicies
+}
+
 func (cd *configuration) GetDefaultRegistry() string {
 	cd.mux.RLock()
 	defer cd.mux.RUnlock()
@@ -286,6 +377,12 @@ func (cd *configuration) GetEnableDefaultRegistryMutation() bool {
 	return cd.enableDefaultRegistryMutation
 }
 
+func (cd *configuration) GetPreserveImageReferences() bool {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.preserveImageReferences
+}
+
 func (cd *configuration) GetGenerateSuccessEvents() bool {
 	cd.mux.RLock()
 	defer cd.mux.RUnlock()
@@ -316,6 +413,111 @@ func (cd *configuration) GetMatchConditions() []admissionregistrationv1.MatchCon
 	return cd.matchConditions
 }
 
+func (cd *configuration) GetSeverity(severity string) string {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	if mapped, ok := cd.severityMapping[severity]; ok {
+		return mapped
+	}
+	return severity
+}
+
+func (cd *configuration) GetCategory(category string) string {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	if len(cd.categoryMapping) == 0 || category == "" {
+		return category
+	}
+	// policies can declare multiple comma separated categories
+	var categories []string
+	for _, item := range strings.Split(category, ",") {
+		item = strings.TrimSpace(item)
+		if mapped, ok := cd.categoryMapping[item]; ok {
+			item = mapped
+		}
+		if item != "" && !slices.Contains(categories, item) {
+			categories = append(categories, item)
+		}
+	}
+	return strings.Join(categories, ", ")
+}
+
+func (cd *configuration) GetSelfProtection() bool {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.selfProtection
+}
+
+func (cd *configuration) GetExcludeKyvernoNamespace() bool {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.excludeKyvernoNamespace
+}
+
+func (cd *configuration) GetOutboundTransport() OutboundTransport {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.outboundTransport
+}
+
+func (cd *configuration) GetExceptionApproval() ExceptionApproval {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.exceptionApproval
+}
+
+// IsExceptionApprovalEnforced returns true when the webhooks enforce the approval of policy exceptions in the
+// given namespace. Approval can't be enforced when the status updates of exceptions in the namespace are filtered
+// by the resource filters or excluded from the webhooks. Only the name label of the namespace is known, a webhook
+// namespace selector using other labels is assumed to exclude the namespace.
+func IsExceptionApprovalEnforced(configuration Configuration, namespace string) bool {
+	gvk := schema.GroupVersionKind{Group: "kyverno.io", Version: "v2", Kind: "PolicyException"}
+	if configuration.ToFilter(gvk, "status", namespace, "") {
+		return false
+	}
+	if namespace == KyvernoNamespace() && configuration.GetExcludeKyvernoNamespace() {
+		return false
+	}
+	for _, webhook := range configuration.GetWebhooks() {
+		if webhook.NamespaceSelector == nil {
+			continue
+		}
+		for key := range webhook.NamespaceSelector.MatchLabels {
+			if key != corev1.LabelMetadataName {
+				return false
+			}
+		}
+		for _, expression := range webhook.NamespaceSelector.MatchExpressions {
+			if expression.Key != corev1.LabelMetadataName {
+				return false
+			}
+		}
+		selector, err := metav1.LabelSelectorAsSelector(webhook.NamespaceSelector)
+		if err != nil || !selector.Matches(labels.Set{corev1.LabelMetadataName: namespace}) {
+			return false
+		}
+	}
+	return true
+}
+
+func (cd *configuration) GetEvents() EventsConfig {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.events
+}
+
+func (cd *configuration) GetAutogenControllers() []AutogenController {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.autogenControllers
+}
+
+func (cd *configuration) GetClusterMetadata() ClusterMetadata {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.clusterMetadata
+}
+
 func (cd *configuration) Load(cm *corev1.ConfigMap) {
 	if cm != nil {
 		cd.load(cm)
@@ -336,6 +538,7 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 	// reset
 	cd.defaultRegistry = "docker.io"
 	cd.enableDefaultRegistryMutation = true
+	cd.preserveImageReferences = false
 	cd.exclusions = match{}
 	cd.inclusions = match{}
 	cd.filters = []filter{}
@@ -344,9 +547,21 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 	cd.webhookAnnotations = nil
 	cd.webhookLabels = nil
 	cd.matchConditions = nil
+	cd.severityMapping = nil
+	cd.categoryMapping = nil
+	cd.outboundTransport = OutboundTransport{}
+	cd.excludeKyvernoNamespace = false
+	cd.selfProtection = false
+	cd.exceptionApproval = ExceptionApproval{}
+	cd.events = EventsConfig{}
+	cd.autogenControllers = nil
+	cd.clusterMetadata = ClusterMetadata{}
 	// load filters
 	cd.filters = parseKinds(data[resourceFilters])
 	logger.Info("filters configured", "filters", cd.filters)
+	if len(cd.filters) != 0 {
+		logger.Info("resourceFilters are deprecated, use ExclusionPolicy resources instead")
+	}
 	// load defaultRegistry
 	defaultRegistry, ok := data[defaultRegistry]
 	if !ok {
@@ -374,6 +589,20 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 			logger.Info("enableDefaultRegistryMutation configured")
 		}
 	}
+	// load preserveImageReferences
+	preserveImageReferences, ok := data[preserveImageReferences]
+	if !ok {
+		logger.Info("preserveImageReferences not set")
+	} else {
+		logger := logger.WithValues("preserveImageReferences", preserveImageReferences)
+		preserveImageReferences, err := strconv.ParseBool(preserveImageReferences)
+		if err != nil {
+			logger.Error(err, "preserveImageReferences is not a boolean")
+		} else {
+			cd.preserveImageReferences = preserveImageReferences
+			logger.Info("preserveImageReferences configured")
+		}
+	}
 	// load excludeGroupRole
 	excludedGroups, ok := data[excludeGroups]
 	if !ok {
@@ -476,6 +705,136 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 			logger.Info("matchConditions configured")
 		}
 	}
+	// load severity mapping
+	severityMapping, ok := data[severityMapping]
+	if !ok {
+		logger.Info("severityMapping not set")
+	} else {
+		logger := logger.WithValues("severityMapping", severityMapping)
+		severityMapping, err := parseSeverityMapping(severityMapping)
+		if err != nil {
+			logger.Error(err, "failed to parse severity mapping")
+		} else {
+			cd.severityMapping = severityMapping
+			logger.Info("severityMapping configured")
+		}
+	}
+	// load category mapping
+	categoryMapping, ok := data[categoryMapping]
+	if !ok {
+		logger.Info("categoryMapping not set")
+	} else {
+		logger := logger.WithValues("categoryMapping", categoryMapping)
+		categoryMapping, err := parseCategoryMapping(categoryMapping)
+		if err != nil {
+			logger.Error(err, "failed to parse category mapping")
+		} else {
+			cd.categoryMapping = categoryMapping
+			logger.Info("categoryMapping configured")
+		}
+	}
+	// load outbound transport
+	outboundTransport, ok := data[outboundTransport]
+	if !ok {
+		logger.Info("outboundTransport not set")
+	} else {
+		outboundTransport, err := parseOutboundTransport(outboundTransport)
+		if err != nil {
+			logger.Error(err, "failed to parse outbound transport")
+		} else {
+			cd.outboundTransport = outboundTransport
+			logger.Info("outboundTransport configured", "destinations", len(outboundTransport.Destinations))
+		}
+	}
+	// load excludeKyvernoNamespace
+	excludeKyvernoNamespace, ok := data[excludeKyvernoNamespace]
+	if !ok {
+		logger.Info("excludeKyvernoNamespace not set")
+	} else {
+		logger := logger.WithValues("excludeKyvernoNamespace", excludeKyvernoNamespace)
+		excludeKyvernoNamespace, err := strconv.ParseBool(excludeKyvernoNamespace)
+		if err != nil {
+			logger.Error(err, "excludeKyvernoNamespace is not a boolean")
+		} else {
+			cd.excludeKyvernoNamespace = excludeKyvernoNamespace
+			logger.Info("excludeKyvernoNamespace configured")
+		}
+	}
+	if cd.excludeKyvernoNamespace {
+		// equivalent to the [*/*,<kyverno namespace>,*] resource filter
+		cd.filters = append([]filter{newFilter("*/*", kyvernoNamespace, "*")}, cd.filters...)
+		cd.webhooks = excludeNamespaceFromWebhooks(cd.webhooks, kyvernoNamespace)
+	}
+	// load selfProtection
+	selfProtection, ok := data[selfProtection]
+	if !ok {
+		logger.Info("selfProtection not set")
+	} else {
+		logger := logger.WithValues("selfProtection", selfProtection)
+		selfProtection, err := strconv.ParseBool(selfProtection)
+		if err != nil {
+			logger.Error(err, "selfProtection is not a boolean")
+		} else {
+			cd.selfProtection = selfProtection
+			logger.Info("selfProtection configured")
+		}
+	}
+	// load exceptionApproval
+	exceptionApproval, ok := data[exceptionApproval]
+	if !ok {
+		logger.Info("exceptionApproval not set")
+	} else {
+		logger := logger.WithValues("exceptionApproval", exceptionApproval)
+		exceptionApproval, err := parseExceptionApproval(exceptionApproval)
+		if err != nil {
+			logger.Error(err, "failed to parse exception approval")
+		} else {
+			cd.exceptionApproval = exceptionApproval
+			logger.Info("exceptionApproval configured")
+		}
+	}
+	// load events
+	events, ok := data[eventsConfig]
+	if !ok {
+		logger.Info("events not set")
+	} else {
+		logger := logger.WithValues("events", events)
+		events, err := parseEventsConfig(events)
+		if err != nil {
+			logger.Error(err, "failed to parse events")
+		} else {
+			cd.events = events
+			logger.Info("events configured")
+		}
+	}
+	// load autogen controllers
+	autogenControllers, ok := data[autogenControllers]
+	if !ok {
+		logger.Info("autogenControllers not set")
+	} else {
+		logger := logger.WithValues("autogenControllers", autogenControllers)
+		autogenControllers, err := parseAutogenControllers(autogenControllers)
+		if err != nil {
+			logger.Error(err, "failed to parse autogen controllers")
+		} else {
+			cd.autogenControllers = autogenControllers
+			logger.Info("autogenControllers configured")
+		}
+	}
+	// load cluster metadata
+	clusterMetadata, ok := data[clusterMetadata]
+	if !ok {
+		logger.Info("clusterMetadata not set")
+	} else {
+		logger := logger.WithValues("clusterMetadata", clusterMetadata)
+		clusterMetadata, err := parseClusterMetadata(clusterMetadata)
+		if err != nil {
+			logger.Error(err, "failed to parse cluster metadata")
+		} else {
+			cd.clusterMetadata = clusterMetadata
+			logger.Info("clusterMetadata configured")
+		}
+	}
 }
 
 func (cd *configuration) unload() {
@@ -484,6 +843,7 @@ func (cd *configuration) unload() {
 	defer cd.notify()
 	cd.defaultRegistry = "docker.io"
 	cd.enableDefaultRegistryMutation = true
+	cd.preserveImageReferences = false
 	cd.exclusions = match{}
 	cd.inclusions = match{}
 	cd.filters = []filter{}
@@ -491,6 +851,15 @@ func (cd *configuration) unload() {
 	cd.webhooks = nil
 	cd.webhookAnnotations = nil
 	cd.webhookLabels = nil
+	cd.severityMapping = nil
+	cd.categoryMapping = nil
+	cd.outboundTransport = OutboundTransport{}
+	cd.excludeKyvernoNamespace = false
+	cd.selfProtection = false
+	cd.exceptionApproval = ExceptionApproval{}
+	cd.events = EventsConfig{}
+	cd.autogenControllers = nil
+	cd.clusterMetadata = ClusterMetadata{}
 	logger.Info("configuration unloaded")
 }
 
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_configuration_taxonomy(t *testing.T) {
+	cfg := NewDefaultConfiguration(false)
+	cfg.Load(&corev1.ConfigMap{
+		Data: map[string]string{
+			severityMapping: `{"P1": "critical"}`,
+			categoryMapping: `{"Pod Security Standards (Baseline)": "Workload Hardening", "Best Practices": "Workload Hardening"}`,
+		},
+	})
+	tests := []struct {
+		name     string
+		severity string
+		category string
+		want     [2]string
+	}{{
+		name:     "mapped",
+		severity: "P1",
+		category: "Pod Security Standards (Baseline)",
+		want:     [2]string{"critical", "Workload Hardening"},
+	}, {
+		name:     "not mapped",
+		severity: "high",
+		category: "Multi-Tenancy",
+		want:     [2]string{"high", "Multi-Tenancy"},
+	}, {
+		name:     "multiple categories",
+		category: "Best Practices, Pod Security Standards (Baseline), Multi-Tenancy",
+		want:     [2]string{"", "Workload Hardening, Multi-Tenancy"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := [2]string{cfg.GetSeverity(tt.severity), cfg.GetCategory(tt.category)}
+			if got != tt.want {
+				t.Errorf("taxonomy = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_configuration_excludeKyvernoNamespace(t *testing.T) {
+	cfg := NewDefaultConfiguration(false)
+	cfg.Load(&corev1.ConfigMap{
+		Data: map[string]string{
+			webhooks:                `[{"namespaceSelector": {"matchLabels": {"team": "a"}}}]`,
+			excludeKyvernoNamespace: "true",
+		},
+	})
+	pod := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	if !cfg.ToFilter(pod, "", kyvernoNamespace, "kyverno-admission-controller") {
+		t.Error("resources in the Kyverno namespace should be filtered")
+	}
+	if cfg.ToFilter(pod, "", "default", "nginx") {
+		t.Error("resources outside of the Kyverno namespace should not be filtered")
+	}
+	webhooks := cfg.GetWebhooks()
+	if len(webhooks) != 1 {
+		t.Fatalf("expected a single webhook config, got %d", len(webhooks))
+	}
+	selector := webhooks[0].NamespaceSelector
+	if selector.MatchLabels["team"] != "a" || len(selector.MatchExpressions) != 1 || selector.MatchExpressions[0].Values[0] != kyvernoNamespace {
+		t.Errorf("unexpected namespace selector %v", selector)
+	}
+	cfg.Load(&corev1.ConfigMap{})
+	if cfg.ToFilter(pod, "", kyvernoNamespace, "kyverno-admission-controller") || len(cfg.GetWebhooks()) != 0 {
+		t.Error("the Kyverno namespace should not be excluded once the setting is removed")
+	}
+}
+
+type namespaceExclusions struct {
+	namespace string
+	recorded  []string
+}
+
+func (e *namespaceExclusions) Match(_ schema.GroupVersionKind, _, namespace, _ string, _ map[string]string) (string, bool) {
+	return "system", namespace == e.namespace
+}
+
+func (e *namespaceExclusions) Record(policy string) {
+	e.recorded = append(e.recorded, policy)
+}
+
+func Test_configuration_exclusionPolicies(t *testing.T) {
+	pod := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	cfg := NewDefaultConfiguration(false)
+	if _, excluded := cfg.MatchExclusionPolicy(pod, "", "kube-system", "coredns", nil); excluded {
+		t.Error("resources should not be excluded without exclusion policies")
+	}
+	cfg.RecordExclusionPolicy("system")
+	exclusions := &namespaceExclusions{namespace: "kube-system"}
+	cfg.SetExclusionPolicies(exclusions)
+	if policy, excluded := cfg.MatchExclusionPolicy(pod, "", "kube-system", "coredns", nil); !excluded || policy != "system" {
+		t.Errorf("resources should be excluded by the system policy, got %s", policy)
+	}
+	if _, excluded := cfg.MatchExclusionPolicy(pod, "", "default", "nginx", nil); excluded {
+		t.Error("resources outside of the excluded namespace should not be excluded")
+	}
+	cfg.RecordExclusionPolicy("system")
+	if len(exclusions.recorded) != 1 {
+		t.Errorf("expected a single recorded request, got %d", len(exclusions.recorded))
+	}
+	skipping := NewDefaultConfiguration(true)
+	skipping.SetExclusionPolicies(exclusions)
+	if _, excluded := skipping.MatchExclusionPolicy(pod, "", "kube-system", "coredns", nil); excluded {
+		t.Error("exclusion policies should be ignored when resource filters are skipped")
+	}
+}
+
+func Test_IsExceptionApprovalEnforced(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      map[string]string
+		namespace string
+		want      bool
+	}{{
+		name:      "default",
+		namespace: "exceptions",
+		want:      true,
+	}, {
+		name:      "filtered namespace",
+		data:      map[string]string{"resourceFilters": "[*/*,kube-system,*]"},
+		namespace: "kube-system",
+	}, {
+		name:      "excluded kyverno namespace",
+		data:      map[string]string{"excludeKyvernoNamespace": "true"},
+		namespace: KyvernoNamespace(),
+	}, {
+		name:      "namespace excluded by name",
+		data:      map[string]string{"webhooks": `[{"namespaceSelector":{"matchExpressions":[{"key":"kubernetes.io/metadata.name","operator":"NotIn","values":["exceptions"]}]}}]`},
+		namespace: "exceptions",
+	}, {
+		name:      "namespace selected by name",
+		data:      map[string]string{"webhooks": `[{"namespaceSelector":{"matchExpressions":[{"key":"kubernetes.io/metadata.name","operator":"NotIn","values":["kube-system"]}]}}]`},
+		namespace: "exceptions",
+		want:      true,
+	}, {
+		name:      "namespace selector on other labels",
+		data:      map[string]string{"webhooks": `[{"namespaceSelector":{"matchExpressions":[{"key":"webhooks.kyverno.io/exclude","operator":"DoesNotExist"}]}}]`},
+		namespace: "exceptions",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfiguration(false)
+			cfg.Load(&corev1.ConfigMap{Data: tt.data})
+			if got := IsExceptionApprovalEnforced(cfg, tt.namespace); got != tt.want {
+				t.Errorf("IsExceptionApprovalEnforced() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
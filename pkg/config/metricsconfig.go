@@ -22,8 +22,13 @@ type MetricsConfiguration interface {
 	CheckNamespace(string) bool
 	// GetBucketBoundaries returns the bucket boundaries for Histogram metrics
 	GetBucketBoundaries() []float64
-	// BuildMeterProviderViews returns OTL view removing attributes which were disabled in the config
+	// BuildMeterProviderViews returns OTL views configuring the bucket boundaries of Histogram metrics
 	BuildMeterProviderViews() []sdkmetric.View
+	// RelabelAttributes returns the attributes a measurement of the given metric is recorded with,
+	// it returns false when the metric is disabled
+	RelabelAttributes(metric string, attributes attribute.Set) (attribute.Set, bool)
+	// GetRuleLatency returns the configuration of the per rule latency histogram
+	GetRuleLatency() RuleLatencyConfig
 	// Load loads configuration from a configmap
 	Load(*corev1.ConfigMap)
 	// OnChanged adds a callback to be invoked when the configuration is reloaded
@@ -36,6 +41,7 @@ type metricsConfig struct {
 	metricsRefreshInterval time.Duration
 	bucketBoundaries       []float64
 	metricsExposure        map[string]metricExposureConfig
+	ruleLatency            RuleLatencyConfig
 	mux                    sync.RWMutex
 	callbacks              []func()
 }
@@ -78,32 +84,59 @@ func (mcd *metricsConfig) BuildMeterProviderViews() []sdkmetric.View {
 	mcd.mux.RLock()
 	defer mcd.mux.RUnlock()
 	var views []sdkmetric.View
+	// metrics and labels are filtered when measurements are recorded so that they can be changed without restart,
+	// the aggregation of an instrument is fixed once it is created though
 	for key, value := range mcd.metricsExposure {
-		if *value.Enabled {
-			views = append(views, sdkmetric.NewView(
-				sdkmetric.Instrument{Name: key},
-				sdkmetric.Stream{
-					AttributeFilter: func(kv attribute.KeyValue) bool {
-						return !slices.Contains(value.DisabledLabelDimensions, string(kv.Key))
-					},
-					Aggregation: sdkmetric.AggregationExplicitBucketHistogram{
-						Boundaries: value.BucketBoundaries,
-						NoMinMax:   false,
-					},
+		views = append(views, sdkmetric.NewView(
+			sdkmetric.Instrument{Name: key, Kind: sdkmetric.InstrumentKindHistogram},
+			sdkmetric.Stream{
+				Aggregation: sdkmetric.AggregationExplicitBucketHistogram{
+					Boundaries: value.BucketBoundaries,
+					NoMinMax:   false,
 				},
-			))
-		} else if !*value.Enabled {
-			views = append(views, sdkmetric.NewView(
-				sdkmetric.Instrument{Name: key},
-				sdkmetric.Stream{
-					Aggregation: sdkmetric.AggregationDrop{},
-				},
-			))
-		}
+			},
+		))
 	}
 	return views
 }
 
+// RelabelAttributes returns the attributes a measurement of the given metric is recorded with,
+// it returns false when the metric is disabled
+func (mcd *metricsConfig) RelabelAttributes(metric string, attributes attribute.Set) (attribute.Set, bool) {
+	mcd.mux.RLock()
+	defer mcd.mux.RUnlock()
+	config, ok := mcd.metricsExposure[metric]
+	if !ok {
+		return attributes, true
+	}
+	if config.Enabled != nil && !*config.Enabled {
+		return attributes, false
+	}
+	if len(config.DisabledLabelDimensions) == 0 && len(config.Relabel) == 0 {
+		return attributes, true
+	}
+	kvs := make([]attribute.KeyValue, 0, attributes.Len())
+	for _, kv := range attributes.ToSlice() {
+		if slices.Contains(config.DisabledLabelDimensions, string(kv.Key)) {
+			continue
+		}
+		for _, relabel := range config.Relabel {
+			if relabel.Label == string(kv.Key) {
+				kv = kv.Key.String(relabel.relabel(kv.Value.Emit()))
+			}
+		}
+		kvs = append(kvs, kv)
+	}
+	return attribute.NewSet(kvs...), true
+}
+
+// GetRuleLatency returns the configuration of the per rule latency histogram
+func (mcd *metricsConfig) GetRuleLatency() RuleLatencyConfig {
+	mcd.mux.RLock()
+	defer mcd.mux.RUnlock()
+	return mcd.ruleLatency
+}
+
 // GetMetricsRefreshInterval returns the refresh interval for the metrics
 func (mcd *metricsConfig) GetMetricsRefreshInterval() time.Duration {
 	mcd.mux.RLock()
@@ -138,9 +171,10 @@ func (mcd *metricsConfig) Load(cm *corev1.ConfigMap) {
 
 func (cd *metricsConfig) load(cm *corev1.ConfigMap) {
 	logger := logger.WithValues("name", cm.Name, "namespace", cm.Namespace)
+	// callbacks are notified once the lock is released so that they can read the configuration
+	defer cd.notify()
 	cd.mux.Lock()
 	defer cd.mux.Unlock()
-	defer cd.notify()
 	data := cm.Data
 	if data == nil {
 		data = map[string]string{}
@@ -203,12 +237,26 @@ func (cd *metricsConfig) load(cm *corev1.ConfigMap) {
 			logger.Info("metricsExposure configured")
 		}
 	}
+	// load rule latency
+	ruleLatency, ok := data["ruleLatency"]
+	if !ok {
+		logger.Info("ruleLatency not set")
+	} else {
+		logger := logger.WithValues("ruleLatency", ruleLatency)
+		ruleLatency, err := parseRuleLatencyConfig(ruleLatency)
+		if err != nil {
+			logger.Error(err, "failed to parse ruleLatency")
+		} else {
+			cd.ruleLatency = ruleLatency
+			logger.Info("ruleLatency configured")
+		}
+	}
 }
 
 func (mcd *metricsConfig) unload() {
+	defer mcd.notify()
 	mcd.mux.Lock()
 	defer mcd.mux.Unlock()
-	defer mcd.notify()
 	mcd.reset()
 }
 
@@ -236,6 +284,9 @@ func (mcd *metricsConfig) reset() {
 		30,
 	}
 	mcd.metricsExposure = map[string]metricExposureConfig{}
+	mcd.ruleLatency = RuleLatencyConfig{
+		MaxSeries: defaultRuleLatencyMaxSeries,
+	}
 }
 
 func (mcd *metricsConfig) notify() {
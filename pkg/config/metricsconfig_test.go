@@ -30,6 +30,7 @@ func Test_metricsConfig_load(t *testing.T) {
 				namespaces:             namespacesConfig{IncludeNamespaces: []string{}, ExcludeNamespaces: []string{}},
 				bucketBoundaries:       []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 15, 20, 25, 30},
 				metricsExposure:        map[string]metricExposureConfig{},
+				ruleLatency:            RuleLatencyConfig{MaxSeries: 1000},
 			},
 		},
 		{
@@ -40,6 +41,7 @@ func Test_metricsConfig_load(t *testing.T) {
 					"namespaces":             `{"include": ["namespace1"], "exclude": ["namespace2"]}`,
 					"bucketBoundaries":       "0.005, 0.01, 0.025, 0.05",
 					"metricsExposure":        `{"metric1": {"enabled": true, "disabledLabelDimensions": ["dim1"]}, "metric2": {"enabled": true, "disabledLabelDimensions": ["dim1","dim2"], "bucketBoundaries": [0.025, 0.05]}}`,
+					"ruleLatency":            `{"enabled": true, "maxSeries": 100}`,
 				},
 			},
 			expectedValue: &metricsConfig{
@@ -50,6 +52,7 @@ func Test_metricsConfig_load(t *testing.T) {
 					"metric1": {Enabled: boolPtr(true), DisabledLabelDimensions: []string{"dim1"}, BucketBoundaries: []float64{0.005, 0.01, 0.025, 0.05}},
 					"metric2": {Enabled: boolPtr(true), DisabledLabelDimensions: []string{"dim1", "dim2"}, BucketBoundaries: []float64{0.025, 0.05}},
 				},
+				ruleLatency: RuleLatencyConfig{Enabled: true, MaxSeries: 100},
 			},
 		},
 		{
@@ -68,6 +71,7 @@ func Test_metricsConfig_load(t *testing.T) {
 					"metric1": {Enabled: boolPtr(true), DisabledLabelDimensions: []string{"dim1"}, BucketBoundaries: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 15, 20, 25, 30}},
 					"metric2": {Enabled: boolPtr(true), DisabledLabelDimensions: []string{"dim1", "dim2"}, BucketBoundaries: []float64{0.025, 0.05}},
 				},
+				ruleLatency: RuleLatencyConfig{MaxSeries: 1000},
 			},
 		},
 	}
@@ -88,6 +92,9 @@ func Test_metricsConfig_load(t *testing.T) {
 			if !reflect.DeepEqual(cd.metricsExposure, tt.expectedValue.metricsExposure) {
 				t.Errorf("Expected %+v, but got %+v", tt.expectedValue.metricsExposure, cd.metricsRefreshInterval)
 			}
+			if !reflect.DeepEqual(cd.ruleLatency, tt.expectedValue.ruleLatency) {
+				t.Errorf("Expected %+v, but got %+v", tt.expectedValue.ruleLatency, cd.ruleLatency)
+			}
 		})
 	}
 }
@@ -105,31 +112,28 @@ func Test_metricsConfig_BuildMeterProviderViews(t *testing.T) {
 			expectedSize:    0,
 		},
 		{
-			name: "Case 2: metrics enabled",
+			name: "Case 2: histogram bucket boundaries",
 			metricsExposure: map[string]metricExposureConfig{
 				"metric1": {Enabled: boolPtr(true), DisabledLabelDimensions: []string{"dim1"}, BucketBoundaries: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 15, 20, 25, 30}},
 			},
 			expectedSize: 1,
 			validateFunc: func(views []sdkmetric.View) bool {
-				stream, _ := views[0](sdkmetric.Instrument{Name: "metric1"})
-				assert := stream.AttributeFilter(attribute.String("policy_validation_mode", ""))
-				assert = assert && !stream.AttributeFilter(attribute.String("dim1", ""))
-				assert = assert && reflect.DeepEqual(stream.Aggregation, sdkmetric.AggregationExplicitBucketHistogram{
+				stream, ok := views[0](sdkmetric.Instrument{Name: "metric1", Kind: sdkmetric.InstrumentKindHistogram})
+				return ok && stream.AttributeFilter == nil && reflect.DeepEqual(stream.Aggregation, sdkmetric.AggregationExplicitBucketHistogram{
 					Boundaries: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 15, 20, 25, 30},
 					NoMinMax:   false,
 				})
-				return assert
 			},
 		},
 		{
-			name: "Case 3: metrics disabled",
+			name: "Case 3: counters are not turned into histograms",
 			metricsExposure: map[string]metricExposureConfig{
 				"metric1": {Enabled: boolPtr(false)},
 			},
 			expectedSize: 1,
 			validateFunc: func(views []sdkmetric.View) bool {
-				stream, _ := views[0](sdkmetric.Instrument{Name: "metric1"})
-				return reflect.DeepEqual(stream.Aggregation, sdkmetric.AggregationDrop{})
+				_, ok := views[0](sdkmetric.Instrument{Name: "metric1", Kind: sdkmetric.InstrumentKindCounter})
+				return !ok
 			},
 		},
 	}
@@ -150,6 +154,74 @@ func Test_metricsConfig_BuildMeterProviderViews(t *testing.T) {
 	}
 }
 
+func Test_metricsConfig_RelabelAttributes(t *testing.T) {
+	attributes := attribute.NewSet(
+		attribute.String("resource_namespace", "kube-system"),
+		attribute.String("resource_name", "coredns"),
+		attribute.String("policy_name", "require-labels"),
+	)
+	tests := []struct {
+		name            string
+		metricsExposure string
+		want            attribute.Set
+		wantEnabled     bool
+	}{
+		{
+			name:        "Case 1: not configured",
+			want:        attributes,
+			wantEnabled: true,
+		},
+		{
+			name:            "Case 2: disabled",
+			metricsExposure: `{"metric1": {"enabled": false}}`,
+			want:            attributes,
+			wantEnabled:     false,
+		},
+		{
+			name:            "Case 3: other metric configured",
+			metricsExposure: `{"metric2": {"enabled": false}}`,
+			want:            attributes,
+			wantEnabled:     true,
+		},
+		{
+			name:            "Case 4: dropped and relabeled labels",
+			metricsExposure: `{"metric1": {"disabledLabelDimensions": ["resource_name"], "relabel": [{"label": "resource_namespace", "regex": "kube-(.*)", "replacement": "system-$1"}, {"label": "policy_name", "regex": "audit-.*", "replacement": "-"}]}}`,
+			want: attribute.NewSet(
+				attribute.String("resource_namespace", "system-system"),
+				attribute.String("policy_name", "require-labels"),
+			),
+			wantEnabled: true,
+		},
+		{
+			name:            "Case 5: relabeled to a constant",
+			metricsExposure: `{"metric1": {"relabel": [{"label": "resource_name", "replacement": "-"}]}}`,
+			want: attribute.NewSet(
+				attribute.String("resource_namespace", "kube-system"),
+				attribute.String("resource_name", "-"),
+				attribute.String("policy_name", "require-labels"),
+			),
+			wantEnabled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mcd := NewDefaultMetricsConfiguration()
+			data := map[string]string{}
+			if tt.metricsExposure != "" {
+				data["metricsExposure"] = tt.metricsExposure
+			}
+			mcd.Load(&corev1.ConfigMap{Data: data})
+			got, enabled := mcd.RelabelAttributes("metric1", attributes)
+			if enabled != tt.wantEnabled {
+				t.Errorf("RelabelAttributes() enabled = %v, want %v", enabled, tt.wantEnabled)
+			}
+			if !got.Equals(&tt.want) {
+				t.Errorf("RelabelAttributes() = %v, want %v", got.Encoded(attribute.DefaultEncoder()), tt.want.Encoded(attribute.DefaultEncoder()))
+			}
+		})
+	}
+}
+
 func Test_metricsConfig_GetBucketBoundaries(t *testing.T) {
 	tests := []struct {
 		name     string
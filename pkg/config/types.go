@@ -2,11 +2,16 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/kyverno/kyverno/ext/wildcard"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -25,6 +30,29 @@ func parseWebhooks(in string) ([]WebhookConfig, error) {
 	return webhookCfgs, nil
 }
 
+// excludeNamespaceFromWebhooks adds an expression excluding the namespace to the namespace selector of every webhook config
+func excludeNamespaceFromWebhooks(in []WebhookConfig, namespace string) []WebhookConfig {
+	exclusion := metav1.LabelSelectorRequirement{
+		Key:      "kubernetes.io/metadata.name",
+		Operator: metav1.LabelSelectorOpNotIn,
+		Values:   []string{namespace},
+	}
+	if len(in) == 0 {
+		in = []WebhookConfig{{}}
+	}
+	out := make([]WebhookConfig, 0, len(in))
+	for _, webhook := range in {
+		selector := &metav1.LabelSelector{}
+		if webhook.NamespaceSelector != nil {
+			selector = webhook.NamespaceSelector.DeepCopy()
+		}
+		selector.MatchExpressions = append(selector.MatchExpressions, exclusion)
+		webhook.NamespaceSelector = selector
+		out = append(out, webhook)
+	}
+	return out
+}
+
 func parseExclusions(in string) (exclusions, inclusions []string) {
 	for _, in := range strings.Split(in, ",") {
 		in := strings.TrimSpace(in)
@@ -63,6 +91,156 @@ func parseWebhookLabels(in string) (map[string]string, error) {
 	return out, nil
 }
 
+// severities are the severities supported by policy reports
+var severities = []string{"critical", "high", "medium", "low", "info"}
+
+func parseSeverityMapping(in string) (map[string]string, error) {
+	var out map[string]string
+	if err := json.Unmarshal([]byte(in), &out); err != nil {
+		return nil, err
+	}
+	for custom, severity := range out {
+		if !slices.Contains(severities, severity) {
+			return nil, fmt.Errorf("severity %s mapped from %s must be one of %s", severity, custom, strings.Join(severities, ", "))
+		}
+	}
+	return out, nil
+}
+
+func parseCategoryMapping(in string) (map[string]string, error) {
+	var out map[string]string
+	if err := json.Unmarshal([]byte(in), &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OutboundTransportSettings configures how Kyverno connects to an external service.
+// PEM data can be provided inline or read from files mounted in the Kyverno pods.
+type OutboundTransportSettings struct {
+	CABundle              string           `json:"caBundle,omitempty"`
+	CAFile                string           `json:"caFile,omitempty"`
+	ClientCertificate     string           `json:"clientCertificate,omitempty"`
+	ClientKey             string           `json:"clientKey,omitempty"`
+	ClientCertificateFile string           `json:"clientCertificateFile,omitempty"`
+	ClientKeyFile         string           `json:"clientKeyFile,omitempty"`
+	Proxy                 string           `json:"proxy,omitempty"`
+	Timeout               *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// OutboundDestination overrides the default outbound settings for a set of hosts.
+// Hosts support wildcards and can include a port.
+type OutboundDestination struct {
+	Hosts                     []string `json:"hosts"`
+	OutboundTransportSettings `json:",inline"`
+}
+
+// OutboundTransport configures the transport used for calls to registries, apiCall services,
+// Rekor and report exporters.
+type OutboundTransport struct {
+	Defaults     OutboundTransportSettings `json:"defaults,omitempty"`
+	Destinations []OutboundDestination     `json:"destinations,omitempty"`
+}
+
+// IsEmpty returns true if no outbound settings are configured.
+func (t OutboundTransport) IsEmpty() bool {
+	return t.Defaults == (OutboundTransportSettings{}) && len(t.Destinations) == 0
+}
+
+// Settings returns the settings applying to the given host, the first matching destination
+// takes precedence over the defaults field by field.
+func (t OutboundTransport) Settings(host string) OutboundTransportSettings {
+	out := t.Defaults
+	hostname := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		hostname = h
+	}
+	for _, destination := range t.Destinations {
+		if !slices.ContainsFunc(destination.Hosts, func(pattern string) bool {
+			return wildcard.Match(pattern, host) || wildcard.Match(pattern, hostname)
+		}) {
+			continue
+		}
+		in := destination.OutboundTransportSettings
+		if in.CABundle != "" || in.CAFile != "" {
+			out.CABundle, out.CAFile = in.CABundle, in.CAFile
+		}
+		if in.ClientCertificate != "" || in.ClientCertificateFile != "" {
+			out.ClientCertificate, out.ClientCertificateFile = in.ClientCertificate, in.ClientCertificateFile
+			out.ClientKey, out.ClientKeyFile = in.ClientKey, in.ClientKeyFile
+		}
+		if in.Proxy != "" {
+			out.Proxy = in.Proxy
+		}
+		if in.Timeout != nil {
+			out.Timeout = in.Timeout
+		}
+		break
+	}
+	return out
+}
+
+func validateOutboundTransportSettings(in OutboundTransportSettings) error {
+	if in.Proxy != "" {
+		if _, err := url.Parse(in.Proxy); err != nil {
+			return fmt.Errorf("invalid proxy %s: %w", in.Proxy, err)
+		}
+	}
+	if (in.ClientCertificate != "" || in.ClientCertificateFile != "") != (in.ClientKey != "" || in.ClientKeyFile != "") {
+		return errors.New("a client certificate and a client key must be configured together")
+	}
+	if in.Timeout != nil && in.Timeout.Duration < 0 {
+		return fmt.Errorf("timeout must not be negative")
+	}
+	return nil
+}
+
+func parseOutboundTransport(in string) (OutboundTransport, error) {
+	var out OutboundTransport
+	if err := json.Unmarshal([]byte(in), &out); err != nil {
+		return out, err
+	}
+	if err := validateOutboundTransportSettings(out.Defaults); err != nil {
+		return out, fmt.Errorf("invalid defaults: %w", err)
+	}
+	for i, destination := range out.Destinations {
+		if len(destination.Hosts) == 0 {
+			return out, fmt.Errorf("destination %d must declare at least one host", i)
+		}
+		if err := validateOutboundTransportSettings(destination.OutboundTransportSettings); err != nil {
+			return out, fmt.Errorf("invalid destination %d: %w", i, err)
+		}
+	}
+	return out, nil
+}
+
+// ExceptionApproval configures the approval workflow of policy exceptions.
+// When enabled, policy exceptions only apply once approved by a cluster administrator
+// or a user belonging to one of the approver groups or bound to one of the approver cluster roles.
+type ExceptionApproval struct {
+	Enabled      bool     `json:"enabled,omitempty"`
+	Groups       []string `json:"groups,omitempty"`
+	ClusterRoles []string `json:"clusterRoles,omitempty"`
+}
+
+func parseExceptionApproval(in string) (ExceptionApproval, error) {
+	var out ExceptionApproval
+	if err := json.Unmarshal([]byte(in), &out); err != nil {
+		return out, err
+	}
+	for _, group := range out.Groups {
+		if group == "" {
+			return out, errors.New("approver groups must not be empty")
+		}
+	}
+	for _, clusterRole := range out.ClusterRoles {
+		if clusterRole == "" {
+			return out, errors.New("approver cluster roles must not be empty")
+		}
+	}
+	return out, nil
+}
+
 func parseMatchConditions(in string) ([]admissionregistrationv1.MatchCondition, error) {
 	var out []admissionregistrationv1.MatchCondition
 	if err := json.Unmarshal([]byte(in), &out); err != nil {
@@ -83,9 +261,30 @@ func parseIncludeExcludeNamespacesFromNamespacesConfig(in string) (namespacesCon
 }
 
 type metricExposureConfig struct {
-	Enabled                 *bool     `json:"enabled,omitempty"`
-	DisabledLabelDimensions []string  `json:"disabledLabelDimensions,omitempty"`
-	BucketBoundaries        []float64 `json:"bucketBoundaries,omitempty"`
+	Enabled                 *bool                 `json:"enabled,omitempty"`
+	DisabledLabelDimensions []string              `json:"disabledLabelDimensions,omitempty"`
+	BucketBoundaries        []float64             `json:"bucketBoundaries,omitempty"`
+	Relabel                 []metricRelabelConfig `json:"relabel,omitempty"`
+}
+
+// metricRelabelConfig replaces the values of a metric label matching a regular expression, it is used to reduce
+// the cardinality of labels like the resource namespace without dropping them
+type metricRelabelConfig struct {
+	// Label is the name of the label to relabel
+	Label string `json:"label"`
+	// Regex is the regular expression the whole label value must match, all values match when it is empty
+	Regex string `json:"regex,omitempty"`
+	// Replacement is the new label value, it can reference the capture groups of the regular expression
+	Replacement string `json:"replacement"`
+	regex       *regexp.Regexp
+}
+
+func (c metricRelabelConfig) relabel(value string) string {
+	match := c.regex.FindStringSubmatchIndex(value)
+	if match == nil {
+		return value
+	}
+	return string(c.regex.ExpandString(nil, c.Replacement, value, match))
 }
 
 func parseMetricExposureConfig(in string, defaultBoundaries []float64) (map[string]metricExposureConfig, error) {
@@ -106,12 +305,53 @@ func parseMetricExposureConfig(in string, defaultBoundaries []float64) (map[stri
 		if config.BucketBoundaries == nil {
 			config.BucketBoundaries = defaultBoundaries
 		}
+		for i := range config.Relabel {
+			relabel := &config.Relabel[i]
+			if relabel.Label == "" {
+				return nil, fmt.Errorf("relabel of metric %s must have a label", key)
+			}
+			expression := relabel.Regex
+			if expression == "" {
+				expression = ".*"
+			}
+			regex, err := regexp.Compile("^(?:" + expression + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("invalid relabel regex of metric %s: %w", key, err)
+			}
+			relabel.regex = regex
+		}
 		metricExposureMap[key] = config
 	}
 
 	return metricExposureMap, err
 }
 
+// defaultRuleLatencyMaxSeries is the default number of policy rules the latency histogram has series for
+const defaultRuleLatencyMaxSeries = 1000
+
+// RuleLatencyConfig configures the per rule latency histogram, it is disabled by default because it
+// has a series for every policy rule.
+type RuleLatencyConfig struct {
+	// Enabled enables the histogram
+	Enabled bool `json:"enabled,omitempty"`
+	// MaxSeries is the maximum number of policy rules with their own series, the latency of other rules
+	// is recorded in a single overflow series
+	MaxSeries int `json:"maxSeries,omitempty"`
+}
+
+func parseRuleLatencyConfig(in string) (RuleLatencyConfig, error) {
+	out := RuleLatencyConfig{
+		MaxSeries: defaultRuleLatencyMaxSeries,
+	}
+	if err := json.Unmarshal([]byte(in), &out); err != nil {
+		return out, err
+	}
+	if out.MaxSeries <= 0 {
+		return out, errors.New("maxSeries must be positive")
+	}
+	return out, nil
+}
+
 type filter struct {
 	Group       string
 	Version     string
@@ -183,3 +423,148 @@ func parseBucketBoundariesConfig(boundariesString string) ([]float64, error) {
 
 	return boundaries, nil
 }
+
+// EventsConfig configures how events are emitted to protect the events API during violation storms
+type EventsConfig struct {
+	// DeduplicationWindow drops the events identical to an event emitted within the window
+	DeduplicationWindow *metav1.Duration `json:"deduplicationWindow,omitempty"`
+	// RateLimit limits the rate at which events are emitted, the events exceeding it are dropped
+	RateLimit *EventsRateLimit `json:"rateLimit,omitempty"`
+	// Aggregation replaces the violations of a policy beyond a threshold with a single "N similar violations" event
+	Aggregation *EventsAggregation `json:"aggregation,omitempty"`
+	// Policies enables or disables the events of policies, the first entry matching a policy applies
+	Policies []PolicyEvents `json:"policies,omitempty"`
+}
+
+// EventsRateLimit is a token bucket rate limit
+type EventsRateLimit struct {
+	QPS   float32 `json:"qps"`
+	Burst int     `json:"burst,omitempty"`
+}
+
+// EventsAggregation aggregates the violations of a policy once more than Threshold were emitted within Window
+type EventsAggregation struct {
+	Threshold int             `json:"threshold"`
+	Window    metav1.Duration `json:"window"`
+}
+
+// PolicyEvents enables or disables the events of the policies matching one of the names,
+// names support wildcards and namespaced policies are matched as namespace/name
+type PolicyEvents struct {
+	Names    []string `json:"names"`
+	Disabled bool     `json:"disabled,omitempty"`
+	// Reasons restricts the entry to the events with one of the reasons, it applies to all events when empty
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// Enabled returns false if the events of the policy with the given reason are disabled
+func (c EventsConfig) Enabled(policy, reason string) bool {
+	for _, entry := range c.Policies {
+		if len(entry.Reasons) != 0 && !slices.Contains(entry.Reasons, reason) {
+			continue
+		}
+		if slices.ContainsFunc(entry.Names, func(name string) bool { return wildcard.Match(name, policy) }) {
+			return !entry.Disabled
+		}
+	}
+	return true
+}
+
+func parseEventsConfig(in string) (EventsConfig, error) {
+	var out EventsConfig
+	if err := json.Unmarshal([]byte(in), &out); err != nil {
+		return out, err
+	}
+	if out.DeduplicationWindow != nil && out.DeduplicationWindow.Duration < 0 {
+		return out, errors.New("deduplication window must not be negative")
+	}
+	if out.RateLimit != nil {
+		if out.RateLimit.QPS <= 0 {
+			return out, errors.New("rate limit qps must be positive")
+		}
+		if out.RateLimit.Burst < 0 {
+			return out, errors.New("rate limit burst must not be negative")
+		}
+	}
+	if out.Aggregation != nil {
+		if out.Aggregation.Threshold <= 0 {
+			return out, errors.New("aggregation threshold must be positive")
+		}
+		if out.Aggregation.Window.Duration <= 0 {
+			return out, errors.New("aggregation window must be positive")
+		}
+	}
+	for i, entry := range out.Policies {
+		if len(entry.Names) == 0 {
+			return out, fmt.Errorf("policy events %d must declare at least one name", i)
+		}
+	}
+	return out, nil
+}
+
+// AutogenController registers a pod controller autogen generates rules for, in addition to the built-in ones
+type AutogenController struct {
+	// Kind is the kind of the controller (e.g. Rollout)
+	Kind string `json:"kind"`
+	// APIVersion is the group version of the controller (e.g. argoproj.io/v1alpha1), any version matches when empty
+	APIVersion string `json:"apiVersion,omitempty"`
+	// PodTemplatePath is the dot separated path to the pod template in the controller (e.g. spec.template)
+	PodTemplatePath string `json:"podTemplatePath"`
+}
+
+// MatchKind returns the kind used to match the controller in rules
+func (c AutogenController) MatchKind() string {
+	if c.APIVersion == "" {
+		return c.Kind
+	}
+	return c.APIVersion + "/" + c.Kind
+}
+
+var builtinAutogenKinds = []string{"Pod", "DaemonSet", "Deployment", "Job", "StatefulSet", "ReplicaSet", "ReplicationController", "CronJob"}
+
+func parseAutogenControllers(in string) ([]AutogenController, error) {
+	var out []AutogenController
+	if err := json.Unmarshal([]byte(in), &out); err != nil {
+		return nil, err
+	}
+	kinds := map[string]bool{}
+	for i, controller := range out {
+		if controller.Kind == "" {
+			return nil, fmt.Errorf("autogen controller %d must declare a kind", i)
+		}
+		if slices.Contains(builtinAutogenKinds, controller.Kind) {
+			return nil, fmt.Errorf("autogen controller %s is a built-in controller", controller.Kind)
+		}
+		if kinds[controller.Kind] {
+			return nil, fmt.Errorf("autogen controller %s is declared more than once", controller.Kind)
+		}
+		kinds[controller.Kind] = true
+		path := controller.PodTemplatePath
+		if path == "" || strings.HasPrefix(path, ".") || strings.HasSuffix(path, ".") || strings.Contains(path, "..") {
+			return nil, fmt.Errorf("autogen controller %s pod template path %q is invalid", controller.Kind, path)
+		}
+	}
+	return out, nil
+}
+
+// ClusterMetadata describes the cluster Kyverno runs in, attestations can be checked against it to reject
+// images attested for other clusters or environments
+type ClusterMetadata struct {
+	// Name is the name of the cluster
+	Name string `json:"name,omitempty"`
+	// Labels are the labels of the cluster (e.g. environment: production)
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+func parseClusterMetadata(in string) (ClusterMetadata, error) {
+	var out ClusterMetadata
+	if err := json.Unmarshal([]byte(in), &out); err != nil {
+		return out, err
+	}
+	for key := range out.Labels {
+		if key == "" {
+			return out, errors.New("cluster label keys must not be empty")
+		}
+	}
+	return out, nil
+}
@@ -3,7 +3,11 @@ package config
 import (
 	"errors"
 	"reflect"
+	"regexp"
 	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func Test_parseExclusions(t *testing.T) {
@@ -295,6 +299,219 @@ func Test_parseWebhookLabels(t *testing.T) {
 	}
 }
 
+func Test_parseSeverityMapping(t *testing.T) {
+	type args struct {
+		in string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    map[string]string
+		wantErr bool
+	}{{
+		args:    args{"hello"},
+		wantErr: true,
+	}, {
+		args:    args{`{"P1": "urgent"}`},
+		wantErr: true,
+	}, {
+		args: args{`{"P1": "critical", "P2": "high"}`},
+		want: map[string]string{
+			"P1": "critical",
+			"P2": "high",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSeverityMapping(tt.args.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseSeverityMapping() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSeverityMapping() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseOutboundTransport(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		wantErr bool
+	}{{
+		name:    "invalid json",
+		in:      "hello",
+		wantErr: true,
+	}, {
+		name:    "destination without hosts",
+		in:      `{"destinations": [{"proxy": "http://proxy:3128"}]}`,
+		wantErr: true,
+	}, {
+		name:    "client certificate without key",
+		in:      `{"defaults": {"clientCertificateFile": "/certs/tls.crt"}}`,
+		wantErr: true,
+	}, {
+		name: "valid",
+		in:   `{"defaults": {"timeout": "10s"}, "destinations": [{"hosts": ["*.corp.example.com"], "clientCertificateFile": "/certs/tls.crt", "clientKeyFile": "/certs/tls.key"}]}`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseOutboundTransport(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseOutboundTransport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_parseExceptionApproval(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    ExceptionApproval
+		wantErr bool
+	}{{
+		name:    "invalid json",
+		in:      "hello",
+		wantErr: true,
+	}, {
+		name:    "empty group",
+		in:      `{"enabled": true, "groups": [""]}`,
+		wantErr: true,
+	}, {
+		name: "valid",
+		in:   `{"enabled": true, "groups": ["security-team"], "clusterRoles": ["exception-approver"]}`,
+		want: ExceptionApproval{Enabled: true, Groups: []string{"security-team"}, ClusterRoles: []string{"exception-approver"}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseExceptionApproval(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseExceptionApproval() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseExceptionApproval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseEventsConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    EventsConfig
+		wantErr bool
+	}{{
+		name:    "invalid json",
+		in:      "hello",
+		wantErr: true,
+	}, {
+		name:    "invalid rate limit",
+		in:      `{"rateLimit": {"qps": 0}}`,
+		wantErr: true,
+	}, {
+		name:    "invalid aggregation",
+		in:      `{"aggregation": {"threshold": 10}}`,
+		wantErr: true,
+	}, {
+		name:    "policies without names",
+		in:      `{"policies": [{"disabled": true}]}`,
+		wantErr: true,
+	}, {
+		name: "valid",
+		in:   `{"deduplicationWindow": "5m", "rateLimit": {"qps": 10, "burst": 50}, "aggregation": {"threshold": 20, "window": "1m"}, "policies": [{"names": ["audit-*"], "disabled": true}]}`,
+		want: EventsConfig{
+			DeduplicationWindow: &metav1.Duration{Duration: 5 * time.Minute},
+			RateLimit:           &EventsRateLimit{QPS: 10, Burst: 50},
+			Aggregation:         &EventsAggregation{Threshold: 20, Window: metav1.Duration{Duration: time.Minute}},
+			Policies:            []PolicyEvents{{Names: []string{"audit-*"}, Disabled: true}},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseEventsConfig(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseEventsConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseEventsConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventsConfig_Enabled(t *testing.T) {
+	cfg := EventsConfig{
+		Policies: []PolicyEvents{
+			{Names: []string{"critical-*"}},
+			{Names: []string{"*"}, Disabled: true, Reasons: []string{"PolicyApplied"}},
+			{Names: []string{"audit-*", "team-a/*"}, Disabled: true},
+		},
+	}
+	tests := []struct {
+		policy string
+		reason string
+		want   bool
+	}{
+		{"critical-pods", "PolicyApplied", true},
+		{"require-labels", "PolicyApplied", false},
+		{"require-labels", "PolicyViolation", true},
+		{"audit-labels", "PolicyViolation", false},
+		{"team-a/require-labels", "PolicyViolation", false},
+		{"team-b/require-labels", "PolicyViolation", true},
+	}
+	for _, tt := range tests {
+		if got := cfg.Enabled(tt.policy, tt.reason); got != tt.want {
+			t.Errorf("Enabled(%s, %s) = %v, want %v", tt.policy, tt.reason, got, tt.want)
+		}
+	}
+}
+
+func TestOutboundTransport_Settings(t *testing.T) {
+	transport, err := parseOutboundTransport(`{
+		"defaults": {"proxy": "http://proxy:3128", "timeout": "30s"},
+		"destinations": [
+			{"hosts": ["rekor.corp.example.com:8443"], "timeout": "5s"},
+			{"hosts": ["*.corp.example.com"], "caFile": "/certs/ca.crt", "proxy": "http://corp-proxy:3128"}
+		]
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		host    string
+		caFile  string
+		proxy   string
+		timeout time.Duration
+	}{{
+		host:    "ghcr.io",
+		proxy:   "http://proxy:3128",
+		timeout: 30 * time.Second,
+	}, {
+		host:    "registry.corp.example.com:443",
+		caFile:  "/certs/ca.crt",
+		proxy:   "http://corp-proxy:3128",
+		timeout: 30 * time.Second,
+	}, {
+		host:    "rekor.corp.example.com:8443",
+		proxy:   "http://proxy:3128",
+		timeout: 5 * time.Second,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			got := transport.Settings(tt.host)
+			if got.CAFile != tt.caFile || got.Proxy != tt.proxy || got.Timeout.Duration != tt.timeout {
+				t.Errorf("Settings() = %+v, want caFile %s, proxy %s, timeout %s", got, tt.caFile, tt.proxy, tt.timeout)
+			}
+		})
+	}
+}
+
 func Test_parseBucketBoundariesConfig(t *testing.T) {
 	var emptyBoundaries []float64
 
@@ -387,6 +604,34 @@ func Test_parseMetricExposureConfig(t *testing.T) {
 			expected:      nil,
 			expectedError: true,
 		},
+		// Test case 6: Valid JSON with "relabel" set
+		{
+			input: `{
+				"key1": {"relabel": [{"label": "resource_namespace", "regex": "kube-.*", "replacement": "system"}]}
+			}`,
+			expected: map[string]metricExposureConfig{
+				"key1": {Enabled: boolPtr(true), DisabledLabelDimensions: []string{}, BucketBoundaries: defaultBoundaries, Relabel: []metricRelabelConfig{
+					{Label: "resource_namespace", Regex: "kube-.*", Replacement: "system", regex: regexp.MustCompile("^(?:kube-.*)$")},
+				}},
+			},
+			expectedError: false,
+		},
+		// Test case 7: Invalid relabel regex
+		{
+			input: `{
+				"key1": {"relabel": [{"label": "resource_namespace", "regex": "kube-(", "replacement": "system"}]}
+			}`,
+			expected:      nil,
+			expectedError: true,
+		},
+		// Test case 8: Relabel without label
+		{
+			input: `{
+				"key1": {"relabel": [{"replacement": "-"}]}
+			}`,
+			expected:      nil,
+			expectedError: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -407,3 +652,118 @@ func Test_parseMetricExposureConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseRuleLatencyConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    RuleLatencyConfig
+		wantErr bool
+	}{{
+		name:    "invalid json",
+		in:      "hello",
+		wantErr: true,
+	}, {
+		name:    "negative max series",
+		in:      `{"enabled": true, "maxSeries": -1}`,
+		wantErr: true,
+	}, {
+		name: "default max series",
+		in:   `{"enabled": true}`,
+		want: RuleLatencyConfig{Enabled: true, MaxSeries: defaultRuleLatencyMaxSeries},
+	}, {
+		name: "valid",
+		in:   `{"enabled": true, "maxSeries": 50}`,
+		want: RuleLatencyConfig{Enabled: true, MaxSeries: 50},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRuleLatencyConfig(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseRuleLatencyConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseRuleLatencyConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseAutogenControllers(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    []AutogenController
+		wantErr bool
+	}{{
+		name:    "invalid json",
+		in:      "hello",
+		wantErr: true,
+	}, {
+		name:    "missing kind",
+		in:      `[{"podTemplatePath": "spec.template"}]`,
+		wantErr: true,
+	}, {
+		name:    "built-in controller",
+		in:      `[{"kind": "Deployment", "podTemplatePath": "spec.template"}]`,
+		wantErr: true,
+	}, {
+		name:    "duplicate controller",
+		in:      `[{"kind": "Rollout", "podTemplatePath": "spec.template"}, {"kind": "Rollout", "podTemplatePath": "spec.template"}]`,
+		wantErr: true,
+	}, {
+		name:    "invalid path",
+		in:      `[{"kind": "Rollout", "podTemplatePath": "spec..template"}]`,
+		wantErr: true,
+	}, {
+		name: "valid",
+		in:   `[{"kind": "Rollout", "apiVersion": "argoproj.io/v1alpha1", "podTemplatePath": "spec.template"}]`,
+		want: []AutogenController{{Kind: "Rollout", APIVersion: "argoproj.io/v1alpha1", PodTemplatePath: "spec.template"}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAutogenControllers(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseAutogenControllers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseAutogenControllers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseClusterMetadata(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    ClusterMetadata
+		wantErr bool
+	}{{
+		name:    "invalid json",
+		in:      "hello",
+		wantErr: true,
+	}, {
+		name:    "empty label key",
+		in:      `{"name": "prod-eu-1", "labels": {"": "production"}}`,
+		wantErr: true,
+	}, {
+		name: "valid",
+		in:   `{"name": "prod-eu-1", "labels": {"environment": "production"}}`,
+		want: ClusterMetadata{Name: "prod-eu-1", Labels: map[string]string{"environment": "production"}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseClusterMetadata(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseClusterMetadata() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseClusterMetadata() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
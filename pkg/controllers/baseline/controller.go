@@ -0,0 +1,220 @@
+package baseline
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	"go.uber.org/multierr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	maxRetries     = 10
+	Workers        = 2
+	ControllerName = "baseline-controller"
+)
+
+type controller struct {
+	// clients
+	client        dclient.Interface
+	kyvernoClient versioned.Interface
+
+	// listers
+	baselineLister kyvernov2alpha1listers.ClusterBaselineLister
+	nsLister       corev1listers.NamespaceLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+}
+
+func NewController(
+	client dclient.Interface,
+	kyvernoClient versioned.Interface,
+	baselineInformer kyvernov2alpha1informers.ClusterBaselineInformer,
+	nsLister corev1listers.NamespaceLister,
+) controllers.Controller {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+	c := &controller{
+		client:         client,
+		kyvernoClient:  kyvernoClient,
+		baselineLister: baselineInformer.Lister(),
+		nsLister:       nsLister,
+		queue:          queue,
+	}
+	enqueue := controllerutils.LogError(logger, controllerutils.Parse(controllerutils.MetaNamespaceKeyT[*kyvernov2alpha1.ClusterBaseline], controllerutils.Queue(queue)))
+	if _, err := controllerutils.AddEventHandlersT(
+		baselineInformer.Informer(),
+		controllerutils.AddFuncT(logger, enqueue),
+		// status updates don't change the generation, reconciling them would loop forever
+		func(old, obj *kyvernov2alpha1.ClusterBaseline) {
+			if old.GetGeneration() != obj.GetGeneration() {
+				if err := enqueue(obj); err != nil {
+					logger.Error(err, "failed to enqueue object", "obj", obj)
+				}
+			}
+		},
+		nil,
+	); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger.V(3), ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, name string) error {
+	baseline, err := c.baselineLister.Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		logger.Error(err, "unable to get the baseline from baseline informer")
+		return err
+	}
+	var statuses []kyvernov2alpha1.BaselineResourceStatus
+	var errs []error
+	if invalid := baseline.Validate(); len(invalid) != 0 {
+		errs = append(errs, invalid.ToAggregate())
+	} else {
+		for i := range baseline.Spec.Resources {
+			resourceStatuses, err := c.reconcileResource(ctx, logger, &baseline.Spec.Resources[i])
+			statuses = append(statuses, resourceStatuses...)
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	err = multierr.Combine(errs...)
+	if err := c.updateStatus(ctx, baseline, statuses, err); err != nil {
+		logger.Error(err, "failed to update the baseline status")
+		return err
+	}
+	// reconcile the baseline again after the interval to detect drifts
+	c.queue.AddAfter(key, baseline.GetReconcileInterval())
+	return nil
+}
+
+func (c *controller) reconcileResource(ctx context.Context, logger logr.Logger, resource *kyvernov2alpha1.BaselineResource) ([]kyvernov2alpha1.BaselineResourceStatus, error) {
+	data, err := resource.GetData()
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := c.getNamespaces(resource)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []kyvernov2alpha1.BaselineResourceStatus
+	var errs []error
+	for _, namespace := range namespaces {
+		status := kyvernov2alpha1.BaselineResourceStatus{
+			APIVersion: resource.APIVersion,
+			Kind:       resource.Kind,
+			Namespace:  namespace,
+			Name:       resource.Name,
+		}
+		drift, created, err := c.reconcileObject(ctx, buildDesired(resource, namespace, data))
+		if err != nil {
+			logger.Error(err, "failed to reconcile baseline resource", "kind", resource.Kind, "namespace", namespace, "name", resource.Name)
+			status.State = kyvernov2alpha1.BaselineResourceFailed
+			status.Message = err.Error()
+			errs = append(errs, err)
+		} else if created {
+			status.State = kyvernov2alpha1.BaselineResourceCreated
+		} else if len(drift) != 0 {
+			logger.Info("baseline resource drifted", "kind", resource.Kind, "namespace", namespace, "name", resource.Name, "drift", drift)
+			status.State = kyvernov2alpha1.BaselineResourceDrifted
+			status.Drift = drift
+		} else {
+			status.State = kyvernov2alpha1.BaselineResourceInSync
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, multierr.Combine(errs...)
+}
+
+// reconcileObject creates the object when missing, or corrects the declared fields that drifted.
+func (c *controller) reconcileObject(ctx context.Context, desired *unstructured.Unstructured) ([]string, bool, error) {
+	apiVersion, kind, namespace, name := desired.GetAPIVersion(), desired.GetKind(), desired.GetNamespace(), desired.GetName()
+	actual, err := c.client.GetResource(ctx, apiVersion, kind, namespace, name)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, false, err
+		}
+		if _, err := c.client.CreateResource(ctx, apiVersion, kind, namespace, desired, false); err != nil {
+			return nil, false, err
+		}
+		return nil, true, nil
+	}
+	drift := computeDrift(desired.Object, actual.Object)
+	if len(drift) == 0 {
+		return nil, false, nil
+	}
+	mergeDeclared(desired.Object, actual.Object)
+	if _, err := c.client.UpdateResource(ctx, apiVersion, kind, namespace, actual, false); err != nil {
+		return drift, false, err
+	}
+	return drift, false, nil
+}
+
+// getNamespaces returns the namespaces the resource must be reconciled in.
+func (c *controller) getNamespaces(resource *kyvernov2alpha1.BaselineResource) ([]string, error) {
+	if resource.NamespaceSelector == nil {
+		return []string{resource.Namespace}, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(resource.NamespaceSelector)
+	if err != nil {
+		return nil, err
+	}
+	list, err := c.nsLister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := make([]string, 0, len(list))
+	for _, ns := range list {
+		namespaces = append(namespaces, ns.GetName())
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+func (c *controller) updateStatus(ctx context.Context, baseline *kyvernov2alpha1.ClusterBaseline, statuses []kyvernov2alpha1.BaselineResourceStatus, err error) error {
+	latest := baseline.DeepCopy()
+	latest.Status.Resources = statuses
+	latest.Status.LastReconcileTime = metav1.Now()
+	if err != nil {
+		latest.Status.SetReady(false, err.Error())
+	} else {
+		latest.Status.SetReady(true, fmt.Sprintf("%d resources reconciled", len(statuses)))
+	}
+	_, updateErr := c.kyvernoClient.KyvernoV2alpha1().ClusterBaselines().UpdateStatus(ctx, latest, metav1.UpdateOptions{})
+	return updateErr
+}
+
+// buildDesired returns the object declared by the resource in the given namespace.
+func buildDesired(resource *kyvernov2alpha1.BaselineResource, namespace string, data map[string]interface{}) *unstructured.Unstructured {
+	desired := &unstructured.Unstructured{Object: runtime.DeepCopyJSON(data)}
+	desired.SetAPIVersion(resource.APIVersion)
+	desired.SetKind(resource.Kind)
+	desired.SetName(resource.Name)
+	if namespace != "" {
+		desired.SetNamespace(namespace)
+	}
+	return desired
+}
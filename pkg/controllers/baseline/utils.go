@@ -0,0 +1,59 @@
+package baseline
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+)
+
+// computeDrift returns the JSON pointers of the declared fields that don't match the actual object.
+// Only the declared fields are compared, fields set on the actual object but not declared are ignored.
+func computeDrift(declared, actual map[string]interface{}, segments ...string) []string {
+	var drift []string
+	keys := make([]string, 0, len(declared))
+	for key := range declared {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		path := append(append([]string{}, segments...), key)
+		actualValue, ok := actual[key]
+		if !ok {
+			drift = append(drift, jsonutils.JoinPointer(path...))
+			continue
+		}
+		declaredMap, declaredIsMap := declared[key].(map[string]interface{})
+		actualMap, actualIsMap := actualValue.(map[string]interface{})
+		if declaredIsMap && actualIsMap {
+			drift = append(drift, computeDrift(declaredMap, actualMap, path...)...)
+		} else if !equal(declared[key], actualValue) {
+			drift = append(drift, jsonutils.JoinPointer(path...))
+		}
+	}
+	return drift
+}
+
+// mergeDeclared sets the declared fields on the actual object, nested objects are merged and other values replaced.
+func mergeDeclared(declared, actual map[string]interface{}) {
+	for key, value := range declared {
+		declaredMap, declaredIsMap := value.(map[string]interface{})
+		actualMap, actualIsMap := actual[key].(map[string]interface{})
+		if declaredIsMap && actualIsMap {
+			mergeDeclared(declaredMap, actualMap)
+		} else {
+			actual[key] = value
+		}
+	}
+}
+
+// equal compares values through their JSON representation so that numbers decoded with different types are equal.
+func equal(a, b interface{}) bool {
+	aBytes, aErr := json.Marshal(a)
+	bBytes, bErr := json.Marshal(b)
+	if aErr != nil || bErr != nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return string(aBytes) == string(bBytes)
+}
@@ -0,0 +1,65 @@
+package baseline
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_computeDrift(t *testing.T) {
+	tests := []struct {
+		name     string
+		declared map[string]interface{}
+		actual   map[string]interface{}
+		want     []string
+	}{{
+		name:     "in sync",
+		declared: map[string]interface{}{"value": float64(1000), "spec": map[string]interface{}{"hard": map[string]interface{}{"cpu": "4"}}},
+		actual:   map[string]interface{}{"value": int64(1000), "spec": map[string]interface{}{"hard": map[string]interface{}{"cpu": "4", "memory": "8Gi"}}},
+	}, {
+		name:     "changed value",
+		declared: map[string]interface{}{"spec": map[string]interface{}{"hard": map[string]interface{}{"cpu": "4"}}},
+		actual:   map[string]interface{}{"spec": map[string]interface{}{"hard": map[string]interface{}{"cpu": "8"}}},
+		want:     []string{"/spec/hard/cpu"},
+	}, {
+		name:     "missing fields",
+		declared: map[string]interface{}{"globalDefault": false, "metadata": map[string]interface{}{"labels": map[string]interface{}{"tier/name": "critical"}}},
+		actual:   map[string]interface{}{"metadata": map[string]interface{}{"labels": map[string]interface{}{}}},
+		want:     []string{"/globalDefault", "/metadata/labels/tier~1name"},
+	}, {
+		name:     "list replaced",
+		declared: map[string]interface{}{"spec": map[string]interface{}{"limits": []interface{}{map[string]interface{}{"type": "Container"}}}},
+		actual:   map[string]interface{}{"spec": map[string]interface{}{"limits": []interface{}{}}},
+		want:     []string{"/spec/limits"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := computeDrift(tt.declared, tt.actual); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("computeDrift() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_mergeDeclared(t *testing.T) {
+	declared := map[string]interface{}{
+		"value": float64(1000),
+		"spec":  map[string]interface{}{"hard": map[string]interface{}{"cpu": "4"}},
+	}
+	actual := map[string]interface{}{
+		"value":    int64(10),
+		"metadata": map[string]interface{}{"resourceVersion": "42"},
+		"spec":     map[string]interface{}{"hard": map[string]interface{}{"cpu": "8", "memory": "8Gi"}},
+	}
+	mergeDeclared(declared, actual)
+	want := map[string]interface{}{
+		"value":    float64(1000),
+		"metadata": map[string]interface{}{"resourceVersion": "42"},
+		"spec":     map[string]interface{}{"hard": map[string]interface{}{"cpu": "4", "memory": "8Gi"}},
+	}
+	if !reflect.DeepEqual(actual, want) {
+		t.Errorf("mergeDeclared() = %v, want %v", actual, want)
+	}
+	if drift := computeDrift(declared, actual); len(drift) != 0 {
+		t.Errorf("computeDrift() after merge = %v, want none", drift)
+	}
+}
@@ -6,9 +6,13 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/tls"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	retryutils "github.com/kyverno/kyverno/pkg/utils/retry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -39,6 +43,9 @@ type controller struct {
 	caSecretName  string
 	tlsSecretName string
 	namespace     string
+
+	// expirySeconds reports the number of seconds before the certificates expire
+	expirySeconds metric.Float64ObservableGauge
 }
 
 func NewController(
@@ -63,9 +70,45 @@ func NewController(
 		tlsSecretName: tlsSecretName,
 		namespace:     namespace,
 	}
+	c.expirySeconds = c.newMetrics()
 	return &c
 }
 
+func (c *controller) newMetrics() metric.Float64ObservableGauge {
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	expirySeconds, err := meter.Float64ObservableGauge(
+		"kyverno_tls_certificate_expiry_seconds",
+		metric.WithDescription("can be used to track the number of seconds before the webhook certificates expire, a value that jumps up means the certificate was rotated."),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_tls_certificate_expiry_seconds")
+		return nil
+	}
+	if _, err := meter.RegisterCallback(c.report, expirySeconds); err != nil {
+		logger.Error(err, "Failed to register callback")
+	}
+	return expirySeconds
+}
+
+func (c *controller) report(ctx context.Context, observer metric.Observer) error {
+	now := time.Now()
+	secrets := map[string]corev1listers.SecretLister{
+		c.caSecretName:  c.caLister,
+		c.tlsSecretName: c.tlsLister,
+	}
+	for name, lister := range secrets {
+		expiry, err := tls.ReadCertificateExpiry(name, c.namespace, lister.Secrets(c.namespace))
+		if err != nil {
+			continue
+		}
+		observer.ObserveFloat64(c.expirySeconds, expiry.Sub(now).Seconds(), metric.WithAttributes(
+			attribute.String("secret_namespace", c.namespace),
+			attribute.String("secret_name", name),
+		))
+	}
+	return nil
+}
+
 func (c *controller) Run(ctx context.Context, workers int) {
 	// we need to enqueue our secrets in case they don't exist yet in the cluster
 	// this way we ensure the reconcile happens (hence renewal/creation)
@@ -0,0 +1,69 @@
+package cleanup
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// actionPatch returns the JSON patch performing the action on the resource,
+// nil is returned when the resource is already in the desired state.
+// The delete action doesn't use a patch.
+func actionPatch(spec *kyvernov2beta1.CleanupPolicySpec, resource unstructured.Unstructured) ([]byte, error) {
+	patch := jsonutils.NewPatchBuilder()
+	switch action := spec.GetAction(); action {
+	case kyvernov2beta1.CleanupActionScaleToZero:
+		replicas, ok, err := unstructured.NestedInt64(resource.Object, "spec", "replicas")
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("%s %s/%s can not be scaled, spec.replicas is not set", resource.GetKind(), resource.GetNamespace(), resource.GetName())
+		}
+		if replicas == 0 {
+			return nil, nil
+		}
+		patch.Add(0, "spec", "replicas")
+		addAnnotations(patch, resource, map[string]string{kyverno.AnnotationCleanupReplicas: strconv.FormatInt(replicas, 10)})
+	case kyvernov2beta1.CleanupActionRestore:
+		value, ok := resource.GetAnnotations()[kyverno.AnnotationCleanupReplicas]
+		if !ok {
+			return nil, nil
+		}
+		replicas, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %w", kyverno.AnnotationCleanupReplicas, err)
+		}
+		patch.Add(replicas, "spec", "replicas")
+		patch.Remove("metadata", "annotations", kyverno.AnnotationCleanupReplicas)
+	case kyvernov2beta1.CleanupActionAnnotate:
+		annotations := resource.GetAnnotations()
+		missing := map[string]string{}
+		for key, value := range spec.Annotations {
+			if current, ok := annotations[key]; !ok || current != value {
+				missing[key] = value
+			}
+		}
+		if len(missing) == 0 {
+			return nil, nil
+		}
+		addAnnotations(patch, resource, missing)
+	default:
+		return nil, fmt.Errorf("action %s doesn't use a patch", action)
+	}
+	return patch.Bytes()
+}
+
+// addAnnotations adds the operations setting the annotations on the resource to the patch
+func addAnnotations(patch *jsonutils.PatchBuilder, resource unstructured.Unstructured, annotations map[string]string) {
+	if resource.GetAnnotations() == nil {
+		patch.Add(map[string]string{}, "metadata", "annotations")
+	}
+	for key, value := range annotations {
+		patch.Add(value, "metadata", "annotations", key)
+	}
+}
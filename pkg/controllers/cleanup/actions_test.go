@@ -0,0 +1,97 @@
+package cleanup
+
+import (
+	"encoding/json"
+	"testing"
+
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newDeployment(replicas int64, annotations map[string]interface{}) unstructured.Unstructured {
+	metadata := map[string]interface{}{
+		"name":      "deployment",
+		"namespace": "default",
+	}
+	if annotations != nil {
+		metadata["annotations"] = annotations
+	}
+	return unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   metadata,
+			"spec": map[string]interface{}{
+				"replicas": replicas,
+			},
+		},
+	}
+}
+
+func applyPatch(t *testing.T, resource unstructured.Unstructured, patch []byte) unstructured.Unstructured {
+	document, err := resource.MarshalJSON()
+	assert.NilError(t, err)
+	var operations []jsonutils.PatchOperation
+	assert.NilError(t, json.Unmarshal(patch, &operations))
+	builder := jsonutils.NewPatchBuilder()
+	for _, operation := range operations {
+		builder.Operation(operation.Op, operation.Path, operation.Value)
+	}
+	patched, err := builder.Apply(document)
+	assert.NilError(t, err)
+	var result unstructured.Unstructured
+	assert.NilError(t, result.UnmarshalJSON(patched))
+	return result
+}
+
+func Test_actionPatch_scaleToZeroAndRestore(t *testing.T) {
+	scaleToZero := &kyvernov2beta1.CleanupPolicySpec{Action: kyvernov2beta1.CleanupActionScaleToZero}
+	restore := &kyvernov2beta1.CleanupPolicySpec{Action: kyvernov2beta1.CleanupActionRestore}
+	deployment := newDeployment(3, nil)
+	// nothing to restore yet
+	patch, err := actionPatch(restore, deployment)
+	assert.NilError(t, err)
+	assert.Assert(t, patch == nil)
+	// park the deployment
+	patch, err = actionPatch(scaleToZero, deployment)
+	assert.NilError(t, err)
+	parked := applyPatch(t, deployment, patch)
+	replicas, _, _ := unstructured.NestedInt64(parked.Object, "spec", "replicas")
+	assert.Equal(t, replicas, int64(0))
+	assert.Equal(t, parked.GetAnnotations()["cleanup.kyverno.io/replicas"], "3")
+	// already parked
+	patch, err = actionPatch(scaleToZero, parked)
+	assert.NilError(t, err)
+	assert.Assert(t, patch == nil)
+	// bring it back
+	patch, err = actionPatch(restore, parked)
+	assert.NilError(t, err)
+	restored := applyPatch(t, parked, patch)
+	replicas, _, _ = unstructured.NestedInt64(restored.Object, "spec", "replicas")
+	assert.Equal(t, replicas, int64(3))
+	_, ok := restored.GetAnnotations()["cleanup.kyverno.io/replicas"]
+	assert.Assert(t, !ok)
+}
+
+func Test_actionPatch_scaleToZeroWithoutReplicas(t *testing.T) {
+	spec := &kyvernov2beta1.CleanupPolicySpec{Action: kyvernov2beta1.CleanupActionScaleToZero}
+	_, err := actionPatch(spec, newJob(nil))
+	assert.ErrorContains(t, err, "can not be scaled")
+}
+
+func Test_actionPatch_annotate(t *testing.T) {
+	spec := &kyvernov2beta1.CleanupPolicySpec{
+		Action:      kyvernov2beta1.CleanupActionAnnotate,
+		Annotations: map[string]string{"example.com/parked": "true"},
+	}
+	deployment := newDeployment(1, map[string]interface{}{"foo": "bar"})
+	patch, err := actionPatch(spec, deployment)
+	assert.NilError(t, err)
+	annotated := applyPatch(t, deployment, patch)
+	assert.DeepEqual(t, annotated.GetAnnotations(), map[string]string{"foo": "bar", "example.com/parked": "true"})
+	patch, err = actionPatch(spec, annotated)
+	assert.NilError(t, err)
+	assert.Assert(t, patch == nil)
+}
@@ -0,0 +1,88 @@
+package cleanup
+
+import (
+	"encoding/json"
+	"time"
+
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ageVariables maps the variables available in the conditions under `age` to the timestamps they are computed from
+var ageVariables = map[string]kyvernov2beta1.CleanupAgeReference{
+	"creation":       kyvernov2beta1.CleanupAgeCreation,
+	"completion":     kyvernov2beta1.CleanupAgeCompletion,
+	"lastTransition": kyvernov2beta1.CleanupAgeLastTransition,
+}
+
+// referenceTime returns the point in time the age of a resource is computed from
+func referenceTime(resource unstructured.Unstructured, since kyvernov2beta1.CleanupAgeReference) (time.Time, bool) {
+	switch since {
+	case kyvernov2beta1.CleanupAgeCompletion:
+		return parseTime(resource.Object, "status", "completionTime")
+	case kyvernov2beta1.CleanupAgeLastTransition:
+		conditions, ok, _ := unstructured.NestedSlice(resource.Object, "status", "conditions")
+		if !ok {
+			return time.Time{}, false
+		}
+		var last time.Time
+		for _, condition := range conditions {
+			if condition, ok := condition.(map[string]interface{}); ok {
+				if t, ok := parseTime(condition, "lastTransitionTime"); ok && t.After(last) {
+					last = t
+				}
+			}
+		}
+		return last, !last.IsZero()
+	default:
+		creation := resource.GetCreationTimestamp().Time
+		return creation, !creation.IsZero()
+	}
+}
+
+func parseTime(obj map[string]interface{}, fields ...string) (time.Time, bool) {
+	value, ok, _ := unstructured.NestedString(obj, fields...)
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// isOlderThan checks the resource is older than the given age, resources without the reference timestamp are not
+func isOlderThan(resource unstructured.Unstructured, age *kyvernov2beta1.CleanupAge, now time.Time) (bool, error) {
+	duration, err := age.GetDuration()
+	if err != nil {
+		return false, err
+	}
+	reference, ok := referenceTime(resource, age.GetSince())
+	if !ok {
+		return false, nil
+	}
+	return now.Sub(reference) > duration, nil
+}
+
+// resourceAge returns the age of the resource computed from the available timestamps,
+// durations are formatted as strings so that they can be compared with the condition operators
+func resourceAge(resource unstructured.Unstructured, now time.Time) map[string]interface{} {
+	age := map[string]interface{}{}
+	for name, since := range ageVariables {
+		if reference, ok := referenceTime(resource, since); ok {
+			age[name] = now.Sub(reference).Round(time.Second).String()
+		}
+	}
+	return age
+}
+
+// addAge replaces the `age` variable in the context with the age of the resource
+func addAge(ctx enginecontext.Interface, resource unstructured.Unstructured, now time.Time) error {
+	data, err := json.Marshal(resourceAge(resource, now))
+	if err != nil {
+		return err
+	}
+	return ctx.ReplaceContextEntry("age", data)
+}
@@ -0,0 +1,102 @@
+package cleanup
+
+import (
+	"testing"
+	"time"
+
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newJob(status map[string]interface{}) unstructured.Unstructured {
+	job := unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "batch/v1",
+			"kind":       "Job",
+			"metadata": map[string]interface{}{
+				"name":              "job",
+				"namespace":         "default",
+				"creationTimestamp": "2023-01-01T00:00:00Z",
+			},
+		},
+	}
+	if status != nil {
+		job.Object["status"] = status
+	}
+	return job
+}
+
+func Test_isOlderThan(t *testing.T) {
+	now := time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC)
+	completed := newJob(map[string]interface{}{
+		"completionTime": "2023-01-05T00:00:00Z",
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Suspended", "lastTransitionTime": "2023-01-01T12:00:00Z"},
+			map[string]interface{}{"type": "Complete", "lastTransitionTime": "2023-01-05T00:00:00Z"},
+		},
+	})
+	running := newJob(nil)
+	tests := []struct {
+		name     string
+		resource unstructured.Unstructured
+		age      kyvernov2beta1.CleanupAge
+		want     bool
+		wantErr  bool
+	}{{
+		name:     "creation older",
+		resource: running,
+		age:      kyvernov2beta1.CleanupAge{Duration: "7d"},
+		want:     true,
+	}, {
+		name:     "creation newer",
+		resource: running,
+		age:      kyvernov2beta1.CleanupAge{Duration: "2w"},
+		want:     false,
+	}, {
+		name:     "completion newer",
+		resource: completed,
+		age:      kyvernov2beta1.CleanupAge{Duration: "7d", Since: kyvernov2beta1.CleanupAgeCompletion},
+		want:     false,
+	}, {
+		name:     "completion older",
+		resource: completed,
+		age:      kyvernov2beta1.CleanupAge{Duration: "96h", Since: kyvernov2beta1.CleanupAgeCompletion},
+		want:     true,
+	}, {
+		name:     "not completed",
+		resource: running,
+		age:      kyvernov2beta1.CleanupAge{Duration: "1h", Since: kyvernov2beta1.CleanupAgeCompletion},
+		want:     false,
+	}, {
+		name:     "most recent transition",
+		resource: completed,
+		age:      kyvernov2beta1.CleanupAge{Duration: "6d", Since: kyvernov2beta1.CleanupAgeLastTransition},
+		want:     false,
+	}, {
+		name:     "invalid duration",
+		resource: running,
+		age:      kyvernov2beta1.CleanupAge{Duration: "week"},
+		wantErr:  true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := isOlderThan(tt.resource, &tt.age, now)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+				assert.Equal(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_resourceAge(t *testing.T) {
+	now := time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC)
+	age := resourceAge(newJob(map[string]interface{}{"completionTime": "2023-01-09T23:00:00Z"}), now)
+	assert.DeepEqual(t, age, map[string]interface{}{
+		"creation":   "216h0m0s",
+		"completion": "1h0m0s",
+	})
+}
@@ -2,6 +2,7 @@ package cleanup
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -10,7 +11,9 @@ import (
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
 	kyvernov2beta1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2beta1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
 	kyvernov2beta1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2beta1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
@@ -31,8 +34,10 @@ import (
 	"go.uber.org/multierr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/sets"
 	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
 )
 
@@ -42,9 +47,11 @@ type controller struct {
 	kyvernoClient versioned.Interface
 
 	// listers
-	cpolLister kyvernov2beta1listers.ClusterCleanupPolicyLister
-	polLister  kyvernov2beta1listers.CleanupPolicyLister
-	nsLister   corev1listers.NamespaceLister
+	cpolLister    kyvernov2beta1listers.ClusterCleanupPolicyLister
+	polLister     kyvernov2beta1listers.CleanupPolicyLister
+	previewLister kyvernov2alpha1listers.CleanupPreviewLister
+	reportLister  kyvernov2alpha1listers.CleanupReportLister
+	nsLister      corev1listers.NamespaceLister
 
 	// queue
 	queue   workqueue.RateLimitingInterface
@@ -55,12 +62,30 @@ type controller struct {
 	cmResolver    engineapi.ConfigmapResolver
 	eventGen      event.Interface
 	jp            jmespath.Interface
+	transport     http.RoundTripper
 	metrics       cleanupMetrics
+
+	// limits
+	limits         DeletionLimits
+	globalLimiter  flowcontrol.RateLimiter
+	policyLimiters policyLimiters
 }
 
 type cleanupMetrics struct {
-	deletedObjectsTotal  metric.Int64Counter
-	cleanupFailuresTotal metric.Int64Counter
+	deletedObjectsTotal     metric.Int64Counter
+	cleanupFailuresTotal    metric.Int64Counter
+	deletionsAttemptedTotal metric.Int64Counter
+	deletionsThrottledTotal metric.Int64Counter
+}
+
+// cleanupResult is the outcome of a cleanup run
+type cleanupResult struct {
+	// selected are the resources matching the policy in dry run mode
+	selected []kyvernov2alpha1.CleanupResource
+	// deletions are the deletions performed
+	deletions []kyvernov2alpha1.CleanupDeletion
+	// pending is set when the batch size was reached before all matching resources were deleted
+	pending bool
 }
 
 const (
@@ -74,11 +99,15 @@ func NewController(
 	kyvernoClient versioned.Interface,
 	cpolInformer kyvernov2beta1informers.ClusterCleanupPolicyInformer,
 	polInformer kyvernov2beta1informers.CleanupPolicyInformer,
+	previewInformer kyvernov2alpha1informers.CleanupPreviewInformer,
+	reportInformer kyvernov2alpha1informers.CleanupReportInformer,
 	nsLister corev1listers.NamespaceLister,
 	configuration config.Configuration,
 	cmResolver engineapi.ConfigmapResolver,
 	jp jmespath.Interface,
 	eventGen event.Interface,
+	transport http.RoundTripper,
+	limits DeletionLimits,
 ) controllers.Controller {
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
 	keyFunc := controllerutils.MetaNamespaceKeyT[kyvernov2alpha1.CleanupPolicyInterface]
@@ -103,6 +132,8 @@ func NewController(
 		kyvernoClient: kyvernoClient,
 		cpolLister:    cpolInformer.Lister(),
 		polLister:     polInformer.Lister(),
+		previewLister: previewInformer.Lister(),
+		reportLister:  reportInformer.Lister(),
 		nsLister:      nsLister,
 		queue:         queue,
 		enqueue:       baseEnqueueFunc,
@@ -111,6 +142,9 @@ func NewController(
 		eventGen:      eventGen,
 		metrics:       newCleanupMetrics(logger),
 		jp:            jp,
+		transport:     transport,
+		limits:        limits,
+		globalLimiter: limits.newGlobalLimiter(),
 	}
 	if _, err := controllerutils.AddEventHandlersT(
 		cpolInformer.Informer(),
@@ -147,9 +181,25 @@ func newCleanupMetrics(logger logr.Logger) cleanupMetrics {
 	if err != nil {
 		logger.Error(err, "Failed to create instrument, cleanup_controller_errors_total")
 	}
+	deletionsAttemptedTotal, err := meter.Int64Counter(
+		"kyverno_cleanup_controller_deletions_attempted",
+		metric.WithDescription("can be used to track number of deletions attempted, including retries after throttling."),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, cleanup_controller_deletions_attempted_total")
+	}
+	deletionsThrottledTotal, err := meter.Int64Counter(
+		"kyverno_cleanup_controller_deletions_throttled",
+		metric.WithDescription("can be used to track number of deletions throttled by the API server."),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, cleanup_controller_deletions_throttled_total")
+	}
 	return cleanupMetrics{
-		deletedObjectsTotal:  deletedObjectsTotal,
-		cleanupFailuresTotal: cleanupFailuresTotal,
+		deletedObjectsTotal:     deletedObjectsTotal,
+		cleanupFailuresTotal:    cleanupFailuresTotal,
+		deletionsAttemptedTotal: deletionsAttemptedTotal,
+		deletionsThrottledTotal: deletionsThrottledTotal,
 	}
 }
 
@@ -173,11 +223,19 @@ func (c *controller) getPolicy(namespace, name string) (kyvernov2alpha1.CleanupP
 	}
 }
 
-func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyvernov2alpha1.CleanupPolicyInterface) error {
+// cleanup deletes the resources matching the policy and returns the deletions performed,
+// in dry run mode nothing is deleted and the matching resources are returned instead.
+// Deletions stop once the batch size is reached, the result is then marked as pending.
+func (c *controller) cleanup(ctx context.Context, logger logr.Logger, key string, policy kyvernov2alpha1.CleanupPolicyInterface) (cleanupResult, error) {
 	spec := policy.GetSpec()
 	kinds := sets.New(spec.MatchResources.GetKinds()...)
 	debug := logger.V(4)
+	var result cleanupResult
 	var errs []error
+	now := time.Now()
+	action := spec.GetAction()
+	batchSize := c.limits.batchSize(spec.RateLimit)
+	policyLimiter := c.policyLimiters.get(key, spec.RateLimit)
 
 	enginectx := enginecontext.NewContext(c.jp)
 	ctxFactory := factories.DefaultContextLoaderFactory(c.cmResolver)
@@ -191,15 +249,19 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 		spec.Context,
 		enginectx,
 	); err != nil {
-		return err
+		return result, err
 	}
 
 	for kind := range kinds {
+		if result.pending {
+			break
+		}
 		commonLabels := []attribute.KeyValue{
 			attribute.String("policy_type", policy.GetKind()),
 			attribute.String("policy_namespace", policy.GetNamespace()),
 			attribute.String("policy_name", policy.GetName()),
 			attribute.String("resource_kind", kind),
+			attribute.String("action", string(action)),
 		}
 		debug := debug.WithValues("kind", kind)
 		debug.Info("processing...")
@@ -212,6 +274,11 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 			}
 		} else {
 			for i := range list.Items {
+				if batchSize > 0 && len(result.deletions) >= batchSize {
+					debug.Info("batch size reached, remaining resources will be processed in the next run", "batchSize", batchSize)
+					result.pending = true
+					break
+				}
 				resource := list.Items[i]
 				namespace := resource.GetNamespace()
 				name := resource.GetName()
@@ -232,6 +299,9 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 					}
 					// match resource with match/exclude clause
 					matched := match.CheckMatchesResources(
+						ctx,
+						// owners are not resolved, only the direct owner references are checked
+						nil,
 						resource,
 						spec.MatchResources,
 						nsLabels,
@@ -240,6 +310,7 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 						kyvernov1beta1.RequestInfo{},
 						resource.GroupVersionKind(),
 						"",
+						"",
 					)
 					if matched != nil {
 						debug.Info("resource/match didn't match", "result", matched)
@@ -247,6 +318,8 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 					}
 					if spec.ExcludeResources != nil {
 						excluded := match.CheckMatchesResources(
+							ctx,
+							nil,
 							resource,
 							*spec.ExcludeResources,
 							nsLabels,
@@ -255,6 +328,7 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 							kyvernov1beta1.RequestInfo{},
 							resource.GroupVersionKind(),
 							"",
+							"",
 						)
 						if excluded == nil {
 							debug.Info("resource/exclude matched")
@@ -263,6 +337,19 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 							debug.Info("resource/exclude didn't match", "result", excluded)
 						}
 					}
+					// check age
+					if spec.OlderThan != nil {
+						older, err := isOlderThan(resource, spec.OlderThan, now)
+						if err != nil {
+							debug.Error(err, "failed to check resource age")
+							errs = append(errs, err)
+							continue
+						}
+						if !older {
+							debug.Info("resource is not old enough")
+							continue
+						}
+					}
 					// check conditions
 					if spec.Conditions != nil {
 						enginectx.Reset()
@@ -276,6 +363,11 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 							errs = append(errs, err)
 							continue
 						}
+						if err := addAge(enginectx, resource, now); err != nil {
+							debug.Error(err, "failed to add age in context")
+							errs = append(errs, err)
+							continue
+						}
 						if err := enginectx.AddImageInfos(&resource, c.configuration); err != nil {
 							debug.Error(err, "failed to add image infos in context")
 							errs = append(errs, err)
@@ -292,11 +384,60 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 							continue
 						}
 					}
+					// compute the patch of actions other than delete
+					var patch []byte
+					if action != kyvernov2beta1.CleanupActionDelete {
+						patch, err = actionPatch(spec, resource)
+						if err != nil {
+							debug.Error(err, "failed to compute action patch")
+							errs = append(errs, err)
+							continue
+						}
+						if patch == nil {
+							debug.Info("resource is up to date")
+							continue
+						}
+					}
+					if spec.DryRun {
+						debug.Info("resource matched, dry run is enabled")
+						result.selected = append(result.selected, cleanupResource(resource))
+						continue
+					}
 					var labels []attribute.KeyValue
 					labels = append(labels, commonLabels...)
 					labels = append(labels, attribute.String("resource_namespace", namespace))
+					if action != kyvernov2beta1.CleanupActionDelete {
+						logger.WithValues("name", name, "namespace", namespace, "action", action).Info("resource matched, action will be performed...")
+						if err := c.patch(ctx, policyLimiter, resource, patch, labels); err != nil {
+							if c.metrics.cleanupFailuresTotal != nil {
+								c.metrics.cleanupFailuresTotal.Add(ctx, 1, metric.WithAttributes(labels...))
+							}
+							debug.Error(err, "failed to perform action")
+							errs = append(errs, err)
+							c.eventGen.Add(event.NewCleanupPolicyActionEvent(policy, resource, string(action), err))
+						} else {
+							debug.Info("action performed")
+							result.deletions = append(result.deletions, kyvernov2alpha1.CleanupDeletion{
+								CleanupResource: cleanupResource(resource),
+								Action:          string(action),
+								Timestamp:       metav1.Now(),
+							})
+							c.eventGen.Add(event.NewCleanupPolicyActionEvent(policy, resource, string(action), nil))
+						}
+						continue
+					}
 					logger.WithValues("name", name, "namespace", namespace).Info("resource matched, it will be deleted...")
-					if err := c.client.DeleteResource(ctx, resource.GetAPIVersion(), resource.GetKind(), namespace, name, false); err != nil {
+					if err := c.preDelete(ctx, policy, resource); err != nil {
+						if c.metrics.cleanupFailuresTotal != nil {
+							c.metrics.cleanupFailuresTotal.Add(ctx, 1, metric.WithAttributes(labels...))
+						}
+						debug.Error(err, "pre-delete hooks failed, resource will not be deleted")
+						errs = append(errs, err)
+						e := event.NewCleanupPolicyEvent(policy, resource, err)
+						c.eventGen.Add(e)
+						continue
+					}
+					if err := c.delete(ctx, policyLimiter, resource, labels); err != nil {
 						if c.metrics.cleanupFailuresTotal != nil {
 							c.metrics.cleanupFailuresTotal.Add(ctx, 1, metric.WithAttributes(labels...))
 						}
@@ -309,6 +450,10 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 							c.metrics.deletedObjectsTotal.Add(ctx, 1, metric.WithAttributes(labels...))
 						}
 						debug.Info("deleted")
+						result.deletions = append(result.deletions, kyvernov2alpha1.CleanupDeletion{
+							CleanupResource: cleanupResource(resource),
+							Timestamp:       metav1.Now(),
+						})
 						e := event.NewCleanupPolicyEvent(policy, resource, nil)
 						c.eventGen.Add(e)
 					}
@@ -316,13 +461,47 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 			}
 		}
 	}
-	return multierr.Combine(errs...)
+	return result, multierr.Combine(errs...)
+}
+
+// delete deletes the resource once the rate limiters allow it
+func (c *controller) delete(ctx context.Context, policyLimiter flowcontrol.RateLimiter, resource unstructured.Unstructured, labels []attribute.KeyValue) error {
+	return c.perform(ctx, policyLimiter, labels, func() error {
+		return c.client.DeleteResource(ctx, resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName(), false)
+	})
+}
+
+// patch applies the action patch to the resource once the rate limiters allow it
+func (c *controller) patch(ctx context.Context, policyLimiter flowcontrol.RateLimiter, resource unstructured.Unstructured, patch []byte, labels []attribute.KeyValue) error {
+	return c.perform(ctx, policyLimiter, labels, func() error {
+		_, err := c.client.PatchResource(ctx, resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName(), patch)
+		return err
+	})
+}
+
+// perform calls the action once the rate limiters allow it, calls throttled by the API server are retried with a backoff
+func (c *controller) perform(ctx context.Context, policyLimiter flowcontrol.RateLimiter, labels []attribute.KeyValue, action func() error) error {
+	if err := waitLimiters(ctx, c.globalLimiter, policyLimiter); err != nil {
+		return err
+	}
+	throttled := func() {
+		if c.metrics.deletionsThrottledTotal != nil {
+			c.metrics.deletionsThrottledTotal.Add(ctx, 1, metric.WithAttributes(labels...))
+		}
+	}
+	return retryThrottled(ctx, throttled, func() error {
+		if c.metrics.deletionsAttemptedTotal != nil {
+			c.metrics.deletionsAttemptedTotal.Add(ctx, 1, metric.WithAttributes(labels...))
+		}
+		return action()
+	})
 }
 
 func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
 	policy, err := c.getPolicy(namespace, name)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
+			c.policyLimiters.forget(key)
 			return nil
 		}
 		logger.Error(err, "unable to get the policy from policy informer")
@@ -335,23 +514,52 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 		logger.Error(err, "failed to get the policy execution time")
 		return err
 	}
+	dryRun := policy.GetSpec().DryRun
 	// In case it is the time to do the cleanup process
-	if time.Now().After(*executionTime) {
-		err := c.cleanup(ctx, logger, policy)
+	due := time.Now().After(*executionTime)
+	if due {
+		nextExecutionTime, err = policy.GetNextExecutionTime(*executionTime)
+		if err != nil {
+			logger.Error(err, "failed to get the policy next execution time")
+			return err
+		}
+	} else {
+		nextExecutionTime = executionTime
+	}
+	// in dry run mode the preview is refreshed every time the policy is reconciled
+	var pending bool
+	if due || dryRun {
+		result, err := c.cleanup(ctx, logger, key, policy)
+		if err := c.recordDeletions(ctx, policy, result.deletions); err != nil {
+			logger.Error(err, "failed to record deletions in the cleanup report")
+		}
 		if err != nil {
 			return err
 		}
+		if dryRun {
+			if err := c.updatePreview(ctx, policy, result.selected, *nextExecutionTime); err != nil {
+				logger.Error(err, "failed to update the cleanup preview")
+				return err
+			}
+		}
+		pending = result.pending
+	}
+	// the execution is not complete until the remaining batches are processed
+	if pending {
+		c.queue.AddAfter(key, batchInterval)
+		return nil
+	}
+	if due {
 		if err := c.updateCleanupPolicyStatus(ctx, policy, namespace, *executionTime); err != nil {
 			logger.Error(err, "failed to update the cleanup policy status")
 			return err
 		}
-		nextExecutionTime, err = policy.GetNextExecutionTime(*executionTime)
-		if err != nil {
-			logger.Error(err, "failed to get the policy next execution time")
+	}
+	if !dryRun {
+		if err := c.deletePreview(ctx, policy); err != nil {
+			logger.Error(err, "failed to delete the cleanup preview")
 			return err
 		}
-	} else {
-		nextExecutionTime = executionTime
 	}
 
 	// calculate the remaining time until deletion.
@@ -0,0 +1,97 @@
+package cleanup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"github.com/kyverno/kyverno/pkg/event"
+	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// webhookTimeout bounds pre-delete webhook calls, the outbound transport settings can configure a shorter timeout
+	webhookTimeout = 10 * time.Second
+	// maxWebhookResponseLength is the number of bytes of the webhook response kept in error messages
+	maxWebhookResponseLength = 1024
+)
+
+// preDeleteRequest is the payload sent to pre-delete webhooks
+type preDeleteRequest struct {
+	Policy   kyvernov2alpha1.CleanupPolicyReference `json:"policy"`
+	Resource kyvernov2alpha1.CleanupResource        `json:"resource"`
+	Object   map[string]interface{}                 `json:"object"`
+}
+
+// preDelete runs the pre-delete hooks of the policy, the resource must not be deleted if an error is returned.
+// The webhook runs first so that it can veto the deletion before the resource is annotated or an event is emitted.
+func (c *controller) preDelete(ctx context.Context, policy kyvernov2alpha1.CleanupPolicyInterface, resource unstructured.Unstructured) error {
+	hooks := policy.GetSpec().PreDelete
+	if hooks == nil {
+		return nil
+	}
+	if hooks.Webhook != nil {
+		if err := c.callWebhook(ctx, hooks.Webhook, policy, resource); err != nil {
+			return fmt.Errorf("pre-delete webhook failed: %w", err)
+		}
+	}
+	if len(hooks.Annotations) != 0 {
+		if err := c.annotate(ctx, resource, hooks.Annotations); err != nil {
+			return fmt.Errorf("failed to add pre-delete annotations: %w", err)
+		}
+	}
+	if hooks.Event {
+		c.eventGen.Add(event.NewCleanupPolicyPreDeleteEvent(policy, resource))
+	}
+	return nil
+}
+
+func (c *controller) callWebhook(ctx context.Context, webhook *kyvernov2beta1.CleanupWebhook, policy kyvernov2alpha1.CleanupPolicyInterface, resource unstructured.Unstructured) error {
+	data, err := json.Marshal(preDeleteRequest{
+		Policy:   policyRef(policy),
+		Resource: cleanupResource(resource),
+		Object:   resource.Object,
+	})
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := http.Client{Transport: c.transport}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponseLength))
+		if err != nil || len(body) == 0 {
+			return fmt.Errorf("HTTP %s", resp.Status)
+		}
+		return fmt.Errorf("HTTP %s: %s", resp.Status, string(body))
+	}
+	return nil
+}
+
+func (c *controller) annotate(ctx context.Context, resource unstructured.Unstructured, annotations map[string]string) error {
+	patch := jsonutils.NewPatchBuilder()
+	addAnnotations(patch, resource, annotations)
+	data, err := patch.Bytes()
+	if err != nil {
+		return err
+	}
+	_, err = c.client.PatchResource(ctx, resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName(), data)
+	return err
+}
@@ -0,0 +1,51 @@
+package cleanup
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_preDelete_webhook(t *testing.T) {
+	var received preDeleteRequest
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Method, http.MethodPost)
+		assert.NilError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("denied"))
+	}))
+	defer server.Close()
+	c := &controller{transport: http.DefaultTransport}
+	policy := &kyvernov2beta1.CleanupPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "CleanupPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "policy",
+			Namespace: "default",
+		},
+		Spec: kyvernov2beta1.CleanupPolicySpec{
+			PreDelete: &kyvernov2beta1.CleanupPreDelete{
+				Webhook: &kyvernov2beta1.CleanupWebhook{
+					URL: server.URL,
+				},
+			},
+		},
+	}
+	job := newJob(nil)
+	assert.NilError(t, c.preDelete(context.TODO(), policy, job))
+	assert.Equal(t, received.Policy.Name, "policy")
+	assert.Equal(t, received.Resource.Kind, "Job")
+	assert.Equal(t, received.Resource.Name, "job")
+	assert.Equal(t, received.Object["kind"], "Job")
+	status = http.StatusForbidden
+	err := c.preDelete(context.TODO(), policy, job)
+	assert.ErrorContains(t, err, "HTTP 403 Forbidden: denied")
+}
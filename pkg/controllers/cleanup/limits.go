@@ -0,0 +1,124 @@
+package cleanup
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/retry"
+)
+
+// batchInterval is the delay before a policy that reached its batch size is processed again
+const batchInterval = time.Second
+
+// throttlingBackoff is used to retry deletions rejected by the API server with 429 responses
+var throttlingBackoff = wait.Backoff{
+	Duration: 500 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    5,
+}
+
+// DeletionLimits are the global limits applied to the deletions performed by the controller,
+// zero values mean unlimited.
+type DeletionLimits struct {
+	// QPS is the maximum sustained number of deletions per second across all policies
+	QPS float32
+	// Burst is the maximum number of deletions performed at once across all policies
+	Burst int
+	// BatchSize is the maximum number of resources deleted by a policy in a single run
+	BatchSize int
+}
+
+// newGlobalLimiter returns the rate limiter shared by all policies, nil when unlimited
+func (l DeletionLimits) newGlobalLimiter() flowcontrol.RateLimiter {
+	if l.QPS <= 0 {
+		return nil
+	}
+	burst := l.Burst
+	if burst <= 0 {
+		burst = int(l.QPS)
+	}
+	return flowcontrol.NewTokenBucketRateLimiter(l.QPS, max(burst, 1))
+}
+
+// batchSize returns the maximum number of resources the policy can delete in a single run, 0 when unlimited
+func (l DeletionLimits) batchSize(rateLimit *kyvernov2beta1.CleanupRateLimit) int {
+	size := l.BatchSize
+	if rateLimit != nil && rateLimit.BatchSize > 0 && (size <= 0 || rateLimit.BatchSize < size) {
+		size = rateLimit.BatchSize
+	}
+	return max(size, 0)
+}
+
+type policyLimiter struct {
+	limit   kyvernov2beta1.CleanupRateLimit
+	limiter flowcontrol.RateLimiter
+}
+
+// policyLimiters keeps a rate limiter per policy so that tokens are preserved across runs
+type policyLimiters struct {
+	lock     sync.Mutex
+	limiters map[string]policyLimiter
+}
+
+// get returns the rate limiter of the policy, nil when the policy has no rate limit.
+// The limiter is rebuilt when the rate limit of the policy changes.
+func (p *policyLimiters) get(key string, rateLimit *kyvernov2beta1.CleanupRateLimit) flowcontrol.RateLimiter {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if rateLimit == nil || rateLimit.DeletionsPerSecond <= 0 {
+		delete(p.limiters, key)
+		return nil
+	}
+	if existing, ok := p.limiters[key]; ok && existing.limit == *rateLimit {
+		return existing.limiter
+	}
+	if p.limiters == nil {
+		p.limiters = map[string]policyLimiter{}
+	}
+	limiter := flowcontrol.NewTokenBucketRateLimiter(float32(rateLimit.DeletionsPerSecond), rateLimit.GetBurst())
+	p.limiters[key] = policyLimiter{limit: *rateLimit, limiter: limiter}
+	return limiter
+}
+
+func (p *policyLimiters) forget(key string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.limiters, key)
+}
+
+// waitLimiters blocks until all the given limiters allow a deletion
+func waitLimiters(ctx context.Context, limiters ...flowcontrol.RateLimiter) error {
+	for _, limiter := range limiters {
+		if limiter != nil {
+			if err := limiter.Wait(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// retryThrottled calls delete until it succeeds, fails with an error other than 429 or the backoff is exhausted.
+// The delay suggested by the API server is honoured before the backoff delay.
+func retryThrottled(ctx context.Context, throttled func(), delete func() error) error {
+	return retry.OnError(throttlingBackoff, apierrors.IsTooManyRequests, func() error {
+		err := delete()
+		if apierrors.IsTooManyRequests(err) {
+			throttled()
+			if seconds, ok := apierrors.SuggestsClientDelay(err); ok {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(time.Duration(seconds) * time.Second):
+				}
+			}
+		}
+		return err
+	})
+}
@@ -0,0 +1,78 @@
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"gotest.tools/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func Test_DeletionLimits_batchSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		limits    DeletionLimits
+		rateLimit *kyvernov2beta1.CleanupRateLimit
+		want      int
+	}{{
+		name: "unlimited",
+		want: 0,
+	}, {
+		name:   "global only",
+		limits: DeletionLimits{BatchSize: 100},
+		want:   100,
+	}, {
+		name:      "policy only",
+		rateLimit: &kyvernov2beta1.CleanupRateLimit{BatchSize: 10},
+		want:      10,
+	}, {
+		name:      "smallest applies",
+		limits:    DeletionLimits{BatchSize: 5},
+		rateLimit: &kyvernov2beta1.CleanupRateLimit{BatchSize: 10},
+		want:      5,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.limits.batchSize(tt.rateLimit), tt.want)
+		})
+	}
+}
+
+func Test_policyLimiters(t *testing.T) {
+	var limiters policyLimiters
+	assert.Assert(t, limiters.get("default/policy", nil) == nil)
+	limit := &kyvernov2beta1.CleanupRateLimit{DeletionsPerSecond: 5}
+	limiter := limiters.get("default/policy", limit)
+	assert.Assert(t, limiter != nil)
+	assert.Equal(t, limiters.get("default/policy", limit), limiter)
+	assert.Equal(t, limiter.QPS(), float32(5))
+	changed := limiters.get("default/policy", &kyvernov2beta1.CleanupRateLimit{DeletionsPerSecond: 10})
+	assert.Equal(t, changed.QPS(), float32(10))
+	limiters.forget("default/policy")
+	assert.Equal(t, len(limiters.limiters), 0)
+}
+
+func Test_retryThrottled(t *testing.T) {
+	throttledErr := apierrors.NewTooManyRequests("slow down", 0)
+	var attempts, throttled int
+	err := retryThrottled(context.TODO(), func() { throttled++ }, func() error {
+		attempts++
+		if attempts < 3 {
+			return throttledErr
+		}
+		return nil
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, attempts, 3)
+	assert.Equal(t, throttled, 2)
+	attempts, throttled = 0, 0
+	err = retryThrottled(context.TODO(), func() { throttled++ }, func() error {
+		attempts++
+		return errors.New("forbidden")
+	})
+	assert.Error(t, err, "forbidden")
+	assert.Equal(t, attempts, 1)
+	assert.Equal(t, throttled, 0)
+}
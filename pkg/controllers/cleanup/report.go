@@ -0,0 +1,110 @@
+package cleanup
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"github.com/kyverno/kyverno/pkg/config"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// maxReportDeletions is the number of deletions kept in a cleanup report
+const maxReportDeletions = 1000
+
+// resultName returns the name of the preview and report of a policy
+func resultName(policy kyvernov2alpha1.CleanupPolicyInterface) string {
+	return strings.ToLower(policy.GetKind()) + "-" + policy.GetName()
+}
+
+// resultNamespace returns the namespace of the preview and report of a policy,
+// cluster policies have their results stored in the kyverno namespace
+func resultNamespace(policy kyvernov2alpha1.CleanupPolicyInterface) string {
+	if policy.IsNamespaced() {
+		return policy.GetNamespace()
+	}
+	return config.KyvernoNamespace()
+}
+
+func policyRef(policy kyvernov2alpha1.CleanupPolicyInterface) kyvernov2alpha1.CleanupPolicyReference {
+	return kyvernov2alpha1.CleanupPolicyReference{
+		Kind:      policy.GetKind(),
+		Namespace: policy.GetNamespace(),
+		Name:      policy.GetName(),
+	}
+}
+
+func cleanupResource(resource unstructured.Unstructured) kyvernov2alpha1.CleanupResource {
+	return kyvernov2alpha1.CleanupResource{
+		APIVersion: resource.GetAPIVersion(),
+		Kind:       resource.GetKind(),
+		Namespace:  resource.GetNamespace(),
+		Name:       resource.GetName(),
+		UID:        resource.GetUID(),
+	}
+}
+
+func (c *controller) updatePreview(ctx context.Context, policy kyvernov2alpha1.CleanupPolicyInterface, resources []kyvernov2alpha1.CleanupResource, nextExecutionTime time.Time) error {
+	namespace := resultNamespace(policy)
+	_, err := controllerutils.CreateOrUpdate[kyvernov2alpha1.CleanupPreview](
+		ctx,
+		resultName(policy),
+		c.previewLister.CleanupPreviews(namespace),
+		c.kyvernoClient.KyvernoV2alpha1().CleanupPreviews(namespace),
+		func(preview *kyvernov2alpha1.CleanupPreview) error {
+			preview.SetNamespace(namespace)
+			controllerutils.SetOwner(preview, kyvernov2beta1.SchemeGroupVersion.String(), policy.GetKind(), policy.GetName(), policy.GetUID())
+			controllerutils.SetManagedByKyvernoLabel(preview)
+			preview.Spec = kyvernov2alpha1.CleanupPreviewSpec{
+				PolicyRef:         policyRef(policy),
+				EvaluationTime:    metav1.Now(),
+				NextExecutionTime: &metav1.Time{Time: nextExecutionTime},
+				Resources:         resources,
+			}
+			return nil
+		},
+	)
+	return err
+}
+
+func (c *controller) deletePreview(ctx context.Context, policy kyvernov2alpha1.CleanupPolicyInterface) error {
+	namespace := resultNamespace(policy)
+	name := resultName(policy)
+	if _, err := c.previewLister.CleanupPreviews(namespace).Get(name); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if err := c.kyvernoClient.KyvernoV2alpha1().CleanupPreviews(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *controller) recordDeletions(ctx context.Context, policy kyvernov2alpha1.CleanupPolicyInterface, deletions []kyvernov2alpha1.CleanupDeletion) error {
+	if len(deletions) == 0 {
+		return nil
+	}
+	namespace := resultNamespace(policy)
+	_, err := controllerutils.CreateOrUpdate[kyvernov2alpha1.CleanupReport](
+		ctx,
+		resultName(policy),
+		c.reportLister.CleanupReports(namespace),
+		c.kyvernoClient.KyvernoV2alpha1().CleanupReports(namespace),
+		func(report *kyvernov2alpha1.CleanupReport) error {
+			report.SetNamespace(namespace)
+			controllerutils.SetOwner(report, kyvernov2beta1.SchemeGroupVersion.String(), policy.GetKind(), policy.GetName(), policy.GetUID())
+			controllerutils.SetManagedByKyvernoLabel(report)
+			report.Spec.PolicyRef = policyRef(policy)
+			report.Spec.AddDeletions(maxReportDeletions, deletions...)
+			return nil
+		},
+	)
+	return err
+}
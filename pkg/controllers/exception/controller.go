@@ -0,0 +1,260 @@
+package exception
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2"
+	kyvernov2listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 2
+	ControllerName = "exception-controller"
+	maxRetries     = 10
+)
+
+type controller struct {
+	// clients
+	kyvernoClient versioned.Interface
+
+	// listers
+	polexLister kyvernov2listers.PolicyExceptionLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	eventGen      event.Interface
+	configuration config.Configuration
+	metrics       exceptionMetrics
+
+	// warning is how long before the expiration an event is emitted
+	warning time.Duration
+	// warned stores the expiration time of the exceptions an expiring event was emitted for
+	warned sync.Map
+	// now returns the current time, it is replaced in tests
+	now func() time.Time
+}
+
+type exceptionMetrics struct {
+	expiredTotal    metric.Int64Counter
+	expirySeconds   metric.Float64ObservableGauge
+	lastUsedSeconds metric.Float64ObservableGauge
+}
+
+// NewController creates a controller maintaining the status of policy exceptions.
+// Expired exceptions are marked inactive, and deleted when deleteOnExpiry is set.
+// An event is emitted when an exception is about to expire and when it expired.
+// When the approval workflow is enabled, exceptions without an approval condition for their
+// current generation are marked pending.
+func NewController(
+	kyvernoClient versioned.Interface,
+	polexInformer kyvernov2informers.PolicyExceptionInformer,
+	eventGen event.Interface,
+	configuration config.Configuration,
+	warning time.Duration,
+) controllers.Controller {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+	c := &controller{
+		kyvernoClient: kyvernoClient,
+		polexLister:   polexInformer.Lister(),
+		queue:         queue,
+		eventGen:      eventGen,
+		configuration: configuration,
+		warning:       warning,
+		now:           time.Now,
+	}
+	c.metrics = c.newMetrics()
+	if _, _, err := controllerutils.AddDefaultEventHandlers(logger, polexInformer.Informer(), queue); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	configuration.OnChanged(c.enqueueAll)
+	return c
+}
+
+func (c *controller) enqueueAll() {
+	exceptions, err := c.polexLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list policy exceptions")
+		return
+	}
+	for _, polex := range exceptions {
+		key, err := cache.MetaNamespaceKeyFunc(polex)
+		if err != nil {
+			logger.Error(err, "failed to compute policy exception key")
+			continue
+		}
+		c.queue.Add(key)
+	}
+}
+
+func (c *controller) newMetrics() exceptionMetrics {
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	expiredTotal, err := meter.Int64Counter(
+		"kyverno_policy_exceptions_expired",
+		metric.WithDescription("can be used to track number of policy exceptions that expired."),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_policy_exceptions_expired_total")
+	}
+	expirySeconds, err := meter.Float64ObservableGauge(
+		"kyverno_policy_exception_expiry_seconds",
+		metric.WithDescription("can be used to track the number of seconds before policy exceptions expire, negative values mean the exception already expired."),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_policy_exception_expiry_seconds")
+	}
+	lastUsedSeconds, err := meter.Float64ObservableGauge(
+		"kyverno_policy_exception_last_used_seconds",
+		metric.WithDescription("can be used to track the number of seconds since policy exceptions last skipped a rule, or since they were created when they were never used."),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_policy_exception_last_used_seconds")
+	}
+	var observables []metric.Observable
+	for _, gauge := range []metric.Float64ObservableGauge{expirySeconds, lastUsedSeconds} {
+		if gauge != nil {
+			observables = append(observables, gauge)
+		}
+	}
+	if len(observables) != 0 {
+		if _, err := meter.RegisterCallback(c.report, observables...); err != nil {
+			logger.Error(err, "Failed to register callback")
+		}
+	}
+	return exceptionMetrics{
+		expiredTotal:    expiredTotal,
+		expirySeconds:   expirySeconds,
+		lastUsedSeconds: lastUsedSeconds,
+	}
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger.V(3), ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) report(ctx context.Context, observer metric.Observer) error {
+	exceptions, err := c.polexLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list policy exceptions")
+		return err
+	}
+	now := c.now()
+	for _, polex := range exceptions {
+		attributes := metric.WithAttributes(
+			attribute.String("exception_namespace", polex.GetNamespace()),
+			attribute.String("exception_name", polex.GetName()),
+		)
+		if c.metrics.expirySeconds != nil {
+			if expiration, err := polex.GetExpirationTime(); err == nil && expiration != nil {
+				observer.ObserveFloat64(c.metrics.expirySeconds, expiration.Sub(now).Seconds(), attributes)
+			}
+		}
+		if c.metrics.lastUsedSeconds != nil {
+			lastUsed := polex.GetCreationTimestamp()
+			if used := polex.Status.GetLastUsed(); used != nil {
+				lastUsed = *used
+			}
+			observer.ObserveFloat64(c.metrics.lastUsedSeconds, now.Sub(lastUsed.Time).Seconds(), attributes)
+		}
+	}
+	return nil
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	polex, err := c.polexLister.PolicyExceptions(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			c.warned.Delete(key)
+			return nil
+		}
+		return err
+	}
+	expiration, err := polex.GetExpirationTime()
+	if err != nil {
+		// invalid exceptions are rejected at admission, nothing can be done until the exception is fixed
+		logger.Error(err, "failed to compute the expiration time")
+		return nil
+	}
+	if expiration == nil {
+		c.warned.Delete(key)
+		return c.updateStatus(ctx, polex, true, "exception doesn't expire")
+	}
+	now := c.now()
+	if polex.IsExpired(now) {
+		return c.expire(ctx, logger, key, polex, *expiration)
+	}
+	if err := c.updateStatus(ctx, polex, true, fmt.Sprintf("exception expires at %s", expiration.UTC().Format(time.RFC3339))); err != nil {
+		return err
+	}
+	remaining := expiration.Sub(now)
+	if remaining <= c.warning {
+		if warned, ok := c.warned.Load(key); !ok || !warned.(time.Time).Equal(*expiration) {
+			logger.V(2).Info("policy exception is about to expire", "expiration", expiration)
+			c.eventGen.Add(event.NewPolicyExceptionExpiringEvent(polex, *expiration))
+			c.warned.Store(key, *expiration)
+		}
+	} else {
+		c.queue.AddAfter(key, remaining-c.warning)
+	}
+	c.queue.AddAfter(key, remaining)
+	return nil
+}
+
+// expire marks the exception inactive and deletes it when requested, the expired event is emitted once
+func (c *controller) expire(ctx context.Context, logger logr.Logger, key string, polex *kyvernov2.PolicyException, expiration time.Time) error {
+	if !polex.Status.IsExpired() {
+		if err := c.updateStatus(ctx, polex, false, fmt.Sprintf("exception expired at %s", expiration.UTC().Format(time.RFC3339))); err != nil {
+			return err
+		}
+		logger.Info("policy exception expired", "expiration", expiration)
+		c.eventGen.Add(event.NewPolicyExceptionExpiredEvent(polex, expiration))
+		if c.metrics.expiredTotal != nil {
+			c.metrics.expiredTotal.Add(ctx, 1, metric.WithAttributes(
+				attribute.String("exception_namespace", polex.GetNamespace()),
+				attribute.Bool("deleted", polex.Spec.DeleteOnExpiry),
+			))
+		}
+	}
+	c.warned.Delete(key)
+	if polex.Spec.DeleteOnExpiry {
+		logger.V(2).Info("deleting expired policy exception")
+		err := c.kyvernoClient.KyvernoV2().PolicyExceptions(polex.GetNamespace()).Delete(ctx, polex.GetName(), metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *controller) updateStatus(ctx context.Context, polex *kyvernov2.PolicyException, active bool, message string) error {
+	approval := c.configuration.GetExceptionApproval().Enabled
+	_, err := controllerutils.UpdateStatus(ctx, polex, c.kyvernoClient.KyvernoV2().PolicyExceptions(polex.GetNamespace()), func(polex *kyvernov2.PolicyException) error {
+		polex.Status.SetActive(active, message)
+		// exceptions start pending, and changing the spec of an approved exception requires a new approval
+		if approval && !polex.HasApprovalCondition() {
+			polex.Status.SetPending(polex.GetGeneration())
+		}
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,118 @@
+package exception
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernov2listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/event"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+type recorder struct {
+	events []event.Info
+}
+
+func (r *recorder) Add(infos ...event.Info) {
+	r.events = append(r.events, infos...)
+}
+
+func newTestController(t *testing.T, now time.Time, polex *kyvernov2.PolicyException, data map[string]string) (*controller, *recorder) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	assert.NilError(t, indexer.Add(polex))
+	events := &recorder{}
+	configuration := config.NewDefaultConfiguration(false)
+	configuration.Load(&corev1.ConfigMap{Data: data})
+	c := &controller{
+		kyvernoClient: fake.NewSimpleClientset(polex),
+		polexLister:   kyvernov2listers.NewPolicyExceptionLister(indexer),
+		queue:         workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		eventGen:      events,
+		configuration: configuration,
+		warning:       24 * time.Hour,
+		now:           func() time.Time { return now },
+	}
+	t.Cleanup(c.queue.ShutDown)
+	return c, events
+}
+
+func newPolicyException(expiresAt time.Time, deleteOnExpiry bool) *kyvernov2.PolicyException {
+	return &kyvernov2.PolicyException{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "kyverno",
+			Name:      "temporary",
+		},
+		Spec: kyvernov2.PolicyExceptionSpec{
+			ExpiresAt:      &metav1.Time{Time: expiresAt},
+			DeleteOnExpiry: deleteOnExpiry,
+		},
+	}
+}
+
+func Test_reconcile_expiring(t *testing.T) {
+	now := time.Now()
+	polex := newPolicyException(now.Add(time.Hour), false)
+	c, events := newTestController(t, now, polex, nil)
+	for i := 0; i < 2; i++ {
+		assert.NilError(t, c.reconcile(context.TODO(), logger, "kyverno/temporary", "kyverno", "temporary"))
+	}
+	assert.Equal(t, len(events.events), 1)
+	assert.Equal(t, events.events[0].Reason, event.ExceptionExpiring)
+	updated, err := c.kyvernoClient.KyvernoV2().PolicyExceptions("kyverno").Get(context.TODO(), "temporary", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, !updated.Status.IsExpired())
+	assert.Equal(t, len(updated.Status.Conditions), 1)
+}
+
+func Test_reconcile_expired(t *testing.T) {
+	now := time.Now()
+	polex := newPolicyException(now.Add(-time.Hour), false)
+	c, events := newTestController(t, now, polex, nil)
+	assert.NilError(t, c.reconcile(context.TODO(), logger, "kyverno/temporary", "kyverno", "temporary"))
+	assert.Equal(t, len(events.events), 1)
+	assert.Equal(t, events.events[0].Reason, event.ExceptionExpired)
+	updated, err := c.kyvernoClient.KyvernoV2().PolicyExceptions("kyverno").Get(context.TODO(), "temporary", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, updated.Status.IsExpired())
+}
+
+func Test_reconcile_deleteOnExpiry(t *testing.T) {
+	now := time.Now()
+	polex := newPolicyException(now.Add(-time.Hour), true)
+	c, events := newTestController(t, now, polex, nil)
+	assert.NilError(t, c.reconcile(context.TODO(), logger, "kyverno/temporary", "kyverno", "temporary"))
+	assert.Equal(t, len(events.events), 1)
+	_, err := c.kyvernoClient.KyvernoV2().PolicyExceptions("kyverno").Get(context.TODO(), "temporary", metav1.GetOptions{})
+	assert.Assert(t, apierrors.IsNotFound(err))
+}
+
+func Test_reconcile_pending(t *testing.T) {
+	now := time.Now()
+	polex := newPolicyException(now.Add(time.Hour), false)
+	polex.Generation = 2
+	polex.Status.Conditions = []metav1.Condition{{
+		Type:               kyvernov2.PolicyExceptionConditionApproved,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Approved",
+		ObservedGeneration: 1,
+	}}
+	c, _ := newTestController(t, now, polex, map[string]string{"exceptionApproval": `{"enabled": true}`})
+	assert.NilError(t, c.reconcile(context.TODO(), logger, "kyverno/temporary", "kyverno", "temporary"))
+	updated, err := c.kyvernoClient.KyvernoV2().PolicyExceptions("kyverno").Get(context.TODO(), "temporary", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, !updated.IsApproved())
+	assert.Assert(t, updated.HasApprovalCondition())
+	condition := meta.FindStatusCondition(updated.Status.Conditions, kyvernov2.PolicyExceptionConditionApproved)
+	assert.Equal(t, condition.Reason, kyvernov2.PolicyExceptionReasonPending)
+	assert.Equal(t, condition.ObservedGeneration, int64(2))
+}
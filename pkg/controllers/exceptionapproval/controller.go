@@ -0,0 +1,61 @@
+package exceptionapproval
+
+import (
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/controllers/generic/clusterpolicy"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "exception-approval-controller"
+)
+
+// NewController creates a controller maintaining the policy that restricts the approval of policy exceptions
+// according to the exceptionApproval setting of the Kyverno configuration, serviceAccounts are the usernames
+// of the Kyverno controllers updating the status of policy exceptions.
+func NewController(
+	kyvernoClient versioned.Interface,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	configuration config.Configuration,
+	serviceAccounts []string,
+	exceptionNamespace string,
+) controllers.Controller {
+	return clusterpolicy.NewController(
+		ControllerName,
+		PolicyName,
+		kyvernoClient,
+		cpolInformer,
+		configuration,
+		func() *kyvernov1.ClusterPolicy {
+			approval := configuration.GetExceptionApproval()
+			if !approval.Enabled {
+				return nil
+			}
+			if err := ValidateConfiguration(configuration, exceptionNamespace); err != nil {
+				logger.Error(err, "exception approval can be bypassed")
+			}
+			return buildPolicy(serviceAccounts, approval)
+		},
+	)
+}
+
+// ValidateConfiguration returns an error when policy exceptions are restricted to a namespace where the webhooks
+// don't enforce their approval, no exception could ever apply. Requests filtered by the configuration never reach the
+// approval policy, this is the case of every request in the Kyverno namespace when excludeKyvernoNamespace is set.
+// When exceptions can be created in any namespace, the exceptions in such namespaces are ignored.
+func ValidateConfiguration(configuration config.Configuration, exceptionNamespace string) error {
+	if !configuration.GetExceptionApproval().Enabled || exceptionNamespace == "" {
+		return nil
+	}
+	if !config.IsExceptionApprovalEnforced(configuration, exceptionNamespace) {
+		return fmt.Errorf("policy exceptions in the %s namespace are excluded from the webhooks, they can't be protected by the exception approval", exceptionNamespace)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package exceptionapproval
+
+import (
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestValidateConfiguration(t *testing.T) {
+	tests := []struct {
+		name               string
+		data               map[string]string
+		exceptionNamespace string
+		wantErr            bool
+	}{{
+		name: "approval disabled",
+		data: map[string]string{
+			"excludeKyvernoNamespace": "true",
+		},
+		exceptionNamespace: "kyverno",
+	}, {
+		name: "kyverno namespace not excluded",
+		data: map[string]string{
+			"excludeKyvernoNamespace": "false",
+			"exceptionApproval":       `{"enabled": true}`,
+		},
+		exceptionNamespace: "kyverno",
+	}, {
+		name: "exceptions in the excluded kyverno namespace",
+		data: map[string]string{
+			"excludeKyvernoNamespace": "true",
+			"exceptionApproval":       `{"enabled": true}`,
+		},
+		exceptionNamespace: "kyverno",
+		wantErr:            true,
+	}, {
+		name: "exceptions in any namespace",
+		data: map[string]string{
+			"excludeKyvernoNamespace": "true",
+			"exceptionApproval":       `{"enabled": true}`,
+		},
+	}, {
+		name: "exceptions in another namespace",
+		data: map[string]string{
+			"excludeKyvernoNamespace": "true",
+			"exceptionApproval":       `{"enabled": true}`,
+		},
+		exceptionNamespace: "exceptions",
+	}, {
+		name: "exception namespace filtered",
+		data: map[string]string{
+			"resourceFilters":   "[*/*,exceptions,*]",
+			"exceptionApproval": `{"enabled": true}`,
+		},
+		exceptionNamespace: "exceptions",
+		wantErr:            true,
+	}, {
+		name: "exception namespace excluded by the webhooks",
+		data: map[string]string{
+			"webhooks":          `[{"namespaceSelector":{"matchExpressions":[{"key":"kubernetes.io/metadata.name","operator":"NotIn","values":["exceptions"]}]}}]`,
+			"exceptionApproval": `{"enabled": true}`,
+		},
+		exceptionNamespace: "exceptions",
+		wantErr:            true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configuration := config.NewDefaultConfiguration(false)
+			configuration.Load(&corev1.ConfigMap{Data: tt.data})
+			err := ValidateConfiguration(configuration, tt.exceptionNamespace)
+			assert.Equal(t, err != nil, tt.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package exceptionapproval
+
+import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/config"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+)
+
+// PolicyName is the name of the generated policy restricting the approval of policy exceptions
+const PolicyName = "kyverno-exception-approval"
+
+// approvalCondition is the JMESPath expression extracting the fields of the approval condition from an exception
+const approvalCondition = "status.conditions[?type=='" + kyvernov2.PolicyExceptionConditionApproved + "'].[status, reason, observedGeneration]"
+
+// buildPolicy builds the policy preventing users who are not approvers from changing the approval condition
+// of policy exceptions. Cluster administrators are always approvers, the service accounts of the Kyverno
+// controllers are excluded so that they can mark exceptions pending and record their usage, other service
+// accounts of the Kyverno namespace are not. The status of an exception can't be set when it is created,
+// the policy only needs to check updates of the status subresource.
+func buildPolicy(serviceAccounts []string, approval config.ExceptionApproval) *kyvernov1.ClusterPolicy {
+	admission, background := true, false
+	approvers := kyvernov1.UserInfo{
+		ClusterRoles: append([]string{"cluster-admin"}, approval.ClusterRoles...),
+		Subjects: []rbacv1.Subject{{
+			Kind: rbacv1.GroupKind,
+			Name: "system:masters",
+		}},
+	}
+	for _, username := range serviceAccounts {
+		namespace, name, err := serviceaccount.SplitUsername(username)
+		if err != nil {
+			logger.Error(err, "ignoring invalid service account", "username", username)
+			continue
+		}
+		approvers.Subjects = append(approvers.Subjects, rbacv1.Subject{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      name,
+			Namespace: namespace,
+		})
+	}
+	for _, group := range approval.Groups {
+		approvers.Subjects = append(approvers.Subjects, rbacv1.Subject{
+			Kind: rbacv1.GroupKind,
+			Name: group,
+		})
+	}
+	conditions := map[string]interface{}{
+		"all": []interface{}{
+			map[string]interface{}{
+				"key":      "{{ to_string(request.object." + approvalCondition + ") }}",
+				"operator": "NotEquals",
+				"value":    "{{ to_string(request.oldObject." + approvalCondition + ") }}",
+			},
+		},
+	}
+	policy := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: PolicyName,
+			Annotations: map[string]string{
+				"policies.kyverno.io/title":       "Kyverno Exception Approval",
+				"policies.kyverno.io/category":    "Kyverno",
+				"policies.kyverno.io/description": "Generated by Kyverno when exceptionApproval is enabled in the Kyverno ConfigMap, changes are reverted.",
+			},
+		},
+		Spec: kyvernov1.Spec{
+			ValidationFailureAction: kyvernov1.Enforce,
+			Admission:               &admission,
+			Background:              &background,
+			Rules: []kyvernov1.Rule{{
+				Name: "approve-policy-exceptions",
+				MatchResources: kyvernov1.MatchResources{
+					Any: kyvernov1.ResourceFilters{{
+						ResourceDescription: kyvernov1.ResourceDescription{
+							Kinds:      []string{"kyverno.io/*/PolicyException/status"},
+							Operations: []kyvernov1.AdmissionOperation{kyvernov1.Update},
+						},
+					}},
+				},
+				ExcludeResources: kyvernov1.MatchResources{
+					Any: kyvernov1.ResourceFilters{{
+						UserInfo: approvers,
+					}},
+				},
+				Validation: kyvernov1.Validation{
+					Message: "Policy exceptions can only be approved or rejected by exception approvers.",
+					Deny: &kyvernov1.Deny{
+						RawAnyAllConditions: kyvernov1.ToJSON(conditions),
+					},
+				},
+			}},
+		},
+	}
+	// set the defaults applied by the API server so that the observed policy doesn't drift
+	for i := range policy.Spec.Rules {
+		policy.Spec.Rules[i].SkipBackgroundRequests = true
+	}
+	controllerutils.SetManagedByKyvernoLabel(policy)
+	return policy
+}
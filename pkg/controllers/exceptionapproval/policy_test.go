@@ -0,0 +1,156 @@
+package exceptionapproval
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/config"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/utils"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	matchutils "github.com/kyverno/kyverno/pkg/utils/match"
+	"gotest.tools/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+var serviceAccounts = []string{
+	"system:serviceaccount:kyverno:kyverno-admission-controller",
+	"system:serviceaccount:kyverno:kyverno-background-controller",
+}
+
+func Test_buildPolicy(t *testing.T) {
+	policy := buildPolicy(serviceAccounts, config.ExceptionApproval{
+		Enabled:      true,
+		Groups:       []string{"security-team"},
+		ClusterRoles: []string{"exception-approver"},
+	})
+	assert.Equal(t, policy.GetName(), PolicyName)
+	assert.Equal(t, len(policy.Spec.Rules), 1)
+	approvers := policy.Spec.Rules[0].ExcludeResources.Any[0].UserInfo
+	assert.DeepEqual(t, approvers.ClusterRoles, []string{"cluster-admin", "exception-approver"})
+	assert.Equal(t, approvers.Subjects[len(approvers.Subjects)-1].Name, "security-team")
+	assert.Assert(t, policy.GetSpec().ValidationFailureAction.Enforce())
+	assert.Assert(t, !policy.BackgroundProcessingEnabled())
+	assert.NilError(t, policy.Validate(nil).ToAggregate())
+}
+
+func Test_buildPolicy_approvers(t *testing.T) {
+	policy := buildPolicy(append(serviceAccounts, "invalid"), config.ExceptionApproval{Enabled: true, Groups: []string{"security-team"}})
+	approvers := policy.Spec.Rules[0].ExcludeResources.Any[0].UserInfo
+	serviceAccount := func(name string) authenticationv1.UserInfo {
+		return authenticationv1.UserInfo{
+			Username: "system:serviceaccount:kyverno:" + name,
+			Groups:   []string{"system:serviceaccounts", "system:serviceaccounts:kyverno", "system:authenticated"},
+		}
+	}
+	tests := []struct {
+		name     string
+		userInfo authenticationv1.UserInfo
+		approver bool
+	}{{
+		name:     "admission controller",
+		userInfo: serviceAccount("kyverno-admission-controller"),
+		approver: true,
+	}, {
+		name:     "background controller",
+		userInfo: serviceAccount("kyverno-background-controller"),
+		approver: true,
+	}, {
+		name:     "other service account in the kyverno namespace",
+		userInfo: serviceAccount("workload"),
+	}, {
+		name:     "masters",
+		userInfo: authenticationv1.UserInfo{Username: "admin", Groups: []string{"system:masters"}},
+		approver: true,
+	}, {
+		name:     "approvers group",
+		userInfo: authenticationv1.UserInfo{Username: "alice", Groups: []string{"security-team"}},
+		approver: true,
+	}, {
+		name:     "developer",
+		userInfo: authenticationv1.UserInfo{Username: "bob", Groups: []string{"developers"}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, matchutils.CheckSubjects(approvers.Subjects, tt.userInfo), tt.approver)
+		})
+	}
+}
+
+func Test_buildPolicy_deny(t *testing.T) {
+	pending := map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []interface{}{map[string]interface{}{
+				"type":               "Approved",
+				"status":             "False",
+				"reason":             "Pending",
+				"observedGeneration": 1,
+			}},
+		},
+	}
+	approved := map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []interface{}{map[string]interface{}{
+				"type":               "Approved",
+				"status":             "True",
+				"reason":             "Approved",
+				"observedGeneration": 1,
+			}, map[string]interface{}{
+				"type":   "Active",
+				"status": "True",
+				"reason": "Valid",
+			}},
+		},
+	}
+	pendingAndActive := map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []interface{}{map[string]interface{}{
+				"type":               "Approved",
+				"status":             "False",
+				"reason":             "Pending",
+				"observedGeneration": 1,
+			}, map[string]interface{}{
+				"type":   "Active",
+				"status": "True",
+				"reason": "Valid",
+			}},
+		},
+	}
+	tests := []struct {
+		name      string
+		oldObject map[string]interface{}
+		object    map[string]interface{}
+		deny      bool
+	}{{
+		name:      "approve",
+		oldObject: pending,
+		object:    approved,
+		deny:      true,
+	}, {
+		name:      "approve without status",
+		oldObject: map[string]interface{}{},
+		object:    approved,
+		deny:      true,
+	}, {
+		name:      "other conditions",
+		oldObject: pending,
+		object:    pendingAndActive,
+	}}
+	policy := buildPolicy(serviceAccounts, config.ExceptionApproval{Enabled: true})
+	conditions, err := utils.TransformConditions(policy.Spec.Rules[0].Validation.Deny.GetAnyAllConditions())
+	assert.NilError(t, err)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := enginecontext.NewContextFromRaw(jmespath.New(config.NewDefaultConfiguration(false)), map[string]interface{}{
+				"request": map[string]interface{}{
+					"object":    tt.object,
+					"oldObject": tt.oldObject,
+				},
+			})
+			deny, _, err := variables.EvaluateConditions(logr.Discard(), ctx, conditions)
+			assert.NilError(t, err)
+			assert.Equal(t, deny, tt.deny)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package exceptionusage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 2
+	ControllerName = "exception-usage-controller"
+	maxRetries     = 10
+	// flushInterval is how long usage is accumulated before it is written to the status of an exception
+	flushInterval = time.Minute
+)
+
+// Controller records the rules skipped by policy exceptions and writes the usage to the status of the exceptions.
+type Controller interface {
+	controllers.Controller
+	engineapi.PolicyExceptionUsageRecorder
+}
+
+type ruleKey struct {
+	policy string
+	rule   string
+}
+
+type usage struct {
+	count    int64
+	lastUsed time.Time
+}
+
+type controller struct {
+	// clients
+	kyvernoClient versioned.Interface
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	// lock protects pending
+	lock sync.Mutex
+	// pending stores the usage not written yet, by exception key
+	pending map[string]map[ruleKey]usage
+	// now returns the current time, it is replaced in tests
+	now func() time.Time
+}
+
+// NewController creates a controller maintaining the usage stored in the status of policy exceptions.
+// Usage is accumulated in memory and written at most once per flush interval for each exception,
+// so that exceptions applied to many resources don't cause an update for every admission request.
+func NewController(kyvernoClient versioned.Interface) Controller {
+	return &controller{
+		kyvernoClient: kyvernoClient,
+		queue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		pending:       map[string]map[ruleKey]usage{},
+		now:           time.Now,
+	}
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger.V(3), ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) Record(responses ...engineapi.EngineResponse) {
+	now := c.now()
+	for _, response := range responses {
+		policy, err := cache.MetaNamespaceKeyFunc(response.Policy().MetaObject())
+		if err != nil {
+			logger.Error(err, "failed to compute policy key")
+			continue
+		}
+		for _, rule := range response.PolicyResponse.Rules {
+			if !rule.IsException() {
+				continue
+			}
+			key, err := cache.MetaNamespaceKeyFunc(rule.Exception())
+			if err != nil {
+				logger.Error(err, "failed to compute policy exception key")
+				continue
+			}
+			c.add(key, ruleKey{policy: policy, rule: rule.Name()}, usage{count: 1, lastUsed: now})
+			c.queue.AddAfter(key, flushInterval)
+		}
+	}
+}
+
+func (c *controller) add(key string, rule ruleKey, u usage) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	rules := c.pending[key]
+	if rules == nil {
+		rules = map[ruleKey]usage{}
+		c.pending[key] = rules
+	}
+	existing := rules[rule]
+	existing.count += u.count
+	if existing.lastUsed.Before(u.lastUsed) {
+		existing.lastUsed = u.lastUsed
+	}
+	rules[rule] = existing
+}
+
+// take removes the pending usage of the exception
+func (c *controller) take(key string) map[ruleKey]usage {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	rules := c.pending[key]
+	delete(c.pending, key)
+	return rules
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	rules := c.take(key)
+	if len(rules) == 0 {
+		return nil
+	}
+	if err := c.updateStatus(ctx, namespace, name, rules); err != nil {
+		// keep the usage so that it is written when the key is retried
+		for rule, u := range rules {
+			c.add(key, rule, u)
+		}
+		return err
+	}
+	logger.V(4).Info("policy exception usage updated", "rules", len(rules))
+	return nil
+}
+
+func (c *controller) updateStatus(ctx context.Context, namespace, name string, rules map[ruleKey]usage) error {
+	client := c.kyvernoClient.KyvernoV2().PolicyExceptions(namespace)
+	polex, err := client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		// usage of deleted exceptions is dropped
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	_, err = controllerutils.UpdateStatus(ctx, polex, client, func(polex *kyvernov2.PolicyException) error {
+		for rule, u := range rules {
+			polex.Status.RecordUsage(rule.policy, rule.rule, u.count, metav1.NewTime(u.lastUsed))
+		}
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,67 @@
+package exceptionusage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func newResponse(polex *kyvernov2.PolicyException, rules ...string) engineapi.EngineResponse {
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "require-labels"}}
+	var responses []engineapi.RuleResponse
+	for _, rule := range rules {
+		responses = append(responses, *engineapi.RuleSkip(rule, engineapi.Validation, "rule skipped due to policy exception").WithException(polex))
+	}
+	responses = append(responses, *engineapi.RulePass("other", engineapi.Validation, ""))
+	return engineapi.NewEngineResponse(unstructured.Unstructured{}, engineapi.NewKyvernoPolicy(policy), nil).
+		WithPolicyResponse(engineapi.PolicyResponse{Rules: responses})
+}
+
+func Test_reconcile(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	polex := &kyvernov2.PolicyException{ObjectMeta: metav1.ObjectMeta{Namespace: "kyverno", Name: "legacy"}}
+	client := fake.NewSimpleClientset(polex)
+	c := NewController(client).(*controller)
+	t.Cleanup(c.queue.ShutDown)
+	c.now = func() time.Time { return now }
+	c.Record(newResponse(polex, "check-team"), newResponse(polex, "check-team", "check-owner"))
+	// status updates fail once, the usage must be kept for the retry
+	client.PrependReactor("update", "policyexceptions", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		client.ReactionChain = client.ReactionChain[1:]
+		return true, nil, errors.New("conflict")
+	})
+	assert.ErrorContains(t, c.reconcile(context.TODO(), logger, "kyverno/legacy", "kyverno", "legacy"), "conflict")
+	assert.NilError(t, c.reconcile(context.TODO(), logger, "kyverno/legacy", "kyverno", "legacy"))
+	updated, err := client.KyvernoV2().PolicyExceptions("kyverno").Get(context.TODO(), "legacy", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(updated.Status.Usage), 2)
+	counts := map[string]int64{}
+	for _, usage := range updated.Status.Usage {
+		assert.Equal(t, usage.PolicyName, "require-labels")
+		assert.Assert(t, usage.LastUsed.Time.Equal(now))
+		counts[usage.RuleName] = usage.Count
+	}
+	assert.DeepEqual(t, counts, map[string]int64{"check-team": 2, "check-owner": 1})
+	// nothing is pending anymore
+	assert.Equal(t, len(c.pending), 0)
+}
+
+func Test_reconcile_deleted(t *testing.T) {
+	polex := &kyvernov2.PolicyException{ObjectMeta: metav1.ObjectMeta{Namespace: "kyverno", Name: "deleted"}}
+	c := NewController(fake.NewSimpleClientset()).(*controller)
+	t.Cleanup(c.queue.ShutDown)
+	c.Record(newResponse(polex, "check-team"))
+	assert.NilError(t, c.reconcile(context.TODO(), logger, "kyverno/deleted", "kyverno", "deleted"))
+	assert.Equal(t, len(c.pending), 0)
+}
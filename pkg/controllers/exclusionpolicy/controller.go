@@ -0,0 +1,196 @@
+package exclusionpolicy
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "exclusion-policy-controller"
+	maxRetries     = 10
+	// flushInterval is how long filtered requests are counted before they are written to the status of a policy
+	flushInterval = time.Minute
+)
+
+// Controller matches resources against the exclusion policies scoped to a Kyverno controller
+// and writes the number of filtered requests to the status of the policies.
+type Controller interface {
+	controllers.Controller
+	config.ExclusionPolicies
+}
+
+type filtered struct {
+	count        int64
+	lastFiltered time.Time
+}
+
+type controller struct {
+	// clients
+	kyvernoClient versioned.Interface
+
+	// listers
+	exclpolLister kyvernov2alpha1listers.ExclusionPolicyLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	// scope is the controller the policies are compiled for
+	scope kyvernov2alpha1.ExclusionController
+	// policies stores the compiled policies, sorted by name
+	policies atomic.Pointer[[]policy]
+
+	// lock protects pending
+	lock sync.Mutex
+	// pending stores the filtered requests not written yet, by policy name
+	pending map[string]filtered
+	// now returns the current time, it is replaced in tests
+	now func() time.Time
+}
+
+// NewController creates a controller compiling the exclusion policies scoped to the given Kyverno controller.
+// Filtered requests are accumulated in memory and written at most once per flush interval for each policy.
+func NewController(
+	kyvernoClient versioned.Interface,
+	exclpolInformer kyvernov2alpha1informers.ExclusionPolicyInformer,
+	scope kyvernov2alpha1.ExclusionController,
+) Controller {
+	c := &controller{
+		kyvernoClient: kyvernoClient,
+		exclpolLister: exclpolInformer.Lister(),
+		queue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		scope:         scope,
+		pending:       map[string]filtered{},
+		now:           time.Now,
+	}
+	c.policies.Store(&[]policy{})
+	compile := func(_ *kyvernov2alpha1.ExclusionPolicy) { c.compile() }
+	if _, err := controllerutils.AddEventHandlersT(
+		exclpolInformer.Informer(),
+		compile,
+		func(_, obj *kyvernov2alpha1.ExclusionPolicy) { compile(obj) },
+		compile,
+	); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger.V(3), ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+// compile compiles the policies scoped to the controller, invalid policies are ignored
+func (c *controller) compile() {
+	exclpols, err := c.exclpolLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list exclusion policies")
+		return
+	}
+	compiled := make([]policy, 0, len(exclpols))
+	for _, exclpol := range exclpols {
+		if !exclpol.Spec.AppliesTo(c.scope) {
+			continue
+		}
+		if errs := exclpol.Validate(); len(errs) != 0 {
+			logger.Error(errs.ToAggregate(), "invalid exclusion policy", "name", exclpol.Name)
+			continue
+		}
+		p, err := compilePolicy(exclpol)
+		if err != nil {
+			logger.Error(err, "failed to compile exclusion policy", "name", exclpol.Name)
+			continue
+		}
+		compiled = append(compiled, p)
+	}
+	sort.Slice(compiled, func(i, j int) bool { return compiled[i].name < compiled[j].name })
+	c.policies.Store(&compiled)
+	logger.V(4).Info("exclusion policies compiled", "scope", c.scope, "policies", len(compiled))
+}
+
+func (c *controller) Match(gvk schema.GroupVersionKind, subresource, namespace, name string, labels map[string]string) (string, bool) {
+	// the namespace of a Namespace resource is its name
+	if gvk.Group == "" && gvk.Version == "v1" && gvk.Kind == "Namespace" {
+		namespace = name
+	}
+	for _, p := range *c.policies.Load() {
+		if p.matches(gvk, subresource, namespace, name, labels) {
+			return p.name, true
+		}
+	}
+	return "", false
+}
+
+func (c *controller) Record(policy string) {
+	c.add(policy, filtered{count: 1, lastFiltered: c.now()})
+	c.queue.AddAfter(policy, flushInterval)
+}
+
+func (c *controller) add(policy string, f filtered) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	existing := c.pending[policy]
+	existing.count += f.count
+	if existing.lastFiltered.Before(f.lastFiltered) {
+		existing.lastFiltered = f.lastFiltered
+	}
+	c.pending[policy] = existing
+}
+
+// take removes the pending filtered requests of the policy
+func (c *controller) take(policy string) (filtered, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	f, ok := c.pending[policy]
+	delete(c.pending, policy)
+	return f, ok
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, _, _, name string) error {
+	f, ok := c.take(name)
+	if !ok {
+		return nil
+	}
+	if err := c.updateStatus(ctx, name, f); err != nil {
+		// keep the count so that it is written when the key is retried
+		c.add(name, f)
+		return err
+	}
+	logger.V(4).Info("exclusion policy status updated", "filtered", f.count)
+	return nil
+}
+
+func (c *controller) updateStatus(ctx context.Context, name string, f filtered) error {
+	client := c.kyvernoClient.KyvernoV2alpha1().ExclusionPolicies()
+	exclpol, err := client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		// counts of deleted policies are dropped
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	_, err = controllerutils.UpdateStatus(ctx, exclpol, client, func(exclpol *kyvernov2alpha1.ExclusionPolicy) error {
+		exclpol.Status.RecordFiltered(f.count, metav1.NewTime(f.lastFiltered))
+		return nil
+	})
+	return err
+}
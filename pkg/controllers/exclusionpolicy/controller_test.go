@@ -0,0 +1,116 @@
+package exclusionpolicy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func newController(t *testing.T, scope kyvernov2alpha1.ExclusionController, exclpols ...*kyvernov2alpha1.ExclusionPolicy) (*controller, *fake.Clientset) {
+	var objects []runtime.Object
+	for _, exclpol := range exclpols {
+		objects = append(objects, exclpol)
+	}
+	client := fake.NewSimpleClientset(objects...)
+	informer := kyvernoinformer.NewSharedInformerFactory(client, 0).Kyverno().V2alpha1().ExclusionPolicies()
+	c := NewController(client, informer, scope).(*controller)
+	t.Cleanup(c.queue.ShutDown)
+	for _, exclpol := range exclpols {
+		assert.NilError(t, informer.Informer().GetIndexer().Add(exclpol))
+	}
+	c.compile()
+	return c, client
+}
+
+func Test_Match(t *testing.T) {
+	pod := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	namespace := schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}
+	deployment := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	system := &kyvernov2alpha1.ExclusionPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "system"},
+		Spec: kyvernov2alpha1.ExclusionPolicySpec{
+			Exclusions: []kyvernov2alpha1.ResourceExclusion{{
+				Namespaces: []string{"kube-system", "kyverno-*"},
+			}, {
+				Kinds:    []string{"Pod"},
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"skip": "true"}},
+			}},
+		},
+	}
+	reports := &kyvernov2alpha1.ExclusionPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "reports"},
+		Spec: kyvernov2alpha1.ExclusionPolicySpec{
+			Controllers: []kyvernov2alpha1.ExclusionController{kyvernov2alpha1.ExclusionReports},
+			Exclusions: []kyvernov2alpha1.ResourceExclusion{{
+				Kinds: []string{"apps/v1/Deployment"},
+				Names: []string{"legacy-*"},
+			}},
+		},
+	}
+	invalid := &kyvernov2alpha1.ExclusionPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid"},
+		Spec:       kyvernov2alpha1.ExclusionPolicySpec{Exclusions: []kyvernov2alpha1.ResourceExclusion{{}}},
+	}
+	admission, _ := newController(t, kyvernov2alpha1.ExclusionAdmission, system, reports, invalid)
+	reportsController, _ := newController(t, kyvernov2alpha1.ExclusionReports, system, reports, invalid)
+	tests := []struct {
+		name        string
+		c           *controller
+		gvk         schema.GroupVersionKind
+		subresource string
+		namespace   string
+		resource    string
+		labels      map[string]string
+		want        string
+	}{
+		{name: "namespace", c: admission, gvk: pod, namespace: "kube-system", resource: "coredns", want: "system"},
+		{name: "namespace wildcard", c: admission, gvk: deployment, namespace: "kyverno-system", resource: "kyverno", want: "system"},
+		{name: "namespace resource", c: admission, gvk: namespace, resource: "kube-system", want: "system"},
+		{name: "selector", c: admission, gvk: pod, namespace: "default", resource: "nginx", labels: map[string]string{"skip": "true"}, want: "system"},
+		{name: "selector other kind", c: admission, gvk: deployment, namespace: "default", resource: "nginx", labels: map[string]string{"skip": "true"}},
+		{name: "subresource", c: admission, gvk: pod, subresource: "exec", namespace: "default", resource: "nginx", labels: map[string]string{"skip": "true"}},
+		{name: "not matched", c: admission, gvk: pod, namespace: "default", resource: "nginx"},
+		{name: "other controller", c: admission, gvk: deployment, namespace: "default", resource: "legacy-app"},
+		{name: "scoped controller", c: reportsController, gvk: deployment, namespace: "default", resource: "legacy-app", want: "reports"},
+		{name: "scoped controller name", c: reportsController, gvk: deployment, namespace: "default", resource: "app"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, excluded := tt.c.Match(tt.gvk, tt.subresource, tt.namespace, tt.resource, tt.labels)
+			assert.Equal(t, excluded, tt.want != "")
+			assert.Equal(t, policy, tt.want)
+		})
+	}
+}
+
+func Test_reconcile(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	exclpol := &kyvernov2alpha1.ExclusionPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "system"},
+		Spec: kyvernov2alpha1.ExclusionPolicySpec{
+			Exclusions: []kyvernov2alpha1.ResourceExclusion{{Namespaces: []string{"kube-system"}}},
+		},
+		Status: kyvernov2alpha1.ExclusionPolicyStatus{FilteredRequests: 3},
+	}
+	c, client := newController(t, kyvernov2alpha1.ExclusionAdmission, exclpol)
+	c.now = func() time.Time { return now }
+	c.Record("system")
+	c.Record("system")
+	c.Record("deleted")
+	assert.NilError(t, c.reconcile(context.TODO(), logger, "system", "", "system"))
+	assert.NilError(t, c.reconcile(context.TODO(), logger, "deleted", "", "deleted"))
+	updated, err := client.KyvernoV2alpha1().ExclusionPolicies().Get(context.TODO(), "system", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, updated.Status.FilteredRequests, int64(5))
+	assert.Assert(t, updated.Status.LastFilteredTime.Time.Equal(now))
+	// nothing is pending anymore
+	assert.Equal(t, len(c.pending), 0)
+}
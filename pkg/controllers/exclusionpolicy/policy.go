@@ -0,0 +1,102 @@
+package exclusionpolicy
+
+import (
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/ext/wildcard"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type kindSelector struct {
+	group       string
+	version     string
+	kind        string
+	subresource string
+}
+
+func (s kindSelector) matches(gvk schema.GroupVersionKind, subresource string) bool {
+	return wildcard.Match(s.group, gvk.Group) &&
+		wildcard.Match(s.version, gvk.Version) &&
+		wildcard.Match(s.kind, gvk.Kind) &&
+		wildcard.Match(s.subresource, subresource)
+}
+
+type exclusion struct {
+	kinds      []kindSelector
+	namespaces []string
+	names      []string
+	selector   labels.Selector
+}
+
+func (e exclusion) matches(gvk schema.GroupVersionKind, subresource, namespace, name string, resourceLabels map[string]string) bool {
+	if len(e.kinds) != 0 {
+		matched := false
+		for _, kind := range e.kinds {
+			if kind.matches(gvk, subresource) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(e.namespaces) != 0 && !matchesAny(e.namespaces, namespace) {
+		return false
+	}
+	if len(e.names) != 0 && !matchesAny(e.names, name) {
+		return false
+	}
+	if e.selector != nil && !e.selector.Matches(labels.Set(resourceLabels)) {
+		return false
+	}
+	return true
+}
+
+type policy struct {
+	name       string
+	exclusions []exclusion
+}
+
+func (p policy) matches(gvk schema.GroupVersionKind, subresource, namespace, name string, labels map[string]string) bool {
+	for _, e := range p.exclusions {
+		if e.matches(gvk, subresource, namespace, name, labels) {
+			return true
+		}
+	}
+	return false
+}
+
+func compilePolicy(exclpol *kyvernov2alpha1.ExclusionPolicy) (policy, error) {
+	p := policy{name: exclpol.Name}
+	for _, e := range exclpol.Spec.Exclusions {
+		compiled := exclusion{
+			namespaces: e.Namespaces,
+			names:      e.Names,
+		}
+		for _, kind := range e.Kinds {
+			group, version, kind, subresource := kubeutils.ParseKindSelector(kind)
+			compiled.kinds = append(compiled.kinds, kindSelector{group: group, version: version, kind: kind, subresource: subresource})
+		}
+		if e.Selector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(e.Selector)
+			if err != nil {
+				return policy{}, err
+			}
+			compiled.selector = selector
+		}
+		p.exclusions = append(p.exclusions, compiled)
+	}
+	return p, nil
+}
+
+func matchesAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if wildcard.Match(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package clusterpolicy
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/logging"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	maxRetries = 10
+)
+
+// BuildFunc returns the desired policy, or nil when the policy is disabled in the Kyverno configuration
+type BuildFunc func() *kyvernov1.ClusterPolicy
+
+type controller struct {
+	// clients
+	kyvernoClient versioned.Interface
+
+	// listers
+	cpolLister kyvernov1listers.ClusterPolicyLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	// config
+	controllerName string
+	logger         logr.Logger
+	policyName     string
+	build          BuildFunc
+}
+
+// NewController creates a controller maintaining a ClusterPolicy generated by Kyverno according to the Kyverno
+// configuration. The policy is rebuilt when the configuration changes, changes made to the policy are reverted
+// and the policy is deleted when it's disabled, unless it isn't managed by Kyverno.
+func NewController(
+	controllerName string,
+	policyName string,
+	kyvernoClient versioned.Interface,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	configuration config.Configuration,
+	build BuildFunc,
+) controllers.Controller {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), controllerName)
+	c := &controller{
+		kyvernoClient:  kyvernoClient,
+		cpolLister:     cpolInformer.Lister(),
+		queue:          queue,
+		controllerName: controllerName,
+		logger:         logging.ControllerLogger(controllerName),
+		policyName:     policyName,
+		build:          build,
+	}
+	enqueue := func(obj *kyvernov1.ClusterPolicy) {
+		if obj.GetName() == policyName {
+			c.enqueue()
+		}
+	}
+	if _, err := controllerutils.AddEventHandlersT(
+		cpolInformer.Informer(),
+		enqueue,
+		func(_, obj *kyvernov1.ClusterPolicy) { enqueue(obj) },
+		enqueue,
+	); err != nil {
+		c.logger.Error(err, "failed to register event handlers")
+	}
+	configuration.OnChanged(c.enqueue)
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	c.enqueue()
+	controllerutils.Run(ctx, c.logger.V(3), c.controllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) enqueue() {
+	c.queue.Add(c.policyName)
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, _, _, name string) error {
+	observed, err := c.cpolLister.Get(name)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		observed = nil
+	}
+	desired := c.build()
+	if desired == nil {
+		// only delete the policy if it was generated by kyverno
+		if observed == nil || !controllerutils.IsManagedByKyverno(observed) {
+			return nil
+		}
+		logger.Info("policy disabled, deleting policy")
+		err := c.kyvernoClient.KyvernoV1().ClusterPolicies().Delete(ctx, name, metav1.DeleteOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if observed == nil {
+		logger.Info("policy enabled, creating policy")
+		_, err := c.kyvernoClient.KyvernoV1().ClusterPolicies().Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	}
+	_, err = controllerutils.Update(ctx, observed, c.kyvernoClient.KyvernoV1().ClusterPolicies(), func(policy *kyvernov1.ClusterPolicy) error {
+		controllerutils.SetManagedByKyvernoLabel(policy)
+		for key, value := range desired.Annotations {
+			controllerutils.SetAnnotation(policy, key, value)
+		}
+		policy.Spec = desired.Spec
+		return nil
+	})
+	return err
+}
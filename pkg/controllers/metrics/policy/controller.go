@@ -8,6 +8,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/autogen"
 	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
@@ -20,6 +21,7 @@ import (
 
 type controller struct {
 	metricsConfig metrics.MetricsConfigManager
+	configuration config.Configuration
 	ruleInfo      metric.Float64ObservableGauge
 
 	// listers
@@ -32,6 +34,7 @@ type controller struct {
 // TODO: this is a strange controller, it only processes events, this should be changed to a real controller.
 func NewController(
 	metricsConfig metrics.MetricsConfigManager,
+	configuration config.Configuration,
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
 	polInformer kyvernov1informers.PolicyInformer,
 	waitGroup *sync.WaitGroup,
@@ -47,6 +50,7 @@ func NewController(
 	}
 	c := controller{
 		metricsConfig: metricsConfig,
+		configuration: configuration,
 		ruleInfo:      policyRuleInfoMetric,
 		cpolLister:    cpolInformer.Lister(),
 		polLister:     polInformer.Lister(),
@@ -111,7 +115,7 @@ func (c *controller) reportPolicy(ctx context.Context, policy kyvernov1.PolicyIn
 			attribute.String("policy_type", string(policyType)),
 			attribute.String("policy_background_mode", string(backgroundMode)),
 		}
-		for _, rule := range autogen.ComputeRules(policy) {
+		for _, rule := range autogen.ComputeRules(policy, c.configuration.GetAutogenControllers()) {
 			ruleType := metrics.ParseRuleType(rule)
 			ruleAttributes := []attribute.KeyValue{
 				attribute.String("rule_name", rule.Name),
@@ -9,7 +9,9 @@ import (
 	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/engine/compiled"
 	pcache "github.com/kyverno/kyverno/pkg/policycache"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -32,7 +34,9 @@ type Controller interface {
 }
 
 type controller struct {
-	cache pcache.Cache
+	cache         pcache.Cache
+	compiledCache compiled.Cache
+	configuration config.Configuration
 
 	// listers
 	cpolLister kyvernov1listers.ClusterPolicyLister
@@ -45,13 +49,15 @@ type controller struct {
 	client dclient.Interface
 }
 
-func NewController(client dclient.Interface, pcache pcache.Cache, cpolInformer kyvernov1informers.ClusterPolicyInformer, polInformer kyvernov1informers.PolicyInformer) Controller {
+func NewController(client dclient.Interface, pcache pcache.Cache, compiledCache compiled.Cache, configuration config.Configuration, cpolInformer kyvernov1informers.ClusterPolicyInformer, polInformer kyvernov1informers.PolicyInformer) Controller {
 	c := controller{
-		cache:      pcache,
-		cpolLister: cpolInformer.Lister(),
-		polLister:  polInformer.Lister(),
-		queue:      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
-		client:     client,
+		cache:         pcache,
+		compiledCache: compiledCache,
+		configuration: configuration,
+		cpolLister:    cpolInformer.Lister(),
+		polLister:     polInformer.Lister(),
+		queue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		client:        client,
 	}
 	if _, _, err := controllerutils.AddDefaultEventHandlers(logger, cpolInformer.Informer(), c.queue); err != nil {
 		logger.Error(err, "failed to register event handlers")
@@ -59,9 +65,39 @@ func NewController(client dclient.Interface, pcache pcache.Cache, cpolInformer k
 	if _, _, err := controllerutils.AddDefaultEventHandlers(logger, polInformer.Informer(), c.queue); err != nil {
 		logger.Error(err, "failed to register event handlers")
 	}
+	// the rules of the policies depend on the autogen controllers of the configuration
+	configuration.OnChanged(c.enqueueAll)
 	return &c
 }
 
+func (c *controller) enqueueAll() {
+	pols, err := c.polLister.Policies(metav1.NamespaceAll).List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list policies")
+		return
+	}
+	for _, policy := range pols {
+		c.enqueue(policy)
+	}
+	cpols, err := c.cpolLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list cluster policies")
+		return
+	}
+	for _, policy := range cpols {
+		c.enqueue(policy)
+	}
+}
+
+func (c *controller) enqueue(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		logger.Error(err, "failed to compute policy key")
+		return
+	}
+	c.queue.Add(key)
+}
+
 func (c *controller) WarmUp() error {
 	logger.Info("warming up ...")
 	defer logger.Info("warm up done")
@@ -74,7 +110,7 @@ func (c *controller) WarmUp() error {
 		if key, err := cache.MetaNamespaceKeyFunc(policy); err != nil {
 			return err
 		} else {
-			return c.cache.Set(key, policy, c.client.Discovery())
+			return c.set(key, policy)
 		}
 	}
 	cpols, err := c.cpolLister.List(labels.Everything())
@@ -85,7 +121,7 @@ func (c *controller) WarmUp() error {
 		if key, err := cache.MetaNamespaceKeyFunc(policy); err != nil {
 			return err
 		} else {
-			return c.cache.Set(key, policy, c.client.Discovery())
+			return c.set(key, policy)
 		}
 	}
 	return nil
@@ -99,18 +135,33 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 	policy, err := c.loadPolicy(namespace, name)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			c.cache.Unset(key)
+			c.unset(key)
 		}
 		return err
 	}
 	if policy.AdmissionProcessingEnabled() {
-		return c.cache.Set(key, policy, c.client.Discovery())
+		return c.set(key, policy)
 	} else {
-		c.cache.Unset(key)
+		c.unset(key)
 		return nil
 	}
 }
 
+func (c *controller) set(key string, policy kyvernov1.PolicyInterface) error {
+	autogenControllers := c.configuration.GetAutogenControllers()
+	if c.compiledCache != nil {
+		c.compiledCache.Set(key, policy, autogenControllers)
+	}
+	return c.cache.Set(key, policy, c.client.Discovery(), autogenControllers)
+}
+
+func (c *controller) unset(key string) {
+	if c.compiledCache != nil {
+		c.compiledCache.Unset(key)
+	}
+	c.cache.Unset(key)
+}
+
 func (c *controller) loadPolicy(namespace, name string) (kyvernov1.PolicyInterface, error) {
 	if namespace == "" {
 		return c.cpolLister.Get(name)
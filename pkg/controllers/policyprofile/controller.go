@@ -0,0 +1,244 @@
+package policyprofile
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	maxRetries     = 10
+	Workers        = 2
+	ControllerName = "policyprofile-controller"
+)
+
+type controller struct {
+	// clients
+	kyvernoClient versioned.Interface
+
+	// listers
+	profileLister kyvernov2alpha1listers.PolicyProfileLister
+	polLister     kyvernov1listers.PolicyLister
+	nsLister      corev1listers.NamespaceLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+}
+
+func NewController(
+	kyvernoClient versioned.Interface,
+	profileInformer kyvernov2alpha1informers.PolicyProfileInformer,
+	polInformer kyvernov1informers.PolicyInformer,
+	nsInformer corev1informers.NamespaceInformer,
+) controllers.Controller {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+	c := &controller{
+		kyvernoClient: kyvernoClient,
+		profileLister: profileInformer.Lister(),
+		polLister:     polInformer.Lister(),
+		nsLister:      nsInformer.Lister(),
+		queue:         queue,
+	}
+	enqueue := controllerutils.LogError(logger, controllerutils.Parse(controllerutils.MetaNamespaceKeyT[*kyvernov2alpha1.PolicyProfile], controllerutils.Queue(queue)))
+	if _, err := controllerutils.AddEventHandlersT(
+		profileInformer.Informer(),
+		controllerutils.AddFuncT(logger, enqueue),
+		// status updates don't change the generation, reconciling them would loop forever
+		func(old, obj *kyvernov2alpha1.PolicyProfile) {
+			if old.GetGeneration() != obj.GetGeneration() {
+				if err := enqueue(obj); err != nil {
+					logger.Error(err, "failed to enqueue object", "obj", obj)
+				}
+			}
+		},
+		nil,
+	); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	// namespaces joining or leaving a profile
+	if _, err := controllerutils.AddEventHandlersT(
+		nsInformer.Informer(),
+		func(obj *corev1.Namespace) { c.enqueueProfile(obj.GetLabels()[kyverno.LabelPolicyProfile]) },
+		func(old, obj *corev1.Namespace) {
+			oldProfile, profile := old.GetLabels()[kyverno.LabelPolicyProfile], obj.GetLabels()[kyverno.LabelPolicyProfile]
+			if oldProfile != profile {
+				c.enqueueProfile(oldProfile)
+				c.enqueueProfile(profile)
+			}
+		},
+		func(obj *corev1.Namespace) { c.enqueueProfile(obj.GetLabels()[kyverno.LabelPolicyProfile]) },
+	); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	// template changes and copies modified or deleted behind the controller's back
+	if _, err := controllerutils.AddEventHandlersT(
+		polInformer.Informer(),
+		func(obj *kyvernov1.Policy) { c.enqueuePolicy(obj) },
+		func(old, obj *kyvernov1.Policy) {
+			if old.GetResourceVersion() != obj.GetResourceVersion() {
+				c.enqueuePolicy(obj)
+			}
+		},
+		func(obj *kyvernov1.Policy) { c.enqueuePolicy(obj) },
+	); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger.V(3), ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) enqueueProfile(name string) {
+	if name != "" {
+		c.queue.Add(name)
+	}
+}
+
+// enqueuePolicy enqueues the profile managing a policy and the profiles using it as a template
+func (c *controller) enqueuePolicy(policy *kyvernov1.Policy) {
+	c.enqueueProfile(policy.GetLabels()[kyverno.LabelPolicyProfile])
+	profiles, err := c.profileLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list policy profiles")
+		return
+	}
+	for _, profile := range profiles {
+		if references(profile, policy.GetNamespace(), policy.GetName()) {
+			c.enqueueProfile(profile.GetName())
+		}
+	}
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, _, _, name string) error {
+	profile, err := c.profileLister.Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		logger.Error(err, "unable to get the policy profile from policy profile informer")
+		return err
+	}
+	if invalid := profile.Validate(); len(invalid) != 0 {
+		return c.updateStatus(ctx, profile, nil, invalid.ToAggregate().Error())
+	}
+	namespaces, err := c.nsLister.List(labels.SelectorFromSet(labels.Set{kyverno.LabelPolicyProfile: name}))
+	if err != nil {
+		return err
+	}
+	sort.Slice(namespaces, func(i, j int) bool { return namespaces[i].GetName() < namespaces[j].GetName() })
+	var problems []string
+	templates := make([]*kyvernov1.Policy, len(profile.Spec.Policies))
+	for i, ref := range profile.Spec.Policies {
+		template, err := c.polLister.Policies(ref.Namespace).Get(ref.Name)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			problems = append(problems, fmt.Sprintf("template policy %s/%s not found", ref.Namespace, ref.Name))
+			continue
+		}
+		templates[i] = template
+	}
+	desired := sets.New[string]()
+	statuses := make([]kyvernov2alpha1.PolicyProfileNamespace, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		status := kyvernov2alpha1.PolicyProfileNamespace{Name: namespace.GetName()}
+		var failures []string
+		for i, ref := range profile.Spec.Policies {
+			template := templates[i]
+			// the template already applies to its own namespace
+			if template == nil || ref.Namespace == namespace.GetName() {
+				continue
+			}
+			desired.Insert(namespace.GetName() + "/" + ref.Name)
+			if err := c.applyPolicy(ctx, profile, ref, template, namespace.GetName()); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %s", ref.Name, err))
+				continue
+			}
+			status.Policies = append(status.Policies, ref.Name)
+		}
+		if len(failures) != 0 {
+			status.Message = strings.Join(failures, "; ")
+			problems = append(problems, fmt.Sprintf("failed to apply policies to namespace %s", namespace.GetName()))
+		}
+		statuses = append(statuses, status)
+	}
+	// delete the copies of the namespaces that left the profile and of the templates removed from the profile
+	owned, err := c.polLister.List(labels.SelectorFromSet(labels.Set{kyverno.LabelPolicyProfile: name}))
+	if err != nil {
+		return err
+	}
+	for _, policy := range owned {
+		if desired.Has(policy.GetNamespace()+"/"+policy.GetName()) || references(profile, policy.GetNamespace(), policy.GetName()) {
+			continue
+		}
+		if err := c.kyvernoClient.KyvernoV1().Policies(policy.GetNamespace()).Delete(ctx, policy.GetName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return c.updateStatus(ctx, profile, statuses, strings.Join(problems, ", "))
+}
+
+func (c *controller) applyPolicy(ctx context.Context, profile *kyvernov2alpha1.PolicyProfile, ref kyvernov2alpha1.PolicyProfilePolicy, template *kyvernov1.Policy, namespace string) error {
+	desired, err := buildDesired(profile, ref, template, namespace)
+	if err != nil {
+		return err
+	}
+	existing, err := c.polLister.Policies(namespace).Get(desired.GetName())
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		_, err = c.kyvernoClient.KyvernoV1().Policies(namespace).Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	}
+	if existing.GetLabels()[kyverno.LabelPolicyProfile] != profile.GetName() {
+		return fmt.Errorf("policy %s already exists and is not managed by the profile", existing.GetName())
+	}
+	if isUpToDate(existing, desired) {
+		return nil
+	}
+	desired.SetResourceVersion(existing.GetResourceVersion())
+	_, err = c.kyvernoClient.KyvernoV1().Policies(namespace).Update(ctx, desired, metav1.UpdateOptions{})
+	return err
+}
+
+func (c *controller) updateStatus(ctx context.Context, profile *kyvernov2alpha1.PolicyProfile, namespaces []kyvernov2alpha1.PolicyProfileNamespace, problems string) error {
+	latest := profile.DeepCopy()
+	latest.Status.Namespaces = namespaces
+	if problems == "" {
+		latest.Status.SetReady(true, fmt.Sprintf("policies applied to %d namespaces", len(namespaces)))
+	} else {
+		latest.Status.SetReady(false, problems)
+	}
+	if equality.Semantic.DeepEqual(profile.Status, latest.Status) {
+		return nil
+	}
+	_, err := c.kyvernoClient.KyvernoV2alpha1().PolicyProfiles().UpdateStatus(ctx, latest, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,86 @@
+package policyprofile
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// buildDesired returns the copy of a template policy applied to a namespace of the profile. The copy is labelled
+// and owned by the profile, deleting the profile garbage collects its copies.
+func buildDesired(profile *kyvernov2alpha1.PolicyProfile, ref kyvernov2alpha1.PolicyProfilePolicy, template *kyvernov1.Policy, namespace string) (*kyvernov1.Policy, error) {
+	spec := template.Spec.DeepCopy()
+	if ref.ValidationFailureAction != "" {
+		spec.ValidationFailureAction = ref.ValidationFailureAction
+	}
+	hash, err := specHash(spec)
+	if err != nil {
+		return nil, err
+	}
+	labels := map[string]string{}
+	for k, v := range template.GetLabels() {
+		labels[k] = v
+	}
+	labels[kyverno.LabelPolicyProfile] = profile.GetName()
+	annotations := map[string]string{}
+	for k, v := range template.GetAnnotations() {
+		annotations[k] = v
+	}
+	// the last applied configuration of the template does not describe the copy
+	delete(annotations, "kubectl.kubernetes.io/last-applied-configuration")
+	annotations[kyverno.AnnotationPolicyProfileHash] = hash
+	return &kyvernov1.Policy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        template.GetName(),
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: annotations,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: kyvernov2alpha1.SchemeGroupVersion.String(),
+				Kind:       "PolicyProfile",
+				Name:       profile.GetName(),
+				UID:        profile.GetUID(),
+			}},
+		},
+		Spec: *spec,
+	}, nil
+}
+
+// specHash returns the hash of a policy spec. Copies are compared with the hash of the spec they were built from
+// instead of their spec, which can be defaulted when they are created.
+func specHash(spec *kyvernov1.Spec) (string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// isUpToDate returns true if an existing copy was built from the same template spec as the desired copy
+func isUpToDate(existing, desired *kyvernov1.Policy) bool {
+	if existing.GetAnnotations()[kyverno.AnnotationPolicyProfileHash] != desired.GetAnnotations()[kyverno.AnnotationPolicyProfileHash] {
+		return false
+	}
+	for k, v := range desired.GetLabels() {
+		if existing.GetLabels()[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// references returns true if the profile uses the policy as a template
+func references(profile *kyvernov2alpha1.PolicyProfile, namespace, name string) bool {
+	for _, ref := range profile.Spec.Policies {
+		if ref.Namespace == namespace && ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package policyprofile
+
+import (
+	"testing"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newProfile() *kyvernov2alpha1.PolicyProfile {
+	return &kyvernov2alpha1.PolicyProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "restricted", UID: "1234"},
+		Spec: kyvernov2alpha1.PolicyProfileSpec{
+			Policies: []kyvernov2alpha1.PolicyProfilePolicy{{Namespace: "templates", Name: "require-labels"}},
+		},
+	}
+}
+
+func newTemplate() *kyvernov1.Policy {
+	return &kyvernov1.Policy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "require-labels",
+			Namespace: "templates",
+			Labels:    map[string]string{"tier": "platform"},
+			Annotations: map[string]string{
+				"policies.kyverno.io/title":                        "Require labels",
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			},
+		},
+		Spec: kyvernov1.Spec{
+			ValidationFailureAction: kyvernov1.Audit,
+			Rules:                   []kyvernov1.Rule{{Name: "check-team"}},
+		},
+	}
+}
+
+func Test_buildDesired(t *testing.T) {
+	profile := newProfile()
+	desired, err := buildDesired(profile, profile.Spec.Policies[0], newTemplate(), "team-a")
+	assert.NilError(t, err)
+	assert.Equal(t, desired.GetName(), "require-labels")
+	assert.Equal(t, desired.GetNamespace(), "team-a")
+	assert.DeepEqual(t, desired.GetLabels(), map[string]string{"tier": "platform", kyverno.LabelPolicyProfile: "restricted"})
+	assert.Equal(t, desired.GetAnnotations()["policies.kyverno.io/title"], "Require labels")
+	assert.Equal(t, desired.GetAnnotations()["kubectl.kubernetes.io/last-applied-configuration"], "")
+	assert.Assert(t, desired.GetAnnotations()[kyverno.AnnotationPolicyProfileHash] != "")
+	assert.Equal(t, len(desired.GetOwnerReferences()), 1)
+	assert.Equal(t, desired.GetOwnerReferences()[0].Kind, "PolicyProfile")
+	assert.Equal(t, string(desired.GetOwnerReferences()[0].UID), "1234")
+	assert.Equal(t, desired.Spec.ValidationFailureAction, kyvernov1.Audit)
+}
+
+func Test_buildDesired_ValidationFailureAction(t *testing.T) {
+	profile := newProfile()
+	template := newTemplate()
+	profile.Spec.Policies[0].ValidationFailureAction = kyvernov1.Enforce
+	desired, err := buildDesired(profile, profile.Spec.Policies[0], template, "team-a")
+	assert.NilError(t, err)
+	assert.Equal(t, desired.Spec.ValidationFailureAction, kyvernov1.Enforce)
+	// the template is left untouched
+	assert.Equal(t, template.Spec.ValidationFailureAction, kyvernov1.Audit)
+}
+
+func Test_isUpToDate(t *testing.T) {
+	profile := newProfile()
+	desired, err := buildDesired(profile, profile.Spec.Policies[0], newTemplate(), "team-a")
+	assert.NilError(t, err)
+	existing := desired.DeepCopy()
+	// defaulting the spec of the copy doesn't make it stale
+	existing.Spec.Background = new(bool)
+	assert.Assert(t, isUpToDate(existing, desired))
+	template := newTemplate()
+	template.Spec.Rules[0].Name = "check-app"
+	changed, err := buildDesired(profile, profile.Spec.Policies[0], template, "team-a")
+	assert.NilError(t, err)
+	assert.Assert(t, !isUpToDate(existing, changed))
+	unlabelled := desired.DeepCopy()
+	delete(unlabelled.Labels, kyverno.LabelPolicyProfile)
+	assert.Assert(t, !isUpToDate(unlabelled, desired))
+}
+
+func Test_references(t *testing.T) {
+	profile := newProfile()
+	assert.Assert(t, references(profile, "templates", "require-labels"))
+	assert.Assert(t, !references(profile, "team-a", "require-labels"))
+	assert.Assert(t, !references(profile, "templates", "other"))
+}
@@ -0,0 +1,308 @@
+package policyset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	"go.uber.org/multierr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	maxRetries     = 10
+	Workers        = 2
+	ControllerName = "policyset-controller"
+)
+
+type controller struct {
+	// clients
+	kyvernoClient versioned.Interface
+	rclient       registryclient.Client
+
+	// listers
+	policySetLister kyvernov2alpha1listers.PolicySetLister
+	cpolLister      kyvernov1listers.ClusterPolicyLister
+	polLister       kyvernov1listers.PolicyLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+}
+
+func NewController(
+	kyvernoClient versioned.Interface,
+	rclient registryclient.Client,
+	policySetInformer kyvernov2alpha1informers.PolicySetInformer,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	polInformer kyvernov1informers.PolicyInformer,
+) controllers.Controller {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+	c := &controller{
+		kyvernoClient:   kyvernoClient,
+		rclient:         rclient,
+		policySetLister: policySetInformer.Lister(),
+		cpolLister:      cpolInformer.Lister(),
+		polLister:       polInformer.Lister(),
+		queue:           queue,
+	}
+	enqueue := controllerutils.LogError(logger, controllerutils.Parse(controllerutils.MetaNamespaceKeyT[*kyvernov2alpha1.PolicySet], controllerutils.Queue(queue)))
+	if _, err := controllerutils.AddEventHandlersT(
+		policySetInformer.Informer(),
+		controllerutils.AddFuncT(logger, enqueue),
+		// status updates don't change the generation, reconciling them would loop forever
+		func(old, obj *kyvernov2alpha1.PolicySet) {
+			if old.GetGeneration() != obj.GetGeneration() {
+				if err := enqueue(obj); err != nil {
+					logger.Error(err, "failed to enqueue object", "obj", obj)
+				}
+			}
+		},
+		nil,
+	); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger.V(3), ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, name string) error {
+	set, err := c.policySetLister.Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		logger.Error(err, "unable to get the policy set from policy set informer")
+		return err
+	}
+	// suspended policy sets are synchronized again when their spec changes
+	if set.Spec.Suspend {
+		return nil
+	}
+	entry := kyvernov2alpha1.PolicySetVersionStatus{
+		Source: describeSource(set.Spec.Source),
+		Time:   metav1.Now(),
+	}
+	var policies []kyvernov2alpha1.PolicySetPolicy
+	if invalid := set.Validate(); len(invalid) != 0 {
+		err = invalid.ToAggregate()
+	} else {
+		var b *bundle
+		b, err = pull(ctx, c.rclient, set.Spec.Source)
+		if err == nil {
+			entry.Version = b.version
+			entry.Policies = len(b.policies)
+			policies, err = c.apply(ctx, set, b)
+		}
+	}
+	if err != nil {
+		logger.Error(err, "failed to synchronize policy set", "source", entry.Source, "version", entry.Version)
+		entry.State = kyvernov2alpha1.PolicySetVersionFailed
+		entry.Message = err.Error()
+	} else {
+		entry.State = kyvernov2alpha1.PolicySetVersionApplied
+		entry.Message = fmt.Sprintf("%d policies applied", len(policies))
+	}
+	if err := c.updateStatus(ctx, set, entry, policies); err != nil {
+		logger.Error(err, "failed to update the policy set status")
+		return err
+	}
+	// synchronize the policy set again after the interval to pick up moving tags and branches
+	c.queue.AddAfter(key, set.GetSyncInterval())
+	return nil
+}
+
+// apply creates or updates the policies of the bundle and deletes the policies of the previous version that are
+// not part of the bundle anymore. Nothing is applied when a policy is invalid or conflicts with a policy not
+// managed by the policy set, and the changes already applied are rolled back when a policy fails to be applied
+// or deleted.
+func (c *controller) apply(ctx context.Context, set *kyvernov2alpha1.PolicySet, b *bundle) ([]kyvernov2alpha1.PolicySetPolicy, error) {
+	if err := validateBundle(b); err != nil {
+		return nil, err
+	}
+	for _, policy := range b.policies {
+		existing, err := c.getPolicy(policy)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if existing.GetLabels()[kyverno.LabelPolicySet] != set.GetName() {
+			return nil, fmt.Errorf("%s %s already exists and is not managed by the policy set", policy.GetKind(), existing.GetName())
+		}
+	}
+	owned, err := c.ownedPolicies(set.GetName())
+	if err != nil {
+		return nil, err
+	}
+	var tx transaction
+	policies := make([]kyvernov2alpha1.PolicySetPolicy, 0, len(b.policies))
+	for _, policy := range b.policies {
+		if err := c.applyPolicy(ctx, &tx, buildDesired(set, b.version, policy)); err != nil {
+			return nil, c.rollback(ctx, tx, fmt.Errorf("failed to apply %s %s: %w", policy.GetKind(), policy.GetName(), err))
+		}
+		policies = append(policies, policyRef(policy))
+	}
+	for _, policy := range stalePolicies(owned, b) {
+		if err := c.deletePolicy(ctx, policy); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, c.rollback(ctx, tx, fmt.Errorf("failed to delete %s %s: %w", policy.GetKind(), policy.GetName(), err))
+		}
+		tx.deleted = append(tx.deleted, policy)
+	}
+	return policies, nil
+}
+
+// transaction records the changes made to the policies of a policy set while applying a version
+type transaction struct {
+	// created policies are deleted on rollback
+	created []kyvernov1.PolicyInterface
+	// updated policies are restored on rollback, they hold the previous spec and the resource version of the update
+	updated []kyvernov1.PolicyInterface
+	// deleted policies are created again on rollback
+	deleted []kyvernov1.PolicyInterface
+}
+
+// rollback restores the policies of the previous version and returns the error that caused the rollback
+func (c *controller) rollback(ctx context.Context, tx transaction, cause error) error {
+	var errs []error
+	for _, policy := range tx.deleted {
+		previous := policy.CreateDeepCopy()
+		previous.SetResourceVersion("")
+		previous.SetUID("")
+		if _, err := c.createPolicy(ctx, previous); err != nil && !apierrors.IsAlreadyExists(err) {
+			errs = append(errs, fmt.Errorf("failed to restore %s %s: %w", policy.GetKind(), policy.GetName(), err))
+		}
+	}
+	for _, policy := range tx.updated {
+		if _, err := c.updatePolicy(ctx, policy); err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore %s %s: %w", policy.GetKind(), policy.GetName(), err))
+		}
+	}
+	for _, policy := range tx.created {
+		if err := c.deletePolicy(ctx, policy); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete %s %s: %w", policy.GetKind(), policy.GetName(), err))
+		}
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("%w, rollback failed: %w", cause, multierr.Combine(errs...))
+	}
+	return fmt.Errorf("%w, previous version restored", cause)
+}
+
+func (c *controller) getPolicy(policy kyvernov1.PolicyInterface) (kyvernov1.PolicyInterface, error) {
+	if policy.IsNamespaced() {
+		return c.polLister.Policies(policy.GetNamespace()).Get(policy.GetName())
+	}
+	return c.cpolLister.Get(policy.GetName())
+}
+
+// ownedPolicies returns the policies labelled with the policy set name
+func (c *controller) ownedPolicies(name string) ([]kyvernov1.PolicyInterface, error) {
+	selector := labels.SelectorFromSet(labels.Set{kyverno.LabelPolicySet: name})
+	cpols, err := c.cpolLister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	pols, err := c.polLister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	owned := make([]kyvernov1.PolicyInterface, 0, len(cpols)+len(pols))
+	for _, cpol := range cpols {
+		owned = append(owned, cpol)
+	}
+	for _, pol := range pols {
+		owned = append(owned, pol)
+	}
+	return owned, nil
+}
+
+func (c *controller) applyPolicy(ctx context.Context, tx *transaction, desired kyvernov1.PolicyInterface) error {
+	existing, err := c.getPolicy(desired)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		created, err := c.createPolicy(ctx, desired)
+		if err != nil {
+			return err
+		}
+		tx.created = append(tx.created, created)
+		return nil
+	}
+	desired.SetResourceVersion(existing.GetResourceVersion())
+	updated, err := c.updatePolicy(ctx, desired)
+	if err != nil {
+		return err
+	}
+	previous := existing.CreateDeepCopy()
+	previous.SetResourceVersion(updated.GetResourceVersion())
+	tx.updated = append(tx.updated, previous)
+	return nil
+}
+
+func (c *controller) createPolicy(ctx context.Context, policy kyvernov1.PolicyInterface) (kyvernov1.PolicyInterface, error) {
+	switch policy := policy.(type) {
+	case *kyvernov1.ClusterPolicy:
+		return c.kyvernoClient.KyvernoV1().ClusterPolicies().Create(ctx, policy, metav1.CreateOptions{})
+	case *kyvernov1.Policy:
+		return c.kyvernoClient.KyvernoV1().Policies(policy.GetNamespace()).Create(ctx, policy, metav1.CreateOptions{})
+	default:
+		return nil, fmt.Errorf("unsupported policy type %T", policy)
+	}
+}
+
+func (c *controller) updatePolicy(ctx context.Context, policy kyvernov1.PolicyInterface) (kyvernov1.PolicyInterface, error) {
+	switch policy := policy.(type) {
+	case *kyvernov1.ClusterPolicy:
+		return c.kyvernoClient.KyvernoV1().ClusterPolicies().Update(ctx, policy, metav1.UpdateOptions{})
+	case *kyvernov1.Policy:
+		return c.kyvernoClient.KyvernoV1().Policies(policy.GetNamespace()).Update(ctx, policy, metav1.UpdateOptions{})
+	default:
+		return nil, fmt.Errorf("unsupported policy type %T", policy)
+	}
+}
+
+func (c *controller) deletePolicy(ctx context.Context, policy kyvernov1.PolicyInterface) error {
+	if policy.IsNamespaced() {
+		return c.kyvernoClient.KyvernoV1().Policies(policy.GetNamespace()).Delete(ctx, policy.GetName(), metav1.DeleteOptions{})
+	}
+	return c.kyvernoClient.KyvernoV1().ClusterPolicies().Delete(ctx, policy.GetName(), metav1.DeleteOptions{})
+}
+
+func (c *controller) updateStatus(ctx context.Context, set *kyvernov2alpha1.PolicySet, entry kyvernov2alpha1.PolicySetVersionStatus, policies []kyvernov2alpha1.PolicySetPolicy) error {
+	latest := set.DeepCopy()
+	latest.Status.LastSyncTime = entry.Time
+	latest.Status.RecordVersion(entry)
+	if entry.State == kyvernov2alpha1.PolicySetVersionApplied {
+		latest.Status.Version = entry.Version
+		latest.Status.Policies = policies
+		latest.Status.SetReady(true, fmt.Sprintf("version %s applied", entry.Version))
+	} else {
+		// the version failed to apply as a whole, the policies of the previous version are restored
+		latest.Status.SetReady(false, entry.Message)
+	}
+	_, err := c.kyvernoClient.KyvernoV2alpha1().PolicySets().UpdateStatus(ctx, latest, metav1.UpdateOptions{})
+	return err
+}
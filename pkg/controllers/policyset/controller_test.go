@@ -0,0 +1,78 @@
+package policyset
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"gotest.tools/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+)
+
+func Test_apply_rollback(t *testing.T) {
+	tests := []struct {
+		name     string
+		verb     string
+		resource string
+		wantErr  string
+	}{{
+		name:     "apply fails",
+		verb:     "update",
+		resource: "clusterpolicies",
+		wantErr:  "failed to apply ClusterPolicy require-labels: update failed, previous version restored",
+	}, {
+		name:     "delete fails",
+		verb:     "delete",
+		resource: "clusterpolicies",
+		wantErr:  "failed to delete ClusterPolicy disallow-latest-tag: delete failed, previous version restored",
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			set := &kyvernov2alpha1.PolicySet{ObjectMeta: metav1.ObjectMeta{Name: "baseline", UID: "1234"}}
+			previous := loadBundle(t, "sha256:1")
+			// the previous version manages the cluster policy and a policy removed from the new version
+			applied := buildDesired(set, previous.version, previous.policies[0]).(*kyvernov1.ClusterPolicy)
+			applied.SetResourceVersion("1")
+			stale := buildDesired(set, previous.version, &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "disallow-latest-tag"}}).(*kyvernov1.ClusterPolicy)
+			stale.SetResourceVersion("1")
+			client := fake.NewSimpleClientset(applied, stale)
+			client.PrependReactor(tt.verb, tt.resource, func(action clienttesting.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New(tt.verb + " failed")
+			})
+			cpols := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			assert.NilError(t, cpols.Add(applied))
+			assert.NilError(t, cpols.Add(stale))
+			pols := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			c := &controller{
+				kyvernoClient: client,
+				cpolLister:    kyvernov1listers.NewClusterPolicyLister(cpols),
+				polLister:     kyvernov1listers.NewPolicyLister(pols),
+			}
+
+			// the new policy is created first, then the cluster policy is updated
+			next := loadBundle(t, "sha256:2")
+			next.policies[0], next.policies[1] = next.policies[1], next.policies[0]
+			policies, err := c.apply(context.TODO(), set, next)
+			assert.Error(t, err, tt.wantErr)
+			assert.Assert(t, policies == nil)
+
+			cpol, err := client.KyvernoV1().ClusterPolicies().Get(context.TODO(), "require-labels", metav1.GetOptions{})
+			assert.NilError(t, err)
+			assert.Equal(t, cpol.GetAnnotations()[kyverno.AnnotationPolicySetVersion], "sha256:1")
+			_, err = client.KyvernoV1().ClusterPolicies().Get(context.TODO(), "disallow-latest-tag", metav1.GetOptions{})
+			assert.NilError(t, err)
+			_, err = client.KyvernoV1().Policies("team-a").Get(context.TODO(), "require-labels", metav1.GetOptions{})
+			assert.Assert(t, apierrors.IsNotFound(err))
+		})
+	}
+}
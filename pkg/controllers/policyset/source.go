@@ -0,0 +1,196 @@
+package policyset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/cosign"
+	"github.com/kyverno/kyverno/pkg/images"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	gitutils "github.com/kyverno/kyverno/pkg/utils/git"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+)
+
+const (
+	// policyLayerMediaType is the media type of the image layers containing policies, as pushed by `kyverno oci push`
+	policyLayerMediaType = "application/vnd.cncf.kyverno.policy.layer.v1+yaml"
+	// defaultRekorURL is the transparency log used to verify keyless signatures
+	defaultRekorURL = "https://rekor.sigstore.dev"
+)
+
+// bundle is a verified version of a policy set bundle
+type bundle struct {
+	// version is the image digest or the commit hash
+	version  string
+	policies []kyvernov1.PolicyInterface
+}
+
+// describeSource returns the image reference or the repository revision of a source
+func describeSource(source kyvernov2alpha1.PolicySetSource) string {
+	switch {
+	case source.OCI != nil:
+		return source.OCI.Image
+	case source.Git != nil:
+		return source.Git.URL + "@" + source.Git.Revision
+	default:
+		return ""
+	}
+}
+
+// pull downloads and verifies the bundle of a source
+func pull(ctx context.Context, rclient registryclient.Client, source kyvernov2alpha1.PolicySetSource) (*bundle, error) {
+	switch {
+	case source.OCI != nil:
+		return pullOCI(ctx, rclient, source.OCI)
+	case source.Git != nil:
+		return pullGit(ctx, source.Git)
+	default:
+		return nil, errors.New("no bundle source")
+	}
+}
+
+// pullOCI verifies the cosign signature of the bundle image and reads the policies from the verified digest
+func pullOCI(ctx context.Context, rclient registryclient.Client, source *kyvernov2alpha1.OCIPolicySetSource) (*bundle, error) {
+	ref, err := name.ParseReference(source.Image)
+	if err != nil {
+		return nil, fmt.Errorf("parsing image reference: %w", err)
+	}
+	rekorURL := source.RekorURL
+	if rekorURL == "" {
+		rekorURL = defaultRekorURL
+	}
+	response, err := cosign.NewVerifier().VerifySignature(ctx, images.Options{
+		ImageRef:   ref.String(),
+		Client:     rclient,
+		Key:        source.Key,
+		Subject:    source.Subject,
+		Issuer:     source.Issuer,
+		RekorURL:   rekorURL,
+		IgnoreTlog: source.IgnoreTlog,
+		// certificate transparency only applies to keyless signatures
+		IgnoreSCT: source.Key != "",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("verifying image signature: %w", err)
+	}
+	options, err := rclient.Options(ctx)
+	if err != nil {
+		return nil, err
+	}
+	img, err := remote.Image(ref.Context().Digest(response.Digest), options...)
+	if err != nil {
+		return nil, fmt.Errorf("getting image: %w", err)
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("getting image layers: %w", err)
+	}
+	b := &bundle{version: response.Digest}
+	for _, layer := range layers {
+		mediaType, err := layer.MediaType()
+		if err != nil {
+			return nil, fmt.Errorf("getting layer media type: %w", err)
+		}
+		if mediaType != policyLayerMediaType {
+			continue
+		}
+		blob, err := layer.Compressed()
+		if err != nil {
+			return nil, fmt.Errorf("getting layer blob: %w", err)
+		}
+		data, err := io.ReadAll(blob)
+		blob.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading layer blob: %w", err)
+		}
+		policies, _, err := yamlutils.GetPolicy(data)
+		if err != nil {
+			return nil, fmt.Errorf("decoding layer blob: %w", err)
+		}
+		b.policies = append(b.policies, policies...)
+	}
+	return b, nil
+}
+
+// pullGit clones the revision of the repository, verifies the signature of its commit and reads the policies
+// from the yaml files under the configured path
+func pullGit(ctx context.Context, source *kyvernov2alpha1.GitPolicySetSource) (*bundle, error) {
+	repo, fs, err := cloneRevision(ctx, source.URL, source.Revision)
+	if err != nil {
+		return nil, fmt.Errorf("cloning repository: %w", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return nil, err
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := commit.Verify(source.PublicKeys); err != nil {
+		return nil, fmt.Errorf("verifying commit signature: %w", err)
+	}
+	path := source.Path
+	if path == "" {
+		path = "/"
+	}
+	files, err := gitutils.ListYamls(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("listing policy files: %w", err)
+	}
+	b := &bundle{version: head.Hash().String()}
+	for _, file := range files {
+		data, err := readFile(fs, file)
+		if err != nil {
+			return nil, err
+		}
+		policies, _, err := yamlutils.GetPolicy(data)
+		if err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", file, err)
+		}
+		b.policies = append(b.policies, policies...)
+	}
+	return b, nil
+}
+
+// cloneRevision clones the branch or the tag named revision
+func cloneRevision(ctx context.Context, url, revision string) (*git.Repository, billy.Filesystem, error) {
+	var err error
+	for _, ref := range []plumbing.ReferenceName{plumbing.NewBranchReferenceName(revision), plumbing.NewTagReferenceName(revision)} {
+		fs := memfs.New()
+		var repo *git.Repository
+		repo, err = git.CloneContext(ctx, memory.NewStorage(), fs, &git.CloneOptions{
+			URL:           url,
+			ReferenceName: ref,
+			SingleBranch:  true,
+			Depth:         1,
+		})
+		if err == nil {
+			return repo, fs, nil
+		}
+		if !errors.Is(err, plumbing.ErrReferenceNotFound) && !errors.Is(err, git.NoMatchingRefSpecError{}) {
+			return nil, nil, err
+		}
+	}
+	return nil, nil, err
+}
+
+func readFile(fs billy.Filesystem, path string) ([]byte, error) {
+	file, err := fs.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
@@ -0,0 +1,83 @@
+package policyset
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// validateBundle checks every policy of a bundle is valid, so that a version is applied as a whole or not at all
+func validateBundle(b *bundle) error {
+	if len(b.policies) == 0 {
+		return errors.New("bundle contains no policy")
+	}
+	seen := sets.New[string]()
+	for _, policy := range b.policies {
+		key := policy.GetKind() + " " + policyutils.Key(policy)
+		if seen.Has(key) {
+			return fmt.Errorf("bundle contains %s more than once", key)
+		}
+		seen.Insert(key)
+		if errs := policy.Validate(nil); len(errs) != 0 {
+			return fmt.Errorf("%s is invalid: %w", key, errs.ToAggregate())
+		}
+	}
+	return nil
+}
+
+// buildDesired returns a copy of a bundle policy labelled and owned by the policy set, deleting the policy set
+// garbage collects its policies
+func buildDesired(set *kyvernov2alpha1.PolicySet, version string, policy kyvernov1.PolicyInterface) kyvernov1.PolicyInterface {
+	desired := policy.CreateDeepCopy()
+	desired.SetResourceVersion("")
+	desired.SetUID("")
+	labels := desired.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[kyverno.LabelPolicySet] = set.GetName()
+	desired.SetLabels(labels)
+	annotations := desired.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[kyverno.AnnotationPolicySetVersion] = version
+	desired.SetAnnotations(annotations)
+	desired.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion: kyvernov2alpha1.SchemeGroupVersion.String(),
+		Kind:       "PolicySet",
+		Name:       set.GetName(),
+		UID:        set.GetUID(),
+	}})
+	return desired
+}
+
+// stalePolicies returns the policies owned by the policy set that are not part of the bundle anymore
+func stalePolicies(owned []kyvernov1.PolicyInterface, b *bundle) []kyvernov1.PolicyInterface {
+	current := sets.New[string]()
+	for _, policy := range b.policies {
+		current.Insert(policy.GetKind() + " " + policyutils.Key(policy))
+	}
+	var stale []kyvernov1.PolicyInterface
+	for _, policy := range owned {
+		if !current.Has(policy.GetKind() + " " + policyutils.Key(policy)) {
+			stale = append(stale, policy)
+		}
+	}
+	return stale
+}
+
+// policyRef returns the status reference of a policy
+func policyRef(policy kyvernov1.PolicyInterface) kyvernov2alpha1.PolicySetPolicy {
+	return kyvernov2alpha1.PolicySetPolicy{
+		Kind:      policy.GetKind(),
+		Namespace: policy.GetNamespace(),
+		Name:      policy.GetName(),
+	}
+}
@@ -0,0 +1,99 @@
+package policyset
+
+import (
+	"testing"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const bundlePolicies = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+spec:
+  rules:
+  - name: check-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: label team is required
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+---
+apiVersion: kyverno.io/v1
+kind: Policy
+metadata:
+  name: require-labels
+  namespace: team-a
+  labels:
+    tier: platform
+spec:
+  rules:
+  - name: check-app
+    match:
+      any:
+      - resources:
+          kinds:
+          - Pod
+    validate:
+      message: label app is required
+      pattern:
+        metadata:
+          labels:
+            app: "?*"
+`
+
+func loadBundle(t *testing.T, version string) *bundle {
+	policies, _, err := yamlutils.GetPolicy([]byte(bundlePolicies))
+	assert.NilError(t, err)
+	return &bundle{version: version, policies: policies}
+}
+
+func Test_validateBundle(t *testing.T) {
+	b := loadBundle(t, "sha256:1")
+	assert.NilError(t, validateBundle(b))
+	assert.Error(t, validateBundle(&bundle{version: "sha256:2"}), "bundle contains no policy")
+	duplicated := &bundle{version: "sha256:3", policies: append(b.policies, b.policies[0])}
+	assert.Error(t, validateBundle(duplicated), "bundle contains ClusterPolicy require-labels more than once")
+	invalid := b.policies[0].CreateDeepCopy()
+	invalid.GetSpec().Rules = append(invalid.GetSpec().Rules, invalid.GetSpec().Rules[0])
+	assert.ErrorContains(t, validateBundle(&bundle{version: "sha256:4", policies: []kyvernov1.PolicyInterface{invalid}}), "ClusterPolicy require-labels is invalid")
+}
+
+func Test_buildDesired(t *testing.T) {
+	set := &kyvernov2alpha1.PolicySet{ObjectMeta: metav1.ObjectMeta{Name: "baseline", UID: "1234"}}
+	b := loadBundle(t, "sha256:1")
+	desired := buildDesired(set, b.version, b.policies[1])
+	assert.DeepEqual(t, desired.GetLabels(), map[string]string{"tier": "platform", kyverno.LabelPolicySet: "baseline"})
+	assert.DeepEqual(t, desired.GetAnnotations(), map[string]string{kyverno.AnnotationPolicySetVersion: "sha256:1"})
+	assert.DeepEqual(t, desired.GetOwnerReferences(), []metav1.OwnerReference{{
+		APIVersion: "kyverno.io/v2alpha1",
+		Kind:       "PolicySet",
+		Name:       "baseline",
+		UID:        "1234",
+	}})
+	// the bundle policy is left untouched
+	assert.Equal(t, len(b.policies[1].GetLabels()), 1)
+}
+
+func Test_stalePolicies(t *testing.T) {
+	b := loadBundle(t, "sha256:1")
+	removed := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "disallow-latest-tag"}}
+	moved := &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Name: "require-labels", Namespace: "team-b"}}
+	owned := []kyvernov1.PolicyInterface{b.policies[0], removed, b.policies[1], moved}
+	stale := stalePolicies(owned, b)
+	assert.Equal(t, len(stale), 2)
+	assert.Equal(t, stale[0].GetName(), "disallow-latest-tag")
+	assert.Equal(t, stale[1].GetNamespace(), "team-b")
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/controllers"
 	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
+	"github.com/kyverno/kyverno/pkg/tracing"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	"go.uber.org/multierr"
@@ -31,7 +32,8 @@ const (
 	Workers        = 10
 	ControllerName = "admission-report-controller"
 	maxRetries     = 10
-	deletionGrace  = time.Minute * 2
+	// DefaultTTL is the default time an intermediate report is kept waiting for aggregation
+	DefaultTTL = time.Minute * 2
 )
 
 type controller struct {
@@ -45,22 +47,33 @@ type controller struct {
 
 	// queue
 	queue workqueue.RateLimitingInterface
+
+	// metrics
+	metrics pruneMetrics
+
+	pruneOptions PruneOptions
 }
 
 func NewController(
 	client versioned.Interface,
 	dclient dclient.Interface,
 	metadataFactory metadatainformers.SharedInformerFactory,
+	pruneOptions PruneOptions,
 ) controllers.Controller {
 	admrInformer := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("admissionreports"))
 	cadmrInformer := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("clusteradmissionreports"))
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+	if pruneOptions.TTL <= 0 {
+		pruneOptions.TTL = DefaultTTL
+	}
 	c := controller{
-		client:      client,
-		dclient:     dclient,
-		admrLister:  admrInformer.Lister(),
-		cadmrLister: cadmrInformer.Lister(),
-		queue:       queue,
+		client:       client,
+		dclient:      dclient,
+		admrLister:   admrInformer.Lister(),
+		cadmrLister:  cadmrInformer.Lister(),
+		queue:        queue,
+		metrics:      newPruneMetrics(logger),
+		pruneOptions: pruneOptions,
 	}
 	if _, err := controllerutils.AddEventHandlersT(
 		admrInformer.Informer(),
@@ -82,7 +95,7 @@ func NewController(
 }
 
 func (c *controller) Run(ctx context.Context, workers int) {
-	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, controllerutils.WithTracing(ControllerName, c.reconcile), c.pruneRoutine)
 }
 
 func (c *controller) getReports(uid types.UID) ([]metav1.Object, error) {
@@ -193,6 +206,9 @@ func (c *controller) aggregateReports(ctx context.Context, uid types.UID) (kyver
 	if len(reports) == 0 {
 		return nil, nil, nil
 	}
+	// link the aggregation to the admission requests the reports were created from
+	ctx, span := tracing.StartChildSpan(ctx, "", "AGGREGATE admission reports", tracing.WithObjectLinks(reports...))
+	defer span.End()
 	// do we have an aggregated report ?
 	var aggregated kyvernov1alpha2.ReportInterface
 	for _, report := range reports {
@@ -316,7 +332,7 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _,
 		}
 		// delete outdated reports
 		for _, report := range reports {
-			if report.GetCreationTimestamp().Add(deletionGrace).Before(time.Now()) {
+			if report.GetCreationTimestamp().Add(c.pruneOptions.TTL).Before(time.Now()) {
 				if err := c.deleteReport(ctx, report.GetNamespace(), report.GetName()); err != nil {
 					errs = append(errs, err)
 				}
@@ -0,0 +1,42 @@
+package admission
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type pruneMetrics struct {
+	prunedReportsTotal metric.Int64Counter
+}
+
+func newPruneMetrics(logger logr.Logger) pruneMetrics {
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	prunedReportsTotal, err := meter.Int64Counter(
+		"kyverno_admission_reports_pruned",
+		metric.WithDescription("can be used to track the number of admission reports deleted before they were aggregated."),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_admission_reports_pruned")
+	}
+	return pruneMetrics{
+		prunedReportsTotal: prunedReportsTotal,
+	}
+}
+
+func (m pruneMetrics) recordPruned(ctx context.Context, namespace, reason string) {
+	if m.prunedReportsTotal != nil {
+		m.prunedReportsTotal.Add(
+			ctx,
+			1,
+			metric.WithAttributes(
+				attribute.String("resource_namespace", namespace),
+				attribute.String("reason", reason),
+			),
+		)
+	}
+}
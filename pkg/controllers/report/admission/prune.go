@@ -0,0 +1,118 @@
+package admission
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"go.uber.org/multierr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	pruneInterval = 30 * time.Second
+
+	pruneReasonTTL       = "ttl"
+	pruneReasonNamespace = "max_per_namespace"
+)
+
+// PruneOptions control the retention of intermediate admission reports.
+type PruneOptions struct {
+	// TTL is the time an intermediate report is kept waiting for aggregation.
+	TTL time.Duration
+	// MaxPerNamespace is the max number of intermediate reports kept in a namespace, zero means unlimited.
+	MaxPerNamespace int
+	// BacklogThreshold is the queue length above which reports older than TTL are deleted
+	// without waiting for aggregation, zero disables aggressive pruning.
+	BacklogThreshold int
+}
+
+type prunedReport struct {
+	report metav1.Object
+	reason string
+}
+
+// selectReportsToPrune returns the intermediate reports that should be deleted.
+// Aggregated reports are never pruned.
+func selectReportsToPrune(reports []metav1.Object, now time.Time, options PruneOptions, aggressive bool) []prunedReport {
+	var results []prunedReport
+	byNamespace := map[string][]metav1.Object{}
+	for _, report := range reports {
+		if _, ok := report.GetLabels()[reportutils.LabelAggregatedReport]; ok {
+			continue
+		}
+		if aggressive && report.GetCreationTimestamp().Add(options.TTL).Before(now) {
+			results = append(results, prunedReport{report: report, reason: pruneReasonTTL})
+			continue
+		}
+		byNamespace[report.GetNamespace()] = append(byNamespace[report.GetNamespace()], report)
+	}
+	if options.MaxPerNamespace <= 0 {
+		return results
+	}
+	for _, reports := range byNamespace {
+		if len(reports) <= options.MaxPerNamespace {
+			continue
+		}
+		sort.SliceStable(reports, func(i, j int) bool {
+			return reports[i].GetCreationTimestamp().Time.Before(reports[j].GetCreationTimestamp().Time)
+		})
+		for _, report := range reports[:len(reports)-options.MaxPerNamespace] {
+			results = append(results, prunedReport{report: report, reason: pruneReasonNamespace})
+		}
+	}
+	return results
+}
+
+func (c *controller) listReports() ([]metav1.Object, error) {
+	var results []metav1.Object
+	admrs, err := c.admrLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, admr := range admrs {
+		results = append(results, admr.(metav1.Object))
+	}
+	cadmrs, err := c.cadmrLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, cadmr := range cadmrs {
+		results = append(results, cadmr.(metav1.Object))
+	}
+	return results, nil
+}
+
+func (c *controller) prune(ctx context.Context, logger logr.Logger) error {
+	reports, err := c.listReports()
+	if err != nil {
+		return err
+	}
+	// the aggregation loop is falling behind, don't wait for it to delete outdated reports
+	aggressive := c.pruneOptions.BacklogThreshold > 0 && c.queue.Len() > c.pruneOptions.BacklogThreshold
+	pruned := selectReportsToPrune(reports, time.Now(), c.pruneOptions, aggressive)
+	if len(pruned) != 0 {
+		logger.V(2).Info("pruning admission reports", "count", len(pruned), "aggressive", aggressive)
+	}
+	var errs []error
+	for _, p := range pruned {
+		if err := c.deleteReport(ctx, p.report.GetNamespace(), p.report.GetName()); err != nil {
+			errs = append(errs, err)
+		} else {
+			c.metrics.recordPruned(ctx, p.report.GetNamespace(), p.reason)
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
+func (c *controller) pruneRoutine(ctx context.Context, logger logr.Logger) {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := c.prune(ctx, logger); err != nil {
+			logger.Error(err, "failed to prune admission reports")
+		}
+	}, pruneInterval)
+}
@@ -0,0 +1,68 @@
+package admission
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_selectReportsToPrune(t *testing.T) {
+	now := time.Now()
+	report := func(namespace, name string, age time.Duration, aggregated bool) metav1.Object {
+		obj := &metav1.PartialObjectMetadata{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         namespace,
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+		}
+		if aggregated {
+			obj.SetLabels(map[string]string{reportutils.LabelAggregatedReport: name})
+		}
+		return obj
+	}
+	reports := []metav1.Object{
+		report("foo", "new", time.Second, false),
+		report("foo", "old", time.Hour, false),
+		report("foo", "older", 2*time.Hour, false),
+		report("foo", "aggregated", 3*time.Hour, true),
+		report("bar", "old", time.Hour, false),
+	}
+	tests := []struct {
+		name       string
+		options    PruneOptions
+		aggressive bool
+		want       []string
+	}{{
+		name:    "unlimited",
+		options: PruneOptions{TTL: time.Minute},
+	}, {
+		name:    "max per namespace",
+		options: PruneOptions{TTL: time.Minute, MaxPerNamespace: 1},
+		want:    []string{"foo/older:max_per_namespace", "foo/old:max_per_namespace"},
+	}, {
+		name:       "aggressive",
+		options:    PruneOptions{TTL: time.Minute},
+		aggressive: true,
+		want:       []string{"foo/old:ttl", "foo/older:ttl", "bar/old:ttl"},
+	}, {
+		name:       "aggressive and max per namespace",
+		options:    PruneOptions{TTL: 90 * time.Minute, MaxPerNamespace: 1},
+		aggressive: true,
+		want:       []string{"foo/older:ttl", "foo/old:max_per_namespace"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, pruned := range selectReportsToPrune(reports, now, tt.options, tt.aggressive) {
+				got = append(got, pruned.report.GetNamespace()+"/"+pruned.report.GetName()+":"+pruned.reason)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("selectReportsToPrune() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
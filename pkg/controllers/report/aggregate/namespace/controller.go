@@ -2,7 +2,7 @@ package namespace
 
 import (
 	"context"
-	"fmt"
+	"sort"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -13,6 +13,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers"
 	"github.com/kyverno/kyverno/pkg/controllers/report/resource"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
@@ -58,7 +59,14 @@ type controller struct {
 	// cache
 	metadataCache resource.MetadataCache
 
-	chunkSize int
+	// config
+	configuration config.Configuration
+
+	// metrics
+	metrics shardMetrics
+
+	chunkSize  int
+	sizeBudget int
 }
 
 type policyMapEntry struct {
@@ -76,7 +84,9 @@ func NewController(
 	polInformer kyvernov1informers.PolicyInformer,
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
 	metadataCache resource.MetadataCache,
+	configuration config.Configuration,
 	chunkSize int,
+	sizeBudget int,
 ) controllers.Controller {
 	admrInformer := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("admissionreports"))
 	cadmrInformer := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("clusteradmissionreports"))
@@ -94,7 +104,10 @@ func NewController(
 		cbgscanrLister: cbgscanrInformer.Lister(),
 		queue:          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
 		metadataCache:  metadataCache,
+		configuration:  configuration,
+		metrics:        newShardMetrics(logger),
 		chunkSize:      chunkSize,
+		sizeBudget:     sizeBudget,
 	}
 	if _, _, err := controllerutils.AddDelayedExplicitEventHandlers(logger, polrInformer.Informer(), c.queue, enqueueDelay, keyFunc); err != nil {
 		logger.Error(err, "failed to register event handlers")
@@ -134,7 +147,7 @@ func NewController(
 }
 
 func (c *controller) Run(ctx context.Context, workers int) {
-	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, controllerutils.WithTracing(ControllerName, c.reconcile))
 }
 
 func (c *controller) mergeAdmissionReports(ctx context.Context, namespace string, policyMap map[string]policyMapEntry, accumulator map[string]policyreportv1alpha2.PolicyReportResult) error {
@@ -221,15 +234,33 @@ func (c *controller) mergeBackgroundScanReports(ctx context.Context, namespace s
 	}
 }
 
-func (c *controller) reconcileReport(ctx context.Context, policyMap map[string]policyMapEntry, report kyvernov1alpha2.ReportInterface, namespace, name string, results ...policyreportv1alpha2.PolicyReportResult) (kyvernov1alpha2.ReportInterface, error) {
+// reconcileReport creates or updates a report holding the results, shardOf is the name of the summary report when the report is a shard.
+func (c *controller) reconcileReport(ctx context.Context, policyMap map[string]policyMapEntry, report kyvernov1alpha2.ReportInterface, namespace, name, shardOf string, results ...policyreportv1alpha2.PolicyReportResult) (kyvernov1alpha2.ReportInterface, error) {
+	return c.applyReport(ctx, policyMap, report, namespace, name, results, func(report kyvernov1alpha2.ReportInterface) {
+		if shardOf != "" {
+			reportutils.SetShardOf(report, shardOf)
+		}
+		reportutils.SetResults(report, results...)
+	})
+}
+
+// reconcileSummary creates or updates the summary report of a sharded report, it holds the summary of all the shards but no results.
+func (c *controller) reconcileSummary(ctx context.Context, policyMap map[string]policyMapEntry, report kyvernov1alpha2.ReportInterface, namespace, name string, shards int, results []policyreportv1alpha2.PolicyReportResult) (kyvernov1alpha2.ReportInterface, error) {
+	return c.applyReport(ctx, policyMap, report, namespace, name, results, func(report kyvernov1alpha2.ReportInterface) {
+		reportutils.SetSummaryOf(report, shards, results)
+	})
+}
+
+func (c *controller) applyReport(ctx context.Context, policyMap map[string]policyMapEntry, report kyvernov1alpha2.ReportInterface, namespace, name string, results []policyreportv1alpha2.PolicyReportResult, set func(kyvernov1alpha2.ReportInterface)) (kyvernov1alpha2.ReportInterface, error) {
 	if report == nil {
-		report = reportutils.NewPolicyReport(namespace, name, nil, results...)
+		report = reportutils.NewPolicyReport(namespace, name, nil)
 		for _, result := range results {
 			policy := policyMap[result.Policy]
 			if policy.policy != nil {
 				reportutils.SetPolicyLabel(report, engineapi.NewKyvernoPolicy(policy.policy))
 			}
 		}
+		set(report)
 		return reportutils.CreateReport(ctx, report, c.client)
 	}
 	after := reportutils.DeepCopy(report)
@@ -242,7 +273,9 @@ func (c *controller) reconcileReport(ctx context.Context, policyMap map[string]p
 			reportutils.SetPolicyLabel(after, engineapi.NewKyvernoPolicy(policy.policy))
 		}
 	}
-	reportutils.SetResults(after, results...)
+	// the number of shards changes with the results
+	delete(after.GetAnnotations(), reportutils.AnnotationReportShards)
+	set(after)
 	if datautils.DeepEqual(report, after) {
 		return after, nil
 	}
@@ -294,6 +327,7 @@ func mergeReports(policyMap map[string]policyMapEntry, accumulator map[string]po
 
 func (c *controller) createPolicyMap() (map[string]policyMapEntry, error) {
 	results := map[string]policyMapEntry{}
+	autogenControllers := c.configuration.GetAutogenControllers()
 	cpols, err := c.cpolLister.List(labels.Everything())
 	if err != nil {
 		return nil, err
@@ -307,7 +341,7 @@ func (c *controller) createPolicyMap() (map[string]policyMapEntry, error) {
 			policy: cpol,
 			rules:  sets.New[string](),
 		}
-		for _, rule := range autogen.ComputeRules(cpol) {
+		for _, rule := range autogen.ComputeRules(cpol, autogenControllers) {
 			results[key].rules.Insert(rule.Name)
 		}
 	}
@@ -324,7 +358,7 @@ func (c *controller) createPolicyMap() (map[string]policyMapEntry, error) {
 			policy: pol,
 			rules:  sets.New[string](),
 		}
-		for _, rule := range autogen.ComputeRules(pol) {
+		for _, rule := range autogen.ComputeRules(pol, autogenControllers) {
 			results[key].rules.Insert(rule.Name)
 		}
 	}
@@ -358,7 +392,7 @@ func (c *controller) getPolicyReports(ctx context.Context, namespace string) ([]
 			return nil, err
 		}
 		for i := range list.Items {
-			if controllerutils.IsManagedByKyverno(&list.Items[i]) {
+			if controllerutils.IsManagedByKyverno(&list.Items[i]) && !controllerutils.HasLabel(&list.Items[i], reportutils.LabelCanaryReport) {
 				reports = append(reports, &list.Items[i])
 			}
 		}
@@ -368,7 +402,7 @@ func (c *controller) getPolicyReports(ctx context.Context, namespace string) ([]
 			return nil, err
 		}
 		for i := range list.Items {
-			if controllerutils.IsManagedByKyverno(&list.Items[i]) {
+			if controllerutils.IsManagedByKyverno(&list.Items[i]) && !controllerutils.HasLabel(&list.Items[i], reportutils.LabelCanaryReport) {
 				reports = append(reports, &list.Items[i])
 			}
 		}
@@ -390,27 +424,37 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _,
 		actual[report.GetName()] = report
 	}
 	splitReports := reportutils.SplitResultsByPolicy(logger, results)
-	var expected []kyvernov1alpha2.ReportInterface
-	chunkSize := c.chunkSize
-	if chunkSize <= 0 {
-		chunkSize = len(results)
+	names := make([]string, 0, len(splitReports))
+	for name := range splitReports {
+		names = append(names, name)
 	}
-	for name, results := range splitReports {
-		for i := 0; i < len(results); i += chunkSize {
-			end := i + chunkSize
-			if end > len(results) {
-				end = len(results)
-			}
-			name := name
-			if i > 0 {
-				name = fmt.Sprintf("%s-%d", name, i/chunkSize)
+	sort.Strings(names)
+	var expected []kyvernov1alpha2.ReportInterface
+	for _, name := range names {
+		results := splitReports[name]
+		shards := reportutils.ShardResults(results, c.chunkSize, c.sizeBudget)
+		if len(shards) == 1 {
+			report, err := c.reconcileReport(ctx, policyMap, actual[name], key, name, "", shards[0]...)
+			if err != nil {
+				return err
 			}
-			report, err := c.reconcileReport(ctx, policyMap, actual[name], key, name, results[i:end]...)
+			expected = append(expected, report)
+			continue
+		}
+		logger.V(2).Info("sharding policy report", "name", name, "results", len(results), "shards", len(shards))
+		c.metrics.recordShards(ctx, key, len(shards))
+		for i, shard := range shards {
+			report, err := c.reconcileReport(ctx, policyMap, actual[reportutils.ShardName(name, i)], key, reportutils.ShardName(name, i), name, shard...)
 			if err != nil {
 				return err
 			}
 			expected = append(expected, report)
 		}
+		report, err := c.reconcileSummary(ctx, policyMap, actual[name], key, name, len(shards), results)
+		if err != nil {
+			return err
+		}
+		expected = append(expected, report)
 	}
 	return c.cleanReports(ctx, actual, expected)
 }
@@ -0,0 +1,48 @@
+package namespace
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type shardMetrics struct {
+	shardedReportsTotal metric.Int64Counter
+	reportShardsTotal   metric.Int64Counter
+}
+
+func newShardMetrics(logger logr.Logger) shardMetrics {
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	shardedReportsTotal, err := meter.Int64Counter(
+		"kyverno_policy_report_sharded",
+		metric.WithDescription("can be used to track the number of times a policy report exceeded its size budget and was sharded."),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_policy_report_sharded")
+	}
+	reportShardsTotal, err := meter.Int64Counter(
+		"kyverno_policy_report_shards",
+		metric.WithDescription("can be used to track the number of shards produced when sharding policy reports."),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_policy_report_shards")
+	}
+	return shardMetrics{
+		shardedReportsTotal: shardedReportsTotal,
+		reportShardsTotal:   reportShardsTotal,
+	}
+}
+
+func (m shardMetrics) recordShards(ctx context.Context, namespace string, shards int) {
+	attributes := metric.WithAttributes(attribute.String("resource_namespace", namespace))
+	if m.shardedReportsTotal != nil {
+		m.shardedReportsTotal.Add(ctx, 1, attributes)
+	}
+	if m.reportShardsTotal != nil {
+		m.reportShardsTotal.Add(ctx, int64(shards), attributes)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers"
 	"github.com/kyverno/kyverno/pkg/controllers/report/resource"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
@@ -20,6 +21,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	admissionregistrationv1alpha1informers "k8s.io/client-go/informers/admissionregistration/v1alpha1"
@@ -52,7 +54,15 @@ type controller struct {
 	// cache
 	metadataCache resource.MetadataCache
 
+	// config
+	configuration config.Configuration
+
 	chunkSize int
+
+	// aggregateByOwner rolls pod results up to the report of their controller owner
+	aggregateByOwner bool
+	// keepPodDetails keeps one result per pod instead of one per rule in the owner report
+	keepPodDetails bool
 }
 
 type policyMapEntry struct {
@@ -67,7 +77,10 @@ func NewController(
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
 	vapInformer admissionregistrationv1alpha1informers.ValidatingAdmissionPolicyInformer,
 	metadataCache resource.MetadataCache,
+	configuration config.Configuration,
 	chunkSize int,
+	aggregateByOwner bool,
+	keepPodDetails bool,
 ) controllers.Controller {
 	admrInformer := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("admissionreports"))
 	cadmrInformer := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("clusteradmissionreports"))
@@ -76,22 +89,31 @@ func NewController(
 	polrInformer := metadataFactory.ForResource(policyreportv1alpha2.SchemeGroupVersion.WithResource("policyreports"))
 	cpolrInformer := metadataFactory.ForResource(policyreportv1alpha2.SchemeGroupVersion.WithResource("clusterpolicyreports"))
 	c := controller{
-		client:        client,
-		polLister:     polInformer.Lister(),
-		cpolLister:    cpolInformer.Lister(),
-		queue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
-		metadataCache: metadataCache,
-		chunkSize:     chunkSize,
+		client:           client,
+		polLister:        polInformer.Lister(),
+		cpolLister:       cpolInformer.Lister(),
+		queue:            workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		metadataCache:    metadataCache,
+		configuration:    configuration,
+		chunkSize:        chunkSize,
+		aggregateByOwner: aggregateByOwner,
+		keepPodDetails:   keepPodDetails,
 	}
 	enqueueAll := func() {
 		if list, err := polrInformer.Lister().List(labels.Everything()); err == nil {
 			for _, item := range list {
-				c.queue.AddAfter(controllerutils.MetaObjectToName(item.(*metav1.PartialObjectMetadata)), enqueueDelay)
+				// canary reports are not tied to a resource
+				if obj := item.(*metav1.PartialObjectMetadata); !controllerutils.HasLabel(obj, reportutils.LabelCanaryReport) {
+					c.queue.AddAfter(controllerutils.MetaObjectToName(obj), enqueueDelay)
+				}
 			}
 		}
 		if list, err := cpolrInformer.Lister().List(labels.Everything()); err == nil {
 			for _, item := range list {
-				c.queue.AddAfter(controllerutils.MetaObjectToName(item.(*metav1.PartialObjectMetadata)), enqueueDelay)
+				// canary reports are not tied to a resource
+				if obj := item.(*metav1.PartialObjectMetadata); !controllerutils.HasLabel(obj, reportutils.LabelCanaryReport) {
+					c.queue.AddAfter(controllerutils.MetaObjectToName(obj), enqueueDelay)
+				}
 			}
 		}
 	}
@@ -111,6 +133,8 @@ func NewController(
 	); err != nil {
 		logger.Error(err, "failed to register event handlers")
 	}
+	// the rules of the policies depend on the autogen controllers of the configuration
+	configuration.OnChanged(enqueueAll)
 	if vapInformer != nil {
 		c.vapLister = vapInformer.Lister()
 		if _, err := controllerutils.AddEventHandlersT(
@@ -154,11 +178,12 @@ func NewController(
 }
 
 func (c *controller) Run(ctx context.Context, workers int) {
-	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, controllerutils.WithTracing(ControllerName, c.reconcile))
 }
 
 func (c *controller) createPolicyMap() (map[string]policyMapEntry, error) {
 	results := map[string]policyMapEntry{}
+	autogenControllers := c.configuration.GetAutogenControllers()
 	cpols, err := c.cpolLister.List(labels.Everything())
 	if err != nil {
 		return nil, err
@@ -172,7 +197,7 @@ func (c *controller) createPolicyMap() (map[string]policyMapEntry, error) {
 			policy: cpol,
 			rules:  sets.New[string](),
 		}
-		for _, rule := range autogen.ComputeRules(cpol) {
+		for _, rule := range autogen.ComputeRules(cpol, autogenControllers) {
 			results[key].rules.Insert(rule.Name)
 		}
 	}
@@ -189,7 +214,7 @@ func (c *controller) createPolicyMap() (map[string]policyMapEntry, error) {
 			policy: pol,
 			rules:  sets.New[string](),
 		}
-		for _, rule := range autogen.ComputeRules(pol) {
+		for _, rule := range autogen.ComputeRules(pol, autogenControllers) {
 			results[key].rules.Insert(rule.Name)
 		}
 	}
@@ -295,6 +320,11 @@ func (c *controller) getReports(ctx context.Context, namespace, name string) (ky
 func (c *controller) reconcile(ctx context.Context, logger logr.Logger, _, namespace, name string) error {
 	uid := types.UID(name)
 	resource, gvk, exists := c.metadataCache.GetResourceHash(uid)
+	if exists && c.aggregateByOwner && gvk.Group == "" && gvk.Kind == "Pod" {
+		if ownerUID, owner, ownerGVK, ok := c.getAggregationOwner(resource); ok {
+			return c.reconcileOwned(ctx, namespace, uid, resource, gvk, ownerUID, owner, ownerGVK)
+		}
+	}
 	if exists {
 		admissionReport, backgroundReport, err := c.getReports(ctx, namespace, name)
 		if err != nil {
@@ -327,28 +357,9 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, _, names
 			return err
 		}
 		merged := map[string]policyreportv1alpha2.PolicyReportResult{}
-		mergeReports(policyMap, vapMap, merged, uid, policyReport, admissionReport, backgroundReport)
-		var results []policyreportv1alpha2.PolicyReportResult
-		for _, result := range merged {
-			results = append(results, result)
-		}
-		if len(results) == 0 {
-			if !create {
-				if err := deleteReport(ctx, policyReport, c.client); err != nil {
-					return err
-				}
-			}
-		} else {
-			reportutils.SetResults(policyReport, results...)
-			if create {
-				if _, err := reportutils.CreateReport(ctx, policyReport, c.client); err != nil {
-					return err
-				}
-			} else {
-				if _, err := updateReport(ctx, policyReport, c.client); err != nil {
-					return err
-				}
-			}
+		mergeReports(policyMap, vapMap, merged, uid, c.keepPodDetails, c.pruneOwnedResults(policyReport), admissionReport, backgroundReport)
+		if err := c.storePolicyReport(ctx, policyReport, create, merged); err != nil {
+			return err
 		}
 		if admissionReport != nil {
 			if err := deleteReport(ctx, admissionReport, c.client); err != nil {
@@ -373,3 +384,145 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, _, names
 	}
 	return nil
 }
+
+// getAggregationOwner walks up the controller owners of the resource known to the metadata cache
+// and returns the top most one, i.e. the Deployment rather than the ReplicaSet owning a pod.
+func (c *controller) getAggregationOwner(res resource.Resource) (types.UID, resource.Resource, schema.GroupVersionKind, bool) {
+	var ownerUID types.UID
+	var owner resource.Resource
+	var ownerGVK schema.GroupVersionKind
+	found := false
+	for ref := res.Owner; ref != nil; ref = owner.Owner {
+		parent, gvk, exists := c.metadataCache.GetResourceHash(ref.UID)
+		if !exists {
+			break
+		}
+		ownerUID, owner, ownerGVK, found = ref.UID, parent, gvk, true
+	}
+	return ownerUID, owner, ownerGVK, found
+}
+
+// reconcileOwned folds the results of an owned pod into the policy report of its owner,
+// the pod doesn't get a policy report of its own.
+func (c *controller) reconcileOwned(
+	ctx context.Context,
+	namespace string,
+	uid types.UID,
+	res resource.Resource,
+	gvk schema.GroupVersionKind,
+	ownerUID types.UID,
+	owner resource.Resource,
+	ownerGVK schema.GroupVersionKind,
+) error {
+	admissionReport, backgroundReport, err := c.getReports(ctx, namespace, string(uid))
+	if err != nil {
+		return err
+	}
+	// the pod may have been reported before aggregation was enabled
+	policyReport, err := c.getPolicyReport(ctx, namespace, string(uid))
+	if err != nil {
+		return err
+	}
+	if policyReport != nil {
+		if err := deleteReport(ctx, policyReport, c.client); err != nil {
+			return err
+		}
+	}
+	if admissionReport == nil && backgroundReport == nil {
+		return nil
+	}
+	ownerReport, err := c.getPolicyReport(ctx, namespace, string(ownerUID))
+	if err != nil {
+		return err
+	}
+	create := false
+	if ownerReport == nil {
+		create = true
+		scope := &corev1.ObjectReference{
+			Kind:       ownerGVK.Kind,
+			Namespace:  namespace,
+			Name:       owner.Name,
+			UID:        ownerUID,
+			APIVersion: ownerGVK.GroupVersion().String(),
+		}
+		ownerReport = reportutils.NewPolicyReport(namespace, string(ownerUID), scope)
+		controllerutils.SetOwner(ownerReport, ownerGVK.GroupVersion().String(), ownerGVK.Kind, owner.Name, ownerUID)
+	}
+	policyMap, err := c.createPolicyMap()
+	if err != nil {
+		return err
+	}
+	vapMap, err := c.createVapMap()
+	if err != nil {
+		return err
+	}
+	child := corev1.ObjectReference{
+		Kind:       gvk.Kind,
+		Namespace:  namespace,
+		Name:       res.Name,
+		UID:        uid,
+		APIVersion: gvk.GroupVersion().String(),
+	}
+	merged := map[string]policyreportv1alpha2.PolicyReportResult{}
+	mergeReports(policyMap, vapMap, merged, ownerUID, c.keepPodDetails, c.pruneOwnedResults(ownerReport))
+	mergeReports(policyMap, vapMap, merged, ownerUID, c.keepPodDetails, setOwnedResource(child, admissionReport, backgroundReport)...)
+	if err := c.storePolicyReport(ctx, ownerReport, create, merged); err != nil {
+		return err
+	}
+	if admissionReport != nil {
+		if err := deleteReport(ctx, admissionReport, c.client); err != nil {
+			return err
+		}
+	}
+	if backgroundReport != nil {
+		if err := deleteReport(ctx, backgroundReport, c.client); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneOwnedResults drops the per pod results of pods that don't exist anymore.
+func (c *controller) pruneOwnedResults(report kyvernov1alpha2.ReportInterface) kyvernov1alpha2.ReportInterface {
+	if report == nil || !c.keepPodDetails {
+		return report
+	}
+	var results []policyreportv1alpha2.PolicyReportResult
+	for _, result := range report.GetResults() {
+		if ref := ownedResource(result); ref != nil {
+			if _, _, exists := c.metadataCache.GetResourceHash(ref.UID); !exists {
+				continue
+			}
+		}
+		results = append(results, result)
+	}
+	// the results are replaced by the merged ones before the report is stored
+	report.SetResults(results)
+	return report
+}
+
+func (c *controller) storePolicyReport(ctx context.Context, policyReport kyvernov1alpha2.ReportInterface, create bool, merged map[string]policyreportv1alpha2.PolicyReportResult) error {
+	var results []policyreportv1alpha2.PolicyReportResult
+	for _, result := range merged {
+		results = append(results, result)
+	}
+	if len(results) == 0 {
+		if !create {
+			if err := deleteReport(ctx, policyReport, c.client); err != nil {
+				return err
+			}
+		}
+	} else {
+		reportutils.SetResults(policyReport, results...)
+		if create {
+			if _, err := reportutils.CreateReport(ctx, policyReport, c.client); err != nil {
+				return err
+			}
+		} else {
+			if _, err := updateReport(ctx, policyReport, c.client); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
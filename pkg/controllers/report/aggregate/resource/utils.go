@@ -9,14 +9,16 @@ import (
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
-func mergeReports(policyMap map[string]policyMapEntry, vapMap sets.Set[string], accumulator map[string]policyreportv1alpha2.PolicyReportResult, uid types.UID, reports ...kyvernov1alpha2.ReportInterface) {
+func mergeReports(policyMap map[string]policyMapEntry, vapMap sets.Set[string], accumulator map[string]policyreportv1alpha2.PolicyReportResult, uid types.UID, keepPodDetails bool, reports ...kyvernov1alpha2.ReportInterface) {
 	for _, report := range reports {
 		if report != nil {
 			for _, result := range report.GetResults() {
+				uid := resultUID(result, uid, keepPodDetails)
 				if result.Source == "ValidatingAdmissionPolicy" {
 					if vapMap != nil && vapMap.Has(result.Policy) {
 						key := result.Source + "/" + result.Policy + "/" + string(uid)
@@ -42,6 +44,42 @@ func mergeReports(policyMap map[string]policyMapEntry, vapMap sets.Set[string],
 	}
 }
 
+// resultUID returns the uid a result is keyed with in the aggregated report.
+// Results rolled up from owned pods are kept apart from the results of the owner itself,
+// they collapse into one result per rule unless per pod details are kept.
+func resultUID(result policyreportv1alpha2.PolicyReportResult, uid types.UID, keepPodDetails bool) types.UID {
+	ref := ownedResource(result)
+	if ref == nil {
+		return uid
+	}
+	if keepPodDetails {
+		return ref.UID
+	}
+	return uid + "/" + types.UID(ref.Kind)
+}
+
+// ownedResource returns the owned resource a result was rolled up from, if any.
+func ownedResource(result policyreportv1alpha2.PolicyReportResult) *corev1.ObjectReference {
+	if len(result.Resources) != 1 {
+		return nil
+	}
+	return &result.Resources[0]
+}
+
+// setOwnedResource records the owned resource the results of the reports were produced for.
+func setOwnedResource(child corev1.ObjectReference, reports ...kyvernov1alpha2.ReportInterface) []kyvernov1alpha2.ReportInterface {
+	for _, report := range reports {
+		if report != nil {
+			results := report.GetResults()
+			for i := range results {
+				results[i].Resources = []corev1.ObjectReference{child}
+			}
+			report.SetResults(results)
+		}
+	}
+	return reports
+}
+
 func deleteReport(ctx context.Context, report kyvernov1alpha2.ReportInterface, client versioned.Interface) error {
 	if !controllerutils.IsManagedByKyverno(report) {
 		return errors.New("can't delete report because it is not managed by kyverno")
@@ -0,0 +1,74 @@
+package resource
+
+import (
+	"testing"
+
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func newReport(results ...policyreportv1alpha2.PolicyReportResult) kyvernov1alpha2.ReportInterface {
+	return &policyreportv1alpha2.PolicyReport{Results: results}
+}
+
+func newResult(rule string, status policyreportv1alpha2.PolicyResult, timestamp int64) policyreportv1alpha2.PolicyReportResult {
+	return policyreportv1alpha2.PolicyReportResult{
+		Source:    "kyverno",
+		Policy:    "pol",
+		Rule:      rule,
+		Result:    status,
+		Timestamp: metav1.Timestamp{Seconds: timestamp},
+	}
+}
+
+func Test_mergeReports_owned(t *testing.T) {
+	policyMap := map[string]policyMapEntry{"pol": {rules: sets.New("rule", "autogen-rule")}}
+	pod1 := corev1.ObjectReference{Kind: "Pod", Name: "pod-1", UID: "pod-1"}
+	pod2 := corev1.ObjectReference{Kind: "Pod", Name: "pod-2", UID: "pod-2"}
+	tests := []struct {
+		name           string
+		keepPodDetails bool
+		want           []policyreportv1alpha2.PolicyResult
+		wantResources  int
+	}{{
+		name:          "rolled up",
+		want:          []policyreportv1alpha2.PolicyResult{policyreportv1alpha2.StatusPass, policyreportv1alpha2.StatusFail},
+		wantResources: 1,
+	}, {
+		name:           "per pod details",
+		keepPodDetails: true,
+		want:           []policyreportv1alpha2.PolicyResult{policyreportv1alpha2.StatusPass, policyreportv1alpha2.StatusPass, policyreportv1alpha2.StatusFail},
+		wantResources:  2,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner := newReport(newResult("autogen-rule", policyreportv1alpha2.StatusPass, 1))
+			reports := setOwnedResource(pod1, newReport(newResult("rule", policyreportv1alpha2.StatusPass, 1)))
+			reports = append(reports, setOwnedResource(pod2, newReport(newResult("rule", policyreportv1alpha2.StatusFail, 2)))...)
+			merged := map[string]policyreportv1alpha2.PolicyReportResult{}
+			mergeReports(policyMap, nil, merged, types.UID("owner"), tt.keepPodDetails, owner)
+			mergeReports(policyMap, nil, merged, types.UID("owner"), tt.keepPodDetails, reports...)
+			assert.Equal(t, len(merged), len(tt.want))
+			counts := map[policyreportv1alpha2.PolicyResult]int{}
+			for _, status := range tt.want {
+				counts[status]++
+			}
+			pods := sets.New[types.UID]()
+			for _, result := range merged {
+				counts[result.Result]--
+				if ref := ownedResource(result); ref != nil {
+					pods.Insert(ref.UID)
+				}
+			}
+			for status, count := range counts {
+				assert.Equal(t, count, 0, string(status))
+			}
+			assert.Equal(t, pods.Len(), tt.wantResources)
+		})
+	}
+}
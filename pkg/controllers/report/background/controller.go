@@ -2,6 +2,8 @@ package background
 
 import (
 	"context"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -17,16 +19,20 @@ import (
 	"github.com/kyverno/kyverno/pkg/controllers/report/resource"
 	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/prefetch"
 	"github.com/kyverno/kyverno/pkg/event"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
-	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	admissionregistrationv1alpha1informers "k8s.io/client-go/informers/admissionregistration/v1alpha1"
 	corev1informers "k8s.io/client-go/informers/core/v1"
 	admissionregistrationv1alpha1listers "k8s.io/client-go/listers/admissionregistration/v1alpha1"
@@ -50,14 +56,18 @@ type controller struct {
 	client        dclient.Interface
 	kyvernoClient versioned.Interface
 	engine        engineapi.Engine
+	prefetchCache prefetch.Cache
+	relatedLister resolvers.RelatedResourceInformer
+	schedules     *engineapi.ScheduleCache
 
 	// listers
-	polLister      kyvernov1listers.PolicyLister
-	cpolLister     kyvernov1listers.ClusterPolicyLister
-	vapLister      admissionregistrationv1alpha1listers.ValidatingAdmissionPolicyLister
-	bgscanrLister  cache.GenericLister
-	cbgscanrLister cache.GenericLister
-	nsLister       corev1listers.NamespaceLister
+	exceptionSelector engineapi.PolicyExceptionSelector
+	polLister         kyvernov1listers.PolicyLister
+	cpolLister        kyvernov1listers.ClusterPolicyLister
+	vapLister         admissionregistrationv1alpha1listers.ValidatingAdmissionPolicyLister
+	bgscanrLister     cache.GenericLister
+	cbgscanrLister    cache.GenericLister
+	nsLister          corev1listers.NamespaceLister
 
 	// queue
 	queue workqueue.RateLimitingInterface
@@ -66,6 +76,15 @@ type controller struct {
 	metadataCache resource.MetadataCache
 	forceDelay    time.Duration
 
+	// scheduling
+	scanOptions ScanOptions
+	nsLimiter   *namespaceLimiter
+	metrics     scanMetrics
+
+	// related resources changes, keyed by namespace ("" for all namespaces)
+	relatedLock    sync.Mutex
+	relatedChanges map[string]time.Time
+
 	// config
 	config        config.Configuration
 	jp            jmespath.Interface
@@ -77,6 +96,9 @@ func NewController(
 	client dclient.Interface,
 	kyvernoClient versioned.Interface,
 	engine engineapi.Engine,
+	prefetchCache prefetch.Cache,
+	relatedLister resolvers.RelatedResourceInformer,
+	exceptionSelector engineapi.PolicyExceptionSelector,
 	metadataFactory metadatainformers.SharedInformerFactory,
 	polInformer kyvernov1informers.PolicyInformer,
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
@@ -84,6 +106,7 @@ func NewController(
 	nsInformer corev1informers.NamespaceInformer,
 	metadataCache resource.MetadataCache,
 	forceDelay time.Duration,
+	scanOptions ScanOptions,
 	config config.Configuration,
 	jp jmespath.Interface,
 	eventGen event.Interface,
@@ -93,22 +116,30 @@ func NewController(
 	cbgscanr := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("clusterbackgroundscanreports"))
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
 	c := controller{
-		client:         client,
-		kyvernoClient:  kyvernoClient,
-		engine:         engine,
-		polLister:      polInformer.Lister(),
-		cpolLister:     cpolInformer.Lister(),
-		bgscanrLister:  bgscanr.Lister(),
-		cbgscanrLister: cbgscanr.Lister(),
-		nsLister:       nsInformer.Lister(),
-		queue:          queue,
-		metadataCache:  metadataCache,
-		forceDelay:     forceDelay,
-		config:         config,
-		jp:             jp,
-		eventGen:       eventGen,
-		policyReports:  policyReports,
-	}
+		client:            client,
+		kyvernoClient:     kyvernoClient,
+		engine:            engine,
+		prefetchCache:     prefetchCache,
+		relatedLister:     relatedLister,
+		schedules:         engineapi.NewScheduleCache(),
+		exceptionSelector: exceptionSelector,
+		polLister:         polInformer.Lister(),
+		cpolLister:        cpolInformer.Lister(),
+		bgscanrLister:     bgscanr.Lister(),
+		cbgscanrLister:    cbgscanr.Lister(),
+		nsLister:          nsInformer.Lister(),
+		queue:             queue,
+		metadataCache:     metadataCache,
+		forceDelay:        forceDelay,
+		scanOptions:       scanOptions,
+		nsLimiter:         newNamespaceLimiter(scanOptions.NamespaceConcurrency),
+		config:            config,
+		jp:                jp,
+		eventGen:          eventGen,
+		policyReports:     policyReports,
+		relatedChanges:    map[string]time.Time{},
+	}
+	c.metrics = c.newMetrics()
 	if vapInformer != nil {
 		c.vapLister = vapInformer.Lister()
 		if _, err := controllerutils.AddEventHandlersT(vapInformer.Informer(), c.addVAP, c.updateVAP, c.deleteVAP); err != nil {
@@ -121,6 +152,11 @@ func NewController(
 	if _, err := controllerutils.AddEventHandlersT(cpolInformer.Informer(), c.addPolicy, c.updatePolicy, c.deletePolicy); err != nil {
 		logger.Error(err, "failed to register event handlers")
 	}
+	if relatedLister != nil {
+		if err := relatedLister.AddEventHandler(c.relatedResourceChanged); err != nil {
+			logger.Error(err, "failed to register event handlers")
+		}
+	}
 	c.metadataCache.AddEventHandler(func(eventType resource.EventType, uid types.UID, _ schema.GroupVersionKind, res resource.Resource) {
 		// if it's a deletion, nothing to do
 		if eventType == resource.Deleted {
@@ -136,8 +172,44 @@ func NewController(
 }
 
 func (c *controller) Run(ctx context.Context, workers int) {
-	logger.Info("background scan", "interval", c.forceDelay.Abs().String())
-	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+	logger.Info("background scan", "interval", c.forceDelay.Abs().String(), "priorityIntervals", c.scanOptions.PriorityIntervals, "namespaceConcurrency", c.scanOptions.NamespaceConcurrency)
+	if c.prefetchCache != nil && c.prefetchCache.Interval() > 0 {
+		// prefetched data is loaded before the workers start and then once per interval,
+		// for all resources at once instead of in the reconcile loop
+		c.warmPrefetchCache(ctx)
+		go func() {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(c.prefetchCache.Interval()):
+			}
+			wait.UntilWithContext(ctx, c.warmPrefetchCache, c.prefetchCache.Interval())
+		}()
+	}
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, controllerutils.WithTracing(ControllerName, c.reconcile))
+}
+
+func (c *controller) warmPrefetchCache(ctx context.Context) {
+	policies, err := c.cpolLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list cluster policies")
+		return
+	}
+	var kyvernoPolicies []kyvernov1.PolicyInterface
+	for _, policy := range policies {
+		kyvernoPolicies = append(kyvernoPolicies, policy)
+	}
+	pols, err := c.polLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list policies")
+		return
+	}
+	for _, policy := range pols {
+		kyvernoPolicies = append(kyvernoPolicies, policy)
+	}
+	if err := c.prefetchCache.Warm(ctx, c.config.GetAutogenControllers(), utils.RemoveNonBackgroundPolicies(kyvernoPolicies...)...); err != nil {
+		logger.Error(err, "failed to prefetch data")
+	}
 }
 
 func (c *controller) addPolicy(obj kyvernov1.PolicyInterface) {
@@ -174,6 +246,53 @@ func (c *controller) enqueueResources() {
 	}
 }
 
+// relatedResourceChanged records the change time of related resources and enqueues the resources whose
+// results may depend on them, the results are then recomputed against these resources.
+func (c *controller) relatedResourceChanged(gvk schema.GroupVersionKind, namespace string) {
+	policies, err := utils.FetchClusterPolicies(c.cpolLister)
+	if err != nil {
+		logger.Error(err, "failed to list cluster policies")
+		return
+	}
+	pols, err := c.polLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list policies")
+		return
+	}
+	for _, pol := range pols {
+		policies = append(policies, pol)
+	}
+	tracked, allNamespaces := utils.RelatedResourcesScope(gvk, c.config.GetAutogenControllers(), utils.RemoveNonBackgroundPolicies(policies...)...)
+	if !tracked {
+		return
+	}
+	if allNamespaces {
+		namespace = ""
+	}
+	c.relatedLock.Lock()
+	c.relatedChanges[namespace] = time.Now()
+	c.relatedLock.Unlock()
+	for _, key := range c.metadataCache.GetAllResourceKeys() {
+		if namespace == "" || strings.HasPrefix(key, namespace+"/") {
+			c.queue.AddAfter(key, enqueueDelay)
+		}
+	}
+}
+
+// relatedResourcesChangedAfter returns true if related resources affecting resources in namespace changed after t.
+func (c *controller) relatedResourcesChangedAfter(namespace string, t time.Time) bool {
+	c.relatedLock.Lock()
+	defer c.relatedLock.Unlock()
+	if changed, ok := c.relatedChanges[""]; ok && changed.After(t) {
+		return true
+	}
+	if namespace == "" {
+		return false
+	}
+	changed, ok := c.relatedChanges[namespace]
+	return ok && changed.After(t)
+}
+
 func (c *controller) getReport(ctx context.Context, namespace, name string) (kyvernov1alpha2.ReportInterface, error) {
 	if namespace == "" {
 		return c.kyvernoClient.KyvernoV1alpha2().ClusterBackgroundScanReports().Get(ctx, name, metav1.GetOptions{})
@@ -198,31 +317,74 @@ func (c *controller) getMeta(namespace, name string) (metav1.Object, error) {
 	}
 }
 
-func (c *controller) needsReconcile(namespace, name, hash string, policies ...engineapi.GenericPolicy) (bool, bool, error) {
+// needsReconcile returns the scope of the reconcile the report needs, nothing is scanned again when it is empty.
+func (c *controller) needsReconcile(namespace, name, hash string, policies ...engineapi.GenericPolicy) (reconcileScope, error) {
+	full := reconcileScope{full: true}
 	// if the reportMetadata does not exist, we need a full reconcile
 	reportMetadata, err := c.getMeta(namespace, name)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return true, true, nil
+			return full, nil
 		}
-		return false, false, err
+		return reconcileScope{}, err
 	}
 	// if the resource changed, we need a full reconcile
 	if !reportutils.CompareHash(reportMetadata, hash) {
-		return true, true, nil
+		return full, nil
 	}
-	// if the last scan time is older than recomputation interval, we need a full reconcile
 	reportAnnotations := reportMetadata.GetAnnotations()
+	// if the namespace labels changed, we need a full reconcile
+	if c.scanOptions.Incremental {
+		nsHash, err := c.namespaceLabelsHash(namespace)
+		if err != nil {
+			return reconcileScope{}, err
+		}
+		if reportAnnotations[annotationNamespaceLabelsHash] != nsHash {
+			return full, nil
+		}
+		// if the exceptions or the schedules of the policies changed, we need a full reconcile
+		stateHash, err := c.policyStateHash(time.Now(), policies...)
+		if err != nil {
+			return reconcileScope{}, err
+		}
+		if reportAnnotations[annotationPolicyStateHash] != stateHash {
+			return full, nil
+		}
+	}
+	scope := reconcileScope{
+		policies: map[string]struct{}{},
+	}
+	// if the last scan time is older than recomputation interval, we need a full reconcile
 	if reportAnnotations == nil || reportAnnotations[annotationLastScanTime] == "" {
-		return true, true, nil
+		return full, nil
 	} else {
 		annTime, err := time.Parse(time.RFC3339, reportAnnotations[annotationLastScanTime])
 		if err != nil {
 			logger.Error(err, "failed to parse last scan time annotation", "namespace", namespace, "name", name, "hash", hash)
-			return true, true, nil
+			return full, nil
+		}
+		// if related resources changed since the last scan, we need a full reconcile
+		if c.relatedResourcesChangedAfter(namespace, annTime) {
+			return full, nil
 		}
 		if time.Now().After(annTime.Add(c.forceDelay)) {
-			return true, true, nil
+			if !c.scanOptions.Incremental {
+				return full, nil
+			}
+			// in incremental mode, only the policies depending on external data are scanned again
+			scope.interval = true
+			for _, policy := range policies {
+				if dependsOnExternalData(policy, c.config.GetAutogenControllers()) {
+					scope.policies[reportutils.PolicyLabel(policy)] = struct{}{}
+				}
+			}
+		}
+	}
+	// if the results of a priority class are older than its interval, we need a partial reconcile
+	scope.classes = c.stalePriorityClasses(reportAnnotations, time.Now(), policies...)
+	for _, policy := range policies {
+		if isStale(c.priorityClass(policy), scope.classes) {
+			scope.policies[reportutils.PolicyLabel(policy)] = struct{}{}
 		}
 	}
 	// if a policy changed, we need a partial reconcile
@@ -230,24 +392,24 @@ func (c *controller) needsReconcile(namespace, name, hash string, policies ...en
 	for _, policy := range policies {
 		expected[reportutils.PolicyLabel(policy)] = policy.GetResourceVersion()
 	}
-	actual := map[string]string{}
 	for key, value := range reportMetadata.GetLabels() {
-		if reportutils.IsPolicyLabel(key) {
-			actual[key] = value
+		if reportutils.IsPolicyLabel(key) && expected[key] != value {
+			scope.policies[key] = struct{}{}
 		}
 	}
-	if !datautils.DeepEqual(expected, actual) {
-		return true, false, nil
+	for key, value := range expected {
+		if reportMetadata.GetLabels()[key] != value {
+			scope.policies[key] = struct{}{}
+		}
 	}
-	// no need to reconcile
-	return false, false, nil
+	return scope, nil
 }
 
 func (c *controller) reconcileReport(
 	ctx context.Context,
 	namespace string,
 	name string,
-	full bool,
+	scope reconcileScope,
 	uid types.UID,
 	gvk schema.GroupVersionKind,
 	resource resource.Resource,
@@ -262,11 +424,37 @@ func (c *controller) reconcileReport(
 		}
 		nsLabels = ns.GetLabels()
 	}
+	// exceptions and schedules the results depend on, computed before scanning so that changes during the scan trigger another one
+	var stateHash string
+	if c.scanOptions.Incremental {
+		var err error
+		if stateHash, err = c.policyStateHash(time.Now(), policies...); err != nil {
+			return err
+		}
+	}
 	// load target resource
 	target, err := c.client.GetResource(ctx, gvk.GroupVersion().String(), gvk.Kind, resource.Namespace, resource.Name)
 	if err != nil {
 		return err
 	}
+	// load the resource a revision retained in the rollout history is evaluated as
+	var revisionTarget *unstructured.Unstructured
+	var revision string
+	if c.scanOptions.RolloutHistory {
+		if ownerRef := utils.RevisionOwner(*target); ownerRef != nil {
+			owner, err := c.client.GetResource(ctx, ownerRef.APIVersion, ownerRef.Kind, target.GetNamespace(), ownerRef.Name)
+			if err != nil {
+				if !apierrors.IsNotFound(err) {
+					return err
+				}
+			} else {
+				revisionTarget, revision, err = utils.RevisionTarget(*target, *owner)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
 	// load observed report
 	observed, err := c.getReport(ctx, namespace, name)
 	if err != nil {
@@ -287,7 +475,7 @@ func (c *controller) reconcileReport(
 		}
 	}
 	var ruleResults []policyreportv1alpha2.PolicyReportResult
-	if !full {
+	if !scope.full {
 		policyNameToLabel := map[string]string{}
 		for _, policy := range policies {
 			var key string
@@ -304,25 +492,37 @@ func (c *controller) reconcileReport(
 		}
 		// keep up to date results
 		for _, result := range observed.GetResults() {
-			// if the policy did not change, keep the result
+			// if the policy did not change and does not need to be scanned again, keep the result
 			label := policyNameToLabel[result.Policy]
-			if label != "" && expected[label] == actual[label] {
+			if label != "" && expected[label] == actual[label] && !scope.rescan(label) {
 				ruleResults = append(ruleResults, result)
 			}
 		}
 	}
 	// calculate necessary results
 	for _, policy := range policies {
-		if full || actual[reportutils.PolicyLabel(policy)] != policy.GetResourceVersion() {
+		if scope.rescan(reportutils.PolicyLabel(policy)) || actual[reportutils.PolicyLabel(policy)] != policy.GetResourceVersion() {
 			scanner := utils.NewScanner(logger, c.engine, c.config, c.jp)
 			for _, result := range scanner.ScanResource(ctx, *target, nsLabels, policy) {
 				if result.Error != nil {
 					return result.Error
 				} else if result.EngineResponse != nil {
-					ruleResults = append(ruleResults, reportutils.EngineResponseToReportResults(*result.EngineResponse)...)
+					results := reportutils.EngineResponseToReportResults(*result.EngineResponse, c.config)
+					ruleResults = append(ruleResults, reportutils.FilterResults(*result.EngineResponse, results)...)
 					utils.GenerateEvents(logger, c.eventGen, c.config, *result.EngineResponse)
 				}
 			}
+			if revisionTarget != nil {
+				// no events are generated for revisions, the evaluated resource does not exist
+				for _, result := range scanner.ScanResource(ctx, *revisionTarget, nsLabels, policy) {
+					if result.Error != nil {
+						return result.Error
+					} else if result.EngineResponse != nil {
+						results := reportutils.EngineResponseToReportResults(*result.EngineResponse, c.config)
+						ruleResults = mergeRevisionResults(ruleResults, revision, reportutils.FilterResults(*result.EngineResponse, results)...)
+					}
+				}
+			}
 		}
 	}
 	desired := reportutils.DeepCopy(observed)
@@ -336,8 +536,20 @@ func (c *controller) reconcileReport(
 	}
 	reportutils.SetResourceVersionLabels(desired, target)
 	reportutils.SetResults(desired, ruleResults...)
-	if full || !controllerutils.HasAnnotation(desired, annotationLastScanTime) {
-		controllerutils.SetAnnotation(desired, annotationLastScanTime, time.Now().Format(time.RFC3339))
+	now := time.Now().Format(time.RFC3339)
+	if scope.full || scope.interval || !controllerutils.HasAnnotation(desired, annotationLastScanTime) {
+		controllerutils.SetAnnotation(desired, annotationLastScanTime, now)
+	}
+	if c.scanOptions.Incremental {
+		controllerutils.SetAnnotation(desired, annotationNamespaceLabelsHash, hashNamespaceLabels(nsLabels))
+		controllerutils.SetAnnotation(desired, annotationPolicyStateHash, stateHash)
+	}
+	for _, policy := range policies {
+		if class := c.priorityClass(policy); class != "" {
+			if scope.full || isStale(class, scope.classes) || !controllerutils.HasAnnotation(desired, priorityScanTimeAnnotation(class)) {
+				controllerutils.SetAnnotation(desired, priorityScanTimeAnnotation(class), now)
+			}
+		}
 	}
 	if c.policyReports {
 		return c.storeReport(ctx, observed, desired)
@@ -419,14 +631,22 @@ func (c *controller) reconcile(ctx context.Context, log logr.Logger, key, namesp
 		}
 	}
 	// we have the resource, check if we need to reconcile
-	if needsReconcile, full, err := c.needsReconcile(namespace, name, resource.Hash, policies...); err != nil {
+	if scope, err := c.needsReconcile(namespace, name, resource.Hash, policies...); err != nil {
 		return err
 	} else {
+		if scope.needsReconcile() {
+			// bound the number of resources of a namespace scanned concurrently
+			if !c.nsLimiter.acquire(namespace) {
+				c.queue.AddAfter(key, namespaceRetryDelay)
+				return nil
+			}
+			defer c.nsLimiter.release(namespace)
+		}
 		defer func() {
-			c.queue.AddAfter(key, c.forceDelay)
+			c.queue.AddAfter(key, c.requeueDelay(policies...))
 		}()
-		if needsReconcile {
-			return c.reconcileReport(ctx, namespace, name, full, uid, gvk, resource, policies...)
+		if scope.needsReconcile() {
+			return c.reconcileReport(ctx, namespace, name, scope, uid, gvk, resource, policies...)
 		}
 	}
 	return nil
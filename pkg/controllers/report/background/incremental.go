@@ -0,0 +1,135 @@
+package background
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// annotationNamespaceLabelsHash records the hash of the labels of the namespace of the resource when it was scanned,
+// namespace selectors are evaluated against them.
+const annotationNamespaceLabelsHash = "audit.kyverno.io/namespace-labels.hash"
+
+// annotationPolicyStateHash records the hash of the policy exceptions applying to the policies and of whether the
+// schedules of the policies were active when the resource was scanned, both change the results without changing the policies.
+const annotationPolicyStateHash = "audit.kyverno.io/policy-state.hash"
+
+// reconcileScope describes what needs to be scanned again to reconcile a report.
+type reconcileScope struct {
+	// full is true when all the policies need to be scanned again
+	full bool
+	// policies are the labels of the policies to scan again
+	policies map[string]struct{}
+	// classes are the priority classes whose interval elapsed
+	classes map[string]struct{}
+	// interval is true when the background scan interval elapsed
+	interval bool
+}
+
+func (s reconcileScope) needsReconcile() bool {
+	return s.full || len(s.policies) != 0
+}
+
+// rescan returns whether the policy with the given label needs to be scanned again.
+func (s reconcileScope) rescan(label string) bool {
+	if s.full {
+		return true
+	}
+	_, ok := s.policies[label]
+	return ok
+}
+
+// dependsOnExternalData returns whether the results of a policy can change while neither the policy nor the resource changed,
+// in incremental mode these policies are still scanned again at the background scan interval.
+func dependsOnExternalData(policy engineapi.GenericPolicy, autogenControllers []config.AutogenController) bool {
+	switch p := policy.GetPolicy().(type) {
+	case kyvernov1.PolicyInterface:
+		return utils.DependsOnExternalData(p, autogenControllers)
+	case admissionregistrationv1alpha1.ValidatingAdmissionPolicy:
+		return p.Spec.ParamKind != nil
+	}
+	return true
+}
+
+func (c *controller) namespaceLabelsHash(namespace string) (string, error) {
+	if namespace == "" {
+		return hashNamespaceLabels(nil), nil
+	}
+	ns, err := c.nsLister.Get(namespace)
+	if err != nil {
+		return "", err
+	}
+	return hashNamespaceLabels(ns.GetLabels()), nil
+}
+
+func hashNamespaceLabels(nsLabels map[string]string) string {
+	sum := sha256.Sum256([]byte(labels.Set(nsLabels).String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// policyStateHash hashes the exceptions applying to the policies and whether their schedules are active at the given time,
+// exceptions are filtered the way the engine does.
+func (c *controller) policyStateHash(now time.Time, policies ...engineapi.GenericPolicy) (string, error) {
+	var exceptions []*kyvernov2.PolicyException
+	if c.exceptionSelector != nil {
+		polexs, err := c.exceptionSelector.List(labels.Everything())
+		if err != nil {
+			return "", err
+		}
+		approval := c.config.GetExceptionApproval().Enabled
+		for _, polex := range polexs {
+			if polex.IsExpired(now) {
+				continue
+			}
+			if approval && (!polex.IsApproved() || !config.IsExceptionApprovalEnforced(c.config, polex.GetNamespace())) {
+				continue
+			}
+			exceptions = append(exceptions, polex)
+		}
+	}
+	var state []string
+	for _, policy := range policies {
+		pol, ok := policy.GetPolicy().(kyvernov1.PolicyInterface)
+		if !ok {
+			continue
+		}
+		policyKey, err := cache.MetaNamespaceKeyFunc(pol)
+		if err != nil {
+			return "", err
+		}
+		entry := []string{reportutils.PolicyLabel(policy), fmt.Sprint(c.schedules.IsActive(pol, now))}
+		for _, polex := range exceptions {
+			if referencesPolicy(polex, policyKey) {
+				entry = append(entry, polex.GetNamespace()+"/"+polex.GetName()+"@"+polex.GetResourceVersion())
+			}
+		}
+		sort.Strings(entry[2:])
+		state = append(state, strings.Join(entry, ","))
+	}
+	sort.Strings(state)
+	sum := sha256.Sum256([]byte(strings.Join(state, ";")))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// referencesPolicy returns whether the exception applies to rules of the policy with the given key.
+func referencesPolicy(polex *kyvernov2.PolicyException, policyKey string) bool {
+	for _, exception := range polex.Spec.Exceptions {
+		if exception.PolicyName == policyKey {
+			return true
+		}
+	}
+	return false
+}
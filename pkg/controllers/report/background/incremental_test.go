@@ -0,0 +1,152 @@
+package background
+
+import (
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	kyvernov2listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestDependsOnExternalData(t *testing.T) {
+	policy := func(rule kyvernov1.Rule) engineapi.GenericPolicy {
+		return engineapi.NewKyvernoPolicy(&kyvernov1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec:       kyvernov1.Spec{Rules: []kyvernov1.Rule{rule}},
+		})
+	}
+	tests := []struct {
+		name   string
+		policy engineapi.GenericPolicy
+		want   bool
+	}{{
+		name: "pattern",
+		policy: policy(kyvernov1.Rule{
+			Name:       "labels",
+			Validation: kyvernov1.Validation{Message: "label required"},
+		}),
+		want: false,
+	}, {
+		name: "variable",
+		policy: policy(kyvernov1.Rule{
+			Name:    "variable",
+			Context: []kyvernov1.ContextEntry{{Name: "name", Variable: &kyvernov1.Variable{JMESPath: "request.object.metadata.name"}}},
+		}),
+		want: false,
+	}, {
+		name: "config map",
+		policy: policy(kyvernov1.Rule{
+			Name:    "config-map",
+			Context: []kyvernov1.ContextEntry{{Name: "data", ConfigMap: &kyvernov1.ConfigMapReference{Name: "data", Namespace: "default"}}},
+		}),
+		want: true,
+	}, {
+		name: "time function",
+		policy: policy(kyvernov1.Rule{
+			Name:       "time",
+			Validation: kyvernov1.Validation{Message: "expired at {{ time_now_utc() }}"},
+		}),
+		want: true,
+	}, {
+		name:   "validating admission policy",
+		policy: engineapi.NewValidatingAdmissionPolicy(admissionregistrationv1alpha1.ValidatingAdmissionPolicy{}),
+		want:   false,
+	}, {
+		name: "validating admission policy with params",
+		policy: engineapi.NewValidatingAdmissionPolicy(admissionregistrationv1alpha1.ValidatingAdmissionPolicy{
+			Spec: admissionregistrationv1alpha1.ValidatingAdmissionPolicySpec{
+				ParamKind: &admissionregistrationv1alpha1.ParamKind{APIVersion: "v1", Kind: "ConfigMap"},
+			},
+		}),
+		want: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dependsOnExternalData(tt.policy, nil); got != tt.want {
+				t.Errorf("dependsOnExternalData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileScope(t *testing.T) {
+	if (reconcileScope{interval: true}).needsReconcile() {
+		t.Error("expected no reconcile when no policy needs to be scanned again")
+	}
+	full := reconcileScope{full: true}
+	if !full.needsReconcile() || !full.rescan("cpol.kyverno.io/any") {
+		t.Error("expected a full reconcile to scan all policies again")
+	}
+	partial := reconcileScope{policies: map[string]struct{}{"cpol.kyverno.io/changed": {}}}
+	if !partial.needsReconcile() || !partial.rescan("cpol.kyverno.io/changed") || partial.rescan("cpol.kyverno.io/unchanged") {
+		t.Error("expected a partial reconcile to only scan the changed policies again")
+	}
+	if hashNamespaceLabels(map[string]string{"a": "b"}) == hashNamespaceLabels(map[string]string{"a": "c"}) {
+		t.Error("expected namespace labels hashes to differ")
+	}
+}
+
+func TestPolicyStateHash(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	c := &controller{
+		exceptionSelector: kyvernov2listers.NewPolicyExceptionLister(indexer),
+		config:            config.NewDefaultConfiguration(false),
+	}
+	policy := engineapi.NewKyvernoPolicy(&kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "test", ResourceVersion: "1"}})
+	hash := func(policies ...engineapi.GenericPolicy) string {
+		t.Helper()
+		hash, err := c.policyStateHash(now, policies...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hash
+	}
+	exception := func(name, policy string) *kyvernov2.PolicyException {
+		return &kyvernov2.PolicyException{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kyverno", ResourceVersion: "1"},
+			Spec: kyvernov2.PolicyExceptionSpec{
+				Exceptions: []kyvernov2.Exception{{PolicyName: policy, RuleNames: []string{"*"}}},
+			},
+		}
+	}
+	initial := hash(policy)
+	if err := indexer.Add(exception("other", "other")); err != nil {
+		t.Fatal(err)
+	}
+	if got := hash(policy); got != initial {
+		t.Error("expected the hash not to change when an exception for another policy is added")
+	}
+	polex := exception("test", "test")
+	if err := indexer.Add(polex); err != nil {
+		t.Fatal(err)
+	}
+	excepted := hash(policy)
+	if excepted == initial {
+		t.Error("expected the hash to change when an exception for the policy is added")
+	}
+	expired := polex.DeepCopy()
+	expired.ResourceVersion = "2"
+	expired.Spec.ExpiresAt = &metav1.Time{Time: now.Add(-time.Minute)}
+	if err := indexer.Update(expired); err != nil {
+		t.Fatal(err)
+	}
+	if got := hash(policy); got != initial {
+		t.Error("expected the hash of an expired exception to be the hash without exceptions")
+	}
+	scheduled := engineapi.NewKyvernoPolicy(&kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", ResourceVersion: "1"},
+		Spec: kyvernov1.Spec{Schedule: &kyvernov1.PolicySchedule{
+			Windows: []kyvernov1.ScheduleWindow{{Start: "0 0 * * *", Duration: metav1.Duration{Duration: time.Hour}}},
+		}},
+	})
+	if got := hash(scheduled); got == initial {
+		t.Error("expected the hash to change when the schedule of the policy is inactive")
+	}
+}
@@ -0,0 +1,31 @@
+package background
+
+import (
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// propertyRevision is the result property recording the number of a revision evaluated as its owner
+const propertyRevision = "revision"
+
+// mergeRevisionResults appends the results of a revision evaluated as its owner to the results of the revision,
+// the rules already reported for the revision itself are skipped
+func mergeRevisionResults(results []policyreportv1alpha2.PolicyReportResult, revision string, revisionResults ...policyreportv1alpha2.PolicyReportResult) []policyreportv1alpha2.PolicyReportResult {
+	reported := sets.New[string]()
+	for _, result := range results {
+		reported.Insert(result.Policy + "/" + result.Rule)
+	}
+	for _, result := range revisionResults {
+		if reported.Has(result.Policy + "/" + result.Rule) {
+			continue
+		}
+		if revision != "" {
+			if result.Properties == nil {
+				result.Properties = map[string]string{}
+			}
+			result.Properties[propertyRevision] = revision
+		}
+		results = append(results, result)
+	}
+	return results
+}
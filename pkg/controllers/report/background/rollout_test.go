@@ -0,0 +1,26 @@
+package background
+
+import (
+	"testing"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"gotest.tools/assert"
+)
+
+func Test_mergeRevisionResults(t *testing.T) {
+	results := []policyreportv1alpha2.PolicyReportResult{
+		{Policy: "require-signed-images", Rule: "autogen-verify", Result: policyreportv1alpha2.StatusPass},
+	}
+	merged := mergeRevisionResults(results, "3",
+		policyreportv1alpha2.PolicyReportResult{Policy: "require-signed-images", Rule: "autogen-verify", Result: policyreportv1alpha2.StatusFail},
+		policyreportv1alpha2.PolicyReportResult{Policy: "disallow-latest-tag", Rule: "validate-image-tag", Result: policyreportv1alpha2.StatusFail},
+	)
+	assert.Equal(t, len(merged), 2)
+	assert.Equal(t, merged[0].Result, policyreportv1alpha2.StatusPass)
+	assert.Equal(t, merged[1].Policy, "disallow-latest-tag")
+	assert.DeepEqual(t, merged[1].Properties, map[string]string{"revision": "3"})
+
+	merged = mergeRevisionResults(nil, "", policyreportv1alpha2.PolicyReportResult{Policy: "disallow-latest-tag", Rule: "validate-image-tag"})
+	assert.Equal(t, len(merged), 1)
+	assert.Assert(t, merged[0].Properties == nil)
+}
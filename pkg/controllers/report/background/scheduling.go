@@ -0,0 +1,294 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// namespaceRetryDelay is the delay after which a resource is retried when its namespace
+// already reached the max number of concurrent scans.
+const namespaceRetryDelay = 5 * time.Second
+
+// ScanOptions control the scheduling of background scans.
+type ScanOptions struct {
+	// NamespaceConcurrency is the max number of resources of a namespace scanned concurrently, zero means unlimited.
+	NamespaceConcurrency int
+	// PriorityIntervals are the scan intervals of the policy priority classes, policies declare
+	// their priority class with the policies.kyverno.io/scan-priority annotation. Policies without
+	// a priority class, or with an unknown one, are scanned at the background scan interval.
+	PriorityIntervals map[string]time.Duration
+	// Incremental skips the scans of the resources when neither the resource, its namespace labels nor the policy changed
+	// since the last scan. Policies loading external data are still scanned again at the background scan interval.
+	Incremental bool
+	// RolloutHistory also evaluates the revisions retained in the rollout history of the pod controllers, the ReplicaSets
+	// of Deployments and the ControllerRevisions of DaemonSets and StatefulSets, as their owner with the pod template of
+	// the revision, so that old revisions still referencing vulnerable or unsigned images are reported. The active revision
+	// of an owner is only evaluated as itself.
+	RolloutHistory bool
+}
+
+// ParsePriorityIntervals parses a comma separated list of <priority class>=<interval> entries.
+func ParsePriorityIntervals(value string) (map[string]time.Duration, error) {
+	intervals := map[string]time.Duration{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		class, interval, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid priority interval %q, expected <priority class>=<interval>", entry)
+		}
+		class = strings.TrimSpace(class)
+		if errs := validation.IsDNS1123Label(class); len(errs) != 0 {
+			return nil, fmt.Errorf("invalid priority class %q: %s", class, strings.Join(errs, ", "))
+		}
+		duration, err := time.ParseDuration(strings.TrimSpace(interval))
+		if err != nil {
+			return nil, fmt.Errorf("invalid interval of priority class %q: %w", class, err)
+		}
+		if duration <= 0 {
+			return nil, fmt.Errorf("invalid interval of priority class %q: must be positive", class)
+		}
+		intervals[class] = duration
+	}
+	return intervals, nil
+}
+
+// namespaceLimiter bounds the number of concurrent scans per namespace.
+type namespaceLimiter struct {
+	limit    int
+	lock     sync.Mutex
+	inflight map[string]int
+}
+
+func newNamespaceLimiter(limit int) *namespaceLimiter {
+	return &namespaceLimiter{
+		limit:    limit,
+		inflight: map[string]int{},
+	}
+}
+
+// acquire returns false if the namespace already reached the max number of concurrent scans,
+// cluster scoped resources are not limited.
+func (l *namespaceLimiter) acquire(namespace string) bool {
+	if l.limit <= 0 || namespace == "" {
+		return true
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.inflight[namespace] >= l.limit {
+		return false
+	}
+	l.inflight[namespace]++
+	return true
+}
+
+func (l *namespaceLimiter) release(namespace string) {
+	if l.limit <= 0 || namespace == "" {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.inflight[namespace] <= 1 {
+		delete(l.inflight, namespace)
+	} else {
+		l.inflight[namespace]--
+	}
+}
+
+// priorityClass returns the scan priority class of a policy, or an empty string when
+// the policy is scanned at the background scan interval.
+func (c *controller) priorityClass(policy engineapi.GenericPolicy) string {
+	class := policy.GetAnnotations()[kyverno.AnnotationPolicyScanPriority]
+	if _, ok := c.scanOptions.PriorityIntervals[class]; !ok {
+		return ""
+	}
+	return class
+}
+
+// requeueDelay returns the delay after which a resource is scanned again,
+// the shortest interval of the priority classes of the policies applying to it.
+func (c *controller) requeueDelay(policies ...engineapi.GenericPolicy) time.Duration {
+	delay := c.forceDelay
+	for _, policy := range policies {
+		if class := c.priorityClass(policy); class != "" {
+			if interval := c.scanOptions.PriorityIntervals[class]; interval < delay {
+				delay = interval
+			}
+		}
+	}
+	return delay
+}
+
+// stalePriorityClasses returns the priority classes of the policies whose results in the report are older than their interval.
+func (c *controller) stalePriorityClasses(reportAnnotations map[string]string, now time.Time, policies ...engineapi.GenericPolicy) map[string]struct{} {
+	stale := map[string]struct{}{}
+	for _, policy := range policies {
+		class := c.priorityClass(policy)
+		if class == "" {
+			continue
+		}
+		lastScan, err := time.Parse(time.RFC3339, reportAnnotations[priorityScanTimeAnnotation(class)])
+		if err != nil || now.After(lastScan.Add(c.scanOptions.PriorityIntervals[class])) {
+			stale[class] = struct{}{}
+		}
+	}
+	return stale
+}
+
+func priorityScanTimeAnnotation(class string) string {
+	return annotationLastScanTime + "." + class
+}
+
+type scanMetrics struct {
+	queueDepth metric.Int64ObservableGauge
+	staleness  metric.Float64ObservableGauge
+}
+
+func (c *controller) newMetrics() scanMetrics {
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	queueDepth, err := meter.Int64ObservableGauge(
+		"kyverno_background_scan_queue_depth",
+		metric.WithDescription("can be used to track the number of resources waiting to be scanned by the background scan."),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_background_scan_queue_depth")
+	}
+	staleness, err := meter.Float64ObservableGauge(
+		"kyverno_background_scan_staleness_seconds",
+		metric.WithDescription("can be used to track the number of seconds since the least recently scanned resource was scanned against a policy."),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_background_scan_staleness_seconds")
+	}
+	var observables []metric.Observable
+	if queueDepth != nil {
+		observables = append(observables, queueDepth)
+	}
+	if staleness != nil {
+		observables = append(observables, staleness)
+	}
+	if len(observables) != 0 {
+		if _, err := meter.RegisterCallback(c.report, observables...); err != nil {
+			logger.Error(err, "Failed to register callback")
+		}
+	}
+	return scanMetrics{
+		queueDepth: queueDepth,
+		staleness:  staleness,
+	}
+}
+
+func (c *controller) report(ctx context.Context, observer metric.Observer) error {
+	if c.metrics.queueDepth != nil {
+		observer.ObserveInt64(c.metrics.queueDepth, int64(c.queue.Len()))
+	}
+	if c.metrics.staleness == nil {
+		return nil
+	}
+	staleness, err := c.staleness(time.Now())
+	if err != nil {
+		logger.Error(err, "failed to compute background scan staleness")
+		return err
+	}
+	for policy, seconds := range staleness {
+		observer.ObserveFloat64(c.metrics.staleness, seconds, metric.WithAttributes(
+			attribute.String("policy_namespace", policy.namespace),
+			attribute.String("policy_name", policy.name),
+		))
+	}
+	return nil
+}
+
+type policyKey struct {
+	namespace string
+	name      string
+}
+
+// staleness returns, per policy, the number of seconds since the least recently scanned resource was scanned against it.
+func (c *controller) staleness(now time.Time) (map[policyKey]float64, error) {
+	classes := map[policyKey]string{}
+	cpols, err := c.cpolLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range cpols {
+		classes[policyKey{name: policy.GetName()}] = c.priorityClass(engineapi.NewKyvernoPolicy(policy))
+	}
+	pols, err := c.polLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range pols {
+		classes[policyKey{namespace: policy.GetNamespace(), name: policy.GetName()}] = c.priorityClass(engineapi.NewKyvernoPolicy(policy))
+	}
+	oldest := map[policyKey]time.Time{}
+	observe := func(report metav1.Object) {
+		for label := range report.GetLabels() {
+			var key policyKey
+			if strings.HasPrefix(label, reportutils.LabelPrefixClusterPolicy) {
+				key = policyKey{name: strings.TrimPrefix(label, reportutils.LabelPrefixClusterPolicy)}
+			} else if strings.HasPrefix(label, reportutils.LabelPrefixPolicy) {
+				key = policyKey{namespace: report.GetNamespace(), name: strings.TrimPrefix(label, reportutils.LabelPrefixPolicy)}
+			} else {
+				continue
+			}
+			class, ok := classes[key]
+			if !ok {
+				continue
+			}
+			annotation := annotationLastScanTime
+			if class != "" {
+				annotation = priorityScanTimeAnnotation(class)
+			}
+			lastScan, err := time.Parse(time.RFC3339, report.GetAnnotations()[annotation])
+			if err != nil {
+				continue
+			}
+			if current, ok := oldest[key]; !ok || lastScan.Before(current) {
+				oldest[key] = lastScan
+			}
+		}
+	}
+	bgscanrs, err := c.bgscanrLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	cbgscanrs, err := c.cbgscanrLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range append(bgscanrs, cbgscanrs...) {
+		if report, ok := obj.(metav1.Object); ok {
+			observe(report)
+		}
+	}
+	staleness := map[policyKey]float64{}
+	for key, lastScan := range oldest {
+		staleness[key] = now.Sub(lastScan).Seconds()
+	}
+	return staleness, nil
+}
+
+func isStale(class string, stale map[string]struct{}) bool {
+	if class == "" {
+		return false
+	}
+	_, ok := stale[class]
+	return ok
+}
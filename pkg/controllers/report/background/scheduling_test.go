@@ -0,0 +1,110 @@
+package background
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParsePriorityIntervals(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]time.Duration
+		wantErr bool
+	}{{
+		name:  "empty",
+		value: "",
+		want:  map[string]time.Duration{},
+	}, {
+		name:  "classes",
+		value: "critical=5m, high=15m",
+		want:  map[string]time.Duration{"critical": 5 * time.Minute, "high": 15 * time.Minute},
+	}, {
+		name:    "missing interval",
+		value:   "critical",
+		wantErr: true,
+	}, {
+		name:    "invalid class",
+		value:   "Critical=5m",
+		wantErr: true,
+	}, {
+		name:    "invalid interval",
+		value:   "critical=-5m",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePriorityIntervals(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePriorityIntervals() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePriorityIntervals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNamespaceLimiter(t *testing.T) {
+	limiter := newNamespaceLimiter(2)
+	if !limiter.acquire("foo") || !limiter.acquire("foo") {
+		t.Fatal("expected to acquire two slots")
+	}
+	if limiter.acquire("foo") {
+		t.Fatal("expected the namespace to be limited")
+	}
+	if !limiter.acquire("bar") || !limiter.acquire("") || !limiter.acquire("") || !limiter.acquire("") {
+		t.Fatal("expected other namespaces and cluster scoped resources not to be limited")
+	}
+	limiter.release("foo")
+	if !limiter.acquire("foo") {
+		t.Fatal("expected to acquire a released slot")
+	}
+	if unlimited := newNamespaceLimiter(0); !unlimited.acquire("foo") || !unlimited.acquire("foo") {
+		t.Fatal("expected no limit")
+	}
+}
+
+func TestPriorityScheduling(t *testing.T) {
+	policy := func(name, class string) engineapi.GenericPolicy {
+		pol := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if class != "" {
+			pol.SetAnnotations(map[string]string{kyverno.AnnotationPolicyScanPriority: class})
+		}
+		return engineapi.NewKyvernoPolicy(pol)
+	}
+	c := &controller{
+		forceDelay: time.Hour,
+		scanOptions: ScanOptions{
+			PriorityIntervals: map[string]time.Duration{"critical": 5 * time.Minute, "high": 15 * time.Minute},
+		},
+	}
+	standard, unknown, critical, high := policy("standard", ""), policy("unknown", "low"), policy("critical", "critical"), policy("high", "high")
+	if class := c.priorityClass(unknown); class != "" {
+		t.Errorf("priorityClass() = %q, want no class for an unknown priority class", class)
+	}
+	if delay := c.requeueDelay(standard, unknown); delay != time.Hour {
+		t.Errorf("requeueDelay() = %v, want %v", delay, time.Hour)
+	}
+	if delay := c.requeueDelay(standard, high, critical); delay != 5*time.Minute {
+		t.Errorf("requeueDelay() = %v, want %v", delay, 5*time.Minute)
+	}
+	now := time.Now()
+	annotations := map[string]string{
+		priorityScanTimeAnnotation("critical"): now.Add(-10 * time.Minute).Format(time.RFC3339),
+		priorityScanTimeAnnotation("high"):     now.Add(-10 * time.Minute).Format(time.RFC3339),
+	}
+	stale := c.stalePriorityClasses(annotations, now, standard, critical, high)
+	if want := map[string]struct{}{"critical": {}}; !reflect.DeepEqual(stale, want) {
+		t.Errorf("stalePriorityClasses() = %v, want %v", stale, want)
+	}
+	if !isStale("critical", stale) || isStale("high", stale) || isStale("", stale) {
+		t.Error("isStale() returned unexpected results")
+	}
+}
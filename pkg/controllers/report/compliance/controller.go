@@ -0,0 +1,313 @@
+package compliance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "compliance-scan-controller"
+	maxRetries     = 10
+	// exportKey is the config map key the exported results are stored in
+	exportKey = "results.json"
+)
+
+type controller struct {
+	// clients
+	client        dclient.Interface
+	kyvernoClient versioned.Interface
+	engine        engineapi.Engine
+
+	// listers
+	scanLister kyvernov2alpha1listers.ComplianceScanLister
+	polLister  kyvernov1listers.PolicyLister
+	cpolLister kyvernov1listers.ClusterPolicyLister
+	nsLister   corev1listers.NamespaceLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	// config
+	config config.Configuration
+	jp     jmespath.Interface
+}
+
+func NewController(
+	client dclient.Interface,
+	kyvernoClient versioned.Interface,
+	engine engineapi.Engine,
+	scanInformer kyvernov2alpha1informers.ComplianceScanInformer,
+	polInformer kyvernov1informers.PolicyInformer,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	nsInformer corev1informers.NamespaceInformer,
+	config config.Configuration,
+	jp jmespath.Interface,
+) controllers.Controller {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+	c := &controller{
+		client:        client,
+		kyvernoClient: kyvernoClient,
+		engine:        engine,
+		scanLister:    scanInformer.Lister(),
+		polLister:     polInformer.Lister(),
+		cpolLister:    cpolInformer.Lister(),
+		nsLister:      nsInformer.Lister(),
+		queue:         queue,
+		config:        config,
+		jp:            jp,
+	}
+	if _, _, err := controllerutils.AddDefaultEventHandlers(logger, scanInformer.Informer(), queue); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, _, _, name string) error {
+	scan, err := c.scanLister.Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	// the results of a scan are never updated, only the export may still be pending
+	if scan.Status.IsDone() {
+		return c.export(ctx, scan)
+	}
+	latest := scan.DeepCopy()
+	if invalid := scan.Validate(); len(invalid) != 0 {
+		return c.complete(ctx, latest, nil, invalid.ToAggregate())
+	}
+	if latest.Status.State != kyvernov2alpha1.ComplianceScanRunning {
+		now := metav1.Now()
+		latest.Status.State = kyvernov2alpha1.ComplianceScanRunning
+		latest.Status.StartTime = &now
+		latest, err = c.kyvernoClient.KyvernoV2alpha1().ComplianceScans().UpdateStatus(ctx, latest, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+	}
+	logger.V(2).Info("running compliance scan")
+	results, err := c.scan(ctx, logger, &latest.Spec)
+	if err := c.complete(ctx, latest, results, err); err != nil {
+		return err
+	}
+	return c.export(ctx, latest)
+}
+
+// complete records the outcome of the scan, the status is not updated afterwards.
+func (c *controller) complete(ctx context.Context, scan *kyvernov2alpha1.ComplianceScan, results []policyreportv1alpha2.PolicyReportResult, err error) error {
+	now := metav1.Now()
+	status := &scan.Status
+	status.CompletionTime = &now
+	if err != nil {
+		status.State = kyvernov2alpha1.ComplianceScanFailed
+		status.Message = err.Error()
+		status.SetReady(false, err.Error())
+	} else {
+		reportutils.SortReportResults(results)
+		status.State = kyvernov2alpha1.ComplianceScanCompleted
+		status.Message = fmt.Sprintf("%d results recorded", len(results))
+		status.Summary = reportutils.CalculateSummary(results)
+		status.Results = results
+		if scan.Spec.Export {
+			status.ExportRef = &corev1.ObjectReference{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+				Namespace:  config.KyvernoNamespace(),
+				Name:       fmt.Sprintf("compliance-scan-%s-%d", scan.GetName(), now.Unix()),
+			}
+		}
+		status.SetReady(true, status.Message)
+	}
+	updated, updateErr := c.kyvernoClient.KyvernoV2alpha1().ComplianceScans().UpdateStatus(ctx, scan, metav1.UpdateOptions{})
+	if updateErr != nil {
+		return updateErr
+	}
+	updated.DeepCopyInto(scan)
+	return nil
+}
+
+// export writes the results of a completed scan in an immutable config map.
+func (c *controller) export(ctx context.Context, scan *kyvernov2alpha1.ComplianceScan) error {
+	ref := scan.Status.ExportRef
+	if ref == nil {
+		return nil
+	}
+	data, err := exportData(scan)
+	if err != nil {
+		return err
+	}
+	immutable := true
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ref.Name,
+			Namespace: ref.Namespace,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: kyvernov2alpha1.SchemeGroupVersion.String(),
+				Kind:       "ComplianceScan",
+				Name:       scan.GetName(),
+				UID:        scan.GetUID(),
+			}},
+		},
+		Immutable: &immutable,
+		Data: map[string]string{
+			exportKey: string(data),
+		},
+	}
+	if _, err := c.client.GetKubeClient().CoreV1().ConfigMaps(ref.Namespace).Create(ctx, cm, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *controller) fetchPolicies() ([]kyvernov1.PolicyInterface, error) {
+	policies, err := utils.FetchClusterPolicies(c.cpolLister)
+	if err != nil {
+		return nil, err
+	}
+	pols, err := c.polLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, pol := range pols {
+		policies = append(policies, pol)
+	}
+	return policies, nil
+}
+
+// scan evaluates the selected policies against the selected resources.
+func (c *controller) scan(ctx context.Context, logger logr.Logger, spec *kyvernov2alpha1.ComplianceScanSpec) ([]policyreportv1alpha2.PolicyReportResult, error) {
+	policies, err := c.fetchPolicies()
+	if err != nil {
+		return nil, err
+	}
+	policies, err = selectPolicies(spec, policies...)
+	if err != nil {
+		return nil, err
+	}
+	if len(policies) == 0 {
+		return nil, errors.New("no policy selected")
+	}
+	kinds := sets.New(spec.Resources.Kinds...)
+	if kinds.Len() == 0 {
+		kinds = utils.BuildKindSet(logger, c.config.GetAutogenControllers(), policies...)
+	}
+	scanner := utils.NewScanner(logger, c.engine, c.config, c.jp)
+	seen := sets.New[types.UID]()
+	var results []policyreportv1alpha2.PolicyReportResult
+	for _, selector := range sets.List(kinds) {
+		group, version, kind, subresource := kubeutils.ParseKindSelector(selector)
+		if subresource != "" {
+			continue
+		}
+		apis, err := c.client.Discovery().FindResources(group, version, kind, subresource)
+		if err != nil {
+			return nil, err
+		}
+		for api, resource := range apis {
+			if !reportutils.IsGvkSupported(api.GroupVersionKind()) || !slices.Contains(resource.Verbs, "list") {
+				continue
+			}
+			namespaces := spec.Resources.Namespaces
+			if !resource.Namespaced && len(namespaces) != 0 {
+				continue
+			}
+			if !resource.Namespaced || len(namespaces) == 0 {
+				namespaces = []string{""}
+			}
+			for _, namespace := range namespaces {
+				list, err := c.client.ListResource(ctx, api.GroupVersion.String(), api.Kind, namespace, spec.Resources.Selector)
+				if err != nil {
+					return nil, err
+				}
+				for i := range list.Items {
+					resource := list.Items[i]
+					if seen.Has(resource.GetUID()) {
+						continue
+					}
+					seen.Insert(resource.GetUID())
+					resourceResults, err := c.scanResource(ctx, scanner, resource, policies...)
+					if err != nil {
+						return nil, err
+					}
+					results = append(results, resourceResults...)
+				}
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *controller) scanResource(ctx context.Context, scanner utils.Scanner, resource unstructured.Unstructured, policies ...kyvernov1.PolicyInterface) ([]policyreportv1alpha2.PolicyReportResult, error) {
+	var nsLabels map[string]string
+	if namespace := resource.GetNamespace(); namespace != "" {
+		ns, err := c.nsLister.Get(namespace)
+		if err != nil {
+			return nil, err
+		}
+		nsLabels = ns.GetLabels()
+	}
+	var generic []engineapi.GenericPolicy
+	for _, policy := range policiesFor(resource.GetNamespace(), policies...) {
+		generic = append(generic, engineapi.NewKyvernoPolicy(policy))
+	}
+	ref := corev1.ObjectReference{
+		APIVersion: resource.GetAPIVersion(),
+		Kind:       resource.GetKind(),
+		Namespace:  resource.GetNamespace(),
+		Name:       resource.GetName(),
+		UID:        resource.GetUID(),
+	}
+	var results []policyreportv1alpha2.PolicyReportResult
+	for _, result := range scanner.ScanResource(ctx, resource, nsLabels, generic...) {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.EngineResponse != nil {
+			for _, r := range reportutils.EngineResponseToReportResults(*result.EngineResponse, c.config) {
+				r.Resources = []corev1.ObjectReference{ref}
+				results = append(results, r)
+			}
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,74 @@
+package compliance
+
+import (
+	"encoding/json"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+)
+
+// selectPolicies returns the background policies selected by the scan.
+func selectPolicies(spec *kyvernov2alpha1.ComplianceScanSpec, policies ...kyvernov1.PolicyInterface) ([]kyvernov1.PolicyInterface, error) {
+	selector := labels.Everything()
+	if spec.PolicySelector != nil {
+		s, err := metav1.LabelSelectorAsSelector(spec.PolicySelector)
+		if err != nil {
+			return nil, err
+		}
+		selector = s
+	}
+	names := sets.New(spec.Policies...)
+	var selected []kyvernov1.PolicyInterface
+	for _, policy := range utils.RemoveNonBackgroundPolicies(policies...) {
+		if !selector.Matches(labels.Set(policy.GetLabels())) {
+			continue
+		}
+		if names.Len() != 0 {
+			key, err := cache.MetaNamespaceKeyFunc(policy)
+			if err != nil {
+				return nil, err
+			}
+			if !names.Has(key) {
+				continue
+			}
+		}
+		selected = append(selected, policy)
+	}
+	return selected, nil
+}
+
+// policiesFor returns the policies applying to resources in the given namespace.
+func policiesFor(namespace string, policies ...kyvernov1.PolicyInterface) []kyvernov1.PolicyInterface {
+	var applicable []kyvernov1.PolicyInterface
+	for _, policy := range policies {
+		if !policy.IsNamespaced() || policy.GetNamespace() == namespace {
+			applicable = append(applicable, policy)
+		}
+	}
+	return applicable
+}
+
+type export struct {
+	Scan           string                                    `json:"scan"`
+	StartTime      *metav1.Time                              `json:"startTime,omitempty"`
+	CompletionTime *metav1.Time                              `json:"completionTime,omitempty"`
+	Summary        policyreportv1alpha2.PolicyReportSummary  `json:"summary"`
+	Results        []policyreportv1alpha2.PolicyReportResult `json:"results,omitempty"`
+}
+
+// exportData returns the JSON document the results of a scan are exported as.
+func exportData(scan *kyvernov2alpha1.ComplianceScan) ([]byte, error) {
+	return json.Marshal(export{
+		Scan:           scan.GetName(),
+		StartTime:      scan.Status.StartTime,
+		CompletionTime: scan.Status.CompletionTime,
+		Summary:        scan.Status.Summary,
+		Results:        scan.Status.Results,
+	})
+}
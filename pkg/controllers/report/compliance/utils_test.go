@@ -0,0 +1,69 @@
+package compliance
+
+import (
+	"reflect"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_selectPolicies(t *testing.T) {
+	background := false
+	policies := []kyvernov1.PolicyInterface{
+		&kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "require-labels", Labels: map[string]string{"benchmark": "cis"}}},
+		&kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "disallow-latest"}},
+		&kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "admission-only"}, Spec: kyvernov1.Spec{Background: &background}},
+		&kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: "team", Name: "require-labels", Labels: map[string]string{"benchmark": "cis"}}},
+	}
+	tests := []struct {
+		name string
+		spec kyvernov2alpha1.ComplianceScanSpec
+		want []string
+	}{{
+		name: "all",
+		want: []string{"require-labels", "disallow-latest", "team/require-labels"},
+	}, {
+		name: "names",
+		spec: kyvernov2alpha1.ComplianceScanSpec{Policies: []string{"team/require-labels", "admission-only"}},
+		want: []string{"team/require-labels"},
+	}, {
+		name: "selector",
+		spec: kyvernov2alpha1.ComplianceScanSpec{PolicySelector: &metav1.LabelSelector{MatchLabels: map[string]string{"benchmark": "cis"}}},
+		want: []string{"require-labels", "team/require-labels"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := selectPolicies(&tt.spec, policies...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, policy := range selected {
+				if policy.IsNamespaced() {
+					got = append(got, policy.GetNamespace()+"/"+policy.GetName())
+				} else {
+					got = append(got, policy.GetName())
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("selectPolicies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_policiesFor(t *testing.T) {
+	policies := []kyvernov1.PolicyInterface{
+		&kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
+		&kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: "team", Name: "team"}},
+		&kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "other"}},
+	}
+	if got := len(policiesFor("team", policies...)); got != 2 {
+		t.Errorf("policiesFor(team) returned %d policies, want 2", got)
+	}
+	if got := len(policiesFor("", policies...)); got != 1 {
+		t.Errorf("policiesFor() returned %d policies, want 1", got)
+	}
+}
@@ -0,0 +1,142 @@
+package export
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "report-export-controller"
+	maxRetries     = 10
+)
+
+type controller struct {
+	// clients
+	client versioned.Interface
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	// exporter
+	exporter *exporter
+
+	// exported keeps track of the results already exported per report, it is not persisted
+	// and results are exported again when the controller restarts (at least once delivery)
+	lock     sync.Mutex
+	exported map[string]sets.Set[string]
+}
+
+func NewController(
+	client versioned.Interface,
+	metadataFactory metadatainformers.SharedInformerFactory,
+	sinks []Sink,
+	batchSize int,
+	batchInterval time.Duration,
+	sinkRetries int,
+) controllers.Controller {
+	polrInformer := metadataFactory.ForResource(policyreportv1alpha2.SchemeGroupVersion.WithResource("policyreports"))
+	cpolrInformer := metadataFactory.ForResource(policyreportv1alpha2.SchemeGroupVersion.WithResource("clusterpolicyreports"))
+	c := controller{
+		client:   client,
+		queue:    workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		exporter: newExporter(sinks, batchSize, batchInterval, sinkRetries),
+		exported: map[string]sets.Set[string]{},
+	}
+	if _, _, err := controllerutils.AddDefaultEventHandlers(logger, polrInformer.Informer(), c.queue); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	if _, _, err := controllerutils.AddDefaultEventHandlers(logger, cpolrInformer.Informer(), c.queue); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	return &c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile, c.exporter.run)
+}
+
+func (c *controller) getReport(ctx context.Context, namespace, name string) (kyvernov1alpha2.ReportInterface, *corev1.ObjectReference, error) {
+	if namespace == "" {
+		report, err := c.client.Wgpolicyk8sV1alpha2().ClusterPolicyReports().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, err
+		}
+		return report, report.Scope, nil
+	}
+	report, err := c.client.Wgpolicyk8sV1alpha2().PolicyReports(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	return report, report.Scope, nil
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	report, scope, err := c.getReport(ctx, namespace, name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			c.lock.Lock()
+			delete(c.exported, key)
+			c.lock.Unlock()
+			return nil
+		}
+		return err
+	}
+	if !controllerutils.IsManagedByKyverno(report) {
+		return nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	previous := c.exported[key]
+	current := sets.New[string]()
+	var records []Record
+	for _, result := range report.GetResults() {
+		hash := resultHash(result)
+		current.Insert(hash)
+		if previous.Has(hash) {
+			continue
+		}
+		records = append(records, Record{
+			Report:             name,
+			Namespace:          namespace,
+			Resource:           scope,
+			PolicyReportResult: result,
+		})
+	}
+	if err := c.exporter.add(records...); err != nil {
+		return err
+	}
+	logger.V(4).Info("queued results for export", "records", len(records))
+	c.exported[key] = current
+	return nil
+}
+
+// resultHash identifies a result, the timestamp is ignored so that unchanged results
+// are not exported again every time the resource is scanned.
+func resultHash(result policyreportv1alpha2.PolicyReportResult) string {
+	result.Timestamp = metav1.Timestamp{}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,155 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// pendingBatches is the number of batches the exporter buffers before refusing new records.
+const pendingBatches = 100
+
+var errExporterFull = errors.New("too many records waiting to be exported")
+
+type exporter struct {
+	sinks      []Sink
+	batchSize  int
+	interval   time.Duration
+	maxRetries int
+	backoff    time.Duration
+	metrics    exportMetrics
+
+	lock    sync.Mutex
+	pending []Record
+	flush   chan struct{}
+}
+
+func newExporter(sinks []Sink, batchSize int, interval time.Duration, maxRetries int) *exporter {
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	return &exporter{
+		sinks:      sinks,
+		batchSize:  batchSize,
+		interval:   interval,
+		maxRetries: maxRetries,
+		backoff:    time.Second,
+		metrics:    newExportMetrics(logger),
+		flush:      make(chan struct{}, 1),
+	}
+}
+
+// add queues records for export, the batch is flushed early when it is full.
+// An error is returned when the buffer is full, the caller is expected to retry later.
+func (e *exporter) add(records ...Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if len(e.pending)+len(records) > e.batchSize*pendingBatches {
+		return errExporterFull
+	}
+	e.pending = append(e.pending, records...)
+	if len(e.pending) >= e.batchSize {
+		select {
+		case e.flush <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// next removes and returns the next batch of pending records.
+func (e *exporter) next() []Record {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	n := len(e.pending)
+	if n > e.batchSize {
+		n = e.batchSize
+	}
+	batch := e.pending[:n:n]
+	e.pending = e.pending[n:]
+	return batch
+}
+
+func (e *exporter) run(ctx context.Context, logger logr.Logger) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-e.flush:
+		}
+		for batch := e.next(); len(batch) != 0; batch = e.next() {
+			e.export(ctx, logger, batch)
+		}
+	}
+}
+
+// export sends a batch to every sink, retrying with an exponential backoff.
+// A sink failing does not prevent the batch from being sent to the other sinks.
+func (e *exporter) export(ctx context.Context, logger logr.Logger, batch []Record) {
+	for _, sink := range e.sinks {
+		logger := logger.WithValues("sink", sink.Name(), "records", len(batch))
+		backoff := e.backoff
+		var err error
+		for attempt := 0; attempt <= e.maxRetries; attempt++ {
+			if attempt != 0 {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(backoff):
+				}
+				backoff *= 2
+			}
+			if err = sink.Send(ctx, batch); err == nil {
+				break
+			}
+			logger.V(3).Info("failed to export records", "attempt", attempt, "error", err.Error())
+		}
+		if err != nil {
+			logger.Error(err, "failed to export records, dropping them")
+			e.metrics.record(ctx, sink.Name(), "failure", len(batch))
+		} else {
+			logger.V(4).Info("records exported")
+			e.metrics.record(ctx, sink.Name(), "success", len(batch))
+		}
+	}
+}
+
+type exportMetrics struct {
+	recordsTotal metric.Int64Counter
+}
+
+func newExportMetrics(logger logr.Logger) exportMetrics {
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	recordsTotal, err := meter.Int64Counter(
+		"kyverno_report_export_records",
+		metric.WithDescription("can be used to track the number of policy report results exported to external sinks."),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_report_export_records")
+	}
+	return exportMetrics{
+		recordsTotal: recordsTotal,
+	}
+}
+
+func (m exportMetrics) record(ctx context.Context, sink, status string, records int) {
+	if m.recordsTotal != nil {
+		m.recordsTotal.Add(ctx, int64(records), metric.WithAttributes(
+			attribute.String("sink", sink),
+			attribute.String("status", status),
+		))
+	}
+}
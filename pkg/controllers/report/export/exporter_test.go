@@ -0,0 +1,94 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"gotest.tools/assert"
+)
+
+type fakeSink struct {
+	lock     sync.Mutex
+	failures int
+	calls    int
+	batches  [][]Record
+}
+
+func (s *fakeSink) Name() string {
+	return "fake"
+}
+
+func (s *fakeSink) Send(_ context.Context, records []Record) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.calls++
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("failure")
+	}
+	s.batches = append(s.batches, records)
+	return nil
+}
+
+func records(n int) []Record {
+	var records []Record
+	for i := 0; i < n; i++ {
+		records = append(records, Record{Report: "report", PolicyReportResult: policyreportv1alpha2.PolicyReportResult{Policy: "policy"}})
+	}
+	return records
+}
+
+func Test_exporter_batches(t *testing.T) {
+	e := newExporter(nil, 2, 0, 0)
+	assert.NilError(t, e.add(records(5)...))
+	assert.Equal(t, len(e.next()), 2)
+	assert.Equal(t, len(e.next()), 2)
+	assert.Equal(t, len(e.next()), 1)
+	assert.Equal(t, len(e.next()), 0)
+}
+
+func Test_exporter_full(t *testing.T) {
+	e := newExporter(nil, 1, 0, 0)
+	assert.NilError(t, e.add(records(pendingBatches)...))
+	assert.Equal(t, e.add(records(1)...), errExporterFull)
+}
+
+func Test_exporter_retries(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		retries  int
+		calls    int
+		batches  int
+	}{{
+		name:    "success",
+		calls:   1,
+		batches: 1,
+	}, {
+		name:     "success after retry",
+		failures: 2,
+		retries:  2,
+		calls:    3,
+		batches:  1,
+	}, {
+		name:     "dropped",
+		failures: 3,
+		retries:  2,
+		calls:    3,
+		batches:  0,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &fakeSink{failures: tt.failures}
+			e := newExporter([]Sink{sink}, 10, 0, tt.retries)
+			e.backoff = 0
+			e.export(context.TODO(), logr.Discard(), records(3))
+			assert.Equal(t, sink.calls, tt.calls)
+			assert.Equal(t, len(sink.batches), tt.batches)
+		})
+	}
+}
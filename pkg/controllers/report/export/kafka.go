@@ -0,0 +1,52 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type kafkaSink struct {
+	client *http.Client
+	url    string
+	topic  string
+}
+
+type kafkaRecord struct {
+	Key   string `json:"key,omitempty"`
+	Value Record `json:"value"`
+}
+
+// NewKafkaSink returns a sink producing records to a Kafka topic through a Kafka REST proxy (v2 API).
+// Records are keyed by report so that results of the same report land in the same partition.
+func NewKafkaSink(client *http.Client, proxyURL, topic string) Sink {
+	return &kafkaSink{
+		client: client,
+		url:    strings.TrimSuffix(proxyURL, "/") + "/topics/" + url.PathEscape(topic),
+		topic:  topic,
+	}
+}
+
+func (s *kafkaSink) Name() string {
+	return "kafka"
+}
+
+func (s *kafkaSink) Send(ctx context.Context, records []Record) error {
+	payload := struct {
+		Records []kafkaRecord `json:"records"`
+	}{}
+	for _, record := range records {
+		key := record.Report
+		if record.Namespace != "" {
+			key = record.Namespace + "/" + record.Report
+		}
+		payload.Records = append(payload.Records, kafkaRecord{Key: key, Value: record})
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return send(ctx, s.client, http.MethodPost, s.url, "application/vnd.kafka.json.v2+json", body, nil)
+}
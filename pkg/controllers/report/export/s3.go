@@ -0,0 +1,84 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+)
+
+type s3Sink struct {
+	client      *http.Client
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	bucket      string
+	prefix      string
+	region      string
+	endpoint    string
+	sequence    atomic.Uint64
+	now         func() time.Time
+}
+
+// NewS3Sink returns a sink writing every batch of records as a JSON lines object in an S3 bucket.
+// Credentials are resolved with the default AWS credentials chain. When an endpoint is given,
+// objects are addressed with path style requests, this is useful for S3 compatible stores.
+func NewS3Sink(ctx context.Context, client *http.Client, bucket, prefix, region, endpoint string) (Sink, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration (%w)", err)
+	}
+	return &s3Sink{
+		client:      client,
+		credentials: cfg.Credentials,
+		signer:      v4.NewSigner(),
+		bucket:      bucket,
+		prefix:      prefix,
+		region:      region,
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+		now:         time.Now,
+	}, nil
+}
+
+func (s *s3Sink) Name() string {
+	return "s3"
+}
+
+func (s *s3Sink) Send(ctx context.Context, records []Record) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	now := s.now().UTC()
+	key := path.Join(s.prefix, now.Format("2006/01/02"), fmt.Sprintf("%d-%d.jsonl", now.UnixNano(), s.sequence.Add(1)))
+	payloadHash := sha256.Sum256(body.Bytes())
+	return send(ctx, s.client, http.MethodPut, s.objectURL(key), "application/x-ndjson", body.Bytes(), func(req *http.Request) error {
+		credentials, err := s.credentials.Retrieve(ctx)
+		if err != nil {
+			return err
+		}
+		hash := hex.EncodeToString(payloadHash[:])
+		req.Header.Set("X-Amz-Content-Sha256", hash)
+		return s.signer.SignHTTP(ctx, credentials, req, hash, "s3", s.region, now)
+	})
+}
+
+func (s *s3Sink) objectURL(key string) string {
+	if s.endpoint != "" {
+		return s.endpoint + "/" + s.bucket + "/" + key
+	}
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.bucket, s.region, key)
+}
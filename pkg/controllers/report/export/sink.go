@@ -0,0 +1,54 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Record is a policy report result sent to the sinks.
+type Record struct {
+	// Report is the name of the policy report the result comes from
+	Report string `json:"report"`
+	// Namespace is the namespace of the policy report, empty for cluster policy reports
+	Namespace string `json:"namespace,omitempty"`
+	// Resource is the resource the result applies to
+	Resource *corev1.ObjectReference `json:"resource,omitempty"`
+	policyreportv1alpha2.PolicyReportResult
+}
+
+// Sink is an external system policy report results are exported to.
+type Sink interface {
+	// Name returns the name of the sink, it is used in logs and metrics
+	Name() string
+	// Send sends a batch of records to the sink
+	Send(context.Context, []Record) error
+}
+
+func send(ctx context.Context, client *http.Client, method, url, contentType string, body []byte, sign func(*http.Request) error) error {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	if sign != nil {
+		if err := sign(req); err != nil {
+			return err
+		}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s %s returned status %d: %s", method, url, resp.StatusCode, bytes.TrimSpace(message))
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_webhookSink(t *testing.T) {
+	var received []Record
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Method, http.MethodPost)
+		assert.Equal(t, r.Header.Get("Content-Type"), "application/json")
+		assert.NilError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+	sink := NewWebhookSink(server.Client(), server.URL)
+	assert.NilError(t, sink.Send(context.TODO(), records(2)))
+	assert.Equal(t, len(received), 2)
+	assert.Equal(t, received[0].Policy, "policy")
+}
+
+func Test_webhookSink_error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	sink := NewWebhookSink(server.Client(), server.URL)
+	assert.ErrorContains(t, sink.Send(context.TODO(), records(1)), "returned status 503: unavailable")
+}
+
+func Test_kafkaSink(t *testing.T) {
+	var path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		assert.Equal(t, r.Header.Get("Content-Type"), "application/vnd.kafka.json.v2+json")
+		data, err := io.ReadAll(r.Body)
+		assert.NilError(t, err)
+		body = string(data)
+	}))
+	defer server.Close()
+	sink := NewKafkaSink(server.Client(), server.URL+"/", "results")
+	record := records(1)[0]
+	record.Namespace = "default"
+	assert.NilError(t, sink.Send(context.TODO(), []Record{record}))
+	assert.Equal(t, path, "/topics/results")
+	assert.Equal(t, body, `{"records":[{"key":"default/report","value":{"report":"report","namespace":"default","source":"","policy":"policy","timestamp":{"seconds":0,"nanos":0}}}]}`)
+}
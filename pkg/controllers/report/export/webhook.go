@@ -0,0 +1,32 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+type webhookSink struct {
+	client *http.Client
+	url    string
+}
+
+// NewWebhookSink returns a sink posting batches of records as a JSON array to a generic HTTP endpoint.
+func NewWebhookSink(client *http.Client, url string) Sink {
+	return &webhookSink{
+		client: client,
+		url:    url,
+	}
+}
+
+func (s *webhookSink) Name() string {
+	return "webhook"
+}
+
+func (s *webhookSink) Send(ctx context.Context, records []Record) error {
+	body, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	return send(ctx, s.client, http.MethodPost, s.url, "application/json", body, nil)
+}
@@ -11,6 +11,7 @@ import (
 	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers"
 	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
@@ -41,6 +42,8 @@ type Resource struct {
 	Namespace string
 	Name      string
 	Hash      string
+	// Owner is the controller owner of the resource, if any
+	Owner *metav1.OwnerReference
 }
 
 type EventType string
@@ -87,6 +90,10 @@ type controller struct {
 	lock            sync.RWMutex
 	dynamicWatchers map[schema.GroupVersionResource]*watcher
 	eventHandlers   []EventHandler
+
+	// config
+	configuration  config.Configuration
+	rolloutHistory bool
 }
 
 func NewController(
@@ -94,6 +101,8 @@ func NewController(
 	polInformer kyvernov1informers.PolicyInformer,
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
 	vapInformer admissionregistrationv1alpha1informers.ValidatingAdmissionPolicyInformer,
+	configuration config.Configuration,
+	rolloutHistory bool,
 ) Controller {
 	c := controller{
 		client:          client,
@@ -101,6 +110,8 @@ func NewController(
 		cpolLister:      cpolInformer.Lister(),
 		queue:           workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
 		dynamicWatchers: map[schema.GroupVersionResource]*watcher{},
+		configuration:   configuration,
+		rolloutHistory:  rolloutHistory,
 	}
 
 	if vapInformer != nil {
@@ -116,6 +127,8 @@ func NewController(
 	if _, _, err := controllerutils.AddDefaultEventHandlers(logger, cpolInformer.Informer(), c.queue); err != nil {
 		logger.Error(err, "failed to register event handlers")
 	}
+	// the watched kinds depend on the autogen controllers of the configuration
+	configuration.OnChanged(func() { c.queue.Add(ControllerName) })
 	return &c
 }
 
@@ -181,6 +194,7 @@ func (c *controller) startWatcher(ctx context.Context, logger logr.Logger, gvr s
 				Hash:      hash,
 				Namespace: obj.GetNamespace(),
 				Name:      obj.GetName(),
+				Owner:     metav1.GetControllerOf(&obj),
 			}
 			c.notify(Added, uid, gvk, hashes[uid])
 		}
@@ -235,7 +249,11 @@ func (c *controller) updateDynamicWatchers(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	kinds := utils.BuildKindSet(logger, utils.RemoveNonValidationPolicies(append(clusterPolicies, policies...)...)...)
+	kinds := utils.BuildKindSet(logger, c.configuration.GetAutogenControllers(), utils.RemoveNonValidationPolicies(append(clusterPolicies, policies...)...)...)
+	// watch the revisions retained in the rollout history of the pod controllers
+	if c.rolloutHistory {
+		kinds.Insert(utils.RolloutHistoryKinds(kinds)...)
+	}
 	gvkToGvr := map[schema.GroupVersionKind]schema.GroupVersionResource{}
 	for _, policyKind := range sets.List(kinds) {
 		group, version, kind, subresource := kubeutils.ParseKindSelector(policyKind)
@@ -332,6 +350,7 @@ func (c *controller) updateHash(eventType EventType, obj *unstructured.Unstructu
 				Hash:      hash,
 				Namespace: obj.GetNamespace(),
 				Name:      obj.GetName(),
+				Owner:     metav1.GetControllerOf(obj),
 			}
 			c.notify(eventType, uid, watcher.gvk, watcher.hashes[uid])
 		}
@@ -0,0 +1,268 @@
+package simulation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "policy-simulation-controller"
+	maxRetries     = 10
+	// maxResults is the maximum number of results recorded in the status of a simulation
+	maxResults = 1000
+)
+
+type controller struct {
+	// clients
+	client        dclient.Interface
+	kyvernoClient versioned.Interface
+	engine        engineapi.Engine
+
+	// listers
+	simulationLister kyvernov2alpha1listers.PolicySimulationLister
+	nsLister         corev1listers.NamespaceLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	// config
+	config config.Configuration
+	jp     jmespath.Interface
+}
+
+func NewController(
+	client dclient.Interface,
+	kyvernoClient versioned.Interface,
+	engine engineapi.Engine,
+	simulationInformer kyvernov2alpha1informers.PolicySimulationInformer,
+	nsInformer corev1informers.NamespaceInformer,
+	config config.Configuration,
+	jp jmespath.Interface,
+) controllers.Controller {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+	c := &controller{
+		client:           client,
+		kyvernoClient:    kyvernoClient,
+		engine:           engine,
+		simulationLister: simulationInformer.Lister(),
+		nsLister:         nsInformer.Lister(),
+		queue:            queue,
+		config:           config,
+		jp:               jp,
+	}
+	if _, _, err := controllerutils.AddDefaultEventHandlers(logger, simulationInformer.Informer(), queue); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, _, _, name string) error {
+	simulation, err := c.simulationLister.Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	// a simulation runs once, it is only deleted afterwards
+	if simulation.Status.IsDone() {
+		return c.cleanup(ctx, logger, simulation)
+	}
+	latest := simulation.DeepCopy()
+	policy := candidatePolicy(latest)
+	if invalid := latest.Validate(); len(invalid) != 0 {
+		err = invalid.ToAggregate()
+	} else if invalid := policyvalidation.ValidateVariables(policy, true); invalid != nil {
+		err = fmt.Errorf("the policy can't be evaluated against existing resources: %w", invalid)
+	}
+	if err != nil {
+		if err := c.complete(ctx, latest, nil, err); err != nil {
+			return err
+		}
+		return c.cleanup(ctx, logger, latest)
+	}
+	if latest.Status.State != kyvernov2alpha1.PolicySimulationRunning {
+		now := metav1.Now()
+		latest.Status.State = kyvernov2alpha1.PolicySimulationRunning
+		latest.Status.StartTime = &now
+		latest, err = c.kyvernoClient.KyvernoV2alpha1().PolicySimulations().UpdateStatus(ctx, latest, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+	}
+	logger.V(2).Info("running policy simulation")
+	results, err := c.simulate(ctx, logger, &latest.Spec, policy)
+	if err := c.complete(ctx, latest, results, err); err != nil {
+		return err
+	}
+	return c.cleanup(ctx, logger, latest)
+}
+
+// complete records the outcome of the simulation and its expiration time, the status is not updated afterwards.
+func (c *controller) complete(ctx context.Context, simulation *kyvernov2alpha1.PolicySimulation, results []policyreportv1alpha2.PolicyReportResult, err error) error {
+	now := metav1.Now()
+	expiration := metav1.NewTime(now.Add(simulation.GetTTL()))
+	status := &simulation.Status
+	status.CompletionTime = &now
+	status.ExpirationTime = &expiration
+	if err != nil {
+		status.State = kyvernov2alpha1.PolicySimulationFailed
+		status.Message = err.Error()
+		status.SetReady(false, err.Error())
+	} else {
+		reportutils.SortReportResults(results)
+		status.State = kyvernov2alpha1.PolicySimulationCompleted
+		status.Summary = reportutils.CalculateSummary(results)
+		status.Results, status.Message = truncateResults(results, maxResults)
+		status.SetReady(true, status.Message)
+	}
+	updated, updateErr := c.kyvernoClient.KyvernoV2alpha1().PolicySimulations().UpdateStatus(ctx, simulation, metav1.UpdateOptions{})
+	if updateErr != nil {
+		return updateErr
+	}
+	updated.DeepCopyInto(simulation)
+	return nil
+}
+
+// cleanup deletes the simulation once expired, until then it is enqueued again when it expires.
+func (c *controller) cleanup(ctx context.Context, logger logr.Logger, simulation *kyvernov2alpha1.PolicySimulation) error {
+	if simulation.Status.ExpirationTime == nil {
+		return nil
+	}
+	if remaining := time.Until(simulation.Status.ExpirationTime.Time); remaining > 0 {
+		c.queue.AddAfter(simulation.GetName(), remaining)
+		return nil
+	}
+	logger.V(2).Info("deleting expired policy simulation")
+	err := c.kyvernoClient.KyvernoV2alpha1().PolicySimulations().Delete(ctx, simulation.GetName(), metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// simulate evaluates the candidate policy against the selected resources.
+func (c *controller) simulate(ctx context.Context, logger logr.Logger, spec *kyvernov2alpha1.PolicySimulationSpec, policy kyvernov1.PolicyInterface) ([]policyreportv1alpha2.PolicyReportResult, error) {
+	kinds := sets.New(spec.Resources.Kinds...)
+	if kinds.Len() == 0 {
+		kinds = utils.BuildKindSet(logger, c.config.GetAutogenControllers(), policy)
+	}
+	if kinds.Len() == 0 {
+		return nil, errors.New("the policy has no validate or verifyImages rule to simulate")
+	}
+	namespaces := spec.Resources.Namespaces
+	if spec.Namespace != "" {
+		namespaces = []string{spec.Namespace}
+	}
+	scanner := utils.NewScanner(logger, c.engine, c.config, c.jp)
+	seen := sets.New[types.UID]()
+	var results []policyreportv1alpha2.PolicyReportResult
+	for _, selector := range sets.List(kinds) {
+		group, version, kind, subresource := kubeutils.ParseKindSelector(selector)
+		if subresource != "" {
+			continue
+		}
+		apis, err := c.client.Discovery().FindResources(group, version, kind, subresource)
+		if err != nil {
+			return nil, err
+		}
+		for api, resource := range apis {
+			if !reportutils.IsGvkSupported(api.GroupVersionKind()) || !slices.Contains(resource.Verbs, "list") {
+				continue
+			}
+			if !resource.Namespaced && len(namespaces) != 0 {
+				continue
+			}
+			listNamespaces := namespaces
+			if !resource.Namespaced || len(listNamespaces) == 0 {
+				listNamespaces = []string{""}
+			}
+			for _, namespace := range listNamespaces {
+				list, err := c.client.ListResource(ctx, api.GroupVersion.String(), api.Kind, namespace, spec.Resources.Selector)
+				if err != nil {
+					return nil, err
+				}
+				for i := range list.Items {
+					resource := list.Items[i]
+					if seen.Has(resource.GetUID()) {
+						continue
+					}
+					seen.Insert(resource.GetUID())
+					resourceResults, err := c.simulateResource(ctx, scanner, resource, policy)
+					if err != nil {
+						return nil, err
+					}
+					results = append(results, resourceResults...)
+				}
+			}
+		}
+	}
+	return results, nil
+}
+
+func (c *controller) simulateResource(ctx context.Context, scanner utils.Scanner, resource unstructured.Unstructured, policy kyvernov1.PolicyInterface) ([]policyreportv1alpha2.PolicyReportResult, error) {
+	var nsLabels map[string]string
+	if namespace := resource.GetNamespace(); namespace != "" {
+		ns, err := c.nsLister.Get(namespace)
+		if err != nil {
+			return nil, err
+		}
+		nsLabels = ns.GetLabels()
+	}
+	ref := corev1.ObjectReference{
+		APIVersion: resource.GetAPIVersion(),
+		Kind:       resource.GetKind(),
+		Namespace:  resource.GetNamespace(),
+		Name:       resource.GetName(),
+		UID:        resource.GetUID(),
+	}
+	var results []policyreportv1alpha2.PolicyReportResult
+	for _, result := range scanner.ScanResource(ctx, resource, nsLabels, engineapi.NewKyvernoPolicy(policy)) {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.EngineResponse != nil {
+			for _, r := range reportutils.EngineResponseToReportResults(*result.EngineResponse, c.config) {
+				r.Resources = []corev1.ObjectReference{ref}
+				results = append(results, r)
+			}
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,37 @@
+package simulation
+
+import (
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// candidatePolicy returns the policy evaluated by a simulation, named after it.
+// The candidate is always in audit mode and processed in background.
+func candidatePolicy(simulation *kyvernov2alpha1.PolicySimulation) kyvernov1.PolicyInterface {
+	spec := simulation.Spec.Policy.DeepCopy()
+	background := true
+	spec.Background = &background
+	spec.ValidationFailureAction = kyvernov1.Audit
+	spec.ValidationFailureActionOverrides = nil
+	meta := metav1.ObjectMeta{
+		Name: simulation.GetName(),
+		UID:  simulation.GetUID(),
+	}
+	if simulation.Spec.Namespace != "" {
+		meta.Namespace = simulation.Spec.Namespace
+		return &kyvernov1.Policy{ObjectMeta: meta, Spec: *spec}
+	}
+	return &kyvernov1.ClusterPolicy{ObjectMeta: meta, Spec: *spec}
+}
+
+// truncateResults keeps at most max results and returns the message describing the results recorded.
+func truncateResults(results []policyreportv1alpha2.PolicyReportResult, max int) ([]policyreportv1alpha2.PolicyReportResult, string) {
+	if len(results) <= max {
+		return results, fmt.Sprintf("%d results recorded", len(results))
+	}
+	return results[:max], fmt.Sprintf("%d results recorded, %d results omitted", max, len(results)-max)
+}
@@ -0,0 +1,58 @@
+package simulation
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_candidatePolicy(t *testing.T) {
+	background := false
+	simulation := &kyvernov2alpha1.PolicySimulation{
+		ObjectMeta: metav1.ObjectMeta{Name: "require-labels", UID: "uid"},
+		Spec: kyvernov2alpha1.PolicySimulationSpec{
+			Policy: kyvernov1.Spec{
+				ValidationFailureAction:          kyvernov1.Enforce,
+				ValidationFailureActionOverrides: []kyvernov1.ValidationFailureActionOverride{{Action: kyvernov1.Enforce}},
+				Background:                       &background,
+			},
+		},
+	}
+	policy := candidatePolicy(simulation)
+	if policy.IsNamespaced() {
+		t.Fatal("expected a cluster policy")
+	}
+	if policy.GetName() != "require-labels" || policy.GetUID() != "uid" {
+		t.Errorf("unexpected policy identity %s/%s", policy.GetName(), policy.GetUID())
+	}
+	spec := policy.GetSpec()
+	if spec.ValidationFailureAction != kyvernov1.Audit || spec.ValidationFailureActionOverrides != nil {
+		t.Error("expected the candidate policy to be in audit mode")
+	}
+	if !spec.BackgroundProcessingEnabled() {
+		t.Error("expected the candidate policy to be processed in background")
+	}
+	if simulation.Spec.Policy.ValidationFailureAction != kyvernov1.Enforce || *simulation.Spec.Policy.Background {
+		t.Error("expected the simulation to be left unchanged")
+	}
+	simulation.Spec.Namespace = "team"
+	policy = candidatePolicy(simulation)
+	if !policy.IsNamespaced() || policy.GetNamespace() != "team" {
+		t.Errorf("expected a policy in namespace team, got %q", policy.GetNamespace())
+	}
+}
+
+func Test_truncateResults(t *testing.T) {
+	results := make([]policyreportv1alpha2.PolicyReportResult, 3)
+	truncated, message := truncateResults(results, 5)
+	if len(truncated) != 3 || message != "3 results recorded" {
+		t.Errorf("unexpected truncation: %d, %q", len(truncated), message)
+	}
+	truncated, message = truncateResults(results, 2)
+	if len(truncated) != 2 || message != "2 results recorded, 1 results omitted" {
+		t.Errorf("unexpected truncation: %d, %q", len(truncated), message)
+	}
+}
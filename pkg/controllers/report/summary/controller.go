@@ -0,0 +1,141 @@
+package summary
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "compliance-summary-controller"
+	maxRetries     = 10
+	listLimit      = 1000
+)
+
+type controller struct {
+	// clients
+	client versioned.Interface
+
+	// listers
+	summaryLister kyvernov2alpha1listers.ClusterComplianceSummaryLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+}
+
+func NewController(
+	client versioned.Interface,
+	summaryInformer kyvernov2alpha1informers.ClusterComplianceSummaryInformer,
+) controllers.Controller {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+	c := &controller{
+		client:        client,
+		summaryLister: summaryInformer.Lister(),
+		queue:         queue,
+	}
+	if _, _, err := controllerutils.AddDefaultEventHandlers(logger, summaryInformer.Informer(), queue); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, name string) error {
+	summary, err := c.summaryLister.Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	interval := summary.Spec.GetInterval()
+	now := time.Now()
+	// the summary is also enqueued when its status is updated, wait for the next sample
+	if last := summary.Status.LastUpdateTime; last != nil {
+		if next := last.Add(interval); now.Before(next) {
+			c.queue.AddAfter(key, next.Sub(now))
+			return nil
+		}
+	}
+	latest := summary.DeepCopy()
+	if invalid := summary.Validate(); len(invalid) != 0 {
+		latest.Status.SetReady(false, invalid.ToAggregate().Error())
+		_, err := c.client.KyvernoV2alpha1().ClusterComplianceSummaries().UpdateStatus(ctx, latest, metav1.UpdateOptions{})
+		return err
+	}
+	counter := newCounter(summary.Spec.Policies...)
+	if err := c.count(ctx, counter); err != nil {
+		return err
+	}
+	counts := counter.result()
+	windows := summary.Spec.GetWindows()
+	status := &latest.Status
+	status.Samples = recordSample(status.Samples, counts, now, windows)
+	status.Policies = computeTrends(counts, status.Samples, now, windows)
+	status.Summary = total(counts)
+	status.LastUpdateTime = &metav1.Time{Time: now}
+	status.SetReady(true, fmt.Sprintf("%d policies tracked", len(counts)))
+	if _, err := c.client.KyvernoV2alpha1().ClusterComplianceSummaries().UpdateStatus(ctx, latest, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	logger.V(3).Info("compliance summary updated", "policies", len(counts))
+	c.queue.AddAfter(key, interval)
+	return nil
+}
+
+// count accumulates the results of all the cluster and namespaced policy reports.
+func (c *controller) count(ctx context.Context, counter *counter) error {
+	next := ""
+	for {
+		cpolrs, err := c.client.Wgpolicyk8sV1alpha2().ClusterPolicyReports().List(ctx, metav1.ListOptions{
+			// canary results are not enforced, they don't count towards compliance
+			LabelSelector: "!" + reportutils.LabelCanaryReport,
+			Limit:         listLimit,
+			Continue:      next,
+		})
+		if err != nil {
+			return err
+		}
+		next = cpolrs.Continue
+		for i := range cpolrs.Items {
+			counter.add(cpolrs.Items[i].Results...)
+		}
+		if next == "" {
+			break
+		}
+	}
+	for {
+		polrs, err := c.client.Wgpolicyk8sV1alpha2().PolicyReports(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+			// canary results are not enforced, they don't count towards compliance
+			LabelSelector: "!" + reportutils.LabelCanaryReport,
+			Limit:         listLimit,
+			Continue:      next,
+		})
+		if err != nil {
+			return err
+		}
+		next = polrs.Continue
+		for i := range polrs.Items {
+			counter.add(polrs.Items[i].Results...)
+		}
+		if next == "" {
+			return nil
+		}
+	}
+}
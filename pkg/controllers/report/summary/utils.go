@@ -0,0 +1,138 @@
+package summary
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// samplesPerWindow bounds the number of samples kept for the shortest window,
+// deltas over a window are computed from a sample at most window / samplesPerWindow off.
+const samplesPerWindow = 12
+
+// counter accumulates the results of the tracked policies.
+type counter struct {
+	policies sets.Set[string]
+	counts   map[string]*kyvernov2alpha1.PolicyComplianceCount
+}
+
+func newCounter(policies ...string) *counter {
+	return &counter{
+		policies: sets.New(policies...),
+		counts:   map[string]*kyvernov2alpha1.PolicyComplianceCount{},
+	}
+}
+
+func (c *counter) add(results ...policyreportv1alpha2.PolicyReportResult) {
+	for _, result := range results {
+		if result.Policy == "" || (c.policies.Len() != 0 && !c.policies.Has(result.Policy)) {
+			continue
+		}
+		count := c.counts[result.Policy]
+		if count == nil {
+			count = &kyvernov2alpha1.PolicyComplianceCount{Policy: result.Policy}
+			c.counts[result.Policy] = count
+		}
+		switch result.Result {
+		case policyreportv1alpha2.StatusPass:
+			count.Pass++
+		case policyreportv1alpha2.StatusFail:
+			count.Fail++
+		case policyreportv1alpha2.StatusWarn:
+			count.Warn++
+		case policyreportv1alpha2.StatusError:
+			count.Error++
+		case policyreportv1alpha2.StatusSkip:
+			count.Skip++
+		}
+	}
+}
+
+// result returns the counts sorted by policy.
+func (c *counter) result() []kyvernov2alpha1.PolicyComplianceCount {
+	counts := make([]kyvernov2alpha1.PolicyComplianceCount, 0, len(c.counts))
+	for _, count := range c.counts {
+		counts = append(counts, *count)
+	}
+	slices.SortFunc(counts, func(a, b kyvernov2alpha1.PolicyComplianceCount) int {
+		return strings.Compare(a.Policy, b.Policy)
+	})
+	return counts
+}
+
+// recordSample appends the counts to the samples when the latest sample is old enough,
+// and drops the samples that are not needed anymore to compute the deltas over the longest window.
+func recordSample(samples []kyvernov2alpha1.ComplianceSample, counts []kyvernov2alpha1.PolicyComplianceCount, now time.Time, windows []time.Duration) []kyvernov2alpha1.ComplianceSample {
+	if len(windows) == 0 {
+		return nil
+	}
+	resolution := slices.Min(windows) / samplesPerWindow
+	if len(samples) == 0 || now.Sub(samples[len(samples)-1].Time.Time) >= resolution {
+		samples = append(samples, kyvernov2alpha1.ComplianceSample{
+			Time:     metav1.NewTime(now),
+			Policies: counts,
+		})
+	}
+	// keep the most recent sample older than the longest window, it is the baseline of that window
+	start := baseline(samples, now.Add(-slices.Max(windows)))
+	return slices.Clone(samples[start:])
+}
+
+// baseline returns the index of the most recent sample recorded at or before the given time,
+// or the oldest sample when all of them are more recent.
+func baseline(samples []kyvernov2alpha1.ComplianceSample, at time.Time) int {
+	index := 0
+	for i := range samples {
+		if samples[i].Time.Time.After(at) {
+			break
+		}
+		index = i
+	}
+	return index
+}
+
+// computeTrends builds the per policy summaries with the deltas over each window.
+func computeTrends(counts []kyvernov2alpha1.PolicyComplianceCount, samples []kyvernov2alpha1.ComplianceSample, now time.Time, windows []time.Duration) []kyvernov2alpha1.PolicyComplianceSummary {
+	summaries := make([]kyvernov2alpha1.PolicyComplianceSummary, 0, len(counts))
+	for _, count := range counts {
+		summaries = append(summaries, kyvernov2alpha1.PolicyComplianceSummary{PolicyComplianceCount: count})
+	}
+	if len(samples) == 0 {
+		return summaries
+	}
+	for _, window := range windows {
+		sample := samples[baseline(samples, now.Add(-window))]
+		previous := map[string]kyvernov2alpha1.PolicyComplianceCount{}
+		for _, count := range sample.Policies {
+			previous[count.Policy] = count
+		}
+		for i := range summaries {
+			before := previous[summaries[i].Policy]
+			summaries[i].Trends = append(summaries[i].Trends, kyvernov2alpha1.ComplianceTrend{
+				Window: metav1.Duration{Duration: window},
+				Since:  sample.Time,
+				Pass:   summaries[i].Pass - before.Pass,
+				Fail:   summaries[i].Fail - before.Fail,
+				Warn:   summaries[i].Warn - before.Warn,
+			})
+		}
+	}
+	return summaries
+}
+
+// total sums the counts of all policies.
+func total(counts []kyvernov2alpha1.PolicyComplianceCount) (summary policyreportv1alpha2.PolicyReportSummary) {
+	for _, count := range counts {
+		summary.Pass += count.Pass
+		summary.Fail += count.Fail
+		summary.Warn += count.Warn
+		summary.Error += count.Error
+		summary.Skip += count.Skip
+	}
+	return summary
+}
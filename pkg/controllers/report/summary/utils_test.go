@@ -0,0 +1,91 @@
+package summary
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_counter(t *testing.T) {
+	results := []policyreportv1alpha2.PolicyReportResult{
+		{Policy: "require-labels", Result: policyreportv1alpha2.StatusPass},
+		{Policy: "require-labels", Result: policyreportv1alpha2.StatusFail},
+		{Policy: "team/disallow-latest", Result: policyreportv1alpha2.StatusWarn},
+		{Policy: "untracked", Result: policyreportv1alpha2.StatusFail},
+	}
+	counter := newCounter("require-labels", "team/disallow-latest")
+	counter.add(results...)
+	got := counter.result()
+	want := []kyvernov2alpha1.PolicyComplianceCount{
+		{Policy: "require-labels", Pass: 1, Fail: 1},
+		{Policy: "team/disallow-latest", Warn: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("counter.result() = %v, want %v", got, want)
+	}
+	if summary := total(got); summary.Pass != 1 || summary.Fail != 1 || summary.Warn != 1 {
+		t.Errorf("total() = %v", summary)
+	}
+}
+
+func Test_recordSample(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	windows := []time.Duration{time.Hour}
+	var samples []kyvernov2alpha1.ComplianceSample
+	for i := 0; i <= 120; i++ {
+		samples = recordSample(samples, nil, start.Add(time.Duration(i)*time.Minute), windows)
+	}
+	// one sample every 5 minutes over the last hour, plus the baseline of the window
+	if len(samples) != 13 {
+		t.Fatalf("expected 13 samples, got %d", len(samples))
+	}
+	if got := samples[0].Time.Time; !got.Equal(start.Add(time.Hour)) {
+		t.Errorf("expected oldest sample at %v, got %v", start.Add(time.Hour), got)
+	}
+	// samples closer than the resolution are not recorded
+	samples = recordSample(samples, nil, start.Add(121*time.Minute), windows)
+	if len(samples) != 13 {
+		t.Errorf("expected 13 samples, got %d", len(samples))
+	}
+}
+
+func Test_computeTrends(t *testing.T) {
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	samples := []kyvernov2alpha1.ComplianceSample{{
+		Time:     metav1.NewTime(now.Add(-24 * time.Hour)),
+		Policies: []kyvernov2alpha1.PolicyComplianceCount{{Policy: "require-labels", Pass: 1, Fail: 10}},
+	}, {
+		Time:     metav1.NewTime(now.Add(-90 * time.Minute)),
+		Policies: []kyvernov2alpha1.PolicyComplianceCount{{Policy: "require-labels", Pass: 8, Fail: 3}},
+	}}
+	counts := []kyvernov2alpha1.PolicyComplianceCount{
+		{Policy: "require-labels", Pass: 10, Fail: 1},
+		{Policy: "disallow-latest", Pass: 2},
+	}
+	summaries := computeTrends(counts, samples, now, []time.Duration{time.Hour, 24 * time.Hour})
+	if len(summaries) != 2 {
+		t.Fatalf("expected 2 summaries, got %d", len(summaries))
+	}
+	want := []kyvernov2alpha1.ComplianceTrend{{
+		Window: metav1.Duration{Duration: time.Hour},
+		Since:  samples[1].Time,
+		Pass:   2,
+		Fail:   -2,
+	}, {
+		Window: metav1.Duration{Duration: 24 * time.Hour},
+		Since:  samples[0].Time,
+		Pass:   9,
+		Fail:   -9,
+	}}
+	if !reflect.DeepEqual(summaries[0].Trends, want) {
+		t.Errorf("computeTrends() = %v, want %v", summaries[0].Trends, want)
+	}
+	// policies missing from the baseline count from zero
+	if trend := summaries[1].Trends[0]; trend.Pass != 2 {
+		t.Errorf("expected pass delta of 2, got %d", trend.Pass)
+	}
+}
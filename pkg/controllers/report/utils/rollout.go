@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const annotationDeploymentRevision = "deployment.kubernetes.io/revision"
+
+// rolloutHistoryKinds maps the pod controllers to the kind of the revisions retained in their rollout history
+var rolloutHistoryKinds = map[string]string{
+	"Deployment":  "apps/v1/ReplicaSet",
+	"DaemonSet":   "apps/v1/ControllerRevision",
+	"StatefulSet": "apps/v1/ControllerRevision",
+}
+
+// RolloutHistoryKinds returns the kinds of the revisions retained in the rollout history of the given kinds
+func RolloutHistoryKinds(kinds sets.Set[string]) []string {
+	revisions := sets.New[string]()
+	for kind := range kinds {
+		_, _, kind, subresource := kubeutils.ParseKindSelector(kind)
+		if revision, ok := rolloutHistoryKinds[kind]; ok && subresource == "" {
+			revisions.Insert(revision)
+		}
+	}
+	return sets.List(revisions)
+}
+
+// RevisionOwner returns the owner of a revision retained in the rollout history of a pod controller,
+// it returns nil when the resource is not such a revision.
+func RevisionOwner(resource unstructured.Unstructured) *metav1.OwnerReference {
+	owner := metav1.GetControllerOf(&resource)
+	if owner == nil || rolloutHistoryKinds[owner.Kind] != resource.GetAPIVersion()+"/"+resource.GetKind() {
+		return nil
+	}
+	return owner
+}
+
+// RevisionTarget returns the resource a revision retained in the rollout history of a pod controller is evaluated as,
+// that is a resource of the kind of its owner with the pod template of the revision, and the revision number.
+// It returns nil when the resource is not a revision of the owner or when it is the active revision of the owner,
+// the one whose pod template is the current template of the owner, as the owner is evaluated already.
+func RevisionTarget(resource unstructured.Unstructured, owner unstructured.Unstructured) (*unstructured.Unstructured, string, error) {
+	ownerRef := RevisionOwner(resource)
+	if ownerRef == nil || ownerRef.UID != owner.GetUID() {
+		return nil, "", nil
+	}
+	var template map[string]interface{}
+	var revision string
+	var err error
+	if resource.GetKind() == "ReplicaSet" {
+		template, _, err = unstructured.NestedMap(resource.Object, "spec", "template")
+		revision = resource.GetAnnotations()[annotationDeploymentRevision]
+	} else {
+		// the revision data is a strategic merge patch replacing the pod template of the owner
+		template, _, err = unstructured.NestedMap(resource.Object, "data", "spec", "template")
+		delete(template, "$patch")
+		if number, found, _ := unstructured.NestedInt64(resource.Object, "revision"); found {
+			revision = strconv.FormatInt(number, 10)
+		}
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read the pod template of revision %s/%s: %w", resource.GetNamespace(), resource.GetName(), err)
+	}
+	if template == nil {
+		return nil, "", nil
+	}
+	current, _, err := unstructured.NestedMap(owner.Object, "spec", "template")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read the pod template of %s %s/%s: %w", owner.GetKind(), owner.GetNamespace(), owner.GetName(), err)
+	}
+	if equalIgnoreHash(template, current) {
+		return nil, "", nil
+	}
+	target := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	target.SetAPIVersion(ownerRef.APIVersion)
+	target.SetKind(ownerRef.Kind)
+	target.SetNamespace(resource.GetNamespace())
+	target.SetName(resource.GetName())
+	target.SetUID(resource.GetUID())
+	target.SetLabels(resource.GetLabels())
+	if err := unstructured.SetNestedMap(target.Object, template, "spec", "template"); err != nil {
+		return nil, "", err
+	}
+	return target, revision, nil
+}
+
+// equalIgnoreHash returns whether the pod template of a revision is the given pod template of its owner,
+// ignoring the pod-template-hash label added by the Deployment controller to the templates of its ReplicaSets
+func equalIgnoreHash(template, current map[string]interface{}) bool {
+	template = runtime.DeepCopyJSON(template)
+	unstructured.RemoveNestedField(template, "metadata", "labels", appsv1.DefaultDeploymentUniqueLabelKey)
+	return equality.Semantic.DeepEqual(template, current)
+}
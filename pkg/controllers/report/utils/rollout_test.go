@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestRolloutHistoryKinds(t *testing.T) {
+	assert.DeepEqual(t, RolloutHistoryKinds(sets.New("Pod", "Deployment", "apps/v1/StatefulSet", "DaemonSet", "Deployment/scale")), []string{"apps/v1/ControllerRevision", "apps/v1/ReplicaSet"})
+	assert.Equal(t, len(RolloutHistoryKinds(sets.New("Pod", "Job"))), 0)
+}
+
+func podTemplate(labels map[string]interface{}, image string) map[string]interface{} {
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{"labels": labels},
+		"spec":     map[string]interface{}{"containers": []interface{}{map[string]interface{}{"name": "app", "image": image}}},
+	}
+}
+
+func owner(kind, name, uid string, template map[string]interface{}) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": name, "namespace": "shop", "uid": uid},
+		"spec":       map[string]interface{}{"template": template},
+	}}
+}
+
+func TestRevisionTarget(t *testing.T) {
+	controllerRevision := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "ControllerRevision",
+		"metadata": map[string]interface{}{
+			"name":      "web-7d4b9c8f6",
+			"namespace": "shop",
+			"labels":    map[string]interface{}{"app": "web", "controller-revision-hash": "7d4b9c8f6"},
+			"ownerReferences": []interface{}{map[string]interface{}{
+				"apiVersion": "apps/v1", "kind": "StatefulSet", "name": "web", "uid": "1", "controller": true,
+			}},
+		},
+		"revision": int64(2),
+		"data": map[string]interface{}{"spec": map[string]interface{}{"template": map[string]interface{}{
+			"$patch":   "replace",
+			"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": "web"}},
+			"spec":     map[string]interface{}{"containers": []interface{}{map[string]interface{}{"name": "app", "image": "web:v1"}}},
+		}}},
+	}}
+	assert.Equal(t, RevisionOwner(controllerRevision).Name, "web")
+	target, revision, err := RevisionTarget(controllerRevision, owner("StatefulSet", "web", "1", podTemplate(map[string]interface{}{"app": "web"}, "web:v2")))
+	assert.NilError(t, err)
+	assert.Equal(t, revision, "2")
+	assert.Equal(t, target.GetKind(), "StatefulSet")
+	assert.Equal(t, target.GetName(), "web-7d4b9c8f6")
+	assert.Equal(t, target.GetNamespace(), "shop")
+	assert.DeepEqual(t, target.GetLabels(), map[string]string{"app": "web", "controller-revision-hash": "7d4b9c8f6"})
+	template, _, _ := unstructured.NestedMap(target.Object, "spec", "template")
+	assert.DeepEqual(t, template, podTemplate(map[string]interface{}{"app": "web"}, "web:v1"))
+
+	// the active revision is not evaluated again as its owner
+	target, _, err = RevisionTarget(controllerRevision, owner("StatefulSet", "web", "1", podTemplate(map[string]interface{}{"app": "web"}, "web:v1")))
+	assert.NilError(t, err)
+	assert.Assert(t, target == nil)
+
+	replicaSet := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "ReplicaSet",
+		"metadata": map[string]interface{}{
+			"name":        "api-5f7c9",
+			"namespace":   "shop",
+			"annotations": map[string]interface{}{"deployment.kubernetes.io/revision": "4"},
+			"ownerReferences": []interface{}{map[string]interface{}{
+				"apiVersion": "apps/v1", "kind": "Deployment", "name": "api", "uid": "2", "controller": true,
+			}},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(0),
+			"template": podTemplate(map[string]interface{}{"app": "api", "pod-template-hash": "5f7c9"}, "api:v3"),
+		},
+	}}
+	target, revision, err = RevisionTarget(replicaSet, owner("Deployment", "api", "2", podTemplate(map[string]interface{}{"app": "api"}, "api:v4")))
+	assert.NilError(t, err)
+	assert.Equal(t, revision, "4")
+	assert.Equal(t, target.GetAPIVersion(), "apps/v1")
+	assert.Equal(t, target.GetKind(), "Deployment")
+	image, _, _ := unstructured.NestedSlice(target.Object, "spec", "template", "spec", "containers")
+	assert.Equal(t, image[0].(map[string]interface{})["image"], "api:v3")
+
+	// the active ReplicaSet only differs from the Deployment template by its pod-template-hash label
+	target, _, err = RevisionTarget(replicaSet, owner("Deployment", "api", "2", podTemplate(map[string]interface{}{"app": "api"}, "api:v3")))
+	assert.NilError(t, err)
+	assert.Assert(t, target == nil)
+
+	// a ReplicaSet without a Deployment owner is not a revision
+	replicaSet.SetOwnerReferences(nil)
+	assert.Assert(t, RevisionOwner(replicaSet) == nil)
+	target, _, err = RevisionTarget(replicaSet, owner("Deployment", "api", "2", podTemplate(map[string]interface{}{"app": "api"}, "api:v4")))
+	assert.NilError(t, err)
+	assert.Assert(t, target == nil)
+}
@@ -1,16 +1,22 @@
 package utils
 
 import (
+	"encoding/json"
+	"strings"
+
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
 	"github.com/kyverno/kyverno/pkg/autogen"
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
 	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 	admissionregistrationv1alpha1listers "k8s.io/client-go/listers/admissionregistration/v1alpha1"
 )
@@ -28,10 +34,10 @@ func CanBackgroundProcess(p kyvernov1.PolicyInterface) bool {
 	return true
 }
 
-func BuildKindSet(logger logr.Logger, policies ...kyvernov1.PolicyInterface) sets.Set[string] {
+func BuildKindSet(logger logr.Logger, autogenControllers []config.AutogenController, policies ...kyvernov1.PolicyInterface) sets.Set[string] {
 	kinds := sets.New[string]()
 	for _, policy := range policies {
-		for _, rule := range autogen.ComputeRules(policy) {
+		for _, rule := range autogen.ComputeRules(policy, autogenControllers) {
 			if rule.HasValidate() || rule.HasVerifyImages() {
 				kinds.Insert(rule.MatchResources.GetKinds()...)
 			}
@@ -40,6 +46,66 @@ func BuildKindSet(logger logr.Logger, policies ...kyvernov1.PolicyInterface) set
 	return kinds
 }
 
+// RelatedResourcesScope returns whether policies have relatedResources context entries of the given kind
+// and whether these entries can list related resources outside of the namespace of the resource being scanned.
+// Entries with an empty or constant namespace are assumed to affect resources in all namespaces.
+func RelatedResourcesScope(gvk schema.GroupVersionKind, autogenControllers []config.AutogenController, policies ...kyvernov1.PolicyInterface) (bool, bool) {
+	tracked, allNamespaces := false, false
+	check := func(entries []kyvernov1.ContextEntry) {
+		for _, entry := range entries {
+			related := entry.RelatedResources
+			if related == nil || related.Kind != gvk.Kind || related.APIVersion != gvk.GroupVersion().String() {
+				continue
+			}
+			tracked = true
+			if !regex.RegexVariables.MatchString(related.Namespace) {
+				allNamespaces = true
+			}
+		}
+	}
+	for _, policy := range policies {
+		for _, rule := range autogen.ComputeRules(policy, autogenControllers) {
+			check(rule.Context)
+			if rule.HasValidate() {
+				for _, foreach := range rule.Validation.ForEachValidation {
+					check(foreach.Context)
+				}
+			}
+		}
+	}
+	return tracked, allNamespaces
+}
+
+// DependsOnExternalData returns whether the results of a policy can change without the policy or the scanned
+// resource changing, because its rules load data from config maps, API calls or image registries, verify images
+// or use time functions. Related resources are not considered, their changes are tracked with informers.
+func DependsOnExternalData(policy kyvernov1.PolicyInterface, autogenControllers []config.AutogenController) bool {
+	external := func(entries []kyvernov1.ContextEntry) bool {
+		for _, entry := range entries {
+			if entry.ConfigMap != nil || entry.APICall != nil || entry.ImageRegistry != nil {
+				return true
+			}
+		}
+		return false
+	}
+	for _, rule := range autogen.ComputeRules(policy, autogenControllers) {
+		if rule.HasVerifyImages() || external(rule.Context) {
+			return true
+		}
+		if rule.HasValidate() {
+			for _, foreach := range rule.Validation.ForEachValidation {
+				if external(foreach.Context) {
+					return true
+				}
+			}
+		}
+		if data, err := json.Marshal(rule); err == nil && strings.Contains(string(data), "time_") {
+			return true
+		}
+	}
+	return false
+}
+
 func RemoveNonBackgroundPolicies(policies ...kyvernov1.PolicyInterface) []kyvernov1.PolicyInterface {
 	var backgroundPolicies []kyvernov1.PolicyInterface
 	for _, pol := range policies {
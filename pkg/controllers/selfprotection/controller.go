@@ -0,0 +1,39 @@
+package selfprotection
+
+import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/controllers/generic/clusterpolicy"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "self-protection-controller"
+)
+
+// NewController creates a controller maintaining the policy that protects Kyverno resources
+// according to the selfProtection setting of the Kyverno configuration.
+func NewController(
+	kyvernoClient versioned.Interface,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	configuration config.Configuration,
+	namespace string,
+) controllers.Controller {
+	return clusterpolicy.NewController(
+		ControllerName,
+		PolicyName,
+		kyvernoClient,
+		cpolInformer,
+		configuration,
+		func() *kyvernov1.ClusterPolicy {
+			if !configuration.GetSelfProtection() {
+				return nil
+			}
+			return buildPolicy(namespace, configuration.GetExcludeKyvernoNamespace())
+		},
+	)
+}
@@ -0,0 +1,143 @@
+package selfprotection
+
+import (
+	"fmt"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PolicyName is the name of the generated policy protecting Kyverno resources
+const PolicyName = "kyverno-self-protection"
+
+var (
+	protectedOperations = []kyvernov1.AdmissionOperation{kyvernov1.Update, kyvernov1.Delete}
+	// Kyverno custom resource definitions
+	protectedCRDs = []string{"*.kyverno.io", "*.wgpolicyk8s.io"}
+	// resources deployed in the Kyverno namespace
+	protectedKinds = []string{"Deployment", "Service", "ServiceAccount", "ConfigMap", "Secret", "Role", "RoleBinding"}
+)
+
+// buildPolicy builds the policy preventing non admin users from altering Kyverno resources,
+// including the policies managed by Kyverno like this one.
+// Admins are members of the system:masters group or bound to the cluster-admin cluster role, Kyverno
+// service accounts are excluded so that the controllers can manage their own resources, as well as
+// the Kubernetes controllers cleaning up resources when the Kyverno namespace is deleted.
+// Resources in the Kyverno namespace are only protected when the namespace is not excluded from the
+// webhooks, otherwise admission requests for them never reach Kyverno.
+func buildPolicy(namespace string, excludeNamespace bool) *kyvernov1.ClusterPolicy {
+	admission, background := true, false
+	exclude := kyvernov1.MatchResources{
+		Any: kyvernov1.ResourceFilters{{
+			UserInfo: kyvernov1.UserInfo{ClusterRoles: []string{"cluster-admin"}},
+		}, {
+			UserInfo: kyvernov1.UserInfo{Subjects: []rbacv1.Subject{{
+				Kind: rbacv1.GroupKind,
+				Name: "system:masters",
+			}, {
+				Kind: rbacv1.GroupKind,
+				Name: "system:serviceaccounts:" + namespace,
+			}, {
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      "namespace-controller",
+				Namespace: metav1.NamespaceSystem,
+			}, {
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      "generic-garbage-collector",
+				Namespace: metav1.NamespaceSystem,
+			}}},
+		}},
+	}
+	rules := []kyvernov1.Rule{{
+		Name: "protect-kyverno-crds",
+		MatchResources: kyvernov1.MatchResources{
+			Any: kyvernov1.ResourceFilters{{
+				ResourceDescription: kyvernov1.ResourceDescription{
+					Kinds:      []string{"CustomResourceDefinition"},
+					Names:      protectedCRDs,
+					Operations: protectedOperations,
+				},
+			}},
+		},
+		ExcludeResources: exclude,
+		Validation: kyvernov1.Validation{
+			Message: "Kyverno custom resource definitions can only be modified by cluster administrators.",
+			Deny:    &kyvernov1.Deny{},
+		},
+	}, {
+		Name: "protect-kyverno-policies",
+		MatchResources: kyvernov1.MatchResources{
+			Any: kyvernov1.ResourceFilters{{
+				ResourceDescription: kyvernov1.ResourceDescription{
+					Kinds: []string{"kyverno.io/*/ClusterPolicy", "kyverno.io/*/Policy"},
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{kyverno.LabelAppManagedBy: kyverno.ValueKyvernoApp},
+					},
+					Operations: protectedOperations,
+				},
+			}, {
+				// the label of this policy can be removed by an update
+				ResourceDescription: kyvernov1.ResourceDescription{
+					Kinds:      []string{"kyverno.io/*/ClusterPolicy"},
+					Names:      []string{PolicyName},
+					Operations: protectedOperations,
+				},
+			}},
+		},
+		ExcludeResources: exclude,
+		Validation: kyvernov1.Validation{
+			Message: "Policies managed by Kyverno can only be modified by cluster administrators.",
+			Deny:    &kyvernov1.Deny{},
+		},
+	}}
+	if !excludeNamespace {
+		rules = append(rules, kyvernov1.Rule{
+			Name: "protect-kyverno-namespace",
+			MatchResources: kyvernov1.MatchResources{
+				Any: kyvernov1.ResourceFilters{{
+					ResourceDescription: kyvernov1.ResourceDescription{
+						Kinds:      protectedKinds,
+						Namespaces: []string{namespace},
+						Operations: protectedOperations,
+					},
+				}, {
+					ResourceDescription: kyvernov1.ResourceDescription{
+						Kinds:      []string{"Namespace"},
+						Names:      []string{namespace},
+						Operations: protectedOperations,
+					},
+				}},
+			},
+			ExcludeResources: exclude,
+			Validation: kyvernov1.Validation{
+				Message: fmt.Sprintf("Resources in the %s namespace can only be modified by cluster administrators.", namespace),
+				Deny:    &kyvernov1.Deny{},
+			},
+		})
+	}
+	policy := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: PolicyName,
+			Annotations: map[string]string{
+				"policies.kyverno.io/title":       "Kyverno Self Protection",
+				"policies.kyverno.io/category":    "Kyverno",
+				"policies.kyverno.io/description": "Generated by Kyverno when selfProtection is enabled in the Kyverno ConfigMap, changes are reverted.",
+			},
+		},
+		Spec: kyvernov1.Spec{
+			ValidationFailureAction: kyvernov1.Enforce,
+			Admission:               &admission,
+			Background:              &background,
+			Rules:                   rules,
+		},
+	}
+	// set the defaults applied by the API server so that the observed policy doesn't drift
+	for i := range policy.Spec.Rules {
+		policy.Spec.Rules[i].SkipBackgroundRequests = true
+	}
+	controllerutils.SetManagedByKyvernoLabel(policy)
+	return policy
+}
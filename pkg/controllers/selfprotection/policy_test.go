@@ -0,0 +1,24 @@
+package selfprotection
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_buildPolicy(t *testing.T) {
+	policy := buildPolicy("kyverno", false)
+	assert.Equal(t, policy.GetName(), PolicyName)
+	assert.Equal(t, len(policy.Spec.Rules), 3)
+	assert.Equal(t, policy.Spec.Rules[1].Name, "protect-kyverno-policies")
+	assert.Equal(t, policy.Spec.Rules[1].MatchResources.Any[1].Names[0], PolicyName)
+	assert.Equal(t, policy.Spec.Rules[2].MatchResources.Any[0].Namespaces[0], "kyverno")
+	assert.Assert(t, policy.GetSpec().ValidationFailureAction.Enforce())
+	assert.Assert(t, !policy.BackgroundProcessingEnabled())
+	assert.NilError(t, policy.Validate(nil).ToAggregate())
+	// resources in an excluded namespace never reach the webhooks
+	policy = buildPolicy("kyverno", true)
+	assert.Equal(t, len(policy.Spec.Rules), 2)
+	assert.Equal(t, policy.Spec.Rules[0].Name, "protect-kyverno-crds")
+	assert.Equal(t, policy.Spec.Rules[1].Name, "protect-kyverno-policies")
+}
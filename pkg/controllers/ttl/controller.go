@@ -156,10 +156,9 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, itemKey
 		// No 'ttl' label present, no further action needed
 		return nil
 	}
-	var deletionTime time.Time
-	// Try parsing ttlValue as duration
-	if err := parseDeletionTime(metaObj, &deletionTime, ttlValue); err != nil {
-		logger.Error(err, "failed to parse label", "value", ttlValue)
+	deletionTime, err := getDeletionTime(metaObj, ttlValue)
+	if err != nil {
+		logger.Error(err, "failed to compute deletion time", "ttl", ttlValue, "expiresAt", metaObj.GetAnnotations()[kyverno.AnnotationCleanupExpiresAt])
 		return nil
 	}
 	if time.Now().After(deletionTime) {
@@ -68,3 +68,16 @@ func parseDeletionTime(metaObj metav1.Object, deletionTime *time.Time, ttlValue
 	}
 	return nil
 }
+
+// getDeletionTime returns the time the resource must be deleted at,
+// an absolute timestamp set in the expires-at annotation overrides the ttl label
+func getDeletionTime(metaObj metav1.Object, ttlValue string) (time.Time, error) {
+	if expiresAt, ok := metaObj.GetAnnotations()[kyverno.AnnotationCleanupExpiresAt]; ok {
+		return time.Parse(time.RFC3339, expiresAt)
+	}
+	var deletionTime time.Time
+	if err := parseDeletionTime(metaObj, &deletionTime, ttlValue); err != nil {
+		return time.Time{}, err
+	}
+	return deletionTime, nil
+}
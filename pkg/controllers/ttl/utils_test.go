@@ -71,3 +71,56 @@ func TestParseDeletionTime(t *testing.T) {
 		}
 	}
 }
+
+func TestGetDeletionTime(t *testing.T) {
+	creationTime := time.Date(2023, 7, 18, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name                 string
+		annotations          map[string]string
+		ttlValue             string
+		expectedDeletionTime time.Time
+		expectError          bool
+	}{{
+		name:                 "ttl label only",
+		ttlValue:             "1h",
+		expectedDeletionTime: time.Date(2023, 7, 18, 13, 0, 0, 0, time.UTC),
+	}, {
+		name:                 "expires-at overrides the ttl label",
+		annotations:          map[string]string{"cleanup.kyverno.io/expires-at": "2023-07-20T08:30:00Z"},
+		ttlValue:             "1h",
+		expectedDeletionTime: time.Date(2023, 7, 20, 8, 30, 0, 0, time.UTC),
+	}, {
+		name:                 "expires-at with a time zone offset",
+		annotations:          map[string]string{"cleanup.kyverno.io/expires-at": "2023-07-20T10:30:00+02:00"},
+		ttlValue:             "1h",
+		expectedDeletionTime: time.Date(2023, 7, 20, 8, 30, 0, 0, time.UTC),
+	}, {
+		name:        "invalid expires-at",
+		annotations: map[string]string{"cleanup.kyverno.io/expires-at": "2023-07-20"},
+		ttlValue:    "1h",
+		expectError: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			metaObj := &mockMetaObj{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.NewTime(creationTime),
+					Annotations:       test.annotations,
+				},
+			}
+			deletionTime, err := getDeletionTime(metaObj, test.ttlValue)
+			if test.expectError {
+				if err == nil {
+					t.Errorf("Expected an error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+			if !deletionTime.Equal(test.expectedDeletionTime) {
+				t.Errorf("Expected deletion time: %v but got: %v", test.expectedDeletionTime, deletionTime)
+			}
+		})
+	}
+}
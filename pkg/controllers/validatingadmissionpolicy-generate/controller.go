@@ -18,6 +18,7 @@ import (
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"github.com/kyverno/kyverno/pkg/utils/validatingadmissionpolicy"
+	admissionpolicy "github.com/kyverno/kyverno/pkg/validatingadmissionpolicy"
 	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -214,89 +215,6 @@ func (c *controller) getValidatingAdmissionPolicyBinding(name string) (*admissio
 	return vapbinding, nil
 }
 
-func (c *controller) buildValidatingAdmissionPolicy(vap *admissionregistrationv1alpha1.ValidatingAdmissionPolicy, cpol kyvernov1.PolicyInterface) error {
-	// set owner reference
-	vap.OwnerReferences = []metav1.OwnerReference{
-		{
-			APIVersion: "kyverno.io/v1",
-			Kind:       cpol.GetKind(),
-			Name:       cpol.GetName(),
-			UID:        cpol.GetUID(),
-		},
-	}
-
-	// construct validating admission policy resource rules
-	var matchResources admissionregistrationv1alpha1.MatchResources
-	var matchRules []admissionregistrationv1alpha1.NamedRuleWithOperations
-
-	rule := cpol.GetSpec().Rules[0]
-	match := rule.MatchResources
-	if !match.ResourceDescription.IsEmpty() {
-		if err := c.translateResource(&matchResources, &matchRules, match.ResourceDescription); err != nil {
-			return err
-		}
-	}
-
-	if match.Any != nil {
-		if err := c.translateResourceFilters(&matchResources, &matchRules, match.Any); err != nil {
-			return err
-		}
-	}
-	if match.All != nil {
-		if err := c.translateResourceFilters(&matchResources, &matchRules, match.All); err != nil {
-			return err
-		}
-	}
-
-	// set validating admission policy spec
-	vap.Spec = admissionregistrationv1alpha1.ValidatingAdmissionPolicySpec{
-		MatchConstraints: &matchResources,
-		ParamKind:        rule.Validation.CEL.ParamKind,
-		Variables:        rule.Validation.CEL.Variables,
-		Validations:      rule.Validation.CEL.Expressions,
-		AuditAnnotations: rule.Validation.CEL.AuditAnnotations,
-		MatchConditions:  rule.CELPreconditions,
-	}
-
-	// set labels
-	controllerutils.SetManagedByKyvernoLabel(vap)
-	return nil
-}
-
-func (c *controller) buildValidatingAdmissionPolicyBinding(vapbinding *admissionregistrationv1alpha1.ValidatingAdmissionPolicyBinding, cpol kyvernov1.PolicyInterface) error {
-	// set owner reference
-	vapbinding.OwnerReferences = []metav1.OwnerReference{
-		{
-			APIVersion: "kyverno.io/v1",
-			Kind:       cpol.GetKind(),
-			Name:       cpol.GetName(),
-			UID:        cpol.GetUID(),
-		},
-	}
-
-	// set validation action for vap binding
-	var validationActions []admissionregistrationv1alpha1.ValidationAction
-	action := cpol.GetSpec().ValidationFailureAction
-	if action.Enforce() {
-		validationActions = append(validationActions, admissionregistrationv1alpha1.Deny)
-	} else if action.Audit() {
-		validationActions = append(validationActions, admissionregistrationv1alpha1.Audit)
-		validationActions = append(validationActions, admissionregistrationv1alpha1.Warn)
-	}
-
-	// set validating admission policy binding spec
-	rule := cpol.GetSpec().Rules[0]
-	vapbinding.Spec = admissionregistrationv1alpha1.ValidatingAdmissionPolicyBindingSpec{
-		PolicyName:        cpol.GetName(),
-		ParamRef:          rule.Validation.CEL.ParamRef,
-		ValidationActions: validationActions,
-	}
-
-	// set labels
-	controllerutils.SetManagedByKyvernoLabel(vapbinding)
-	return nil
-}
-
 func constructVapBindingName(vapName string) string {
 	return vapName + "-binding"
 }
@@ -335,7 +253,7 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 
 	observedVAP, vapErr := c.getValidatingAdmissionPolicy(vapName)
 	observedVAPbinding, vapBindingErr := c.getValidatingAdmissionPolicyBinding(vapBindingName)
-	if ok, msg := canGenerateVAP(spec); !ok {
+	if ok, msg := admissionpolicy.CanGenerateVAP(spec); !ok {
 		// delete the ValidatingAdmissionPolicy if exist
 		if vapErr == nil {
 			err = c.client.AdmissionregistrationV1alpha1().ValidatingAdmissionPolicies().Delete(ctx, vapName, metav1.DeleteOptions{})
@@ -379,7 +297,7 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 	}
 
 	if observedVAP.ResourceVersion == "" {
-		err := c.buildValidatingAdmissionPolicy(observedVAP, policy)
+		err := admissionpolicy.BuildValidatingAdmissionPolicy(c.discoveryClient, observedVAP, policy)
 		if err != nil {
 			c.updateClusterPolicyStatus(ctx, *policy, false, err.Error())
 			return err
@@ -395,7 +313,7 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 			observedVAP,
 			c.client.AdmissionregistrationV1alpha1().ValidatingAdmissionPolicies(),
 			func(observed *admissionregistrationv1alpha1.ValidatingAdmissionPolicy) error {
-				return c.buildValidatingAdmissionPolicy(observed, policy)
+				return admissionpolicy.BuildValidatingAdmissionPolicy(c.discoveryClient, observed, policy)
 			})
 		if err != nil {
 			c.updateClusterPolicyStatus(ctx, *policy, false, err.Error())
@@ -404,7 +322,7 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 	}
 
 	if observedVAPbinding.ResourceVersion == "" {
-		err := c.buildValidatingAdmissionPolicyBinding(observedVAPbinding, policy)
+		err := admissionpolicy.BuildValidatingAdmissionPolicyBinding(observedVAPbinding, policy)
 		if err != nil {
 			c.updateClusterPolicyStatus(ctx, *policy, false, err.Error())
 			return err
@@ -420,7 +338,7 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 			observedVAPbinding,
 			c.client.AdmissionregistrationV1alpha1().ValidatingAdmissionPolicyBindings(),
 			func(observed *admissionregistrationv1alpha1.ValidatingAdmissionPolicyBinding) error {
-				return c.buildValidatingAdmissionPolicyBinding(observed, policy)
+				return admissionpolicy.BuildValidatingAdmissionPolicyBinding(observed, policy)
 			})
 		if err != nil {
 			c.updateClusterPolicyStatus(ctx, *policy, false, err.Error())
@@ -439,6 +357,23 @@ func (c *controller) updateClusterPolicyStatus(ctx context.Context, cpol kyverno
 	latest := cpol.DeepCopy()
 	latest.Status.ValidatingAdmissionPolicy.Generated = generated
 	latest.Status.ValidatingAdmissionPolicy.Message = msg
+	for _, rule := range latest.GetSpec().Rules {
+		if !rule.HasValidateCEL() {
+			continue
+		}
+		condition := metav1.Condition{
+			Type:    kyvernov1.RuleConditionValidatingAdmissionPolicyGenerated,
+			Status:  metav1.ConditionTrue,
+			Reason:  kyvernov1.RuleReasonValidatingAdmissionPolicyGenerated,
+			Message: "Validating admission policy is generated",
+		}
+		if !generated {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = kyvernov1.RuleReasonValidatingAdmissionPolicyNotGenerated
+			condition.Message = msg
+		}
+		latest.Status.SetRuleCondition(rule.Name, condition)
+	}
 
 	new, _ := c.kyvernoClient.KyvernoV1().ClusterPolicies().UpdateStatus(ctx, latest, metav1.UpdateOptions{})
 	logging.V(3).Info("updated kyverno policy status", "name", cpol.GetName(), "status", new.Status)
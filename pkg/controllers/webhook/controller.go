@@ -18,6 +18,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/tls"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
@@ -57,6 +58,7 @@ var (
 	none         = admissionregistrationv1.SideEffectClassNone
 	noneOnDryRun = admissionregistrationv1.SideEffectClassNoneOnDryRun
 	ifNeeded     = admissionregistrationv1.IfNeededReinvocationPolicy
+	never        = admissionregistrationv1.NeverReinvocationPolicy
 	ignore       = admissionregistrationv1.Ignore
 	fail         = admissionregistrationv1.Fail
 	policyRule   = admissionregistrationv1.Rule{
@@ -79,6 +81,9 @@ type controller struct {
 	leaseClient     controllerutils.ObjectClient[*coordinationv1.Lease]
 	kyvernoClient   versioned.Interface
 
+	// resolvers
+	configMapResolver engineapi.ConfigmapResolver
+
 	// listers
 	mwcLister         admissionregistrationv1listers.MutatingWebhookConfigurationLister
 	vwcLister         admissionregistrationv1listers.ValidatingWebhookConfigurationLister
@@ -119,6 +124,7 @@ func NewController(
 	secretInformer corev1informers.SecretInformer,
 	leaseInformer coordinationv1informers.LeaseInformer,
 	clusterroleInformer rbacv1informers.ClusterRoleInformer,
+	configMapResolver engineapi.ConfigmapResolver,
 	server string,
 	defaultTimeout int32,
 	servicePort int32,
@@ -136,6 +142,7 @@ func NewController(
 		vwcClient:          vwcClient,
 		leaseClient:        leaseClient,
 		kyvernoClient:      kyvernoClient,
+		configMapResolver:  configMapResolver,
 		mwcLister:          mwcInformer.Lister(),
 		vwcLister:          vwcInformer.Lister(),
 		cpolLister:         cpolInformer.Lister(),
@@ -437,13 +444,30 @@ func (c *controller) updatePolicyStatuses(ctx context.Context) error {
 		status := policy.GetStatus()
 		status.SetReady(ready, message)
 		status.Autogen.Rules = nil
-		rules := autogen.ComputeRules(policy)
+		rules := autogen.ComputeRules(policy, c.configuration.GetAutogenControllers())
 		setRuleCount(rules, status)
 		for _, rule := range rules {
 			if strings.HasPrefix(rule.Name, "autogen-") {
 				status.Autogen.Rules = append(status.Autogen.Rules, rule)
 			}
 		}
+		status.Autogen.Hash = autogen.ComputeHash(status.Autogen.Rules)
+		mutatingConfigured, validatingConfigured := !c.autoUpdateWebhooks, !c.autoUpdateWebhooks
+		if c.autoUpdateWebhooks {
+			mutatingConfigured = c.policyState[config.MutatingWebhookConfigurationName].Has(policyKey)
+			validatingConfigured = c.policyState[config.ValidatingWebhookConfigurationName].Has(policyKey)
+		}
+		var ruleNames []string
+		for _, rule := range rules {
+			if strings.HasPrefix(rule.Name, "autogen-") {
+				continue
+			}
+			ruleNames = append(ruleNames, rule.Name)
+			status.SetRuleCondition(rule.Name, webhookCondition(rule, policy.AdmissionProcessingEnabled(), mutatingConfigured, validatingConfigured))
+			status.SetRuleCondition(rule.Name, contextCondition(ctx, c.configMapResolver, rule))
+			status.GetRuleStatus(rule.Name).AutogenRules = autogenRuleNames(rule, rules)
+		}
+		status.RetainRules(ruleNames...)
 		return nil
 	}
 	for _, policy := range policies {
@@ -634,22 +658,17 @@ func (c *controller) buildResourceMutatingWebhookConfiguration(ctx context.Conte
 		Webhooks:   []admissionregistrationv1.MutatingWebhook{},
 	}
 	if c.watchdogCheck() {
-		ignore := newWebhook(c.defaultTimeout, ignore)
-		fail := newWebhook(c.defaultTimeout, fail)
 		policies, err := c.getAllPolicies()
 		if err != nil {
 			return nil, err
 		}
 		c.recordPolicyState(config.MutatingWebhookConfigurationName, policies...)
+		webhooks := map[webhookGroup]*webhook{}
 		for _, p := range policies {
 			if p.AdmissionProcessingEnabled() {
 				spec := p.GetSpec()
 				if spec.HasMutate() || spec.HasVerifyImages() {
-					if spec.GetFailurePolicy(ctx) == kyvernov1.Ignore {
-						c.mergeWebhook(ignore, p, false)
-					} else {
-						c.mergeWebhook(fail, p, false)
-					}
+					c.mergeWebhook(c.groupWebhook(ctx, webhooks, spec, true), p, false)
 				}
 			}
 		}
@@ -658,40 +677,26 @@ func (c *controller) buildResourceMutatingWebhookConfiguration(ctx context.Conte
 		if len(webhookCfgs) > 0 {
 			webhookCfg = webhookCfgs[0]
 		}
-		if !ignore.isEmpty() {
-			timeout := capTimeout(ignore.maxWebhookTimeout)
-			result.Webhooks = append(
-				result.Webhooks,
-				admissionregistrationv1.MutatingWebhook{
-					Name:                    config.MutatingWebhookName + "-ignore",
-					ClientConfig:            c.clientConfig(caBundle, config.MutatingWebhookServicePath+"/ignore"),
-					Rules:                   ignore.buildRulesWithOperations(admissionregistrationv1.Create, admissionregistrationv1.Update),
-					FailurePolicy:           &ignore.failurePolicy,
-					SideEffects:             &noneOnDryRun,
-					AdmissionReviewVersions: []string{"v1"},
-					NamespaceSelector:       webhookCfg.NamespaceSelector,
-					ObjectSelector:          webhookCfg.ObjectSelector,
-					TimeoutSeconds:          &timeout,
-					ReinvocationPolicy:      &ifNeeded,
-					MatchConditions:         cfg.GetMatchConditions(),
-				},
-			)
-		}
-		if !fail.isEmpty() {
-			timeout := capTimeout(fail.maxWebhookTimeout)
+		for _, group := range sortedWebhookGroups(webhooks) {
+			wh := webhooks[group]
+			if wh.isEmpty() {
+				continue
+			}
+			timeout := capTimeout(wh.timeout)
+			reinvocationPolicy := group.reinvocationPolicy
 			result.Webhooks = append(
 				result.Webhooks,
 				admissionregistrationv1.MutatingWebhook{
-					Name:                    config.MutatingWebhookName + "-fail",
-					ClientConfig:            c.clientConfig(caBundle, config.MutatingWebhookServicePath+"/fail"),
-					Rules:                   fail.buildRulesWithOperations(admissionregistrationv1.Create, admissionregistrationv1.Update),
-					FailurePolicy:           &fail.failurePolicy,
+					Name:                    config.MutatingWebhookName + group.suffix(),
+					ClientConfig:            c.clientConfig(caBundle, config.MutatingWebhookServicePath+group.path()),
+					Rules:                   wh.buildRulesWithOperations(),
+					FailurePolicy:           &wh.failurePolicy,
 					SideEffects:             &noneOnDryRun,
 					AdmissionReviewVersions: []string{"v1"},
 					NamespaceSelector:       webhookCfg.NamespaceSelector,
-					ObjectSelector:          webhookCfg.ObjectSelector,
+					ObjectSelector:          wh.buildObjectSelector(webhookCfg.ObjectSelector),
 					TimeoutSeconds:          &timeout,
-					ReinvocationPolicy:      &ifNeeded,
+					ReinvocationPolicy:      &reinvocationPolicy,
 					MatchConditions:         cfg.GetMatchConditions(),
 				},
 			)
@@ -760,22 +765,17 @@ func (c *controller) buildResourceValidatingWebhookConfiguration(ctx context.Con
 		Webhooks:   []admissionregistrationv1.ValidatingWebhook{},
 	}
 	if c.watchdogCheck() {
-		ignore := newWebhook(c.defaultTimeout, ignore)
-		fail := newWebhook(c.defaultTimeout, fail)
 		policies, err := c.getAllPolicies()
 		if err != nil {
 			return nil, err
 		}
 		c.recordPolicyState(config.ValidatingWebhookConfigurationName, policies...)
+		webhooks := map[webhookGroup]*webhook{}
 		for _, p := range policies {
 			if p.AdmissionProcessingEnabled() {
 				spec := p.GetSpec()
 				if spec.HasValidate() || spec.HasGenerate() || spec.HasMutate() || spec.HasVerifyImageChecks() || spec.HasVerifyManifests() {
-					if spec.GetFailurePolicy(ctx) == kyvernov1.Ignore {
-						c.mergeWebhook(ignore, p, true)
-					} else {
-						c.mergeWebhook(fail, p, true)
-					}
+					c.mergeWebhook(c.groupWebhook(ctx, webhooks, spec, false), p, true)
 				}
 			}
 		}
@@ -788,37 +788,23 @@ func (c *controller) buildResourceValidatingWebhookConfiguration(ctx context.Con
 		if c.admissionReports {
 			sideEffects = &noneOnDryRun
 		}
-		if !ignore.isEmpty() {
-			timeout := capTimeout(ignore.maxWebhookTimeout)
-			result.Webhooks = append(
-				result.Webhooks,
-				admissionregistrationv1.ValidatingWebhook{
-					Name:                    config.ValidatingWebhookName + "-ignore",
-					ClientConfig:            c.clientConfig(caBundle, config.ValidatingWebhookServicePath+"/ignore"),
-					Rules:                   ignore.buildRulesWithOperations(admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete, admissionregistrationv1.Connect),
-					FailurePolicy:           &ignore.failurePolicy,
-					SideEffects:             sideEffects,
-					AdmissionReviewVersions: []string{"v1"},
-					NamespaceSelector:       webhookCfg.NamespaceSelector,
-					ObjectSelector:          webhookCfg.ObjectSelector,
-					TimeoutSeconds:          &timeout,
-					MatchConditions:         cfg.GetMatchConditions(),
-				},
-			)
-		}
-		if !fail.isEmpty() {
-			timeout := capTimeout(fail.maxWebhookTimeout)
+		for _, group := range sortedWebhookGroups(webhooks) {
+			wh := webhooks[group]
+			if wh.isEmpty() {
+				continue
+			}
+			timeout := capTimeout(wh.timeout)
 			result.Webhooks = append(
 				result.Webhooks,
 				admissionregistrationv1.ValidatingWebhook{
-					Name:                    config.ValidatingWebhookName + "-fail",
-					ClientConfig:            c.clientConfig(caBundle, config.ValidatingWebhookServicePath+"/fail"),
-					Rules:                   fail.buildRulesWithOperations(admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete, admissionregistrationv1.Connect),
-					FailurePolicy:           &fail.failurePolicy,
+					Name:                    config.ValidatingWebhookName + group.suffix(),
+					ClientConfig:            c.clientConfig(caBundle, config.ValidatingWebhookServicePath+group.path()),
+					Rules:                   wh.buildRulesWithOperations(),
+					FailurePolicy:           &wh.failurePolicy,
 					SideEffects:             sideEffects,
 					AdmissionReviewVersions: []string{"v1"},
 					NamespaceSelector:       webhookCfg.NamespaceSelector,
-					ObjectSelector:          webhookCfg.ObjectSelector,
+					ObjectSelector:          wh.buildObjectSelector(webhookCfg.ObjectSelector),
 					TimeoutSeconds:          &timeout,
 					MatchConditions:         cfg.GetMatchConditions(),
 				},
@@ -830,6 +816,34 @@ func (c *controller) buildResourceValidatingWebhookConfiguration(ctx context.Con
 	return &result, nil
 }
 
+// groupWebhook returns the webhook the policy is registered in, policies are grouped by failure policy and timeout,
+// and by reinvocation policy in mutating webhooks.
+func (c *controller) groupWebhook(ctx context.Context, webhooks map[webhookGroup]*webhook, spec *kyvernov1.Spec, mutating bool) *webhook {
+	group := webhookGroup{failurePolicy: fail}
+	if spec.GetFailurePolicy(ctx) == kyvernov1.Ignore {
+		group.failurePolicy = ignore
+	}
+	if spec.WebhookTimeoutSeconds != nil {
+		group.timeout = *spec.WebhookTimeoutSeconds
+	}
+	if mutating {
+		group.reinvocationPolicy = ifNeeded
+		if spec.GetReinvocationPolicy() == kyvernov1.NeverReinvocationPolicy {
+			group.reinvocationPolicy = never
+		}
+	}
+	wh := webhooks[group]
+	if wh == nil {
+		timeout := c.defaultTimeout
+		if group.timeout != 0 {
+			timeout = group.timeout
+		}
+		wh = newWebhook(timeout, group.failurePolicy)
+		webhooks[group] = wh
+	}
+	return wh
+}
+
 func (c *controller) getAllPolicies() ([]kyvernov1.PolicyInterface, error) {
 	var policies []kyvernov1.PolicyInterface
 	if cpols, err := c.cpolLister.List(labels.Everything()); err != nil {
@@ -855,55 +869,72 @@ func (c *controller) getLease() (*coordinationv1.Lease, error) {
 
 // mergeWebhook merges the matching kinds of the policy to webhook.rule
 func (c *controller) mergeWebhook(dst *webhook, policy kyvernov1.PolicyInterface, updateValidate bool) {
-	var matchedGVK []string
-	for _, rule := range autogen.ComputeRules(policy) {
+	defaults := []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update}
+	if updateValidate {
+		defaults = append(defaults, admissionregistrationv1.Delete, admissionregistrationv1.Connect)
+	}
+	for _, rule := range autogen.ComputeRules(policy, c.configuration.GetAutogenControllers()) {
+		var matchedGVK []string
+		ops := defaults
 		// matching kinds in generate policies need to be added to both webhook
 		if rule.HasGenerate() {
 			matchedGVK = append(matchedGVK, rule.MatchResources.GetKinds()...)
-			if rule.Generation.ResourceSpec.Kind != "" {
+			// downstream resources in remote clusters are not watched
+			if rule.Generation.ResourceSpec.Kind != "" && rule.Generation.Cluster == nil {
 				matchedGVK = append(matchedGVK, rule.Generation.ResourceSpec.Kind)
 			}
 			matchedGVK = append(matchedGVK, rule.Generation.CloneList.Kinds...)
-			continue
-		}
-		if (updateValidate && rule.HasValidate() || rule.HasVerifyImageChecks()) ||
+			// downstream and source resources don't carry the trigger labels
+			dst.mergeObjectRequirements(nil)
+		} else if (updateValidate && rule.HasValidate() || rule.HasVerifyImageChecks()) ||
 			(updateValidate && rule.HasMutate() && rule.IsMutateExisting()) ||
 			(!updateValidate && rule.HasMutate()) && !rule.IsMutateExisting() ||
 			(!updateValidate && rule.HasVerifyImages()) || (!updateValidate && rule.HasVerifyManifests()) {
 			matchedGVK = append(matchedGVK, rule.MatchResources.GetKinds()...)
+			ops = ruleOperations(rule, defaults...)
+			if len(matchedGVK) != 0 && len(ops) != 0 {
+				if matchesSubresources(matchedGVK) {
+					// the object of a subresource request doesn't always carry the labels of its parent
+					dst.mergeObjectRequirements(nil)
+				} else {
+					dst.mergeObjectRequirements(ruleObjectRequirements(rule.MatchResources))
+				}
+			}
 		}
-	}
-	var gvrsList []schema.GroupVersionResource
-	for _, gvk := range matchedGVK {
-		// NOTE: webhook stores GVR in its rules while policy stores GVK in its rules definition
-		group, version, kind, subresource := kubeutils.ParseKindSelector(gvk)
-		// if kind is `*` no need to lookup resources
-		if kind == "*" && subresource == "*" {
-			gvrsList = append(gvrsList, schema.GroupVersionResource{Group: group, Version: version, Resource: "*/*"})
-		} else if kind == "*" && subresource == "" {
-			gvrsList = append(gvrsList, schema.GroupVersionResource{Group: group, Version: version, Resource: "*"})
-		} else if kind == "*" && subresource != "" {
-			gvrsList = append(gvrsList, schema.GroupVersionResource{Group: group, Version: version, Resource: "*/" + subresource})
-		} else {
-			gvrss, err := c.discoveryClient.FindResources(group, version, kind, subresource)
-			if err != nil {
-				logger.Error(err, "unable to find resource", "group", group, "version", version, "kind", kind, "subresource", subresource)
-				continue
+		if len(ops) == 0 {
+			continue
+		}
+		for _, gvk := range matchedGVK {
+			// NOTE: webhook stores GVR in its rules while policy stores GVK in its rules definition
+			group, version, kind, subresource := kubeutils.ParseKindSelector(gvk)
+			// namespaced policies only apply to namespaced resources
+			scope := admissionregistrationv1.AllScopes
+			if policy.IsNamespaced() {
+				scope = admissionregistrationv1.NamespacedScope
 			}
-			for gvrs := range gvrss {
-				gvrsList = append(gvrsList, gvrs.GroupVersion.WithResource(gvrs.ResourceSubresource()))
+			// if kind is `*` no need to lookup resources
+			if kind == "*" && subresource == "*" {
+				dst.set(schema.GroupVersionResource{Group: group, Version: version, Resource: "*/*"}, scope, ops...)
+			} else if kind == "*" && subresource == "" {
+				dst.set(schema.GroupVersionResource{Group: group, Version: version, Resource: "*"}, scope, ops...)
+			} else if kind == "*" && subresource != "" {
+				dst.set(schema.GroupVersionResource{Group: group, Version: version, Resource: "*/" + subresource}, scope, ops...)
+			} else {
+				gvrss, err := c.discoveryClient.FindResources(group, version, kind, subresource)
+				if err != nil {
+					logger.Error(err, "unable to find resource", "group", group, "version", version, "kind", kind, "subresource", subresource)
+					continue
+				}
+				for gvrs, resource := range gvrss {
+					scope := admissionregistrationv1.ClusterScope
+					if resource.Namespaced {
+						scope = admissionregistrationv1.NamespacedScope
+					}
+					dst.set(gvrs.GroupVersion.WithResource(gvrs.ResourceSubresource()), scope, ops...)
+				}
 			}
 		}
 	}
-	for _, gvr := range gvrsList {
-		dst.set(gvr)
-	}
-	spec := policy.GetSpec()
-	if spec.WebhookTimeoutSeconds != nil {
-		if dst.maxWebhookTimeout < *spec.WebhookTimeoutSeconds {
-			dst.maxWebhookTimeout = *spec.WebhookTimeoutSeconds
-		}
-	}
 }
 
 func (c *controller) buildOwner() []metav1.OwnerReference {
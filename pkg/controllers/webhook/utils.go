@@ -2,11 +2,16 @@ package webhook
 
 import (
 	"cmp"
+	"context"
+	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/kyverno/kyverno/api/kyverno"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"golang.org/x/exp/maps"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -17,34 +22,129 @@ import (
 // webhook is the instance that aggregates the GVK of existing policies
 // based on kind, failurePolicy and webhookTimeout
 type webhook struct {
-	maxWebhookTimeout int32
-	failurePolicy     admissionregistrationv1.FailurePolicyType
-	rules             map[schema.GroupVersion]sets.Set[string]
+	timeout       int32
+	failurePolicy admissionregistrationv1.FailurePolicyType
+	rules         map[groupVersionScope]map[string]sets.Set[admissionregistrationv1.OperationType]
+	// objectRequirements is the intersection of the label requirements of the rules merged in the webhook,
+	// it is nil until a rule is merged and empty when nothing can be pushed down to the object selector
+	objectRequirements map[string]metav1.LabelSelectorRequirement
+}
+
+// groupVersionScope is the group version and scope of the resources in a webhook rule
+type groupVersionScope struct {
+	schema.GroupVersion
+	scope admissionregistrationv1.ScopeType
+}
+
+// webhookGroup identifies a webhook by the failure policy, the timeout and the reinvocation policy declared
+// by its policies, the timeout is zero for policies relying on the default timeout and the reinvocation
+// policy is empty for validating webhooks
+type webhookGroup struct {
+	failurePolicy      admissionregistrationv1.FailurePolicyType
+	timeout            int32
+	reinvocationPolicy admissionregistrationv1.ReinvocationPolicyType
+}
+
+// suffix returns the suffix of the webhook name, policies using the default timeout and reinvocation policy keep the historical names
+func (g webhookGroup) suffix() string {
+	suffix := "-" + strings.ToLower(string(g.failurePolicy))
+	if g.timeout != 0 {
+		suffix += fmt.Sprintf("-%ds", g.timeout)
+	}
+	if g.reinvocationPolicy == admissionregistrationv1.NeverReinvocationPolicy {
+		suffix += "-never"
+	}
+	return suffix
+}
+
+// path returns the path of the webhook service, the server only evaluates the policies of the group
+func (g webhookGroup) path() string {
+	timeout := "default"
+	if g.timeout != 0 {
+		timeout = strconv.Itoa(int(g.timeout))
+	}
+	path := "/" + strings.ToLower(string(g.failurePolicy)) + "/" + timeout
+	if g.reinvocationPolicy != "" {
+		path += "/" + strings.ToLower(string(g.reinvocationPolicy))
+	}
+	return path
+}
+
+// sortedWebhookGroups returns the groups with ignore webhooks first, ordered by timeout,
+// webhooks called once come before the reinvoked ones
+func sortedWebhookGroups(webhooks map[webhookGroup]*webhook) []webhookGroup {
+	groups := maps.Keys(webhooks)
+	slices.SortFunc(groups, func(a, b webhookGroup) int {
+		if a.failurePolicy != b.failurePolicy {
+			if a.failurePolicy == admissionregistrationv1.Ignore {
+				return -1
+			}
+			return 1
+		}
+		if x := cmp.Compare(a.timeout, b.timeout); x != 0 {
+			return x
+		}
+		return cmp.Compare(reinvocationOrder(a.reinvocationPolicy), reinvocationOrder(b.reinvocationPolicy))
+	})
+	return groups
+}
+
+func reinvocationOrder(policy admissionregistrationv1.ReinvocationPolicyType) int {
+	if policy == admissionregistrationv1.IfNeededReinvocationPolicy {
+		return 1
+	}
+	return 0
 }
 
 func newWebhook(timeout int32, failurePolicy admissionregistrationv1.FailurePolicyType) *webhook {
 	return &webhook{
-		maxWebhookTimeout: timeout,
-		failurePolicy:     failurePolicy,
-		rules:             map[schema.GroupVersion]sets.Set[string]{},
+		timeout:       timeout,
+		failurePolicy: failurePolicy,
+		rules:         map[groupVersionScope]map[string]sets.Set[admissionregistrationv1.OperationType]{},
 	}
 }
 
-func (wh *webhook) buildRulesWithOperations(ops ...admissionregistrationv1.OperationType) []admissionregistrationv1.RuleWithOperations {
+func (wh *webhook) buildRulesWithOperations() []admissionregistrationv1.RuleWithOperations {
 	var rules []admissionregistrationv1.RuleWithOperations
-	for gv, resources := range wh.rules {
+	for gvs, resources := range wh.rules {
 		// if we have pods, we add pods/ephemeralcontainers by default
-		if (gv.Group == "" || gv.Group == "*") && (gv.Version == "v1" || gv.Version == "*") && (resources.Has("pods") || resources.Has("*")) {
-			resources.Insert("pods/ephemeralcontainers")
-		}
-		rules = append(rules, admissionregistrationv1.RuleWithOperations{
-			Rule: admissionregistrationv1.Rule{
-				APIGroups:   []string{gv.Group},
-				APIVersions: []string{gv.Version},
-				Resources:   sets.List(resources),
-			},
-			Operations: ops,
-		})
+		if (gvs.Group == "" || gvs.Group == "*") && (gvs.Version == "v1" || gvs.Version == "*") {
+			ops := sets.New[admissionregistrationv1.OperationType]()
+			if pods, ok := resources["pods"]; ok {
+				ops.Insert(pods.UnsortedList()...)
+			}
+			if all, ok := resources["*"]; ok {
+				ops.Insert(all.UnsortedList()...)
+			}
+			if ops.Len() != 0 {
+				if resources["pods/ephemeralcontainers"] == nil {
+					resources["pods/ephemeralcontainers"] = sets.New[admissionregistrationv1.OperationType]()
+				}
+				resources["pods/ephemeralcontainers"].Insert(ops.UnsortedList()...)
+			}
+		}
+		// resources sharing the same operations are grouped in the same rule
+		byOperations := map[string][]string{}
+		operations := map[string][]admissionregistrationv1.OperationType{}
+		for resource, ops := range resources {
+			sorted := sortOperations(ops)
+			key := fmt.Sprint(sorted)
+			byOperations[key] = append(byOperations[key], resource)
+			operations[key] = sorted
+		}
+		for key, resources := range byOperations {
+			scope := gvs.scope
+			slices.Sort(resources)
+			rules = append(rules, admissionregistrationv1.RuleWithOperations{
+				Rule: admissionregistrationv1.Rule{
+					APIGroups:   []string{gvs.Group},
+					APIVersions: []string{gvs.Version},
+					Resources:   resources,
+					Scope:       &scope,
+				},
+				Operations: operations[key],
+			})
+		}
 	}
 	less := func(a []string, b []string) (int, bool) {
 		if x := cmp.Compare(len(a), len(b)); x != 0 {
@@ -67,18 +167,25 @@ func (wh *webhook) buildRulesWithOperations(ops ...admissionregistrationv1.Opera
 		if x, match := less(a.Resources, b.Resources); match {
 			return x
 		}
-		return 0
+		if x := cmp.Compare(*a.Scope, *b.Scope); x != 0 {
+			return x
+		}
+		return slices.Compare(a.Operations, b.Operations)
 	})
 	return rules
 }
 
-func (wh *webhook) set(gvrs schema.GroupVersionResource) {
-	gv := gvrs.GroupVersion()
-	resources := wh.rules[gv]
+func (wh *webhook) set(gvrs schema.GroupVersionResource, scope admissionregistrationv1.ScopeType, ops ...admissionregistrationv1.OperationType) {
+	gvs := groupVersionScope{GroupVersion: gvrs.GroupVersion(), scope: scope}
+	resources := wh.rules[gvs]
 	if resources == nil {
-		wh.rules[gv] = sets.New(gvrs.Resource)
+		resources = map[string]sets.Set[admissionregistrationv1.OperationType]{}
+		wh.rules[gvs] = resources
+	}
+	if resources[gvrs.Resource] == nil {
+		resources[gvrs.Resource] = sets.New(ops...)
 	} else {
-		resources.Insert(gvrs.Resource)
+		resources[gvrs.Resource].Insert(ops...)
 	}
 }
 
@@ -86,6 +193,161 @@ func (wh *webhook) isEmpty() bool {
 	return len(wh.rules) == 0
 }
 
+// mergeObjectRequirements keeps the label requirements shared with the previously merged rules,
+// nil requirements mean the rule can match objects regardless of their labels.
+func (wh *webhook) mergeObjectRequirements(requirements map[string]metav1.LabelSelectorRequirement) {
+	if wh.objectRequirements == nil {
+		wh.objectRequirements = map[string]metav1.LabelSelectorRequirement{}
+		maps.Copy(wh.objectRequirements, requirements)
+		return
+	}
+	for key := range wh.objectRequirements {
+		if _, ok := requirements[key]; !ok {
+			delete(wh.objectRequirements, key)
+		}
+	}
+}
+
+// buildObjectSelector adds the label requirements shared by all the rules to the configured object selector.
+func (wh *webhook) buildObjectSelector(configured *metav1.LabelSelector) *metav1.LabelSelector {
+	if len(wh.objectRequirements) == 0 {
+		return configured
+	}
+	selector := &metav1.LabelSelector{}
+	if configured != nil {
+		selector = configured.DeepCopy()
+	}
+	for _, key := range sets.List(sets.KeySet(wh.objectRequirements)) {
+		selector.MatchExpressions = append(selector.MatchExpressions, wh.objectRequirements[key])
+	}
+	return selector
+}
+
+// labelRequirements normalizes a label selector to a set of requirements keyed by their string representation,
+// it returns nil when the selector can't be evaluated by the API server (wildcards).
+func labelRequirements(selector *metav1.LabelSelector) map[string]metav1.LabelSelectorRequirement {
+	if selector == nil || kubeutils.LabelSelectorContainsWildcard(selector) {
+		return nil
+	}
+	requirements := map[string]metav1.LabelSelectorRequirement{}
+	add := func(requirement metav1.LabelSelectorRequirement) {
+		requirement.Values = slices.Clone(requirement.Values)
+		slices.Sort(requirement.Values)
+		requirements[fmt.Sprintf("%s %s %v", requirement.Key, requirement.Operator, requirement.Values)] = requirement
+	}
+	for key, value := range selector.MatchLabels {
+		add(metav1.LabelSelectorRequirement{Key: key, Operator: metav1.LabelSelectorOpIn, Values: []string{value}})
+	}
+	for _, expression := range selector.MatchExpressions {
+		add(expression)
+	}
+	return requirements
+}
+
+// ruleObjectRequirements returns the label requirements an object must satisfy to be matched by the rule.
+func ruleObjectRequirements(match kyvernov1.MatchResources) map[string]metav1.LabelSelectorRequirement {
+	if len(match.Any) != 0 {
+		// every filter must share the requirement
+		var requirements map[string]metav1.LabelSelectorRequirement
+		for i, filter := range match.Any {
+			filterRequirements := labelRequirements(filter.Selector)
+			if i == 0 {
+				requirements = filterRequirements
+				continue
+			}
+			for key := range requirements {
+				if _, ok := filterRequirements[key]; !ok {
+					delete(requirements, key)
+				}
+			}
+		}
+		return requirements
+	}
+	if len(match.All) != 0 {
+		// requirements of any filter apply
+		var requirements map[string]metav1.LabelSelectorRequirement
+		for _, filter := range match.All {
+			if filterRequirements := labelRequirements(filter.Selector); filterRequirements != nil {
+				if requirements == nil {
+					requirements = map[string]metav1.LabelSelectorRequirement{}
+				}
+				maps.Copy(requirements, filterRequirements)
+			}
+		}
+		return requirements
+	}
+	return labelRequirements(match.Selector)
+}
+
+// matchesSubresources returns true if any of the kinds targets a subresource.
+func matchesSubresources(kinds []string) bool {
+	for _, kind := range kinds {
+		if _, _, _, subresource := kubeutils.ParseKindSelector(kind); subresource != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleOperations returns the operations matched by a rule, among the given defaults.
+func ruleOperations(rule kyvernov1.Rule, defaults ...admissionregistrationv1.OperationType) []admissionregistrationv1.OperationType {
+	toSet := func(ops []kyvernov1.AdmissionOperation) sets.Set[admissionregistrationv1.OperationType] {
+		if len(ops) == 0 {
+			return sets.New(defaults...)
+		}
+		result := sets.New[admissionregistrationv1.OperationType]()
+		for _, op := range ops {
+			result.Insert(admissionregistrationv1.OperationType(op))
+		}
+		return result
+	}
+	match := rule.MatchResources
+	var ops sets.Set[admissionregistrationv1.OperationType]
+	if len(match.Any) != 0 {
+		ops = sets.New[admissionregistrationv1.OperationType]()
+		for _, filter := range match.Any {
+			ops = ops.Union(toSet(filter.Operations))
+		}
+	} else if len(match.All) != 0 {
+		ops = sets.New(defaults...)
+		for _, filter := range match.All {
+			ops = ops.Intersection(toSet(filter.Operations))
+		}
+	} else {
+		ops = toSet(match.Operations)
+	}
+	// exclude filters declaring only operations exclude them for all the matched resources
+	exclude := rule.ExcludeResources
+	excluded := sets.New[admissionregistrationv1.OperationType]()
+	for _, filter := range exclude.Any {
+		if filter.ResourceDescription.IsEmpty() && filter.UserInfo.IsEmpty() && len(filter.Operations) != 0 {
+			excluded.Insert(toSet(filter.Operations).UnsortedList()...)
+		}
+	}
+	if exclude.ResourceDescription.IsEmpty() && exclude.UserInfo.IsEmpty() && len(exclude.Any) == 0 && len(exclude.All) == 0 && len(exclude.Operations) != 0 {
+		excluded.Insert(toSet(exclude.Operations).UnsortedList()...)
+	}
+	return sortOperations(ops.Intersection(sets.New(defaults...)).Difference(excluded))
+}
+
+// operationsOrder is the order operations are listed in webhook rules
+var operationsOrder = []admissionregistrationv1.OperationType{
+	admissionregistrationv1.Create,
+	admissionregistrationv1.Update,
+	admissionregistrationv1.Delete,
+	admissionregistrationv1.Connect,
+}
+
+func sortOperations(ops sets.Set[admissionregistrationv1.OperationType]) []admissionregistrationv1.OperationType {
+	var sorted []admissionregistrationv1.OperationType
+	for _, op := range operationsOrder {
+		if ops.Has(op) {
+			sorted = append(sorted, op)
+		}
+	}
+	return sorted
+}
+
 func objectMeta(name string, annotations map[string]string, labels map[string]string, owner ...metav1.OwnerReference) metav1.ObjectMeta {
 	desiredLabels := make(map[string]string)
 	defaultLabels := map[string]string{
@@ -125,9 +387,87 @@ func setRuleCount(rules []kyvernov1.Rule, status *kyvernov1.PolicyStatus) {
 	status.RuleCount.VerifyImages = verifyImagesCount
 }
 
-func capTimeout(maxWebhookTimeout int32) int32 {
-	if maxWebhookTimeout > 30 {
+// ruleWebhooks returns whether the rule is served by the mutating and the validating resource webhooks
+func ruleWebhooks(rule kyvernov1.Rule) (mutating bool, validating bool) {
+	mutating = rule.HasMutate() || rule.HasVerifyImages()
+	validating = rule.HasValidate() || rule.HasGenerate() || rule.HasMutate() || rule.HasVerifyImageChecks() || rule.HasVerifyManifests()
+	return mutating, validating
+}
+
+// webhookCondition returns the WebhookConfigured condition of a rule given whether the webhooks serving the policy are configured
+func webhookCondition(rule kyvernov1.Rule, admissionProcessing bool, mutatingConfigured bool, validatingConfigured bool) metav1.Condition {
+	condition := metav1.Condition{
+		Type:    kyvernov1.RuleConditionWebhookConfigured,
+		Status:  metav1.ConditionTrue,
+		Reason:  kyvernov1.RuleReasonWebhookConfigured,
+		Message: "Webhooks are configured",
+	}
+	mutating, validating := ruleWebhooks(rule)
+	if !admissionProcessing || (!mutating && !validating) {
+		condition.Reason = kyvernov1.RuleReasonWebhookNotRequired
+		condition.Message = "Rule is not processed at admission"
+		return condition
+	}
+	var pending []string
+	if mutating && !mutatingConfigured {
+		pending = append(pending, "mutating")
+	}
+	if validating && !validatingConfigured {
+		pending = append(pending, "validating")
+	}
+	if len(pending) != 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = kyvernov1.RuleReasonWebhookPending
+		condition.Message = fmt.Sprintf("Waiting for the %s webhook to be configured", strings.Join(pending, " and "))
+	}
+	return condition
+}
+
+// contextCondition returns the ContextResolvable condition of a rule, only the config maps referenced
+// by a static name are checked as other context entries depend on the resource being processed
+func contextCondition(ctx context.Context, resolver engineapi.ConfigmapResolver, rule kyvernov1.Rule) metav1.Condition {
+	condition := metav1.Condition{
+		Type:    kyvernov1.RuleConditionContextResolvable,
+		Status:  metav1.ConditionTrue,
+		Reason:  kyvernov1.RuleReasonContextResolved,
+		Message: "Context entries can be resolved",
+	}
+	if resolver == nil {
+		return condition
+	}
+	for _, entry := range rule.Context {
+		if entry.ConfigMap == nil || strings.Contains(entry.ConfigMap.Name, "{{") || strings.Contains(entry.ConfigMap.Namespace, "{{") {
+			continue
+		}
+		namespace := entry.ConfigMap.Namespace
+		if namespace == "" {
+			namespace = "default"
+		}
+		if _, err := resolver.Get(ctx, namespace, entry.ConfigMap.Name); err != nil {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = kyvernov1.RuleReasonContextUnresolved
+			condition.Message = fmt.Sprintf("Context entry %s can not be resolved: %s", entry.Name, err)
+			return condition
+		}
+	}
+	return condition
+}
+
+// autogenRuleNames returns the names of the rules auto generated from a rule, autogen rules are named
+// autogen-<rule> and autogen-<kind>-<rule> for CronJob and the custom pod controllers
+func autogenRuleNames(rule kyvernov1.Rule, rules []kyvernov1.Rule) []string {
+	var names []string
+	for _, r := range rules {
+		if strings.HasPrefix(r.Name, "autogen-") && strings.HasSuffix(r.Name, "-"+rule.Name) {
+			names = append(names, r.Name)
+		}
+	}
+	return names
+}
+
+func capTimeout(timeout int32) int32 {
+	if timeout > 30 {
 		return 30
 	}
-	return maxWebhookTimeout
+	return timeout
 }
@@ -1,14 +1,19 @@
 package webhook
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
 	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
 	"gotest.tools/assert"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubefake "k8s.io/client-go/kubernetes/fake"
 )
 
 func Test_webhook_isEmpty(t *testing.T) {
@@ -17,7 +22,7 @@ func Test_webhook_isEmpty(t *testing.T) {
 	notEmpty := newWebhook(DefaultWebhookTimeout, admissionregistrationv1.Ignore)
 	notEmpty.set(schema.GroupVersionResource{
 		Group: "", Version: "v1", Resource: "pods",
-	})
+	}, admissionregistrationv1.NamespacedScope, admissionregistrationv1.Create)
 	assert.Equal(t, notEmpty.isEmpty(), false)
 }
 
@@ -152,10 +157,206 @@ func Test_RuleCount(t *testing.T) {
 	err := json.Unmarshal([]byte(policy), &cpol)
 	assert.NilError(t, err)
 	status := cpol.GetStatus()
-	rules := autogen.ComputeRules(&cpol)
+	rules := autogen.ComputeRules(&cpol, nil)
 	setRuleCount(rules, status)
 	assert.Equal(t, status.RuleCount.Validate, 0)
 	assert.Equal(t, status.RuleCount.Generate, 0)
 	assert.Equal(t, status.RuleCount.Mutate, 1)
 	assert.Equal(t, status.RuleCount.VerifyImages, 2)
 }
+
+func Test_webhook_buildRulesWithOperations(t *testing.T) {
+	wh := newWebhook(DefaultWebhookTimeout, admissionregistrationv1.Ignore)
+	wh.set(schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"}, admissionregistrationv1.NamespacedScope, admissionregistrationv1.Create)
+	wh.set(schema.GroupVersionResource{Group: "", Version: "v1", Resource: "configmaps"}, admissionregistrationv1.NamespacedScope, admissionregistrationv1.Create, admissionregistrationv1.Update)
+	wh.set(schema.GroupVersionResource{Group: "", Version: "v1", Resource: "configmaps"}, admissionregistrationv1.NamespacedScope, admissionregistrationv1.Delete)
+	wh.set(schema.GroupVersionResource{Group: "", Version: "v1", Resource: "namespaces"}, admissionregistrationv1.ClusterScope, admissionregistrationv1.Create)
+	rules := wh.buildRulesWithOperations()
+	assert.Equal(t, len(rules), 3)
+	assert.DeepEqual(t, rules[0].Resources, []string{"configmaps"})
+	assert.DeepEqual(t, rules[0].Operations, []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete})
+	assert.DeepEqual(t, rules[1].Resources, []string{"namespaces"})
+	assert.Equal(t, *rules[1].Scope, admissionregistrationv1.ClusterScope)
+	assert.DeepEqual(t, rules[2].Resources, []string{"pods", "pods/ephemeralcontainers"})
+	assert.DeepEqual(t, rules[2].Operations, []admissionregistrationv1.OperationType{admissionregistrationv1.Create})
+	assert.Equal(t, *rules[2].Scope, admissionregistrationv1.NamespacedScope)
+}
+
+func Test_ruleOperations(t *testing.T) {
+	defaults := []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete, admissionregistrationv1.Connect}
+	tests := []struct {
+		name string
+		rule kyverno.Rule
+		want []admissionregistrationv1.OperationType
+	}{{
+		name: "no operations",
+		rule: kyverno.Rule{
+			MatchResources: kyverno.MatchResources{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Pod"}}},
+		},
+		want: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete, admissionregistrationv1.Connect},
+	}, {
+		name: "any",
+		rule: kyverno.Rule{
+			MatchResources: kyverno.MatchResources{Any: kyverno.ResourceFilters{
+				{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Pod"}, Operations: []kyverno.AdmissionOperation{kyverno.Create}}},
+				{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Service"}, Operations: []kyverno.AdmissionOperation{kyverno.Update}}},
+			}},
+		},
+		want: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+	}, {
+		name: "all",
+		rule: kyverno.Rule{
+			MatchResources: kyverno.MatchResources{All: kyverno.ResourceFilters{
+				{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Pod"}, Operations: []kyverno.AdmissionOperation{kyverno.Create, kyverno.Update}}},
+				{ResourceDescription: kyverno.ResourceDescription{Namespaces: []string{"prod"}, Operations: []kyverno.AdmissionOperation{kyverno.Update}}},
+			}},
+		},
+		want: []admissionregistrationv1.OperationType{admissionregistrationv1.Update},
+	}, {
+		name: "excluded operations",
+		rule: kyverno.Rule{
+			MatchResources: kyverno.MatchResources{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Pod"}}},
+			ExcludeResources: kyverno.MatchResources{Any: kyverno.ResourceFilters{
+				{ResourceDescription: kyverno.ResourceDescription{Operations: []kyverno.AdmissionOperation{kyverno.Delete, kyverno.Connect}}},
+			}},
+		},
+		want: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+	}, {
+		name: "exclude with other criteria",
+		rule: kyverno.Rule{
+			MatchResources: kyverno.MatchResources{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Pod"}, Operations: []kyverno.AdmissionOperation{kyverno.Create}}},
+			ExcludeResources: kyverno.MatchResources{Any: kyverno.ResourceFilters{
+				{ResourceDescription: kyverno.ResourceDescription{Namespaces: []string{"kube-system"}, Operations: []kyverno.AdmissionOperation{kyverno.Create}}},
+			}},
+		},
+		want: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.DeepEqual(t, ruleOperations(tt.rule, defaults...), tt.want)
+		})
+	}
+}
+
+func Test_matchesSubresources(t *testing.T) {
+	assert.Assert(t, !matchesSubresources([]string{"Pod", "apps/v1/Deployment", "*"}))
+	assert.Assert(t, matchesSubresources([]string{"Pod", "Deployment/scale"}))
+	assert.Assert(t, matchesSubresources([]string{"Pod/*"}))
+}
+
+func Test_webhook_buildObjectSelector(t *testing.T) {
+	team := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+	teamAndTier := &metav1.LabelSelector{
+		MatchLabels:      map[string]string{"team": "a"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: metav1.LabelSelectorOpExists}},
+	}
+	configured := &metav1.LabelSelector{MatchLabels: map[string]string{"managed": "true"}}
+	// requirements shared by all the rules are pushed down
+	wh := newWebhook(DefaultWebhookTimeout, admissionregistrationv1.Ignore)
+	wh.mergeObjectRequirements(ruleObjectRequirements(kyverno.MatchResources{ResourceDescription: kyverno.ResourceDescription{Selector: team}}))
+	wh.mergeObjectRequirements(ruleObjectRequirements(kyverno.MatchResources{Any: kyverno.ResourceFilters{
+		{ResourceDescription: kyverno.ResourceDescription{Selector: teamAndTier}},
+		{ResourceDescription: kyverno.ResourceDescription{Selector: team}},
+	}}))
+	assert.DeepEqual(t, wh.buildObjectSelector(configured), &metav1.LabelSelector{
+		MatchLabels: map[string]string{"managed": "true"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"a"}},
+		},
+	})
+	// a rule without selector disables the push down
+	wh.mergeObjectRequirements(ruleObjectRequirements(kyverno.MatchResources{ResourceDescription: kyverno.ResourceDescription{Kinds: []string{"Pod"}}}))
+	assert.Equal(t, wh.buildObjectSelector(configured), configured)
+	// wildcards can't be evaluated by the API server
+	wildcard := newWebhook(DefaultWebhookTimeout, admissionregistrationv1.Ignore)
+	wildcard.mergeObjectRequirements(ruleObjectRequirements(kyverno.MatchResources{ResourceDescription: kyverno.ResourceDescription{
+		Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "*"}},
+	}}))
+	assert.Assert(t, wildcard.buildObjectSelector(nil) == nil)
+}
+
+func Test_webhookGroup(t *testing.T) {
+	webhooks := map[webhookGroup]*webhook{
+		{failurePolicy: admissionregistrationv1.Fail, timeout: 15}: nil,
+		{failurePolicy: admissionregistrationv1.Fail}:              nil,
+		{failurePolicy: admissionregistrationv1.Ignore}:            nil,
+	}
+	groups := sortedWebhookGroups(webhooks)
+	assert.Equal(t, len(groups), 3)
+	assert.Equal(t, groups[0].suffix(), "-ignore")
+	assert.Equal(t, groups[0].path(), "/ignore/default")
+	assert.Equal(t, groups[1].suffix(), "-fail")
+	assert.Equal(t, groups[1].path(), "/fail/default")
+	assert.Equal(t, groups[2].suffix(), "-fail-15s")
+	assert.Equal(t, groups[2].path(), "/fail/15")
+}
+
+func Test_webhookGroup_ReinvocationPolicy(t *testing.T) {
+	webhooks := map[webhookGroup]*webhook{
+		{failurePolicy: admissionregistrationv1.Fail, reinvocationPolicy: admissionregistrationv1.IfNeededReinvocationPolicy}:           nil,
+		{failurePolicy: admissionregistrationv1.Fail, reinvocationPolicy: admissionregistrationv1.NeverReinvocationPolicy}:              nil,
+		{failurePolicy: admissionregistrationv1.Fail, timeout: 15, reinvocationPolicy: admissionregistrationv1.NeverReinvocationPolicy}: nil,
+	}
+	groups := sortedWebhookGroups(webhooks)
+	assert.Equal(t, len(groups), 3)
+	assert.Equal(t, groups[0].suffix(), "-fail-never")
+	assert.Equal(t, groups[0].path(), "/fail/default/never")
+	assert.Equal(t, groups[1].suffix(), "-fail")
+	assert.Equal(t, groups[1].path(), "/fail/default/ifneeded")
+	assert.Equal(t, groups[2].suffix(), "-fail-15s-never")
+	assert.Equal(t, groups[2].path(), "/fail/15/never")
+}
+
+func Test_webhookCondition(t *testing.T) {
+	var cpol kyverno.ClusterPolicy
+	err := json.Unmarshal([]byte(policy), &cpol)
+	assert.NilError(t, err)
+	rule := cpol.Spec.Rules[0]
+	condition := webhookCondition(rule, true, true, true)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Equal(t, condition.Reason, kyverno.RuleReasonWebhookConfigured)
+	condition = webhookCondition(rule, true, false, true)
+	assert.Equal(t, condition.Status, metav1.ConditionFalse)
+	assert.Equal(t, condition.Reason, kyverno.RuleReasonWebhookPending)
+	assert.Equal(t, condition.Message, "Waiting for the mutating webhook to be configured")
+	condition = webhookCondition(rule, false, false, false)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Equal(t, condition.Reason, kyverno.RuleReasonWebhookNotRequired)
+}
+
+func Test_contextCondition(t *testing.T) {
+	client := kubefake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "allowed", Namespace: "default"},
+	})
+	resolver, err := resolvers.NewClientBasedResolver(client)
+	assert.NilError(t, err)
+	rule := kyverno.Rule{
+		Name: "rule",
+		Context: []kyverno.ContextEntry{{
+			Name:      "allowed",
+			ConfigMap: &kyverno.ConfigMapReference{Name: "allowed"},
+		}, {
+			Name:      "dynamic",
+			ConfigMap: &kyverno.ConfigMapReference{Name: "{{ request.object.metadata.name }}", Namespace: "default"},
+		}},
+	}
+	condition := contextCondition(context.TODO(), resolver, rule)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Equal(t, condition.Reason, kyverno.RuleReasonContextResolved)
+	rule.Context = append(rule.Context, kyverno.ContextEntry{
+		Name:      "missing",
+		ConfigMap: &kyverno.ConfigMapReference{Name: "missing", Namespace: "kyverno"},
+	})
+	condition = contextCondition(context.TODO(), resolver, rule)
+	assert.Equal(t, condition.Status, metav1.ConditionFalse)
+	assert.Equal(t, condition.Reason, kyverno.RuleReasonContextUnresolved)
+}
+
+func Test_autogenRuleNames(t *testing.T) {
+	var cpol kyverno.ClusterPolicy
+	err := json.Unmarshal([]byte(policy), &cpol)
+	assert.NilError(t, err)
+	rules := autogen.ComputeRules(&cpol, nil)
+	rule := cpol.Spec.Rules[0]
+	assert.DeepEqual(t, autogenRuleNames(rule, rules), []string{"autogen-" + rule.Name, "autogen-cronjob-" + rule.Name})
+}
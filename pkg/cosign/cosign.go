@@ -21,7 +21,6 @@ import (
 	"github.com/sigstore/cosign/v2/pkg/oci"
 	"github.com/sigstore/cosign/v2/pkg/oci/remote"
 	sigs "github.com/sigstore/cosign/v2/pkg/signature"
-	rekorclient "github.com/sigstore/rekor/pkg/client"
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
 	"github.com/sigstore/sigstore/pkg/fulcioroots"
 	"github.com/sigstore/sigstore/pkg/signature"
@@ -186,7 +185,7 @@ func buildCosignOptions(ctx context.Context, opts images.Options) (*cosign.Check
 
 	cosignOpts.IgnoreTlog = opts.IgnoreTlog
 	if !opts.IgnoreTlog {
-		cosignOpts.RekorClient, err = rekorclient.GetRekorClient(opts.RekorURL)
+		cosignOpts.RekorClient, err = getRekorClient(opts.RekorURL, opts.Client)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create Rekor client from URL %s: %w", opts.RekorURL, err)
 		}
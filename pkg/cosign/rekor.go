@@ -0,0 +1,42 @@
+package cosign
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/go-openapi/runtime"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/kyverno/kyverno/pkg/images"
+	rekorclient "github.com/sigstore/rekor/pkg/client"
+	rekorgenerated "github.com/sigstore/rekor/pkg/generated/client"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+// outboundTransportProvider is implemented by registry clients configured with the outbound transport.
+type outboundTransportProvider interface {
+	OutboundTransport() http.RoundTripper
+}
+
+// getRekorClient creates a Rekor client sharing the outbound transport of the registry client when one is
+// configured, this mirrors rekorclient.GetRekorClient which does not allow to customize the transport.
+func getRekorClient(rekorURL string, client images.Client) (*rekorgenerated.Rekor, error) {
+	provider, ok := client.(outboundTransportProvider)
+	if !ok || provider.OutboundTransport() == nil {
+		return rekorclient.GetRekorClient(rekorURL)
+	}
+	u, err := url.Parse(rekorURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Path == "" {
+		u.Path = rekorgenerated.DefaultBasePath
+	}
+	rt := httptransport.NewWithClient(u.Host, u.Path, []string{u.Scheme}, &http.Client{Transport: provider.OutboundTransport()})
+	rt.Consumers["application/json"] = runtime.JSONConsumer()
+	rt.Consumers["application/x-pem-file"] = runtime.TextConsumer()
+	rt.Producers["application/json"] = runtime.JSONProducer()
+	registry := strfmt.Default
+	registry.Add("signedCheckpoint", &util.SignedNote{}, util.SignedCheckpointValidator)
+	return rekorgenerated.New(rt, registry), nil
+}
@@ -0,0 +1,110 @@
+package decisionlog
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Decision is the structured record of an admission request processed by a webhook.
+type Decision struct {
+	Time        time.Time        `json:"time"`
+	UID         types.UID        `json:"uid"`
+	Webhook     string           `json:"webhook"`
+	Operation   string           `json:"operation"`
+	Kind        string           `json:"kind"`
+	SubResource string           `json:"subResource,omitempty"`
+	Namespace   string           `json:"namespace,omitempty"`
+	Name        string           `json:"name,omitempty"`
+	User        string           `json:"user"`
+	DryRun      bool             `json:"dryRun,omitempty"`
+	Allowed     bool             `json:"allowed"`
+	Message     string           `json:"message,omitempty"`
+	Warnings    []string         `json:"warnings,omitempty"`
+	Latency     float64          `json:"latencySeconds"`
+	Policies    []PolicyDecision `json:"policies,omitempty"`
+	Patches     json.RawMessage  `json:"patches,omitempty"`
+}
+
+// PolicyDecision holds the rules of a policy evaluated on the admission request.
+type PolicyDecision struct {
+	Name      string         `json:"name"`
+	Namespace string         `json:"namespace,omitempty"`
+	Rules     []RuleDecision `json:"rules,omitempty"`
+}
+
+// RuleDecision holds the outcome of a rule.
+type RuleDecision struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+type collectorKey struct{}
+
+// Collector accumulates the engine responses produced while an admission request is processed.
+type Collector struct {
+	lock      sync.Mutex
+	responses []engineapi.EngineResponse
+}
+
+// NewContext returns a context collecting the engine responses recorded while the admission request is processed,
+// the collector of the context is reused when there is one already.
+func NewContext(ctx context.Context) (context.Context, *Collector) {
+	if c, ok := ctx.Value(collectorKey{}).(*Collector); ok {
+		return ctx, c
+	}
+	c := &Collector{}
+	return context.WithValue(ctx, collectorKey{}, c), c
+}
+
+// Record adds the engine responses to the decision of the admission request being processed,
+// it does nothing when decision logging is not enabled.
+func Record(ctx context.Context, responses ...engineapi.EngineResponse) {
+	c, ok := ctx.Value(collectorKey{}).(*Collector)
+	if !ok || len(responses) == 0 {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.responses = append(c.responses, responses...)
+}
+
+// Responses returns the engine responses recorded while the admission request was processed.
+func (c *Collector) Responses() []engineapi.EngineResponse {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append([]engineapi.EngineResponse(nil), c.responses...)
+}
+
+// Policies returns the policies and rules evaluated on the admission request.
+func (c *Collector) Policies() []PolicyDecision {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	var policies []PolicyDecision
+	for _, response := range c.responses {
+		if len(response.PolicyResponse.Rules) == 0 {
+			continue
+		}
+		policy := response.Policy()
+		decision := PolicyDecision{
+			Name:      policy.GetName(),
+			Namespace: policy.GetNamespace(),
+		}
+		for _, rule := range response.PolicyResponse.Rules {
+			decision.Rules = append(decision.Rules, RuleDecision{
+				Name:    rule.Name(),
+				Type:    string(rule.RuleType()),
+				Status:  string(rule.Status()),
+				Message: rule.Message(),
+			})
+		}
+		policies = append(policies, decision)
+	}
+	return policies
+}
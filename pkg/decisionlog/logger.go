@@ -0,0 +1,62 @@
+package decisionlog
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"io"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Logger writes the decisions as JSON lines to a sink.
+type Logger struct {
+	logger   logr.Logger
+	lock     sync.Mutex
+	sink     io.Writer
+	sampling int
+}
+
+// NewLogger returns a logger writing one in sampling allowed decisions to the sink,
+// denied decisions are always written.
+func NewLogger(logger logr.Logger, sink io.Writer, sampling int) *Logger {
+	if sampling < 1 {
+		sampling = 1
+	}
+	return &Logger{
+		logger:   logger,
+		sink:     sink,
+		sampling: sampling,
+	}
+}
+
+// Log writes the decision to the sink when it is sampled, a failure to write is logged but not returned,
+// the decision log never affects the admission response.
+func (l *Logger) Log(decision Decision) {
+	if decision.Allowed && !IsSampled(decision.UID, l.sampling) {
+		return
+	}
+	data, err := json.Marshal(decision)
+	if err != nil {
+		l.logger.Error(err, "failed to marshal admission decision", "uid", decision.UID)
+		return
+	}
+	data = append(data, '\n')
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if _, err := l.sink.Write(data); err != nil {
+		l.logger.Error(err, "failed to write admission decision", "uid", decision.UID)
+	}
+}
+
+// IsSampled returns true for one in rate requests, it is deterministic for a given request so that the mutating
+// and validating decisions of a request are either both kept or both dropped.
+func IsSampled(uid types.UID, rate int) bool {
+	if rate == 1 {
+		return true
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(uid))
+	return h.Sum32()%uint32(rate) == 0
+}
@@ -0,0 +1,77 @@
+package decisionlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestRecord(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "require-labels"}}
+	response := engineapi.NewEngineResponse(unstructured.Unstructured{}, engineapi.NewKyvernoPolicy(policy), nil).
+		WithPolicyResponse(engineapi.PolicyResponse{
+			Rules: []engineapi.RuleResponse{*engineapi.RuleFail("check-team", engineapi.Validation, "label team is required")},
+		})
+	// recording without a collector is a no-op
+	Record(context.Background(), response)
+	ctx, collector := NewContext(context.Background())
+	if _, reused := NewContext(ctx); reused != collector {
+		t.Fatal("expected the collector of the context to be reused")
+	}
+	Record(ctx, response)
+	policies := collector.Policies()
+	if len(policies) != 1 || policies[0].Name != "require-labels" {
+		t.Fatalf("unexpected policies %v", policies)
+	}
+	want := RuleDecision{Name: "check-team", Type: "Validation", Status: "fail", Message: "label team is required"}
+	if len(policies[0].Rules) != 1 || policies[0].Rules[0] != want {
+		t.Errorf("unexpected rules %v, want %v", policies[0].Rules, want)
+	}
+}
+
+func TestLoggerSampling(t *testing.T) {
+	var buffer bytes.Buffer
+	logger := NewLogger(logr.Discard(), &buffer, 4)
+	allowed := 0
+	for i := 0; i < 100; i++ {
+		logger.Log(Decision{UID: types.UID(fmt.Sprintf("uid-%d", i)), Allowed: true})
+		logger.Log(Decision{UID: types.UID(fmt.Sprintf("denied-%d", i)), Allowed: false})
+	}
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	for _, line := range lines {
+		var decision Decision
+		if err := json.Unmarshal([]byte(line), &decision); err != nil {
+			t.Fatalf("failed to decode decision %q: %v", line, err)
+		}
+		if decision.Allowed {
+			allowed++
+		}
+	}
+	if denied := len(lines) - allowed; denied != 100 {
+		t.Errorf("expected all 100 denied decisions, got %d", denied)
+	}
+	if allowed == 0 || allowed >= 100 {
+		t.Errorf("expected allowed decisions to be sampled, got %d", allowed)
+	}
+}
+
+func TestNewSink(t *testing.T) {
+	for _, target := range []string{"stdout", "unix:///tmp/kyverno.sock", "tcp://localhost:5170"} {
+		if _, err := NewSink(target); err != nil {
+			t.Errorf("unexpected error for %s: %v", target, err)
+		}
+	}
+	if _, err := NewSink("http://localhost"); err == nil {
+		t.Error("expected an error for an unsupported sink")
+	}
+}
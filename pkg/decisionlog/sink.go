@@ -0,0 +1,74 @@
+package decisionlog
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const dialTimeout = 5 * time.Second
+
+// NewSink returns the writer decisions are sent to, the target is one of:
+// - `stdout`
+// - `file://<path>`, decisions are appended to the file
+// - `unix://<path>` or `tcp://<host>:<port>`, decisions are streamed to the socket
+func NewSink(target string) (io.WriteCloser, error) {
+	switch {
+	case target == "stdout":
+		return nopCloser{os.Stdout}, nil
+	case strings.HasPrefix(target, "file://"):
+		return os.OpenFile(strings.TrimPrefix(target, "file://"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600) //nolint:gosec
+	case strings.HasPrefix(target, "unix://"):
+		return &socketSink{network: "unix", address: strings.TrimPrefix(target, "unix://")}, nil
+	case strings.HasPrefix(target, "tcp://"):
+		return &socketSink{network: "tcp", address: strings.TrimPrefix(target, "tcp://")}, nil
+	}
+	return nil, fmt.Errorf("unsupported decision log sink %q, expected stdout, file://, unix:// or tcp://", target)
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
+// socketSink connects lazily and reconnects on the next write when the connection fails.
+type socketSink struct {
+	network string
+	address string
+	lock    sync.Mutex
+	conn    net.Conn
+}
+
+func (s *socketSink) Write(data []byte) (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.conn == nil {
+		conn, err := net.DialTimeout(s.network, s.address, dialTimeout)
+		if err != nil {
+			return 0, err
+		}
+		s.conn = conn
+	}
+	n, err := s.conn.Write(data)
+	if err != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+	return n, err
+}
+
+func (s *socketSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"time"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/ext/wildcard"
@@ -25,6 +26,8 @@ type EngineResponse struct {
 	PolicyResponse PolicyResponse
 	// stats contains engine statistics
 	stats ExecutionStats
+	// scheduleActive records if the policy schedule was active when the policy was applied
+	scheduleActive *bool
 }
 
 func resource(policyContext PolicyContext) unstructured.Unstructured {
@@ -71,6 +74,12 @@ func (r EngineResponse) WithStats(stats ExecutionStats) EngineResponse {
 	return r
 }
 
+// WithScheduleActive records if the policy schedule was active when the policy was applied
+func (er EngineResponse) WithScheduleActive(active bool) EngineResponse {
+	er.scheduleActive = &active
+	return er
+}
+
 func (er EngineResponse) WithPatchedResource(patchedResource unstructured.Unstructured) EngineResponse {
 	er.PatchedResource = patchedResource
 	return er
@@ -89,6 +98,10 @@ func (er *EngineResponse) Policy() GenericPolicy {
 	return er.policy
 }
 
+func (er *EngineResponse) Stats() ExecutionStats {
+	return er.stats
+}
+
 // IsOneOf checks if any rule has status in a given list
 func (er EngineResponse) IsOneOf(status ...RuleStatus) bool {
 	for _, r := range er.PolicyResponse.Rules {
@@ -119,6 +132,11 @@ func (er EngineResponse) IsError() bool {
 	return er.IsOneOf(RuleStatusError)
 }
 
+// IsApplied checks if any rule was applied to the resource, without skipping it
+func (er EngineResponse) IsApplied() bool {
+	return er.IsOneOf(RuleStatusPass, RuleStatusFail, RuleStatusWarn, RuleStatusError)
+}
+
 // IsEmpty checks if any rule results are present
 func (er EngineResponse) IsEmpty() bool {
 	return len(er.PolicyResponse.Rules) == 0
@@ -193,12 +211,16 @@ func (er EngineResponse) getRulesWithErrors(predicate func(RuleResponse) bool) [
 }
 
 // If the policy is of type ValidatingAdmissionPolicy, an empty string is returned.
+// Outside of the policy schedule windows, the action is always Audit.
 func (er EngineResponse) GetValidationFailureAction() kyvernov1.ValidationFailureAction {
 	pol := er.Policy()
 	if polType := pol.GetType(); polType == ValidatingAdmissionPolicyType {
 		return ""
 	}
 	spec := pol.GetPolicy().(kyvernov1.PolicyInterface).GetSpec()
+	if !er.isScheduleActive(spec.Schedule) {
+		return kyvernov1.Audit
+	}
 	for _, v := range spec.ValidationFailureActionOverrides {
 		if !v.Action.IsValid() {
 			continue
@@ -223,3 +245,15 @@ func (er EngineResponse) GetValidationFailureAction() kyvernov1.ValidationFailur
 	}
 	return spec.ValidationFailureAction
 }
+
+// isScheduleActive returns if the policy schedule was active when the policy was applied, the schedule is evaluated
+// at the time of the stats, or the current time, when the response doesn't record it.
+func (er EngineResponse) isScheduleActive(schedule *kyvernov1.PolicySchedule) bool {
+	if er.scheduleActive != nil {
+		return *er.scheduleActive
+	}
+	if at := er.stats.Time(); !at.IsZero() {
+		return schedule.IsActive(at)
+	}
+	return schedule.IsActive(time.Now())
+}
@@ -3,8 +3,10 @@ package api
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -907,6 +909,30 @@ func TestEngineResponse_GetValidationFailureAction(t *testing.T) {
 	}
 }
 
+func TestEngineResponse_GetValidationFailureAction_Schedule(t *testing.T) {
+	policy := NewKyvernoPolicy(&kyvernov1.ClusterPolicy{
+		Spec: kyvernov1.Spec{
+			ValidationFailureAction: kyvernov1.Enforce,
+			Schedule: &kyvernov1.PolicySchedule{
+				Windows: []kyvernov1.ScheduleWindow{{
+					Start:    "0 0 * * 6",
+					Duration: metav1.Duration{Duration: 48 * time.Hour},
+				}},
+			},
+		},
+	})
+	saturday := time.Date(2024, 1, 13, 12, 0, 0, 0, time.UTC)
+	monday := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	er := EngineResponse{}.WithPolicy(policy).WithStats(NewExecutionStats(saturday, saturday))
+	assert.Equal(t, er.GetValidationFailureAction(), kyvernov1.Enforce)
+	er = er.WithStats(NewExecutionStats(monday, monday))
+	assert.Equal(t, er.GetValidationFailureAction(), kyvernov1.Audit)
+	// the schedule state recorded by the engine prevails
+	assert.Equal(t, er.WithScheduleActive(true).GetValidationFailureAction(), kyvernov1.Enforce)
+	er = er.WithStats(NewExecutionStats(saturday, saturday))
+	assert.Equal(t, er.WithScheduleActive(false).GetValidationFailureAction(), kyvernov1.Audit)
+}
+
 // func TestEngineResponse_GetPatches(t *testing.T) {
 // 	type fields struct {
 // 		PatchedResource unstructured.Unstructured
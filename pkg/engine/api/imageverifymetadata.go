@@ -3,15 +3,28 @@ package api
 import (
 	"encoding/json"
 	"fmt"
-	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/api/kyverno"
+	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
 	"gomodules.xyz/jsonpatch/v2"
 )
 
 type ImageVerificationMetadata struct {
 	Data map[string]bool `json:"data"`
+	// Verified lists the images verified by rules requesting a signed stamp of the verification.
+	Verified []VerifiedImage `json:"-"`
+}
+
+// VerifiedImage records the verification of an image by an image verification rule.
+type VerifiedImage struct {
+	Image      string    `json:"image"`
+	Digest     string    `json:"digest,omitempty"`
+	Policy     string    `json:"policy"`
+	Rule       string    `json:"rule"`
+	Attestors  []string  `json:"attestors,omitempty"`
+	VerifiedAt time.Time `json:"verifiedAt"`
 }
 
 func (ivm *ImageVerificationMetadata) Add(image string, verified bool) {
@@ -21,6 +34,10 @@ func (ivm *ImageVerificationMetadata) Add(image string, verified bool) {
 	ivm.Data[image] = verified
 }
 
+func (ivm *ImageVerificationMetadata) AddVerified(image VerifiedImage) {
+	ivm.Verified = append(ivm.Verified, image)
+}
+
 func (ivm *ImageVerificationMetadata) IsVerified(image string) bool {
 	if ivm.Data == nil {
 		return false
@@ -71,6 +88,7 @@ func (ivm *ImageVerificationMetadata) Merge(other ImageVerificationMetadata) {
 	for k, v := range other.Data {
 		ivm.Add(k, v)
 	}
+	ivm.Verified = append(ivm.Verified, other.Verified...)
 }
 
 func (ivm *ImageVerificationMetadata) IsEmpty() bool {
@@ -78,5 +96,5 @@ func (ivm *ImageVerificationMetadata) IsEmpty() bool {
 }
 
 func makeAnnotationKeyForJSONPatch() string {
-	return "/metadata/annotations/" + strings.ReplaceAll(kyverno.AnnotationImageVerify, "/", "~1")
+	return jsonutils.JoinPointer("metadata", "annotations", kyverno.AnnotationImageVerify)
 }
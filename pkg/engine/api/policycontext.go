@@ -28,4 +28,5 @@ type PolicyContext interface {
 	OldPolicyContext() (PolicyContext, error)
 	JSONContext() enginecontext.Interface
 	Copy() PolicyContext
+	CopyWithJSONContext(jsonContext enginecontext.Interface) PolicyContext
 }
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // NamespacedResourceResolver is an abstract interface used to resolve namespaced resources
@@ -21,6 +23,29 @@ type NamespacedResourceResolver[T any] interface {
 // ConfigmapResolver is an abstract interface used to resolve configmaps
 type ConfigmapResolver = NamespacedResourceResolver[*corev1.ConfigMap]
 
+// RelatedResourceLister is an abstract interface used to list the resources of a kind
+// Any implementation might exist, informer based, client based etc...
+type RelatedResourceLister interface {
+	// List is used to list resources given an api version, a kind and a namespace,
+	// resources from all namespaces are listed when the namespace is empty
+	List(
+		ctx context.Context,
+		apiVersion string,
+		kind string,
+		namespace string,
+	) ([]unstructured.Unstructured, error)
+}
+
+// PolicyBindingResolver is an abstract interface used to resolve the binding of a cluster policy to a namespace
+type PolicyBindingResolver interface {
+	// Resolve returns the binding of a cluster policy to a namespace, nil when the policy is not bound to the namespace
+	Resolve(
+		ctx context.Context,
+		policyName string,
+		namespace string,
+	) (*kyvernov2alpha1.PolicyBinding, error)
+}
+
 // namespacedResourceResolverChain represents a chain of NamespacedResourceResolver
 type namespacedResourceResolverChain[T any] []NamespacedResourceResolver[T]
 
@@ -20,6 +20,27 @@ type PodSecurityChecks struct {
 	Checks []pssutils.PSSCheckResult
 }
 
+// Violation details an element of a list violating a rule
+type Violation struct {
+	// Pointer is the JSON pointer of the element, empty when the list isn't a path of the resource
+	Pointer string `json:"pointer,omitempty"`
+	// Index is the index of the element in the list
+	Index int `json:"index"`
+	// Reason explains the violation
+	Reason string `json:"reason,omitempty"`
+}
+
+func (v Violation) String() string {
+	element := v.Pointer
+	if element == "" {
+		element = fmt.Sprintf("element %d", v.Index)
+	}
+	if v.Reason == "" {
+		return element
+	}
+	return element + ": " + v.Reason
+}
+
 // RuleResponse details for each rule application
 type RuleResponse struct {
 	// name is the rule name specified in policy
@@ -28,6 +49,10 @@ type RuleResponse struct {
 	ruleType RuleType
 	// message is the message response from the rule application
 	message string
+	// remediation explains how to fix a violation
+	remediation string
+	// violations lists the elements violating the rule (only if the rule reports structured violations)
+	violations []Violation
 	// status rule status
 	status RuleStatus
 	// stats contains rule statistics
@@ -83,6 +108,21 @@ func (r RuleResponse) WithException(exception *kyvernov2.PolicyException) *RuleR
 	return &r
 }
 
+func (r RuleResponse) WithMessage(message string) *RuleResponse {
+	r.message = message
+	return &r
+}
+
+func (r RuleResponse) WithRemediation(remediation string) *RuleResponse {
+	r.remediation = remediation
+	return &r
+}
+
+func (r RuleResponse) WithViolations(violations []Violation) *RuleResponse {
+	r.violations = violations
+	return &r
+}
+
 func (r RuleResponse) WithPodSecurityChecks(checks PodSecurityChecks) *RuleResponse {
 	r.podSecurityChecks = &checks
 	return &r
@@ -133,6 +173,14 @@ func (r *RuleResponse) Message() string {
 	return r.message
 }
 
+func (r *RuleResponse) Remediation() string {
+	return r.remediation
+}
+
+func (r *RuleResponse) Violations() []Violation {
+	return r.violations
+}
+
 func (r *RuleResponse) Name() string {
 	return r.name
 }
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"sync"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// scheduleIdleTimeout is the time after which the schedule of a policy that is no longer evaluated is released
+const scheduleIdleTimeout = time.Hour
+
+// ScheduleCache holds the compiled schedules of the policies, a schedule is parsed once per policy generation.
+// A nil cache parses the schedule on every call.
+type ScheduleCache struct {
+	lock    sync.Mutex
+	entries map[types.UID]*scheduleEntry
+}
+
+type scheduleEntry struct {
+	generation int64
+	schedule   *kyvernov1.CompiledSchedule
+	lastUsed   time.Time
+}
+
+// NewScheduleCache creates a new schedule cache
+func NewScheduleCache() *ScheduleCache {
+	return &ScheduleCache{
+		entries: map[types.UID]*scheduleEntry{},
+	}
+}
+
+// IsActive returns true if now falls within one of the windows of the policy schedule, policies without schedule are always active.
+// Policies without UID, not read from the cluster, are not cached.
+func (c *ScheduleCache) IsActive(policy kyvernov1.PolicyInterface, now time.Time) bool {
+	schedule := policy.GetSpec().Schedule
+	if schedule == nil {
+		return true
+	}
+	uid := policy.GetUID()
+	if c == nil || uid == "" {
+		return schedule.IsActive(now)
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry := c.entries[uid]
+	if entry == nil || entry.generation != policy.GetGeneration() {
+		c.prune(now)
+		compiled, _ := schedule.Compile()
+		entry = &scheduleEntry{
+			generation: policy.GetGeneration(),
+			schedule:   compiled,
+		}
+		c.entries[uid] = entry
+	}
+	entry.lastUsed = now
+	return entry.schedule.IsActive(now)
+}
+
+// prune releases the schedules of the policies not evaluated recently, deleted policies are never evaluated again.
+// The lock must be held.
+func (c *ScheduleCache) prune(now time.Time) {
+	for uid, entry := range c.entries {
+		if now.Sub(entry.lastUsed) > scheduleIdleTimeout {
+			delete(c.entries, uid)
+		}
+	}
+}
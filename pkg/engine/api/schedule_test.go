@@ -0,0 +1,43 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestScheduleCache_IsActive(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "weekend", UID: "uid", Generation: 1},
+		Spec: kyvernov1.Spec{
+			Schedule: &kyvernov1.PolicySchedule{
+				Windows: []kyvernov1.ScheduleWindow{{
+					Start:    "0 0 * * 6",
+					Duration: metav1.Duration{Duration: 48 * time.Hour},
+				}},
+			},
+		},
+	}
+	saturday := time.Date(2024, 1, 13, 12, 0, 0, 0, time.UTC)
+	monday := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	cache := NewScheduleCache()
+	assert.Equal(t, cache.IsActive(policy, saturday), true)
+	assert.Equal(t, cache.IsActive(policy, monday), false)
+	// the schedule is parsed once per generation
+	policy.Spec.Schedule.Windows[0].Start = "0 0 * * 1"
+	assert.Equal(t, cache.IsActive(policy, monday), false)
+	policy.Generation = 2
+	assert.Equal(t, cache.IsActive(policy, monday), true)
+	// schedules of the policies no longer evaluated are released
+	other := policy.DeepCopy()
+	other.UID = "other"
+	assert.Equal(t, cache.IsActive(other, monday.Add(2*scheduleIdleTimeout)), true)
+	assert.Equal(t, len(cache.entries), 1)
+	// policies without schedule are always active, a nil cache parses the schedule
+	assert.Equal(t, cache.IsActive(&kyvernov1.ClusterPolicy{}, monday), true)
+	var none *ScheduleCache
+	assert.Equal(t, none.IsActive(policy, saturday), false)
+}
@@ -15,3 +15,9 @@ type NamespacedResourceSelector[T any] interface {
 
 // PolicyExceptionSelector is an abstract interface used to resolve poliicy exceptions
 type PolicyExceptionSelector = NamespacedResourceSelector[*kyvernov2.PolicyException]
+
+// PolicyExceptionUsageRecorder is an abstract interface used to record the rules skipped by policy exceptions
+type PolicyExceptionUsageRecorder interface {
+	// Record records the rules skipped by policy exceptions in the engine responses.
+	Record(responses ...EngineResponse)
+}
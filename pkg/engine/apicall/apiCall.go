@@ -3,20 +3,22 @@ package apicall
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/concurrency"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/tracing"
+	tlsutils "github.com/kyverno/kyverno/pkg/utils/tls"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
@@ -31,14 +33,25 @@ type apiCall struct {
 
 type APICallConfiguration struct {
 	maxAPICallResponseLength int64
+	transport                http.RoundTripper
+	limiter                  *concurrency.RuleLimiter
 }
 
-func NewAPICallConfiguration(maxLen int64) APICallConfiguration {
+// NewAPICallConfiguration creates the configuration of API calls, the transport is used for service calls
+// that do not declare their own CA bundle and can be nil to use the default transport.
+func NewAPICallConfiguration(maxLen int64, transport http.RoundTripper) APICallConfiguration {
 	return APICallConfiguration{
 		maxAPICallResponseLength: maxLen,
+		transport:                transport,
 	}
 }
 
+// WithLimiter returns a copy of the configuration limiting the concurrency of the calls with the given rule limiter
+func (c APICallConfiguration) WithLimiter(limiter *concurrency.RuleLimiter) APICallConfiguration {
+	c.limiter = limiter
+	return c
+}
+
 type ClientInterface interface {
 	RawAbsPath(ctx context.Context, path string, method string, dataReader io.Reader) ([]byte, error)
 }
@@ -98,12 +111,32 @@ func (a *apiCall) Store(data []byte) ([]byte, error) {
 	return results, nil
 }
 
-func (a *apiCall) execute(ctx context.Context, call *kyvernov1.APICall) ([]byte, error) {
-	if call.URLPath != "" {
-		return a.executeK8sAPICall(ctx, call.URLPath, call.Method, call.Data)
+func (a *apiCall) execute(ctx context.Context, call *kyvernov1.APICall) (data []byte, err error) {
+	// calls to the API server are already protected by the client circuit breakers, they are limited but never shed
+	var destination string
+	if call.URLPath == "" && call.Service != nil {
+		destination = serviceHost(call.Service.URL)
+	}
+	limitErr := a.config.limiter.Call(ctx, destination, func() error {
+		if call.URLPath != "" {
+			data, err = a.executeK8sAPICall(ctx, call.URLPath, call.Method, call.Data)
+		} else {
+			data, err = a.executeServiceCall(ctx, call)
+		}
+		return err
+	})
+	if err == nil && limitErr != nil {
+		return nil, fmt.Errorf("failed to execute APICall %s: %w", a.entry.Name, limitErr)
 	}
+	return data, err
+}
 
-	return a.executeServiceCall(ctx, call)
+func serviceHost(serviceURL string) string {
+	u, err := url.Parse(serviceURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
 }
 
 func (a *apiCall) executeK8sAPICall(ctx context.Context, path string, method kyvernov1.Method, data []kyvernov1.RequestData) ([]byte, error) {
@@ -153,12 +186,11 @@ func (a *apiCall) executeServiceCall(ctx context.Context, apiCall *kyvernov1.API
 
 	reader := io.LimitReader(resp.Body, max(a.config.maxAPICallResponseLength, resp.ContentLength))
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		b, err := io.ReadAll(reader)
-		if err == nil {
-			return nil, fmt.Errorf("HTTP %s: %s", resp.Status, string(b))
+		statusErr := &httpStatusError{code: resp.StatusCode, status: resp.Status}
+		if b, err := io.ReadAll(reader); err == nil {
+			statusErr.body = string(b)
 		}
-
-		return nil, fmt.Errorf("HTTP %s", resp.Status)
+		return nil, statusErr
 	}
 
 	body, err := io.ReadAll(reader)
@@ -213,17 +245,21 @@ func (a *apiCall) getToken() string {
 
 func (a *apiCall) buildHTTPClient(service *kyvernov1.ServiceCall) (*http.Client, error) {
 	if service == nil || service.CABundle == "" {
-		return http.DefaultClient, nil
+		if a.config.transport == nil {
+			return http.DefaultClient, nil
+		}
+		return &http.Client{
+			Transport: tracing.Transport(a.config.transport, otelhttp.WithFilter(tracing.RequestFilterIsInSpan)),
+		}, nil
 	}
 	caCertPool := x509.NewCertPool()
 	if ok := caCertPool.AppendCertsFromPEM([]byte(service.CABundle)); !ok {
 		return nil, fmt.Errorf("failed to parse PEM CA bundle for APICall %s", a.entry.Name)
 	}
+	tlsConfig := tlsutils.ClientConfig()
+	tlsConfig.RootCAs = caCertPool
 	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			RootCAs:    caCertPool,
-			MinVersion: tls.VersionTLS12,
-		},
+		TLSClientConfig: tlsConfig,
 	}
 	return &http.Client{
 		Transport: tracing.Transport(transport, otelhttp.WithFilter(tracing.RequestFilterIsInSpan)),
@@ -286,3 +322,20 @@ func (a *apiCall) applyJMESPathJSON(jmesPath string, jsonData []byte) (interface
 	}
 	return a.jp.Search(jmesPath, data)
 }
+
+type httpStatusError struct {
+	code   int
+	status string
+	body   string
+}
+
+func (e *httpStatusError) Error() string {
+	if e.body == "" {
+		return fmt.Sprintf("HTTP %s", e.status)
+	}
+	return fmt.Sprintf("HTTP %s: %s", e.status, e.body)
+}
+
+func (e *httpStatusError) StatusCode() int {
+	return e.code
+}
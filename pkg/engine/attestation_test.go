@@ -264,3 +264,69 @@ func Test_Conditions(t *testing.T) {
 	assert.NilError(t, err)
 	assert.Equal(t, pass, true)
 }
+
+var deploymentPredicate = `
+{
+    "predicate": {
+        "deployment": {
+            "clusters": ["prod-eu-*"],
+            "environment": "staging"
+        }
+    }
+}
+`
+
+func Test_CheckClusterMetadata(t *testing.T) {
+	var statement map[string]interface{}
+	err := json.Unmarshal([]byte(deploymentPredicate), &statement)
+	assert.NilError(t, err)
+	tests := []struct {
+		name    string
+		check   v1.AttestationClusterMetadata
+		cluster config.ClusterMetadata
+		want    bool
+		wantErr bool
+	}{{
+		name:    "cluster name matches",
+		check:   v1.AttestationClusterMetadata{ClusterName: "deployment.clusters"},
+		cluster: config.ClusterMetadata{Name: "prod-eu-1"},
+		want:    true,
+	}, {
+		name:    "cluster name doesn't match",
+		check:   v1.AttestationClusterMetadata{ClusterName: "deployment.clusters"},
+		cluster: config.ClusterMetadata{Name: "prod-us-1"},
+	}, {
+		name:    "environment matches",
+		check:   v1.AttestationClusterMetadata{Labels: map[string]string{"environment": "deployment.environment"}},
+		cluster: config.ClusterMetadata{Labels: map[string]string{"environment": "staging"}},
+		want:    true,
+	}, {
+		name:    "staging image in production",
+		check:   v1.AttestationClusterMetadata{ClusterName: "deployment.clusters", Labels: map[string]string{"environment": "deployment.environment"}},
+		cluster: config.ClusterMetadata{Name: "prod-eu-1", Labels: map[string]string{"environment": "production"}},
+	}, {
+		name:    "missing predicate field",
+		check:   v1.AttestationClusterMetadata{Labels: map[string]string{"region": "deployment.region"}},
+		cluster: config.ClusterMetadata{Labels: map[string]string{"region": "eu"}},
+	}, {
+		name:    "cluster label not configured",
+		check:   v1.AttestationClusterMetadata{Labels: map[string]string{"environment": "deployment.environment"}},
+		wantErr: true,
+	}, {
+		name:    "cluster name not configured",
+		check:   v1.AttestationClusterMetadata{ClusterName: "deployment.clusters"},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.NewContext(jmespath.New(config.NewDefaultConfiguration(false)))
+			pass, _, err := internal.CheckClusterMetadata(&tt.check, tt.cluster, ctx, statement)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, pass, tt.want)
+		})
+	}
+}
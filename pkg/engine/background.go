@@ -24,10 +24,11 @@ func (e *engine) applyBackgroundChecks(
 	logger logr.Logger,
 	policyContext engineapi.PolicyContext,
 ) engineapi.PolicyResponse {
-	return e.filterRules(policyContext, logger, time.Now())
+	return e.filterRules(ctx, policyContext, logger, time.Now())
 }
 
 func (e *engine) filterRules(
+	ctx context.Context,
 	policyContext engineapi.PolicyContext,
 	logger logr.Logger,
 	startTime time.Time,
@@ -35,9 +36,9 @@ func (e *engine) filterRules(
 	policy := policyContext.Policy()
 	resp := engineapi.NewPolicyResponse()
 	applyRules := policy.GetSpec().GetApplyRules()
-	for _, rule := range autogen.ComputeRules(policy) {
+	for _, rule := range autogen.ComputeRules(policy, e.configuration.GetAutogenControllers()) {
 		logger := internal.LoggerWithRule(logger, rule)
-		if ruleResp := e.filterRule(rule, logger, policyContext); ruleResp != nil {
+		if ruleResp := e.filterRule(ctx, rule, logger, policyContext); ruleResp != nil {
 			resp.Rules = append(resp.Rules, *ruleResp)
 			if applyRules == kyvernov1.ApplyOne && ruleResp.Status() != engineapi.RuleStatusSkip {
 				break
@@ -48,6 +49,7 @@ func (e *engine) filterRules(
 }
 
 func (e *engine) filterRule(
+	ctx context.Context,
 	rule kyvernov1.Rule,
 	logger logr.Logger,
 	policyContext engineapi.PolicyContext,
@@ -68,7 +70,7 @@ func (e *engine) filterRule(
 		return nil
 	}
 	// check if there is a policy exception matches the incoming resource
-	exception := engineutils.MatchesException(exceptions, policyContext, logger)
+	exception := engineutils.MatchesException(ctx, e.ownerResolver, exceptions, policyContext, logger)
 	if exception != nil {
 		key, err := cache.MetaNamespaceKeyFunc(exception)
 		if err != nil {
@@ -87,10 +89,10 @@ func (e *engine) filterRule(
 	policy := policyContext.Policy()
 	gvk, subresource := policyContext.ResourceKind()
 
-	if err := engineutils.MatchesResourceDescription(newResource, rule, admissionInfo, namespaceLabels, policy.GetNamespace(), gvk, subresource, policyContext.Operation()); err != nil {
+	if err := engineutils.MatchesResourceDescription(ctx, e.ownerResolver, newResource, rule, admissionInfo, namespaceLabels, policy.GetNamespace(), gvk, subresource, policyContext.Operation()); err != nil {
 		if ruleType == engineapi.Generation {
 			// if the oldResource matched, return "false" to delete GR for it
-			if err = engineutils.MatchesResourceDescription(oldResource, rule, admissionInfo, namespaceLabels, policy.GetNamespace(), gvk, subresource, policyContext.Operation()); err == nil {
+			if err = engineutils.MatchesResourceDescription(ctx, e.ownerResolver, oldResource, rule, admissionInfo, namespaceLabels, policy.GetNamespace(), gvk, subresource, policyContext.Operation()); err == nil {
 				return engineapi.RuleFail(rule.Name, ruleType, "")
 			}
 		}
@@ -0,0 +1,198 @@
+package compiled
+
+import (
+	"regexp"
+	"sync"
+
+	"github.com/go-logr/logr"
+	gojmespath "github.com/kyverno/go-jmespath"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	celutils "github.com/kyverno/kyverno/pkg/utils/cel"
+	"k8s.io/apiserver/pkg/admission/plugin/validatingadmissionpolicy"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Cache holds the compiled expressions of the policies, expressions are compiled once when a policy is synced
+// and reused across admission requests.
+type Cache interface {
+	jmespath.Precompiled
+	// Set compiles the expressions of a policy and of the rules autogen generates for the given custom
+	// pod controllers, nothing is recompiled if the policy rules didn't change
+	Set(string, kyvernov1.PolicyInterface, []config.AutogenController)
+	// Unset releases the expressions of a policy
+	Unset(string)
+	// Validator returns the compiled validator of a validate.cel rule
+	Validator(kyvernov1.PolicyInterface, string) (validatingadmissionpolicy.Validator, bool)
+}
+
+type entry struct {
+	hash            string
+	resourceVersion string
+	queries         []string
+	regexps         []string
+	validators      map[string]validatingadmissionpolicy.Validator
+}
+
+type ref[T any] struct {
+	compiled T
+	count    int
+}
+
+type compiledCache struct {
+	logger   logr.Logger
+	metrics  cacheMetrics
+	lock     sync.RWMutex
+	policies map[string]*entry
+	queries  map[string]*ref[*gojmespath.JMESPath]
+	regexps  map[string]*ref[*regexp.Regexp]
+}
+
+// NewCache creates a new compiled expressions cache
+func NewCache(logger logr.Logger) Cache {
+	c := &compiledCache{
+		logger:   logger,
+		policies: map[string]*entry{},
+		queries:  map[string]*ref[*gojmespath.JMESPath]{},
+		regexps:  map[string]*ref[*regexp.Regexp]{},
+	}
+	c.metrics = newCacheMetrics(logger, c.size)
+	return c
+}
+
+func (c *compiledCache) Set(key string, policy kyvernov1.PolicyInterface, autogenControllers []config.AutogenController) {
+	rules := autogen.ComputeRules(policy, autogenControllers)
+	hash := autogen.ComputeHash(rules)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if current := c.policies[key]; current != nil && current.hash == hash {
+		// only the metadata or the status changed, the compiled expressions are still valid
+		current.resourceVersion = policy.GetResourceVersion()
+		return
+	}
+	next := &entry{
+		hash:            hash,
+		resourceVersion: policy.GetResourceVersion(),
+		validators:      map[string]validatingadmissionpolicy.Validator{},
+	}
+	queries, regexps := collect(rules)
+	for _, query := range queries {
+		if r := c.queries[query]; r != nil {
+			r.count++
+		} else if compiled, err := gojmespath.Compile(query); err != nil {
+			c.logger.V(4).Info("failed to compile JMESPath expression", "policy", key, "query", query, "error", err.Error())
+			continue
+		} else {
+			c.queries[query] = &ref[*gojmespath.JMESPath]{compiled: compiled, count: 1}
+		}
+		next.queries = append(next.queries, query)
+	}
+	for _, expr := range regexps {
+		if r := c.regexps[expr]; r != nil {
+			r.count++
+		} else if compiled, err := regexp.Compile(expr); err != nil {
+			c.logger.V(4).Info("failed to compile regular expression", "policy", key, "regexp", expr, "error", err.Error())
+			continue
+		} else {
+			c.regexps[expr] = &ref[*regexp.Regexp]{compiled: compiled, count: 1}
+		}
+		next.regexps = append(next.regexps, expr)
+	}
+	for _, rule := range rules {
+		if !rule.HasValidateCEL() {
+			continue
+		}
+		validator, err := celutils.NewValidator(policy.GetKind(), policy.GetName(), rule)
+		if err != nil {
+			c.logger.Error(err, "failed to compile CEL expressions", "policy", key, "rule", rule.Name)
+			continue
+		}
+		next.validators[rule.Name] = validator
+	}
+	c.release(key)
+	c.policies[key] = next
+}
+
+func (c *compiledCache) Unset(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.release(key)
+}
+
+// release drops the policy entry and the expressions no other policy uses, the lock must be held
+func (c *compiledCache) release(key string) {
+	current := c.policies[key]
+	if current == nil {
+		return
+	}
+	delete(c.policies, key)
+	for _, query := range current.queries {
+		if r := c.queries[query]; r != nil {
+			if r.count--; r.count == 0 {
+				delete(c.queries, query)
+			}
+		}
+	}
+	for _, expr := range current.regexps {
+		if r := c.regexps[expr]; r != nil {
+			if r.count--; r.count == 0 {
+				delete(c.regexps, expr)
+			}
+		}
+	}
+}
+
+func (c *compiledCache) JMESPath(query string) (*gojmespath.JMESPath, bool) {
+	c.lock.RLock()
+	r := c.queries[query]
+	c.lock.RUnlock()
+	c.metrics.recordLookup(kindJMESPath, r != nil)
+	if r == nil {
+		return nil, false
+	}
+	return r.compiled, true
+}
+
+func (c *compiledCache) Regexp(expr string) (*regexp.Regexp, bool) {
+	c.lock.RLock()
+	r := c.regexps[expr]
+	c.lock.RUnlock()
+	c.metrics.recordLookup(kindRegexp, r != nil)
+	if r == nil {
+		return nil, false
+	}
+	return r.compiled, true
+}
+
+// Validator only returns a validator compiled from the same version of the policy, the engine can evaluate
+// a policy version the cache has not synced yet.
+func (c *compiledCache) Validator(policy kyvernov1.PolicyInterface, rule string) (validatingadmissionpolicy.Validator, bool) {
+	key, err := cache.MetaNamespaceKeyFunc(policy)
+	if err != nil {
+		return nil, false
+	}
+	c.lock.RLock()
+	var validator validatingadmissionpolicy.Validator
+	if current := c.policies[key]; current != nil && current.resourceVersion == policy.GetResourceVersion() {
+		validator = current.validators[rule]
+	}
+	c.lock.RUnlock()
+	c.metrics.recordLookup(kindCEL, validator != nil)
+	return validator, validator != nil
+}
+
+func (c *compiledCache) size() map[string]int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	validators := 0
+	for _, current := range c.policies {
+		validators += len(current.validators)
+	}
+	return map[string]int{
+		kindJMESPath: len(c.queries),
+		kindRegexp:   len(c.regexps),
+		kindCEL:      validators,
+	}
+}
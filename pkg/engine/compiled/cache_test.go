@@ -0,0 +1,96 @@
+package compiled
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+)
+
+func newPolicy(t *testing.T, resourceVersion, label string) *kyvernov1.ClusterPolicy {
+	raw := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "check-labels", "resourceVersion": "` + resourceVersion + `"},
+		"spec": {
+			"rules": [{
+				"name": "check-team",
+				"match": {"any": [{"resources": {"kinds": ["ConfigMap"]}}]},
+				"context": [{"name": "team", "variable": {"jmesPath": "request.object.metadata.labels.` + label + `"}}],
+				"preconditions": {"all": [{"key": "{{ regex_match('^team-.*', team) }}", "operator": "Equals", "value": true}]},
+				"validate": {"message": "invalid team {{ team }}", "deny": {}}
+			}, {
+				"name": "check-cel",
+				"match": {"any": [{"resources": {"kinds": ["ConfigMap"]}}]},
+				"validate": {"cel": {"expressions": [{"expression": "has(object.metadata.labels)"}]}}
+			}]
+		}
+	}`)
+	var policy kyvernov1.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(raw, &policy))
+	return &policy
+}
+
+func Test_Set(t *testing.T) {
+	cache := NewCache(logr.Discard())
+	policy := newPolicy(t, "1", "team")
+	cache.Set("check-labels", policy, nil)
+	for _, query := range []string{"request.object.metadata.labels.team", "regex_match('^team-.*', team)", "team"} {
+		compiled, ok := cache.JMESPath(query)
+		assert.Assert(t, ok, query)
+		assert.Assert(t, compiled != nil)
+	}
+	_, ok := cache.JMESPath("request.object.metadata.name")
+	assert.Assert(t, !ok)
+	compiled, ok := cache.Regexp("^team-.*")
+	assert.Assert(t, ok)
+	assert.Assert(t, compiled.MatchString("team-a"))
+	_, ok = cache.Validator(policy, "check-cel")
+	assert.Assert(t, ok)
+	_, ok = cache.Validator(policy, "check-team")
+	assert.Assert(t, !ok)
+	// a validator compiled from another version of the policy is not returned
+	_, ok = cache.Validator(newPolicy(t, "2", "team"), "check-cel")
+	assert.Assert(t, !ok)
+}
+
+func Test_Set_SameRules(t *testing.T) {
+	cache := NewCache(logr.Discard()).(*compiledCache)
+	cache.Set("check-labels", newPolicy(t, "1", "team"), nil)
+	validator, _ := cache.Validator(newPolicy(t, "1", "team"), "check-cel")
+	// a new resource version with the same rules keeps the compiled expressions
+	policy := newPolicy(t, "2", "team")
+	cache.Set("check-labels", policy, nil)
+	same, ok := cache.Validator(policy, "check-cel")
+	assert.Assert(t, ok)
+	assert.Assert(t, same == validator)
+}
+
+func Test_Set_ChangedRules(t *testing.T) {
+	cache := NewCache(logr.Discard())
+	cache.Set("check-labels", newPolicy(t, "1", "team"), nil)
+	cache.Set("check-labels", newPolicy(t, "2", "owner"), nil)
+	_, ok := cache.JMESPath("request.object.metadata.labels.team")
+	assert.Assert(t, !ok)
+	_, ok = cache.JMESPath("request.object.metadata.labels.owner")
+	assert.Assert(t, ok)
+	_, ok = cache.Regexp("^team-.*")
+	assert.Assert(t, ok)
+}
+
+func Test_Unset(t *testing.T) {
+	cache := NewCache(logr.Discard())
+	cache.Set("check-labels", newPolicy(t, "1", "team"), nil)
+	cache.Set("other", newPolicy(t, "1", "owner"), nil)
+	cache.Unset("check-labels")
+	// expressions shared with another policy are kept
+	_, ok := cache.Regexp("^team-.*")
+	assert.Assert(t, ok)
+	_, ok = cache.JMESPath("request.object.metadata.labels.team")
+	assert.Assert(t, !ok)
+	cache.Unset("other")
+	_, ok = cache.Regexp("^team-.*")
+	assert.Assert(t, !ok)
+}
@@ -0,0 +1,85 @@
+package compiled
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	gojmespath "github.com/kyverno/go-jmespath"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// regexFunctions are the JMESPath functions taking a regular expression as first argument
+var regexFunctions = sets.New("regex_match", "regex_replace_all", "regex_replace_all_literal")
+
+// collect returns the JMESPath expressions used in the rules, either as `{{ }}` variables or as `jmesPath` fields,
+// and the literal regular expressions passed to the regex functions.
+func collect(rules []kyvernov1.Rule) ([]string, []string) {
+	queries := sets.New[string]()
+	regexps := sets.New[string]()
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return nil, nil
+	}
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, nil
+	}
+	walk(document, "", func(key, value string) {
+		if key == "jmesPath" {
+			queries.Insert(strings.TrimSpace(value))
+		}
+		for _, match := range regex.RegexVariables.FindAllStringSubmatch(value, -1) {
+			variable := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(match[2], "{{"), "}}"))
+			if variable != "" {
+				queries.Insert(variable)
+			}
+		}
+	})
+	for query := range queries {
+		ast, err := gojmespath.NewParser().Parse(query)
+		if err != nil {
+			continue
+		}
+		collectRegexps(ast, regexps)
+	}
+	return sorted(queries), sorted(regexps)
+}
+
+func walk(document interface{}, key string, visit func(string, string)) {
+	switch typed := document.(type) {
+	case map[string]interface{}:
+		for k, v := range typed {
+			walk(v, k, visit)
+		}
+	case []interface{}:
+		for _, v := range typed {
+			walk(v, key, visit)
+		}
+	case string:
+		visit(key, typed)
+	}
+}
+
+func collectRegexps(node gojmespath.ASTNode, regexps sets.Set[string]) {
+	if node.NodeType == gojmespath.ASTFunctionExpression && len(node.Children) != 0 {
+		if name, ok := node.Value.(string); ok && regexFunctions.Has(name) {
+			if arg := node.Children[0]; arg.NodeType == gojmespath.ASTLiteral {
+				if expr, ok := arg.Value.(string); ok {
+					regexps.Insert(expr)
+				}
+			}
+		}
+	}
+	for _, child := range node.Children {
+		collectRegexps(child, regexps)
+	}
+}
+
+func sorted(values sets.Set[string]) []string {
+	list := values.UnsortedList()
+	sort.Strings(list)
+	return list
+}
@@ -0,0 +1,70 @@
+package compiled
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	kindJMESPath = "jmespath"
+	kindRegexp   = "regexp"
+	kindCEL      = "cel"
+)
+
+type cacheMetrics struct {
+	lookupsTotal metric.Int64Counter
+}
+
+func newCacheMetrics(logger logr.Logger, size func() map[string]int) cacheMetrics {
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	lookupsTotal, err := meter.Int64Counter(
+		"kyverno_policy_compilation_cache_lookups",
+		metric.WithDescription("can be used to track the lookups of compiled policy expressions, a miss means the expression is compiled when it is evaluated"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_policy_compilation_cache_lookups")
+	}
+	entries, err := meter.Int64ObservableGauge(
+		"kyverno_policy_compilation_cache_entries",
+		metric.WithDescription("can be used to track the number of compiled policy expressions held in the cache"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_policy_compilation_cache_entries")
+	} else {
+		callback := func(ctx context.Context, observer metric.Observer) error {
+			for kind, count := range size() {
+				observer.ObserveInt64(entries, int64(count), metric.WithAttributes(attribute.String("expression_type", kind)))
+			}
+			return nil
+		}
+		if _, err := meter.RegisterCallback(callback, entries); err != nil {
+			logger.Error(err, "failed to register callback")
+		}
+	}
+	return cacheMetrics{
+		lookupsTotal: lookupsTotal,
+	}
+}
+
+func (m cacheMetrics) recordLookup(kind string, hit bool) {
+	if m.lookupsTotal == nil {
+		return
+	}
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.lookupsTotal.Add(
+		context.Background(),
+		1,
+		metric.WithAttributes(
+			attribute.String("expression_type", kind),
+			attribute.String("lookup_result", result),
+		),
+	)
+}
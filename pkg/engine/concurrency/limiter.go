@@ -0,0 +1,229 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
+	kyvernometrics "github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+)
+
+// maxBreakers bounds the number of destinations tracked by circuit breakers, calls to destinations
+// seen once the bound is reached are limited but never shed.
+const maxBreakers = 1024
+
+// ErrLimitReached is returned for the calls that could not get a slot before their context was done
+var ErrLimitReached = errors.New("concurrency limit reached")
+
+// StatusError is implemented by the errors carrying the HTTP status code returned by a destination
+type StatusError interface {
+	StatusCode() int
+}
+
+// IsUnavailable returns true for the errors showing a destination is unreachable, too slow or failing
+func IsUnavailable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || utilnet.IsConnectionRefused(err) || utilnet.IsConnectionReset(err) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var statusErr StatusError
+	if errors.As(err, &statusErr) {
+		return isUnavailableStatus(statusErr.StatusCode())
+	}
+	var registryErr *transport.Error
+	if errors.As(err, &registryErr) {
+		return isUnavailableStatus(registryErr.StatusCode)
+	}
+	return false
+}
+
+func isUnavailableStatus(code int) bool {
+	return code == 429 || code >= 500
+}
+
+// BreakerConfig configures the circuit breaker of every destination, breakers are disabled when Failures is zero
+type BreakerConfig struct {
+	Failures int
+	Cooldown time.Duration
+}
+
+// Limiter bounds the number of concurrent external calls made to load context entries (apiCall, imageRegistry),
+// globally and per rule, and sheds the calls to destinations failing consecutively.
+// A nil Limiter does not limit anything.
+type Limiter struct {
+	global   chan struct{}
+	perRule  int
+	breaker  BreakerConfig
+	metrics  *limiterMetrics
+	lock     sync.Mutex
+	rules    map[string]chan struct{}
+	breakers map[string]*middleware.CircuitBreaker
+}
+
+// NewLimiter returns a limiter allowing global concurrent calls overall and perRule concurrent calls for a single rule,
+// zero means unlimited.
+func NewLimiter(logger logr.Logger, global int, perRule int, breaker BreakerConfig) *Limiter {
+	l := &Limiter{
+		perRule:  perRule,
+		breaker:  breaker,
+		rules:    map[string]chan struct{}{},
+		breakers: map[string]*middleware.CircuitBreaker{},
+	}
+	if global > 0 {
+		l.global = make(chan struct{}, global)
+	}
+	l.metrics = newLimiterMetrics(logger, l)
+	return l
+}
+
+// ForRule returns the limiter of the calls made by a rule
+func (l *Limiter) ForRule(rule string) *RuleLimiter {
+	if l == nil {
+		return nil
+	}
+	rl := &RuleLimiter{
+		limiter: l,
+		rule:    rule,
+	}
+	if l.perRule > 0 {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+		sem, ok := l.rules[rule]
+		if !ok {
+			sem = make(chan struct{}, l.perRule)
+			l.rules[rule] = sem
+		}
+		rl.sem = sem
+	}
+	return rl
+}
+
+func (l *Limiter) breakerFor(destination string) *middleware.CircuitBreaker {
+	if destination == "" || l.breaker.Failures <= 0 {
+		return nil
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	breaker, ok := l.breakers[destination]
+	if !ok {
+		if len(l.breakers) >= maxBreakers {
+			return nil
+		}
+		breaker = middleware.NewCircuitBreakerWithClassifier(destination, l.breaker.Failures, l.breaker.Cooldown, IsUnavailable)
+		l.breakers[destination] = breaker
+	}
+	return breaker
+}
+
+func (l *Limiter) inFlight() int {
+	return len(l.global)
+}
+
+// RuleLimiter limits the calls made by a single rule, a nil RuleLimiter does not limit anything.
+type RuleLimiter struct {
+	limiter *Limiter
+	rule    string
+	sem     chan struct{}
+}
+
+// Call calls fn once a global and a rule slot are available, or returns ErrLimitReached when ctx is done first.
+// Calls to a destination whose circuit breaker is open are shed with middleware.ErrCircuitOpen,
+// an empty destination is never shed.
+func (r *RuleLimiter) Call(ctx context.Context, destination string, fn func() error) error {
+	if r == nil {
+		return fn()
+	}
+	release, err := acquire(ctx, r.limiter.global)
+	if err != nil {
+		r.limiter.metrics.recordRejected(ctx, "global", destination)
+		return fmt.Errorf("%w for external calls: %w", ErrLimitReached, err)
+	}
+	defer release()
+	release, err = acquire(ctx, r.sem)
+	if err != nil {
+		r.limiter.metrics.recordRejected(ctx, "rule", destination)
+		return fmt.Errorf("%w for external calls of rule %s: %w", ErrLimitReached, r.rule, err)
+	}
+	defer release()
+	breaker := r.limiter.breakerFor(destination)
+	if breaker == nil {
+		return fn()
+	}
+	err = breaker.Call(ctx, fn)
+	if errors.Is(err, middleware.ErrCircuitOpen) {
+		r.limiter.metrics.recordRejected(ctx, "circuit_open", destination)
+	}
+	return err
+}
+
+func acquire(ctx context.Context, sem chan struct{}) (func(), error) {
+	if sem == nil {
+		return func() {}, nil
+	}
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+type limiterMetrics struct {
+	inFlight metric.Int64ObservableGauge
+	rejected metric.Int64Counter
+}
+
+func newLimiterMetrics(logger logr.Logger, limiter *Limiter) *limiterMetrics {
+	meter := otel.GetMeterProvider().Meter(kyvernometrics.MeterName)
+	inFlight, err := meter.Int64ObservableGauge(
+		"kyverno_context_external_calls_in_flight",
+		metric.WithDescription("can be used to track the number of external calls (apiCall, imageRegistry) in flight when a global concurrency limit is set."),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_context_external_calls_in_flight")
+	} else {
+		_, err := meter.RegisterCallback(func(ctx context.Context, observer metric.Observer) error {
+			observer.ObserveInt64(inFlight, int64(limiter.inFlight()))
+			return nil
+		}, inFlight)
+		if err != nil {
+			logger.Error(err, "Failed to register callback, kyverno_context_external_calls_in_flight")
+		}
+	}
+	rejected, err := meter.Int64Counter(
+		"kyverno_context_external_calls_rejected",
+		metric.WithDescription("can be used to track the number of external calls (apiCall, imageRegistry) rejected by a concurrency limit or an open circuit breaker."),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_context_external_calls_rejected")
+	}
+	return &limiterMetrics{
+		inFlight: inFlight,
+		rejected: rejected,
+	}
+}
+
+func (m *limiterMetrics) recordRejected(ctx context.Context, reason string, destination string) {
+	if m != nil && m.rejected != nil {
+		m.rejected.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("reason", reason),
+			attribute.String("destination", destination),
+		))
+	}
+}
@@ -0,0 +1,124 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/kyverno/kyverno/pkg/clients/middleware"
+	"gotest.tools/assert"
+)
+
+type statusError int
+
+func (e statusError) Error() string   { return http.StatusText(int(e)) }
+func (e statusError) StatusCode() int { return int(e) }
+
+func TestIsUnavailable(t *testing.T) {
+	assert.Assert(t, !IsUnavailable(nil))
+	assert.Assert(t, !IsUnavailable(errors.New("boom")))
+	assert.Assert(t, !IsUnavailable(context.Canceled))
+	assert.Assert(t, IsUnavailable(context.DeadlineExceeded))
+	assert.Assert(t, IsUnavailable(statusError(http.StatusTooManyRequests)))
+	assert.Assert(t, IsUnavailable(statusError(http.StatusBadGateway)))
+	assert.Assert(t, !IsUnavailable(statusError(http.StatusNotFound)))
+	assert.Assert(t, IsUnavailable(&transport.Error{StatusCode: http.StatusServiceUnavailable}))
+	assert.Assert(t, !IsUnavailable(&transport.Error{StatusCode: http.StatusUnauthorized}))
+}
+
+func TestRuleLimiter_Nil(t *testing.T) {
+	var limiter *Limiter
+	rule := limiter.ForRule("policy/rule")
+	assert.Assert(t, rule == nil)
+	calls := 0
+	assert.NilError(t, rule.Call(context.TODO(), "example.com", func() error {
+		calls++
+		return nil
+	}))
+	assert.Equal(t, calls, 1)
+}
+
+func TestRuleLimiter_Limits(t *testing.T) {
+	tests := []struct {
+		name    string
+		global  int
+		perRule int
+		other   string
+		limited bool
+	}{{
+		name:    "global limit",
+		global:  1,
+		other:   "other/rule",
+		limited: true,
+	}, {
+		name:    "rule limit",
+		perRule: 1,
+		other:   "policy/rule",
+		limited: true,
+	}, {
+		name:    "rule limit does not apply to other rules",
+		perRule: 1,
+		other:   "other/rule",
+		limited: false,
+	}, {
+		name:    "unlimited",
+		other:   "policy/rule",
+		limited: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewLimiter(logr.Discard(), tt.global, tt.perRule, BreakerConfig{})
+			started := make(chan struct{})
+			release := make(chan struct{})
+			done := make(chan error)
+			go func() {
+				done <- limiter.ForRule("policy/rule").Call(context.TODO(), "", func() error {
+					close(started)
+					<-release
+					return nil
+				})
+			}()
+			<-started
+			ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+			defer cancel()
+			err := limiter.ForRule(tt.other).Call(ctx, "", func() error { return nil })
+			close(release)
+			assert.NilError(t, <-done)
+			if tt.limited {
+				assert.Assert(t, errors.Is(err, ErrLimitReached))
+			} else {
+				assert.NilError(t, err)
+			}
+			// slots are released once calls return
+			assert.NilError(t, limiter.ForRule(tt.other).Call(context.TODO(), "", func() error { return nil }))
+		})
+	}
+}
+
+func TestRuleLimiter_CircuitBreaker(t *testing.T) {
+	limiter := NewLimiter(logr.Discard(), 0, 0, BreakerConfig{Failures: 2, Cooldown: time.Hour})
+	rule := limiter.ForRule("policy/rule")
+	unavailable := statusError(http.StatusServiceUnavailable)
+	calls := 0
+	call := func(destination string, err error) error {
+		return rule.Call(context.TODO(), destination, func() error {
+			calls++
+			return err
+		})
+	}
+	assert.Equal(t, call("svc.example.com", unavailable), error(unavailable))
+	assert.Equal(t, call("svc.example.com", unavailable), error(unavailable))
+	// the destination is shed once the breaker is open
+	assert.Assert(t, errors.Is(call("svc.example.com", nil), middleware.ErrCircuitOpen))
+	assert.Equal(t, calls, 2)
+	// other destinations and calls without destination are not shed
+	assert.NilError(t, call("registry.example.com", nil))
+	assert.Equal(t, call("", unavailable), error(unavailable))
+	assert.Equal(t, call("", unavailable), error(unavailable))
+	assert.NilError(t, call("", nil))
+	assert.Equal(t, calls, 6)
+}
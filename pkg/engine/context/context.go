@@ -1,6 +1,7 @@
 package context
 
 import (
+	gocontext "context"
 	"encoding/csv"
 	"fmt"
 	"regexp"
@@ -14,9 +15,11 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/jsonutils"
 	"github.com/kyverno/kyverno/pkg/logging"
 	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 var (
@@ -77,6 +80,9 @@ type Interface interface {
 	// AddNamespace merges resource json under request.namespace
 	AddNamespace(namespace string) error
 
+	// AddResourceKind merges the top level resource kind under request.parentKind and request.isSubresource
+	AddResourceKind(gvk schema.GroupVersionKind, subresource string) error
+
 	// AddElement adds element info to the context
 	AddElement(data interface{}, index, nesting int) error
 
@@ -106,6 +112,14 @@ type Interface interface {
 	// Reset sets the internal state to the last checkpoint, but does not remove the checkpoint.
 	Reset()
 
+	// WithCancellation makes queries fail once the given context is done,
+	// the returned function restores the previous cancellation.
+	WithCancellation(cancellation gocontext.Context) func()
+
+	// Copy returns an independent copy of the current state of the context,
+	// checkpoints and deferred loaders that were not loaded yet are not copied.
+	Copy() Interface
+
 	EvalInterface
 
 	// AddJSON  merges the json map with context
@@ -120,6 +134,7 @@ type context struct {
 	images             map[string]map[string]apiutils.ImageInfo
 	operation          kyvernov1.AdmissionOperation
 	deferred           DeferredLoaders
+	cancellation       gocontext.Context
 }
 
 // NewContext returns a new context
@@ -166,6 +181,13 @@ func (ctx *context) AddRequest(request admissionv1.AdmissionRequest) error {
 		return err
 	}
 
+	if requestMap, ok := mapObj.(map[string]interface{}); ok {
+		for _, key := range []string{"object", "oldObject"} {
+			if object, ok := requestMap[key].(map[string]interface{}); ok {
+				requestMap[key] = withDecodedData(object)
+			}
+		}
+	}
 	if err := addToContext(ctx, mapObj, "request"); err != nil {
 		return err
 	}
@@ -210,13 +232,28 @@ func (ctx *context) ReplaceContextEntry(name string, dataRaw []byte) error {
 // AddResource data at path: request.object
 func (ctx *context) AddResource(data map[string]interface{}) error {
 	clearLeafValue(ctx.jsonRaw, "request", "object")
-	return addToContext(ctx, data, "request", "object")
+	return addToContext(ctx, withDecodedData(data), "request", "object")
 }
 
 // AddOldResource data at path: request.oldObject
 func (ctx *context) AddOldResource(data map[string]interface{}) error {
 	clearLeafValue(ctx.jsonRaw, "request", "oldObject")
-	return addToContext(ctx, data, "request", "oldObject")
+	return addToContext(ctx, withDecodedData(data), "request", "oldObject")
+}
+
+// withDecodedData returns a copy of a Secret with the decoded values of its data under dataDecoded,
+// the values are only added to the context and policies can't reference them in messages.
+func withDecodedData(object map[string]interface{}) map[string]interface{} {
+	decoded := kubeutils.DecodeSecretData(object)
+	if decoded == nil {
+		return object
+	}
+	result := make(map[string]interface{}, len(object)+1)
+	for key, value := range object {
+		result[key] = value
+	}
+	result["dataDecoded"] = decoded
+	return result
 }
 
 // AddTargetResource adds data at path: target
@@ -278,6 +315,20 @@ func (ctx *context) AddNamespace(namespace string) error {
 	return addToContext(ctx, namespace, "request", "namespace")
 }
 
+// AddResourceKind adds the kind of the top level resource at path request.parentKind,
+// it differs from request.kind when the request targets a subresource (the kind of a scale subresource is Scale).
+func (ctx *context) AddResourceKind(gvk schema.GroupVersionKind, subresource string) error {
+	data := map[string]interface{}{
+		"parentKind": map[string]interface{}{
+			"group":   gvk.Group,
+			"version": gvk.Version,
+			"kind":    gvk.Kind,
+		},
+		"isSubresource": subresource != "",
+	}
+	return addToContext(ctx, data, "request")
+}
+
 func (ctx *context) AddElement(data interface{}, index, nesting int) error {
 	nestedElement := fmt.Sprintf("element%d", nesting)
 	nestedElementIndex := fmt.Sprintf("elementIndex%d", nesting)
@@ -299,6 +350,8 @@ func (ctx *context) AddImageInfo(info apiutils.ImageInfo, cfg config.Configurati
 		"name":             info.Name,
 		"tag":              info.Tag,
 		"digest":           info.Digest,
+		"original":         info.Display(),
+		"canonical":        info.String(),
 	}
 	return addToContext(ctx, data, "image")
 }
@@ -366,6 +419,24 @@ func (ctx *context) ImageInfo() map[string]map[string]apiutils.ImageInfo {
 	return ctx.images
 }
 
+// WithCancellation makes queries fail once the given context is done,
+// the returned function restores the previous cancellation.
+func (ctx *context) WithCancellation(cancellation gocontext.Context) func() {
+	previous := ctx.cancellation
+	ctx.cancellation = cancellation
+	return func() {
+		ctx.cancellation = previous
+	}
+}
+
+func (ctx *context) Copy() Interface {
+	copy := NewContextFromRaw(ctx.jp, runtime.DeepCopyJSON(ctx.jsonRaw)).(*context)
+	copy.images = ctx.images
+	copy.operation = ctx.operation
+	copy.cancellation = ctx.cancellation
+	return copy
+}
+
 // Checkpoint creates a copy of the current internal state and
 // pushes it into a stack of stored states.
 func (ctx *context) Checkpoint() {
@@ -7,8 +7,11 @@ import (
 	urkyverno "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
+	imageutils "github.com/kyverno/kyverno/pkg/utils/image"
 	"github.com/stretchr/testify/assert"
 	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 var jp = jmespath.New(config.NewDefaultConfiguration(false))
@@ -227,3 +230,79 @@ func TestAddVariable(t *testing.T) {
 		})
 	}
 }
+
+func TestAddImageInfo(t *testing.T) {
+	ctx := NewContext(jp)
+	info := apiutils.ImageInfo{
+		ImageInfo: imageutils.ImageInfo{
+			Registry: "docker.io",
+			Name:     "nginx",
+			Path:     "nginx",
+			Tag:      "latest",
+			Original: "nginx",
+		},
+		Pointer: "/spec/containers/0/image",
+	}
+	assert.NoError(t, ctx.AddImageInfo(info, config.NewDefaultConfiguration(false)))
+	original, err := ctx.Query("image.original")
+	assert.NoError(t, err)
+	assert.Equal(t, "nginx", original)
+	canonical, err := ctx.Query("image.canonical")
+	assert.NoError(t, err)
+	assert.Equal(t, "docker.io/nginx:latest", canonical)
+}
+
+func TestAddResource_SecretDataDecoded(t *testing.T) {
+	ctx := NewContext(jp)
+	secret := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"data": map[string]interface{}{
+			"password": "c2VjcmV0",
+			"invalid":  "not base64!",
+		},
+	}
+	assert.NoError(t, ctx.AddResource(secret))
+	decoded, err := ctx.Query("request.object.dataDecoded")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"password": "secret"}, decoded)
+	// the resource is left untouched
+	assert.NotContains(t, secret, "dataDecoded")
+
+	configMap := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"data":       map[string]interface{}{"password": "c2VjcmV0"},
+	}
+	assert.NoError(t, ctx.AddOldResource(configMap))
+	_, err = ctx.Query("request.oldObject.dataDecoded")
+	assert.Error(t, err)
+}
+
+func TestAddResourceKind(t *testing.T) {
+	ctx := NewContext(jp)
+	assert.NoError(t, ctx.AddResourceKind(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, "scale"))
+	kind, err := ctx.Query("request.parentKind.kind")
+	assert.NoError(t, err)
+	assert.Equal(t, "Deployment", kind)
+	isSubresource, err := ctx.Query("request.isSubresource")
+	assert.NoError(t, err)
+	assert.Equal(t, true, isSubresource)
+}
+
+func TestCopy(t *testing.T) {
+	ctx := NewContext(jp)
+	assert.NoError(t, ctx.AddVariable("foo", "bar"))
+	copy := ctx.Copy()
+	assert.NoError(t, copy.AddVariable("foo", "baz"))
+	assert.NoError(t, copy.AddVariable("other", "value"))
+	// changes made to the copy don't affect the original context
+	result, err := ctx.Query("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", result)
+	_, err = ctx.Query("other")
+	assert.Error(t, err)
+	result, err = copy.Query("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, "baz", result)
+}
@@ -9,6 +9,11 @@ import (
 
 // Query the JSON context with JMESPATH search path
 func (ctx *context) Query(query string) (interface{}, error) {
+	if ctx.cancellation != nil {
+		if err := ctx.cancellation.Err(); err != nil {
+			return nil, fmt.Errorf("query cancelled: %w", err)
+		}
+	}
 	if err := ctx.loadDeferred(query); err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package context
 
 import (
+	gocontext "context"
 	"testing"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
@@ -56,6 +57,25 @@ func TestMissingObject(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestQueryCancelled(t *testing.T) {
+	ctx := createTestContext(`{"a": {"b": 1}}`, `{"a": {"b": 2}}`)
+	cancellation, cancel := gocontext.WithCancel(gocontext.TODO())
+	restore := ctx.WithCancellation(cancellation)
+
+	val, err := ctx.Query("request.object.a.b")
+	assert.NoError(t, err)
+	assert.Equal(t, val, 1.0)
+
+	cancel()
+	_, err = ctx.Query("request.object.a.b")
+	assert.ErrorIs(t, err, gocontext.Canceled)
+
+	restore()
+	val, err = ctx.Query("request.object.a.b")
+	assert.NoError(t, err)
+	assert.Equal(t, val, 1.0)
+}
+
 func createTestContext(obj, oldObj string) Interface {
 	request := admissionv1.AdmissionRequest{}
 	request.Operation = "UPDATE"
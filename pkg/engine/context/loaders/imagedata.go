@@ -6,8 +6,10 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/name"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/concurrency"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
@@ -20,6 +22,7 @@ type imageDataLoader struct {
 	enginectx      enginecontext.Interface
 	jp             jmespath.Interface
 	rclientFactory engineapi.RegistryClientFactory
+	limiter        *concurrency.RuleLimiter
 	data           []byte
 }
 
@@ -30,6 +33,7 @@ func NewImageDataLoader(
 	enginectx enginecontext.Interface,
 	jp jmespath.Interface,
 	rclientFactory engineapi.RegistryClientFactory,
+	limiter *concurrency.RuleLimiter,
 ) enginecontext.Loader {
 	return &imageDataLoader{
 		ctx:            ctx,
@@ -38,6 +42,7 @@ func NewImageDataLoader(
 		enginectx:      enginectx,
 		jp:             jp,
 		rclientFactory: rclientFactory,
+		limiter:        limiter,
 	}
 }
 
@@ -108,7 +113,12 @@ func (idl *imageDataLoader) fetchImageData() (interface{}, error) {
 
 // FetchImageDataMap fetches image information from the remote registry.
 func (idl *imageDataLoader) fetchImageDataMap(client engineapi.ImageDataClient, ref string) (interface{}, error) {
-	desc, err := client.ForRef(context.Background(), ref)
+	var desc *engineapi.ImageData
+	err := idl.limiter.Call(idl.ctx, registryHost(ref), func() error {
+		var err error
+		desc, err = client.ForRef(idl.ctx, ref)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch image descriptor: %s, error: %v", ref, err)
 	}
@@ -150,3 +160,11 @@ func (idl *imageDataLoader) fetchImageDataMap(client engineapi.ImageDataClient,
 
 	return untyped, nil
 }
+
+func registryHost(ref string) string {
+	parsed, err := name.ParseReference(ref)
+	if err != nil {
+		return ""
+	}
+	return parsed.Context().RegistryStr()
+}
@@ -0,0 +1,121 @@
+package loaders
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextvalidation "k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ParametersVariable is the name of the variable holding the parameter resource of a policy
+const ParametersVariable = "params"
+
+type parametersLoader struct {
+	ctx        context.Context //nolint:containedctx
+	logger     logr.Logger
+	parameters *kyvernov1.PolicyParameters
+	namespace  string
+	cmResolver engineapi.ConfigmapResolver
+	client     engineapi.ResourceClient
+	enginectx  enginecontext.Interface
+	data       []byte
+}
+
+// NewParametersLoader returns a loader adding the parameter resource of a policy to the context in the `params` variable,
+// ConfigMaps are resolved with the ConfigMap resolver when available and other resources with the client.
+func NewParametersLoader(
+	ctx context.Context,
+	logger logr.Logger,
+	parameters *kyvernov1.PolicyParameters,
+	policyNamespace string,
+	cmResolver engineapi.ConfigmapResolver,
+	client engineapi.ResourceClient,
+	enginectx enginecontext.Interface,
+) enginecontext.Loader {
+	return &parametersLoader{
+		ctx:        ctx,
+		logger:     logger,
+		parameters: parameters,
+		namespace:  parameters.GetNamespace(policyNamespace),
+		cmResolver: cmResolver,
+		client:     client,
+		enginectx:  enginectx,
+	}
+}
+
+func (pl *parametersLoader) HasLoaded() bool {
+	return pl.data != nil
+}
+
+func (pl *parametersLoader) LoadData() error {
+	if pl.data == nil {
+		data, err := pl.fetchParameters()
+		if err != nil {
+			return fmt.Errorf("failed to load policy parameters: %w", err)
+		}
+		pl.data = data
+	}
+	if err := pl.enginectx.AddContextEntry(ParametersVariable, pl.data); err != nil {
+		return fmt.Errorf("failed to add policy parameters to the context: %w", err)
+	}
+	return nil
+}
+
+func (pl *parametersLoader) fetchParameters() ([]byte, error) {
+	kind, ref := pl.parameters.ParamKind, pl.parameters.ParamRef
+	var object map[string]interface{}
+	if kind.IsConfigMap() && pl.cmResolver != nil {
+		cm, err := pl.cmResolver.Get(pl.ctx, pl.namespace, ref.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get configmap %s/%s: %w", pl.namespace, ref.Name, err)
+		}
+		object, err = runtime.DefaultUnstructuredConverter.ToUnstructured(cm)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert configmap %s/%s: %w", pl.namespace, ref.Name, err)
+		}
+	} else if pl.client != nil {
+		resource, err := pl.client.GetResource(pl.ctx, kind.APIVersion, kind.Kind, pl.namespace, ref.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s %s %s: %w", kind.APIVersion, kind.Kind, ref.Name, err)
+		}
+		object = resource.UnstructuredContent()
+	} else {
+		return nil, fmt.Errorf("a client is required to get %s %s %s", kind.APIVersion, kind.Kind, ref.Name)
+	}
+	if pl.parameters.Schema != nil {
+		validator, err := NewParametersValidator(pl.parameters.Schema)
+		if err != nil {
+			return nil, err
+		}
+		if errs := apiextvalidation.ValidateCustomResource(field.NewPath(ParametersVariable), object, validator); len(errs) != 0 {
+			return nil, fmt.Errorf("%s %s %s does not match the parameters schema: %w", kind.APIVersion, kind.Kind, ref.Name, errs.ToAggregate())
+		}
+	}
+	return json.Marshal(object)
+}
+
+// NewParametersValidator returns a validator for the OpenAPI v3 schema of policy parameters
+func NewParametersValidator(schema *apiextv1.JSON) (apiextvalidation.SchemaValidator, error) {
+	var props apiextv1.JSONSchemaProps
+	if err := json.Unmarshal(schema.Raw, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse the parameters schema: %w", err)
+	}
+	var internal apiextensions.JSONSchemaProps
+	if err := apiextv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(&props, &internal, nil); err != nil {
+		return nil, fmt.Errorf("failed to convert the parameters schema: %w", err)
+	}
+	validator, _, err := apiextvalidation.NewSchemaValidator(&internal)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters schema: %w", err)
+	}
+	return validator, nil
+}
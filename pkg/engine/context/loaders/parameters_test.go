@@ -0,0 +1,109 @@
+package loaders
+
+import (
+	"context"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type fakeConfigMapResolver map[string]*corev1.ConfigMap
+
+func (r fakeConfigMapResolver) Get(_ context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	if cm, ok := r[namespace+"/"+name]; ok {
+		return cm, nil
+	}
+	return nil, kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, name)
+}
+
+func Test_ParametersLoader(t *testing.T) {
+	resolver := fakeConfigMapResolver{
+		"kyverno/limits": &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "limits", Namespace: "kyverno"},
+			Data:       map[string]string{"maxReplicas": "5", "registries": "ghcr.io"},
+		},
+		"team/limits": &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "limits", Namespace: "team"},
+			Data:       map[string]string{"registries": "ghcr.io"},
+		},
+	}
+	schema := &apiextv1.JSON{Raw: []byte(`{"type":"object","required":["data"],"properties":{"data":{"type":"object","required":["maxReplicas"]}}}`)}
+	tests := []struct {
+		name            string
+		parameters      kyvernov1.PolicyParameters
+		policyNamespace string
+		wantErr         bool
+		want            interface{}
+	}{{
+		name: "configmap",
+		parameters: kyvernov1.PolicyParameters{
+			ParamKind: kyvernov1.ParamKind{APIVersion: "v1", Kind: "ConfigMap"},
+			ParamRef:  kyvernov1.ParamRef{Name: "limits", Namespace: "kyverno"},
+			Schema:    schema,
+		},
+		want: "5",
+	}, {
+		name: "policy namespace",
+		parameters: kyvernov1.PolicyParameters{
+			ParamKind: kyvernov1.ParamKind{APIVersion: "v1", Kind: "ConfigMap"},
+			ParamRef:  kyvernov1.ParamRef{Name: "limits"},
+		},
+		policyNamespace: "kyverno",
+		want:            "5",
+	}, {
+		name: "schema mismatch",
+		parameters: kyvernov1.PolicyParameters{
+			ParamKind: kyvernov1.ParamKind{APIVersion: "v1", Kind: "ConfigMap"},
+			ParamRef:  kyvernov1.ParamRef{Name: "limits", Namespace: "team"},
+			Schema:    schema,
+		},
+		wantErr: true,
+	}, {
+		name: "not found",
+		parameters: kyvernov1.PolicyParameters{
+			ParamKind: kyvernov1.ParamKind{APIVersion: "v1", Kind: "ConfigMap"},
+			ParamRef:  kyvernov1.ParamRef{Name: "missing", Namespace: "kyverno"},
+		},
+		wantErr: true,
+	}, {
+		name: "custom resource without client",
+		parameters: kyvernov1.PolicyParameters{
+			ParamKind: kyvernov1.ParamKind{APIVersion: "example.com/v1", Kind: "Limits"},
+			ParamRef:  kyvernov1.ParamRef{Name: "limits"},
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jp := jmespath.New(config.NewDefaultConfiguration(false))
+			ctx := enginecontext.NewContext(jp)
+			loader := NewParametersLoader(context.TODO(), logging.GlobalLogger(), &tt.parameters, tt.policyNamespace, resolver, nil, ctx)
+			err := loader.LoadData()
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			got, err := ctx.Query("params.data.maxReplicas")
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+}
+
+func Test_NewParametersValidator(t *testing.T) {
+	_, err := NewParametersValidator(&apiextv1.JSON{Raw: []byte(`{"type":"object","properties":{"maxReplicas":{"type":"integer"}}}`)})
+	assert.NilError(t, err)
+	_, err = NewParametersValidator(&apiextv1.JSON{Raw: []byte(`{"type":1}`)})
+	assert.Assert(t, err != nil)
+}
@@ -0,0 +1,165 @@
+package loaders
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/jsonutils"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type relatedResourcesLoader struct {
+	ctx       context.Context //nolint:containedctx
+	logger    logr.Logger
+	entry     kyvernov1.ContextEntry
+	enginectx enginecontext.Interface
+	jp        jmespath.Interface
+	lister    engineapi.RelatedResourceLister
+	data      []byte
+}
+
+func NewRelatedResourcesLoader(
+	ctx context.Context,
+	logger logr.Logger,
+	entry kyvernov1.ContextEntry,
+	enginectx enginecontext.Interface,
+	jp jmespath.Interface,
+	lister engineapi.RelatedResourceLister,
+) enginecontext.Loader {
+	return &relatedResourcesLoader{
+		ctx:       ctx,
+		logger:    logger,
+		entry:     entry,
+		enginectx: enginectx,
+		jp:        jp,
+		lister:    lister,
+	}
+}
+
+func (rl *relatedResourcesLoader) HasLoaded() bool {
+	return rl.data != nil
+}
+
+func (rl *relatedResourcesLoader) LoadData() error {
+	if rl.lister == nil {
+		return fmt.Errorf("a RelatedResourceLister is required")
+	}
+	if rl.data == nil {
+		data, err := rl.fetchRelatedResources()
+		if err != nil {
+			return fmt.Errorf("failed to retrieve related resources for context entry %s: %v", rl.entry.Name, err)
+		}
+		rl.data = data
+	}
+	if err := rl.enginectx.AddContextEntry(rl.entry.Name, rl.data); err != nil {
+		return fmt.Errorf("failed to add related resources for context entry %s: %v", rl.entry.Name, err)
+	}
+	return nil
+}
+
+func (rl *relatedResourcesLoader) fetchRelatedResources() ([]byte, error) {
+	logger := rl.logger
+	entry := rl.entry
+	related := entry.RelatedResources
+	namespace, err := variables.SubstituteAll(logger, rl.enginectx, related.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variables in context %s relatedResources.namespace %s: %v", entry.Name, related.Namespace, err)
+	}
+	var selected labels.Set
+	if related.Labels != nil {
+		selected, err = rl.labels()
+		if err != nil {
+			return nil, err
+		}
+	}
+	resources, err := rl.lister.List(rl.ctx, related.APIVersion, related.Kind, namespace.(string))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s/%s: %v", related.APIVersion, related.Kind, err)
+	}
+	items := make([]interface{}, 0, len(resources))
+	for _, resource := range resources {
+		if related.SelectorPath != "" {
+			matches, err := selects(resource, related.SelectorPath, selected)
+			if err != nil {
+				return nil, fmt.Errorf("failed to evaluate selector of %s/%s: %v", resource.GetNamespace(), resource.GetName(), err)
+			}
+			if !matches {
+				continue
+			}
+		}
+		items = append(items, resource.UnstructuredContent())
+	}
+	logger.V(4).Info("evaluated related resources", "variable name", entry.Name, "count", len(items))
+	data, err := json.Marshal(items)
+	if err != nil || related.JMESPath == "" {
+		return data, err
+	}
+	path, err := variables.SubstituteAll(logger, rl.enginectx, related.JMESPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variables in context entry %s %s: %v", entry.Name, related.JMESPath, err)
+	}
+	// round trip through json so that numbers are typed as jmespath expects them
+	var untyped interface{}
+	if err := json.Unmarshal(data, &untyped); err != nil {
+		return nil, err
+	}
+	output, err := applyJMESPath(rl.jp, path.(string), untyped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply jmespath %s to related resources: %v", path, err)
+	}
+	return json.Marshal(output)
+}
+
+// labels returns the labels the related resources selector must select, after variable substitution.
+func (rl *relatedResourcesLoader) labels() (labels.Set, error) {
+	entry := rl.entry
+	document, err := jsonutils.DocumentToUntyped(entry.RelatedResources.Labels)
+	if err != nil {
+		return nil, fmt.Errorf("invalid labels in context entry %s: %v", entry.Name, err)
+	}
+	value, err := variables.SubstituteAll(rl.logger, rl.enginectx, document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variables in context entry %s relatedResources.labels: %v", entry.Name, err)
+	}
+	set := labels.Set{}
+	if value == nil {
+		return set, nil
+	}
+	values, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("labels in context entry %s must be a map, got %T", entry.Name, value)
+	}
+	for k, v := range values {
+		set[k] = fmt.Sprint(v)
+	}
+	return set, nil
+}
+
+// selects returns true if the label selector found at path in the resource selects the labels.
+// Resources without a selector at path don't select anything.
+func selects(resource unstructured.Unstructured, path string, selected labels.Set) (bool, error) {
+	value, found, err := unstructured.NestedMap(resource.UnstructuredContent(), strings.Split(path, ".")...)
+	if err != nil || !found {
+		return false, err
+	}
+	var labelSelector metav1.LabelSelector
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(value, &labelSelector); err != nil {
+		return false, err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&labelSelector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(selected), nil
+}
@@ -0,0 +1,107 @@
+package loaders
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"gotest.tools/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type fakeLister []unstructured.Unstructured
+
+func (l fakeLister) List(_ context.Context, apiVersion, kind, namespace string) ([]unstructured.Unstructured, error) {
+	var out []unstructured.Unstructured
+	for _, resource := range l {
+		if resource.GetAPIVersion() == apiVersion && resource.GetKind() == kind && (namespace == "" || resource.GetNamespace() == namespace) {
+			out = append(out, resource)
+		}
+	}
+	return out, nil
+}
+
+func newResource(t *testing.T, raw string) unstructured.Unstructured {
+	var resource unstructured.Unstructured
+	assert.NilError(t, json.Unmarshal([]byte(raw), &resource.Object))
+	return resource
+}
+
+func newRelatedResourcesContext(t *testing.T) enginecontext.Interface {
+	jp := jmespath.New(config.NewDefaultConfiguration(false))
+	ctx := enginecontext.NewContext(jp)
+	assert.NilError(t, ctx.AddResource(map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "web", "namespace": "prod"},
+		"spec": map[string]interface{}{
+			"replicas": 3,
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": "web", "tier": "frontend"}},
+			},
+		},
+	}))
+	return ctx
+}
+
+func Test_RelatedResourcesLoader(t *testing.T) {
+	lister := fakeLister{
+		newResource(t, `{"apiVersion":"policy/v1","kind":"PodDisruptionBudget","metadata":{"name":"web","namespace":"prod"},"spec":{"selector":{"matchLabels":{"app":"web"}}}}`),
+		newResource(t, `{"apiVersion":"policy/v1","kind":"PodDisruptionBudget","metadata":{"name":"db","namespace":"prod"},"spec":{"selector":{"matchLabels":{"app":"db"}}}}`),
+		newResource(t, `{"apiVersion":"policy/v1","kind":"PodDisruptionBudget","metadata":{"name":"web","namespace":"staging"},"spec":{"selector":{"matchLabels":{"app":"web"}}}}`),
+		newResource(t, `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"prod"},"spec":{"replicas":3}}`),
+		newResource(t, `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"db","namespace":"prod"},"spec":{"replicas":2}}`),
+	}
+	testCases := []struct {
+		name     string
+		related  kyvernov1.RelatedResources
+		expected interface{}
+	}{{
+		name: "covering pdbs",
+		related: kyvernov1.RelatedResources{
+			APIVersion:   "policy/v1",
+			Kind:         "PodDisruptionBudget",
+			Namespace:    "{{ request.object.metadata.namespace }}",
+			SelectorPath: "spec.selector",
+			Labels:       &apiextv1.JSON{Raw: []byte(`"{{ request.object.spec.template.metadata.labels }}"`)},
+			JMESPath:     "[].metadata.name",
+		},
+		expected: []interface{}{"web"},
+	}, {
+		name: "replica sum",
+		related: kyvernov1.RelatedResources{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Namespace:  "prod",
+			JMESPath:   "sum([].spec.replicas)",
+		},
+		expected: 5.0,
+	}, {
+		name: "all namespaces",
+		related: kyvernov1.RelatedResources{
+			APIVersion: "policy/v1",
+			Kind:       "PodDisruptionBudget",
+			JMESPath:   "length(@)",
+		},
+		expected: 3.0,
+	}}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := newRelatedResourcesContext(t)
+			related := test.related
+			entry := kyvernov1.ContextEntry{Name: "related", RelatedResources: &related}
+			loader := NewRelatedResourcesLoader(context.TODO(), logging.GlobalLogger(), entry, ctx, jmespath.New(config.NewDefaultConfiguration(false)), lister)
+			assert.NilError(t, loader.LoadData())
+			assert.Assert(t, loader.HasLoaded())
+			result, err := ctx.Query("related")
+			assert.NilError(t, err)
+			assert.DeepEqual(t, result, test.expected)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	matchutils "github.com/kyverno/kyverno/pkg/utils/match"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+)
+
+// OwnerInformer is an OwnerResolver backed by informers tracking a fixed set of owner kinds.
+type OwnerInformer interface {
+	matchutils.OwnerResolver
+	Start(stopCh <-chan struct{})
+	WaitForCacheSync(stopCh <-chan struct{}) map[schema.GroupVersionResource]bool
+}
+
+type informerBasedOwnerResolver struct {
+	factory   dynamicinformer.DynamicSharedInformerFactory
+	informers map[schema.GroupVersionKind]informers.GenericInformer
+}
+
+// NewInformerBasedOwnerResolver returns an owner resolver backed by one informer per tracked owner kind.
+// Informers must be started and synced before owners are resolved, resolving an owner of a kind that is not tracked fails.
+func NewInformerBasedOwnerResolver(
+	client dynamic.Interface,
+	mapper GVRMapper,
+	kinds []schema.GroupVersionKind,
+	resyncPeriod time.Duration,
+) (OwnerInformer, error) {
+	if client == nil {
+		return nil, errors.New("client must not be nil")
+	}
+	if mapper == nil {
+		return nil, errors.New("mapper must not be nil")
+	}
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(client, resyncPeriod)
+	resolver := &informerBasedOwnerResolver{
+		factory:   factory,
+		informers: map[schema.GroupVersionKind]informers.GenericInformer{},
+	}
+	for _, gvk := range kinds {
+		if _, ok := resolver.informers[gvk]; ok {
+			continue
+		}
+		gvr, err := mapper(gvk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map %s to a resource: %w", gvk, err)
+		}
+		resolver.informers[gvk] = factory.ForResource(gvr)
+	}
+	return resolver, nil
+}
+
+func (r *informerBasedOwnerResolver) Start(stopCh <-chan struct{}) {
+	r.factory.Start(stopCh)
+}
+
+func (r *informerBasedOwnerResolver) WaitForCacheSync(stopCh <-chan struct{}) map[schema.GroupVersionResource]bool {
+	return r.factory.WaitForCacheSync(stopCh)
+}
+
+// Get returns the owner from the informer cache, the returned owner must not be modified.
+// Owners of namespaced resources are looked up in the namespace of the owned resource.
+func (r *informerBasedOwnerResolver) Get(ctx context.Context, apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	gvk := gv.WithKind(kind)
+	informer, ok := r.informers[gvk]
+	if !ok {
+		return nil, fmt.Errorf("owners of kind %s are not tracked, it must be added to the configured owner resources", gvk)
+	}
+	if !informer.Informer().HasSynced() {
+		return nil, fmt.Errorf("owners of kind %s are not synced yet", gvk)
+	}
+	var obj runtime.Object
+	if namespace != "" {
+		obj, err = informer.Lister().ByNamespace(namespace).Get(name)
+	} else {
+		obj, err = informer.Lister().Get(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	owner, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type %T for %s", obj, gvk)
+	}
+	return owner, nil
+}
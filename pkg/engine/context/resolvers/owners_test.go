@@ -0,0 +1,59 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+var jobGVR = schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}
+
+func Test_InformerBasedOwnerResolver(t *testing.T) {
+	job := &unstructured.Unstructured{}
+	job.SetAPIVersion("batch/v1")
+	job.SetKind("Job")
+	job.SetNamespace("prod")
+	job.SetName("backup")
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{jobGVR: "JobList"},
+		job,
+	)
+	mapper := func(gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
+		return jobGVR, nil
+	}
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	resolver, err := NewInformerBasedOwnerResolver(client, mapper, []schema.GroupVersionKind{{Group: "batch", Version: "v1", Kind: "Job"}}, 15*time.Minute)
+	assert.NilError(t, err)
+	// owners can't be resolved until the informers are synced
+	_, err = resolver.Get(ctx, "batch/v1", "Job", "prod", "backup")
+	assert.ErrorContains(t, err, "not synced yet")
+	resolver.Start(ctx.Done())
+	for _, synced := range resolver.WaitForCacheSync(ctx.Done()) {
+		assert.Assert(t, synced)
+	}
+	owner, err := resolver.Get(ctx, "batch/v1", "Job", "prod", "backup")
+	assert.NilError(t, err)
+	assert.Equal(t, owner.GetName(), "backup")
+	// owners of namespaced resources are only looked up in the namespace of the owned resource
+	_, err = resolver.Get(ctx, "batch/v1", "Job", "staging", "backup")
+	assert.Assert(t, apierrors.IsNotFound(err))
+	_, err = resolver.Get(ctx, "batch/v1", "Job", "prod", "restore")
+	assert.Assert(t, apierrors.IsNotFound(err))
+	// owners of kinds that are not tracked can't be resolved
+	_, err = resolver.Get(ctx, "batch/v1", "CronJob", "prod", "backup")
+	assert.ErrorContains(t, err, "not tracked")
+	// lookups are bound by the context of the request
+	cancelled, cancelRequest := context.WithCancel(ctx)
+	cancelRequest()
+	_, err = resolver.Get(cancelled, "batch/v1", "Job", "prod", "backup")
+	assert.Equal(t, err, context.Canceled)
+}
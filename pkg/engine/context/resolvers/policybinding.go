@@ -0,0 +1,68 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+type informerBasedPolicyBindingResolver struct {
+	bindings   kyvernov2alpha1listers.PolicyBindingLister
+	namespaces corev1listers.NamespaceLister
+}
+
+// NewInformerBasedPolicyBindingResolver returns a resolver matching the policy bindings against the labels of namespaces.
+// When several bindings of a policy bind the same namespace, the first one in name order wins.
+func NewInformerBasedPolicyBindingResolver(
+	bindings kyvernov2alpha1listers.PolicyBindingLister,
+	namespaces corev1listers.NamespaceLister,
+) (engineapi.PolicyBindingResolver, error) {
+	if bindings == nil {
+		return nil, errors.New("bindings lister must not be nil")
+	}
+	if namespaces == nil {
+		return nil, errors.New("namespaces lister must not be nil")
+	}
+	return &informerBasedPolicyBindingResolver{
+		bindings:   bindings,
+		namespaces: namespaces,
+	}, nil
+}
+
+func (r *informerBasedPolicyBindingResolver) Resolve(ctx context.Context, policyName string, namespace string) (*kyvernov2alpha1.PolicyBinding, error) {
+	if namespace == "" {
+		return nil, nil
+	}
+	bindings, err := r.bindings.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var candidates []*kyvernov2alpha1.PolicyBinding
+	for _, binding := range bindings {
+		if binding.Spec.PolicyName == policyName && len(binding.Validate()) == 0 {
+			candidates = append(candidates, binding)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	var namespaceLabels map[string]string
+	if ns, err := r.namespaces.Get(namespace); err == nil {
+		namespaceLabels = ns.GetLabels()
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Name < candidates[j].Name
+	})
+	for _, binding := range candidates {
+		if binding.Spec.Binds(namespace, namespaceLabels) {
+			return binding, nil
+		}
+	}
+	return nil, nil
+}
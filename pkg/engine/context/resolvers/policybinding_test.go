@@ -0,0 +1,79 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newPolicyBinding(name string, policy string, namespaces []string, selector *metav1.LabelSelector) *kyvernov2alpha1.PolicyBinding {
+	return &kyvernov2alpha1.PolicyBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: kyvernov2alpha1.PolicyBindingSpec{
+			PolicyName:        policy,
+			Namespaces:        namespaces,
+			NamespaceSelector: selector,
+			Variables: []kyvernov2alpha1.PolicyBindingVariable{{
+				Name:  "maxReplicas",
+				Value: apiextv1.JSON{Raw: []byte("5")},
+			}},
+		},
+	}
+}
+
+func Test_PolicyBindingResolver(t *testing.T) {
+	bindings := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	namespaces := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NilError(t, namespaces.Add(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"tier": "gold"}}}))
+	assert.NilError(t, namespaces.Add(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}}))
+	assert.NilError(t, bindings.Add(newPolicyBinding("gold", "replicas", nil, &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "gold"}})))
+	assert.NilError(t, bindings.Add(newPolicyBinding("all-teams", "replicas", []string{"team-*"}, nil)))
+	assert.NilError(t, bindings.Add(newPolicyBinding("other", "other", []string{"*"}, nil)))
+	// invalid bindings are ignored
+	invalid := newPolicyBinding("aaa-invalid", "replicas", []string{"*"}, nil)
+	invalid.Spec.Variables = nil
+	assert.NilError(t, bindings.Add(invalid))
+	resolver, err := NewInformerBasedPolicyBindingResolver(
+		kyvernov2alpha1listers.NewPolicyBindingLister(bindings),
+		corev1listers.NewNamespaceLister(namespaces),
+	)
+	assert.NilError(t, err)
+	tests := []struct {
+		policy    string
+		namespace string
+		want      string
+	}{
+		// the first binding in name order wins
+		{policy: "replicas", namespace: "team-a", want: "all-teams"},
+		{policy: "replicas", namespace: "team-b", want: "all-teams"},
+		{policy: "replicas", namespace: "default", want: ""},
+		{policy: "replicas", namespace: "", want: ""},
+		{policy: "other", namespace: "default", want: "other"},
+		{policy: "unbound", namespace: "team-a", want: ""},
+	}
+	for _, tt := range tests {
+		binding, err := resolver.Resolve(context.TODO(), tt.policy, tt.namespace)
+		assert.NilError(t, err)
+		if tt.want == "" {
+			assert.Assert(t, binding == nil, "%s/%s", tt.policy, tt.namespace)
+		} else {
+			assert.Equal(t, binding.Name, tt.want)
+		}
+	}
+	// selectors match the labels of the namespace
+	assert.NilError(t, bindings.Delete(newPolicyBinding("all-teams", "replicas", nil, nil)))
+	binding, err := resolver.Resolve(context.TODO(), "replicas", "team-a")
+	assert.NilError(t, err)
+	assert.Equal(t, binding.Name, "gold")
+	binding, err = resolver.Resolve(context.TODO(), "replicas", "team-b")
+	assert.NilError(t, err)
+	assert.Assert(t, binding == nil)
+}
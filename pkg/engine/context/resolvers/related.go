@@ -0,0 +1,170 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// GVRMapper maps a group version kind to the corresponding group version resource.
+type GVRMapper = func(schema.GroupVersionKind) (schema.GroupVersionResource, error)
+
+// RelatedResourceEventHandler is called when a tracked related resource is created, updated or deleted.
+type RelatedResourceEventHandler = func(gvk schema.GroupVersionKind, namespace string)
+
+// RelatedResourceInformer is a RelatedResourceLister backed by informers tracking a fixed set of kinds.
+type RelatedResourceInformer interface {
+	engineapi.RelatedResourceLister
+	// Kinds returns the tracked kinds.
+	Kinds() []schema.GroupVersionKind
+	// AddEventHandler registers a handler notified of changes to tracked resources.
+	AddEventHandler(RelatedResourceEventHandler) error
+	Start(stopCh <-chan struct{})
+	WaitForCacheSync(stopCh <-chan struct{}) map[schema.GroupVersionResource]bool
+}
+
+type informerBasedRelatedResourceLister struct {
+	factory   dynamicinformer.DynamicSharedInformerFactory
+	kinds     []schema.GroupVersionKind
+	informers map[schema.GroupVersionKind]informers.GenericInformer
+}
+
+// NewInformerBasedRelatedResourceLister returns a lister backed by one informer per tracked kind.
+// Informers must be started and synced before resources are listed, listing a kind that is not tracked fails.
+func NewInformerBasedRelatedResourceLister(
+	client dynamic.Interface,
+	mapper GVRMapper,
+	kinds []schema.GroupVersionKind,
+	resyncPeriod time.Duration,
+) (RelatedResourceInformer, error) {
+	if client == nil {
+		return nil, errors.New("client must not be nil")
+	}
+	if mapper == nil {
+		return nil, errors.New("mapper must not be nil")
+	}
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(client, resyncPeriod)
+	lister := &informerBasedRelatedResourceLister{
+		factory:   factory,
+		informers: map[schema.GroupVersionKind]informers.GenericInformer{},
+	}
+	for _, gvk := range kinds {
+		if _, ok := lister.informers[gvk]; ok {
+			continue
+		}
+		gvr, err := mapper(gvk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map %s to a resource: %w", gvk, err)
+		}
+		lister.kinds = append(lister.kinds, gvk)
+		lister.informers[gvk] = factory.ForResource(gvr)
+	}
+	return lister, nil
+}
+
+func (l *informerBasedRelatedResourceLister) Kinds() []schema.GroupVersionKind {
+	return l.kinds
+}
+
+func (l *informerBasedRelatedResourceLister) AddEventHandler(handler RelatedResourceEventHandler) error {
+	for gvk, informer := range l.informers {
+		gvk := gvk
+		notify := func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if resource, ok := obj.(*unstructured.Unstructured); ok {
+				handler(gvk, resource.GetNamespace())
+			}
+		}
+		_, err := informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: notify,
+			UpdateFunc: func(old, obj interface{}) {
+				// skip periodic resyncs
+				if oldResource, ok := old.(*unstructured.Unstructured); ok {
+					if resource, ok := obj.(*unstructured.Unstructured); ok && oldResource.GetResourceVersion() == resource.GetResourceVersion() {
+						return
+					}
+				}
+				notify(obj)
+			},
+			DeleteFunc: notify,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *informerBasedRelatedResourceLister) Start(stopCh <-chan struct{}) {
+	l.factory.Start(stopCh)
+}
+
+func (l *informerBasedRelatedResourceLister) WaitForCacheSync(stopCh <-chan struct{}) map[schema.GroupVersionResource]bool {
+	return l.factory.WaitForCacheSync(stopCh)
+}
+
+func (l *informerBasedRelatedResourceLister) List(_ context.Context, apiVersion, kind, namespace string) ([]unstructured.Unstructured, error) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	gvk := gv.WithKind(kind)
+	informer, ok := l.informers[gvk]
+	if !ok {
+		return nil, fmt.Errorf("related resources of kind %s are not tracked, it must be added to the configured related resources", gvk)
+	}
+	if !informer.Informer().HasSynced() {
+		return nil, fmt.Errorf("related resources of kind %s are not synced yet", gvk)
+	}
+	var objs []runtime.Object
+	if namespace == "" {
+		objs, err = informer.Lister().List(labels.Everything())
+	} else {
+		objs, err = informer.Lister().ByNamespace(namespace).List(labels.Everything())
+	}
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]unstructured.Unstructured, 0, len(objs))
+	for _, obj := range objs {
+		resource, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return nil, fmt.Errorf("unexpected object type %T", obj)
+		}
+		resources = append(resources, *resource.DeepCopy())
+	}
+	return resources, nil
+}
+
+type clientBasedRelatedResourceLister struct {
+	client engineapi.ResourceClient
+}
+
+// NewClientBasedRelatedResourceLister returns a lister querying the API server every time resources are listed.
+func NewClientBasedRelatedResourceLister(client engineapi.ResourceClient) (engineapi.RelatedResourceLister, error) {
+	if client == nil {
+		return nil, errors.New("client must not be nil")
+	}
+	return &clientBasedRelatedResourceLister{client}, nil
+}
+
+func (l *clientBasedRelatedResourceLister) List(ctx context.Context, apiVersion, kind, namespace string) ([]unstructured.Unstructured, error) {
+	list, err := l.client.ListResource(ctx, apiVersion, kind, namespace, nil)
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
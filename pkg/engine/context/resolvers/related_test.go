@@ -0,0 +1,84 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+var (
+	pdbGVK = schema.GroupVersionKind{Group: "policy", Version: "v1", Kind: "PodDisruptionBudget"}
+	pdbGVR = schema.GroupVersionResource{Group: "policy", Version: "v1", Resource: "poddisruptionbudgets"}
+)
+
+func newPDB(namespace, name string) *unstructured.Unstructured {
+	pdb := &unstructured.Unstructured{}
+	pdb.SetAPIVersion("policy/v1")
+	pdb.SetKind("PodDisruptionBudget")
+	pdb.SetNamespace(namespace)
+	pdb.SetName(name)
+	return pdb
+}
+
+func Test_InformerBasedRelatedResourceLister(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{pdbGVR: "PodDisruptionBudgetList"},
+		newPDB("prod", "web"),
+		newPDB("prod", "db"),
+		newPDB("staging", "web"),
+	)
+	mapper := func(gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
+		return pdbGVR, nil
+	}
+	lister, err := NewInformerBasedRelatedResourceLister(client, mapper, []schema.GroupVersionKind{pdbGVK, pdbGVK}, 15*time.Minute)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, lister.Kinds(), []schema.GroupVersionKind{pdbGVK})
+	changes := make(chan string, 10)
+	assert.NilError(t, lister.AddEventHandler(func(gvk schema.GroupVersionKind, namespace string) {
+		changes <- namespace
+	}))
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	// listing before the informers are synced must not block
+	_, err = lister.List(ctx, "policy/v1", "PodDisruptionBudget", "prod")
+	assert.Error(t, err, "related resources of kind policy/v1, Kind=PodDisruptionBudget are not synced yet")
+	lister.Start(ctx.Done())
+	for gvr, synced := range lister.WaitForCacheSync(ctx.Done()) {
+		assert.Assert(t, synced, gvr)
+	}
+	resources, err := lister.List(ctx, "policy/v1", "PodDisruptionBudget", "prod")
+	assert.NilError(t, err)
+	assert.Equal(t, len(resources), 2)
+	resources, err = lister.List(ctx, "policy/v1", "PodDisruptionBudget", "")
+	assert.NilError(t, err)
+	assert.Equal(t, len(resources), 3)
+	_, err = lister.List(ctx, "apps/v1", "Deployment", "prod")
+	assert.Error(t, err, "related resources of kind apps/v1, Kind=Deployment are not tracked, it must be added to the configured related resources")
+	// initial list notifies once per resource
+	for i := 0; i < 3; i++ {
+		<-changes
+	}
+	_, err = client.Resource(pdbGVR).Namespace("staging").Create(ctx, newPDB("staging", "db"), metav1.CreateOptions{})
+	assert.NilError(t, err)
+	select {
+	case namespace := <-changes:
+		assert.Equal(t, namespace, "staging")
+	case <-time.After(10 * time.Second):
+		t.Fatal("no event received")
+	}
+}
+
+func Test_InformerBasedRelatedResourceListerErrors(t *testing.T) {
+	_, err := NewInformerBasedRelatedResourceLister(nil, nil, nil, 15*time.Minute)
+	assert.Error(t, err, "client must not be nil")
+	_, err = NewClientBasedRelatedResourceLister(nil)
+	assert.Error(t, err, "client must not be nil")
+}
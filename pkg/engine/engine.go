@@ -3,6 +3,7 @@ package engine
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -10,48 +11,70 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/compiled"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/handlers"
 	"github.com/kyverno/kyverno/pkg/engine/internal"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	"github.com/kyverno/kyverno/pkg/engine/wasm"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/tracing"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	matchutils "github.com/kyverno/kyverno/pkg/utils/match"
 	stringutils "github.com/kyverno/kyverno/pkg/utils/strings"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/clock"
 )
 
 type engine struct {
 	configuration            config.Configuration
 	metricsConfiguration     config.MetricsConfiguration
 	jp                       jmespath.Interface
+	compiledCache            compiled.Cache
 	client                   engineapi.Client
 	rclientFactory           engineapi.RegistryClientFactory
 	ivCache                  imageverifycache.Client
 	contextLoader            engineapi.ContextLoaderFactory
 	exceptionSelector        engineapi.PolicyExceptionSelector
+	exceptionUsage           engineapi.PolicyExceptionUsageRecorder
+	ownerResolver            matchutils.OwnerResolver
 	imageSignatureRepository string
+	wasmRuntime              wasm.Runtime
+	schedules                *engineapi.ScheduleCache
+	clock                    clock.PassiveClock
+	// abandonedRules counts the evaluations of timed out rules still running
+	abandonedRules atomic.Int64
 	// metrics
-	resultCounter     metric.Int64Counter
-	durationHistogram metric.Float64Histogram
+	resultCounter         metric.Int64Counter
+	durationHistogram     metric.Float64Histogram
+	exceptionUsageCounter metric.Int64Counter
+	ruleLatency           *metrics.RuleLatencyRecorder
 }
 
 type handlerFactory = func() (handlers.Handler, error)
 
+// maxAbandonedRules is the maximum number of evaluations of timed out rules still running,
+// rules with a timeout time out immediately beyond it
+const maxAbandonedRules = 100
+
 func NewEngine(
 	configuration config.Configuration,
 	metricsConfiguration config.MetricsConfiguration,
 	jp jmespath.Interface,
+	compiledCache compiled.Cache,
 	client engineapi.Client,
 	rclientFactory engineapi.RegistryClientFactory,
 	ivCache imageverifycache.Client,
 	contextLoader engineapi.ContextLoaderFactory,
 	exceptionSelector engineapi.PolicyExceptionSelector,
+	exceptionUsage engineapi.PolicyExceptionUsageRecorder,
+	ownerResolver matchutils.OwnerResolver,
 	imageSignatureRepository string,
 ) engineapi.Engine {
 	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
@@ -69,18 +92,37 @@ func NewEngine(
 	if err != nil {
 		logging.Error(err, "failed to register metric kyverno_policy_execution_duration_seconds")
 	}
+	exceptionUsageCounter, err := meter.Int64Counter(
+		"kyverno_policy_exception_usage",
+		metric.WithDescription("can be used to track how often policy exceptions skipped policy rules, exceptions that are never used can be removed"),
+	)
+	if err != nil {
+		logging.Error(err, "failed to register metric kyverno_policy_exception_usage")
+	}
+	ruleLatency, err := metrics.NewRuleLatencyRecorder(meter, metricsConfiguration)
+	if err != nil {
+		logging.Error(err, "failed to register metric "+metrics.RuleLatencyMetricName)
+	}
 	return &engine{
 		configuration:            configuration,
 		metricsConfiguration:     metricsConfiguration,
 		jp:                       jp,
+		compiledCache:            compiledCache,
 		client:                   client,
 		rclientFactory:           rclientFactory,
 		ivCache:                  ivCache,
 		contextLoader:            contextLoader,
 		exceptionSelector:        exceptionSelector,
+		exceptionUsage:           exceptionUsage,
+		ownerResolver:            ownerResolver,
 		imageSignatureRepository: imageSignatureRepository,
+		wasmRuntime:              wasm.NewRuntime(logging.WithName("wasm"), wasm.RegistryFetcher(rclientFactory)),
+		schedules:                engineapi.NewScheduleCache(),
+		clock:                    clock.RealClock{},
 		resultCounter:            resultCounter,
 		durationHistogram:        durationHistogram,
+		exceptionUsageCounter:    exceptionUsageCounter,
+		ruleLatency:              ruleLatency,
 	}
 }
 
@@ -88,15 +130,16 @@ func (e *engine) Validate(
 	ctx context.Context,
 	policyContext engineapi.PolicyContext,
 ) engineapi.EngineResponse {
-	startTime := time.Now()
-	response := engineapi.NewEngineResponseFromPolicyContext(policyContext)
+	startTime := e.clock.Now()
+	response := e.newEngineResponse(policyContext, startTime)
 	logger := internal.LoggerWithPolicyContext(logging.WithName("engine.validate"), policyContext)
 	if internal.MatchPolicyContext(logger, policyContext, e.configuration) {
 		policyResponse := e.validate(ctx, logger, policyContext)
-		response = response.WithPolicyResponse(policyResponse)
+		response = response.WithPolicyResponse(redactSecretData(policyContext, policyResponse))
 	}
-	response = response.WithStats(engineapi.NewExecutionStats(startTime, time.Now()))
+	response = response.WithStats(engineapi.NewExecutionStats(startTime, e.clock.Now()))
 	e.reportMetrics(ctx, logger, policyContext.Operation(), policyContext.AdmissionOperation(), response)
+	e.recordExceptionUsage(policyContext.AdmissionOperation(), response)
 	return response
 }
 
@@ -104,17 +147,18 @@ func (e *engine) Mutate(
 	ctx context.Context,
 	policyContext engineapi.PolicyContext,
 ) engineapi.EngineResponse {
-	startTime := time.Now()
-	response := engineapi.NewEngineResponseFromPolicyContext(policyContext)
+	startTime := e.clock.Now()
+	response := e.newEngineResponse(policyContext, startTime)
 	logger := internal.LoggerWithPolicyContext(logging.WithName("engine.mutate"), policyContext)
 	if internal.MatchPolicyContext(logger, policyContext, e.configuration) {
 		policyResponse, patchedResource := e.mutate(ctx, logger, policyContext)
 		response = response.
-			WithPolicyResponse(policyResponse).
+			WithPolicyResponse(redactSecretData(policyContext, policyResponse)).
 			WithPatchedResource(patchedResource)
 	}
-	response = response.WithStats(engineapi.NewExecutionStats(startTime, time.Now()))
+	response = response.WithStats(engineapi.NewExecutionStats(startTime, e.clock.Now()))
 	e.reportMetrics(ctx, logger, policyContext.Operation(), policyContext.AdmissionOperation(), response)
+	e.recordExceptionUsage(policyContext.AdmissionOperation(), response)
 	return response
 }
 
@@ -122,15 +166,16 @@ func (e *engine) Generate(
 	ctx context.Context,
 	policyContext engineapi.PolicyContext,
 ) engineapi.EngineResponse {
-	startTime := time.Now()
-	response := engineapi.NewEngineResponseFromPolicyContext(policyContext)
+	startTime := e.clock.Now()
+	response := e.newEngineResponse(policyContext, startTime)
 	logger := internal.LoggerWithPolicyContext(logging.WithName("engine.generate"), policyContext)
 	if internal.MatchPolicyContext(logger, policyContext, e.configuration) {
 		policyResponse := e.generateResponse(ctx, logger, policyContext)
-		response = response.WithPolicyResponse(policyResponse)
+		response = response.WithPolicyResponse(redactSecretData(policyContext, policyResponse))
 	}
-	response = response.WithStats(engineapi.NewExecutionStats(startTime, time.Now()))
+	response = response.WithStats(engineapi.NewExecutionStats(startTime, e.clock.Now()))
 	e.reportMetrics(ctx, logger, policyContext.Operation(), policyContext.AdmissionOperation(), response)
+	e.recordExceptionUsage(policyContext.AdmissionOperation(), response)
 	return response
 }
 
@@ -138,18 +183,19 @@ func (e *engine) VerifyAndPatchImages(
 	ctx context.Context,
 	policyContext engineapi.PolicyContext,
 ) (engineapi.EngineResponse, engineapi.ImageVerificationMetadata) {
-	startTime := time.Now()
-	response := engineapi.NewEngineResponseFromPolicyContext(policyContext)
+	startTime := e.clock.Now()
+	response := e.newEngineResponse(policyContext, startTime)
 	ivm := engineapi.ImageVerificationMetadata{}
 	logger := internal.LoggerWithPolicyContext(logging.WithName("engine.verify"), policyContext)
 	if internal.MatchPolicyContext(logger, policyContext, e.configuration) {
 		policyResponse, patchedResource, innerIvm := e.verifyAndPatchImages(ctx, logger, policyContext)
 		response, ivm = response.
-			WithPolicyResponse(policyResponse).
+			WithPolicyResponse(redactSecretData(policyContext, policyResponse)).
 			WithPatchedResource(patchedResource), innerIvm
 	}
-	response = response.WithStats(engineapi.NewExecutionStats(startTime, time.Now()))
+	response = response.WithStats(engineapi.NewExecutionStats(startTime, e.clock.Now()))
 	e.reportMetrics(ctx, logger, policyContext.Operation(), policyContext.AdmissionOperation(), response)
+	e.recordExceptionUsage(policyContext.AdmissionOperation(), response)
 	return response, ivm
 }
 
@@ -157,15 +203,25 @@ func (e *engine) ApplyBackgroundChecks(
 	ctx context.Context,
 	policyContext engineapi.PolicyContext,
 ) engineapi.EngineResponse {
-	startTime := time.Now()
-	response := engineapi.NewEngineResponseFromPolicyContext(policyContext)
+	startTime := e.clock.Now()
+	response := e.newEngineResponse(policyContext, startTime)
 	logger := internal.LoggerWithPolicyContext(logging.WithName("engine.background"), policyContext)
 	if internal.MatchPolicyContext(logger, policyContext, e.configuration) {
 		policyResponse := e.applyBackgroundChecks(ctx, logger, policyContext)
-		response = response.WithPolicyResponse(policyResponse)
+		response = response.WithPolicyResponse(redactSecretData(policyContext, policyResponse))
 	}
-	response = response.WithStats(engineapi.NewExecutionStats(startTime, time.Now()))
+	response = response.WithStats(engineapi.NewExecutionStats(startTime, e.clock.Now()))
 	e.reportMetrics(ctx, logger, policyContext.Operation(), policyContext.AdmissionOperation(), response)
+	e.recordExceptionUsage(policyContext.AdmissionOperation(), response)
+	return response
+}
+
+// newEngineResponse creates the response of the policy context, recording if the policy schedule is active
+func (e *engine) newEngineResponse(policyContext engineapi.PolicyContext, now time.Time) engineapi.EngineResponse {
+	response := engineapi.NewEngineResponseFromPolicyContext(policyContext)
+	if policy := policyContext.Policy(); policy != nil && policy.GetSpec().Schedule != nil {
+		response = response.WithScheduleActive(e.schedules.IsActive(policy, now))
+	}
 	return response
 }
 
@@ -186,8 +242,34 @@ func (e *engine) ContextLoader(
 	}
 }
 
+// redactSecretData masks the data of the Secrets being processed in the rule responses, messages can't reference
+// `request.object.dataDecoded` and `request.oldObject.dataDecoded` but can still decode `request.object.data`
+func redactSecretData(policyContext engineapi.PolicyContext, response engineapi.PolicyResponse) engineapi.PolicyResponse {
+	newResource, oldResource := policyContext.NewResource(), policyContext.OldResource()
+	if !kubeutils.IsSecret(newResource.Object) && !kubeutils.IsSecret(oldResource.Object) {
+		return response
+	}
+	redact := func(message string) string {
+		return kubeutils.RedactSecretData(message, newResource.Object, oldResource.Object)
+	}
+	for i, rule := range response.Rules {
+		redacted := rule.WithMessage(redact(rule.Message())).WithRemediation(redact(rule.Remediation()))
+		if violations := rule.Violations(); len(violations) != 0 {
+			redactedViolations := make([]engineapi.Violation, 0, len(violations))
+			for _, violation := range violations {
+				violation.Reason = redact(violation.Reason)
+				redactedViolations = append(redactedViolations, violation)
+			}
+			redacted = redacted.WithViolations(redactedViolations)
+		}
+		response.Rules[i] = *redacted
+	}
+	return response
+}
+
 // matches checks if either the new or old resource satisfies the filter conditions defined in the rule
 func (e *engine) matches(
+	ctx context.Context,
 	rule kyvernov1.Rule,
 	policyContext engineapi.PolicyContext,
 	resource unstructured.Unstructured,
@@ -200,6 +282,8 @@ func (e *engine) matches(
 	}
 	gvk, subresource := policyContext.ResourceKind()
 	err := engineutils.MatchesResourceDescription(
+		ctx,
+		e.ownerResolver,
 		resource,
 		rule,
 		policyContext.AdmissionInfo(),
@@ -215,6 +299,8 @@ func (e *engine) matches(
 	oldResource := policyContext.OldResource()
 	if resource.Object == nil && oldResource.Object != nil {
 		err := engineutils.MatchesResourceDescription(
+			ctx,
+			e.ownerResolver,
 			policyContext.OldResource(),
 			rule,
 			policyContext.AdmissionInfo(),
@@ -246,7 +332,7 @@ func (e *engine) invokeRuleHandler(
 		fmt.Sprintf("RULE %s", rule.Name),
 		func(ctx context.Context, span trace.Span) (patchedResource unstructured.Unstructured, results []engineapi.RuleResponse) {
 			// check if resource and rule match
-			if err := e.matches(rule, policyContext, resource); err != nil {
+			if err := e.matches(ctx, rule, policyContext, resource); err != nil {
 				logger.V(4).Info("rule not matched", "reason", err.Error())
 				return resource, nil
 			}
@@ -264,36 +350,118 @@ func (e *engine) invokeRuleHandler(
 						}
 					}
 				}()
-				// load rule context
-				contextLoader := e.ContextLoader(policyContext.Policy(), rule)
-				if err := contextLoader(ctx, rule.Context, policyContext.JSONContext()); err != nil {
-					if _, ok := err.(gojmespath.NotFoundError); ok {
-						logger.V(3).Info("failed to load context", "reason", err.Error())
-					} else {
-						logger.Error(err, "failed to load context")
-					}
-					return resource, handlers.WithError(rule, ruleType, "failed to load context", err)
+				if rule.Timeout == nil {
+					return e.applyRule(ctx, logger, handler, policyContext, resource, rule, ruleType)
 				}
-				// check preconditions
-				preconditionsPassed, msg, err := internal.CheckPreconditions(logger, policyContext.JSONContext(), rule.GetAnyAllConditions())
-				if err != nil {
-					return resource, handlers.WithError(rule, ruleType, "failed to evaluate preconditions", err)
+				ruleCtx, cancel := context.WithTimeout(ctx, rule.Timeout.Duration)
+				defer cancel()
+				// the rule is evaluated with its own copies of the json context and the resource,
+				// an evaluation abandoned when the timeout expires can't alter the next rules
+				jsonContext := policyContext.JSONContext().Copy()
+				jsonContext.WithCancellation(ruleCtx)
+				isolated := policyContext.CopyWithJSONContext(jsonContext)
+				type ruleResult struct {
+					patchedResource unstructured.Unstructured
+					results         []engineapi.RuleResponse
 				}
-				if !preconditionsPassed {
-					s := stringutils.JoinNonEmpty([]string{"preconditions not met", msg}, "; ")
-					return resource, handlers.WithSkip(rule, ruleType, s)
+				// evaluations abandoned after a timeout keep running until their handler returns,
+				// rules are not evaluated while too many of them are still running
+				if e.abandonedRules.Load() >= maxAbandonedRules {
+					logger.V(2).Info("too many timed out rule evaluations still running", "limit", maxAbandonedRules)
+					return resource, timeoutResponse(ctx, policyContext, rule, ruleType)
 				}
-				// get policy exceptions that matches both policy and rule name
-				exceptions, err := e.GetPolicyExceptions(policyContext.Policy(), rule.Name)
-				if err != nil {
-					logger.Error(err, "failed to get exceptions")
-					return resource, nil
+				done := make(chan ruleResult, 1)
+				go func(resource unstructured.Unstructured) {
+					defer func() {
+						if r := recover(); r != nil {
+							logger.Error(fmt.Errorf("%v", r), "rule evaluation panicked")
+							done <- ruleResult{resource, handlers.WithError(rule, ruleType, "rule evaluation panicked", fmt.Errorf("%v", r))}
+						}
+					}()
+					patchedResource, results := e.applyRule(ruleCtx, logger, handler, isolated, resource, rule, ruleType)
+					done <- ruleResult{patchedResource, results}
+				}(*resource.DeepCopy())
+				select {
+				case result := <-done:
+					patchedResource, results = result.patchedResource, result.results
+				case <-ruleCtx.Done():
+					// the evaluation is abandoned, handlers checking the rule context stop early
+					e.abandonedRules.Add(1)
+					go func() {
+						<-done
+						e.abandonedRules.Add(-1)
+					}()
+					// the parent context expiring is not a timeout of the rule
+					if ctx.Err() != nil {
+						return resource, handlers.WithError(rule, ruleType, "rule evaluation cancelled", ctx.Err())
+					}
+					logger.V(2).Info("rule timed out", "timeout", rule.Timeout.Duration)
+					return resource, timeoutResponse(ctx, policyContext, rule, ruleType)
 				}
-				// process handler
-				resource, ruleResponses := handler.Process(ctx, logger, policyContext, resource, rule, contextLoader, exceptions)
-				return resource, ruleResponses
+				return patchedResource, results
 			}
 			return resource, nil
 		},
 	)
 }
+
+func (e *engine) applyRule(
+	ctx context.Context,
+	logger logr.Logger,
+	handler handlers.Handler,
+	policyContext engineapi.PolicyContext,
+	resource unstructured.Unstructured,
+	rule kyvernov1.Rule,
+	ruleType engineapi.RuleType,
+) (unstructured.Unstructured, []engineapi.RuleResponse) {
+	// load rule context
+	contextLoader := e.ContextLoader(policyContext.Policy(), rule)
+	if err := contextLoader(ctx, rule.Context, policyContext.JSONContext()); err != nil {
+		if _, ok := err.(gojmespath.NotFoundError); ok {
+			logger.V(3).Info("failed to load context", "reason", err.Error())
+		} else {
+			logger.Error(err, "failed to load context")
+		}
+		return resource, handlers.WithError(rule, ruleType, "failed to load context", err)
+	}
+	// check preconditions
+	preconditionsPassed, msg, err := internal.CheckPreconditions(logger, policyContext.JSONContext(), rule.GetAnyAllConditions())
+	if err != nil {
+		return resource, handlers.WithError(rule, ruleType, "failed to evaluate preconditions", err)
+	}
+	if !preconditionsPassed {
+		s := stringutils.JoinNonEmpty([]string{"preconditions not met", msg}, "; ")
+		return resource, handlers.WithSkip(rule, ruleType, s)
+	}
+	// get policy exceptions that matches both policy and rule name
+	exceptions, err := e.GetPolicyExceptions(policyContext.Policy(), rule.Name)
+	if err != nil {
+		logger.Error(err, "failed to get exceptions")
+		return resource, nil
+	}
+	// process handler
+	resource, ruleResponses := handler.Process(ctx, logger, policyContext, resource, rule, contextLoader, exceptions)
+	// attach remediation hints to violations
+	if rule.Remediation != "" {
+		for i := range ruleResponses {
+			if ruleResponses[i].HasStatus(engineapi.RuleStatusFail, engineapi.RuleStatusWarn) {
+				if remediation, err := internal.ResolveRemediation(logger, policyContext.JSONContext(), rule.Remediation); err != nil {
+					logger.Error(err, "failed to resolve remediation")
+				} else {
+					ruleResponses[i] = *ruleResponses[i].WithRemediation(remediation)
+				}
+			}
+		}
+	}
+	return resource, ruleResponses
+}
+
+// timeoutResponse reports a rule that timed out according to its timeout failure policy,
+// a warning is returned when failing open so that the rule never blocks the request
+func timeoutResponse(ctx context.Context, policyContext engineapi.PolicyContext, rule kyvernov1.Rule, ruleType engineapi.RuleType) []engineapi.RuleResponse {
+	msg := fmt.Sprintf("rule timed out after %s", rule.Timeout.Duration)
+	if rule.GetTimeoutFailurePolicy(policyContext.Policy().GetSpec().GetFailurePolicy(ctx)) == kyvernov1.Ignore {
+		return handlers.WithResponses(engineapi.RuleWarn(rule.Name, ruleType, msg))
+	}
+	return handlers.WithError(rule, ruleType, msg, nil)
+}
@@ -0,0 +1,43 @@
+package engine
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_redactSecretData(t *testing.T) {
+	// data.token is "secret-token"
+	secret := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]interface{}{"name": "token"},
+		"data":       map[string]interface{}{"token": "c2VjcmV0LXRva2Vu"},
+	}}
+	// the secret is being deleted, its data is only in the old object
+	policyContext := newPolicyContext(t, unstructured.Unstructured{}, kyvernov1.Delete, nil).WithOldResource(secret)
+	response := engineapi.NewPolicyResponse()
+	response.Add(
+		engineapi.ExecutionStats{},
+		*engineapi.RuleFail("mutate", engineapi.Mutation, "failed to patch secret-token").WithRemediation("rotate secret-token"),
+		*engineapi.RuleFail("generate", engineapi.Generation, "token c2VjcmV0LXRva2Vu is not allowed"),
+	)
+	response = redactSecretData(policyContext, response)
+	assert.Equal(t, response.Rules[0].Message(), "failed to patch **REDACTED**")
+	assert.Equal(t, response.Rules[0].Remediation(), "rotate **REDACTED**")
+	assert.Equal(t, response.Rules[1].Message(), "token **REDACTED** is not allowed")
+	// messages about other resources are left untouched
+	configMap := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "token"},
+		"data":       map[string]interface{}{"token": "c2VjcmV0LXRva2Vu"},
+	}}
+	response = engineapi.NewPolicyResponse()
+	response.Add(engineapi.ExecutionStats{}, *engineapi.RuleFail("validate", engineapi.Validation, "token c2VjcmV0LXRva2Vu"))
+	response = redactSecretData(newPolicyContext(t, configMap, kyvernov1.Create, nil), response)
+	assert.Equal(t, response.Rules[0].Message(), "token c2VjcmV0LXRva2Vu")
+}
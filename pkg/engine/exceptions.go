@@ -5,6 +5,8 @@ import (
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
 )
@@ -26,10 +28,34 @@ func (e *engine) GetPolicyExceptions(
 	if err != nil {
 		return exceptions, fmt.Errorf("failed to compute policy key: %w", err)
 	}
+	now := e.clock.Now()
+	approval := e.configuration.GetExceptionApproval().Enabled
 	for _, polex := range polexs {
+		// expired exceptions no longer apply, even before the controller marks them inactive
+		if polex.IsExpired(now) {
+			continue
+		}
+		// exceptions waiting for approval don't apply, nor exceptions in namespaces where the approval isn't enforced
+		if approval && (!polex.IsApproved() || !config.IsExceptionApprovalEnforced(e.configuration, polex.GetNamespace())) {
+			continue
+		}
 		if polex.Contains(policyName, rule) {
 			exceptions = append(exceptions, *polex)
 		}
 	}
 	return exceptions, nil
 }
+
+// recordExceptionUsage records the rules skipped by policy exceptions in the response.
+// Only admission requests are recorded, periodic background scans would keep unused exceptions from looking stale.
+func (e *engine) recordExceptionUsage(admissionOperation bool, response engineapi.EngineResponse) {
+	if e.exceptionUsage == nil || !admissionOperation {
+		return
+	}
+	for _, rule := range response.PolicyResponse.Rules {
+		if rule.IsException() {
+			e.exceptionUsage.Record(response)
+			return
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/adapters"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type exceptionSelector []*kyvernov2.PolicyException
+
+func (s exceptionSelector) List(labels.Selector) ([]*kyvernov2.PolicyException, error) {
+	return s, nil
+}
+
+type usageRecorder struct {
+	responses []engineapi.EngineResponse
+}
+
+func (r *usageRecorder) Record(responses ...engineapi.EngineResponse) {
+	r.responses = append(r.responses, responses...)
+}
+
+func Test_recordExceptionUsage(t *testing.T) {
+	var policy kyvernov1.ClusterPolicy
+	assert.NilError(t, json.Unmarshal([]byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "require-team"},
+		"spec": {
+			"rules": [{
+				"name": "check-team",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"validate": {"message": "team label is required", "pattern": {"metadata": {"labels": {"team": "?*"}}}}
+			}]
+		}
+	}`), &policy))
+	var polex kyvernov2.PolicyException
+	assert.NilError(t, json.Unmarshal([]byte(`{
+		"apiVersion": "kyverno.io/v2",
+		"kind": "PolicyException",
+		"metadata": {"name": "controller", "namespace": "kyverno"},
+		"spec": {
+			"exceptions": [{"policyName": "require-team", "ruleNames": ["check-team"]}],
+			"match": {"any": [{"resources": {"kinds": ["Pod"]}}]}
+		}
+	}`), &polex))
+	var pod unstructured.Unstructured
+	assert.NilError(t, json.Unmarshal([]byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {"name": "controller", "namespace": "default"}
+	}`), &pod.Object))
+	cfg := config.NewDefaultConfiguration(false)
+	tests := []struct {
+		name               string
+		admissionOperation bool
+		wantRecorded       int
+	}{{
+		name:               "admission request",
+		admissionOperation: true,
+		wantRecorded:       1,
+	}, {
+		name: "background scan",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &usageRecorder{}
+			e := NewEngine(
+				cfg,
+				config.NewDefaultMetricsConfiguration(),
+				jp,
+				nil,
+				nil,
+				factories.DefaultRegistryClientFactory(adapters.RegistryClient(registryclient.NewOrDie()), nil),
+				imageverifycache.DisabledImageVerifyCache(),
+				factories.DefaultContextLoaderFactory(nil),
+				exceptionSelector{&polex},
+				recorder,
+				nil,
+				"",
+			)
+			policyContext, err := NewPolicyContext(jp, pod, kyvernov1.Create, nil, cfg)
+			assert.NilError(t, err)
+			policyContext = policyContext.
+				WithPolicy(&policy).
+				WithResourceKind(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, "").
+				WithAdmissionOperation(tt.admissionOperation)
+			response := e.Validate(context.TODO(), policyContext)
+			assert.Equal(t, len(response.PolicyResponse.Rules), 1)
+			assert.Assert(t, response.PolicyResponse.Rules[0].IsException())
+			assert.Equal(t, len(recorder.responses), tt.wantRecorded)
+		})
+	}
+}
+
+func Test_GetPolicyExceptions_approval(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "require-team"}}
+	exception := func(namespace string, approved bool) *kyvernov2.PolicyException {
+		polex := &kyvernov2.PolicyException{
+			ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: namespace},
+			Spec: kyvernov2.PolicyExceptionSpec{
+				Exceptions: []kyvernov2.Exception{{PolicyName: "require-team", RuleNames: []string{"check-team"}}},
+			},
+		}
+		if approved {
+			meta.SetStatusCondition(&polex.Status.Conditions, metav1.Condition{
+				Type:   kyvernov2.PolicyExceptionConditionApproved,
+				Status: metav1.ConditionTrue,
+				Reason: "Approved",
+			})
+		}
+		return polex
+	}
+	cfg := config.NewDefaultConfiguration(false)
+	cfg.Load(&corev1.ConfigMap{Data: map[string]string{
+		"resourceFilters":   "[*/*,kube-system,*]",
+		"exceptionApproval": `{"enabled": true}`,
+	}})
+	e := NewEngine(
+		cfg,
+		config.NewDefaultMetricsConfiguration(),
+		jp,
+		nil,
+		nil,
+		factories.DefaultRegistryClientFactory(adapters.RegistryClient(registryclient.NewOrDie()), nil),
+		imageverifycache.DisabledImageVerifyCache(),
+		factories.DefaultContextLoaderFactory(nil),
+		exceptionSelector{exception("default", true), exception("default", false), exception("kube-system", true)},
+		nil,
+		nil,
+		"",
+	)
+	exceptions, err := e.(*engine).GetPolicyExceptions(policy, "check-team")
+	assert.NilError(t, err)
+	// pending exceptions and exceptions in namespaces filtered from the webhooks don't apply
+	assert.Equal(t, len(exceptions), 1)
+	assert.Equal(t, exceptions[0].GetNamespace(), "default")
+}
@@ -6,10 +6,13 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	"github.com/kyverno/kyverno/pkg/engine/concurrency"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/context/loaders"
+	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/toggle"
@@ -18,7 +21,7 @@ import (
 type ContextLoaderFactoryOptions func(*contextLoader)
 
 func DefaultContextLoaderFactory(cmResolver engineapi.ConfigmapResolver, opts ...ContextLoaderFactoryOptions) engineapi.ContextLoaderFactory {
-	return func(_ kyvernov1.PolicyInterface, _ kyvernov1.Rule) engineapi.ContextLoader {
+	return func(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) engineapi.ContextLoader {
 		cl := &contextLoader{
 			logger:     logging.WithName("DefaultContextLoaderFactory"),
 			cmResolver: cmResolver,
@@ -26,10 +29,31 @@ func DefaultContextLoaderFactory(cmResolver engineapi.ConfigmapResolver, opts ..
 		for _, o := range opts {
 			o(cl)
 		}
+		if policy != nil {
+			if !policy.IsNamespaced() {
+				cl.clusterPolicyName = policy.GetName()
+			}
+			cl.parameters = policy.GetSpec().Parameters
+			cl.policyNamespace = policy.GetNamespace()
+		}
+		if cl.limiter != nil {
+			cl.ruleLimiter = cl.limiter.ForRule(ruleKey(policy, rule))
+			cl.apiCallConfig = cl.apiCallConfig.WithLimiter(cl.ruleLimiter)
+		}
 		return cl
 	}
 }
 
+func ruleKey(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) string {
+	if policy == nil {
+		return rule.Name
+	}
+	if policy.GetNamespace() == "" {
+		return policy.GetName() + "/" + rule.Name
+	}
+	return policy.GetNamespace() + "/" + policy.GetName() + "/" + rule.Name
+}
+
 func WithInitializer(initializer engineapi.Initializer) ContextLoaderFactoryOptions {
 	return func(cl *contextLoader) {
 		cl.initializers = append(cl.initializers, initializer)
@@ -42,11 +66,41 @@ func WithAPICallConfig(config apicall.APICallConfiguration) ContextLoaderFactory
 	}
 }
 
+// WithLimiter limits the concurrency of the external calls made by apiCall and imageRegistry context entries
+func WithLimiter(limiter *concurrency.Limiter) ContextLoaderFactoryOptions {
+	return func(cl *contextLoader) {
+		cl.limiter = limiter
+	}
+}
+
+// WithPolicyBindingResolver overrides the variables of cluster policies with the variables of the policy binding
+// of the namespace of the resource
+func WithPolicyBindingResolver(resolver engineapi.PolicyBindingResolver) ContextLoaderFactoryOptions {
+	return func(cl *contextLoader) {
+		cl.bindingResolver = resolver
+	}
+}
+
+func WithRelatedResourceLister(lister engineapi.RelatedResourceLister) ContextLoaderFactoryOptions {
+	return func(cl *contextLoader) {
+		cl.relatedLister = lister
+	}
+}
+
 type contextLoader struct {
 	logger        logr.Logger
 	cmResolver    engineapi.ConfigmapResolver
 	initializers  []engineapi.Initializer
 	apiCallConfig apicall.APICallConfiguration
+	relatedLister engineapi.RelatedResourceLister
+	limiter       *concurrency.Limiter
+	ruleLimiter   *concurrency.RuleLimiter
+	// policy bindings
+	bindingResolver   engineapi.PolicyBindingResolver
+	clusterPolicyName string
+	// policy parameters
+	parameters      *kyvernov1.PolicyParameters
+	policyNamespace string
 }
 
 func (l *contextLoader) Load(
@@ -62,24 +116,115 @@ func (l *contextLoader) Load(
 			return err
 		}
 	}
+	if l.parameters != nil {
+		loader, err := l.newParametersLoader(ctx, client, jsonContext)
+		if err != nil {
+			return fmt.Errorf("failed to create deferred loader for policy parameters")
+		}
+		if err := l.addLoader(ctx, loader, jsonContext); err != nil {
+			return err
+		}
+	}
+	contextEntries, err := l.bindVariables(ctx, contextEntries, jsonContext)
+	if err != nil {
+		return err
+	}
 	for _, entry := range contextEntries {
 		loader, err := l.newLoader(ctx, jp, client, rclientFactory, entry, jsonContext)
 		if err != nil {
 			return fmt.Errorf("failed to create deferred loader for context entry %s", entry.Name)
 		}
-		if loader != nil {
-			if toggle.FromContext(ctx).EnableDeferredLoading() {
-				if err := jsonContext.AddDeferredLoader(loader); err != nil {
-					return err
-				}
-			} else {
-				if err := loader.LoadData(); err != nil {
-					return err
+		if err := l.addLoader(ctx, loader, jsonContext); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *contextLoader) addLoader(ctx context.Context, loader enginecontext.DeferredLoader, jsonContext enginecontext.Interface) error {
+	if loader == nil {
+		return nil
+	}
+	if toggle.FromContext(ctx).EnableDeferredLoading() {
+		return jsonContext.AddDeferredLoader(loader)
+	}
+	return loader.LoadData()
+}
+
+// newParametersLoader returns the loader of the parameter resource of the policy, ConfigMaps are resolved
+// with the ConfigMap resolver and other resources with the client
+func (l *contextLoader) newParametersLoader(ctx context.Context, client engineapi.RawClient, jsonContext enginecontext.Interface) (enginecontext.DeferredLoader, error) {
+	var resourceClient engineapi.ResourceClient
+	if c, ok := client.(engineapi.ResourceClient); ok && c != nil {
+		resourceClient = c
+	}
+	if resourceClient == nil && (l.cmResolver == nil || !l.parameters.ParamKind.IsConfigMap()) {
+		l.logger.Info("disabled loading of policy parameters", "kind", l.parameters.ParamKind.Kind, "name", l.parameters.ParamRef.Name)
+		return nil, nil
+	}
+	ldr := loaders.NewParametersLoader(ctx, l.logger, l.parameters, l.policyNamespace, l.cmResolver, resourceClient, jsonContext)
+	return enginecontext.NewDeferredLoader(loaders.ParametersVariable, ldr, l.logger)
+}
+
+// bindVariables overrides the variable context entries with the variables of the policy binding of the namespace
+// of the resource, variables not declared by the entries are loaded first so that entries can reference them
+func (l *contextLoader) bindVariables(ctx context.Context, contextEntries []kyvernov1.ContextEntry, jsonContext enginecontext.Interface) ([]kyvernov1.ContextEntry, error) {
+	if l.bindingResolver == nil || l.clusterPolicyName == "" {
+		return contextEntries, nil
+	}
+	namespace, err := jsonContext.Query("request.namespace")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace to resolve policy binding: %w", err)
+	}
+	ns, _ := namespace.(string)
+	binding, err := l.bindingResolver.Resolve(ctx, l.clusterPolicyName, ns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve policy binding of policy %s in namespace %s: %w", l.clusterPolicyName, ns, err)
+	}
+	if binding == nil {
+		return contextEntries, nil
+	}
+	l.logger.V(4).Info("applying policy binding", "policy", l.clusterPolicyName, "namespace", ns, "binding", binding.Name)
+	return bindEntries(contextEntries, binding.Spec.Variables), nil
+}
+
+// bindEntries returns a copy of the context entries where the variable entries are replaced by the binding variables
+// of the same name, binding variables without a matching entry are added in front of the entries.
+func bindEntries(contextEntries []kyvernov1.ContextEntry, variables []kyvernov2alpha1.PolicyBindingVariable) []kyvernov1.ContextEntry {
+	bound := make([]kyvernov1.ContextEntry, 0, len(variables)+len(contextEntries))
+	declared := map[string]bool{}
+	for _, entry := range contextEntries {
+		if entry.Variable != nil {
+			declared[entry.Name] = true
+		}
+	}
+	for i := range variables {
+		if !declared[variables[i].Name] {
+			bound = append(bound, bindingEntry(variables[i]))
+		}
+	}
+	for _, entry := range contextEntries {
+		if entry.Variable != nil {
+			for i := range variables {
+				if variables[i].Name == entry.Name {
+					entry = bindingEntry(variables[i])
+					break
 				}
 			}
 		}
+		bound = append(bound, entry)
+	}
+	return bound
+}
+
+func bindingEntry(variable kyvernov2alpha1.PolicyBindingVariable) kyvernov1.ContextEntry {
+	value := variable.Value.DeepCopy()
+	return kyvernov1.ContextEntry{
+		Name: variable.Name,
+		Variable: &kyvernov1.Variable{
+			Value: value,
+		},
 	}
-	return nil
 }
 
 func (l *contextLoader) newLoader(
@@ -108,7 +253,7 @@ func (l *contextLoader) newLoader(
 		}
 	} else if entry.ImageRegistry != nil {
 		if rclientFactory != nil {
-			ldr := loaders.NewImageDataLoader(ctx, l.logger, entry, jsonContext, jp, rclientFactory)
+			ldr := loaders.NewImageDataLoader(ctx, l.logger, entry, jsonContext, jp, rclientFactory, l.ruleLimiter)
 			return enginecontext.NewDeferredLoader(entry.Name, ldr, l.logger)
 		} else {
 			l.logger.Info("disabled loading of ImageRegistry context entry", "name", entry.Name)
@@ -117,6 +262,21 @@ func (l *contextLoader) newLoader(
 	} else if entry.Variable != nil {
 		ldr := loaders.NewVariableLoader(l.logger, entry, jsonContext, jp)
 		return enginecontext.NewDeferredLoader(entry.Name, ldr, l.logger)
+	} else if entry.RelatedResources != nil {
+		lister := l.relatedLister
+		if lister == nil {
+			// fallback to listing resources from the api server when informers are not available
+			if resourceClient, ok := client.(engineapi.ResourceClient); ok && resourceClient != nil {
+				lister, _ = resolvers.NewClientBasedRelatedResourceLister(resourceClient)
+			}
+		}
+		if lister != nil {
+			ldr := loaders.NewRelatedResourcesLoader(ctx, l.logger, entry, jsonContext, jp, lister)
+			return enginecontext.NewDeferredLoader(entry.Name, ldr, l.logger)
+		} else {
+			l.logger.Info("disabled loading of RelatedResources context entry", "name", entry.Name)
+			return nil, nil
+		}
 	}
-	return nil, fmt.Errorf("missing ConfigMap|APICall|ImageRegistry|Variable in context entry %s", entry.Name)
+	return nil, fmt.Errorf("missing ConfigMap|APICall|ImageRegistry|Variable|RelatedResources in context entry %s", entry.Name)
 }
@@ -0,0 +1,43 @@
+package factories
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"gotest.tools/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func Test_bindEntries(t *testing.T) {
+	entries := []kyvernov1.ContextEntry{{
+		Name:     "maxReplicas",
+		Variable: &kyvernov1.Variable{Value: &apiextv1.JSON{Raw: []byte("3")}},
+	}, {
+		Name:    "deployments",
+		APICall: &kyvernov1.APICall{URLPath: "/apis/apps/v1/deployments"},
+	}, {
+		Name:     "team",
+		Variable: &kyvernov1.Variable{JMESPath: "request.namespace"},
+	}}
+	variables := []kyvernov2alpha1.PolicyBindingVariable{{
+		Name:  "maxReplicas",
+		Value: apiextv1.JSON{Raw: []byte("10")},
+	}, {
+		Name:  "tier",
+		Value: apiextv1.JSON{Raw: []byte(`"gold"`)},
+	}}
+	bound := bindEntries(entries, variables)
+	assert.Equal(t, len(bound), 4)
+	// undeclared variables are loaded first
+	assert.Equal(t, bound[0].Name, "tier")
+	assert.Equal(t, string(bound[0].Variable.Value.Raw), `"gold"`)
+	// declared variables are overridden in place
+	assert.Equal(t, bound[1].Name, "maxReplicas")
+	assert.Equal(t, string(bound[1].Variable.Value.Raw), "10")
+	assert.Equal(t, bound[2].Name, "deployments")
+	assert.Equal(t, bound[3].Name, "team")
+	assert.Equal(t, bound[3].Variable.JMESPath, "request.namespace")
+	// the entries of the policy are not modified
+	assert.Equal(t, string(entries[0].Variable.Value.Raw), "3")
+}
@@ -39,10 +39,13 @@ var (
 		config.NewDefaultMetricsConfiguration(),
 		fuzzJp,
 		nil,
+		nil,
 		factories.DefaultRegistryClientFactory(adapters.RegistryClient(regClient), nil),
 		imageverifycache.DisabledImageVerifyCache(),
 		factories.DefaultContextLoaderFactory(nil),
 		nil,
+		nil,
+		nil,
 		"",
 	)
 	initter sync.Once
@@ -56,7 +59,7 @@ func buildFuzzContext(ff *fuzz.ConsumeFuzzer) (*PolicyContext, error) {
 	cpol := &kyverno.ClusterPolicy{}
 	cpol.Spec = cpSpec
 
-	if len(autogen.ComputeRules(cpol)) == 0 {
+	if len(autogen.ComputeRules(cpol, nil)) == 0 {
 		return nil, fmt.Errorf("No rules created")
 	}
 
@@ -123,10 +126,13 @@ func FuzzVerifyImageAndPatchTest(f *testing.F) {
 			fuzzMetricsCfg,
 			fuzzJp,
 			nil,
+			nil,
 			factories.DefaultRegistryClientFactory(adapters.RegistryClient(registryclient.NewOrDie()), nil),
 			imageverifycache.DisabledImageVerifyCache(),
 			factories.DefaultContextLoaderFactory(nil),
 			nil,
+			nil,
+			nil,
 			"",
 		)
 
@@ -147,7 +153,7 @@ func FuzzEngineValidateTest(f *testing.F) {
 		policy := &kyverno.ClusterPolicy{}
 		policy.Spec = cpSpec
 
-		if len(autogen.ComputeRules(policy)) == 0 {
+		if len(autogen.ComputeRules(policy, nil)) == 0 {
 			return
 		}
 
@@ -244,7 +250,7 @@ func FuzzMutateTest(f *testing.F) {
 		policy := &kyverno.ClusterPolicy{}
 		policy.Spec = cpSpec
 
-		if len(autogen.ComputeRules(policy)) == 0 {
+		if len(autogen.ComputeRules(policy, nil)) == 0 {
 			return
 		}
 
@@ -269,11 +275,14 @@ func FuzzMutateTest(f *testing.F) {
 			fuzzCfg,
 			config.NewDefaultMetricsConfiguration(),
 			fuzzJp,
+			nil,
 			adapters.Client(fuzzInterface),
 			factories.DefaultRegistryClientFactory(adapters.RegistryClient(nil), nil),
 			imageverifycache.DisabledImageVerifyCache(),
 			factories.DefaultContextLoaderFactory(nil),
 			nil,
+			nil,
+			nil,
 			"",
 		)
 		e.Mutate(
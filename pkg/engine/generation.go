@@ -16,9 +16,9 @@ func (e *engine) generateResponse(
 	policyContext engineapi.PolicyContext,
 ) engineapi.PolicyResponse {
 	resp := engineapi.NewPolicyResponse()
-	for _, rule := range autogen.ComputeRules(policyContext.Policy()) {
+	for _, rule := range autogen.ComputeRules(policyContext.Policy(), e.configuration.GetAutogenControllers()) {
 		logger := internal.LoggerWithRule(logger, rule)
-		if ruleResp := e.filterRule(rule, logger, policyContext); ruleResp != nil {
+		if ruleResp := e.filterRule(ctx, rule, logger, policyContext); ruleResp != nil {
 			resp.Rules = append(resp.Rules, *ruleResp)
 		}
 	}
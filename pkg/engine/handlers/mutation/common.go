@@ -46,7 +46,7 @@ func (f *forEachMutator) mutateForEach(ctx context.Context) *mutate.Response {
 			if mutateResp.Status == engineapi.RuleStatusPass {
 				f.resource.unstructured = mutateResp.PatchedResource
 			}
-			f.logger.Info("mutateResp.PatchedResource", "resource", mutateResp.PatchedResource)
+			f.logger.V(6).Info("mutateResp.PatchedResource", "resource", mutateResp.PatchedResource)
 			if err := f.policyContext.JSONContext().AddResource(mutateResp.PatchedResource.Object); err != nil {
 				f.logger.Error(err, "failed to update resource in context")
 			}
@@ -127,7 +127,7 @@ func (f *forEachMutator) mutateElements(ctx context.Context, foreach kyvernov1.F
 
 			mutateResp = m.mutateForEach(ctx)
 		} else {
-			mutateResp = mutate.ForEach(f.rule.Name, foreach, policyContext, patchedResource.unstructured, element, f.logger)
+			mutateResp = mutate.ForEach(f.rule.Name, foreach, policyContext, patchedResource.unstructured, element, isReinvoked(policyContext), f.logger)
 		}
 
 		if mutateResp.Status == engineapi.RuleStatusFail || mutateResp.Status == engineapi.RuleStatusError {
@@ -174,3 +174,10 @@ func buildSuccessMessage(r unstructured.Unstructured) string {
 	}
 	return fmt.Sprintf("mutated %s/%s in namespace %s", r.GetKind(), r.GetName(), r.GetNamespace())
 }
+
+// isReinvoked returns true if the policy is applied again when the mutating webhook is reinvoked,
+// its rules must then skip the patches already applied
+func isReinvoked(policyContext engineapi.PolicyContext) bool {
+	policy := policyContext.Policy()
+	return policy != nil && policy.GetSpec().GetReinvocationPolicy() == kyvernov1.IfNeededReinvocationPolicy
+}
@@ -11,20 +11,24 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/internal"
 	"github.com/kyverno/kyverno/pkg/engine/mutate"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	matchutils "github.com/kyverno/kyverno/pkg/utils/match"
 	stringutils "github.com/kyverno/kyverno/pkg/utils/strings"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/tools/cache"
 )
 
 type mutateExistingHandler struct {
-	client engineapi.Client
+	client        engineapi.Client
+	ownerResolver matchutils.OwnerResolver
 }
 
 func NewMutateExistingHandler(
 	client engineapi.Client,
+	ownerResolver matchutils.OwnerResolver,
 ) (handlers.Handler, error) {
 	return mutateExistingHandler{
-		client: client,
+		client:        client,
+		ownerResolver: ownerResolver,
 	}, nil
 }
 
@@ -38,7 +42,7 @@ func (h mutateExistingHandler) Process(
 	exceptions []kyvernov2.PolicyException,
 ) (unstructured.Unstructured, []engineapi.RuleResponse) {
 	// check if there is a policy exception matches the incoming resource
-	exception := engineutils.MatchesException(exceptions, policyContext, logger)
+	exception := engineutils.MatchesException(ctx, h.ownerResolver, exceptions, policyContext, logger)
 	if exception != nil {
 		key, err := cache.MetaNamespaceKeyFunc(exception)
 		if err != nil {
@@ -103,7 +107,7 @@ func (h mutateExistingHandler) Process(
 			}
 			mutateResp = m.mutateForEach(ctx)
 		} else {
-			mutateResp = mutate.Mutate(&rule, policyContext.JSONContext(), target.unstructured, logger)
+			mutateResp = mutate.Mutate(&rule, policyContext.JSONContext(), target.unstructured, isReinvoked(policyContext), logger)
 		}
 		if ruleResponse := buildRuleResponse(&rule, mutateResp, target.resourceInfo); ruleResponse != nil {
 			responses = append(responses, *ruleResponse)
@@ -3,7 +3,6 @@ package mutation
 import (
 	"context"
 
-	json_patch "github.com/evanphx/json-patch/v5"
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
@@ -12,12 +11,12 @@ import (
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/handlers"
 	"github.com/kyverno/kyverno/pkg/engine/internal"
-	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
 	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+	matchutils "github.com/kyverno/kyverno/pkg/utils/match"
 	"gomodules.xyz/jsonpatch/v2"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/tools/cache"
@@ -30,6 +29,7 @@ type mutateImageHandler struct {
 	ivm                      *engineapi.ImageVerificationMetadata
 	images                   []apiutils.ImageInfo
 	imageSignatureRepository string
+	ownerResolver            matchutils.OwnerResolver
 }
 
 func NewMutateImageHandler(
@@ -41,6 +41,7 @@ func NewMutateImageHandler(
 	ivCache imageverifycache.Client,
 	ivm *engineapi.ImageVerificationMetadata,
 	imageSignatureRepository string,
+	ownerResolver matchutils.OwnerResolver,
 ) (handlers.Handler, error) {
 	if len(rule.VerifyImages) == 0 {
 		return nil, nil
@@ -59,6 +60,7 @@ func NewMutateImageHandler(
 		ivCache:                  ivCache,
 		images:                   ruleImages,
 		imageSignatureRepository: imageSignatureRepository,
+		ownerResolver:            ownerResolver,
 	}, nil
 }
 
@@ -72,7 +74,7 @@ func (h mutateImageHandler) Process(
 	exceptions []kyvernov2.PolicyException,
 ) (unstructured.Unstructured, []engineapi.RuleResponse) {
 	// check if there is a policy exception matches the incoming resource
-	exception := engineutils.MatchesException(exceptions, policyContext, logger)
+	exception := engineutils.MatchesException(ctx, h.ownerResolver, exceptions, policyContext, logger)
 	if exception != nil {
 		key, err := cache.MetaNamespaceKeyFunc(exception)
 		if err != nil {
@@ -108,21 +110,17 @@ func (h mutateImageHandler) Process(
 		engineResponses = append(engineResponses, ruleResponse...)
 	}
 	if len(patches) != 0 {
-		patch := jsonutils.JoinPatches(patch.ConvertPatches(patches...)...)
-		decoded, err := json_patch.DecodePatch(patch)
-		if err != nil {
-			return resource, handlers.WithResponses(
-				engineapi.RuleError(rule.Name, engineapi.ImageVerify, "failed to decode patch", err),
-			)
+		builder := jsonutils.NewPatchBuilder()
+		for _, patch := range patches {
+			builder.Operation(patch.Operation, patch.Path, patch.Value)
 		}
-		options := &json_patch.ApplyOptions{SupportNegativeIndices: true, AllowMissingPathOnRemove: true, EnsurePathExistsOnAdd: true}
 		resourceBytes, err := resource.MarshalJSON()
 		if err != nil {
 			return resource, handlers.WithResponses(
 				engineapi.RuleError(rule.Name, engineapi.ImageVerify, "failed to marshal resource", err),
 			)
 		}
-		patchedResourceBytes, err := decoded.ApplyWithOptions(resourceBytes, options)
+		patchedResourceBytes, err := builder.Apply(resourceBytes)
 		if err != nil {
 			return resource, handlers.WithResponses(
 				engineapi.RuleError(rule.Name, engineapi.ImageVerify, "failed to apply patch", err),
@@ -10,15 +10,20 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/handlers"
 	"github.com/kyverno/kyverno/pkg/engine/mutate"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	matchutils "github.com/kyverno/kyverno/pkg/utils/match"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/tools/cache"
 )
 
-type mutateResourceHandler struct{}
+type mutateResourceHandler struct {
+	ownerResolver matchutils.OwnerResolver
+}
 
-func NewMutateResourceHandler() (handlers.Handler, error) {
-	return mutateResourceHandler{}, nil
+func NewMutateResourceHandler(ownerResolver matchutils.OwnerResolver) (handlers.Handler, error) {
+	return mutateResourceHandler{
+		ownerResolver: ownerResolver,
+	}, nil
 }
 
 func (h mutateResourceHandler) Process(
@@ -31,7 +36,7 @@ func (h mutateResourceHandler) Process(
 	exceptions []kyvernov2.PolicyException,
 ) (unstructured.Unstructured, []engineapi.RuleResponse) {
 	// check if there is a policy exception matches the incoming resource
-	exception := engineutils.MatchesException(exceptions, policyContext, logger)
+	exception := engineutils.MatchesException(ctx, h.ownerResolver, exceptions, policyContext, logger)
 	if exception != nil {
 		key, err := cache.MetaNamespaceKeyFunc(exception)
 		if err != nil {
@@ -70,7 +75,7 @@ func (h mutateResourceHandler) Process(
 		}
 		mutateResp = m.mutateForEach(ctx)
 	} else {
-		mutateResp = mutate.Mutate(&rule, policyContext.JSONContext(), resource, logger)
+		mutateResp = mutate.Mutate(&rule, policyContext.JSONContext(), resource, isReinvoked(policyContext), logger)
 	}
 	if mutateResp == nil {
 		return resource, nil
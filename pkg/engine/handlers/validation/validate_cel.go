@@ -8,10 +8,12 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/compiled"
 	"github.com/kyverno/kyverno/pkg/engine/handlers"
 	"github.com/kyverno/kyverno/pkg/engine/internal"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
 	celutils "github.com/kyverno/kyverno/pkg/utils/cel"
+	matchutils "github.com/kyverno/kyverno/pkg/utils/match"
 	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,20 +21,22 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apiserver/pkg/admission"
-	"k8s.io/apiserver/pkg/admission/plugin/cel"
 	"k8s.io/apiserver/pkg/admission/plugin/validatingadmissionpolicy"
-	"k8s.io/apiserver/pkg/admission/plugin/webhook/matchconditions"
 	celconfig "k8s.io/apiserver/pkg/apis/cel"
 	"k8s.io/client-go/tools/cache"
 )
 
 type validateCELHandler struct {
-	client engineapi.Client
+	client        engineapi.Client
+	compiledCache compiled.Cache
+	ownerResolver matchutils.OwnerResolver
 }
 
-func NewValidateCELHandler(client engineapi.Client) (handlers.Handler, error) {
+func NewValidateCELHandler(client engineapi.Client, compiledCache compiled.Cache, ownerResolver matchutils.OwnerResolver) (handlers.Handler, error) {
 	return validateCELHandler{
-		client: client,
+		client:        client,
+		compiledCache: compiledCache,
+		ownerResolver: ownerResolver,
 	}, nil
 }
 
@@ -51,7 +55,7 @@ func (h validateCELHandler) Process(
 	}
 
 	// check if there is a policy exception matches the incoming resource
-	exception := engineutils.MatchesException(exceptions, policyContext, logger)
+	exception := engineutils.MatchesException(ctx, h.ownerResolver, exceptions, policyContext, logger)
 	if exception != nil {
 		key, err := cache.MetaNamespaceKeyFunc(exception)
 		if err != nil {
@@ -93,37 +97,21 @@ func (h validateCELHandler) Process(
 
 	// check if the rule uses parameter resources
 	hasParam := rule.Validation.CEL.HasParam()
-	// extract preconditions written as CEL expressions
-	matchConditions := rule.CELPreconditions
-	// extract CEL expressions used in validations and audit annotations
-	variables := rule.Validation.CEL.Variables
-	validations := rule.Validation.CEL.Expressions
-	for i := range validations {
-		if validations[i].Message == "" {
-			validations[i].Message = rule.Validation.Message
-		}
+	// use the validator compiled when the policy was synced, compile the CEL expressions otherwise
+	var validator validatingadmissionpolicy.Validator
+	if h.compiledCache != nil {
+		validator, _ = h.compiledCache.Validator(policyContext.Policy(), rule.Name)
 	}
-	auditAnnotations := rule.Validation.CEL.AuditAnnotations
-
-	optionalVars := cel.OptionalVariableDeclarations{HasParams: hasParam, HasAuthorizer: true}
-	expressionOptionalVars := cel.OptionalVariableDeclarations{HasParams: hasParam, HasAuthorizer: false}
-	// compile CEL expressions
-	compiler, err := celutils.NewCompiler(validations, auditAnnotations, matchConditions, variables)
-	if err != nil {
-		return resource, handlers.WithError(rule, engineapi.Validation, "Error while creating composited compiler", err)
+	if validator == nil {
+		newValidator, err := celutils.NewValidator(policyKind, policyName, rule)
+		if err != nil {
+			return resource, handlers.WithError(rule, engineapi.Validation, "Error while creating composited compiler", err)
+		}
+		validator = newValidator
 	}
-	compiler.CompileVariables(optionalVars)
-	filter := compiler.CompileValidateExpressions(optionalVars)
-	messageExpressionfilter := compiler.CompileMessageExpressions(expressionOptionalVars)
-	auditAnnotationFilter := compiler.CompileAuditAnnotationsExpressions(optionalVars)
-	matchConditionFilter := compiler.CompileMatchExpressions(optionalVars)
-
-	// newMatcher will be used to check if the incoming resource matches the CEL preconditions
-	newMatcher := matchconditions.NewMatcher(matchConditionFilter, nil, policyKind, "", policyName)
-	// newValidator will be used to validate CEL expressions against the incoming object
-	validator := validatingadmissionpolicy.NewValidator(filter, newMatcher, auditAnnotationFilter, messageExpressionfilter, nil)
 
 	var namespace *corev1.Namespace
+	var err error
 	// Special case, the namespace object has the namespace of itself.
 	// unset it if the incoming object is a namespace
 	if gvk.Kind == "Namespace" && gvk.Version == "v1" && gvk.Group == "" {
@@ -12,17 +12,21 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/handlers"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
 	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
+	matchutils "github.com/kyverno/kyverno/pkg/utils/match"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/tools/cache"
 )
 
-type validateImageHandler struct{}
+type validateImageHandler struct {
+	ownerResolver matchutils.OwnerResolver
+}
 
 func NewValidateImageHandler(
 	policyContext engineapi.PolicyContext,
 	resource unstructured.Unstructured,
 	rule kyvernov1.Rule,
 	configuration config.Configuration,
+	ownerResolver matchutils.OwnerResolver,
 ) (handlers.Handler, error) {
 	if engineutils.IsDeleteRequest(policyContext) {
 		return nil, nil
@@ -34,7 +38,9 @@ func NewValidateImageHandler(
 	if len(ruleImages) == 0 {
 		return nil, nil
 	}
-	return validateImageHandler{}, nil
+	return validateImageHandler{
+		ownerResolver: ownerResolver,
+	}, nil
 }
 
 func (h validateImageHandler) Process(
@@ -47,7 +53,7 @@ func (h validateImageHandler) Process(
 	exceptions []kyvernov2.PolicyException,
 ) (unstructured.Unstructured, []engineapi.RuleResponse) {
 	// check if there is a policy exception matches the incoming resource
-	exception := engineutils.MatchesException(exceptions, policyContext, logger)
+	exception := engineutils.MatchesException(ctx, h.ownerResolver, exceptions, policyContext, logger)
 	if exception != nil {
 		key, err := cache.MetaNamespaceKeyFunc(exception)
 		if err != nil {
@@ -87,7 +93,7 @@ func validateImage(ctx engineapi.PolicyContext, imageVerify *kyvernov1.ImageVeri
 	image := imageInfo.String()
 	if imageVerify.VerifyDigest && imageInfo.Digest == "" {
 		log.V(2).Info("missing digest", "image", imageInfo.String())
-		return fmt.Errorf("missing digest for %s", image)
+		return fmt.Errorf("missing digest for %s", imageInfo.Display())
 	}
 	newResource := ctx.NewResource()
 	if imageVerify.Required && newResource.Object != nil {
@@ -96,7 +102,7 @@ func validateImage(ctx engineapi.PolicyContext, imageVerify *kyvernov1.ImageVeri
 			return err
 		}
 		if !verified {
-			return fmt.Errorf("unverified image %s", image)
+			return fmt.Errorf("unverified image %s", imageInfo.Display())
 		}
 	}
 	return nil
@@ -22,6 +22,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/internal"
 	engineresources "github.com/kyverno/kyverno/pkg/engine/resources"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	matchutils "github.com/kyverno/kyverno/pkg/utils/match"
 	"github.com/sigstore/k8s-manifest-sigstore/pkg/k8smanifest"
 	"go.uber.org/multierr"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -35,18 +36,21 @@ const (
 )
 
 type validateManifestHandler struct {
-	client engineapi.Client
+	client        engineapi.Client
+	ownerResolver matchutils.OwnerResolver
 }
 
 func NewValidateManifestHandler(
 	policyContext engineapi.PolicyContext,
 	client engineapi.Client,
+	ownerResolver matchutils.OwnerResolver,
 ) (handlers.Handler, error) {
 	if engineutils.IsDeleteRequest(policyContext) {
 		return nil, nil
 	}
 	return validateManifestHandler{
-		client: client,
+		client:        client,
+		ownerResolver: ownerResolver,
 	}, nil
 }
 
@@ -60,7 +64,7 @@ func (h validateManifestHandler) Process(
 	exceptions []kyvernov2.PolicyException,
 ) (unstructured.Unstructured, []engineapi.RuleResponse) {
 	// check if there is a policy exception matches the incoming resource
-	exception := engineutils.MatchesException(exceptions, policyContext, logger)
+	exception := engineutils.MatchesException(ctx, h.ownerResolver, exceptions, policyContext, logger)
 	if exception != nil {
 		key, err := cache.MetaNamespaceKeyFunc(exception)
 		if err != nil {
@@ -12,6 +12,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/handlers"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/pss"
+	matchutils "github.com/kyverno/kyverno/pkg/utils/match"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -20,10 +21,14 @@ import (
 	"k8s.io/client-go/tools/cache"
 )
 
-type validatePssHandler struct{}
+type validatePssHandler struct {
+	ownerResolver matchutils.OwnerResolver
+}
 
-func NewValidatePssHandler() (handlers.Handler, error) {
-	return validatePssHandler{}, nil
+func NewValidatePssHandler(ownerResolver matchutils.OwnerResolver) (handlers.Handler, error) {
+	return validatePssHandler{
+		ownerResolver: ownerResolver,
+	}, nil
 }
 
 func (h validatePssHandler) Process(
@@ -36,7 +41,7 @@ func (h validatePssHandler) Process(
 	exceptions []kyvernov2.PolicyException,
 ) (unstructured.Unstructured, []engineapi.RuleResponse) {
 	// check if there is a policy exception matches the incoming resource
-	exception := engineutils.MatchesException(exceptions, policyContext, logger)
+	exception := engineutils.MatchesException(ctx, h.ownerResolver, exceptions, policyContext, logger)
 	if exception != nil {
 		key, err := cache.MetaNamespaceKeyFunc(exception)
 		if err != nil {
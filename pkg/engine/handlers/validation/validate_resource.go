@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -13,11 +15,14 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/handlers"
 	"github.com/kyverno/kyverno/pkg/engine/internal"
+	"github.com/kyverno/kyverno/pkg/engine/jsonutils"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/engine/validate"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/utils/api"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	"github.com/kyverno/kyverno/pkg/utils/jsonpointer"
+	matchutils "github.com/kyverno/kyverno/pkg/utils/match"
 	stringutils "github.com/kyverno/kyverno/pkg/utils/strings"
 	"github.com/pkg/errors"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
@@ -25,10 +30,14 @@ import (
 	"k8s.io/client-go/tools/cache"
 )
 
-type validateResourceHandler struct{}
+type validateResourceHandler struct {
+	ownerResolver matchutils.OwnerResolver
+}
 
-func NewValidateResourceHandler() (handlers.Handler, error) {
-	return validateResourceHandler{}, nil
+func NewValidateResourceHandler(ownerResolver matchutils.OwnerResolver) (handlers.Handler, error) {
+	return validateResourceHandler{
+		ownerResolver: ownerResolver,
+	}, nil
 }
 
 func (h validateResourceHandler) Process(
@@ -41,7 +50,7 @@ func (h validateResourceHandler) Process(
 	exceptions []kyvernov2.PolicyException,
 ) (unstructured.Unstructured, []engineapi.RuleResponse) {
 	// check if there is a policy exception matches the incoming resource
-	exception := engineutils.MatchesException(exceptions, policyContext, logger)
+	exception := engineutils.MatchesException(ctx, h.ownerResolver, exceptions, policyContext, logger)
 	if exception != nil {
 		key, err := cache.MetaNamespaceKeyFunc(exception)
 		if err != nil {
@@ -269,6 +278,9 @@ func (v *validator) loadContext(ctx context.Context) error {
 }
 
 func (v *validator) validateDeny() *engineapi.RuleResponse {
+	if v.deny.Violations != nil {
+		return v.validateDenyViolations()
+	}
 	if deny, msg, err := internal.CheckDenyPreconditions(v.log, v.policyContext.JSONContext(), v.deny.GetAnyAllConditions()); err != nil {
 		return engineapi.RuleError(v.rule.Name, engineapi.Validation, "failed to check deny conditions", err)
 	} else {
@@ -279,6 +291,75 @@ func (v *validator) validateDeny() *engineapi.RuleResponse {
 	}
 }
 
+// validateDenyViolations fails the rule when the deny conditions (if any) are met or when some elements
+// of the list violate the rule, violations are attached to the rule response.
+func (v *validator) validateDenyViolations() *engineapi.RuleResponse {
+	var deny bool
+	var msg string
+	if v.deny.RawAnyAllConditions != nil {
+		var err error
+		if deny, msg, err = internal.CheckDenyPreconditions(v.log, v.policyContext.JSONContext(), v.deny.GetAnyAllConditions()); err != nil {
+			return engineapi.RuleError(v.rule.Name, engineapi.Validation, "failed to check deny conditions", err)
+		}
+	}
+	violations, err := v.findDenyViolations(v.deny.Violations)
+	if err != nil {
+		return engineapi.RuleError(v.rule.Name, engineapi.Validation, "failed to check deny violations", err)
+	}
+	if !deny && len(violations) == 0 {
+		return engineapi.RulePass(v.rule.Name, engineapi.Validation, v.getDenyMessage(false, msg))
+	}
+	reasons := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		reasons = append(reasons, violation.String())
+	}
+	msg = stringutils.JoinNonEmpty([]string{msg, strings.Join(reasons, ", ")}, "; ")
+	return engineapi.RuleFail(v.rule.Name, engineapi.Validation, v.getDenyMessage(true, msg)).WithViolations(violations)
+}
+
+// findDenyViolations evaluates the violation conditions for each element of the list.
+func (v *validator) findDenyViolations(violations *kyvernov1.DenyViolations) ([]engineapi.Violation, error) {
+	jsonContext := v.policyContext.JSONContext()
+	elements, err := engineutils.EvaluateList(violations.List, jsonContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate list %s: %w", violations.List, err)
+	}
+	pointer, hasPointer := listPointer(violations.List)
+	jsonContext.Checkpoint()
+	defer jsonContext.Restore()
+	var result []engineapi.Violation
+	for index, element := range elements {
+		jsonContext.Reset()
+		data, err := jsonutils.DocumentToUntyped(element)
+		if err != nil {
+			return nil, err
+		}
+		if err := jsonContext.AddElement(data, index, v.nesting); err != nil {
+			return nil, fmt.Errorf("failed to add element to context: %w", err)
+		}
+		matched, _, err := internal.CheckDenyPreconditions(v.log, jsonContext, violations.GetAnyAllConditions())
+		if err != nil {
+			return nil, err
+		}
+		if !matched {
+			continue
+		}
+		violation := engineapi.Violation{Index: index}
+		if hasPointer {
+			violation.Pointer = "/" + pointer.Append(strconv.Itoa(index)).String()
+		}
+		if violations.Reason != "" {
+			reason, err := variables.SubstituteAll(v.log, jsonContext, violations.Reason)
+			if err != nil {
+				return nil, fmt.Errorf("failed to substitute variables in reason: %w", err)
+			}
+			violation.Reason = fmt.Sprint(reason)
+		}
+		result = append(result, violation)
+	}
+	return result, nil
+}
+
 func (v *validator) getDenyMessage(deny bool, msg string) string {
 	if !deny {
 		return fmt.Sprintf("validation rule '%s' passed.", v.rule.Name)
@@ -477,3 +558,14 @@ func (v *validator) substitutePatterns() error {
 
 	return nil
 }
+
+var resourcePath = regexp.MustCompile(`^request\.object((\.[A-Za-z_][A-Za-z0-9_]*)*)$`)
+
+// listPointer returns the JSON pointer of a list when its JMESPath expression is a path of the resource.
+func listPointer(list string) (jsonpointer.Pointer, bool) {
+	match := resourcePath.FindStringSubmatch(strings.TrimSpace(list))
+	if match == nil {
+		return nil, false
+	}
+	return jsonpointer.Parse(strings.ReplaceAll(match[1], ".", "/")), true
+}
@@ -0,0 +1,98 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/handlers"
+	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/kyverno/kyverno/pkg/engine/wasm"
+	matchutils "github.com/kyverno/kyverno/pkg/utils/match"
+	stringutils "github.com/kyverno/kyverno/pkg/utils/strings"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+)
+
+type validateWASMHandler struct {
+	runtime       wasm.Runtime
+	ownerResolver matchutils.OwnerResolver
+}
+
+func NewValidateWASMHandler(runtime wasm.Runtime, ownerResolver matchutils.OwnerResolver) (handlers.Handler, error) {
+	return validateWASMHandler{
+		runtime:       runtime,
+		ownerResolver: ownerResolver,
+	}, nil
+}
+
+func (h validateWASMHandler) Process(
+	ctx context.Context,
+	logger logr.Logger,
+	policyContext engineapi.PolicyContext,
+	resource unstructured.Unstructured,
+	rule kyvernov1.Rule,
+	_ engineapi.EngineContextLoader,
+	exceptions []kyvernov2.PolicyException,
+) (unstructured.Unstructured, []engineapi.RuleResponse) {
+	// check if there is a policy exception matches the incoming resource
+	exception := engineutils.MatchesException(ctx, h.ownerResolver, exceptions, policyContext, logger)
+	if exception != nil {
+		key, err := cache.MetaNamespaceKeyFunc(exception)
+		if err != nil {
+			logger.Error(err, "failed to compute policy exception key", "namespace", exception.GetNamespace(), "name", exception.GetName())
+			return resource, handlers.WithError(rule, engineapi.Validation, "failed to compute exception key", err)
+		} else {
+			logger.V(3).Info("policy rule skipped due to policy exception", "exception", key)
+			return resource, handlers.WithResponses(
+				engineapi.RuleSkip(rule.Name, engineapi.Validation, "rule skipped due to policy exception "+key).WithException(exception),
+			)
+		}
+	}
+
+	// the module receives the whole rule context, including the request and the context entries of the rule
+	jsonContext, err := policyContext.JSONContext().Query("@")
+	if err != nil {
+		return resource, handlers.WithError(rule, engineapi.Validation, "failed to read the rule context", err)
+	}
+	input := wasm.Input{
+		Resource:    resource.Object,
+		OldResource: policyContext.OldResource().Object,
+		Context:     jsonContext,
+	}
+	module := rule.Validation.WASM
+	result, err := h.runtime.Validate(ctx, module.Module, module.Entrypoint, input)
+	if err != nil {
+		return resource, handlers.WithError(rule, engineapi.Validation, "failed to run the WebAssembly module", err)
+	}
+	if !result.Allowed {
+		return resource, handlers.WithResponses(
+			engineapi.RuleFail(rule.Name, engineapi.Validation, h.buildErrorMessage(logger, policyContext, rule, result.Message)),
+		)
+	}
+	msg := fmt.Sprintf("Validation rule '%s' passed.", rule.Name)
+	return resource, handlers.WithResponses(
+		engineapi.RulePass(rule.Name, engineapi.Validation, msg),
+	)
+}
+
+// buildErrorMessage prefixes the message returned by the module with the message of the rule,
+// variables are only substituted in the message of the rule
+func (h validateWASMHandler) buildErrorMessage(logger logr.Logger, policyContext engineapi.PolicyContext, rule kyvernov1.Rule, msg string) string {
+	ruleMsg := rule.Validation.Message
+	if ruleMsg != "" {
+		if raw, err := variables.SubstituteAll(logger, policyContext.JSONContext(), ruleMsg); err == nil {
+			if typed, ok := raw.(string); ok {
+				ruleMsg = typed
+			}
+		}
+	}
+	if s := stringutils.JoinNonEmpty([]string{ruleMsg, msg}, "; "); s != "" {
+		return s
+	}
+	return fmt.Sprintf("validation error: rule %s failed", rule.Name)
+}
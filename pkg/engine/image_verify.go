@@ -28,7 +28,7 @@ func (e *engine) verifyAndPatchImages(
 	policyContext.JSONContext().Checkpoint()
 	defer policyContext.JSONContext().Restore()
 
-	for _, rule := range autogen.ComputeRules(policy) {
+	for _, rule := range autogen.ComputeRules(policy, e.configuration.GetAutogenControllers()) {
 		startTime := time.Now()
 		logger := internal.LoggerWithRule(logger, rule)
 		handlerFactory := func() (handlers.Handler, error) {
@@ -44,6 +44,7 @@ func (e *engine) verifyAndPatchImages(
 				e.ivCache,
 				&ivm,
 				e.imageSignatureRepository,
+				e.ownerResolver,
 			)
 		}
 		resource, ruleResp := e.invokeRuleHandler(
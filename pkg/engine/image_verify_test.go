@@ -318,10 +318,13 @@ func testVerifyAndPatchImages(
 		metricsCfg,
 		jp,
 		nil,
+		nil,
 		factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), nil),
 		imageverifycache.DisabledImageVerifyCache(),
 		factories.DefaultContextLoaderFactory(cmResolver),
 		nil,
+		nil,
+		nil,
 		"",
 	)
 	return e.VerifyAndPatchImages(
@@ -1071,10 +1074,13 @@ func testImageVerifyCache(
 		metricsCfg,
 		jp,
 		nil,
+		nil,
 		factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), nil),
 		ivCache,
 		factories.DefaultContextLoaderFactory(cmResolver),
 		nil,
+		nil,
+		nil,
 		"",
 	)
 	return e.VerifyAndPatchImages(
@@ -2,10 +2,13 @@ package internal
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
+	"sort"
 	"strings"
 	"time"
 
@@ -26,6 +29,8 @@ import (
 	"go.uber.org/multierr"
 	"gomodules.xyz/jsonpatch/v2"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
 )
 
 type ImageVerifier struct {
@@ -206,6 +211,79 @@ func EvaluateConditions(
 	return variables.EvaluateAnyAllConditions(log, ctx, c)
 }
 
+// CheckClusterMetadata checks the predicate fields declared by an attestation against the name and labels of the
+// cluster, images attested for other clusters or environments are rejected. The check fails when the cluster
+// metadata it relies on is not configured.
+func CheckClusterMetadata(
+	check *kyvernov1.AttestationClusterMetadata,
+	cluster config.ClusterMetadata,
+	ctx enginecontext.Interface,
+	s map[string]interface{},
+) (bool, string, error) {
+	predicate, ok := s["predicate"].(map[string]interface{})
+	if !ok {
+		return false, "", fmt.Errorf("failed to extract predicate from statement: %v", s)
+	}
+	if err := enginecontext.AddJSONObject(ctx, predicate); err != nil {
+		return false, "", fmt.Errorf("failed to add Statement to the context %v: %w", s, err)
+	}
+	if check.ClusterName != "" {
+		if cluster.Name == "" {
+			return false, "", errors.New("the cluster name is not configured in the Kyverno ConfigMap")
+		}
+		if val, msg, err := matchPredicateField(ctx, check.ClusterName, cluster.Name, "cluster"); err != nil || !val {
+			return val, msg, err
+		}
+	}
+	keys := make([]string, 0, len(check.Labels))
+	for key := range check.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value, ok := cluster.Labels[key]
+		if !ok {
+			return false, "", fmt.Errorf("the cluster label %s is not configured in the Kyverno ConfigMap", key)
+		}
+		if val, msg, err := matchPredicateField(ctx, check.Labels[key], value, key); err != nil || !val {
+			return val, msg, err
+		}
+	}
+	return true, "", nil
+}
+
+// matchPredicateField returns true if the predicate field holds a string or a list of strings matching the value
+func matchPredicateField(ctx enginecontext.EvalInterface, field, value, description string) (bool, string, error) {
+	result, err := ctx.Query(field)
+	if err != nil {
+		// missing fields fail the query, the image isn't attested for the cluster
+		return false, fmt.Sprintf("failed to evaluate predicate field %s: %s", field, err), nil
+	}
+	var attested []string
+	switch typed := result.(type) {
+	case nil:
+		return false, fmt.Sprintf("predicate field %s not found", field), nil
+	case string:
+		attested = append(attested, typed)
+	case []interface{}:
+		for _, item := range typed {
+			item, ok := item.(string)
+			if !ok {
+				return false, fmt.Sprintf("predicate field %s must be a string or a list of strings", field), nil
+			}
+			attested = append(attested, item)
+		}
+	default:
+		return false, fmt.Sprintf("predicate field %s must be a string or a list of strings", field), nil
+	}
+	for _, pattern := range attested {
+		if wildcard.Match(pattern, value) {
+			return true, "", nil
+		}
+	}
+	return false, fmt.Sprintf("image is attested for %s %s, not %s", description, strings.Join(attested, ", "), value), nil
+}
+
 // verify applies policy rules to each matching image. The policy rule results and annotation patches are
 // added to tme imageVerifier `resp` and `ivm` fields.
 func (iv *ImageVerifier) Verify(
@@ -230,7 +308,7 @@ func (iv *ImageVerifier) Verify(
 			continue
 		}
 
-		pointer := jsonpointer.ParsePath(imageInfo.Pointer).JMESPath()
+		pointer := jsonpointer.Parse(imageInfo.Pointer).JMESPath()
 		changed, err := iv.policyContext.JSONContext().HasChanged(pointer)
 		if err == nil && !changed {
 			iv.logger.V(4).Info("no change in image, skipping check", "image", image)
@@ -296,6 +374,9 @@ func (iv *ImageVerifier) Verify(
 		if ruleResp != nil {
 			if len(imageVerify.Attestors) > 0 || len(imageVerify.Attestations) > 0 {
 				iv.ivm.Add(image, ruleResp.Status() == engineapi.RuleStatusPass)
+				if imageVerify.StampVerification && ruleResp.Status() == engineapi.RuleStatusPass {
+					iv.ivm.AddVerified(iv.verifiedImage(imageVerify, imageInfo, digest))
+				}
 			}
 			responses = append(responses, ruleResp)
 		}
@@ -303,6 +384,73 @@ func (iv *ImageVerifier) Verify(
 	return patches, responses
 }
 
+// verifiedImage builds the record stamped on the resource for an image verified by the rule.
+func (iv *ImageVerifier) verifiedImage(imageVerify kyvernov1.ImageVerification, imageInfo apiutils.ImageInfo, digest string) engineapi.VerifiedImage {
+	if imageInfo.Digest != "" {
+		digest = imageInfo.Digest
+	}
+	policy, err := cache.MetaNamespaceKeyFunc(iv.policyContext.Policy())
+	if err != nil {
+		policy = iv.policyContext.Policy().GetName()
+	}
+	return engineapi.VerifiedImage{
+		Image:      imageInfo.String(),
+		Digest:     digest,
+		Policy:     policy,
+		Rule:       iv.rule.Name,
+		Attestors:  attestorIdentities(imageVerify),
+		VerifiedAt: time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// attestorIdentities returns the identities of the attestors declared by an image verification rule,
+// key material is identified by its SHA-256 fingerprint.
+func attestorIdentities(imageVerify kyvernov1.ImageVerification) []string {
+	identities := sets.New[string]()
+	var addAttestorSets func(attestorSets []kyvernov1.AttestorSet)
+	addAttestorSets = func(attestorSets []kyvernov1.AttestorSet) {
+		for _, attestorSet := range attestorSets {
+			for _, attestor := range attestorSet.Entries {
+				if attestor.Keys != nil {
+					switch {
+					case attestor.Keys.Secret != nil:
+						identities.Insert(fmt.Sprintf("secret:%s/%s", attestor.Keys.Secret.Namespace, attestor.Keys.Secret.Name))
+					case attestor.Keys.KMS != "":
+						identities.Insert("kms:" + attestor.Keys.KMS)
+					case attestor.Keys.PublicKeys != "":
+						identities.Insert("key:" + fingerprint(attestor.Keys.PublicKeys))
+					}
+				}
+				if attestor.Certificates != nil {
+					if attestor.Certificates.Certificate != "" {
+						identities.Insert("cert:" + fingerprint(attestor.Certificates.Certificate))
+					} else if attestor.Certificates.CertificateChain != "" {
+						identities.Insert("certChain:" + fingerprint(attestor.Certificates.CertificateChain))
+					}
+				}
+				if attestor.Keyless != nil {
+					identities.Insert(fmt.Sprintf("keyless:issuer=%s,subject=%s", attestor.Keyless.Issuer, attestor.Keyless.Subject))
+				}
+				if attestor.Attestor != nil {
+					if nested, err := kyvernov1.AttestorSetUnmarshal(attestor.Attestor); err == nil {
+						addAttestorSets([]kyvernov1.AttestorSet{*nested})
+					}
+				}
+			}
+		}
+	}
+	addAttestorSets(imageVerify.Attestors)
+	for _, attestation := range imageVerify.Attestations {
+		addAttestorSets(attestation.Attestors)
+	}
+	return sets.List(identities)
+}
+
+func fingerprint(data string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(data)))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
 func (iv *ImageVerifier) verifyImage(
 	ctx context.Context,
 	imageVerify kyvernov1.ImageVerification,
@@ -321,7 +469,7 @@ func (iv *ImageVerifier) verifyImage(
 	iv.logger.V(2).Info("verifying image signatures", "image", image, "attestors", len(imageVerify.Attestors), "attestations", len(imageVerify.Attestations))
 	if err := iv.policyContext.JSONContext().AddImageInfo(imageInfo, cfg); err != nil {
 		iv.logger.Error(err, "failed to add image to context")
-		return engineapi.RuleError(iv.rule.Name, engineapi.ImageVerify, fmt.Sprintf("failed to add image to context %s", image), err), ""
+		return engineapi.RuleError(iv.rule.Name, engineapi.ImageVerify, fmt.Sprintf("failed to add image to context %s", imageInfo.Display()), err), ""
 	}
 	if len(imageVerify.Attestors) > 0 {
 		if !matchImageReferences(imageVerify.ImageReferences, image) {
@@ -339,7 +487,7 @@ func (iv *ImageVerifier) verifyImage(
 		}
 	}
 
-	return iv.verifyAttestations(ctx, imageVerify, imageInfo)
+	return iv.verifyAttestations(ctx, imageVerify, imageInfo, cfg.GetClusterMetadata())
 }
 
 func (iv *ImageVerifier) verifyAttestors(
@@ -350,7 +498,6 @@ func (iv *ImageVerifier) verifyAttestors(
 	predicateType string,
 ) (*engineapi.RuleResponse, *images.Response) {
 	var cosignResponse *images.Response
-	image := imageInfo.String()
 	for i, attestorSet := range attestors {
 		var err error
 		path := fmt.Sprintf(".attestors[%d]", i)
@@ -358,13 +505,13 @@ func (iv *ImageVerifier) verifyAttestors(
 		cosignResponse, err = iv.verifyAttestorSet(ctx, attestorSet, imageVerify, imageInfo, path)
 		if err != nil {
 			iv.logger.Error(err, "failed to verify image")
-			return iv.handleRegistryErrors(image, err), nil
+			return iv.handleRegistryErrors(imageInfo.Display(), err), nil
 		}
 	}
 	if cosignResponse == nil {
 		return engineapi.RuleError(iv.rule.Name, engineapi.ImageVerify, "invalid response", fmt.Errorf("nil")), nil
 	}
-	msg := fmt.Sprintf("verified image signatures for %s", image)
+	msg := fmt.Sprintf("verified image signatures for %s", imageInfo.Display())
 	return engineapi.RulePass(iv.rule.Name, engineapi.ImageVerify, msg), cosignResponse
 }
 
@@ -382,6 +529,7 @@ func (iv *ImageVerifier) verifyAttestations(
 	ctx context.Context,
 	imageVerify kyvernov1.ImageVerification,
 	imageInfo apiutils.ImageInfo,
+	cluster config.ClusterMetadata,
 ) (*engineapi.RuleResponse, string) {
 	image := imageInfo.String()
 	for i, attestation := range imageVerify.Attestations {
@@ -413,7 +561,7 @@ func (iv *ImageVerifier) verifyAttestations(
 				cosignResp, err := v.FetchAttestations(ctx, *opts)
 				if err != nil {
 					iv.logger.Error(err, "failed to fetch attestations")
-					return iv.handleRegistryErrors(image, err), ""
+					return iv.handleRegistryErrors(imageInfo.Display(), err), ""
 				}
 
 				if imageInfo.Digest == "" {
@@ -421,7 +569,7 @@ func (iv *ImageVerifier) verifyAttestations(
 					image = imageInfo.String()
 				}
 
-				attestationError = iv.verifyAttestation(cosignResp.Statements, attestation, imageInfo)
+				attestationError = iv.verifyAttestation(cosignResp.Statements, attestation, imageInfo, cluster)
 				if attestationError != nil {
 					attestationError = fmt.Errorf("%s: %w", entryPath+subPath, attestationError)
 					return engineapi.RuleFail(iv.rule.Name, engineapi.ImageVerify, attestationError.Error()), ""
@@ -443,7 +591,7 @@ func (iv *ImageVerifier) verifyAttestations(
 		iv.logger.V(4).Info("attestation checks passed", "path", path, "image", imageInfo.String(), "type", attestation.Type)
 	}
 
-	msg := fmt.Sprintf("verified image attestations for %s", image)
+	msg := fmt.Sprintf("verified image attestations for %s", imageInfo.Display())
 	iv.logger.V(2).Info(msg)
 	return engineapi.RulePass(iv.rule.Name, engineapi.ImageVerify, msg), imageInfo.Digest
 }
@@ -649,7 +797,7 @@ func (iv *ImageVerifier) buildNotaryVerifier(
 	return notary.NewVerifier(), opts, path
 }
 
-func (iv *ImageVerifier) verifyAttestation(statements []map[string]interface{}, attestation kyvernov1.Attestation, imageInfo apiutils.ImageInfo) error {
+func (iv *ImageVerifier) verifyAttestation(statements []map[string]interface{}, attestation kyvernov1.Attestation, imageInfo apiutils.ImageInfo, cluster config.ClusterMetadata) error {
 	if attestation.Type == "" && attestation.PredicateType == "" {
 		return fmt.Errorf("a type is required")
 	}
@@ -663,23 +811,31 @@ func (iv *ImageVerifier) verifyAttestation(statements []map[string]interface{},
 	}
 	for _, s := range statements {
 		iv.logger.Info("checking attestation", "predicates", types, "image", imageInfo.String())
-		val, msg, err := iv.checkAttestations(attestation, s)
+		val, msg, err := iv.checkAttestations(attestation, s, cluster)
 		if err != nil {
 			return fmt.Errorf("failed to check attestations: %w", err)
 		}
 		if !val {
-			return fmt.Errorf("attestation checks failed for %s and predicate %s: %s", imageInfo.String(), attestation.Type, msg)
+			return fmt.Errorf("attestation checks failed for %s and predicate %s: %s", imageInfo.Display(), attestation.Type, msg)
 		}
 	}
 	return nil
 }
 
-func (iv *ImageVerifier) checkAttestations(a kyvernov1.Attestation, s map[string]interface{}) (bool, string, error) {
-	if len(a.Conditions) == 0 {
+func (iv *ImageVerifier) checkAttestations(a kyvernov1.Attestation, s map[string]interface{}, cluster config.ClusterMetadata) (bool, string, error) {
+	if len(a.Conditions) == 0 && a.ClusterMetadata == nil {
 		return true, "", nil
 	}
 	iv.policyContext.JSONContext().Checkpoint()
 	defer iv.policyContext.JSONContext().Restore()
+	if a.ClusterMetadata != nil {
+		if val, msg, err := CheckClusterMetadata(a.ClusterMetadata, cluster, iv.policyContext.JSONContext(), s); err != nil || !val {
+			return val, msg, err
+		}
+	}
+	if len(a.Conditions) == 0 {
+		return true, "", nil
+	}
 	return EvaluateConditions(a.Conditions, iv.policyContext.JSONContext(), s, iv.logger)
 }
 
@@ -32,6 +32,9 @@ func checkResourceFilters(configuration config.Configuration, gvk schema.GroupVe
 			if configuration.ToFilter(gvk, subresource, resource.GetNamespace(), resource.GetName()) {
 				return false
 			}
+			if _, excluded := configuration.MatchExclusionPolicy(gvk, subresource, resource.GetNamespace(), resource.GetName(), resource.GetLabels()); excluded {
+				return false
+			}
 		}
 	}
 	return true
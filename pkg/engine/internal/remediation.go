@@ -0,0 +1,22 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+)
+
+// ResolveRemediation substitutes the variables contained in a rule remediation template.
+func ResolveRemediation(logger logr.Logger, jsonContext enginecontext.EvalInterface, remediation string) (string, error) {
+	raw, err := variables.SubstituteAll(logger, jsonContext, remediation)
+	if err != nil {
+		return "", err
+	}
+	typed, ok := raw.(string)
+	if !ok {
+		return "", fmt.Errorf("remediation didn't resolve to a string: %v", raw)
+	}
+	return typed, nil
+}
@@ -0,0 +1,91 @@
+package jmespath
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+)
+
+// function names
+var (
+	hasChanged = "has_changed"
+)
+
+// jpHasChanged compares the values of request.object and request.oldObject at a JSON pointer.
+// A `*` token matches every key of an object or every item of an array, a missing value only
+// equals a missing value (every value set on creation has changed).
+func jpHasChanged(arguments []interface{}) (interface{}, error) {
+	request, err := validateArg(hasChanged, arguments, 0, reflect.Map)
+	if err != nil {
+		return nil, err
+	}
+	pointer, err := validateArg(hasChanged, arguments, 1, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := parsePointer(pointer.String())
+	if err != nil {
+		return nil, formatError(genericError, hasChanged, err.Error())
+	}
+	values, ok := request.Interface().(map[string]interface{})
+	if !ok {
+		return nil, formatError(invalidArgumentTypeError, hasChanged, 1, "Object")
+	}
+	newValues := map[string]interface{}{}
+	collectPointerValues(values["object"], tokens, "", newValues)
+	oldValues := map[string]interface{}{}
+	collectPointerValues(values["oldObject"], tokens, "", oldValues)
+	return !datautils.DeepEqual(newValues, oldValues), nil
+}
+
+// parsePointer splits a JSON pointer into its unescaped reference tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, errors.New("the JSON pointer must start with /")
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+// collectPointerValues collects the values found at the tokens, keyed by their concrete pointer.
+func collectPointerValues(value interface{}, tokens []string, path string, values map[string]interface{}) {
+	if value == nil {
+		return
+	}
+	if len(tokens) == 0 {
+		values[path] = value
+		return
+	}
+	token, rest := tokens[0], tokens[1:]
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		if token == "*" {
+			for key, item := range typed {
+				collectPointerValues(item, rest, path+"/"+escapePointerToken(key), values)
+			}
+		} else if item, ok := typed[token]; ok {
+			collectPointerValues(item, rest, path+"/"+escapePointerToken(token), values)
+		}
+	case []interface{}:
+		if token == "*" {
+			for i, item := range typed {
+				collectPointerValues(item, rest, path+"/"+strconv.Itoa(i), values)
+			}
+		} else if i, err := strconv.Atoi(token); err == nil && i >= 0 && i < len(typed) {
+			collectPointerValues(typed[i], rest, path+"/"+token, values)
+		}
+	}
+}
+
+func escapePointerToken(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
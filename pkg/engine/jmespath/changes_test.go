@@ -0,0 +1,65 @@
+package jmespath
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_HasChanged(t *testing.T) {
+	const request = `{
+		"object": {
+			"spec": {
+				"replicas": 3,
+				"containers": [{ "name": "nginx", "image": "nginx:1.25" }, { "name": "sidecar", "image": "envoy:1.0" }]
+			},
+			"metadata": { "labels": { "app.kubernetes.io/name": "nginx" } }
+		},
+		"oldObject": {
+			"spec": {
+				"replicas": 3,
+				"containers": [{ "name": "nginx", "image": "nginx:1.24" }, { "name": "sidecar", "image": "envoy:1.0" }]
+			},
+			"metadata": { "labels": { "app.kubernetes.io/name": "nginx" } }
+		}
+	}`
+	tests := []struct {
+		pointer string
+		want    bool
+	}{
+		{pointer: "/spec/replicas", want: false},
+		{pointer: "/spec/containers/0/image", want: true},
+		{pointer: "/spec/containers/1/image", want: false},
+		{pointer: "/spec/containers/*/image", want: true},
+		{pointer: "/spec/containers/*/name", want: false},
+		{pointer: "/metadata/labels/app.kubernetes.io~1name", want: false},
+		{pointer: "/spec/missing", want: false},
+		{pointer: "", want: true},
+	}
+	var data interface{}
+	assert.NilError(t, json.Unmarshal([]byte(request), &data))
+	for _, tt := range tests {
+		t.Run(tt.pointer, func(t *testing.T) {
+			query, err := jmespathInterface.Query("has_changed(@, '" + tt.pointer + "')")
+			assert.NilError(t, err)
+			res, err := query.Search(data)
+			assert.NilError(t, err)
+			assert.Equal(t, res, tt.want)
+		})
+	}
+}
+
+func Test_HasChanged_Create(t *testing.T) {
+	var data interface{}
+	assert.NilError(t, json.Unmarshal([]byte(`{"object": {"spec": {"replicas": 3}}, "oldObject": null}`), &data))
+	query, err := jmespathInterface.Query("has_changed(@, '/spec/replicas')")
+	assert.NilError(t, err)
+	res, err := query.Search(data)
+	assert.NilError(t, err)
+	assert.Equal(t, res, true)
+	query, err = jmespathInterface.Query("has_changed(@, 'spec/replicas')")
+	assert.NilError(t, err)
+	_, err = query.Search(data)
+	assert.ErrorContains(t, err, "must start with /")
+}
@@ -187,7 +187,7 @@ func GetFunctions(configuration config.Configuration) []FunctionEntry {
 				{Types: []jpType{jpString, jpNumber}},
 				{Types: []jpType{jpString, jpNumber}},
 			},
-			Handler: jpRegexReplaceAll,
+			Handler: jpRegexReplaceAll(regexp.Compile),
 		},
 		ReturnType: []jpType{jpString},
 		Note:       "converts all parameters to string",
@@ -199,7 +199,7 @@ func GetFunctions(configuration config.Configuration) []FunctionEntry {
 				{Types: []jpType{jpString, jpNumber}},
 				{Types: []jpType{jpString, jpNumber}},
 			},
-			Handler: jpRegexReplaceAllLiteral,
+			Handler: jpRegexReplaceAllLiteral(regexp.Compile),
 		},
 		ReturnType: []jpType{jpString},
 		Note:       "converts all parameters to string",
@@ -210,7 +210,7 @@ func GetFunctions(configuration config.Configuration) []FunctionEntry {
 				{Types: []jpType{jpString}},
 				{Types: []jpType{jpString, jpNumber}},
 			},
-			Handler: jpRegexMatch,
+			Handler: jpRegexMatch(regexp.Compile),
 		},
 		ReturnType: []jpType{jpBool},
 		Note:       "first string is the regular exression which is compared with second input which can be a number or string",
@@ -593,6 +593,51 @@ func GetFunctions(configuration config.Configuration) []FunctionEntry {
 		},
 		ReturnType: []jpType{jpBool},
 		Note:       "determine if a URL points to an external network address",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: nodeSelectorTerms,
+			Arguments: []argSpec{
+				{Types: []jpType{jpObject}},
+			},
+			Handler: jpNodeSelectorTerms,
+		},
+		ReturnType: []jpType{jpArray},
+		Note:       "normalizes the nodeSelector and required node affinity of a pod, a pod controller or their spec into a list of alternative terms, each term being a list of merged and sorted node selector requirements (matchFields requirements use `field` instead of `key`)",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: nodeSelectorRequires,
+			Arguments: []argSpec{
+				{Types: []jpType{jpObject}},
+				{Types: []jpType{jpString}},
+				{Types: []jpType{jpArrayString}},
+			},
+			Handler: jpNodeSelectorRequires,
+		},
+		ReturnType: []jpType{jpBool},
+		Note:       "checks if every node a pod, a pod controller or their spec can be scheduled on has the given label set to one of the given values",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: nodeSelectorAllows,
+			Arguments: []argSpec{
+				{Types: []jpType{jpObject}},
+				{Types: []jpType{jpString}},
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpNodeSelectorAllows,
+		},
+		ReturnType: []jpType{jpBool},
+		Note:       "checks if a pod, a pod controller or their spec can be scheduled on a node with the given label value",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: hasChanged,
+			Arguments: []argSpec{
+				{Types: []jpType{jpObject}},
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpHasChanged,
+		},
+		ReturnType: []jpType{jpBool},
+		Note:       "checks if the values at a JSON pointer differ between the object and oldObject of a request, `*` tokens match every key or item (e.g. `has_changed(request, '/spec/containers/*/image')`)",
 	}}
 }
 
@@ -710,7 +755,16 @@ func jpfSplit(arguments []interface{}) (interface{}, error) {
 	return arr, nil
 }
 
-func jpRegexReplaceAll(arguments []interface{}) (interface{}, error) {
+// regexpCompiler returns the compiled regular expression, it lets the regex functions use precompiled expressions.
+type regexpCompiler func(string) (*regexp.Regexp, error)
+
+func jpRegexReplaceAll(compile regexpCompiler) gojmespath.JpFunction {
+	return func(arguments []interface{}) (interface{}, error) {
+		return regexReplaceAllWith(compile, arguments)
+	}
+}
+
+func regexReplaceAllWith(compile regexpCompiler, arguments []interface{}) (interface{}, error) {
 	var err error
 	regex, err := validateArg(regexReplaceAll, arguments, 0, reflect.String)
 	if err != nil {
@@ -727,14 +781,20 @@ func jpRegexReplaceAll(arguments []interface{}) (interface{}, error) {
 		return nil, formatError(invalidArgumentTypeError, regexReplaceAll, 3, "String or Real")
 	}
 
-	reg, err := regexp.Compile(regex.String())
+	reg, err := compile(regex.String())
 	if err != nil {
 		return nil, formatError(genericError, regexReplaceAll, err.Error())
 	}
 	return string(reg.ReplaceAll([]byte(src), []byte(repl))), nil
 }
 
-func jpRegexReplaceAllLiteral(arguments []interface{}) (interface{}, error) {
+func jpRegexReplaceAllLiteral(compile regexpCompiler) gojmespath.JpFunction {
+	return func(arguments []interface{}) (interface{}, error) {
+		return regexReplaceAllLiteralWith(compile, arguments)
+	}
+}
+
+func regexReplaceAllLiteralWith(compile regexpCompiler, arguments []interface{}) (interface{}, error) {
 	var err error
 	regex, err := validateArg(regexReplaceAllLiteral, arguments, 0, reflect.String)
 	if err != nil {
@@ -751,14 +811,20 @@ func jpRegexReplaceAllLiteral(arguments []interface{}) (interface{}, error) {
 		return nil, formatError(invalidArgumentTypeError, regexReplaceAllLiteral, 3, "String or Real")
 	}
 
-	reg, err := regexp.Compile(regex.String())
+	reg, err := compile(regex.String())
 	if err != nil {
 		return nil, formatError(genericError, regexReplaceAllLiteral, err.Error())
 	}
 	return string(reg.ReplaceAllLiteral([]byte(src), []byte(repl))), nil
 }
 
-func jpRegexMatch(arguments []interface{}) (interface{}, error) {
+func jpRegexMatch(compile regexpCompiler) gojmespath.JpFunction {
+	return func(arguments []interface{}) (interface{}, error) {
+		return regexMatchWith(compile, arguments)
+	}
+}
+
+func regexMatchWith(compile regexpCompiler, arguments []interface{}) (interface{}, error) {
 	var err error
 	regex, err := validateArg(regexMatch, arguments, 0, reflect.String)
 	if err != nil {
@@ -770,7 +836,11 @@ func jpRegexMatch(arguments []interface{}) (interface{}, error) {
 		return nil, formatError(invalidArgumentTypeError, regexMatch, 2, "String or Real")
 	}
 
-	return regexp.Match(regex.String(), []byte(src))
+	reg, err := compile(regex.String())
+	if err != nil {
+		return nil, err
+	}
+	return reg.MatchString(src), nil
 }
 
 func jpPatternMatch(arguments []interface{}) (interface{}, error) {
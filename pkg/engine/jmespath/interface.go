@@ -1,6 +1,8 @@
 package jmespath
 
 import (
+	"regexp"
+
 	gojmespath "github.com/kyverno/go-jmespath"
 	"github.com/kyverno/kyverno/pkg/config"
 )
@@ -14,18 +16,40 @@ type Interface interface {
 	Search(string, interface{}) (interface{}, error)
 }
 
+// Precompiled provides the queries and regular expressions compiled ahead of time,
+// expressions that were not precompiled are compiled when they are evaluated.
+type Precompiled interface {
+	JMESPath(string) (*gojmespath.JMESPath, bool)
+	Regexp(string) (*regexp.Regexp, bool)
+}
+
 type implementation struct {
 	functionCaller *gojmespath.FunctionCaller
+	precompiled    Precompiled
 }
 
 func New(configuration config.Configuration) Interface {
-	return newImplementation(configuration)
+	return newImplementation(configuration, nil)
+}
+
+func NewWithPrecompiled(configuration config.Configuration, precompiled Precompiled) Interface {
+	return newImplementation(configuration, precompiled)
 }
 
 func (i implementation) Query(query string) (Query, error) {
+	if i.precompiled != nil {
+		if jmesPath, ok := i.precompiled.JMESPath(query); ok {
+			return &QueryProxy{jmesPath, i.functionCaller}, nil
+		}
+	}
 	return newJMESPath(query, i.functionCaller)
 }
 
 func (i implementation) Search(query string, data interface{}) (interface{}, error) {
+	if i.precompiled != nil {
+		if jmesPath, ok := i.precompiled.JMESPath(query); ok {
+			return jmesPath.Search(data, gojmespath.WithFunctionCaller(i.functionCaller))
+		}
+	}
 	return newExecution(i.functionCaller, query, data)
 }
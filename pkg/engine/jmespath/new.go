@@ -1,6 +1,8 @@
 package jmespath
 
 import (
+	"regexp"
+
 	gojmespath "github.com/kyverno/go-jmespath"
 	"github.com/kyverno/kyverno/pkg/config"
 )
@@ -25,16 +27,41 @@ func newJMESPath(query string, functionCaller *gojmespath.FunctionCaller) (*Quer
 	}, nil
 }
 
-func newImplementation(configuration config.Configuration) Interface {
+func newImplementation(configuration config.Configuration, precompiled Precompiled) Interface {
 	functionCaller := gojmespath.NewFunctionCaller()
 	functions := GetFunctions(configuration)
+	if precompiled != nil {
+		functions = withPrecompiledRegexps(functions, precompiled)
+	}
 	for _, f := range functions {
 		functionCaller.Register(f.FunctionEntry)
 	}
 
 	return implementation{
 		functionCaller,
+		precompiled,
+	}
+}
+
+// withPrecompiledRegexps makes the regex functions use the precompiled regular expressions when available.
+func withPrecompiledRegexps(functions []FunctionEntry, precompiled Precompiled) []FunctionEntry {
+	compile := func(expr string) (*regexp.Regexp, error) {
+		if reg, ok := precompiled.Regexp(expr); ok {
+			return reg, nil
+		}
+		return regexp.Compile(expr)
+	}
+	for i := range functions {
+		switch functions[i].Name {
+		case regexReplaceAll:
+			functions[i].Handler = jpRegexReplaceAll(compile)
+		case regexReplaceAllLiteral:
+			functions[i].Handler = jpRegexReplaceAllLiteral(compile)
+		case regexMatch:
+			functions[i].Handler = jpRegexMatch(compile)
+		}
 	}
+	return functions
 }
 
 func newExecution(fCall *gojmespath.FunctionCaller, query string, data interface{}) (interface{}, error) {
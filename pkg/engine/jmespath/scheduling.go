@@ -0,0 +1,307 @@
+package jmespath
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// function names
+var (
+	nodeSelectorTerms    = "node_selector_terms"
+	nodeSelectorRequires = "node_selector_requires"
+	nodeSelectorAllows   = "node_selector_allows"
+)
+
+// getPodSpecArg converts an argument holding a pod spec, a pod, or a pod controller to a typed pod spec.
+func getPodSpecArg(f string, arguments []interface{}, index int) (*corev1.PodSpec, error) {
+	arg, err := validateArg(f, arguments, index, reflect.Map)
+	if err != nil {
+		return nil, err
+	}
+	object, ok := arg.Interface().(map[string]interface{})
+	if !ok {
+		return nil, formatError(invalidArgumentTypeError, f, index+1, "Object")
+	}
+	object, ok = findPodSpec(object)
+	if !ok {
+		return nil, formatError(genericError, f, "argument doesn't contain a pod spec")
+	}
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	var spec corev1.PodSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, formatError(genericError, f, err.Error())
+	}
+	return &spec, nil
+}
+
+// findPodSpec unwraps the pod spec of a pod (spec), a pod controller (spec.template.spec)
+// or a cronjob (spec.jobTemplate.spec.template.spec), specs of those objects are unwrapped too.
+// An object with a kind but without a spec doesn't contain a pod spec.
+func findPodSpec(object map[string]interface{}) (map[string]interface{}, bool) {
+	if spec, ok := object["spec"].(map[string]interface{}); ok {
+		object = spec
+	} else if _, ok := object["kind"]; ok {
+		return nil, false
+	}
+	if template, ok := object["jobTemplate"]; ok {
+		spec, ok := nestedMap(template, "spec")
+		if !ok {
+			return nil, false
+		}
+		object = spec
+	}
+	if template, ok := object["template"]; ok {
+		return nestedMap(template, "spec")
+	}
+	return object, true
+}
+
+func nestedMap(object interface{}, field string) (map[string]interface{}, bool) {
+	m, ok := object.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	nested, ok := m[field].(map[string]interface{})
+	return nested, ok
+}
+
+// nodeSelectorTerm is a normalized node selector term, with requirements on node labels and on node fields.
+type nodeSelectorTerm struct {
+	labels []corev1.NodeSelectorRequirement
+	fields []corev1.NodeSelectorRequirement
+}
+
+// normalizeNodeSelectorTerms computes the list of alternative node selector terms a pod can be scheduled with.
+// The node selector is merged in every required node affinity term, requirements are merged per key
+// (label requirements and field requirements separately) and sorted so that equivalent constraints produce
+// the same result. A pod without any constraint produces a single empty term.
+func normalizeNodeSelectorTerms(spec *corev1.PodSpec) []nodeSelectorTerm {
+	var base []corev1.NodeSelectorRequirement
+	for key, value := range spec.NodeSelector {
+		base = append(base, corev1.NodeSelectorRequirement{
+			Key:      key,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{value},
+		})
+	}
+	var terms []nodeSelectorTerm
+	if spec.Affinity != nil && spec.Affinity.NodeAffinity != nil && spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		for _, term := range spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+			// an empty term matches no nodes
+			if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+				continue
+			}
+			requirements := append(slices.Clone(base), term.MatchExpressions...)
+			terms = append(terms, nodeSelectorTerm{
+				labels: mergeNodeSelectorRequirements(requirements),
+				fields: mergeNodeSelectorRequirements(term.MatchFields),
+			})
+		}
+	} else {
+		terms = append(terms, nodeSelectorTerm{labels: mergeNodeSelectorRequirements(base)})
+	}
+	return terms
+}
+
+func mergeNodeSelectorRequirements(requirements []corev1.NodeSelectorRequirement) []corev1.NodeSelectorRequirement {
+	type state struct {
+		in           sets.Set[string]
+		notIn        sets.Set[string]
+		exists       bool
+		doesNotExist bool
+		others       []corev1.NodeSelectorRequirement
+	}
+	states := map[string]*state{}
+	for _, requirement := range requirements {
+		s := states[requirement.Key]
+		if s == nil {
+			s = &state{notIn: sets.New[string]()}
+			states[requirement.Key] = s
+		}
+		switch requirement.Operator {
+		case corev1.NodeSelectorOpIn:
+			if s.in == nil {
+				s.in = sets.New(requirement.Values...)
+			} else {
+				s.in = s.in.Intersection(sets.New(requirement.Values...))
+			}
+		case corev1.NodeSelectorOpNotIn:
+			s.notIn.Insert(requirement.Values...)
+		case corev1.NodeSelectorOpExists:
+			s.exists = true
+		case corev1.NodeSelectorOpDoesNotExist:
+			s.doesNotExist = true
+		default:
+			s.others = append(s.others, requirement)
+		}
+	}
+	var out []corev1.NodeSelectorRequirement
+	for key, s := range states {
+		switch {
+		case s.in != nil:
+			values := s.in.Difference(s.notIn)
+			if s.doesNotExist {
+				values = sets.New[string]()
+			}
+			out = append(out, corev1.NodeSelectorRequirement{Key: key, Operator: corev1.NodeSelectorOpIn, Values: sets.List(values)})
+		case s.doesNotExist && s.exists:
+			// unsatisfiable
+			out = append(out, corev1.NodeSelectorRequirement{Key: key, Operator: corev1.NodeSelectorOpIn, Values: []string{}})
+		case s.doesNotExist:
+			out = append(out, corev1.NodeSelectorRequirement{Key: key, Operator: corev1.NodeSelectorOpDoesNotExist})
+		default:
+			if s.exists {
+				out = append(out, corev1.NodeSelectorRequirement{Key: key, Operator: corev1.NodeSelectorOpExists})
+			}
+			if s.notIn.Len() != 0 {
+				out = append(out, corev1.NodeSelectorRequirement{Key: key, Operator: corev1.NodeSelectorOpNotIn, Values: sets.List(s.notIn)})
+			}
+		}
+		out = append(out, s.others...)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Key != out[j].Key {
+			return out[i].Key < out[j].Key
+		}
+		return out[i].Operator < out[j].Operator
+	})
+	return out
+}
+
+// requirementAllows returns true if a node with the given label value satisfies the requirement.
+func requirementAllows(requirement corev1.NodeSelectorRequirement, value string) bool {
+	switch requirement.Operator {
+	case corev1.NodeSelectorOpIn:
+		return slices.Contains(requirement.Values, value)
+	case corev1.NodeSelectorOpNotIn:
+		return !slices.Contains(requirement.Values, value)
+	case corev1.NodeSelectorOpExists:
+		return true
+	case corev1.NodeSelectorOpDoesNotExist:
+		return false
+	case corev1.NodeSelectorOpGt, corev1.NodeSelectorOpLt:
+		if len(requirement.Values) != 1 {
+			return false
+		}
+		actual, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false
+		}
+		bound, err := strconv.ParseInt(requirement.Values[0], 10, 64)
+		if err != nil {
+			return false
+		}
+		if requirement.Operator == corev1.NodeSelectorOpGt {
+			return actual > bound
+		}
+		return actual < bound
+	}
+	return false
+}
+
+func jpNodeSelectorTerms(arguments []interface{}) (interface{}, error) {
+	spec, err := getPodSpecArg(nodeSelectorTerms, arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	terms := normalizeNodeSelectorTerms(spec)
+	out := make([]interface{}, 0, len(terms))
+	for _, term := range terms {
+		requirements := make([]interface{}, 0, len(term.labels)+len(term.fields))
+		for _, requirement := range term.labels {
+			requirements = append(requirements, requirementToMap("key", requirement))
+		}
+		for _, requirement := range term.fields {
+			requirements = append(requirements, requirementToMap("field", requirement))
+		}
+		out = append(out, requirements)
+	}
+	return out, nil
+}
+
+// requirementToMap converts a requirement, the key is stored under `key` for labels and `field` for fields.
+func requirementToMap(keyName string, requirement corev1.NodeSelectorRequirement) map[string]interface{} {
+	values := make([]interface{}, 0, len(requirement.Values))
+	for _, value := range requirement.Values {
+		values = append(values, value)
+	}
+	return map[string]interface{}{
+		keyName:    requirement.Key,
+		"operator": string(requirement.Operator),
+		"values":   values,
+	}
+}
+
+func jpNodeSelectorRequires(arguments []interface{}) (interface{}, error) {
+	spec, err := getPodSpecArg(nodeSelectorRequires, arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	key, err := validateArg(nodeSelectorRequires, arguments, 1, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	values, err := validateArg(nodeSelectorRequires, arguments, 2, reflect.Slice)
+	if err != nil {
+		return nil, err
+	}
+	allowed := sets.New[string]()
+	for i := 0; i < values.Len(); i++ {
+		value, ok := values.Index(i).Interface().(string)
+		if !ok {
+			return nil, formatError(invalidArgumentTypeError, nodeSelectorRequires, 3, "Array of strings")
+		}
+		allowed.Insert(value)
+	}
+	// every term must restrict the key to a subset of the allowed values
+	for _, term := range normalizeNodeSelectorTerms(spec) {
+		restricted := false
+		for _, requirement := range term.labels {
+			if requirement.Key == key.String() && requirement.Operator == corev1.NodeSelectorOpIn {
+				restricted = allowed.IsSuperset(sets.New(requirement.Values...))
+			}
+		}
+		if !restricted {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func jpNodeSelectorAllows(arguments []interface{}) (interface{}, error) {
+	spec, err := getPodSpecArg(nodeSelectorAllows, arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	key, err := validateArg(nodeSelectorAllows, arguments, 1, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	value, err := validateArg(nodeSelectorAllows, arguments, 2, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	// at least one term must accept nodes with the given label value
+	for _, term := range normalizeNodeSelectorTerms(spec) {
+		allowed := true
+		for _, requirement := range term.labels {
+			if requirement.Key == key.String() && !requirementAllows(requirement, value.String()) {
+				allowed = false
+				break
+			}
+		}
+		if allowed {
+			return true, nil
+		}
+	}
+	return false, nil
+}
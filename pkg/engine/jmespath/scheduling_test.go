@@ -0,0 +1,207 @@
+package jmespath
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+const podWithAffinity = `{
+	"spec": {
+		"nodeSelector": { "kubernetes.io/os": "linux" },
+		"affinity": {
+			"nodeAffinity": {
+				"requiredDuringSchedulingIgnoredDuringExecution": {
+					"nodeSelectorTerms": [{
+						"matchExpressions": [
+							{ "key": "kubernetes.io/arch", "operator": "In", "values": ["arm64", "amd64"] },
+							{ "key": "kubernetes.io/arch", "operator": "NotIn", "values": ["arm64"] }
+						]
+					}, {
+						"matchExpressions": [
+							{ "key": "kubernetes.io/arch", "operator": "In", "values": ["amd64"] },
+							{ "key": "pool", "operator": "Exists" }
+						]
+					}]
+				}
+			}
+		}
+	}
+}`
+
+func Test_NodeSelectorTerms(t *testing.T) {
+	var pod interface{}
+	assert.NilError(t, json.Unmarshal([]byte(podWithAffinity), &pod))
+	query, err := jmespathInterface.Query("node_selector_terms(spec)")
+	assert.NilError(t, err)
+	res, err := query.Search(pod)
+	assert.NilError(t, err)
+	expected := []interface{}{
+		[]interface{}{
+			map[string]interface{}{"key": "kubernetes.io/arch", "operator": "In", "values": []interface{}{"amd64"}},
+			map[string]interface{}{"key": "kubernetes.io/os", "operator": "In", "values": []interface{}{"linux"}},
+		},
+		[]interface{}{
+			map[string]interface{}{"key": "kubernetes.io/arch", "operator": "In", "values": []interface{}{"amd64"}},
+			map[string]interface{}{"key": "kubernetes.io/os", "operator": "In", "values": []interface{}{"linux"}},
+			map[string]interface{}{"key": "pool", "operator": "Exists", "values": []interface{}{}},
+		},
+	}
+	assert.DeepEqual(t, expected, res)
+}
+
+func Test_NodeSelectorRequires(t *testing.T) {
+	testCases := []struct {
+		jmesPath       string
+		pod            string
+		expectedResult bool
+	}{{
+		jmesPath:       "node_selector_requires(@, 'kubernetes.io/arch', ['amd64'])",
+		pod:            podWithAffinity,
+		expectedResult: true,
+	}, {
+		jmesPath:       "node_selector_requires(@, 'kubernetes.io/os', ['linux', 'windows'])",
+		pod:            podWithAffinity,
+		expectedResult: true,
+	}, {
+		jmesPath:       "node_selector_requires(@, 'pool', ['default'])",
+		pod:            podWithAffinity,
+		expectedResult: false,
+	}, {
+		jmesPath:       "node_selector_requires(@, 'kubernetes.io/arch', ['amd64'])",
+		pod:            `{ "spec": {} }`,
+		expectedResult: false,
+	}, {
+		jmesPath:       "node_selector_requires(@, 'kubernetes.io/arch', ['amd64'])",
+		pod:            `{ "spec": { "nodeSelector": { "kubernetes.io/arch": "amd64" } } }`,
+		expectedResult: true,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.jmesPath, func(t *testing.T) {
+			var pod interface{}
+			assert.NilError(t, json.Unmarshal([]byte(tc.pod), &pod))
+			query, err := jmespathInterface.Query(tc.jmesPath)
+			assert.NilError(t, err)
+			res, err := query.Search(pod)
+			assert.NilError(t, err)
+			assert.Equal(t, tc.expectedResult, res)
+		})
+	}
+}
+
+func Test_NodeSelectorAllows(t *testing.T) {
+	testCases := []struct {
+		jmesPath       string
+		expectedResult bool
+	}{{
+		jmesPath:       "node_selector_allows(@, 'kubernetes.io/arch', 'amd64')",
+		expectedResult: true,
+	}, {
+		jmesPath:       "node_selector_allows(@, 'kubernetes.io/arch', 'arm64')",
+		expectedResult: false,
+	}, {
+		jmesPath:       "node_selector_allows(@, 'kubernetes.io/os', 'windows')",
+		expectedResult: false,
+	}, {
+		jmesPath:       "node_selector_allows(@, 'pool', 'gpu')",
+		expectedResult: true,
+	}}
+	var pod interface{}
+	assert.NilError(t, json.Unmarshal([]byte(podWithAffinity), &pod))
+	for _, tc := range testCases {
+		t.Run(tc.jmesPath, func(t *testing.T) {
+			query, err := jmespathInterface.Query(tc.jmesPath)
+			assert.NilError(t, err)
+			res, err := query.Search(pod)
+			assert.NilError(t, err)
+			assert.Equal(t, tc.expectedResult, res)
+		})
+	}
+}
+
+func Test_NodeSelectorPodControllers(t *testing.T) {
+	testCases := []struct {
+		name     string
+		resource string
+	}{{
+		name:     "deployment",
+		resource: `{ "kind": "Deployment", "spec": { "template": { "spec": { "nodeSelector": { "kubernetes.io/arch": "amd64" } } } } }`,
+	}, {
+		name:     "deployment spec",
+		resource: `{ "template": { "spec": { "nodeSelector": { "kubernetes.io/arch": "amd64" } } } }`,
+	}, {
+		name:     "cronjob",
+		resource: `{ "kind": "CronJob", "spec": { "jobTemplate": { "spec": { "template": { "spec": { "nodeSelector": { "kubernetes.io/arch": "amd64" } } } } } } }`,
+	}}
+	query, err := jmespathInterface.Query("node_selector_requires(@, 'kubernetes.io/arch', ['amd64'])")
+	assert.NilError(t, err)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var resource interface{}
+			assert.NilError(t, json.Unmarshal([]byte(tc.resource), &resource))
+			res, err := query.Search(resource)
+			assert.NilError(t, err)
+			assert.Equal(t, true, res)
+		})
+	}
+}
+
+func Test_NodeSelectorWithoutPodSpec(t *testing.T) {
+	for _, resource := range []string{
+		`{ "kind": "ConfigMap", "data": { "key": "value" } }`,
+		`{ "kind": "Deployment", "spec": { "template": {} } }`,
+		`{ "kind": "CronJob", "spec": { "jobTemplate": {} } }`,
+	} {
+		t.Run(resource, func(t *testing.T) {
+			var object interface{}
+			assert.NilError(t, json.Unmarshal([]byte(resource), &object))
+			query, err := jmespathInterface.Query("node_selector_terms(@)")
+			assert.NilError(t, err)
+			_, err = query.Search(object)
+			assert.ErrorContains(t, err, "doesn't contain a pod spec")
+		})
+	}
+}
+
+func Test_NodeSelectorMatchFields(t *testing.T) {
+	const pod = `{
+		"spec": {
+			"nodeSelector": { "kubernetes.io/os": "linux" },
+			"affinity": {
+				"nodeAffinity": {
+					"requiredDuringSchedulingIgnoredDuringExecution": {
+						"nodeSelectorTerms": [{
+							"matchFields": [
+								{ "key": "metadata.name", "operator": "In", "values": ["node-b", "node-a"] }
+							]
+						}]
+					}
+				}
+			}
+		}
+	}`
+	var resource interface{}
+	assert.NilError(t, json.Unmarshal([]byte(pod), &resource))
+	query, err := jmespathInterface.Query("node_selector_terms(@)")
+	assert.NilError(t, err)
+	res, err := query.Search(resource)
+	assert.NilError(t, err)
+	expected := []interface{}{
+		[]interface{}{
+			map[string]interface{}{"key": "kubernetes.io/os", "operator": "In", "values": []interface{}{"linux"}},
+			map[string]interface{}{"field": "metadata.name", "operator": "In", "values": []interface{}{"node-a", "node-b"}},
+		},
+	}
+	assert.DeepEqual(t, expected, res)
+	query, err = jmespathInterface.Query("node_selector_requires(@, 'kubernetes.io/os', ['linux'])")
+	assert.NilError(t, err)
+	res, err = query.Search(resource)
+	assert.NilError(t, err)
+	assert.Equal(t, true, res)
+	query, err = jmespathInterface.Query("node_selector_allows(@, 'kubernetes.io/arch', 'arm64')")
+	assert.NilError(t, err)
+	res, err = query.Search(resource)
+	assert.NilError(t, err)
+	assert.Equal(t, true, res)
+}
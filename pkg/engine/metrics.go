@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/metrics"
@@ -19,7 +20,7 @@ func (e *engine) reportMetrics(
 	admissionOperation bool,
 	response engineapi.EngineResponse,
 ) {
-	if e.resultCounter == nil && e.durationHistogram == nil {
+	if e.resultCounter == nil && e.durationHistogram == nil && e.exceptionUsageCounter == nil && e.ruleLatency == nil {
 		return
 	}
 	policy := response.Policy().GetPolicy().(kyvernov1.PolicyInterface)
@@ -32,6 +33,11 @@ func (e *engine) reportMetrics(
 		if !e.metricsConfiguration.CheckNamespace(namespace) {
 			return
 		}
+		annotations := policy.GetAnnotations()
+		severity, category := annotations[kyverno.AnnotationPolicySeverity], annotations[kyverno.AnnotationPolicyCategory]
+		if e.configuration != nil {
+			severity, category = e.configuration.GetSeverity(severity), e.configuration.GetCategory(category)
+		}
 		resourceSpec := response.Resource
 		resourceKind := resourceSpec.GetKind()
 		resourceNamespace := resourceSpec.GetNamespace()
@@ -71,6 +77,8 @@ func (e *engine) reportMetrics(
 					attribute.String("rule_result", string(ruleResult)),
 					attribute.String("rule_type", string(ruleType)),
 					attribute.String("rule_execution_cause", string(executionCause)),
+					attribute.String("policy_severity", severity),
+					attribute.String("policy_category", category),
 				}
 				e.resultCounter.Add(ctx, 1, metric.WithAttributes(commonLabels...))
 			}
@@ -91,6 +99,20 @@ func (e *engine) reportMetrics(
 				}
 				e.durationHistogram.Record(ctx, rule.Stats().ProcessingTime().Seconds(), metric.WithAttributes(commonLabels...))
 			}
+			e.ruleLatency.Record(ctx, namespace, name, ruleName, ruleType, rule.Stats().ProcessingTime().Seconds())
+			// like the exception status, usage is only counted for admission requests
+			if e.exceptionUsageCounter != nil && admissionOperation && rule.IsException() {
+				exception := rule.Exception()
+				commonLabels := []attribute.KeyValue{
+					attribute.String("exception_namespace", exception.GetNamespace()),
+					attribute.String("exception_name", exception.GetName()),
+					attribute.String("policy_namespace", namespace),
+					attribute.String("policy_name", name),
+					attribute.String("rule_name", ruleName),
+					attribute.String("rule_execution_cause", string(executionCause)),
+				}
+				e.exceptionUsageCounter.Add(ctx, 1, metric.WithAttributes(commonLabels...))
+			}
 		}
 	}
 }
@@ -1,9 +1,9 @@
 package mutate
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
@@ -12,6 +12,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -37,68 +38,63 @@ func NewErrorResponse(msg string, err error) *Response {
 	return NewResponse(engineapi.RuleStatusError, unstructured.Unstructured{}, msg)
 }
 
-func Mutate(rule *kyvernov1.Rule, ctx context.Interface, resource unstructured.Unstructured, logger logr.Logger) *Response {
+// Mutate applies a mutate rule to the resource, idempotent rules skip the JSON patch operations already applied
+// to the resource when the mutating webhook is reinvoked.
+func Mutate(rule *kyvernov1.Rule, ctx context.Interface, resource unstructured.Unstructured, idempotent bool, logger logr.Logger) *Response {
 	updatedRule, err := variables.SubstituteAllInRule(logger, ctx, *rule)
 	if err != nil {
 		return NewErrorResponse("variable substitution failed", err)
 	}
 	m := updatedRule.Mutation
-	patcher := NewPatcher(m.GetPatchStrategicMerge(), m.PatchesJSON6902)
+	patcher := newPatcher(m.GetPatchStrategicMerge(), m.PatchesJSON6902, idempotent)
 	if patcher == nil {
 		return NewErrorResponse("empty mutate rule", nil)
 	}
 
-	patchedResource := resource.DeepCopy()
-	resourceBytes, err := patchedResource.MarshalJSON()
-	if err != nil {
-		return NewErrorResponse("failed to marshal resource", err)
-	}
-	patchedBytes, err := patcher.Patch(logger, resourceBytes)
-	if err != nil {
-		return NewErrorResponse("failed to patch resource", err)
-	}
-	if strings.TrimSpace(string(resourceBytes)) == strings.TrimSpace(string(patchedBytes)) {
-		return NewResponse(engineapi.RuleStatusSkip, resource, "no patches applied")
-	}
-	if err := patchedResource.UnmarshalJSON(patchedBytes); err != nil {
-		return NewErrorResponse("failed to unmarshal patched resource", err)
-	}
-	if rule.IsMutateExisting() {
-		if err := ctx.SetTargetResource(patchedResource.Object); err != nil {
+	resp := applyPatcher(patcher, resource, logger)
+	if resp.Status == engineapi.RuleStatusPass && rule.IsMutateExisting() {
+		if err := ctx.SetTargetResource(resp.PatchedResource.Object); err != nil {
 			return NewErrorResponse("failed to update patched target resource in the JSON context", err)
 		}
 	}
-	return NewResponse(engineapi.RuleStatusPass, *patchedResource, "resource patched")
+	return resp
 }
 
-func ForEach(name string, foreach kyvernov1.ForEachMutation, policyContext engineapi.PolicyContext, resource unstructured.Unstructured, element interface{}, logger logr.Logger) *Response {
+func ForEach(name string, foreach kyvernov1.ForEachMutation, policyContext engineapi.PolicyContext, resource unstructured.Unstructured, element interface{}, idempotent bool, logger logr.Logger) *Response {
 	ctx := policyContext.JSONContext()
 	fe, err := substituteAllInForEach(foreach, ctx, logger)
 	if err != nil {
 		return NewErrorResponse("variable substitution failed", err)
 	}
-	patcher := NewPatcher(fe.GetPatchStrategicMerge(), fe.PatchesJSON6902)
+	patcher := newPatcher(fe.GetPatchStrategicMerge(), fe.PatchesJSON6902, idempotent)
 	if patcher == nil {
 		return NewErrorResponse("empty mutate rule", nil)
 	}
 
-	patchedResource := resource.DeepCopy()
-	resourceBytes, err := patchedResource.MarshalJSON()
-	if err != nil {
+	return applyPatcher(patcher, resource, logger)
+}
+
+// applyPatcher patches the resource, the resource is encoded in a pooled buffer and only the patched resource is decoded,
+// a foreach rule calls it for every element and the intermediate documents would otherwise be allocated for each of them.
+func applyPatcher(patcher patch.Patcher, resource unstructured.Unstructured, logger logr.Logger) *Response {
+	buffer := jsonutils.GetBuffer()
+	defer jsonutils.PutBuffer(buffer)
+	if err := json.NewEncoder(buffer).Encode(resource.Object); err != nil {
 		return NewErrorResponse("failed to marshal resource", err)
 	}
+	resourceBytes := bytes.TrimSpace(buffer.Bytes())
 	patchedBytes, err := patcher.Patch(logger, resourceBytes)
 	if err != nil {
 		return NewErrorResponse("failed to patch resource", err)
 	}
-	if strings.TrimSpace(string(resourceBytes)) == strings.TrimSpace(string(patchedBytes)) {
+	if bytes.Equal(resourceBytes, bytes.TrimSpace(patchedBytes)) {
 		return NewResponse(engineapi.RuleStatusSkip, resource, "no patches applied")
 	}
+	var patchedResource unstructured.Unstructured
 	if err := patchedResource.UnmarshalJSON(patchedBytes); err != nil {
 		return NewErrorResponse("failed to unmarshal patched resource", err)
 	}
-
-	return NewResponse(engineapi.RuleStatusPass, *patchedResource, "resource patched")
+	return NewResponse(engineapi.RuleStatusPass, patchedResource, "resource patched")
 }
 
 func substituteAllInForEach(fe kyvernov1.ForEachMutation, ctx context.Interface, logger logr.Logger) (*kyvernov1.ForEachMutation, error) {
@@ -126,10 +122,19 @@ func substituteAllInForEach(fe kyvernov1.ForEachMutation, ctx context.Interface,
 }
 
 func NewPatcher(strategicMergePatch apiextensions.JSON, jsonPatch string) patch.Patcher {
+	return newPatcher(strategicMergePatch, jsonPatch, false)
+}
+
+// newPatcher returns the patcher of a rule, strategic merge patches are idempotent
+// and only JSON patches need to skip the operations already applied
+func newPatcher(strategicMergePatch apiextensions.JSON, jsonPatch string, idempotent bool) patch.Patcher {
 	if strategicMergePatch != nil {
 		return patch.NewPatchStrategicMerge(strategicMergePatch)
 	}
 	if len(jsonPatch) > 0 {
+		if idempotent {
+			return patch.NewIdempotentPatchesJSON6902(jsonPatch)
+		}
 		return patch.NewPatchesJSON6902(jsonPatch)
 	}
 	return nil
@@ -1,7 +1,11 @@
 package patch
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
 
 	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/go-logr/logr"
@@ -29,3 +33,122 @@ func applyPatchesWithOptions(resource, patch []byte) ([]byte, error) {
 	}
 	return patchedResource, nil
 }
+
+// ProcessIdempotentPatchJSON6902 applies the patch operations one by one and skips the `add` operations already applied
+// to the resource, a webhook called again after other admission plugins modified the resource doesn't add twice the same value.
+func ProcessIdempotentPatchJSON6902(logger logr.Logger, patchesJSON6902 []byte, resource resource) (resource, error) {
+	patches, err := jsonpatch.DecodePatch(patchesJSON6902)
+	if err != nil {
+		err = fmt.Errorf("failed to decode patches: %v", err)
+		logger.Error(err, "failed to apply JSON Patch")
+		return nil, err
+	}
+	options := &jsonpatch.ApplyOptions{SupportNegativeIndices: true, AllowMissingPathOnRemove: true, EnsurePathExistsOnAdd: true}
+	for _, operation := range patches {
+		applied, err := isApplied(operation, resource)
+		if err != nil {
+			logger.Error(err, "failed to apply JSON Patch")
+			return nil, err
+		}
+		if applied {
+			continue
+		}
+		resource, err = jsonpatch.Patch{operation}.ApplyWithOptions(resource, options)
+		if err != nil {
+			logger.Error(err, "failed to apply JSON Patch")
+			return nil, err
+		}
+	}
+	return resource, nil
+}
+
+// isApplied returns true if the resource already holds the value added by an `add` operation, at the path
+// of the operation or in the array the value is appended to
+func isApplied(operation jsonpatch.Operation, resource resource) (bool, error) {
+	if operation.Kind() != "add" {
+		return false, nil
+	}
+	path, err := operation.Path()
+	if err != nil {
+		return false, err
+	}
+	value, err := operation.ValueInterface()
+	if err != nil {
+		return false, err
+	}
+	var document interface{}
+	if err := json.Unmarshal(resource, &document); err != nil {
+		return false, err
+	}
+	if parent, ok := strings.CutSuffix(path, "/-"); ok {
+		array, ok := lookup(document, parent).([]interface{})
+		if !ok {
+			return false, nil
+		}
+		for _, element := range array {
+			if reflect.DeepEqual(element, value) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	existing := lookup(document, path)
+	return existing != nil && reflect.DeepEqual(existing, value), nil
+}
+
+// lookup returns the value at a JSON pointer in a document, or nil if the pointer doesn't resolve
+func lookup(document interface{}, pointer string) interface{} {
+	if pointer == "" {
+		return document
+	}
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch typed := document.(type) {
+		case map[string]interface{}:
+			document = typed[token]
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil {
+				return nil
+			}
+			if index < 0 {
+				index += len(typed)
+			}
+			if index < 0 || index >= len(typed) {
+				return nil
+			}
+			document = typed[index]
+		default:
+			return nil
+		}
+	}
+	return document
+}
+
+// CheckIdempotency returns an error if the patch contains operations giving a different result when applied
+// again to the patched resource, `move` and `copy` operations and `remove` operations of array elements.
+func CheckIdempotency(patches string) error {
+	patchesJSON6902, err := convertPatchesToJSON(patches)
+	if err != nil {
+		return err
+	}
+	decoded, err := jsonpatch.DecodePatch(patchesJSON6902)
+	if err != nil {
+		return fmt.Errorf("failed to decode patches: %v", err)
+	}
+	for i, operation := range decoded {
+		switch operation.Kind() {
+		case "move", "copy":
+			return fmt.Errorf("operation %d: %s operations are not idempotent", i, operation.Kind())
+		case "remove":
+			path, err := operation.Path()
+			if err != nil {
+				return fmt.Errorf("operation %d: %v", i, err)
+			}
+			if _, err := strconv.Atoi(path[strings.LastIndex(path, "/")+1:]); err == nil {
+				return fmt.Errorf("operation %d: remove operations of array elements are not idempotent", i)
+			}
+		}
+	}
+	return nil
+}
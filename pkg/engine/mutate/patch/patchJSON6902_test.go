@@ -369,3 +369,74 @@ spec:
 		}
 	}
 }
+
+func Test_ProcessIdempotentPatchJSON6902(t *testing.T) {
+	resource := []byte(`{"spec":{"containers":[{"name":"nginx"}],"tolerations":[{"key":"a"}]}}`)
+	patches := []byte(`[
+		{"op":"add","path":"/spec/tolerations/-","value":{"key":"b"}},
+		{"op":"add","path":"/spec/containers/0","value":{"name":"sidecar"}},
+		{"op":"add","path":"/metadata/labels/app","value":"nginx"},
+		{"op":"replace","path":"/spec/containers/1/name","value":"app"}
+	]`)
+	expected := `{"metadata":{"labels":{"app":"nginx"}},"spec":{"containers":[{"name":"sidecar"},{"name":"app"}],"tolerations":[{"key":"a"},{"key":"b"}]}}`
+	patched, err := ProcessIdempotentPatchJSON6902(logr.Discard(), patches, resource)
+	require.NoError(t, err)
+	assert.JSONEq(t, expected, string(patched))
+	// the reinvoked webhook applies the patches again to the patched resource
+	reinvoked, err := ProcessIdempotentPatchJSON6902(logr.Discard(), patches, patched)
+	require.NoError(t, err)
+	assert.JSONEq(t, expected, string(reinvoked))
+	// the non idempotent patcher appends the values again
+	duplicated, err := ProcessPatchJSON6902(logr.Discard(), patches, patched)
+	require.NoError(t, err)
+	assert.NotEqual(t, expected, string(duplicated))
+}
+
+func Test_CheckIdempotency(t *testing.T) {
+	tests := []struct {
+		name    string
+		patches string
+		err     string
+	}{{
+		name: "add",
+		patches: `
+- op: add
+  path: /spec/tolerations/-
+  value: {"key": "a"}
+`,
+	}, {
+		name: "remove field",
+		patches: `
+- op: remove
+  path: /metadata/labels/app
+`,
+	}, {
+		name: "remove array element",
+		patches: `
+- op: remove
+  path: /spec/containers/0
+`,
+		err: "operation 0: remove operations of array elements are not idempotent",
+	}, {
+		name: "copy",
+		patches: `
+- op: add
+  path: /metadata/labels/app
+  value: nginx
+- op: copy
+  from: /metadata/labels/app
+  path: /metadata/labels/name
+`,
+		err: "operation 1: copy operations are not idempotent",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckIdempotency(tt.patches)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
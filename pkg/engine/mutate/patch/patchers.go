@@ -48,3 +48,25 @@ func (h patchesJSON6902Handler) Patch(logger logr.Logger, resource resource) (re
 	}
 	return ProcessPatchJSON6902(logger, patchesJSON6902, resource)
 }
+
+// idempotentPatchesJSON6902Handler
+type idempotentPatchesJSON6902Handler struct {
+	patches string
+}
+
+// NewIdempotentPatchesJSON6902 returns a patcher skipping the operations already applied to the resource,
+// it is used by the policies applied again when the mutating webhook is reinvoked.
+func NewIdempotentPatchesJSON6902(patches string) Patcher {
+	return idempotentPatchesJSON6902Handler{
+		patches: patches,
+	}
+}
+
+func (h idempotentPatchesJSON6902Handler) Patch(logger logr.Logger, resource resource) (resource, error) {
+	patchesJSON6902, err := convertPatchesToJSON(h.patches)
+	if err != nil {
+		logger.Error(err, "error in type conversion")
+		return nil, err
+	}
+	return ProcessIdempotentPatchJSON6902(logger, patchesJSON6902, resource)
+}
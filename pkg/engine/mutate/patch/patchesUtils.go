@@ -3,6 +3,7 @@ package patch
 import (
 	"fmt"
 
+	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
 	"gomodules.xyz/jsonpatch/v2"
 	"sigs.k8s.io/yaml"
 )
@@ -17,6 +18,13 @@ func ConvertPatches(in ...jsonpatch.JsonPatchOperation) [][]byte {
 	return out
 }
 
+// WritePatches writes the patch operations to the stream, like ConvertPatches operations failing to marshal are dropped.
+func WritePatches(stream *jsonutils.PatchStream, in ...jsonpatch.JsonPatchOperation) {
+	for i := range in {
+		_ = stream.WriteOperation(&in[i])
+	}
+}
+
 func convertPatchesToJSON(patchesJSON6902 string) ([]byte, error) {
 	if len(patchesJSON6902) == 0 {
 		return []byte(patchesJSON6902), nil
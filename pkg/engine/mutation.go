@@ -28,7 +28,7 @@ func (e *engine) mutate(
 	policyContext.JSONContext().Checkpoint()
 	defer policyContext.JSONContext().Restore()
 
-	for _, rule := range autogen.ComputeRules(policy) {
+	for _, rule := range autogen.ComputeRules(policy, e.configuration.GetAutogenControllers()) {
 		startTime := time.Now()
 		logger := internal.LoggerWithRule(logger, rule)
 		handlerFactory := func() (handlers.Handler, error) {
@@ -36,9 +36,9 @@ func (e *engine) mutate(
 				return nil, nil
 			}
 			if !policyContext.AdmissionOperation() && rule.IsMutateExisting() {
-				return mutation.NewMutateExistingHandler(e.client)
+				return mutation.NewMutateExistingHandler(e.client, e.ownerResolver)
 			}
-			return mutation.NewMutateResourceHandler()
+			return mutation.NewMutateResourceHandler(e.ownerResolver)
 		}
 		resource, ruleResp := e.invokeRuleHandler(
 			ctx,
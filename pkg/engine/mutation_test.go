@@ -37,11 +37,14 @@ func testMutate(
 		cfg,
 		config.NewDefaultMetricsConfiguration(),
 		jp,
+		nil,
 		adapters.Client(client),
 		factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), nil),
 		imageverifycache.DisabledImageVerifyCache(),
 		contextLoader,
 		nil,
+		nil,
+		nil,
 		"",
 	)
 	return e.Mutate(
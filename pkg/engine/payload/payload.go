@@ -0,0 +1,194 @@
+package payload
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/validate"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// Variable is the name of the context variable holding the payload in conditions and messages
+const Variable = "payload"
+
+// PolicyResponse stores the responses of the rules of a policy applied to a payload
+type PolicyResponse struct {
+	// Policy is the name of the policy
+	Policy string `json:"policy"`
+	// Rules contains the responses of the rules matching the payload
+	Rules []RuleResponse `json:"rules,omitempty"`
+}
+
+// RuleResponse stores the response of a rule applied to a payload
+type RuleResponse struct {
+	// Name is the name of the rule
+	Name string `json:"name"`
+	// Status is the outcome of the rule
+	Status engineapi.RuleStatus `json:"status"`
+	// Message contains details about the outcome
+	Message string `json:"message,omitempty"`
+}
+
+// Validate applies the rules of the policies to a JSON payload decoded with encoding/json.
+// Rules are evaluated with the pattern and condition engine used for Kubernetes resources, the payload is
+// available to conditions and messages with the `payload` variable.
+func Validate(logger logr.Logger, jp jmespath.Interface, payload interface{}, policies ...*kyvernov2alpha1.ValidatingPolicy) ([]PolicyResponse, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	responses := make([]PolicyResponse, 0, len(policies))
+	for _, policy := range policies {
+		logger := logger.WithValues("policy", policy.GetName())
+		response := PolicyResponse{Policy: policy.GetName()}
+		for _, rule := range policy.Spec.Rules {
+			// every rule gets a fresh context so that a rule can't leak variables to the next one
+			ctx := enginecontext.NewContext(jp)
+			if err := ctx.AddContextEntry(Variable, raw); err != nil {
+				return nil, err
+			}
+			if ruleResponse := validateRule(logger.WithValues("rule", rule.Name), ctx, payload, rule); ruleResponse != nil {
+				response.Rules = append(response.Rules, *ruleResponse)
+			}
+		}
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
+
+// validateRule returns nil when the rule doesn't match the payload
+func validateRule(logger logr.Logger, ctx enginecontext.Interface, payload interface{}, rule kyvernov2alpha1.ValidatingRule) *RuleResponse {
+	if rule.Match != nil && !matches(logger, payload, rule.Match) {
+		return nil
+	}
+	if rule.Exclude != nil && matches(logger, payload, rule.Exclude) {
+		return nil
+	}
+	if rule.Preconditions != nil {
+		met, msg, err := variables.EvaluateConditions(logger, ctx, *rule.Preconditions)
+		if err != nil {
+			return ruleResponse(rule, engineapi.RuleStatusError, fmt.Sprintf("failed to evaluate preconditions: %s", err))
+		}
+		if !met {
+			message := "preconditions not met"
+			if msg != "" {
+				message = message + ": " + msg
+			}
+			return ruleResponse(rule, engineapi.RuleStatusSkip, message)
+		}
+	}
+	validation := rule.Validation
+	switch {
+	case validation.Deny != nil:
+		deny, msg, err := variables.EvaluateConditions(logger, ctx, *validation.Deny)
+		if err != nil {
+			return ruleResponse(rule, engineapi.RuleStatusError, fmt.Sprintf("failed to evaluate deny conditions: %s", err))
+		}
+		if deny {
+			return ruleResponse(rule, engineapi.RuleStatusFail, failureMessage(logger, ctx, rule, msg))
+		}
+	case validation.Pattern != nil:
+		if status, msg := matchPattern(logger, ctx, payload, *validation.Pattern); status != engineapi.RuleStatusPass {
+			if status == engineapi.RuleStatusFail {
+				msg = failureMessage(logger, ctx, rule, msg)
+			}
+			return ruleResponse(rule, status, msg)
+		}
+	case len(validation.AnyPattern) != 0:
+		var errs []string
+		for i, pattern := range validation.AnyPattern {
+			status, msg := matchPattern(logger, ctx, payload, pattern)
+			if status == engineapi.RuleStatusPass {
+				return ruleResponse(rule, engineapi.RuleStatusPass, fmt.Sprintf("validation rule '%s' anyPattern[%d] passed.", rule.Name, i))
+			}
+			if status == engineapi.RuleStatusError {
+				return ruleResponse(rule, status, msg)
+			}
+			errs = append(errs, fmt.Sprintf("anyPattern[%d] %s", i, msg))
+		}
+		return ruleResponse(rule, engineapi.RuleStatusFail, failureMessage(logger, ctx, rule, strings.Join(errs, "; ")))
+	}
+	return ruleResponse(rule, engineapi.RuleStatusPass, fmt.Sprintf("validation rule '%s' passed.", rule.Name))
+}
+
+// matches returns true when the payload matches any or all of the patterns, depending on which one is set
+func matches(logger logr.Logger, payload interface{}, match *kyvernov2alpha1.PayloadMatch) bool {
+	for _, pattern := range match.Any {
+		if matchRaw(logger, payload, pattern) {
+			return true
+		}
+	}
+	for _, pattern := range match.All {
+		if !matchRaw(logger, payload, pattern) {
+			return false
+		}
+	}
+	return len(match.All) != 0
+}
+
+func matchRaw(logger logr.Logger, payload interface{}, pattern apiextv1.JSON) bool {
+	var decoded interface{}
+	if err := json.Unmarshal(pattern.Raw, &decoded); err != nil {
+		logger.Error(err, "failed to decode match pattern")
+		return false
+	}
+	return validate.MatchPattern(logger, payload, decoded) == nil
+}
+
+// matchPattern substitutes the variables of a validation pattern and matches it against the payload
+func matchPattern(logger logr.Logger, ctx enginecontext.Interface, payload interface{}, pattern apiextv1.JSON) (engineapi.RuleStatus, string) {
+	var decoded interface{}
+	if err := json.Unmarshal(pattern.Raw, &decoded); err != nil {
+		return engineapi.RuleStatusError, fmt.Sprintf("failed to decode pattern: %s", err)
+	}
+	substituted, err := variables.SubstituteAll(logger, ctx, decoded)
+	if err != nil {
+		return engineapi.RuleStatusError, fmt.Sprintf("failed to substitute variables in pattern: %s", err)
+	}
+	if err := validate.MatchPattern(logger, payload, substituted); err != nil {
+		if pe, ok := err.(*validate.PatternError); ok {
+			if pe.Skip {
+				return engineapi.RuleStatusSkip, pe.Error()
+			}
+			if pe.Path != "" {
+				return engineapi.RuleStatusFail, fmt.Sprintf("failed at path %s", pe.Path)
+			}
+		}
+		return engineapi.RuleStatusError, err.Error()
+	}
+	return engineapi.RuleStatusPass, ""
+}
+
+// failureMessage prefixes the details of a failure with the rule message, variables are substituted in the rule message
+func failureMessage(logger logr.Logger, ctx enginecontext.Interface, rule kyvernov2alpha1.ValidatingRule, details string) string {
+	message := rule.Validation.Message
+	if message != "" {
+		if substituted, err := variables.SubstituteAll(logger, ctx, message); err != nil {
+			logger.V(2).Info("failed to substitute variables in message", "error", err)
+		} else if typed, ok := substituted.(string); ok {
+			message = typed
+		}
+	}
+	if message == "" {
+		message = fmt.Sprintf("validation error: rule %s failed", rule.Name)
+	}
+	if details == "" {
+		return message
+	}
+	return strings.TrimSuffix(message, ".") + ": " + details
+}
+
+func ruleResponse(rule kyvernov2alpha1.ValidatingRule, status engineapi.RuleStatus, message string) *RuleResponse {
+	return &RuleResponse{
+		Name:    rule.Name,
+		Status:  status,
+		Message: message,
+	}
+}
@@ -0,0 +1,90 @@
+package payload
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"gotest.tools/assert"
+)
+
+func Test_Validate(t *testing.T) {
+	rawPolicy := `{
+		"apiVersion": "kyverno.io/v2alpha1",
+		"kind": "ValidatingPolicy",
+		"metadata": { "name": "dockerfile" },
+		"spec": {
+			"rules": [{
+				"name": "non-root",
+				"match": { "any": [{ "kind": "Dockerfile" }] },
+				"validate": {
+					"message": "the {{ payload.name }} image must not run as root",
+					"pattern": { "user": "!root" }
+				}
+			}, {
+				"name": "trusted-base",
+				"validate": {
+					"anyPattern": [{ "from": "ghcr.io/*" }, { "from": "docker.io/library/*" }]
+				}
+			}, {
+				"name": "no-latest",
+				"preconditions": { "all": [{ "key": "{{ payload.from || '' }}", "operator": "NotEquals", "value": "" }] },
+				"validate": {
+					"deny": { "any": [{ "key": "{{ ends_with(payload.from, ':latest') }}", "operator": "Equals", "value": true }] }
+				}
+			}, {
+				"name": "excluded",
+				"exclude": { "all": [{ "kind": "Dockerfile" }] },
+				"validate": { "pattern": { "user": "nobody" } }
+			}]
+		}
+	}`
+	var policy kyvernov2alpha1.ValidatingPolicy
+	assert.NilError(t, json.Unmarshal([]byte(rawPolicy), &policy))
+	assert.Equal(t, len(policy.Validate()), 0)
+	tests := []struct {
+		name    string
+		payload string
+		want    []RuleResponse
+	}{{
+		name:    "pass",
+		payload: `{ "kind": "Dockerfile", "name": "app", "from": "ghcr.io/org/base:1.0", "user": "app" }`,
+		want: []RuleResponse{
+			{Name: "non-root", Status: engineapi.RuleStatusPass, Message: "validation rule 'non-root' passed."},
+			{Name: "trusted-base", Status: engineapi.RuleStatusPass, Message: "validation rule 'trusted-base' anyPattern[0] passed."},
+			{Name: "no-latest", Status: engineapi.RuleStatusPass, Message: "validation rule 'no-latest' passed."},
+		},
+	}, {
+		name:    "fail",
+		payload: `{ "kind": "Dockerfile", "name": "app", "from": "quay.io/org/base:latest", "user": "root" }`,
+		want: []RuleResponse{
+			{Name: "non-root", Status: engineapi.RuleStatusFail, Message: "the app image must not run as root: failed at path /user/"},
+			{Name: "trusted-base", Status: engineapi.RuleStatusFail, Message: "validation error: rule trusted-base failed: anyPattern[0] failed at path /from/; anyPattern[1] failed at path /from/"},
+			{Name: "no-latest", Status: engineapi.RuleStatusFail, Message: "validation error: rule no-latest failed"},
+		},
+	}, {
+		name:    "not matched",
+		payload: `{ "kind": "Containerfile", "user": "nobody" }`,
+		want: []RuleResponse{
+			{Name: "trusted-base", Status: engineapi.RuleStatusFail, Message: "validation error: rule trusted-base failed: anyPattern[0] failed at path /from/; anyPattern[1] failed at path /from/"},
+			{Name: "no-latest", Status: engineapi.RuleStatusSkip, Message: "preconditions not met"},
+			{Name: "excluded", Status: engineapi.RuleStatusPass, Message: "validation rule 'excluded' passed."},
+		},
+	}}
+	jp := jmespath.New(config.NewDefaultConfiguration(false))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var payload interface{}
+			assert.NilError(t, json.Unmarshal([]byte(tt.payload), &payload))
+			responses, err := Validate(logr.Discard(), jp, payload, &policy)
+			assert.NilError(t, err)
+			assert.Equal(t, len(responses), 1)
+			assert.Equal(t, responses[0].Policy, "dockerfile")
+			assert.DeepEqual(t, responses[0].Rules, tt.want)
+		})
+	}
+}
@@ -138,6 +138,12 @@ func (c PolicyContext) Copy() engineapi.PolicyContext {
 	return c.copy()
 }
 
+func (c PolicyContext) CopyWithJSONContext(jsonContext enginectx.Interface) engineapi.PolicyContext {
+	copy := c.copy()
+	copy.jsonContext = jsonContext
+	return copy
+}
+
 // Mutators
 
 func (c *PolicyContext) WithPolicy(policy kyvernov1.PolicyInterface) *PolicyContext {
@@ -272,6 +278,9 @@ func NewPolicyContextFromAdmissionRequest(
 	if err := engineCtx.AddImageInfos(&newResource, configuration); err != nil {
 		return nil, fmt.Errorf("failed to add image information to the policy rule context: %w", err)
 	}
+	if err := engineCtx.AddResourceKind(gvk, request.SubResource); err != nil {
+		return nil, fmt.Errorf("failed to add resource kind to the policy rule context: %w", err)
+	}
 	policyContext := newPolicyContextWithJsonContext(kyvernov1.AdmissionOperation(request.Operation), engineCtx).
 		WithNewResource(newResource).
 		WithOldResource(oldResource).
@@ -0,0 +1,339 @@
+package prefetch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Cache holds data declared by rule prefetch entries.
+// Data is loaded in bulk by Warm and only served to the context entries of the rules declaring it,
+// until it expires.
+type Cache interface {
+	// Warm loads the data declared by the rules of the given policies, including the rules autogen
+	// generates for the given custom pod controllers.
+	// Entries no longer declared by any rule are evicted.
+	Warm(context.Context, []config.AutogenController, ...kyvernov1.PolicyInterface) error
+	// Interval returns how often Warm must be called for entries to stay fresh.
+	Interval() time.Duration
+	// ContextLoaderFactory wraps a context loader factory so that the context entries of rules declaring
+	// prefetch data are loaded from the cache. The factory is created with the config map resolver to use.
+	ContextLoaderFactory(engineapi.ConfigmapResolver, func(engineapi.ConfigmapResolver) engineapi.ContextLoaderFactory) engineapi.ContextLoaderFactory
+}
+
+type resourceEntry struct {
+	list      *unstructured.UnstructuredList
+	fetchedAt time.Time
+}
+
+type configMapEntry struct {
+	configMap *corev1.ConfigMap
+	fetchedAt time.Time
+}
+
+type cache struct {
+	client    engineapi.RawClient
+	ttl       time.Duration
+	lock      sync.RWMutex
+	resources map[resourceKey]resourceEntry
+	cms       map[kyvernov1.ConfigMapReference]configMapEntry
+}
+
+// NewCache creates a Cache loading data with the given client, entries expire after ttl.
+func NewCache(client engineapi.RawClient, ttl time.Duration) Cache {
+	return &cache{
+		client:    client,
+		ttl:       ttl,
+		resources: map[resourceKey]resourceEntry{},
+		cms:       map[kyvernov1.ConfigMapReference]configMapEntry{},
+	}
+}
+
+func (c *cache) Warm(ctx context.Context, autogenControllers []config.AutogenController, policies ...kyvernov1.PolicyInterface) error {
+	resources, configMaps := sets.New[resourceKey](), sets.New[kyvernov1.ConfigMapReference]()
+	for _, policy := range policies {
+		for _, rule := range autogen.ComputeRules(policy, autogenControllers) {
+			r, cms := collect(rule)
+			resources, configMaps = resources.Union(r), configMaps.Union(cms)
+		}
+	}
+	var errs []error
+	loadedResources := map[resourceKey]resourceEntry{}
+	for key := range resources {
+		data, err := c.client.RawAbsPath(ctx, key.path(), "GET", nil)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		var list unstructured.UnstructuredList
+		if err := list.UnmarshalJSON(data); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		loadedResources[key] = resourceEntry{list: &list, fetchedAt: time.Now()}
+	}
+	loadedConfigMaps := map[kyvernov1.ConfigMapReference]configMapEntry{}
+	for ref := range configMaps {
+		data, err := c.client.RawAbsPath(ctx, fmt.Sprintf("/api/v1/namespaces/%s/configmaps/%s", ref.Namespace, ref.Name), "GET", nil)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		var cm corev1.ConfigMap
+		if err := json.Unmarshal(data, &cm); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		loadedConfigMaps[ref] = configMapEntry{configMap: &cm, fetchedAt: time.Now()}
+	}
+	// entries failing to load are evicted too, lookups fall back to the API server
+	c.lock.Lock()
+	c.resources, c.cms = loadedResources, loadedConfigMaps
+	c.lock.Unlock()
+	if len(errs) != 0 {
+		return fmt.Errorf("failed to prefetch data: %v", errs)
+	}
+	return nil
+}
+
+func (c *cache) Interval() time.Duration {
+	return c.ttl
+}
+
+func (c *cache) ContextLoaderFactory(
+	cmResolver engineapi.ConfigmapResolver,
+	factory func(engineapi.ConfigmapResolver) engineapi.ContextLoaderFactory,
+) engineapi.ContextLoaderFactory {
+	inner := factory(cmResolver)
+	return func(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) engineapi.ContextLoader {
+		if rule.Prefetch == nil {
+			return inner(policy, rule)
+		}
+		resources, configMaps := collect(rule)
+		return &contextLoader{
+			inner:     factory(&resolver{inner: cmResolver, cache: c, configMaps: configMaps})(policy, rule),
+			cache:     c,
+			resources: resources,
+		}
+	}
+}
+
+// fresh returns true if the entry was loaded by one of the last two calls to Warm,
+// a single failed or late call doesn't make every lookup fall back to the API server.
+func (c *cache) fresh(fetchedAt time.Time) bool {
+	return time.Since(fetchedAt) < 2*c.ttl
+}
+
+func (c *cache) getResources(key resourceKey) (*unstructured.UnstructuredList, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	entry, ok := c.resources[key]
+	if !ok || !c.fresh(entry.fetchedAt) {
+		return nil, false
+	}
+	return entry.list, true
+}
+
+func (c *cache) getConfigMap(ref kyvernov1.ConfigMapReference) (*corev1.ConfigMap, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	entry, ok := c.cms[ref]
+	if !ok || !c.fresh(entry.fetchedAt) {
+		return nil, false
+	}
+	return entry.configMap, true
+}
+
+// lookup returns the prefetched objects matching a parsed request, either from a namespace
+// specific entry or from an all namespaces entry. Only the entries declared by the rule are used.
+func (c *cache) lookup(declared sets.Set[resourceKey], req request) ([]unstructured.Unstructured, *unstructured.UnstructuredList, bool) {
+	key := resourceKey{apiVersion: req.apiVersion, resource: req.resource, namespace: req.namespace}
+	if declared.Has(key) {
+		if list, ok := c.getResources(key); ok {
+			return list.Items, list, true
+		}
+	}
+	if req.namespace == "" {
+		return nil, nil, false
+	}
+	key.namespace = ""
+	if !declared.Has(key) {
+		return nil, nil, false
+	}
+	list, ok := c.getResources(key)
+	if !ok {
+		return nil, nil, false
+	}
+	var items []unstructured.Unstructured
+	for _, item := range list.Items {
+		if item.GetNamespace() == req.namespace {
+			items = append(items, item)
+		}
+	}
+	return items, list, true
+}
+
+// contextLoader loads the context entries of a rule declaring prefetch data,
+// API calls are served from the data declared by the rule.
+type contextLoader struct {
+	inner     engineapi.ContextLoader
+	cache     *cache
+	resources sets.Set[resourceKey]
+}
+
+func (l *contextLoader) Load(
+	ctx context.Context,
+	jp jmespath.Interface,
+	client engineapi.RawClient,
+	rclientFactory engineapi.RegistryClientFactory,
+	contextEntries []kyvernov1.ContextEntry,
+	jsonContext enginecontext.Interface,
+) error {
+	if client != nil {
+		client = &rawClient{inner: client, cache: l.cache, resources: l.resources}
+	}
+	return l.inner.Load(ctx, jp, client, rclientFactory, contextEntries, jsonContext)
+}
+
+type rawClient struct {
+	inner     engineapi.RawClient
+	cache     *cache
+	resources sets.Set[resourceKey]
+}
+
+func (c *rawClient) RawAbsPath(ctx context.Context, path string, method string, dataReader io.Reader) ([]byte, error) {
+	if method == "GET" {
+		if req, ok := parsePath(path); ok {
+			if items, list, ok := c.cache.lookup(c.resources, req); ok {
+				if req.name == "" {
+					result := unstructured.UnstructuredList{Object: list.Object, Items: items}
+					return result.MarshalJSON()
+				}
+				for _, item := range items {
+					if item.GetName() == req.name {
+						return item.MarshalJSON()
+					}
+				}
+				// the prefetched list is complete, a missing object doesn't exist
+				return nil, apierrors.NewNotFound(req.groupResource(), req.name)
+			}
+		}
+	}
+	return c.inner.RawAbsPath(ctx, path, method, dataReader)
+}
+
+type resolver struct {
+	inner      engineapi.ConfigmapResolver
+	cache      *cache
+	configMaps sets.Set[kyvernov1.ConfigMapReference]
+}
+
+func (r *resolver) Get(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	ref := kyvernov1.ConfigMapReference{Namespace: namespace, Name: name}
+	if r.configMaps.Has(ref) {
+		if cm, ok := r.cache.getConfigMap(ref); ok {
+			return cm, nil
+		}
+	}
+	return r.inner.Get(ctx, namespace, name)
+}
+
+type resourceKey struct {
+	apiVersion string
+	resource   string
+	namespace  string
+}
+
+func (k resourceKey) path() string {
+	prefix := "/apis/" + k.apiVersion
+	if !strings.Contains(k.apiVersion, "/") {
+		prefix = "/api/" + k.apiVersion
+	}
+	if k.namespace == "" {
+		return prefix + "/" + k.resource
+	}
+	return prefix + "/namespaces/" + k.namespace + "/" + k.resource
+}
+
+type request struct {
+	apiVersion string
+	resource   string
+	namespace  string
+	name       string
+}
+
+func (r request) groupResource() schema.GroupResource {
+	group := ""
+	if i := strings.Index(r.apiVersion, "/"); i >= 0 {
+		group = r.apiVersion[:i]
+	}
+	return schema.GroupResource{Group: group, Resource: r.resource}
+}
+
+// parsePath parses a raw API server path targeting a resource list or a single resource.
+// Paths with query parameters or targeting subresources are not supported.
+func parsePath(path string) (request, bool) {
+	if strings.Contains(path, "?") {
+		return request{}, false
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var req request
+	var rest []string
+	switch {
+	case len(segments) >= 3 && segments[0] == "api":
+		req.apiVersion = segments[1]
+		rest = segments[2:]
+	case len(segments) >= 4 && segments[0] == "apis":
+		req.apiVersion = segments[1] + "/" + segments[2]
+		rest = segments[3:]
+	default:
+		return request{}, false
+	}
+	if len(rest) >= 3 && rest[0] == "namespaces" {
+		req.namespace = rest[1]
+		rest = rest[2:]
+	}
+	switch len(rest) {
+	case 1:
+		req.resource = rest[0]
+	case 2:
+		req.resource, req.name = rest[0], rest[1]
+	default:
+		return request{}, false
+	}
+	return req, true
+}
+
+// collect returns the resources and config maps declared by the prefetch entry of a rule.
+func collect(rule kyvernov1.Rule) (sets.Set[resourceKey], sets.Set[kyvernov1.ConfigMapReference]) {
+	resources, configMaps := sets.New[resourceKey](), sets.New[kyvernov1.ConfigMapReference]()
+	if rule.Prefetch == nil {
+		return resources, configMaps
+	}
+	for _, resource := range rule.Prefetch.Resources {
+		resources.Insert(resourceKey{apiVersion: resource.APIVersion, resource: resource.Resource, namespace: resource.Namespace})
+	}
+	for _, cm := range rule.Prefetch.ConfigMaps {
+		if cm.Namespace == "" {
+			cm.Namespace = "default"
+		}
+		configMaps.Insert(cm)
+	}
+	return resources, configMaps
+}
@@ -0,0 +1,234 @@
+package prefetch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type fakeClient struct {
+	engineapi.Client
+	data  map[string]string
+	calls map[string]int
+}
+
+func (c *fakeClient) RawAbsPath(_ context.Context, path string, _ string, _ io.Reader) ([]byte, error) {
+	c.calls[path]++
+	if data, ok := c.data[path]; ok {
+		return []byte(data), nil
+	}
+	return nil, apierrors.NewNotFound(schema.GroupResource{}, path)
+}
+
+const services = `{
+	"apiVersion": "v1",
+	"kind": "ServiceList",
+	"metadata": {},
+	"items": [
+		{ "apiVersion": "v1", "kind": "Service", "metadata": { "name": "foo", "namespace": "ns-1" } },
+		{ "apiVersion": "v1", "kind": "Service", "metadata": { "name": "bar", "namespace": "ns-2" } }
+	]
+}`
+
+func newPolicy(prefetch *kyvernov1.Prefetch) kyvernov1.PolicyInterface {
+	return &kyvernov1.ClusterPolicy{
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{{Name: "rule", Prefetch: prefetch}},
+		},
+	}
+}
+
+func Test_parsePath(t *testing.T) {
+	tests := []struct {
+		path string
+		want request
+		ok   bool
+	}{{
+		path: "/api/v1/services",
+		want: request{apiVersion: "v1", resource: "services"},
+		ok:   true,
+	}, {
+		path: "/api/v1/namespaces",
+		want: request{apiVersion: "v1", resource: "namespaces"},
+		ok:   true,
+	}, {
+		path: "/api/v1/namespaces/default",
+		want: request{apiVersion: "v1", resource: "namespaces", name: "default"},
+		ok:   true,
+	}, {
+		path: "/api/v1/namespaces/default/services/foo",
+		want: request{apiVersion: "v1", resource: "services", namespace: "default", name: "foo"},
+		ok:   true,
+	}, {
+		path: "/apis/apps/v1/namespaces/default/deployments",
+		want: request{apiVersion: "apps/v1", resource: "deployments", namespace: "default"},
+		ok:   true,
+	}, {
+		path: "/apis/apps/v1/namespaces/default/deployments/foo/scale",
+	}, {
+		path: "/api/v1/services?labelSelector=app",
+	}, {
+		path: "/version",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := parsePath(tt.path)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// captureLoader records the client and the config map resolver it was created with.
+type captureLoader struct {
+	resolver engineapi.ConfigmapResolver
+	client   engineapi.RawClient
+}
+
+func (l *captureLoader) Load(_ context.Context, _ jmespath.Interface, client engineapi.RawClient, _ engineapi.RegistryClientFactory, _ []kyvernov1.ContextEntry, _ enginecontext.Interface) error {
+	l.client = client
+	return nil
+}
+
+// load returns the client and the config map resolver used to load the context entries of the first rule of a policy.
+func load(t *testing.T, c Cache, inner *fakeClient, policy kyvernov1.PolicyInterface) (engineapi.RawClient, engineapi.ConfigmapResolver) {
+	factory := c.ContextLoaderFactory(inner, func(resolver engineapi.ConfigmapResolver) engineapi.ContextLoaderFactory {
+		return func(kyvernov1.PolicyInterface, kyvernov1.Rule) engineapi.ContextLoader {
+			return &captureLoader{resolver: resolver}
+		}
+	})
+	loader := factory(policy, policy.GetSpec().Rules[0])
+	assert.NilError(t, loader.Load(context.TODO(), nil, inner, nil, nil, nil))
+	if capture, ok := loader.(*captureLoader); ok {
+		return capture.client, capture.resolver
+	}
+	capture := loader.(*contextLoader).inner.(*captureLoader)
+	return capture.client, capture.resolver
+}
+
+func (c *fakeClient) Get(_ context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	path := "/api/v1/namespaces/" + namespace + "/configmaps/" + name
+	c.calls[path]++
+	return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, name)
+}
+
+func Test_cache(t *testing.T) {
+	ctx := context.TODO()
+	inner := &fakeClient{
+		data: map[string]string{
+			"/api/v1/services":                          services,
+			"/api/v1/namespaces/ns-1/services/foo":      `{"apiVersion":"v1","kind":"Service","metadata":{"name":"foo","namespace":"ns-1"}}`,
+			"/api/v1/namespaces/default/configmaps/cfg": `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cfg","namespace":"default"},"data":{"key":"value"}}`,
+		},
+		calls: map[string]int{},
+	}
+	c := NewCache(inner, time.Minute)
+	policy := newPolicy(&kyvernov1.Prefetch{
+		Resources:  []kyvernov1.PrefetchResource{{APIVersion: "v1", Resource: "services"}},
+		ConfigMaps: []kyvernov1.ConfigMapReference{{Name: "cfg"}},
+	})
+	assert.NilError(t, c.Warm(ctx, nil, policy, policy))
+	assert.Equal(t, 1, inner.calls["/api/v1/services"])
+	assert.Equal(t, 1, inner.calls["/api/v1/namespaces/default/configmaps/cfg"])
+	client, resolver := load(t, c, inner, policy)
+	// list in a namespace is served from the all namespaces entry
+	data, err := client.RawAbsPath(ctx, "/api/v1/namespaces/ns-1/services", "GET", nil)
+	assert.NilError(t, err)
+	var list unstructured.UnstructuredList
+	assert.NilError(t, list.UnmarshalJSON(data))
+	assert.Equal(t, 1, len(list.Items))
+	assert.Equal(t, "foo", list.Items[0].GetName())
+	// get is served from the cache
+	data, err = client.RawAbsPath(ctx, "/api/v1/namespaces/ns-1/services/foo", "GET", nil)
+	assert.NilError(t, err)
+	var object map[string]interface{}
+	assert.NilError(t, json.Unmarshal(data, &object))
+	assert.Equal(t, 0, inner.calls["/api/v1/namespaces/ns-1/services/foo"])
+	// objects missing from the prefetched list don't exist
+	_, err = client.RawAbsPath(ctx, "/api/v1/namespaces/ns-1/services/baz", "GET", nil)
+	assert.Assert(t, apierrors.IsNotFound(err))
+	assert.Equal(t, 0, inner.calls["/api/v1/namespaces/ns-1/services/baz"])
+	// undeclared resources fall back to the inner client
+	_, err = client.RawAbsPath(ctx, "/api/v1/namespaces/ns-1/pods", "GET", nil)
+	assert.Assert(t, apierrors.IsNotFound(err))
+	assert.Equal(t, 1, inner.calls["/api/v1/namespaces/ns-1/pods"])
+	// config maps are served from the cache
+	cm, err := resolver.Get(ctx, "default", "cfg")
+	assert.NilError(t, err)
+	assert.Equal(t, "value", cm.Data["key"])
+	// rules without prefetch entries use the inner client and resolver
+	client, resolver = load(t, c, inner, newPolicy(nil))
+	_, err = client.RawAbsPath(ctx, "/api/v1/namespaces/ns-1/services/foo", "GET", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, 1, inner.calls["/api/v1/namespaces/ns-1/services/foo"])
+	_, err = resolver.Get(ctx, "default", "cfg")
+	assert.Assert(t, apierrors.IsNotFound(err))
+	// rules declaring other data don't use the prefetched services
+	client, _ = load(t, c, inner, newPolicy(&kyvernov1.Prefetch{
+		Resources: []kyvernov1.PrefetchResource{{APIVersion: "v1", Resource: "services", Namespace: "ns-2"}},
+	}))
+	_, err = client.RawAbsPath(ctx, "/api/v1/namespaces/ns-1/services/foo", "GET", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, 2, inner.calls["/api/v1/namespaces/ns-1/services/foo"])
+}
+
+func Test_cacheEviction(t *testing.T) {
+	ctx := context.TODO()
+	inner := &fakeClient{
+		data:  map[string]string{"/api/v1/services": services},
+		calls: map[string]int{},
+	}
+	c := NewCache(inner, time.Minute)
+	policy := newPolicy(&kyvernov1.Prefetch{
+		Resources: []kyvernov1.PrefetchResource{{APIVersion: "v1", Resource: "services"}},
+	})
+	assert.NilError(t, c.Warm(ctx, nil, policy))
+	assert.NilError(t, c.Warm(ctx, nil, policy))
+	assert.Equal(t, 2, inner.calls["/api/v1/services"])
+	client, _ := load(t, c, inner, policy)
+	_, err := client.RawAbsPath(ctx, "/api/v1/services", "GET", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, 2, inner.calls["/api/v1/services"])
+	// entries no longer declared are evicted
+	assert.NilError(t, c.Warm(ctx, nil))
+	_, err = client.RawAbsPath(ctx, "/api/v1/services", "GET", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, 3, inner.calls["/api/v1/services"])
+	// entries failing to reload are evicted
+	assert.NilError(t, c.Warm(ctx, nil, policy))
+	delete(inner.data, "/api/v1/services")
+	assert.ErrorContains(t, c.Warm(ctx, nil, policy), "failed to prefetch data")
+	_, err = client.RawAbsPath(ctx, "/api/v1/services", "GET", nil)
+	assert.Assert(t, apierrors.IsNotFound(err))
+	assert.Equal(t, 6, inner.calls["/api/v1/services"])
+}
+
+func Test_cacheExpiry(t *testing.T) {
+	ctx := context.TODO()
+	inner := &fakeClient{
+		data:  map[string]string{"/api/v1/services": services},
+		calls: map[string]int{},
+	}
+	c := NewCache(inner, 0)
+	policy := newPolicy(&kyvernov1.Prefetch{
+		Resources: []kyvernov1.PrefetchResource{{APIVersion: "v1", Resource: "services"}},
+	})
+	assert.NilError(t, c.Warm(ctx, nil, policy))
+	assert.Equal(t, 1, inner.calls["/api/v1/services"])
+	client, _ := load(t, c, inner, policy)
+	_, err := client.RawAbsPath(ctx, "/api/v1/services", "GET", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, 2, inner.calls["/api/v1/services"])
+}
@@ -1,16 +1,23 @@
 package utils
 
 import (
+	"context"
+
 	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/utils/conditions"
 	matched "github.com/kyverno/kyverno/pkg/utils/match"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // MatchesException takes a list of exceptions and checks if there is an exception applies to the incoming resource.
 // It returns the matched policy exception.
 func MatchesException(
+	ctx context.Context,
+	ownerResolver matched.OwnerResolver,
 	polexs []kyvernov2.PolicyException,
 	policyContext engineapi.PolicyContext,
 	logger logr.Logger,
@@ -20,28 +27,78 @@ func MatchesException(
 	if resource.Object == nil {
 		resource = policyContext.OldResource()
 	}
+	// background scans don't come from an operation, exceptions scoped to operations apply to them
+	// so that reports agree with admission
+	var operation kyvernov1.AdmissionOperation
+	if policyContext.AdmissionOperation() {
+		operation = policyContext.Operation()
+	}
 	for _, polex := range polexs {
 		err := matched.CheckMatchesResources(
+			ctx,
+			ownerResolver,
 			resource,
 			polex.Spec.Match,
 			policyContext.NamespaceLabels(),
 			policyContext.AdmissionInfo(),
 			gvk,
 			subresource,
+			operation,
 		)
 		// if there's no error it means a match
-		if err == nil {
-			if polex.Spec.Conditions != nil {
-				passed, err := conditions.CheckAnyAllConditions(logger, policyContext.JSONContext(), *polex.Spec.Conditions)
-				if err != nil {
-					return nil
-				}
-				if !passed {
-					return nil
-				}
+		if err != nil {
+			continue
+		}
+		if len(polex.Spec.Images) > 0 && !imagesMatchException(policyContext, polex.Spec.Images) {
+			continue
+		}
+		if polex.Spec.NamespaceSelector != nil {
+			passed, err := namespaceMatchesException(resource, policyContext.NamespaceLabels(), polex.Spec.NamespaceSelector)
+			if err != nil {
+				logger.Error(err, "failed to check policy exception namespace selector", "exception", polex.GetName())
+				continue
+			}
+			if !passed {
+				continue
+			}
+		}
+		if polex.Spec.Conditions != nil {
+			passed, err := conditions.CheckAnyAllConditions(logger, policyContext.JSONContext(), *polex.Spec.Conditions)
+			if err != nil {
+				logger.Error(err, "failed to check policy exception conditions", "exception", polex.GetName())
+				continue
+			}
+			if !passed {
+				continue
 			}
-			return &polex
 		}
+		return &polex
 	}
 	return nil
 }
+
+// namespaceMatchesException checks the namespace of the resource against the selector,
+// namespaces are checked against their own labels and other cluster scoped resources never match
+func namespaceMatchesException(resource unstructured.Unstructured, namespaceLabels map[string]string, selector *metav1.LabelSelector) (bool, error) {
+	if resource.GetKind() == "Namespace" {
+		namespaceLabels = resource.GetLabels()
+	} else if resource.GetNamespace() == "" {
+		return false, nil
+	}
+	return matched.CheckSelector(selector, namespaceLabels)
+}
+
+// imagesMatchException returns true when the resource has images and all of them match one of the patterns
+func imagesMatchException(policyContext engineapi.PolicyContext, patterns []string) bool {
+	images := policyContext.JSONContext().ImageInfo()
+	found := false
+	for _, infoMap := range images {
+		for _, imageInfo := range infoMap {
+			if !ImageMatches(imageInfo.String(), patterns) {
+				return false
+			}
+			found = true
+		}
+	}
+	return found
+}
@@ -0,0 +1,205 @@
+package utils
+
+import (
+	"context"
+
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/policycontext"
+	"github.com/stretchr/testify/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func newTestException(name string, filter kyvernov1.ResourceFilter, images ...string) kyvernov2.PolicyException {
+	return kyvernov2.PolicyException{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kyverno"},
+		Spec: kyvernov2.PolicyExceptionSpec{
+			Match: kyvernov2beta1.MatchResources{
+				Any: kyvernov1.ResourceFilters{filter},
+			},
+			Images: images,
+		},
+	}
+}
+
+func TestMatchesException(t *testing.T) {
+	pod := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name":      "controller",
+			"namespace": "default",
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{
+					"name":  "controller",
+					"image": "ghcr.io/example/controller:v1.0.0",
+				},
+				map[string]interface{}{
+					"name":  "sidecar",
+					"image": "ghcr.io/example/sidecar:v1.0.0",
+				},
+			},
+		},
+	}}
+	admissionInfo := kyvernov1beta1.RequestInfo{
+		AdmissionUserInfo: authenticationv1.UserInfo{
+			Username: "system:serviceaccount:default:controller",
+		},
+	}
+	podFilter := func(operations ...kyvernov1.AdmissionOperation) kyvernov1.ResourceFilter {
+		return kyvernov1.ResourceFilter{
+			ResourceDescription: kyvernov1.ResourceDescription{
+				Kinds:      []string{"Pod"},
+				Operations: operations,
+			},
+		}
+	}
+	serviceAccountFilter := podFilter(kyvernov1.Update)
+	serviceAccountFilter.UserInfo = kyvernov1.UserInfo{
+		Subjects: []rbacv1.Subject{{Kind: "ServiceAccount", Name: "controller", Namespace: "default"}},
+	}
+	tests := []struct {
+		name      string
+		polexs    []kyvernov2.PolicyException
+		operation kyvernov1.AdmissionOperation
+		// background scans evaluate resources as if they were created
+		background bool
+		want       string
+	}{{
+		name:      "kind only",
+		polexs:    []kyvernov2.PolicyException{newTestException("kind", podFilter())},
+		operation: kyvernov1.Create,
+		want:      "kind",
+	}, {
+		name:      "operation matches",
+		polexs:    []kyvernov2.PolicyException{newTestException("update", podFilter(kyvernov1.Update))},
+		operation: kyvernov1.Update,
+		want:      "update",
+	}, {
+		name:      "operation doesn't match",
+		polexs:    []kyvernov2.PolicyException{newTestException("update", podFilter(kyvernov1.Update))},
+		operation: kyvernov1.Create,
+	}, {
+		name:      "all images match",
+		polexs:    []kyvernov2.PolicyException{newTestException("images", podFilter(), "ghcr.io/example/*")},
+		operation: kyvernov1.Create,
+		want:      "images",
+	}, {
+		name:      "some images don't match",
+		polexs:    []kyvernov2.PolicyException{newTestException("images", podFilter(), "ghcr.io/example/controller:*")},
+		operation: kyvernov1.Create,
+	}, {
+		name:      "service account, operation and images",
+		polexs:    []kyvernov2.PolicyException{newTestException("scoped", serviceAccountFilter, "ghcr.io/example/*")},
+		operation: kyvernov1.Update,
+		want:      "scoped",
+	}, {
+		name: "first exception doesn't match",
+		polexs: []kyvernov2.PolicyException{
+			newTestException("create", podFilter(kyvernov1.Create)),
+			newTestException("update", podFilter(kyvernov1.Update)),
+		},
+		operation: kyvernov1.Update,
+		want:      "update",
+	}, {
+		name:       "background scan with an operation scoped exception",
+		polexs:     []kyvernov2.PolicyException{newTestException("update", podFilter(kyvernov1.Update))},
+		operation:  kyvernov1.Create,
+		background: true,
+		want:       "update",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policyContext, err := policycontext.NewPolicyContext(
+				jmespath.New(config.NewDefaultConfiguration(false)),
+				pod,
+				tt.operation,
+				&admissionInfo,
+				config.NewDefaultConfiguration(false),
+			)
+			assert.NoError(t, err)
+			policyContext = policyContext.
+				WithResourceKind(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, "").
+				WithAdmissionOperation(!tt.background)
+			got := MatchesException(context.TODO(), nil, tt.polexs, policyContext, logr.Discard())
+			if tt.want == "" {
+				assert.Nil(t, got)
+			} else if assert.NotNil(t, got) {
+				assert.Equal(t, tt.want, got.GetName())
+			}
+		})
+	}
+}
+
+func TestMatchesException_NamespaceSelector(t *testing.T) {
+	polex := newTestException("system", kyvernov1.ResourceFilter{
+		ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod", "Namespace", "ClusterRole"}},
+	})
+	polex.Spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "system"}}
+	newResource := func(kind, namespace string, labels map[string]interface{}) unstructured.Unstructured {
+		metadata := map[string]interface{}{"name": "test"}
+		if namespace != "" {
+			metadata["namespace"] = namespace
+		}
+		if labels != nil {
+			metadata["labels"] = labels
+		}
+		return unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       kind,
+			"metadata":   metadata,
+		}}
+	}
+	tests := []struct {
+		name            string
+		resource        unstructured.Unstructured
+		namespaceLabels map[string]string
+		want            bool
+	}{{
+		name:            "namespace matches",
+		resource:        newResource("Pod", "kube-system", nil),
+		namespaceLabels: map[string]string{"tier": "system"},
+		want:            true,
+	}, {
+		name:            "namespace doesn't match",
+		resource:        newResource("Pod", "default", nil),
+		namespaceLabels: map[string]string{"tier": "apps"},
+	}, {
+		name:     "namespace resource uses its own labels",
+		resource: newResource("Namespace", "", map[string]interface{}{"tier": "system"}),
+		want:     true,
+	}, {
+		name:     "cluster scoped resource",
+		resource: newResource("ClusterRole", "", map[string]interface{}{"tier": "system"}),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policyContext, err := policycontext.NewPolicyContext(
+				jmespath.New(config.NewDefaultConfiguration(false)),
+				tt.resource,
+				kyvernov1.Create,
+				nil,
+				config.NewDefaultConfiguration(false),
+			)
+			assert.NoError(t, err)
+			policyContext = policyContext.
+				WithResourceKind(schema.GroupVersionKind{Version: "v1", Kind: tt.resource.GetKind()}, "").
+				WithNamespaceLabels(tt.namespaceLabels)
+			got := MatchesException(context.TODO(), nil, []kyvernov2.PolicyException{polex}, policyContext, logr.Discard())
+			assert.Equal(t, tt.want, got != nil)
+		})
+	}
+}
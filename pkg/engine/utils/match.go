@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"slices"
 
@@ -38,6 +39,8 @@ func checkNameSpace(namespaces []string, resource unstructured.Unstructured) boo
 //	Name       string
 //	Namespaces []string
 //	Selector
+//	FieldSelector
+//	Owner
 //
 // UserInfo:
 //
@@ -50,6 +53,8 @@ func checkNameSpace(namespaces []string, resource unstructured.Unstructured) boo
 // To filter out the targeted resources with UserInfo, the check
 // should be: OR (across & inside) attributes
 func doesResourceMatchConditionBlock(
+	ctx context.Context,
+	ownerResolver matchutils.OwnerResolver,
 	conditionBlock kyvernov1.ResourceDescription,
 	userInfo kyvernov1.UserInfo,
 	admissionInfo kyvernov1beta1.RequestInfo,
@@ -122,6 +127,28 @@ func doesResourceMatchConditionBlock(
 		}
 	}
 
+	if conditionBlock.FieldSelector != "" {
+		hasPassed, err := matchutils.CheckFieldSelector(conditionBlock.FieldSelector, resource.Object)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse field selector: %v", err))
+		} else {
+			if !hasPassed {
+				errs = append(errs, fmt.Errorf("field selector does not match"))
+			}
+		}
+	}
+
+	if conditionBlock.Owner != nil {
+		hasPassed, err := matchutils.CheckOwner(ctx, ownerResolver, *conditionBlock.Owner, resource)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to check owner: %v", err))
+		} else {
+			if !hasPassed {
+				errs = append(errs, fmt.Errorf("owner does not match"))
+			}
+		}
+	}
+
 	if conditionBlock.NamespaceSelector != nil {
 		if resource.GetKind() == "Namespace" {
 			errs = append(errs, fmt.Errorf("namespace selector is not applicable for namespace resource"))
@@ -166,6 +193,8 @@ func matchSubjects(ruleSubjects []rbacv1.Subject, userInfo authenticationv1.User
 
 // matchesResourceDescription checks if the resource matches resource description of the rule or not
 func MatchesResourceDescription(
+	ctx context.Context,
+	ownerResolver matchutils.OwnerResolver,
 	resource unstructured.Unstructured,
 	rule kyvernov1.Rule,
 	admissionInfo kyvernov1beta1.RequestInfo,
@@ -190,7 +219,7 @@ func MatchesResourceDescription(
 		oneMatched := false
 		for _, rmr := range rule.MatchResources.Any {
 			// if there are no errors it means it was a match
-			if len(matchesResourceDescriptionMatchHelper(rmr, admissionInfo, resource, namespaceLabels, gvk, subresource, operation)) == 0 {
+			if len(matchesResourceDescriptionMatchHelper(ctx, ownerResolver, rmr, admissionInfo, resource, namespaceLabels, gvk, subresource, operation)) == 0 {
 				oneMatched = true
 				break
 			}
@@ -201,11 +230,11 @@ func MatchesResourceDescription(
 	} else if len(rule.MatchResources.All) > 0 {
 		// include object if ALL of the criteria match
 		for _, rmr := range rule.MatchResources.All {
-			reasonsForFailure = append(reasonsForFailure, matchesResourceDescriptionMatchHelper(rmr, admissionInfo, resource, namespaceLabels, gvk, subresource, operation)...)
+			reasonsForFailure = append(reasonsForFailure, matchesResourceDescriptionMatchHelper(ctx, ownerResolver, rmr, admissionInfo, resource, namespaceLabels, gvk, subresource, operation)...)
 		}
 	} else {
 		rmr := kyvernov1.ResourceFilter{UserInfo: rule.MatchResources.UserInfo, ResourceDescription: rule.MatchResources.ResourceDescription}
-		reasonsForFailure = append(reasonsForFailure, matchesResourceDescriptionMatchHelper(rmr, admissionInfo, resource, namespaceLabels, gvk, subresource, operation)...)
+		reasonsForFailure = append(reasonsForFailure, matchesResourceDescriptionMatchHelper(ctx, ownerResolver, rmr, admissionInfo, resource, namespaceLabels, gvk, subresource, operation)...)
 	}
 
 	// check exlude conditions only if match succeeds
@@ -213,7 +242,7 @@ func MatchesResourceDescription(
 		if len(rule.ExcludeResources.Any) > 0 {
 			// exclude the object if ANY of the criteria match
 			for _, rer := range rule.ExcludeResources.Any {
-				reasonsForFailure = append(reasonsForFailure, matchesResourceDescriptionExcludeHelper(rer, admissionInfo, resource, namespaceLabels, gvk, subresource, operation)...)
+				reasonsForFailure = append(reasonsForFailure, matchesResourceDescriptionExcludeHelper(ctx, ownerResolver, rer, admissionInfo, resource, namespaceLabels, gvk, subresource, operation)...)
 			}
 		} else if len(rule.ExcludeResources.All) > 0 {
 			// exclude the object if ALL the criteria match
@@ -221,7 +250,7 @@ func MatchesResourceDescription(
 			for _, rer := range rule.ExcludeResources.All {
 				// we got no errors inplying a resource did NOT exclude it
 				// "matchesResourceDescriptionExcludeHelper" returns errors if resource is excluded by a filter
-				if len(matchesResourceDescriptionExcludeHelper(rer, admissionInfo, resource, namespaceLabels, gvk, subresource, operation)) == 0 {
+				if len(matchesResourceDescriptionExcludeHelper(ctx, ownerResolver, rer, admissionInfo, resource, namespaceLabels, gvk, subresource, operation)) == 0 {
 					excludedByAll = false
 					break
 				}
@@ -231,7 +260,7 @@ func MatchesResourceDescription(
 			}
 		} else {
 			rer := kyvernov1.ResourceFilter{UserInfo: rule.ExcludeResources.UserInfo, ResourceDescription: rule.ExcludeResources.ResourceDescription}
-			reasonsForFailure = append(reasonsForFailure, matchesResourceDescriptionExcludeHelper(rer, admissionInfo, resource, namespaceLabels, gvk, subresource, operation)...)
+			reasonsForFailure = append(reasonsForFailure, matchesResourceDescriptionExcludeHelper(ctx, ownerResolver, rer, admissionInfo, resource, namespaceLabels, gvk, subresource, operation)...)
 		}
 	}
 
@@ -251,6 +280,8 @@ func MatchesResourceDescription(
 }
 
 func matchesResourceDescriptionMatchHelper(
+	ctx context.Context,
+	ownerResolver matchutils.OwnerResolver,
 	rmr kyvernov1.ResourceFilter,
 	admissionInfo kyvernov1beta1.RequestInfo,
 	resource unstructured.Unstructured,
@@ -267,7 +298,7 @@ func matchesResourceDescriptionMatchHelper(
 	// checking if resource matches the rule
 	if !datautils.DeepEqual(rmr.ResourceDescription, kyvernov1.ResourceDescription{}) ||
 		!datautils.DeepEqual(rmr.UserInfo, kyvernov1.UserInfo{}) {
-		matchErrs := doesResourceMatchConditionBlock(rmr.ResourceDescription, rmr.UserInfo, admissionInfo, resource, namespaceLabels, gvk, subresource, operation)
+		matchErrs := doesResourceMatchConditionBlock(ctx, ownerResolver, rmr.ResourceDescription, rmr.UserInfo, admissionInfo, resource, namespaceLabels, gvk, subresource, operation)
 		errs = append(errs, matchErrs...)
 	} else {
 		errs = append(errs, fmt.Errorf("match cannot be empty"))
@@ -276,6 +307,8 @@ func matchesResourceDescriptionMatchHelper(
 }
 
 func matchesResourceDescriptionExcludeHelper(
+	ctx context.Context,
+	ownerResolver matchutils.OwnerResolver,
 	rer kyvernov1.ResourceFilter,
 	admissionInfo kyvernov1beta1.RequestInfo,
 	resource unstructured.Unstructured,
@@ -288,7 +321,7 @@ func matchesResourceDescriptionExcludeHelper(
 	// checking if resource matches the rule
 	if !datautils.DeepEqual(rer.ResourceDescription, kyvernov1.ResourceDescription{}) ||
 		!datautils.DeepEqual(rer.UserInfo, kyvernov1.UserInfo{}) {
-		excludeErrs := doesResourceMatchConditionBlock(rer.ResourceDescription, rer.UserInfo, admissionInfo, resource, namespaceLabels, gvk, subresource, operation)
+		excludeErrs := doesResourceMatchConditionBlock(ctx, ownerResolver, rer.ResourceDescription, rer.UserInfo, admissionInfo, resource, namespaceLabels, gvk, subresource, operation)
 		// it was a match so we want to exclude it
 		if len(excludeErrs) == 0 {
 			errs = append(errs, fmt.Errorf("resource excluded since one of the criteria excluded it"))
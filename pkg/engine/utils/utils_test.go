@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"context"
+
 	"encoding/json"
 	"testing"
 
@@ -904,8 +906,8 @@ func TestMatchesResourceDescription(t *testing.T) {
 		}
 		resource, _ := kubeutils.BytesToUnstructured(tc.Resource)
 
-		for _, rule := range autogen.ComputeRules(&policy) {
-			err := MatchesResourceDescription(*resource, rule, tc.AdmissionInfo, nil, "", resource.GroupVersionKind(), "", "CREATE")
+		for _, rule := range autogen.ComputeRules(&policy, nil) {
+			err := MatchesResourceDescription(context.TODO(), nil, *resource, rule, tc.AdmissionInfo, nil, "", resource.GroupVersionKind(), "", "CREATE")
 			if err != nil {
 				if !tc.areErrorsExpected {
 					t.Errorf("Testcase %d Unexpected error: %v\nmsg: %s", i+1, err, tc.Description)
@@ -1809,8 +1811,8 @@ func TestMatchesResourceDescription_GenerateName(t *testing.T) {
 		}
 		resource, _ := kubeutils.BytesToUnstructured(tc.Resource)
 
-		for _, rule := range autogen.ComputeRules(&policy) {
-			err := MatchesResourceDescription(*resource, rule, tc.AdmissionInfo, nil, "", resource.GroupVersionKind(), "", "CREATE")
+		for _, rule := range autogen.ComputeRules(&policy, nil) {
+			err := MatchesResourceDescription(context.TODO(), nil, *resource, rule, tc.AdmissionInfo, nil, "", resource.GroupVersionKind(), "", "CREATE")
 			if err != nil {
 				if !tc.areErrorsExpected {
 					t.Errorf("Testcase %d Unexpected error: %v\nmsg: %s", i+1, err, tc.Description)
@@ -1877,7 +1879,7 @@ func TestResourceDescriptionMatch_MultipleKind(t *testing.T) {
 	}
 	rule := v1.Rule{MatchResources: v1.MatchResources{ResourceDescription: resourceDescription}}
 
-	if err := MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, nil, "", resource.GroupVersionKind(), "", "CREATE"); err != nil {
+	if err := MatchesResourceDescription(context.TODO(), nil, *resource, rule, v1beta1.RequestInfo{}, nil, "", resource.GroupVersionKind(), "", "CREATE"); err != nil {
 		t.Errorf("Testcase has failed due to the following:%v", err)
 	}
 }
@@ -1980,7 +1982,7 @@ func TestResourceDescriptionMatch_ExcludeDefaultGroups(t *testing.T) {
 	}
 
 	// First test: confirm that this above rule produces errors (and raise an error if err == nil)
-	if err := MatchesResourceDescription(*resource, rule, requestInfo, nil, "", resource.GroupVersionKind(), "", "CREATE"); err == nil {
+	if err := MatchesResourceDescription(context.TODO(), nil, *resource, rule, requestInfo, nil, "", resource.GroupVersionKind(), "", "CREATE"); err == nil {
 		t.Error("Testcase was expected to fail, but err was nil")
 	}
 
@@ -2000,7 +2002,7 @@ func TestResourceDescriptionMatch_ExcludeDefaultGroups(t *testing.T) {
 	}
 
 	// Second test: confirm that matching this rule does not create any errors (and raise if err != nil)
-	if err := MatchesResourceDescription(*resource, rule2, requestInfo, nil, "", resource.GroupVersionKind(), "", "CREATE"); err != nil {
+	if err := MatchesResourceDescription(context.TODO(), nil, *resource, rule2, requestInfo, nil, "", resource.GroupVersionKind(), "", "CREATE"); err != nil {
 		t.Errorf("Testcase was expected to not fail, but err was %s", err)
 	}
 
@@ -2014,7 +2016,7 @@ func TestResourceDescriptionMatch_ExcludeDefaultGroups(t *testing.T) {
 	}}
 
 	// Third test: confirm that now the custom exclude-snippet should run in CheckSubjects() and that should result in this rule failing (raise if err == nil for that reason)
-	if err := MatchesResourceDescription(*resource, rule2, requestInfo, nil, "", resource.GroupVersionKind(), "", "CREATE"); err == nil {
+	if err := MatchesResourceDescription(context.TODO(), nil, *resource, rule2, requestInfo, nil, "", resource.GroupVersionKind(), "", "CREATE"); err == nil {
 		t.Error("Testcase was expected to fail, but err was nil #1!")
 	}
 }
@@ -2073,7 +2075,7 @@ func TestResourceDescriptionMatch_Name(t *testing.T) {
 	}
 	rule := v1.Rule{MatchResources: v1.MatchResources{ResourceDescription: resourceDescription}}
 
-	if err := MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, nil, "", resource.GroupVersionKind(), "", "CREATE"); err != nil {
+	if err := MatchesResourceDescription(context.TODO(), nil, *resource, rule, v1beta1.RequestInfo{}, nil, "", resource.GroupVersionKind(), "", "CREATE"); err != nil {
 		t.Errorf("Testcase has failed due to the following:%v", err)
 	}
 }
@@ -2131,7 +2133,7 @@ func TestResourceDescriptionMatch_GenerateName(t *testing.T) {
 	}
 	rule := v1.Rule{MatchResources: v1.MatchResources{ResourceDescription: resourceDescription}}
 
-	if err := MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, nil, "", resource.GroupVersionKind(), "", "CREATE"); err != nil {
+	if err := MatchesResourceDescription(context.TODO(), nil, *resource, rule, v1beta1.RequestInfo{}, nil, "", resource.GroupVersionKind(), "", "CREATE"); err != nil {
 		t.Errorf("Testcase has failed due to the following:%v", err)
 	}
 }
@@ -2190,7 +2192,7 @@ func TestResourceDescriptionMatch_Name_Regex(t *testing.T) {
 	}
 	rule := v1.Rule{MatchResources: v1.MatchResources{ResourceDescription: resourceDescription}}
 
-	if err := MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, nil, "", resource.GroupVersionKind(), "", "CREATE"); err != nil {
+	if err := MatchesResourceDescription(context.TODO(), nil, *resource, rule, v1beta1.RequestInfo{}, nil, "", resource.GroupVersionKind(), "", "CREATE"); err != nil {
 		t.Errorf("Testcase has failed due to the following:%v", err)
 	}
 }
@@ -2248,7 +2250,7 @@ func TestResourceDescriptionMatch_GenerateName_Regex(t *testing.T) {
 	}
 	rule := v1.Rule{MatchResources: v1.MatchResources{ResourceDescription: resourceDescription}}
 
-	if err := MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, nil, "", resource.GroupVersionKind(), "", "CREATE"); err != nil {
+	if err := MatchesResourceDescription(context.TODO(), nil, *resource, rule, v1beta1.RequestInfo{}, nil, "", resource.GroupVersionKind(), "", "CREATE"); err != nil {
 		t.Errorf("Testcase has failed due to the following:%v", err)
 	}
 }
@@ -2315,7 +2317,7 @@ func TestResourceDescriptionMatch_Label_Expression_NotMatch(t *testing.T) {
 	}
 	rule := v1.Rule{MatchResources: v1.MatchResources{ResourceDescription: resourceDescription}}
 
-	if err := MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, nil, "", resource.GroupVersionKind(), "", "CREATE"); err != nil {
+	if err := MatchesResourceDescription(context.TODO(), nil, *resource, rule, v1beta1.RequestInfo{}, nil, "", resource.GroupVersionKind(), "", "CREATE"); err != nil {
 		t.Errorf("Testcase has failed due to the following:%v", err)
 	}
 }
@@ -2383,7 +2385,7 @@ func TestResourceDescriptionMatch_Label_Expression_Match(t *testing.T) {
 	}
 	rule := v1.Rule{MatchResources: v1.MatchResources{ResourceDescription: resourceDescription}}
 
-	if err := MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, nil, "", resource.GroupVersionKind(), "", "CREATE"); err != nil {
+	if err := MatchesResourceDescription(context.TODO(), nil, *resource, rule, v1beta1.RequestInfo{}, nil, "", resource.GroupVersionKind(), "", "CREATE"); err != nil {
 		t.Errorf("Testcase has failed due to the following:%v", err)
 	}
 }
@@ -2464,7 +2466,87 @@ func TestResourceDescriptionExclude_Label_Expression_Match(t *testing.T) {
 		ExcludeResources: v1.MatchResources{ResourceDescription: resourceDescriptionExclude},
 	}
 
-	if err := MatchesResourceDescription(*resource, rule, v1beta1.RequestInfo{}, nil, "", resource.GroupVersionKind(), "", "CREATE"); err == nil {
+	if err := MatchesResourceDescription(context.TODO(), nil, *resource, rule, v1beta1.RequestInfo{}, nil, "", resource.GroupVersionKind(), "", "CREATE"); err == nil {
 		t.Errorf("Testcase has failed due to the following:\n Function has returned no error, even though it was supposed to fail")
 	}
 }
+
+func TestResourceDescriptionMatch_FieldSelector(t *testing.T) {
+	rawResource := []byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+		   "name": "nginx"
+		},
+		"spec": {
+		   "nodeName": "node-1",
+		   "containers": [
+			  {
+				 "name": "nginx",
+				 "image": "nginx:1.7.9"
+			  }
+		   ]
+		},
+		"status": {
+		   "phase": "Running"
+		}
+	 }`)
+	resource, err := kubeutils.BytesToUnstructured(rawResource)
+	if err != nil {
+		t.Errorf("unable to convert raw resource to unstructured: %v", err)
+	}
+	match := v1.ResourceDescription{
+		Kinds:         []string{"Pod"},
+		FieldSelector: "spec.nodeName=node-*",
+	}
+	rule := v1.Rule{MatchResources: v1.MatchResources{ResourceDescription: match}}
+	if err := MatchesResourceDescription(context.TODO(), nil, *resource, rule, v1beta1.RequestInfo{}, nil, "", resource.GroupVersionKind(), "", "CREATE"); err != nil {
+		t.Errorf("Testcase has failed due to the following:%v", err)
+	}
+	rule.ExcludeResources = v1.MatchResources{ResourceDescription: v1.ResourceDescription{FieldSelector: "status.phase=Running"}}
+	if err := MatchesResourceDescription(context.TODO(), nil, *resource, rule, v1beta1.RequestInfo{}, nil, "", resource.GroupVersionKind(), "", "CREATE"); err == nil {
+		t.Errorf("Testcase has failed, the resource should be excluded")
+	}
+	rule = v1.Rule{MatchResources: v1.MatchResources{ResourceDescription: v1.ResourceDescription{Kinds: []string{"Pod"}, FieldSelector: "spec.nodeName!=node-1"}}}
+	if err := MatchesResourceDescription(context.TODO(), nil, *resource, rule, v1beta1.RequestInfo{}, nil, "", resource.GroupVersionKind(), "", "CREATE"); err == nil {
+		t.Errorf("Testcase has failed, the resource should not match")
+	}
+}
+
+func TestResourceDescriptionMatch_Owner(t *testing.T) {
+	rawResource := []byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+		   "name": "nightly-28000000-abcde",
+		   "ownerReferences": [
+			  {
+				 "apiVersion": "batch/v1",
+				 "kind": "Job",
+				 "name": "nightly-28000000",
+				 "uid": "1",
+				 "controller": true
+			  }
+		   ]
+		}
+	 }`)
+	resource, err := kubeutils.BytesToUnstructured(rawResource)
+	if err != nil {
+		t.Errorf("unable to convert raw resource to unstructured: %v", err)
+	}
+	rule := v1.Rule{MatchResources: v1.MatchResources{ResourceDescription: v1.ResourceDescription{
+		Kinds: []string{"Pod"},
+		Owner: &v1.OwnerDescription{Kinds: []string{"Job"}, Names: []string{"nightly-*"}},
+	}}}
+	if err := MatchesResourceDescription(context.TODO(), nil, *resource, rule, v1beta1.RequestInfo{}, nil, "", resource.GroupVersionKind(), "", "CREATE"); err != nil {
+		t.Errorf("Testcase has failed due to the following:%v", err)
+	}
+	rule.ExcludeResources = v1.MatchResources{ResourceDescription: v1.ResourceDescription{Owner: &v1.OwnerDescription{Kinds: []string{"Job"}}}}
+	if err := MatchesResourceDescription(context.TODO(), nil, *resource, rule, v1beta1.RequestInfo{}, nil, "", resource.GroupVersionKind(), "", "CREATE"); err == nil {
+		t.Errorf("Testcase has failed, the resource should be excluded")
+	}
+	rule = v1.Rule{MatchResources: v1.MatchResources{ResourceDescription: v1.ResourceDescription{Kinds: []string{"Pod"}, Owner: &v1.OwnerDescription{Kinds: []string{"ReplicaSet"}}}}}
+	if err := MatchesResourceDescription(context.TODO(), nil, *resource, rule, v1beta1.RequestInfo{}, nil, "", resource.GroupVersionKind(), "", "CREATE"); err == nil {
+		t.Errorf("Testcase has failed, the resource should not match")
+	}
+}
@@ -26,7 +26,7 @@ func (e *engine) validate(
 	policyContext.JSONContext().Checkpoint()
 	defer policyContext.JSONContext().Restore()
 
-	for _, rule := range autogen.ComputeRules(policy) {
+	for _, rule := range autogen.ComputeRules(policy, e.configuration.GetAutogenControllers()) {
 		startTime := time.Now()
 		logger := internal.LoggerWithRule(logger, rule)
 		handlerFactory := func() (handlers.Handler, error) {
@@ -39,17 +39,21 @@ func (e *engine) validate(
 				hasVerifyManifest := rule.HasVerifyManifests()
 				hasValidatePss := rule.HasValidatePodSecurity()
 				hasValidateCEL := rule.HasValidateCEL()
+				hasValidateWASM := rule.HasValidateWASM()
 				if hasVerifyManifest {
 					return validation.NewValidateManifestHandler(
 						policyContext,
 						e.client,
+						e.ownerResolver,
 					)
 				} else if hasValidatePss {
-					return validation.NewValidatePssHandler()
+					return validation.NewValidatePssHandler(e.ownerResolver)
 				} else if hasValidateCEL {
-					return validation.NewValidateCELHandler(e.client)
+					return validation.NewValidateCELHandler(e.client, e.compiledCache, e.ownerResolver)
+				} else if hasValidateWASM {
+					return validation.NewValidateWASMHandler(e.wasmRuntime, e.ownerResolver)
 				} else {
-					return validation.NewValidateResourceHandler()
+					return validation.NewValidateResourceHandler(e.ownerResolver)
 				}
 			} else if hasVerifyImageChecks {
 				return validation.NewValidateImageHandler(
@@ -57,6 +61,7 @@ func (e *engine) validate(
 					policyContext.NewResource(),
 					rule,
 					e.configuration,
+					e.ownerResolver,
 				)
 			}
 			return nil, nil
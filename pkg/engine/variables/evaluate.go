@@ -55,6 +55,10 @@ func EvaluateAnyAllConditions(log logr.Logger, ctx context.EvalInterface, condit
 
 // evaluateAnyAllConditions evaluates multiple conditions as a logical AND (all) or OR (any) operation depending on the conditions
 func evaluateAnyAllConditions(log logr.Logger, ctx context.EvalInterface, conditions kyvernov1.AnyAllConditions) (bool, string, error) {
+	// condition templates are expanded when rules are computed, references left at this point could not be resolved
+	if len(conditions.Templates) != 0 {
+		return false, "", fmt.Errorf("unresolved condition templates %v", conditions.Templates)
+	}
 	anyConditions, allConditions := conditions.AnyConditions, conditions.AllConditions
 	anyConditionsResult, allConditionsResult := true, true
 	var conditionFalseMessages []string
@@ -516,3 +516,18 @@ func Test_Condition_Messages(t *testing.T) {
 	assert.Equal(t, false, val)
 	assert.Contains(t, msg, "invalid name; invalid foo; invalid foo2")
 }
+
+func Test_Unresolved_Condition_Templates(t *testing.T) {
+	ctx := context.NewContext(jmespath.New(config.NewDefaultConfiguration(false)))
+	conditions := []kyverno.AnyAllConditions{{
+		AllConditions: []kyverno.Condition{{
+			RawKey:   kyverno.ToJSON("foo"),
+			Operator: kyverno.ConditionOperators["Equal"],
+			RawValue: kyverno.ToJSON("foo"),
+		}},
+		Templates: []string{"is-production"},
+	}}
+	val, _, err := EvaluateAnyAllConditions(logr.Discard(), ctx, conditions)
+	assert.EqualError(t, err, "unresolved condition templates [is-production]")
+	assert.Equal(t, false, val)
+}
@@ -0,0 +1,102 @@
+package wasm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+)
+
+const (
+	// ociScheme is the scheme of the module references
+	ociScheme = "oci://"
+	// maxModuleSize is the max size of a module layer
+	maxModuleSize = 32 << 20
+)
+
+// moduleLayerMediaTypes are the media types of the image layers containing a WebAssembly module
+var moduleLayerMediaTypes = []string{
+	"application/vnd.wasm.content.layer.v1+wasm",
+	"application/vnd.module.wasm.content.layer.v1+wasm",
+	"application/wasm",
+}
+
+// wasmMagic is the preamble of the WebAssembly binary format
+var wasmMagic = []byte{0x00, 0x61, 0x73, 0x6d}
+
+// Fetcher downloads the binary of a module from its reference
+type Fetcher = func(ctx context.Context, module string) ([]byte, error)
+
+// RegistryFetcher returns a fetcher pulling modules pushed as OCI artifacts, the registry client
+// is created by the factory with the credentials configured for the engine.
+func RegistryFetcher(rclientFactory engineapi.RegistryClientFactory) Fetcher {
+	return func(ctx context.Context, module string) ([]byte, error) {
+		if !strings.HasPrefix(module, ociScheme) {
+			return nil, fmt.Errorf("unsupported module reference %s, only %s references are supported", module, ociScheme)
+		}
+		ref, err := name.ParseReference(strings.TrimPrefix(module, ociScheme))
+		if err != nil {
+			return nil, fmt.Errorf("parsing module reference: %w", err)
+		}
+		if rclientFactory == nil {
+			return nil, errors.New("registry client factory is not configured")
+		}
+		rclient, err := rclientFactory.GetClient(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		options, err := rclient.Options(ctx)
+		if err != nil {
+			return nil, err
+		}
+		img, err := remote.Image(ref, options...)
+		if err != nil {
+			return nil, fmt.Errorf("getting module image: %w", err)
+		}
+		layers, err := img.Layers()
+		if err != nil {
+			return nil, fmt.Errorf("getting module image layers: %w", err)
+		}
+		for _, layer := range layers {
+			mediaType, err := layer.MediaType()
+			if err != nil {
+				return nil, fmt.Errorf("getting layer media type: %w", err)
+			}
+			if len(layers) != 1 && !isModuleLayer(string(mediaType)) {
+				continue
+			}
+			blob, err := layer.Compressed()
+			if err != nil {
+				return nil, fmt.Errorf("getting layer blob: %w", err)
+			}
+			data, err := io.ReadAll(io.LimitReader(blob, maxModuleSize+1))
+			blob.Close()
+			if err != nil {
+				return nil, fmt.Errorf("reading layer blob: %w", err)
+			}
+			if len(data) > maxModuleSize {
+				return nil, fmt.Errorf("module is larger than %d bytes", maxModuleSize)
+			}
+			if !bytes.HasPrefix(data, wasmMagic) {
+				return nil, errors.New("layer is not a WebAssembly binary")
+			}
+			return data, nil
+		}
+		return nil, errors.New("no WebAssembly layer found in the module image")
+	}
+}
+
+func isModuleLayer(mediaType string) bool {
+	for _, moduleLayerMediaType := range moduleLayerMediaTypes {
+		if mediaType == moduleLayerMediaType {
+			return true
+		}
+	}
+	return false
+}
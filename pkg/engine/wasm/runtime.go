@@ -0,0 +1,301 @@
+package wasm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+const (
+	// memoryLimitPages is the max memory of a module instance, in 64KiB pages
+	memoryLimitPages = 512
+	// callTimeout is the max duration of a call to a module, the instance is closed when it expires
+	callTimeout = 5 * time.Second
+	// moduleTTL is the duration after which the module of a reference is pulled again,
+	// and after which an unused reference is forgotten
+	moduleTTL = 10 * time.Minute
+	// maxInstances is the max number of module instances at the same time, calls wait for an instance to be closed
+	maxInstances = 16
+	// allocFunction is the function exported by the modules to allocate the input in their memory
+	allocFunction = "alloc"
+)
+
+// Input is the JSON document passed to the entrypoint of a module
+type Input struct {
+	Resource    map[string]interface{} `json:"resource"`
+	OldResource map[string]interface{} `json:"oldResource,omitempty"`
+	Context     interface{}            `json:"context,omitempty"`
+}
+
+// Result is the JSON document returned by the entrypoint of a module
+type Result struct {
+	Allowed bool   `json:"allowed"`
+	Message string `json:"message,omitempty"`
+}
+
+// Runtime executes the validation functions of WebAssembly modules.
+//
+// Modules are instantiated in a sandbox for every call: they have no access to the file system,
+// the network or the environment, their memory is limited and calls are interrupted after a timeout.
+// Modules may import WASI (preview 1) for their language runtime, and must export:
+//   - a memory named "memory"
+//   - an "alloc(size i32) i32" function returning the address of a buffer of the given size
+//   - the entrypoint, an "(address i32, size i32) i64" function receiving the input JSON document
+//     and returning the address of the result JSON document in the high 32 bits and its size in the low 32 bits
+type Runtime interface {
+	// Validate calls the entrypoint of a module with the input
+	Validate(ctx context.Context, module string, entrypoint string, input Input) (*Result, error)
+}
+
+type cachedModule struct {
+	digest  [sha256.Size]byte
+	fetched time.Time
+	used    time.Time
+}
+
+type compiledModule struct {
+	module wazero.CompiledModule
+	// calls is the number of calls using the module, it is closed only when no call uses it
+	calls int
+}
+
+// pull is a pull in progress, concurrent loads of the same reference wait for it instead of pulling again
+type pull struct {
+	done chan struct{}
+	err  error
+}
+
+type runtime struct {
+	logger    logr.Logger
+	fetcher   Fetcher
+	now       func() time.Time
+	timeout   time.Duration
+	once      sync.Once
+	sandbox   wazero.Runtime
+	instances chan struct{}
+	lock      sync.Mutex
+	modules   map[string]cachedModule
+	pulls     map[string]*pull
+	// compiled holds the compiled modules by digest, a module is compiled once even if
+	// several references point to it or if its reference is pulled again
+	compiled map[[sha256.Size]byte]*compiledModule
+}
+
+// NewRuntime creates a runtime pulling modules with the fetcher, the wazero runtime is created on first use.
+func NewRuntime(logger logr.Logger, fetcher Fetcher) Runtime {
+	return &runtime{
+		logger:    logger,
+		fetcher:   fetcher,
+		now:       time.Now,
+		timeout:   callTimeout,
+		instances: make(chan struct{}, maxInstances),
+		modules:   map[string]cachedModule{},
+		pulls:     map[string]*pull{},
+		compiled:  map[[sha256.Size]byte]*compiledModule{},
+	}
+}
+
+func (r *runtime) Validate(ctx context.Context, module string, entrypoint string, input Input) (*Result, error) {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal module input: %w", err)
+	}
+	compiled, err := r.load(ctx, module)
+	if err != nil {
+		return nil, err
+	}
+	defer r.release(compiled)
+	output, err := r.call(ctx, compiled, entrypoint, data)
+	if err != nil {
+		return nil, err
+	}
+	var result Result
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal module result: %w", err)
+	}
+	return &result, nil
+}
+
+func (r *runtime) init() {
+	r.once.Do(func() {
+		ctx := context.Background()
+		config := wazero.NewRuntimeConfig().
+			WithMemoryLimitPages(memoryLimitPages).
+			WithCloseOnContextDone(true)
+		r.sandbox = wazero.NewRuntimeWithConfig(ctx, config)
+		// WASI is instantiated without preopened directories, environment or arguments,
+		// the standard streams are discarded
+		wasi_snapshot_preview1.MustInstantiate(ctx, r.sandbox)
+	})
+}
+
+// load returns the compiled module of a reference, the module is pulled again when its cache entry expired,
+// the cached module is used when pulling fails. Concurrent loads of a reference share a single pull.
+// The returned module must be released when the call is done.
+func (r *runtime) load(ctx context.Context, module string) (*compiledModule, error) {
+	r.init()
+	r.lock.Lock()
+	if cached, found := r.modules[module]; found && r.now().Sub(cached.fetched) < moduleTTL {
+		defer r.lock.Unlock()
+		return r.acquire(module)
+	}
+	p, pulling := r.pulls[module]
+	if !pulling {
+		p = &pull{done: make(chan struct{})}
+		r.pulls[module] = p
+	}
+	r.lock.Unlock()
+	if pulling {
+		select {
+		case <-p.done:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to pull module %s: %w", module, ctx.Err())
+		}
+	} else {
+		p.err = r.pull(ctx, module)
+		r.lock.Lock()
+		delete(r.pulls, module)
+		r.lock.Unlock()
+		close(p.done)
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.acquire(module)
+}
+
+// pull fetches and compiles the module of a reference, the cached module is kept when fetching fails
+func (r *runtime) pull(ctx context.Context, module string) error {
+	binary, err := r.fetcher(ctx, module)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if err != nil {
+		if _, found := r.modules[module]; found {
+			r.logger.Error(err, "failed to pull module, using the cached module", "module", module)
+			return nil
+		}
+		return fmt.Errorf("failed to pull module %s: %w", module, err)
+	}
+	digest := sha256.Sum256(binary)
+	if r.compiled[digest] == nil {
+		compiled, err := r.sandbox.CompileModule(ctx, binary)
+		if err != nil {
+			return fmt.Errorf("failed to compile module %s: %w", module, err)
+		}
+		r.compiled[digest] = &compiledModule{module: compiled}
+	}
+	now := r.now()
+	r.modules[module] = cachedModule{digest: digest, fetched: now, used: now}
+	// the previous module of the reference may not be referenced anymore
+	r.sweep()
+	return nil
+}
+
+// acquire returns the compiled module of a cached reference and marks it in use, the lock must be held
+func (r *runtime) acquire(module string) (*compiledModule, error) {
+	cached, found := r.modules[module]
+	if !found {
+		return nil, fmt.Errorf("module %s was evicted", module)
+	}
+	cached.used = r.now()
+	r.modules[module] = cached
+	compiled := r.compiled[cached.digest]
+	compiled.calls++
+	return compiled, nil
+}
+
+// release marks the end of a call using the module and evicts the modules that are not used anymore
+func (r *runtime) release(compiled *compiledModule) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	compiled.calls--
+	r.sweep()
+}
+
+// sweep forgets the references unused for longer than the module TTL, and closes the compiled modules
+// no reference points to once no call uses them, the lock must be held
+func (r *runtime) sweep() {
+	now := r.now()
+	referenced := map[[sha256.Size]byte]struct{}{}
+	for module, cached := range r.modules {
+		if now.Sub(cached.used) >= moduleTTL {
+			delete(r.modules, module)
+			continue
+		}
+		referenced[cached.digest] = struct{}{}
+	}
+	for digest, compiled := range r.compiled {
+		if _, ok := referenced[digest]; ok || compiled.calls > 0 {
+			continue
+		}
+		delete(r.compiled, digest)
+		if err := compiled.module.Close(context.Background()); err != nil {
+			r.logger.Error(err, "failed to close module")
+		}
+	}
+}
+
+// call instantiates the module, writes the input in its memory and calls the entrypoint
+func (r *runtime) call(ctx context.Context, compiled *compiledModule, entrypoint string, input []byte) ([]byte, error) {
+	select {
+	case r.instances <- struct{}{}:
+		defer func() { <-r.instances }()
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to instantiate module: %w", ctx.Err())
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	// reactor modules export _initialize instead of _start, missing start functions are skipped
+	config := wazero.NewModuleConfig().WithName("").WithStartFunctions("_initialize")
+	instance, err := r.sandbox.InstantiateModule(ctx, compiled.module, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate module: %w", err)
+	}
+	defer instance.Close(context.WithoutCancel(ctx))
+	memory := instance.Memory()
+	if memory == nil {
+		return nil, errors.New("module doesn't export a memory")
+	}
+	alloc := instance.ExportedFunction(allocFunction)
+	if alloc == nil {
+		return nil, fmt.Errorf("module doesn't export the %s function", allocFunction)
+	}
+	function := instance.ExportedFunction(entrypoint)
+	if function == nil {
+		return nil, fmt.Errorf("module doesn't export the %s function", entrypoint)
+	}
+	results, err := alloc.Call(ctx, uint64(len(input)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate module input: %w", err)
+	}
+	if len(results) != 1 {
+		return nil, fmt.Errorf("unexpected %s function signature", allocFunction)
+	}
+	address := uint32(results[0])
+	if !memory.Write(address, input) {
+		return nil, errors.New("module input address is out of range")
+	}
+	results, err = function.Call(ctx, uint64(address), uint64(len(input)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", entrypoint, err)
+	}
+	if len(results) != 1 {
+		return nil, fmt.Errorf("unexpected %s function signature", entrypoint)
+	}
+	output, ok := memory.Read(uint32(results[0]>>32), uint32(results[0]))
+	if !ok {
+		return nil, errors.New("module result address is out of range")
+	}
+	// the memory is released when the instance is closed
+	return append([]byte(nil), output...), nil
+}
@@ -0,0 +1,193 @@
+package wasm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"gotest.tools/assert"
+)
+
+// checksModule is a module implementing the ABI expected by the runtime, assembled from:
+//
+//	(module
+//	  (memory (export "memory") 1)
+//	  (global $heap (mut i32) (i32.const 1024))
+//	  (func (export "alloc") (param $size i32) (result i32)
+//	    global.get $heap
+//	    (global.set $heap (i32.add (global.get $heap) (local.get $size))))
+//	  (func (export "allow") (param i32 i32) (result i64) (i64.const 16))
+//	  (func (export "deny") (param i32 i32) (result i64) (i64.const 0x4000000031))
+//	  (func (export "echo") (param $ptr i32) (param $len i32) (result i64)
+//	    (i64.or (i64.shl (i64.extend_i32_u (local.get $ptr)) (i64.const 32)) (i64.extend_i32_u (local.get $len))))
+//	  (func (export "loop") (param i32 i32) (result i64) (loop (br 0)) unreachable)
+//	  (data (i32.const 0) "{\"allowed\":true}")
+//	  (data (i32.const 64) "{\"allowed\":false,\"message\":\"image is not signed\"}"))
+var checksModule = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, 0x01, 0x0c, 0x02, 0x60, 0x01, 0x7f, 0x01, 0x7f,
+	0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7e, 0x03, 0x06, 0x05, 0x00, 0x01, 0x01, 0x01, 0x01, 0x05, 0x03,
+	0x01, 0x00, 0x01, 0x06, 0x07, 0x01, 0x7f, 0x01, 0x41, 0x80, 0x08, 0x0b, 0x07, 0x2f, 0x06, 0x06,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x02, 0x00, 0x05, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x00, 0x00,
+	0x05, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x00, 0x01, 0x04, 0x64, 0x65, 0x6e, 0x79, 0x00, 0x02, 0x04,
+	0x65, 0x63, 0x68, 0x6f, 0x00, 0x03, 0x04, 0x6c, 0x6f, 0x6f, 0x70, 0x00, 0x04, 0x0a, 0x32, 0x05,
+	0x0b, 0x00, 0x23, 0x00, 0x23, 0x00, 0x20, 0x00, 0x6a, 0x24, 0x00, 0x0b, 0x04, 0x00, 0x42, 0x10,
+	0x0b, 0x09, 0x00, 0x42, 0xb1, 0x80, 0x80, 0x80, 0x80, 0x08, 0x0b, 0x0c, 0x00, 0x20, 0x00, 0xad,
+	0x42, 0x20, 0x86, 0x20, 0x01, 0xad, 0x84, 0x0b, 0x08, 0x00, 0x03, 0x40, 0x0c, 0x00, 0x0b, 0x00,
+	0x0b, 0x0b, 0x4d, 0x02, 0x00, 0x41, 0x00, 0x0b, 0x10, 0x7b, 0x22, 0x61, 0x6c, 0x6c, 0x6f, 0x77,
+	0x65, 0x64, 0x22, 0x3a, 0x74, 0x72, 0x75, 0x65, 0x7d, 0x00, 0x41, 0xc0, 0x00, 0x0b, 0x31, 0x7b,
+	0x22, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x22, 0x3a, 0x66, 0x61, 0x6c, 0x73, 0x65, 0x2c,
+	0x22, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x3a, 0x22, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x20, 0x69, 0x73, 0x20, 0x6e, 0x6f, 0x74, 0x20, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x22, 0x7d,
+}
+
+func newTestRuntime(fetched *int) *runtime {
+	return NewRuntime(logr.Discard(), func(context.Context, string) ([]byte, error) {
+		*fetched++
+		return checksModule, nil
+	}).(*runtime)
+}
+
+func TestValidate(t *testing.T) {
+	var fetched int
+	r := newTestRuntime(&fetched)
+	input := Input{Resource: map[string]interface{}{"kind": "Pod"}}
+	result, err := r.Validate(context.Background(), "oci://ghcr.io/org/checks:v1", "allow", input)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, *result, Result{Allowed: true})
+	result, err = r.Validate(context.Background(), "oci://ghcr.io/org/checks:v1", "deny", input)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, *result, Result{Allowed: false, Message: "image is not signed"})
+	assert.Equal(t, fetched, 1, "the module must be cached")
+	_, err = r.Validate(context.Background(), "oci://ghcr.io/org/checks:v1", "missing", input)
+	assert.ErrorContains(t, err, "module doesn't export the missing function")
+}
+
+func TestCallInput(t *testing.T) {
+	var fetched int
+	r := newTestRuntime(&fetched)
+	compiled, err := r.load(context.Background(), "oci://ghcr.io/org/checks:v1")
+	assert.NilError(t, err)
+	defer r.release(compiled)
+	input, err := json.Marshal(Input{
+		Resource: map[string]interface{}{"kind": "Pod"},
+		Context:  map[string]interface{}{"request": map[string]interface{}{"operation": "CREATE"}},
+	})
+	assert.NilError(t, err)
+	output, err := r.call(context.Background(), compiled, "echo", input)
+	assert.NilError(t, err)
+	assert.Equal(t, string(output), `{"resource":{"kind":"Pod"},"context":{"request":{"operation":"CREATE"}}}`)
+}
+
+func TestCallTimeout(t *testing.T) {
+	var fetched int
+	r := newTestRuntime(&fetched)
+	r.timeout = 100 * time.Millisecond
+	_, err := r.Validate(context.Background(), "oci://ghcr.io/org/checks:v1", "loop", Input{})
+	assert.ErrorContains(t, err, "failed to call loop")
+}
+
+func TestModuleCache(t *testing.T) {
+	var fetched int
+	now := time.Now()
+	r := NewRuntime(logr.Discard(), func(context.Context, string) ([]byte, error) {
+		fetched++
+		if fetched > 2 {
+			return nil, errors.New("registry unavailable")
+		}
+		return checksModule, nil
+	}).(*runtime)
+	r.now = func() time.Time { return now }
+	_, err := r.Validate(context.Background(), "oci://ghcr.io/org/checks:v1", "allow", Input{})
+	assert.NilError(t, err)
+	now = now.Add(moduleTTL)
+	_, err = r.Validate(context.Background(), "oci://ghcr.io/org/checks:v1", "allow", Input{})
+	assert.NilError(t, err)
+	assert.Equal(t, fetched, 2, "the module must be pulled again when it expired")
+	assert.Equal(t, len(r.compiled), 1, "the module must be compiled once")
+	now = now.Add(moduleTTL)
+	_, err = r.Validate(context.Background(), "oci://ghcr.io/org/checks:v1", "allow", Input{})
+	assert.NilError(t, err, "the cached module must be used when the registry is unavailable")
+	_, err = r.Validate(context.Background(), "oci://ghcr.io/org/other:v1", "allow", Input{})
+	assert.ErrorContains(t, err, "failed to pull module oci://ghcr.io/org/other:v1")
+}
+
+func TestModuleEviction(t *testing.T) {
+	var fetched int
+	now := time.Now()
+	r := NewRuntime(logr.Discard(), func(context.Context, string) ([]byte, error) {
+		fetched++
+		if fetched > 1 {
+			// a custom section changes the digest of the module
+			return append(append([]byte(nil), checksModule...), 0x00, 0x03, 0x01, 'x', 0x00), nil
+		}
+		return checksModule, nil
+	}).(*runtime)
+	r.now = func() time.Time { return now }
+	compiled, err := r.load(context.Background(), "oci://ghcr.io/org/checks:v1")
+	assert.NilError(t, err)
+	now = now.Add(moduleTTL)
+	_, err = r.Validate(context.Background(), "oci://ghcr.io/org/checks:v1", "allow", Input{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(r.compiled), 2, "the previous module must not be closed while it is used")
+	_, err = r.call(context.Background(), compiled, "allow", nil)
+	assert.NilError(t, err)
+	r.release(compiled)
+	assert.Equal(t, len(r.compiled), 1, "the previous module must be closed once it is not used anymore")
+	now = now.Add(moduleTTL)
+	r.lock.Lock()
+	r.sweep()
+	r.lock.Unlock()
+	assert.Equal(t, len(r.modules), 0, "unused references must be forgotten")
+	assert.Equal(t, len(r.compiled), 0, "unreferenced modules must be closed")
+}
+
+func TestPullDeduplication(t *testing.T) {
+	var fetched atomic.Int32
+	release := make(chan struct{})
+	r := NewRuntime(logr.Discard(), func(context.Context, string) ([]byte, error) {
+		fetched.Add(1)
+		<-release
+		return checksModule, nil
+	}).(*runtime)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := r.Validate(context.Background(), "oci://ghcr.io/org/checks:v1", "allow", Input{})
+			assert.Check(t, err)
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, fetched.Load(), int32(1), "concurrent loads must share a single pull")
+}
+
+func TestInstanceLimit(t *testing.T) {
+	var fetched int
+	r := newTestRuntime(&fetched)
+	compiled, err := r.load(context.Background(), "oci://ghcr.io/org/checks:v1")
+	assert.NilError(t, err)
+	defer r.release(compiled)
+	for i := 0; i < maxInstances; i++ {
+		r.instances <- struct{}{}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = r.call(ctx, compiled, "allow", nil)
+	assert.ErrorContains(t, err, "failed to instantiate module: context deadline exceeded")
+	<-r.instances
+	_, err = r.call(context.Background(), compiled, "allow", nil)
+	assert.NilError(t, err)
+}
+
+func TestRegistryFetcher(t *testing.T) {
+	_, err := RegistryFetcher(nil)(context.Background(), "https://example.com/checks.wasm")
+	assert.ErrorContains(t, err, "unsupported module reference")
+}
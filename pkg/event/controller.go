@@ -11,6 +11,7 @@ import (
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
 	kyvernov2beta1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2beta1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	corev1 "k8s.io/api/core/v1"
 	errors "k8s.io/apimachinery/pkg/api/errors"
@@ -24,6 +25,7 @@ import (
 const (
 	eventWorkQueueName  = "kyverno-events"
 	workQueueRetryLimit = 3
+	sinkFlushPeriod     = time.Second
 )
 
 // generator generate events
@@ -54,6 +56,9 @@ type generator struct {
 
 	omitEvents []string
 
+	// applies the events configuration, nil when events are emitted as they come
+	sink *sink
+
 	log logr.Logger
 }
 
@@ -76,6 +81,7 @@ func NewEventGenerator(
 	pInformer kyvernov1informers.PolicyInformer,
 	maxQueuedEvents int,
 	omitEvents []string,
+	configuration config.Configuration,
 	log logr.Logger,
 ) Controller {
 	gen := generator{
@@ -91,6 +97,9 @@ func NewEventGenerator(
 		omitEvents:             omitEvents,
 		log:                    log,
 	}
+	if configuration != nil {
+		gen.sink = newSink(configuration)
+	}
 	return &gen
 }
 
@@ -101,6 +110,7 @@ func NewEventCleanupGenerator(
 	clustercleanuppolInformer kyvernov2beta1informers.ClusterCleanupPolicyInformer,
 	cleanuppolInformer kyvernov2beta1informers.CleanupPolicyInformer,
 	maxQueuedEvents int,
+	configuration config.Configuration,
 	log logr.Logger,
 ) Controller {
 	gen := generator{
@@ -112,6 +122,9 @@ func NewEventCleanupGenerator(
 		maxQueuedEvents:         maxQueuedEvents,
 		log:                     log,
 	}
+	if configuration != nil {
+		gen.sink = newSink(configuration)
+	}
 	return &gen
 }
 
@@ -123,6 +136,7 @@ func (gen *generator) Add(infos ...Info) {
 		logger.V(2).Info("exceeds the event queue limit, dropping the event", "maxQueuedEvents", gen.maxQueuedEvents, "current size", gen.queue.Len())
 		return
 	}
+	var emitted []Info
 	for _, info := range infos {
 		if info.Name == "" {
 			// dont create event for resources with generateName
@@ -140,10 +154,20 @@ func (gen *generator) Add(infos ...Info) {
 		}
 
 		if shouldEmitEvent {
-			gen.queue.Add(info)
-			logger.V(6).Info("creating event", "kind", info.Kind, "name", info.Name, "namespace", info.Namespace, "reason", info.Reason)
+			emitted = append(emitted, info)
 		}
 	}
+	if gen.sink != nil {
+		emitted = gen.sink.filter(emitted...)
+	}
+	gen.enqueue(emitted...)
+}
+
+func (gen *generator) enqueue(infos ...Info) {
+	for _, info := range infos {
+		gen.queue.Add(info)
+		gen.log.V(6).Info("creating event", "kind", info.Kind, "name", info.Name, "namespace", info.Namespace, "reason", info.Reason)
+	}
 }
 
 // Run begins generator
@@ -160,6 +184,14 @@ func (gen *generator) Run(ctx context.Context, workers int, waitGroup *sync.Wait
 			wait.UntilWithContext(ctx, gen.runWorker, time.Second)
 		}()
 	}
+	if gen.sink != nil {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			// aggregated events are emitted when their aggregation window ends
+			wait.UntilWithContext(ctx, func(context.Context) { gen.enqueue(gen.sink.flush()...) }, sinkFlushPeriod)
+		}()
+	}
 	<-ctx.Done()
 }
 
@@ -3,8 +3,10 @@ package event
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -107,6 +109,10 @@ func NewResourceViolationEvent(source Source, reason Reason, engineResponse engi
 	fmt.Fprintf(&bldr, "policy %s/%s %s: %s", pol.GetName(),
 		ruleResp.Name(), ruleResp.Status(), ruleResp.Message())
 	resource := engineResponse.GetResourceSpec()
+	policy := pol.GetName()
+	if pol.GetNamespace() != "" {
+		policy = pol.GetNamespace() + "/" + policy
+	}
 
 	return Info{
 		Kind:      resource.Kind,
@@ -116,6 +122,7 @@ func NewResourceViolationEvent(source Source, reason Reason, engineResponse engi
 		Source:    source,
 		Message:   bldr.String(),
 		Action:    ResourcePassed,
+		Policy:    policy,
 	}
 }
 
@@ -130,6 +137,7 @@ func NewResourceGenerationEvent(policy, rule string, source Source, resource kyv
 		Reason:    PolicyApplied,
 		Message:   msg,
 		Action:    None,
+		Policy:    policy,
 	}
 }
 
@@ -221,6 +229,32 @@ func NewPolicyExceptionEvents(engineResponse engineapi.EngineResponse, ruleResp
 	return []Info{policyEvent, exceptionEvent}
 }
 
+// NewPolicyExceptionExpiringEvent is emitted when a policy exception is about to expire
+func NewPolicyExceptionExpiringEvent(polex *kyvernov2.PolicyException, expiration time.Time) Info {
+	return newPolicyExceptionEvent(polex, ExceptionExpiring, fmt.Sprintf("policy exception %s/%s expires at %s", polex.GetNamespace(), polex.GetName(), expiration.UTC().Format(time.RFC3339)))
+}
+
+// NewPolicyExceptionExpiredEvent is emitted when a policy exception expired and no longer applies
+func NewPolicyExceptionExpiredEvent(polex *kyvernov2.PolicyException, expiration time.Time) Info {
+	return newPolicyExceptionEvent(polex, ExceptionExpired, fmt.Sprintf("policy exception %s/%s expired at %s and no longer applies", polex.GetNamespace(), polex.GetName(), expiration.UTC().Format(time.RFC3339)))
+}
+
+func newPolicyExceptionEvent(polex *kyvernov2.PolicyException, reason Reason, message string) Info {
+	return Info{
+		Kind:              "PolicyException",
+		Namespace:         polex.GetNamespace(),
+		Name:              polex.GetName(),
+		RelatedAPIVersion: kyvernov2.SchemeGroupVersion.String(),
+		RelatedKind:       "PolicyException",
+		RelatedNamespace:  polex.GetNamespace(),
+		RelatedName:       polex.GetName(),
+		Source:            PolicyController,
+		Action:            None,
+		Reason:            reason,
+		Message:           message,
+	}
+}
+
 func NewCleanupPolicyEvent(policy kyvernov2alpha1.CleanupPolicyInterface, resource unstructured.Unstructured, err error) Info {
 	if err == nil {
 		return Info{
@@ -253,6 +287,47 @@ func NewCleanupPolicyEvent(policy kyvernov2alpha1.CleanupPolicyInterface, resour
 	}
 }
 
+// NewCleanupPolicyActionEvent is emitted by cleanup policies performing an action other than delete on a resource
+func NewCleanupPolicyActionEvent(policy kyvernov2alpha1.CleanupPolicyInterface, resource unstructured.Unstructured, action string, err error) Info {
+	info := Info{
+		Kind:              policy.GetKind(),
+		Namespace:         policy.GetNamespace(),
+		Name:              policy.GetName(),
+		RelatedAPIVersion: resource.GetAPIVersion(),
+		RelatedKind:       resource.GetKind(),
+		RelatedNamespace:  resource.GetNamespace(),
+		RelatedName:       resource.GetName(),
+		Source:            CleanupController,
+	}
+	if err == nil {
+		info.Action = ResourceCleanedUp
+		info.Reason = PolicyApplied
+		info.Message = fmt.Sprintf("successfully performed %v on the target resource %v/%v/%v", action, resource.GetKind(), resource.GetNamespace(), resource.GetName())
+	} else {
+		info.Action = None
+		info.Reason = PolicyError
+		info.Message = fmt.Sprintf("failed to perform %v on the target resource %v/%v/%v: %v", action, resource.GetKind(), resource.GetNamespace(), resource.GetName(), err.Error())
+	}
+	return info
+}
+
+// NewCleanupPolicyPreDeleteEvent is emitted by cleanup policies with a pre-delete event hook before the resource is deleted
+func NewCleanupPolicyPreDeleteEvent(policy kyvernov2alpha1.CleanupPolicyInterface, resource unstructured.Unstructured) Info {
+	return Info{
+		Kind:              policy.GetKind(),
+		Namespace:         policy.GetNamespace(),
+		Name:              policy.GetName(),
+		RelatedAPIVersion: resource.GetAPIVersion(),
+		RelatedKind:       resource.GetKind(),
+		RelatedNamespace:  resource.GetNamespace(),
+		RelatedName:       resource.GetName(),
+		Source:            CleanupController,
+		Action:            ResourceCleanupPending,
+		Reason:            PolicyApplied,
+		Message:           fmt.Sprintf("the target resource %v/%v/%v (uid %v) is about to be cleaned up", resource.GetKind(), resource.GetNamespace(), resource.GetName(), resource.GetUID()),
+	}
+}
+
 func NewValidatingAdmissionPolicyEvent(policy kyvernov1.PolicyInterface, vapName, vapBindingName string) []Info {
 	vapEvent := Info{
 		Kind:              policy.GetKind(),
@@ -290,6 +365,7 @@ func NewFailedEvent(err error, policy, rule string, source Source, resource kyve
 		Reason:    PolicyError,
 		Message:   fmt.Sprintf("policy %s/%s error: %v", policy, rule, err),
 		Action:    None,
+		Policy:    policy,
 	}
 }
 
@@ -15,6 +15,9 @@ type Info struct {
 	Message           string
 	Action            Action
 	Source            Source
+	// Policy is the name of the policy the event is about when the event is not regarding the policy,
+	// namespace/name for namespaced policies
+	Policy string
 }
 
 func (i *Info) Resource() string {
@@ -23,3 +26,17 @@ func (i *Info) Resource() string {
 	}
 	return strings.Join([]string{i.Kind, i.Namespace, i.Name}, "/")
 }
+
+// PolicyName returns the name of the policy the event is about, namespace/name for namespaced policies
+func (i *Info) PolicyName() string {
+	if i.Policy != "" {
+		return i.Policy
+	}
+	switch i.Kind {
+	case "ClusterPolicy", "ClusterCleanupPolicy":
+		return i.Name
+	case "Policy", "CleanupPolicy":
+		return i.Namespace + "/" + i.Name
+	}
+	return ""
+}
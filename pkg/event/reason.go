@@ -8,4 +8,8 @@ const (
 	PolicyApplied   Reason = "PolicyApplied"
 	PolicyError     Reason = "PolicyError"
 	PolicySkipped   Reason = "PolicySkipped"
+	// ExceptionExpiring is used when a policy exception is about to expire
+	ExceptionExpiring Reason = "ExceptionExpiring"
+	// ExceptionExpired is used when a policy exception expired
+	ExceptionExpired Reason = "ExceptionExpired"
 )
@@ -0,0 +1,137 @@
+package event
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// sink applies the events configuration before events are queued, it drops the events of disabled policies,
+// the events identical to an event emitted within the deduplication window and the events exceeding the rate limit.
+// The violations of a policy beyond the aggregation threshold are replaced by a single aggregated event
+// emitted when the aggregation window ends.
+type sink struct {
+	configuration config.Configuration
+	now           func() time.Time
+
+	lock      sync.Mutex
+	seen      map[Info]time.Time
+	limit     config.EventsRateLimit
+	limiter   flowcontrol.RateLimiter
+	windows   map[string]*aggregationWindow
+	dedupTTL  time.Duration
+	aggWindow time.Duration
+}
+
+type aggregationWindow struct {
+	start      time.Time
+	count      int
+	suppressed int
+	last       Info
+}
+
+func newSink(configuration config.Configuration) *sink {
+	return &sink{
+		configuration: configuration,
+		now:           time.Now,
+		seen:          map[Info]time.Time{},
+		windows:       map[string]*aggregationWindow{},
+	}
+}
+
+// filter returns the events to emit
+func (s *sink) filter(infos ...Info) []Info {
+	cfg := s.configuration.GetEvents()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	now := s.now()
+	s.configure(cfg)
+	var out []Info
+	for _, info := range infos {
+		policy := info.PolicyName()
+		if policy != "" && !cfg.Enabled(policy, string(info.Reason)) {
+			continue
+		}
+		if s.dedupTTL > 0 {
+			if at, ok := s.seen[info]; ok && now.Sub(at) < s.dedupTTL {
+				continue
+			}
+		}
+		if cfg.Aggregation != nil && policy != "" && info.Reason == PolicyViolation {
+			window := s.windows[policy]
+			if window == nil || now.Sub(window.start) >= s.aggWindow {
+				if window != nil && window.suppressed > 0 {
+					out = append(out, s.aggregated(window))
+				}
+				window = &aggregationWindow{start: now}
+				s.windows[policy] = window
+			}
+			window.count++
+			if window.count > cfg.Aggregation.Threshold {
+				window.suppressed++
+				window.last = info
+				continue
+			}
+		}
+		if s.limiter != nil && !s.limiter.TryAccept() {
+			continue
+		}
+		if s.dedupTTL > 0 {
+			s.seen[info] = now
+		}
+		out = append(out, info)
+	}
+	return out
+}
+
+// flush returns the aggregated events of the aggregation windows that ended and forgets the expired events
+func (s *sink) flush() []Info {
+	cfg := s.configuration.GetEvents()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	now := s.now()
+	s.configure(cfg)
+	for info, at := range s.seen {
+		if now.Sub(at) >= s.dedupTTL {
+			delete(s.seen, info)
+		}
+	}
+	var out []Info
+	for policy, window := range s.windows {
+		if now.Sub(window.start) < s.aggWindow {
+			continue
+		}
+		if window.suppressed > 0 {
+			out = append(out, s.aggregated(window))
+		}
+		delete(s.windows, policy)
+	}
+	return out
+}
+
+func (s *sink) configure(cfg config.EventsConfig) {
+	s.dedupTTL = 0
+	if cfg.DeduplicationWindow != nil {
+		s.dedupTTL = cfg.DeduplicationWindow.Duration
+	}
+	s.aggWindow = 0
+	if cfg.Aggregation != nil {
+		s.aggWindow = cfg.Aggregation.Window.Duration
+	}
+	if cfg.RateLimit == nil {
+		s.limit, s.limiter = config.EventsRateLimit{}, nil
+	} else if s.limiter == nil || *cfg.RateLimit != s.limit {
+		s.limit = *cfg.RateLimit
+		s.limiter = flowcontrol.NewTokenBucketRateLimiter(s.limit.QPS, max(s.limit.Burst, 1))
+	}
+}
+
+func (s *sink) aggregated(window *aggregationWindow) Info {
+	info := window.last
+	info.RelatedAPIVersion, info.RelatedKind, info.RelatedName, info.RelatedNamespace = "", "", "", ""
+	info.Message = fmt.Sprintf("%d similar violations of policy %s were not reported individually since %s", window.suppressed, info.PolicyName(), window.start.UTC().Format(time.RFC3339))
+	return info
+}
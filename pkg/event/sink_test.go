@@ -0,0 +1,80 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func newTestSink(t *testing.T, events string) (*sink, *time.Time) {
+	configuration := config.NewDefaultConfiguration(false)
+	configuration.Load(&corev1.ConfigMap{Data: map[string]string{"events": events}})
+	s := newSink(configuration)
+	now := time.Now()
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func violation(policy, resource string) Info {
+	return Info{
+		Kind:        "ClusterPolicy",
+		Name:        policy,
+		RelatedKind: "Pod",
+		RelatedName: resource,
+		Reason:      PolicyViolation,
+		Message:     "Pod " + resource + ": [check] fail",
+		Source:      AdmissionController,
+	}
+}
+
+func Test_sink_policies(t *testing.T) {
+	s, _ := newTestSink(t, `{"policies": [{"names": ["audit-*"], "disabled": true}]}`)
+	out := s.filter(violation("audit-labels", "a"), violation("require-labels", "a"))
+	assert.DeepEqual(t, out, []Info{violation("require-labels", "a")})
+	resourceEvent := Info{Kind: "Pod", Name: "a", Reason: PolicyViolation, Policy: "audit-labels"}
+	assert.Equal(t, len(s.filter(resourceEvent)), 0)
+}
+
+func Test_sink_deduplication(t *testing.T) {
+	s, now := newTestSink(t, `{"deduplicationWindow": "1m"}`)
+	assert.Equal(t, len(s.filter(violation("require-labels", "a"), violation("require-labels", "a"))), 1)
+	assert.Equal(t, len(s.filter(violation("require-labels", "a"), violation("require-labels", "b"))), 1)
+	*now = now.Add(time.Minute)
+	assert.Equal(t, len(s.flush()), 0)
+	assert.Equal(t, len(s.seen), 0)
+	assert.Equal(t, len(s.filter(violation("require-labels", "a"))), 1)
+}
+
+func Test_sink_rateLimit(t *testing.T) {
+	s, _ := newTestSink(t, `{"rateLimit": {"qps": 0.001, "burst": 2}}`)
+	out := s.filter(violation("require-labels", "a"), violation("require-labels", "b"), violation("require-labels", "c"))
+	assert.Equal(t, len(out), 2)
+}
+
+func Test_sink_aggregation(t *testing.T) {
+	s, now := newTestSink(t, `{"aggregation": {"threshold": 2, "window": "1m"}}`)
+	out := s.filter(
+		violation("require-labels", "a"),
+		violation("require-labels", "b"),
+		violation("require-labels", "c"),
+		violation("require-labels", "d"),
+		violation("require-requests", "a"),
+	)
+	assert.DeepEqual(t, out, []Info{
+		violation("require-labels", "a"),
+		violation("require-labels", "b"),
+		violation("require-requests", "a"),
+	})
+	assert.Equal(t, len(s.flush()), 0)
+	*now = now.Add(time.Minute)
+	out = s.flush()
+	assert.Equal(t, len(out), 1)
+	assert.Equal(t, out[0].Kind, "ClusterPolicy")
+	assert.Equal(t, out[0].Name, "require-labels")
+	assert.Equal(t, out[0].RelatedName, "")
+	assert.Assert(t, len(out[0].Message) > 0)
+	assert.Equal(t, len(s.windows), 0)
+}
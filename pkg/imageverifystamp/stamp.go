@@ -0,0 +1,217 @@
+package imageverifystamp
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+// SecretKey is the key of the signing secret holding the HMAC key.
+const SecretKey = "key"
+
+// Resource identifies the resource a stamp was issued for, a stamp copied to another resource doesn't verify.
+type Resource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	// Name is empty for resources created with a generated name, the name is generated after the admission.
+	Name         string `json:"name,omitempty"`
+	GenerateName string `json:"generateName,omitempty"`
+	// UID is empty for resources stamped when they are created, it is set after the admission.
+	UID types.UID `json:"uid,omitempty"`
+	// Controller is the UID of the controller owning the resource.
+	Controller types.UID `json:"controller,omitempty"`
+}
+
+// NewResource returns the identity of a resource.
+func NewResource(apiVersion, kind string, resource metav1.Object) Resource {
+	identity := Resource{
+		APIVersion:   apiVersion,
+		Kind:         kind,
+		Namespace:    resource.GetNamespace(),
+		Name:         resource.GetName(),
+		GenerateName: resource.GetGenerateName(),
+		UID:          resource.GetUID(),
+	}
+	if controller := metav1.GetControllerOfNoCopy(resource); controller != nil {
+		identity.Controller = controller.UID
+	}
+	return identity
+}
+
+// Matches returns true if the stamp issued for r applies to the given resource. Stamps issued when a resource is created
+// have no UID, and no name when the name is generated, the generated name must then start with the stamped prefix.
+// The name is not generated yet when the mutating webhook is reinvoked.
+func (r Resource) Matches(resource Resource) bool {
+	if r.APIVersion != resource.APIVersion || r.Kind != resource.Kind || r.Namespace != resource.Namespace || r.Controller != resource.Controller {
+		return false
+	}
+	if r.UID != "" && r.UID != resource.UID {
+		return false
+	}
+	if r.Name != "" {
+		return r.Name == resource.Name
+	}
+	if r.GenerateName == "" || r.GenerateName != resource.GenerateName {
+		return false
+	}
+	return resource.Name == "" || strings.HasPrefix(resource.Name, r.GenerateName)
+}
+
+// Stamp is the payload of the kyverno.io/verified-images annotation.
+type Stamp struct {
+	// Resource is the resource the stamp was issued for.
+	Resource Resource `json:"resource"`
+	// Images are the images verified when admitting the resource.
+	Images []engineapi.VerifiedImage `json:"images"`
+	// Timestamp is the time the stamp was signed.
+	Timestamp time.Time `json:"timestamp"`
+	// KeyID identifies the key used to sign the stamp, it is the first bytes of the SHA-256 of the key.
+	KeyID string `json:"keyId"`
+	// Signature is the base64 encoded HMAC-SHA256 of the stamp without its signature.
+	Signature string `json:"signature,omitempty"`
+}
+
+type Signer interface {
+	// Sign returns the signed annotation value stamping the images verified when admitting the resource
+	Sign(resource Resource, images ...engineapi.VerifiedImage) (string, error)
+	// Verify checks that the annotation value was signed by Kyverno for the resource
+	Verify(value string, resource Resource) error
+}
+
+type signer struct {
+	secretLister corev1listers.SecretNamespaceLister
+	secretName   string
+}
+
+// NewSigner returns a Signer using the HMAC key stored in the given secret,
+// the secret is read when signing so that the key can be rotated.
+func NewSigner(secretLister corev1listers.SecretNamespaceLister, secretName string) Signer {
+	return &signer{
+		secretLister: secretLister,
+		secretName:   secretName,
+	}
+}
+
+func (s *signer) Sign(resource Resource, images ...engineapi.VerifiedImage) (string, error) {
+	key, err := s.key()
+	if err != nil {
+		return "", err
+	}
+	return Sign(key, time.Now().UTC().Truncate(time.Second), resource, images...)
+}
+
+func (s *signer) Verify(value string, resource Resource) error {
+	key, err := s.key()
+	if err != nil {
+		return err
+	}
+	_, err = Verify(key, value, resource)
+	return err
+}
+
+func (s *signer) key() ([]byte, error) {
+	secret, err := s.secretLister.Get(s.secretName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signing secret %s: %w", s.secretName, err)
+	}
+	key := secret.Data[SecretKey]
+	if len(key) == 0 {
+		return nil, fmt.Errorf("signing secret %s has no %s entry", s.secretName, SecretKey)
+	}
+	return key, nil
+}
+
+// Sign builds a stamp of the images verified when admitting the resource and signs it with the given key.
+func Sign(key []byte, timestamp time.Time, resource Resource, images ...engineapi.VerifiedImage) (string, error) {
+	stamp := Stamp{
+		Resource:  resource,
+		Images:    images,
+		Timestamp: timestamp,
+		KeyID:     keyID(key),
+	}
+	signature, err := signature(key, stamp)
+	if err != nil {
+		return "", err
+	}
+	stamp.Signature = signature
+	data, err := json.Marshal(stamp)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Verify parses an annotation value, checks its signature against the given key and that it was issued for the resource.
+func Verify(key []byte, value string, resource Resource) (*Stamp, error) {
+	var stamp Stamp
+	if err := json.Unmarshal([]byte(value), &stamp); err != nil {
+		return nil, fmt.Errorf("failed to parse stamp: %w", err)
+	}
+	if stamp.KeyID != keyID(key) {
+		return nil, fmt.Errorf("stamp was signed with key %s", stamp.KeyID)
+	}
+	actual, err := base64.StdEncoding.DecodeString(stamp.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signature: %w", err)
+	}
+	signature := stamp.Signature
+	stamp.Signature = ""
+	expected, err := mac(key, stamp)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(actual, expected) {
+		return nil, errors.New("invalid stamp signature")
+	}
+	if !stamp.Resource.Matches(resource) {
+		return nil, fmt.Errorf("stamp was issued for %s %s", stamp.Resource.Kind, stampedName(stamp.Resource))
+	}
+	stamp.Signature = signature
+	return &stamp, nil
+}
+
+func signature(key []byte, stamp Stamp) (string, error) {
+	sum, err := mac(key, stamp)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sum), nil
+}
+
+func mac(key []byte, stamp Stamp) ([]byte, error) {
+	data, err := json.Marshal(stamp)
+	if err != nil {
+		return nil, err
+	}
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	return h.Sum(nil), nil
+}
+
+func stampedName(resource Resource) string {
+	name := resource.Name
+	if name == "" {
+		name = resource.GenerateName
+	}
+	if resource.Namespace == "" {
+		return name
+	}
+	return resource.Namespace + "/" + name
+}
+
+func keyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
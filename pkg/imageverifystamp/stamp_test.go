@@ -0,0 +1,135 @@
+package imageverifystamp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var verified = engineapi.VerifiedImage{
+	Image:      "ghcr.io/kyverno/test-verify-image:signed",
+	Digest:     "sha256:b31bfb4d0213f254d361e0079deaaebefa4f82ba7aa76ef82e90b4935ad5b105",
+	Policy:     "check-image",
+	Rule:       "verify-signature",
+	Attestors:  []string{"keyless:issuer=https://token.actions.githubusercontent.com,subject=https://github.com/kyverno/*"},
+	VerifiedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+}
+
+var pod = Resource{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "nginx"}
+
+func TestSignVerify(t *testing.T) {
+	key := []byte("secret")
+	value, err := Sign(key, time.Date(2024, 1, 2, 3, 4, 6, 0, time.UTC), pod, verified)
+	assert.NilError(t, err)
+
+	stamp, err := Verify(key, value, pod)
+	assert.NilError(t, err)
+	assert.Equal(t, stamp.Resource, pod)
+	assert.Equal(t, len(stamp.Images), 1)
+	assert.Equal(t, stamp.Images[0].Digest, verified.Digest)
+	assert.Equal(t, stamp.Images[0].Policy, verified.Policy)
+
+	_, err = Verify([]byte("other"), value, pod)
+	assert.ErrorContains(t, err, "stamp was signed with key")
+
+	tampered := strings.Replace(value, "check-image", "other-policy", 1)
+	_, err = Verify(key, tampered, pod)
+	assert.ErrorContains(t, err, "invalid stamp signature")
+
+	// a stamp copied to another resource doesn't verify
+	other := pod
+	other.Name = "other"
+	_, err = Verify(key, value, other)
+	assert.Error(t, err, "stamp was issued for Pod default/nginx")
+
+	tampered = strings.Replace(value, `"name":"nginx"`, `"name":"other"`, 1)
+	_, err = Verify(key, tampered, other)
+	assert.ErrorContains(t, err, "invalid stamp signature")
+}
+
+func TestResourceMatches(t *testing.T) {
+	created := Resource{APIVersion: "v1", Kind: "Pod", Namespace: "default", GenerateName: "nginx-", Controller: "rs-uid"}
+	tests := []struct {
+		name     string
+		stamped  Resource
+		resource Resource
+		want     bool
+	}{{
+		name:     "same resource",
+		stamped:  pod,
+		resource: pod,
+		want:     true,
+	}, {
+		name:     "other namespace",
+		stamped:  pod,
+		resource: Resource{APIVersion: "v1", Kind: "Pod", Namespace: "other", Name: "nginx"},
+	}, {
+		name:     "other kind",
+		stamped:  pod,
+		resource: Resource{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "nginx"},
+	}, {
+		name:     "stamped when created",
+		stamped:  Resource{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "nginx"},
+		resource: Resource{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "nginx", UID: "uid"},
+		want:     true,
+	}, {
+		name:     "other uid",
+		stamped:  Resource{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "nginx", UID: "uid"},
+		resource: Resource{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "nginx", UID: "other"},
+	}, {
+		name:     "generated name",
+		stamped:  created,
+		resource: Resource{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "nginx-abcde", GenerateName: "nginx-", UID: "uid", Controller: "rs-uid"},
+		want:     true,
+	}, {
+		name:     "generated name reinvoked",
+		stamped:  created,
+		resource: created,
+		want:     true,
+	}, {
+		name:     "other controller",
+		stamped:  created,
+		resource: Resource{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "nginx-abcde", GenerateName: "nginx-", Controller: "other"},
+	}, {
+		name:     "name not generated",
+		stamped:  created,
+		resource: Resource{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "other", GenerateName: "nginx-", Controller: "rs-uid"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.stamped.Matches(tt.resource), tt.want)
+		})
+	}
+}
+
+func TestSigner(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kyverno", Name: "stamp"},
+		Data:       map[string][]byte{SecretKey: []byte("secret")},
+	})
+	factory := kubeinformers.NewSharedInformerFactoryWithOptions(client, 0, kubeinformers.WithNamespace("kyverno"))
+	secrets := factory.Core().V1().Secrets()
+	informer := secrets.Informer()
+	stop := make(chan struct{})
+	defer close(stop)
+	factory.Start(stop)
+	factory.WaitForCacheSync(stop)
+	assert.Assert(t, informer.HasSynced())
+
+	signer := NewSigner(secrets.Lister().Secrets("kyverno"), "stamp")
+	value, err := signer.Sign(pod, verified)
+	assert.NilError(t, err)
+	_, err = Verify([]byte("secret"), value, pod)
+	assert.NilError(t, err)
+	assert.NilError(t, signer.Verify(value, pod))
+
+	_, err = NewSigner(secrets.Lister().Secrets("kyverno"), "missing").Sign(pod, verified)
+	assert.ErrorContains(t, err, "failed to get signing secret missing")
+}
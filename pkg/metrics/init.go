@@ -2,37 +2,62 @@ package metrics
 
 import (
 	"context"
+	"fmt"
+	"net"
 	"net/http"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/pkg/config"
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 )
 
+// InitMetrics configures the exporters listed in otelProvider, a comma separated list of `prometheus` and `grpc`,
+// it returns the prometheus mux when metrics are scraped and the meter provider to shut down on exit
 func InitMetrics(
 	ctx context.Context,
 	disableMetricsExport bool,
 	otelProvider string,
 	metricsAddr string,
 	otelCollector string,
+	otelTemporality string,
+	otelResourceAttributes string,
 	metricsConfiguration config.MetricsConfiguration,
 	transportCreds string,
 	kubeClient kubernetes.Interface,
 	logger logr.Logger,
 ) (MetricsConfigManager, *http.ServeMux, *sdkmetric.MeterProvider, error) {
-	var err error
 	var metricsServerMux *http.ServeMux
+	var meterProvider *sdkmetric.MeterProvider
 	if !disableMetricsExport {
-		var meterProvider metric.MeterProvider
-		if otelProvider == "grpc" {
-			endpoint := otelCollector + metricsAddr
-			meterProvider, err = NewOTLPGRPCConfig(
+		exporters := sets.New[string]()
+		for _, exporter := range strings.Split(otelProvider, ",") {
+			if exporter = strings.TrimSpace(exporter); exporter != "" {
+				exporters.Insert(exporter)
+			}
+		}
+		if unknown := exporters.Clone().Delete(ExporterPrometheus, ExporterGRPC); unknown.Len() != 0 {
+			return nil, nil, nil, fmt.Errorf("unsupported metrics exporters %v, must be %s or %s", sets.List(unknown), ExporterPrometheus, ExporterGRPC)
+		}
+		resourceAttributes, err := ParseResourceAttributes(otelResourceAttributes)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		var readers []sdkmetric.Reader
+		if exporters.Has(ExporterGRPC) {
+			endpoint := otelCollector
+			// the metrics port is used when the collector address doesn't have one
+			if _, _, err := net.SplitHostPort(otelCollector); err != nil {
+				endpoint = otelCollector + metricsAddr
+			}
+			reader, err := NewOTLPGRPCReader(
 				ctx,
 				endpoint,
 				transportCreds,
+				otelTemporality,
 				kubeClient,
 				logger,
 				metricsConfiguration,
@@ -40,24 +65,32 @@ func InitMetrics(
 			if err != nil {
 				return nil, nil, nil, err
 			}
-		} else if otelProvider == "prometheus" {
-			meterProvider, metricsServerMux, err = NewPrometheusConfig(ctx, logger, metricsConfiguration)
+			readers = append(readers, reader)
+		}
+		if exporters.Has(ExporterPrometheus) {
+			var reader sdkmetric.Reader
+			reader, metricsServerMux, err = NewPrometheusReader(logger, metricsConfiguration)
 			if err != nil {
 				return nil, nil, nil, err
 			}
+			readers = append(readers, reader)
 		}
-		if meterProvider != nil {
-			otel.SetMeterProvider(meterProvider)
+		if len(readers) != 0 {
+			meterProvider, err = NewMeterProvider(logger, metricsConfiguration, resourceAttributes, readers...)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			otel.SetMeterProvider(newMeterProvider(meterProvider, metricsConfiguration))
 		}
 	}
 	metricsConfig := MetricsConfig{
 		Log:    logger,
 		config: metricsConfiguration,
 	}
-	err = metricsConfig.initializeMetrics(otel.GetMeterProvider())
+	err := metricsConfig.initializeMetrics(otel.GetMeterProvider())
 	if err != nil {
 		logger.Error(err, "Failed initializing metrics")
 		return nil, nil, nil, err
 	}
-	return &metricsConfig, metricsServerMux, nil, nil
+	return &metricsConfig, metricsServerMux, meterProvider, nil
 }
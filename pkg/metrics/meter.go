@@ -0,0 +1,150 @@
+package metrics
+
+import (
+	"context"
+
+	kconfig "github.com/kyverno/kyverno/pkg/config"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// meterProvider applies the metrics configuration to the measurements recorded with its instruments.
+// Views are fixed once the sdk meter provider is created, filtering measurements when they are recorded
+// allows metrics and labels to be switched on and off without restarting kyverno.
+type meterProvider struct {
+	metric.MeterProvider
+	configuration kconfig.MetricsConfiguration
+}
+
+func newMeterProvider(provider metric.MeterProvider, configuration kconfig.MetricsConfiguration) metric.MeterProvider {
+	return meterProvider{
+		MeterProvider: provider,
+		configuration: configuration,
+	}
+}
+
+func (p meterProvider) Meter(name string, options ...metric.MeterOption) metric.Meter {
+	return meter{
+		Meter:         p.MeterProvider.Meter(name, options...),
+		configuration: p.configuration,
+	}
+}
+
+type meter struct {
+	metric.Meter
+	configuration kconfig.MetricsConfiguration
+}
+
+func (m meter) Int64Counter(name string, options ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	counter, err := m.Meter.Int64Counter(name, options...)
+	if err != nil {
+		return nil, err
+	}
+	return int64Counter{Int64Counter: counter, name: name, configuration: m.configuration}, nil
+}
+
+func (m meter) Float64Histogram(name string, options ...metric.Float64HistogramOption) (metric.Float64Histogram, error) {
+	histogram, err := m.Meter.Float64Histogram(name, options...)
+	if err != nil {
+		return nil, err
+	}
+	return float64Histogram{Float64Histogram: histogram, name: name, configuration: m.configuration}, nil
+}
+
+func (m meter) Int64ObservableGauge(name string, options ...metric.Int64ObservableGaugeOption) (metric.Int64ObservableGauge, error) {
+	gauge, err := m.Meter.Int64ObservableGauge(name, options...)
+	if err != nil {
+		return nil, err
+	}
+	return int64ObservableGauge{Int64ObservableGauge: gauge, name: name}, nil
+}
+
+func (m meter) Float64ObservableGauge(name string, options ...metric.Float64ObservableGaugeOption) (metric.Float64ObservableGauge, error) {
+	gauge, err := m.Meter.Float64ObservableGauge(name, options...)
+	if err != nil {
+		return nil, err
+	}
+	return float64ObservableGauge{Float64ObservableGauge: gauge, name: name}, nil
+}
+
+// RegisterCallback registers the callback with the underlying instruments, observations made by the callback
+// go through the metrics configuration
+func (m meter) RegisterCallback(callback metric.Callback, instruments ...metric.Observable) (metric.Registration, error) {
+	unwrapped := make([]metric.Observable, 0, len(instruments))
+	for _, instrument := range instruments {
+		unwrapped = append(unwrapped, unwrapObservable(instrument))
+	}
+	return m.Meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		return callback(ctx, observer{Observer: o, configuration: m.configuration})
+	}, unwrapped...)
+}
+
+func unwrapObservable(instrument metric.Observable) metric.Observable {
+	switch instrument := instrument.(type) {
+	case int64ObservableGauge:
+		return instrument.Int64ObservableGauge
+	case float64ObservableGauge:
+		return instrument.Float64ObservableGauge
+	}
+	return instrument
+}
+
+type int64Counter struct {
+	metric.Int64Counter
+	name          string
+	configuration kconfig.MetricsConfiguration
+}
+
+func (c int64Counter) Add(ctx context.Context, incr int64, options ...metric.AddOption) {
+	if attributes, ok := c.configuration.RelabelAttributes(c.name, metric.NewAddConfig(options).Attributes()); ok {
+		c.Int64Counter.Add(ctx, incr, metric.WithAttributeSet(attributes))
+	}
+}
+
+type float64Histogram struct {
+	metric.Float64Histogram
+	name          string
+	configuration kconfig.MetricsConfiguration
+}
+
+func (h float64Histogram) Record(ctx context.Context, incr float64, options ...metric.RecordOption) {
+	if attributes, ok := h.configuration.RelabelAttributes(h.name, metric.NewRecordConfig(options).Attributes()); ok {
+		h.Float64Histogram.Record(ctx, incr, metric.WithAttributeSet(attributes))
+	}
+}
+
+type int64ObservableGauge struct {
+	metric.Int64ObservableGauge
+	name string
+}
+
+type float64ObservableGauge struct {
+	metric.Float64ObservableGauge
+	name string
+}
+
+type observer struct {
+	metric.Observer
+	configuration kconfig.MetricsConfiguration
+}
+
+func (o observer) ObserveInt64(instrument metric.Int64Observable, value int64, options ...metric.ObserveOption) {
+	if gauge, ok := instrument.(int64ObservableGauge); ok {
+		attributes, ok := o.configuration.RelabelAttributes(gauge.name, metric.NewObserveConfig(options).Attributes())
+		if !ok {
+			return
+		}
+		instrument, options = gauge.Int64ObservableGauge, []metric.ObserveOption{metric.WithAttributeSet(attributes)}
+	}
+	o.Observer.ObserveInt64(instrument, value, options...)
+}
+
+func (o observer) ObserveFloat64(instrument metric.Float64Observable, value float64, options ...metric.ObserveOption) {
+	if gauge, ok := instrument.(float64ObservableGauge); ok {
+		attributes, ok := o.configuration.RelabelAttributes(gauge.name, metric.NewObserveConfig(options).Attributes())
+		if !ok {
+			return
+		}
+		instrument, options = gauge.Float64ObservableGauge, []metric.ObserveOption{metric.WithAttributeSet(attributes)}
+	}
+	o.Observer.ObserveFloat64(instrument, value, options...)
+}
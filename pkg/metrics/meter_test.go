@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_meterProvider(t *testing.T) {
+	configuration := config.NewDefaultMetricsConfiguration()
+	reader := sdkmetric.NewManualReader()
+	provider := newMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)), configuration)
+	meter := provider.Meter(MeterName)
+	counter, err := meter.Int64Counter("kyverno_test_total")
+	assert.NoError(t, err)
+	gauge, err := meter.Int64ObservableGauge("kyverno_test_info")
+	assert.NoError(t, err)
+	_, err = meter.RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+		observer.ObserveInt64(gauge, 1, metric.WithAttributes(attribute.String("resource_namespace", "kube-system")))
+		return nil
+	}, gauge)
+	assert.NoError(t, err)
+	attributes := metric.WithAttributes(
+		attribute.String("resource_namespace", "kube-system"),
+		attribute.String("resource_name", "coredns"),
+	)
+	collect := func() map[string][]attribute.Set {
+		var data metricdata.ResourceMetrics
+		assert.NoError(t, reader.Collect(context.TODO(), &data))
+		out := map[string][]attribute.Set{}
+		for _, scope := range data.ScopeMetrics {
+			for _, m := range scope.Metrics {
+				switch data := m.Data.(type) {
+				case metricdata.Sum[int64]:
+					for _, point := range data.DataPoints {
+						out[m.Name] = append(out[m.Name], point.Attributes)
+					}
+				case metricdata.Gauge[int64]:
+					for _, point := range data.DataPoints {
+						out[m.Name] = append(out[m.Name], point.Attributes)
+					}
+				}
+			}
+		}
+		return out
+	}
+	// not configured
+	counter.Add(context.TODO(), 1, attributes)
+	got := collect()
+	assert.Len(t, got["kyverno_test_total"], 1)
+	assert.Len(t, got["kyverno_test_info"], 1)
+	// labels dropped and relabeled at runtime
+	configuration.Load(&corev1.ConfigMap{Data: map[string]string{
+		"metricsExposure": `{
+			"kyverno_test_total": {"disabledLabelDimensions": ["resource_name"], "relabel": [{"label": "resource_namespace", "regex": "kube-.*", "replacement": "system"}]},
+			"kyverno_test_info": {"enabled": false}
+		}`,
+	}})
+	counter.Add(context.TODO(), 1, attributes)
+	got = collect()
+	assert.Contains(t, got["kyverno_test_total"], attribute.NewSet(attribute.String("resource_namespace", "system")))
+	// the gauge is observed on collection, nothing is observed once it is disabled
+	assert.Empty(t, got["kyverno_test_info"])
+}
@@ -2,7 +2,9 @@ package metrics
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -17,6 +19,7 @@ import (
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 	"k8s.io/client-go/kubernetes"
@@ -24,6 +27,14 @@ import (
 
 const (
 	MeterName = "kyverno"
+	// ExporterPrometheus exposes metrics to prometheus scraping
+	ExporterPrometheus = "prometheus"
+	// ExporterGRPC pushes metrics to an OpenTelemetry collector
+	ExporterGRPC = "grpc"
+	// TemporalityCumulative reports metrics accumulated since kyverno started
+	TemporalityCumulative = "cumulative"
+	// TemporalityDelta reports metrics accumulated since the previous push
+	TemporalityDelta = "delta"
 )
 
 type MetricsConfig struct {
@@ -85,8 +96,17 @@ func aggregationSelector(metricsConfiguration kconfig.MetricsConfiguration) func
 	}
 }
 
-func NewOTLPGRPCConfig(ctx context.Context, endpoint string, certs string, kubeClient kubernetes.Interface, log logr.Logger, configuration kconfig.MetricsConfiguration) (metric.MeterProvider, error) {
-	options := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(endpoint), otlpmetricgrpc.WithAggregationSelector(aggregationSelector(configuration))}
+// NewOTLPGRPCReader creates a reader periodically pushing metrics to an OpenTelemetry collector
+func NewOTLPGRPCReader(ctx context.Context, endpoint string, certs string, temporality string, kubeClient kubernetes.Interface, log logr.Logger, configuration kconfig.MetricsConfiguration) (sdkmetric.Reader, error) {
+	selector, err := temporalitySelector(temporality)
+	if err != nil {
+		return nil, err
+	}
+	options := []otlpmetricgrpc.Option{
+		otlpmetricgrpc.WithEndpoint(endpoint),
+		otlpmetricgrpc.WithAggregationSelector(aggregationSelector(configuration)),
+		otlpmetricgrpc.WithTemporalitySelector(selector),
+	}
 	if certs != "" {
 		// here the certificates are stored as configmaps
 		transportCreds, err := tlsutils.FetchCert(ctx, certs, kubeClient)
@@ -104,45 +124,14 @@ func NewOTLPGRPCConfig(ctx context.Context, endpoint string, certs string, kubeC
 		log.Error(err, "Failed to create the collector exporter")
 		return nil, err
 	}
-	res, err := resource.Merge(
-		resource.Default(),
-		resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceNameKey.String(MeterName),
-			semconv.ServiceVersionKey.String(version.Version()),
-		),
-	)
-	if err != nil {
-		log.Error(err, "failed creating resource")
-		return nil, err
-	}
-	reader := sdkmetric.NewPeriodicReader(
+	return sdkmetric.NewPeriodicReader(
 		exporter,
 		sdkmetric.WithInterval(2*time.Second),
-	)
-	// create controller and bind the exporter with it
-	provider := sdkmetric.NewMeterProvider(
-		sdkmetric.WithReader(reader),
-		sdkmetric.WithResource(res),
-		sdkmetric.WithView(configuration.BuildMeterProviderViews()...),
-	)
-	return provider, nil
+	), nil
 }
 
-func NewPrometheusConfig(ctx context.Context, log logr.Logger, configuration kconfig.MetricsConfiguration) (metric.MeterProvider, *http.ServeMux, error) {
-	res, err := resource.Merge(
-		resource.Default(),
-		resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceNameKey.String("kyverno-svc-metrics"),
-			semconv.ServiceNamespaceKey.String(kconfig.KyvernoNamespace()),
-			semconv.ServiceVersionKey.String(version.Version()),
-		),
-	)
-	if err != nil {
-		log.Error(err, "failed creating resource")
-		return nil, nil, err
-	}
+// NewPrometheusReader creates a reader exposing metrics to prometheus scraping and the mux serving them
+func NewPrometheusReader(log logr.Logger, configuration kconfig.MetricsConfiguration) (sdkmetric.Reader, *http.ServeMux, error) {
 	exporter, err := prometheus.New(
 		prometheus.WithoutUnits(),
 		prometheus.WithoutTargetInfo(),
@@ -152,14 +141,73 @@ func NewPrometheusConfig(ctx context.Context, log logr.Logger, configuration kco
 		log.Error(err, "failed to initialize prometheus exporter")
 		return nil, nil, err
 	}
-	provider := sdkmetric.NewMeterProvider(
-		sdkmetric.WithReader(exporter),
-		sdkmetric.WithResource(res),
-		sdkmetric.WithView(configuration.BuildMeterProviderViews()...),
-	)
 	metricsServerMux := http.NewServeMux()
 	metricsServerMux.Handle(config.MetricsPath, promhttp.Handler())
-	return provider, metricsServerMux, nil
+	return exporter, metricsServerMux, nil
+}
+
+// NewMeterProvider creates a meter provider reporting metrics to the given readers,
+// the resource attributes are added to the resource describing kyverno
+func NewMeterProvider(log logr.Logger, configuration kconfig.MetricsConfiguration, resourceAttributes []attribute.KeyValue, readers ...sdkmetric.Reader) (*sdkmetric.MeterProvider, error) {
+	attributes := []attribute.KeyValue{
+		semconv.ServiceNameKey.String(MeterName),
+		semconv.ServiceNamespaceKey.String(kconfig.KyvernoNamespace()),
+		semconv.ServiceVersionKey.String(version.Version()),
+	}
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, append(attributes, resourceAttributes...)...),
+	)
+	if err != nil {
+		log.Error(err, "failed creating resource")
+		return nil, err
+	}
+	options := []sdkmetric.Option{
+		sdkmetric.WithResource(res),
+		sdkmetric.WithView(configuration.BuildMeterProviderViews()...),
+	}
+	for _, reader := range readers {
+		options = append(options, sdkmetric.WithReader(reader))
+	}
+	return sdkmetric.NewMeterProvider(options...), nil
+}
+
+// temporalitySelector returns the temporality of the metrics pushed to an OpenTelemetry collector,
+// with delta temporality up down counters stay cumulative as they don't make sense otherwise
+func temporalitySelector(temporality string) (sdkmetric.TemporalitySelector, error) {
+	switch temporality {
+	case "", TemporalityCumulative:
+		return sdkmetric.DefaultTemporalitySelector, nil
+	case TemporalityDelta:
+		return func(ik sdkmetric.InstrumentKind) metricdata.Temporality {
+			switch ik {
+			case sdkmetric.InstrumentKindUpDownCounter, sdkmetric.InstrumentKindObservableUpDownCounter:
+				return metricdata.CumulativeTemporality
+			default:
+				return metricdata.DeltaTemporality
+			}
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid metrics temporality %s, must be %s or %s", temporality, TemporalityCumulative, TemporalityDelta)
+	}
+}
+
+// ParseResourceAttributes parses a comma separated list of key=value resource attributes
+func ParseResourceAttributes(in string) ([]attribute.KeyValue, error) {
+	var attributes []attribute.KeyValue
+	for _, pair := range strings.Split(in, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid resource attribute %s, must be key=value", pair)
+		}
+		attributes = append(attributes, attribute.String(key, strings.TrimSpace(value)))
+	}
+	return attributes, nil
 }
 
 func (m *MetricsConfig) RecordPolicyChanges(ctx context.Context, policyValidationMode PolicyValidationMode, policyType PolicyType, policyBackgroundMode PolicyBackgroundMode, policyNamespace string, policyName string, policyChangeType string) {
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestParseResourceAttributes(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    []attribute.KeyValue
+		wantErr bool
+	}{{
+		name: "empty",
+	}, {
+		name: "attributes",
+		in:   "k8s.cluster.name=prod, deployment.environment = production,",
+		want: []attribute.KeyValue{
+			attribute.String("k8s.cluster.name", "prod"),
+			attribute.String("deployment.environment", "production"),
+		},
+	}, {
+		name:    "missing value",
+		in:      "k8s.cluster.name",
+		wantErr: true,
+	}, {
+		name:    "missing key",
+		in:      "=prod",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseResourceAttributes(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_temporalitySelector(t *testing.T) {
+	selector, err := temporalitySelector("")
+	assert.NoError(t, err)
+	assert.Equal(t, metricdata.CumulativeTemporality, selector(sdkmetric.InstrumentKindCounter))
+	selector, err = temporalitySelector(TemporalityDelta)
+	assert.NoError(t, err)
+	assert.Equal(t, metricdata.DeltaTemporality, selector(sdkmetric.InstrumentKindCounter))
+	assert.Equal(t, metricdata.DeltaTemporality, selector(sdkmetric.InstrumentKindHistogram))
+	assert.Equal(t, metricdata.CumulativeTemporality, selector(sdkmetric.InstrumentKindUpDownCounter))
+	_, err = temporalitySelector("gauge")
+	assert.Error(t, err)
+}
@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+
+	kconfig "github.com/kyverno/kyverno/pkg/config"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	RuleLatencyMetricName = "kyverno_rule_execution_latency_seconds"
+	// overflowLabelValue is used for all the labels of the series rules are recorded in once the series limit is reached
+	overflowLabelValue = "-"
+)
+
+// RuleLatencyRecorder records the execution latency of policy rules in a histogram.
+// The histogram is recorded with the context of the request so that the exemplars
+// sampled by the meter provider link observations to the trace of the request.
+type RuleLatencyRecorder struct {
+	configuration kconfig.MetricsConfiguration
+	histogram     metric.Float64Histogram
+
+	mux    sync.RWMutex
+	series map[ruleLatencyKey]struct{}
+}
+
+type ruleLatencyKey struct {
+	policyNamespace string
+	policyName      string
+	ruleName        string
+	ruleType        RuleType
+}
+
+// NewRuleLatencyRecorder creates the rule latency histogram with the given meter,
+// nothing is recorded unless the histogram is enabled in the metrics configuration
+func NewRuleLatencyRecorder(meter metric.Meter, configuration kconfig.MetricsConfiguration) (*RuleLatencyRecorder, error) {
+	histogram, err := meter.Float64Histogram(
+		RuleLatencyMetricName,
+		metric.WithDescription("can be used to track the execution latency of individual policy rules, observations link to the trace of the request when it is sampled"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	r := &RuleLatencyRecorder{
+		configuration: configuration,
+		histogram:     histogram,
+		series:        map[ruleLatencyKey]struct{}{},
+	}
+	configuration.OnChanged(r.reset)
+	return r, nil
+}
+
+// reset forgets the series recorded so far so that a new series limit applies to all the rules
+func (r *RuleLatencyRecorder) reset() {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.series = map[ruleLatencyKey]struct{}{}
+}
+
+// attributes returns the attributes of the series the rule is recorded in, rules seen after the series
+// limit was reached are recorded in the overflow series
+func (r *RuleLatencyRecorder) attributes(key ruleLatencyKey, maxSeries int) []attribute.KeyValue {
+	r.mux.RLock()
+	_, ok := r.series[key]
+	r.mux.RUnlock()
+	if !ok {
+		r.mux.Lock()
+		if _, ok = r.series[key]; !ok && len(r.series) < maxSeries {
+			r.series[key] = struct{}{}
+			ok = true
+		}
+		r.mux.Unlock()
+	}
+	if !ok {
+		key = ruleLatencyKey{
+			policyNamespace: overflowLabelValue,
+			policyName:      overflowLabelValue,
+			ruleName:        overflowLabelValue,
+			ruleType:        overflowLabelValue,
+		}
+	}
+	return []attribute.KeyValue{
+		attribute.String("policy_namespace", key.policyNamespace),
+		attribute.String("policy_name", key.policyName),
+		attribute.String("rule_name", key.ruleName),
+		attribute.String("rule_type", string(key.ruleType)),
+	}
+}
+
+// Record records the execution latency of a policy rule, it does nothing unless the rule latency
+// histogram is enabled in the metrics configuration
+func (r *RuleLatencyRecorder) Record(ctx context.Context, policyNamespace, policyName, ruleName string, ruleType RuleType, seconds float64) {
+	if r == nil {
+		return
+	}
+	config := r.configuration.GetRuleLatency()
+	if !config.Enabled {
+		return
+	}
+	key := ruleLatencyKey{
+		policyNamespace: policyNamespace,
+		policyName:      policyName,
+		ruleName:        ruleName,
+		ruleType:        ruleType,
+	}
+	r.histogram.Record(ctx, seconds, metric.WithAttributes(r.attributes(key, config.MaxSeries)...))
+}
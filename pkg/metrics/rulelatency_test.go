@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/stretchr/testify/assert"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_RuleLatencyRecorder(t *testing.T) {
+	configuration := config.NewDefaultMetricsConfiguration()
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	recorder, err := NewRuleLatencyRecorder(meterProvider.Meter(MeterName), configuration)
+	assert.NoError(t, err)
+	collect := func() map[string]uint64 {
+		var data metricdata.ResourceMetrics
+		assert.NoError(t, reader.Collect(context.TODO(), &data))
+		series := map[string]uint64{}
+		for _, scope := range data.ScopeMetrics {
+			for _, m := range scope.Metrics {
+				if m.Name != RuleLatencyMetricName {
+					continue
+				}
+				for _, point := range m.Data.(metricdata.Histogram[float64]).DataPoints {
+					rule, _ := point.Attributes.Value("rule_name")
+					series[rule.AsString()] = point.Count
+				}
+			}
+		}
+		return series
+	}
+	// disabled by default
+	recorder.Record(context.TODO(), "-", "require-labels", "check-team", Validate, 0.1)
+	assert.Empty(t, collect())
+	// enabled with a single series
+	configuration.Load(&corev1.ConfigMap{Data: map[string]string{"ruleLatency": `{"enabled": true, "maxSeries": 1}`}})
+	recorder.Record(context.TODO(), "-", "require-labels", "check-team", Validate, 0.1)
+	recorder.Record(context.TODO(), "-", "require-labels", "check-team", Validate, 0.3)
+	recorder.Record(context.TODO(), "-", "require-labels", "check-owner", Validate, 0.2)
+	assert.Equal(t, map[string]uint64{"check-team": 2, overflowLabelValue: 1}, collect())
+	// a configuration change resets the series limit
+	configuration.Load(&corev1.ConfigMap{Data: map[string]string{"ruleLatency": `{"enabled": true, "maxSeries": 1}`}})
+	recorder.Record(context.TODO(), "-", "require-labels", "check-owner", Validate, 0.2)
+	assert.Equal(t, map[string]uint64{"check-team": 2, "check-owner": 1, overflowLabelValue: 1}, collect())
+	// a nil recorder does nothing
+	var nilRecorder *RuleLatencyRecorder
+	nilRecorder.Record(context.TODO(), "-", "require-labels", "check-team", Validate, 0.1)
+}
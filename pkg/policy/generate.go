@@ -64,7 +64,7 @@ func (pc *policyController) handleGenerateForExisting(policy kyvernov1.PolicyInt
 
 func (pc *policyController) createURForDownstreamDeletion(policy kyvernov1.PolicyInterface) error {
 	var errs []error
-	rules := autogen.ComputeRules(policy)
+	rules := autogen.ComputeRules(policy, pc.configuration.GetAutogenControllers())
 	for _, r := range rules {
 		generateType, sync := r.GetGenerateTypeAndSync()
 		if sync && (generateType == kyvernov1.Data) {
@@ -86,6 +86,15 @@ func (pc *policyController) syncDataPolicyChanges(policy kyvernov1.PolicyInterfa
 		if generate.GetData() == nil {
 			continue
 		}
+		// downstream resources in remote clusters are synchronized when their trigger changes
+		if generate.Cluster != nil {
+			if deleteDownstream {
+				if err := pc.createURForClusterDownstreamDeletion(policy, rule); err != nil {
+					errorList = append(errorList, err)
+				}
+			}
+			continue
+		}
 		if err := pc.syncDataRulechanges(policy, rule, deleteDownstream); err != nil {
 			errorList = append(errorList, err)
 		}
@@ -128,6 +137,20 @@ func (pc *policyController) syncDataRulechanges(policy kyvernov1.PolicyInterface
 	return multierr.Combine(errorList...)
 }
 
+// createURForClusterDownstreamDeletion creates a ur deleting the downstream resources of a rule in a remote cluster,
+// they can't be listed from the local cluster and are looked up in the remote cluster by the background controller
+func (pc *policyController) createURForClusterDownstreamDeletion(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) error {
+	ur := newUR(policy, kyvernov1.ResourceSpec{}, rule.Name, kyvernov1beta1.Generate, true)
+	created, err := pc.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).Create(context.TODO(), ur, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	updated := created.DeepCopy()
+	updated.Status = newClusterURStatus(rule.Generation)
+	_, err = pc.kyvernoClient.KyvernoV1beta1().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(context.TODO(), updated, metav1.UpdateOptions{})
+	return err
+}
+
 // ruleDeletion returns true if any rule is deleted, along with deleted rules
 func ruleDeletion(old, new kyvernov1.PolicyInterface) (_ kyvernov1.PolicyInterface, ruleDeleted bool) {
 	if !new.GetDeletionTimestamp().IsZero() {
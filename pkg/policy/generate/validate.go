@@ -83,6 +83,12 @@ func (g *Generate) Validate(ctx context.Context) (string, error) {
 		}
 	}
 
+	// resources in a remote cluster are managed with the permissions granted by its kubeconfig,
+	// only the access to the kubeconfig secret can be checked
+	if rule.Cluster != nil {
+		return "cluster", g.canIGetKubeconfig(ctx, rule.Cluster)
+	}
+
 	// Kyverno generate-controller create/update/deletes the resources specified in generate rule of policy
 	// kyverno uses SA 'kyverno' and has default ClusterRoles and ClusterRoleBindings
 	// instructions to modify the RBAC for kyverno are mentioned at https://github.com/kyverno/kyverno/blob/master/documentation/installation.md
@@ -147,6 +153,18 @@ func (g *Generate) canIGenerate(ctx context.Context, gvk, namespace, subresource
 	return nil
 }
 
+// canIGetKubeconfig returns a error if kyverno cannot read the kubeconfig secret of a remote cluster
+func (g *Generate) canIGetKubeconfig(ctx context.Context, cluster *kyvernov1.ClusterReference) error {
+	ok, err := g.authCheck.CanIGet(ctx, "v1/Secret", cluster.KubeconfigSecret.Namespace, "")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%s does not have permissions to 'get' the kubeconfig secret %s. Grant proper permissions to the background controller", g.user, cluster)
+	}
+	return nil
+}
+
 func parseCloneKind(gvks string) (gvk, sub string) {
 	gv, ks := kubeutils.GetKindFromGVK(gvks)
 	k, sub := kubeutils.SplitSubresource(ks)
@@ -88,3 +88,50 @@ func Test_Validate_Generate_HasAnchors(t *testing.T) {
 		assert.Assert(t, err != nil)
 	}
 }
+
+// secretsAuth only grants access to secrets in a namespace
+type secretsAuth struct {
+	namespace string
+}
+
+func (a secretsAuth) CanICreate(context.Context, string, string, string) (bool, error) {
+	return false, nil
+}
+
+func (a secretsAuth) CanIUpdate(context.Context, string, string, string) (bool, error) {
+	return false, nil
+}
+
+func (a secretsAuth) CanIDelete(context.Context, string, string, string) (bool, error) {
+	return false, nil
+}
+
+func (a secretsAuth) CanIGet(_ context.Context, gvk, namespace, _ string) (bool, error) {
+	return gvk == "v1/Secret" && namespace == a.namespace, nil
+}
+
+func Test_Validate_Generate_Cluster(t *testing.T) {
+	rawGenerate := []byte(`
+	{
+		"apiVersion": "v1",
+		"kind": "ResourceQuota",
+		"name": "default",
+		"namespace": "tenants",
+		"cluster": {
+			"kubeconfigSecret": {"name": "management-kubeconfig", "namespace": "clusters"}
+		},
+		"data": {"spec": {"hard": {"pods": "10"}}}
+	}`)
+
+	var genRule kyverno.Generation
+	assert.NilError(t, json.Unmarshal(rawGenerate, &genRule))
+	checker := NewFakeGenerate(genRule)
+	checker.authCheck = secretsAuth{namespace: "clusters"}
+	_, err := checker.Validate(context.TODO())
+	assert.NilError(t, err)
+
+	checker.authCheck = secretsAuth{namespace: "kyverno"}
+	path, err := checker.Validate(context.TODO())
+	assert.Equal(t, path, "cluster")
+	assert.ErrorContains(t, err, "does not have permissions to 'get' the kubeconfig secret clusters/management-kubeconfig")
+}
@@ -26,7 +26,7 @@ func newUR(policy kyvernov1.PolicyInterface, trigger kyvernov1.ResourceSpec, rul
 		label = common.GenerateLabelsSet(policyNameNamespaceKey, trigger)
 	}
 
-	return &kyvernov1beta1.UpdateRequest{
+	ur := &kyvernov1beta1.UpdateRequest{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: kyvernov1beta1.SchemeGroupVersion.String(),
 			Kind:       "UpdateRequest",
@@ -50,6 +50,11 @@ func newUR(policy kyvernov1.PolicyInterface, trigger kyvernov1.ResourceSpec, rul
 			DeleteDownstream: deleteDownstream,
 		},
 	}
+	// the downstream resources are deleted on behalf of the policy service account, even once the policy is deleted
+	if deleteDownstream {
+		ur.Spec.ServiceAccount = policy.GetSpec().ServiceAccount
+	}
+	return ur
 }
 
 func newURStatus(downstream unstructured.Unstructured) kyvernov1beta1.UpdateRequestStatus {
@@ -66,3 +71,19 @@ func newURStatus(downstream unstructured.Unstructured) kyvernov1beta1.UpdateRequ
 		},
 	}
 }
+
+func newClusterURStatus(generation kyvernov1.Generation) kyvernov1beta1.UpdateRequestStatus {
+	return kyvernov1beta1.UpdateRequestStatus{
+		State: kyvernov1beta1.Pending,
+		ClusterTargets: []kyvernov1beta1.ClusterTargetStatus{
+			{
+				Cluster: *generation.Cluster,
+				Resource: kyvernov1.ResourceSpec{
+					APIVersion: generation.GetAPIVersion(),
+					Kind:       generation.GetKind(),
+				},
+				State: kyvernov1beta1.Pending,
+			},
+		},
+	}
+}
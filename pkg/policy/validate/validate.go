@@ -3,6 +3,7 @@ package validate
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/engine/anchor"
@@ -120,17 +121,31 @@ func (v *Validate) Validate(ctx context.Context) (string, error) {
 		}
 	}
 
+	if v.rule.WASM != nil {
+		if v.rule.WASM.Module == "" {
+			return "", fmt.Errorf("wasm.module is required")
+		}
+
+		if !strings.HasPrefix(v.rule.WASM.Module, "oci://") {
+			return "", fmt.Errorf("wasm.module must be an oci:// reference")
+		}
+
+		if v.rule.WASM.Entrypoint == "" {
+			return "", fmt.Errorf("wasm.entrypoint is required")
+		}
+	}
+
 	return "", nil
 }
 
 func (v *Validate) validateElements() error {
 	count := validationElemCount(v.rule)
 	if count == 0 {
-		return fmt.Errorf("one of pattern, anyPattern, deny, foreach, cel must be specified")
+		return fmt.Errorf("one of pattern, anyPattern, deny, foreach, cel, wasm must be specified")
 	}
 
 	if count > 1 {
-		return fmt.Errorf("only one of pattern, anyPattern, deny, foreach, cel can be specified")
+		return fmt.Errorf("only one of pattern, anyPattern, deny, foreach, cel, wasm can be specified")
 	}
 
 	return nil
@@ -166,6 +181,10 @@ func validationElemCount(v *kyvernov1.Validation) int {
 		count++
 	}
 
+	if v.WASM != nil {
+		count++
+	}
+
 	if v.Manifests != nil && len(v.Manifests.Attestors) != 0 {
 		count++
 	}
@@ -380,3 +380,42 @@ func Test_Validate_Validate_Unsupported(t *testing.T) {
 	}
 
 }
+
+func Test_Validate_WASM(t *testing.T) {
+	testCases := []struct {
+		rawValidation []byte
+		err           string
+	}{
+		{
+			rawValidation: []byte(`{"wasm": {"module": "oci://ghcr.io/org/checks:v1", "entrypoint": "validate"}}`),
+		},
+		{
+			rawValidation: []byte(`{"wasm": {"entrypoint": "validate"}}`),
+			err:           "wasm.module is required",
+		},
+		{
+			rawValidation: []byte(`{"wasm": {"module": "https://example.com/checks.wasm", "entrypoint": "validate"}}`),
+			err:           "wasm.module must be an oci:// reference",
+		},
+		{
+			rawValidation: []byte(`{"wasm": {"module": "oci://ghcr.io/org/checks:v1"}}`),
+			err:           "wasm.entrypoint is required",
+		},
+		{
+			rawValidation: []byte(`{"wasm": {"module": "oci://ghcr.io/org/checks:v1", "entrypoint": "validate"}, "deny": {}}`),
+			err:           "only one of pattern, anyPattern, deny, foreach, cel, wasm can be specified",
+		},
+	}
+	for _, tc := range testCases {
+		var validation kyverno.Validation
+		err := json.Unmarshal(tc.rawValidation, &validation)
+		assert.NilError(t, err)
+		checker := NewValidateFactory(&validation)
+		_, err = checker.Validate(context.TODO())
+		if tc.err == "" {
+			assert.NilError(t, err)
+		} else {
+			assert.ErrorContains(t, err, tc.err)
+		}
+	}
+}
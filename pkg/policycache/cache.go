@@ -4,6 +4,8 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/ext/wildcard"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -14,8 +16,8 @@ type ResourceFinder interface {
 
 // Cache get method use for to get policy names and mostly use to test cache testcases
 type Cache interface {
-	// Set inserts a policy in the cache
-	Set(string, kyvernov1.PolicyInterface, ResourceFinder) error
+	// Set inserts a policy in the cache, including the rules autogen generates for the given custom pod controllers
+	Set(string, kyvernov1.PolicyInterface, ResourceFinder, []config.AutogenController) error
 	// Unset removes a policy from the cache
 	Unset(string)
 	// GetPolicies returns all policies that apply to a namespace, including cluster-wide policies
@@ -34,8 +36,8 @@ func NewCache() Cache {
 	}
 }
 
-func (c *cache) Set(key string, policy kyvernov1.PolicyInterface, client ResourceFinder) error {
-	return c.store.set(key, policy, client)
+func (c *cache) Set(key string, policy kyvernov1.PolicyInterface, client ResourceFinder, autogenControllers []config.AutogenController) error {
+	return c.store.set(key, policy, client, autogenControllers)
 }
 
 func (c *cache) Unset(key string) {
@@ -55,7 +57,12 @@ func (c *cache) GetPolicies(pkey PolicyType, gvr schema.GroupVersionResource, su
 	if pkey == ValidateAudit || pkey == ValidateEnforce {
 		result = filterPolicies(pkey, result, nspace)
 	}
-	return result
+	// policies are returned in the order they must be applied
+	sorted, err := policyutils.Sort(result...)
+	if err != nil {
+		logger.Error(err, "failed to order policies")
+	}
+	return sorted
 }
 
 // Filter cluster policies using validationFailureAction override
@@ -13,7 +13,7 @@ import (
 
 func setPolicy(t *testing.T, store store, policy kyvernov1.PolicyInterface, finder ResourceFinder) {
 	key, _ := kubecache.MetaNamespaceKeyFunc(policy)
-	err := store.set(key, policy, finder)
+	err := store.set(key, policy, finder, nil)
 	assert.NilError(t, err)
 }
 
@@ -28,7 +28,7 @@ func Test_All(t *testing.T) {
 	finder := TestResourceFinder{}
 	//add
 	setPolicy(t, pCache, policy, finder)
-	for _, rule := range autogen.ComputeRules(policy) {
+	for _, rule := range autogen.ComputeRules(policy, nil) {
 		for _, kind := range rule.MatchResources.Kinds {
 			group, version, kind, subresource := kubeutils.ParseKindSelector(kind)
 			gvrs, err := finder.FindResources(group, version, kind, subresource)
@@ -64,7 +64,7 @@ func Test_Add_Duplicate_Policy(t *testing.T) {
 	setPolicy(t, pCache, policy, finder)
 	setPolicy(t, pCache, policy, finder)
 	setPolicy(t, pCache, policy, finder)
-	for _, rule := range autogen.ComputeRules(policy) {
+	for _, rule := range autogen.ComputeRules(policy, nil) {
 		for _, kind := range rule.MatchResources.Kinds {
 			group, version, kind, subresource := kubeutils.ParseKindSelector(kind)
 			gvrs, err := finder.FindResources(group, version, kind, subresource)
@@ -97,7 +97,7 @@ func Test_Add_Validate_Audit(t *testing.T) {
 	policy.Spec.ValidationFailureAction = "audit"
 	setPolicy(t, pCache, policy, finder)
 	setPolicy(t, pCache, policy, finder)
-	for _, rule := range autogen.ComputeRules(policy) {
+	for _, rule := range autogen.ComputeRules(policy, nil) {
 		for _, kind := range rule.MatchResources.Kinds {
 			group, version, kind, subresource := kubeutils.ParseKindSelector(kind)
 			gvrs, err := finder.FindResources(group, version, kind, subresource)
@@ -894,7 +894,7 @@ func Test_Ns_All(t *testing.T) {
 	//add
 	setPolicy(t, pCache, policy, finder)
 	nspace := policy.GetNamespace()
-	for _, rule := range autogen.ComputeRules(policy) {
+	for _, rule := range autogen.ComputeRules(policy, nil) {
 		for _, kind := range rule.MatchResources.Kinds {
 			group, version, kind, subresource := kubeutils.ParseKindSelector(kind)
 			gvrs, err := finder.FindResources(group, version, kind, subresource)
@@ -930,7 +930,7 @@ func Test_Ns_Add_Duplicate_Policy(t *testing.T) {
 	setPolicy(t, pCache, policy, finder)
 	setPolicy(t, pCache, policy, finder)
 	nspace := policy.GetNamespace()
-	for _, rule := range autogen.ComputeRules(policy) {
+	for _, rule := range autogen.ComputeRules(policy, nil) {
 		for _, kind := range rule.MatchResources.Kinds {
 			group, version, kind, subresource := kubeutils.ParseKindSelector(kind)
 			gvrs, err := finder.FindResources(group, version, kind, subresource)
@@ -963,7 +963,7 @@ func Test_Ns_Add_Validate_Audit(t *testing.T) {
 	policy.GetSpec().ValidationFailureAction = "audit"
 	setPolicy(t, pCache, policy, finder)
 	setPolicy(t, pCache, policy, finder)
-	for _, rule := range autogen.ComputeRules(policy) {
+	for _, rule := range autogen.ComputeRules(policy, nil) {
 		for _, kind := range rule.MatchResources.Kinds {
 			group, version, kind, subresource := kubeutils.ParseKindSelector(kind)
 			gvrs, err := finder.FindResources(group, version, kind, subresource)
@@ -1006,7 +1006,7 @@ func Test_GVk_Cache(t *testing.T) {
 	finder := TestResourceFinder{}
 	//add
 	setPolicy(t, pCache, policy, finder)
-	for _, rule := range autogen.ComputeRules(policy) {
+	for _, rule := range autogen.ComputeRules(policy, nil) {
 		for _, kind := range rule.MatchResources.Kinds {
 			group, version, kind, subresource := kubeutils.ParseKindSelector(kind)
 			gvrs, err := finder.FindResources(group, version, kind, subresource)
@@ -1044,7 +1044,7 @@ func Test_Add_Validate_Enforce(t *testing.T) {
 	finder := TestResourceFinder{}
 	//add
 	setPolicy(t, pCache, policy, finder)
-	for _, rule := range autogen.ComputeRules(policy) {
+	for _, rule := range autogen.ComputeRules(policy, nil) {
 		for _, kind := range rule.MatchResources.Kinds {
 			group, version, kind, subresource := kubeutils.ParseKindSelector(kind)
 			gvrs, err := finder.FindResources(group, version, kind, subresource)
@@ -1085,7 +1085,7 @@ func Test_Mutate_Policy(t *testing.T) {
 	setPolicy(t, pCache, policy, finder)
 	setPolicy(t, pCache, policy, finder)
 	setPolicy(t, pCache, policy, finder)
-	for _, rule := range autogen.ComputeRules(policy) {
+	for _, rule := range autogen.ComputeRules(policy, nil) {
 		for _, kind := range rule.MatchResources.Kinds {
 			group, version, kind, subresource := kubeutils.ParseKindSelector(kind)
 			gvrs, err := finder.FindResources(group, version, kind, subresource)
@@ -1107,7 +1107,7 @@ func Test_Generate_Policy(t *testing.T) {
 	finder := TestResourceFinder{}
 	//add
 	setPolicy(t, pCache, policy, finder)
-	for _, rule := range autogen.ComputeRules(policy) {
+	for _, rule := range autogen.ComputeRules(policy, nil) {
 		for _, kind := range rule.MatchResources.Kinds {
 			group, version, kind, subresource := kubeutils.ParseKindSelector(kind)
 			gvrs, err := finder.FindResources(group, version, kind, subresource)
@@ -1178,7 +1178,7 @@ func Test_Get_Policies(t *testing.T) {
 	policy := newPolicy(t)
 	finder := TestResourceFinder{}
 	key, _ := kubecache.MetaNamespaceKeyFunc(policy)
-	cache.Set(key, policy, finder)
+	cache.Set(key, policy, finder, nil)
 	validateAudit := cache.GetPolicies(ValidateAudit, namespacesGVRS.GroupVersionResource(), "", "")
 	if len(validateAudit) != 0 {
 		t.Errorf("expected 0 validate audit policy, found %v", len(validateAudit))
@@ -1206,7 +1206,7 @@ func Test_Get_Policies_Ns(t *testing.T) {
 	policy := newNsPolicy(t)
 	finder := TestResourceFinder{}
 	key, _ := kubecache.MetaNamespaceKeyFunc(policy)
-	cache.Set(key, policy, finder)
+	cache.Set(key, policy, finder, nil)
 	nspace := policy.GetNamespace()
 	validateAudit := cache.GetPolicies(ValidateAudit, podsGVRS.GroupVersionResource(), "", nspace)
 	if len(validateAudit) != 0 {
@@ -1232,9 +1232,9 @@ func Test_Get_Policies_Validate_Failure_Action_Overrides(t *testing.T) {
 	policy2 := newValidateEnforcePolicy(t)
 	finder := TestResourceFinder{}
 	key1, _ := kubecache.MetaNamespaceKeyFunc(policy1)
-	cache.Set(key1, policy1, finder)
+	cache.Set(key1, policy1, finder, nil)
 	key2, _ := kubecache.MetaNamespaceKeyFunc(policy2)
-	cache.Set(key2, policy2, finder)
+	cache.Set(key2, policy2, finder, nil)
 	validateAudit := cache.GetPolicies(ValidateAudit, podsGVRS.GroupVersionResource(), "", "")
 	if len(validateAudit) != 1 {
 		t.Errorf("expected 1 validate audit policy, found %v", len(validateAudit))
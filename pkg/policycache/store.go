@@ -5,6 +5,7 @@ import (
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/config"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"go.uber.org/multierr"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -14,7 +15,7 @@ import (
 
 type store interface {
 	// set inserts a policy in the cache
-	set(string, kyvernov1.PolicyInterface, ResourceFinder) error
+	set(string, kyvernov1.PolicyInterface, ResourceFinder, []config.AutogenController) error
 	// unset removes a policy from the cache
 	unset(string)
 	// get finds policies that match a given type, gvr, subresource and namespace
@@ -32,10 +33,10 @@ func newPolicyCache() store {
 	}
 }
 
-func (pc *policyCache) set(key string, policy kyvernov1.PolicyInterface, client ResourceFinder) error {
+func (pc *policyCache) set(key string, policy kyvernov1.PolicyInterface, client ResourceFinder, autogenControllers []config.AutogenController) error {
 	pc.lock.Lock()
 	defer pc.lock.Unlock()
-	if err := pc.store.set(key, policy, client); err != nil {
+	if err := pc.store.set(key, policy, client, autogenControllers); err != nil {
 		return err
 	}
 	logger.V(4).Info("policy is added to cache", "key", key)
@@ -99,7 +100,7 @@ func set(set sets.Set[string], item string, value bool) sets.Set[string] {
 	}
 }
 
-func (m *policyMap) set(key string, policy kyvernov1.PolicyInterface, client ResourceFinder) error {
+func (m *policyMap) set(key string, policy kyvernov1.PolicyInterface, client ResourceFinder, autogenControllers []config.AutogenController) error {
 	var errs []error
 	enforcePolicy := computeEnforcePolicy(policy.GetSpec())
 	m.policies[key] = policy
@@ -107,7 +108,7 @@ func (m *policyMap) set(key string, policy kyvernov1.PolicyInterface, client Res
 		hasMutate, hasValidate, hasGenerate, hasVerifyImages, hasImagesValidationChecks bool
 	}
 	kindStates := map[policyKey]state{}
-	for _, rule := range autogen.ComputeRules(policy) {
+	for _, rule := range autogen.ComputeRules(policy, autogenControllers) {
 		entries := sets.New[policyKey]()
 		for _, gvk := range rule.MatchResources.GetKinds() {
 			group, version, kind, subresource := kubeutils.ParseKindSelector(gvk)
@@ -0,0 +1,189 @@
+package recorder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/decisionlog"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"k8s.io/apimachinery/pkg/types"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+const (
+	// batchSize is the number of recordings written in a single object.
+	batchSize = 100
+	// flushInterval is the max delay before the pending recordings are written.
+	flushInterval = 30 * time.Second
+	// pendingBatches is the number of batches the recorder buffers before dropping new recordings.
+	pendingBatches = 10
+)
+
+// Recorder persists sampled admission requests to a store, recordings are buffered and written
+// in batches as JSON lines objects under <yyyy>/<mm>/<dd>/<unix nanos>-<sequence>.jsonl keys.
+type Recorder struct {
+	logger   logr.Logger
+	store    Store
+	sampling int
+	nsLister corev1listers.NamespaceLister
+	now      func() time.Time
+	sequence atomic.Uint64
+
+	lock    sync.Mutex
+	pending []Recording
+	flush   chan struct{}
+}
+
+// NewRecorder returns a recorder keeping one in sampling admission requests,
+// the namespace lister is optional and used to record the labels of the namespace of the resources.
+func NewRecorder(logger logr.Logger, store Store, sampling int, nsLister corev1listers.NamespaceLister) *Recorder {
+	if sampling < 1 {
+		sampling = 1
+	}
+	return &Recorder{
+		logger:   logger,
+		store:    store,
+		sampling: sampling,
+		nsLister: nsLister,
+		now:      time.Now,
+		flush:    make(chan struct{}, 1),
+	}
+}
+
+// IsSampled returns true when the admission request must be recorded, the mutating and validating
+// admission requests of a resource are either both recorded or both dropped.
+func (r *Recorder) IsSampled(uid types.UID) bool {
+	return decisionlog.IsSampled(uid, r.sampling)
+}
+
+// Record queues a recording, it never blocks the admission request: the recording is dropped
+// when too many recordings are waiting to be written.
+func (r *Recorder) Record(recording Recording) {
+	if err := redact(&recording); err != nil {
+		r.logger.Error(err, "failed to redact admission request, dropping it", "uid", recording.Request.UID)
+		return
+	}
+	if r.nsLister != nil && recording.Request.Namespace != "" {
+		if namespace, err := r.nsLister.Get(recording.Request.Namespace); err == nil {
+			recording.NamespaceLabels = namespace.GetLabels()
+		}
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if len(r.pending) >= batchSize*pendingBatches {
+		r.logger.V(2).Info("too many recordings waiting to be written, dropping admission request", "uid", recording.Request.UID)
+		return
+	}
+	r.pending = append(r.pending, recording)
+	if len(r.pending) >= batchSize {
+		select {
+		case r.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Run writes the pending recordings periodically until the context is cancelled,
+// the recordings still pending are written before returning.
+func (r *Recorder) Run(ctx context.Context, waitGroup *sync.WaitGroup) {
+	waitGroup.Add(1)
+	defer waitGroup.Done()
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			r.writeAll(context.WithoutCancel(ctx))
+			return
+		case <-ticker.C:
+		case <-r.flush:
+		}
+		r.writeAll(ctx)
+	}
+}
+
+func (r *Recorder) writeAll(ctx context.Context) {
+	for batch := r.next(); len(batch) != 0; batch = r.next() {
+		if err := r.write(ctx, batch); err != nil {
+			r.logger.Error(err, "failed to write recordings, dropping them", "recordings", len(batch))
+		}
+	}
+}
+
+// next removes and returns the next batch of pending recordings.
+func (r *Recorder) next() []Recording {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	n := len(r.pending)
+	if n > batchSize {
+		n = batchSize
+	}
+	batch := r.pending[:n:n]
+	r.pending = r.pending[n:]
+	return batch
+}
+
+func (r *Recorder) write(ctx context.Context, batch []Recording) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, recording := range batch {
+		if err := encoder.Encode(recording); err != nil {
+			return err
+		}
+	}
+	now := r.now().UTC()
+	key := fmt.Sprintf("%s/%d-%d.jsonl", now.Format("2006/01/02"), now.UnixNano(), r.sequence.Add(1))
+	return r.store.Put(ctx, key, body.Bytes())
+}
+
+// redact removes the data of the secrets from the admission request.
+func redact(recording *Recording) error {
+	if recording.Request.Kind.Kind != "Secret" {
+		return nil
+	}
+	for _, raw := range []*[]byte{&recording.Request.Object.Raw, &recording.Request.OldObject.Raw} {
+		if len(*raw) == 0 {
+			continue
+		}
+		resource, err := kubeutils.BytesToUnstructured(*raw)
+		if err != nil {
+			return err
+		}
+		redacted, err := kubeutils.RedactSecret(resource)
+		if err != nil {
+			return err
+		}
+		data, err := redacted.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		*raw = data
+	}
+	recording.Request.Object.Object, recording.Request.OldObject.Object = nil, nil
+	return nil
+}
+
+// Decode reads the recordings of a JSON lines object.
+func Decode(data []byte) ([]Recording, error) {
+	var recordings []Recording
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for decoder.More() {
+		// the decoder reuses its buffer, policies keep references to the raw JSON they were decoded from
+		var raw json.RawMessage
+		var recording Recording
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("failed to decode recording %d (%w)", len(recordings)+1, err)
+		}
+		if err := json.Unmarshal(raw, &recording); err != nil {
+			return nil, fmt.Errorf("failed to decode recording %d (%w)", len(recordings)+1, err)
+		}
+		recordings = append(recordings, recording)
+	}
+	return recordings, nil
+}
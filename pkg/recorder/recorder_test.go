@@ -0,0 +1,110 @@
+package recorder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestAddPolicies(t *testing.T) {
+	response := func(policy kyvernov1.PolicyInterface) engineapi.EngineResponse {
+		return engineapi.NewEngineResponse(unstructured.Unstructured{}, engineapi.NewKyvernoPolicy(policy), nil)
+	}
+	cpol := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "require-labels", ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}}}}
+	pol := &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "require-labels"}}
+	var recording Recording
+	recording.AddPolicies(response(cpol), response(pol), response(cpol))
+	assert.Equal(t, len(recording.ClusterPolicies), 1)
+	assert.Equal(t, len(recording.Policies), 1)
+	assert.Equal(t, recording.ClusterPolicies[0].Kind, "ClusterPolicy")
+	assert.Equal(t, recording.ClusterPolicies[0].APIVersion, "kyverno.io/v1")
+	assert.Assert(t, recording.ClusterPolicies[0].ManagedFields == nil)
+	assert.Equal(t, recording.Policies[0].Kind, "Policy")
+	assert.Equal(t, len(recording.PolicyInterfaces()), 2)
+	assert.Assert(t, cpol.Kind == "" && cpol.ManagedFields != nil, "the policies of the responses must not be modified")
+}
+
+func TestRecorder(t *testing.T) {
+	directory := t.TempDir()
+	store, err := NewStore(context.Background(), "file://"+directory)
+	assert.NilError(t, err)
+	recorder := NewRecorder(logr.Discard(), store, 1, nil)
+	recorder.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+	recorder.Record(Recording{
+		Webhook: "validate",
+		Request: admissionv1.AdmissionRequest{
+			UID:       "secret",
+			Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Secret"},
+			Namespace: "default",
+			Name:      "credentials",
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"credentials","namespace":"default"},"data":{"password":"c2VjcmV0"}}`)},
+		},
+		Allowed: true,
+	})
+	recorder.Record(Recording{
+		Webhook: "mutate",
+		Request: admissionv1.AdmissionRequest{
+			UID:       "pod",
+			Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Pod"},
+			Namespace: "default",
+			Name:      "nginx",
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"nginx","namespace":"default"}}`)},
+		},
+		Allowed: true,
+		Patches: []byte(`[{"op":"add","path":"/metadata/labels","value":{"env":"dev"}}]`),
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var wg sync.WaitGroup
+	recorder.Run(ctx, &wg)
+
+	files, err := filepath.Glob(filepath.Join(directory, "2024", "01", "02", "*.jsonl"))
+	assert.NilError(t, err)
+	assert.Equal(t, len(files), 1)
+	data, err := os.ReadFile(files[0])
+	assert.NilError(t, err)
+	assert.Assert(t, !strings.Contains(string(data), "c2VjcmV0"), "secret data must be redacted")
+	recordings, err := Decode(data)
+	assert.NilError(t, err)
+	assert.Equal(t, len(recordings), 2)
+	assert.Equal(t, recordings[0].Request.Name, "credentials")
+	assert.Equal(t, recordings[1].Webhook, "mutate")
+	assert.Equal(t, string(recordings[1].Patches), `[{"op":"add","path":"/metadata/labels","value":{"env":"dev"}}]`)
+}
+
+func TestSampling(t *testing.T) {
+	recorder := NewRecorder(logr.Discard(), nil, 4, nil)
+	sampled := 0
+	for i := 0; i < 100; i++ {
+		uid := types.UID(fmt.Sprintf("uid-%d", i))
+		if recorder.IsSampled(uid) {
+			sampled++
+		}
+		assert.Equal(t, recorder.IsSampled(uid), recorder.IsSampled(uid))
+	}
+	assert.Assert(t, sampled != 0 && sampled < 100)
+}
+
+func TestNewStore(t *testing.T) {
+	for _, target := range []string{"stdout", "http://localhost", "s3:///prefix"} {
+		_, err := NewStore(context.Background(), target)
+		assert.Assert(t, err != nil, target)
+	}
+}
@@ -0,0 +1,88 @@
+package recorder
+
+import (
+	"encoding/json"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+// Recording is an admission request processed by a webhook and the policies in effect when it was processed,
+// it holds everything needed to evaluate the request again against other versions of the policies.
+type Recording struct {
+	// Time is the time the admission request was received.
+	Time time.Time `json:"time"`
+	// Webhook is the webhook that processed the admission request, either mutate or validate.
+	Webhook string `json:"webhook"`
+	// Request is the admission request, the data of secrets is redacted.
+	Request admissionv1.AdmissionRequest `json:"request"`
+	// Roles are the roles of the user who sent the admission request.
+	Roles []string `json:"roles,omitempty"`
+	// ClusterRoles are the cluster roles of the user who sent the admission request.
+	ClusterRoles []string `json:"clusterRoles,omitempty"`
+	// NamespaceLabels are the labels of the namespace of the resource.
+	NamespaceLabels map[string]string `json:"namespaceLabels,omitempty"`
+	// Allowed is false when the admission request was denied.
+	Allowed bool `json:"allowed"`
+	// Message explains why the admission request was denied.
+	Message string `json:"message,omitempty"`
+	// Warnings are the warnings returned to the user.
+	Warnings []string `json:"warnings,omitempty"`
+	// Patches are the JSON patches returned by the mutate webhook.
+	Patches json.RawMessage `json:"patches,omitempty"`
+	// ClusterPolicies are the cluster policies evaluated on the admission request.
+	ClusterPolicies []kyvernov1.ClusterPolicy `json:"clusterPolicies,omitempty"`
+	// Policies are the namespaced policies evaluated on the admission request.
+	Policies []kyvernov1.Policy `json:"policies,omitempty"`
+}
+
+// AddPolicies adds the Kyverno policies of the engine responses to the recording, each policy is added once.
+func (r *Recording) AddPolicies(responses ...engineapi.EngineResponse) {
+	seen := map[string]struct{}{}
+	for _, policy := range r.ClusterPolicies {
+		seen[policy.GetName()] = struct{}{}
+	}
+	for _, policy := range r.Policies {
+		seen[policy.GetNamespace()+"/"+policy.GetName()] = struct{}{}
+	}
+	for _, response := range responses {
+		policy, ok := response.Policy().GetPolicy().(kyvernov1.PolicyInterface)
+		if !ok {
+			continue
+		}
+		key := policy.GetName()
+		if policy.IsNamespaced() {
+			key = policy.GetNamespace() + "/" + key
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		switch policy := policy.(type) {
+		case *kyvernov1.ClusterPolicy:
+			policy = policy.DeepCopy()
+			policy.APIVersion, policy.Kind = kyvernov1.SchemeGroupVersion.String(), "ClusterPolicy"
+			policy.ManagedFields = nil
+			r.ClusterPolicies = append(r.ClusterPolicies, *policy)
+		case *kyvernov1.Policy:
+			policy = policy.DeepCopy()
+			policy.APIVersion, policy.Kind = kyvernov1.SchemeGroupVersion.String(), "Policy"
+			policy.ManagedFields = nil
+			r.Policies = append(r.Policies, *policy)
+		}
+	}
+}
+
+// PolicyInterfaces returns the policies of the recording.
+func (r *Recording) PolicyInterfaces() []kyvernov1.PolicyInterface {
+	policies := make([]kyvernov1.PolicyInterface, 0, len(r.ClusterPolicies)+len(r.Policies))
+	for i := range r.ClusterPolicies {
+		policies = append(policies, &r.ClusterPolicies[i])
+	}
+	for i := range r.Policies {
+		policies = append(policies, &r.Policies[i])
+	}
+	return policies
+}
@@ -0,0 +1,123 @@
+package recorder
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+)
+
+// Store is the object storage recordings are persisted to.
+type Store interface {
+	// Put writes an object, the key is a slash separated path
+	Put(ctx context.Context, key string, data []byte) error
+}
+
+// NewStore returns the store of the target, the target is one of:
+// - `file://<directory>`, objects are written as files in the directory
+// - `s3://<bucket>/<prefix>?region=<region>&endpoint=<endpoint>`, objects are written in the S3 bucket,
+// credentials are resolved with the default AWS credentials chain and the endpoint is only needed
+// for S3 compatible stores
+func NewStore(ctx context.Context, target string) (Store, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recording store %q (%w)", target, err)
+	}
+	switch u.Scheme {
+	case "file":
+		return &fileStore{directory: u.Path}, nil
+	case "s3":
+		query := u.Query()
+		return newS3Store(ctx, &http.Client{Timeout: 30 * time.Second}, u.Host, strings.TrimPrefix(u.Path, "/"), query.Get("region"), query.Get("endpoint"))
+	}
+	return nil, fmt.Errorf("unsupported recording store %q, expected file:// or s3://", target)
+}
+
+type fileStore struct {
+	directory string
+}
+
+func (s *fileStore) Put(_ context.Context, key string, data []byte) error {
+	path := filepath.Join(s.directory, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+type s3Store struct {
+	client      *http.Client
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	bucket      string
+	prefix      string
+	region      string
+	endpoint    string
+}
+
+func newS3Store(ctx context.Context, client *http.Client, bucket, prefix, region, endpoint string) (Store, error) {
+	if bucket == "" {
+		return nil, errors.New("a bucket is required")
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration (%w)", err)
+	}
+	return &s3Store{
+		client:      client,
+		credentials: cfg.Credentials,
+		signer:      v4.NewSigner(),
+		bucket:      bucket,
+		prefix:      strings.TrimSuffix(prefix, "/"),
+		region:      cfg.Region,
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+	}, nil
+}
+
+func (s *s3Store) Put(ctx context.Context, key string, data []byte) error {
+	if s.prefix != "" {
+		key = s.prefix + "/" + key
+	}
+	url := fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.bucket, s.region, key)
+	if s.endpoint != "" {
+		url = s.endpoint + "/" + s.bucket + "/" + key
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	credentials, err := s.credentials.Retrieve(ctx)
+	if err != nil {
+		return err
+	}
+	payloadHash := sha256.Sum256(data)
+	hash := hex.EncodeToString(payloadHash[:])
+	req.Header.Set("X-Amz-Content-Sha256", hash)
+	if err := s.signer.SignHTTP(ctx, credentials, req, hash, "s3", s.region, time.Now().UTC()); err != nil {
+		return err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("PUT %s returned status %d: %s", url, resp.StatusCode, bytes.TrimSpace(message))
+	}
+	return nil
+}
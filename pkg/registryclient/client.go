@@ -9,11 +9,15 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/authn/github"
 	"github.com/google/go-containerregistry/pkg/name"
 	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	kyvernoconfig "github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/tracing"
+	"github.com/kyverno/kyverno/pkg/transport"
+	tlsutils "github.com/kyverno/kyverno/pkg/utils/tls"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"k8s.io/apimachinery/pkg/util/sets"
 	corev1listers "k8s.io/client-go/listers/core/v1"
@@ -60,11 +64,13 @@ type Client interface {
 type client struct {
 	keychain  authn.Keychain
 	transport http.RoundTripper
+	outbound  bool
 }
 
 type config struct {
 	keychain  []authn.Keychain
 	transport *http.Transport
+	outbound  func(*http.Transport) http.RoundTripper
 	tracing   bool
 }
 
@@ -81,6 +87,8 @@ func New(options ...Option) (Client, error) {
 			return nil, err
 		}
 	}
+	// the transport is cloned with the restricted tls configuration in the strict profile
+	cfg.transport = tlsutils.ConfigureTransport(cfg.transport)
 	c := &client{
 		keychain:  defaultKeychain,
 		transport: cfg.transport,
@@ -88,8 +96,12 @@ func New(options ...Option) (Client, error) {
 	if len(cfg.keychain) > 0 {
 		c.keychain = authn.NewMultiKeychain(cfg.keychain...)
 	}
+	if cfg.outbound != nil {
+		c.transport = cfg.outbound(cfg.transport)
+		c.outbound = true
+	}
 	if cfg.tracing {
-		c.transport = tracing.Transport(cfg.transport, otelhttp.WithFilter(tracing.RequestFilterIsInSpan))
+		c.transport = tracing.Transport(c.transport, otelhttp.WithFilter(tracing.RequestFilterIsInSpan))
 	}
 	return c, nil
 }
@@ -156,6 +168,16 @@ func WithLocalKeychain() Option {
 	}
 }
 
+// WithOutboundTransport applies the outbound transport settings from the Kyverno configuration.
+func WithOutboundTransport(logger logr.Logger, configuration kyvernoconfig.Configuration) Option {
+	return func(c *config) error {
+		c.outbound = func(base *http.Transport) http.RoundTripper {
+			return transport.New(logger, configuration, base)
+		}
+		return nil
+	}
+}
+
 // WithTracing enables tracing in the http client.
 func WithTracing() Option {
 	return func(c *config) error {
@@ -212,3 +234,12 @@ func (c *client) Keychain() authn.Keychain {
 func (c *client) getTransport() http.RoundTripper {
 	return c.transport
 }
+
+// OutboundTransport returns the transport configured from the Kyverno configuration, if any,
+// so that other clients talking to the same services (like Rekor) can share it.
+func (c *client) OutboundTransport() http.RoundTripper {
+	if !c.outbound {
+		return nil
+	}
+	return c.transport
+}
@@ -0,0 +1,216 @@
+package tls
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// CertificateModeSelfSigned lets kyverno generate and rotate a self signed CA and TLS pair
+	CertificateModeSelfSigned = "self-signed"
+	// CertificateModeCertManager lets cert-manager issue and rotate the TLS pair from a Certificate resource
+	CertificateModeCertManager = "cert-manager"
+	// caKey is the key cert-manager uses to store the issuing CA in the TLS secret
+	caKey = "ca.crt"
+)
+
+// CertificatesResource is the cert-manager Certificate resource
+var CertificatesResource = schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+
+// IssuerRef references the cert-manager issuer signing the TLS certificate
+type IssuerRef struct {
+	Name  string
+	Kind  string
+	Group string
+}
+
+// certManagerRenewer requests the TLS pair from cert-manager,
+// cert-manager writes the pair and the issuing CA in the TLS secret and renews it before expiration
+type certManagerRenewer struct {
+	client              dynamic.ResourceInterface
+	secretClient        client
+	tlsValidityDuration time.Duration
+	renewBefore         time.Duration
+
+	commonName string
+	dnsNames   []string
+	namespace  string
+	pairSecret string
+	issuerRef  IssuerRef
+}
+
+// NewCertManagerRenewer returns an instance of CertRenewer backed by a cert-manager Certificate
+func NewCertManagerRenewer(
+	client dynamic.ResourceInterface,
+	secretClient client,
+	tlsValidityDuration,
+	renewBefore time.Duration,
+	commonName string,
+	dnsNames []string,
+	namespace string,
+	pairSecret string,
+	issuerRef IssuerRef,
+) *certManagerRenewer {
+	return &certManagerRenewer{
+		client:              client,
+		secretClient:        secretClient,
+		tlsValidityDuration: tlsValidityDuration,
+		renewBefore:         renewBefore,
+		commonName:          commonName,
+		dnsNames:            dnsNames,
+		namespace:           namespace,
+		pairSecret:          pairSecret,
+		issuerRef:           issuerRef,
+	}
+}
+
+// RenewCA does nothing, the CA is owned by the cert-manager issuer
+func (c *certManagerRenewer) RenewCA(context.Context) error {
+	return nil
+}
+
+// RenewTLS creates or updates the Certificate and checks it is ready
+func (c *certManagerRenewer) RenewTLS(ctx context.Context) error {
+	certificate, err := c.client.Get(ctx, c.pairSecret, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get certificate (%w)", err)
+		}
+		certificate, err = c.client.Create(ctx, c.desiredCertificate(), metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to create certificate (%w)", err)
+		}
+	} else {
+		desired := c.desiredCertificate()
+		// only compare the fields we set, cert-manager and other controllers can default the rest of the spec
+		if !containsFields(certificate.Object["spec"], desired.Object["spec"]) {
+			spec, _, _ := unstructured.NestedMap(certificate.Object, "spec")
+			certificate.Object["spec"] = mergeFields(spec, desired.Object["spec"].(map[string]interface{}))
+			certificate, err = c.client.Update(ctx, certificate, metav1.UpdateOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to update certificate (%w)", err)
+			}
+		}
+	}
+	if ready, reason, message := certificateReady(certificate); !ready {
+		return fmt.Errorf("certificate %s/%s is not ready (%s: %s)", c.namespace, c.pairSecret, reason, message)
+	}
+	return nil
+}
+
+// ValidateCert validates the TLS certificate against the CA issued with it
+func (c *certManagerRenewer) ValidateCert(ctx context.Context) (bool, error) {
+	secret, err := c.secretClient.Get(ctx, c.pairSecret, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	certs := pemToCertificates(secret.Data[corev1.TLSCertKey])
+	if len(certs) == 0 {
+		return false, nil
+	}
+	caCerts := pemToCertificates(secret.Data[caKey])
+	if len(caCerts) == 0 {
+		return false, fmt.Errorf("%s in secret %s/%s", errorsNotFound, c.namespace, c.pairSecret)
+	}
+	return validateCert(time.Now(), certs[0], caCerts...), nil
+}
+
+func (c *certManagerRenewer) desiredCertificate() *unstructured.Unstructured {
+	dnsNames := make([]interface{}, 0, len(c.dnsNames))
+	for _, dnsName := range c.dnsNames {
+		dnsNames = append(dnsNames, dnsName)
+	}
+	issuerRef := map[string]interface{}{
+		"name": c.issuerRef.Name,
+	}
+	if c.issuerRef.Kind != "" {
+		issuerRef["kind"] = c.issuerRef.Kind
+	}
+	if c.issuerRef.Group != "" {
+		issuerRef["group"] = c.issuerRef.Group
+	}
+	certificate := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"secretName":  c.pairSecret,
+				"commonName":  c.commonName,
+				"dnsNames":    dnsNames,
+				"duration":    c.tlsValidityDuration.String(),
+				"renewBefore": c.renewBefore.String(),
+				"issuerRef":   issuerRef,
+				"usages":      []interface{}{"server auth"},
+				"privateKey": map[string]interface{}{
+					"algorithm":      "RSA",
+					"encoding":       "PKCS1",
+					"rotationPolicy": "Always",
+				},
+			},
+		},
+	}
+	certificate.SetAPIVersion(CertificatesResource.GroupVersion().String())
+	certificate.SetKind("Certificate")
+	certificate.SetName(c.pairSecret)
+	certificate.SetNamespace(c.namespace)
+	certificate.SetLabels(map[string]string{
+		kyverno.LabelCertManagedBy: kyverno.ValueKyvernoApp,
+	})
+	return certificate
+}
+
+func certificateReady(certificate *unstructured.Unstructured) (bool, string, string) {
+	conditions, _, _ := unstructured.NestedSlice(certificate.Object, "status", "conditions")
+	for _, condition := range conditions {
+		condition, ok := condition.(map[string]interface{})
+		if !ok || condition["type"] != "Ready" {
+			continue
+		}
+		reason, _ := condition["reason"].(string)
+		message, _ := condition["message"].(string)
+		return condition["status"] == string(metav1.ConditionTrue), reason, message
+	}
+	return false, "Pending", "certificate has not been issued yet"
+}
+
+// containsFields returns true if every field set in desired has the same value in actual
+func containsFields(actual, desired interface{}) bool {
+	desiredMap, ok := desired.(map[string]interface{})
+	if !ok {
+		return equality.Semantic.DeepEqual(actual, desired)
+	}
+	actualMap, ok := actual.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for key, value := range desiredMap {
+		if !containsFields(actualMap[key], value) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeFields sets the fields of desired in actual and keeps the other fields of actual
+func mergeFields(actual, desired map[string]interface{}) map[string]interface{} {
+	if actual == nil {
+		actual = map[string]interface{}{}
+	}
+	for key, value := range desired {
+		if desiredMap, ok := value.(map[string]interface{}); ok {
+			actualMap, _ := actual[key].(map[string]interface{})
+			actual[key] = mergeFields(actualMap, desiredMap)
+		} else {
+			actual[key] = value
+		}
+	}
+	return actual
+}
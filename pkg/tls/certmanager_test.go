@@ -0,0 +1,206 @@
+package tls
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func newCertManagerRenewer(objects ...runtime.Object) (*certManagerRenewer, *dynamicfake.FakeDynamicClient, *kubefake.Clientset) {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{CertificatesResource: "CertificateList"},
+		objects...,
+	)
+	kubeClient := kubefake.NewSimpleClientset()
+	renewer := NewCertManagerRenewer(
+		client.Resource(CertificatesResource).Namespace("kyverno"),
+		kubeClient.CoreV1().Secrets("kyverno"),
+		TLSValidityDuration,
+		15*24*time.Hour,
+		"kyverno-svc",
+		[]string{"kyverno-svc", "kyverno-svc.kyverno", "kyverno-svc.kyverno.svc"},
+		"kyverno",
+		"kyverno-svc.kyverno.svc.kyverno-tls-pair",
+		IssuerRef{Name: "kyverno", Kind: "ClusterIssuer", Group: "cert-manager.io"},
+	)
+	return renewer, client, kubeClient
+}
+
+// issuedCertificate returns the certificate desired by the renewer, defaulted and issued by cert-manager
+func issuedCertificate(renewer *certManagerRenewer, status string) *unstructured.Unstructured {
+	certificate := renewer.desiredCertificate()
+	spec := certificate.Object["spec"].(map[string]interface{})
+	spec["revisionHistoryLimit"] = int64(1)
+	spec["privateKey"].(map[string]interface{})["size"] = int64(2048)
+	certificate.Object["status"] = map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{
+				"type":    "Ready",
+				"status":  status,
+				"reason":  "Issuing",
+				"message": "issuing certificate as secret does not exist",
+			},
+		},
+	}
+	return certificate
+}
+
+func countActions(client *dynamicfake.FakeDynamicClient, verb string) int {
+	count := 0
+	for _, action := range client.Actions() {
+		if action.GetVerb() == verb {
+			count++
+		}
+	}
+	return count
+}
+
+func TestCertManagerRenewTLS(t *testing.T) {
+	t.Run("create certificate", func(t *testing.T) {
+		renewer, client, _ := newCertManagerRenewer()
+		err := renewer.RenewTLS(context.TODO())
+		assert.Error(t, err, "certificate kyverno/kyverno-svc.kyverno.svc.kyverno-tls-pair is not ready (Pending: certificate has not been issued yet)")
+		certificate, err := client.Resource(CertificatesResource).Namespace("kyverno").Get(context.TODO(), "kyverno-svc.kyverno.svc.kyverno-tls-pair", metav1.GetOptions{})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, certificate.Object["spec"], renewer.desiredCertificate().Object["spec"])
+		assert.Equal(t, certificate.GetLabels()[kyverno.LabelCertManagedBy], kyverno.ValueKyvernoApp)
+	})
+	t.Run("certificate up to date", func(t *testing.T) {
+		renewer, _, _ := newCertManagerRenewer()
+		renewer, client, _ := newCertManagerRenewer(issuedCertificate(renewer, "True"))
+		assert.NilError(t, renewer.RenewTLS(context.TODO()))
+		assert.Equal(t, countActions(client, "update"), 0)
+	})
+	t.Run("certificate not ready", func(t *testing.T) {
+		renewer, _, _ := newCertManagerRenewer()
+		renewer, client, _ := newCertManagerRenewer(issuedCertificate(renewer, "False"))
+		err := renewer.RenewTLS(context.TODO())
+		assert.Error(t, err, "certificate kyverno/kyverno-svc.kyverno.svc.kyverno-tls-pair is not ready (Issuing: issuing certificate as secret does not exist)")
+		assert.Equal(t, countActions(client, "update"), 0)
+	})
+	t.Run("certificate out of date", func(t *testing.T) {
+		renewer, _, _ := newCertManagerRenewer()
+		certificate := issuedCertificate(renewer, "True")
+		spec := certificate.Object["spec"].(map[string]interface{})
+		spec["dnsNames"] = []interface{}{"kyverno-svc"}
+		spec["privateKey"].(map[string]interface{})["algorithm"] = "ECDSA"
+		renewer, client, _ := newCertManagerRenewer(certificate)
+		assert.NilError(t, renewer.RenewTLS(context.TODO()))
+		assert.Equal(t, countActions(client, "update"), 1)
+		certificate, err := client.Resource(CertificatesResource).Namespace("kyverno").Get(context.TODO(), "kyverno-svc.kyverno.svc.kyverno-tls-pair", metav1.GetOptions{})
+		assert.NilError(t, err)
+		assert.Assert(t, containsFields(certificate.Object["spec"], renewer.desiredCertificate().Object["spec"]))
+		// fields we don't set are preserved
+		revisionHistoryLimit, _, _ := unstructured.NestedInt64(certificate.Object, "spec", "revisionHistoryLimit")
+		assert.Equal(t, revisionHistoryLimit, int64(1))
+		size, _, _ := unstructured.NestedInt64(certificate.Object, "spec", "privateKey", "size")
+		assert.Equal(t, size, int64(2048))
+	})
+}
+
+func TestCertManagerValidateCert(t *testing.T) {
+	caPrivateKey, caCert, err := generateCA(nil, CAValidityDuration)
+	assert.NilError(t, err)
+	_, otherCACert, err := generateCA(nil, CAValidityDuration)
+	assert.NilError(t, err)
+	tlsKey, tlsCert, err := generateTLS("", caCert, caPrivateKey, TLSValidityDuration, "kyverno-svc", []string{"kyverno-svc.kyverno.svc"})
+	assert.NilError(t, err)
+	tests := []struct {
+		name    string
+		data    map[string][]byte
+		want    bool
+		wantErr string
+	}{{
+		name: "valid certificate",
+		data: map[string][]byte{
+			corev1.TLSCertKey:       certificateToPem(tlsCert),
+			corev1.TLSPrivateKeyKey: privateKeyToPem(tlsKey),
+			caKey:                   certificateToPem(caCert),
+		},
+		want: true,
+	}, {
+		name: "not issued by the ca",
+		data: map[string][]byte{
+			corev1.TLSCertKey:       certificateToPem(tlsCert),
+			corev1.TLSPrivateKeyKey: privateKeyToPem(tlsKey),
+			caKey:                   certificateToPem(otherCACert),
+		},
+	}, {
+		name: "not issued yet",
+		data: map[string][]byte{},
+	}, {
+		name: "missing ca",
+		data: map[string][]byte{
+			corev1.TLSCertKey:       certificateToPem(tlsCert),
+			corev1.TLSPrivateKeyKey: privateKeyToPem(tlsKey),
+		},
+		wantErr: "root CA certificate not found in secret kyverno/kyverno-svc.kyverno.svc.kyverno-tls-pair",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renewer, _, kubeClient := newCertManagerRenewer()
+			_, err := kubeClient.CoreV1().Secrets("kyverno").Create(context.TODO(), &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "kyverno-svc.kyverno.svc.kyverno-tls-pair", Namespace: "kyverno"},
+				Type:       corev1.SecretTypeTLS,
+				Data:       tt.data,
+			}, metav1.CreateOptions{})
+			assert.NilError(t, err)
+			valid, err := renewer.ValidateCert(context.TODO())
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+			} else {
+				assert.NilError(t, err)
+			}
+			assert.Equal(t, valid, tt.want)
+		})
+	}
+	t.Run("missing secret", func(t *testing.T) {
+		renewer, _, _ := newCertManagerRenewer()
+		valid, err := renewer.ValidateCert(context.TODO())
+		assert.Assert(t, err != nil)
+		assert.Assert(t, !valid)
+	})
+}
+
+func TestContainsFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		actual  interface{}
+		desired interface{}
+		want    bool
+	}{{
+		name:    "equal",
+		actual:  map[string]interface{}{"a": "b", "c": []interface{}{"d"}},
+		desired: map[string]interface{}{"a": "b", "c": []interface{}{"d"}},
+		want:    true,
+	}, {
+		name:    "extra fields",
+		actual:  map[string]interface{}{"a": "b", "e": map[string]interface{}{"f": "g", "h": "i"}},
+		desired: map[string]interface{}{"a": "b", "e": map[string]interface{}{"f": "g"}},
+		want:    true,
+	}, {
+		name:    "missing field",
+		actual:  map[string]interface{}{"a": "b"},
+		desired: map[string]interface{}{"a": "b", "e": map[string]interface{}{"f": "g"}},
+	}, {
+		name:    "different value",
+		actual:  map[string]interface{}{"a": "b", "c": []interface{}{"d", "e"}},
+		desired: map[string]interface{}{"a": "b", "c": []interface{}{"d"}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, containsFields(tt.actual, tt.desired), tt.want)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package tls
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	"github.com/kyverno/kyverno/pkg/logging"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	certificatesv1client "k8s.io/client-go/kubernetes/typed/certificates/v1"
+)
+
+const (
+	// CertificateModeCSR lets an external signer issue the TLS pair from a CertificateSigningRequest
+	CertificateModeCSR = "csr"
+	// csrApprovalReason is the reason of the approval condition kyverno sets on its certificate signing requests
+	csrApprovalReason = "KyvernoApproved"
+	// csrPollInterval is the interval at which kyverno checks if the certificate was issued
+	csrPollInterval = time.Second
+	// csrSigningTimeout is the time kyverno waits for the signer to issue the certificate
+	csrSigningTimeout = time.Minute
+)
+
+var logger = logging.WithName("tls")
+
+// csrRenewer requests the TLS pair from an external signer through the certificates API,
+// the CA of the signer is provided in the CA secret and is not managed by kyverno
+type csrRenewer struct {
+	*certRenewer
+	csrClient  certificatesv1client.CertificateSigningRequestInterface
+	signerName string
+}
+
+// NewCSRRenewer returns an instance of CertRenewer backed by CertificateSigningRequests
+func NewCSRRenewer(
+	client client,
+	csrClient certificatesv1client.CertificateSigningRequestInterface,
+	tlsValidityDuration,
+	renewBefore time.Duration,
+	commonName string,
+	dnsNames []string,
+	namespace string,
+	caSecret string,
+	pairSecret string,
+	signerName string,
+) *csrRenewer {
+	return &csrRenewer{
+		certRenewer: &certRenewer{
+			client:              client,
+			tlsValidityDuration: tlsValidityDuration,
+			renewBefore:         renewBefore,
+			commonName:          commonName,
+			dnsNames:            dnsNames,
+			namespace:           namespace,
+			caSecret:            caSecret,
+			pairSecret:          pairSecret,
+		},
+		csrClient:  csrClient,
+		signerName: signerName,
+	}
+}
+
+// RenewCA checks the CA of the signer is provided, the CA itself is owned by the signer
+func (c *csrRenewer) RenewCA(ctx context.Context) error {
+	_, _, caCerts, err := c.decodeCASecret(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read CA (%w)", err)
+	}
+	if len(caCerts) == 0 {
+		return fmt.Errorf("%s in secret %s/%s", errorsNotFound, c.namespace, c.caSecret)
+	}
+	return nil
+}
+
+// RenewTLS requests a new TLS certificate from the signer if needed
+func (c *csrRenewer) RenewTLS(ctx context.Context) error {
+	secret, _, cert, err := c.decodeTLSSecret(ctx)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to read TLS (%w)", err)
+	}
+	if cert != nil {
+		valid, err := c.ValidateCert(ctx)
+		if err == nil && valid && !allCertificatesExpired(time.Now().Add(c.renewBefore), cert) {
+			return nil
+		}
+	}
+	if !isSecretManagedByKyverno(secret) {
+		return fmt.Errorf("tls is not valid but certificates are not managed by kyverno, we can't renew them")
+	}
+	if secret != nil && secret.Type != corev1.SecretTypeTLS {
+		return c.client.Delete(ctx, secret.Name, metav1.DeleteOptions{})
+	}
+	tlsKey, tlsCert, err := c.requestTLS(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to request TLS (%w)", err)
+	}
+	if err := c.writeTLSSecret(ctx, tlsKey, tlsCert); err != nil {
+		return fmt.Errorf("failed to write TLS (%w)", err)
+	}
+	return nil
+}
+
+// requestTLS creates and approves a certificate signing request and waits for the signer to issue the certificate
+func (c *csrRenewer) requestTLS(ctx context.Context) (*rsa.PrivateKey, *x509.Certificate, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, err
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: c.commonName},
+		DNSNames: c.dnsNames,
+	}, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	expirationSeconds := int32(c.tlsValidityDuration.Seconds())
+	csr := &certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: c.pairSecret + "-",
+			Labels: map[string]string{
+				kyverno.LabelCertManagedBy: kyverno.ValueKyvernoApp,
+			},
+		},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Request:           pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}),
+			SignerName:        c.signerName,
+			ExpirationSeconds: &expirationSeconds,
+			Usages: []certificatesv1.KeyUsage{
+				certificatesv1.UsageDigitalSignature,
+				certificatesv1.UsageKeyEncipherment,
+				certificatesv1.UsageServerAuth,
+			},
+		},
+	}
+	csr, err = c.csrClient.Create(ctx, csr, metav1.CreateOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create certificate signing request (%w)", err)
+	}
+	// the certificate is stored in the TLS secret, the request is not needed once it's issued or failed
+	defer func() {
+		if err := c.csrClient.Delete(context.Background(), csr.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			logger.Error(err, "failed to delete certificate signing request", "name", csr.Name)
+		}
+	}()
+	csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+		Type:    certificatesv1.CertificateApproved,
+		Status:  corev1.ConditionTrue,
+		Reason:  csrApprovalReason,
+		Message: "certificate requested by kyverno for its webhook server",
+	})
+	if _, err := c.csrClient.UpdateApproval(ctx, csr.Name, csr, metav1.UpdateOptions{}); err != nil {
+		return nil, nil, fmt.Errorf("failed to approve certificate signing request %s (%w)", csr.Name, err)
+	}
+	var certs []*x509.Certificate
+	err = wait.PollUntilContextTimeout(ctx, csrPollInterval, csrSigningTimeout, true, func(ctx context.Context) (bool, error) {
+		csr, err := c.csrClient.Get(ctx, csr.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, condition := range csr.Status.Conditions {
+			if condition.Type == certificatesv1.CertificateDenied || condition.Type == certificatesv1.CertificateFailed {
+				return false, fmt.Errorf("certificate signing request %s is %s (%s: %s)", csr.Name, condition.Type, condition.Reason, condition.Message)
+			}
+		}
+		if len(csr.Status.Certificate) == 0 {
+			return false, nil
+		}
+		certs = pemToCertificates(csr.Status.Certificate)
+		if len(certs) == 0 {
+			return false, fmt.Errorf("certificate signing request %s contains an invalid certificate", csr.Name)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to wait for certificate signing request %s (%w)", csr.Name, err)
+	}
+	// the signer returns the issued certificate first, followed by the intermediates if any
+	return key, certs[0], nil
+}
@@ -0,0 +1,179 @@
+package tls
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// fakeSigner signs the approved certificate signing requests with its CA
+type fakeSigner struct {
+	caKey  *rsa.PrivateKey
+	caCert *x509.Certificate
+	deny   bool
+}
+
+func (s *fakeSigner) react(action k8stesting.Action) (bool, runtime.Object, error) {
+	csr := action.(k8stesting.UpdateAction).GetObject().(*certificatesv1.CertificateSigningRequest)
+	if s.deny {
+		csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+			Type:    certificatesv1.CertificateDenied,
+			Status:  corev1.ConditionTrue,
+			Reason:  "PolicyDenied",
+			Message: "not allowed",
+		})
+		return false, nil, nil
+	}
+	block, _ := pem.Decode(csr.Spec.Request)
+	request, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return true, nil, err
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      request.Subject,
+		DNSNames:     request.DNSNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Duration(*csr.Spec.ExpirationSeconds) * time.Second),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, s.caCert, request.PublicKey, s.caKey)
+	if err != nil {
+		return true, nil, err
+	}
+	csr.Status.Certificate = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	return false, nil, nil
+}
+
+func newCSRRenewer(signer *fakeSigner, objects ...runtime.Object) (*csrRenewer, *kubefake.Clientset) {
+	client := kubefake.NewSimpleClientset(objects...)
+	// the fake client doesn't support generate name
+	client.PrependReactor("create", "certificatesigningrequests", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		csr := action.(k8stesting.CreateAction).GetObject().(*certificatesv1.CertificateSigningRequest)
+		csr.Name = csr.GenerateName + "abcde"
+		return false, nil, nil
+	})
+	// the fake client doesn't set resource versions
+	client.PrependReactor("create", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		action.(k8stesting.CreateAction).GetObject().(*corev1.Secret).ResourceVersion = "1"
+		return false, nil, nil
+	})
+	client.PrependReactor("update", "certificatesigningrequests", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "approval" {
+			return false, nil, nil
+		}
+		return signer.react(action)
+	})
+	renewer := NewCSRRenewer(
+		client.CoreV1().Secrets("kyverno"),
+		client.CertificatesV1().CertificateSigningRequests(),
+		TLSValidityDuration,
+		15*24*time.Hour,
+		"kyverno-svc",
+		[]string{"kyverno-svc", "kyverno-svc.kyverno", "kyverno-svc.kyverno.svc"},
+		"kyverno",
+		"kyverno-svc.kyverno.svc.kyverno-tls-ca",
+		"kyverno-svc.kyverno.svc.kyverno-tls-pair",
+		"example.com/kyverno",
+	)
+	return renewer, client
+}
+
+func newFakeSigner(t *testing.T) (*fakeSigner, *corev1.Secret) {
+	caKey, caCert, err := generateCA(nil, CAValidityDuration)
+	assert.NilError(t, err)
+	return &fakeSigner{caKey: caKey, caCert: caCert}, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "kyverno-svc.kyverno.svc.kyverno-tls-ca", Namespace: "kyverno"},
+		Data: map[string][]byte{
+			"ca.crt": certificateToPem(caCert),
+		},
+	}
+}
+
+func countCSRActions(client *kubefake.Clientset, verb string) int {
+	count := 0
+	for _, action := range client.Actions() {
+		if action.GetResource().Resource == "certificatesigningrequests" && action.GetVerb() == verb {
+			count++
+		}
+	}
+	return count
+}
+
+func TestCSRRenewCA(t *testing.T) {
+	signer, caSecret := newFakeSigner(t)
+	renewer, _ := newCSRRenewer(signer, caSecret)
+	assert.NilError(t, renewer.RenewCA(context.TODO()))
+
+	renewer, _ = newCSRRenewer(signer)
+	assert.ErrorContains(t, renewer.RenewCA(context.TODO()), "failed to read CA")
+
+	caSecret.Data = nil
+	renewer, _ = newCSRRenewer(signer, caSecret)
+	assert.Error(t, renewer.RenewCA(context.TODO()), "root CA certificate not found in secret kyverno/kyverno-svc.kyverno.svc.kyverno-tls-ca")
+}
+
+func TestCSRRenewTLS(t *testing.T) {
+	t.Run("request certificate", func(t *testing.T) {
+		signer, caSecret := newFakeSigner(t)
+		renewer, client := newCSRRenewer(signer, caSecret)
+		assert.NilError(t, renewer.RenewTLS(context.TODO()))
+		valid, err := renewer.ValidateCert(context.TODO())
+		assert.NilError(t, err)
+		assert.Assert(t, valid)
+		_, key, cert, err := renewer.decodeTLSSecret(context.TODO())
+		assert.NilError(t, err)
+		assert.Assert(t, key.PublicKey.Equal(cert.PublicKey))
+		assert.DeepEqual(t, cert.DNSNames, []string{"kyverno-svc", "kyverno-svc.kyverno", "kyverno-svc.kyverno.svc"})
+		// the request is deleted once the certificate is issued
+		csrs, err := client.CertificatesV1().CertificateSigningRequests().List(context.TODO(), metav1.ListOptions{})
+		assert.NilError(t, err)
+		assert.Equal(t, len(csrs.Items), 0)
+		// the certificate is valid, no new request is created
+		assert.NilError(t, renewer.RenewTLS(context.TODO()))
+		assert.Equal(t, countCSRActions(client, "create"), 1)
+	})
+	t.Run("certificate about to expire", func(t *testing.T) {
+		signer, caSecret := newFakeSigner(t)
+		tlsKey, tlsCert, err := generateTLS("", signer.caCert, signer.caKey, 24*time.Hour, "kyverno-svc", []string{"kyverno-svc"})
+		assert.NilError(t, err)
+		renewer, client := newCSRRenewer(signer, caSecret)
+		assert.NilError(t, renewer.writeTLSSecret(context.TODO(), tlsKey, tlsCert))
+		assert.NilError(t, renewer.RenewTLS(context.TODO()))
+		assert.Equal(t, countCSRActions(client, "create"), 1)
+		_, _, cert, err := renewer.decodeTLSSecret(context.TODO())
+		assert.NilError(t, err)
+		assert.Assert(t, cert.NotAfter.After(time.Now().Add(renewer.renewBefore)))
+	})
+	t.Run("certificate not managed by kyverno", func(t *testing.T) {
+		signer, caSecret := newFakeSigner(t)
+		renewer, client := newCSRRenewer(signer, caSecret, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "kyverno-svc.kyverno.svc.kyverno-tls-pair", Namespace: "kyverno"},
+			Type:       corev1.SecretTypeTLS,
+		})
+		assert.Error(t, renewer.RenewTLS(context.TODO()), "tls is not valid but certificates are not managed by kyverno, we can't renew them")
+		assert.Equal(t, countCSRActions(client, "create"), 0)
+	})
+	t.Run("request denied", func(t *testing.T) {
+		signer, caSecret := newFakeSigner(t)
+		signer.deny = true
+		renewer, client := newCSRRenewer(signer, caSecret)
+		assert.ErrorContains(t, renewer.RenewTLS(context.TODO()), "certificate signing request kyverno-svc.kyverno.svc.kyverno-tls-pair-abcde is Denied (PolicyDenied: not allowed)")
+		assert.Equal(t, countCSRActions(client, "delete"), 1)
+		_, err := client.CoreV1().Secrets("kyverno").Get(context.TODO(), "kyverno-svc.kyverno.svc.kyverno-tls-pair", metav1.GetOptions{})
+		assert.Assert(t, err != nil)
+	})
+}
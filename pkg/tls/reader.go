@@ -2,6 +2,7 @@ package tls
 
 import (
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
@@ -15,8 +16,12 @@ func ReadRootCASecret(name, namespace string, client corev1listers.SecretNamespa
 	if err != nil {
 		return nil, err
 	}
-	// try "tls.crt"
-	result := stlsca.Data[corev1.TLSCertKey]
+	// try "ca.crt", set when the secret is issued by cert-manager
+	result := stlsca.Data[caKey]
+	// if not there, try "tls.crt"
+	if len(result) == 0 {
+		result = stlsca.Data[corev1.TLSCertKey]
+	}
 	// if not there, try old "rootCA.crt"
 	if len(result) == 0 {
 		result = stlsca.Data[rootCAKey]
@@ -26,3 +31,16 @@ func ReadRootCASecret(name, namespace string, client corev1listers.SecretNamespa
 	}
 	return result, nil
 }
+
+// ReadCertificateExpiry returns the expiration time of the certificate stored in the given secret
+func ReadCertificateExpiry(name, namespace string, client corev1listers.SecretNamespaceLister) (time.Time, error) {
+	secret, err := client.Get(name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	certs := pemToCertificates(secret.Data[corev1.TLSCertKey])
+	if len(certs) == 0 {
+		return time.Time{}, fmt.Errorf("certificate not found in secret %s/%s", namespace, secret.Name)
+	}
+	return certs[0].NotAfter, nil
+}
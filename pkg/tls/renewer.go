@@ -193,6 +193,9 @@ func (c *certRenewer) decodeSecret(ctx context.Context, name string) (*corev1.Se
 	if secret != nil {
 		keyBytes = secret.Data[corev1.TLSPrivateKeyKey]
 		certBytes = secret.Data[corev1.TLSCertKey]
+		if len(certBytes) == 0 {
+			certBytes = secret.Data[caKey]
+		}
 		if len(certBytes) == 0 {
 			certBytes = secret.Data[rootCAKey]
 		}
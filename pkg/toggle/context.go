@@ -11,6 +11,10 @@ type Toggles interface {
 	ForceFailurePolicyIgnore() bool
 	EnableDeferredLoading() bool
 	GenerateValidatingAdmissionPolicy() bool
+	ShadowMode() bool
+	ReportUpdateDiff() bool
+	AdmissionDeduplication() bool
+	NamespacedPolicyDelegation() bool
 }
 
 type defaultToggles struct{}
@@ -31,6 +35,22 @@ func (defaultToggles) GenerateValidatingAdmissionPolicy() bool {
 	return GenerateValidatingAdmissionPolicy.enabled()
 }
 
+func (defaultToggles) ShadowMode() bool {
+	return ShadowMode.enabled()
+}
+
+func (defaultToggles) ReportUpdateDiff() bool {
+	return ReportUpdateDiff.enabled()
+}
+
+func (defaultToggles) AdmissionDeduplication() bool {
+	return AdmissionDeduplication.enabled()
+}
+
+func (defaultToggles) NamespacedPolicyDelegation() bool {
+	return NamespacedPolicyDelegation.enabled()
+}
+
 type contextKey struct{}
 
 func NewContext(ctx context.Context, toggles Toggles) context.Context {
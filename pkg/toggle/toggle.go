@@ -26,6 +26,26 @@ const (
 	GenerateValidatingAdmissionPolicyDescription = "Set the flag to 'true', to generate validating admission policies."
 	generateValidatingAdmissionPolicyEnvVar      = "FLAG_GENERATE_VALIDATING_ADMISSION_POLICY"
 	defaultGenerateValidatingAdmissionPolicy     = false
+	// shadow mode
+	ShadowModeFlagName    = "shadowMode"
+	ShadowModeDescription = "Set the flag to 'true', to evaluate admission requests without ever denying or mutating them."
+	shadowModeEnvVar      = "FLAG_SHADOW_MODE"
+	defaultShadowMode     = false
+	// report update diff
+	ReportUpdateDiffFlagName    = "reportUpdateDiff"
+	ReportUpdateDiffDescription = "Set the flag to 'true', to record the fields changed by an update in the report results of the violations it triggers."
+	reportUpdateDiffEnvVar      = "FLAG_REPORT_UPDATE_DIFF"
+	defaultReportUpdateDiff     = false
+	// admission requests deduplication
+	AdmissionDeduplicationFlagName    = "admissionDeduplication"
+	AdmissionDeduplicationDescription = "Set the flag to 'true', to share the response of an admission request with the identical requests received while it is processed."
+	admissionDeduplicationEnvVar      = "FLAG_ADMISSION_DEDUPLICATION"
+	defaultAdmissionDeduplication     = false
+	// namespaced policy delegation
+	NamespacedPolicyDelegationFlagName    = "namespacedPolicyDelegation"
+	NamespacedPolicyDelegationDescription = "Set the flag to 'true', to allow namespaced policies to access resources in other namespaces when their author is authorized to."
+	namespacedPolicyDelegationEnvVar      = "FLAG_NAMESPACED_POLICY_DELEGATION"
+	defaultNamespacedPolicyDelegation     = false
 )
 
 var (
@@ -33,6 +53,10 @@ var (
 	ForceFailurePolicyIgnore          = newToggle(defaultForceFailurePolicyIgnore, forceFailurePolicyIgnoreEnvVar)
 	EnableDeferredLoading             = newToggle(defaultEnableDeferredLoading, enableDeferredLoadingEnvVar)
 	GenerateValidatingAdmissionPolicy = newToggle(defaultGenerateValidatingAdmissionPolicy, generateValidatingAdmissionPolicyEnvVar)
+	ShadowMode                        = newToggle(defaultShadowMode, shadowModeEnvVar)
+	ReportUpdateDiff                  = newToggle(defaultReportUpdateDiff, reportUpdateDiffEnvVar)
+	AdmissionDeduplication            = newToggle(defaultAdmissionDeduplication, admissionDeduplicationEnvVar)
+	NamespacedPolicyDelegation        = newToggle(defaultNamespacedPolicyDelegation, namespacedPolicyDelegationEnvVar)
 )
 
 type ToggleFlag interface {
@@ -58,6 +58,12 @@ const (
 	ResponseResultReasonKey  = attribute.Key("admission.response.result.reason")
 	ResponseResultCodeKey    = attribute.Key("admission.response.result.code")
 	ResponsePatchTypeKey     = attribute.Key("admission.response.patchtype")
+	// controller attributes
+	ControllerNameKey        = attribute.Key("kyverno.controller.name")
+	ControllerKeyKey         = attribute.Key("kyverno.controller.key")
+	UpdateRequestNameKey     = attribute.Key("kyverno.updaterequest.name")
+	UpdateRequestTypeKey     = attribute.Key("kyverno.updaterequest.type")
+	UpdateRequestResourceKey = attribute.Key("kyverno.updaterequest.resource")
 	// kube client attributes
 	KubeClientGroupKey     = attribute.Key("kube.client.group")
 	KubeClientKindKey      = attribute.Key("kube.client.kind")
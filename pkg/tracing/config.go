@@ -18,7 +18,7 @@ import (
 )
 
 // NewTraceConfig generates the initial tracing configuration with 'address' as the endpoint to connect to the Opentelemetry Collector
-func NewTraceConfig(log logr.Logger, tracerName, address, certs string, kubeClient kubernetes.Interface) (func(), error) {
+func NewTraceConfig(log logr.Logger, tracerName, address, certs string, sampling Sampling, kubeClient kubernetes.Interface) (func(), error) {
 	ctx := context.Background()
 	var client otlptrace.Client
 	if certs != "" {
@@ -59,7 +59,8 @@ func NewTraceConfig(log logr.Logger, tracerName, address, certs string, kubeClie
 	}
 	// create controller and bind the exporter with it
 	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(traceExp),
+		sdktrace.WithSampler(sampling.sampler()),
+		sdktrace.WithSpanProcessor(sampling.processor(sdktrace.NewBatchSpanProcessor(traceExp))),
 		sdktrace.WithResource(res),
 	)
 	// set global propagator to tracecontext (the default is no-op).
@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TraceParentAnnotation records the trace context a resource was created from,
+// controllers processing the resource later link their spans to it
+const TraceParentAnnotation = "kyverno.io/traceparent"
+
+const traceParentHeader = "traceparent"
+
+// SetTraceParent records the span context found in ctx in the annotations of obj, if any
+func SetTraceParent(ctx context.Context, obj metav1.Object) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return
+	}
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	if traceParent := carrier.Get(traceParentHeader); traceParent != "" {
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[TraceParentAnnotation] = traceParent
+		obj.SetAnnotations(annotations)
+	}
+}
+
+// LinkFromObject returns a link to the trace recorded in the annotations of obj,
+// the link is invalid when obj has no trace context
+func LinkFromObject(obj metav1.Object) trace.Link {
+	traceParent := obj.GetAnnotations()[TraceParentAnnotation]
+	if traceParent == "" {
+		return trace.Link{}
+	}
+	ctx := propagation.TraceContext{}.Extract(context.Background(), propagation.MapCarrier{traceParentHeader: traceParent})
+	return trace.Link{SpanContext: trace.SpanContextFromContext(ctx)}
+}
+
+// WithObjectLinks links a span to the traces recorded in the annotations of the given objects
+func WithObjectLinks[T metav1.Object](objs ...T) trace.SpanStartOption {
+	var links []trace.Link
+	for _, obj := range objs {
+		if link := LinkFromObject(obj); link.SpanContext.IsValid() {
+			links = append(links, link)
+		}
+	}
+	return trace.WithLinks(links...)
+}
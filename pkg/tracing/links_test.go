@@ -0,0 +1,30 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTraceParent(t *testing.T) {
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	obj := &metav1.ObjectMeta{}
+	// no span context
+	SetTraceParent(context.TODO(), obj)
+	assert.Empty(t, obj.GetAnnotations())
+	assert.False(t, LinkFromObject(obj).SpanContext.IsValid())
+	// span context recorded and linked
+	SetTraceParent(trace.ContextWithSpanContext(context.TODO(), spanContext), obj)
+	assert.Equal(t, "00-01000000000000000000000000000000-0200000000000000-01", obj.GetAnnotations()[TraceParentAnnotation])
+	link := LinkFromObject(obj)
+	assert.Equal(t, spanContext.TraceID(), link.SpanContext.TraceID())
+	assert.Equal(t, spanContext.SpanID(), link.SpanContext.SpanID())
+	assert.True(t, link.SpanContext.IsRemote())
+}
@@ -0,0 +1,141 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SamplingKeepKey is a tail sampling hint, traces containing a span with this attribute set to true are always retained.
+const SamplingKeepKey = attribute.Key("kyverno.sampling.keep")
+
+// maxPendingTraces bounds the number of traces buffered by the tail sampler,
+// spans of new traces are decided on their own when the limit is reached.
+const maxPendingTraces = 10000
+
+// Sampling configures which traces are retained.
+type Sampling struct {
+	// Ratio is the ratio of traces retained, between 0 and 1.
+	Ratio float64
+	// Tail enables tail sampling, traces are buffered until their local root span ends and are always
+	// retained when they contain an error, a denied admission request or a span marked with SamplingKeepKey.
+	// Other traces are retained according to Ratio.
+	Tail bool
+}
+
+// Keep marks the trace of the span found in the context so that it is always retained by the tail sampler.
+func Keep(ctx context.Context) {
+	trace.SpanFromContext(ctx).SetAttributes(SamplingKeepKey.Bool(true))
+}
+
+// sampler returns the head sampler, every span is recorded when tail sampling is enabled
+// and the decision is taken when the trace ends.
+func (s Sampling) sampler() sdktrace.Sampler {
+	if s.Tail {
+		return sdktrace.AlwaysSample()
+	}
+	return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(s.Ratio))
+}
+
+// processor wraps the span processor exporting spans with the tail sampler when enabled.
+func (s Sampling) processor(next sdktrace.SpanProcessor) sdktrace.SpanProcessor {
+	if !s.Tail {
+		return next
+	}
+	return newTailSampler(next, s.Ratio)
+}
+
+type pendingTrace struct {
+	spans []sdktrace.ReadOnlySpan
+	keep  bool
+}
+
+type tailSampler struct {
+	next   sdktrace.SpanProcessor
+	ratio  sdktrace.Sampler
+	lock   sync.Mutex
+	traces map[trace.TraceID]*pendingTrace
+}
+
+func newTailSampler(next sdktrace.SpanProcessor, ratio float64) *tailSampler {
+	return &tailSampler{
+		next:   next,
+		ratio:  sdktrace.TraceIDRatioBased(ratio),
+		traces: map[trace.TraceID]*pendingTrace{},
+	}
+}
+
+func (t *tailSampler) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	t.next.OnStart(parent, s)
+}
+
+func (t *tailSampler) OnEnd(s sdktrace.ReadOnlySpan) {
+	traceID := s.SpanContext().TraceID()
+	// the local root span ends last, its parent is either missing or remote
+	root := !s.Parent().IsValid() || s.Parent().IsRemote()
+	t.lock.Lock()
+	pending, ok := t.traces[traceID]
+	if !ok {
+		pending = &pendingTrace{}
+	}
+	pending.spans = append(pending.spans, s)
+	pending.keep = pending.keep || mustKeep(s)
+	switch {
+	case root:
+		delete(t.traces, traceID)
+	case ok:
+		t.lock.Unlock()
+		return
+	case len(t.traces) < maxPendingTraces:
+		t.traces[traceID] = pending
+		t.lock.Unlock()
+		return
+	}
+	t.lock.Unlock()
+	if pending.keep || t.sampled(traceID) {
+		for _, span := range pending.spans {
+			t.next.OnEnd(span)
+		}
+	}
+}
+
+func (t *tailSampler) Shutdown(ctx context.Context) error {
+	return t.next.Shutdown(ctx)
+}
+
+func (t *tailSampler) ForceFlush(ctx context.Context) error {
+	return t.next.ForceFlush(ctx)
+}
+
+func (t *tailSampler) sampled(traceID trace.TraceID) bool {
+	result := t.ratio.ShouldSample(sdktrace.SamplingParameters{TraceID: traceID})
+	return result.Decision == sdktrace.RecordAndSample
+}
+
+// mustKeep returns true when a span carries a tail sampling hint: an error, a denied admission request,
+// a keep hint or a sampled remote parent.
+func mustKeep(s sdktrace.ReadOnlySpan) bool {
+	if s.Status().Code == codes.Error {
+		return true
+	}
+	if s.Parent().IsRemote() && s.Parent().IsSampled() {
+		return true
+	}
+	for _, attr := range s.Attributes() {
+		switch attr.Key {
+		case ResponseAllowedKey:
+			if !attr.Value.AsBool() {
+				return true
+			}
+		case SamplingKeepKey:
+			if attr.Value.AsBool() {
+				return true
+			}
+		}
+	}
+	return false
+}
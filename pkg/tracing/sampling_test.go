@@ -0,0 +1,76 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gotest.tools/assert"
+)
+
+func newTestProvider(sampling Sampling) (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sampling.sampler()),
+		sdktrace.WithSpanProcessor(sampling.processor(sdktrace.NewSimpleSpanProcessor(exporter))),
+	)
+	return provider, exporter
+}
+
+func TestTailSampling(t *testing.T) {
+	provider, exporter := newTestProvider(Sampling{Ratio: 0, Tail: true})
+	tracer := provider.Tracer("test")
+	// allowed request, dropped
+	ctx, root := tracer.Start(context.TODO(), "allowed")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+	root.SetAttributes(ResponseAllowedKey.Bool(true))
+	root.End()
+	assert.Equal(t, 0, len(exporter.GetSpans()))
+	// denied request, retained with its children
+	ctx, root = tracer.Start(context.TODO(), "denied")
+	_, child = tracer.Start(ctx, "child")
+	child.End()
+	assert.Equal(t, 0, len(exporter.GetSpans()))
+	root.SetAttributes(ResponseAllowedKey.Bool(false))
+	root.End()
+	assert.Equal(t, 2, len(exporter.GetSpans()))
+	exporter.Reset()
+	// error in a child span, retained
+	ctx, root = tracer.Start(context.TODO(), "error")
+	_, child = tracer.Start(ctx, "child")
+	SetSpanStatus(child, errors.New("failed"))
+	child.End()
+	root.End()
+	spans := exporter.GetSpans()
+	assert.Equal(t, 2, len(spans))
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	exporter.Reset()
+	// keep hint, retained
+	ctx, root = tracer.Start(context.TODO(), "hint")
+	Keep(ctx)
+	root.End()
+	assert.Equal(t, 1, len(exporter.GetSpans()))
+}
+
+func TestTailSamplingRatio(t *testing.T) {
+	provider, exporter := newTestProvider(Sampling{Ratio: 1, Tail: true})
+	tracer := provider.Tracer("test")
+	ctx, root := tracer.Start(context.TODO(), "allowed")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+	root.End()
+	assert.Equal(t, 2, len(exporter.GetSpans()))
+}
+
+func TestHeadSampling(t *testing.T) {
+	provider, exporter := newTestProvider(Sampling{Ratio: 0})
+	tracer := provider.Tracer("test")
+	_, root := tracer.Start(context.TODO(), "denied")
+	root.SetAttributes(ResponseAllowedKey.Bool(false))
+	root.End()
+	assert.Equal(t, 0, len(exporter.GetSpans()))
+}
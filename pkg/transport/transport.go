@@ -0,0 +1,199 @@
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/config"
+	tlsutils "github.com/kyverno/kyverno/pkg/utils/tls"
+)
+
+type destination struct {
+	transport *http.Transport
+	timeout   time.Duration
+}
+
+type roundTripper struct {
+	logger        logr.Logger
+	configuration config.Configuration
+	base          *http.Transport
+	changed       atomic.Bool
+	mux           sync.Mutex
+	destinations  map[string]destination
+}
+
+// New returns a round tripper applying the outbound transport settings from the Kyverno configuration.
+// The base transport is used as is when no settings apply to the destination host, otherwise it is cloned
+// and the CA bundle, client certificate, proxy and timeout configured for the host are applied to the clone.
+func New(logger logr.Logger, configuration config.Configuration, base *http.Transport) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport.(*http.Transport)
+	}
+	base = tlsutils.ConfigureTransport(base)
+	rt := &roundTripper{
+		logger:        logger,
+		configuration: configuration,
+		base:          base,
+		destinations:  map[string]destination{},
+	}
+	// callbacks are invoked while the configuration is locked, the transports are rebuilt lazily
+	configuration.OnChanged(func() { rt.changed.Store(true) })
+	return rt
+}
+
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	destination, err := rt.destination(req.URL.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure transport for %s: %w", req.URL.Host, err)
+	}
+	if destination.timeout <= 0 {
+		return destination.transport.RoundTrip(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), destination.timeout)
+	resp, err := destination.transport.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// the deadline must hold until the response body has been consumed
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+func (rt *roundTripper) destination(host string) (destination, error) {
+	rt.mux.Lock()
+	defer rt.mux.Unlock()
+	if rt.changed.Swap(false) {
+		for _, destination := range rt.destinations {
+			if destination.transport != rt.base {
+				destination.transport.CloseIdleConnections()
+			}
+		}
+		rt.destinations = map[string]destination{}
+	}
+	if destination, ok := rt.destinations[host]; ok {
+		return destination, nil
+	}
+	settings := rt.configuration.GetOutboundTransport().Settings(host)
+	transport, err := build(rt.base, settings)
+	if err != nil {
+		return destination{}, err
+	}
+	out := destination{transport: transport}
+	if settings.Timeout != nil {
+		out.timeout = settings.Timeout.Duration
+	}
+	rt.destinations[host] = out
+	rt.logger.V(4).Info("outbound transport configured", "host", host, "proxy", settings.Proxy, "timeout", out.timeout)
+	return out, nil
+}
+
+func build(base *http.Transport, settings config.OutboundTransportSettings) (*http.Transport, error) {
+	hasCA := settings.CABundle != "" || settings.CAFile != ""
+	hasClientCertificate := settings.ClientCertificate != "" || settings.ClientCertificateFile != ""
+	if !hasCA && !hasClientCertificate && settings.Proxy == "" {
+		return base, nil
+	}
+	transport := base.Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = tlsutils.ClientConfig()
+	}
+	if hasCA {
+		pool, err := certPool(settings)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+	if hasClientCertificate {
+		getClientCertificate, err := clientCertificate(settings)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig.GetClientCertificate = getClientCertificate
+	}
+	if settings.Proxy != "" {
+		proxy, err := url.Parse(settings.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy %s: %w", settings.Proxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	return transport, nil
+}
+
+// certPool adds the configured CA bundle to the system roots so that public endpoints keep working.
+func certPool(settings config.OutboundTransportSettings) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	bundle := []byte(settings.CABundle)
+	if settings.CAFile != "" {
+		data, err := os.ReadFile(settings.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		bundle = data
+	}
+	if !pool.AppendCertsFromPEM(bundle) {
+		return nil, errors.New("failed to parse PEM CA bundle")
+	}
+	return pool, nil
+}
+
+// clientCertificate loads the client key pair, key pairs read from files are reloaded on every handshake
+// so that rotated certificates are picked up without restarting.
+func clientCertificate(settings config.OutboundTransportSettings) (func(*tls.CertificateRequestInfo) (*tls.Certificate, error), error) {
+	load := func() (tls.Certificate, error) {
+		certificate := []byte(settings.ClientCertificate)
+		key := []byte(settings.ClientKey)
+		if settings.ClientCertificateFile != "" {
+			data, err := os.ReadFile(settings.ClientCertificateFile)
+			if err != nil {
+				return tls.Certificate{}, fmt.Errorf("failed to read client certificate file: %w", err)
+			}
+			certificate = data
+		}
+		if settings.ClientKeyFile != "" {
+			data, err := os.ReadFile(settings.ClientKeyFile)
+			if err != nil {
+				return tls.Certificate{}, fmt.Errorf("failed to read client key file: %w", err)
+			}
+			key = data
+		}
+		return tls.X509KeyPair(certificate, key)
+	}
+	// fail early on a broken configuration
+	if _, err := load(); err != nil {
+		return nil, err
+	}
+	return func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		pair, err := load()
+		if err != nil {
+			return nil, err
+		}
+		return &pair, nil
+	}, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
@@ -0,0 +1,77 @@
+package transport
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func load(t *testing.T, configuration config.Configuration, transport map[string]any) {
+	data, err := json.Marshal(transport)
+	assert.NilError(t, err)
+	configuration.Load(&corev1.ConfigMap{Data: map[string]string{"outboundTransport": string(data)}})
+}
+
+func Test_CABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	u, err := url.Parse(server.URL)
+	assert.NilError(t, err)
+	configuration := config.NewDefaultConfiguration(false)
+	client := &http.Client{Transport: New(logr.Discard(), configuration, nil)}
+	// the test server certificate is not trusted by default
+	_, err = client.Get(server.URL)
+	assert.Assert(t, err != nil)
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	load(t, configuration, map[string]any{
+		"destinations": []map[string]any{{
+			"hosts":    []string{u.Host},
+			"caBundle": string(ca),
+		}},
+	})
+	resp, err := client.Get(server.URL)
+	assert.NilError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+}
+
+func Test_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+	configuration := config.NewDefaultConfiguration(false)
+	load(t, configuration, map[string]any{
+		"defaults": map[string]any{"timeout": "100ms"},
+	})
+	client := &http.Client{Transport: New(logr.Discard(), configuration, nil)}
+	start := time.Now()
+	_, err := client.Get(server.URL)
+	assert.Assert(t, err != nil)
+	assert.Assert(t, time.Since(start) < 5*time.Second)
+}
+
+func Test_InvalidClientCertificate(t *testing.T) {
+	configuration := config.NewDefaultConfiguration(false)
+	load(t, configuration, map[string]any{
+		"defaults": map[string]any{
+			"clientCertificateFile": "/does/not/exist.crt",
+			"clientKeyFile":         "/does/not/exist.key",
+		},
+	})
+	client := &http.Client{Transport: New(logr.Discard(), configuration, nil)}
+	_, err := client.Get("https://example.com")
+	assert.ErrorContains(t, err, "failed to configure transport")
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/logging"
 	imageutils "github.com/kyverno/kyverno/pkg/utils/image"
+	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -23,7 +24,9 @@ var (
 	podExtractors               = BuildStandardExtractors("spec")
 	podControllerExtractors     = BuildStandardExtractors("spec", "template", "spec")
 	cronjobControllerExtractors = BuildStandardExtractors("spec", "jobTemplate", "spec", "template", "spec")
-	registeredExtractors        = map[string][]imageExtractor{
+	// revisions of DaemonSets and StatefulSets store the pod template of the revision in their data
+	controllerRevisionExtractors = BuildStandardExtractors("data", "spec", "template", "spec")
+	registeredExtractors         = map[string][]imageExtractor{
 		"Pod":                   podExtractors,
 		"DaemonSet":             podControllerExtractors,
 		"Deployment":            podControllerExtractors,
@@ -32,6 +35,7 @@ var (
 		"StatefulSet":           podControllerExtractors,
 		"CronJob":               cronjobControllerExtractors,
 		"Job":                   podControllerExtractors,
+		"ControllerRevision":    controllerRevisionExtractors,
 	}
 )
 
@@ -88,7 +92,7 @@ func extract(
 		return fmt.Errorf("invalid image config")
 	}
 	if len(fields) == 0 {
-		pointer := fmt.Sprintf("/%s/%s", strings.Join(path, "/"), valuePath)
+		pointer := jsonutils.JoinPointer(append(path, valuePath)...)
 		key := pointer
 		if keyPath != "" {
 			key, ok = output[keyPath].(string)
@@ -128,6 +128,22 @@ func Test_extractImageInfo(t *testing.T) {
 				},
 			},
 		},
+		{
+			raw: []byte(`{"apiVersion": "apps/v1","kind": "ControllerRevision","metadata": {"name": "web-7d4b9c8f6"},"revision": 2,"data": {"spec": {"template": {"$patch": "replace","spec": {"containers": [{"name": "web","image": "test.example.com/test/web:v1"}]}}}}}`),
+			images: map[string]map[string]ImageInfo{
+				"containers": {
+					"web": {
+						imageutils.ImageInfo{
+							Registry: "test.example.com",
+							Name:     "web",
+							Path:     "test/web",
+							Tag:      "v1",
+						},
+						"/data/spec/template/spec/containers/0/image",
+					},
+				},
+			},
+		},
 		{
 			extractionConfig: kyvernov1.ImageExtractorConfigs{
 				"Task": []kyvernov1.ImageExtractorConfig{
@@ -238,6 +254,27 @@ func Test_extractImageInfo(t *testing.T) {
 				},
 			},
 		},
+		{
+			extractionConfig: kyvernov1.ImageExtractorConfigs{
+				"Task": []kyvernov1.ImageExtractorConfig{
+					{Path: "/spec/images/*", Value: "image"},
+				},
+			},
+			raw: []byte(`{"apiVersion":"tekton.dev/v1beta1","kind":"Task","metadata":{"name":"mytask"},"spec":{"images":{"web/frontend":{"image":"nginx:latest"}}}}`),
+			images: map[string]map[string]ImageInfo{
+				"custom": {
+					"/spec/images/web~1frontend/image": {
+						imageutils.ImageInfo{
+							Registry: "docker.io",
+							Name:     "nginx",
+							Path:     "nginx",
+							Tag:      "latest",
+						},
+						"/spec/images/web~1frontend/image",
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
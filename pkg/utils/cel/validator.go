@@ -0,0 +1,51 @@
+package cel
+
+import (
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	admissionregistrationv1alpha1 "k8s.io/api/admissionregistration/v1alpha1"
+	"k8s.io/apiserver/pkg/admission/plugin/cel"
+	"k8s.io/apiserver/pkg/admission/plugin/validatingadmissionpolicy"
+	"k8s.io/apiserver/pkg/admission/plugin/webhook/matchconditions"
+)
+
+// NewValidator compiles the CEL expressions of a validate.cel rule, the returned validator
+// doesn't depend on the admission request and can be reused across requests.
+func NewValidator(policyKind, policyName string, rule kyvernov1.Rule) (validatingadmissionpolicy.Validator, error) {
+	if rule.Validation.CEL == nil {
+		return nil, fmt.Errorf("rule %s is not a validate.cel rule", rule.Name)
+	}
+	// check if the rule uses parameter resources
+	hasParam := rule.Validation.CEL.HasParam()
+	// extract preconditions written as CEL expressions
+	matchConditions := rule.CELPreconditions
+	// extract CEL expressions used in validations and audit annotations
+	variables := rule.Validation.CEL.Variables
+	validations := make([]admissionregistrationv1alpha1.Validation, len(rule.Validation.CEL.Expressions))
+	copy(validations, rule.Validation.CEL.Expressions)
+	for i := range validations {
+		if validations[i].Message == "" {
+			validations[i].Message = rule.Validation.Message
+		}
+	}
+	auditAnnotations := rule.Validation.CEL.AuditAnnotations
+
+	optionalVars := cel.OptionalVariableDeclarations{HasParams: hasParam, HasAuthorizer: true}
+	expressionOptionalVars := cel.OptionalVariableDeclarations{HasParams: hasParam, HasAuthorizer: false}
+	// compile CEL expressions
+	compiler, err := NewCompiler(validations, auditAnnotations, matchConditions, variables)
+	if err != nil {
+		return nil, err
+	}
+	compiler.CompileVariables(optionalVars)
+	filter := compiler.CompileValidateExpressions(optionalVars)
+	messageExpressionfilter := compiler.CompileMessageExpressions(expressionOptionalVars)
+	auditAnnotationFilter := compiler.CompileAuditAnnotationsExpressions(optionalVars)
+	matchConditionFilter := compiler.CompileMatchExpressions(optionalVars)
+
+	// newMatcher will be used to check if the incoming resource matches the CEL preconditions
+	newMatcher := matchconditions.NewMatcher(matchConditionFilter, nil, policyKind, "", policyName)
+	// the validator will be used to validate CEL expressions against the incoming object
+	return validatingadmissionpolicy.NewValidator(filter, newMatcher, auditAnnotationFilter, messageExpressionfilter, nil), nil
+}
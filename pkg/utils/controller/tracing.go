@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithTracing runs every reconciliation of a controller in its own trace
+func WithTracing(controllerName string, r reconcileFunc) reconcileFunc {
+	return func(ctx context.Context, logger logr.Logger, key string, namespace string, name string) error {
+		return tracing.Span1(
+			ctx,
+			"",
+			fmt.Sprintf("RECONCILE %s", controllerName),
+			func(ctx context.Context, span trace.Span) error {
+				err := r(ctx, logger, key, namespace, name)
+				tracing.SetSpanStatus(span, err)
+				return err
+			},
+			trace.WithNewRoot(),
+			trace.WithAttributes(
+				tracing.ControllerNameKey.String(controllerName),
+				tracing.ControllerKeyKey.String(tracing.StringValue(key)),
+			),
+		)
+	}
+}
@@ -23,6 +23,9 @@ type ImageInfo struct {
 
 	// Digest is the image digest portion e.g. `sha256:128c6e3534b842a2eec139999b8ce8aa9a2af9907e2b9269550809d18cd832a3`
 	Digest string `json:"digest,omitempty"`
+
+	// Original is the image reference as written in the resource, it is only set when image references are preserved
+	Original string `json:"original,omitempty"`
 }
 
 func (i *ImageInfo) String() string {
@@ -39,6 +42,15 @@ func (i *ImageInfo) String() string {
 	}
 }
 
+// Display returns the image reference as it should be shown to users.
+// When image references are preserved this is the reference as written in the resource, otherwise the canonical one.
+func (i *ImageInfo) Display() string {
+	if i.Original != "" {
+		return i.Original
+	}
+	return i.String()
+}
+
 func (i *ImageInfo) ReferenceWithTag() string {
 	if i.Registry != "" {
 		return fmt.Sprintf("%s/%s:%s", i.Registry, i.Path, i.Tag)
@@ -72,8 +84,12 @@ func GetImageInfo(image string, cfg config.Configuration) (*ImageInfo, error) {
 	if digest == "" && tag == "" {
 		tag = "latest"
 	}
-	// if registry mutation isn't enabled don't add the default registry
-	if fullImageName != image && !config.Configuration.GetEnableDefaultRegistryMutation(cfg) {
+	// when image references are preserved the engine works on the canonical form and keeps the original one for display
+	var original string
+	if config.Configuration.GetPreserveImageReferences(cfg) {
+		original = image
+	} else if fullImageName != image && !config.Configuration.GetEnableDefaultRegistryMutation(cfg) {
+		// if registry mutation isn't enabled don't add the default registry
 		registry = ""
 	}
 
@@ -83,6 +99,7 @@ func GetImageInfo(image string, cfg config.Configuration) (*ImageInfo, error) {
 		Path:     path,
 		Tag:      tag,
 		Digest:   digest,
+		Original: original,
 	}, nil
 }
 
@@ -225,3 +225,27 @@ func Test_addDefaultRegistry(t *testing.T) {
 		assert.Equal(t, tt.want, got)
 	}
 }
+
+func Test_PreserveImageReferences(t *testing.T) {
+	cm := v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kyverno", Name: "kyverno"},
+		Data: map[string]string{
+			"enableDefaultRegistryMutation": "false",
+			"preserveImageReferences":       "true",
+		},
+	}
+	preserved := config.NewDefaultConfiguration(false)
+	preserved.Load(&cm)
+	imageInfo, err := GetImageInfo("nginx", preserved)
+	assert.NoError(t, err)
+	assert.Equal(t, "docker.io", imageInfo.Registry)
+	assert.Equal(t, "docker.io/nginx:latest", imageInfo.String())
+	assert.Equal(t, "nginx", imageInfo.Display())
+
+	cfg, err := initializeMockConfig("docker.io", false)
+	assert.NoError(t, err)
+	imageInfo, err = GetImageInfo("nginx", cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "", imageInfo.Original)
+	assert.Equal(t, "nginx:latest", imageInfo.Display())
+}
@@ -2,10 +2,13 @@ package json
 
 import (
 	"encoding/json"
+	"strings"
 
 	jsonpatch "github.com/evanphx/json-patch/v5"
 )
 
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
 type PatchOperation struct {
 	Path  string      `json:"path"`
 	Op    string      `json:"op"`
@@ -45,3 +48,72 @@ func UnmarshalPatchOperation(patch []byte) (*PatchOperation, error) {
 	}
 	return &p, nil
 }
+
+// JoinPointer returns the RFC 6901 JSON pointer made of the given unescaped segments.
+func JoinPointer(segments ...string) string {
+	var sb strings.Builder
+	for _, segment := range segments {
+		sb.WriteByte('/')
+		_, _ = pointerEscaper.WriteString(&sb, segment)
+	}
+	return sb.String()
+}
+
+// PatchBuilder builds a JSON patch from typed operations and applies them to a document at once.
+// Values are marshalled when the patch is built, paths given as segments are escaped with JoinPointer.
+type PatchBuilder struct {
+	operations []PatchOperation
+}
+
+func NewPatchBuilder() *PatchBuilder {
+	return &PatchBuilder{}
+}
+
+// Add adds an add operation at the path made of the given segments.
+func (b *PatchBuilder) Add(value interface{}, segments ...string) *PatchBuilder {
+	return b.Operation("add", JoinPointer(segments...), value)
+}
+
+// Replace adds a replace operation at the path made of the given segments.
+func (b *PatchBuilder) Replace(value interface{}, segments ...string) *PatchBuilder {
+	return b.Operation("replace", JoinPointer(segments...), value)
+}
+
+// Remove adds a remove operation at the path made of the given segments.
+func (b *PatchBuilder) Remove(segments ...string) *PatchBuilder {
+	return b.Operation("remove", JoinPointer(segments...), nil)
+}
+
+// Operation adds an operation at a path that is already an escaped JSON pointer.
+func (b *PatchBuilder) Operation(op, path string, value interface{}) *PatchBuilder {
+	b.operations = append(b.operations, NewPatchOperation(path, op, value))
+	return b
+}
+
+// Len returns the number of operations in the patch.
+func (b *PatchBuilder) Len() int {
+	return len(b.operations)
+}
+
+// Bytes returns the JSON patch.
+func (b *PatchBuilder) Bytes() ([]byte, error) {
+	return json.Marshal(b.operations)
+}
+
+// Apply applies the patch to the document, the patch is decoded once and applied in a single pass.
+// Missing paths are created on add and ignored on remove, negative array indices are supported.
+func (b *PatchBuilder) Apply(document []byte) ([]byte, error) {
+	if len(b.operations) == 0 {
+		return document, nil
+	}
+	data, err := b.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	patch, err := jsonpatch.DecodePatch(data)
+	if err != nil {
+		return nil, err
+	}
+	options := &jsonpatch.ApplyOptions{SupportNegativeIndices: true, AllowMissingPathOnRemove: true, EnsurePathExistsOnAdd: true}
+	return patch.ApplyWithOptions(document, options)
+}
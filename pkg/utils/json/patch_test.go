@@ -208,3 +208,28 @@ func TestUnmarshalPatchOperation(t *testing.T) {
 		})
 	}
 }
+
+func TestJoinPointer(t *testing.T) {
+	assert.Equal(t, "", JoinPointer())
+	assert.Equal(t, "/spec/containers/0/image", JoinPointer("spec", "containers", "0", "image"))
+	assert.Equal(t, "/metadata/annotations/kyverno.io~1verify-images", JoinPointer("metadata", "annotations", "kyverno.io/verify-images"))
+	assert.Equal(t, "/a~0b/c~01", JoinPointer("a~b", "c~1"))
+}
+
+func TestPatchBuilder(t *testing.T) {
+	builder := NewPatchBuilder().
+		Replace("registry.io/team~a/nginx:1.25@sha256:abc", "spec", "images", "web/frontend").
+		Add(map[string]string{"kyverno.io/verify-images": `{"nginx":true}`}, "metadata", "annotations").
+		Remove("spec", "missing")
+	assert.Equal(t, 3, builder.Len())
+	patch, err := builder.Bytes()
+	assert.NoError(t, err)
+	assert.Contains(t, string(patch), `"path":"/spec/images/web~1frontend"`)
+	patched, err := builder.Apply([]byte(`{"spec":{"images":{"web/frontend":"nginx"}}}`))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"metadata":{"annotations":{"kyverno.io/verify-images":"{\"nginx\":true}"}},"spec":{"images":{"web/frontend":"registry.io/team~a/nginx:1.25@sha256:abc"}}}`, string(patched))
+	document := []byte(`{"a":1}`)
+	patched, err = NewPatchBuilder().Apply(document)
+	assert.NoError(t, err)
+	assert.Equal(t, document, patched)
+}
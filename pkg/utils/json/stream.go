@@ -0,0 +1,97 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+)
+
+// maxPooledBufferSize is the capacity above which buffers are not returned to the pool,
+// so that a single huge request does not pin its memory for the lifetime of the process.
+const maxPooledBufferSize = 4 << 20
+
+var bufferPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+// GetBuffer returns an empty buffer from the pool, it must be given back with PutBuffer
+// once its bytes are not referenced anymore.
+func GetBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+// PutBuffer gives a buffer back to the pool.
+func PutBuffer(buffer *bytes.Buffer) {
+	if buffer == nil || buffer.Cap() > maxPooledBufferSize {
+		return
+	}
+	buffer.Reset()
+	bufferPool.Put(buffer)
+}
+
+// PatchStream writes JSON patch operations to a pooled buffer as they are produced,
+// instead of collecting them in intermediate slices joined once all of them are known.
+type PatchStream struct {
+	buffer *bytes.Buffer
+	count  int
+}
+
+func NewPatchStream() *PatchStream {
+	return &PatchStream{}
+}
+
+// WriteOperation marshals and writes a patch operation.
+func (s *PatchStream) WriteOperation(operation json.Marshaler) error {
+	data, err := operation.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	s.write(data)
+	return nil
+}
+
+// WritePatch writes a serialized patch operation or patch (array of patch operations), empty patches are ignored.
+func (s *PatchStream) WritePatch(patch []byte) {
+	patch = bytes.TrimSpace(patch)
+	if len(patch) > 0 && patch[0] == '[' {
+		patch = bytes.TrimPrefix(patch, []byte("["))
+		patch = bytes.TrimSuffix(patch, []byte("]"))
+		patch = bytes.TrimSpace(patch)
+	}
+	s.write(patch)
+}
+
+func (s *PatchStream) write(operation []byte) {
+	if len(operation) == 0 {
+		return
+	}
+	if s.buffer == nil {
+		s.buffer = GetBuffer()
+		s.buffer.WriteByte('[')
+	} else {
+		s.buffer.WriteString(", ")
+	}
+	s.buffer.Write(operation)
+	s.count++
+}
+
+// Len returns the number of patches written to the stream.
+func (s *PatchStream) Len() int {
+	return s.count
+}
+
+// Close returns the JSON patch made of the written patches, or nil when nothing was written,
+// and gives the buffer back to the pool. The stream can be reused after it is closed.
+func (s *PatchStream) Close() []byte {
+	if s.buffer == nil {
+		return nil
+	}
+	s.buffer.WriteByte(']')
+	result := bytes.Clone(s.buffer.Bytes())
+	PutBuffer(s.buffer)
+	s.buffer = nil
+	s.count = 0
+	return result
+}
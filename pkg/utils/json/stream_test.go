@@ -0,0 +1,37 @@
+package json
+
+import (
+	"testing"
+
+	"gomodules.xyz/jsonpatch/v2"
+	"gotest.tools/assert"
+)
+
+func Test_PatchStream(t *testing.T) {
+	stream := NewPatchStream()
+	assert.Assert(t, stream.Close() == nil)
+
+	stream.WritePatch([]byte(""))
+	stream.WritePatch([]byte(" [ ] "))
+	assert.Equal(t, stream.Len(), 0)
+	assert.Assert(t, stream.Close() == nil)
+
+	stream.WritePatch([]byte(`[{"op":"remove","path":"/foo"}]`))
+	assert.NilError(t, stream.WriteOperation(&jsonpatch.JsonPatchOperation{Operation: "add", Path: "/bar", Value: "baz"}))
+	stream.WritePatch([]byte(`{"op":"replace","path":"/baz","value":null}`))
+	assert.Equal(t, stream.Len(), 3)
+	assert.Equal(t, string(stream.Close()), `[{"op":"remove","path":"/foo"}, {"op":"add","path":"/bar","value":"baz"}, {"op":"replace","path":"/baz","value":null}]`)
+
+	// the stream is reset when closed
+	assert.Equal(t, stream.Len(), 0)
+	stream.WritePatch([]byte(`{"op":"remove","path":"/foo"}`))
+	assert.Equal(t, string(stream.Close()), `[{"op":"remove","path":"/foo"}]`)
+}
+
+func Test_PutBuffer(t *testing.T) {
+	buffer := GetBuffer()
+	buffer.WriteString("data")
+	PutBuffer(buffer)
+	assert.Equal(t, GetBuffer().Len(), 0)
+	PutBuffer(nil)
+}
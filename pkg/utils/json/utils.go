@@ -1,9 +1,5 @@
 package json
 
-import (
-	"strings"
-)
-
 // JoinPatches joins array of serialized JSON patches to the single JSONPatch array
 // It accepts patch operations and patches (arrays of patch operations) and returns
 // a single combined patch.
@@ -11,27 +7,9 @@ func JoinPatches(patches ...[]byte) []byte {
 	if len(patches) == 0 {
 		return nil
 	}
-
-	var patchOperations []string
+	stream := NewPatchStream()
 	for _, patch := range patches {
-		str := strings.TrimSpace(string(patch))
-		if len(str) == 0 {
-			continue
-		}
-
-		if strings.HasPrefix(str, "[") {
-			str = strings.TrimPrefix(str, "[")
-			str = strings.TrimSuffix(str, "]")
-			str = strings.TrimSpace(str)
-		}
-
-		patchOperations = append(patchOperations, str)
+		stream.WritePatch(patch)
 	}
-
-	if len(patchOperations) == 0 {
-		return nil
-	}
-
-	result := "[" + strings.Join(patchOperations, ", ") + "]"
-	return []byte(result)
+	return stream.Close()
 }
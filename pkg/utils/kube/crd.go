@@ -20,7 +20,9 @@ func CRDsInstalled(apiserverClient apiserver.Interface) error {
 		"clustercleanuppolicies.kyverno.io",
 		"clusterpolicies.kyverno.io",
 		"clusterpolicyreports.wgpolicyk8s.io",
+		"exclusionpolicies.kyverno.io",
 		"policies.kyverno.io",
+		"policybindings.kyverno.io",
 		"policyexceptions.kyverno.io",
 		"policyreports.wgpolicyk8s.io",
 		"updaterequests.kyverno.io",
@@ -1,8 +1,11 @@
 package kube
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	corev1 "k8s.io/api/core/v1"
@@ -66,3 +69,58 @@ func RedactSecret(resource *unstructured.Unstructured) (unstructured.Unstructure
 	}
 	return *resource, nil
 }
+
+// minRedactedLength is the minimum length of the secret values redacted from messages,
+// shorter values would mask unrelated parts of the messages.
+const minRedactedLength = 4
+
+// IsSecret returns true if the object is a core Secret.
+func IsSecret(object map[string]interface{}) bool {
+	return object["apiVersion"] == "v1" && object["kind"] == "Secret"
+}
+
+// DecodeSecretData returns the base64 decoded values of the data of a Secret, values that can't be decoded are left out.
+// It returns nil if the object is not a Secret.
+func DecodeSecretData(object map[string]interface{}) map[string]interface{} {
+	if !IsSecret(object) {
+		return nil
+	}
+	decoded := map[string]interface{}{}
+	data, _ := object["data"].(map[string]interface{})
+	for key, value := range data {
+		if encoded, ok := value.(string); ok {
+			if value, err := base64.StdEncoding.DecodeString(encoded); err == nil {
+				decoded[key] = string(value)
+			}
+		}
+	}
+	return decoded
+}
+
+// RedactSecretData masks the values of the data of the Secrets in a message, both encoded and decoded.
+// Values are also masked in their JSON escaped form, values shorter than minRedactedLength are not masked.
+// Only the values found as is can be masked, messages must not reference the decoded data of the Secrets.
+func RedactSecretData(message string, objects ...map[string]interface{}) string {
+	var values []string
+	for _, object := range objects {
+		for key, decoded := range DecodeSecretData(object) {
+			for _, value := range []string{decoded.(string), object["data"].(map[string]interface{})[key].(string)} {
+				if len(value) < minRedactedLength {
+					continue
+				}
+				values = append(values, value)
+				if escaped, err := json.Marshal(value); err == nil {
+					if escaped := string(escaped[1 : len(escaped)-1]); escaped != value {
+						values = append(values, escaped)
+					}
+				}
+			}
+		}
+	}
+	// longer values first, a value can contain another one
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	for _, value := range values {
+		message = strings.ReplaceAll(message, value, "**REDACTED**")
+	}
+	return message
+}
@@ -0,0 +1,52 @@
+package match
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kyverno/kyverno/ext/wildcard"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// CheckFieldSelector checks the fields of a resource against a field selector,
+// values support wildcards and missing fields match the empty value
+func CheckFieldSelector(expected string, resource map[string]interface{}) (bool, error) {
+	selector, err := fields.ParseSelector(expected)
+	if err != nil {
+		return false, err
+	}
+	for _, requirement := range selector.Requirements() {
+		actual := fieldValue(resource, requirement.Field)
+		matched := wildcard.Match(requirement.Value, actual)
+		switch requirement.Operator {
+		case selection.Equals, selection.DoubleEquals:
+			if !matched {
+				return false, nil
+			}
+		case selection.NotEquals:
+			if matched {
+				return false, nil
+			}
+		default:
+			return false, fmt.Errorf("unsupported operator %s in field selector", requirement.Operator)
+		}
+	}
+	return true, nil
+}
+
+func fieldValue(resource map[string]interface{}, path string) string {
+	value, found, err := unstructured.NestedFieldNoCopy(resource, strings.Split(path, ".")...)
+	if err != nil || !found || value == nil {
+		return ""
+	}
+	switch typed := value.(type) {
+	case string:
+		return typed
+	case bool, int64, float64:
+		return fmt.Sprint(typed)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,70 @@
+package match
+
+import "testing"
+
+func TestCheckFieldSelector(t *testing.T) {
+	resource := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"nodeName":    "node-1",
+			"hostNetwork": true,
+			"replicas":    int64(3),
+		},
+		"status": map[string]interface{}{
+			"phase": "Running",
+		},
+	}
+	tests := []struct {
+		name     string
+		selector string
+		want     bool
+		wantErr  bool
+	}{{
+		name:     "equals",
+		selector: "spec.nodeName=node-1",
+		want:     true,
+	}, {
+		name:     "double equals",
+		selector: "spec.nodeName==node-2",
+		want:     false,
+	}, {
+		name:     "not equals",
+		selector: "status.phase!=Running",
+		want:     false,
+	}, {
+		name:     "wildcard",
+		selector: "spec.nodeName=node-*,status.phase=Run?ing",
+		want:     true,
+	}, {
+		name:     "all requirements",
+		selector: "spec.nodeName=node-1,status.phase=Pending",
+		want:     false,
+	}, {
+		name:     "scalars",
+		selector: "spec.hostNetwork=true,spec.replicas=3",
+		want:     true,
+	}, {
+		name:     "missing field",
+		selector: "spec.priorityClassName=",
+		want:     true,
+	}, {
+		name:     "missing field not equals",
+		selector: "spec.priorityClassName!=",
+		want:     false,
+	}, {
+		name:     "invalid",
+		selector: "spec.nodeName",
+		wantErr:  true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckFieldSelector(tt.selector, resource)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckFieldSelector() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("CheckFieldSelector() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
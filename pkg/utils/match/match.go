@@ -1,7 +1,9 @@
 package match
 
 import (
+	"context"
 	"fmt"
+	"slices"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
@@ -24,12 +26,15 @@ func CheckNamespace(statement string, resource unstructured.Unstructured) error
 }
 
 func CheckMatchesResources(
+	ctx context.Context,
+	ownerResolver OwnerResolver,
 	resource unstructured.Unstructured,
 	statement kyvernov2beta1.MatchResources,
 	namespaceLabels map[string]string,
 	admissionInfo kyvernov1beta1.RequestInfo,
 	gvk schema.GroupVersionKind,
 	subresource string,
+	operation kyvernov1.AdmissionOperation,
 ) error {
 	var errs []error
 	if len(statement.Any) > 0 {
@@ -39,12 +44,15 @@ func CheckMatchesResources(
 		for _, rmr := range statement.Any {
 			// if there are no errors it means it was a match
 			if len(checkResourceFilter(
+				ctx,
+				ownerResolver,
 				rmr,
 				resource,
 				namespaceLabels,
 				admissionInfo,
 				gvk,
 				subresource,
+				operation,
 			)) == 0 {
 				oneMatched = true
 				break
@@ -59,12 +67,15 @@ func CheckMatchesResources(
 			errs = append(
 				errs,
 				checkResourceFilter(
+					ctx,
+					ownerResolver,
 					rmr,
 					resource,
 					namespaceLabels,
 					admissionInfo,
 					gvk,
 					subresource,
+					operation,
 				)...,
 			)
 		}
@@ -73,12 +84,15 @@ func CheckMatchesResources(
 }
 
 func checkResourceFilter(
+	ctx context.Context,
+	ownerResolver OwnerResolver,
 	statement kyvernov1.ResourceFilter,
 	resource unstructured.Unstructured,
 	namespaceLabels map[string]string,
 	admissionInfo kyvernov1beta1.RequestInfo,
 	gvk schema.GroupVersionKind,
 	subresource string,
+	operation kyvernov1.AdmissionOperation,
 ) []error {
 	var errs []error
 	// checking if the block is empty
@@ -87,11 +101,14 @@ func checkResourceFilter(
 		return errs
 	}
 	matchErrs := checkResourceDescription(
+		ctx,
+		ownerResolver,
 		statement.ResourceDescription,
 		resource,
 		namespaceLabels,
 		gvk,
 		subresource,
+		operation,
 	)
 	userErrs := checkUserInfo(
 		statement.UserInfo,
@@ -126,13 +143,22 @@ func checkUserInfo(
 }
 
 func checkResourceDescription(
+	ctx context.Context,
+	ownerResolver OwnerResolver,
 	conditionBlock kyvernov1.ResourceDescription,
 	resource unstructured.Unstructured,
 	namespaceLabels map[string]string,
 	gvk schema.GroupVersionKind,
 	subresource string,
+	operation kyvernov1.AdmissionOperation,
 ) []error {
 	var errs []error
+	// operations are not checked when the caller has no operation, like policy exceptions in background scans
+	if operation != "" && len(conditionBlock.Operations) > 0 {
+		if !slices.Contains(conditionBlock.Operations, operation) {
+			errs = append(errs, fmt.Errorf("operation does not match"))
+		}
+	}
 	if len(conditionBlock.Kinds) > 0 {
 		// Matching on ephemeralcontainers even when they are not explicitly specified is only applicable to policies.
 		if !CheckKind(conditionBlock.Kinds, gvk, subresource, false) {
@@ -180,6 +206,28 @@ func checkResourceDescription(
 			}
 		}
 	}
+	if conditionBlock.FieldSelector != "" {
+		hasPassed, err := CheckFieldSelector(conditionBlock.FieldSelector, resource.Object)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse field selector: %v", err))
+		} else {
+			if !hasPassed {
+				errs = append(errs, fmt.Errorf("field selector does not match"))
+			}
+		}
+	}
+
+	if conditionBlock.Owner != nil {
+		hasPassed, err := CheckOwner(ctx, ownerResolver, *conditionBlock.Owner, resource)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to check owner: %v", err))
+		} else {
+			if !hasPassed {
+				errs = append(errs, fmt.Errorf("owner does not match"))
+			}
+		}
+	}
+
 	if conditionBlock.NamespaceSelector != nil && resource.GetKind() != "Namespace" && resource.GetKind() != "" {
 		hasPassed, err := CheckSelector(conditionBlock.NamespaceSelector, namespaceLabels)
 		if err != nil {
@@ -0,0 +1,98 @@
+package match
+
+import (
+	"context"
+	"errors"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// maxOwnerDepth bounds the number of owners walked up the owner references chain
+const maxOwnerDepth = 5
+
+// OwnerResolver gets the owners of resources
+type OwnerResolver interface {
+	// Get returns the owner with the given api version, kind and name, namespace is the namespace of the owned resource.
+	// The lookup is bound by the context of the request matching the owned resource.
+	Get(ctx context.Context, apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error)
+}
+
+// CheckOwner checks if an owner in the owner references chain of the resource matches the owner description.
+// The chain follows the controller owner reference, or the first owner reference when none is the controller.
+// Without resolver, only the direct owner reference is checked.
+func CheckOwner(ctx context.Context, resolver OwnerResolver, expected kyvernov1.OwnerDescription, resource unstructured.Unstructured) (bool, error) {
+	namespace := resource.GetNamespace()
+	references := resource.GetOwnerReferences()
+	for depth := 0; depth < maxOwnerDepth; depth++ {
+		reference := ownerOf(references)
+		if reference == nil {
+			return false, nil
+		}
+		referenceMatches := checkOwnerReference(expected, *reference)
+		if referenceMatches && expected.Selector == nil {
+			return true, nil
+		}
+		if resolver == nil {
+			if referenceMatches {
+				return false, errors.New("owner labels can't be checked, no owner resolver is configured")
+			}
+			return false, errors.New("owner references chain can't be walked, no owner resolver is configured")
+		}
+		owner, err := resolver.Get(ctx, reference.APIVersion, reference.Kind, namespace, reference.Name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		if referenceMatches {
+			matches, err := CheckSelector(expected.Selector, owner.GetLabels())
+			if err != nil {
+				return false, err
+			}
+			if matches {
+				return true, nil
+			}
+		}
+		references = owner.GetOwnerReferences()
+	}
+	return false, nil
+}
+
+func ownerOf(references []metav1.OwnerReference) *metav1.OwnerReference {
+	for i := range references {
+		if references[i].Controller != nil && *references[i].Controller {
+			return &references[i]
+		}
+	}
+	if len(references) != 0 {
+		return &references[0]
+	}
+	return nil
+}
+
+func checkOwnerReference(expected kyvernov1.OwnerDescription, reference metav1.OwnerReference) bool {
+	if len(expected.Kinds) != 0 {
+		gv, err := schema.ParseGroupVersion(reference.APIVersion)
+		if err != nil || !CheckKind(expected.Kinds, gv.WithKind(reference.Kind), "", false) {
+			return false
+		}
+	}
+	if len(expected.Names) != 0 {
+		matches := false
+		for _, name := range expected.Names {
+			if CheckName(name, reference.Name) {
+				matches = true
+				break
+			}
+		}
+		if !matches {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+package match
+
+import (
+	"context"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type fakeOwnerResolver map[string]*unstructured.Unstructured
+
+func (r fakeOwnerResolver) Get(_ context.Context, apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	if owner, ok := r[kind+"/"+name]; ok {
+		return owner, nil
+	}
+	return nil, apierrors.NewNotFound(schema.GroupResource{Resource: kind}, name)
+}
+
+func newOwned(apiVersion, kind, name string, labels map[string]string, owner *metav1.OwnerReference) *unstructured.Unstructured {
+	resource := &unstructured.Unstructured{}
+	resource.SetAPIVersion(apiVersion)
+	resource.SetKind(kind)
+	resource.SetNamespace("default")
+	resource.SetName(name)
+	resource.SetLabels(labels)
+	if owner != nil {
+		resource.SetOwnerReferences([]metav1.OwnerReference{*owner})
+	}
+	return resource
+}
+
+func controllerRef(apiVersion, kind, name string) *metav1.OwnerReference {
+	controller := true
+	return &metav1.OwnerReference{APIVersion: apiVersion, Kind: kind, Name: name, Controller: &controller}
+}
+
+func TestCheckOwner(t *testing.T) {
+	cronjob := newOwned("batch/v1", "CronJob", "backup", map[string]string{"team": "storage"}, nil)
+	job := newOwned("batch/v1", "Job", "backup-28000000", nil, controllerRef("batch/v1", "CronJob", "backup"))
+	pod := newOwned("v1", "Pod", "backup-28000000-abcde", nil, controllerRef("batch/v1", "Job", "backup-28000000"))
+	orphan := newOwned("v1", "Pod", "orphan", nil, nil)
+	resolver := fakeOwnerResolver{"CronJob/backup": cronjob, "Job/backup-28000000": job}
+	tests := []struct {
+		name     string
+		expected kyvernov1.OwnerDescription
+		resource *unstructured.Unstructured
+		want     bool
+		wantErr  bool
+	}{{
+		name:     "direct owner kind",
+		expected: kyvernov1.OwnerDescription{Kinds: []string{"Job"}},
+		resource: pod,
+		want:     true,
+	}, {
+		name:     "owner chain kind",
+		expected: kyvernov1.OwnerDescription{Kinds: []string{"batch/v1/CronJob"}},
+		resource: pod,
+		want:     true,
+	}, {
+		name:     "owner chain name",
+		expected: kyvernov1.OwnerDescription{Kinds: []string{"CronJob"}, Names: []string{"back*"}},
+		resource: pod,
+		want:     true,
+	}, {
+		name: "owner chain labels",
+		expected: kyvernov1.OwnerDescription{Kinds: []string{"CronJob"}, Selector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"team": "storage"},
+		}},
+		resource: pod,
+		want:     true,
+	}, {
+		name: "owner chain labels not matching",
+		expected: kyvernov1.OwnerDescription{Kinds: []string{"CronJob"}, Selector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"team": "web"},
+		}},
+		resource: pod,
+		want:     false,
+	}, {
+		name:     "no owner in chain",
+		expected: kyvernov1.OwnerDescription{Kinds: []string{"Deployment"}},
+		resource: pod,
+		want:     false,
+	}, {
+		name:     "no owner",
+		expected: kyvernov1.OwnerDescription{Kinds: []string{"Job"}},
+		resource: orphan,
+		want:     false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckOwner(context.TODO(), resolver, tt.expected, *tt.resource)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckOwner() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("CheckOwner() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckOwnerWithoutResolver(t *testing.T) {
+	pod := newOwned("v1", "Pod", "backup-28000000-abcde", nil, controllerRef("batch/v1", "Job", "backup-28000000"))
+	matches, err := CheckOwner(context.TODO(), nil, kyvernov1.OwnerDescription{Kinds: []string{"Job"}}, *pod)
+	if err != nil || !matches {
+		t.Errorf("CheckOwner() = %v, %v, the direct owner must match without resolver", matches, err)
+	}
+	if _, err := CheckOwner(context.TODO(), nil, kyvernov1.OwnerDescription{Kinds: []string{"CronJob"}}, *pod); err == nil {
+		t.Errorf("CheckOwner() must fail when the chain can't be walked")
+	}
+}
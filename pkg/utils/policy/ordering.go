@@ -0,0 +1,128 @@
+package policy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Key returns the key a policy is referenced by in ordering declarations,
+// the name for cluster policies and namespace/name for policies.
+func Key(policy kyvernov1.PolicyInterface) string {
+	if policy.IsNamespaced() {
+		return policy.GetNamespace() + "/" + policy.GetName()
+	}
+	return policy.GetName()
+}
+
+// Sort returns the policies in application order, policies are ordered by key unless the before and after
+// declarations of their ordering require otherwise. Declarations referencing policies that are not in the list
+// are ignored. When declarations contain a cycle, an error is returned and the policies that can't be ordered
+// are appended in key order.
+func Sort(policies ...kyvernov1.PolicyInterface) ([]kyvernov1.PolicyInterface, error) {
+	byKey := make(map[string]kyvernov1.PolicyInterface, len(policies))
+	var keys []string
+	for _, policy := range policies {
+		key := Key(policy)
+		if _, ok := byKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		byKey[key] = policy
+	}
+	slices.Sort(keys)
+	// successors[a] contains b when a is applied before b
+	successors := map[string]sets.Set[string]{}
+	predecessors := map[string]int{}
+	addEdge := func(from, to string) {
+		if _, ok := byKey[from]; !ok {
+			return
+		}
+		if _, ok := byKey[to]; !ok {
+			return
+		}
+		if successors[from] == nil {
+			successors[from] = sets.New[string]()
+		}
+		if !successors[from].Has(to) {
+			successors[from].Insert(to)
+			predecessors[to]++
+		}
+	}
+	for _, key := range keys {
+		ordering := byKey[key].GetSpec().Ordering
+		if ordering == nil {
+			continue
+		}
+		for _, other := range ordering.Before {
+			addEdge(key, other)
+		}
+		for _, other := range ordering.After {
+			addEdge(other, key)
+		}
+	}
+	sorted := make([]kyvernov1.PolicyInterface, 0, len(keys))
+	done := sets.New[string]()
+	for len(done) < len(keys) {
+		next := ""
+		for _, key := range keys {
+			if !done.Has(key) && predecessors[key] == 0 {
+				next = key
+				break
+			}
+		}
+		if next == "" {
+			var unordered []string
+			for _, key := range keys {
+				if !done.Has(key) {
+					unordered = append(unordered, key)
+					sorted = append(sorted, byKey[key])
+				}
+			}
+			return sorted, fmt.Errorf("policy ordering contains a cycle, policies %s can't be ordered", strings.Join(unordered, ", "))
+		}
+		done.Insert(next)
+		sorted = append(sorted, byKey[next])
+		for successor := range successors[next] {
+			predecessors[successor]--
+		}
+	}
+	return sorted, nil
+}
+
+// Exclusions tracks the mutual exclusion groups of the policies applied to a resource
+type Exclusions struct {
+	applied map[string]string
+}
+
+// NewExclusions returns the exclusions of a resource no policy was applied to yet
+func NewExclusions() *Exclusions {
+	return &Exclusions{
+		applied: map[string]string{},
+	}
+}
+
+// Excluded returns the key of the applied policy sharing an exclusion group with the policy, empty when the policy can be applied
+func (e *Exclusions) Excluded(policy kyvernov1.PolicyInterface) string {
+	if ordering := policy.GetSpec().Ordering; ordering != nil {
+		for _, group := range ordering.ExclusionGroups {
+			if other, ok := e.applied[group]; ok {
+				return other
+			}
+		}
+	}
+	return ""
+}
+
+// Applied records the policy was applied to the resource
+func (e *Exclusions) Applied(policy kyvernov1.PolicyInterface) {
+	if ordering := policy.GetSpec().Ordering; ordering != nil {
+		for _, group := range ordering.ExclusionGroups {
+			if _, ok := e.applied[group]; !ok {
+				e.applied[group] = Key(policy)
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package policy
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func clusterPolicy(name string, ordering *kyvernov1.PolicyOrdering) kyvernov1.PolicyInterface {
+	return &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       kyvernov1.Spec{Ordering: ordering},
+	}
+}
+
+func keys(policies []kyvernov1.PolicyInterface) []string {
+	var keys []string
+	for _, policy := range policies {
+		keys = append(keys, Key(policy))
+	}
+	return keys
+}
+
+func TestSort(t *testing.T) {
+	namespaced := &kyvernov1.Policy{
+		ObjectMeta: metav1.ObjectMeta{Name: "add-labels", Namespace: "team-a"},
+		Spec: kyvernov1.Spec{Ordering: &kyvernov1.PolicyOrdering{
+			Before: []string{"add-defaults"},
+		}},
+	}
+	tests := []struct {
+		name     string
+		policies []kyvernov1.PolicyInterface
+		want     []string
+		wantErr  bool
+	}{{
+		name: "name order",
+		policies: []kyvernov1.PolicyInterface{
+			clusterPolicy("c", nil),
+			clusterPolicy("a", nil),
+			clusterPolicy("b", nil),
+		},
+		want: []string{"a", "b", "c"},
+	}, {
+		name: "before and after",
+		policies: []kyvernov1.PolicyInterface{
+			clusterPolicy("a", &kyvernov1.PolicyOrdering{After: []string{"c"}}),
+			clusterPolicy("b", nil),
+			clusterPolicy("c", &kyvernov1.PolicyOrdering{After: []string{"d"}}),
+			clusterPolicy("d", &kyvernov1.PolicyOrdering{Before: []string{"b"}}),
+		},
+		want: []string{"d", "b", "c", "a"},
+	}, {
+		name: "unknown policies",
+		policies: []kyvernov1.PolicyInterface{
+			clusterPolicy("b", &kyvernov1.PolicyOrdering{Before: []string{"a"}, After: []string{"missing"}}),
+			clusterPolicy("a", nil),
+		},
+		want: []string{"b", "a"},
+	}, {
+		name: "namespaced policies",
+		policies: []kyvernov1.PolicyInterface{
+			clusterPolicy("add-defaults", nil),
+			namespaced,
+		},
+		want: []string{"team-a/add-labels", "add-defaults"},
+	}, {
+		name: "cycle",
+		policies: []kyvernov1.PolicyInterface{
+			clusterPolicy("a", nil),
+			clusterPolicy("b", &kyvernov1.PolicyOrdering{Before: []string{"c"}}),
+			clusterPolicy("c", &kyvernov1.PolicyOrdering{Before: []string{"b"}}),
+		},
+		want:    []string{"a", "b", "c"},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted, err := Sort(tt.policies...)
+			assert.Equal(t, err != nil, tt.wantErr)
+			assert.DeepEqual(t, keys(sorted), tt.want)
+		})
+	}
+}
+
+func TestExclusions(t *testing.T) {
+	first := clusterPolicy("first", &kyvernov1.PolicyOrdering{ExclusionGroups: []string{"sidecars"}})
+	second := clusterPolicy("second", &kyvernov1.PolicyOrdering{ExclusionGroups: []string{"registries", "sidecars"}})
+	other := clusterPolicy("other", &kyvernov1.PolicyOrdering{ExclusionGroups: []string{"registries"}})
+	exclusions := NewExclusions()
+	assert.Equal(t, exclusions.Excluded(first), "")
+	assert.Equal(t, exclusions.Excluded(second), "")
+	exclusions.Applied(first)
+	assert.Equal(t, exclusions.Excluded(second), "first")
+	assert.Equal(t, exclusions.Excluded(other), "")
+	assert.Equal(t, exclusions.Excluded(clusterPolicy("none", nil)), "")
+}
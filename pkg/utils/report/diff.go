@@ -0,0 +1,169 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// maxDiffEntries is the max number of changed fields recorded in a result
+	maxDiffEntries = 10
+	// maxDiffValueLength is the max length of the values recorded in a diff entry
+	maxDiffValueLength = 64
+)
+
+// ignoredDiffPaths are changed on every update and don't help understanding a violation
+var ignoredDiffPaths = []string{
+	"/metadata/managedFields",
+	"/metadata/resourceVersion",
+	"/metadata/generation",
+	"/metadata/uid",
+	"/metadata/creationTimestamp",
+	"/status",
+}
+
+var failedPathRegex = regexp.MustCompile(`failed at path (\S+)`)
+
+// DiffEntry describes a field changed by an update.
+type DiffEntry struct {
+	Path string
+	Old  interface{}
+	New  interface{}
+}
+
+func (e DiffEntry) String() string {
+	return fmt.Sprintf("%s: %s -> %s", e.Path, formatDiffValue(e.Old), formatDiffValue(e.New))
+}
+
+func formatDiffValue(value interface{}) string {
+	if value == nil {
+		return "<none>"
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "<invalid>"
+	}
+	if len(data) > maxDiffValueLength {
+		return string(data[:maxDiffValueLength]) + "..."
+	}
+	return string(data)
+}
+
+// ComputeDiff returns the leaf fields that differ between the old and new objects, sorted by path.
+func ComputeDiff(oldObj, newObj map[string]interface{}) []DiffEntry {
+	var entries []DiffEntry
+	computeDiff("", oldObj, newObj, &entries)
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	return entries
+}
+
+func computeDiff(path string, oldValue, newValue interface{}, entries *[]DiffEntry) {
+	for _, ignored := range ignoredDiffPaths {
+		if path == ignored {
+			return
+		}
+	}
+	if reflect.DeepEqual(oldValue, newValue) {
+		return
+	}
+	switch typedOld := oldValue.(type) {
+	case map[string]interface{}:
+		if typedNew, ok := newValue.(map[string]interface{}); ok {
+			keys := map[string]struct{}{}
+			for key := range typedOld {
+				keys[key] = struct{}{}
+			}
+			for key := range typedNew {
+				keys[key] = struct{}{}
+			}
+			for key := range keys {
+				computeDiff(path+"/"+escapePointer(key), typedOld[key], typedNew[key], entries)
+			}
+			return
+		}
+	case []interface{}:
+		if typedNew, ok := newValue.([]interface{}); ok {
+			for i := 0; i < len(typedOld) || i < len(typedNew); i++ {
+				var o, n interface{}
+				if i < len(typedOld) {
+					o = typedOld[i]
+				}
+				if i < len(typedNew) {
+					n = typedNew[i]
+				}
+				computeDiff(fmt.Sprintf("%s/%d", path, i), o, n, entries)
+			}
+			return
+		}
+	}
+	*entries = append(*entries, DiffEntry{Path: path, Old: oldValue, New: newValue})
+}
+
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// FormatDiff renders diff entries in a compact form, entries under the failed path reported in
+// the message are preferred when some exist.
+func FormatDiff(entries []DiffEntry, message string) string {
+	if match := failedPathRegex.FindStringSubmatch(message); match != nil {
+		prefix := strings.TrimSuffix(match[1], "/")
+		var focused []DiffEntry
+		for _, entry := range entries {
+			if entry.Path == prefix || strings.HasPrefix(entry.Path, prefix+"/") {
+				focused = append(focused, entry)
+			}
+		}
+		if len(focused) != 0 {
+			entries = focused
+		}
+	}
+	var parts []string
+	for i, entry := range entries {
+		if i == maxDiffEntries {
+			parts = append(parts, fmt.Sprintf("(%d more)", len(entries)-maxDiffEntries))
+			break
+		}
+		parts = append(parts, entry.String())
+	}
+	return strings.Join(parts, "; ")
+}
+
+// SetUpdateDiff records the fields changed by an update request in the failed and warned results of a report.
+func SetUpdateDiff(report kyvernov1alpha2.ReportInterface, request admissionv1.AdmissionRequest) error {
+	if request.Operation != admissionv1.Update || len(request.OldObject.Raw) == 0 || len(request.Object.Raw) == 0 {
+		return nil
+	}
+	var oldObj, newObj unstructured.Unstructured
+	if err := oldObj.UnmarshalJSON(request.OldObject.Raw); err != nil {
+		return err
+	}
+	if err := newObj.UnmarshalJSON(request.Object.Raw); err != nil {
+		return err
+	}
+	entries := ComputeDiff(oldObj.Object, newObj.Object)
+	if len(entries) == 0 {
+		return nil
+	}
+	results := report.GetResults()
+	for i := range results {
+		if results[i].Result != policyreportv1alpha2.StatusFail && results[i].Result != policyreportv1alpha2.StatusWarn {
+			continue
+		}
+		if results[i].Properties == nil {
+			results[i].Properties = map[string]string{}
+		}
+		results[i].Properties["diff"] = FormatDiff(entries, results[i].Message)
+	}
+	report.SetResults(results)
+	return nil
+}
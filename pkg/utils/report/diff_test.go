@@ -0,0 +1,60 @@
+package report
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestComputeDiff(t *testing.T) {
+	oldObj := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":            "web",
+			"resourceVersion": "1",
+			"labels":          map[string]interface{}{"app": "web", "team/name": "infra"},
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "nginx", "image": "nginx:1.25"},
+			},
+		},
+	}
+	newObj := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":            "web",
+			"resourceVersion": "2",
+			"labels":          map[string]interface{}{"app": "web"},
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "nginx", "image": "nginx:latest"},
+				map[string]interface{}{"name": "sidecar", "image": "busybox"},
+			},
+		},
+	}
+	entries := ComputeDiff(oldObj, newObj)
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.String())
+	}
+	assert.DeepEqual(t, got, []string{
+		`/metadata/labels/team~1name: "infra" -> <none>`,
+		`/spec/containers/0/image: "nginx:1.25" -> "nginx:latest"`,
+		`/spec/containers/1: <none> -> {"image":"busybox","name":"sidecar"}`,
+	})
+}
+
+func TestFormatDiff(t *testing.T) {
+	entries := []DiffEntry{
+		{Path: "/metadata/labels/team", Old: "infra"},
+		{Path: "/spec/containers/0/image", Old: "nginx:1.25", New: "nginx:latest"},
+	}
+	assert.Equal(t,
+		FormatDiff(entries, "validation error: rule check-image failed"),
+		`/metadata/labels/team: "infra" -> <none>; /spec/containers/0/image: "nginx:1.25" -> "nginx:latest"`,
+	)
+	assert.Equal(t,
+		FormatDiff(entries, "validation error: latest tag is not allowed. rule check-image failed at path /spec/containers/0/image/"),
+		`/spec/containers/0/image: "nginx:1.25" -> "nginx:latest"`,
+	)
+}
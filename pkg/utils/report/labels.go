@@ -34,6 +34,8 @@ const (
 	LabelPrefixValidatingAdmissionPolicy = "validatingadmissionpolicy.apiserver.io/"
 	//	aggregated admission report label
 	LabelAggregatedReport = "audit.kyverno.io/report.aggregate"
+	//	canary policy report label
+	LabelCanaryReport = "audit.kyverno.io/report.canary"
 )
 
 func IsPolicyLabel(label string) bool {
@@ -2,6 +2,7 @@ package report
 
 import (
 	"cmp"
+	"encoding/json"
 	"slices"
 	"sort"
 	"strings"
@@ -87,13 +88,23 @@ func SeverityFromString(severity string) policyreportv1alpha2.PolicySeverity {
 	return ""
 }
 
-func EngineResponseToReportResults(response engineapi.EngineResponse) []policyreportv1alpha2.PolicyReportResult {
+// Taxonomy maps the severities and categories declared by policies to the ones stored in reports.
+type Taxonomy interface {
+	GetSeverity(severity string) string
+	GetCategory(category string) string
+}
+
+func EngineResponseToReportResults(response engineapi.EngineResponse, taxonomy Taxonomy) []policyreportv1alpha2.PolicyReportResult {
 	pol := response.Policy()
 	var results []policyreportv1alpha2.PolicyReportResult
 	if pol.GetType() == engineapi.KyvernoPolicyType {
 		key, _ := cache.MetaNamespaceKeyFunc(pol.GetPolicy().(kyvernov1.PolicyInterface))
+		annotations := pol.GetAnnotations()
+		category, severity := annotations[kyverno.AnnotationPolicyCategory], annotations[kyverno.AnnotationPolicySeverity]
+		if taxonomy != nil {
+			category, severity = taxonomy.GetCategory(category), taxonomy.GetSeverity(severity)
+		}
 		for _, ruleResult := range response.PolicyResponse.Rules {
-			annotations := pol.GetAnnotations()
 			result := policyreportv1alpha2.PolicyReportResult{
 				Source:  kyverno.ValueKyvernoApp,
 				Policy:  key,
@@ -104,8 +115,8 @@ func EngineResponseToReportResults(response engineapi.EngineResponse) []policyre
 				Timestamp: metav1.Timestamp{
 					Seconds: time.Now().Unix(),
 				},
-				Category: annotations[kyverno.AnnotationPolicyCategory],
-				Severity: SeverityFromString(annotations[kyverno.AnnotationPolicySeverity]),
+				Category: category,
+				Severity: SeverityFromString(severity),
 			}
 			pss := ruleResult.PodSecurityChecks()
 			if pss != nil {
@@ -124,6 +135,20 @@ func EngineResponseToReportResults(response engineapi.EngineResponse) []policyre
 					}
 				}
 			}
+			if remediation := ruleResult.Remediation(); remediation != "" {
+				if result.Properties == nil {
+					result.Properties = map[string]string{}
+				}
+				result.Properties["remediation"] = remediation
+			}
+			if violations := ruleResult.Violations(); len(violations) != 0 {
+				if data, err := json.Marshal(violations); err == nil {
+					if result.Properties == nil {
+						result.Properties = map[string]string{}
+					}
+					result.Properties["violations"] = string(data)
+				}
+			}
 			if result.Result == "fail" && !result.Scored {
 				result.Result = "warn"
 			}
@@ -176,12 +201,12 @@ func SetResults(report kyvernov1alpha2.ReportInterface, results ...policyreportv
 	report.SetSummary(CalculateSummary(results))
 }
 
-func SetResponses(report kyvernov1alpha2.ReportInterface, engineResponses ...engineapi.EngineResponse) {
+func SetResponses(report kyvernov1alpha2.ReportInterface, taxonomy Taxonomy, engineResponses ...engineapi.EngineResponse) {
 	var ruleResults []policyreportv1alpha2.PolicyReportResult
 	for _, result := range engineResponses {
 		pol := result.Policy()
 		SetPolicyLabel(report, pol)
-		ruleResults = append(ruleResults, EngineResponseToReportResults(result)...)
+		ruleResults = append(ruleResults, FilterResults(result, EngineResponseToReportResults(result, taxonomy))...)
 	}
 	SetResults(report, ruleResults...)
 }
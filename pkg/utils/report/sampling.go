@@ -0,0 +1,55 @@
+package report
+
+import (
+	"hash/fnv"
+	"strconv"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+)
+
+// IsReportExcluded returns true when the policy opted out of reports.
+func IsReportExcluded(annotations map[string]string) bool {
+	return annotations[kyverno.AnnotationPolicyReport] == "false"
+}
+
+// PassSamplingRate returns N when only one in N pass results of the policy should be recorded.
+func PassSamplingRate(annotations map[string]string) int {
+	rate, err := strconv.Atoi(annotations[kyverno.AnnotationPolicyReportSample])
+	if err != nil || rate < 1 {
+		return 1
+	}
+	return rate
+}
+
+// FilterResults drops the results of policies excluded from reports and samples pass results.
+// Sampling is deterministic for a given resource and rule so that reports don't churn between scans,
+// results other than pass are always kept.
+func FilterResults(response engineapi.EngineResponse, results []policyreportv1alpha2.PolicyReportResult) []policyreportv1alpha2.PolicyReportResult {
+	annotations := response.Policy().GetAnnotations()
+	if IsReportExcluded(annotations) {
+		return nil
+	}
+	rate := PassSamplingRate(annotations)
+	if rate == 1 {
+		return results
+	}
+	resource := string(response.Resource.GetUID())
+	if resource == "" {
+		resource = response.Resource.GetNamespace() + "/" + response.Resource.GetName()
+	}
+	var filtered []policyreportv1alpha2.PolicyReportResult
+	for _, result := range results {
+		if result.Result != policyreportv1alpha2.StatusPass || isSampled(resource, result.Policy, result.Rule, rate) {
+			filtered = append(filtered, result)
+		}
+	}
+	return filtered
+}
+
+func isSampled(resource, policy, rule string, rate int) bool {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(resource + "/" + policy + "/" + rule))
+	return h.Sum32()%uint32(rate) == 0
+}
@@ -0,0 +1,62 @@
+package report
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newSamplingResponse(annotations map[string]string, uid string) engineapi.EngineResponse {
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "noisy", Annotations: annotations}}
+	var resource unstructured.Unstructured
+	resource.SetUID(types.UID(uid))
+	return engineapi.NewEngineResponse(resource, engineapi.NewKyvernoPolicy(policy), nil)
+}
+
+func TestFilterResults(t *testing.T) {
+	results := []policyreportv1alpha2.PolicyReportResult{
+		{Policy: "noisy", Rule: "fails", Result: policyreportv1alpha2.StatusFail},
+		{Policy: "noisy", Rule: "passes", Result: policyreportv1alpha2.StatusPass},
+	}
+	t.Run("no annotations", func(t *testing.T) {
+		assert.Equal(t, len(FilterResults(newSamplingResponse(nil, "uid"), results)), 2)
+	})
+	t.Run("excluded", func(t *testing.T) {
+		response := newSamplingResponse(map[string]string{kyverno.AnnotationPolicyReport: "false"}, "uid")
+		assert.Equal(t, len(FilterResults(response, results)), 0)
+	})
+	t.Run("sampled", func(t *testing.T) {
+		annotations := map[string]string{kyverno.AnnotationPolicyReportSample: "4"}
+		var fails, passes int
+		for i := 0; i < 1000; i++ {
+			response := newSamplingResponse(annotations, fmt.Sprintf("uid-%d", i))
+			filtered := FilterResults(response, results)
+			// sampling is stable for a given resource
+			assert.DeepEqual(t, FilterResults(response, results), filtered)
+			for _, result := range filtered {
+				if result.Result == policyreportv1alpha2.StatusPass {
+					passes++
+				} else {
+					fails++
+				}
+			}
+		}
+		assert.Equal(t, fails, 1000)
+		assert.Assert(t, passes > 150 && passes < 350, passes)
+	})
+}
+
+func TestPassSamplingRate(t *testing.T) {
+	assert.Equal(t, PassSamplingRate(nil), 1)
+	assert.Equal(t, PassSamplingRate(map[string]string{kyverno.AnnotationPolicyReportSample: "10"}), 10)
+	assert.Equal(t, PassSamplingRate(map[string]string{kyverno.AnnotationPolicyReportSample: "0"}), 1)
+	assert.Equal(t, PassSamplingRate(map[string]string{kyverno.AnnotationPolicyReportSample: "ten"}), 1)
+}
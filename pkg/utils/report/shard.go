@@ -0,0 +1,62 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+)
+
+const (
+	// LabelReportShardOf is set on report shards, it contains the name of the summary report
+	LabelReportShardOf = "audit.kyverno.io/report.shard-of"
+	// AnnotationReportShards is set on summary reports, it contains the number of shards
+	AnnotationReportShards = "audit.kyverno.io/report.shards"
+)
+
+// ShardResults splits results in shards holding at most maxResults results and at most maxBytes bytes of serialized results.
+// Results are sorted first so that the same results always produce the same shards, a limit lower or equal to zero is ignored.
+func ShardResults(results []policyreportv1alpha2.PolicyReportResult, maxResults, maxBytes int) [][]policyreportv1alpha2.PolicyReportResult {
+	if len(results) == 0 {
+		return nil
+	}
+	SortReportResults(results)
+	var shards [][]policyreportv1alpha2.PolicyReportResult
+	start, size := 0, 0
+	for i, result := range results {
+		resultSize := 0
+		if maxBytes > 0 {
+			if data, err := json.Marshal(result); err == nil {
+				resultSize = len(data)
+			}
+		}
+		count := i - start
+		// a result bigger than the budget still gets a shard of its own
+		if count > 0 && ((maxResults > 0 && count >= maxResults) || (maxBytes > 0 && size+resultSize > maxBytes)) {
+			shards = append(shards, results[start:i])
+			start, size = i, 0
+		}
+		size += resultSize
+	}
+	return append(shards, results[start:])
+}
+
+// ShardName returns the name of the shard at the given index.
+func ShardName(name string, index int) string {
+	return fmt.Sprintf("%s-%d", name, index)
+}
+
+// SetShardOf marks a report as a shard of the given summary report.
+func SetShardOf(report kyvernov1alpha2.ReportInterface, name string) {
+	controllerutils.SetLabel(report, LabelReportShardOf, name)
+}
+
+// SetSummaryOf turns a report in the summary of its shards, the report holds no results.
+func SetSummaryOf(report kyvernov1alpha2.ReportInterface, shards int, results []policyreportv1alpha2.PolicyReportResult) {
+	controllerutils.SetAnnotation(report, AnnotationReportShards, strconv.Itoa(shards))
+	report.SetResults(nil)
+	report.SetSummary(CalculateSummary(results))
+}
@@ -0,0 +1,76 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"gotest.tools/assert"
+)
+
+func newResults(count int) []policyreportv1alpha2.PolicyReportResult {
+	var results []policyreportv1alpha2.PolicyReportResult
+	// results are created in reverse order, sharding must not depend on it
+	for i := count - 1; i >= 0; i-- {
+		results = append(results, policyreportv1alpha2.PolicyReportResult{
+			Policy: "policy",
+			Rule:   fmt.Sprintf("rule-%02d", i),
+			Result: policyreportv1alpha2.StatusPass,
+		})
+	}
+	return results
+}
+
+func TestShardResults(t *testing.T) {
+	data, err := json.Marshal(newResults(1)[0])
+	assert.NilError(t, err)
+	resultSize := len(data)
+	tests := []struct {
+		name       string
+		results    int
+		maxResults int
+		maxBytes   int
+		want       []int
+	}{{
+		name: "no results",
+	}, {
+		name:    "no limits",
+		results: 10,
+		want:    []int{10},
+	}, {
+		name:       "max results",
+		results:    10,
+		maxResults: 4,
+		want:       []int{4, 4, 2},
+	}, {
+		name:     "max bytes",
+		results:  10,
+		maxBytes: 3 * resultSize,
+		want:     []int{3, 3, 3, 1},
+	}, {
+		name:       "both limits",
+		results:    10,
+		maxResults: 2,
+		maxBytes:   3 * resultSize,
+		want:       []int{2, 2, 2, 2, 2},
+	}, {
+		name:     "budget smaller than a result",
+		results:  2,
+		maxBytes: 1,
+		want:     []int{1, 1},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shards := ShardResults(newResults(tt.results), tt.maxResults, tt.maxBytes)
+			var sizes []int
+			for _, shard := range shards {
+				sizes = append(sizes, len(shard))
+			}
+			assert.DeepEqual(t, tt.want, sizes)
+			if len(shards) != 0 {
+				assert.Equal(t, "rule-00", shards[0][0].Rule)
+			}
+		})
+	}
+}
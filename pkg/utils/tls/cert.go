@@ -26,6 +26,8 @@ func FetchCert(
 		return nil, fmt.Errorf("credentials: failed to append certificates")
 	}
 
-	transportCreds := credentials.NewClientTLSFromCert(cp, "")
+	config := ClientConfig()
+	config.RootCAs = cp
+	transportCreds := credentials.NewTLS(config)
 	return transportCreds, nil
 }
@@ -0,0 +1,171 @@
+package tls
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync/atomic"
+)
+
+// Profile is the cryptographic posture of the TLS servers and clients
+type Profile string
+
+const (
+	// ProfileDefault accepts TLS 1.2+ with the Go default cipher suites on clients
+	ProfileDefault Profile = "default"
+	// ProfileStrict accepts TLS 1.2 only with FIPS approved cipher suites and curves, on servers and clients.
+	// TLS 1.3 is not negotiated, its cipher suites can't be restricted and include CHACHA20_POLY1305.
+	ProfileStrict Profile = "strict"
+)
+
+var (
+	// profile is the current Profile, it's set once at startup but read concurrently by the servers and clients
+	profile atomic.Value
+	// serverCipherSuites are the cipher suites accepted by servers in the default profile
+	serverCipherSuites = []uint16{
+		// AEADs w/ ECDHE
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+		tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+	}
+	// strictCipherSuites are the cipher suites accepted by servers and clients in the strict profile
+	strictCipherSuites = []uint16{
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	}
+	strictCurves = []tls.CurveID{tls.CurveP256, tls.CurveP384}
+)
+
+// Posture describes the TLS settings enforced by the current profile
+type Posture struct {
+	Profile      Profile  `json:"profile"`
+	FIPSBuild    bool     `json:"fipsBuild"`
+	MinVersion   string   `json:"minVersion"`
+	MaxVersion   string   `json:"maxVersion,omitempty"`
+	CipherSuites []string `json:"cipherSuites"`
+}
+
+// SetProfile sets the profile of the TLS servers and clients created afterwards
+func SetProfile(p Profile) error {
+	switch p {
+	case ProfileDefault, ProfileStrict:
+		if fipsBuild && p != ProfileStrict {
+			return fmt.Errorf("tls profile %s is not allowed in a fips build", p)
+		}
+		profile.Store(p)
+		return nil
+	default:
+		return fmt.Errorf("unsupported tls profile %s", p)
+	}
+}
+
+// CurrentProfile returns the profile of the TLS servers and clients
+func CurrentProfile() Profile {
+	if p, ok := profile.Load().(Profile); ok {
+		return p
+	}
+	return defaultProfile
+}
+
+// CurrentPosture returns the TLS settings enforced by the current profile
+func CurrentPosture() Posture {
+	config := ServerConfig()
+	posture := Posture{
+		Profile:    CurrentProfile(),
+		FIPSBuild:  fipsBuild,
+		MinVersion: tls.VersionName(config.MinVersion),
+	}
+	if config.MaxVersion != 0 {
+		posture.MaxVersion = tls.VersionName(config.MaxVersion)
+	}
+	for _, suite := range config.CipherSuites {
+		posture.CipherSuites = append(posture.CipherSuites, tls.CipherSuiteName(suite))
+	}
+	return posture
+}
+
+// ServerConfig returns the TLS configuration of servers, the certificate must be set by the caller
+func ServerConfig() *tls.Config {
+	if CurrentProfile() == ProfileStrict {
+		return &tls.Config{
+			MinVersion:       tls.VersionTLS12,
+			MaxVersion:       tls.VersionTLS12,
+			CipherSuites:     slices.Clone(strictCipherSuites),
+			CurvePreferences: slices.Clone(strictCurves),
+		}
+	}
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		CipherSuites: slices.Clone(serverCipherSuites),
+	}
+}
+
+// ClientConfig returns the TLS configuration of clients, root CAs and client certificates must be set by the caller
+func ClientConfig() *tls.Config {
+	if CurrentProfile() == ProfileStrict {
+		return &tls.Config{
+			MinVersion:       tls.VersionTLS12,
+			MaxVersion:       tls.VersionTLS12,
+			CipherSuites:     slices.Clone(strictCipherSuites),
+			CurvePreferences: slices.Clone(strictCurves),
+		}
+	}
+	return &tls.Config{MinVersion: tls.VersionTLS12}
+}
+
+// ConfigureTransport returns a transport enforcing the strict profile, the transport is returned as is in the default profile.
+// Certificate verification can't be skipped in the strict profile, InsecureSkipVerify is not copied from the transport.
+func ConfigureTransport(transport *http.Transport) *http.Transport {
+	if CurrentProfile() != ProfileStrict || transport == nil {
+		return transport
+	}
+	config := ClientConfig()
+	if base := transport.TLSClientConfig; base != nil {
+		config.RootCAs = base.RootCAs
+		config.Certificates = base.Certificates
+		config.GetClientCertificate = base.GetClientCertificate
+		config.ServerName = base.ServerName
+	}
+	transport = transport.Clone()
+	transport.TLSClientConfig = config
+	return transport
+}
+
+// Validate checks a TLS configuration complies with the current profile
+func Validate(config *tls.Config) error {
+	strict := CurrentProfile() == ProfileStrict
+	if config == nil {
+		if strict {
+			return errors.New("tls configuration is required in the strict profile")
+		}
+		return nil
+	}
+	if config.MinVersion < tls.VersionTLS12 {
+		return fmt.Errorf("tls minimum version %s is lower than %s", tls.VersionName(config.MinVersion), tls.VersionName(tls.VersionTLS12))
+	}
+	if !strict {
+		return nil
+	}
+	if config.InsecureSkipVerify {
+		return errors.New("tls certificate verification can't be skipped in the strict profile")
+	}
+	if len(config.CipherSuites) == 0 {
+		return errors.New("tls cipher suites must be restricted in the strict profile")
+	}
+	for _, suite := range config.CipherSuites {
+		if !slices.Contains(strictCipherSuites, suite) {
+			return fmt.Errorf("tls cipher suite %s is not allowed in the strict profile", tls.CipherSuiteName(suite))
+		}
+	}
+	if config.MaxVersion != tls.VersionTLS12 {
+		return fmt.Errorf("tls maximum version must be %s in the strict profile", tls.VersionName(tls.VersionTLS12))
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+//go:build !fips
+
+package tls
+
+const (
+	fipsBuild      = false
+	defaultProfile = ProfileDefault
+)
@@ -0,0 +1,9 @@
+//go:build fips
+
+package tls
+
+// binaries built with the fips tag enforce the strict profile
+const (
+	fipsBuild      = true
+	defaultProfile = ProfileStrict
+)
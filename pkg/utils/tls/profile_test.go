@@ -0,0 +1,191 @@
+package tls
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func withProfile(t *testing.T, p Profile) {
+	previous := CurrentProfile()
+	profile.Store(p)
+	t.Cleanup(func() { profile.Store(previous) })
+}
+
+func TestServerConfig(t *testing.T) {
+	tests := []struct {
+		profile      Profile
+		maxVersion   uint16
+		cipherSuites []uint16
+		curves       []tls.CurveID
+	}{{
+		profile:      ProfileDefault,
+		cipherSuites: serverCipherSuites,
+	}, {
+		profile:      ProfileStrict,
+		maxVersion:   tls.VersionTLS12,
+		cipherSuites: strictCipherSuites,
+		curves:       strictCurves,
+	}}
+	for _, tt := range tests {
+		t.Run(string(tt.profile), func(t *testing.T) {
+			withProfile(t, tt.profile)
+			config := ServerConfig()
+			assert.Equal(t, config.MinVersion, uint16(tls.VersionTLS12))
+			assert.Equal(t, config.MaxVersion, tt.maxVersion)
+			assert.DeepEqual(t, config.CipherSuites, tt.cipherSuites)
+			assert.DeepEqual(t, config.CurvePreferences, tt.curves)
+			// the returned configuration can be modified by the caller
+			config.CipherSuites[0] = tls.TLS_RSA_WITH_AES_128_CBC_SHA
+			assert.Assert(t, ServerConfig().CipherSuites[0] != tls.TLS_RSA_WITH_AES_128_CBC_SHA)
+		})
+	}
+}
+
+func TestClientConfig(t *testing.T) {
+	tests := []struct {
+		profile      Profile
+		maxVersion   uint16
+		cipherSuites []uint16
+		curves       []tls.CurveID
+	}{{
+		profile: ProfileDefault,
+	}, {
+		profile:      ProfileStrict,
+		maxVersion:   tls.VersionTLS12,
+		cipherSuites: strictCipherSuites,
+		curves:       strictCurves,
+	}}
+	for _, tt := range tests {
+		t.Run(string(tt.profile), func(t *testing.T) {
+			withProfile(t, tt.profile)
+			config := ClientConfig()
+			assert.Equal(t, config.MinVersion, uint16(tls.VersionTLS12))
+			assert.Equal(t, config.MaxVersion, tt.maxVersion)
+			assert.DeepEqual(t, config.CipherSuites, tt.cipherSuites)
+			assert.DeepEqual(t, config.CurvePreferences, tt.curves)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile Profile
+		config  *tls.Config
+		wantErr string
+	}{{
+		name:    "default profile without config",
+		profile: ProfileDefault,
+	}, {
+		name:    "default profile with insecure config",
+		profile: ProfileDefault,
+		config:  &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: true}, //nolint:gosec
+	}, {
+		name:    "default profile with tls 1.1",
+		profile: ProfileDefault,
+		config:  &tls.Config{MinVersion: tls.VersionTLS11}, //nolint:gosec
+		wantErr: "tls minimum version TLS 1.1 is lower than TLS 1.2",
+	}, {
+		name:    "default profile with server config",
+		profile: ProfileDefault,
+		config:  ServerConfig(),
+	}, {
+		name:    "strict profile without config",
+		profile: ProfileStrict,
+		wantErr: "tls configuration is required in the strict profile",
+	}, {
+		name:    "strict profile with insecure config",
+		profile: ProfileStrict,
+		config:  &tls.Config{MinVersion: tls.VersionTLS12, CipherSuites: strictCipherSuites, InsecureSkipVerify: true}, //nolint:gosec
+		wantErr: "tls certificate verification can't be skipped in the strict profile",
+	}, {
+		name:    "strict profile with default cipher suites",
+		profile: ProfileStrict,
+		config:  &tls.Config{MinVersion: tls.VersionTLS12},
+		wantErr: "tls cipher suites must be restricted in the strict profile",
+	}, {
+		name:    "strict profile with chacha20",
+		profile: ProfileStrict,
+		config:  &tls.Config{MinVersion: tls.VersionTLS12, CipherSuites: serverCipherSuites},
+		wantErr: "tls cipher suite TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256 is not allowed in the strict profile",
+	}, {
+		name:    "strict profile with tls 1.3",
+		profile: ProfileStrict,
+		config:  &tls.Config{MinVersion: tls.VersionTLS12, CipherSuites: strictCipherSuites},
+		wantErr: "tls maximum version must be TLS 1.2 in the strict profile",
+	}, {
+		name:    "strict profile with strict config",
+		profile: ProfileStrict,
+		config:  &tls.Config{MinVersion: tls.VersionTLS12, MaxVersion: tls.VersionTLS12, CipherSuites: strictCipherSuites},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withProfile(t, tt.profile)
+			err := Validate(tt.config)
+			if tt.wantErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCurrentPosture(t *testing.T) {
+	tests := []struct {
+		profile Profile
+		want    Posture
+	}{{
+		profile: ProfileDefault,
+		want: Posture{
+			Profile:    ProfileDefault,
+			FIPSBuild:  fipsBuild,
+			MinVersion: "TLS 1.2",
+			CipherSuites: []string{
+				"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+				"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+				"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+				"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+				"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256",
+				"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+			},
+		},
+	}, {
+		profile: ProfileStrict,
+		want: Posture{
+			Profile:    ProfileStrict,
+			FIPSBuild:  fipsBuild,
+			MinVersion: "TLS 1.2",
+			MaxVersion: "TLS 1.2",
+			CipherSuites: []string{
+				"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+				"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+				"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+				"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+			},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(string(tt.profile), func(t *testing.T) {
+			withProfile(t, tt.profile)
+			assert.DeepEqual(t, CurrentPosture(), tt.want)
+		})
+	}
+}
+
+func TestConfigureTransport(t *testing.T) {
+	base := &http.Transport{TLSClientConfig: &tls.Config{ServerName: "registry", InsecureSkipVerify: true}} //nolint:gosec
+
+	withProfile(t, ProfileDefault)
+	assert.Equal(t, ConfigureTransport(base), base)
+
+	withProfile(t, ProfileStrict)
+	transport := ConfigureTransport(base)
+	assert.Assert(t, transport != base)
+	assert.Equal(t, transport.TLSClientConfig.ServerName, "registry")
+	assert.Assert(t, !transport.TLSClientConfig.InsecureSkipVerify)
+	assert.NilError(t, Validate(transport.TLSClientConfig))
+}
@@ -0,0 +1,147 @@
+package validatingadmissionpolicy
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/api/admissionregistration/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ConvertToKyvernoPolicy builds a Kyverno ClusterPolicy with a single CEL rule from a ValidatingAdmissionPolicy.
+// The binding is optional, when set it provides the param reference and the validation failure action.
+func ConvertToKyvernoPolicy(discoveryClient dclient.IDiscovery, vap v1alpha1.ValidatingAdmissionPolicy, binding *v1alpha1.ValidatingAdmissionPolicyBinding) (*kyvernov1.ClusterPolicy, error) {
+	constraints := vap.Spec.MatchConstraints
+	if constraints == nil {
+		return nil, errors.New("matchConstraints is required")
+	}
+	match, err := translateRulesToFilters(discoveryClient, constraints.ResourceRules)
+	if err != nil {
+		return nil, err
+	}
+	for i := range match {
+		match[i].NamespaceSelector = constraints.NamespaceSelector
+		match[i].Selector = constraints.ObjectSelector
+	}
+	exclude, err := translateRulesToFilters(discoveryClient, constraints.ExcludeResourceRules)
+	if err != nil {
+		return nil, err
+	}
+	rule := kyvernov1.Rule{
+		Name: vap.Name,
+		MatchResources: kyvernov1.MatchResources{
+			Any: match,
+		},
+		ExcludeResources: kyvernov1.MatchResources{
+			Any: exclude,
+		},
+		CELPreconditions: vap.Spec.MatchConditions,
+		Validation: kyvernov1.Validation{
+			CEL: &kyvernov1.CEL{
+				Expressions:      vap.Spec.Validations,
+				ParamKind:        vap.Spec.ParamKind,
+				AuditAnnotations: vap.Spec.AuditAnnotations,
+				Variables:        vap.Spec.Variables,
+			},
+		},
+	}
+	action := kyvernov1.Audit
+	if binding != nil {
+		if binding.Spec.MatchResources != nil {
+			return nil, errors.New("matchResources in ValidatingAdmissionPolicyBinding isn't applicable")
+		}
+		rule.Validation.CEL.ParamRef = binding.Spec.ParamRef
+		if slices.Contains(binding.Spec.ValidationActions, v1alpha1.Deny) {
+			action = kyvernov1.Enforce
+		}
+	}
+	policy := &kyvernov1.ClusterPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: kyvernov1.SchemeGroupVersion.String(),
+			Kind:       "ClusterPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: vap.Name,
+		},
+		Spec: kyvernov1.Spec{
+			ValidationFailureAction: action,
+			Rules:                   []kyvernov1.Rule{rule},
+		},
+	}
+	if vap.Spec.FailurePolicy != nil {
+		failurePolicy := kyvernov1.FailurePolicyType(*vap.Spec.FailurePolicy)
+		policy.Spec.FailurePolicy = &failurePolicy
+	}
+	return policy, nil
+}
+
+func translateRulesToFilters(discoveryClient dclient.IDiscovery, rules []v1alpha1.NamedRuleWithOperations) (kyvernov1.ResourceFilters, error) {
+	var filters kyvernov1.ResourceFilters
+	for _, rule := range rules {
+		kinds, err := translateRuleToKinds(discoveryClient, rule.Rule)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, kyvernov1.ResourceFilter{
+			ResourceDescription: kyvernov1.ResourceDescription{
+				Kinds:      kinds,
+				Names:      rule.ResourceNames,
+				Operations: translateOperationsToKyverno(rule.Operations),
+			},
+		})
+	}
+	return filters, nil
+}
+
+func translateRuleToKinds(discoveryClient dclient.IDiscovery, rule admissionregistrationv1.Rule) ([]string, error) {
+	var kinds []string
+	for _, group := range rule.APIGroups {
+		for _, version := range rule.APIVersions {
+			for _, resource := range rule.Resources {
+				if group == "*" && version == "*" && resource == "*" {
+					kinds = append(kinds, "*")
+					continue
+				}
+				if group == "*" || version == "*" || strings.Contains(resource, "*") {
+					return nil, fmt.Errorf("wildcards in apiGroups / apiVersions / resources aren't applicable: %s/%s/%s", group, version, resource)
+				}
+				resource, subresource, _ := strings.Cut(resource, "/")
+				gvk, err := discoveryClient.GetGVKFromGVR(schema.GroupVersionResource{Group: group, Version: version, Resource: resource})
+				if err != nil {
+					return nil, err
+				}
+				kind := gvk.Kind
+				if subresource != "" {
+					kind = kind + "." + subresource
+				}
+				kinds = append(kinds, schema.GroupVersion{Group: group, Version: version}.String()+"/"+kind)
+			}
+		}
+	}
+	return kinds, nil
+}
+
+func translateOperationsToKyverno(operations []admissionregistrationv1.OperationType) []kyvernov1.AdmissionOperation {
+	var out []kyvernov1.AdmissionOperation
+	for _, op := range operations {
+		switch op {
+		case admissionregistrationv1.OperationAll:
+			return []kyvernov1.AdmissionOperation{kyvernov1.Create, kyvernov1.Update, kyvernov1.Delete, kyvernov1.Connect}
+		case admissionregistrationv1.Create:
+			out = append(out, kyvernov1.Create)
+		case admissionregistrationv1.Update:
+			out = append(out, kyvernov1.Update)
+		case admissionregistrationv1.Delete:
+			out = append(out, kyvernov1.Delete)
+		case admissionregistrationv1.Connect:
+			out = append(out, kyvernov1.Connect)
+		}
+	}
+	return out
+}
@@ -0,0 +1,95 @@
+package validatingadmissionpolicy
+
+import (
+	"errors"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"gotest.tools/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/api/admissionregistration/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type fakeDiscovery struct {
+	dclient.IDiscovery
+	kinds map[schema.GroupVersionResource]string
+}
+
+func (d fakeDiscovery) GetGVKFromGVR(gvr schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	if kind, ok := d.kinds[gvr]; ok {
+		return gvr.GroupVersion().WithKind(kind), nil
+	}
+	return schema.GroupVersionKind{}, errors.New("not found")
+}
+
+func Test_ConvertToKyvernoPolicy(t *testing.T) {
+	discovery := fakeDiscovery{
+		kinds: map[schema.GroupVersionResource]string{
+			{Group: "apps", Version: "v1", Resource: "deployments"}: "Deployment",
+			{Version: "v1", Resource: "pods"}:                       "Pod",
+		},
+	}
+	fail := v1alpha1.Fail
+	vap := v1alpha1.ValidatingAdmissionPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "check-replicas"},
+		Spec: v1alpha1.ValidatingAdmissionPolicySpec{
+			FailurePolicy: &fail,
+			MatchConstraints: &v1alpha1.MatchResources{
+				ResourceRules: []v1alpha1.NamedRuleWithOperations{{
+					RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+						Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+						Rule: admissionregistrationv1.Rule{
+							APIGroups:   []string{"apps"},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"deployments", "deployments/scale"},
+						},
+					},
+				}},
+			},
+			Validations: []v1alpha1.Validation{{Expression: "object.spec.replicas <= 5"}},
+		},
+	}
+	binding := v1alpha1.ValidatingAdmissionPolicyBinding{
+		Spec: v1alpha1.ValidatingAdmissionPolicyBindingSpec{
+			PolicyName:        "check-replicas",
+			ValidationActions: []v1alpha1.ValidationAction{v1alpha1.Deny},
+		},
+	}
+	policy, err := ConvertToKyvernoPolicy(discovery, vap, &binding)
+	assert.NilError(t, err)
+	assert.Equal(t, "check-replicas", policy.GetName())
+	assert.Equal(t, kyvernov1.Enforce, policy.Spec.ValidationFailureAction)
+	assert.Equal(t, kyvernov1.Fail, *policy.Spec.FailurePolicy)
+	assert.Equal(t, 1, len(policy.Spec.Rules))
+	rule := policy.Spec.Rules[0]
+	assert.DeepEqual(t, []string{"apps/v1/Deployment", "apps/v1/Deployment.scale"}, rule.MatchResources.Any[0].Kinds)
+	assert.DeepEqual(t, []kyvernov1.AdmissionOperation{kyvernov1.Create}, rule.MatchResources.Any[0].Operations)
+	assert.Equal(t, "object.spec.replicas <= 5", rule.Validation.CEL.Expressions[0].Expression)
+	// round trip
+	ok, msg := CanGenerateVAP(&policy.Spec)
+	assert.Assert(t, ok, msg)
+}
+
+func Test_ConvertToKyvernoPolicy_Wildcards(t *testing.T) {
+	vap := v1alpha1.ValidatingAdmissionPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "wildcards"},
+		Spec: v1alpha1.ValidatingAdmissionPolicySpec{
+			MatchConstraints: &v1alpha1.MatchResources{
+				ResourceRules: []v1alpha1.NamedRuleWithOperations{{
+					RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+						Rule: admissionregistrationv1.Rule{
+							APIGroups:   []string{"*"},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"pods"},
+						},
+					},
+				}},
+			},
+		},
+	}
+	_, err := ConvertToKyvernoPolicy(fakeDiscovery{}, vap, nil)
+	assert.ErrorContains(t, err, "wildcards")
+}
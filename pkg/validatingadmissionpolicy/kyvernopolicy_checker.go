@@ -1,4 +1,4 @@
-package validatingadmissionpolicygenerate
+package validatingadmissionpolicy
 
 import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
@@ -6,8 +6,8 @@ import (
 
 func checkResources(resource kyvernov1.ResourceDescription) (bool, string) {
 	var msg string
-	if len(resource.Namespaces) != 0 || len(resource.Annotations) != 0 {
-		msg = "skip generating ValidatingAdmissionPolicy: Namespaces / Annotations in resource description isn't applicable."
+	if len(resource.Namespaces) != 0 || len(resource.Annotations) != 0 || resource.FieldSelector != "" || resource.Owner != nil {
+		msg = "skip generating ValidatingAdmissionPolicy: Namespaces / Annotations / FieldSelector / Owner in resource description isn't applicable."
 		return false, msg
 	}
 	return true, msg
@@ -22,7 +22,7 @@ func checkUserInfo(info kyvernov1.UserInfo) (bool, string) {
 	return true, msg
 }
 
-func canGenerateVAP(spec *kyvernov1.Spec) (bool, string) {
+func CanGenerateVAP(spec *kyvernov1.Spec) (bool, string) {
 	var msg string
 	if len(spec.Rules) > 1 {
 		msg = "skip generating ValidatingAdmissionPolicy: multiple rules aren't applicable."
@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 	"github.com/kyverno/kyverno/pkg/auth"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
@@ -66,22 +67,25 @@ func validatePolicy(clusterResources sets.Set[string], policy kyvernov2alpha1.Cl
 	return errs.ToAggregate()
 }
 
-// validateAuth checks the the delete action is allowed
+// validateAuth checks the action of the policy is allowed, deleting requires the delete permission
+// and other actions, like pre-delete annotations, require the patch permission
 func validateAuth(ctx context.Context, client dclient.Interface, policy kyvernov2alpha1.CleanupPolicyInterface) error {
 	namespace := policy.GetNamespace()
 	spec := policy.GetSpec()
 	kinds := sets.New(spec.MatchResources.GetKinds()...)
 	for kind := range kinds {
-		checker := auth.NewCanI(client.Discovery(), client.GetKubeClient().AuthorizationV1().SubjectAccessReviews(), kind, namespace, "delete", "", config.KyvernoUserName(config.KyvernoServiceAccountName()))
-		allowedDeletion, _, err := checker.RunAccessCheck(ctx)
-		if err != nil {
-			return err
-		}
-		if !allowedDeletion {
-			return fmt.Errorf("cleanup controller has no permission to delete kind %s", kind)
+		if spec.GetAction() == kyvernov2beta1.CleanupActionDelete {
+			checker := auth.NewCanI(client.Discovery(), client.GetKubeClient().AuthorizationV1().SubjectAccessReviews(), kind, namespace, "delete", "", config.KyvernoUserName(config.KyvernoServiceAccountName()))
+			allowedDeletion, _, err := checker.RunAccessCheck(ctx)
+			if err != nil {
+				return err
+			}
+			if !allowedDeletion {
+				return fmt.Errorf("cleanup controller has no permission to delete kind %s", kind)
+			}
 		}
 
-		checker = auth.NewCanI(client.Discovery(), client.GetKubeClient().AuthorizationV1().SubjectAccessReviews(), kind, namespace, "list", "", config.KyvernoUserName(config.KyvernoServiceAccountName()))
+		checker := auth.NewCanI(client.Discovery(), client.GetKubeClient().AuthorizationV1().SubjectAccessReviews(), kind, namespace, "list", "", config.KyvernoUserName(config.KyvernoServiceAccountName()))
 		allowedList, _, err := checker.RunAccessCheck(ctx)
 		if err != nil {
 			return err
@@ -89,6 +93,21 @@ func validateAuth(ctx context.Context, client dclient.Interface, policy kyvernov
 		if !allowedList {
 			return fmt.Errorf("cleanup controller has no permission to list kind %s", kind)
 		}
+
+		requiredBy := fmt.Sprintf("the %s action", spec.GetAction())
+		if spec.GetAction() == kyvernov2beta1.CleanupActionDelete {
+			requiredBy = "the pre-delete annotations"
+		}
+		if spec.GetAction() != kyvernov2beta1.CleanupActionDelete || (spec.PreDelete != nil && len(spec.PreDelete.Annotations) != 0) {
+			checker = auth.NewCanI(client.Discovery(), client.GetKubeClient().AuthorizationV1().SubjectAccessReviews(), kind, namespace, "patch", "", config.KyvernoUserName(config.KyvernoServiceAccountName()))
+			allowedPatch, _, err := checker.RunAccessCheck(ctx)
+			if err != nil {
+				return err
+			}
+			if !allowedPatch {
+				return fmt.Errorf("cleanup controller has no permission to patch kind %s, required by %s", kind, requiredBy)
+			}
+		}
 	}
 	return nil
 }
@@ -104,4 +123,4 @@ func validateVariables(logger logr.Logger, policy kyvernov2alpha1.CleanupPolicyI
 	return nil
 }
 
-var allowedVariables = regexp.MustCompile(`([a-z_0-9]+)|(target\.|images\.|([a-z_0-9]+\()[^{}])`)
+var allowedVariables = regexp.MustCompile(`([a-z_0-9]+)|(target\.|images\.|age\.|([a-z_0-9]+\()[^{}])`)
@@ -487,3 +487,42 @@ spec:
 	err = hasInvalidVariables(policy[0], false)
 	assert.NilError(t, err)
 }
+
+func TestAllowedVars_Parameters(t *testing.T) {
+	var policyManifest = []byte(`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: max-replicas
+spec:
+  parameters:
+    paramKind:
+      apiVersion: v1
+      kind: ConfigMap
+    paramRef:
+      name: limits
+      namespace: kyverno
+  rules:
+  - name: max-replicas
+    match:
+      any:
+      - resources:
+          kinds:
+          - Deployment
+    validate:
+      deny:
+        conditions:
+          any:
+          - key: "{{ request.object.spec.replicas }}"
+            operator: GreaterThan
+            value: "{{ params.data.maxReplicas }}"
+`)
+	policy, _, err := yamlutils.GetPolicy(policyManifest)
+	assert.NilError(t, err)
+	err = hasInvalidVariables(policy[0], false)
+	assert.NilError(t, err)
+
+	policy[0].GetSpec().Parameters = nil
+	err = hasInvalidVariables(policy[0], false)
+	assert.Assert(t, err != nil)
+}
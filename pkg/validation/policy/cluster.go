@@ -0,0 +1,45 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AuthorizeKubeconfigSecrets checks the user creating or updating a policy is allowed to get the kubeconfig secrets
+// of the remote clusters targeted by the policy, otherwise the policy would let the user act in any remote cluster
+// with the credentials of its kubeconfig
+func AuthorizeKubeconfigSecrets(ctx context.Context, client dclient.Interface, policy kyvernov1.PolicyInterface, user string, groups []string) error {
+	for _, rule := range policy.GetSpec().Rules {
+		cluster := rule.Generation.Cluster
+		if cluster == nil {
+			continue
+		}
+		secret := cluster.KubeconfigSecret
+		review := &authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Version:   "v1",
+					Resource:  "secrets",
+					Namespace: secret.Namespace,
+					Name:      secret.Name,
+					Verb:      "get",
+				},
+				User:   user,
+				Groups: groups,
+			},
+		}
+		resp, err := client.GetKubeClient().AuthorizationV1().SubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to check the permissions of %s: %w", user, err)
+		}
+		if !resp.Status.Allowed {
+			return fmt.Errorf("user %s is not allowed to get the kubeconfig secret %s/%s, required by rule %s", user, secret.Namespace, secret.Name, rule.Name)
+		}
+	}
+	return nil
+}
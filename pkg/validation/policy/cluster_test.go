@@ -0,0 +1,46 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"gotest.tools/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	kubetesting "k8s.io/client-go/testing"
+)
+
+func Test_AuthorizeKubeconfigSecrets(t *testing.T) {
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), nil)
+	assert.NilError(t, err)
+	client.GetKubeClient().(*kubefake.Clientset).PrependReactor("create", "subjectaccessreviews", func(action kubetesting.Action) (bool, runtime.Object, error) {
+		review := action.(kubetesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		attributes := review.Spec.ResourceAttributes
+		assert.Equal(t, attributes.Resource, "secrets")
+		assert.Equal(t, attributes.Verb, "get")
+		assert.Equal(t, attributes.Namespace, "clusters")
+		review.Status.Allowed = review.Spec.User == "platform-admin" && attributes.Name == "workload-kubeconfig"
+		return true, review, nil
+	})
+
+	policy := &kyverno.ClusterPolicy{}
+	policy.Spec.Rules = []kyverno.Rule{{Name: "local"}}
+	assert.NilError(t, AuthorizeKubeconfigSecrets(context.TODO(), client, policy, "developer", nil))
+
+	policy.Spec.Rules = append(policy.Spec.Rules, kyverno.Rule{
+		Name: "remote",
+		Generation: kyverno.Generation{
+			Cluster: &kyverno.ClusterReference{KubeconfigSecret: kyverno.SecretReference{Namespace: "clusters", Name: "workload-kubeconfig"}},
+		},
+	})
+	assert.NilError(t, AuthorizeKubeconfigSecrets(context.TODO(), client, policy, "platform-admin", nil))
+	assert.Error(t, AuthorizeKubeconfigSecrets(context.TODO(), client, policy, "developer", nil),
+		"user developer is not allowed to get the kubeconfig secret clusters/workload-kubeconfig, required by rule remote")
+
+	policy.Spec.Rules[1].Generation.Cluster.KubeconfigSecret.Name = "management-kubeconfig"
+	assert.Error(t, AuthorizeKubeconfigSecrets(context.TODO(), client, policy, "platform-admin", nil),
+		"user platform-admin is not allowed to get the kubeconfig secret clusters/management-kubeconfig, required by rule remote")
+}
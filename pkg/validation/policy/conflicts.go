@@ -0,0 +1,245 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/engine/anchor"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/tools/cache"
+)
+
+// FindConflicts returns messages describing rules of the policy that duplicate
+// a rule of another policy (same match and same check) or whose mutation
+// directly conflicts with the mutation of another policy.
+// Rules are compared after autogen so that pod controller rules are checked too.
+// Mutations are compared using the unconditional fields of strategic merge patches,
+// the add, replace and remove operations of JSON patches and, for foreach mutations
+// without preconditions, the patches applied to elements of the same list. Nested
+// foreach declarations and mutations of existing resources are not compared.
+func FindConflicts(policy kyvernov1.PolicyInterface, others ...kyvernov1.PolicyInterface) []string {
+	var conflicts []string
+	key := policyKey(policy)
+	rules, origins := computeRules(policy)
+	for _, other := range others {
+		if other.GetKind() == policy.GetKind() && policyKey(other) == key {
+			continue
+		}
+		otherRules, otherOrigins := computeRules(other)
+		// autogen rules conflict whenever the rules they were generated from conflict,
+		// only the first conflict between two user rules is reported
+		reported := sets.New[[2]string]()
+		for _, rule := range rules {
+			for _, otherRule := range otherRules {
+				pair := [2]string{origins[rule.Name], otherOrigins[otherRule.Name]}
+				if reported.Has(pair) || !sameMatch(rule, otherRule) {
+					continue
+				}
+				if isDuplicateRule(rule, otherRule) {
+					conflicts = append(conflicts, fmt.Sprintf("rule %s duplicates rule %s of %s %s", rule.Name, otherRule.Name, other.GetKind(), policyKey(other)))
+					reported.Insert(pair)
+				} else if path := conflictingMutation(rule, otherRule); path != "" {
+					conflicts = append(conflicts, fmt.Sprintf("rule %s mutation conflicts with rule %s of %s %s at path %s", rule.Name, otherRule.Name, other.GetKind(), policyKey(other), path))
+					reported.Insert(pair)
+				}
+			}
+		}
+	}
+	return conflicts
+}
+
+// computeRules returns the rules of a policy after autogen, with the name of the
+// user rule every computed rule originates from. The rules of the custom pod controllers
+// are left out, they conflict whenever the rules they are generated from conflict.
+func computeRules(policy kyvernov1.PolicyInterface) ([]kyvernov1.Rule, map[string]string) {
+	origins := map[string]string{}
+	for _, rule := range policy.GetSpec().Rules {
+		for _, prefix := range []string{"autogen-", "autogen-cronjob-"} {
+			name := prefix + rule.Name
+			if len(name) > 63 {
+				name = name[:63]
+			}
+			origins[name] = rule.Name
+		}
+	}
+	rules := autogen.ComputeRules(policy, nil)
+	for _, rule := range rules {
+		if _, ok := origins[rule.Name]; !ok {
+			origins[rule.Name] = rule.Name
+		}
+	}
+	return rules, origins
+}
+
+func policyKey(policy kyvernov1.PolicyInterface) string {
+	key, _ := cache.MetaNamespaceKeyFunc(policy)
+	return key
+}
+
+// sameMatch compares match and exclude blocks regardless of the order of kinds, names, namespaces
+// and operations, and the rule preconditions.
+func sameMatch(a, b kyvernov1.Rule) bool {
+	return datautils.DeepEqual(normalizeSets(a.MatchResources), normalizeSets(b.MatchResources)) &&
+		datautils.DeepEqual(normalizeSets(a.ExcludeResources), normalizeSets(b.ExcludeResources)) &&
+		datautils.DeepEqual(a.GetAnyAllConditions(), b.GetAnyAllConditions())
+}
+
+// normalizeSets converts a value to its unstructured form with every list of strings sorted.
+func normalizeSets(in interface{}) interface{} {
+	return sortStringLists(toUnstructured(in))
+}
+
+// toUnstructured converts a value to its unstructured form so that raw JSON fields
+// are compared by content instead of by encoding.
+func toUnstructured(in interface{}) interface{} {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return in
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return in
+	}
+	return out
+}
+
+func sortStringLists(in interface{}) interface{} {
+	switch value := in.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			value[key] = sortStringLists(child)
+		}
+	case []interface{}:
+		strs := make([]string, 0, len(value))
+		for i, child := range value {
+			value[i] = sortStringLists(child)
+			if str, ok := child.(string); ok {
+				strs = append(strs, str)
+			}
+		}
+		if len(strs) == len(value) {
+			sort.Strings(strs)
+			for i := range strs {
+				value[i] = strs[i]
+			}
+		}
+	}
+	return in
+}
+
+// isDuplicateRule compares the checks of both rules, validation messages are ignored
+// as they don't change what the rule enforces.
+func isDuplicateRule(a, b kyvernov1.Rule) bool {
+	switch {
+	case a.HasValidate() && b.HasValidate():
+		validationA, validationB := *a.Validation.DeepCopy(), *b.Validation.DeepCopy()
+		validationA.Message, validationB.Message = "", ""
+		return datautils.DeepEqual(toUnstructured(validationA), toUnstructured(validationB))
+	case a.HasMutate() && b.HasMutate():
+		return datautils.DeepEqual(toUnstructured(a.Mutation), toUnstructured(b.Mutation))
+	case a.HasGenerate() && b.HasGenerate():
+		return datautils.DeepEqual(toUnstructured(a.Generation), toUnstructured(b.Generation))
+	case a.HasVerifyImages() && b.HasVerifyImages():
+		return datautils.DeepEqual(toUnstructured(a.VerifyImages), toUnstructured(b.VerifyImages))
+	}
+	return false
+}
+
+// removed marks a path removed by a JSON patch.
+type removed struct{}
+
+// conflictingMutation returns the first path set to different values by the
+// patches of both rules, or an empty string.
+func conflictingMutation(a, b kyvernov1.Rule) string {
+	if !a.HasMutate() || !b.HasMutate() || len(a.Mutation.Targets) != 0 || len(b.Mutation.Targets) != 0 {
+		return ""
+	}
+	leavesA, leavesB := mutationLeaves(a.Mutation), mutationLeaves(b.Mutation)
+	var paths []string
+	for path, value := range leavesA {
+		if other, ok := leavesB[path]; ok && !datautils.DeepEqual(value, other) {
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) == 0 {
+		return ""
+	}
+	// return the smallest path to get a stable result
+	sort.Strings(paths)
+	return paths[0]
+}
+
+// mutationLeaves collects the paths set by every form of patch of a mutation,
+// foreach paths are prefixed with the list they apply to.
+func mutationLeaves(mutation kyvernov1.Mutation) map[string]interface{} {
+	leaves := map[string]interface{}{}
+	if mutation.RawPatchStrategicMerge != nil {
+		collectLeaves("", mutation.GetPatchStrategicMerge(), leaves)
+	}
+	collectJSONPatchLeaves("", mutation.PatchesJSON6902, leaves)
+	for _, foreach := range mutation.ForEachMutation {
+		if foreach.AnyAllConditions != nil || foreach.ForEachMutation != nil {
+			continue
+		}
+		prefix := fmt.Sprintf("foreach(%s)", foreach.List)
+		if foreach.RawPatchStrategicMerge != nil {
+			collectLeaves(prefix, foreach.GetPatchStrategicMerge(), leaves)
+		}
+		collectJSONPatchLeaves(prefix, foreach.PatchesJSON6902, leaves)
+	}
+	return leaves
+}
+
+// collectLeaves flattens the unconditional map fields of a patch, anchored
+// fields are skipped as they only apply under specific conditions.
+func collectLeaves(prefix string, value interface{}, leaves map[string]interface{}) {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		leaves[prefix] = value
+		return
+	}
+	for key, child := range object {
+		if anchor.Parse(key) != nil {
+			continue
+		}
+		collectLeaves(prefix+jsonutils.JoinPointer(key), child, leaves)
+	}
+}
+
+// collectJSONPatchLeaves collects the paths set or removed by a JSON patch,
+// appends to arrays never conflict and are skipped.
+func collectJSONPatchLeaves(prefix string, patch string, leaves map[string]interface{}) {
+	if patch == "" {
+		return
+	}
+	data, err := yaml.ToJSON([]byte(patch))
+	if err != nil {
+		return
+	}
+	operations, err := jsonpatch.DecodePatch(data)
+	if err != nil {
+		return
+	}
+	for _, operation := range operations {
+		path, err := operation.Path()
+		if err != nil || strings.HasSuffix(path, "/-") {
+			continue
+		}
+		switch operation.Kind() {
+		case "add", "replace":
+			if value, err := operation.ValueInterface(); err == nil {
+				leaves[prefix+path] = value
+			}
+		case "remove":
+			leaves[prefix+path] = removed{}
+		}
+	}
+}
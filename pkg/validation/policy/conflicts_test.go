@@ -0,0 +1,155 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+)
+
+func loadClusterPolicy(t *testing.T, raw string) *kyverno.ClusterPolicy {
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal([]byte(raw), &policy))
+	return &policy
+}
+
+func Test_FindConflicts(t *testing.T) {
+	requireLabel := `{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": { "name": "%s" },
+		"spec": {
+			"rules": [{
+				"name": "require-label",
+				"match": { "any": [{ "resources": { "kinds": ["Pod"] } }] },
+				"validate": { "message": "label required", "pattern": { "metadata": { "labels": { "app": "?*" } } } }
+			}]
+		}
+	}`
+	setPolicy := `{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": { "name": "%s" },
+		"spec": {
+			"rules": [{
+				"name": "set-policy",
+				"match": { "any": [{ "resources": { "kinds": ["Pod"] } }] },
+				"mutate": { "patchStrategicMerge": { "spec": { "restartPolicy": "%s", "+(dnsPolicy)": "%s" } } }
+			}]
+		}
+	}`
+	requireLabelOn := `{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": { "name": "%s" },
+		"spec": {
+			"rules": [{
+				"name": "require-label",
+				"match": { "any": [{ "resources": { "kinds": %s } }] },
+				"validate": { "message": "%s", "pattern": { "metadata": { "labels": { "app": "?*" } } } }
+			}]
+		}
+	}`
+	requireDeploymentLabel := `{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": { "name": "%s" },
+		"spec": {
+			"rules": [{
+				"name": "require-deployment-label",
+				"match": { "any": [{ "resources": { "kinds": ["DaemonSet", "Deployment", "Job", "ReplicaSet", "ReplicationController", "StatefulSet"] } }] },
+				"validate": { "message": "label required", "pattern": { "spec": { "template": { "metadata": { "labels": { "app": "?*" } } } } } }
+			}]
+		}
+	}`
+	jsonPatch := `{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": { "name": "%s" },
+		"spec": {
+			"rules": [{
+				"name": "json-patch",
+				"match": { "any": [{ "resources": { "kinds": ["Pod"] } }] },
+				"mutate": { "patchesJson6902": "- op: add\n  path: /spec/restartPolicy\n  value: %s\n- op: add\n  path: /spec/tolerations/-\n  value: { key: %s }" }
+			}]
+		}
+	}`
+	foreachPatch := `{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": { "name": "%s" },
+		"spec": {
+			"rules": [{
+				"name": "foreach-patch",
+				"match": { "any": [{ "resources": { "kinds": ["Pod"] } }] },
+				"mutate": { "foreach": [{
+					"list": "request.object.spec.containers",
+					"patchStrategicMerge": { "spec": { "containers": [{ "(name)": "{{ element.name }}", "imagePullPolicy": "%s" }] } }
+				}, {
+					"list": "request.object.spec.containers",
+					"patchesJson6902": "- op: replace\n  path: /spec/securityContext/runAsUser\n  value: %s"
+				}] }
+			}]
+		}
+	}`
+	tests := []struct {
+		name   string
+		policy string
+		others []string
+		want   []string
+	}{{
+		name:   "duplicate validation",
+		policy: fmt.Sprintf(requireLabel, "a"),
+		others: []string{fmt.Sprintf(requireLabel, "b")},
+		want:   []string{"rule require-label duplicates rule require-label of ClusterPolicy b"},
+	}, {
+		name:   "same policy is ignored",
+		policy: fmt.Sprintf(requireLabel, "a"),
+		others: []string{fmt.Sprintf(requireLabel, "a")},
+	}, {
+		name:   "conflicting mutation",
+		policy: fmt.Sprintf(setPolicy, "a", "Always", "Default"),
+		others: []string{fmt.Sprintf(setPolicy, "b", "Never", "Default")},
+		want:   []string{"rule set-policy mutation conflicts with rule set-policy of ClusterPolicy b at path /spec/restartPolicy"},
+	}, {
+		name:   "anchored fields do not conflict",
+		policy: fmt.Sprintf(setPolicy, "a", "Always", "Default"),
+		others: []string{fmt.Sprintf(setPolicy, "b", "Always", "None")},
+		want:   []string{},
+	}, {
+		name:   "duplicate ignores kinds order and message",
+		policy: fmt.Sprintf(requireLabelOn, "a", `["Pod", "Service"]`, "label required"),
+		others: []string{fmt.Sprintf(requireLabelOn, "b", `["Service", "Pod"]`, "app label is required")},
+		want:   []string{"rule require-label duplicates rule require-label of ClusterPolicy b"},
+	}, {
+		name:   "duplicate autogen rule",
+		policy: fmt.Sprintf(requireLabel, "a"),
+		others: []string{fmt.Sprintf(requireDeploymentLabel, "b")},
+		want:   []string{"rule autogen-require-label duplicates rule require-deployment-label of ClusterPolicy b"},
+	}, {
+		name:   "conflicting json patch",
+		policy: fmt.Sprintf(jsonPatch, "a", "Always", "a"),
+		others: []string{fmt.Sprintf(setPolicy, "b", "Never", "Default"), fmt.Sprintf(jsonPatch, "c", "Always", "c")},
+		want:   []string{"rule json-patch mutation conflicts with rule set-policy of ClusterPolicy b at path /spec/restartPolicy"},
+	}, {
+		name:   "conflicting foreach patch",
+		policy: fmt.Sprintf(foreachPatch, "a", "Always", "1000"),
+		others: []string{fmt.Sprintf(foreachPatch, "b", "Always", "2000")},
+		want:   []string{"rule foreach-patch mutation conflicts with rule foreach-patch of ClusterPolicy b at path foreach(request.object.spec.containers)/spec/securityContext/runAsUser"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var others []kyverno.PolicyInterface
+			for _, other := range tt.others {
+				others = append(others, loadClusterPolicy(t, other))
+			}
+			got := FindConflicts(loadClusterPolicy(t, tt.policy), others...)
+			assert.Equal(t, len(tt.want), len(got))
+			for i := range tt.want {
+				assert.Equal(t, tt.want[i], got[i])
+			}
+		})
+	}
+}
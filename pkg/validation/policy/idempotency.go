@@ -0,0 +1,48 @@
+package policy
+
+import (
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
+)
+
+// validateIdempotency checks the JSON patches of a rule applied again when the mutating webhook is reinvoked,
+// strategic merge patches are idempotent and only JSON patch operations are checked.
+func validateIdempotency(rule kyvernov1.Rule, ruleIdx int) error {
+	check := func(path string, patches string) error {
+		if patches == "" {
+			return nil
+		}
+		patches = variables.ReplaceAllVars(patches, func(s string) string { return "kyvernojsonpatchvariable" })
+		if err := patch.CheckIdempotency(patches); err != nil {
+			return fmt.Errorf("path: spec.rules[%d].mutate.%s: reinvoked policies require idempotent patches: %v", ruleIdx, path, err)
+		}
+		return nil
+	}
+	if err := check("patchesJson6902", rule.Mutation.PatchesJSON6902); err != nil {
+		return err
+	}
+	var checkForEach func(path string, foreach []kyvernov1.ForEachMutation) error
+	checkForEach = func(path string, foreach []kyvernov1.ForEachMutation) error {
+		for i, fe := range foreach {
+			fePath := fmt.Sprintf("%s[%d]", path, i)
+			if err := check(fePath+".patchesJson6902", fe.PatchesJSON6902); err != nil {
+				return err
+			}
+			if fe.ForEachMutation != nil {
+				nested, err := apiutils.DeserializeJSONArray[kyvernov1.ForEachMutation](fe.ForEachMutation)
+				if err != nil {
+					return fmt.Errorf("path: spec.rules[%d].mutate.%s.foreach: %v", ruleIdx, fePath, err)
+				}
+				if err := checkForEach(fePath+".foreach", nested); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	return checkForEach("foreach", rule.Mutation.ForEachMutation)
+}
@@ -0,0 +1,108 @@
+package policy
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+)
+
+func Test_validateIdempotency(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+		err    string
+	}{{
+		name: "add and replace",
+		policy: `{
+			"apiVersion": "kyverno.io/v1",
+			"kind": "ClusterPolicy",
+			"metadata": {"name": "add-toleration"},
+			"spec": {"rules": [{
+				"name": "add-toleration",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"mutate": {"patchesJson6902": "- op: add\n  path: /spec/tolerations/-\n  value: {\"key\": \"{{ request.object.metadata.name }}\"}\n- op: replace\n  path: /spec/priority\n  value: 10"}
+			}]}
+		}`,
+	}, {
+		name: "remove array element",
+		policy: `{
+			"apiVersion": "kyverno.io/v1",
+			"kind": "ClusterPolicy",
+			"metadata": {"name": "remove-container"},
+			"spec": {"rules": [{
+				"name": "remove-container",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"mutate": {"patchesJson6902": "- op: remove\n  path: /spec/containers/0"}
+			}]}
+		}`,
+		err: "path: spec.rules[0].mutate.patchesJson6902: reinvoked policies require idempotent patches: operation 0: remove operations of array elements are not idempotent",
+	}, {
+		name: "nested foreach move",
+		policy: `{
+			"apiVersion": "kyverno.io/v1",
+			"kind": "ClusterPolicy",
+			"metadata": {"name": "move-labels"},
+			"spec": {"rules": [{
+				"name": "move-labels",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"mutate": {"foreach": [{
+					"list": "request.object.spec.containers",
+					"foreach": [{
+						"list": "element.env",
+						"patchesJson6902": "- op: move\n  from: /metadata/labels/app\n  path: /metadata/labels/name"
+					}]
+				}]}
+			}]}
+		}`,
+		err: "path: spec.rules[0].mutate.foreach[0].foreach[0].patchesJson6902: reinvoked policies require idempotent patches: operation 0: move operations are not idempotent",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := loadClusterPolicy(t, tt.policy)
+			err := validateIdempotency(policy.Spec.Rules[0], 0)
+			if tt.err == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func Test_validateIdempotency_StrategicMerge(t *testing.T) {
+	rule := kyverno.Rule{Name: "add-label"}
+	rule.Mutation.SetPatchStrategicMerge(map[string]interface{}{"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": "nginx"}}})
+	assert.NilError(t, validateIdempotency(rule, 0))
+}
+
+func Test_Validate_Idempotency(t *testing.T) {
+	policy := loadClusterPolicy(t, `{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "remove-container"},
+		"spec": {"rules": [{
+			"name": "remove-container",
+			"match": {"any": [{"resources": {"kinds": ["Deployment"]}}]},
+			"mutate": {"patchesJson6902": "- op: remove\n  path: /spec/template/spec/containers/0"}
+		}]}
+	}`)
+	idempotencyError := "path: spec.rules[0].mutate.patchesJson6902: reinvoked policies require idempotent patches: operation 0: remove operations of array elements are not idempotent"
+	// policies reinvoked by default are accepted with a warning
+	warnings, err := Validate(context.TODO(), policy, nil, nil, true, "admin")
+	assert.NilError(t, err)
+	assert.Assert(t, slices.Contains(warnings, idempotencyError), warnings)
+
+	ifNeeded := kyverno.IfNeededReinvocationPolicy
+	policy.Spec.ReinvocationPolicy = &ifNeeded
+	_, err = Validate(context.TODO(), policy, nil, nil, true, "admin")
+	assert.Error(t, err, idempotencyError)
+
+	never := kyverno.NeverReinvocationPolicy
+	policy.Spec.ReinvocationPolicy = &never
+	warnings, err = Validate(context.TODO(), policy, nil, nil, true, "admin")
+	assert.NilError(t, err)
+	assert.Assert(t, !slices.Contains(warnings, idempotencyError), warnings)
+}
@@ -0,0 +1,192 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/auth/checker"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// NamespacedAccess is an access of a namespaced policy to resources outside of its namespace.
+type NamespacedAccess struct {
+	// Path is the policy field declaring the access
+	Path *field.Path
+	// Verbs are the operations performed on behalf of the policy
+	Verbs []string
+	// Kind is the kind of the accessed resources, optionally prefixed with the api version
+	Kind string
+	// Namespace of the accessed resources, empty when it depends on variables
+	Namespace string
+}
+
+func (a NamespacedAccess) String() string {
+	return fmt.Sprintf("%s %s (%s)", strings.Join(a.Verbs, ","), a.target(), a.Path)
+}
+
+func (a NamespacedAccess) target() string {
+	if a.Namespace == "" {
+		return a.Kind + " in all namespaces"
+	}
+	return a.Kind + " in namespace " + a.Namespace
+}
+
+// NamespacedAccesses returns the accesses a namespaced policy delegates to Kyverno outside of its namespace:
+// generating resources in, or cloning resources from, other namespaces and reading image verification keys
+// stored in secrets of other namespaces.
+func NamespacedAccesses(policy kyvernov1.PolicyInterface) []NamespacedAccess {
+	return namespacedAccesses(policy, true)
+}
+
+func namespacedAccesses(policy kyvernov1.PolicyInterface, verifyImages bool) []NamespacedAccess {
+	if !policy.IsNamespaced() {
+		return nil
+	}
+	var accesses []NamespacedAccess
+	namespace := policy.GetNamespace()
+	rulesPath := field.NewPath("spec").Child("rules")
+	for i, rule := range policy.GetSpec().Rules {
+		rulePath := rulesPath.Index(i)
+		if rule.HasGenerate() {
+			accesses = append(accesses, generateAccesses(rulePath.Child("generate"), rule.Generation, namespace)...)
+		}
+		if verifyImages {
+			for j, iv := range rule.VerifyImages {
+				accesses = append(accesses, verifyImagesAccesses(rulePath.Child("verifyImages").Index(j), iv, namespace)...)
+			}
+		}
+	}
+	return accesses
+}
+
+func generateAccesses(path *field.Path, generation kyvernov1.Generation, namespace string) []NamespacedAccess {
+	var accesses []NamespacedAccess
+	kind := kindOf(generation.GetAPIVersion(), generation.GetKind())
+	targetKinds := generation.CloneList.Kinds
+	if generation.GetKind() != "" {
+		targetKinds = []string{kind}
+	}
+	if generation.GetNamespace() != namespace {
+		target := generation.GetNamespace()
+		if regex.IsVariable(target) {
+			target = ""
+		}
+		// generated resources are updated when synchronized and deleted with their trigger
+		for _, kind := range targetKinds {
+			accesses = append(accesses, NamespacedAccess{
+				Path:      path.Child("namespace"),
+				Verbs:     []string{"create", "update", "delete"},
+				Kind:      kind,
+				Namespace: target,
+			})
+		}
+	}
+	if generation.Clone.Name != "" && generation.Clone.Namespace != namespace {
+		accesses = append(accesses, NamespacedAccess{
+			Path:      path.Child("clone").Child("namespace"),
+			Verbs:     []string{"get"},
+			Kind:      kind,
+			Namespace: generation.Clone.Namespace,
+		})
+	}
+	if len(generation.CloneList.Kinds) != 0 && generation.CloneList.Namespace != namespace {
+		for _, kind := range generation.CloneList.Kinds {
+			accesses = append(accesses, NamespacedAccess{
+				Path:      path.Child("cloneList").Child("namespace"),
+				Verbs:     []string{"list"},
+				Kind:      kind,
+				Namespace: generation.CloneList.Namespace,
+			})
+		}
+	}
+	return accesses
+}
+
+func verifyImagesAccesses(path *field.Path, iv kyvernov1.ImageVerification, namespace string) []NamespacedAccess {
+	var accesses []NamespacedAccess
+	secret := func(path *field.Path, secretNamespace string) {
+		if secretNamespace != namespace {
+			accesses = append(accesses, NamespacedAccess{
+				Path:      path,
+				Verbs:     []string{"get"},
+				Kind:      "v1/Secret",
+				Namespace: secretNamespace,
+			})
+		}
+	}
+	attestors := func(path *field.Path, sets []kyvernov1.AttestorSet) {
+		for i, set := range sets {
+			for j, attestor := range set.Entries {
+				if attestor.Keys == nil {
+					continue
+				}
+				keysPath := path.Index(i).Child("entries").Index(j).Child("keys")
+				if attestor.Keys.Secret != nil {
+					secret(keysPath.Child("secret").Child("namespace"), attestor.Keys.Secret.Namespace)
+				}
+				if ref, ok := strings.CutPrefix(attestor.Keys.PublicKeys, "k8s://"); ok {
+					secretNamespace, _, _ := strings.Cut(ref, "/")
+					secret(keysPath.Child("publicKeys"), secretNamespace)
+				}
+			}
+		}
+	}
+	attestors(path.Child("attestors"), iv.Attestors)
+	for i, attestation := range iv.Attestations {
+		attestors(path.Child("attestations").Index(i).Child("attestors"), attestation.Attestors)
+	}
+	// registry credentials are read by kyverno from secrets of its own namespace, they are not accessed on behalf of the author
+	return accesses
+}
+
+func kindOf(apiVersion, kind string) string {
+	if apiVersion == "" {
+		return kind
+	}
+	return apiVersion + "/" + kind
+}
+
+// validateNamespacedAccesses forbids namespaced policies to generate resources outside of their namespace,
+// image verification keys can be read from other namespaces as they always could
+func validateNamespacedAccesses(policy kyvernov1.PolicyInterface) (errs field.ErrorList) {
+	for _, access := range namespacedAccesses(policy, false) {
+		errs = append(errs, field.Forbidden(access.Path, fmt.Sprintf("a namespaced policy cannot %s %s, expected namespace: %v", strings.Join(access.Verbs, ","), access.target(), policy.GetNamespace())))
+	}
+	return errs
+}
+
+// AuthorizeNamespacedAccesses checks the user creating or updating a namespaced policy is allowed to perform
+// every access the policy delegates outside of its namespace. The granted accesses are returned so that they can
+// be reported, an error is returned for the first denied access.
+func AuthorizeNamespacedAccesses(ctx context.Context, client dclient.Interface, policy kyvernov1.PolicyInterface, user string, groups []string) ([]NamespacedAccess, error) {
+	accesses := NamespacedAccesses(policy)
+	if len(accesses) == 0 {
+		return nil, nil
+	}
+	authChecker := checker.NewSubjectChecker(client.GetKubeClient().AuthorizationV1().SubjectAccessReviews(), user, groups)
+	for _, access := range accesses {
+		group, version, kind, _ := kubeutils.ParseKindSelector(access.Kind)
+		resources, err := client.Discovery().FindResources(group, version, kind, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the resource of %s: %w", access.Path, err)
+		}
+		for resource := range resources {
+			gvr := resource.GroupVersionResource()
+			for _, verb := range access.Verbs {
+				result, err := authChecker.Check(ctx, gvr.Group, gvr.Version, gvr.Resource, "", access.Namespace, verb)
+				if err != nil {
+					return nil, fmt.Errorf("failed to check the permissions of %s: %w", user, err)
+				}
+				if !result.Allowed {
+					return nil, fmt.Errorf("user %s is not allowed to %s %s, required by %s", user, verb, access.target(), access.Path)
+				}
+			}
+		}
+	}
+	return accesses, nil
+}
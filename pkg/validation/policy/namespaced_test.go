@@ -0,0 +1,97 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+)
+
+func Test_NamespacedAccesses(t *testing.T) {
+	raw := `{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "Policy",
+		"metadata": { "name": "delegated", "namespace": "team-a" },
+		"spec": {
+			"rules": [{
+				"name": "clone-secret",
+				"match": { "any": [{ "resources": { "kinds": ["ConfigMap"] } }] },
+				"generate": {
+					"apiVersion": "v1", "kind": "Secret", "name": "regcred", "namespace": "team-b",
+					"clone": { "namespace": "team-a", "name": "regcred" }
+				}
+			}, {
+				"name": "clone-list",
+				"match": { "any": [{ "resources": { "kinds": ["ConfigMap"] } }] },
+				"generate": {
+					"namespace": "{{request.object.metadata.name}}",
+					"cloneList": { "namespace": "shared", "kinds": ["v1/ConfigMap"] }
+				}
+			}, {
+				"name": "same-namespace",
+				"match": { "any": [{ "resources": { "kinds": ["ConfigMap"] } }] },
+				"generate": {
+					"apiVersion": "v1", "kind": "ConfigMap", "name": "copy", "namespace": "team-a",
+					"clone": { "namespace": "team-a", "name": "source" }
+				}
+			}, {
+				"name": "verify-images",
+				"match": { "any": [{ "resources": { "kinds": ["Pod"] } }] },
+				"verifyImages": [{
+					"imageReferences": ["ghcr.io/team-a/*"],
+					"imageRegistryCredentials": { "secrets": ["regcred"] },
+					"attestors": [{ "entries": [
+						{ "keys": { "publicKeys": "k8s://security/cosign" } },
+						{ "keys": { "secret": { "name": "cosign", "namespace": "team-a" } } }
+					] }]
+				}]
+			}]
+		}
+	}`
+	var policy kyverno.Policy
+	assert.NilError(t, json.Unmarshal([]byte(raw), &policy))
+	var accesses []string
+	for _, access := range NamespacedAccesses(&policy) {
+		accesses = append(accesses, access.String())
+	}
+	assert.DeepEqual(t, accesses, []string{
+		"create,update,delete v1/Secret in namespace team-b (spec.rules[0].generate.namespace)",
+		"create,update,delete v1/ConfigMap in all namespaces (spec.rules[1].generate.namespace)",
+		"list v1/ConfigMap in namespace shared (spec.rules[1].generate.cloneList.namespace)",
+		"get v1/Secret in namespace security (spec.rules[3].verifyImages[0].attestors[0].entries[0].keys.publicKeys)",
+	})
+	// verifyImages keys in other namespaces are allowed without delegation
+	errs := validateNamespacedAccesses(&policy)
+	assert.Equal(t, len(errs), 3)
+	assert.Equal(t, errs[0].Error(), "spec.rules[0].generate.namespace: Forbidden: a namespaced policy cannot create,update,delete v1/Secret in namespace team-b, expected namespace: team-a")
+	assert.Equal(t, len(NamespacedAccesses(&kyverno.ClusterPolicy{Spec: policy.Spec})), 0)
+}
+
+func Test_Validate_NamespacedVerifyImages(t *testing.T) {
+	raw := `{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "Policy",
+		"metadata": { "name": "verify-images", "namespace": "team-a" },
+		"spec": {
+			"rules": [{
+				"name": "verify-images",
+				"match": { "any": [{ "resources": { "kinds": ["Pod"] } }] },
+				"verifyImages": [{
+					"imageReferences": ["ghcr.io/team-a/*"],
+					"imageRegistryCredentials": { "secrets": ["regcred"] },
+					"attestors": [{ "entries": [
+						{ "keys": { "publicKeys": "k8s://security/cosign" } },
+						{ "keys": { "secret": { "name": "cosign", "namespace": "security" } } }
+					] }]
+				}]
+			}]
+		}
+	}`
+	var policy kyverno.Policy
+	assert.NilError(t, json.Unmarshal([]byte(raw), &policy))
+	// without delegation, namespaced policies read keys and registry credentials like cluster policies
+	_, err := Validate(context.TODO(), &policy, nil, nil, true, "admin")
+	assert.NilError(t, err)
+}
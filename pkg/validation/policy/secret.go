@@ -0,0 +1,119 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// messageFields are the fields of a rule reported to users
+var messageFields = sets.New("message", "reason", "remediation")
+
+// validateDecodedDataInMessages rejects the messages of a rule referencing the decoded data of Secrets, directly or through
+// context variables and list elements. Once transformed, the decoded values can't be found and redacted from the messages.
+func validateDecodedDataInMessages(rule kyvernov1.Rule) (string, error) {
+	raw, err := json.Marshal(rule)
+	if err != nil {
+		return "", err
+	}
+	var document interface{}
+	if err := json.Unmarshal(raw, &document); err != nil {
+		return "", err
+	}
+	references := decodedDataReferences(document)
+	return findDecodedDataInMessages(document, "", references)
+}
+
+// decodedDataReferences returns a regex matching the variables referencing the decoded data of Secrets
+func decodedDataReferences(document interface{}) *regexp.Regexp {
+	names := sets.New("dataDecoded")
+	for {
+		references := referencesRegex(names)
+		count := names.Len()
+		collectDecodedDataNames(document, references, names)
+		if names.Len() == count {
+			return references
+		}
+	}
+}
+
+func referencesRegex(names sets.Set[string]) *regexp.Regexp {
+	quoted := make([]string, 0, names.Len())
+	for _, name := range sets.List(names) {
+		quoted = append(quoted, regexp.QuoteMeta(name))
+	}
+	return regexp.MustCompile(`\b(` + strings.Join(quoted, "|") + `)\b`)
+}
+
+// collectDecodedDataNames adds the context entries and list elements derived from the references to names
+func collectDecodedDataNames(document interface{}, references *regexp.Regexp, names sets.Set[string]) {
+	switch typed := document.(type) {
+	case map[string]interface{}:
+		for key, value := range typed {
+			switch key {
+			case "context":
+				entries, _ := value.([]interface{})
+				for _, entry := range entries {
+					entry, ok := entry.(map[string]interface{})
+					if !ok {
+						continue
+					}
+					name, _ := entry["name"].(string)
+					if raw, err := json.Marshal(entry); err == nil && name != "" && references.Match(raw) {
+						names.Insert(name)
+					}
+				}
+			case "list":
+				if list, ok := value.(string); ok && references.MatchString(list) {
+					names.Insert("element")
+				}
+			}
+			collectDecodedDataNames(value, references, names)
+		}
+	case []interface{}:
+		for _, value := range typed {
+			collectDecodedDataNames(value, references, names)
+		}
+	}
+}
+
+// findDecodedDataInMessages returns the path of the first message with a variable matching the references
+func findDecodedDataInMessages(document interface{}, path string, references *regexp.Regexp) (string, error) {
+	switch typed := document.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			if message, ok := typed[key].(string); ok && messageFields.Has(key) {
+				for _, match := range regex.RegexVariables.FindAllStringSubmatch(message, -1) {
+					if variable := match[2]; references.MatchString(variable) {
+						return childPath, fmt.Errorf("variable %s references the decoded data of Secrets, which can't be used in messages", variable)
+					}
+				}
+			}
+			if path, err := findDecodedDataInMessages(typed[key], childPath, references); err != nil {
+				return path, err
+			}
+		}
+	case []interface{}:
+		for i, value := range typed {
+			if path, err := findDecodedDataInMessages(value, fmt.Sprintf("%s[%d]", path, i), references); err != nil {
+				return path, err
+			}
+		}
+	}
+	return "", nil
+}
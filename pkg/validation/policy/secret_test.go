@@ -0,0 +1,159 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+)
+
+func Test_validateDecodedDataInMessages(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     string
+		wantPath string
+		wantErr  string
+	}{{
+		name: "conditions only",
+		rule: `{
+			"name": "no-private-keys",
+			"validate": {
+				"message": "Secret must not contain private keys",
+				"deny": { "conditions": { "any": [{ "key": "{{ contains(request.object.dataDecoded.key, 'PRIVATE KEY') }}", "operator": "Equals", "value": true }] } }
+			}
+		}`,
+	}, {
+		name: "encoded data",
+		rule: `{
+			"name": "check-cert",
+			"validate": {
+				"message": "certificate {{ x509_decode(base64_decode(request.object.data.cert)).Subject.CommonName }} is not allowed",
+				"deny": {}
+			}
+		}`,
+	}, {
+		name: "message",
+		rule: `{
+			"name": "no-private-keys",
+			"validate": { "message": "found {{ request.object.dataDecoded.key }}", "deny": {} }
+		}`,
+		wantPath: "validate.message",
+		wantErr:  "variable {{ request.object.dataDecoded.key }} references the decoded data of Secrets, which can't be used in messages",
+	}, {
+		name: "old object transformed with to_upper",
+		rule: `{
+			"name": "no-private-keys",
+			"validate": { "message": "found {{ to_upper(request.oldObject.dataDecoded.key) }}", "deny": {} }
+		}`,
+		wantPath: "validate.message",
+		wantErr:  "variable {{ to_upper(request.oldObject.dataDecoded.key) }} references the decoded data of Secrets, which can't be used in messages",
+	}, {
+		name: "remediation",
+		rule: `{
+			"name": "no-private-keys",
+			"remediation": "remove {{ request.object.dataDecoded.key }}",
+			"validate": { "deny": {} }
+		}`,
+		wantPath: "remediation",
+		wantErr:  "variable {{ request.object.dataDecoded.key }} references the decoded data of Secrets, which can't be used in messages",
+	}, {
+		name: "context variable",
+		rule: `{
+			"name": "check-cert",
+			"context": [
+				{ "name": "cert", "variable": { "jmesPath": "x509_decode(request.object.dataDecoded.\"tls.crt\")" } },
+				{ "name": "subject", "variable": { "jmesPath": "cert.Subject" } }
+			],
+			"validate": { "message": "subject {{ subject.CommonName }} is not allowed", "deny": {} }
+		}`,
+		wantPath: "validate.message",
+		wantErr:  "variable {{ subject.CommonName }} references the decoded data of Secrets, which can't be used in messages",
+	}, {
+		name: "unrelated context variable",
+		rule: `{
+			"name": "check-cert",
+			"context": [
+				{ "name": "cert", "variable": { "jmesPath": "x509_decode(request.object.dataDecoded.\"tls.crt\")" } },
+				{ "name": "certificates", "variable": { "value": "none" } }
+			],
+			"validate": { "message": "{{ certificates }} are allowed", "deny": {} }
+		}`,
+	}, {
+		name: "violations",
+		rule: `{
+			"name": "strong-secrets",
+			"validate": {
+				"deny": {
+					"violations": {
+						"list": "values(request.object.dataDecoded)",
+						"conditions": { "any": [{ "key": "{{ length(element) }}", "operator": "LessThan", "value": 8 }] },
+						"reason": "value {{ element }} is too weak"
+					}
+				}
+			}
+		}`,
+		wantPath: "validate.deny.violations.reason",
+		wantErr:  "variable {{ element }} references the decoded data of Secrets, which can't be used in messages",
+	}, {
+		name: "violations index",
+		rule: `{
+			"name": "strong-secrets",
+			"validate": {
+				"deny": {
+					"violations": {
+						"list": "values(request.object.dataDecoded)",
+						"conditions": { "any": [{ "key": "{{ length(element) }}", "operator": "LessThan", "value": 8 }] },
+						"reason": "value {{ elementIndex }} is too weak"
+					}
+				}
+			}
+		}`,
+	}, {
+		name: "condition message",
+		rule: `{
+			"name": "no-private-keys",
+			"validate": {
+				"deny": { "conditions": { "any": [{ "key": "{{ request.object.dataDecoded.key }}", "operator": "Equals", "value": "", "message": "key {{ request.object.dataDecoded.key }} is set" }] } }
+			}
+		}`,
+		wantPath: "validate.deny.conditions.any[0].message",
+		wantErr:  "variable {{ request.object.dataDecoded.key }} references the decoded data of Secrets, which can't be used in messages",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rule kyverno.Rule
+			assert.NilError(t, json.Unmarshal([]byte(tt.rule), &rule))
+			path, err := validateDecodedDataInMessages(rule)
+			if tt.wantErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.wantErr)
+				assert.Equal(t, path, tt.wantPath)
+			}
+		})
+	}
+}
+
+func Test_Validate_DecodedDataInMessages(t *testing.T) {
+	raw := `{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": { "name": "no-private-keys" },
+		"spec": {
+			"rules": [{
+				"name": "no-private-keys",
+				"match": { "any": [{ "resources": { "kinds": ["Secret"] } }] },
+				"validate": {
+					"message": "found {{ request.object.dataDecoded.key }}",
+					"deny": { "conditions": { "any": [{ "key": "{{ contains(request.object.dataDecoded.key, 'PRIVATE KEY') }}", "operator": "Equals", "value": true }] } }
+				}
+			}]
+		}
+	}`
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal([]byte(raw), &policy))
+	_, err := Validate(context.TODO(), &policy, nil, nil, true, "")
+	assert.Error(t, err, "path: spec.rules[0].validate.message: variable {{ request.object.dataDecoded.key }} references the decoded data of Secrets, which can't be used in messages")
+}
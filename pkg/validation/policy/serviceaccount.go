@@ -0,0 +1,70 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/auth"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// validateServiceAccount checks the background controller is allowed to impersonate the service account of the policy
+func validateServiceAccount(ctx context.Context, client dclient.Interface, policy kyvernov1.PolicyInterface, username string) error {
+	serviceAccount := policy.GetSpec().ServiceAccount
+	if serviceAccount == nil {
+		return nil
+	}
+	namespace := serviceAccount.GetNamespace(policy.GetNamespace())
+	checker := auth.NewCanI(client.Discovery(), client.GetKubeClient().AuthorizationV1().SubjectAccessReviews(), "v1/ServiceAccount", namespace, "impersonate", "", username)
+	allowed, _, err := checker.RunAccessCheck(ctx)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return fmt.Errorf("%s does not have permissions to 'impersonate' the service account %s/%s. Grant proper permissions to the background controller", username, namespace, serviceAccount.Name)
+	}
+	return nil
+}
+
+// actionsUsername returns the user applying the actions of a rule, the policy service account when one is configured
+func actionsUsername(policy kyvernov1.PolicyInterface, username string) string {
+	serviceAccount := policy.GetSpec().ServiceAccount
+	if serviceAccount == nil {
+		return username
+	}
+	return serviceAccount.Username(policy.GetNamespace())
+}
+
+// AuthorizeServiceAccount checks the user creating or updating a policy is allowed to impersonate the service account
+// of the policy, otherwise the policy would let the user act with the permissions of any service account
+func AuthorizeServiceAccount(ctx context.Context, client dclient.Interface, policy kyvernov1.PolicyInterface, user string, groups []string) error {
+	serviceAccount := policy.GetSpec().ServiceAccount
+	if serviceAccount == nil {
+		return nil
+	}
+	namespace := serviceAccount.GetNamespace(policy.GetNamespace())
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Version:   "v1",
+				Resource:  "serviceaccounts",
+				Namespace: namespace,
+				Name:      serviceAccount.Name,
+				Verb:      "impersonate",
+			},
+			User:   user,
+			Groups: groups,
+		},
+	}
+	resp, err := client.GetKubeClient().AuthorizationV1().SubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to check the permissions of %s: %w", user, err)
+	}
+	if !resp.Status.Allowed {
+		return fmt.Errorf("user %s is not allowed to impersonate the service account %s/%s, required by spec.serviceAccount", user, namespace, serviceAccount.Name)
+	}
+	return nil
+}
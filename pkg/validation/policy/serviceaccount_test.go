@@ -0,0 +1,51 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"gotest.tools/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	kubetesting "k8s.io/client-go/testing"
+)
+
+func Test_actionsUsername(t *testing.T) {
+	username := "system:serviceaccount:kyverno:kyverno-background-controller"
+	policy := &kyverno.Policy{}
+	policy.SetNamespace("team-a")
+	assert.Equal(t, actionsUsername(policy, username), username)
+
+	policy.Spec.ServiceAccount = &kyverno.ServiceAccountReference{Name: "generator"}
+	assert.Equal(t, actionsUsername(policy, username), "system:serviceaccount:team-a:generator")
+}
+
+func Test_AuthorizeServiceAccount(t *testing.T) {
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), nil)
+	assert.NilError(t, err)
+	client.GetKubeClient().(*kubefake.Clientset).PrependReactor("create", "subjectaccessreviews", func(action kubetesting.Action) (bool, runtime.Object, error) {
+		review := action.(kubetesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		attributes := review.Spec.ResourceAttributes
+		assert.Equal(t, attributes.Resource, "serviceaccounts")
+		assert.Equal(t, attributes.Verb, "impersonate")
+		assert.Equal(t, attributes.Namespace, "team-a")
+		review.Status.Allowed = review.Spec.User == "team-a-admin" && attributes.Name == "generator"
+		return true, review, nil
+	})
+
+	policy := &kyverno.Policy{}
+	policy.SetNamespace("team-a")
+	assert.NilError(t, AuthorizeServiceAccount(context.TODO(), client, policy, "developer", nil))
+
+	policy.Spec.ServiceAccount = &kyverno.ServiceAccountReference{Name: "generator"}
+	assert.NilError(t, AuthorizeServiceAccount(context.TODO(), client, policy, "team-a-admin", nil))
+	assert.Error(t, AuthorizeServiceAccount(context.TODO(), client, policy, "developer", nil),
+		"user developer is not allowed to impersonate the service account team-a/generator, required by spec.serviceAccount")
+
+	policy.Spec.ServiceAccount.Name = "admin"
+	assert.Error(t, AuthorizeServiceAccount(context.TODO(), client, policy, "team-a-admin", nil),
+		"user team-a-admin is not allowed to impersonate the service account team-a/admin, required by spec.serviceAccount")
+}
@@ -1,6 +1,7 @@
 package policy
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -20,12 +21,15 @@ import (
 	"github.com/kyverno/kyverno/pkg/autogen"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/context/loaders"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
 	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/toggle"
 	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -115,14 +119,62 @@ func checkValidationFailureAction(spec *kyvernov1.Spec) []string {
 	return nil
 }
 
+// checkSchedule warns when the policy schedule has no effect as the policy never enforces validation failures
+func checkSchedule(spec *kyvernov1.Spec) []string {
+	if spec.Schedule == nil || spec.ValidationFailureAction.Enforce() {
+		return nil
+	}
+	for _, override := range spec.ValidationFailureActionOverrides {
+		if override.Action.Enforce() {
+			return nil
+		}
+	}
+	return []string{"The policy schedule has no effect, validation failures are only enforced when the validation failure action is Enforce."}
+}
+
+// validateOrdering checks the policy doesn't reference itself in its ordering and that namespaced policies
+// only reference cluster policies and policies of their namespace
+func validateOrdering(policy kyvernov1.PolicyInterface, path *field.Path) (errs field.ErrorList) {
+	ordering := policy.GetSpec().Ordering
+	if ordering == nil {
+		return nil
+	}
+	key := policyutils.Key(policy)
+	check := func(path *field.Path, references []string) {
+		for i, reference := range references {
+			if reference == key {
+				errs = append(errs, field.Invalid(path.Index(i), reference, "policy can't be ordered relative to itself"))
+			} else if namespace, _, ok := strings.Cut(reference, "/"); ok && policy.IsNamespaced() && namespace != policy.GetNamespace() {
+				errs = append(errs, field.Invalid(path.Index(i), reference, "policy can only be ordered relative to cluster policies and policies in the same namespace"))
+			}
+		}
+	}
+	check(path.Child("before"), ordering.Before)
+	check(path.Child("after"), ordering.After)
+	return errs
+}
+
+// validateParameters checks the schema of the policy parameters is a valid OpenAPI v3 schema
+func validateParameters(policy kyvernov1.PolicyInterface, path *field.Path) (errs field.ErrorList) {
+	parameters := policy.GetSpec().Parameters
+	if parameters == nil || parameters.Schema == nil {
+		return nil
+	}
+	if _, err := loaders.NewParametersValidator(parameters.Schema); err != nil {
+		errs = append(errs, field.Invalid(path.Child("schema"), string(parameters.Schema.Raw), err.Error()))
+	}
+	return errs
+}
+
 // Validate checks the policy and rules declarations for required configurations
-func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interface, mock bool, username string) ([]string, error) {
+func Validate(ctx context.Context, policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interface, mock bool, username string) ([]string, error) {
 	var warnings []string
 	spec := policy.GetSpec()
 	background := spec.BackgroundProcessingEnabled()
 	mutateExistingOnPolicyUpdate := spec.GetMutateExistingOnPolicyUpdate()
 
 	warnings = append(warnings, checkValidationFailureAction(spec)...)
+	warnings = append(warnings, checkSchedule(spec)...)
 	var errs field.ErrorList
 	specPath := field.NewPath("spec")
 
@@ -189,12 +241,31 @@ func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interf
 		}
 	}
 
+	if !mock {
+		if err := validateServiceAccount(ctx, client, policy, username); err != nil {
+			return warnings, err
+		}
+	}
+
 	if !policy.IsNamespaced() {
 		err := validateNamespaces(spec, specPath.Child("validationFailureActionOverrides"))
 		if err != nil {
 			return warnings, err
 		}
 	}
+	if errs := validateOrdering(policy, specPath.Child("ordering")); len(errs) != 0 {
+		return warnings, errs.ToAggregate()
+	}
+	if errs := validateParameters(policy, specPath.Child("parameters")); len(errs) != 0 {
+		return warnings, errs.ToAggregate()
+	}
+	// namespaced policies access resources outside of their namespace only when delegation is enabled,
+	// the permissions of the policy author are then checked at admission
+	if !toggle.FromContext(ctx).NamespacedPolicyDelegation() {
+		if errs := validateNamespacedAccesses(policy); len(errs) != 0 {
+			return warnings, errs.ToAggregate()
+		}
+	}
 	if !policy.AdmissionProcessingEnabled() && !policy.BackgroundProcessingEnabled() {
 		return warnings, fmt.Errorf("disabling both admission and background processing is not allowed")
 	}
@@ -208,7 +279,13 @@ func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interf
 		return warnings, err
 	}
 
-	rules := autogen.ComputeRules(policy)
+	if _, err := autogen.ExpandConditionTemplates(spec); err != nil {
+		return warnings, err
+	}
+
+	// the rules autogen generates for the custom pod controllers of the configuration only differ from the
+	// built-in ones by their kinds, which are not required to be installed, they are left out of the validation
+	rules := autogen.ComputeRules(policy, nil)
 	rulesPath := specPath.Child("rules")
 
 	for i, rule := range rules {
@@ -253,6 +330,15 @@ func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interf
 		if err := validateJSONPatch(rule.Mutation.PatchesJSON6902, i); err != nil {
 			return warnings, fmt.Errorf("%s", err)
 		}
+		if spec.GetReinvocationPolicy() == kyvernov1.IfNeededReinvocationPolicy {
+			if err := validateIdempotency(rule, i); err != nil {
+				// policies were reinvoked before the reinvocation policy could be set, they are still accepted
+				if spec.ReinvocationPolicy != nil {
+					return warnings, err
+				}
+				warnings = append(warnings, err.Error())
+			}
+		}
 
 		if jsonPatchOnPod(rule) {
 			msg := "Pods managed by workload controllers should not be directly mutated using policies. " +
@@ -279,6 +365,10 @@ func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interf
 			return warnings, fmt.Errorf("path: spec.rules[%d]: %v", i, err)
 		}
 
+		if path, err := validateDecodedDataInMessages(rule); err != nil {
+			return warnings, fmt.Errorf("path: spec.rules[%d].%s: %v", i, path, err)
+		}
+
 		// If a rule's match block does not match any kind,
 		// we should only allow it to have metadata in its overlay
 		if len(rule.MatchResources.Any) > 0 {
@@ -299,7 +389,7 @@ func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interf
 			}
 		}
 
-		msg, err := validateActions(i, &rules[i], client, mock, username)
+		msg, err := validateActions(i, &rules[i], client, mock, actionsUsername(policy, username))
 		if err != nil {
 			return warnings, err
 		} else {
@@ -380,6 +470,8 @@ func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interf
 			checkForStatusSubresource(mutationJson, allKinds, &warnings)
 		}
 
+		checkForScaleSelector(match, &warnings)
+
 		if rule.HasVerifyImages() {
 			checkForDeprecatedFieldsInVerifyImages(rule, &warnings)
 		}
@@ -405,7 +497,7 @@ func ValidateVariables(p kyvernov1.PolicyInterface, backgroundMode bool) error {
 
 // hasInvalidVariables - checks for unexpected variables in the policy
 func hasInvalidVariables(policy kyvernov1.PolicyInterface, background bool) error {
-	for _, r := range autogen.ComputeRules(policy) {
+	for _, r := range autogen.ComputeRules(policy, nil) {
 		ruleCopy := r.DeepCopy()
 
 		if err := ruleForbiddenSectionsHaveVariables(ruleCopy); err != nil {
@@ -426,6 +518,7 @@ func hasInvalidVariables(policy kyvernov1.PolicyInterface, background bool) erro
 			for i := range ruleCopy.Mutation.Targets {
 				withTargetOnly.Mutation.Targets[i].ResourceSpec = ruleCopy.Mutation.Targets[i].ResourceSpec
 				ctx := buildContext(withTargetOnly, background, false)
+				addParametersVariable(policy, ctx)
 				if _, err := variables.SubstituteAllInRule(logging.GlobalLogger(), ctx, *withTargetOnly); !variables.CheckNotFoundErr(err) {
 					return fmt.Errorf("invalid variables defined at mutate.targets[%d]: %s", i, err.Error())
 				}
@@ -433,6 +526,7 @@ func hasInvalidVariables(policy kyvernov1.PolicyInterface, background bool) erro
 		}
 
 		ctx := buildContext(ruleCopy, background, mutateTarget)
+		addParametersVariable(policy, ctx)
 		if _, err := variables.SubstituteAllInRule(logging.GlobalLogger(), ctx, *ruleCopy); !variables.CheckNotFoundErr(err) {
 			return fmt.Errorf("variable substitution failed for rule %s: %s", ruleCopy.Name, err.Error())
 		}
@@ -601,6 +695,13 @@ func buildContext(rule *kyvernov1.Rule, background bool, target bool) *enginecon
 	return ctx
 }
 
+// addParametersVariable allows the rules of a policy declaring parameters to reference the parameter resource
+func addParametersVariable(policy kyvernov1.PolicyInterface, ctx *enginecontext.MockContext) {
+	if policy.GetSpec().Parameters != nil {
+		ctx.AddVariable(loaders.ParametersVariable + "*")
+	}
+}
+
 func getAllowedVariables(background bool, target bool) *regexp.Regexp {
 	if target {
 		if background {
@@ -617,7 +718,7 @@ func getAllowedVariables(background bool, target bool) *regexp.Regexp {
 
 func addContextVariables(entries []kyvernov1.ContextEntry, ctx *enginecontext.MockContext) {
 	for _, contextEntry := range entries {
-		if contextEntry.APICall != nil || contextEntry.ImageRegistry != nil || contextEntry.Variable != nil {
+		if contextEntry.APICall != nil || contextEntry.ImageRegistry != nil || contextEntry.Variable != nil || contextEntry.RelatedResources != nil {
 			ctx.AddVariable(contextEntry.Name + "*")
 		}
 
@@ -853,6 +954,13 @@ func validateResources(path *field.Path, rule kyvernov1.Rule) (string, error) {
 				return fmt.Sprintf("validate.deny.%s", path), err
 			}
 		}
+		if violations := rule.Validation.Deny.Violations; violations != nil {
+			if target := violations.GetAnyAllConditions(); target != nil {
+				if path, err := validateConditions(target, "conditions"); err != nil {
+					return fmt.Sprintf("validate.deny.violations.%s", path), err
+				}
+			}
+		}
 	}
 	return "", nil
 }
@@ -982,16 +1090,18 @@ func validateRuleContext(rule kyvernov1.Rule) error {
 		}
 
 		var err error
-		if entry.ConfigMap != nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable == nil {
+		if entry.ConfigMap != nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable == nil && entry.RelatedResources == nil {
 			err = validateConfigMap(entry)
-		} else if entry.ConfigMap == nil && entry.APICall != nil && entry.ImageRegistry == nil && entry.Variable == nil {
+		} else if entry.ConfigMap == nil && entry.APICall != nil && entry.ImageRegistry == nil && entry.Variable == nil && entry.RelatedResources == nil {
 			err = validateAPICall(entry)
-		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.ImageRegistry != nil && entry.Variable == nil {
+		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.ImageRegistry != nil && entry.Variable == nil && entry.RelatedResources == nil {
 			err = validateImageRegistry(entry)
-		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable != nil {
+		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable != nil && entry.RelatedResources == nil {
 			err = validateVariable(entry)
+		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.ImageRegistry == nil && entry.Variable == nil && entry.RelatedResources != nil {
+			err = validateRelatedResources(entry)
 		} else {
-			return fmt.Errorf("exactly one of configMap or apiCall or imageRegistry or variable is required for context entries")
+			return fmt.Errorf("exactly one of configMap or apiCall or imageRegistry or variable or relatedResources is required for context entries")
 		}
 
 		if err != nil {
@@ -1058,6 +1168,28 @@ func validateVariable(entry kyvernov1.ContextEntry) error {
 	return nil
 }
 
+func validateRelatedResources(entry kyvernov1.ContextEntry) error {
+	if entry.RelatedResources.APIVersion == "" || entry.RelatedResources.Kind == "" {
+		return fmt.Errorf("an apiVersion and a kind are required for relatedResources context entry")
+	}
+	if entry.RelatedResources.SelectorPath != "" && entry.RelatedResources.Labels == nil {
+		return fmt.Errorf("labels are required when a selectorPath is defined for relatedResources context entry")
+	}
+	if entry.RelatedResources.SelectorPath == "" && entry.RelatedResources.Labels != nil {
+		return fmt.Errorf("a selectorPath is required when labels are defined for relatedResources context entry")
+	}
+	// If JMESPath contains variables, the validation will fail because it's not possible to infer which value
+	// will be inserted by the variable
+	// Skip validation if a variable is detected
+	jmesPath := variables.ReplaceAllVars(entry.RelatedResources.JMESPath, func(s string) string { return "kyvernojmespathvariable" })
+	if !strings.Contains(jmesPath, "kyvernojmespathvariable") && entry.RelatedResources.JMESPath != "" {
+		if _, err := jmespath.NewParser().Parse(entry.RelatedResources.JMESPath); err != nil {
+			return fmt.Errorf("failed to parse JMESPath %s: %v", entry.RelatedResources.JMESPath, err)
+		}
+	}
+	return nil
+}
+
 func validateConfigMap(entry kyvernov1.ContextEntry) error {
 	if entry.ConfigMap.Name == "" {
 		return fmt.Errorf("a name is required for configMap context entry")
@@ -1341,6 +1473,25 @@ func checkForStatusSubresource(ruleTypeJson []byte, allKinds []string, warnings
 	}
 }
 
+// checkForScaleSelector warns about label selectors matching the scale subresource,
+// a Scale object doesn't carry the labels of its parent resource.
+func checkForScaleSelector(match kyvernov1.MatchResources, warnings *[]string) {
+	filters := append(kyvernov1.ResourceFilters{{ResourceDescription: match.ResourceDescription}}, match.Any...)
+	filters = append(filters, match.All...)
+	for _, filter := range filters {
+		if filter.Selector == nil {
+			continue
+		}
+		for _, kind := range filter.Kinds {
+			if _, _, _, subresource := kubeutils.ParseKindSelector(kind); subresource == "scale" {
+				msg := "You are matching the scale subresource with a label selector, Scale objects don't carry the labels of their parent resource."
+				*warnings = append(*warnings, msg)
+				return
+			}
+		}
+	}
+}
+
 func checkForDeprecatedFieldsInVerifyImages(rule kyvernov1.Rule, warnings *[]string) {
 	for _, imageVerify := range rule.VerifyImages {
 		for _, attestation := range imageVerify.Attestations {
@@ -10,6 +10,11 @@ import (
 )
 
 func ValidateTtlLabel(_ context.Context, object metav1.Object) error {
+	if expiresAt, ok := object.GetAnnotations()[kyverno.AnnotationCleanupExpiresAt]; ok {
+		if _, err := time.Parse(time.RFC3339, expiresAt); err != nil {
+			return err
+		}
+	}
 	labels := object.GetLabels()
 	if labels == nil {
 		return nil
@@ -53,3 +53,13 @@ func Test_ValidateTTL(t *testing.T) {
 	err = ValidateTtlLabel(ctx, metadata)
 	assert.NilError(t, err)
 }
+
+func Test_ValidateTTL_ExpiresAt(t *testing.T) {
+	object := &metav1.ObjectMeta{
+		Labels:      map[string]string{"cleanup.kyverno.io/ttl": "1d"},
+		Annotations: map[string]string{"cleanup.kyverno.io/expires-at": "2023-07-20T08:30:00Z"},
+	}
+	assert.NilError(t, ValidateTtlLabel(ctx, object))
+	object.Annotations["cleanup.kyverno.io/expires-at"] = "tomorrow"
+	assert.Assert(t, ValidateTtlLabel(ctx, object) != nil)
+}
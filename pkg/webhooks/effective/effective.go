@@ -0,0 +1,355 @@
+package effective
+
+import (
+	"sort"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/ext/wildcard"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	matchutils "github.com/kyverno/kyverno/pkg/utils/match"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// FilteredByResourceFilters is set when the namespace is filtered by the resource filters of the configuration
+	FilteredByResourceFilters = "resourceFilters"
+	// FilteredByWebhooks is set when the namespace is not selected by the webhooks namespace selector of the configuration
+	FilteredByWebhooks = "webhooks"
+	// ProfileSourceNamespace is the source of the profiles set by the pod security admission labels of the namespace
+	ProfileSourceNamespace = "namespace"
+)
+
+// podSecurityModes are the pod security admission modes, each one is configured by a namespace label.
+var podSecurityModes = []string{"enforce", "audit", "warn"}
+
+// Namespace lists the policies and rules in effect in a namespace.
+type Namespace struct {
+	// Namespace is the name of the namespace.
+	Namespace string `json:"namespace"`
+	// Filtered is set when admission requests in the namespace are not processed because of the configuration,
+	// the value is either resourceFilters or webhooks.
+	Filtered string `json:"filtered,omitempty"`
+	// Policies are the policies having at least one rule in effect in the namespace.
+	Policies []Policy `json:"policies,omitempty"`
+	// Profiles are the pod security profiles applying to the pods of the namespace.
+	Profiles []Profile `json:"profiles,omitempty"`
+}
+
+// Profile is a pod security profile applying to the pods of a namespace.
+type Profile struct {
+	// Source is either namespace, for the pod security admission labels of the namespace,
+	// or the policy rule enforcing the profile as ClusterPolicy/name/rule or Policy/namespace/name/rule.
+	Source string `json:"source"`
+	// Mode is the pod security admission mode (enforce, audit or warn) for the namespace labels,
+	// or the validation failure action of the policy in the namespace.
+	Mode string `json:"mode"`
+	PodSecurity
+}
+
+// Policy lists the rules of a policy in effect in a namespace.
+type Policy struct {
+	// Kind is either ClusterPolicy or Policy.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the policy, empty for a ClusterPolicy.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the policy.
+	Name string `json:"name"`
+	// ValidationFailureAction is the action applied to validation failures in the namespace,
+	// taking validationFailureActionOverrides into account.
+	ValidationFailureAction kyvernov1.ValidationFailureAction `json:"validationFailureAction,omitempty"`
+	// Admission is set when the policy applies to admission requests.
+	Admission bool `json:"admission"`
+	// Background is set when the policy applies to existing resources.
+	Background bool `json:"background"`
+	// Rules are the rules in effect in the namespace, including the auto generated ones.
+	Rules []Rule `json:"rules"`
+}
+
+// Rule describes a rule in effect in a namespace.
+type Rule struct {
+	// Name is the name of the rule.
+	Name string `json:"name"`
+	// Type is the type of the rule (Mutation, Validation, Generation or ImageVerify).
+	Type engineapi.RuleType `json:"type"`
+	// Kinds are the kinds matched by the rule.
+	Kinds []string `json:"kinds,omitempty"`
+	// PodSecurity is the pod security profile enforced by the rule.
+	PodSecurity *PodSecurity `json:"podSecurity,omitempty"`
+	// Exceptions are the policy exceptions, as namespace/name, which may exempt resources of the namespace from the rule.
+	Exceptions []string `json:"exceptions,omitempty"`
+}
+
+// PodSecurity is a pod security profile.
+type PodSecurity struct {
+	Level   string `json:"level"`
+	Version string `json:"version,omitempty"`
+}
+
+// Compute returns the policies and rules in effect in a namespace.
+// A rule is in effect if its match statement can select resources in the namespace and its exclude
+// statement doesn't exclude the whole namespace. Conditions depending on the resources or on the user
+// sending the request can't be evaluated and are not taken into account.
+// Policy schedules and exception expirations are evaluated at now, schedules are compiled with the given cache.
+func Compute(
+	namespace *corev1.Namespace,
+	configuration config.Configuration,
+	policies []kyvernov1.PolicyInterface,
+	exceptions []*kyvernov2.PolicyException,
+	schedules *engineapi.ScheduleCache,
+	now time.Time,
+) Namespace {
+	result := Namespace{
+		Namespace: namespace.GetName(),
+		Profiles:  namespaceProfiles(namespace),
+	}
+	if configuration != nil {
+		if configuration.ToFilter(schema.GroupVersionKind{Group: "*", Version: "*", Kind: "*"}, "", namespace.GetName(), "*") {
+			result.Filtered = FilteredByResourceFilters
+		} else {
+			for _, webhook := range configuration.GetWebhooks() {
+				if webhook.NamespaceSelector == nil {
+					continue
+				}
+				if matches, err := matchutils.CheckSelector(webhook.NamespaceSelector, namespace.GetLabels()); err != nil || !matches {
+					result.Filtered = FilteredByWebhooks
+					break
+				}
+			}
+		}
+	}
+	if configuration != nil && configuration.GetExceptionApproval().Enabled {
+		// exceptions waiting for approval don't apply, nor exceptions in namespaces where the approval isn't enforced
+		var approved []*kyvernov2.PolicyException
+		for _, exception := range exceptions {
+			if exception.IsApproved() && config.IsExceptionApprovalEnforced(configuration, exception.GetNamespace()) {
+				approved = append(approved, exception)
+			}
+		}
+		exceptions = approved
+	}
+	var autogenControllers []config.AutogenController
+	if configuration != nil {
+		autogenControllers = configuration.GetAutogenControllers()
+	}
+	for _, policy := range policies {
+		if policy.IsNamespaced() && policy.GetNamespace() != namespace.GetName() {
+			continue
+		}
+		key, err := cache.MetaNamespaceKeyFunc(policy)
+		if err != nil {
+			continue
+		}
+		var rules []Rule
+		for _, rule := range autogen.ComputeRules(policy, autogenControllers) {
+			if !matchesNamespace(rule.MatchResources, namespace) || excludesNamespace(rule.ExcludeResources, namespace) {
+				continue
+			}
+			rules = append(rules, newRule(rule, key, namespace, exceptions, now))
+		}
+		if len(rules) == 0 {
+			continue
+		}
+		spec := policy.GetSpec()
+		kind := "ClusterPolicy"
+		if policy.IsNamespaced() {
+			kind = "Policy"
+		}
+		result.Policies = append(result.Policies, Policy{
+			Kind:                    kind,
+			Namespace:               policy.GetNamespace(),
+			Name:                    policy.GetName(),
+			ValidationFailureAction: validationFailureAction(spec, namespace, schedules.IsActive(policy, now)),
+			Admission:               spec.AdmissionProcessingEnabled(),
+			Background:              spec.BackgroundProcessingEnabled(),
+			Rules:                   rules,
+		})
+	}
+	sort.Slice(result.Policies, func(i, j int) bool {
+		if result.Policies[i].Namespace != result.Policies[j].Namespace {
+			return result.Policies[i].Namespace < result.Policies[j].Namespace
+		}
+		return result.Policies[i].Name < result.Policies[j].Name
+	})
+	for _, policy := range result.Policies {
+		source := policy.Kind + "/"
+		if policy.Namespace != "" {
+			source += policy.Namespace + "/"
+		}
+		for _, rule := range policy.Rules {
+			if rule.PodSecurity == nil {
+				continue
+			}
+			result.Profiles = append(result.Profiles, Profile{
+				Source:      source + policy.Name + "/" + rule.Name,
+				Mode:        string(policy.ValidationFailureAction),
+				PodSecurity: *rule.PodSecurity,
+			})
+		}
+	}
+	return result
+}
+
+// namespaceProfiles returns the profiles set by the pod security admission labels of the namespace.
+func namespaceProfiles(namespace *corev1.Namespace) []Profile {
+	var profiles []Profile
+	labels := namespace.GetLabels()
+	for _, mode := range podSecurityModes {
+		level, ok := labels["pod-security.kubernetes.io/"+mode]
+		if !ok {
+			continue
+		}
+		profiles = append(profiles, Profile{
+			Source: ProfileSourceNamespace,
+			Mode:   mode,
+			PodSecurity: PodSecurity{
+				Level:   level,
+				Version: labels["pod-security.kubernetes.io/"+mode+"-version"],
+			},
+		})
+	}
+	return profiles
+}
+
+func newRule(rule kyvernov1.Rule, policy string, namespace *corev1.Namespace, exceptions []*kyvernov2.PolicyException, now time.Time) Rule {
+	out := Rule{
+		Name:  rule.Name,
+		Type:  ruleType(rule),
+		Kinds: rule.MatchResources.GetKinds(),
+	}
+	if rule.Validation.PodSecurity != nil {
+		out.PodSecurity = &PodSecurity{
+			Level:   string(rule.Validation.PodSecurity.Level),
+			Version: rule.Validation.PodSecurity.Version,
+		}
+	}
+	for _, exception := range exceptions {
+		if exception.IsExpired(now) {
+			continue
+		}
+		if exception.Contains(policy, rule.Name) && matchesFilters(exception.Spec.Match.Any, exception.Spec.Match.All, namespace) {
+			out.Exceptions = append(out.Exceptions, exception.GetNamespace()+"/"+exception.GetName())
+		}
+	}
+	return out
+}
+
+func ruleType(rule kyvernov1.Rule) engineapi.RuleType {
+	switch {
+	case rule.HasMutate():
+		return engineapi.Mutation
+	case rule.HasGenerate():
+		return engineapi.Generation
+	case rule.HasVerifyImages():
+		return engineapi.ImageVerify
+	default:
+		return engineapi.Validation
+	}
+}
+
+// validationFailureAction returns the validation failure action of the policy in the namespace,
+// Audit outside of the policy schedule windows.
+func validationFailureAction(spec *kyvernov1.Spec, namespace *corev1.Namespace, scheduleActive bool) kyvernov1.ValidationFailureAction {
+	if !scheduleActive {
+		return kyvernov1.Audit
+	}
+	for _, override := range spec.ValidationFailureActionOverrides {
+		if !override.Action.IsValid() {
+			continue
+		}
+		if len(override.Namespaces) != 0 && !wildcard.CheckPatterns(override.Namespaces, namespace.GetName()) {
+			continue
+		}
+		if override.NamespaceSelector != nil {
+			if matches, err := matchutils.CheckSelector(override.NamespaceSelector, namespace.GetLabels()); err != nil || !matches {
+				continue
+			}
+		} else if len(override.Namespaces) == 0 {
+			continue
+		}
+		return override.Action
+	}
+	return spec.ValidationFailureAction
+}
+
+func matchesNamespace(match kyvernov1.MatchResources, namespace *corev1.Namespace) bool {
+	if len(match.Any) == 0 && len(match.All) == 0 {
+		return matchesDescription(match.ResourceDescription, namespace)
+	}
+	return matchesFilters(match.Any, match.All, namespace)
+}
+
+func matchesFilters(anyFilters kyvernov1.ResourceFilters, allFilters kyvernov1.ResourceFilters, namespace *corev1.Namespace) bool {
+	if len(anyFilters) != 0 {
+		for _, filter := range anyFilters {
+			if matchesDescription(filter.ResourceDescription, namespace) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, filter := range allFilters {
+		if !matchesDescription(filter.ResourceDescription, namespace) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesDescription returns true if the resource description can select resources in the namespace.
+func matchesDescription(description kyvernov1.ResourceDescription, namespace *corev1.Namespace) bool {
+	if len(description.Namespaces) != 0 && !wildcard.CheckPatterns(description.Namespaces, namespace.GetName()) {
+		return false
+	}
+	if description.NamespaceSelector != nil {
+		if matches, err := matchutils.CheckSelector(description.NamespaceSelector, namespace.GetLabels()); err != nil || !matches {
+			return false
+		}
+	}
+	return true
+}
+
+// excludesNamespace returns true if the exclude statement excludes every resource of the namespace.
+func excludesNamespace(exclude kyvernov1.MatchResources, namespace *corev1.Namespace) bool {
+	if len(exclude.Any) == 0 && len(exclude.All) == 0 {
+		return excludesDescription(exclude.UserInfo, exclude.ResourceDescription, namespace)
+	}
+	for _, filter := range exclude.Any {
+		if excludesDescription(filter.UserInfo, filter.ResourceDescription, namespace) {
+			return true
+		}
+	}
+	if len(exclude.All) == 0 {
+		return false
+	}
+	for _, filter := range exclude.All {
+		if !excludesDescription(filter.UserInfo, filter.ResourceDescription, namespace) {
+			return false
+		}
+	}
+	return true
+}
+
+// excludesDescription returns true if the resource description only selects namespaces, and selects the namespace.
+func excludesDescription(userInfo kyvernov1.UserInfo, description kyvernov1.ResourceDescription, namespace *corev1.Namespace) bool {
+	if !userInfo.IsEmpty() {
+		return false
+	}
+	if len(description.Namespaces) == 0 && description.NamespaceSelector == nil {
+		return false
+	}
+	for _, kind := range description.Kinds {
+		if kind != "*" {
+			return false
+		}
+	}
+	if description.Name != "" || len(description.Names) != 0 || len(description.Annotations) != 0 || description.Selector != nil || description.FieldSelector != "" || description.Owner != nil || len(description.Operations) != 0 {
+		return false
+	}
+	return matchesDescription(description, namespace)
+}
@@ -0,0 +1,151 @@
+package effective
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const clusterPolicy = `{
+  "apiVersion": "kyverno.io/v1",
+  "kind": "ClusterPolicy",
+  "metadata": {
+    "name": "pod-security",
+    "annotations": {
+      "pod-policies.kyverno.io/autogen-controllers": "none"
+    }
+  },
+  "spec": {
+    "validationFailureAction": "Audit",
+    "validationFailureActionOverrides": [
+      { "action": "Enforce", "namespaces": ["prod-*"] }
+    ],
+    "rules": [
+      {
+        "name": "restricted",
+        "match": { "any": [{ "resources": { "kinds": ["Pod"] } }] },
+        "exclude": { "any": [{ "resources": { "namespaces": ["kube-system"] } }] },
+        "validate": { "podSecurity": { "level": "restricted", "version": "latest" } }
+      },
+      {
+        "name": "team-labels",
+        "match": { "any": [{ "resources": { "kinds": ["ConfigMap"], "namespaceSelector": { "matchLabels": { "team": "payments" } } } }] },
+        "validate": { "pattern": { "metadata": { "labels": { "app": "?*" } } } }
+      },
+      {
+        "name": "kube-system-services",
+        "match": { "any": [{ "resources": { "kinds": ["Service"], "namespaces": ["kube-system"] } }] },
+        "validate": { "pattern": { "metadata": { "labels": { "app": "?*" } } } }
+      }
+    ]
+  }
+}`
+
+const namespacedPolicy = `{
+  "apiVersion": "kyverno.io/v1",
+  "kind": "Policy",
+  "metadata": {
+    "name": "add-labels",
+    "namespace": "prod-payments"
+  },
+  "spec": {
+    "rules": [
+      {
+        "name": "add-team",
+        "match": { "any": [{ "resources": { "kinds": ["Secret"] } }] },
+        "mutate": { "patchStrategicMerge": { "metadata": { "labels": { "team": "payments" } } } }
+      }
+    ]
+  }
+}`
+
+func newNamespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func loadPolicies(t *testing.T) []kyvernov1.PolicyInterface {
+	var cpol kyvernov1.ClusterPolicy
+	assert.NilError(t, json.Unmarshal([]byte(clusterPolicy), &cpol))
+	var pol kyvernov1.Policy
+	assert.NilError(t, json.Unmarshal([]byte(namespacedPolicy), &pol))
+	return []kyvernov1.PolicyInterface{&cpol, &pol}
+}
+
+func TestCompute(t *testing.T) {
+	exceptions := []*kyvernov2.PolicyException{{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-debug", Namespace: "kyverno"},
+		Spec: kyvernov2.PolicyExceptionSpec{
+			Exceptions: []kyvernov2.Exception{{PolicyName: "pod-security", RuleNames: []string{"restricted"}}},
+		},
+	}}
+	exceptions[0].Spec.Match.Any = kyvernov1.ResourceFilters{{
+		ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}, Namespaces: []string{"prod-*"}},
+	}}
+	result := Compute(newNamespace("prod-payments", map[string]string{"team": "payments"}), nil, loadPolicies(t), exceptions, nil, time.Now())
+	assert.Equal(t, result.Namespace, "prod-payments")
+	assert.Equal(t, result.Filtered, "")
+	assert.Equal(t, len(result.Policies), 2)
+	cpol := result.Policies[0]
+	assert.Equal(t, cpol.Kind, "ClusterPolicy")
+	assert.Equal(t, cpol.Name, "pod-security")
+	assert.Equal(t, cpol.ValidationFailureAction, kyvernov1.Enforce)
+	assert.Equal(t, len(cpol.Rules), 2)
+	assert.Equal(t, cpol.Rules[0].Name, "restricted")
+	assert.Equal(t, cpol.Rules[0].Type, engineapi.Validation)
+	assert.DeepEqual(t, cpol.Rules[0].PodSecurity, &PodSecurity{Level: "restricted", Version: "latest"})
+	assert.DeepEqual(t, cpol.Rules[0].Exceptions, []string{"kyverno/allow-debug"})
+	assert.Equal(t, cpol.Rules[1].Name, "team-labels")
+	assert.Equal(t, len(cpol.Rules[1].Exceptions), 0)
+	pol := result.Policies[1]
+	assert.Equal(t, pol.Kind, "Policy")
+	assert.Equal(t, pol.Namespace, "prod-payments")
+	assert.Equal(t, pol.Rules[0].Type, engineapi.Mutation)
+	assert.DeepEqual(t, result.Profiles, []Profile{{
+		Source:      "ClusterPolicy/pod-security/restricted",
+		Mode:        "Enforce",
+		PodSecurity: PodSecurity{Level: "restricted", Version: "latest"},
+	}})
+}
+
+func TestComputeProfiles(t *testing.T) {
+	namespace := newNamespace("staging", map[string]string{
+		"pod-security.kubernetes.io/enforce":         "baseline",
+		"pod-security.kubernetes.io/enforce-version": "v1.29",
+		"pod-security.kubernetes.io/warn":            "restricted",
+	})
+	result := Compute(namespace, nil, loadPolicies(t), nil, nil, time.Now())
+	assert.DeepEqual(t, result.Profiles, []Profile{
+		{Source: ProfileSourceNamespace, Mode: "enforce", PodSecurity: PodSecurity{Level: "baseline", Version: "v1.29"}},
+		{Source: ProfileSourceNamespace, Mode: "warn", PodSecurity: PodSecurity{Level: "restricted"}},
+		{Source: "ClusterPolicy/pod-security/restricted", Mode: "Audit", PodSecurity: PodSecurity{Level: "restricted", Version: "latest"}},
+	})
+}
+
+func TestComputeExcluded(t *testing.T) {
+	result := Compute(newNamespace("kube-system", nil), nil, loadPolicies(t), nil, nil, time.Now())
+	assert.Equal(t, len(result.Policies), 1)
+	assert.Equal(t, result.Policies[0].ValidationFailureAction, kyvernov1.Audit)
+	assert.Equal(t, len(result.Policies[0].Rules), 1)
+	assert.Equal(t, result.Policies[0].Rules[0].Name, "kube-system-services")
+}
+
+func TestComputeFiltered(t *testing.T) {
+	configuration := config.NewDefaultConfiguration(false)
+	configuration.Load(&corev1.ConfigMap{
+		Data: map[string]string{
+			"resourceFilters": "[*,kube-system,*][Pod,default,*]",
+			"webhooks":        `[{"namespaceSelector":{"matchExpressions":[{"key":"kubernetes.io/metadata.name","operator":"NotIn","values":["kyverno"]}]}}]`,
+		},
+	})
+	assert.Equal(t, Compute(newNamespace("kube-system", nil), configuration, nil, nil, nil, time.Now()).Filtered, FilteredByResourceFilters)
+	assert.Equal(t, Compute(newNamespace("default", map[string]string{"kubernetes.io/metadata.name": "default"}), configuration, nil, nil, nil, time.Now()).Filtered, "")
+	assert.Equal(t, Compute(newNamespace("kyverno", map[string]string{"kubernetes.io/metadata.name": "kyverno"}), configuration, nil, nil, nil, time.Now()).Filtered, FilteredByWebhooks)
+}
@@ -0,0 +1,146 @@
+package effective
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/webhooks"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	authenticationv1client "k8s.io/client-go/kubernetes/typed/authentication/v1"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/clock"
+)
+
+// Subresource is the namespaces subresource users must be allowed to get to list the policies in effect in a namespace.
+const Subresource = "effectivepolicies"
+
+type effectiveHandlers struct {
+	configuration     config.Configuration
+	nsLister          corev1listers.NamespaceLister
+	cpolLister        kyvernov1listers.ClusterPolicyLister
+	polLister         kyvernov1listers.PolicyLister
+	exceptionSelector engineapi.PolicyExceptionSelector
+	tokenReviews      authenticationv1client.TokenReviewInterface
+	accessReviews     authorizationv1client.SubjectAccessReviewInterface
+	schedules         *engineapi.ScheduleCache
+	clock             clock.PassiveClock
+}
+
+func NewHandlers(
+	configuration config.Configuration,
+	nsLister corev1listers.NamespaceLister,
+	cpolLister kyvernov1listers.ClusterPolicyLister,
+	polLister kyvernov1listers.PolicyLister,
+	exceptionSelector engineapi.PolicyExceptionSelector,
+	tokenReviews authenticationv1client.TokenReviewInterface,
+	accessReviews authorizationv1client.SubjectAccessReviewInterface,
+) webhooks.EffectivePolicyHandlers {
+	return &effectiveHandlers{
+		configuration:     configuration,
+		nsLister:          nsLister,
+		cpolLister:        cpolLister,
+		polLister:         polLister,
+		exceptionSelector: exceptionSelector,
+		tokenReviews:      tokenReviews,
+		accessReviews:     accessReviews,
+		schedules:         engineapi.NewScheduleCache(),
+		clock:             clock.RealClock{},
+	}
+}
+
+// Authorize authenticates the bearer token with a TokenReview and checks with a SubjectAccessReview
+// that the user is allowed to get the effectivepolicies subresource of the namespace.
+func (h *effectiveHandlers) Authorize(ctx context.Context, logger logr.Logger, token string, namespace string) error {
+	tokenReview, err := h.tokenReviews.Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token: token,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	if !tokenReview.Status.Authenticated {
+		message := tokenReview.Status.Error
+		if message == "" {
+			message = "invalid bearer token"
+		}
+		return apierrors.NewUnauthorized(message)
+	}
+	user := tokenReview.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	accessReview, err := h.accessReviews.Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   namespace,
+				Verb:        "get",
+				Version:     "v1",
+				Resource:    "namespaces",
+				Subresource: Subresource,
+				Name:        namespace,
+			},
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	if !accessReview.Status.Allowed {
+		logger.V(4).Info("access denied", "user", user.Username, "reason", accessReview.Status.Reason)
+		reason := accessReview.Status.Reason
+		if reason == "" {
+			reason = "user " + user.Username + " is not allowed to get the effective policies of the namespace"
+		}
+		return apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces/" + Subresource}, namespace, errors.New(reason))
+	}
+	return nil
+}
+
+func (h *effectiveHandlers) Namespace(_ context.Context, logger logr.Logger, name string) (interface{}, error) {
+	namespace, err := h.nsLister.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	var policies []kyvernov1.PolicyInterface
+	cpols, err := h.cpolLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, cpol := range cpols {
+		policies = append(policies, cpol)
+	}
+	pols, err := h.polLister.Policies(name).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, pol := range pols {
+		policies = append(policies, pol)
+	}
+	var exceptions []*kyvernov2.PolicyException
+	if h.exceptionSelector != nil {
+		exceptions, err = h.exceptionSelector.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+	}
+	result := Compute(namespace, h.configuration, policies, exceptions, h.schedules, h.clock.Now())
+	logger.V(4).Info("computed effective policies", "policies", len(result.Policies))
+	return result, nil
+}
@@ -0,0 +1,50 @@
+package effective
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"gotest.tools/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	kubetesting "k8s.io/client-go/testing"
+)
+
+func newAuthorizer(t *testing.T) *effectiveHandlers {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "tokenreviews", func(action kubetesting.Action) (bool, runtime.Object, error) {
+		review := action.(kubetesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if review.Spec.Token == "valid" {
+			review.Status.Authenticated = true
+			review.Status.User = authenticationv1.UserInfo{Username: "dev", Groups: []string{"team-payments"}}
+		}
+		return true, review, nil
+	})
+	client.PrependReactor("create", "subjectaccessreviews", func(action kubetesting.Action) (bool, runtime.Object, error) {
+		review := action.(kubetesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		attributes := review.Spec.ResourceAttributes
+		assert.Equal(t, attributes.Resource, "namespaces")
+		assert.Equal(t, attributes.Subresource, Subresource)
+		assert.Equal(t, attributes.Verb, "get")
+		assert.Equal(t, attributes.Name, attributes.Namespace)
+		review.Status.Allowed = review.Spec.User == "dev" && attributes.Name == "payments"
+		return true, review, nil
+	})
+	return &effectiveHandlers{
+		tokenReviews:  client.AuthenticationV1().TokenReviews(),
+		accessReviews: client.AuthorizationV1().SubjectAccessReviews(),
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	h := newAuthorizer(t)
+	assert.NilError(t, h.Authorize(context.TODO(), logr.Discard(), "valid", "payments"))
+	err := h.Authorize(context.TODO(), logr.Discard(), "invalid", "payments")
+	assert.Assert(t, apierrors.IsUnauthorized(err), err)
+	err = h.Authorize(context.TODO(), logr.Discard(), "valid", "kube-system")
+	assert.Assert(t, apierrors.IsForbidden(err), err)
+}
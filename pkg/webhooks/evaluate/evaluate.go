@@ -0,0 +1,241 @@
+package evaluate
+
+import (
+	"context"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
+	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
+	"gomodules.xyz/jsonpatch/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Request is the body of an evaluation request.
+type Request struct {
+	// Resource is the resource to evaluate, as it would be sent to the API server.
+	Resource map[string]interface{} `json:"resource,omitempty"`
+	// OldResource is the existing resource, for UPDATE and DELETE operations.
+	OldResource map[string]interface{} `json:"oldResource,omitempty"`
+	// Operation is the admission operation (CREATE, UPDATE or DELETE), CREATE by default.
+	Operation kyvernov1.AdmissionOperation `json:"operation,omitempty"`
+	// Policies restricts the evaluation to the given policies, referenced by name for a ClusterPolicy
+	// and namespace/name for a Policy. All the policies that can apply to the resource are evaluated when empty.
+	Policies []string `json:"policies,omitempty"`
+}
+
+// Result is the outcome of the evaluation of a resource, as the admission controller would process it.
+type Result struct {
+	// Allowed is false when the admission request would be denied.
+	Allowed bool `json:"allowed"`
+	// Message explains why the admission request would be denied.
+	Message string `json:"message,omitempty"`
+	// Patches are the JSON patches the mutate rules would apply to the resource.
+	Patches []jsonpatch.JsonPatchOperation `json:"patches,omitempty"`
+	// PatchedResource is the resource with the patches applied.
+	PatchedResource map[string]interface{} `json:"patchedResource,omitempty"`
+	// Responses are the engine responses of the policies, in evaluation order.
+	Responses []EngineResponse `json:"responses,omitempty"`
+}
+
+// EngineResponse is the serializable form of the engine response of a policy, it carries all of its fields.
+type EngineResponse struct {
+	// Kind is either ClusterPolicy or Policy.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the policy, empty for a ClusterPolicy.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the policy.
+	Name string `json:"name"`
+	// ValidationFailureAction is the action applied to validation failures.
+	ValidationFailureAction kyvernov1.ValidationFailureAction `json:"validationFailureAction,omitempty"`
+	// Resource is the resource the policy was applied to, patched by the policies applied before it.
+	Resource map[string]interface{} `json:"resource,omitempty"`
+	// PatchedResource is the resource patched by the policy, only set when the policy patches the resource.
+	PatchedResource map[string]interface{} `json:"patchedResource,omitempty"`
+	// Patches are the JSON patches applied by the policy.
+	Patches []jsonpatch.JsonPatchOperation `json:"patches,omitempty"`
+	// NamespaceLabels are the labels of the namespace of the resource, used to match the policy.
+	NamespaceLabels map[string]string `json:"namespaceLabels,omitempty"`
+	// Stats are the execution statistics of the policy.
+	Stats ExecutionStats `json:"stats"`
+	// RulesAppliedCount is the number of rules applied successfully.
+	RulesAppliedCount int `json:"rulesAppliedCount"`
+	// RulesErrorCount is the number of rules failing with an error.
+	RulesErrorCount int `json:"rulesErrorCount"`
+	// Rules are the responses of the rules applied to the resource.
+	Rules []RuleResponse `json:"rules,omitempty"`
+}
+
+// RuleResponse is the serializable form of the response of a rule.
+type RuleResponse struct {
+	Name        string                       `json:"name"`
+	Type        engineapi.RuleType           `json:"type"`
+	Status      engineapi.RuleStatus         `json:"status"`
+	Message     string                       `json:"message,omitempty"`
+	Remediation string                       `json:"remediation,omitempty"`
+	Violations  []engineapi.Violation        `json:"violations,omitempty"`
+	Exception   string                       `json:"exception,omitempty"`
+	PodSecurity *engineapi.PodSecurityChecks `json:"podSecurity,omitempty"`
+	Stats       ExecutionStats               `json:"stats"`
+	// PatchedTarget references the target patched by a mutate existing rule and GeneratedResource the resource generated
+	// by a generate rule. They are read with the privileges of Kyverno and not the ones of the user, only references are returned.
+	PatchedTarget     *corev1.ObjectReference `json:"patchedTarget,omitempty"`
+	GeneratedResource *corev1.ObjectReference `json:"generatedResource,omitempty"`
+}
+
+// ExecutionStats is the serializable form of the execution statistics of a policy or a rule.
+type ExecutionStats struct {
+	// Timestamp is the time the policy or the rule was applied.
+	Timestamp metav1.Time `json:"timestamp"`
+	// ProcessingTime is the time taken to apply the policy or the rule.
+	ProcessingTime metav1.Duration `json:"processingTime"`
+}
+
+// Evaluate applies the mutate, verifyImages, validate and generate rules of the policies in the order the admission
+// controller does: mutations are applied first and the following rules are evaluated on the patched resource.
+// Nothing is persisted, generated resources are not created and no event or report is produced.
+func Evaluate(ctx context.Context, eng engineapi.Engine, policyContext *engine.PolicyContext, policies []kyvernov1.PolicyInterface) Result {
+	policies, _ = policyutils.Sort(policies...)
+	var responses []engineapi.EngineResponse
+	original := policyContext.NewResource()
+	exclusions := policyutils.NewExclusions()
+	for _, policy := range policies {
+		if !policy.GetSpec().HasMutate() || exclusions.Excluded(policy) != "" {
+			continue
+		}
+		response := eng.Mutate(ctx, policyContext.WithPolicy(policy))
+		if response.PatchedResource.Object != nil {
+			policyContext = policyContext.WithNewResource(response.PatchedResource)
+		}
+		responses = append(responses, response)
+		if response.IsApplied() {
+			exclusions.Applied(policy)
+		}
+	}
+	for _, policy := range policies {
+		if !policy.GetSpec().HasVerifyImages() || exclusions.Excluded(policy) != "" {
+			continue
+		}
+		response, _ := eng.VerifyAndPatchImages(ctx, policyContext.WithPolicy(policy))
+		if response.PatchedResource.Object != nil {
+			policyContext = policyContext.WithNewResource(response.PatchedResource)
+		}
+		responses = append(responses, response)
+	}
+	for _, policy := range policies {
+		if !policy.GetSpec().HasValidate() || exclusions.Excluded(policy) != "" {
+			continue
+		}
+		response := eng.Validate(ctx, policyContext.WithPolicy(policy))
+		if response.IsNil() {
+			continue
+		}
+		responses = append(responses, response)
+		if response.IsApplied() {
+			exclusions.Applied(policy)
+		}
+	}
+	for _, policy := range policies {
+		if !policy.GetSpec().HasGenerate() {
+			continue
+		}
+		responses = append(responses, eng.Generate(ctx, policyContext.WithPolicy(policy)))
+	}
+	return newResult(ctx, original, policyContext, policies, responses)
+}
+
+func newResult(ctx context.Context, original unstructured.Unstructured, policyContext *engine.PolicyContext, policies []kyvernov1.PolicyInterface, responses []engineapi.EngineResponse) Result {
+	result := Result{Allowed: true}
+	failurePolicy := kyvernov1.Ignore
+	for _, policy := range policies {
+		if policy.GetSpec().GetFailurePolicy(ctx) == kyvernov1.Fail {
+			failurePolicy = kyvernov1.Fail
+		}
+	}
+	var blocking []engineapi.EngineResponse
+	for _, response := range responses {
+		if engineutils.BlockRequest(response, failurePolicy) {
+			blocking = append(blocking, response)
+		}
+		result.Responses = append(result.Responses, newEngineResponse(response))
+	}
+	if len(blocking) != 0 {
+		result.Allowed = false
+		result.Message = strings.TrimSpace(webhookutils.GetBlockedMessages(blocking))
+	}
+	patched := policyContext.NewResource()
+	if patches := engineapi.NewEngineResponse(original, nil, nil).WithPatchedResource(patched).GetPatches(); len(patches) != 0 {
+		result.Patches = patches
+		result.PatchedResource = patched.Object
+	}
+	return result
+}
+
+func newEngineResponse(response engineapi.EngineResponse) EngineResponse {
+	policy := response.Policy()
+	result := EngineResponse{
+		Kind:                    "ClusterPolicy",
+		Namespace:               policy.GetNamespace(),
+		Name:                    policy.GetName(),
+		ValidationFailureAction: response.GetValidationFailureAction(),
+		Resource:                response.Resource.Object,
+		NamespaceLabels:         response.NamespaceLabels(),
+		Stats:                   newExecutionStats(response.Stats()),
+		RulesAppliedCount:       response.PolicyResponse.RulesAppliedCount(),
+		RulesErrorCount:         response.PolicyResponse.RulesErrorCount(),
+	}
+	if result.Namespace != "" {
+		result.Kind = "Policy"
+	}
+	if response.PatchedResource.Object != nil {
+		result.Patches = response.GetPatches()
+		if len(result.Patches) != 0 {
+			result.PatchedResource = response.PatchedResource.Object
+		}
+	}
+	for _, rule := range response.PolicyResponse.Rules {
+		ruleResponse := RuleResponse{
+			Name:        rule.Name(),
+			Type:        rule.RuleType(),
+			Status:      rule.Status(),
+			Message:     rule.Message(),
+			Remediation: rule.Remediation(),
+			Violations:  rule.Violations(),
+			PodSecurity: rule.PodSecurityChecks(),
+			Stats:       newExecutionStats(rule.Stats()),
+		}
+		if exception := rule.Exception(); exception != nil {
+			ruleResponse.Exception = exception.GetNamespace() + "/" + exception.GetName()
+		}
+		if target, _, _ := rule.PatchedTarget(); target != nil {
+			ruleResponse.PatchedTarget = newObjectReference(*target)
+		}
+		if generated := rule.GeneratedResource(); generated.Object != nil {
+			ruleResponse.GeneratedResource = newObjectReference(generated)
+		}
+		result.Rules = append(result.Rules, ruleResponse)
+	}
+	return result
+}
+
+func newExecutionStats(stats engineapi.ExecutionStats) ExecutionStats {
+	return ExecutionStats{
+		Timestamp:      metav1.NewTime(stats.Time()),
+		ProcessingTime: metav1.Duration{Duration: stats.ProcessingTime()},
+	}
+}
+
+func newObjectReference(resource unstructured.Unstructured) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		APIVersion: resource.GetAPIVersion(),
+		Kind:       resource.GetKind(),
+		Namespace:  resource.GetNamespace(),
+		Name:       resource.GetName(),
+		UID:        resource.GetUID(),
+	}
+}
@@ -0,0 +1,96 @@
+package evaluate
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/adapters"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var ignoreStats = cmpopts.IgnoreFields(RuleResponse{}, "Stats")
+
+func Test_Evaluate(t *testing.T) {
+	rawPolicy := `{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": { "name": "labels" },
+		"spec": {
+			"validationFailureAction": "Enforce",
+			"rules": [{
+				"name": "add-managed-by",
+				"match": { "any": [{ "resources": { "kinds": ["ConfigMap"] } }] },
+				"mutate": { "patchStrategicMerge": { "metadata": { "labels": { "managed-by": "kyverno" } } } }
+			}, {
+				"name": "require-team",
+				"match": { "any": [{ "resources": { "kinds": ["ConfigMap"] } }] },
+				"validate": {
+					"message": "the team label is required",
+					"pattern": { "metadata": { "labels": { "team": "?*", "managed-by": "kyverno" } } }
+				}
+			}]
+		}
+	}`
+	var policy kyvernov1.ClusterPolicy
+	assert.NilError(t, json.Unmarshal([]byte(rawPolicy), &policy))
+	cfg := config.NewDefaultConfiguration(false)
+	jp := jmespath.New(cfg)
+	eng := engine.NewEngine(
+		cfg,
+		config.NewDefaultMetricsConfiguration(),
+		jp,
+		nil,
+		nil,
+		factories.DefaultRegistryClientFactory(adapters.RegistryClient(registryclient.NewOrDie()), nil),
+		imageverifycache.DisabledImageVerifyCache(),
+		factories.DefaultContextLoaderFactory(nil),
+		nil,
+		nil,
+		nil,
+		"",
+	)
+	evaluate := func(labels map[string]interface{}) Result {
+		resource := unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": "settings", "namespace": "default", "labels": labels},
+		}}
+		policyContext, err := engine.NewPolicyContext(jp, resource, kyvernov1.Create, nil, cfg)
+		assert.NilError(t, err)
+		return Evaluate(context.TODO(), eng, policyContext, []kyvernov1.PolicyInterface{&policy})
+	}
+	result := evaluate(map[string]interface{}{"team": "payments"})
+	assert.Assert(t, result.Allowed)
+	assert.Equal(t, result.Message, "")
+	assert.Equal(t, len(result.Patches), 1)
+	assert.Equal(t, result.PatchedResource["metadata"].(map[string]interface{})["labels"].(map[string]interface{})["managed-by"], "kyverno")
+	assert.Equal(t, len(result.Responses), 2)
+	assert.Equal(t, result.Responses[0].Kind, "ClusterPolicy")
+	assert.DeepEqual(t, result.Responses[0].Rules, []RuleResponse{{Name: "add-managed-by", Type: engineapi.Mutation, Status: engineapi.RuleStatusPass, Message: "mutated ConfigMap/settings in namespace default"}}, ignoreStats)
+	assert.Equal(t, result.Responses[0].RulesAppliedCount, 1)
+	assert.Assert(t, !result.Responses[0].Stats.Timestamp.IsZero())
+	assert.Assert(t, !result.Responses[0].Rules[0].Stats.Timestamp.IsZero())
+	assert.Equal(t, len(result.Responses[0].Patches), 1)
+	assert.DeepEqual(t, result.Responses[0].PatchedResource, result.PatchedResource)
+	assert.Equal(t, result.Responses[1].Rules[0].Status, engineapi.RuleStatusPass)
+	// the validate rule is applied to the patched resource
+	assert.DeepEqual(t, result.Responses[1].Resource, result.PatchedResource)
+	assert.Assert(t, result.Responses[1].PatchedResource == nil)
+
+	result = evaluate(nil)
+	assert.Assert(t, !result.Allowed)
+	assert.Equal(t, len(result.Responses), 2)
+	assert.DeepEqual(t, result.Responses[1].Rules, []RuleResponse{{Name: "require-team", Type: engineapi.Validation, Status: engineapi.RuleStatusFail, Message: "validation error: the team label is required. rule require-team failed at path /metadata/labels/team/"}}, ignoreStats)
+	assert.Assert(t, result.Message != "")
+}
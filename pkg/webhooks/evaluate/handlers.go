@@ -0,0 +1,245 @@
+package evaluate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/userinfo"
+	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
+	"github.com/kyverno/kyverno/pkg/webhooks"
+	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	authenticationv1client "k8s.io/client-go/kubernetes/typed/authentication/v1"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	rbacv1listers "k8s.io/client-go/listers/rbac/v1"
+	"k8s.io/utils/ptr"
+)
+
+// Subresource is the policies subresource users must be allowed to create in the namespace of a resource to evaluate it.
+const Subresource = "evaluate"
+
+type evaluateHandlers struct {
+	engine        engineapi.Engine
+	pcBuilder     webhookutils.PolicyContextBuilder
+	discovery     dclient.IDiscovery
+	nsLister      corev1listers.NamespaceLister
+	cpolLister    kyvernov1listers.ClusterPolicyLister
+	polLister     kyvernov1listers.PolicyLister
+	rbLister      rbacv1listers.RoleBindingLister
+	crbLister     rbacv1listers.ClusterRoleBindingLister
+	tokenReviews  authenticationv1client.TokenReviewInterface
+	accessReviews authorizationv1client.SubjectAccessReviewInterface
+}
+
+func NewHandlers(
+	engine engineapi.Engine,
+	pcBuilder webhookutils.PolicyContextBuilder,
+	discovery dclient.IDiscovery,
+	nsLister corev1listers.NamespaceLister,
+	cpolLister kyvernov1listers.ClusterPolicyLister,
+	polLister kyvernov1listers.PolicyLister,
+	rbLister rbacv1listers.RoleBindingLister,
+	crbLister rbacv1listers.ClusterRoleBindingLister,
+	tokenReviews authenticationv1client.TokenReviewInterface,
+	accessReviews authorizationv1client.SubjectAccessReviewInterface,
+) webhooks.PolicyEvaluationHandlers {
+	return &evaluateHandlers{
+		engine:        engine,
+		pcBuilder:     pcBuilder,
+		discovery:     discovery,
+		nsLister:      nsLister,
+		cpolLister:    cpolLister,
+		polLister:     polLister,
+		rbLister:      rbLister,
+		crbLister:     crbLister,
+		tokenReviews:  tokenReviews,
+		accessReviews: accessReviews,
+	}
+}
+
+// Authenticate returns the user authenticated by the bearer token with a TokenReview.
+func (h *evaluateHandlers) Authenticate(ctx context.Context, _ logr.Logger, token string) (authenticationv1.UserInfo, error) {
+	tokenReview, err := h.tokenReviews.Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token: token,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return authenticationv1.UserInfo{}, err
+	}
+	if !tokenReview.Status.Authenticated {
+		message := tokenReview.Status.Error
+		if message == "" {
+			message = "invalid bearer token"
+		}
+		return authenticationv1.UserInfo{}, apierrors.NewUnauthorized(message)
+	}
+	return tokenReview.Status.User, nil
+}
+
+// authorize checks with a SubjectAccessReview that the user is allowed to create the evaluate subresource of policies
+// in the namespace of the resource.
+func (h *evaluateHandlers) authorize(ctx context.Context, logger logr.Logger, user authenticationv1.UserInfo, namespace string) error {
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	accessReview, err := h.accessReviews.Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   namespace,
+				Verb:        "create",
+				Group:       kyvernov1.GroupVersion.Group,
+				Version:     kyvernov1.GroupVersion.Version,
+				Resource:    "policies",
+				Subresource: Subresource,
+			},
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	if !accessReview.Status.Allowed {
+		logger.V(4).Info("access denied", "user", user.Username, "reason", accessReview.Status.Reason)
+		reason := accessReview.Status.Reason
+		if reason == "" {
+			reason = "user " + user.Username + " is not allowed to evaluate policies in the namespace"
+		}
+		return apierrors.NewForbidden(kyvernov1.Resource("policies/"+Subresource), namespace, errors.New(reason))
+	}
+	return nil
+}
+
+// Evaluate decodes the evaluation request, checks the user is allowed to evaluate resources in the namespace of the
+// resource and returns the result of the policies, the user is the one of the simulated admission request.
+func (h *evaluateHandlers) Evaluate(ctx context.Context, logger logr.Logger, user authenticationv1.UserInfo, body []byte) (interface{}, error) {
+	var request Request
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, apierrors.NewBadRequest("invalid request: " + err.Error())
+	}
+	if request.Operation == "" {
+		request.Operation = kyvernov1.Create
+	}
+	resource := unstructured.Unstructured{Object: request.Resource}
+	oldResource := unstructured.Unstructured{Object: request.OldResource}
+	target := resource
+	switch request.Operation {
+	case kyvernov1.Create:
+		if request.Resource == nil {
+			return nil, apierrors.NewBadRequest("a resource is required")
+		}
+	case kyvernov1.Update:
+		if request.Resource == nil || request.OldResource == nil {
+			return nil, apierrors.NewBadRequest("a resource and an old resource are required")
+		}
+	case kyvernov1.Delete:
+		if request.OldResource == nil {
+			return nil, apierrors.NewBadRequest("an old resource is required")
+		}
+		target = oldResource
+	default:
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("unsupported operation %s", request.Operation))
+	}
+	logger = logger.WithValues("kind", target.GetKind(), "namespace", target.GetNamespace(), "name", target.GetName())
+	if err := h.authorize(ctx, logger, user, target.GetNamespace()); err != nil {
+		return nil, err
+	}
+	policies, err := h.policies(target.GetNamespace(), request.Policies)
+	if err != nil {
+		return nil, err
+	}
+	gvk := target.GroupVersionKind()
+	gvr, err := h.discovery.GetGVRFromGVK(gvk)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("failed to find the resource of %s: %s", gvk, err))
+	}
+	admissionRequest := admissionv1.AdmissionRequest{
+		UID:       uuid.NewUUID(),
+		Kind:      metav1.GroupVersionKind(gvk),
+		Resource:  metav1.GroupVersionResource(gvr),
+		Namespace: target.GetNamespace(),
+		Name:      target.GetName(),
+		Operation: admissionv1.Operation(request.Operation),
+		UserInfo:  user,
+		DryRun:    ptr.To(true),
+	}
+	if request.Resource != nil {
+		if admissionRequest.Object.Raw, err = resource.MarshalJSON(); err != nil {
+			return nil, apierrors.NewBadRequest(err.Error())
+		}
+	}
+	if request.OldResource != nil {
+		if admissionRequest.OldObject.Raw, err = oldResource.MarshalJSON(); err != nil {
+			return nil, apierrors.NewBadRequest(err.Error())
+		}
+	}
+	roles, clusterRoles, err := userinfo.GetRoleRef(h.rbLister, h.crbLister, user)
+	if err != nil {
+		return nil, err
+	}
+	policyContext, err := h.pcBuilder.Build(admissionRequest, roles, clusterRoles, gvk)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+	policyContext = policyContext.WithNamespaceLabels(engineutils.GetNamespaceSelectorsFromNamespaceLister(gvk.Kind, target.GetNamespace(), h.nsLister, logger))
+	result := Evaluate(ctx, h.engine, policyContext, policies)
+	logger.V(4).Info("evaluated policies", "policies", len(policies), "allowed", result.Allowed)
+	return result, nil
+}
+
+// policies returns the policies that can apply to resources of the namespace, restricted to the given keys
+func (h *evaluateHandlers) policies(namespace string, keys []string) ([]kyvernov1.PolicyInterface, error) {
+	var policies []kyvernov1.PolicyInterface
+	cpols, err := h.cpolLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, cpol := range cpols {
+		policies = append(policies, cpol)
+	}
+	if namespace != "" {
+		pols, err := h.polLister.Policies(namespace).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		for _, pol := range pols {
+			policies = append(policies, pol)
+		}
+	}
+	if len(keys) == 0 {
+		return policies, nil
+	}
+	wanted := sets.New(keys...)
+	var selected []kyvernov1.PolicyInterface
+	for _, policy := range policies {
+		if key := policyutils.Key(policy); wanted.Has(key) {
+			selected = append(selected, policy)
+			wanted.Delete(key)
+		}
+	}
+	if wanted.Len() != 0 {
+		return nil, apierrors.NewNotFound(kyvernov1.Resource("policies"), sets.List(wanted)[0])
+	}
+	return selected, nil
+}
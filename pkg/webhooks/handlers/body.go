@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+)
+
+// maxRequestBodyBytes is the maximum size of the bodies of the evaluation and scan requests, it's the
+// object size limit of the API server so that any resource stored in the cluster can be sent.
+// Admission requests are not bounded, an update carries both the object and the old object.
+const maxRequestBodyBytes = 3 * 1024 * 1024
+
+// readBody reads the body of the request up to maxRequestBodyBytes,
+// it returns the status code to reply with when the body can't be read.
+func readBody(writer http.ResponseWriter, request *http.Request) ([]byte, int, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(writer, request.Body, maxRequestBodyBytes))
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			return nil, http.StatusRequestEntityTooLarge, err
+		}
+		return nil, http.StatusBadRequest, err
+	}
+	return body, http.StatusOK, nil
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/decisionlog"
+)
+
+func (inner AdmissionHandler) WithDecisionLog(decisionLogger *decisionlog.Logger, webhook string) AdmissionHandler {
+	if decisionLogger == nil {
+		return inner
+	}
+	return inner.withDecisionLog(decisionLogger, webhook).WithTrace("DECISION")
+}
+
+func (inner AdmissionHandler) withDecisionLog(decisionLogger *decisionlog.Logger, webhook string) AdmissionHandler {
+	return func(ctx context.Context, logger logr.Logger, request AdmissionRequest, startTime time.Time) AdmissionResponse {
+		ctx, collector := decisionlog.NewContext(ctx)
+		response := inner(ctx, logger, request, startTime)
+		decisionLogger.Log(newDecision(webhook, request, response, collector, startTime))
+		return response
+	}
+}
+
+func newDecision(webhook string, request AdmissionRequest, response AdmissionResponse, collector *decisionlog.Collector, startTime time.Time) decisionlog.Decision {
+	decision := decisionlog.Decision{
+		Time:        startTime,
+		UID:         request.UID,
+		Webhook:     webhook,
+		Operation:   string(request.Operation),
+		Kind:        request.Kind.Kind,
+		SubResource: request.SubResource,
+		Namespace:   request.Namespace,
+		Name:        request.Name,
+		User:        request.UserInfo.Username,
+		DryRun:      request.DryRun != nil && *request.DryRun,
+		Allowed:     response.Allowed,
+		Warnings:    response.Warnings,
+		Latency:     time.Since(startTime).Seconds(),
+		Policies:    collector.Policies(),
+	}
+	if response.Result != nil {
+		decision.Message = response.Result.Message
+	}
+	if len(response.Patch) != 0 {
+		decision.Patches = response.Patch
+	}
+	return decision
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/julienschmidt/httprouter"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// EffectivePolicies serves as json the policies in effect in the namespace given by the `namespace` url parameter.
+// The request must carry a bearer token, authorize is called with the token before the policies are computed.
+func EffectivePolicies(
+	logger logr.Logger,
+	authorize func(context.Context, logr.Logger, string, string) error,
+	inner func(context.Context, logr.Logger, string) (interface{}, error),
+) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		ctx := request.Context()
+		namespace := httprouter.ParamsFromContext(ctx).ByName("namespace")
+		logger := logger.WithValues("namespace", namespace)
+		token, ok := strings.CutPrefix(request.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			HttpError(ctx, writer, request, logger, apierrors.NewUnauthorized("missing bearer token"), http.StatusUnauthorized)
+			return
+		}
+		if err := authorize(ctx, logger, token, namespace); err != nil {
+			HttpError(ctx, writer, request, logger, err, statusCode(err))
+			return
+		}
+		result, err := inner(ctx, logger, namespace)
+		if err != nil {
+			HttpError(ctx, writer, request, logger, err, statusCode(err))
+			return
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			HttpError(ctx, writer, request, logger, err, http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if _, err := writer.Write(data); err != nil {
+			logger.Error(err, "failed to write response")
+		}
+	}
+}
+
+func statusCode(err error) int {
+	switch {
+	case apierrors.IsUnauthorized(err):
+		return http.StatusUnauthorized
+	case apierrors.IsForbidden(err):
+		return http.StatusForbidden
+	case apierrors.IsBadRequest(err):
+		return http.StatusBadRequest
+	case apierrors.IsNotFound(err):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
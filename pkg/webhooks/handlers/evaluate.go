@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// EvaluatePolicies serves as json the result of the evaluation of the resource sent in the request body.
+// The request must carry a bearer token, the authenticated user is passed to inner which authorizes the request
+// once the resource is known.
+func EvaluatePolicies(
+	logger logr.Logger,
+	authenticate func(context.Context, logr.Logger, string) (authenticationv1.UserInfo, error),
+	inner func(context.Context, logr.Logger, authenticationv1.UserInfo, []byte) (interface{}, error),
+) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		ctx := request.Context()
+		token, ok := strings.CutPrefix(request.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			HttpError(ctx, writer, request, logger, apierrors.NewUnauthorized("missing bearer token"), http.StatusUnauthorized)
+			return
+		}
+		user, err := authenticate(ctx, logger, token)
+		if err != nil {
+			HttpError(ctx, writer, request, logger, err, statusCode(err))
+			return
+		}
+		logger := logger.WithValues("user", user.Username)
+		body, status, err := readBody(writer, request)
+		if err != nil {
+			HttpError(ctx, writer, request, logger, err, status)
+			return
+		}
+		result, err := inner(ctx, logger, user, body)
+		if err != nil {
+			HttpError(ctx, writer, request, logger, err, statusCode(err))
+			return
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			HttpError(ctx, writer, request, logger, err, http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if _, err := writer.Write(data); err != nil {
+			logger.Error(err, "failed to write response")
+		}
+	}
+}
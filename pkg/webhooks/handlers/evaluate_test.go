@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"gotest.tools/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+func Test_EvaluatePolicies(t *testing.T) {
+	authenticate := func(context.Context, logr.Logger, string) (authenticationv1.UserInfo, error) {
+		return authenticationv1.UserInfo{Username: "alice"}, nil
+	}
+	tests := []struct {
+		name       string
+		token      string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{{
+		name:       "missing token",
+		body:       `{}`,
+		wantStatus: http.StatusUnauthorized,
+	}, {
+		name:       "evaluated",
+		token:      "token",
+		body:       `{"resource":{}}`,
+		wantStatus: http.StatusOK,
+		wantBody:   `{"size":15,"user":"alice"}`,
+	}, {
+		name:       "body at the limit",
+		token:      "token",
+		body:       strings.Repeat(" ", maxRequestBodyBytes),
+		wantStatus: http.StatusOK,
+		wantBody:   `{"size":3145728,"user":"alice"}`,
+	}, {
+		name:       "body too large",
+		token:      "token",
+		body:       strings.Repeat(" ", maxRequestBodyBytes+1),
+		wantStatus: http.StatusRequestEntityTooLarge,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			inner := func(_ context.Context, _ logr.Logger, user authenticationv1.UserInfo, body []byte) (interface{}, error) {
+				called = true
+				return map[string]interface{}{"user": user.Username, "size": len(body)}, nil
+			}
+			request := httptest.NewRequest(http.MethodPost, "/policies/evaluate", strings.NewReader(tt.body))
+			if tt.token != "" {
+				request.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			recorder := httptest.NewRecorder()
+			EvaluatePolicies(logr.Discard(), authenticate, inner)(recorder, request)
+			assert.Equal(t, recorder.Code, tt.wantStatus)
+			assert.Equal(t, called, tt.wantStatus == http.StatusOK)
+			if tt.wantBody != "" {
+				assert.Equal(t, recorder.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
@@ -41,6 +41,11 @@ func (inner AdmissionHandler) withFilter(c config.Configuration) AdmissionHandle
 		if c.ToFilter(request.GroupVersionKind, request.SubResource, request.Namespace, request.Name) {
 			return filtered(ctx, logger, request, "admission request filtered because it apears in configmap resource filters")
 		}
+		// filter by exclusion policies
+		if policy, excluded := matchExclusionPolicy(c, request); excluded {
+			c.RecordExclusionPolicy(policy)
+			return filtered(ctx, logger, request, "admission request filtered by exclusion policy", "policy", policy)
+		}
 		// filter kyverno resources
 		if webhookutils.ExcludeKyvernoResources(request.Kind.Kind) {
 			return filtered(ctx, logger, request, "admission request filtered because it is for a kyverno resource")
@@ -49,6 +54,31 @@ func (inner AdmissionHandler) withFilter(c config.Configuration) AdmissionHandle
 	}
 }
 
+// matchExclusionPolicy matches the object and the old object of the request against the exclusion policies
+func matchExclusionPolicy(c config.Configuration, request AdmissionRequest) (string, bool) {
+	var objects []map[string]string
+	for _, raw := range [][]byte{request.Object.Raw, request.OldObject.Raw} {
+		if len(raw) == 0 {
+			continue
+		}
+		var labels map[string]string
+		if metadata, err := admissionutils.UnmarshalPartialObjectMetadata(raw); err == nil && metadata != nil {
+			labels = metadata.Labels
+		}
+		objects = append(objects, labels)
+	}
+	// requests without object are matched without labels
+	if len(objects) == 0 {
+		objects = append(objects, nil)
+	}
+	for _, labels := range objects {
+		if policy, excluded := c.MatchExclusionPolicy(request.GroupVersionKind, request.SubResource, request.Namespace, request.Name, labels); excluded {
+			return policy, true
+		}
+	}
+	return "", false
+}
+
 func (inner AdmissionHandler) withOperationFilter(operations ...admissionv1.Operation) AdmissionHandler {
 	allowed := sets.New[string]()
 	for _, operation := range operations {
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/decisionlog"
+	"github.com/kyverno/kyverno/pkg/recorder"
+)
+
+func (inner AdmissionHandler) WithRecording(admissionRecorder *recorder.Recorder, webhook string) AdmissionHandler {
+	if admissionRecorder == nil {
+		return inner
+	}
+	return inner.withRecording(admissionRecorder, webhook).WithTrace("RECORDING")
+}
+
+func (inner AdmissionHandler) withRecording(admissionRecorder *recorder.Recorder, webhook string) AdmissionHandler {
+	return func(ctx context.Context, logger logr.Logger, request AdmissionRequest, startTime time.Time) AdmissionResponse {
+		if !admissionRecorder.IsSampled(request.UID) {
+			return inner(ctx, logger, request, startTime)
+		}
+		ctx, collector := decisionlog.NewContext(ctx)
+		response := inner(ctx, logger, request, startTime)
+		admissionRecorder.Record(newRecording(webhook, request, response, collector, startTime))
+		return response
+	}
+}
+
+func newRecording(webhook string, request AdmissionRequest, response AdmissionResponse, collector *decisionlog.Collector, startTime time.Time) recorder.Recording {
+	recording := recorder.Recording{
+		Time:         startTime,
+		Webhook:      webhook,
+		Request:      request.AdmissionRequest,
+		Roles:        request.Roles,
+		ClusterRoles: request.ClusterRoles,
+		Allowed:      response.Allowed,
+		Warnings:     response.Warnings,
+	}
+	if response.Result != nil {
+		recording.Message = response.Result.Message
+	}
+	if len(response.Patch) != 0 {
+		recording.Patches = response.Patch
+	}
+	recording.AddPolicies(collector.Responses()...)
+	return recording
+}
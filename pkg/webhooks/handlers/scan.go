@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ScanPayload serves as json the result of the validation of the JSON payload sent in the request body.
+// The ValidatingPolicies to apply are given by the `policy` query parameters, all policies are applied when none is given.
+// The request must carry a bearer token, authorize is called with the token before the payload is validated.
+func ScanPayload(
+	logger logr.Logger,
+	authorize func(context.Context, logr.Logger, string) error,
+	inner func(context.Context, logr.Logger, interface{}, []string) (interface{}, error),
+) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		ctx := request.Context()
+		token, ok := strings.CutPrefix(request.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			HttpError(ctx, writer, request, logger, apierrors.NewUnauthorized("missing bearer token"), http.StatusUnauthorized)
+			return
+		}
+		if err := authorize(ctx, logger, token); err != nil {
+			HttpError(ctx, writer, request, logger, err, statusCode(err))
+			return
+		}
+		body, status, err := readBody(writer, request)
+		if err != nil {
+			HttpError(ctx, writer, request, logger, err, status)
+			return
+		}
+		var payload interface{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			HttpError(ctx, writer, request, logger, apierrors.NewBadRequest("invalid JSON payload: "+err.Error()), http.StatusBadRequest)
+			return
+		}
+		result, err := inner(ctx, logger, payload, request.URL.Query()["policy"])
+		if err != nil {
+			HttpError(ctx, writer, request, logger, err, statusCode(err))
+			return
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			HttpError(ctx, writer, request, logger, err, http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if _, err := writer.Write(data); err != nil {
+			logger.Error(err, "failed to write response")
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	tlsutils "github.com/kyverno/kyverno/pkg/utils/tls"
+)
+
+// ServerStatus is the status reported by a server
+type ServerStatus struct {
+	// TLSPosture is the cryptographic posture of the TLS servers and clients of the controller
+	TLSPosture tlsutils.Posture `json:"tlsPosture"`
+}
+
+// Status serves as json the status of the server
+func Status() http.HandlerFunc {
+	return func(writer http.ResponseWriter, _ *http.Request) {
+		data, err := json.Marshal(ServerStatus{TLSPosture: tlsutils.CurrentPosture()})
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = writer.Write(data)
+	}
+}
@@ -14,7 +14,7 @@ func Verify(ctx context.Context, logger logr.Logger, request AdmissionRequest, s
 	if request.Name != "kyverno-health" || request.Namespace != config.KyvernoNamespace() {
 		return admissionutils.ResponseSuccess(request.UID)
 	}
-	patch := jsonutils.NewPatchOperation("/metadata/annotations/"+"kyverno.io~1last-request-time", "replace", time.Now().Format(time.RFC3339))
+	patch := jsonutils.NewPatchOperation(jsonutils.JoinPointer("metadata", "annotations", "kyverno.io/last-request-time"), "replace", time.Now().Format(time.RFC3339))
 	bytes, err := patch.ToPatchBytes()
 	if err != nil {
 		logger.Error(err, "failed to build patch bytes")
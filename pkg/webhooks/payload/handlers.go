@@ -0,0 +1,126 @@
+package payload
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/payload"
+	"github.com/kyverno/kyverno/pkg/webhooks"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	authenticationv1client "k8s.io/client-go/kubernetes/typed/authentication/v1"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+// Subresource is the validatingpolicies subresource users must be allowed to create to scan payloads.
+const Subresource = "scan"
+
+type payloadHandlers struct {
+	vpolLister    kyvernov2alpha1listers.ValidatingPolicyLister
+	jp            jmespath.Interface
+	tokenReviews  authenticationv1client.TokenReviewInterface
+	accessReviews authorizationv1client.SubjectAccessReviewInterface
+}
+
+func NewHandlers(
+	vpolLister kyvernov2alpha1listers.ValidatingPolicyLister,
+	jp jmespath.Interface,
+	tokenReviews authenticationv1client.TokenReviewInterface,
+	accessReviews authorizationv1client.SubjectAccessReviewInterface,
+) webhooks.PayloadScanHandlers {
+	return &payloadHandlers{
+		vpolLister:    vpolLister,
+		jp:            jp,
+		tokenReviews:  tokenReviews,
+		accessReviews: accessReviews,
+	}
+}
+
+// Authorize authenticates the bearer token with a TokenReview and checks with a SubjectAccessReview
+// that the user is allowed to create the scan subresource of validatingpolicies.
+func (h *payloadHandlers) Authorize(ctx context.Context, logger logr.Logger, token string) error {
+	tokenReview, err := h.tokenReviews.Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token: token,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	if !tokenReview.Status.Authenticated {
+		message := tokenReview.Status.Error
+		if message == "" {
+			message = "invalid bearer token"
+		}
+		return apierrors.NewUnauthorized(message)
+	}
+	user := tokenReview.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	accessReview, err := h.accessReviews.Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Verb:        "create",
+				Group:       kyvernov2alpha1.SchemeGroupVersion.Group,
+				Version:     kyvernov2alpha1.SchemeGroupVersion.Version,
+				Resource:    "validatingpolicies",
+				Subresource: Subresource,
+			},
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	if !accessReview.Status.Allowed {
+		logger.V(4).Info("access denied", "user", user.Username, "reason", accessReview.Status.Reason)
+		reason := accessReview.Status.Reason
+		if reason == "" {
+			reason = "user " + user.Username + " is not allowed to scan payloads"
+		}
+		return apierrors.NewForbidden(kyvernov2alpha1.Resource("validatingpolicies/"+Subresource), "", errors.New(reason))
+	}
+	return nil
+}
+
+// Scan validates the payload against the named policies, or against all policies when no name is given.
+func (h *payloadHandlers) Scan(_ context.Context, logger logr.Logger, data interface{}, names []string) (interface{}, error) {
+	var policies []*kyvernov2alpha1.ValidatingPolicy
+	if len(names) == 0 {
+		all, err := h.vpolLister.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(all, func(i, j int) bool {
+			return all[i].GetName() < all[j].GetName()
+		})
+		policies = all
+	} else {
+		for _, name := range names {
+			policy, err := h.vpolLister.Get(name)
+			if err != nil {
+				return nil, err
+			}
+			policies = append(policies, policy)
+		}
+	}
+	responses, err := payload.Validate(logger, h.jp, data, policies...)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+	logger.V(4).Info("scanned payload", "policies", len(responses))
+	return responses, nil
+}
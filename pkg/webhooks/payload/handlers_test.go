@@ -0,0 +1,84 @@
+package payload
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/payload"
+	"gotest.tools/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	kubetesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestAuthorize(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "tokenreviews", func(action kubetesting.Action) (bool, runtime.Object, error) {
+		review := action.(kubetesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if review.Spec.Token != "invalid" {
+			review.Status.Authenticated = true
+			review.Status.User = authenticationv1.UserInfo{Username: review.Spec.Token}
+		}
+		return true, review, nil
+	})
+	client.PrependReactor("create", "subjectaccessreviews", func(action kubetesting.Action) (bool, runtime.Object, error) {
+		review := action.(kubetesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		attributes := review.Spec.ResourceAttributes
+		assert.Equal(t, attributes.Group, "kyverno.io")
+		assert.Equal(t, attributes.Resource, "validatingpolicies")
+		assert.Equal(t, attributes.Subresource, Subresource)
+		assert.Equal(t, attributes.Verb, "create")
+		review.Status.Allowed = review.Spec.User == "ci"
+		return true, review, nil
+	})
+	h := &payloadHandlers{
+		tokenReviews:  client.AuthenticationV1().TokenReviews(),
+		accessReviews: client.AuthorizationV1().SubjectAccessReviews(),
+	}
+	assert.NilError(t, h.Authorize(context.TODO(), logr.Discard(), "ci"))
+	err := h.Authorize(context.TODO(), logr.Discard(), "invalid")
+	assert.Assert(t, apierrors.IsUnauthorized(err), err)
+	err = h.Authorize(context.TODO(), logr.Discard(), "dev")
+	assert.Assert(t, apierrors.IsForbidden(err), err)
+}
+
+func TestScan(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, raw := range []string{
+		`{ "metadata": { "name": "require-owner" }, "spec": { "rules": [{ "name": "owner", "validate": { "pattern": { "owner": "?*" } } }] } }`,
+		`{ "metadata": { "name": "deny-public" }, "spec": { "rules": [{ "name": "public", "validate": { "pattern": { "public": false } } }] } }`,
+	} {
+		var policy kyvernov2alpha1.ValidatingPolicy
+		assert.NilError(t, json.Unmarshal([]byte(raw), &policy))
+		assert.NilError(t, indexer.Add(&policy))
+	}
+	h := &payloadHandlers{
+		vpolLister: kyvernov2alpha1listers.NewValidatingPolicyLister(indexer),
+		jp:         jmespath.New(config.NewDefaultConfiguration(false)),
+	}
+	data := map[string]interface{}{"owner": "team-a", "public": true}
+	result, err := h.Scan(context.TODO(), logr.Discard(), data, nil)
+	assert.NilError(t, err)
+	responses := result.([]payload.PolicyResponse)
+	assert.Equal(t, len(responses), 2)
+	assert.Equal(t, responses[0].Policy, "deny-public")
+	assert.Equal(t, responses[0].Rules[0].Status, engineapi.RuleStatusFail)
+	assert.Equal(t, responses[1].Policy, "require-owner")
+	assert.Equal(t, responses[1].Rules[0].Status, engineapi.RuleStatusPass)
+	result, err = h.Scan(context.TODO(), logr.Discard(), data, []string{"require-owner"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(result.([]payload.PolicyResponse)), 1)
+	_, err = h.Scan(context.TODO(), logr.Discard(), data, []string{"missing"})
+	assert.Assert(t, apierrors.IsNotFound(err), err)
+}
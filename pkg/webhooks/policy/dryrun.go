@@ -0,0 +1,140 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// DryRunAction defines what happens when a policy fails to evaluate against existing resources.
+type DryRunAction string
+
+const (
+	// DryRunActionIgnore does not evaluate the policy against existing resources.
+	DryRunActionIgnore DryRunAction = "ignore"
+	// DryRunActionWarn returns a warning for every rule failing to evaluate.
+	DryRunActionWarn DryRunAction = "warn"
+	// DryRunActionReject rejects the policy.
+	DryRunActionReject DryRunAction = "reject"
+)
+
+// dryRunTimeout bounds the time spent evaluating a policy so that the admission request doesn't time out
+const dryRunTimeout = 5 * time.Second
+
+// DryRunOptions configures the evaluation of policies against a sample of existing matching resources.
+type DryRunOptions struct {
+	Action        DryRunAction
+	SampleSize    int
+	Engine        engineapi.Engine
+	Configuration config.Configuration
+	Jp            jmespath.Interface
+}
+
+// dryRun evaluates the validate and mutate rules of a policy in audit mode against a bounded sample of existing
+// matching resources and returns the runtime errors (unresolved variables, JMESPath failures), at most one per rule.
+// Policies not processed in background are skipped as their rules can reference admission request data.
+func dryRun(ctx context.Context, logger logr.Logger, client dclient.Interface, options DryRunOptions, policy kyvernov1.PolicyInterface) []string {
+	if options.Action == DryRunActionIgnore || options.Action == "" || options.Engine == nil || options.SampleSize <= 0 {
+		return nil
+	}
+	spec := policy.GetSpec()
+	if !policy.BackgroundProcessingEnabled() || (!spec.HasValidate() && !spec.HasMutate()) {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, dryRunTimeout)
+	defer cancel()
+	resources := sampleResources(ctx, logger, client, policy, options.Configuration.GetAutogenControllers(), options.SampleSize)
+	failedRules := sets.New[string]()
+	var errors []string
+	for _, resource := range resources {
+		if ctx.Err() != nil {
+			logger.V(2).Info("policy dry run timed out", "resources", len(resources))
+			break
+		}
+		policyContext, err := engine.NewPolicyContext(options.Jp, resource, kyvernov1.Create, nil, options.Configuration)
+		if err != nil {
+			logger.Error(err, "failed to create policy context")
+			continue
+		}
+		policyContext = policyContext.WithNewResource(resource).WithPolicy(policy)
+		var responses []engineapi.EngineResponse
+		if spec.HasValidate() {
+			responses = append(responses, options.Engine.Validate(ctx, policyContext))
+		}
+		if spec.HasMutate() {
+			responses = append(responses, options.Engine.Mutate(ctx, policyContext))
+		}
+		for _, response := range responses {
+			for _, rule := range response.PolicyResponse.Rules {
+				if rule.Status() != engineapi.RuleStatusError || failedRules.Has(rule.Name()) {
+					continue
+				}
+				failedRules.Insert(rule.Name())
+				errors = append(errors, fmt.Sprintf("rule %s failed to evaluate against %s %s: %s", rule.Name(), resource.GetKind(), resourceKey(resource), rule.Message()))
+			}
+		}
+	}
+	return errors
+}
+
+// sampleResources returns at most size existing resources matching the kinds of the policy rules
+func sampleResources(ctx context.Context, logger logr.Logger, client dclient.Interface, policy kyvernov1.PolicyInterface, autogenControllers []config.AutogenController, size int) []unstructured.Unstructured {
+	kinds := sets.New[string]()
+	for _, rule := range autogen.ComputeRules(policy, autogenControllers) {
+		if rule.HasValidate() || rule.HasMutate() {
+			kinds.Insert(rule.MatchResources.GetKinds()...)
+		}
+	}
+	var resources []unstructured.Unstructured
+	for _, kind := range sets.List(kinds) {
+		group, version, kind, subresource := kubeutils.ParseKindSelector(kind)
+		if subresource != "" || kind == "*" {
+			continue
+		}
+		apis, err := client.Discovery().FindResources(group, version, kind, subresource)
+		if err != nil {
+			logger.V(4).Info("failed to find resources", "kind", kind, "error", err.Error())
+			continue
+		}
+		for api := range apis {
+			if len(resources) >= size || ctx.Err() != nil {
+				return resources
+			}
+			if api.SubResource != "" {
+				continue
+			}
+			list, err := client.GetDynamicInterface().Resource(api.GroupVersionResource()).Namespace(policy.GetNamespace()).List(ctx, metav1.ListOptions{
+				Limit: int64(size - len(resources)),
+			})
+			if err != nil {
+				logger.V(4).Info("failed to list resources", "resource", api.GroupVersionResource().String(), "error", err.Error())
+				continue
+			}
+			resources = append(resources, list.Items...)
+		}
+	}
+	if len(resources) > size {
+		resources = resources[:size]
+	}
+	return resources
+}
+
+func resourceKey(resource unstructured.Unstructured) string {
+	if resource.GetNamespace() == "" {
+		return resource.GetName()
+	}
+	return resource.GetNamespace() + "/" + resource.GetName()
+}
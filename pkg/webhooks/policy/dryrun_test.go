@@ -0,0 +1,130 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/engine/adapters"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func newDryRunFixture(t *testing.T, action DryRunAction, sampleSize int) (dclient.Interface, DryRunOptions) {
+	t.Helper()
+	gvrToListKind := map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "configmaps"}: "ConfigMapList",
+	}
+	objects := []runtime.Object{
+		kubeutils.NewUnstructured("v1", "ConfigMap", "default", "cm-1"),
+		kubeutils.NewUnstructured("v1", "ConfigMap", "default", "cm-2"),
+		kubeutils.NewUnstructured("v1", "ConfigMap", "test", "cm-3"),
+	}
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), gvrToListKind, objects...)
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+	cfg := config.NewDefaultConfiguration(false)
+	jp := jmespath.New(cfg)
+	eng := engine.NewEngine(
+		cfg,
+		config.NewDefaultMetricsConfiguration(),
+		jp,
+		nil,
+		nil,
+		factories.DefaultRegistryClientFactory(adapters.RegistryClient(registryclient.NewOrDie()), nil),
+		imageverifycache.DisabledImageVerifyCache(),
+		factories.DefaultContextLoaderFactory(nil),
+		nil,
+		nil,
+		nil,
+		"",
+	)
+	return client, DryRunOptions{
+		Action:        action,
+		SampleSize:    sampleSize,
+		Engine:        eng,
+		Configuration: cfg,
+		Jp:            jp,
+	}
+}
+
+func newDryRunPolicy(t *testing.T, value string) *kyvernov1.ClusterPolicy {
+	t.Helper()
+	raw := `{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "check-configmaps"},
+		"spec": {
+			"rules": [{
+				"name": "check-owner",
+				"match": {"any": [{"resources": {"kinds": ["ConfigMap"]}}]},
+				"validate": {
+					"message": "owner label is required",
+					"deny": {
+						"conditions": {
+							"any": [{"key": "` + value + `", "operator": "Equals", "value": "nobody"}]
+						}
+					}
+				}
+			}]
+		}
+	}`
+	var policy kyvernov1.ClusterPolicy
+	assert.NilError(t, json.Unmarshal([]byte(raw), &policy))
+	return &policy
+}
+
+func Test_sampleResources(t *testing.T) {
+	client, _ := newDryRunFixture(t, DryRunActionWarn, 2)
+	policy := newDryRunPolicy(t, "{{ request.object.metadata.name }}")
+	resources := sampleResources(context.TODO(), logging.GlobalLogger(), client, policy, nil, 2)
+	assert.Equal(t, len(resources), 2)
+	resources = sampleResources(context.TODO(), logging.GlobalLogger(), client, policy, nil, 10)
+	assert.Equal(t, len(resources), 3)
+}
+
+func Test_dryRun(t *testing.T) {
+	tests := []struct {
+		name   string
+		action DryRunAction
+		value  string
+		errors int
+	}{{
+		name:   "ignore",
+		action: DryRunActionIgnore,
+		value:  "{{ request.object.missing.field }}",
+		errors: 0,
+	}, {
+		name:   "resolved variables",
+		action: DryRunActionWarn,
+		value:  "{{ request.object.metadata.name }}",
+		errors: 0,
+	}, {
+		name:   "unresolved variables",
+		action: DryRunActionReject,
+		value:  "{{ request.object.missing.field }}",
+		errors: 1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, options := newDryRunFixture(t, tt.action, 10)
+			errors := dryRun(context.TODO(), logging.GlobalLogger(), client, options, newDryRunPolicy(t, tt.value))
+			assert.Equal(t, len(errors), tt.errors, strings.Join(errors, ", "))
+			for _, err := range errors {
+				assert.Assert(t, strings.HasPrefix(err, "rule check-owner failed to evaluate against ConfigMap "), err)
+			}
+		})
+	}
+}
@@ -2,25 +2,60 @@ package policy
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/toggle"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	policyvalidate "github.com/kyverno/kyverno/pkg/validation/policy"
 	"github.com/kyverno/kyverno/pkg/webhooks"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ConflictAction defines what happens when a policy duplicates or conflicts with an existing policy.
+type ConflictAction string
+
+const (
+	// ConflictActionIgnore does not check for conflicts.
+	ConflictActionIgnore ConflictAction = "ignore"
+	// ConflictActionWarn returns a warning referencing the conflicting policies.
+	ConflictActionWarn ConflictAction = "warn"
+	// ConflictActionReject rejects the policy.
+	ConflictActionReject ConflictAction = "reject"
 )
 
 type policyHandlers struct {
 	client                       dclient.Interface
 	backgroundServiceAccountName string
+	cpolLister                   kyvernov1listers.ClusterPolicyLister
+	polLister                    kyvernov1listers.PolicyLister
+	conflictAction               ConflictAction
+	dryRunOptions                DryRunOptions
 }
 
-func NewHandlers(client dclient.Interface, serviceaccount string) webhooks.PolicyHandlers {
+func NewHandlers(
+	client dclient.Interface,
+	serviceaccount string,
+	cpolLister kyvernov1listers.ClusterPolicyLister,
+	polLister kyvernov1listers.PolicyLister,
+	conflictAction ConflictAction,
+	dryRunOptions DryRunOptions,
+) webhooks.PolicyHandlers {
 	return &policyHandlers{
 		client:                       client,
 		backgroundServiceAccountName: serviceaccount,
+		cpolLister:                   cpolLister,
+		polLister:                    polLister,
+		conflictAction:               conflictAction,
+		dryRunOptions:                dryRunOptions,
 	}
 }
 
@@ -30,13 +65,123 @@ func (h *policyHandlers) Validate(ctx context.Context, logger logr.Logger, reque
 		logger.Error(err, "failed to unmarshal policies from admission request")
 		return admissionutils.Response(request.UID, err)
 	}
-	warnings, err := policyvalidate.Validate(policy, oldPolicy, h.client, false, h.backgroundServiceAccountName)
+	warnings, err := policyvalidate.Validate(ctx, policy, oldPolicy, h.client, false, h.backgroundServiceAccountName)
 	if err != nil {
 		logger.Error(err, "policy validation errors")
+		return admissionutils.Response(request.UID, err, warnings...)
+	}
+	// the policy service account is impersonated by the background controller,
+	// the author must be allowed to impersonate it too
+	if err := policyvalidate.AuthorizeServiceAccount(ctx, h.client, policy, request.UserInfo.Username, request.UserInfo.Groups); err != nil {
+		logger.Error(err, "policy validation errors")
+		return admissionutils.Response(request.UID, err, warnings...)
 	}
-	return admissionutils.Response(request.UID, err, warnings...)
+	// remote clusters are managed with the credentials of their kubeconfig secret,
+	// the author must be allowed to read it
+	if err := policyvalidate.AuthorizeKubeconfigSecrets(ctx, h.client, policy, request.UserInfo.Username, request.UserInfo.Groups); err != nil {
+		logger.Error(err, "policy validation errors")
+		return admissionutils.Response(request.UID, err, warnings...)
+	}
+	if toggle.FromContext(ctx).NamespacedPolicyDelegation() {
+		accesses, err := policyvalidate.AuthorizeNamespacedAccesses(ctx, h.client, policy, request.UserInfo.Username, request.UserInfo.Groups)
+		if err != nil {
+			logger.Error(err, "policy validation errors")
+			return admissionutils.Response(request.UID, err, warnings...)
+		}
+		// delegated accesses are logged and returned to the author so that they can be audited
+		for _, access := range accesses {
+			logger.Info("namespaced policy access delegated", "user", request.UserInfo.Username, "access", access.String())
+			warnings = append(warnings, "access delegated to kyverno: "+access.String())
+		}
+	}
+	if err := h.checkOrdering(policy); err != nil {
+		logger.Error(err, "policy validation errors")
+		return admissionutils.Response(request.UID, err, warnings...)
+	}
+	conflicts, err := h.findConflicts(policy)
+	if err != nil {
+		logger.Error(err, "failed to check policy conflicts")
+	} else if len(conflicts) != 0 {
+		if h.conflictAction == ConflictActionReject {
+			err := errors.New("policy conflicts with existing policies: " + strings.Join(conflicts, ", "))
+			logger.Error(err, "policy validation errors")
+			return admissionutils.Response(request.UID, err, warnings...)
+		}
+		warnings = append(warnings, conflicts...)
+	}
+	if dryRunErrors := dryRun(ctx, logger, h.client, h.dryRunOptions, policy); len(dryRunErrors) != 0 {
+		if h.dryRunOptions.Action == DryRunActionReject {
+			err := errors.New("policy failed to evaluate against existing resources: " + strings.Join(dryRunErrors, ", "))
+			logger.Error(err, "policy validation errors")
+			return admissionutils.Response(request.UID, err, warnings...)
+		}
+		warnings = append(warnings, dryRunErrors...)
+	}
+	return admissionutils.Response(request.UID, nil, warnings...)
 }
 
 func (h *policyHandlers) Mutate(_ context.Context, _ logr.Logger, request handlers.AdmissionRequest, _ time.Time) handlers.AdmissionResponse {
 	return admissionutils.ResponseSuccess(request.UID)
 }
+
+func (h *policyHandlers) findConflicts(policy kyvernov1.PolicyInterface) ([]string, error) {
+	if h.conflictAction == ConflictActionIgnore || h.cpolLister == nil || h.polLister == nil {
+		return nil, nil
+	}
+	var others []kyvernov1.PolicyInterface
+	cpols, err := h.cpolLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, cpol := range cpols {
+		others = append(others, cpol)
+	}
+	// namespaced policies can only conflict with cluster policies and policies in the same namespace,
+	// cluster policies can conflict with policies in any namespace
+	var pols []*kyvernov1.Policy
+	if policy.IsNamespaced() {
+		pols, err = h.polLister.Policies(policy.GetNamespace()).List(labels.Everything())
+	} else {
+		pols, err = h.polLister.List(labels.Everything())
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, pol := range pols {
+		others = append(others, pol)
+	}
+	return policyvalidate.FindConflicts(policy, others...), nil
+}
+
+// checkOrdering returns an error when the ordering of the policy creates a cycle with the ordering of existing policies
+func (h *policyHandlers) checkOrdering(policy kyvernov1.PolicyInterface) error {
+	ordering := policy.GetSpec().Ordering
+	if ordering == nil || (len(ordering.Before) == 0 && len(ordering.After) == 0) || h.cpolLister == nil || h.polLister == nil {
+		return nil
+	}
+	var others []kyvernov1.PolicyInterface
+	cpols, err := h.cpolLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, cpol := range cpols {
+		others = append(others, cpol)
+	}
+	pols, err := h.polLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, pol := range pols {
+		others = append(others, pol)
+	}
+	key := policyutils.Key(policy)
+	others = slices.DeleteFunc(others, func(other kyvernov1.PolicyInterface) bool {
+		return policyutils.Key(other) == key
+	})
+	// cycles between existing policies are not caused by this policy
+	if _, err := policyutils.Sort(others...); err != nil {
+		return nil
+	}
+	_, err = policyutils.Sort(append(others, policy)...)
+	return err
+}
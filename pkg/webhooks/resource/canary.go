@@ -0,0 +1,56 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/tracing"
+	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// handleCanary evaluates the canary policies against the admission request and records their outcomes,
+// it runs on its own policy context and never affects the admission response.
+func (h *resourceHandlers) handleCanary(
+	ctx context.Context,
+	logger logr.Logger,
+	request handlers.AdmissionRequest,
+	policies []kyvernov1.PolicyInterface,
+	evaluate func(context.Context, engineapi.PolicyContext) engineapi.EngineResponse,
+) {
+	if len(policies) == 0 {
+		return
+	}
+	policyContext, err := h.pcBuilder.Build(request.AdmissionRequest, request.Roles, request.ClusterRoles, request.GroupVersionKind)
+	if err != nil {
+		logger.Error(err, "failed to build policy context for canary policies")
+		return
+	}
+	if request.Kind.Kind != "Namespace" && request.Namespace != "" {
+		policyContext = policyContext.WithNamespaceLabels(engineutils.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, logger))
+	}
+	var responses []engineapi.EngineResponse
+	for _, policy := range policies {
+		tracing.ChildSpan(
+			ctx,
+			"pkg/webhooks/resource/canary",
+			fmt.Sprintf("POLICY %s/%s", policy.GetNamespace(), policy.GetName()),
+			func(ctx context.Context, span trace.Span) {
+				response := evaluate(ctx, policyContext.WithPolicy(policy))
+				// old and new resources produced the same response
+				if response.IsNil() {
+					return
+				}
+				if response.IsFailed() || response.IsError() {
+					logger.V(2).Info("canary policy would have failed the admission request", "policy", policy.GetName(), "failed rules", response.GetFailedRules())
+				}
+				responses = append(responses, response)
+			},
+		)
+	}
+	h.canary.Record(ctx, string(request.Operation), responses...)
+}
@@ -0,0 +1,23 @@
+package canary
+
+import (
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+)
+
+// IsCanary returns true when the policy is a canary, its outcomes are recorded but never enforced.
+func IsCanary(policy kyvernov1.PolicyInterface) bool {
+	return policy.GetAnnotations()[kyverno.AnnotationPolicyCanary] == "true"
+}
+
+// Split separates the canary policies from the ones applied to admission requests.
+func Split(policies ...kyvernov1.PolicyInterface) (applied []kyvernov1.PolicyInterface, canaries []kyvernov1.PolicyInterface) {
+	for _, policy := range policies {
+		if IsCanary(policy) {
+			canaries = append(canaries, policy)
+		} else {
+			applied = append(applied, policy)
+		}
+	}
+	return applied, canaries
+}
@@ -0,0 +1,52 @@
+package canary
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type canaryMetrics struct {
+	resultsTotal metric.Int64Counter
+}
+
+func newCanaryMetrics(logger logr.Logger) canaryMetrics {
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	resultsTotal, err := meter.Int64Counter(
+		"kyverno_policy_canary_results",
+		metric.WithDescription("can be used to track the results of the canary policies evaluated on admission requests, these results are never enforced."),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_policy_canary_results")
+	}
+	return canaryMetrics{
+		resultsTotal: resultsTotal,
+	}
+}
+
+func (m canaryMetrics) recordResult(ctx context.Context, operation string, result policyreportv1alpha2.PolicyReportResult) {
+	if m.resultsTotal == nil {
+		return
+	}
+	var kind, namespace string
+	if len(result.Resources) != 0 {
+		kind, namespace = result.Resources[0].Kind, result.Resources[0].Namespace
+	}
+	m.resultsTotal.Add(
+		ctx,
+		1,
+		metric.WithAttributes(
+			attribute.String("policy_name", result.Policy),
+			attribute.String("rule_name", result.Rule),
+			attribute.String("rule_result", string(result.Result)),
+			attribute.String("resource_kind", kind),
+			attribute.String("resource_namespace", namespace),
+			attribute.String("resource_request_operation", operation),
+		),
+	)
+}
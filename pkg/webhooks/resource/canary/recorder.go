@@ -0,0 +1,203 @@
+package canary
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// ReportName is the name of the policy reports holding the results of the canary policies,
+	// there is one per namespace plus a cluster policy report for cluster scoped resources.
+	ReportName = "kyverno-canary"
+	// DefaultRetention is how long a result is kept in the canary reports once it was last recorded
+	DefaultRetention = 24 * time.Hour
+	// DefaultFlushInterval is the period between two updates of the canary reports
+	DefaultFlushInterval = 10 * time.Second
+)
+
+// Recorder records the outcomes of the canary policies evaluated on admission requests.
+type Recorder interface {
+	// Record counts the results of the responses and queues them for the canary reports
+	Record(ctx context.Context, operation string, responses ...engineapi.EngineResponse)
+	// Run periodically flushes the queued results to the canary reports
+	Run(ctx context.Context, waitGroup *sync.WaitGroup)
+}
+
+type recorder struct {
+	client    versioned.Interface
+	logger    logr.Logger
+	metrics   canaryMetrics
+	reports   bool
+	retention time.Duration
+	interval  time.Duration
+
+	lock sync.Mutex
+	// pending holds the results not flushed yet, by namespace and result key
+	pending map[string]map[string]policyreportv1alpha2.PolicyReportResult
+}
+
+// NewRecorder returns a recorder storing the canary results in dedicated policy reports when reports is true,
+// results are only counted in metrics otherwise.
+func NewRecorder(client versioned.Interface, logger logr.Logger, reports bool, retention, interval time.Duration) Recorder {
+	return &recorder{
+		client:    client,
+		logger:    logger,
+		metrics:   newCanaryMetrics(logger),
+		reports:   reports,
+		retention: retention,
+		interval:  interval,
+		pending:   map[string]map[string]policyreportv1alpha2.PolicyReportResult{},
+	}
+}
+
+func (r *recorder) Record(ctx context.Context, operation string, responses ...engineapi.EngineResponse) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, response := range responses {
+		resource := corev1.ObjectReference{
+			APIVersion: response.Resource.GetAPIVersion(),
+			Kind:       response.Resource.GetKind(),
+			Namespace:  response.Resource.GetNamespace(),
+			Name:       response.Resource.GetName(),
+			UID:        response.Resource.GetUID(),
+		}
+		for _, result := range reportutils.EngineResponseToReportResults(response, nil) {
+			result.Resources = []corev1.ObjectReference{resource}
+			if result.Properties == nil {
+				result.Properties = map[string]string{}
+			}
+			result.Properties["operation"] = operation
+			r.metrics.recordResult(ctx, operation, result)
+			if !r.reports {
+				continue
+			}
+			results := r.pending[resource.Namespace]
+			if results == nil {
+				results = map[string]policyreportv1alpha2.PolicyReportResult{}
+				r.pending[resource.Namespace] = results
+			}
+			results[resultKey(result)] = result
+		}
+	}
+}
+
+func (r *recorder) Run(ctx context.Context, waitGroup *sync.WaitGroup) {
+	if !r.reports {
+		return
+	}
+	waitGroup.Add(1)
+	defer waitGroup.Done()
+	wait.UntilWithContext(ctx, r.flush, r.interval)
+}
+
+// flush merges the pending results into the canary reports, results that could not be stored are queued again.
+func (r *recorder) flush(ctx context.Context) {
+	r.lock.Lock()
+	pending := r.pending
+	r.pending = map[string]map[string]policyreportv1alpha2.PolicyReportResult{}
+	r.lock.Unlock()
+	for namespace, results := range pending {
+		if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			return r.store(ctx, namespace, results)
+		}); err != nil {
+			r.logger.Error(err, "failed to store canary results", "namespace", namespace)
+			r.requeue(namespace, results)
+		}
+	}
+}
+
+func (r *recorder) requeue(namespace string, results map[string]policyreportv1alpha2.PolicyReportResult) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	queued := r.pending[namespace]
+	if queued == nil {
+		r.pending[namespace] = results
+		return
+	}
+	for key, result := range results {
+		// results recorded in the meantime are more recent
+		if _, exists := queued[key]; !exists {
+			queued[key] = result
+		}
+	}
+}
+
+func (r *recorder) store(ctx context.Context, namespace string, pending map[string]policyreportv1alpha2.PolicyReportResult) error {
+	report, err := r.getReport(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	create := report == nil
+	if create {
+		report = reportutils.NewPolicyReport(namespace, ReportName, nil)
+		controllerutils.SetLabel(report, reportutils.LabelCanaryReport, "true")
+	}
+	results := mergeResults(report.GetResults(), pending, time.Now().Add(-r.retention))
+	reportutils.SetResults(report, results...)
+	if create {
+		_, err = reportutils.CreateReport(ctx, report, r.client)
+	} else {
+		_, err = reportutils.UpdateReport(ctx, report, r.client)
+	}
+	return err
+}
+
+func (r *recorder) getReport(ctx context.Context, namespace string) (kyvernov1alpha2.ReportInterface, error) {
+	var report kyvernov1alpha2.ReportInterface
+	var err error
+	if namespace == "" {
+		report, err = r.client.Wgpolicyk8sV1alpha2().ClusterPolicyReports().Get(ctx, ReportName, metav1.GetOptions{})
+	} else {
+		report, err = r.client.Wgpolicyk8sV1alpha2().PolicyReports(namespace).Get(ctx, ReportName, metav1.GetOptions{})
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return report, nil
+}
+
+// mergeResults replaces the stored results with the pending ones for the same policy, rule and resource,
+// and drops the stored results last recorded before the given time.
+func mergeResults(stored []policyreportv1alpha2.PolicyReportResult, pending map[string]policyreportv1alpha2.PolicyReportResult, since time.Time) []policyreportv1alpha2.PolicyReportResult {
+	merged := make(map[string]policyreportv1alpha2.PolicyReportResult, len(stored)+len(pending))
+	for _, result := range stored {
+		if result.Timestamp.Seconds >= since.Unix() {
+			merged[resultKey(result)] = result
+		}
+	}
+	for key, result := range pending {
+		merged[key] = result
+	}
+	results := make([]policyreportv1alpha2.PolicyReportResult, 0, len(merged))
+	for _, result := range merged {
+		results = append(results, result)
+	}
+	return results
+}
+
+// resultKey identifies a result by policy, rule and resource, the resource uid is not known yet on creation.
+func resultKey(result policyreportv1alpha2.PolicyReportResult) string {
+	parts := []string{result.Policy, result.Rule}
+	for _, resource := range result.Resources {
+		parts = append(parts, resource.Kind, resource.Namespace, resource.Name)
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,113 @@
+package canary
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newPolicy(name string, canary bool) kyvernov1.PolicyInterface {
+	policy := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{{Name: "check"}},
+		},
+	}
+	if canary {
+		policy.SetAnnotations(map[string]string{kyverno.AnnotationPolicyCanary: "true"})
+	}
+	return policy
+}
+
+func Test_Split(t *testing.T) {
+	applied, canaries := Split(newPolicy("enforced", false), newPolicy("canary", true))
+	if len(applied) != 1 || applied[0].GetName() != "enforced" {
+		t.Errorf("unexpected applied policies %v", applied)
+	}
+	if len(canaries) != 1 || canaries[0].GetName() != "canary" {
+		t.Errorf("unexpected canary policies %v", canaries)
+	}
+}
+
+func Test_mergeResults(t *testing.T) {
+	now := time.Now()
+	resource := []corev1.ObjectReference{{Kind: "Pod", Namespace: "default", Name: "nginx"}}
+	stored := []policyreportv1alpha2.PolicyReportResult{{
+		Policy:    "canary",
+		Rule:      "check",
+		Result:    policyreportv1alpha2.StatusPass,
+		Resources: resource,
+		Timestamp: metav1.Timestamp{Seconds: now.Add(-time.Minute).Unix()},
+	}, {
+		Policy:    "canary",
+		Rule:      "expired",
+		Result:    policyreportv1alpha2.StatusFail,
+		Resources: resource,
+		Timestamp: metav1.Timestamp{Seconds: now.Add(-2 * time.Hour).Unix()},
+	}}
+	updated := policyreportv1alpha2.PolicyReportResult{
+		Policy:    "canary",
+		Rule:      "check",
+		Result:    policyreportv1alpha2.StatusFail,
+		Resources: resource,
+		Timestamp: metav1.Timestamp{Seconds: now.Unix()},
+	}
+	results := mergeResults(stored, map[string]policyreportv1alpha2.PolicyReportResult{resultKey(updated): updated}, now.Add(-time.Hour))
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Result != policyreportv1alpha2.StatusFail {
+		t.Errorf("expected the pending result to replace the stored one, got %s", results[0].Result)
+	}
+}
+
+func Test_recorder(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset()
+	r := NewRecorder(client, logr.Discard(), true, DefaultRetention, DefaultFlushInterval).(*recorder)
+	resource := unstructured.Unstructured{}
+	resource.SetAPIVersion("v1")
+	resource.SetKind("Pod")
+	resource.SetNamespace("default")
+	resource.SetName("nginx")
+	response := engineapi.NewEngineResponse(resource, engineapi.NewKyvernoPolicy(newPolicy("canary", true)), nil).
+		WithPolicyResponse(engineapi.PolicyResponse{
+			Rules: []engineapi.RuleResponse{*engineapi.RuleFail("check", engineapi.Validation, "label is missing")},
+		})
+	r.Record(ctx, "CREATE", response)
+	r.flush(ctx)
+	report, err := client.Wgpolicyk8sV1alpha2().PolicyReports("default").Get(ctx, ReportName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get canary report: %v", err)
+	}
+	if _, ok := report.GetLabels()[reportutils.LabelCanaryReport]; !ok {
+		t.Errorf("expected the canary report label")
+	}
+	if report.Summary.Fail != 1 || len(report.Results) != 1 {
+		t.Fatalf("unexpected results %v", report.Results)
+	}
+	if got := report.Results[0].Resources[0].Name; got != "nginx" {
+		t.Errorf("expected result for nginx, got %s", got)
+	}
+	// recording the same rule again replaces the result
+	r.Record(ctx, "UPDATE", response)
+	r.flush(ctx)
+	report, err = client.Wgpolicyk8sV1alpha2().PolicyReports("default").Get(ctx, ReportName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get canary report: %v", err)
+	}
+	if len(report.Results) != 1 || report.Results[0].Properties["operation"] != "UPDATE" {
+		t.Errorf("unexpected results %v", report.Results)
+	}
+}
@@ -0,0 +1,200 @@
+package resource
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/policycache"
+	"github.com/kyverno/kyverno/pkg/toggle"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// deduplicate shares the response of an admission request with the identical requests received while it is processed.
+// Controllers retrying creates produce bursts of such requests, they are evaluated only once.
+func (h *resourceHandlers) deduplicate(
+	ctx context.Context,
+	logger logr.Logger,
+	webhook string,
+	request handlers.AdmissionRequest,
+	failurePolicy string,
+	policyTypes []policycache.PolicyType,
+	process func(context.Context) handlers.AdmissionResponse,
+) handlers.AdmissionResponse {
+	if !toggle.FromContext(ctx).AdmissionDeduplication() {
+		return process(ctx)
+	}
+	gvr := schema.GroupVersionResource(request.Resource)
+	var policies []kyvernov1.PolicyInterface
+	for _, policyType := range policyTypes {
+		policies = append(policies, h.pCache.GetPolicies(policyType, gvr, request.SubResource, request.Namespace)...)
+	}
+	key, ok := deduplicationKey(webhook, failurePolicy, request, policies...)
+	if !ok {
+		return process(ctx)
+	}
+	response, shared, err := h.inflight.do(ctx, key, h.deduplicationTimeout(failurePolicy), process)
+	if err != nil {
+		logger.Error(err, "stopped waiting for the admission request to be processed")
+		return admissionutils.Response(request.UID, err)
+	}
+	if !shared {
+		return response
+	}
+	if response.UID != request.UID {
+		logger.V(4).Info("admission request deduplicated", "uid", response.UID)
+	}
+	// the response is shared, don't let callers modify each other's copy
+	response.UID = request.UID
+	response.Warnings = slices.Clone(response.Warnings)
+	if response.Result != nil {
+		result := *response.Result
+		response.Result = &result
+	}
+	return response
+}
+
+// deduplicationTimeout returns the timeout of the webhook receiving the request, the webhooks grouped by timeout
+// (`fail/15`) declare it, the other ones use the configured timeout.
+func (h *resourceHandlers) deduplicationTimeout(failurePolicy string) time.Duration {
+	_, timeout, _ := strings.Cut(failurePolicy, "/")
+	timeout, _, _ = strings.Cut(timeout, "/")
+	if seconds, err := strconv.Atoi(timeout); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return h.webhookTimeout
+}
+
+// inflightRequests holds the admission requests being processed, identical requests share their response
+type inflightRequests struct {
+	lock     sync.Mutex
+	requests map[string]*inflightRequest
+	// joined is called once a request has joined an identical request being processed, it is used by tests
+	joined func(key string)
+}
+
+type inflightRequest struct {
+	done     chan struct{}
+	response handlers.AdmissionResponse
+	// panicked holds the value the processing of the request panicked with
+	panicked interface{}
+}
+
+// do returns the response of the identical request being processed if there is one, otherwise it processes the request.
+// The request is processed on a context detached from the caller's one and bounded by timeout, the callers sharing it
+// still get the response when the caller that started it gives up. Every caller stops waiting once its own context is
+// done. The boolean reports whether the response comes from another request, waiters get a copy they must not modify.
+func (g *inflightRequests) do(
+	ctx context.Context,
+	key string,
+	timeout time.Duration,
+	process func(context.Context) handlers.AdmissionResponse,
+) (handlers.AdmissionResponse, bool, error) {
+	g.lock.Lock()
+	if g.requests == nil {
+		g.requests = map[string]*inflightRequest{}
+	}
+	request, shared := g.requests[key]
+	if !shared {
+		request = &inflightRequest{done: make(chan struct{})}
+		g.requests[key] = request
+		go g.process(ctx, key, request, timeout, process)
+	}
+	g.lock.Unlock()
+	if shared && g.joined != nil {
+		g.joined(key)
+	}
+	select {
+	case <-ctx.Done():
+		return handlers.AdmissionResponse{}, shared, ctx.Err()
+	case <-request.done:
+	}
+	if request.panicked != nil {
+		if !shared {
+			panic(request.panicked)
+		}
+		// the request being processed panicked, process this one on its own
+		return process(ctx), false, nil
+	}
+	return request.response, shared, nil
+}
+
+func (g *inflightRequests) process(
+	ctx context.Context,
+	key string,
+	request *inflightRequest,
+	timeout time.Duration,
+	process func(context.Context) handlers.AdmissionResponse,
+) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+	defer func() {
+		request.panicked = recover()
+		g.lock.Lock()
+		delete(g.requests, key)
+		g.lock.Unlock()
+		close(request.done)
+	}()
+	request.response = process(ctx)
+}
+
+// deduplicationKey identifies the admission requests producing the same response, they target the same resource content
+// with the same operation, come from the same user and are evaluated against the same policies.
+// Requests without a resource name are not deduplicated, objects created with a generate name are distinct objects.
+func deduplicationKey(webhook string, failurePolicy string, request handlers.AdmissionRequest, policies ...kyvernov1.PolicyInterface) (string, bool) {
+	if request.Name == "" {
+		return "", false
+	}
+	h := sha256.New()
+	write(h,
+		webhook,
+		failurePolicy,
+		string(request.Operation),
+		request.Kind.String(),
+		request.Resource.String(),
+		request.SubResource,
+		request.Namespace,
+		request.Name,
+		request.UserInfo.Username,
+		request.UserInfo.UID,
+	)
+	write(h, request.UserInfo.Groups...)
+	extra := make([]string, 0, len(request.UserInfo.Extra))
+	for key := range request.UserInfo.Extra {
+		extra = append(extra, key)
+	}
+	sort.Strings(extra)
+	for _, key := range extra {
+		write(h, key)
+		write(h, request.UserInfo.Extra[key]...)
+	}
+	write(h, request.Roles...)
+	write(h, request.ClusterRoles...)
+	if request.DryRun != nil && *request.DryRun {
+		write(h, "dryRun")
+	}
+	write(h, string(request.Object.Raw), string(request.OldObject.Raw), string(request.Options.Raw))
+	for _, policy := range policies {
+		write(h, policy.GetNamespace(), policy.GetName(), policy.GetResourceVersion())
+	}
+	return hex.EncodeToString(h.Sum(nil)), true
+}
+
+func write(h hash.Hash, values ...string) {
+	for _, value := range values {
+		_, _ = h.Write([]byte(value))
+		_, _ = h.Write([]byte{0})
+	}
+	_, _ = h.Write([]byte{1})
+}
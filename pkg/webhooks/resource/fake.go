@@ -2,11 +2,13 @@ package resource
 
 import (
 	"context"
+	"time"
 
 	fakekyvernov1 "github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
 	kyvernoinformers "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
+	webhookcontroller "github.com/kyverno/kyverno/pkg/controllers/webhook"
 	"github.com/kyverno/kyverno/pkg/engine"
 	"github.com/kyverno/kyverno/pkg/engine/adapters"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
@@ -18,6 +20,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/policycache"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 	"github.com/kyverno/kyverno/pkg/webhooks"
+	"github.com/kyverno/kyverno/pkg/webhooks/resource/canary"
 	"github.com/kyverno/kyverno/pkg/webhooks/updaterequest"
 	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
 	kubeinformers "k8s.io/client-go/informers"
@@ -44,24 +47,29 @@ func NewFakeHandlers(ctx context.Context, policyCache policycache.Cache) webhook
 	rclient := registryclient.NewOrDie()
 
 	return &resourceHandlers{
-		client:        dclient,
-		configuration: configuration,
-		metricsConfig: metricsConfig,
-		pCache:        policyCache,
-		nsLister:      informers.Core().V1().Namespaces().Lister(),
-		urLister:      urLister,
-		urGenerator:   updaterequest.NewFake(),
-		eventGen:      event.NewFake(),
-		pcBuilder:     webhookutils.NewPolicyContextBuilder(configuration, jp),
+		client:         dclient,
+		configuration:  configuration,
+		metricsConfig:  metricsConfig,
+		pCache:         policyCache,
+		nsLister:       informers.Core().V1().Namespaces().Lister(),
+		urLister:       urLister,
+		urGenerator:    updaterequest.NewFake(),
+		eventGen:       event.NewFake(),
+		pcBuilder:      webhookutils.NewPolicyContextBuilder(configuration, jp),
+		canary:         canary.NewFake(),
+		webhookTimeout: webhookcontroller.DefaultWebhookTimeout * time.Second,
 		engine: engine.NewEngine(
 			configuration,
 			config.NewDefaultMetricsConfiguration(),
 			jp,
+			nil,
 			adapters.Client(dclient),
 			factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), nil),
 			imageverifycache.DisabledImageVerifyCache(),
 			factories.DefaultContextLoaderFactory(configMapResolver),
 			peLister,
+			nil,
+			nil,
 			"",
 		),
 	}
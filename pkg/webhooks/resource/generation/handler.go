@@ -20,6 +20,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	utils "github.com/kyverno/kyverno/pkg/utils/engine"
+	matchutils "github.com/kyverno/kyverno/pkg/utils/match"
 	webhookgenerate "github.com/kyverno/kyverno/pkg/webhooks/updaterequest"
 	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -42,6 +43,7 @@ func NewGenerationHandler(
 	urGenerator webhookgenerate.Generator,
 	eventGen event.Interface,
 	metrics metrics.MetricsConfigManager,
+	ownerResolver matchutils.OwnerResolver,
 ) GenerationHandler {
 	return &generationHandler{
 		log:           log,
@@ -55,6 +57,7 @@ func NewGenerationHandler(
 		urGenerator:   urGenerator,
 		eventGen:      eventGen,
 		metrics:       metrics,
+		ownerResolver: ownerResolver,
 	}
 }
 
@@ -70,6 +73,7 @@ type generationHandler struct {
 	urGenerator   webhookgenerate.Generator
 	eventGen      event.Interface
 	metrics       metrics.MetricsConfigManager
+	ownerResolver matchutils.OwnerResolver
 }
 
 func (h *generationHandler) Handle(
@@ -308,6 +312,8 @@ func (h *generationHandler) processRequest(ctx context.Context, policyContext *e
 			if rule.Name == pRuleName && rule.Generation.Synchronize {
 				gvk, subresource := policyContext.ResourceKind()
 				if err := engineutils.MatchesResourceDescription(
+					ctx,
+					h.ownerResolver,
 					old,
 					rule,
 					policyContext.AdmissionInfo(),
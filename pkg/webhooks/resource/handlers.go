@@ -3,6 +3,8 @@ package resource
 import (
 	"context"
 	"errors"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -16,13 +18,17 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/imageverifystamp"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/policycache"
+	"github.com/kyverno/kyverno/pkg/toggle"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
 	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+	matchutils "github.com/kyverno/kyverno/pkg/utils/match"
 	"github.com/kyverno/kyverno/pkg/webhooks"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"github.com/kyverno/kyverno/pkg/webhooks/resource/canary"
 	"github.com/kyverno/kyverno/pkg/webhooks/resource/imageverification"
 	"github.com/kyverno/kyverno/pkg/webhooks/resource/mutation"
 	"github.com/kyverno/kyverno/pkg/webhooks/resource/validation"
@@ -32,6 +38,11 @@ import (
 	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
+var (
+	validatePolicyTypes = []policycache.PolicyType{policycache.ValidateEnforce, policycache.ValidateAudit, policycache.Mutate, policycache.Generate, policycache.VerifyImagesValidate}
+	mutatePolicyTypes   = []policycache.PolicyType{policycache.Mutate, policycache.VerifyImagesMutate}
+)
+
 type resourceHandlers struct {
 	// clients
 	client        dclient.Interface
@@ -54,9 +65,18 @@ type resourceHandlers struct {
 	urGenerator webhookgenerate.Generator
 	eventGen    event.Interface
 	pcBuilder   webhookutils.PolicyContextBuilder
+	canary      canary.Recorder
+	stampSigner imageverifystamp.Signer
+	// ownerResolver resolves the owners of resources matched by owner
+	ownerResolver matchutils.OwnerResolver
+
+	inflight inflightRequests
 
 	admissionReports             bool
+	auditLatencyBudget           time.Duration
 	backgroundServiceAccountName string
+	// webhookTimeout is the timeout of the webhooks not grouped by timeout, it bounds the deduplicated requests
+	webhookTimeout time.Duration
 }
 
 func NewHandlers(
@@ -72,9 +92,14 @@ func NewHandlers(
 	polInformer kyvernov1informers.PolicyInformer,
 	urGenerator webhookgenerate.Generator,
 	eventGen event.Interface,
+	canaryRecorder canary.Recorder,
 	admissionReports bool,
+	auditLatencyBudget time.Duration,
 	backgroundServiceAccountName string,
+	webhookTimeout time.Duration,
 	jp jmespath.Interface,
+	stampSigner imageverifystamp.Signer,
+	ownerResolver matchutils.OwnerResolver,
 ) webhooks.ResourceHandlers {
 	return &resourceHandlers{
 		engine:                       engine,
@@ -90,12 +115,27 @@ func NewHandlers(
 		urGenerator:                  urGenerator,
 		eventGen:                     eventGen,
 		pcBuilder:                    webhookutils.NewPolicyContextBuilder(configuration, jp),
+		canary:                       canaryRecorder,
+		stampSigner:                  stampSigner,
+		ownerResolver:                ownerResolver,
 		admissionReports:             admissionReports,
+		auditLatencyBudget:           auditLatencyBudget,
 		backgroundServiceAccountName: backgroundServiceAccountName,
+		webhookTimeout:               webhookTimeout,
 	}
 }
 
 func (h *resourceHandlers) Validate(ctx context.Context, logger logr.Logger, request handlers.AdmissionRequest, failurePolicy string, startTime time.Time) handlers.AdmissionResponse {
+	response := h.deduplicate(ctx, logger, "validate", request, failurePolicy, validatePolicyTypes, func(ctx context.Context) handlers.AdmissionResponse {
+		return h.validate(ctx, logger, request, failurePolicy, startTime)
+	})
+	if toggle.FromContext(ctx).ShadowMode() {
+		return shadowResponse(logger, response)
+	}
+	return response
+}
+
+func (h *resourceHandlers) validate(ctx context.Context, logger logr.Logger, request handlers.AdmissionRequest, failurePolicy string, startTime time.Time) handlers.AdmissionResponse {
 	kind := request.Kind.Kind
 	logger = logger.WithValues("kind", kind)
 	logger.V(4).Info("received an admission request in validating webhook")
@@ -106,13 +146,21 @@ func (h *resourceHandlers) Validate(ctx context.Context, logger logr.Logger, req
 	mutatePolicies := filterPolicies(ctx, failurePolicy, h.pCache.GetPolicies(policycache.Mutate, gvr, request.SubResource, request.Namespace)...)
 	generatePolicies := filterPolicies(ctx, failurePolicy, h.pCache.GetPolicies(policycache.Generate, gvr, request.SubResource, request.Namespace)...)
 	imageVerifyValidatePolicies := filterPolicies(ctx, failurePolicy, h.pCache.GetPolicies(policycache.VerifyImagesValidate, gvr, request.SubResource, request.Namespace)...)
+	// canary policies are only evaluated to record the outcome of their validate rules, other rules are not applied
+	policies, canaryPolicies := canary.Split(policies...)
+	imageVerifyValidatePolicies, _ = canary.Split(imageVerifyValidatePolicies...)
 	policies = append(policies, imageVerifyValidatePolicies...)
+	_, auditCanaryPolicies := canary.Split(filterPolicies(ctx, failurePolicy, h.pCache.GetPolicies(policycache.ValidateAudit, gvr, request.SubResource, request.Namespace)...)...)
+	canaryPolicies = append(canaryPolicies, auditCanaryPolicies...)
+	mutatePolicies, _ = canary.Split(mutatePolicies...)
+	generatePolicies, _ = canary.Split(generatePolicies...)
 
 	if len(policies) == 0 && len(mutatePolicies) == 0 && len(generatePolicies) == 0 {
 		logger.V(4).Info("no policies matched admission request")
 	}
 
-	logger.V(4).Info("processing policies for validate admission request", "validate", len(policies), "mutate", len(mutatePolicies), "generate", len(generatePolicies))
+	logger.V(4).Info("processing policies for validate admission request", "validate", len(policies), "mutate", len(mutatePolicies), "generate", len(generatePolicies), "canary", len(canaryPolicies))
+	go h.handleCanary(context.WithoutCancel(ctx), logger, request, canaryPolicies, h.engine.Validate)
 
 	policyContext, err := h.pcBuilder.Build(request.AdmissionRequest, request.Roles, request.ClusterRoles, request.GroupVersionKind)
 	if err != nil {
@@ -124,28 +172,48 @@ func (h *resourceHandlers) Validate(ctx context.Context, logger logr.Logger, req
 		namespaceLabels = engineutils.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, logger)
 	}
 	policyContext = policyContext.WithNamespaceLabels(namespaceLabels)
-	vh := validation.NewValidationHandler(logger, h.kyvernoClient, h.engine, h.pCache, h.pcBuilder, h.eventGen, h.admissionReports, h.metricsConfig, h.configuration)
+	vh := validation.NewValidationHandler(logger, h.kyvernoClient, h.engine, h.pCache, h.pcBuilder, h.eventGen, h.admissionReports, h.auditLatencyBudget, h.metricsConfig, h.configuration, h.ownerResolver)
 
 	ok, msg, warnings := vh.HandleValidation(ctx, request, policies, policyContext, startTime)
 	if !ok {
 		logger.Info("admission request denied")
 		return admissionutils.Response(request.UID, errors.New(msg), warnings...)
 	}
-	if !admissionutils.IsDryRun(request.AdmissionRequest) {
+	// in shadow mode the cluster must not be changed, generate and mutate existing policies are not applied
+	if !admissionutils.IsDryRun(request.AdmissionRequest) && !toggle.FromContext(ctx).ShadowMode() {
 		go h.handleBackgroundApplies(ctx, logger, request.AdmissionRequest, policyContext, generatePolicies, mutatePolicies, startTime)
 	}
 	return admissionutils.ResponseSuccess(request.UID, warnings...)
 }
 
 func (h *resourceHandlers) Mutate(ctx context.Context, logger logr.Logger, request handlers.AdmissionRequest, failurePolicy string, startTime time.Time) handlers.AdmissionResponse {
+	response := h.deduplicate(ctx, logger, "mutate", request, failurePolicy, mutatePolicyTypes, func(ctx context.Context) handlers.AdmissionResponse {
+		return h.mutate(ctx, logger, request, failurePolicy, startTime)
+	})
+	if toggle.FromContext(ctx).ShadowMode() {
+		return shadowResponse(logger, response)
+	}
+	return response
+}
+
+func (h *resourceHandlers) mutate(ctx context.Context, logger logr.Logger, request handlers.AdmissionRequest, failurePolicy string, startTime time.Time) handlers.AdmissionResponse {
 	kind := request.Kind.Kind
 	logger = logger.WithValues("kind", kind)
 	logger.V(4).Info("received an admission request in mutating webhook")
 	gvr := schema.GroupVersionResource(request.Resource)
 	mutatePolicies := filterPolicies(ctx, failurePolicy, h.pCache.GetPolicies(policycache.Mutate, gvr, request.SubResource, request.Namespace)...)
 	verifyImagesPolicies := filterPolicies(ctx, failurePolicy, h.pCache.GetPolicies(policycache.VerifyImagesMutate, gvr, request.SubResource, request.Namespace)...)
+	// canary policies are only evaluated to record the outcome of their mutate rules, their patches are never applied
+	mutatePolicies, canaryPolicies := canary.Split(mutatePolicies...)
+	verifyImagesPolicies, _ = canary.Split(verifyImagesPolicies...)
+	go h.handleCanary(context.WithoutCancel(ctx), logger, request, canaryPolicies, h.engine.Mutate)
+	// image verification stamps must come from Kyverno, whether policies match the request or not
+	stampPatch := imageverification.UntrustedStampPatch(logger, h.stampSigner, request.AdmissionRequest)
 	if len(mutatePolicies) == 0 && len(verifyImagesPolicies) == 0 {
 		logger.V(4).Info("no policies matched mutate admission request")
+		if stampPatch != nil {
+			return admissionutils.MutationResponse(request.UID, stampPatch)
+		}
 		return admissionutils.ResponseSuccess(request.UID)
 	}
 	logger.V(4).Info("processing policies for mutate admission request", "mutatePolicies", len(mutatePolicies), "verifyImagesPolicies", len(verifyImagesPolicies))
@@ -167,22 +235,49 @@ func (h *resourceHandlers) Mutate(ctx context.Context, logger logr.Logger, reque
 		logger.Error(err, "failed to build policy context")
 		return admissionutils.Response(request.UID, err)
 	}
-	ivh := imageverification.NewImageVerificationHandler(logger, h.kyvernoClient, h.engine, h.eventGen, h.admissionReports, h.configuration, h.nsLister)
+	ivh := imageverification.NewImageVerificationHandler(logger, h.kyvernoClient, h.engine, h.eventGen, h.admissionReports, h.configuration, h.nsLister, h.stampSigner)
 	imagePatches, imageVerifyWarnings, err := ivh.Handle(ctx, newRequest, verifyImagesPolicies, policyContext)
 	if err != nil {
 		logger.Error(err, "image verification failed")
 		return admissionutils.Response(request.UID, err)
 	}
-	patch := jsonutils.JoinPatches(mutatePatches, imagePatches)
+	patch := jsonutils.JoinPatches(stampPatch, mutatePatches, imagePatches)
 	var warnings []string
 	warnings = append(warnings, mutateWarnings...)
 	warnings = append(warnings, imageVerifyWarnings...)
 	return admissionutils.MutationResponse(request.UID, patch, warnings...)
 }
 
+// shadowResponse allows the request without patches, the outcome it replaces is only logged.
+// Reports, events and metrics were already recorded when the request was evaluated.
+func shadowResponse(logger logr.Logger, response handlers.AdmissionResponse) handlers.AdmissionResponse {
+	if !response.Allowed {
+		var message string
+		if response.Result != nil {
+			message = response.Result.Message
+		}
+		logger.Info("admission request would have been denied (shadow mode)", "message", message)
+	}
+	if len(response.Patch) != 0 {
+		logger.V(2).Info("admission request would have been mutated (shadow mode)", "patch", string(response.Patch))
+	}
+	return admissionutils.ResponseSuccess(response.UID, response.Warnings...)
+}
+
+// filterPolicies returns the policies evaluated by a webhook, identified by its failure policy (`ignore` or `fail`)
+// optionally followed by the timeout declared by the policies (`fail/15`, or `fail/default` when not declared)
+// and, for mutating webhooks, by the reinvocation policy (`fail/default/ifneeded`).
 func filterPolicies(ctx context.Context, failurePolicy string, policies ...kyvernov1.PolicyInterface) []kyvernov1.PolicyInterface {
+	failurePolicy, timeout, grouped := strings.Cut(failurePolicy, "/")
+	timeout, reinvocation, reinvocationGrouped := strings.Cut(timeout, "/")
 	var results []kyvernov1.PolicyInterface
 	for _, policy := range policies {
+		if grouped && !matchesWebhookTimeout(policy.GetSpec().WebhookTimeoutSeconds, timeout) {
+			continue
+		}
+		if reinvocationGrouped && !strings.EqualFold(string(policy.GetSpec().GetReinvocationPolicy()), reinvocation) {
+			continue
+		}
 		if failurePolicy == "fail" {
 			if policy.GetSpec().GetFailurePolicy(ctx) == kyvernov1.Fail {
 				results = append(results, policy)
@@ -197,3 +292,10 @@ func filterPolicies(ctx context.Context, failurePolicy string, policies ...kyver
 	}
 	return results
 }
+
+func matchesWebhookTimeout(declared *int32, timeout string) bool {
+	if declared == nil {
+		return timeout == "default"
+	}
+	return timeout == strconv.Itoa(int(*declared))
+}
@@ -3,17 +3,21 @@ package resource
 import (
 	"context"
 	"encoding/json"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
 	log "github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/policycache"
+	"github.com/kyverno/kyverno/pkg/toggle"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
 	"gotest.tools/assert"
 	v1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 var policyCheckLabel = `{
@@ -271,7 +275,7 @@ func Test_AdmissionResponseValid(t *testing.T) {
 	assert.NilError(t, err)
 
 	key := makeKey(&validPolicy)
-	policyCache.Set(key, &validPolicy, policycache.TestResourceFinder{})
+	policyCache.Set(key, &validPolicy, policycache.TestResourceFinder{}, nil)
 
 	request := handlers.AdmissionRequest{
 		AdmissionRequest: v1.AdmissionRequest{
@@ -293,7 +297,7 @@ func Test_AdmissionResponseValid(t *testing.T) {
 	assert.Equal(t, len(response.Warnings), 0)
 
 	validPolicy.Spec.ValidationFailureAction = "Enforce"
-	policyCache.Set(key, &validPolicy, policycache.TestResourceFinder{})
+	policyCache.Set(key, &validPolicy, policycache.TestResourceFinder{}, nil)
 
 	response = resourceHandlers.Validate(ctx, logger, request, "", time.Now())
 	assert.Equal(t, response.Allowed, false)
@@ -329,7 +333,7 @@ func Test_AdmissionResponseInvalid(t *testing.T) {
 
 	keyInvalid := makeKey(&invalidPolicy)
 	invalidPolicy.Spec.ValidationFailureAction = "Enforce"
-	policyCache.Set(keyInvalid, &invalidPolicy, policycache.TestResourceFinder{})
+	policyCache.Set(keyInvalid, &invalidPolicy, policycache.TestResourceFinder{}, nil)
 
 	response := resourceHandlers.Validate(ctx, logger, request, "", time.Now())
 	assert.Equal(t, response.Allowed, false)
@@ -337,7 +341,7 @@ func Test_AdmissionResponseInvalid(t *testing.T) {
 
 	var ignore kyverno.FailurePolicyType = kyverno.Ignore
 	invalidPolicy.Spec.FailurePolicy = &ignore
-	policyCache.Set(keyInvalid, &invalidPolicy, policycache.TestResourceFinder{})
+	policyCache.Set(keyInvalid, &invalidPolicy, policycache.TestResourceFinder{}, nil)
 
 	response = resourceHandlers.Validate(ctx, logger, request, "", time.Now())
 	assert.Equal(t, response.Allowed, true)
@@ -358,7 +362,7 @@ func Test_ImageVerify(t *testing.T) {
 	assert.NilError(t, err)
 
 	key := makeKey(&policy)
-	policyCache.Set(key, &policy, policycache.TestResourceFinder{})
+	policyCache.Set(key, &policy, policycache.TestResourceFinder{}, nil)
 
 	request := handlers.AdmissionRequest{
 		AdmissionRequest: v1.AdmissionRequest{
@@ -373,7 +377,7 @@ func Test_ImageVerify(t *testing.T) {
 	}
 
 	policy.Spec.ValidationFailureAction = "Enforce"
-	policyCache.Set(key, &policy, policycache.TestResourceFinder{})
+	policyCache.Set(key, &policy, policycache.TestResourceFinder{}, nil)
 
 	response := resourceHandlers.Mutate(ctx, logger, request, "", time.Now())
 	assert.Equal(t, response.Allowed, false)
@@ -381,7 +385,7 @@ func Test_ImageVerify(t *testing.T) {
 
 	var ignore kyverno.FailurePolicyType = kyverno.Ignore
 	policy.Spec.FailurePolicy = &ignore
-	policyCache.Set(key, &policy, policycache.TestResourceFinder{})
+	policyCache.Set(key, &policy, policycache.TestResourceFinder{}, nil)
 
 	response = resourceHandlers.Mutate(ctx, logger, request, "", time.Now())
 	assert.Equal(t, response.Allowed, false)
@@ -402,7 +406,7 @@ func Test_MutateAndVerify(t *testing.T) {
 	assert.NilError(t, err)
 
 	key := makeKey(&policy)
-	policyCache.Set(key, &policy, policycache.TestResourceFinder{})
+	policyCache.Set(key, &policy, policycache.TestResourceFinder{}, nil)
 
 	request := handlers.AdmissionRequest{
 		AdmissionRequest: v1.AdmissionRequest{
@@ -421,6 +425,96 @@ func Test_MutateAndVerify(t *testing.T) {
 	assert.Equal(t, len(response.Warnings), 0)
 }
 
+var policyAddLabel = `{
+	"apiVersion": "kyverno.io/v1",
+	"kind": "ClusterPolicy",
+	"metadata": {
+	   "name": "add-label-app"
+	},
+	"spec": {
+	   "rules": [
+		  {
+			 "name": "add-label-app",
+			 "match": {
+				"resources": {
+				   "kinds": [
+					  "Pod"
+				   ]
+				}
+			 },
+			 "mutate": {
+				"patchStrategicMerge": {
+					"metadata": {
+						"labels": {
+							"app": "nginx"
+						}
+					}
+				}
+			}
+		  }
+	   ]
+	}
+ }
+`
+
+type shadowToggles struct {
+	toggle.Toggles
+}
+
+func (shadowToggles) ShadowMode() bool {
+	return true
+}
+
+func Test_ShadowMode(t *testing.T) {
+	policyCache := policycache.NewCache()
+	logger := log.WithName("Test_ShadowMode")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	resourceHandlers := NewFakeHandlers(ctx, policyCache)
+	shadowCtx := toggle.NewContext(ctx, shadowToggles{toggle.FromContext(ctx)})
+
+	var validatePolicy kyverno.ClusterPolicy
+	err := json.Unmarshal([]byte(policyCheckLabel), &validatePolicy)
+	assert.NilError(t, err)
+	validatePolicy.Spec.ValidationFailureAction = "Enforce"
+	policyCache.Set(makeKey(&validatePolicy), &validatePolicy, policycache.TestResourceFinder{}, nil)
+
+	request := handlers.AdmissionRequest{
+		AdmissionRequest: v1.AdmissionRequest{
+			Operation: v1.Create,
+			Kind:      metav1.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"},
+			Resource:  metav1.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"},
+			Object: runtime.RawExtension{
+				Raw: []byte(pod),
+			},
+			RequestResource: &metav1.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"},
+		},
+	}
+
+	response := resourceHandlers.Validate(ctx, logger, request, "", time.Now())
+	assert.Equal(t, response.Allowed, false)
+
+	response = resourceHandlers.Validate(shadowCtx, logger, request, "", time.Now())
+	assert.Equal(t, response.Allowed, true)
+	assert.Assert(t, response.Result == nil)
+
+	var mutatePolicy kyverno.ClusterPolicy
+	err = json.Unmarshal([]byte(policyAddLabel), &mutatePolicy)
+	assert.NilError(t, err)
+	policyCache.Set(makeKey(&mutatePolicy), &mutatePolicy, policycache.TestResourceFinder{}, nil)
+
+	response = resourceHandlers.Mutate(ctx, logger, request, "", time.Now())
+	assert.Equal(t, response.Allowed, true)
+	assert.Assert(t, len(response.Patch) != 0)
+
+	response = resourceHandlers.Mutate(shadowCtx, logger, request, "", time.Now())
+	assert.Equal(t, response.Allowed, true)
+	assert.Assert(t, len(response.Patch) == 0)
+	assert.Assert(t, response.PatchType == nil)
+}
+
 func makeKey(policy kyverno.PolicyInterface) string {
 	name := policy.GetName()
 	namespace := policy.GetNamespace()
@@ -430,3 +524,201 @@ func makeKey(policy kyverno.PolicyInterface) string {
 
 	return namespace + "/" + name
 }
+
+func Test_filterPolicies(t *testing.T) {
+	ignore, fail := kyverno.Ignore, kyverno.Fail
+	timeout := int32(15)
+	policies := []kyverno.PolicyInterface{
+		&kyverno.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "ignore"}, Spec: kyverno.Spec{FailurePolicy: &ignore}},
+		&kyverno.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "fail"}, Spec: kyverno.Spec{FailurePolicy: &fail}},
+		&kyverno.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "fail-15"}, Spec: kyverno.Spec{FailurePolicy: &fail, WebhookTimeoutSeconds: &timeout}},
+	}
+	names := func(policies []kyverno.PolicyInterface) []string {
+		var names []string
+		for _, policy := range policies {
+			names = append(names, policy.GetName())
+		}
+		return names
+	}
+	ctx := context.Background()
+	assert.DeepEqual(t, names(filterPolicies(ctx, "all", policies...)), []string{"ignore", "fail", "fail-15"})
+	assert.DeepEqual(t, names(filterPolicies(ctx, "fail", policies...)), []string{"fail", "fail-15"})
+	assert.DeepEqual(t, names(filterPolicies(ctx, "fail/default", policies...)), []string{"fail"})
+	assert.DeepEqual(t, names(filterPolicies(ctx, "fail/15", policies...)), []string{"fail-15"})
+	assert.DeepEqual(t, names(filterPolicies(ctx, "ignore/15", policies...)), []string(nil))
+}
+
+func Test_filterPolicies_ReinvocationPolicy(t *testing.T) {
+	never := kyverno.NeverReinvocationPolicy
+	policies := []kyverno.PolicyInterface{
+		&kyverno.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "never"}, Spec: kyverno.Spec{ReinvocationPolicy: &never}},
+		&kyverno.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "if-needed"}},
+		&kyverno.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "verify-images"}, Spec: kyverno.Spec{Rules: []kyverno.Rule{{
+			Name:         "verify",
+			VerifyImages: []kyverno.ImageVerification{{ImageReferences: []string{"ghcr.io/*"}}},
+		}}}},
+	}
+	names := func(policies []kyverno.PolicyInterface) []string {
+		var names []string
+		for _, policy := range policies {
+			names = append(names, policy.GetName())
+		}
+		return names
+	}
+	ctx := context.Background()
+	assert.DeepEqual(t, names(filterPolicies(ctx, "fail/default", policies...)), []string{"never", "if-needed", "verify-images"})
+	assert.DeepEqual(t, names(filterPolicies(ctx, "fail/default/never", policies...)), []string{"never"})
+	assert.DeepEqual(t, names(filterPolicies(ctx, "fail/default/ifneeded", policies...)), []string{"if-needed", "verify-images"})
+	assert.DeepEqual(t, names(filterPolicies(ctx, "fail/15/ifneeded", policies...)), []string(nil))
+}
+
+type deduplicationToggles struct {
+	toggle.Toggles
+}
+
+func (deduplicationToggles) AdmissionDeduplication() bool {
+	return true
+}
+
+func Test_deduplicationKey(t *testing.T) {
+	request := handlers.AdmissionRequest{
+		AdmissionRequest: v1.AdmissionRequest{
+			UID:       "first",
+			Operation: v1.Create,
+			Kind:      metav1.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"},
+			Resource:  metav1.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"},
+			Namespace: "default",
+			Name:      "nginx",
+			Object:    runtime.RawExtension{Raw: []byte(pod)},
+		},
+	}
+	policy := &kyverno.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "check-label-app", ResourceVersion: "1"}}
+	key, ok := deduplicationKey("validate", "fail", request, policy)
+	assert.Assert(t, ok)
+	// the request uid is not part of the key
+	retry := request
+	retry.UID = "second"
+	retryKey, _ := deduplicationKey("validate", "fail", retry, policy)
+	assert.Equal(t, key, retryKey)
+	// a different user, webhook or policy version gives a different key
+	other := request
+	other.UserInfo.Username = "admin"
+	otherKey, _ := deduplicationKey("validate", "fail", other, policy)
+	assert.Assert(t, key != otherKey)
+	otherKey, _ = deduplicationKey("mutate", "fail", request, policy)
+	assert.Assert(t, key != otherKey)
+	updated := policy.DeepCopy()
+	updated.ResourceVersion = "2"
+	otherKey, _ = deduplicationKey("validate", "fail", request, updated)
+	assert.Assert(t, key != otherKey)
+	// objects created with a generate name are not deduplicated
+	generated := request
+	generated.Name = ""
+	_, ok = deduplicationKey("validate", "fail", generated, policy)
+	assert.Assert(t, !ok)
+}
+
+func Test_deduplicate(t *testing.T) {
+	logger := log.WithName("Test_deduplicate")
+	ctx := toggle.NewContext(context.Background(), deduplicationToggles{toggle.FromContext(context.Background())})
+	h := &resourceHandlers{pCache: policycache.NewCache()}
+	request := handlers.AdmissionRequest{
+		AdmissionRequest: v1.AdmissionRequest{
+			Operation: v1.Create,
+			Kind:      metav1.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"},
+			Resource:  metav1.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"},
+			Name:      "nginx",
+			Object:    runtime.RawExtension{Raw: []byte(pod)},
+		},
+	}
+	var calls atomic.Int32
+	started, joined := make(chan struct{}), make(chan struct{})
+	h.inflight.joined = func(string) { close(joined) }
+	process := func(context.Context) handlers.AdmissionResponse {
+		if calls.Add(1) == 1 {
+			close(started)
+		}
+		// keep the first request in flight until the second one has joined it
+		<-joined
+		return handlers.AdmissionResponse{UID: "first", Allowed: true, Warnings: []string{"shared"}}
+	}
+	responses := make([]handlers.AdmissionResponse, 2)
+	var wg sync.WaitGroup
+	for i, uid := range []types.UID{"first", "second"} {
+		request := request
+		request.UID = uid
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			responses[i] = h.deduplicate(ctx, logger, "validate", request, "fail", validatePolicyTypes, process)
+		}(i)
+		if i == 0 {
+			<-started
+		}
+	}
+	wg.Wait()
+	assert.Equal(t, calls.Load(), int32(1))
+	assert.Equal(t, responses[0].UID, types.UID("first"))
+	assert.Equal(t, responses[1].UID, types.UID("second"))
+	assert.DeepEqual(t, responses[1].Warnings, []string{"shared"})
+}
+
+func Test_deduplicate_FirstCallerCancelled(t *testing.T) {
+	logger := log.WithName("Test_deduplicate_FirstCallerCancelled")
+	ctx := toggle.NewContext(context.Background(), deduplicationToggles{toggle.FromContext(context.Background())})
+	h := &resourceHandlers{pCache: policycache.NewCache(), webhookTimeout: 10 * time.Second}
+	request := handlers.AdmissionRequest{
+		AdmissionRequest: v1.AdmissionRequest{
+			Operation: v1.Create,
+			Kind:      metav1.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"},
+			Resource:  metav1.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"},
+			Name:      "nginx",
+			Object:    runtime.RawExtension{Raw: []byte(pod)},
+		},
+	}
+	var calls atomic.Int32
+	started, joined, release := make(chan struct{}), make(chan struct{}), make(chan struct{})
+	h.inflight.joined = func(string) { close(joined) }
+	var processErr error
+	var deadline bool
+	process := func(ctx context.Context) handlers.AdmissionResponse {
+		calls.Add(1)
+		close(started)
+		<-release
+		processErr = ctx.Err()
+		_, deadline = ctx.Deadline()
+		return handlers.AdmissionResponse{UID: "first", Allowed: true}
+	}
+	first, second := request, request
+	first.UID, second.UID = "first", "second"
+	firstCtx, cancel := context.WithCancel(ctx)
+	firstResponse, secondResponse := make(chan handlers.AdmissionResponse), make(chan handlers.AdmissionResponse)
+	go func() {
+		firstResponse <- h.deduplicate(firstCtx, logger, "validate", first, "fail/15", validatePolicyTypes, process)
+	}()
+	<-started
+	go func() {
+		secondResponse <- h.deduplicate(ctx, logger, "validate", second, "fail/15", validatePolicyTypes, process)
+	}()
+	<-joined
+	// the first caller gives up, the request keeps being processed for the second one
+	cancel()
+	response := <-firstResponse
+	assert.Equal(t, response.UID, types.UID("first"))
+	assert.Assert(t, !response.Allowed)
+	close(release)
+	response = <-secondResponse
+	assert.Equal(t, response.UID, types.UID("second"))
+	assert.Assert(t, response.Allowed)
+	assert.Equal(t, calls.Load(), int32(1))
+	assert.NilError(t, processErr)
+	assert.Assert(t, deadline)
+}
+
+func Test_deduplicationTimeout(t *testing.T) {
+	h := &resourceHandlers{webhookTimeout: 10 * time.Second}
+	assert.Equal(t, h.deduplicationTimeout("fail"), 10*time.Second)
+	assert.Equal(t, h.deduplicationTimeout("fail/default"), 10*time.Second)
+	assert.Equal(t, h.deduplicationTimeout("ignore/15"), 15*time.Second)
+	assert.Equal(t, h.deduplicationTimeout("ignore/15/ifneeded"), 15*time.Second)
+}
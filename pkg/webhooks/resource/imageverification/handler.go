@@ -2,17 +2,22 @@ package imageverification
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/decisionlog"
 	"github.com/kyverno/kyverno/pkg/engine"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/imageverifystamp"
+	"github.com/kyverno/kyverno/pkg/toggle"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
@@ -39,6 +44,7 @@ type imageVerificationHandler struct {
 	admissionReports bool
 	cfg              config.Configuration
 	nsLister         corev1listers.NamespaceLister
+	stampSigner      imageverifystamp.Signer
 }
 
 func NewImageVerificationHandler(
@@ -49,6 +55,7 @@ func NewImageVerificationHandler(
 	admissionReports bool,
 	cfg config.Configuration,
 	nsLister corev1listers.NamespaceLister,
+	stampSigner imageverifystamp.Signer,
 ) ImageVerificationHandler {
 	return &imageVerificationHandler{
 		kyvernoClient:    kyvernoClient,
@@ -58,6 +65,7 @@ func NewImageVerificationHandler(
 		admissionReports: admissionReports,
 		cfg:              cfg,
 		nsLister:         nsLister,
+		stampSigner:      stampSigner,
 	}
 }
 
@@ -116,6 +124,7 @@ func (h *imageVerificationHandler) handleVerifyImages(
 		)
 	}
 
+	decisionlog.Record(ctx, engineResponses...)
 	blocked := webhookutils.BlockRequest(engineResponses, failurePolicy, logger)
 	events := webhookutils.GenerateEvents(engineResponses, blocked)
 	h.eventGen.Add(events...)
@@ -125,21 +134,86 @@ func (h *imageVerificationHandler) handleVerifyImages(
 		return false, webhookutils.GetBlockedMessages(engineResponses), nil, nil
 	}
 
+	stream := jsonutils.NewPatchStream()
 	if !verifiedImageData.IsEmpty() {
 		hasAnnotations := hasAnnotations(policyContext)
 		annotationPatches, err := verifiedImageData.Patches(hasAnnotations, logger)
 		if err != nil {
 			logger.Error(err, "failed to create image verification annotation patches")
 		} else {
+			if len(verifiedImageData.Verified) != 0 {
+				if stampPatch, err := h.stampPatch(policyContext.NewResource(), verifiedImageData.Verified); err != nil {
+					logger.Error(err, "failed to create image verification stamp patch")
+				} else {
+					annotationPatches = append(annotationPatches, stampPatch)
+				}
+			}
 			// add annotation patches first
-			patches = append(annotationPatches, patches...)
+			patch.WritePatches(stream, annotationPatches...)
 		}
 	}
 
 	go h.handleAudit(ctx, policyContext.NewResource(), request, nil, engineResponses...)
 
 	warnings := webhookutils.GetWarningMessages(engineResponses)
-	return true, "", jsonutils.JoinPatches(patch.ConvertPatches(patches...)...), warnings
+	patch.WritePatches(stream, patches...)
+	return true, "", stream.Close(), warnings
+}
+
+// stampPatch returns the patch adding the signed stamp of the images verified when admitting the resource.
+func (h *imageVerificationHandler) stampPatch(resource unstructured.Unstructured, images []engineapi.VerifiedImage) (jsonpatch.JsonPatchOperation, error) {
+	if h.stampSigner == nil {
+		return jsonpatch.JsonPatchOperation{}, errors.New("image verification stamps are not enabled, no signing secret configured")
+	}
+	value, err := h.stampSigner.Sign(imageverifystamp.NewResource(resource.GetAPIVersion(), resource.GetKind(), &resource), images...)
+	if err != nil {
+		return jsonpatch.JsonPatchOperation{}, err
+	}
+	return jsonpatch.JsonPatchOperation{
+		Operation: "add",
+		Path:      jsonutils.JoinPointer("metadata", "annotations", kyverno.AnnotationImageVerifyStamp),
+		Value:     value,
+	}, nil
+}
+
+// UntrustedStampPatch returns the patch removing the kyverno.io/verified-images annotation of the admitted resource when
+// Kyverno did not produce it. The annotation is kept when an update doesn't change it, or when it was signed by Kyverno for
+// the resource, the mutating webhook being reinvoked receives the annotation Kyverno added.
+func UntrustedStampPatch(logger logr.Logger, signer imageverifystamp.Signer, request admissionv1.AdmissionRequest) []byte {
+	if request.Operation != admissionv1.Create && request.Operation != admissionv1.Update {
+		return nil
+	}
+	object, oldObject, err := admissionutils.GetPartialObjectMetadatas(request)
+	if err != nil || object == nil {
+		return nil
+	}
+	value, ok := object.GetAnnotations()[kyverno.AnnotationImageVerifyStamp]
+	if !ok {
+		return nil
+	}
+	if oldObject != nil {
+		if oldValue, ok := oldObject.GetAnnotations()[kyverno.AnnotationImageVerifyStamp]; ok && oldValue == value {
+			return nil
+		}
+	}
+	if signer != nil {
+		err = signer.Verify(value, imageverifystamp.NewResource(object.APIVersion, object.Kind, object))
+		if err == nil {
+			return nil
+		}
+	} else {
+		err = errors.New("image verification stamps are not enabled")
+	}
+	logger.Info("removing image verification stamp not produced by Kyverno", "reason", err.Error())
+	patch, err := json.Marshal([]jsonpatch.JsonPatchOperation{{
+		Operation: "remove",
+		Path:      jsonutils.JoinPointer("metadata", "annotations", kyverno.AnnotationImageVerifyStamp),
+	}})
+	if err != nil {
+		logger.Error(err, "failed to create image verification stamp removal patch")
+		return nil
+	}
+	return patch
 }
 
 func hasAnnotations(context *engine.PolicyContext) bool {
@@ -173,7 +247,13 @@ func (v *imageVerificationHandler) handleAudit(
 		fmt.Sprintf("AUDIT %s %s", request.Operation, request.Kind),
 		func(ctx context.Context, span trace.Span) {
 			if createReport {
-				report := reportutils.BuildAdmissionReport(resource, request, engineResponses...)
+				report := reportutils.BuildAdmissionReport(resource, request, v.cfg, engineResponses...)
+				tracing.SetTraceParent(ctx, report)
+				if toggle.FromContext(ctx).ReportUpdateDiff() {
+					if err := reportutils.SetUpdateDiff(report, request); err != nil {
+						v.log.Error(err, "failed to compute update diff")
+					}
+				}
 				if len(report.GetResults()) > 0 {
 					_, err := reportutils.CreateReport(context.Background(), report, v.kyvernoClient)
 					if err != nil {
@@ -0,0 +1,96 @@
+package imageverification
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/imageverifystamp"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type keySigner []byte
+
+func (s keySigner) Sign(resource imageverifystamp.Resource, images ...engineapi.VerifiedImage) (string, error) {
+	return imageverifystamp.Sign(s, time.Now(), resource, images...)
+}
+
+func (s keySigner) Verify(value string, resource imageverifystamp.Resource) error {
+	_, err := imageverifystamp.Verify(s, value, resource)
+	return err
+}
+
+func newPod(t *testing.T, name string, stamp string) runtime.RawExtension {
+	t.Helper()
+	metadata := map[string]interface{}{"name": name, "namespace": "default"}
+	if stamp != "" {
+		metadata["annotations"] = map[string]interface{}{kyverno.AnnotationImageVerifyStamp: stamp}
+	}
+	raw, err := json.Marshal(map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "metadata": metadata})
+	assert.NilError(t, err)
+	return runtime.RawExtension{Raw: raw}
+}
+
+func Test_UntrustedStampPatch(t *testing.T) {
+	signer := keySigner("secret")
+	nginx := imageverifystamp.Resource{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "nginx"}
+	stamp, err := signer.Sign(nginx, engineapi.VerifiedImage{Image: "nginx", Digest: "sha256:abc"})
+	assert.NilError(t, err)
+	forged, err := keySigner("other").Sign(nginx)
+	assert.NilError(t, err)
+	remove := fmt.Sprintf(`[{"op":"remove","path":"/metadata/annotations/%s"}]`, "kyverno.io~1verified-images")
+	tests := []struct {
+		name    string
+		signer  imageverifystamp.Signer
+		request admissionv1.AdmissionRequest
+		want    string
+	}{{
+		name:    "no stamp",
+		signer:  signer,
+		request: admissionv1.AdmissionRequest{Operation: admissionv1.Create, Object: newPod(t, "nginx", "")},
+	}, {
+		// the mutating webhook is reinvoked with the stamp Kyverno added
+		name:    "stamp signed for the resource",
+		signer:  signer,
+		request: admissionv1.AdmissionRequest{Operation: admissionv1.Create, Object: newPod(t, "nginx", stamp)},
+	}, {
+		name:    "stamp copied from another resource",
+		signer:  signer,
+		request: admissionv1.AdmissionRequest{Operation: admissionv1.Create, Object: newPod(t, "other", stamp)},
+		want:    remove,
+	}, {
+		name:    "stamp signed with another key",
+		signer:  signer,
+		request: admissionv1.AdmissionRequest{Operation: admissionv1.Create, Object: newPod(t, "nginx", forged)},
+		want:    remove,
+	}, {
+		name:    "stamps not enabled",
+		request: admissionv1.AdmissionRequest{Operation: admissionv1.Create, Object: newPod(t, "nginx", stamp)},
+		want:    remove,
+	}, {
+		name:    "stamp unchanged by an update",
+		signer:  signer,
+		request: admissionv1.AdmissionRequest{Operation: admissionv1.Update, Object: newPod(t, "other", stamp), OldObject: newPod(t, "other", stamp)},
+	}, {
+		name:    "stamp added by an update",
+		signer:  signer,
+		request: admissionv1.AdmissionRequest{Operation: admissionv1.Update, Object: newPod(t, "other", stamp), OldObject: newPod(t, "other", "")},
+		want:    remove,
+	}, {
+		name:    "stamp replaced by an update",
+		signer:  signer,
+		request: admissionv1.AdmissionRequest{Operation: admissionv1.Update, Object: newPod(t, "nginx", forged), OldObject: newPod(t, "nginx", stamp)},
+		want:    remove,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, string(UntrustedStampPatch(logr.Discard(), tt.signer, tt.request)), tt.want)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/decisionlog"
 	"github.com/kyverno/kyverno/pkg/engine"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
@@ -15,10 +16,12 @@ import (
 	"github.com/kyverno/kyverno/pkg/tracing"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
 	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
 	"go.opentelemetry.io/otel/trace"
 	"gomodules.xyz/jsonpatch/v2"
 	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
@@ -60,35 +63,40 @@ func (h *mutationHandler) HandleMutation(
 	policyContext *engine.PolicyContext,
 	admissionRequestTimestamp time.Time,
 ) ([]byte, []string, error) {
-	mutatePatches, mutateEngineResponses, err := h.applyMutations(ctx, request, policies, policyContext)
+	mutatePatches, warnings, err := h.applyMutations(ctx, request, policies, policyContext)
 	if err != nil {
 		return nil, nil, err
 	}
 	h.log.V(6).Info("", "generated patches", string(mutatePatches))
-	return mutatePatches, webhookutils.GetWarningMessages(mutateEngineResponses), nil
+	return mutatePatches, warnings, nil
 }
 
 // applyMutations handles mutating webhook admission request
-// return value: generated patches, triggered policies, engine responses correspdonding to the triggered policies
+// return value: generated patches, warnings of the applied policies
 func (v *mutationHandler) applyMutations(
 	ctx context.Context,
 	request admissionv1.AdmissionRequest,
 	policies []kyvernov1.PolicyInterface,
 	policyContext *engine.PolicyContext,
-) ([]byte, []engineapi.EngineResponse, error) {
+) ([]byte, []string, error) {
 	if len(policies) == 0 {
 		return nil, nil, nil
 	}
 
-	var patches []jsonpatch.JsonPatchOperation
-	var engineResponses []engineapi.EngineResponse
+	// patches and engine responses are streamed as policies are applied instead of being collected until the end
+	results := newMutationResults()
 	failurePolicy := kyvernov1.Ignore
+	exclusions := policyutils.NewExclusions()
 
 	for _, policy := range policies {
 		spec := policy.GetSpec()
 		if !spec.HasMutate() {
 			continue
 		}
+		if other := exclusions.Excluded(policy); other != "" {
+			v.log.V(3).Info("skipping policy mutually exclusive with an applied policy", "policy", policy.GetName(), "applied", other)
+			continue
+		}
 
 		err := tracing.ChildSpan1(
 			ctx,
@@ -107,7 +115,7 @@ func (v *mutationHandler) applyMutations(
 				}
 
 				if len(policyPatches) > 0 {
-					patches = append(patches, policyPatches...)
+					patch.WritePatches(results.patches, policyPatches...)
 					rules := engineResponse.GetSuccessRules()
 					if len(rules) != 0 {
 						v.log.Info("mutation rules from policy applied successfully", "policy", policy.GetName(), "rules", rules)
@@ -116,24 +124,55 @@ func (v *mutationHandler) applyMutations(
 
 				if engineResponse != nil {
 					policyContext = currentContext.WithNewResource(engineResponse.PatchedResource)
-					engineResponses = append(engineResponses, *engineResponse)
+					results.add(ctx, *engineResponse)
+					if engineResponse.IsApplied() {
+						exclusions.Applied(policy)
+					}
 				}
 
 				return nil
 			},
 		)
 		if err != nil {
+			results.patches.Close()
 			return nil, nil, err
 		}
 	}
 
-	events := webhookutils.GenerateEvents(engineResponses, false)
-	v.eventGen.Add(events...)
+	v.eventGen.Add(results.events...)
 
-	logMutationResponse(patches, engineResponses, v.log)
+	logMutationResponse(results, v.log)
 
 	// patches holds all the successful patches, if no patch is created, it returns nil
-	return jsonutils.JoinPatches(patch.ConvertPatches(patches...)...), engineResponses, nil
+	return results.patches.Close(), results.warnings, nil
+}
+
+// mutationResults holds what the admission response needs from the engine responses. Each engine response is consumed
+// once its policy is applied, the engine responses and the patched resources they reference are not kept until all the
+// policies are applied.
+type mutationResults struct {
+	patches  *jsonutils.PatchStream
+	warnings []string
+	events   []event.Info
+	// failures are the unsuccessful engine responses without their patched resource, they are only kept to log the failed rules
+	failures []engineapi.EngineResponse
+}
+
+func newMutationResults() *mutationResults {
+	return &mutationResults{
+		patches: jsonutils.NewPatchStream(),
+	}
+}
+
+// add consumes an engine response, it is only kept by the decision log when decision logging is enabled
+func (r *mutationResults) add(ctx context.Context, engineResponse engineapi.EngineResponse) {
+	decisionlog.Record(ctx, engineResponse)
+	engineResponses := []engineapi.EngineResponse{engineResponse}
+	r.warnings = append(r.warnings, webhookutils.GetWarningMessages(engineResponses)...)
+	r.events = append(r.events, webhookutils.GenerateEvents(engineResponses, false)...)
+	if !engineResponse.IsSuccessful() {
+		r.failures = append(r.failures, engineResponse.WithPatchedResource(unstructured.Unstructured{}))
+	}
 }
 
 func (h *mutationHandler) applyMutation(ctx context.Context, request admissionv1.AdmissionRequest, policyContext *engine.PolicyContext, failurePolicy kyvernov1.FailurePolicyType) (*engineapi.EngineResponse, []jsonpatch.JsonPatchOperation, error) {
@@ -157,13 +196,13 @@ func (h *mutationHandler) applyMutation(ctx context.Context, request admissionv1
 	return &engineResponse, policyPatches, nil
 }
 
-func logMutationResponse(patches []jsonpatch.JsonPatchOperation, engineResponses []engineapi.EngineResponse, logger logr.Logger) {
-	if len(patches) != 0 {
-		logger.V(4).Info("created patches", "count", len(patches))
+func logMutationResponse(results *mutationResults, logger logr.Logger) {
+	if patches := results.patches.Len(); patches != 0 {
+		logger.V(4).Info("created patches", "count", patches)
 	}
 
 	// if any of the policies fails, print out the error
-	if !engineutils.IsResponseSuccessful(engineResponses) {
-		logger.Error(fmt.Errorf(webhookutils.GetErrorMsg(engineResponses)), "failed to apply mutation rules on the resource, reporting policy violation")
+	if len(results.failures) != 0 {
+		logger.Error(fmt.Errorf(webhookutils.GetErrorMsg(results.failures)), "failed to apply mutation rules on the resource, reporting policy violation")
 	}
 }
@@ -0,0 +1,162 @@
+package mutation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/event"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// fakeEngine labels the resource with the name of the policy applied, or fails the policies listed in failed
+type fakeEngine struct {
+	engineapi.Engine
+	failed map[string]bool
+	// mutated is called before a policy is applied
+	mutated func()
+}
+
+func (e *fakeEngine) Mutate(_ context.Context, policyContext engineapi.PolicyContext) engineapi.EngineResponse {
+	if e.mutated != nil {
+		e.mutated()
+	}
+	name := policyContext.Policy().GetName()
+	response := engineapi.NewEngineResponseFromPolicyContext(policyContext)
+	if e.failed[name] {
+		response.PolicyResponse.Add(engineapi.ExecutionStats{}, *engineapi.NewRuleResponse("mutate", engineapi.Mutation, "failed to patch resource", engineapi.RuleStatusError))
+		return response
+	}
+	resource := policyContext.NewResource()
+	patchedResource := resource.DeepCopy()
+	labels := patchedResource.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[name] = "applied"
+	patchedResource.SetLabels(labels)
+	response.PolicyResponse.Add(engineapi.ExecutionStats{}, *engineapi.NewRuleResponse("mutate", engineapi.Mutation, "mutated", engineapi.RuleStatusPass))
+	return response.WithPatchedResource(*patchedResource)
+}
+
+func newPolicy(t testing.TB, name string) kyvernov1.PolicyInterface {
+	t.Helper()
+	var policy kyvernov1.ClusterPolicy
+	assert.NilError(t, json.Unmarshal([]byte(fmt.Sprintf(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": { "name": %q },
+		"spec": {
+			"failurePolicy": "Ignore",
+			"rules": [{
+				"name": "mutate",
+				"match": { "any": [{ "resources": { "kinds": ["ConfigMap"] } }] },
+				"mutate": { "patchStrategicMerge": { "metadata": { "labels": { %q: "applied" } } } }
+			}]
+		}
+	}`, name, name)), &policy))
+	return &policy
+}
+
+func newPolicyContext(t testing.TB, data map[string]interface{}) *engine.PolicyContext {
+	t.Helper()
+	resource := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "config", "labels": map[string]interface{}{}},
+		"data":       data,
+	}}
+	policyContext, err := engine.NewPolicyContext(jmespath.New(config.NewDefaultConfiguration(false)), resource, kyvernov1.Create, nil, config.NewDefaultConfiguration(false))
+	assert.NilError(t, err)
+	return policyContext
+}
+
+func newHandler(engine engineapi.Engine) *mutationHandler {
+	return &mutationHandler{
+		log:      logr.Discard(),
+		engine:   engine,
+		eventGen: event.NewFake(),
+	}
+}
+
+func Test_applyMutations(t *testing.T) {
+	tests := []struct {
+		name         string
+		policies     []string
+		failed       map[string]bool
+		wantPatches  string
+		wantWarnings []string
+	}{{
+		name: "no policies",
+	}, {
+		name:        "policies applied",
+		policies:    []string{"first", "second"},
+		wantPatches: `[{"op":"add","path":"/metadata/labels/first","value":"applied"}, {"op":"add","path":"/metadata/labels/second","value":"applied"}]`,
+	}, {
+		name:         "policy failed",
+		policies:     []string{"first", "second"},
+		failed:       map[string]bool{"first": true},
+		wantPatches:  `[{"op":"add","path":"/metadata/labels/second","value":"applied"}]`,
+		wantWarnings: []string{"policy first.mutate: failed to patch resource"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var policies []kyvernov1.PolicyInterface
+			for _, name := range tt.policies {
+				policies = append(policies, newPolicy(t, name))
+			}
+			handler := newHandler(&fakeEngine{failed: tt.failed})
+			patches, warnings, err := handler.applyMutations(context.TODO(), admissionv1.AdmissionRequest{}, policies, newPolicyContext(t, nil))
+			assert.NilError(t, err)
+			assert.Equal(t, string(patches), tt.wantPatches)
+			assert.DeepEqual(t, warnings, tt.wantWarnings)
+		})
+	}
+}
+
+func liveHeap() int64 {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return int64(stats.HeapAlloc)
+}
+
+// Test_applyMutations_ReleasesEngineResponses checks that the patched resources of the policies already applied are
+// released, collecting the engine responses kept a copy of the resource for every policy until all of them were applied.
+func Test_applyMutations_ReleasesEngineResponses(t *testing.T) {
+	const count = 20
+	data := map[string]interface{}{}
+	for i := 0; i < 20000; i++ {
+		data[fmt.Sprintf("key-%d", i)] = "value"
+	}
+	policyContext := newPolicyContext(t, data)
+	resource := policyContext.NewResource()
+	before := liveHeap()
+	copied := resource.DeepCopy()
+	resourceSize := liveHeap() - before
+	runtime.KeepAlive(copied)
+
+	var policies []kyvernov1.PolicyInterface
+	for i := 0; i < count; i++ {
+		policies = append(policies, newPolicy(t, fmt.Sprintf("policy-%d", i)))
+	}
+	var heap []int64
+	handler := newHandler(&fakeEngine{mutated: func() { heap = append(heap, liveHeap()) }})
+	patches, _, err := handler.applyMutations(context.TODO(), admissionv1.AdmissionRequest{}, policies, policyContext)
+	assert.NilError(t, err)
+	assert.Assert(t, len(patches) != 0)
+	assert.Equal(t, len(heap), count)
+	growth := heap[count-1] - heap[0]
+	t.Logf("resource size: %d bytes, heap growth over %d policies: %d bytes", resourceSize, count, growth)
+	assert.Assert(t, growth < 3*resourceSize, "heap grew by %d bytes, a resource is %d bytes", growth, resourceSize)
+}
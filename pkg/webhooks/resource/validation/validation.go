@@ -7,17 +7,24 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/decisionlog"
 	"github.com/kyverno/kyverno/pkg/engine"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/policycache"
+	"github.com/kyverno/kyverno/pkg/toggle"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	matchutils "github.com/kyverno/kyverno/pkg/utils/match"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"github.com/kyverno/kyverno/pkg/webhooks/resource/canary"
 	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
 	"go.opentelemetry.io/otel/trace"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -40,32 +47,38 @@ func NewValidationHandler(
 	pcBuilder webhookutils.PolicyContextBuilder,
 	eventGen event.Interface,
 	admissionReports bool,
+	auditLatencyBudget time.Duration,
 	metrics metrics.MetricsConfigManager,
 	cfg config.Configuration,
+	ownerResolver matchutils.OwnerResolver,
 ) ValidationHandler {
 	return &validationHandler{
-		log:              log,
-		kyvernoClient:    kyvernoClient,
-		engine:           engine,
-		pCache:           pCache,
-		pcBuilder:        pcBuilder,
-		eventGen:         eventGen,
-		admissionReports: admissionReports,
-		metrics:          metrics,
-		cfg:              cfg,
+		log:                log,
+		kyvernoClient:      kyvernoClient,
+		engine:             engine,
+		pCache:             pCache,
+		pcBuilder:          pcBuilder,
+		eventGen:           eventGen,
+		admissionReports:   admissionReports,
+		auditLatencyBudget: auditLatencyBudget,
+		metrics:            metrics,
+		cfg:                cfg,
+		ownerResolver:      ownerResolver,
 	}
 }
 
 type validationHandler struct {
-	log              logr.Logger
-	kyvernoClient    versioned.Interface
-	engine           engineapi.Engine
-	pCache           policycache.Cache
-	pcBuilder        webhookutils.PolicyContextBuilder
-	eventGen         event.Interface
-	admissionReports bool
-	metrics          metrics.MetricsConfigManager
-	cfg              config.Configuration
+	log                logr.Logger
+	kyvernoClient      versioned.Interface
+	engine             engineapi.Engine
+	pCache             policycache.Cache
+	pcBuilder          webhookutils.PolicyContextBuilder
+	eventGen           event.Interface
+	admissionReports   bool
+	auditLatencyBudget time.Duration
+	metrics            metrics.MetricsConfigManager
+	cfg                config.Configuration
+	ownerResolver      matchutils.OwnerResolver
 }
 
 func (v *validationHandler) HandleValidation(
@@ -80,7 +93,12 @@ func (v *validationHandler) HandleValidation(
 
 	var engineResponses []engineapi.EngineResponse
 	failurePolicy := kyvernov1.Ignore
+	exclusions := policyutils.NewExclusions()
 	for _, policy := range policies {
+		if other := exclusions.Excluded(policy); other != "" {
+			logger.V(3).Info("skipping policy mutually exclusive with an applied policy", "policy", policy.GetName(), "applied", other)
+			continue
+		}
 		tracing.ChildSpan(
 			ctx,
 			"pkg/webhooks/resource/validate",
@@ -99,6 +117,9 @@ func (v *validationHandler) HandleValidation(
 				}
 
 				engineResponses = append(engineResponses, engineResponse)
+				if engineResponse.IsApplied() {
+					exclusions.Applied(policy)
+				}
 				if !engineResponse.IsSuccessful() {
 					logger.V(2).Info("validation failed", "action", policy.GetSpec().ValidationFailureAction, "policy", policy.GetName(), "failed rules", engineResponse.GetFailedRules())
 					return
@@ -111,6 +132,7 @@ func (v *validationHandler) HandleValidation(
 		)
 	}
 
+	decisionlog.Record(ctx, engineResponses...)
 	blocked := webhookutils.BlockRequest(engineResponses, failurePolicy, logger)
 	events := webhookutils.GenerateEvents(engineResponses, blocked)
 	v.eventGen.Add(events...)
@@ -120,10 +142,26 @@ func (v *validationHandler) HandleValidation(
 		return false, webhookutils.GetBlockedMessages(engineResponses), nil
 	}
 
-	go v.handleAudit(ctx, policyContext.NewResource(), request, policyContext.NamespaceLabels(), engineResponses...)
+	resource, namespaceLabels := policyContext.NewResource(), policyContext.NamespaceLabels()
+	// audit policies emitting warnings are evaluated before responding, the others in background
+	warningResponses, err := v.buildAuditResponses(ctx, resource, request, namespaceLabels, admissionRequestTimestamp, emitsWarning)
+	if err != nil {
+		logger.Error(err, "failed to build audit responses")
+	}
+	auditWarnings := webhookutils.GetAuditWarningMessages(warningResponses)
+	go v.handleAudit(ctx, resource, request, func(ctx context.Context) ([]engineapi.EngineResponse, error) {
+		responses, err := v.buildAuditResponses(ctx, resource, request, namespaceLabels, admissionRequestTimestamp, func(policy kyvernov1.PolicyInterface) bool {
+			return !emitsWarning(policy)
+		})
+		return append(warningResponses, responses...), err
+	}, engineResponses...)
 
 	warnings := webhookutils.GetWarningMessages(engineResponses)
-	return true, "", warnings
+	return true, "", append(warnings, auditWarnings...)
+}
+
+func emitsWarning(policy kyvernov1.PolicyInterface) bool {
+	return policy.GetSpec().EmitWarningEnabled()
 }
 
 func (v *validationHandler) buildAuditResponses(
@@ -131,34 +169,78 @@ func (v *validationHandler) buildAuditResponses(
 	resource unstructured.Unstructured,
 	request handlers.AdmissionRequest,
 	namespaceLabels map[string]string,
+	admissionRequestTimestamp time.Time,
+	filter func(kyvernov1.PolicyInterface) bool,
 ) ([]engineapi.EngineResponse, error) {
 	gvr := schema.GroupVersionResource(request.Resource)
-	policies := v.pCache.GetPolicies(policycache.ValidateAudit, gvr, request.SubResource, request.Namespace)
+	// canary policies are recorded in their own reports
+	policies, _ := canary.Split(v.pCache.GetPolicies(policycache.ValidateAudit, gvr, request.SubResource, request.Namespace)...)
+	if filter != nil {
+		var filtered []kyvernov1.PolicyInterface
+		for _, policy := range policies {
+			if filter(policy) {
+				filtered = append(filtered, policy)
+			}
+		}
+		policies = filtered
+	}
+	if len(policies) == 0 {
+		return nil, nil
+	}
 	policyContext, err := v.pcBuilder.Build(request.AdmissionRequest, request.Roles, request.ClusterRoles, request.GroupVersionKind)
 	if err != nil {
 		return nil, err
 	}
+	policyContext = policyContext.WithNamespaceLabels(namespaceLabels)
+	if v.auditLatencyBudget > 0 {
+		return v.validateWithinBudget(ctx, policyContext, policies, admissionRequestTimestamp.Add(v.auditLatencyBudget)), nil
+	}
 	var responses []engineapi.EngineResponse
 	for _, policy := range policies {
-		tracing.ChildSpan(
-			ctx,
-			"pkg/webhooks/resource/validate",
-			fmt.Sprintf("POLICY %s/%s", policy.GetNamespace(), policy.GetName()),
-			func(ctx context.Context, span trace.Span) {
-				policyContext := policyContext.WithPolicy(policy).WithNamespaceLabels(namespaceLabels)
-				response := v.engine.Validate(ctx, policyContext)
-				responses = append(responses, response)
-			},
-		)
+		responses = append(responses, v.validate(ctx, policyContext, policy))
 	}
 	return responses, nil
 }
 
+// validateWithinBudget evaluates the policies until the deadline of the latency budget of the admission request,
+// the policies that were not evaluated before the deadline are recorded as skipped.
+func (v *validationHandler) validateWithinBudget(ctx context.Context, policyContext *engine.PolicyContext, policies []kyvernov1.PolicyInterface, deadline time.Time) []engineapi.EngineResponse {
+	// external context calls of audit policies don't outlive the latency budget
+	budgetCtx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+	var responses []engineapi.EngineResponse
+	for i, policy := range policies {
+		if budgetCtx.Err() != nil {
+			skipped := policies[i:]
+			v.log.V(2).Info("admission latency budget exceeded, skipping audit policies", "budget", v.auditLatencyBudget.String(), "skipped", len(skipped))
+			for _, policy := range skipped {
+				responses = append(responses, skippedResponse(ctx, v.ownerResolver, v.cfg.GetAutogenControllers(), policyContext.WithPolicy(policy), fmt.Sprintf("rule skipped, admission latency budget of %s exceeded", v.auditLatencyBudget)))
+			}
+			break
+		}
+		responses = append(responses, v.validate(budgetCtx, policyContext, policy))
+	}
+	return responses
+}
+
+func (v *validationHandler) validate(ctx context.Context, policyContext *engine.PolicyContext, policy kyvernov1.PolicyInterface) engineapi.EngineResponse {
+	var response engineapi.EngineResponse
+	tracing.ChildSpan(
+		ctx,
+		"pkg/webhooks/resource/validate",
+		fmt.Sprintf("POLICY %s/%s", policy.GetNamespace(), policy.GetName()),
+		func(ctx context.Context, span trace.Span) {
+			response = v.engine.Validate(ctx, policyContext.WithPolicy(policy))
+		},
+	)
+	return response
+}
+
 func (v *validationHandler) handleAudit(
 	ctx context.Context,
 	resource unstructured.Unstructured,
 	request handlers.AdmissionRequest,
-	namespaceLabels map[string]string,
+	auditResponses func(context.Context) ([]engineapi.EngineResponse, error),
 	engineResponses ...engineapi.EngineResponse,
 ) {
 	createReport := v.admissionReports
@@ -182,7 +264,7 @@ func (v *validationHandler) handleAudit(
 		"",
 		fmt.Sprintf("AUDIT %s %s", request.Operation, request.Kind),
 		func(ctx context.Context, span trace.Span) {
-			responses, err := v.buildAuditResponses(ctx, resource, request, namespaceLabels)
+			responses, err := auditResponses(ctx)
 			if err != nil {
 				v.log.Error(err, "failed to build audit responses")
 			}
@@ -190,7 +272,13 @@ func (v *validationHandler) handleAudit(
 			v.eventGen.Add(events...)
 			if createReport {
 				responses = append(responses, engineResponses...)
-				report := reportutils.BuildAdmissionReport(resource, request.AdmissionRequest, responses...)
+				report := reportutils.BuildAdmissionReport(resource, request.AdmissionRequest, v.cfg, responses...)
+				tracing.SetTraceParent(ctx, report)
+				if toggle.FromContext(ctx).ReportUpdateDiff() {
+					if err := reportutils.SetUpdateDiff(report, request.AdmissionRequest); err != nil {
+						v.log.Error(err, "failed to compute update diff")
+					}
+				}
 				if len(report.GetResults()) > 0 {
 					_, err = reportutils.CreateReport(ctx, report, v.kyvernoClient)
 					if err != nil {
@@ -202,3 +290,37 @@ func (v *validationHandler) handleAudit(
 		trace.WithLinks(trace.LinkFromContext(ctx)),
 	)
 }
+
+// skippedResponse records the validate rules of a policy that was not evaluated, only the rules
+// matching the resource are recorded
+func skippedResponse(ctx context.Context, ownerResolver matchutils.OwnerResolver, autogenControllers []config.AutogenController, policyContext *engine.PolicyContext, message string) engineapi.EngineResponse {
+	policy := policyContext.Policy()
+	resource := policyContext.NewResource()
+	if resource.Object == nil {
+		resource = policyContext.OldResource()
+	}
+	gvk, subresource := policyContext.ResourceKind()
+	var rules []engineapi.RuleResponse
+	for _, rule := range autogen.ComputeRules(policy, autogenControllers) {
+		if !rule.HasValidate() {
+			continue
+		}
+		err := engineutils.MatchesResourceDescription(
+			ctx,
+			ownerResolver,
+			resource,
+			rule,
+			policyContext.AdmissionInfo(),
+			policyContext.NamespaceLabels(),
+			policy.GetNamespace(),
+			gvk,
+			subresource,
+			policyContext.Operation(),
+		)
+		if err == nil {
+			rules = append(rules, *engineapi.RuleSkip(rule.Name, engineapi.Validation, message))
+		}
+	}
+	return engineapi.NewEngineResponse(policyContext.NewResource(), engineapi.NewKyvernoPolicy(policy), policyContext.NamespaceLabels()).
+		WithPolicyResponse(engineapi.PolicyResponse{Rules: rules})
+}
@@ -0,0 +1,192 @@
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/policycache"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// slowEngine takes the given time to validate a resource, ignoring the cancellation of the context
+type slowEngine struct {
+	engineapi.Engine
+	delay time.Duration
+}
+
+func (e slowEngine) Validate(_ context.Context, policyContext engineapi.PolicyContext) engineapi.EngineResponse {
+	time.Sleep(e.delay)
+	return engineapi.NewEngineResponse(policyContext.NewResource(), engineapi.NewKyvernoPolicy(policyContext.Policy()), nil)
+}
+
+// failingEngine fails a validate rule of every policy
+type failingEngine struct {
+	engineapi.Engine
+}
+
+func (failingEngine) Validate(_ context.Context, policyContext engineapi.PolicyContext) engineapi.EngineResponse {
+	return engineapi.NewEngineResponse(policyContext.NewResource(), engineapi.NewKyvernoPolicy(policyContext.Policy()), nil).
+		WithPolicyResponse(engineapi.PolicyResponse{Rules: []engineapi.RuleResponse{*engineapi.RuleFail("check-label", engineapi.Validation, "label is required")}})
+}
+
+func newPolicy(t *testing.T, raw string) *kyvernov1.ClusterPolicy {
+	var policy kyvernov1.ClusterPolicy
+	assert.NilError(t, json.Unmarshal([]byte(raw), &policy))
+	return &policy
+}
+
+func newPodRequest() handlers.AdmissionRequest {
+	return handlers.AdmissionRequest{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			UID:       "test",
+			Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Pod"},
+			Resource:  metav1.GroupVersionResource{Version: "v1", Resource: "pods"},
+			Namespace: "default",
+			Operation: admissionv1.Create,
+			Object: runtime.RawExtension{
+				Raw: []byte(`{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "test", "namespace": "default"}}`),
+			},
+		},
+		GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+	}
+}
+
+func newPolicyContext(t *testing.T, request handlers.AdmissionRequest) *engine.PolicyContext {
+	configuration := config.NewDefaultConfiguration(false)
+	policyContext, err := webhookutils.NewPolicyContextBuilder(configuration, jmespath.New(configuration)).
+		Build(request.AdmissionRequest, nil, nil, request.GroupVersionKind)
+	assert.NilError(t, err)
+	return policyContext
+}
+
+func Test_HandleValidation_auditLatencyBudget(t *testing.T) {
+	configuration := config.NewDefaultConfiguration(false)
+	cache := policycache.NewCache()
+	for _, name := range []string{"audit-team", "audit-owner"} {
+		policy := newPolicy(t, `{
+			"metadata": {"name": "`+name+`"},
+			"spec": {
+				"validationFailureAction": "Audit",
+				"rules": [{
+					"name": "check-label",
+					"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+					"validate": {"message": "label is required", "pattern": {"metadata": {"labels": {"team": "?*"}}}}
+				}]
+			}
+		}`)
+		assert.NilError(t, cache.Set(name, policy, policycache.TestResourceFinder{}, nil))
+	}
+	budget := 100 * time.Millisecond
+	handler := &validationHandler{
+		log:                logr.Discard(),
+		engine:             slowEngine{delay: 2 * budget},
+		pCache:             cache,
+		pcBuilder:          webhookutils.NewPolicyContextBuilder(configuration, jmespath.New(configuration)),
+		eventGen:           event.NewFake(),
+		auditLatencyBudget: budget,
+		cfg:                configuration,
+	}
+	request := newPodRequest()
+	start := time.Now()
+	ok, _, _ := handler.HandleValidation(context.TODO(), request, nil, newPolicyContext(t, request), start)
+	assert.Assert(t, ok)
+	// audit policies are evaluated after responding
+	assert.Assert(t, time.Since(start) < budget, "response took %s", time.Since(start))
+
+	// the budget is measured from the admission request, the second policy starts after the deadline
+	responses, err := handler.buildAuditResponses(context.TODO(), newPolicyContext(t, request).NewResource(), request, nil, time.Now(), nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(responses), 2)
+	assert.Equal(t, len(responses[0].PolicyResponse.Rules), 0)
+	assert.Equal(t, len(responses[1].PolicyResponse.Rules), 1)
+	assert.Equal(t, responses[1].PolicyResponse.Rules[0].Status(), engineapi.RuleStatusSkip)
+
+	// the budget of an admission request that already took longer is exceeded
+	responses, err = handler.buildAuditResponses(context.TODO(), newPolicyContext(t, request).NewResource(), request, nil, time.Now().Add(-budget), nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(responses), 2)
+	for _, response := range responses {
+		assert.Equal(t, len(response.PolicyResponse.Rules), 1)
+		assert.Equal(t, response.PolicyResponse.Rules[0].Status(), engineapi.RuleStatusSkip)
+	}
+}
+
+func Test_HandleValidation_emitWarning(t *testing.T) {
+	configuration := config.NewDefaultConfiguration(false)
+	cache := policycache.NewCache()
+	for name, emitWarning := range map[string]string{"audit-warn": "true", "audit-silent": "false"} {
+		policy := newPolicy(t, `{
+			"metadata": {"name": "`+name+`"},
+			"spec": {
+				"validationFailureAction": "Audit",
+				"emitWarning": `+emitWarning+`,
+				"rules": [{
+					"name": "check-label",
+					"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+					"validate": {"message": "label is required", "pattern": {"metadata": {"labels": {"team": "?*"}}}}
+				}]
+			}
+		}`)
+		assert.NilError(t, cache.Set(name, policy, policycache.TestResourceFinder{}, nil))
+	}
+	handler := &validationHandler{
+		log:       logr.Discard(),
+		engine:    failingEngine{},
+		pCache:    cache,
+		pcBuilder: webhookutils.NewPolicyContextBuilder(configuration, jmespath.New(configuration)),
+		eventGen:  event.NewFake(),
+		cfg:       configuration,
+	}
+	request := newPodRequest()
+	ok, _, warnings := handler.HandleValidation(context.TODO(), request, nil, newPolicyContext(t, request), time.Now())
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, warnings, []string{"policy audit-warn.check-label: label is required"})
+}
+
+func Test_skippedResponse(t *testing.T) {
+	policy := newPolicy(t, `{
+		"metadata": {"name": "audit-labels"},
+		"spec": {
+			"validationFailureAction": "Audit",
+			"rules": [{
+				"name": "check-team",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"validate": {"message": "team label is required", "pattern": {"metadata": {"labels": {"team": "?*"}}}}
+			}, {
+				"name": "check-owner",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"exclude": {"any": [{"resources": {"namespaces": ["default"]}}]},
+				"validate": {"message": "owner label is required", "pattern": {"metadata": {"labels": {"owner": "?*"}}}}
+			}, {
+				"name": "check-config",
+				"match": {"any": [{"resources": {"kinds": ["ConfigMap"]}}]},
+				"validate": {"message": "team label is required", "pattern": {"metadata": {"labels": {"team": "?*"}}}}
+			}, {
+				"name": "add-owner",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"mutate": {"patchStrategicMerge": {"metadata": {"labels": {"owner": "kyverno"}}}}
+			}]
+		}
+	}`)
+	response := skippedResponse(context.TODO(), nil, nil, newPolicyContext(t, newPodRequest()).WithPolicy(policy), "budget exceeded")
+	rules := response.PolicyResponse.Rules
+	assert.Equal(t, len(rules), 1)
+	assert.Equal(t, rules[0].Name(), "check-team")
+	assert.Equal(t, rules[0].Status(), engineapi.RuleStatusSkip)
+	assert.Equal(t, rules[0].Message(), "budget exceeded")
+}
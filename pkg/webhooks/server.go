@@ -12,13 +12,17 @@ import (
 	"github.com/kyverno/kyverno/api/kyverno"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/decisionlog"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
+	"github.com/kyverno/kyverno/pkg/recorder"
 	"github.com/kyverno/kyverno/pkg/toggle"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	runtimeutils "github.com/kyverno/kyverno/pkg/utils/runtime"
+	tlsutils "github.com/kyverno/kyverno/pkg/utils/tls"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
 	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	rbacv1listers "k8s.io/client-go/listers/rbac/v1"
@@ -56,6 +60,27 @@ type ResourceHandlers interface {
 	Validate(context.Context, logr.Logger, handlers.AdmissionRequest, string, time.Time) admissionv1.AdmissionResponse
 }
 
+type EffectivePolicyHandlers interface {
+	// Authorize checks the bearer token allows getting the policies in effect in a namespace
+	Authorize(context.Context, logr.Logger, string, string) error
+	// Namespace returns the policies and rules in effect in a namespace
+	Namespace(context.Context, logr.Logger, string) (interface{}, error)
+}
+
+type PolicyEvaluationHandlers interface {
+	// Authenticate returns the user authenticated by the bearer token
+	Authenticate(context.Context, logr.Logger, string) (authenticationv1.UserInfo, error)
+	// Evaluate checks the user is allowed to evaluate the resource of the request body and returns the engine responses
+	Evaluate(context.Context, logr.Logger, authenticationv1.UserInfo, []byte) (interface{}, error)
+}
+
+type PayloadScanHandlers interface {
+	// Authorize checks the bearer token allows scanning payloads
+	Authorize(context.Context, logr.Logger, string) error
+	// Scan validates a payload against the named ValidatingPolicies, or all of them when no name is given
+	Scan(context.Context, logr.Logger, interface{}, []string) (interface{}, error)
+}
+
 type server struct {
 	server      *http.Server
 	runtime     runtimeutils.Runtime
@@ -72,9 +97,14 @@ func NewServer(
 	policyHandlers PolicyHandlers,
 	resourceHandlers ResourceHandlers,
 	exceptionHandlers ExceptionHandlers,
+	effectivePolicyHandlers EffectivePolicyHandlers,
+	policyEvaluationHandlers PolicyEvaluationHandlers,
+	payloadScanHandlers PayloadScanHandlers,
 	configuration config.Configuration,
 	metricsConfig metrics.MetricsConfigManager,
 	debugModeOpts DebugModeOptions,
+	decisionLogger *decisionlog.Logger,
+	admissionRecorder *recorder.Recorder,
 	tlsProvider TlsProvider,
 	mwcClient controllerutils.DeleteCollectionClient,
 	vwcClient controllerutils.DeleteCollectionClient,
@@ -90,6 +120,9 @@ func NewServer(
 	policyLogger := logger.WithName("policy")
 	exceptionLogger := logger.WithName("exception")
 	verifyLogger := logger.WithName("verify")
+	effectiveLogger := logger.WithName("effective")
+	evaluateLogger := logger.WithName("evaluate")
+	scanLogger := logger.WithName("scan")
 	registerWebhookHandlers(
 		mux,
 		"MUTATE",
@@ -97,6 +130,8 @@ func NewServer(
 		resourceHandlers.Mutate,
 		func(handler handlers.AdmissionHandler) handlers.HttpHandler {
 			return handler.
+				WithDecisionLog(decisionLogger, "mutate").
+				WithRecording(admissionRecorder, "mutate").
 				WithFilter(configuration).
 				WithProtection(toggle.FromContext(ctx).ProtectManagedResources()).
 				WithDump(debugModeOpts.DumpPayload).
@@ -114,6 +149,8 @@ func NewServer(
 		resourceHandlers.Validate,
 		func(handler handlers.AdmissionHandler) handlers.HttpHandler {
 			return handler.
+				WithDecisionLog(decisionLogger, "validate").
+				WithRecording(admissionRecorder, "validate").
 				WithFilter(configuration).
 				WithProtection(toggle.FromContext(ctx).ProtectManagedResources()).
 				WithDump(debugModeOpts.DumpPayload).
@@ -159,34 +196,34 @@ func NewServer(
 			WithAdmission(verifyLogger.WithName("mutate")).
 			ToHandlerFunc("VERIFY"),
 	)
+	if effectivePolicyHandlers != nil {
+		mux.HandlerFunc("GET", config.EffectivePoliciesServicePath+"/:namespace", handlers.EffectivePolicies(effectiveLogger, effectivePolicyHandlers.Authorize, effectivePolicyHandlers.Namespace))
+	}
+	if policyEvaluationHandlers != nil {
+		mux.HandlerFunc("POST", config.PolicyEvaluationServicePath, handlers.EvaluatePolicies(evaluateLogger, policyEvaluationHandlers.Authenticate, policyEvaluationHandlers.Evaluate))
+	}
+	if payloadScanHandlers != nil {
+		mux.HandlerFunc("POST", config.PayloadScanServicePath, handlers.ScanPayload(scanLogger, payloadScanHandlers.Authorize, payloadScanHandlers.Scan))
+	}
 	mux.HandlerFunc("GET", config.LivenessServicePath, handlers.Probe(runtime.IsLive))
 	mux.HandlerFunc("GET", config.ReadinessServicePath, handlers.Probe(runtime.IsReady))
+	mux.HandlerFunc("GET", config.StatusServicePath, handlers.Status())
+	tlsConfig := tlsutils.ServerConfig()
+	tlsConfig.GetCertificate = func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+		certPem, keyPem, err := tlsProvider()
+		if err != nil {
+			return nil, err
+		}
+		pair, err := tls.X509KeyPair(certPem, keyPem)
+		if err != nil {
+			return nil, err
+		}
+		return &pair, nil
+	}
 	return &server{
 		server: &http.Server{
-			Addr: fmt.Sprintf(":%d", webhookServerPort),
-			TLSConfig: &tls.Config{
-				GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
-					certPem, keyPem, err := tlsProvider()
-					if err != nil {
-						return nil, err
-					}
-					pair, err := tls.X509KeyPair(certPem, keyPem)
-					if err != nil {
-						return nil, err
-					}
-					return &pair, nil
-				},
-				MinVersion: tls.VersionTLS12,
-				CipherSuites: []uint16{
-					// AEADs w/ ECDHE
-					tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
-					tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
-					tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
-					tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
-					tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
-					tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
-				},
-			},
+			Addr:              fmt.Sprintf(":%d", webhookServerPort),
+			TLSConfig:         tlsConfig,
 			Handler:           mux,
 			ReadTimeout:       30 * time.Second,
 			WriteTimeout:      30 * time.Second,
@@ -282,7 +319,39 @@ func registerWebhookHandlers(
 			return handlerFunc(ctx, logger, request, "fail", startTime)
 		},
 	)
+	// webhooks grouped by timeout only receive the policies declaring the same timeout
+	ignoreTimeout := handlers.FromAdmissionFunc(
+		name,
+		func(ctx context.Context, logger logr.Logger, request handlers.AdmissionRequest, startTime time.Time) admissionv1.AdmissionResponse {
+			return handlerFunc(ctx, logger, request, "ignore/"+httprouter.ParamsFromContext(ctx).ByName("timeout"), startTime)
+		},
+	)
+	failTimeout := handlers.FromAdmissionFunc(
+		name,
+		func(ctx context.Context, logger logr.Logger, request handlers.AdmissionRequest, startTime time.Time) admissionv1.AdmissionResponse {
+			return handlerFunc(ctx, logger, request, "fail/"+httprouter.ParamsFromContext(ctx).ByName("timeout"), startTime)
+		},
+	)
+	// mutating webhooks are also grouped by reinvocation policy
+	ignoreTimeoutReinvocation := handlers.FromAdmissionFunc(
+		name,
+		func(ctx context.Context, logger logr.Logger, request handlers.AdmissionRequest, startTime time.Time) admissionv1.AdmissionResponse {
+			params := httprouter.ParamsFromContext(ctx)
+			return handlerFunc(ctx, logger, request, "ignore/"+params.ByName("timeout")+"/"+params.ByName("reinvocation"), startTime)
+		},
+	)
+	failTimeoutReinvocation := handlers.FromAdmissionFunc(
+		name,
+		func(ctx context.Context, logger logr.Logger, request handlers.AdmissionRequest, startTime time.Time) admissionv1.AdmissionResponse {
+			params := httprouter.ParamsFromContext(ctx)
+			return handlerFunc(ctx, logger, request, "fail/"+params.ByName("timeout")+"/"+params.ByName("reinvocation"), startTime)
+		},
+	)
 	mux.HandlerFunc("POST", basePath, builder(all).ToHandlerFunc(name))
 	mux.HandlerFunc("POST", basePath+"/ignore", builder(ignore).ToHandlerFunc(name))
 	mux.HandlerFunc("POST", basePath+"/fail", builder(fail).ToHandlerFunc(name))
+	mux.HandlerFunc("POST", basePath+"/ignore/:timeout", builder(ignoreTimeout).ToHandlerFunc(name))
+	mux.HandlerFunc("POST", basePath+"/fail/:timeout", builder(failTimeout).ToHandlerFunc(name))
+	mux.HandlerFunc("POST", basePath+"/ignore/:timeout/:reinvocation", builder(ignoreTimeoutReinvocation).ToHandlerFunc(name))
+	mux.HandlerFunc("POST", basePath+"/fail/:timeout/:reinvocation", builder(failTimeoutReinvocation).ToHandlerFunc(name))
 }
@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 )
 
@@ -18,3 +19,14 @@ func GetWarningMessages(engineResponses []engineapi.EngineResponse) []string {
 	}
 	return warnings
 }
+
+// GetAuditWarningMessages returns the warnings of the audit policies configured to emit warnings.
+func GetAuditWarningMessages(engineResponses []engineapi.EngineResponse) []string {
+	var responses []engineapi.EngineResponse
+	for _, er := range engineResponses {
+		if policy, ok := er.Policy().GetPolicy().(kyvernov1.PolicyInterface); ok && policy.GetSpec().EmitWarningEnabled() {
+			responses = append(responses, er)
+		}
+	}
+	return GetWarningMessages(responses)
+}
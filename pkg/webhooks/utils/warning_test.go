@@ -75,3 +75,21 @@ func TestGetWarningMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAuditWarningMessages(t *testing.T) {
+	emitWarning := true
+	response := func(name string, emitWarning *bool) engineapi.EngineResponse {
+		return engineapi.EngineResponse{
+			PolicyResponse: engineapi.PolicyResponse{
+				Rules: []engineapi.RuleResponse{
+					*engineapi.RuleFail("rule", engineapi.Validation, "message fail"),
+				},
+			},
+		}.WithPolicy(engineapi.NewKyvernoPolicy(&v1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1.Spec{EmitWarning: emitWarning},
+		}))
+	}
+	got := GetAuditWarningMessages([]engineapi.EngineResponse{response("silent", nil), response("warn", &emitWarning)})
+	assert.Equal(t, []string{"policy warn.rule: message fail"}, got)
+}